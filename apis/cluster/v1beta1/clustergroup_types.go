@@ -0,0 +1,133 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterGroupLabelPrefix is the prefix of the group labels that the hub agent adds to the member
+	// clusters in a cluster group; the full label key is the prefix followed by the name of the group,
+	// and the label value is always "true".
+	//
+	// Placement policies can select the member clusters in a cluster group with the group label, e.g.,
+	// `group.kubernetes-fleet.io/prod: "true"`.
+	ClusterGroupLabelPrefix = "group.kubernetes-fleet.io/"
+
+	// ClusterGroupLabelValue is the value of the group labels.
+	ClusterGroupLabelValue = "true"
+
+	// ClusterGroupCleanupFinalizer is the finalizer added to a ClusterGroup object, which makes sure
+	// that the group labels are removed from the member clusters before the group is deleted.
+	ClusterGroupCleanupFinalizer = "kubernetes-fleet.io/cluster-group-cleanup"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-cluster},shortName=cg
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.status.memberClusterCount`,name="Member-Clusters",type=integer
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Synced")].status`,name="Synced",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// ClusterGroup is a named group of member clusters in a fleet. The hub agent adds a group label
+// (see ClusterGroupLabelPrefix) to every member cluster in the group and keeps the labels in sync
+// as member clusters join, leave, or change their labels, so that placement policies can target
+// the group by its name.
+// +kubebuilder:validation:XValidation:rule="size(self.metadata.name) < 64",message="metadata.name max length is 63"
+type ClusterGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The desired state of ClusterGroup.
+	// +required
+	Spec ClusterGroupSpec `json:"spec"`
+
+	// The observed status of ClusterGroup.
+	// +optional
+	Status ClusterGroupStatus `json:"status,omitempty"`
+}
+
+// ClusterGroupSpec defines the desired state of ClusterGroup.
+//
+// A member cluster belongs to the group if it matches the cluster selector, or if it is in the
+// explicit list of cluster names.
+// +kubebuilder:validation:XValidation:rule="has(self.clusterSelector) || (has(self.clusterNames) && size(self.clusterNames) > 0)",message="at least one of clusterSelector and clusterNames must be specified"
+type ClusterGroupSpec struct {
+	// ClusterSelector selects the member clusters in the group by their labels.
+	//
+	// Note that group labels (labels with the ClusterGroupLabelPrefix prefix) are not considered
+	// when matching member clusters against the selector.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// ClusterNames is an explicit list of the names of the member clusters in the group.
+	// +kubebuilder:validation:MaxItems=1000
+	// +optional
+	ClusterNames []string `json:"clusterNames,omitempty"`
+}
+
+// ClusterGroupStatus defines the observed state of ClusterGroup.
+type ClusterGroupStatus struct {
+	// MemberClusters is the sorted list of the names of the member clusters in the group.
+	// +optional
+	MemberClusters []string `json:"memberClusters,omitempty"`
+
+	// MemberClusterCount is the number of member clusters in the group.
+	// +optional
+	MemberClusterCount int `json:"memberClusterCount,omitempty"`
+
+	// Conditions is an array of current observed conditions for ClusterGroup.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ClusterGroupConditionType defines a specific condition of a ClusterGroup.
+// +enum
+type ClusterGroupConditionType string
+
+const (
+	// ClusterGroupConditionTypeSynced indicates whether the group labels on the member clusters
+	// are in sync with the spec of the ClusterGroup.
+	// Its condition status can be one of the following:
+	// - "True" means all the member clusters in the group (and only them) have the group label.
+	// - "False" means the hub agent has failed to add or remove the group label on some member clusters.
+	ClusterGroupConditionTypeSynced ClusterGroupConditionType = "Synced"
+)
+
+//+kubebuilder:object:root=true
+
+// ClusterGroupList contains a list of ClusterGroup.
+type ClusterGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterGroup `json:"items"`
+}
+
+// SetConditions sets the conditions of the ClusterGroup.
+func (g *ClusterGroup) SetConditions(conditions ...metav1.Condition) {
+	for _, c := range conditions {
+		meta.SetStatusCondition(&g.Status.Conditions, c)
+	}
+}
+
+// GetCondition returns the condition of the ClusterGroup of the given type.
+func (g *ClusterGroup) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(g.Status.Conditions, conditionType)
+}
+
+// GroupLabelKey returns the key of the group label of the ClusterGroup.
+func (g *ClusterGroup) GroupLabelKey() string {
+	return ClusterGroupLabelPrefix + g.Name
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterGroup{}, &ClusterGroupList{})
+}
@@ -83,6 +83,10 @@ type AgentStatus struct {
 	// Last time we received a heartbeat from the member agent.
 	// +optional
 	LastReceivedHeartbeat metav1.Time `json:"lastReceivedHeartbeat,omitempty"`
+
+	// Version is the version of the member agent binary, e.g., v0.11.0.
+	// +optional
+	Version string `json:"version,omitempty"`
 }
 
 // AgentConditionType identifies a specific condition on the Agent.
@@ -107,6 +111,8 @@ const (
 	MemberClusterKind                = "MemberCluster"
 	MemberClusterResource            = "memberclusters"
 	InternalMemberClusterKind        = "InternalMemberCluster"
+	ClusterGroupKind                 = "ClusterGroup"
+	MemberAgentUpgradeKind           = "MemberAgentUpgrade"
 	ClusterResourcePlacementResource = "clusterresourceplacements"
 )
 
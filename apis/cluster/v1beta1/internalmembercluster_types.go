@@ -64,6 +64,11 @@ type InternalMemberClusterStatus struct {
 	// +optional
 	ResourceUsage ResourceUsage `json:"resourceUsage,omitempty"`
 
+	// APIGroupVersions is the list of the API group versions served by the member cluster, e.g., "v1",
+	// "apps/v1", and the group versions of the installed CRDs, sorted. It is populated by the member agent.
+	// +optional
+	APIGroupVersions []string `json:"apiGroupVersions,omitempty"`
+
 	// AgentStatus is an array of current observed status, each corresponding to one member agent running in the member cluster.
 	// +optional
 	AgentStatus []AgentStatus `json:"agentStatus,omitempty"`
@@ -0,0 +1,216 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MemberAgentTargetVersionAnnotation is the annotation that the hub agent adds to an InternalMemberCluster
+	// object to declare the version the member agent of the member cluster should run.
+	MemberAgentTargetVersionAnnotation = "kubernetes-fleet.io/member-agent-target-version"
+
+	// MemberAgentTargetImageAnnotation is the annotation that the hub agent adds to an InternalMemberCluster
+	// object to declare the image the member agent of the member cluster should run; member agents with
+	// self-upgrade enabled update their own deployment with the image.
+	MemberAgentTargetImageAnnotation = "kubernetes-fleet.io/member-agent-target-image"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-cluster},shortName=mau
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.spec.version`,name="Version",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.currentStage`,name="Current-Stage",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="Succeeded")].status`,name="Succeeded",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="VersionSkewWithinPolicy")].status`,name="Skew-OK",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// MemberAgentUpgrade rolls out a version of the member agent across the member clusters in a fleet,
+// stage by stage.
+//
+// The hub agent declares the target version (and image) of the member agent on the InternalMemberCluster
+// objects of the member clusters in the current stage, and moves on to the next stage once all the member
+// agents in the stage report the target version. Member agents with self-upgrade enabled update their own
+// deployment; other member agents are expected to be upgraded out of band.
+//
+// Only the most recently created MemberAgentUpgrade object is in effect; older objects are superseded.
+type MemberAgentUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The desired state of MemberAgentUpgrade.
+	// +required
+	Spec MemberAgentUpgradeSpec `json:"spec"`
+
+	// The observed status of MemberAgentUpgrade.
+	// +optional
+	Status MemberAgentUpgradeStatus `json:"status,omitempty"`
+}
+
+// MemberAgentUpgradeSpec defines the desired state of MemberAgentUpgrade.
+type MemberAgentUpgradeSpec struct {
+	// Version is the target version of the member agents, e.g., v0.11.0.
+	//
+	// A member cluster is upgraded once its member agent reports the target version in the agent status.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Version string `json:"version"`
+
+	// Image is the member agent image of the target version, e.g., ghcr.io/azure/fleet/member-agent:v0.11.0.
+	//
+	// Member agents with self-upgrade enabled update their own deployment with the image; if not specified,
+	// the member agents must be upgraded out of band.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Stages is the ordered list of stages in which the member clusters are upgraded. A member cluster
+	// belongs to the first stage whose cluster selector it matches; member clusters that match none of the
+	// stages are not upgraded.
+	//
+	// If not specified, all the member clusters are upgraded in a single stage.
+	// +kubebuilder:validation:MaxItems=31
+	// +optional
+	Stages []MemberAgentUpgradeStage `json:"stages,omitempty"`
+
+	// SkewPolicy is the version skew policy between the hub agent and the member agents.
+	// +optional
+	SkewPolicy VersionSkewPolicy `json:"skewPolicy,omitempty"`
+}
+
+// MemberAgentUpgradeStage defines a stage of a MemberAgentUpgrade.
+type MemberAgentUpgradeStage struct {
+	// Name is the name of the stage; it must be unique within the MemberAgentUpgrade.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +required
+	Name string `json:"name"`
+
+	// ClusterSelector selects the member clusters in the stage by their labels. If not specified,
+	// the stage includes all the member clusters not in any of the earlier stages.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+}
+
+// VersionSkewPolicy defines the supported version skew between the hub agent and the member agents.
+type VersionSkewPolicy struct {
+	// MaxMinorVersionSkew is the max number of minor versions by which the version of a member agent
+	// may differ from the version of the hub agent. Defaults to 1.
+	//
+	// The hub agent refuses to roll out a target version beyond the supported skew, and warns about
+	// member agents whose versions are beyond the supported skew.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxMinorVersionSkew *int32 `json:"maxMinorVersionSkew,omitempty"`
+}
+
+// MemberAgentUpgradeStatus defines the observed state of MemberAgentUpgrade.
+type MemberAgentUpgradeStatus struct {
+	// CurrentStage is the name of the stage being rolled out; it is empty if the rollout has not
+	// started or has completed.
+	// +optional
+	CurrentStage string `json:"currentStage,omitempty"`
+
+	// Stages is the status of the stages, in the order of the stages in the spec.
+	// +optional
+	Stages []MemberAgentUpgradeStageStatus `json:"stages,omitempty"`
+
+	// SkewedClusters is the sorted list of the names of the member clusters whose member agent versions
+	// are beyond the supported skew.
+	// +optional
+	SkewedClusters []string `json:"skewedClusters,omitempty"`
+
+	// Conditions is an array of current observed conditions for MemberAgentUpgrade.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// MemberAgentUpgradeStageStatus defines the observed state of a stage of a MemberAgentUpgrade.
+type MemberAgentUpgradeStageStatus struct {
+	// Name is the name of the stage.
+	// +required
+	Name string `json:"name"`
+
+	// Clusters is the status of the member clusters in the stage, sorted by their names.
+	// +optional
+	Clusters []MemberAgentUpgradeClusterStatus `json:"clusters,omitempty"`
+
+	// Completed is true if the member agents of all the member clusters in the stage report the target version.
+	// +optional
+	Completed bool `json:"completed,omitempty"`
+}
+
+// MemberAgentUpgradeClusterStatus defines the observed upgrade state of a member cluster.
+type MemberAgentUpgradeClusterStatus struct {
+	// ClusterName is the name of the member cluster.
+	// +required
+	ClusterName string `json:"clusterName"`
+
+	// Version is the version the member agent of the member cluster reports.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Upgraded is true if the member agent reports the target version.
+	// +optional
+	Upgraded bool `json:"upgraded,omitempty"`
+}
+
+// MemberAgentUpgradeConditionType defines a specific condition of a MemberAgentUpgrade.
+// +enum
+type MemberAgentUpgradeConditionType string
+
+const (
+	// MemberAgentUpgradeConditionTypeProgressing indicates whether the rollout is in progress.
+	// Its condition status can be one of the following:
+	// - "True" means the hub agent is rolling out the target version.
+	// - "False" means the rollout has completed, or cannot proceed, e.g., the object is superseded or
+	// the target version is beyond the supported skew; see the reason for details.
+	MemberAgentUpgradeConditionTypeProgressing MemberAgentUpgradeConditionType = "Progressing"
+
+	// MemberAgentUpgradeConditionTypeSucceeded indicates whether the rollout has completed.
+	// Its condition status can be one of the following:
+	// - "True" means the member agents in all the stages report the target version.
+	// - "False" means the rollout has not completed yet.
+	MemberAgentUpgradeConditionTypeSucceeded MemberAgentUpgradeConditionType = "Succeeded"
+
+	// MemberAgentUpgradeConditionTypeVersionSkewWithinPolicy indicates whether the versions of the member
+	// agents are within the supported skew of the hub agent version.
+	// Its condition status can be one of the following:
+	// - "True" means all the member agents are within the supported skew.
+	// - "False" means some member agents are beyond the supported skew; see status.skewedClusters.
+	// - "Unknown" means the version of the hub agent is not a valid version, e.g., a development build.
+	MemberAgentUpgradeConditionTypeVersionSkewWithinPolicy MemberAgentUpgradeConditionType = "VersionSkewWithinPolicy"
+)
+
+//+kubebuilder:object:root=true
+
+// MemberAgentUpgradeList contains a list of MemberAgentUpgrade.
+type MemberAgentUpgradeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MemberAgentUpgrade `json:"items"`
+}
+
+// SetConditions sets the conditions of the MemberAgentUpgrade.
+func (u *MemberAgentUpgrade) SetConditions(conditions ...metav1.Condition) {
+	for _, c := range conditions {
+		meta.SetStatusCondition(&u.Status.Conditions, c)
+	}
+}
+
+// GetCondition returns the condition of the MemberAgentUpgrade of the given type.
+func (u *MemberAgentUpgrade) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(u.Status.Conditions, conditionType)
+}
+
+func init() {
+	SchemeBuilder.Register(&MemberAgentUpgrade{}, &MemberAgentUpgradeList{})
+}
@@ -12,6 +12,16 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// MaxPlacementsLabel is the label that a fleet admin can add to a MemberCluster object to cap the
+	// number of placements that can be scheduled or bound to the member cluster, e.g., to limit the
+	// blast radius of the cluster or for licensing reasons. Its value must be a non-negative integer.
+	//
+	// The cap is enforced at scheduling time only; placements already on the cluster are not removed
+	// if the cap is lowered.
+	MaxPlacementsLabel = "kubernetes-fleet.io/max-placements"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-cluster},shortName=cluster
 // +kubebuilder:subresource:status
@@ -63,6 +73,33 @@ type MemberClusterSpec struct {
 	// +kubebuilder:validation:MaxItems=100
 	// +optional
 	Taints []Taint `json:"taints,omitempty"`
+
+	// If specified, the MemberCluster is cordoned, i.e., the scheduler no longer picks the cluster
+	// for any ClusterResourcePlacement; the resources already placed on the cluster stay, unless the
+	// cluster is also drained.
+	//
+	// Remove the field to uncordon the cluster, e.g., after the maintenance is done.
+	// +optional
+	Cordon *Cordon `json:"cordon,omitempty"`
+}
+
+// Cordon describes how a MemberCluster is cordoned, e.g., for maintenance.
+type Cordon struct {
+	// Reason is a human-readable explanation of why the cluster is cordoned.
+	// +kubebuilder:validation:MaxLength=256
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Drain, if set to true, has Fleet move the placements off the cluster: the bindings of the
+	// ClusterResourcePlacements on the cluster are unscheduled, so that the resources are placed
+	// on other clusters (if any) and then removed from this cluster.
+	//
+	// The bindings are unscheduled one ClusterResourcePlacement at a time, per the
+	// ClusterResourcePlacementDisruptionBudget (if any) of the ClusterResourcePlacement; the
+	// bindings of the ClusterResourcePlacements of the PickFixed placement type are never
+	// unscheduled, as their clusters are named explicitly.
+	// +optional
+	Drain bool `json:"drain,omitempty"`
 }
 
 // PropertyName is the name of a cluster property; it should be a Kubernetes label name.
@@ -106,6 +143,11 @@ type MemberClusterStatus struct {
 	// +optional
 	ResourceUsage ResourceUsage `json:"resourceUsage,omitempty"`
 
+	// APIGroupVersions is the list of the API group versions served by the member cluster, e.g., "v1",
+	// "apps/v1", and the group versions of the installed CRDs, sorted. It is copied from the corresponding InternalMemberCluster object.
+	// +optional
+	APIGroupVersions []string `json:"apiGroupVersions,omitempty"`
+
 	// AgentStatus is an array of current observed status, each corresponding to one member agent running in the member cluster.
 	// +optional
 	AgentStatus []AgentStatus `json:"agentStatus,omitempty"`
@@ -171,6 +213,14 @@ const (
 	// - "False" means the cluster property collection has failed.
 	// - "Unknown" means it is unknown whether the cluster property collection has succeeded or not.
 	ConditionTypeClusterPropertyCollectionSucceeded MemberClusterConditionType = "ClusterPropertyCollectionSucceeded"
+
+	// ConditionTypeMemberClusterCleanedUp indicates the progress of cleaning up the artifacts of a deleting
+	// member cluster on the hub cluster.
+	// Its condition status can be one of the following:
+	// - "True" means all the artifacts of the member cluster have been cleaned up.
+	// - "False" means the artifacts of the member cluster are being cleaned up; the reason of the
+	//   condition tells the current stage of the cleanup.
+	ConditionTypeMemberClusterCleanedUp MemberClusterConditionType = "CleanedUp"
 )
 
 //+kubebuilder:object:root=true
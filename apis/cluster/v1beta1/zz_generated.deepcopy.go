@@ -38,6 +38,132 @@ func (in *AgentStatus) DeepCopy() *AgentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroup) DeepCopyInto(out *ClusterGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroup.
+func (in *ClusterGroup) DeepCopy() *ClusterGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupList) DeepCopyInto(out *ClusterGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupList.
+func (in *ClusterGroupList) DeepCopy() *ClusterGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupSpec) DeepCopyInto(out *ClusterGroupSpec) {
+	*out = *in
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterNames != nil {
+		in, out := &in.ClusterNames, &out.ClusterNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupSpec.
+func (in *ClusterGroupSpec) DeepCopy() *ClusterGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupStatus) DeepCopyInto(out *ClusterGroupStatus) {
+	*out = *in
+	if in.MemberClusters != nil {
+		in, out := &in.MemberClusters, &out.MemberClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupStatus.
+func (in *ClusterGroupStatus) DeepCopy() *ClusterGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cordon) DeepCopyInto(out *Cordon) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cordon.
+func (in *Cordon) DeepCopy() *Cordon {
+	if in == nil {
+		return nil
+	}
+	out := new(Cordon)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InternalMemberCluster) DeepCopyInto(out *InternalMemberCluster) {
 	*out = *in
@@ -130,6 +256,11 @@ func (in *InternalMemberClusterStatus) DeepCopyInto(out *InternalMemberClusterSt
 		}
 	}
 	in.ResourceUsage.DeepCopyInto(&out.ResourceUsage)
+	if in.APIGroupVersions != nil {
+		in, out := &in.APIGroupVersions, &out.APIGroupVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.AgentStatus != nil {
 		in, out := &in.AgentStatus, &out.AgentStatus
 		*out = make([]AgentStatus, len(*in))
@@ -149,6 +280,177 @@ func (in *InternalMemberClusterStatus) DeepCopy() *InternalMemberClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberAgentUpgrade) DeepCopyInto(out *MemberAgentUpgrade) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberAgentUpgrade.
+func (in *MemberAgentUpgrade) DeepCopy() *MemberAgentUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberAgentUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MemberAgentUpgrade) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberAgentUpgradeClusterStatus) DeepCopyInto(out *MemberAgentUpgradeClusterStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberAgentUpgradeClusterStatus.
+func (in *MemberAgentUpgradeClusterStatus) DeepCopy() *MemberAgentUpgradeClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberAgentUpgradeClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberAgentUpgradeList) DeepCopyInto(out *MemberAgentUpgradeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MemberAgentUpgrade, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberAgentUpgradeList.
+func (in *MemberAgentUpgradeList) DeepCopy() *MemberAgentUpgradeList {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberAgentUpgradeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MemberAgentUpgradeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberAgentUpgradeSpec) DeepCopyInto(out *MemberAgentUpgradeSpec) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]MemberAgentUpgradeStage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.SkewPolicy.DeepCopyInto(&out.SkewPolicy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberAgentUpgradeSpec.
+func (in *MemberAgentUpgradeSpec) DeepCopy() *MemberAgentUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberAgentUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberAgentUpgradeStage) DeepCopyInto(out *MemberAgentUpgradeStage) {
+	*out = *in
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberAgentUpgradeStage.
+func (in *MemberAgentUpgradeStage) DeepCopy() *MemberAgentUpgradeStage {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberAgentUpgradeStage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberAgentUpgradeStageStatus) DeepCopyInto(out *MemberAgentUpgradeStageStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]MemberAgentUpgradeClusterStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberAgentUpgradeStageStatus.
+func (in *MemberAgentUpgradeStageStatus) DeepCopy() *MemberAgentUpgradeStageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberAgentUpgradeStageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberAgentUpgradeStatus) DeepCopyInto(out *MemberAgentUpgradeStatus) {
+	*out = *in
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]MemberAgentUpgradeStageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SkewedClusters != nil {
+		in, out := &in.SkewedClusters, &out.SkewedClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberAgentUpgradeStatus.
+func (in *MemberAgentUpgradeStatus) DeepCopy() *MemberAgentUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberAgentUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemberCluster) DeepCopyInto(out *MemberCluster) {
 	*out = *in
@@ -217,6 +519,11 @@ func (in *MemberClusterSpec) DeepCopyInto(out *MemberClusterSpec) {
 		*out = make([]Taint, len(*in))
 		copy(*out, *in)
 	}
+	if in.Cordon != nil {
+		in, out := &in.Cordon, &out.Cordon
+		*out = new(Cordon)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberClusterSpec.
@@ -247,6 +554,11 @@ func (in *MemberClusterStatus) DeepCopyInto(out *MemberClusterStatus) {
 		}
 	}
 	in.ResourceUsage.DeepCopyInto(&out.ResourceUsage)
+	if in.APIGroupVersions != nil {
+		in, out := &in.APIGroupVersions, &out.APIGroupVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.AgentStatus != nil {
 		in, out := &in.AgentStatus, &out.AgentStatus
 		*out = make([]AgentStatus, len(*in))
@@ -333,3 +645,23 @@ func (in *Taint) DeepCopy() *Taint {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersionSkewPolicy) DeepCopyInto(out *VersionSkewPolicy) {
+	*out = *in
+	if in.MaxMinorVersionSkew != nil {
+		in, out := &in.MaxMinorVersionSkew, &out.MaxMinorVersionSkew
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionSkewPolicy.
+func (in *VersionSkewPolicy) DeepCopy() *VersionSkewPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VersionSkewPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -24,6 +24,12 @@ const (
 	// ClusterApprovalRequestKind is the kind of the ClusterApprovalRequest.
 	ClusterApprovalRequestKind = "ClusterApprovalRequest"
 
+	// PinnedPlacementKind is the kind of the PinnedPlacement.
+	PinnedPlacementKind = "PinnedPlacement"
+
+	// ClusterSchedulingDecisionKind is the kind of the ClusterSchedulingDecision.
+	ClusterSchedulingDecisionKind = "ClusterSchedulingDecision"
+
 	// ClusterStagedUpdateRunFinalizer is used by the ClusterStagedUpdateRun controller to make sure that the ClusterStagedUpdateRun
 	// object is not deleted until all its dependent resources are deleted.
 	ClusterStagedUpdateRunFinalizer = fleetPrefix + "stagedupdaterun-finalizer"
@@ -45,4 +51,8 @@ const (
 
 	// OverrideClusterNameVariable is the reserved variable in the override value that will be replaced by the actual cluster name.
 	OverrideClusterNameVariable = "${MEMBER-CLUSTER-NAME}"
+
+	// OverrideClusterLabelVariablePrefix is the prefix of the reserved variable in the override value that will be
+	// replaced by the value of a label on the cluster, e.g. `${MEMBER-CLUSTER-LABEL:region}`.
+	OverrideClusterLabelVariablePrefix = "${MEMBER-CLUSTER-LABEL:"
 )
@@ -85,7 +85,8 @@ type OverrideRule struct {
 	// The resources will be overridden before applying to the matching clusters.
 	// An empty clusterSelector selects ALL the member clusters.
 	// A nil clusterSelector selects NO member clusters.
-	// For now, only labelSelector is supported.
+	// Each term may select clusters by labels (labelSelector), by properties (propertySelector), or both;
+	// propertySorter is not supported.
 	// +optional
 	ClusterSelector *placementv1beta1.ClusterSelector `json:"clusterSelector,omitempty"`
 
@@ -182,19 +183,27 @@ type ResourceSelector struct {
 // JSONPatchOverride applies a JSON patch on the selected resources following [RFC 6902](https://datatracker.ietf.org/doc/html/rfc6902).
 type JSONPatchOverride struct {
 	// Operator defines the operation on the target field.
-	// +kubebuilder:validation:Enum=add;remove;replace
+	// +kubebuilder:validation:Enum=add;remove;replace;move;copy;test
 	// +required
 	Operator JSONPatchOverrideOperator `json:"op"`
 	// Path defines the target location.
 	// Note: override will fail if the resource path does not exist.
 	// +required
 	Path string `json:"path"`
+	// From defines the source location of the value to be moved or copied.
+	// From is required when operator is `move` or `copy`, and should be empty otherwise.
+	// +optional
+	From string `json:"from,omitempty"`
 	// Value defines the content to be applied on the target location.
-	// Value should be empty when operator is `remove`.
+	// Value should be empty when operator is `remove`, `move`, or `copy`.
+	// When operator is `test`, Value is the content that the target location must hold for the
+	// JSON patch overrides of the rule to be applied.
 	// We have reserved a few variables in this field that will be replaced by the actual values.
 	// Those variables all start with `$` and are case sensitive.
 	// Here is the list of currently supported variables:
 	// `${MEMBER-CLUSTER-NAME}`:  this will be replaced by the name of the memberCluster CR that represents this cluster.
+	// `${MEMBER-CLUSTER-LABEL:<key>}`:  this will be replaced by the value of the label with the given key on the memberCluster CR
+	// that represents this cluster; the override fails on the clusters which do not have the label.
 	// +optional
 	Value apiextensionsv1.JSON `json:"value,omitempty"`
 }
@@ -256,6 +265,63 @@ const (
 	//     "foo": "bar"
 	//   }
 	JSONPatchOverrideOpReplace JSONPatchOverrideOperator = "replace"
+	// JSONPatchOverrideOpMove removes the value at the from location and adds it to the target location.
+	// An example target JSON document:
+	//
+	//   {
+	//     "baz": "qux",
+	//     "foo": "bar"
+	//   }
+	//
+	//   A JSON Patch override:
+	//
+	//   [
+	//     { "op": "move", "from": "/baz", "path": "/boo" }
+	//   ]
+	//
+	//   The resulting JSON document:
+	//
+	//   {
+	//     "boo": "qux",
+	//     "foo": "bar"
+	//   }
+	JSONPatchOverrideOpMove JSONPatchOverrideOperator = "move"
+	// JSONPatchOverrideOpCopy copies the value at the from location to the target location.
+	// An example target JSON document:
+	//
+	//   { "foo": "bar" }
+	//
+	//   A JSON Patch override:
+	//
+	//   [
+	//     { "op": "copy", "from": "/foo", "path": "/baz" }
+	//   ]
+	//
+	//   The resulting JSON document:
+	//
+	//   {
+	//     "baz": "bar",
+	//     "foo": "bar"
+	//   }
+	JSONPatchOverrideOpCopy JSONPatchOverrideOperator = "copy"
+	// JSONPatchOverrideOpTest tests that the value at the target location is equal to the given value.
+	// If the test fails, none of the JSON patch overrides of the same rule is applied and the resource
+	// is left unchanged by that rule.
+	// An example target JSON document:
+	//
+	//   { "foo": "bar" }
+	//
+	//   A JSON Patch override:
+	//
+	//   [
+	//     { "op": "test", "path": "/foo", "value": "bar" },
+	//     { "op": "replace", "path": "/foo", "value": "baz" }
+	//   ]
+	//
+	//   The resulting JSON document:
+	//
+	//   { "foo": "baz" }
+	JSONPatchOverrideOpTest JSONPatchOverrideOperator = "test"
 )
 
 // ClusterResourceOverrideList contains a list of ClusterResourceOverride.
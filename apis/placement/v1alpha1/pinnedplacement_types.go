@@ -0,0 +1,133 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-placement},shortName=pinned
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.spec.mode`,name="Mode",type=string
+// +kubebuilder:printcolumn:JSONPath=`.status.conditions[?(@.type=="InSync")].status`,name="In-Sync",type=string
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// PinnedPlacement is the scheduling decisions of a ClusterResourcePlacement in a declarative form,
+// i.e., the list of clusters that the placement is placed on, which can be committed to and reviewed
+// in a source repository (GitOps).
+//
+// To link a PinnedPlacement to a ClusterResourcePlacement, use the same name for the PinnedPlacement
+// object as the ClusterResourcePlacement object. This guarantees a 1:1 link between the two objects.
+//
+// A PinnedPlacement works in one of the two modes:
+//   - in the Export mode, the hub agent keeps the clusters in the spec in sync with the clusters
+//     that the scheduler has picked for the placement, so that the object can be exported as is; and
+//   - in the Import mode, the scheduler picks clusters for the placement only from the clusters in
+//     the spec, and the hub agent reports whether the clusters picked by the scheduler have drifted
+//     from the pinned ones, e.g., when a pinned cluster has left the fleet.
+//
+// Note that pinning only applies to placements of the PickAll and PickN placement types.
+type PinnedPlacement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The desired state of PinnedPlacement.
+	// +required
+	Spec PinnedPlacementSpec `json:"spec"`
+
+	// The observed status of PinnedPlacement.
+	// +optional
+	Status PinnedPlacementStatus `json:"status,omitempty"`
+}
+
+// PinnedPlacementMode is the mode of a PinnedPlacement.
+// +enum
+type PinnedPlacementMode string
+
+const (
+	// PinnedPlacementModeExport exports the scheduling decisions of the placement to the PinnedPlacement.
+	PinnedPlacementModeExport PinnedPlacementMode = "Export"
+
+	// PinnedPlacementModeImport pins the scheduling decisions of the placement to the clusters in
+	// the PinnedPlacement.
+	PinnedPlacementModeImport PinnedPlacementMode = "Import"
+)
+
+// PinnedPlacementSpec defines the desired state of PinnedPlacement.
+// +kubebuilder:validation:XValidation:rule="self.mode != 'Import' || (has(self.clusterNames) && size(self.clusterNames) > 0)",message="clusterNames must be specified in the Import mode"
+type PinnedPlacementSpec struct {
+	// Mode is the mode of the PinnedPlacement, Export or Import.
+	// +kubebuilder:validation:Enum=Export;Import
+	// +kubebuilder:default=Export
+	// +optional
+	Mode PinnedPlacementMode `json:"mode,omitempty"`
+
+	// ClusterNames is the sorted list of the names of the clusters that the placement is placed on;
+	// in the Export mode, it is managed by the hub agent.
+	// +kubebuilder:validation:MaxItems=1000
+	// +optional
+	ClusterNames []string `json:"clusterNames,omitempty"`
+}
+
+// PinnedPlacementStatus defines the observed state of PinnedPlacement.
+type PinnedPlacementStatus struct {
+	// ObservedGeneration is the generation of the PinnedPlacement that the status is computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ComputedClusterNames is the sorted list of the names of the clusters that the scheduler has
+	// picked for the placement, per the latest scheduling policy snapshot.
+	// +optional
+	ComputedClusterNames []string `json:"computedClusterNames,omitempty"`
+
+	// Conditions is an array of current observed conditions for PinnedPlacement.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// PinnedPlacementConditionType defines a specific condition of a PinnedPlacement.
+// +enum
+type PinnedPlacementConditionType string
+
+const (
+	// PinnedPlacementConditionTypeInSync indicates whether the clusters in the spec of the
+	// PinnedPlacement are the same as the clusters that the scheduler has picked for the placement.
+	// Its condition status can be one of the following:
+	// - "True" means the pinned clusters and the computed clusters are the same.
+	// - "False" means they have drifted apart, or the placement cannot be found; the message
+	//   lists the differences.
+	PinnedPlacementConditionTypeInSync PinnedPlacementConditionType = "InSync"
+)
+
+//+kubebuilder:object:root=true
+
+// PinnedPlacementList contains a list of PinnedPlacement.
+type PinnedPlacementList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PinnedPlacement `json:"items"`
+}
+
+// SetConditions sets the conditions of the PinnedPlacement.
+func (p *PinnedPlacement) SetConditions(conditions ...metav1.Condition) {
+	for _, c := range conditions {
+		meta.SetStatusCondition(&p.Status.Conditions, c)
+	}
+}
+
+// GetCondition returns the condition of the PinnedPlacement of the given type.
+func (p *PinnedPlacement) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(p.Status.Conditions, conditionType)
+}
+
+func init() {
+	SchemeBuilder.Register(&PinnedPlacement{}, &PinnedPlacementList{})
+}
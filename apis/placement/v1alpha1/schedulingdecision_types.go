@@ -0,0 +1,85 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// TargetClusterLabel is the label that indicates the name of the cluster that a
+	// ClusterSchedulingDecision is about.
+	TargetClusterLabel = fleetPrefix + "target-cluster"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,categories={fleet,fleet-placement},shortName=csd
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:JSONPath=`.spec.placementName`,name="Placement",type=string
+// +kubebuilder:printcolumn:JSONPath=`.spec.clusterName`,name="Cluster",type=string
+// +kubebuilder:printcolumn:JSONPath=`.spec.selected`,name="Selected",type=boolean
+// +kubebuilder:printcolumn:JSONPath=`.metadata.creationTimestamp`,name="Age",type=date
+
+// ClusterSchedulingDecision is the scheduling decision that the scheduler has made about one cluster
+// for a ClusterResourcePlacement, per the latest scheduling policy snapshot of the placement.
+//
+// The decisions are also reported in the status of the ClusterSchedulingPolicySnapshot objects;
+// ClusterSchedulingDecision objects exist so that a consumer can watch the decisions of a placement,
+// or of a cluster, one compact object per (placement, cluster) pair, instead of polling the policy
+// snapshots. Each object is labeled with the name of its placement (the CRPTrackingLabel label) and
+// the name of its cluster (the TargetClusterLabel label), and is owned by its placement.
+//
+// The objects are maintained by the hub agent and must not be modified by users.
+type ClusterSchedulingDecision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The scheduling decision.
+	// +required
+	Spec ClusterSchedulingDecisionSpec `json:"spec"`
+}
+
+// ClusterSchedulingDecisionSpec is the scheduling decision about a cluster for a placement.
+type ClusterSchedulingDecisionSpec struct {
+	// PlacementName is the name of the ClusterResourcePlacement.
+	// +required
+	PlacementName string `json:"placementName"`
+
+	// ClusterName is the name of the cluster.
+	// +required
+	ClusterName string `json:"clusterName"`
+
+	// PolicySnapshotName is the name of the scheduling policy snapshot per which the decision is made.
+	// +required
+	PolicySnapshotName string `json:"policySnapshotName"`
+
+	// Selected indicates if the cluster is selected by the scheduler.
+	// +required
+	Selected bool `json:"selected"`
+
+	// ClusterScore is the score of the cluster calculated by the scheduler.
+	// +optional
+	ClusterScore *placementv1beta1.ClusterScore `json:"clusterScore,omitempty"`
+
+	// Reason is the reason why the cluster is selected or not.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterSchedulingDecisionList contains a list of ClusterSchedulingDecision.
+type ClusterSchedulingDecisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterSchedulingDecision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterSchedulingDecision{}, &ClusterSchedulingDecisionList{})
+}
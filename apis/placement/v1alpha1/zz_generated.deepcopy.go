@@ -437,6 +437,84 @@ func (in *ClusterResourcePlacementEvictionList) DeepCopyObject() runtime.Object
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSchedulingDecision) DeepCopyInto(out *ClusterSchedulingDecision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSchedulingDecision.
+func (in *ClusterSchedulingDecision) DeepCopy() *ClusterSchedulingDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSchedulingDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSchedulingDecision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSchedulingDecisionList) DeepCopyInto(out *ClusterSchedulingDecisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterSchedulingDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSchedulingDecisionList.
+func (in *ClusterSchedulingDecisionList) DeepCopy() *ClusterSchedulingDecisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSchedulingDecisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSchedulingDecisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSchedulingDecisionSpec) DeepCopyInto(out *ClusterSchedulingDecisionSpec) {
+	*out = *in
+	if in.ClusterScore != nil {
+		in, out := &in.ClusterScore, &out.ClusterScore
+		*out = new(v1beta1.ClusterScore)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSchedulingDecisionSpec.
+func (in *ClusterSchedulingDecisionSpec) DeepCopy() *ClusterSchedulingDecisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSchedulingDecisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterStagedUpdateRun) DeepCopyInto(out *ClusterStagedUpdateRun) {
 	*out = *in
@@ -651,6 +729,112 @@ func (in *OverrideRule) DeepCopy() *OverrideRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PinnedPlacement) DeepCopyInto(out *PinnedPlacement) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PinnedPlacement.
+func (in *PinnedPlacement) DeepCopy() *PinnedPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(PinnedPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PinnedPlacement) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PinnedPlacementList) DeepCopyInto(out *PinnedPlacementList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PinnedPlacement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PinnedPlacementList.
+func (in *PinnedPlacementList) DeepCopy() *PinnedPlacementList {
+	if in == nil {
+		return nil
+	}
+	out := new(PinnedPlacementList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PinnedPlacementList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PinnedPlacementSpec) DeepCopyInto(out *PinnedPlacementSpec) {
+	*out = *in
+	if in.ClusterNames != nil {
+		in, out := &in.ClusterNames, &out.ClusterNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PinnedPlacementSpec.
+func (in *PinnedPlacementSpec) DeepCopy() *PinnedPlacementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PinnedPlacementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PinnedPlacementStatus) DeepCopyInto(out *PinnedPlacementStatus) {
+	*out = *in
+	if in.ComputedClusterNames != nil {
+		in, out := &in.ComputedClusterNames, &out.ComputedClusterNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PinnedPlacementStatus.
+func (in *PinnedPlacementStatus) DeepCopy() *PinnedPlacementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PinnedPlacementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlacementDisruptionBudgetSpec) DeepCopyInto(out *PlacementDisruptionBudgetSpec) {
 	*out = *in
@@ -174,6 +174,13 @@ const (
 	// - "False" means not all the resources are available in the target cluster yet.
 	// - "Unknown" means we haven't finished the apply yet so that we cannot check the resource availability.
 	ResourceBindingAvailable ResourceBindingConditionType = "Available"
+
+	// ResourceBindingWorkCleanedUp indicates whether the works of a deleting binding have been removed.
+	// It is only reported on bindings that are being deleted, and its condition status can only be:
+	// - "False" means some works are still being removed, i.e., the member cluster has not confirmed that
+	// the resources they carry have been cleaned up yet.
+	// The binding will not be fully deleted until all of its works are removed.
+	ResourceBindingWorkCleanedUp ResourceBindingConditionType = "WorkCleanedUp"
 )
 
 // ClusterResourceBindingList is a collection of ClusterResourceBinding.
@@ -92,6 +92,26 @@ type ClusterResourcePlacementSpec struct {
 	// +kubebuilder:default=10
 	// +kubebuilder:validation:Optional
 	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// Priority is the scheduling priority of the placement. When many placements wait to be scheduled,
+	// the scheduler processes those of higher priorities first; placements of the same priority are
+	// processed in the order in which they become ready for scheduling.
+	//
+	// Note that the priority only decides the order of scheduling; the scheduler never removes a
+	// placement from its clusters to make room for one of a higher priority.
+	// Defaults to 0.
+	// +kubebuilder:validation:Minimum=-1000
+	// +kubebuilder:validation:Maximum=1000
+	// +kubebuilder:validation:Optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// DependsOn is a list of names of other ClusterResourcePlacements this placement depends on, e.g.,
+	// the placement of the CRDs (or operators) that the resources of this placement consume. Fleet
+	// rolls out this placement to a member cluster only after all the placements it depends on are
+	// available on the same cluster; the dependencies must not form a cycle.
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:Optional
+	DependsOn []string `json:"dependsOn,omitempty"`
 }
 
 // ClusterResourceSelector is used to select cluster scoped resources as the target resources to be placed.
@@ -169,6 +189,186 @@ type PlacementPolicy struct {
 	// +kubebuilder:validation:MaxItems=100
 	// +kubebuilder:validation:Optional
 	Tolerations []Toleration `json:"tolerations,omitempty"`
+
+	// Stickiness configures how strongly the scheduler prefers clusters that already have the
+	// selected resources placed, i.e., clusters picked in a previous scheduling cycle, when it
+	// reschedules the placement (e.g., after a policy change).
+	// Only valid if the placement type is "PickN".
+	// +kubebuilder:validation:Optional
+	Stickiness *Stickiness `json:"stickiness,omitempty"`
+
+	// ExclusivityGroup, if specified, makes the placement exclusive on its target clusters within
+	// the group, i.e., the scheduler will not place this ClusterResourcePlacement on a cluster
+	// where another ClusterResourcePlacement of the same exclusivity group has been scheduled or
+	// bound. This supports dedicated-cluster tenancy models.
+	// Exclusivity is not enforced for the "PickFixed" placement type, as the target clusters are
+	// specified explicitly; such placements still occupy their target clusters in the group.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?$`
+	ExclusivityGroup string `json:"exclusivityGroup,omitempty"`
+
+	// RebalanceOnClusterJoin, if specified, has Fleet re-evaluate the clusters picked for the placement
+	// whenever a member cluster joins the fleet (or becomes eligible for resource placement), and move
+	// the placement from one of its clusters to the new cluster if the new cluster is significantly
+	// better. Without it, a fully scheduled placement does not consider new clusters.
+	// Only valid if the placement type is "PickN".
+	// +kubebuilder:validation:Optional
+	RebalanceOnClusterJoin *RebalanceOnClusterJoin `json:"rebalanceOnClusterJoin,omitempty"`
+
+	// ResourceFit, if specified, has the scheduler filter out the member clusters without enough
+	// headroom, per the resource usage and properties they report, for the resources the placement
+	// requests, and score the remaining clusters by how allocated their resources are.
+	// Only valid if the placement type is "PickAll" or "PickN".
+	// +kubebuilder:validation:Optional
+	ResourceFit *ResourceFit `json:"resourceFit,omitempty"`
+
+	// WarmDependencies, if specified, has the scheduler prefer the member clusters where the resources
+	// of some other placements (e.g., a shared ingress stack) have already been placed, so as to avoid
+	// duplicating heavyweight dependencies across clusters.
+	// Only valid if the placement type is "PickN".
+	// +kubebuilder:validation:Optional
+	WarmDependencies *WarmDependencies `json:"warmDependencies,omitempty"`
+
+	// KubernetesVersion, if specified, has the scheduler filter out the member clusters whose
+	// Kubernetes versions, as reported by the Fleet member agents, are out of the given range, e.g.,
+	// clusters that cannot serve the APIs of the selected resources.
+	// Only valid if the placement type is "PickAll" or "PickN".
+	// +kubebuilder:validation:Optional
+	KubernetesVersion *KubernetesVersionRange `json:"kubernetesVersion,omitempty"`
+
+	// RequiredAPIs, if specified, has the scheduler filter out the member clusters which do not serve all
+	// of the given API group versions, as reported by the Fleet member agents, e.g., clusters where the
+	// CRDs of the selected custom resources are not installed. Each entry is in the format of
+	// GROUP/VERSION, e.g., "apps/v1" or "monitoring.coreos.com/v1", or VERSION for the core API group,
+	// i.e., "v1".
+	// Only valid if the placement type is "PickAll" or "PickN".
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=50
+	RequiredAPIs []string `json:"requiredAPIs,omitempty"`
+}
+
+// Stickiness configures the preference of the scheduler for clusters that have been picked in a
+// previous scheduling cycle.
+type Stickiness struct {
+	// ScoreBonus is the affinity score the scheduler adds to a cluster that has been picked in a
+	// previous scheduling cycle, in the range [0, 100]. A higher bonus helps prevent minor score
+	// fluctuations from moving the placement to a different cluster.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	ScoreBonus int32 `json:"scoreBonus"`
+}
+
+// WarmDependencies describes the placements whose presence on a member cluster makes the scheduler
+// prefer the cluster.
+type WarmDependencies struct {
+	// PlacementNames is the names of the ClusterResourcePlacements the placement benefits from being
+	// placed next to. A cluster is warm for a placement in the list if the placement has been
+	// scheduled or bound to the cluster.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	PlacementNames []string `json:"placementNames"`
+
+	// ScoreBonus is the affinity score the scheduler adds to a cluster for each placement in the list
+	// that the cluster is warm for, in the range [1, 100]. Defaults to 20.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=20
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	ScoreBonus *int32 `json:"scoreBonus,omitempty"`
+}
+
+// RebalanceOnClusterJoin configures when a placement moves to a member cluster that joins the fleet.
+//
+// The placement moves to the new cluster if either the score or the skew threshold is met; at most
+// one of the clusters of the placement is replaced with the new cluster.
+type RebalanceOnClusterJoin struct {
+	// MinScoreImprovement is how much better, in the sum of the affinity and topology spread scores,
+	// the new cluster must score than a cluster the placement is bound to, for the placement to move
+	// from the latter to the former, in the range [1, 1000]. Defaults to 20.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=20
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	MinScoreImprovement *int32 `json:"minScoreImprovement,omitempty"`
+
+	// TopologyKey is the key of the member cluster labels whose values divide the clusters into
+	// topology domains for MaxSkew.
+	// +kubebuilder:validation:Optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// MaxSkew, if specified along with TopologyKey, is the max difference tolerated between the
+	// numbers of clusters the placement is bound to in any two topology domains. If the difference
+	// is greater, and the new cluster is in a domain with the fewest clusters of the placement, the
+	// placement moves from a cluster in a domain with the most clusters of the placement to the new
+	// cluster, regardless of their scores.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxSkew *int32 `json:"maxSkew,omitempty"`
+}
+
+// ResourceFit describes the resources a placement requests on each of its member clusters, and how
+// the scheduler prefers clusters with enough headroom for them.
+type ResourceFit struct {
+	// Requests is the amount of resources the placement requests on a member cluster; only "cpu"
+	// and "memory" are supported. A cluster fits only if its available amount of each resource
+	// is no less than the request; clusters that do not report the available amount of a requested
+	// resource are filtered out.
+	// +kubebuilder:validation:Optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+
+	// MinNodeCount is the min. number of nodes a member cluster must have for the placement. Clusters
+	// that do not report their node counts are filtered out.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MinNodeCount *int32 `json:"minNodeCount,omitempty"`
+
+	// ScoringStrategy is how the scheduler scores the member clusters that fit, per the fraction of
+	// the allocatable resources that would be allocated after the requests are placed. Can be
+	// "LeastAllocated", which favors clusters with more headroom and spreads the placements out,
+	// or "MostAllocated", which favors clusters with less headroom and packs the placements in.
+	// Defaults to "LeastAllocated".
+	//
+	// Each cluster receives an affinity score in the range [0, 100]: the average of the scores
+	// for the requested resources (or for CPU and memory, if no resource is requested).
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=LeastAllocated;MostAllocated
+	// +kubebuilder:default=LeastAllocated
+	ScoringStrategy ResourceFitScoringStrategy `json:"scoringStrategy,omitempty"`
+}
+
+// ResourceFitScoringStrategy describes how the scheduler scores member clusters that fit the
+// resource requests of a placement.
+// +enum
+type ResourceFitScoringStrategy string
+
+const (
+	// LeastAllocatedScoringStrategy favors member clusters with a smaller fraction of their
+	// allocatable resources allocated.
+	LeastAllocatedScoringStrategy ResourceFitScoringStrategy = "LeastAllocated"
+
+	// MostAllocatedScoringStrategy favors member clusters with a larger fraction of their
+	// allocatable resources allocated.
+	MostAllocatedScoringStrategy ResourceFitScoringStrategy = "MostAllocated"
+)
+
+// KubernetesVersionRange is a range of Kubernetes versions that the member clusters of a placement
+// must run; at least one of its bounds must be specified.
+type KubernetesVersionRange struct {
+	// Min is the min. Kubernetes version (inclusive), in the format of MAJOR.MINOR or
+	// MAJOR.MINOR.PATCH, e.g., "1.28" or "1.28.3"; an omitted patch version is considered to be 0.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^v?[0-9]+\.[0-9]+(\.[0-9]+)?$`
+	Min string `json:"min,omitempty"`
+
+	// Max is the max. Kubernetes version (inclusive), in the format of MAJOR.MINOR or
+	// MAJOR.MINOR.PATCH, e.g., "1.30" or "1.30.2"; if the patch version is omitted, all the patch
+	// versions of the minor version are in the range.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^v?[0-9]+\.[0-9]+(\.[0-9]+)?$`
+	Max string `json:"max,omitempty"`
 }
 
 // Affinity is a group of cluster affinity scheduling rules. More to be added.
@@ -176,6 +376,12 @@ type Affinity struct {
 	// ClusterAffinity contains cluster affinity scheduling rules for the selected resources.
 	// +kubebuilder:validation:Optional
 	ClusterAffinity *ClusterAffinity `json:"clusterAffinity,omitempty"`
+
+	// PlacementAntiAffinity contains placement anti-affinity scheduling rules for the selected
+	// resources, which keep the placement away from clusters where other placements have been
+	// scheduled or bound.
+	// +kubebuilder:validation:Optional
+	PlacementAntiAffinity *PlacementAntiAffinity `json:"placementAntiAffinity,omitempty"`
 }
 
 // ClusterAffinity contains cluster affinity scheduling rules for the selected resources.
@@ -200,6 +406,49 @@ type ClusterAffinity struct {
 	PreferredDuringSchedulingIgnoredDuringExecution []PreferredClusterSelector `json:"preferredDuringSchedulingIgnoredDuringExecution,omitempty"`
 }
 
+// PlacementAntiAffinity contains placement anti-affinity scheduling rules for the selected resources.
+type PlacementAntiAffinity struct {
+	// If the anti-affinity requirements specified by this field are not met at scheduling time,
+	// the resource will not be scheduled onto the cluster, i.e., the scheduler will not pick a
+	// cluster where any placement selected by the terms has been scheduled or bound.
+	// If the anti-affinity requirements specified by this field cease to be met at some point
+	// after the placement (e.g. another selected placement is scheduled onto the same cluster
+	// later), the system will not try to remove the resource from the cluster.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	RequiredDuringSchedulingIgnoredDuringExecution []PlacementAffinityTerm `json:"requiredDuringSchedulingIgnoredDuringExecution,omitempty"`
+
+	// The scheduler computes a score for each cluster at schedule time by iterating
+	// through the elements of this field and subtracting "weight" from the sum if any
+	// placement selected by the corresponding term has been scheduled or bound to the cluster.
+	// The clusters with the highest sums are thus the ones shared with the fewest selected placements.
+	// This field is ignored if the placement type is "PickAll".
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	PreferredDuringSchedulingIgnoredDuringExecution []WeightedPlacementAffinityTerm `json:"preferredDuringSchedulingIgnoredDuringExecution,omitempty"`
+}
+
+// PlacementAffinityTerm selects a set of placements, other than the placement itself.
+type PlacementAffinityTerm struct {
+	// LabelSelector is a label query over all the ClusterResourcePlacements. Placements matching
+	// the query are selected; the placement itself is never selected.
+	// +kubebuilder:validation:Required
+	LabelSelector metav1.LabelSelector `json:"labelSelector"`
+}
+
+// WeightedPlacementAffinityTerm is a placement affinity term with a weight.
+type WeightedPlacementAffinityTerm struct {
+	// Weight associated with matching the corresponding placementAffinityTerm, in the range [1, 100].
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// A placement affinity term, associated with the corresponding weight.
+	// +kubebuilder:validation:Required
+	PlacementAffinityTerm PlacementAffinityTerm `json:"placementAffinityTerm"`
+}
+
 type ClusterSelector struct {
 	// +kubebuilder:validation:MaxItems=10
 	// ClusterSelectorTerms is a list of cluster selector terms. The terms are `ORed`.
@@ -570,6 +819,16 @@ type ApplyStrategy struct {
 	// +kubebuilder:validation:Optional
 	ServerSideApplyConfig *ServerSideApplyConfig `json:"serverSideApplyConfig,omitempty"`
 
+	// ServiceAccount is the service account on the member cluster which Fleet impersonates to
+	// create and update the resources, so that they are applied with the permissions of the service
+	// account instead of those of the Fleet member agent.
+	//
+	// The service account is honored only when impersonation is enabled on the member agent;
+	// otherwise the resources fail to apply. Fleet still uses its own identity to remove the resources
+	// which are no longer placed.
+	// +kubebuilder:validation:Optional
+	ServiceAccount *ServiceAccountReference `json:"serviceAccount,omitempty"`
+
 	// WhenToTakeOver determines the action to take when Fleet applies resources to a member
 	// cluster for the first time and finds out that the resource already exists in the cluster.
 	//
@@ -633,6 +892,39 @@ type ApplyStrategy struct {
 	// +kubebuilder:validation:Enum=Always;IfNoDiff;Never
 	// +kubebuilder:validation:Optional
 	WhenToTakeOver WhenToTakeOverType `json:"whenToTakeOver,omitempty"`
+
+	// DeletionProtection controls whether Fleet protects critical resources, i.e., namespaces and
+	// CustomResourceDefinitions, placed on the member clusters from an accidental deletion of the
+	// ClusterResourcePlacement.
+	//
+	// Available options are:
+	//
+	// * None: Fleet removes the placed resources from the member clusters once the
+	//   ClusterResourcePlacement is deleted. This is the default option.
+	//
+	// * CriticalResources: Fleet marks the critical resources it places with the
+	//   kubernetes-fleet.io/deletion-protected annotation; and if the ClusterResourcePlacement
+	//   selects any critical resource, deleting it takes two steps: after the
+	//   ClusterResourcePlacement is deleted, Fleet keeps all the placed resources on the member
+	//   clusters until the ClusterResourcePlacement is annotated with
+	//   kubernetes-fleet.io/confirm-deletion set to "true".
+	//
+	// +kubebuilder:validation:Enum=None;CriticalResources
+	// +kubebuilder:validation:Optional
+	DeletionProtection DeletionProtectionType `json:"deletionProtection,omitempty"`
+}
+
+// ServiceAccountReference refers to a service account on the member cluster.
+type ServiceAccountReference struct {
+	// Namespace is the namespace of the service account.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the service account.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
 }
 
 // ComparisonOptionType describes the compare option that Fleet uses to detect drifts and/or
@@ -739,6 +1031,22 @@ const (
 	WhenToTakeOverTypeNever WhenToTakeOverType = "Never"
 )
 
+// DeletionProtectionType describes how Fleet protects placed resources from an accidental
+// deletion of the placement.
+// +enum
+type DeletionProtectionType string
+
+const (
+	// DeletionProtectionTypeNone instructs Fleet to remove the placed resources from the member
+	// clusters once the placement is deleted.
+	DeletionProtectionTypeNone DeletionProtectionType = "None"
+
+	// DeletionProtectionTypeCriticalResources instructs Fleet to keep the placed resources on the
+	// member clusters after the placement is deleted, until the deletion is confirmed, if the
+	// placement selects any namespace or CustomResourceDefinition.
+	DeletionProtectionTypeCriticalResources DeletionProtectionType = "CriticalResources"
+)
+
 // +enum
 type RolloutStrategyType string
 
@@ -826,6 +1134,13 @@ type ClusterResourcePlacementStatus struct {
 	// +kubebuilder:validation:Optional
 	PlacementStatuses []ResourcePlacementStatus `json:"placementStatuses,omitempty"`
 
+	// ApplyErrorSummary counts the selected clusters where some resources fail to be applied, by the
+	// category of the errors, e.g., to alert on RBAC errors across many clusters. A cluster is counted
+	// once for each category of the errors of its failed resources; categories without any cluster
+	// are omitted.
+	// +kubebuilder:validation:Optional
+	ApplyErrorSummary []ApplyErrorCount `json:"applyErrorSummary,omitempty"`
+
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	// +listType=map
@@ -836,6 +1151,19 @@ type ClusterResourcePlacementStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// ApplyErrorCount is the number of clusters where some resources fail to be applied with errors of a
+// category.
+type ApplyErrorCount struct {
+	// Category is the category of the errors.
+	// +kubebuilder:validation:Required
+	Category ApplyErrorCategory `json:"category"`
+
+	// ClusterCount is the number of clusters where some resources fail to be applied with errors of
+	// the category.
+	// +kubebuilder:validation:Required
+	ClusterCount int32 `json:"clusterCount"`
+}
+
 // ResourceIdentifier identifies one Kubernetes resource.
 type ResourceIdentifier struct {
 	// Group is the group name of the selected resource.
@@ -957,6 +1285,16 @@ type FailedResourcePlacement struct {
 	// The failed condition status.
 	// +kubebuilder:validation:Required
 	Condition metav1.Condition `json:"condition"`
+
+	// ApplyFailureCount is the number of consecutive attempts that have failed to apply the resource,
+	// if the resource fails to be applied.
+	// +kubebuilder:validation:Optional
+	ApplyFailureCount int32 `json:"applyFailureCount,omitempty"`
+
+	// ApplyErrorCategory is the category of the error that the last attempt to apply the resource has
+	// failed with, if the resource fails to be applied.
+	// +kubebuilder:validation:Optional
+	ApplyErrorCategory ApplyErrorCategory `json:"applyErrorCategory,omitempty"`
 }
 
 // PatchDetail describes a patch that explains an observed configuration drift or
@@ -31,6 +31,11 @@ const (
 	// MemberClusterFinalizer is used to make sure that we handle gc of all the member cluster resources on the hub cluster.
 	MemberClusterFinalizer = fleetPrefix + "membercluster-finalizer"
 
+	// MemberClusterCleanupFinalizer is used by the cluster deletion controller to make sure that all the artifacts
+	// of a member cluster on the hub cluster, e.g., its reserved namespace, Works, bindings, and generated RBAC
+	// objects, are cleaned up before the member cluster is deleted.
+	MemberClusterCleanupFinalizer = fleetPrefix + "membercluster-cleanup"
+
 	// WorkFinalizer is used by the work generator to make sure that the binding is not deleted until the work objects
 	// it generates are all deleted, or used by the work controller to make sure the work has been deleted in the member
 	// cluster.
@@ -39,6 +44,10 @@ const (
 	// CRPTrackingLabel points to the cluster resource placement that creates this resource binding.
 	CRPTrackingLabel = fleetPrefix + "parent-CRP"
 
+	// ExclusivityGroupLabel is the label applied to a resource binding that records the exclusivity group
+	// of the cluster resource placement that creates the binding, if any.
+	ExclusivityGroupLabel = fleetPrefix + "exclusivity-group"
+
 	// IsLatestSnapshotLabel indicates if the snapshot is the latest one.
 	IsLatestSnapshotLabel = fleetPrefix + "is-latest-snapshot"
 
@@ -57,6 +66,11 @@ const (
 	// The format is {workPrefix}-configMap-uuid.
 	WorkNameWithConfigEnvelopeFmt = "%s-configmap-%s"
 
+	// WorkNameWithPartFmt is the format of the name of a work which holds a part of the manifests generated with
+	// a resource snapshot, when the manifests do not fit in a single work.
+	// The format is {workPrefix}-part-{part}, where the first part is the work named {workPrefix} itself.
+	WorkNameWithPartFmt = "%s-part-%d"
+
 	// ParentClusterResourceOverrideSnapshotHashAnnotation is the annotation to work that contains the hash of the parent cluster resource override snapshot list.
 	ParentClusterResourceOverrideSnapshotHashAnnotation = fleetPrefix + "parent-cluster-resource-override-snapshot-hash"
 
@@ -91,6 +105,87 @@ const (
 	// PreviousBindingStateAnnotation records the previous state of a binding.
 	// This is used to remember if an "unscheduled" binding was moved from a "bound" state or a "scheduled" state.
 	PreviousBindingStateAnnotation = fleetPrefix + "previous-binding-state"
+
+	// UnscheduledReasonAnnotation records why the scheduler has marked a binding as unscheduled.
+	// Its value is one of the UnscheduledReason* constants.
+	UnscheduledReasonAnnotation = fleetPrefix + "unscheduled-reason"
+
+	// UnscheduledByPolicySnapshotAnnotation records the name of the scheduling policy snapshot whose
+	// scheduling cycle has marked a binding as unscheduled.
+	UnscheduledByPolicySnapshotAnnotation = fleetPrefix + "unscheduled-by-policy-snapshot"
+
+	// TargetClusterUIDAnnotation records the UID of the member cluster that a binding targets.
+	// The scheduler uses it to tell if a member cluster has been deleted and re-registered with
+	// the same name, in which case the binding no longer refers to the cluster it is created for.
+	TargetClusterUIDAnnotation = fleetPrefix + "target-cluster-uid"
+
+	// RolloutGroupLabel is the label that the scheduler adds to a binding to assign it to a rollout group,
+	// e.g., canary; the rollout controller rolls out changes to the bindings group by group.
+	RolloutGroupLabel = fleetPrefix + "rollout-group"
+
+	// RolloutGroupOrderAnnotation records the position of the rollout group of a binding in the rollout order;
+	// bindings of a group with a lower position are rolled out first.
+	RolloutGroupOrderAnnotation = fleetPrefix + "rollout-group-order"
+
+	// SchedulerProfileAnnotation is the annotation that a user adds to a CRP to name the scheduling profile
+	// the scheduler uses for the CRP; a CRP without the annotation is scheduled with the default profile.
+	SchedulerProfileAnnotation = fleetPrefix + "scheduler-profile"
+
+	// ConfirmDeletionAnnotation is the annotation that a user adds to a deleted CRP, with the value "true",
+	// to confirm the deletion of a CRP protected by the CriticalResources deletion protection; until then
+	// Fleet keeps the placed resources on the member clusters.
+	ConfirmDeletionAnnotation = fleetPrefix + "confirm-deletion"
+
+	// DeletionProtectedAnnotation is the annotation that the work applier adds to the critical resources,
+	// i.e., namespaces and CustomResourceDefinitions, it places with the CriticalResources deletion protection.
+	DeletionProtectedAnnotation = fleetPrefix + "deletion-protected"
+
+	// NotificationURLAnnotation is the annotation that a user adds to a CRP to send the notifications of
+	// the CRP, e.g., the CRP has become available, to the given HTTP webhook instead of the default one;
+	// it is honored only if the hub agent allows it.
+	NotificationURLAnnotation = fleetPrefix + "notification-url"
+
+	// RescheduleAnnotation is the annotation that a user adds to a CRP of the PickN placement type, with any
+	// value (e.g., "now"), to have Fleet re-pick the clusters of the CRP from scratch, ignoring stickiness and
+	// the score thresholds of rebalancing; Fleet removes the annotation once the CRP is on the best clusters.
+	RescheduleAnnotation = fleetPrefix + "reschedule"
+
+	// RecommendedClustersAnnotation is the annotation that an external recommender (e.g., a cost optimizer)
+	// adds to a CRP, with a comma-separated list of member cluster names, to have the scheduler prefer those
+	// clusters; it is only a hint, i.e., the recommended clusters which fail the placement policy are not picked.
+	RecommendedClustersAnnotation = fleetPrefix + "recommended-clusters"
+)
+
+const (
+	// UnscheduledReasonClusterLeft signals that a binding is unscheduled as its target cluster has left
+	// the fleet (including the case where the cluster has re-joined the fleet with the same name) or
+	// is no longer eligible for resource placement; this is an involuntary removal.
+	UnscheduledReasonClusterLeft = "ClusterLeft"
+
+	// UnscheduledReasonPolicyChanged signals that a binding is unscheduled as its target cluster is no
+	// longer picked under the latest scheduling policy.
+	UnscheduledReasonPolicyChanged = "PolicyChanged"
+
+	// UnscheduledReasonDownscaled signals that a binding is unscheduled as the user has lowered the
+	// number of clusters to pick.
+	UnscheduledReasonDownscaled = "Downscaled"
+
+	// UnscheduledReasonDuplicated signals that a binding is unscheduled as another binding of the same
+	// placement targets the same cluster, e.g., one left behind by a scheduling cycle that failed midway.
+	UnscheduledReasonDuplicated = "Duplicated"
+
+	// UnscheduledReasonPreempted signals that a binding is unscheduled to make room for a placement of
+	// a higher priority; the annotation UnscheduledByPolicySnapshotAnnotation names the scheduling policy
+	// snapshot of the preempting placement.
+	UnscheduledReasonPreempted = "Preempted"
+
+	// UnscheduledReasonRebalanced signals that a binding is unscheduled by the descheduler, so that the
+	// placement can move to a cluster that scores significantly better than its target cluster.
+	UnscheduledReasonRebalanced = "Rebalanced"
+
+	// UnscheduledReasonClusterDrained signals that a binding is unscheduled as its target cluster is
+	// cordoned and drained, e.g., for maintenance.
+	UnscheduledReasonClusterDrained = "ClusterDrained"
 )
 
 // NamespacedName comprises a resource name, with a mandatory namespace.
@@ -19,6 +19,12 @@ const (
 
 	// NumberOfClustersAnnotation is the annotation that indicates how many clusters should be selected for selectN placement type.
 	NumberOfClustersAnnotation = fleetPrefix + "number-of-clusters"
+
+	// CompactedDecisionSummaryAnnotation is the annotation added to an inactive policy snapshot whose
+	// cluster decisions have been compacted (removed) after the retention period; its value is a JSON
+	// object that summarizes the removed decisions, e.g.,
+	// `{"compactedAt":"2024-01-01T00:00:00Z","selectedClusterCount":3,"unselectedClusterCount":5}`.
+	CompactedDecisionSummaryAnnotation = fleetPrefix + "compacted-decision-summary"
 )
 
 // +genclient
@@ -88,6 +94,26 @@ type SchedulingPolicySnapshotStatus struct {
 	// add the clusters that can provide the most insight to the list first.
 	// +optional
 	ClusterDecisions []ClusterDecision `json:"targetClusters,omitempty"`
+
+	// UnselectedClusterSummary aggregates the unselected clusters by the reason why they are not selected.
+	//
+	// It is populated only when the scheduler compacts the cluster decisions of a policy snapshot
+	// with many clusters, in which case ClusterDecisions lists the selected clusters only.
+	// +kubebuilder:validation:MaxItems=100
+	// +optional
+	UnselectedClusterSummary []UnselectedClusterCount `json:"unselectedClusterSummary,omitempty"`
+}
+
+// UnselectedClusterCount is the number of clusters not selected by the scheduler for the same reason.
+type UnselectedClusterCount struct {
+	// Reason is why the clusters are not selected, e.g., the name of the scheduler plugin that
+	// filters them out.
+	// +required
+	Reason string `json:"reason"`
+
+	// Count is the number of clusters not selected for the reason.
+	// +required
+	Count int `json:"count"`
 }
 
 // SchedulingPolicySnapshotConditionType identifies a specific condition of the SchedulingPolicySnapshot.
@@ -101,6 +127,14 @@ const (
 	// - "False" means we did not fully satisfy the placement requirement of the corresponding SchedulingPolicySnapshot.
 	// - "Unknown" means the status of the scheduling is unknown.
 	PolicySnapshotScheduled SchedulingPolicySnapshotConditionType = "Scheduled"
+
+	// Superseded indicates that the SchedulingPolicySnapshot has been found active along with a newer
+	// SchedulingPolicySnapshot of the same placement, and the scheduler has marked it as inactive.
+	// Its condition status can only be:
+	// - "True" means that a SchedulingPolicySnapshot of a larger index is active, and this one is no longer
+	// considered by the scheduler.
+	// The condition is absent from SchedulingPolicySnapshots that have not been superseded.
+	PolicySnapshotSuperseded SchedulingPolicySnapshotConditionType = "Superseded"
 )
 
 // ClusterDecision represents a decision from a placement
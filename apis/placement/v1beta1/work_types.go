@@ -34,6 +34,24 @@ const (
 	// LastAppliedConfigAnnotation is to record the last applied configuration on the object.
 	LastAppliedConfigAnnotation = fleetPrefix + "last-applied-configuration"
 
+	// ProvenancePlacementAnnotation is the annotation that records the name of the placement which
+	// places the object on the member cluster.
+	ProvenancePlacementAnnotation = fleetPrefix + "provenance-placement"
+
+	// ProvenanceResourceSnapshotIndexAnnotation is the annotation that records the index of the
+	// resource snapshot from which the object is applied.
+	ProvenanceResourceSnapshotIndexAnnotation = fleetPrefix + "provenance-resource-snapshot-index"
+
+	// ProvenanceHubAnnotation is the annotation that records the identity of the hub cluster from
+	// which the object is applied.
+	ProvenanceHubAnnotation = fleetPrefix + "provenance-hub"
+
+	// ProvenanceAppliedAtAnnotation is the annotation that records the time (in RFC 3339 format)
+	// when the object is first applied with its current provenance.
+	//
+	// Note that this annotation is not considered when computing the manifest hash.
+	ProvenanceAppliedAtAnnotation = fleetPrefix + "provenance-applied-at"
+
 	// WorkConditionTypeApplied represents workload in Work is applied successfully on the spoke cluster.
 	WorkConditionTypeApplied = "Applied"
 
@@ -217,8 +235,49 @@ type ManifestCondition struct {
 	//
 	// +kubebuilder:validation:Optional
 	DiffDetails *DiffDetails `json:"diffDetails,omitempty"`
+
+	// ApplyFailureCount is the number of consecutive attempts that have failed to apply the resource;
+	// it is reset once the resource is applied.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	ApplyFailureCount int32 `json:"applyFailureCount,omitempty"`
+
+	// LastApplyErrorCategory is the category of the error that the last attempt to apply the resource
+	// has failed with; it is only set when the resource fails to be applied.
+	//
+	// +kubebuilder:validation:Optional
+	LastApplyErrorCategory ApplyErrorCategory `json:"lastApplyErrorCategory,omitempty"`
 }
 
+// ApplyErrorCategory is the category of an error that a resource fails to be applied with, which
+// tells the failures that need the same kind of fix apart.
+// +enum
+// +kubebuilder:validation:Enum=RBAC;Admission;Conflict;Invalid;Other
+type ApplyErrorCategory string
+
+const (
+	// ApplyErrorCategoryRBAC means that the member agent, or the service account it applies the
+	// resource as, is not allowed to apply the resource.
+	ApplyErrorCategoryRBAC ApplyErrorCategory = "RBAC"
+
+	// ApplyErrorCategoryAdmission means that an admission webhook (or policy) on the member cluster
+	// has denied the resource.
+	ApplyErrorCategoryAdmission ApplyErrorCategory = "Admission"
+
+	// ApplyErrorCategoryConflict means that the resource is owned by another placement or
+	// another party, or has been modified concurrently.
+	ApplyErrorCategoryConflict ApplyErrorCategory = "Conflict"
+
+	// ApplyErrorCategoryInvalid means that the resource is malformed, or is rejected by the validation
+	// of the member cluster.
+	ApplyErrorCategoryInvalid ApplyErrorCategory = "Invalid"
+
+	// ApplyErrorCategoryOther means that the resource fails to be applied for any other reason, e.g.,
+	// the member cluster is unreachable, or the API of the resource is not served.
+	ApplyErrorCategoryOther ApplyErrorCategory = "Other"
+)
+
 // +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
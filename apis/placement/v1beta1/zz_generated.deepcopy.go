@@ -10,6 +10,7 @@ Licensed under the MIT license.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -23,6 +24,11 @@ func (in *Affinity) DeepCopyInto(out *Affinity) {
 		*out = new(ClusterAffinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PlacementAntiAffinity != nil {
+		in, out := &in.PlacementAntiAffinity, &out.PlacementAntiAffinity
+		*out = new(PlacementAntiAffinity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Affinity.
@@ -145,6 +151,21 @@ func (in *AppliedWorkStatus) DeepCopy() *AppliedWorkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyErrorCount) DeepCopyInto(out *ApplyErrorCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyErrorCount.
+func (in *ApplyErrorCount) DeepCopy() *ApplyErrorCount {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyErrorCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplyStrategy) DeepCopyInto(out *ApplyStrategy) {
 	*out = *in
@@ -153,6 +174,11 @@ func (in *ApplyStrategy) DeepCopyInto(out *ApplyStrategy) {
 		*out = new(ServerSideApplyConfig)
 		**out = **in
 	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyStrategy.
@@ -351,6 +377,11 @@ func (in *ClusterResourcePlacementSpec) DeepCopyInto(out *ClusterResourcePlaceme
 		*out = new(int32)
 		**out = **in
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourcePlacementSpec.
@@ -380,6 +411,11 @@ func (in *ClusterResourcePlacementStatus) DeepCopyInto(out *ClusterResourcePlace
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ApplyErrorSummary != nil {
+		in, out := &in.ApplyErrorSummary, &out.ApplyErrorSummary
+		*out = make([]ApplyErrorCount, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -746,6 +782,21 @@ func (in *FailedResourcePlacement) DeepCopy() *FailedResourcePlacement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesVersionRange) DeepCopyInto(out *KubernetesVersionRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersionRange.
+func (in *KubernetesVersionRange) DeepCopy() *KubernetesVersionRange {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesVersionRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Manifest) DeepCopyInto(out *Manifest) {
 	*out = *in
@@ -825,6 +876,51 @@ func (in *PatchDetail) DeepCopy() *PatchDetail {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementAffinityTerm) DeepCopyInto(out *PlacementAffinityTerm) {
+	*out = *in
+	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementAffinityTerm.
+func (in *PlacementAffinityTerm) DeepCopy() *PlacementAffinityTerm {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementAffinityTerm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementAntiAffinity) DeepCopyInto(out *PlacementAntiAffinity) {
+	*out = *in
+	if in.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.RequiredDuringSchedulingIgnoredDuringExecution, &out.RequiredDuringSchedulingIgnoredDuringExecution
+		*out = make([]PlacementAffinityTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreferredDuringSchedulingIgnoredDuringExecution != nil {
+		in, out := &in.PreferredDuringSchedulingIgnoredDuringExecution, &out.PreferredDuringSchedulingIgnoredDuringExecution
+		*out = make([]WeightedPlacementAffinityTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementAntiAffinity.
+func (in *PlacementAntiAffinity) DeepCopy() *PlacementAntiAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementAntiAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlacementPolicy) DeepCopyInto(out *PlacementPolicy) {
 	*out = *in
@@ -855,6 +951,36 @@ func (in *PlacementPolicy) DeepCopyInto(out *PlacementPolicy) {
 		*out = make([]Toleration, len(*in))
 		copy(*out, *in)
 	}
+	if in.Stickiness != nil {
+		in, out := &in.Stickiness, &out.Stickiness
+		*out = new(Stickiness)
+		**out = **in
+	}
+	if in.RebalanceOnClusterJoin != nil {
+		in, out := &in.RebalanceOnClusterJoin, &out.RebalanceOnClusterJoin
+		*out = new(RebalanceOnClusterJoin)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceFit != nil {
+		in, out := &in.ResourceFit, &out.ResourceFit
+		*out = new(ResourceFit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WarmDependencies != nil {
+		in, out := &in.WarmDependencies, &out.WarmDependencies
+		*out = new(WarmDependencies)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubernetesVersion != nil {
+		in, out := &in.KubernetesVersion, &out.KubernetesVersion
+		*out = new(KubernetesVersionRange)
+		**out = **in
+	}
+	if in.RequiredAPIs != nil {
+		in, out := &in.RequiredAPIs, &out.RequiredAPIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementPolicy.
@@ -940,6 +1066,31 @@ func (in *PropertySorter) DeepCopy() *PropertySorter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RebalanceOnClusterJoin) DeepCopyInto(out *RebalanceOnClusterJoin) {
+	*out = *in
+	if in.MinScoreImprovement != nil {
+		in, out := &in.MinScoreImprovement, &out.MinScoreImprovement
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSkew != nil {
+		in, out := &in.MaxSkew, &out.MaxSkew
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebalanceOnClusterJoin.
+func (in *RebalanceOnClusterJoin) DeepCopy() *RebalanceOnClusterJoin {
+	if in == nil {
+		return nil
+	}
+	out := new(RebalanceOnClusterJoin)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceBindingSpec) DeepCopyInto(out *ResourceBindingSpec) {
 	*out = *in
@@ -1030,6 +1181,33 @@ func (in *ResourceContent) DeepCopy() *ResourceContent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceFit) DeepCopyInto(out *ResourceFit) {
+	*out = *in
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MinNodeCount != nil {
+		in, out := &in.MinNodeCount, &out.MinNodeCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFit.
+func (in *ResourceFit) DeepCopy() *ResourceFit {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceFit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceIdentifier) DeepCopyInto(out *ResourceIdentifier) {
 	*out = *in
@@ -1244,6 +1422,11 @@ func (in *SchedulingPolicySnapshotStatus) DeepCopyInto(out *SchedulingPolicySnap
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UnselectedClusterSummary != nil {
+		in, out := &in.UnselectedClusterSummary, &out.UnselectedClusterSummary
+		*out = make([]UnselectedClusterCount, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingPolicySnapshotStatus.
@@ -1271,6 +1454,36 @@ func (in *ServerSideApplyConfig) DeepCopy() *ServerSideApplyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountReference.
+func (in *ServiceAccountReference) DeepCopy() *ServiceAccountReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Stickiness) DeepCopyInto(out *Stickiness) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Stickiness.
+func (in *Stickiness) DeepCopy() *Stickiness {
+	if in == nil {
+		return nil
+	}
+	out := new(Stickiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Toleration) DeepCopyInto(out *Toleration) {
 	*out = *in
@@ -1306,6 +1519,62 @@ func (in *TopologySpreadConstraint) DeepCopy() *TopologySpreadConstraint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnselectedClusterCount) DeepCopyInto(out *UnselectedClusterCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnselectedClusterCount.
+func (in *UnselectedClusterCount) DeepCopy() *UnselectedClusterCount {
+	if in == nil {
+		return nil
+	}
+	out := new(UnselectedClusterCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmDependencies) DeepCopyInto(out *WarmDependencies) {
+	*out = *in
+	if in.PlacementNames != nil {
+		in, out := &in.PlacementNames, &out.PlacementNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScoreBonus != nil {
+		in, out := &in.ScoreBonus, &out.ScoreBonus
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmDependencies.
+func (in *WarmDependencies) DeepCopy() *WarmDependencies {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmDependencies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WeightedPlacementAffinityTerm) DeepCopyInto(out *WeightedPlacementAffinityTerm) {
+	*out = *in
+	in.PlacementAffinityTerm.DeepCopyInto(&out.PlacementAffinityTerm)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WeightedPlacementAffinityTerm.
+func (in *WeightedPlacementAffinityTerm) DeepCopy() *WeightedPlacementAffinityTerm {
+	if in == nil {
+		return nil
+	}
+	out := new(WeightedPlacementAffinityTerm)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Work) DeepCopyInto(out *Work) {
 	*out = *in
@@ -9,6 +9,7 @@ import (
 	"flag"
 	"math"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 
@@ -35,10 +36,14 @@ import (
 	fleetv1alpha1 "go.goms.io/fleet/apis/v1alpha1"
 	"go.goms.io/fleet/cmd/hubagent/options"
 	"go.goms.io/fleet/cmd/hubagent/workload"
+	"go.goms.io/fleet/pkg/controllers/clusterdeletion"
 	mcv1alpha1 "go.goms.io/fleet/pkg/controllers/membercluster/v1alpha1"
 	mcv1beta1 "go.goms.io/fleet/pkg/controllers/membercluster/v1beta1"
 	fleetmetrics "go.goms.io/fleet/pkg/metrics"
+	"go.goms.io/fleet/pkg/utils/healthcheck"
+	"go.goms.io/fleet/pkg/utils/resource"
 	"go.goms.io/fleet/pkg/webhook"
+	"go.goms.io/fleet/pkg/webhook/fleetsize"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -74,7 +79,11 @@ func init() {
 
 	metrics.Registry.MustRegister(fleetmetrics.JoinResultMetrics, fleetmetrics.LeaveResultMetrics,
 		fleetmetrics.PlacementApplyFailedCount, fleetmetrics.PlacementApplySucceedCount,
-		fleetmetrics.SchedulingCycleDurationMilliseconds, fleetmetrics.SchedulerActiveWorkers)
+		fleetmetrics.SchedulingCycleDurationMilliseconds, fleetmetrics.SchedulerActiveWorkers, fleetmetrics.SchedulerStalePolicyResyncsTotal,
+		fleetmetrics.PlacementSelectedClusterCount, fleetmetrics.PlacementAvailableClusterCount,
+		fleetmetrics.PlacementAvailableClusterPercent, fleetmetrics.PlacementRolloutDurationSeconds, fleetmetrics.BindingCleanupPendingSeconds,
+		fleetmetrics.ParallelizerWorkChunksTotal, fleetmetrics.ParallelizerWorkDurationSeconds, fleetmetrics.ParallelizerAbortedWorkTotal,
+		fleetmetrics.FleetObjectCount, fleetmetrics.PlacementBindingCount)
 }
 
 func main() {
@@ -102,7 +111,8 @@ func main() {
 		Cache: cache.Options{
 			SyncPeriod: &opts.ResyncPeriod.Duration,
 		},
-		LeaderElection:             opts.LeaderElection.LeaderElect,
+		// A read-only replica never writes to the hub cluster, and thus takes no part in leader election.
+		LeaderElection:             opts.LeaderElection.LeaderElect && !opts.SchedulerReadOnlyReplica,
 		LeaderElectionID:           opts.LeaderElection.ResourceName,
 		LeaderElectionNamespace:    opts.LeaderElection.ResourceNamespace,
 		LeaderElectionResourceLock: opts.LeaderElection.ResourceLock,
@@ -120,6 +130,11 @@ func main() {
 		exitWithErrorFunc()
 	}
 
+	if opts.SchedulerReadOnlyReplica {
+		runSchedulerReadOnlyReplica(mgr, opts)
+		return
+	}
+
 	klog.V(2).InfoS("starting hubagent")
 	if opts.EnableV1Alpha1APIs {
 		klog.Info("Setting up memberCluster v1alpha1 controller")
@@ -142,6 +157,18 @@ func main() {
 			klog.ErrorS(err, "unable to create v1beta1 controller", "controller", "MemberCluster")
 			exitWithErrorFunc()
 		}
+		klog.Info("Setting up cluster deletion controller")
+		if err = (&clusterdeletion.Reconciler{
+			Client: mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			klog.ErrorS(err, "unable to create cluster deletion controller", "controller", "MemberCluster")
+			exitWithErrorFunc()
+		}
+		klog.Info("Setting up the fleet size reporter")
+		if err = mgr.Add(fleetsize.NewReporter(mgr.GetClient())); err != nil {
+			klog.ErrorS(err, "unable to set up the fleet size reporter")
+			exitWithErrorFunc()
+		}
 	}
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -155,10 +182,24 @@ func main() {
 
 	if opts.EnableWebhook {
 		whiteListedUsers := strings.Split(opts.WhiteListedUsers, ",")
-		if err := SetupWebhook(mgr, options.WebhookClientConnectionType(opts.WebhookClientConnectionType), opts.WebhookServiceName, whiteListedUsers, opts.EnableGuardRail, opts.EnableV1Beta1APIs); err != nil {
+		fleetSizeLimits := fleetsize.Limits{
+			MaxMemberClusters:            opts.MaxMemberClusters,
+			MaxClusterResourcePlacements: opts.MaxClusterResourcePlacements,
+			MaxBindingsPerPlacement:      opts.MaxBindingsPerPlacement,
+		}
+		manifestLimits := resource.ManifestLimits{
+			MaxManifestSize:     opts.MaxWorkManifestSize,
+			MaxWorkSize:         opts.MaxWorkSize,
+			MaxManifestsPerWork: opts.MaxManifestsPerWork,
+		}
+		if err := SetupWebhook(mgr, options.WebhookClientConnectionType(opts.WebhookClientConnectionType), opts.WebhookServiceName, whiteListedUsers, opts.EnableGuardRail, opts.EnableV1Beta1APIs, opts.EnableCRPResourceAccessCheck, fleetSizeLimits, manifestLimits); err != nil {
 			klog.ErrorS(err, "unable to set up webhook")
 			exitWithErrorFunc()
 		}
+		if err := mgr.AddReadyzCheck("webhook-cert", healthcheck.CertificateValidityChecker(filepath.Join(FleetWebhookCertDir, "tls.crt"))); err != nil {
+			klog.ErrorS(err, "unable to set up webhook certificate check")
+			exitWithErrorFunc()
+		}
 	}
 
 	ctx := ctrl.SetupSignalHandler()
@@ -186,8 +227,31 @@ func main() {
 	wg.Wait()
 }
 
+// runSchedulerReadOnlyReplica runs the hub agent as a read-only replica, which serves the scheduler
+// explainer only, from its own cache; it blocks until the hub agent exits.
+func runSchedulerReadOnlyReplica(mgr manager.Manager, opts *options.Options) {
+	klog.V(2).InfoS("starting hubagent as a scheduler read-only replica")
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		klog.ErrorS(err, "unable to set up health check")
+		exitWithErrorFunc()
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		klog.ErrorS(err, "unable to set up ready check")
+		exitWithErrorFunc()
+	}
+	if err := workload.SetupSchedulerExplainer(mgr, opts); err != nil {
+		klog.ErrorS(err, "unable to set up the scheduler explainer")
+		exitWithErrorFunc()
+	}
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		klog.ErrorS(err, "problem starting manager")
+		exitWithErrorFunc()
+	}
+	klog.InfoS("The controller manager has exited")
+}
+
 // SetupWebhook generates the webhook cert and then set up the webhook configurator.
-func SetupWebhook(mgr manager.Manager, webhookClientConnectionType options.WebhookClientConnectionType, webhookServiceName string, whiteListedUsers []string, enableGuardRail, isFleetV1Beta1API bool) error {
+func SetupWebhook(mgr manager.Manager, webhookClientConnectionType options.WebhookClientConnectionType, webhookServiceName string, whiteListedUsers []string, enableGuardRail, isFleetV1Beta1API, enableCRPResourceAccessCheck bool, fleetSizeLimits fleetsize.Limits, manifestLimits resource.ManifestLimits) error {
 	// Generate self-signed key and crt files in FleetWebhookCertDir for the webhook server to start.
 	w, err := webhook.NewWebhookConfig(mgr, webhookServiceName, FleetWebhookPort, &webhookClientConnectionType, FleetWebhookCertDir, enableGuardRail)
 	if err != nil {
@@ -198,7 +262,7 @@ func SetupWebhook(mgr manager.Manager, webhookClientConnectionType options.Webho
 		klog.ErrorS(err, "unable to add WebhookConfig")
 		return err
 	}
-	if err = webhook.AddToManager(mgr, whiteListedUsers, isFleetV1Beta1API); err != nil {
+	if err = webhook.AddToManager(mgr, whiteListedUsers, isFleetV1Beta1API, enableCRPResourceAccessCheck, fleetSizeLimits, manifestLimits); err != nil {
 		klog.ErrorS(err, "unable to register webhooks to the manager")
 		return err
 	}
@@ -41,6 +41,21 @@ type Options struct {
 	EnableGuardRail bool
 	// WhiteListedUsers indicates the list of user who are allowed to modify fleet resources
 	WhiteListedUsers string
+	// EnableCRPResourceAccessCheck indicates if the CRP webhook checks that the user who creates or updates
+	// a CRP can read the selected resources on the hub cluster.
+	EnableCRPResourceAccessCheck bool
+	// MaxMemberClusters is the maximum number of member clusters the webhook allows in the fleet; 0 means no limit.
+	MaxMemberClusters int
+	// MaxClusterResourcePlacements is the maximum number of CRPs the webhook allows in the fleet; 0 means no limit.
+	MaxClusterResourcePlacements int
+	// MaxBindingsPerPlacement is the maximum number of clusters the webhook allows a CRP to select; 0 means no limit.
+	MaxBindingsPerPlacement int
+	// MaxWorkManifestSize is the maximum size of a single manifest placed in a work, in bytes; 0 means no limit.
+	MaxWorkManifestSize int
+	// MaxWorkSize is the maximum total size of the manifests in a work, in bytes; 0 means no limit.
+	MaxWorkSize int
+	// MaxManifestsPerWork is the maximum number of manifests in a work; 0 means no limit.
+	MaxManifestsPerWork int
 	// Sets the connection type for the webhook.
 	WebhookClientConnectionType string
 	// NetworkingAgentsEnabled indicates if we enable network agents
@@ -85,6 +100,125 @@ type Options struct {
 	ForceDeleteWaitTime metav1.Duration
 	// EnableStagedUpdateRunAPIs enables the agents to watch the clusterStagedUpdateRun CRs.
 	EnableStagedUpdateRunAPIs bool
+	// EnableClusterGroupAPIs enables the agents to watch the ClusterGroup CRs.
+	EnableClusterGroupAPIs bool
+	// EnableMemberAgentUpgradeAPIs enables the agents to watch the MemberAgentUpgrade CRs.
+	EnableMemberAgentUpgradeAPIs bool
+	// EnablePinnedPlacementAPIs enables the agents to watch the PinnedPlacement CRs.
+	EnablePinnedPlacementAPIs bool
+	// EnableClusterSchedulingDecisionAPIs enables the agents to maintain the ClusterSchedulingDecision CRs.
+	EnableClusterSchedulingDecisionAPIs bool
+	// SchedulerExcludedClusterNames is a list of comma-separated names of clusters that the scheduler
+	// will never consider for any placement.
+	SchedulerExcludedClusterNames string
+	// SchedulerExcludedClusterNamePattern is a regular expression; clusters whose names match the
+	// expression will never be considered by the scheduler for any placement.
+	SchedulerExcludedClusterNamePattern string
+	// SchedulerDecisionCompactionThreshold is the number of clusters considered for a placement above which
+	// the scheduler lists only the selected clusters in the policy snapshot status, and aggregates the
+	// unselected clusters into per-reason counts. Zero disables the compaction.
+	SchedulerDecisionCompactionThreshold int
+	// SchedulerRequiredAgentTypes is a list of comma-separated agent types, in addition to the member agent,
+	// that must have joined and stay healthy in a member cluster for the scheduler to consider the cluster
+	// ready for resource placement.
+	SchedulerRequiredAgentTypes string
+	// SchedulerStrictConsistency makes the scheduler list member clusters directly from the API server in
+	// each scheduling cycle, rather than from the cache; this trades performance for consistency and
+	// is best suited for small fleets.
+	SchedulerStrictConsistency bool
+	// SchedulerBindingWriteQPS is the max number of binding creates, updates, and patches per second that
+	// the scheduler makes across all placements. Zero means no limit.
+	SchedulerBindingWriteQPS float64
+	// SchedulerBindingWriteBurst is the max burst of binding writes above SchedulerBindingWriteQPS.
+	SchedulerBindingWriteBurst int
+	// SchedulerBindingWriteJitter is the max random delay the scheduler waits for before each binding write,
+	// which spreads out the writes of scheduling cycles running at the same time. Zero means no delay.
+	SchedulerBindingWriteJitter metav1.Duration
+	// SchedulerEventDedupWindow is the period in which the scheduler emits the events of the same type and
+	// reason on the same object at most once. Zero disables the deduplication.
+	SchedulerEventDedupWindow metav1.Duration
+	// SchedulerEventQPS is the max number of events per second that the scheduler emits across all placements;
+	// the events over the limit are dropped. Zero means no limit.
+	SchedulerEventQPS float64
+	// SchedulerEventBurst is the max burst of events above SchedulerEventQPS.
+	SchedulerEventBurst int
+	// SchedulerFairShare is the time the scheduling cycles of a placement can take recently before the
+	// scheduler delays the requeues of the placement in favor of other waiting placements. Zero disables
+	// the fair queuing.
+	SchedulerFairShare metav1.Duration
+	// SchedulerExplainerBindAddress is the TCP address on which the hub agent serves the scheduler explainer,
+	// which explains the scheduling decisions of placements and simulates placements without writing to the
+	// hub cluster. The explainer runs on every replica, leader or not. "0" disables the explainer.
+	//
+	// The explainer does not authenticate or authorize its clients; bind it to a loopback address unless
+	// the address is otherwise protected.
+	SchedulerExplainerBindAddress string
+	// SchedulerReadOnlyReplica makes the hub agent run as a read-only replica, which serves the scheduler
+	// explainer only: it takes no part in leader election, and runs no controllers or webhooks.
+	SchedulerReadOnlyReplica bool
+	// RolloutGroupClusterLabel is the key of the member cluster label whose value names the rollout group of
+	// the cluster; if set, the scheduler assigns the bindings to the rollout groups of their target clusters,
+	// and the rollout controller updates the bindings group by group. Empty disables rollout groups.
+	RolloutGroupClusterLabel string
+	// RolloutGroupOrder is a list of comma-separated rollout group names, in the order in which the groups
+	// are rolled out; groups not in the list are rolled out after the listed ones.
+	RolloutGroupOrder string
+	// SchedulerProfileConfigFile is the path to the file, usually mounted from a ConfigMap, with the scheduling
+	// profiles, each of which controls the plugins that the scheduler runs at each extension point; placements
+	// pick a profile by name, or use the first one. Empty means the scheduler uses the default profile only.
+	SchedulerProfileConfigFile string
+	// PolicySnapshotDecisionRetentionPeriod is how long the hub agent keeps the full scheduling decisions
+	// on a policy snapshot after it becomes inactive; the decisions are compacted into a summary afterwards.
+	// Zero disables the compaction.
+	PolicySnapshotDecisionRetentionPeriod metav1.Duration
+	// QueueStarvationThreshold is how long the work queue of a hub agent controller (or the scheduler) can
+	// have items waiting without any item being processed before the hub agent reports itself as unhealthy.
+	// Zero disables the check.
+	QueueStarvationThreshold metav1.Duration
+	// SchedulerDrainTimeout is how long the scheduler waits for the in-flight scheduling cycles to finish
+	// when the hub agent shuts down.
+	SchedulerDrainTimeout metav1.Duration
+	// SchedulerResyncInterval is the interval between two runs of the scheduler resyncer, which re-queues
+	// placements whose bindings still reference a stale policy snapshot, in case the scheduler has missed an event.
+	// Zero disables the resyncer.
+	SchedulerResyncInterval metav1.Duration
+	// SchedulerResyncStaleThreshold is how long the latest policy snapshot of a placement must have been around
+	// before the resyncer re-queues the placement for bindings that still reference an older one.
+	SchedulerResyncStaleThreshold metav1.Duration
+	// DeschedulerInterval is the interval between two runs of the descheduler, which moves placements of the
+	// PickN placement type from the clusters they are bound to, to clusters that score significantly better.
+	// Zero disables the descheduler.
+	DeschedulerInterval metav1.Duration
+	// DeschedulerMinScoreImprovement is how much better, in the sum of the affinity and topology spread scores,
+	// a cluster must score than a cluster that a placement is bound to, for the descheduler to move the placement.
+	DeschedulerMinScoreImprovement int
+	// DeschedulerMaxMovesPerPlacement is the max number of moves that a placement can have in progress, i.e.,
+	// bindings unscheduled by the descheduler whose resources are yet to be removed from their clusters.
+	// It also applies to the moves of placements that opt in to rebalancing on cluster joins.
+	DeschedulerMaxMovesPerPlacement int
+	// DeschedulerMaxMovesPerRun is the max number of moves the descheduler starts in one run, across all placements.
+	DeschedulerMaxMovesPerRun int
+	// PlacementMetricsAllowedCRPNames is a list of comma-separated names of CRPs that always have their
+	// own series in the per-CRP metrics.
+	PlacementMetricsAllowedCRPNames string
+	// PlacementMetricsMaxCRPs is the max number of CRPs, other than the allowed ones, that can have their
+	// own series in the per-CRP metrics. A negative value means no limit.
+	PlacementMetricsMaxCRPs int
+	// FanOutWorkers is the number of workers each rollout controller and work generator reconciler uses to
+	// issue its requests (e.g., updating bindings or works) in parallel. Zero means the default number of workers.
+	FanOutWorkers int
+	// PlacementNotificationURL is the HTTP webhook URL to which the events of all CRPs, e.g., a CRP has become
+	// available, are sent. Empty means no default webhook.
+	PlacementNotificationURL string
+	// EnablePlacementNotificationURLAnnotation indicates if a CRP can send its events to its own webhook, as
+	// specified in its notification URL annotation.
+	EnablePlacementNotificationURLAnnotation bool
+	// PlacementNotificationSigningKeyFile is the path to the file containing the key with which the
+	// notifications are signed (HMAC-SHA256). Empty means the notifications are not signed.
+	PlacementNotificationSigningKeyFile string
+	// PlacementRolloutStallThreshold is how long the rollout of a CRP can stay in progress before it is
+	// notified as stalled. Zero disables the notification.
+	PlacementRolloutStallThreshold metav1.Duration
 }
 
 // NewOptions builds an empty options.
@@ -96,12 +230,16 @@ func NewOptions() *Options {
 			ResourceNamespace: utils.FleetSystemNamespace,
 			ResourceName:      "136224848560.hub.fleet.azure.com",
 		},
-		MaxConcurrentClusterPlacement: 10,
-		ConcurrentResourceChangeSyncs: 1,
-		MaxFleetSizeSupported:         100,
-		EnableV1Alpha1APIs:            false,
-		EnableClusterInventoryAPIs:    false,
-		EnableStagedUpdateRunAPIs:     false,
+		MaxConcurrentClusterPlacement:       10,
+		ConcurrentResourceChangeSyncs:       1,
+		MaxFleetSizeSupported:               100,
+		EnableV1Alpha1APIs:                  false,
+		EnableClusterInventoryAPIs:          false,
+		EnableStagedUpdateRunAPIs:           false,
+		EnableClusterGroupAPIs:              false,
+		EnableMemberAgentUpgradeAPIs:        false,
+		EnablePinnedPlacementAPIs:           false,
+		EnableClusterSchedulingDecisionAPIs: false,
 	}
 }
 
@@ -118,6 +256,13 @@ func (o *Options) AddFlags(flags *flag.FlagSet) {
 	flag.StringVar(&o.WebhookServiceName, "webhook-service-name", "fleetwebhook", "Fleet webhook service name.")
 	flag.BoolVar(&o.EnableGuardRail, "enable-guard-rail", false, "If set, the fleet guard rail webhook configurations are enabled.")
 	flag.StringVar(&o.WhiteListedUsers, "whitelisted-users", "", "If set, white listed users can modify fleet related resources.")
+	flag.BoolVar(&o.EnableCRPResourceAccessCheck, "enable-crp-resource-access-check", false, "If set, the CRP webhook denies a CRP if its creator cannot read the selected resources on the hub cluster.")
+	flag.IntVar(&o.MaxMemberClusters, "max-member-clusters", 0, "The maximum number of member clusters the fleet webhook allows to join the fleet. Set to 0 to disable the limit.")
+	flag.IntVar(&o.MaxClusterResourcePlacements, "max-cluster-resource-placements", 0, "The maximum number of cluster resource placements the fleet webhook allows to create. Set to 0 to disable the limit.")
+	flag.IntVar(&o.MaxBindingsPerPlacement, "max-bindings-per-placement", 0, "The maximum number of clusters the fleet webhook allows a PickN or PickFixed cluster resource placement to select. Set to 0 to disable the limit.")
+	flag.IntVar(&o.MaxWorkManifestSize, "max-work-manifest-size", 0, "The maximum size, in bytes, of a single resource placed to a member cluster; larger resources fail to be placed, and the fleet webhook warns about them when a cluster resource placement is updated. Set to 0 to disable the limit.")
+	flag.IntVar(&o.MaxWorkSize, "max-work-size", 1<<20, "The maximum total size, in bytes, of the resources in a work; the resources that do not fit are split into multiple works. Set to 0 to disable the limit.")
+	flag.IntVar(&o.MaxManifestsPerWork, "max-manifests-per-work", 0, "The maximum number of resources in a work; the resources that do not fit are split into multiple works. Set to 0 to disable the limit.")
 	flag.StringVar(&o.WebhookClientConnectionType, "webhook-client-connection-type", "url", "Sets the connection type used by the webhook client. Only URL or Service is valid.")
 	flag.BoolVar(&o.NetworkingAgentsEnabled, "networking-agents-enabled", false, "Whether the networking agents are enabled or not.")
 	flags.DurationVar(&o.ClusterUnhealthyThreshold.Duration, "cluster-unhealthy-threshold", 60*time.Second, "The duration for a member cluster to be in a degraded state before considered unhealthy.")
@@ -144,6 +289,78 @@ func (o *Options) AddFlags(flags *flag.FlagSet) {
 	flags.BoolVar(&o.EnableClusterInventoryAPIs, "enable-cluster-inventory-apis", false, "If set, the agents will watch for the ClusterInventory APIs.")
 	flags.DurationVar(&o.ForceDeleteWaitTime.Duration, "force-delete-wait-time", 15*time.Minute, "The duration the hub agent waits before force deleting a member cluster.")
 	flags.BoolVar(&o.EnableStagedUpdateRunAPIs, "enable-staged-update-run-apis", false, "If set, the agents will watch for the ClusterStagedUpdateRun APIs.")
+	flags.BoolVar(&o.EnableClusterGroupAPIs, "enable-cluster-group-apis", false, "If set, the agents will watch for the ClusterGroup APIs and keep the group labels on member clusters in sync.")
+	flags.BoolVar(&o.EnableMemberAgentUpgradeAPIs, "enable-member-agent-upgrade-apis", false, "If set, the agents will watch for the MemberAgentUpgrade APIs and roll out member agent versions across the fleet.")
+	flags.BoolVar(&o.EnablePinnedPlacementAPIs, "enable-pinned-placement-apis", false, "If set, the agents will watch for the PinnedPlacement APIs to export, pin, and check the scheduling decisions of placements.")
+	flags.BoolVar(&o.EnableClusterSchedulingDecisionAPIs, "enable-cluster-scheduling-decision-apis", false, "If set, the agents will maintain one ClusterSchedulingDecision object per placement and cluster, which mirrors the latest scheduling decisions of placements, for consumers to watch.")
+	flags.StringVar(&o.SchedulerExcludedClusterNames, "scheduler-excluded-cluster-names", "",
+		"Comma-separated names of member clusters that the scheduler will never consider for any placement, regardless of the scheduling policies in use.")
+	flags.StringVar(&o.SchedulerExcludedClusterNamePattern, "scheduler-excluded-cluster-name-pattern", "",
+		"A regular expression (RE2 syntax); member clusters whose names match the expression will never be considered by the scheduler for any placement, regardless of the scheduling policies in use.")
+	flags.IntVar(&o.SchedulerDecisionCompactionThreshold, "scheduler-decision-compaction-threshold", 0,
+		"The number of member clusters considered for a placement above which the scheduler lists only the selected clusters in the policy snapshot status, and aggregates the unselected clusters into per-reason counts. Set to 0 to disable the compaction.")
+	flags.StringVar(&o.SchedulerRequiredAgentTypes, "scheduler-required-agent-types", "",
+		"Comma-separated agent types, e.g., ServiceExportImportAgent, that must have joined and stay healthy in a member cluster, in addition to the member agent, for the scheduler to consider the cluster ready for resource placement.")
+	flags.BoolVar(&o.SchedulerStrictConsistency, "scheduler-strict-consistency", false,
+		"If set, the scheduler lists member clusters directly from the API server in each scheduling cycle rather than from the cache, trading performance for consistency; recommended for small fleets only.")
+	flags.Float64Var(&o.SchedulerBindingWriteQPS, "scheduler-binding-write-qps", 0,
+		"The max number of binding creates, updates, and patches per second that the scheduler makes across all placements. Set to 0 for no limit.")
+	flags.IntVar(&o.SchedulerBindingWriteBurst, "scheduler-binding-write-burst", 100,
+		"The max burst of binding writes the scheduler makes above --scheduler-binding-write-qps. Only in effect when --scheduler-binding-write-qps is set.")
+	flags.DurationVar(&o.SchedulerBindingWriteJitter.Duration, "scheduler-binding-write-jitter", 0,
+		"The max random delay the scheduler waits for before each binding write, which spreads out the writes of scheduling cycles running at the same time. Set to 0 for no delay.")
+	flags.DurationVar(&o.SchedulerEventDedupWindow.Duration, "scheduler-event-dedup-window", time.Minute,
+		"The period in which the scheduler emits the events of the same type and reason on the same object at most once; the next event reports the number of events suppressed in between. Set to 0 to disable the deduplication.")
+	flags.Float64Var(&o.SchedulerEventQPS, "scheduler-event-qps", 0,
+		"The max number of events per second that the scheduler emits across all placements; the events over the limit are dropped. Set to 0 for no limit.")
+	flags.IntVar(&o.SchedulerEventBurst, "scheduler-event-burst", 25,
+		"The max burst of events the scheduler emits above --scheduler-event-qps. Only in effect when --scheduler-event-qps is set.")
+	flags.DurationVar(&o.SchedulerFairShare.Duration, "scheduler-fair-share", 5*time.Second,
+		"The time the scheduling cycles of a placement can take recently before the scheduler delays the requeues of the placement in favor of other waiting placements, so that a very large or hot-looping placement does not hold up the others. Set to 0 to disable the fair queuing.")
+	flags.StringVar(&o.SchedulerExplainerBindAddress, "scheduler-explainer-bind-address", "0",
+		"The TCP address on which to serve the scheduler explainer, which explains the scheduling decisions of placements and simulates placements without writing to the hub cluster (e.g., 127.0.0.1:8090). The explainer does not authenticate or authorize its clients. Set to 0 to disable the explainer.")
+	flags.BoolVar(&o.SchedulerReadOnlyReplica, "scheduler-read-only-replica", false,
+		"If set, the hub agent runs as a read-only replica, which serves the scheduler explainer only, without leader election, controllers, or webhooks. Requires --scheduler-explainer-bind-address.")
+	flags.StringVar(&o.RolloutGroupClusterLabel, "rollout-group-cluster-label", "",
+		"The key of the member cluster label whose value names the rollout group of the cluster, e.g., canary. If set, the resources of a placement are rolled out to the clusters group by group. Leave empty to disable rollout groups.")
+	flags.StringVar(&o.RolloutGroupOrder, "rollout-group-order", "",
+		"Comma-separated rollout group names, e.g., canary,batch-1,batch-2, in the order in which the groups are rolled out; groups not in the list are rolled out after the listed ones. Only in effect when --rollout-group-cluster-label is set.")
+	flags.StringVar(&o.SchedulerProfileConfigFile, "scheduler-profile-config-file", "",
+		"The path to the scheduler configuration file, in YAML or JSON, with the scheduling profiles, each of which controls the plugins that the scheduler runs at each extension point, their order, weights, and args. "+
+			"A placement picks a profile by name with the kubernetes-fleet.io/scheduler-profile annotation, or uses the first profile. Leave empty to use the default profile only. Cannot be used along with --rollout-group-cluster-label; configure the RolloutGroup plugin in the file instead.")
+	flags.DurationVar(&o.PolicySnapshotDecisionRetentionPeriod.Duration, "policy-snapshot-decision-retention-period", 0,
+		"The duration the hub agent keeps the full scheduling decisions on an inactive policy snapshot before compacting them into a summary. Set to 0 to disable the compaction.")
+	flags.DurationVar(&o.QueueStarvationThreshold.Duration, "queue-starvation-threshold", 10*time.Minute,
+		"The duration the work queue of a controller (or the scheduler) can have items waiting without any item being processed before the hub agent reports itself as unhealthy. Set to 0 to disable the check.")
+	flags.DurationVar(&o.SchedulerDrainTimeout.Duration, "scheduler-drain-timeout", 20*time.Second,
+		"The duration the scheduler waits for the in-flight scheduling cycles to finish when the hub agent shuts down. It should be shorter than the termination grace period of the hub agent pod.")
+	flags.DurationVar(&o.SchedulerResyncInterval.Duration, "scheduler-resync-interval", 5*time.Minute,
+		"The interval between two runs of the scheduler resyncer, which re-queues placements whose bindings still reference a stale policy snapshot, as a safeguard against missed events. Set to 0 to disable the resyncer.")
+	flags.DurationVar(&o.SchedulerResyncStaleThreshold.Duration, "scheduler-resync-stale-threshold", 10*time.Minute,
+		"The duration the latest policy snapshot of a placement must have been around before the scheduler resyncer re-queues the placement for bindings that still reference an older one. Only in effect when --scheduler-resync-interval is set.")
+	flags.DurationVar(&o.DeschedulerInterval.Duration, "descheduler-interval", 0,
+		"The interval between two runs of the descheduler, which moves placements of the PickN placement type from the clusters they are bound to, to clusters that score significantly better. Set to 0 to disable the descheduler.")
+	flags.IntVar(&o.DeschedulerMinScoreImprovement, "descheduler-min-score-improvement", 20,
+		"How much better, in the sum of the affinity and topology spread scores, a cluster must score than a cluster that a placement is bound to, for the descheduler to move the placement. Only in effect when --descheduler-interval is set.")
+	flags.IntVar(&o.DeschedulerMaxMovesPerPlacement, "descheduler-max-moves-per-placement", 1,
+		"The max number of moves that a placement can have in progress, i.e., bindings unscheduled by the descheduler whose resources are yet to be removed. Also applies to placements that opt in to rebalancing on cluster joins.")
+	flags.IntVar(&o.DeschedulerMaxMovesPerRun, "descheduler-max-moves-per-run", 10,
+		"The max number of moves the descheduler starts in one run, across all placements. Only in effect when --descheduler-interval is set.")
+	flags.StringVar(&o.PlacementMetricsAllowedCRPNames, "placement-metrics-allowed-crp-names", "",
+		"Comma-separated names of cluster resource placements that always have their own series in the per-placement metrics, regardless of --placement-metrics-max-crps.")
+	flags.IntVar(&o.PlacementMetricsMaxCRPs, "placement-metrics-max-crps", -1,
+		"The max number of cluster resource placements, other than the ones in --placement-metrics-allowed-crp-names, that can have their own series in the per-placement metrics; "+
+			"the other placements are aggregated (or omitted, for gauges). Set to 0 to only report the allowed placements, or a negative value for no limit.")
+	flags.IntVar(&o.FanOutWorkers, "fan-out-workers", 16,
+		"The number of workers each rollout controller and work generator reconciler uses to issue its requests, e.g., updating bindings or works, in parallel. Set to 0 to use the default number of workers.")
+	flags.StringVar(&o.PlacementNotificationURL, "placement-notification-url", "",
+		"The HTTP webhook URL to which the events of all cluster resource placements are sent, e.g., a placement has become available on all the selected clusters, its rollout has stalled, or it has failed on a cluster.")
+	flags.BoolVar(&o.EnablePlacementNotificationURLAnnotation, "enable-placement-notification-url-annotation", false,
+		"If set, a cluster resource placement can send its events to its own HTTP webhook, as specified in the kubernetes-fleet.io/notification-url annotation, instead of --placement-notification-url.")
+	flags.StringVar(&o.PlacementNotificationSigningKeyFile, "placement-notification-signing-key-file", "",
+		"The path to the file containing the key with which the placement notifications are signed (HMAC-SHA256). If not set, the notifications are not signed.")
+	flags.DurationVar(&o.PlacementRolloutStallThreshold.Duration, "placement-rollout-stall-threshold", 30*time.Minute,
+		"How long the rollout of a cluster resource placement can stay in progress before it is notified as stalled. Set to 0 to disable the notification.")
 
 	o.RateLimiterOpts.AddFlags(flags)
 }
@@ -6,8 +6,15 @@ Licensed under the MIT license.
 package options
 
 import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	"go.goms.io/fleet/pkg/utils"
 )
 
@@ -46,9 +53,149 @@ func (o *Options) Validate() field.ErrorList {
 		errs = append(errs, field.Invalid(newPath.Child("WebhookClientConnectionType"), o.WebhookClientConnectionType, err.Error()))
 	}
 
+	if o.MaxMemberClusters < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("MaxMemberClusters"), o.MaxMemberClusters, "Must be greater than or equal to 0"))
+	}
+
+	if o.MaxClusterResourcePlacements < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("MaxClusterResourcePlacements"), o.MaxClusterResourcePlacements, "Must be greater than or equal to 0"))
+	}
+
+	if o.MaxBindingsPerPlacement < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("MaxBindingsPerPlacement"), o.MaxBindingsPerPlacement, "Must be greater than or equal to 0"))
+	}
+
+	if o.MaxWorkManifestSize < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("MaxWorkManifestSize"), o.MaxWorkManifestSize, "Must be greater than or equal to 0"))
+	}
+
+	if o.MaxWorkSize < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("MaxWorkSize"), o.MaxWorkSize, "Must be greater than or equal to 0"))
+	}
+
+	if o.MaxManifestsPerWork < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("MaxManifestsPerWork"), o.MaxManifestsPerWork, "Must be greater than or equal to 0"))
+	}
+
 	if !o.EnableV1Alpha1APIs && !o.EnableV1Beta1APIs {
 		errs = append(errs, field.Required(newPath.Child("EnableV1Alpha1APIs"), "Either EnableV1Alpha1APIs or EnableV1Beta1APIs is required"))
 	}
 
+	if _, err := regexp.Compile(o.SchedulerExcludedClusterNamePattern); err != nil {
+		errs = append(errs, field.Invalid(newPath.Child("SchedulerExcludedClusterNamePattern"), o.SchedulerExcludedClusterNamePattern, "Invalid regular expression"))
+	}
+
+	if o.PolicySnapshotDecisionRetentionPeriod.Duration < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("PolicySnapshotDecisionRetentionPeriod"), o.PolicySnapshotDecisionRetentionPeriod, "Must be greater than or equal to 0"))
+	}
+
+	for _, agentType := range strings.Split(o.SchedulerRequiredAgentTypes, ",") {
+		switch clusterv1beta1.AgentType(strings.TrimSpace(agentType)) {
+		case "", clusterv1beta1.MemberAgent, clusterv1beta1.MultiClusterServiceAgent, clusterv1beta1.ServiceExportImportAgent:
+		default:
+			errs = append(errs, field.Invalid(newPath.Child("SchedulerRequiredAgentTypes"), o.SchedulerRequiredAgentTypes, fmt.Sprintf("Unknown agent type %q", agentType)))
+		}
+	}
+
+	if o.RolloutGroupClusterLabel != "" {
+		for _, msg := range validation.IsQualifiedName(o.RolloutGroupClusterLabel) {
+			errs = append(errs, field.Invalid(newPath.Child("RolloutGroupClusterLabel"), o.RolloutGroupClusterLabel, msg))
+		}
+		if o.SchedulerProfileConfigFile != "" {
+			errs = append(errs, field.Invalid(newPath.Child("RolloutGroupClusterLabel"), o.RolloutGroupClusterLabel, "Must not be set along with SchedulerProfileConfigFile; configure the RolloutGroup plugin in the scheduling profile configuration instead"))
+		}
+	}
+
+	if o.SchedulerDecisionCompactionThreshold < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("SchedulerDecisionCompactionThreshold"), o.SchedulerDecisionCompactionThreshold, "Must be greater than or equal to 0"))
+	}
+
+	if o.SchedulerBindingWriteQPS < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("SchedulerBindingWriteQPS"), o.SchedulerBindingWriteQPS, "Must be greater than or equal to 0"))
+	}
+
+	if o.SchedulerBindingWriteQPS > 0 && o.SchedulerBindingWriteBurst <= 0 {
+		errs = append(errs, field.Invalid(newPath.Child("SchedulerBindingWriteBurst"), o.SchedulerBindingWriteBurst, "Must be greater than 0 when SchedulerBindingWriteQPS is set"))
+	}
+
+	if o.SchedulerBindingWriteJitter.Duration < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("SchedulerBindingWriteJitter"), o.SchedulerBindingWriteJitter, "Must be greater than or equal to 0"))
+	}
+
+	if o.SchedulerEventDedupWindow.Duration < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("SchedulerEventDedupWindow"), o.SchedulerEventDedupWindow, "Must be greater than or equal to 0"))
+	}
+
+	if o.SchedulerFairShare.Duration < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("SchedulerFairShare"), o.SchedulerFairShare, "Must be greater than or equal to 0"))
+	}
+
+	if o.SchedulerEventQPS < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("SchedulerEventQPS"), o.SchedulerEventQPS, "Must be greater than or equal to 0"))
+	}
+
+	if o.SchedulerEventQPS > 0 && o.SchedulerEventBurst <= 0 {
+		errs = append(errs, field.Invalid(newPath.Child("SchedulerEventBurst"), o.SchedulerEventBurst, "Must be greater than 0 when SchedulerEventQPS is set"))
+	}
+
+	if o.PlacementNotificationURL != "" {
+		if u, err := url.Parse(o.PlacementNotificationURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, field.Invalid(newPath.Child("PlacementNotificationURL"), o.PlacementNotificationURL, "Must be an absolute HTTP or HTTPS URL"))
+		}
+	}
+
+	if o.PlacementRolloutStallThreshold.Duration < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("PlacementRolloutStallThreshold"), o.PlacementRolloutStallThreshold, "Must be greater than or equal to 0"))
+	}
+
+	if o.QueueStarvationThreshold.Duration < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("QueueStarvationThreshold"), o.QueueStarvationThreshold, "Must be greater than or equal to 0"))
+	}
+
+	if o.SchedulerReadOnlyReplica {
+		if o.SchedulerExplainerBindAddress == "" || o.SchedulerExplainerBindAddress == "0" {
+			errs = append(errs, field.Invalid(newPath.Child("SchedulerExplainerBindAddress"), o.SchedulerExplainerBindAddress, "Must be set when SchedulerReadOnlyReplica is set"))
+		}
+		if !o.EnableV1Beta1APIs {
+			errs = append(errs, field.Invalid(newPath.Child("SchedulerReadOnlyReplica"), o.SchedulerReadOnlyReplica, "Requires EnableV1Beta1APIs"))
+		}
+	}
+
+	if o.SchedulerDrainTimeout.Duration < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("SchedulerDrainTimeout"), o.SchedulerDrainTimeout, "Must be greater than or equal to 0"))
+	}
+
+	if o.SchedulerResyncInterval.Duration < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("SchedulerResyncInterval"), o.SchedulerResyncInterval, "Must be greater than or equal to 0"))
+	}
+
+	if o.SchedulerResyncInterval.Duration > 0 && o.SchedulerResyncStaleThreshold.Duration <= 0 {
+		errs = append(errs, field.Invalid(newPath.Child("SchedulerResyncStaleThreshold"), o.SchedulerResyncStaleThreshold, "Must be greater than 0 when SchedulerResyncInterval is set"))
+	}
+
+	if o.DeschedulerInterval.Duration < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("DeschedulerInterval"), o.DeschedulerInterval, "Must be greater than or equal to 0"))
+	}
+
+	if o.DeschedulerInterval.Duration > 0 {
+		if o.DeschedulerMinScoreImprovement <= 0 {
+			errs = append(errs, field.Invalid(newPath.Child("DeschedulerMinScoreImprovement"), o.DeschedulerMinScoreImprovement, "Must be greater than 0 when DeschedulerInterval is set"))
+		}
+		if o.DeschedulerMaxMovesPerPlacement <= 0 {
+			errs = append(errs, field.Invalid(newPath.Child("DeschedulerMaxMovesPerPlacement"), o.DeschedulerMaxMovesPerPlacement, "Must be greater than 0 when DeschedulerInterval is set"))
+		}
+		if o.DeschedulerMaxMovesPerRun <= 0 {
+			errs = append(errs, field.Invalid(newPath.Child("DeschedulerMaxMovesPerRun"), o.DeschedulerMaxMovesPerRun, "Must be greater than 0 when DeschedulerInterval is set"))
+		}
+	}
+
+	if o.PlacementMetricsMaxCRPs < -1 {
+		errs = append(errs, field.Invalid(newPath.Child("PlacementMetricsMaxCRPs"), o.PlacementMetricsMaxCRPs, "Must be greater than or equal to -1"))
+	}
+
+	if o.FanOutWorkers < 0 {
+		errs = append(errs, field.Invalid(newPath.Child("FanOutWorkers"), o.FanOutWorkers, "Must be greater than or equal to 0"))
+	}
+
 	return errs
 }
@@ -80,6 +80,182 @@ func TestValidateControllerManagerConfiguration(t *testing.T) {
 			}),
 			want: field.ErrorList{field.Invalid(newPath.Child("WebhookServiceName"), "", "Webhook service name is required when webhook is enabled")},
 		},
+		"invalid MaxMemberClusters": {
+			opt: newTestOptions(func(option *Options) {
+				option.MaxMemberClusters = -1
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("MaxMemberClusters"), -1, "Must be greater than or equal to 0")},
+		},
+		"invalid MaxBindingsPerPlacement": {
+			opt: newTestOptions(func(option *Options) {
+				option.MaxBindingsPerPlacement = -1
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("MaxBindingsPerPlacement"), -1, "Must be greater than or equal to 0")},
+		},
+		"invalid MaxWorkManifestSize": {
+			opt: newTestOptions(func(option *Options) {
+				option.MaxWorkManifestSize = -1
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("MaxWorkManifestSize"), -1, "Must be greater than or equal to 0")},
+		},
+		"invalid MaxWorkSize": {
+			opt: newTestOptions(func(option *Options) {
+				option.MaxWorkSize = -1
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("MaxWorkSize"), -1, "Must be greater than or equal to 0")},
+		},
+		"invalid MaxManifestsPerWork": {
+			opt: newTestOptions(func(option *Options) {
+				option.MaxManifestsPerWork = -1
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("MaxManifestsPerWork"), -1, "Must be greater than or equal to 0")},
+		},
+		"invalid SchedulerExcludedClusterNamePattern": {
+			opt: newTestOptions(func(option *Options) {
+				option.SchedulerExcludedClusterNamePattern = "test-("
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("SchedulerExcludedClusterNamePattern"), "test-(", "Invalid regular expression")},
+		},
+		"invalid PolicySnapshotDecisionRetentionPeriod": {
+			opt: newTestOptions(func(option *Options) {
+				option.PolicySnapshotDecisionRetentionPeriod.Duration = -1 * time.Hour
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("PolicySnapshotDecisionRetentionPeriod"), metav1.Duration{Duration: -1 * time.Hour}, "Must be greater than or equal to 0")},
+		},
+		"invalid QueueStarvationThreshold": {
+			opt: newTestOptions(func(option *Options) {
+				option.QueueStarvationThreshold.Duration = -1 * time.Minute
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("QueueStarvationThreshold"), metav1.Duration{Duration: -1 * time.Minute}, "Must be greater than or equal to 0")},
+		},
+		"invalid SchedulerRequiredAgentTypes": {
+			opt: newTestOptions(func(option *Options) {
+				option.SchedulerRequiredAgentTypes = "ServiceExportImportAgent,NetworkingAgent"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("SchedulerRequiredAgentTypes"), "ServiceExportImportAgent,NetworkingAgent", `Unknown agent type "NetworkingAgent"`)},
+		},
+		"invalid RolloutGroupClusterLabel": {
+			opt: newTestOptions(func(option *Options) {
+				option.RolloutGroupClusterLabel = "rollout group"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("RolloutGroupClusterLabel"), "rollout group", "name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')")},
+		},
+		"RolloutGroupClusterLabel set along with SchedulerProfileConfigFile": {
+			opt: newTestOptions(func(option *Options) {
+				option.RolloutGroupClusterLabel = "rollout-group"
+				option.SchedulerProfileConfigFile = "/etc/fleet/scheduler/profile.yaml"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("RolloutGroupClusterLabel"), "rollout-group", "Must not be set along with SchedulerProfileConfigFile; configure the RolloutGroup plugin in the scheduling profile configuration instead")},
+		},
+		"invalid SchedulerDecisionCompactionThreshold": {
+			opt: newTestOptions(func(option *Options) {
+				option.SchedulerDecisionCompactionThreshold = -1
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("SchedulerDecisionCompactionThreshold"), -1, "Must be greater than or equal to 0")},
+		},
+		"invalid SchedulerBindingWriteQPS": {
+			opt: newTestOptions(func(option *Options) {
+				option.SchedulerBindingWriteQPS = -1
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("SchedulerBindingWriteQPS"), float64(-1), "Must be greater than or equal to 0")},
+		},
+		"invalid SchedulerBindingWriteBurst": {
+			opt: newTestOptions(func(option *Options) {
+				option.SchedulerBindingWriteQPS = 50
+				option.SchedulerBindingWriteBurst = 0
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("SchedulerBindingWriteBurst"), 0, "Must be greater than 0 when SchedulerBindingWriteQPS is set")},
+		},
+		"invalid SchedulerBindingWriteJitter": {
+			opt: newTestOptions(func(option *Options) {
+				option.SchedulerBindingWriteJitter.Duration = -1 * time.Second
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("SchedulerBindingWriteJitter"), metav1.Duration{Duration: -1 * time.Second}, "Must be greater than or equal to 0")},
+		},
+		"invalid SchedulerEventDedupWindow": {
+			opt: newTestOptions(func(option *Options) {
+				option.SchedulerEventDedupWindow.Duration = -1 * time.Second
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("SchedulerEventDedupWindow"), metav1.Duration{Duration: -1 * time.Second}, "Must be greater than or equal to 0")},
+		},
+		"invalid SchedulerFairShare": {
+			opt: newTestOptions(func(option *Options) {
+				option.SchedulerFairShare.Duration = -1 * time.Second
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("SchedulerFairShare"), metav1.Duration{Duration: -1 * time.Second}, "Must be greater than or equal to 0")},
+		},
+		"invalid SchedulerEventBurst": {
+			opt: newTestOptions(func(option *Options) {
+				option.SchedulerEventQPS = 10
+				option.SchedulerEventBurst = 0
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("SchedulerEventBurst"), 0, "Must be greater than 0 when SchedulerEventQPS is set")},
+		},
+		"SchedulerReadOnlyReplica without an explainer": {
+			opt: newTestOptions(func(option *Options) {
+				option.SchedulerReadOnlyReplica = true
+				option.SchedulerExplainerBindAddress = "0"
+				option.EnableV1Beta1APIs = true
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("SchedulerExplainerBindAddress"), "0", "Must be set when SchedulerReadOnlyReplica is set")},
+		},
+		"SchedulerReadOnlyReplica without v1beta1 APIs": {
+			opt: newTestOptions(func(option *Options) {
+				option.SchedulerReadOnlyReplica = true
+				option.SchedulerExplainerBindAddress = ":8090"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("SchedulerReadOnlyReplica"), true, "Requires EnableV1Beta1APIs")},
+		},
+		"invalid SchedulerDrainTimeout": {
+			opt: newTestOptions(func(option *Options) {
+				option.SchedulerDrainTimeout.Duration = -1 * time.Second
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("SchedulerDrainTimeout"), metav1.Duration{Duration: -1 * time.Second}, "Must be greater than or equal to 0")},
+		},
+		"invalid SchedulerResyncStaleThreshold": {
+			opt: newTestOptions(func(option *Options) {
+				option.SchedulerResyncInterval.Duration = time.Minute
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("SchedulerResyncStaleThreshold"), metav1.Duration{}, "Must be greater than 0 when SchedulerResyncInterval is set")},
+		},
+		"invalid DeschedulerInterval": {
+			opt: newTestOptions(func(option *Options) {
+				option.DeschedulerInterval.Duration = -1 * time.Second
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("DeschedulerInterval"), metav1.Duration{Duration: -1 * time.Second}, "Must be greater than or equal to 0")},
+		},
+		"invalid descheduler disruption budget": {
+			opt: newTestOptions(func(option *Options) {
+				option.DeschedulerInterval.Duration = time.Minute
+				option.DeschedulerMinScoreImprovement = 20
+				option.DeschedulerMaxMovesPerRun = 10
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("DeschedulerMaxMovesPerPlacement"), 0, "Must be greater than 0 when DeschedulerInterval is set")},
+		},
+		"invalid PlacementMetricsMaxCRPs": {
+			opt: newTestOptions(func(option *Options) {
+				option.PlacementMetricsMaxCRPs = -2
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("PlacementMetricsMaxCRPs"), -2, "Must be greater than or equal to -1")},
+		},
+		"invalid FanOutWorkers": {
+			opt: newTestOptions(func(option *Options) {
+				option.FanOutWorkers = -1
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("FanOutWorkers"), -1, "Must be greater than or equal to 0")},
+		},
+		"invalid PlacementNotificationURL": {
+			opt: newTestOptions(func(option *Options) {
+				option.PlacementNotificationURL = "hooks.example.com/fleet"
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("PlacementNotificationURL"), "hooks.example.com/fleet", "Must be an absolute HTTP or HTTPS URL")},
+		},
+		"invalid PlacementRolloutStallThreshold": {
+			opt: newTestOptions(func(option *Options) {
+				option.PlacementRolloutStallThreshold.Duration = -1 * time.Minute
+			}),
+			want: field.ErrorList{field.Invalid(newPath.Child("PlacementRolloutStallThreshold"), metav1.Duration{Duration: -1 * time.Minute}, "Must be greater than or equal to 0")},
+		},
 	}
 
 	for name, tc := range testCases {
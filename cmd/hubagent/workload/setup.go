@@ -6,10 +6,16 @@ Licensed under the MIT license.
 package workload
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"math"
+	"os"
+	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
@@ -25,32 +31,48 @@ import (
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	fleetv1alpha1 "go.goms.io/fleet/apis/v1alpha1"
 	"go.goms.io/fleet/cmd/hubagent/options"
+	"go.goms.io/fleet/pkg/controllers/clustergroup"
 	"go.goms.io/fleet/pkg/controllers/clusterinventory/clusterprofile"
 	"go.goms.io/fleet/pkg/controllers/clusterresourcebindingwatcher"
 	"go.goms.io/fleet/pkg/controllers/clusterresourceplacement"
 	"go.goms.io/fleet/pkg/controllers/clusterresourceplacementeviction"
 	"go.goms.io/fleet/pkg/controllers/clusterresourceplacementwatcher"
+	"go.goms.io/fleet/pkg/controllers/clusterschedulingdecision"
 	"go.goms.io/fleet/pkg/controllers/clusterschedulingpolicysnapshot"
+	"go.goms.io/fleet/pkg/controllers/memberagentupgrade"
 	"go.goms.io/fleet/pkg/controllers/memberclusterplacement"
 	"go.goms.io/fleet/pkg/controllers/overrider"
+	"go.goms.io/fleet/pkg/controllers/pinnedplacement"
 	"go.goms.io/fleet/pkg/controllers/resourcechange"
 	"go.goms.io/fleet/pkg/controllers/rollout"
 	"go.goms.io/fleet/pkg/controllers/updaterun"
 	"go.goms.io/fleet/pkg/controllers/workgenerator"
+	"go.goms.io/fleet/pkg/metrics"
+	"go.goms.io/fleet/pkg/notifier"
 	"go.goms.io/fleet/pkg/resourcewatcher"
 	"go.goms.io/fleet/pkg/scheduler"
 	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/descheduler"
+	"go.goms.io/fleet/pkg/scheduler/explainer"
 	"go.goms.io/fleet/pkg/scheduler/framework"
+	pinnedplacementplugin "go.goms.io/fleet/pkg/scheduler/framework/plugins/pinnedplacement"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/rolloutgroup"
 	"go.goms.io/fleet/pkg/scheduler/profile"
 	"go.goms.io/fleet/pkg/scheduler/queue"
+	"go.goms.io/fleet/pkg/scheduler/resyncer"
 	schedulercrbwatcher "go.goms.io/fleet/pkg/scheduler/watchers/clusterresourcebinding"
 	schedulercrpwatcher "go.goms.io/fleet/pkg/scheduler/watchers/clusterresourceplacement"
 	schedulercspswatcher "go.goms.io/fleet/pkg/scheduler/watchers/clusterschedulingpolicysnapshot"
 	"go.goms.io/fleet/pkg/scheduler/watchers/membercluster"
+	schedulerpinnedplacementwatcher "go.goms.io/fleet/pkg/scheduler/watchers/pinnedplacement"
 	"go.goms.io/fleet/pkg/utils"
 	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/healthcheck"
 	"go.goms.io/fleet/pkg/utils/informer"
+	"go.goms.io/fleet/pkg/utils/parallelizer"
+	"go.goms.io/fleet/pkg/utils/resource"
 	"go.goms.io/fleet/pkg/utils/validator"
+	"go.goms.io/fleet/pkg/version"
 )
 
 const (
@@ -62,6 +84,12 @@ const (
 	mcPlacementControllerName    = "memberCluster-placement-controller"
 
 	schedulerQueueName = "scheduler-queue"
+
+	// informerSyncCheckTimeout is how long the informer sync health check waits for the informers to sync.
+	informerSyncCheckTimeout = 5 * time.Second
+	// leaderStartGracePeriod is how long the leader-only controllers can take to start after the hub agent
+	// is elected as the leader before the hub agent reports itself as unhealthy.
+	leaderStartGracePeriod = 2 * time.Minute
 )
 
 var (
@@ -92,6 +120,22 @@ var (
 		placementv1alpha1.GroupVersion.WithKind(placementv1alpha1.ClusterApprovalRequestKind),
 	}
 
+	clusterGroupGVKs = []schema.GroupVersionKind{
+		clusterv1beta1.GroupVersion.WithKind(clusterv1beta1.ClusterGroupKind),
+	}
+
+	pinnedPlacementGVKs = []schema.GroupVersionKind{
+		placementv1alpha1.GroupVersion.WithKind(placementv1alpha1.PinnedPlacementKind),
+	}
+
+	clusterSchedulingDecisionGVKs = []schema.GroupVersionKind{
+		placementv1alpha1.GroupVersion.WithKind(placementv1alpha1.ClusterSchedulingDecisionKind),
+	}
+
+	memberAgentUpgradeGVKs = []schema.GroupVersionKind{
+		clusterv1beta1.GroupVersion.WithKind(clusterv1beta1.MemberAgentUpgradeKind),
+	}
+
 	clusterInventoryGVKs = []schema.GroupVersionKind{
 		clusterinventory.GroupVersion.WithKind("ClusterProfile"),
 	}
@@ -136,18 +180,49 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 	validator.RestMapper = mgr.GetRESTMapper()          // webhook needs this to validate GVK of resource selector
 
 	// Set up  a custom controller to reconcile cluster resource placement
+	var placementMetricsAllowedCRPNames []string
+	for _, name := range strings.Split(opts.PlacementMetricsAllowedCRPNames, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			placementMetricsAllowedCRPNames = append(placementMetricsAllowedCRPNames, name)
+		}
+	}
+	var placementNotifier *notifier.Notifier
+	if opts.PlacementNotificationURL != "" || opts.EnablePlacementNotificationURLAnnotation {
+		var notifierOpts []notifier.Option
+		if opts.EnablePlacementNotificationURLAnnotation {
+			notifierOpts = append(notifierOpts, notifier.WithURLAnnotation())
+		}
+		if opts.PlacementNotificationSigningKeyFile != "" {
+			signingKey, err := os.ReadFile(opts.PlacementNotificationSigningKeyFile)
+			if err != nil {
+				klog.ErrorS(err, "Unable to read the placement notification signing key", "file", opts.PlacementNotificationSigningKeyFile)
+				return err
+			}
+			notifierOpts = append(notifierOpts, notifier.WithSigningKey(bytes.TrimSpace(signingKey)))
+		}
+		klog.Info("Setting up the placement notifier")
+		placementNotifier = notifier.New(opts.PlacementNotificationURL, notifierOpts...)
+		if err := mgr.Add(placementNotifier); err != nil {
+			klog.ErrorS(err, "Unable to set up the placement notifier")
+			return err
+		}
+	}
 	crpc := &clusterresourceplacement.Reconciler{
-		Client:            mgr.GetClient(),
-		Recorder:          mgr.GetEventRecorderFor(crpControllerName),
-		RestMapper:        mgr.GetRESTMapper(),
-		InformerManager:   dynamicInformerManager,
-		ResourceConfig:    resourceConfig,
-		SkippedNamespaces: skippedNamespaces,
-		Scheme:            mgr.GetScheme(),
-		UncachedReader:    mgr.GetAPIReader(),
+		Client:                  mgr.GetClient(),
+		Recorder:                mgr.GetEventRecorderFor(crpControllerName),
+		RestMapper:              mgr.GetRESTMapper(),
+		InformerManager:         dynamicInformerManager,
+		ResourceConfig:          resourceConfig,
+		SkippedNamespaces:       skippedNamespaces,
+		Scheme:                  mgr.GetScheme(),
+		UncachedReader:          mgr.GetAPIReader(),
+		PlacementMetricsLabeler: metrics.NewPlacementLabeler(placementMetricsAllowedCRPNames, opts.PlacementMetricsMaxCRPs),
+		Notifier:                placementNotifier,
+		RolloutStallThreshold:   opts.PlacementRolloutStallThreshold.Duration,
 	}
 
 	rateLimiter := options.DefaultControllerRateLimiter(opts.RateLimiterOpts)
+	var defaultScheduler *scheduler.Scheduler
 	var clusterResourcePlacementControllerV1Alpha1 controller.Controller
 	var clusterResourcePlacementControllerV1Beta1 controller.Controller
 	var memberClusterPlacementController controller.Controller
@@ -175,6 +250,18 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 				return err
 			}
 		}
+		// The bindings of a placement are looked up from the cache by the index in the controllers and the scheduler.
+		klog.Info("Setting up the clusterResourceBinding index by placement")
+		if err := controller.SetupBindingCRPTrackingIndex(ctx, mgr.GetFieldIndexer()); err != nil {
+			klog.ErrorS(err, "Unable to set up the clusterResourceBinding index by placement")
+			return err
+		}
+		// The scheduler and the descheduler look up the bindings on a leaving or drained member cluster from the cache by the index.
+		klog.Info("Setting up the clusterResourceBinding index by target cluster")
+		if err := controller.SetupBindingTargetClusterIndex(ctx, mgr.GetFieldIndexer()); err != nil {
+			klog.ErrorS(err, "Unable to set up the clusterResourceBinding index by target cluster")
+			return err
+		}
 		klog.Info("Setting up clusterResourcePlacement v1beta1 controller")
 		clusterResourcePlacementControllerV1Beta1 = controller.NewController(crpControllerV1Beta1Name, controller.NamespaceKeyFunc, crpc.Reconcile, rateLimiter)
 		klog.Info("Setting up clusterResourcePlacement watcher")
@@ -203,6 +290,21 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 			return err
 		}
 
+		if opts.PolicySnapshotDecisionRetentionPeriod.Duration > 0 {
+			klog.Info("Setting up clusterSchedulingPolicySnapshot compactor")
+			if err := (&clusterschedulingpolicysnapshot.CompactionReconciler{
+				Client:          mgr.GetClient(),
+				RetentionPeriod: opts.PolicySnapshotDecisionRetentionPeriod.Duration,
+			}).SetupWithManager(mgr); err != nil {
+				klog.ErrorS(err, "Unable to set up the clusterSchedulingPolicySnapshot compactor")
+				return err
+			}
+		}
+
+		// The parallelizer shared by the rollout controller and the work generator for their fan-out operations;
+		// each operation runs with its own set of workers.
+		fanOutParallelizer := parallelizer.NewParallelizer(opts.FanOutWorkers)
+
 		// Set up a new controller to do rollout resources according to CRP rollout strategy
 		klog.Info("Setting up rollout controller")
 		if err := (&rollout.Reconciler{
@@ -210,6 +312,7 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 			UncachedReader:          mgr.GetAPIReader(),
 			MaxConcurrentReconciles: int(math.Ceil(float64(opts.MaxFleetSizeSupported)/30) * math.Ceil(float64(opts.MaxConcurrentClusterPlacement)/10)),
 			InformerManager:         dynamicInformerManager,
+			Parallelizer:            fanOutParallelizer,
 		}).SetupWithManager(mgr); err != nil {
 			klog.ErrorS(err, "Unable to set up rollout controller")
 			return err
@@ -247,6 +350,12 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 			Client:                  mgr.GetClient(),
 			MaxConcurrentReconciles: int(math.Ceil(float64(opts.MaxFleetSizeSupported)/10) * math.Ceil(float64(opts.MaxConcurrentClusterPlacement)/10)),
 			InformerManager:         dynamicInformerManager,
+			Parallelizer:            fanOutParallelizer,
+			ManifestLimits: resource.ManifestLimits{
+				MaxManifestSize:     opts.MaxWorkManifestSize,
+				MaxWorkSize:         opts.MaxWorkSize,
+				MaxManifestsPerWork: opts.MaxManifestsPerWork,
+			},
 		}).SetupWithManager(mgr); err != nil {
 			klog.ErrorS(err, "Unable to set up work generator")
 			return err
@@ -254,14 +363,73 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 
 		// Set up the scheduler
 		klog.Info("Setting up scheduler")
-		defaultProfile := profile.NewDefaultProfile()
-		defaultFramework := framework.NewFramework(defaultProfile, mgr)
+		// Verify pinned placement CRD installation status; the pinned clusters are honored by every profile.
+		if opts.EnablePinnedPlacementAPIs {
+			for _, gvk := range pinnedPlacementGVKs {
+				if err = utils.CheckCRDInstalled(discoverClient, gvk); err != nil {
+					klog.ErrorS(err, "unable to find the required CRD", "GVK", gvk)
+					return err
+				}
+			}
+		}
+		buildProfiles, err := newSchedulerProfileBuilder(opts)
+		if err != nil {
+			klog.ErrorS(err, "Unable to build the scheduling profiles")
+			return err
+		}
+		profiles, err := buildProfiles()
+		if err != nil {
+			klog.ErrorS(err, "Unable to build the scheduling profiles")
+			return err
+		}
+		// Report the scheduling profiles in use, now that all their plugins are in place.
+		if err = mgr.AddMetricsServerExtraHandler(profile.HandlerPath, profile.NewHandler(profiles)); err != nil {
+			klog.ErrorS(err, "Unable to serve the description of the scheduling profiles")
+			return err
+		}
+		clusterEligibilityChecker := buildClusterEligibilityChecker(opts)
+		frameworkOpts, err := buildSchedulerFrameworkOptions(opts)
+		if err != nil {
+			klog.ErrorS(err, "Unable to build the scheduler framework options")
+			return err
+		}
+		frameworkOpts = append(frameworkOpts, framework.WithClusterEligibilityChecker(clusterEligibilityChecker))
+		if !opts.SchedulerStrictConsistency {
+			// All the profiles share the same in-memory view of the fleet.
+			clusterSnapshot := framework.NewClusterSnapshot()
+			if err := clusterSnapshot.SetUp(ctx, mgr.GetCache()); err != nil {
+				klog.ErrorS(err, "Unable to set up the cluster snapshot for the scheduler")
+				return err
+			}
+			frameworkOpts = append(frameworkOpts, framework.WithClusterSnapshot(clusterSnapshot))
+		}
+		// The first profile is the default one; the others are picked by the placements that name them.
+		defaultFramework := framework.NewFramework(profiles[0], mgr, frameworkOpts...)
+		frameworks := []framework.Framework{defaultFramework}
+		schedulerOpts := []scheduler.Option{scheduler.WithDrainTimeout(opts.SchedulerDrainTimeout.Duration)}
+		registeredClusterEvents := profiles[0].RegisteredClusterEvents()
+		for _, p := range profiles[1:] {
+			fw := framework.NewFramework(p, mgr, frameworkOpts...)
+			frameworks = append(frameworks, fw)
+			schedulerOpts = append(schedulerOpts, scheduler.WithFramework(fw))
+			registeredClusterEvents = registeredClusterEvents.Union(p.RegisteredClusterEvents())
+		}
+		if opts.SchedulerExplainerBindAddress != "" && opts.SchedulerExplainerBindAddress != "0" {
+			if err := setupSchedulerExplainer(mgr, opts, buildProfiles, frameworkOpts); err != nil {
+				klog.ErrorS(err, "Unable to set up the scheduler explainer")
+				return err
+			}
+		}
 		defaultSchedulingQueue := queue.NewSimpleClusterResourcePlacementSchedulingQueue(
 			queue.WithName(schedulerQueueName),
+			queue.WithFairShare(opts.SchedulerFairShare.Duration),
+			// Placements of higher priorities are scheduled first.
+			queue.WithPriorityFunc(scheduler.NewPlacementPriorityFunc(mgr.GetClient())),
 		)
 		// we use one scheduler for every 10 concurrent placement
-		defaultScheduler := scheduler.NewScheduler("DefaultScheduler", defaultFramework, defaultSchedulingQueue, mgr,
-			int(math.Ceil(float64(opts.MaxFleetSizeSupported)/50)*math.Ceil(float64(opts.MaxConcurrentClusterPlacement)/10)))
+		defaultScheduler = scheduler.NewScheduler("DefaultScheduler", defaultFramework, defaultSchedulingQueue, mgr,
+			int(math.Ceil(float64(opts.MaxFleetSizeSupported)/50)*math.Ceil(float64(opts.MaxConcurrentClusterPlacement)/10)),
+			schedulerOpts...)
 		klog.Info("Starting the scheduler")
 		// Scheduler must run in a separate goroutine as Run() is a blocking call.
 		wg.Add(1)
@@ -274,6 +442,61 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 			klog.InfoS("The scheduler has exited")
 		}()
 
+		// The descheduler shares the frameworks with the scheduler, so that the scheduler sees its writes right away.
+		defaultDescheduler := descheduler.New(mgr.GetClient(), defaultSchedulingQueue, frameworks,
+			descheduler.WithInterval(opts.DeschedulerInterval.Duration),
+			descheduler.WithMinScoreImprovement(opts.DeschedulerMinScoreImprovement),
+			descheduler.WithMaxMovesPerPlacement(opts.DeschedulerMaxMovesPerPlacement),
+			descheduler.WithMaxMovesPerRun(opts.DeschedulerMaxMovesPerRun),
+		)
+		if opts.DeschedulerInterval.Duration > 0 {
+			klog.Info("Setting up the descheduler")
+			if err := mgr.Add(defaultDescheduler); err != nil {
+				klog.ErrorS(err, "Unable to set up the descheduler")
+				return err
+			}
+		}
+
+		if opts.SchedulerResyncInterval.Duration > 0 {
+			klog.Info("Setting up the scheduler resyncer")
+			if err := mgr.Add(resyncer.New(mgr.GetClient(), defaultSchedulingQueue,
+				resyncer.WithInterval(opts.SchedulerResyncInterval.Duration),
+				resyncer.WithStaleThreshold(opts.SchedulerResyncStaleThreshold.Duration),
+			)); err != nil {
+				klog.ErrorS(err, "Unable to set up the scheduler resyncer")
+				return err
+			}
+		}
+
+		// Placements may opt in to rebalancing on cluster joins whether or not the periodic runs are enabled.
+		klog.Info("Setting up the memberCluster watcher for the descheduler")
+		if err := (&descheduler.ClusterJoinReconciler{
+			Client:                    mgr.GetClient(),
+			Descheduler:               defaultDescheduler,
+			ClusterEligibilityChecker: clusterEligibilityChecker,
+		}).SetupWithManager(mgr); err != nil {
+			klog.ErrorS(err, "Unable to set up memberCluster watcher for the descheduler")
+			return err
+		}
+
+		klog.Info("Setting up the memberCluster watcher for draining clusters")
+		if err := (&descheduler.DrainReconciler{
+			Client:      mgr.GetClient(),
+			Descheduler: defaultDescheduler,
+		}).SetupWithManager(mgr); err != nil {
+			klog.ErrorS(err, "Unable to set up memberCluster watcher for draining clusters")
+			return err
+		}
+
+		klog.Info("Setting up the clusterResourcePlacement watcher for rescheduling")
+		if err := (&descheduler.RescheduleReconciler{
+			Client:      mgr.GetClient(),
+			Descheduler: defaultDescheduler,
+		}).SetupWithManager(mgr); err != nil {
+			klog.ErrorS(err, "Unable to set up clusterResourcePlacement watcher for rescheduling")
+			return err
+		}
+
 		// Set up the watchers for the controller
 		klog.Info("Setting up the clusterResourcePlacement watcher for scheduler")
 		if err := (&schedulercrpwatcher.Reconciler{
@@ -306,12 +529,48 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 		if err := (&membercluster.Reconciler{
 			Client:                    mgr.GetClient(),
 			SchedulerWorkQueue:        defaultSchedulingQueue,
-			ClusterEligibilityChecker: clustereligibilitychecker.New(),
+			ClusterEligibilityChecker: clusterEligibilityChecker,
+			RegisteredClusterEvents:   registeredClusterEvents,
 		}).SetupWithManager(mgr); err != nil {
 			klog.ErrorS(err, "Unable to set up memberCluster watcher for scheduler")
 			return err
 		}
 
+		if opts.EnablePinnedPlacementAPIs {
+			klog.Info("Setting up the pinnedPlacement watcher for scheduler")
+			if err := (&schedulerpinnedplacementwatcher.Reconciler{
+				SchedulerWorkQueue: defaultSchedulingQueue,
+			}).SetupWithManager(mgr); err != nil {
+				klog.ErrorS(err, "Unable to set up pinnedPlacement watcher for scheduler")
+				return err
+			}
+
+			klog.Info("Setting up pinned placement controller")
+			if err := (&pinnedplacement.Reconciler{
+				Client: mgr.GetClient(),
+			}).SetupWithManager(mgr); err != nil {
+				klog.ErrorS(err, "unable to set up PinnedPlacement controller")
+				return err
+			}
+		}
+
+		// Verify cluster scheduling decision CRD installation status.
+		if opts.EnableClusterSchedulingDecisionAPIs {
+			for _, gvk := range clusterSchedulingDecisionGVKs {
+				if err = utils.CheckCRDInstalled(discoverClient, gvk); err != nil {
+					klog.ErrorS(err, "unable to find the required CRD", "GVK", gvk)
+					return err
+				}
+			}
+			klog.Info("Setting up cluster scheduling decision controller")
+			if err := (&clusterschedulingdecision.Reconciler{
+				Client: mgr.GetClient(),
+			}).SetupWithManager(mgr); err != nil {
+				klog.ErrorS(err, "unable to set up ClusterSchedulingDecision controller")
+				return err
+			}
+		}
+
 		// Set up the controllers for overriding resources.
 		klog.Info("Setting up the clusterResourceOverride controller")
 		if err := (&overrider.ClusterResourceReconciler{
@@ -333,6 +592,42 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 			return err
 		}
 
+		// Verify cluster group CRD installation status.
+		if opts.EnableClusterGroupAPIs {
+			for _, gvk := range clusterGroupGVKs {
+				if err = utils.CheckCRDInstalled(discoverClient, gvk); err != nil {
+					klog.ErrorS(err, "unable to find the required CRD", "GVK", gvk)
+					return err
+				}
+			}
+			klog.Info("Setting up cluster group controller")
+			if err = (&clustergroup.Reconciler{
+				Client: mgr.GetClient(),
+			}).SetupWithManager(mgr); err != nil {
+				klog.ErrorS(err, "unable to set up ClusterGroup controller")
+				return err
+			}
+		}
+
+		// Verify member agent upgrade CRD installation status.
+		if opts.EnableMemberAgentUpgradeAPIs {
+			for _, gvk := range memberAgentUpgradeGVKs {
+				if err = utils.CheckCRDInstalled(discoverClient, gvk); err != nil {
+					klog.ErrorS(err, "unable to find the required CRD", "GVK", gvk)
+					return err
+				}
+			}
+			klog.Info("Setting up member agent upgrade controller")
+			if err = (&memberagentupgrade.Reconciler{
+				Client:          mgr.GetClient(),
+				Recorder:        mgr.GetEventRecorderFor("member-agent-upgrade-controller"),
+				HubAgentVersion: version.Version,
+			}).SetupWithManager(mgr); err != nil {
+				klog.ErrorS(err, "unable to set up MemberAgentUpgrade controller")
+				return err
+			}
+		}
+
 		// Verify cluster inventory CRD installation status.
 		if opts.EnableClusterInventoryAPIs {
 			for _, gvk := range clusterInventoryGVKs {
@@ -384,5 +679,234 @@ func SetupControllers(ctx context.Context, wg *sync.WaitGroup, mgr ctrl.Manager,
 		klog.ErrorS(err, "Failed to setup resource detector")
 		return err
 	}
+
+	// The custom controllers are started by the resource change detector once the hub agent is elected as the leader.
+	leaderOnlyQueues := map[string]controller.Controller{
+		crpControllerV1Alpha1Name:    clusterResourcePlacementControllerV1Alpha1,
+		crpControllerV1Beta1Name:     clusterResourcePlacementControllerV1Beta1,
+		mcPlacementControllerName:    memberClusterPlacementController,
+		resourceChangeControllerName: resourceChangeController,
+	}
+	return setupHealthChecks(ctx, mgr, opts, leaderOnlyQueues, defaultScheduler)
+}
+
+// setupHealthChecks adds the health checks that reflect the health of the controllers to the healthz and
+// readyz endpoints of the controller manager.
+func setupHealthChecks(ctx context.Context, mgr ctrl.Manager, opts *options.Options,
+	leaderOnlyQueues map[string]controller.Controller, defaultScheduler *scheduler.Scheduler) error {
+	leaderOnlyProbes := make(map[string]healthcheck.QueueProbe)
+	for name, c := range leaderOnlyQueues {
+		if probe, ok := c.(healthcheck.QueueProbe); ok {
+			leaderOnlyProbes[name] = probe
+		}
+	}
+
+	if err := mgr.AddReadyzCheck("informer-sync", healthcheck.InformerSyncChecker(mgr.GetCache(), informerSyncCheckTimeout)); err != nil {
+		klog.ErrorS(err, "Unable to set up the informer sync health check")
+		return err
+	}
+	if err := mgr.AddHealthzCheck("leader-status", healthcheck.LeaderStatusChecker(ctx, mgr.Elected(), leaderStartGracePeriod, leaderOnlyProbes)); err != nil {
+		klog.ErrorS(err, "Unable to set up the leader status health check")
+		return err
+	}
+
+	if opts.QueueStarvationThreshold.Duration == 0 {
+		klog.InfoS("Queue starvation health checks are disabled")
+		return nil
+	}
+	probes := make(map[string]healthcheck.QueueProbe, len(leaderOnlyProbes)+1)
+	for name, probe := range leaderOnlyProbes {
+		probes[name] = probe
+	}
+	if defaultScheduler != nil {
+		probes[schedulerQueueName] = defaultScheduler
+	}
+	for name, probe := range probes {
+		if err := mgr.AddHealthzCheck(name+"-starvation", healthcheck.QueueStarvationChecker(probe, opts.QueueStarvationThreshold.Duration)); err != nil {
+			klog.ErrorS(err, "Unable to set up the queue starvation health check", "queue", name)
+			return err
+		}
+	}
 	return nil
 }
+
+// buildClusterEligibilityChecker builds the cluster eligibility checker shared by the scheduler and its
+// member cluster watcher from the hub agent options.
+func buildClusterEligibilityChecker(opts *options.Options) *clustereligibilitychecker.ClusterEligibilityChecker {
+	var requiredAgentTypes []clusterv1beta1.AgentType
+	for _, agentType := range strings.Split(opts.SchedulerRequiredAgentTypes, ",") {
+		if agentType = strings.TrimSpace(agentType); agentType != "" {
+			requiredAgentTypes = append(requiredAgentTypes, clusterv1beta1.AgentType(agentType))
+		}
+	}
+	if len(requiredAgentTypes) > 0 {
+		klog.InfoS("Requiring additional agents to be joined and healthy for scheduling", "agentTypes", requiredAgentTypes)
+	}
+	return clustereligibilitychecker.New(clustereligibilitychecker.WithRequiredAgentTypes(requiredAgentTypes))
+}
+
+// newSchedulerProfileBuilder returns a function which builds the scheduling profiles from the
+// scheduler configuration file, if any; otherwise, the default profile is used only. The default
+// profile always comes first.
+//
+// The configuration is loaded (and reported) once; each call of the returned function builds a fresh
+// set of profiles, as plugins are bound to the framework they are set up with.
+func newSchedulerProfileBuilder(opts *options.Options) (explainer.ProfileBuilder, error) {
+	var buildProfiles explainer.ProfileBuilder
+	if opts.SchedulerProfileConfigFile == "" {
+		var rolloutGroupArgs *profile.RolloutGroupArgs
+		var rawRolloutGroupArgs json.RawMessage
+		if opts.RolloutGroupClusterLabel != "" {
+			rolloutGroupArgs = buildRolloutGroupArgs(opts)
+			var err error
+			if rawRolloutGroupArgs, err = json.Marshal(rolloutGroupArgs); err != nil {
+				return nil, fmt.Errorf("failed to marshal the rollout group args: %w", err)
+			}
+		}
+		buildProfiles = func() ([]*framework.Profile, error) {
+			defaultProfile := profile.NewDefaultProfile()
+			if rolloutGroupArgs != nil {
+				rolloutGroupPlugin := rolloutgroup.New(rolloutgroup.WithClusterLabelKey(rolloutGroupArgs.ClusterLabelKey), rolloutgroup.WithGroupOrder(rolloutGroupArgs.GroupOrder))
+				defaultProfile.WithPreBindPlugin(&rolloutGroupPlugin).WithPluginArgs(rolloutGroupPlugin.Name(), rawRolloutGroupArgs)
+			}
+			return []*framework.Profile{defaultProfile}, nil
+		}
+	} else {
+		config, err := profile.LoadConfiguration(opts.SchedulerProfileConfigFile)
+		if err != nil {
+			return nil, err
+		}
+		buildProfiles = func() ([]*framework.Profile, error) {
+			profiles, err := profile.NewProfilesFromConfiguration(config, profile.NewInTreeRegistry())
+			if err != nil {
+				return nil, fmt.Errorf("invalid scheduler configuration in %q: %w", opts.SchedulerProfileConfigFile, err)
+			}
+			return profiles, nil
+		}
+	}
+
+	profiles, err := buildProfiles()
+	if err != nil {
+		return nil, err
+	}
+	profileNames := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		profileNames = append(profileNames, p.Name())
+	}
+	klog.InfoS("Loaded the scheduling profiles", "file", opts.SchedulerProfileConfigFile, "profiles", profileNames, "defaultProfile", profileNames[0])
+
+	if !opts.EnablePinnedPlacementAPIs {
+		return buildProfiles, nil
+	}
+	// The pinned clusters are honored by every profile.
+	return func() ([]*framework.Profile, error) {
+		profiles, err := buildProfiles()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range profiles {
+			pinnedPlacementPlugin := pinnedplacementplugin.New()
+			p.WithPreFilterPlugin(&pinnedPlacementPlugin).WithFilterPlugin(&pinnedPlacementPlugin)
+		}
+		return profiles, nil
+	}, nil
+}
+
+// buildRolloutGroupArgs builds the args of the scheduler plugin that assigns bindings to the rollout
+// groups of their target clusters, in the form of the scheduler configuration.
+func buildRolloutGroupArgs(opts *options.Options) *profile.RolloutGroupArgs {
+	var groupOrder []string
+	for _, group := range strings.Split(opts.RolloutGroupOrder, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groupOrder = append(groupOrder, group)
+		}
+	}
+	klog.InfoS("Assigning bindings to rollout groups", "clusterLabel", opts.RolloutGroupClusterLabel, "groupOrder", groupOrder)
+	return &profile.RolloutGroupArgs{
+		ClusterLabelKey: opts.RolloutGroupClusterLabel,
+		GroupOrder:      groupOrder,
+	}
+}
+
+// SetupSchedulerExplainer sets up the scheduler explainer alone, for read-only replicas of the hub agent.
+func SetupSchedulerExplainer(mgr ctrl.Manager, opts *options.Options) error {
+	buildProfiles, err := newSchedulerProfileBuilder(opts)
+	if err != nil {
+		return err
+	}
+	frameworkOpts, err := buildSchedulerFrameworkOptions(opts)
+	if err != nil {
+		return err
+	}
+	frameworkOpts = append(frameworkOpts, framework.WithClusterEligibilityChecker(buildClusterEligibilityChecker(opts)))
+	return setupSchedulerExplainer(mgr, opts, buildProfiles, frameworkOpts)
+}
+
+// setupSchedulerExplainer sets up the scheduler explainer, which explains the scheduling decisions of
+// placements and simulates placements, reading from the cache of the controller manager without
+// writing to the hub cluster; it is served whether or not the hub agent is the leader.
+func setupSchedulerExplainer(mgr ctrl.Manager, opts *options.Options, buildProfiles explainer.ProfileBuilder, frameworkOpts []framework.Option) error {
+	explainerOpts := []explainer.Option{explainer.WithFrameworkOptions(frameworkOpts...)}
+	if opts.EnablePinnedPlacementAPIs {
+		explainerOpts = append(explainerOpts, explainer.WithPinnedPlacements())
+	}
+	e := explainer.New(mgr.GetClient(), mgr.GetScheme(), buildProfiles, explainerOpts...)
+	klog.InfoS("Serving the scheduler explainer", "address", opts.SchedulerExplainerBindAddress)
+	return mgr.Add(explainer.NewServer(opts.SchedulerExplainerBindAddress, e))
+}
+
+// buildSchedulerFrameworkOptions builds the scheduler framework options from the hub agent options.
+func buildSchedulerFrameworkOptions(opts *options.Options) ([]framework.Option, error) {
+	var frameworkOpts []framework.Option
+
+	var excludedClusterNames []string
+	for _, name := range strings.Split(opts.SchedulerExcludedClusterNames, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			excludedClusterNames = append(excludedClusterNames, name)
+		}
+	}
+	if len(excludedClusterNames) > 0 {
+		klog.InfoS("Excluding clusters from scheduling by name", "clusters", excludedClusterNames)
+		frameworkOpts = append(frameworkOpts, framework.WithExcludedClusterNames(excludedClusterNames))
+	}
+
+	if opts.SchedulerExcludedClusterNamePattern != "" {
+		pattern, err := regexp.Compile(opts.SchedulerExcludedClusterNamePattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile the excluded cluster name pattern %q: %w", opts.SchedulerExcludedClusterNamePattern, err)
+		}
+		klog.InfoS("Excluding clusters from scheduling by name pattern", "pattern", opts.SchedulerExcludedClusterNamePattern)
+		frameworkOpts = append(frameworkOpts, framework.WithExcludedClusterNamePattern(pattern))
+	}
+
+	if opts.SchedulerDecisionCompactionThreshold > 0 {
+		klog.InfoS("Compacting scheduling decisions for placements with many clusters", "threshold", opts.SchedulerDecisionCompactionThreshold)
+		frameworkOpts = append(frameworkOpts, framework.WithDecisionCompactionThreshold(opts.SchedulerDecisionCompactionThreshold))
+	}
+
+	if opts.SchedulerStrictConsistency {
+		klog.InfoS("Listing member clusters from the API server in each scheduling cycle for strict consistency")
+		frameworkOpts = append(frameworkOpts, framework.WithStrictConsistency(true))
+	}
+
+	if opts.SchedulerBindingWriteQPS > 0 {
+		klog.InfoS("Limiting the rate of binding writes in the scheduler", "qps", opts.SchedulerBindingWriteQPS, "burst", opts.SchedulerBindingWriteBurst)
+		frameworkOpts = append(frameworkOpts, framework.WithBindingWriteRateLimit(opts.SchedulerBindingWriteQPS, opts.SchedulerBindingWriteBurst))
+	}
+
+	if opts.SchedulerBindingWriteJitter.Duration > 0 {
+		klog.InfoS("Jittering binding writes in the scheduler", "maxDelay", opts.SchedulerBindingWriteJitter.Duration)
+		frameworkOpts = append(frameworkOpts, framework.WithBindingWriteJitter(opts.SchedulerBindingWriteJitter.Duration))
+	}
+
+	if opts.SchedulerEventDedupWindow.Duration > 0 {
+		klog.InfoS("Deduplicating events in the scheduler", "window", opts.SchedulerEventDedupWindow.Duration)
+		frameworkOpts = append(frameworkOpts, framework.WithEventDedupWindow(opts.SchedulerEventDedupWindow.Duration))
+	}
+
+	if opts.SchedulerEventQPS > 0 {
+		klog.InfoS("Limiting the rate of events in the scheduler", "qps", opts.SchedulerEventQPS, "burst", opts.SchedulerEventBurst)
+		frameworkOpts = append(frameworkOpts, framework.WithEventRateLimit(opts.SchedulerEventQPS, opts.SchedulerEventBurst))
+	}
+	return frameworkOpts, nil
+}
@@ -0,0 +1,282 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/yaml"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/validator"
+)
+
+const (
+	// cloneCRPPollInterval is how often the clone-crp command checks whether the new placement is available.
+	cloneCRPPollInterval = 2 * time.Second
+
+	// lastAppliedConfigAnnotation is the annotation kubectl apply keeps on objects; it describes the
+	// source placement, not the clone, and is therefore dropped.
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+var (
+	// cloneCRPSetAliases is the shorthands of the --set keys, keyed by the shorthands; the full keys
+	// are paths relative to the placement spec.
+	cloneCRPSetAliases = map[string]string{
+		"placementType":    "policy.placementType",
+		"numberOfClusters": "policy.numberOfClusters",
+		"clusterNames":     "policy.clusterNames",
+	}
+)
+
+// cloneCRPOptions is the options of the clone-crp command.
+type cloneCRPOptions struct {
+	// src is the name of the placement to clone.
+	src string
+	// dst is the name of the new placement.
+	dst string
+	// sets is the changes made to the spec of the new placement, in the form of KEY=VALUE.
+	sets []string
+	// labelSelector, if not empty, replaces the required cluster affinity of the new placement.
+	labelSelector string
+	// dryRun prints the new placement instead of creating it.
+	dryRun bool
+	// wait blocks until the new placement becomes available.
+	wait bool
+	// timeout is how long to wait for the new placement to become available.
+	timeout time.Duration
+}
+
+// newCloneCRPCommand returns the clone-crp command, which creates a placement from an existing one
+// on the hub cluster, with changes.
+func newCloneCRPCommand() *cobra.Command {
+	opts := cloneCRPOptions{}
+	cloneCmd := &cobra.Command{
+		Use:   "clone-crp SRC DST",
+		Short: "Create a ClusterResourcePlacement from an existing one, with changes",
+		Long: `Create a ClusterResourcePlacement named DST from the one named SRC on the hub cluster, with changes.
+
+The spec, labels and annotations of SRC are copied. Each --set KEY=VALUE then changes the field at
+KEY, a dot-separated path relative to the spec (e.g., strategy.rollingUpdate.maxSurge), to VALUE,
+which is parsed as YAML; numberOfClusters, placementType and clusterNames are short for the fields
+under policy. The --label-selector flag replaces the required cluster affinity of DST with a single
+term picking the clusters matching the selector.
+
+The new placement is validated before it is created; with --wait, the command blocks until it
+becomes available on all the picked clusters.`,
+		Example: `  # Clone a placement for a staging environment with three clusters.
+  kubectl fleet clone-crp web web-staging --set numberOfClusters=3 --label-selector env=staging --wait
+
+  # Print the new placement without creating it.
+  kubectl fleet clone-crp web web-canary --set strategy.rollingUpdate.maxUnavailable=1 --dry-run`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.src, opts.dst = args[0], args[1]
+			hubClient, err := newHubClient()
+			if err != nil {
+				return err
+			}
+			return runCloneCRP(cmd.Context(), cmd.OutOrStdout(), hubClient, opts)
+		},
+	}
+	cloneCmd.Flags().StringArrayVar(&opts.sets, "set", nil, "Change a field of the spec of the new placement, in the form of KEY=VALUE (repeatable)")
+	cloneCmd.Flags().StringVarP(&opts.labelSelector, "label-selector", "l", "", "Pick the clusters matching the label selector (e.g., env=staging) instead of the source cluster affinity")
+	cloneCmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print the new placement without creating it")
+	cloneCmd.Flags().BoolVar(&opts.wait, "wait", false, "Wait for the new placement to become available")
+	cloneCmd.Flags().DurationVar(&opts.timeout, "timeout", 5*time.Minute, "How long to wait for the new placement to become available, with --wait")
+	return cloneCmd
+}
+
+// newHubClient returns a client of the hub cluster, built from the kubeconfig in use.
+func newHubClient() (client.Client, error) {
+	restConfig, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the kubeconfig: %w", err)
+	}
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return client.New(restConfig, client.Options{Scheme: scheme})
+}
+
+// runCloneCRP clones the placement on the hub cluster as specified by the options.
+func runCloneCRP(ctx context.Context, out io.Writer, hubClient client.Client, opts cloneCRPOptions) error {
+	src := &placementv1beta1.ClusterResourcePlacement{}
+	if err := hubClient.Get(ctx, client.ObjectKey{Name: opts.src}, src); err != nil {
+		return fmt.Errorf("failed to get ClusterResourcePlacement %s: %w", opts.src, err)
+	}
+	dst, err := cloneClusterResourcePlacement(src, opts.dst, opts.sets, opts.labelSelector)
+	if err != nil {
+		return err
+	}
+	if err := validator.ValidateClusterResourcePlacementOffline(dst); err != nil {
+		return fmt.Errorf("ClusterResourcePlacement %s is invalid: %w", dst.Name, err)
+	}
+
+	if opts.dryRun {
+		dst.APIVersion = placementv1beta1.GroupVersion.String()
+		dst.Kind = placementv1beta1.ClusterResourcePlacementKind
+		data, err := yaml.Marshal(dst)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	}
+
+	if err := hubClient.Create(ctx, dst); err != nil {
+		return fmt.Errorf("failed to create ClusterResourcePlacement %s: %w", dst.Name, err)
+	}
+	fmt.Fprintf(out, "ClusterResourcePlacement %s is created from %s.\n", dst.Name, src.Name)
+	if !opts.wait {
+		return nil
+	}
+	if err := waitForAvailableCRP(ctx, hubClient, dst.Name, cloneCRPPollInterval, opts.timeout); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "ClusterResourcePlacement %s is available.\n", dst.Name)
+	return nil
+}
+
+// cloneClusterResourcePlacement returns a new placement with the given name, the spec, labels and
+// annotations of which are copied from the source placement, and then changed as specified.
+func cloneClusterResourcePlacement(src *placementv1beta1.ClusterResourcePlacement, name string, sets []string, labelSelector string) (*placementv1beta1.ClusterResourcePlacement, error) {
+	srcCopy := src.DeepCopy()
+	dst := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      srcCopy.Labels,
+			Annotations: srcCopy.Annotations,
+		},
+		Spec: srcCopy.Spec,
+	}
+	delete(dst.Annotations, lastAppliedConfigAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+
+	if len(sets) > 0 {
+		spec, err := setSpecFields(&dst.Spec, sets)
+		if err != nil {
+			return nil, err
+		}
+		dst.Spec = *spec
+	}
+
+	if labelSelector != "" {
+		selector, err := metav1.ParseToLabelSelector(labelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector %q: %w", labelSelector, err)
+		}
+		if dst.Spec.Policy == nil {
+			dst.Spec.Policy = &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType}
+		}
+		if dst.Spec.Policy.Affinity == nil {
+			dst.Spec.Policy.Affinity = &placementv1beta1.Affinity{}
+		}
+		if dst.Spec.Policy.Affinity.ClusterAffinity == nil {
+			dst.Spec.Policy.Affinity.ClusterAffinity = &placementv1beta1.ClusterAffinity{}
+		}
+		dst.Spec.Policy.Affinity.ClusterAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &placementv1beta1.ClusterSelector{
+			ClusterSelectorTerms: []placementv1beta1.ClusterSelectorTerm{{LabelSelector: selector}},
+		}
+	}
+	return dst, nil
+}
+
+// setSpecFields returns a copy of the placement spec with the fields changed as specified by the
+// KEY=VALUE pairs; the keys are dot-separated paths relative to the spec, and the values are parsed
+// as YAML.
+func setSpecFields(spec *placementv1beta1.ClusterResourcePlacementSpec, sets []string) (*placementv1beta1.ClusterResourcePlacementSpec, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for _, set := range sets {
+		key, rawValue, found := strings.Cut(set, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid --set %q: want KEY=VALUE", set)
+		}
+		if fullKey, ok := cloneCRPSetAliases[key]; ok {
+			key = fullKey
+		}
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(rawValue), &value); err != nil {
+			return nil, fmt.Errorf("invalid --set %q: failed to parse the value: %w", set, err)
+		}
+
+		path := strings.Split(key, ".")
+		parent := fields
+		for _, field := range path[:len(path)-1] {
+			child, ok := parent[field]
+			if !ok || child == nil {
+				child = map[string]interface{}{}
+				parent[field] = child
+			}
+			childFields, ok := child.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid --set %q: %s is not an object", set, field)
+			}
+			parent = childFields
+		}
+		parent[path[len(path)-1]] = value
+	}
+
+	if data, err = json.Marshal(fields); err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	updated := &placementv1beta1.ClusterResourcePlacementSpec{}
+	if err := decoder.Decode(updated); err != nil {
+		return nil, fmt.Errorf("invalid --set values: %w", err)
+	}
+	return updated, nil
+}
+
+// waitForAvailableCRP polls the placement until it becomes available for its latest generation.
+func waitForAvailableCRP(ctx context.Context, hubClient client.Client, name string, interval, timeout time.Duration) error {
+	var message string
+	err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		crp := &placementv1beta1.ClusterResourcePlacement{}
+		if err := hubClient.Get(ctx, client.ObjectKey{Name: name}, crp); err != nil {
+			return false, fmt.Errorf("failed to get ClusterResourcePlacement %s: %w", name, err)
+		}
+		availableCond := meta.FindStatusCondition(crp.Status.Conditions, string(placementv1beta1.ClusterResourcePlacementAvailableConditionType))
+		if availableCond != nil {
+			message = availableCond.Message
+		}
+		return condition.IsConditionStatusTrue(availableCond, crp.Generation), nil
+	})
+	if err != nil {
+		if wait.Interrupted(err) {
+			return fmt.Errorf("ClusterResourcePlacement %s is not available after %s: %s", name, timeout, message)
+		}
+		return err
+	}
+	return nil
+}
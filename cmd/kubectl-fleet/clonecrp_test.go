@@ -0,0 +1,250 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// newSourceCRP returns the placement which the clone-crp tests clone.
+func newSourceCRP() *placementv1beta1.ClusterResourcePlacement {
+	return &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-crp",
+			ResourceVersion: "7",
+			Generation:      3,
+			Labels:          map[string]string{"team": "web"},
+			Annotations: map[string]string{
+				lastAppliedConfigAnnotation: "{}",
+			},
+		},
+		Spec: placementv1beta1.ClusterResourcePlacementSpec{
+			ResourceSelectors: []placementv1beta1.ClusterResourceSelector{
+				{Group: "", Version: "v1", Kind: "Namespace", Name: "test-ns"},
+			},
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: ptr.To(int32(2)),
+			},
+		},
+		Status: placementv1beta1.ClusterResourcePlacementStatus{
+			ObservedResourceIndex: "1",
+		},
+	}
+}
+
+// TestCloneClusterResourcePlacement tests the cloneClusterResourcePlacement function.
+func TestCloneClusterResourcePlacement(t *testing.T) {
+	testCases := []struct {
+		name          string
+		sets          []string
+		labelSelector string
+		wantSpec      func(spec *placementv1beta1.ClusterResourcePlacementSpec)
+		wantErr       bool
+	}{
+		{
+			name:     "plain copy",
+			wantSpec: func(_ *placementv1beta1.ClusterResourcePlacementSpec) {},
+		},
+		{
+			name: "set fields",
+			sets: []string{"numberOfClusters=3", "strategy.rollingUpdate.maxSurge=25%", "revisionHistoryLimit=5"},
+			wantSpec: func(spec *placementv1beta1.ClusterResourcePlacementSpec) {
+				spec.Policy.NumberOfClusters = ptr.To(int32(3))
+				spec.Strategy.RollingUpdate = &placementv1beta1.RollingUpdateConfig{MaxSurge: ptr.To(intstr.FromString("25%"))}
+				spec.RevisionHistoryLimit = ptr.To(int32(5))
+			},
+		},
+		{
+			name:          "label selector",
+			labelSelector: "env=staging",
+			wantSpec: func(spec *placementv1beta1.ClusterResourcePlacementSpec) {
+				spec.Policy.Affinity = &placementv1beta1.Affinity{
+					ClusterAffinity: &placementv1beta1.ClusterAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &placementv1beta1.ClusterSelector{
+							ClusterSelectorTerms: []placementv1beta1.ClusterSelectorTerm{
+								{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "staging"}, MatchExpressions: []metav1.LabelSelectorRequirement{}}},
+							},
+						},
+					},
+				}
+			},
+		},
+		{
+			name:    "unknown field",
+			sets:    []string{"policy.numberOfCluster=3"},
+			wantErr: true,
+		},
+		{
+			name:    "set without a value",
+			sets:    []string{"numberOfClusters"},
+			wantErr: true,
+		},
+		{
+			name:    "set under a non-object field",
+			sets:    []string{"numberOfClusters.value=3"},
+			wantErr: true,
+		},
+		{
+			name:          "invalid label selector",
+			labelSelector: "env in staging",
+			wantErr:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			src := newSourceCRP()
+			got, err := cloneClusterResourcePlacement(src, "test-crp-clone", tc.sets, tc.labelSelector)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("cloneClusterResourcePlacement() = %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			want := &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-crp-clone",
+					Labels: map[string]string{"team": "web"},
+				},
+				Spec: newSourceCRP().Spec,
+			}
+			tc.wantSpec(&want.Spec)
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Errorf("cloneClusterResourcePlacement() mismatch (-got, +want):\n%s", diff)
+			}
+			if diff := cmp.Diff(src, newSourceCRP()); diff != "" {
+				t.Errorf("cloneClusterResourcePlacement() changed the source placement (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestRunCloneCRP tests the runCloneCRP function.
+func TestRunCloneCRP(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        cloneCRPOptions
+		wantOutput  string
+		wantCreated bool
+		wantErrMsg  string
+	}{
+		{
+			name:        "create",
+			opts:        cloneCRPOptions{src: "test-crp", dst: "test-crp-clone", sets: []string{"numberOfClusters=3"}},
+			wantOutput:  "ClusterResourcePlacement test-crp-clone is created from test-crp.",
+			wantCreated: true,
+		},
+		{
+			name:       "dry run",
+			opts:       cloneCRPOptions{src: "test-crp", dst: "test-crp-clone", sets: []string{"numberOfClusters=3"}, dryRun: true},
+			wantOutput: "numberOfClusters: 3",
+		},
+		{
+			name:       "source not found",
+			opts:       cloneCRPOptions{src: "missing-crp", dst: "test-crp-clone"},
+			wantErrMsg: "failed to get ClusterResourcePlacement missing-crp",
+		},
+		{
+			name:       "invalid clone",
+			opts:       cloneCRPOptions{src: "test-crp", dst: "test-crp-clone", sets: []string{"placementType=PickFixed"}},
+			wantErrMsg: "ClusterResourcePlacement test-crp-clone is invalid",
+		},
+		{
+			name:       "destination exists",
+			opts:       cloneCRPOptions{src: "test-crp", dst: "test-crp"},
+			wantErrMsg: "failed to create ClusterResourcePlacement test-crp",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := placementv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the placement APIs to the scheme: %v", err)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newSourceCRP()).Build()
+
+			out := &bytes.Buffer{}
+			err := runCloneCRP(context.Background(), out, fakeClient, tc.opts)
+			if tc.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErrMsg) {
+					t.Fatalf("runCloneCRP() = %v, want error containing %q", err, tc.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runCloneCRP() = %v, want no error", err)
+			}
+			if !strings.Contains(out.String(), tc.wantOutput) {
+				t.Errorf("runCloneCRP() output = %q, want it to contain %q", out.String(), tc.wantOutput)
+			}
+			crp := &placementv1beta1.ClusterResourcePlacement{}
+			err = fakeClient.Get(context.Background(), client.ObjectKey{Name: tc.opts.dst}, crp)
+			if gotCreated := err == nil; gotCreated != tc.wantCreated {
+				t.Errorf("Get(%s) = %v, want created %t", tc.opts.dst, err, tc.wantCreated)
+			}
+		})
+	}
+}
+
+// TestWaitForAvailableCRP tests the waitForAvailableCRP function.
+func TestWaitForAvailableCRP(t *testing.T) {
+	testCases := []struct {
+		name       string
+		conditions []metav1.Condition
+		wantErr    bool
+	}{
+		{
+			name: "available",
+			conditions: []metav1.Condition{
+				{Type: string(placementv1beta1.ClusterResourcePlacementAvailableConditionType), Status: metav1.ConditionTrue, ObservedGeneration: 3},
+			},
+		},
+		{
+			name: "available for an older generation",
+			conditions: []metav1.Condition{
+				{Type: string(placementv1beta1.ClusterResourcePlacementAvailableConditionType), Status: metav1.ConditionTrue, ObservedGeneration: 2},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no condition",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := placementv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add the placement APIs to the scheme: %v", err)
+			}
+			crp := newSourceCRP()
+			crp.Status.Conditions = tc.conditions
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(crp).Build()
+
+			err := waitForAvailableCRP(context.Background(), fakeClient, crp.Name, 10*time.Millisecond, 50*time.Millisecond)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("waitForAvailableCRP() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// kubectl-fleet is a kubectl plugin which helps work with fleets, e.g., "kubectl fleet validate" and
+// "kubectl fleet clone-crp".
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+)
+
+func newRootCommand() *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:          "kubectl-fleet",
+		Short:        "Work with Kubernetes fleets",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+	}
+	rootCmd.AddCommand(newValidateCommand())
+	rootCmd.AddCommand(newCloneCRPCommand())
+	return rootCmd
+}
+
+func main() {
+	klog.InitFlags(nil)
+
+	// Add go flags (e.g., --v) to pflag.
+	// Reference: https://github.com/spf13/pflag#supporting-go-flags-when-using-pflag
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+
+	err := newRootCommand().Execute()
+	klog.Flush()
+	if err != nil {
+		os.Exit(1)
+	}
+}
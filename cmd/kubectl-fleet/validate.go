@@ -0,0 +1,257 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/profile"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/validator"
+)
+
+const (
+	// dryRunPolicySnapshotIndex is the index of the policy snapshot created for the dry scheduling pass.
+	dryRunPolicySnapshotIndex = 0
+)
+
+// newValidateCommand returns the validate command, which validates a placement offline, i.e.,
+// without access to a hub cluster.
+func newValidateCommand() *cobra.Command {
+	var crpFile, clustersFile string
+	validateCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate a ClusterResourcePlacement offline",
+		Long: `Validate a ClusterResourcePlacement offline, i.e., without access to a hub cluster.
+
+The placement is checked with the same rules as the hub agent webhook, except for the checks
+against the API resources served by the hub cluster. If a cluster inventory is provided, the
+scheduler then runs against the inventory with the default scheduling profile, and the command
+fails if the placement cannot be fully scheduled.
+
+The cluster inventory is a YAML file of MemberCluster objects, either as separate documents or as
+a MemberClusterList; the clusters are assumed to be connected to the fleet and healthy.`,
+		Example: "  kubectl fleet validate -f crp.yaml --clusters clusters.yaml",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runValidate(cmd.Context(), cmd.OutOrStdout(), crpFile, clustersFile)
+		},
+	}
+	validateCmd.Flags().StringVarP(&crpFile, "filename", "f", "", "Path to the ClusterResourcePlacement YAML file (required)")
+	_ = validateCmd.MarkFlagRequired("filename")
+	validateCmd.Flags().StringVar(&clustersFile, "clusters", "", "Path to the cluster inventory YAML file (optional)")
+	return validateCmd
+}
+
+// runValidate validates the placement in the given file, and runs a dry scheduling pass against the
+// cluster inventory in the given file, if any.
+func runValidate(ctx context.Context, out io.Writer, crpFile, clustersFile string) error {
+	crp, err := loadClusterResourcePlacement(crpFile)
+	if err != nil {
+		return err
+	}
+	if err := validator.ValidateClusterResourcePlacementOffline(crp); err != nil {
+		return fmt.Errorf("ClusterResourcePlacement %s is invalid: %w", crp.Name, err)
+	}
+	fmt.Fprintf(out, "ClusterResourcePlacement %s is valid.\n", crp.Name)
+
+	if clustersFile == "" {
+		return nil
+	}
+	clusters, err := loadMemberClusters(clustersFile)
+	if err != nil {
+		return err
+	}
+	policy, err := dryRunSchedule(ctx, crp, clusters)
+	if err != nil {
+		return fmt.Errorf("failed to schedule ClusterResourcePlacement %s: %w", crp.Name, err)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tSELECTED\tREASON")
+	for _, decision := range policy.Status.ClusterDecisions {
+		fmt.Fprintf(w, "%s\t%t\t%s\n", decision.ClusterName, decision.Selected, decision.Reason)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	scheduledCond := meta.FindStatusCondition(policy.Status.Conditions, string(placementv1beta1.PolicySnapshotScheduled))
+	if scheduledCond == nil || scheduledCond.Status != metav1.ConditionTrue {
+		message := "no scheduling decision is made"
+		if scheduledCond != nil {
+			message = scheduledCond.Message
+		}
+		return fmt.Errorf("ClusterResourcePlacement %s cannot be fully scheduled: %s", crp.Name, message)
+	}
+	return nil
+}
+
+// loadClusterResourcePlacement reads a ClusterResourcePlacement from a YAML file.
+func loadClusterResourcePlacement(path string) (*placementv1beta1.ClusterResourcePlacement, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the placement file %q: %w", path, err)
+	}
+	crp := &placementv1beta1.ClusterResourcePlacement{}
+	if err := yaml.UnmarshalStrict(data, crp); err != nil {
+		return nil, fmt.Errorf("failed to parse the placement file %q: %w", path, err)
+	}
+	if crp.Kind != "ClusterResourcePlacement" {
+		return nil, fmt.Errorf("the placement file %q holds a %q object, want a ClusterResourcePlacement", path, crp.Kind)
+	}
+	return crp, nil
+}
+
+// loadMemberClusters reads the member clusters from a YAML file, which holds either MemberCluster
+// objects as separate documents or a MemberClusterList.
+func loadMemberClusters(path string) ([]clusterv1beta1.MemberCluster, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the cluster inventory file %q: %w", path, err)
+	}
+
+	var clusters []clusterv1beta1.MemberCluster
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		var doc json.RawMessage
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse the cluster inventory file %q: %w", path, err)
+		}
+		if len(doc) == 0 || string(doc) == "null" {
+			continue
+		}
+
+		typeMeta := metav1.TypeMeta{}
+		if err := json.Unmarshal(doc, &typeMeta); err != nil {
+			return nil, fmt.Errorf("failed to parse the cluster inventory file %q: %w", path, err)
+		}
+		switch typeMeta.Kind {
+		case "MemberCluster":
+			cluster := clusterv1beta1.MemberCluster{}
+			if err := yaml.UnmarshalStrict(doc, &cluster); err != nil {
+				return nil, fmt.Errorf("failed to parse the cluster inventory file %q: %w", path, err)
+			}
+			clusters = append(clusters, cluster)
+		case "MemberClusterList":
+			clusterList := clusterv1beta1.MemberClusterList{}
+			if err := yaml.UnmarshalStrict(doc, &clusterList); err != nil {
+				return nil, fmt.Errorf("failed to parse the cluster inventory file %q: %w", path, err)
+			}
+			clusters = append(clusters, clusterList.Items...)
+		default:
+			return nil, fmt.Errorf("the cluster inventory file %q holds a %q object, want MemberCluster or MemberClusterList", path, typeMeta.Kind)
+		}
+	}
+
+	names := make(map[string]bool, len(clusters))
+	for idx := range clusters {
+		name := clusters[idx].Name
+		if name == "" {
+			return nil, fmt.Errorf("the cluster inventory file %q holds a MemberCluster without a name", path)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("the cluster inventory file %q holds more than one MemberCluster named %s", path, name)
+		}
+		names[name] = true
+	}
+	return clusters, nil
+}
+
+// dryRunSchedule runs a scheduling cycle for the placement against the given clusters, with an
+// in-memory client in place of the hub cluster; it returns the policy snapshot with the scheduling
+// decisions.
+//
+// The clusters are assumed to be connected to the fleet and healthy, i.e., the cluster eligibility
+// check is skipped, as a cluster inventory usually does not carry the agent status.
+func dryRunSchedule(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement, clusters []clusterv1beta1.MemberCluster) (*placementv1beta1.ClusterSchedulingPolicySnapshot, error) {
+	scheme := runtime.NewScheme()
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf(placementv1beta1.PolicySnapshotNameFmt, crp.Name, dryRunPolicySnapshotIndex),
+			Labels: map[string]string{
+				placementv1beta1.CRPTrackingLabel:      crp.Name,
+				placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+				placementv1beta1.PolicyIndexLabel:      strconv.Itoa(dryRunPolicySnapshotIndex),
+			},
+			Annotations: map[string]string{
+				placementv1beta1.CRPGenerationAnnotation: strconv.FormatInt(crp.Generation, 10),
+			},
+		},
+		Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+			Policy: crp.Spec.Policy,
+		},
+	}
+	if crp.Spec.Policy != nil &&
+		crp.Spec.Policy.PlacementType == placementv1beta1.PickNPlacementType &&
+		crp.Spec.Policy.NumberOfClusters != nil {
+		policy.Annotations[placementv1beta1.NumberOfClustersAnnotation] = strconv.Itoa(int(*crp.Spec.Policy.NumberOfClusters))
+	}
+
+	objs := []client.Object{crp.DeepCopy(), policy}
+	for idx := range clusters {
+		objs = append(objs, clusters[idx].DeepCopy())
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&placementv1beta1.ClusterSchedulingPolicySnapshot{}).
+		WithIndex(&placementv1beta1.ClusterResourceBinding{}, controller.BindingCRPTrackingIndexKey, controller.ExtractBindingCRPTracking).
+		Build()
+
+	p, err := profile.NewProfileFromConfiguration(&profile.ProfileConfiguration{
+		Plugins: profile.Plugins{
+			Filter: profile.PluginSet{Disabled: []profile.Plugin{{Name: profile.ClusterEligibilityPluginName}}},
+		},
+	}, profile.NewInTreeRegistry())
+	if err != nil {
+		return nil, err
+	}
+	// Write a decision for every cluster, so that the reasons why clusters are not picked are all
+	// reported.
+	fw := framework.NewDryRunFramework(p, fakeClient, framework.WithMaxClusterDecisionCount(len(clusters)))
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(policy), policy); err != nil {
+		return nil, err
+	}
+	if _, err := fw.RunSchedulingCycleFor(ctx, crp.Name, policy); err != nil {
+		return nil, err
+	}
+
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(policy), policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
@@ -0,0 +1,239 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	pickTwoCRP = `
+apiVersion: placement.kubernetes-fleet.io/v1beta1
+kind: ClusterResourcePlacement
+metadata:
+  name: test-crp
+spec:
+  resourceSelectors:
+  - group: ""
+    version: v1
+    kind: Namespace
+    name: test-ns
+  policy:
+    placementType: PickN
+    numberOfClusters: 2
+    affinity:
+      clusterAffinity:
+        requiredDuringSchedulingIgnoredDuringExecution:
+          clusterSelectorTerms:
+          - labelSelector:
+              matchLabels:
+                env: prod
+`
+	invalidCRP = `
+apiVersion: placement.kubernetes-fleet.io/v1beta1
+kind: ClusterResourcePlacement
+metadata:
+  name: test-crp
+spec:
+  resourceSelectors:
+  - group: ""
+    version: v1
+    kind: Namespace
+    name: test-ns
+  policy:
+    placementType: PickN
+`
+	clusterInventory = `
+apiVersion: cluster.kubernetes-fleet.io/v1beta1
+kind: MemberCluster
+metadata:
+  name: bravelion
+  labels:
+    env: prod
+spec:
+  identity:
+    kind: User
+    name: bravelion
+---
+apiVersion: cluster.kubernetes-fleet.io/v1beta1
+kind: MemberClusterList
+items:
+- apiVersion: cluster.kubernetes-fleet.io/v1beta1
+  kind: MemberCluster
+  metadata:
+    name: smartcat
+    labels:
+      env: prod
+  spec:
+    identity:
+      kind: User
+      name: smartcat
+- apiVersion: cluster.kubernetes-fleet.io/v1beta1
+  kind: MemberCluster
+  metadata:
+    name: singingbutterfly
+    labels:
+      env: canary
+  spec:
+    identity:
+      kind: User
+      name: singingbutterfly
+`
+	singleClusterInventory = `
+apiVersion: cluster.kubernetes-fleet.io/v1beta1
+kind: MemberCluster
+metadata:
+  name: bravelion
+  labels:
+    env: prod
+spec:
+  identity:
+    kind: User
+    name: bravelion
+`
+)
+
+// writeTestFile writes the content to a file in a temporary directory and returns its path.
+func writeTestFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write the test file: %v", err)
+	}
+	return path
+}
+
+// TestRunValidate tests the runValidate function.
+func TestRunValidate(t *testing.T) {
+	testCases := []struct {
+		name         string
+		crp          string
+		clusters     string
+		wantOutput   []string
+		wantErrMsg   string
+		noInventory  bool
+		wantSelected map[string]bool
+	}{
+		{
+			name:        "valid placement without a cluster inventory",
+			crp:         pickTwoCRP,
+			noInventory: true,
+			wantOutput:  []string{"ClusterResourcePlacement test-crp is valid."},
+		},
+		{
+			name:       "invalid placement",
+			crp:        invalidCRP,
+			wantErrMsg: "ClusterResourcePlacement test-crp is invalid",
+		},
+		{
+			name:     "placement fully scheduled",
+			crp:      pickTwoCRP,
+			clusters: clusterInventory,
+			wantOutput: []string{
+				"ClusterResourcePlacement test-crp is valid.",
+				"bravelion",
+				"smartcat",
+				"singingbutterfly",
+			},
+			wantSelected: map[string]bool{"bravelion": true, "smartcat": true, "singingbutterfly": false},
+		},
+		{
+			name:       "placement not fully scheduled",
+			crp:        pickTwoCRP,
+			clusters:   singleClusterInventory,
+			wantErrMsg: "ClusterResourcePlacement test-crp cannot be fully scheduled",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			crpFile := writeTestFile(t, "crp.yaml", tc.crp)
+			clustersFile := ""
+			if !tc.noInventory {
+				clustersFile = writeTestFile(t, "clusters.yaml", tc.clusters)
+			}
+
+			out := &bytes.Buffer{}
+			err := runValidate(context.Background(), out, crpFile, clustersFile)
+			if tc.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErrMsg) {
+					t.Fatalf("runValidate() = %v, want error containing %q", err, tc.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runValidate() = %v, want no error", err)
+			}
+			for _, want := range tc.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("runValidate() output = %q, want it to contain %q", out.String(), want)
+				}
+			}
+			if tc.wantSelected == nil {
+				return
+			}
+			gotSelected := make(map[string]bool)
+			for _, line := range strings.Split(out.String(), "\n") {
+				fields := strings.Fields(line)
+				if len(fields) < 2 || fields[1] != "true" && fields[1] != "false" {
+					continue
+				}
+				gotSelected[fields[0]] = fields[1] == "true"
+			}
+			if diff := cmp.Diff(tc.wantSelected, gotSelected); diff != "" {
+				t.Errorf("runValidate() selected clusters mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestLoadMemberClusters tests the loadMemberClusters function.
+func TestLoadMemberClusters(t *testing.T) {
+	testCases := []struct {
+		name      string
+		inventory string
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "clusters as documents and lists",
+			inventory: clusterInventory,
+			wantNames: []string{"bravelion", "smartcat", "singingbutterfly"},
+		},
+		{
+			name:      "duplicate clusters",
+			inventory: clusterInventory + "---" + singleClusterInventory,
+			wantErr:   true,
+		},
+		{
+			name:      "unexpected kind",
+			inventory: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: test-ns\n",
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clusters, err := loadMemberClusters(writeTestFile(t, "clusters.yaml", tc.inventory))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("loadMemberClusters() = %v, want error %t", err, tc.wantErr)
+			}
+			var gotNames []string
+			for _, cluster := range clusters {
+				gotNames = append(gotNames, cluster.Name)
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames); diff != "" {
+				t.Errorf("loadMemberClusters() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -74,6 +74,13 @@ var (
 	propertyProvider        = flag.String("property-provider", "none", "The property provider to use for the agent.")
 	region                  = flag.String("region", "", "The region where the member cluster resides.")
 	cloudConfigFile         = flag.String("cloud-config", "/etc/kubernetes/provider/config.json", "The path to the cloud cloudconfig file.")
+	selfUpgradeDeployment   = flag.String("self-upgrade-deployment", "",
+		"The <namespace>/<name> of the deployment of the member agent. If set, the agent upgrades itself to the target version declared by the hub cluster by updating the deployment.")
+	selfUpgradeContainer     = flag.String("self-upgrade-container", "", "The name of the member agent container in the deployment to upgrade; defaults to the name of the deployment.")
+	enableApplyImpersonation = flag.Bool("enable-apply-impersonation", false,
+		"If set, the agent applies the manifests of a Work as the service account specified in its apply strategy by impersonating it; otherwise such Works fail to apply.")
+	manifestTransformerWebhookURL = flag.String("manifest-transformer-webhook-url", "",
+		"The URL of a webhook local to the member cluster that transforms the manifests of a Work right before they are applied. If not set, the manifests are applied as they are.")
 )
 
 func init() {
@@ -86,7 +93,8 @@ func init() {
 	utilruntime.Must(placementv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 
-	metrics.Registry.MustRegister(fleetmetrics.JoinResultMetrics, fleetmetrics.LeaveResultMetrics, fleetmetrics.WorkApplyTime)
+	metrics.Registry.MustRegister(fleetmetrics.JoinResultMetrics, fleetmetrics.LeaveResultMetrics, fleetmetrics.WorkApplyTime,
+		fleetmetrics.ParallelizerWorkChunksTotal, fleetmetrics.ParallelizerWorkDurationSeconds, fleetmetrics.ParallelizerAbortedWorkTotal)
 }
 
 func main() {
@@ -348,12 +356,21 @@ func Start(ctx context.Context, hubCfg, memberConfig *rest.Config, hubOpts, memb
 			klog.ErrorS(err, "unable to find the required CRD", "GVK", gvk)
 			return err
 		}
+		workOpts := []work.ApplyWorkReconcilerOption{work.WithHubIdentity(hubCfg.Host)}
+		if *enableApplyImpersonation {
+			workOpts = append(workOpts, work.WithImpersonation(memberConfig))
+		}
+		if *manifestTransformerWebhookURL != "" {
+			workOpts = append(workOpts, work.WithManifestTransformers(
+				work.NewWebhookManifestTransformer("webhook", *manifestTransformerWebhookURL, nil)))
+		}
 		// create the work controller, so we can pass it to the internal member cluster reconciler
 		workController := work.NewApplyWorkReconciler(
 			hubMgr.GetClient(),
 			spokeDynamicClient,
 			memberMgr.GetClient(),
-			restMapper, hubMgr.GetEventRecorderFor("work_controller"), 5, targetNS)
+			restMapper, hubMgr.GetEventRecorderFor("work_controller"), 5, targetNS,
+			workOpts...)
 
 		if err = workController.SetupWithManager(hubMgr); err != nil {
 			klog.ErrorS(err, "Failed to create v1beta1 controller", "controller", "work")
@@ -378,13 +395,20 @@ func Start(ctx context.Context, hubCfg, memberConfig *rest.Config, hubOpts, memb
 			pp = nil
 		}
 
+		selfUpgradeCfg, err := buildSelfUpgradeConfig(*selfUpgradeDeployment, *selfUpgradeContainer)
+		if err != nil {
+			klog.ErrorS(err, "Invalid self-upgrade configuration")
+			return err
+		}
+
 		// Set up the IMC controller.
 		imcReconciler, err := imcv1beta1.NewReconciler(
 			ctx,
 			hubMgr.GetClient(),
 			memberMgr.GetConfig(), memberMgr.GetClient(),
 			workController,
-			pp)
+			pp,
+			selfUpgradeCfg)
 		if err != nil {
 			klog.ErrorS(err, "Failed to create InternalMemberCluster v1beta1 reconciler")
 			return fmt.Errorf("failed to create InternalMemberCluster v1beta1 reconciler: %w", err)
@@ -413,3 +437,23 @@ func Start(ctx context.Context, hubCfg, memberConfig *rest.Config, hubOpts, memb
 
 	return nil
 }
+
+// buildSelfUpgradeConfig builds the self-upgrade configuration of the member agent from the flags; it
+// returns nil if self-upgrade is disabled.
+func buildSelfUpgradeConfig(deployment, container string) (*imcv1beta1.SelfUpgradeConfig, error) {
+	if deployment == "" {
+		return nil, nil
+	}
+	namespace, name, found := strings.Cut(deployment, "/")
+	if !found || namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid self-upgrade deployment %q, must be in the form of <namespace>/<name>", deployment)
+	}
+	if container == "" {
+		container = name
+	}
+	return &imcv1beta1.SelfUpgradeConfig{
+		DeploymentNamespace: namespace,
+		DeploymentName:      name,
+		ContainerName:       container,
+	}, nil
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/client-go/rest"
+
+	imcv1beta1 "go.goms.io/fleet/pkg/controllers/internalmembercluster/v1beta1"
 )
 
 func Test_buildHubConfig(t *testing.T) {
@@ -143,3 +145,46 @@ func Test_buildHubConfig(t *testing.T) {
 		assert.NotNil(t, config.WrapTransport)
 	})
 }
+
+func Test_buildSelfUpgradeConfig(t *testing.T) {
+	testCases := map[string]struct {
+		deployment string
+		container  string
+		want       *imcv1beta1.SelfUpgradeConfig
+		wantErr    bool
+	}{
+		"disabled": {},
+		"default container name": {
+			deployment: "fleet-system/member-agent",
+			want: &imcv1beta1.SelfUpgradeConfig{
+				DeploymentNamespace: "fleet-system",
+				DeploymentName:      "member-agent",
+				ContainerName:       "member-agent",
+			},
+		},
+		"explicit container name": {
+			deployment: "fleet-system/member-agent",
+			container:  "agent",
+			want: &imcv1beta1.SelfUpgradeConfig{
+				DeploymentNamespace: "fleet-system",
+				DeploymentName:      "member-agent",
+				ContainerName:       "agent",
+			},
+		},
+		"no namespace - error": {
+			deployment: "member-agent",
+			wantErr:    true,
+		},
+		"empty name - error": {
+			deployment: "fleet-system/",
+			wantErr:    true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := buildSelfUpgradeConfig(tc.deployment, tc.container)
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -38,6 +38,7 @@ require (
 	sigs.k8s.io/cluster-inventory-api v0.0.0-20240730014211-ef0154379848
 	sigs.k8s.io/controller-runtime v0.18.5
 	sigs.k8s.io/work-api v0.0.0-20220407021756-586d707fdb2c
+	sigs.k8s.io/yaml v1.4.0
 )
 
 require (
@@ -128,7 +129,6 @@ require (
 	knative.dev/pkg v0.0.0-20231010144348-ca8c009405dd // indirect
 	sigs.k8s.io/json v0.0.0-20221116044647-bc3834ca7abd // indirect
 	sigs.k8s.io/structured-merge-diff/v4 v4.4.1 // indirect
-	sigs.k8s.io/yaml v1.4.0 // indirect
 )
 
 replace (
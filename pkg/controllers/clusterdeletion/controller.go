@@ -0,0 +1,300 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package clusterdeletion features a controller that cleans up the artifacts of a member cluster on the
+// hub cluster when the member cluster is deleted.
+package clusterdeletion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// the reasons of the CleanedUp condition.
+	waitingForAgentsToLeaveReason  = "WaitingForAgentsToLeave"
+	deletingBindingsAndWorksReason = "DeletingBindingsAndWorks"
+	deletingNamespaceReason        = "DeletingNamespace"
+	cleanedUpReason                = "CleanedUp"
+
+	// cleanupProgressCheckInterval is how often the controller checks the progress of the cleanup
+	// while it waits for the deleted objects to go away.
+	cleanupProgressCheckInterval = 5 * time.Second
+)
+
+// Reconciler reconciles a deleting MemberCluster object and cleans up its artifacts on the hub cluster.
+//
+// The cleanup starts after the member agents have left the fleet (or the member cluster has been force
+// deleted), i.e., after the member cluster controller removes its own finalizer, and proceeds in the
+// following order:
+//  1. delete the bindings that target the member cluster, so that no more Works are generated for it;
+//  2. delete the Works in the reserved namespace of the member cluster, and remove their finalizers,
+//     which would otherwise be removed by the member agents that have left;
+//  3. delete the generated RBAC objects, i.e., the role binding and then the role, so that the identity
+//     of the member cluster can no longer access the hub cluster;
+//  4. delete the reserved namespace.
+//
+// The progress of the cleanup is reported via the CleanedUp condition of the member cluster.
+type Reconciler struct {
+	client.Client
+}
+
+// Reconcile cleans up the artifacts of a deleting member cluster.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	mcRef := klog.KRef("", req.Name)
+	startTime := time.Now()
+	klog.V(2).InfoS("Reconciliation starts (cluster deletion controller)", "memberCluster", mcRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("Reconciliation ends (cluster deletion controller)", "memberCluster", mcRef, "latency", latency)
+	}()
+
+	mc := &clusterv1beta1.MemberCluster{}
+	if err := r.Get(ctx, req.NamespacedName, mc); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(2).InfoS("Member cluster object is not found", "memberCluster", mcRef)
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get member cluster", "memberCluster", mcRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+
+	if mc.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(mc, placementv1beta1.MemberClusterCleanupFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		controllerutil.AddFinalizer(mc, placementv1beta1.MemberClusterCleanupFinalizer)
+		if err := r.Update(ctx, mc); err != nil {
+			klog.ErrorS(err, "Failed to add the cleanup finalizer to member cluster", "memberCluster", mcRef)
+			return ctrl.Result{}, controller.NewUpdateIgnoreConflictError(err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(mc, placementv1beta1.MemberClusterCleanupFinalizer) {
+		klog.V(2).InfoS("No need to clean up the deleting member cluster without the cleanup finalizer", "memberCluster", mcRef)
+		return ctrl.Result{}, nil
+	}
+
+	if controllerutil.ContainsFinalizer(mc, placementv1beta1.MemberClusterFinalizer) {
+		// The member cluster controller removes its finalizer once the member agents have left; the
+		// removal triggers another reconciliation.
+		klog.V(2).InfoS("Waiting for the member agents to leave", "memberCluster", mcRef)
+		return ctrl.Result{}, r.setCleanedUpCondition(ctx, mc, metav1.ConditionFalse, waitingForAgentsToLeaveReason,
+			"Waiting for the member agents to leave the fleet")
+	}
+
+	namespaceName := fmt.Sprintf(utils.NamespaceNameFormat, mc.Name)
+	bindingCount, err := r.deleteBindings(ctx, mc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	workCount, err := r.deleteWorks(ctx, mc, namespaceName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if bindingCount > 0 || workCount > 0 {
+		klog.V(2).InfoS("Waiting for the bindings and works to be deleted", "memberCluster", mcRef, "bindings", bindingCount, "works", workCount)
+		return ctrl.Result{RequeueAfter: cleanupProgressCheckInterval}, r.setCleanedUpCondition(ctx, mc, metav1.ConditionFalse, deletingBindingsAndWorksReason,
+			fmt.Sprintf("Waiting for %d binding(s) and %d work(s) of the member cluster to be deleted", bindingCount, workCount))
+	}
+
+	if err := r.deleteRBAC(ctx, mc, namespaceName); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	namespaceGone, err := r.deleteNamespace(ctx, mc, namespaceName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !namespaceGone {
+		klog.V(2).InfoS("Waiting for the reserved namespace to be deleted", "memberCluster", mcRef, "namespace", namespaceName)
+		return ctrl.Result{RequeueAfter: cleanupProgressCheckInterval}, r.setCleanedUpCondition(ctx, mc, metav1.ConditionFalse, deletingNamespaceReason,
+			fmt.Sprintf("Waiting for the reserved namespace %s of the member cluster to be deleted", namespaceName))
+	}
+
+	if err := r.setCleanedUpCondition(ctx, mc, metav1.ConditionTrue, cleanedUpReason, "All the artifacts of the member cluster have been cleaned up"); err != nil {
+		return ctrl.Result{}, err
+	}
+	controllerutil.RemoveFinalizer(mc, placementv1beta1.MemberClusterCleanupFinalizer)
+	if err := r.Update(ctx, mc); err != nil {
+		klog.ErrorS(err, "Failed to remove the cleanup finalizer from member cluster", "memberCluster", mcRef)
+		return ctrl.Result{}, controller.NewUpdateIgnoreConflictError(err)
+	}
+	klog.V(2).InfoS("Cleaned up the member cluster", "memberCluster", mcRef)
+	return ctrl.Result{}, nil
+}
+
+// deleteBindings deletes all the bindings that target the member cluster, and returns the number
+// of such bindings that still exist.
+func (r *Reconciler) deleteBindings(ctx context.Context, mc *clusterv1beta1.MemberCluster) (int, error) {
+	bindingList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := r.List(ctx, bindingList); err != nil {
+		klog.ErrorS(err, "Failed to list bindings", "memberCluster", klog.KObj(mc))
+		return 0, controller.NewAPIServerError(true, err)
+	}
+	count := 0
+	for i := range bindingList.Items {
+		binding := &bindingList.Items[i]
+		if binding.Spec.TargetCluster != mc.Name {
+			continue
+		}
+		count++
+		if !binding.DeletionTimestamp.IsZero() {
+			// The work generator removes the finalizer of the binding once its works are deleted.
+			continue
+		}
+		if err := r.Delete(ctx, binding); err != nil && !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to delete binding", "memberCluster", klog.KObj(mc), "binding", klog.KObj(binding))
+			return 0, controller.NewAPIServerError(false, err)
+		}
+		klog.V(2).InfoS("Deleted binding", "memberCluster", klog.KObj(mc), "binding", klog.KObj(binding))
+	}
+	return count, nil
+}
+
+// deleteWorks deletes all the works in the reserved namespace of the member cluster and removes their
+// finalizers, and returns the number of works that still exist.
+func (r *Reconciler) deleteWorks(ctx context.Context, mc *clusterv1beta1.MemberCluster, namespaceName string) (int, error) {
+	workList := &placementv1beta1.WorkList{}
+	if err := r.List(ctx, workList, client.InNamespace(namespaceName)); err != nil {
+		klog.ErrorS(err, "Failed to list works", "memberCluster", klog.KObj(mc), "namespace", namespaceName)
+		return 0, controller.NewAPIServerError(true, err)
+	}
+	for i := range workList.Items {
+		work := &workList.Items[i]
+		if len(work.Finalizers) > 0 {
+			// The member agents, which have left, can no longer remove the finalizers.
+			work.SetFinalizers(nil)
+			if err := r.Update(ctx, work); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				klog.ErrorS(err, "Failed to remove the finalizers from work", "memberCluster", klog.KObj(mc), "work", klog.KObj(work))
+				return 0, controller.NewUpdateIgnoreConflictError(err)
+			}
+		}
+		if !work.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, work); err != nil && !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to delete work", "memberCluster", klog.KObj(mc), "work", klog.KObj(work))
+			return 0, controller.NewAPIServerError(false, err)
+		}
+	}
+	return len(workList.Items), nil
+}
+
+// deleteRBAC deletes the role binding and the role generated for the member cluster.
+func (r *Reconciler) deleteRBAC(ctx context.Context, mc *clusterv1beta1.MemberCluster, namespaceName string) error {
+	// Delete the role binding first so that the identity of the member cluster loses its access at once.
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(utils.RoleBindingNameFormat, mc.Name),
+			Namespace: namespaceName,
+		},
+	}
+	if err := r.Delete(ctx, roleBinding); err != nil && !apierrors.IsNotFound(err) {
+		klog.ErrorS(err, "Failed to delete role binding", "memberCluster", klog.KObj(mc), "roleBinding", klog.KObj(roleBinding))
+		return controller.NewAPIServerError(false, err)
+	}
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(utils.RoleNameFormat, mc.Name),
+			Namespace: namespaceName,
+		},
+	}
+	if err := r.Delete(ctx, role); err != nil && !apierrors.IsNotFound(err) {
+		klog.ErrorS(err, "Failed to delete role", "memberCluster", klog.KObj(mc), "role", klog.KObj(role))
+		return controller.NewAPIServerError(false, err)
+	}
+	return nil
+}
+
+// deleteNamespace deletes the reserved namespace of the member cluster, and returns true if the
+// namespace is gone.
+func (r *Reconciler) deleteNamespace(ctx context.Context, mc *clusterv1beta1.MemberCluster, namespaceName string) (bool, error) {
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: namespaceName}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		klog.ErrorS(err, "Failed to get the reserved namespace", "memberCluster", klog.KObj(mc), "namespace", namespaceName)
+		return false, controller.NewAPIServerError(true, err)
+	}
+	if !ns.DeletionTimestamp.IsZero() {
+		return false, nil
+	}
+	if err := r.Delete(ctx, ns); err != nil && !apierrors.IsNotFound(err) {
+		klog.ErrorS(err, "Failed to delete the reserved namespace", "memberCluster", klog.KObj(mc), "namespace", namespaceName)
+		return false, controller.NewAPIServerError(false, err)
+	}
+	klog.V(2).InfoS("Deleted the reserved namespace", "memberCluster", klog.KObj(mc), "namespace", namespaceName)
+	return false, nil
+}
+
+// setCleanedUpCondition sets the CleanedUp condition of the member cluster, if it has changed.
+func (r *Reconciler) setCleanedUpCondition(ctx context.Context, mc *clusterv1beta1.MemberCluster, status metav1.ConditionStatus, reason, message string) error {
+	existing := mc.GetCondition(string(clusterv1beta1.ConditionTypeMemberClusterCleanedUp))
+	if existing != nil && existing.Status == status && existing.Reason == reason && existing.Message == message &&
+		existing.ObservedGeneration == mc.Generation {
+		return nil
+	}
+	mc.SetConditions(metav1.Condition{
+		Type:               string(clusterv1beta1.ConditionTypeMemberClusterCleanedUp),
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: mc.Generation,
+	})
+	if err := r.Status().Update(ctx, mc); err != nil {
+		klog.ErrorS(err, "Failed to update the cleanup progress of member cluster", "memberCluster", klog.KObj(mc))
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cluster-deletion-controller").
+		For(&clusterv1beta1.MemberCluster{}, builder.WithPredicates(predicate.Funcs{
+			CreateFunc: func(_ event.CreateEvent) bool {
+				return true
+			},
+			// React to the deletion of a member cluster, and to the removal of the finalizer of
+			// the member cluster controller, which signals that the member agents have left.
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return e.ObjectOld.GetDeletionTimestamp().IsZero() != e.ObjectNew.GetDeletionTimestamp().IsZero() ||
+					len(e.ObjectOld.GetFinalizers()) != len(e.ObjectNew.GetFinalizers())
+			},
+			DeleteFunc: func(_ event.DeleteEvent) bool {
+				return false
+			},
+			GenericFunc: func(_ event.GenericEvent) bool {
+				return false
+			},
+		})).
+		Complete(r)
+}
@@ -0,0 +1,233 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterdeletion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	testMemberClusterName = "member-1"
+	testNamespaceName     = "fleet-member-member-1"
+	testOtherClusterName  = "member-2"
+)
+
+var (
+	ignoreConditionTimeOption = cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")
+)
+
+func serviceScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add client-go scheme: %v", err)
+	}
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add cluster v1beta1 scheme: %v", err)
+	}
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add placement v1beta1 scheme: %v", err)
+	}
+	return scheme
+}
+
+func deletingMemberCluster(finalizers ...string) *clusterv1beta1.MemberCluster {
+	return &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              testMemberClusterName,
+			DeletionTimestamp: &metav1.Time{Time: metav1.Now().Time},
+			Finalizers:        finalizers,
+		},
+	}
+}
+
+func binding(name, targetCluster string) *placementv1beta1.ClusterResourceBinding {
+	return &placementv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Finalizers: []string{placementv1beta1.WorkFinalizer},
+		},
+		Spec: placementv1beta1.ResourceBindingSpec{
+			TargetCluster: targetCluster,
+		},
+	}
+}
+
+// TestReconcile tests the Reconcile method.
+func TestReconcile(t *testing.T) {
+	reservedNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespaceName}}
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "fleet-role-member-1", Namespace: testNamespaceName}}
+	roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "fleet-rolebinding-member-1", Namespace: testNamespaceName}}
+	work := &placementv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "work-1",
+			Namespace:  testNamespaceName,
+			Finalizers: []string{placementv1beta1.WorkFinalizer},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		memberCluster *clusterv1beta1.MemberCluster
+		objects       []client.Object
+		wantResult    ctrl.Result
+		// wantFinalizers is nil if the member cluster is expected to be gone.
+		wantFinalizers  []string
+		wantCondition   *metav1.Condition
+		wantGone        []client.Object
+		wantDeleting    []client.Object
+		wantNotAffected []client.Object
+	}{
+		{
+			name: "add the cleanup finalizer",
+			memberCluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       testMemberClusterName,
+					Finalizers: []string{placementv1beta1.MemberClusterFinalizer},
+				},
+			},
+			wantFinalizers: []string{placementv1beta1.MemberClusterFinalizer, placementv1beta1.MemberClusterCleanupFinalizer},
+		},
+		{
+			name:          "wait for the member agents to leave",
+			memberCluster: deletingMemberCluster(placementv1beta1.MemberClusterFinalizer, placementv1beta1.MemberClusterCleanupFinalizer),
+			objects: []client.Object{
+				reservedNamespace.DeepCopy(),
+				binding("binding-1", testMemberClusterName),
+			},
+			wantFinalizers: []string{placementv1beta1.MemberClusterFinalizer, placementv1beta1.MemberClusterCleanupFinalizer},
+			wantCondition: &metav1.Condition{
+				Type:   string(clusterv1beta1.ConditionTypeMemberClusterCleanedUp),
+				Status: metav1.ConditionFalse,
+				Reason: waitingForAgentsToLeaveReason,
+			},
+			wantNotAffected: []client.Object{reservedNamespace, binding("binding-1", testMemberClusterName)},
+		},
+		{
+			name:          "delete the bindings and works",
+			memberCluster: deletingMemberCluster(placementv1beta1.MemberClusterCleanupFinalizer),
+			objects: []client.Object{
+				reservedNamespace.DeepCopy(),
+				role.DeepCopy(),
+				roleBinding.DeepCopy(),
+				work.DeepCopy(),
+				binding("binding-1", testMemberClusterName),
+				binding("binding-2", testOtherClusterName),
+			},
+			wantResult:     ctrl.Result{RequeueAfter: cleanupProgressCheckInterval},
+			wantFinalizers: []string{placementv1beta1.MemberClusterCleanupFinalizer},
+			wantCondition: &metav1.Condition{
+				Type:   string(clusterv1beta1.ConditionTypeMemberClusterCleanedUp),
+				Status: metav1.ConditionFalse,
+				Reason: deletingBindingsAndWorksReason,
+			},
+			wantGone:        []client.Object{work},
+			wantDeleting:    []client.Object{binding("binding-1", testMemberClusterName)},
+			wantNotAffected: []client.Object{reservedNamespace, role, roleBinding, binding("binding-2", testOtherClusterName)},
+		},
+		{
+			name:          "delete the RBAC objects and the namespace",
+			memberCluster: deletingMemberCluster(placementv1beta1.MemberClusterCleanupFinalizer),
+			objects: []client.Object{
+				reservedNamespace.DeepCopy(),
+				role.DeepCopy(),
+				roleBinding.DeepCopy(),
+				binding("binding-2", testOtherClusterName),
+			},
+			wantResult:     ctrl.Result{RequeueAfter: cleanupProgressCheckInterval},
+			wantFinalizers: []string{placementv1beta1.MemberClusterCleanupFinalizer},
+			wantCondition: &metav1.Condition{
+				Type:   string(clusterv1beta1.ConditionTypeMemberClusterCleanedUp),
+				Status: metav1.ConditionFalse,
+				Reason: deletingNamespaceReason,
+			},
+			wantGone:        []client.Object{role, roleBinding, reservedNamespace},
+			wantNotAffected: []client.Object{binding("binding-2", testOtherClusterName)},
+		},
+		{
+			name:          "remove the cleanup finalizer",
+			memberCluster: deletingMemberCluster(placementv1beta1.MemberClusterCleanupFinalizer),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(serviceScheme(t)).
+				WithObjects(append(tc.objects, tc.memberCluster)...).
+				WithStatusSubresource(&clusterv1beta1.MemberCluster{}).
+				Build()
+			r := &Reconciler{Client: fakeClient}
+
+			got, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: testMemberClusterName}})
+			if err != nil {
+				t.Fatalf("Reconcile() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tc.wantResult, got); diff != "" {
+				t.Errorf("Reconcile() result mismatch (-want, +got):\n%s", diff)
+			}
+
+			mc := &clusterv1beta1.MemberCluster{}
+			err = fakeClient.Get(context.Background(), types.NamespacedName{Name: testMemberClusterName}, mc)
+			if tc.wantFinalizers == nil {
+				if !apierrors.IsNotFound(err) {
+					t.Fatalf("Get() member cluster = %v, want not found", err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Get() member cluster = %v, want no error", err)
+				}
+				if diff := cmp.Diff(tc.wantFinalizers, mc.Finalizers); diff != "" {
+					t.Errorf("member cluster finalizers mismatch (-want, +got):\n%s", diff)
+				}
+				gotCondition := mc.GetCondition(string(clusterv1beta1.ConditionTypeMemberClusterCleanedUp))
+				if diff := cmp.Diff(tc.wantCondition, gotCondition, ignoreConditionTimeOption, cmpopts.IgnoreFields(metav1.Condition{}, "Message")); diff != "" {
+					t.Errorf("member cluster CleanedUp condition mismatch (-want, +got):\n%s", diff)
+				}
+			}
+
+			for _, obj := range tc.wantGone {
+				if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object)); !apierrors.IsNotFound(err) {
+					t.Errorf("Get() %T %s = %v, want not found", obj, obj.GetName(), err)
+				}
+			}
+			for _, obj := range tc.wantDeleting {
+				got := obj.DeepCopyObject().(client.Object)
+				if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(obj), got); err != nil {
+					t.Fatalf("Get() %T %s = %v, want no error", obj, obj.GetName(), err)
+				}
+				if got.GetDeletionTimestamp().IsZero() {
+					t.Errorf("%T %s is not being deleted, want deleting", obj, obj.GetName())
+				}
+			}
+			for _, obj := range tc.wantNotAffected {
+				got := obj.DeepCopyObject().(client.Object)
+				if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(obj), got); err != nil {
+					t.Fatalf("Get() %T %s = %v, want no error", obj, obj.GetName(), err)
+				}
+				if !got.GetDeletionTimestamp().IsZero() {
+					t.Errorf("%T %s is being deleted, want not affected", obj, obj.GetName())
+				}
+			}
+		})
+	}
+}
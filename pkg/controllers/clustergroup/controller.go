@@ -0,0 +1,260 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package clustergroup features a controller that keeps the group labels on member clusters in
+// sync with ClusterGroup objects.
+package clustergroup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// the reasons of the Synced condition.
+	clusterGroupSyncedReason       = "GroupLabelsSynced"
+	clusterGroupSyncFailedReason   = "GroupLabelsSyncFailed"
+	clusterGroupInvalidSpecReason  = "InvalidClusterSelector"
+	clusterGroupSyncFailedTemplate = "Failed to sync the group label on %d member cluster(s): %v"
+)
+
+// Reconciler reconciles a ClusterGroup object and keeps the group labels on the member clusters in sync.
+type Reconciler struct {
+	client.Client
+}
+
+// Reconcile adds the group label to the member clusters in a ClusterGroup, and removes it from
+// the member clusters that are no longer in the group.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	groupRef := klog.KRef("", req.Name)
+	startTime := time.Now()
+	klog.V(2).InfoS("Reconciliation starts (cluster group controller)", "clusterGroup", groupRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("Reconciliation ends (cluster group controller)", "clusterGroup", groupRef, "latency", latency)
+	}()
+
+	group := &clusterv1beta1.ClusterGroup{}
+	if err := r.Get(ctx, req.NamespacedName, group); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(2).InfoS("Cluster group object is not found", "clusterGroup", groupRef)
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get cluster group", "clusterGroup", groupRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+
+	memberClusterList := &clusterv1beta1.MemberClusterList{}
+	if err := r.List(ctx, memberClusterList); err != nil {
+		klog.ErrorS(err, "Failed to list member clusters", "clusterGroup", groupRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+
+	if group.DeletionTimestamp != nil {
+		klog.V(2).InfoS("Cluster group object is being deleted; remove the group label from all member clusters", "clusterGroup", groupRef)
+		if err := r.syncGroupLabels(ctx, group, memberClusterList.Items, sets.New[string]()); err != nil {
+			klog.ErrorS(err, "Failed to remove the group label from member clusters", "clusterGroup", groupRef)
+			return ctrl.Result{}, err
+		}
+		controllerutil.RemoveFinalizer(group, clusterv1beta1.ClusterGroupCleanupFinalizer)
+		if err := r.Update(ctx, group); err != nil {
+			klog.ErrorS(err, "Failed to remove cleanup finalizer", "clusterGroup", groupRef)
+			return ctrl.Result{}, controller.NewUpdateIgnoreConflictError(err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(group, clusterv1beta1.ClusterGroupCleanupFinalizer) {
+		controllerutil.AddFinalizer(group, clusterv1beta1.ClusterGroupCleanupFinalizer)
+		if err := r.Update(ctx, group); err != nil {
+			klog.ErrorS(err, "Failed to add cleanup finalizer", "clusterGroup", groupRef)
+			return ctrl.Result{}, controller.NewUpdateIgnoreConflictError(err)
+		}
+	}
+
+	members, err := collectMemberClusters(group, memberClusterList.Items)
+	if err != nil {
+		// The selector is invalid; this is a user error and retrying will not help.
+		klog.ErrorS(err, "Failed to collect member clusters in the group", "clusterGroup", groupRef)
+		group.SetConditions(metav1.Condition{
+			Type:               string(clusterv1beta1.ClusterGroupConditionTypeSynced),
+			Status:             metav1.ConditionFalse,
+			Reason:             clusterGroupInvalidSpecReason,
+			Message:            err.Error(),
+			ObservedGeneration: group.Generation,
+		})
+		return ctrl.Result{}, r.updateStatus(ctx, group)
+	}
+
+	syncErr := r.syncGroupLabels(ctx, group, memberClusterList.Items, members)
+	group.Status.MemberClusters = sets.List(members)
+	group.Status.MemberClusterCount = members.Len()
+	cond := metav1.Condition{
+		Type:               string(clusterv1beta1.ClusterGroupConditionTypeSynced),
+		Status:             metav1.ConditionTrue,
+		Reason:             clusterGroupSyncedReason,
+		Message:            fmt.Sprintf("The group label is in sync on %d member cluster(s)", members.Len()),
+		ObservedGeneration: group.Generation,
+	}
+	if syncErr != nil {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = clusterGroupSyncFailedReason
+		cond.Message = syncErr.Error()
+	}
+	group.SetConditions(cond)
+	if err := r.updateStatus(ctx, group); err != nil {
+		return ctrl.Result{}, err
+	}
+	// Retry the failed member clusters (if any).
+	return ctrl.Result{}, syncErr
+}
+
+// collectMemberClusters returns the names of the member clusters that belong to a ClusterGroup.
+func collectMemberClusters(group *clusterv1beta1.ClusterGroup, memberClusters []clusterv1beta1.MemberCluster) (sets.Set[string], error) {
+	members := sets.New[string]()
+	explicitMembers := sets.New(group.Spec.ClusterNames...)
+
+	var selector labels.Selector
+	if group.Spec.ClusterSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(group.Spec.ClusterSelector); err != nil {
+			return nil, fmt.Errorf("failed to parse the cluster selector: %w", err)
+		}
+	}
+
+	for i := range memberClusters {
+		mc := &memberClusters[i]
+		if explicitMembers.Has(mc.Name) {
+			members.Insert(mc.Name)
+			continue
+		}
+		if selector != nil && selector.Matches(labels.Set(withoutGroupLabels(mc.Labels))) {
+			members.Insert(mc.Name)
+		}
+	}
+	return members, nil
+}
+
+// withoutGroupLabels returns the labels excluding the group labels, so that the membership of a
+// group never depends on the membership of another group.
+func withoutGroupLabels(mcLabels map[string]string) map[string]string {
+	filtered := make(map[string]string, len(mcLabels))
+	for k, v := range mcLabels {
+		if !strings.HasPrefix(k, clusterv1beta1.ClusterGroupLabelPrefix) {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+// syncGroupLabels adds the group label to the member clusters in the group, and removes it from
+// the other member clusters.
+func (r *Reconciler) syncGroupLabels(ctx context.Context, group *clusterv1beta1.ClusterGroup, memberClusters []clusterv1beta1.MemberCluster, members sets.Set[string]) error {
+	labelKey := group.GroupLabelKey()
+	failed := 0
+	var lastErr error
+	for i := range memberClusters {
+		mc := &memberClusters[i]
+		_, hasLabel := mc.Labels[labelKey]
+		wantLabel := members.Has(mc.Name)
+		if hasLabel == wantLabel && (!wantLabel || mc.Labels[labelKey] == clusterv1beta1.ClusterGroupLabelValue) {
+			continue
+		}
+
+		patch := client.MergeFrom(mc.DeepCopy())
+		if wantLabel {
+			if mc.Labels == nil {
+				mc.Labels = make(map[string]string)
+			}
+			mc.Labels[labelKey] = clusterv1beta1.ClusterGroupLabelValue
+		} else {
+			delete(mc.Labels, labelKey)
+		}
+		if err := r.Patch(ctx, mc, patch); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			klog.ErrorS(err, "Failed to sync the group label on member cluster",
+				"clusterGroup", klog.KObj(group), "memberCluster", klog.KObj(mc), "wantLabel", wantLabel)
+			failed++
+			lastErr = err
+			continue
+		}
+		klog.V(2).InfoS("Synced the group label on member cluster",
+			"clusterGroup", klog.KObj(group), "memberCluster", klog.KObj(mc), "wantLabel", wantLabel)
+	}
+	if failed > 0 {
+		return controller.NewAPIServerError(false, fmt.Errorf(clusterGroupSyncFailedTemplate, failed, lastErr))
+	}
+	return nil
+}
+
+// updateStatus updates the status of a ClusterGroup.
+func (r *Reconciler) updateStatus(ctx context.Context, group *clusterv1beta1.ClusterGroup) error {
+	if err := r.Status().Update(ctx, group); err != nil {
+		klog.ErrorS(err, "Failed to update cluster group status", "clusterGroup", klog.KObj(group))
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cluster-group-controller").
+		For(&clusterv1beta1.ClusterGroup{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Re-evaluate all the cluster groups when a member cluster joins, or when the labels of
+		// a member cluster change.
+		Watches(&clusterv1beta1.MemberCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueAllClusterGroups),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc: func(_ event.CreateEvent) bool {
+					return true
+				},
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return !labels.Equals(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+				},
+				DeleteFunc: func(_ event.DeleteEvent) bool {
+					return true
+				},
+				GenericFunc: func(_ event.GenericEvent) bool {
+					return false
+				},
+			})).
+		Complete(r)
+}
+
+// enqueueAllClusterGroups returns reconcile requests for all the cluster groups.
+func (r *Reconciler) enqueueAllClusterGroups(ctx context.Context, _ client.Object) []reconcile.Request {
+	groupList := &clusterv1beta1.ClusterGroupList{}
+	if err := r.List(ctx, groupList); err != nil {
+		klog.ErrorS(controller.NewAPIServerError(true, err), "Failed to list cluster groups")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(groupList.Items))
+	for i := range groupList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&groupList.Items[i])})
+	}
+	return requests
+}
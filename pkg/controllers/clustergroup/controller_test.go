@@ -0,0 +1,216 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clustergroup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+)
+
+const (
+	testGroupName = "prod"
+)
+
+var (
+	sortStringsOption = cmpopts.SortSlices(func(s1, s2 string) bool { return s1 < s2 })
+)
+
+func memberCluster(name string, mcLabels map[string]string) *clusterv1beta1.MemberCluster {
+	return &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: mcLabels,
+		},
+	}
+}
+
+// TestCollectMemberClusters tests the collectMemberClusters function.
+func TestCollectMemberClusters(t *testing.T) {
+	groupLabelKey := clusterv1beta1.ClusterGroupLabelPrefix + "other"
+	memberClusters := []clusterv1beta1.MemberCluster{
+		*memberCluster("member-1", map[string]string{"env": "prod"}),
+		*memberCluster("member-2", map[string]string{"env": "staging"}),
+		*memberCluster("member-3", map[string]string{groupLabelKey: clusterv1beta1.ClusterGroupLabelValue}),
+	}
+	tests := map[string]struct {
+		spec        clusterv1beta1.ClusterGroupSpec
+		wantMembers []string
+		wantErr     bool
+	}{
+		"selector only": {
+			spec: clusterv1beta1.ClusterGroupSpec{
+				ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			},
+			wantMembers: []string{"member-1"},
+		},
+		"cluster names only": {
+			spec: clusterv1beta1.ClusterGroupSpec{
+				ClusterNames: []string{"member-2", "member-4"},
+			},
+			wantMembers: []string{"member-2"},
+		},
+		"selector and cluster names": {
+			spec: clusterv1beta1.ClusterGroupSpec{
+				ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				ClusterNames:    []string{"member-2"},
+			},
+			wantMembers: []string{"member-1", "member-2"},
+		},
+		"group labels are ignored by the selector": {
+			spec: clusterv1beta1.ClusterGroupSpec{
+				ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{groupLabelKey: clusterv1beta1.ClusterGroupLabelValue}},
+			},
+			wantMembers: []string{},
+		},
+		"invalid selector": {
+			spec: clusterv1beta1.ClusterGroupSpec{
+				ClusterSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Unknown"}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			group := &clusterv1beta1.ClusterGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: testGroupName},
+				Spec:       tc.spec,
+			}
+			got, err := collectMemberClusters(group, memberClusters)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("collectMemberClusters() = %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.wantMembers, got.UnsortedList(), sortStringsOption); diff != "" {
+				t.Errorf("collectMemberClusters() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestReconcile tests the Reconcile function of the Reconciler.
+func TestReconcile(t *testing.T) {
+	groupLabelKey := clusterv1beta1.ClusterGroupLabelPrefix + testGroupName
+	tests := map[string]struct {
+		group          *clusterv1beta1.ClusterGroup
+		memberClusters []*clusterv1beta1.MemberCluster
+		wantLabeled    []string
+		wantStatus     []string
+		wantFinalizer  bool
+		wantDeleted    bool
+	}{
+		"add and remove group labels": {
+			group: &clusterv1beta1.ClusterGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: testGroupName},
+				Spec: clusterv1beta1.ClusterGroupSpec{
+					ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+					ClusterNames:    []string{"member-3"},
+				},
+			},
+			memberClusters: []*clusterv1beta1.MemberCluster{
+				memberCluster("member-1", map[string]string{"env": "prod"}),
+				memberCluster("member-2", map[string]string{"env": "staging", groupLabelKey: clusterv1beta1.ClusterGroupLabelValue}),
+				memberCluster("member-3", nil),
+			},
+			wantLabeled:   []string{"member-1", "member-3"},
+			wantStatus:    []string{"member-1", "member-3"},
+			wantFinalizer: true,
+		},
+		"remove group labels on deletion": {
+			group: &clusterv1beta1.ClusterGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              testGroupName,
+					DeletionTimestamp: &metav1.Time{Time: metav1.Now().Time},
+					Finalizers:        []string{clusterv1beta1.ClusterGroupCleanupFinalizer},
+				},
+				Spec: clusterv1beta1.ClusterGroupSpec{
+					ClusterNames: []string{"member-1"},
+				},
+			},
+			memberClusters: []*clusterv1beta1.MemberCluster{
+				memberCluster("member-1", map[string]string{groupLabelKey: clusterv1beta1.ClusterGroupLabelValue}),
+			},
+			wantLabeled: []string{},
+			wantDeleted: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme() = %v, want nil", err)
+			}
+			objs := []client.Object{tc.group}
+			for _, mc := range tc.memberClusters {
+				objs = append(objs, mc)
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objs...).
+				WithStatusSubresource(tc.group).
+				Build()
+			r := &Reconciler{Client: fakeClient}
+			if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: testGroupName}}); err != nil {
+				t.Fatalf("Reconcile() got error %v, want nil", err)
+			}
+
+			mcList := &clusterv1beta1.MemberClusterList{}
+			if err := fakeClient.List(ctx, mcList); err != nil {
+				t.Fatalf("failed to list member clusters: %v", err)
+			}
+			gotLabeled := []string{}
+			for _, mc := range mcList.Items {
+				if mc.Labels[groupLabelKey] == clusterv1beta1.ClusterGroupLabelValue {
+					gotLabeled = append(gotLabeled, mc.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.wantLabeled, gotLabeled, sortStringsOption); diff != "" {
+				t.Errorf("labeled member clusters mismatch (-want, +got):\n%s", diff)
+			}
+
+			group := &clusterv1beta1.ClusterGroup{}
+			err := fakeClient.Get(ctx, types.NamespacedName{Name: testGroupName}, group)
+			if tc.wantDeleted {
+				if err == nil {
+					t.Errorf("cluster group still exists after its finalizer is removed, want deleted")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get the cluster group: %v", err)
+			}
+			if got := controllerutil.ContainsFinalizer(group, clusterv1beta1.ClusterGroupCleanupFinalizer); got != tc.wantFinalizer {
+				t.Errorf("cluster group has cleanup finalizer = %v, want %v", got, tc.wantFinalizer)
+			}
+			if diff := cmp.Diff(tc.wantStatus, group.Status.MemberClusters); diff != "" {
+				t.Errorf("status member clusters mismatch (-want, +got):\n%s", diff)
+			}
+			if group.Status.MemberClusterCount != len(tc.wantStatus) {
+				t.Errorf("status member cluster count = %d, want %d", group.Status.MemberClusterCount, len(tc.wantStatus))
+			}
+			cond := group.GetCondition(string(clusterv1beta1.ClusterGroupConditionTypeSynced))
+			if cond == nil || cond.Status != metav1.ConditionTrue {
+				t.Errorf("synced condition = %v, want true", cond)
+			}
+		})
+	}
+}
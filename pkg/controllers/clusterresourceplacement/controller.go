@@ -56,6 +56,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, key controller.QueueKey) (ct
 	if err := r.Client.Get(ctx, types.NamespacedName{Name: name}, &crp); err != nil {
 		if apierrors.IsNotFound(err) {
 			klog.V(4).InfoS("Ignoring NotFound clusterResourcePlacement", "clusterResourcePlacement", name)
+			r.untrackPlacementMetrics(name)
+			forgetPlacementNotifications(name)
 			return ctrl.Result{}, nil
 		}
 		klog.ErrorS(err, "Failed to get clusterResourcePlacement", "clusterResourcePlacement", name)
@@ -80,6 +82,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, key controller.QueueKey) (ct
 
 func (r *Reconciler) handleDelete(ctx context.Context, crp *fleetv1beta1.ClusterResourcePlacement) (ctrl.Result, error) {
 	crpKObj := klog.KObj(crp)
+	r.untrackPlacementMetrics(crp.Name)
+	forgetPlacementNotifications(crp.Name)
 	if !controllerutil.ContainsFinalizer(crp, fleetv1beta1.ClusterResourcePlacementCleanupFinalizer) {
 		klog.V(4).InfoS("clusterResourcePlacement is being deleted and no cleanup work needs to be done by the CRP controller, waiting for the scheduler to cleanup the bindings", "clusterResourcePlacement", crpKObj)
 		return ctrl.Result{}, nil
@@ -200,6 +204,9 @@ func (r *Reconciler) handleUpdate(ctx context.Context, crp *fleetv1beta1.Cluster
 		return ctrl.Result{}, err
 	}
 	klog.V(2).InfoS("Updated the clusterResourcePlacement status", "clusterResourcePlacement", crpKObj)
+	r.trackPlacementAvailabilityMetrics(crp)
+	r.trackPlacementRolloutDuration(crp, isRolloutCompleted(crp))
+	r.notifyPlacementEvents(oldCRP, crp)
 
 	// We skip checking the last resource condition (available) because it will be covered by checking isRolloutCompleted func.
 	for i := condition.RolloutStartedCondition; i < condition.TotalCondition-1; i++ {
@@ -910,6 +917,7 @@ func (r *Reconciler) setPlacementStatus(ctx context.Context, crp *fleetv1beta1.C
 		// The undeleted resources on these old clusters could lead to failed synchronized or applied condition.
 		// Today, we only track the resources progress if the same cluster is selected again.
 		crp.Status.PlacementStatuses = []fleetv1beta1.ResourcePlacementStatus{}
+		crp.Status.ApplyErrorSummary = nil
 		return false, nil
 	}
 
@@ -0,0 +1,90 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterresourceplacement
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+	"go.goms.io/fleet/pkg/utils/condition"
+)
+
+// rolloutStartTimes keeps the time when the controller first observes that the rollout of a CRP
+// has not completed, keyed by the CRP names.
+//
+// Note that the start times are kept in memory only; if the hub agent restarts amid a rollout, the
+// duration of the rollout is measured from when the controller observes the CRP after the restart.
+var rolloutStartTimes sync.Map
+
+// trackPlacementAvailabilityMetrics reports the number (and the percentage) of selected clusters
+// where the resources of the CRP have become available.
+func (r *Reconciler) trackPlacementAvailabilityMetrics(crp *fleetv1beta1.ClusterResourcePlacement) {
+	name, ok := r.PlacementMetricsLabeler.LabelFor(crp.Name)
+	if !ok {
+		// The gauges of different CRPs cannot be aggregated; skip the CRPs without their own series.
+		return
+	}
+	selected, available := countSelectedAndAvailableClusters(crp)
+	// A CRP that selects no cluster is considered as fully available, which is consistent with
+	// how the CRP available condition is computed.
+	percent := 100.0
+	if selected > 0 {
+		percent = float64(available) * 100 / float64(selected)
+	}
+	metrics.PlacementSelectedClusterCount.WithLabelValues(name).Set(float64(selected))
+	metrics.PlacementAvailableClusterCount.WithLabelValues(name).Set(float64(available))
+	metrics.PlacementAvailableClusterPercent.WithLabelValues(name).Set(percent)
+}
+
+// trackPlacementRolloutDuration reports how long the rollout of a CRP takes once it completes.
+func (r *Reconciler) trackPlacementRolloutDuration(crp *fleetv1beta1.ClusterResourcePlacement, completed bool) {
+	if !completed {
+		rolloutStartTimes.LoadOrStore(crp.Name, time.Now())
+		return
+	}
+	startTime, found := rolloutStartTimes.LoadAndDelete(crp.Name)
+	if !found {
+		// The controller has not observed the rollout in progress, e.g., the CRP has been
+		// available since the hub agent starts.
+		return
+	}
+	// Unlike the gauges, the durations of the CRPs without their own series are aggregated
+	// under a shared label value.
+	name, _ := r.PlacementMetricsLabeler.LabelFor(crp.Name)
+	metrics.PlacementRolloutDurationSeconds.WithLabelValues(name).Observe(time.Since(startTime.(time.Time)).Seconds())
+}
+
+// untrackPlacementMetrics removes the per-CRP metrics of a CRP that is gone.
+func (r *Reconciler) untrackPlacementMetrics(crpName string) {
+	metrics.PlacementSelectedClusterCount.DeleteLabelValues(crpName)
+	metrics.PlacementAvailableClusterCount.DeleteLabelValues(crpName)
+	metrics.PlacementAvailableClusterPercent.DeleteLabelValues(crpName)
+	metrics.PlacementRolloutDurationSeconds.DeleteLabelValues(crpName)
+	rolloutStartTimes.Delete(crpName)
+	r.PlacementMetricsLabeler.Forget(crpName)
+}
+
+// countSelectedAndAvailableClusters returns the number of selected clusters and the number of
+// selected clusters where the resources have become available, based on the CRP status.
+func countSelectedAndAvailableClusters(crp *fleetv1beta1.ClusterResourcePlacement) (selected, available int) {
+	for i := range crp.Status.PlacementStatuses {
+		ps := &crp.Status.PlacementStatuses[i]
+		// Placement statuses without a cluster name are reported for unselected clusters.
+		if len(ps.ClusterName) == 0 {
+			continue
+		}
+		selected++
+		availableCond := meta.FindStatusCondition(ps.Conditions, string(fleetv1beta1.ResourcesAvailableConditionType))
+		if condition.IsConditionStatusTrue(availableCond, crp.Generation) {
+			available++
+		}
+	}
+	return selected, available
+}
@@ -0,0 +1,190 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterresourceplacement
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+)
+
+// TestTrackPlacementAvailabilityMetrics tests the trackPlacementAvailabilityMetrics and the
+// untrackPlacementMetrics methods.
+func TestTrackPlacementAvailabilityMetrics(t *testing.T) {
+	availableConditions := func(status metav1.ConditionStatus, generation int64) []metav1.Condition {
+		return []metav1.Condition{
+			{
+				Type:               string(fleetv1beta1.ResourcesAvailableConditionType),
+				Status:             status,
+				ObservedGeneration: generation,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		crp           *fleetv1beta1.ClusterResourcePlacement
+		wantSelected  float64
+		wantAvailable float64
+		wantPercent   float64
+	}{
+		{
+			name: "no cluster is selected",
+			crp: &fleetv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       testName,
+					Generation: 1,
+				},
+			},
+			wantSelected:  0,
+			wantAvailable: 0,
+			wantPercent:   100,
+		},
+		{
+			name: "resources are available on some of the selected clusters",
+			crp: &fleetv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       testName,
+					Generation: 2,
+				},
+				Status: fleetv1beta1.ClusterResourcePlacementStatus{
+					PlacementStatuses: []fleetv1beta1.ResourcePlacementStatus{
+						{
+							ClusterName: "member-1",
+							Conditions:  availableConditions(metav1.ConditionTrue, 2),
+						},
+						{
+							ClusterName: "member-2",
+							Conditions:  availableConditions(metav1.ConditionFalse, 2),
+						},
+						{
+							// The condition is stale.
+							ClusterName: "member-3",
+							Conditions:  availableConditions(metav1.ConditionTrue, 1),
+						},
+						{
+							ClusterName: "member-4",
+							Conditions:  availableConditions(metav1.ConditionTrue, 2),
+						},
+						{
+							// Unselected cluster.
+							Conditions: []metav1.Condition{},
+						},
+					},
+				},
+			},
+			wantSelected:  4,
+			wantAvailable: 2,
+			wantPercent:   50,
+		},
+	}
+
+	r := &Reconciler{}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r.trackPlacementAvailabilityMetrics(tc.crp)
+			defer r.untrackPlacementMetrics(tc.crp.Name)
+
+			if got := testutil.ToFloat64(metrics.PlacementSelectedClusterCount.WithLabelValues(tc.crp.Name)); got != tc.wantSelected {
+				t.Errorf("placement_selected_cluster_count = %v, want %v", got, tc.wantSelected)
+			}
+			if got := testutil.ToFloat64(metrics.PlacementAvailableClusterCount.WithLabelValues(tc.crp.Name)); got != tc.wantAvailable {
+				t.Errorf("placement_available_cluster_count = %v, want %v", got, tc.wantAvailable)
+			}
+			if got := testutil.ToFloat64(metrics.PlacementAvailableClusterPercent.WithLabelValues(tc.crp.Name)); got != tc.wantPercent {
+				t.Errorf("placement_available_cluster_percent = %v, want %v", got, tc.wantPercent)
+			}
+		})
+	}
+
+	r.untrackPlacementMetrics(testName)
+	if c := testutil.CollectAndCount(metrics.PlacementAvailableClusterPercent); c != 0 {
+		t.Errorf("placement_available_cluster_percent metric count after untracking = %d, want 0", c)
+	}
+}
+
+// TestTrackPlacementMetricsWithLabeler tests the per-CRP metrics with a capped number of series.
+func TestTrackPlacementMetricsWithLabeler(t *testing.T) {
+	r := &Reconciler{
+		PlacementMetricsLabeler: metrics.NewPlacementLabeler([]string{"critical-crp"}, 1),
+	}
+	crpNames := []string{"critical-crp", "crp-1", "crp-2"}
+	for _, name := range crpNames {
+		crp := &fleetv1beta1.ClusterResourcePlacement{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		r.trackPlacementRolloutDuration(crp, false)
+		r.trackPlacementAvailabilityMetrics(crp)
+		r.trackPlacementRolloutDuration(crp, true)
+	}
+	defer func() {
+		for _, name := range append(crpNames, metrics.OtherPlacementsLabelValue) {
+			r.untrackPlacementMetrics(name)
+		}
+	}()
+
+	// crp-2 is beyond the cap.
+	if c := testutil.CollectAndCount(metrics.PlacementSelectedClusterCount); c != 2 {
+		t.Errorf("placement_selected_cluster_count metric count = %d, want 2", c)
+	}
+	if c := testutil.CollectAndCount(metrics.PlacementRolloutDurationSeconds); c != 3 {
+		t.Errorf("placement_rollout_duration_seconds metric count = %d, want 3", c)
+	}
+	if c := rolloutDurationSampleCount(t, metrics.OtherPlacementsLabelValue); c != 1 {
+		t.Errorf("placement_rollout_duration_seconds sample count of other CRPs = %d, want 1", c)
+	}
+
+	// Untracking crp-1 makes room for crp-2.
+	r.untrackPlacementMetrics("crp-1")
+	r.trackPlacementAvailabilityMetrics(&fleetv1beta1.ClusterResourcePlacement{ObjectMeta: metav1.ObjectMeta{Name: "crp-2"}})
+	if got := testutil.ToFloat64(metrics.PlacementAvailableClusterPercent.WithLabelValues("crp-2")); got != 100 {
+		t.Errorf("placement_available_cluster_percent of crp-2 = %v, want 100", got)
+	}
+}
+
+// TestTrackPlacementRolloutDuration tests the trackPlacementRolloutDuration method.
+func TestTrackPlacementRolloutDuration(t *testing.T) {
+	r := &Reconciler{}
+	crp := &fleetv1beta1.ClusterResourcePlacement{ObjectMeta: metav1.ObjectMeta{Name: testName}}
+	defer r.untrackPlacementMetrics(testName)
+
+	// The rollout is not observed in progress.
+	r.trackPlacementRolloutDuration(crp, true)
+	if c := testutil.CollectAndCount(metrics.PlacementRolloutDurationSeconds); c != 0 {
+		t.Fatalf("placement_rollout_duration_seconds metric count = %d, want 0", c)
+	}
+
+	r.trackPlacementRolloutDuration(crp, false)
+	r.trackPlacementRolloutDuration(crp, false)
+	r.trackPlacementRolloutDuration(crp, true)
+	// The rollout has been observed already.
+	r.trackPlacementRolloutDuration(crp, true)
+	if c := testutil.CollectAndCount(metrics.PlacementRolloutDurationSeconds); c != 1 {
+		t.Fatalf("placement_rollout_duration_seconds metric count = %d, want 1", c)
+	}
+	if c := rolloutDurationSampleCount(t, testName); c != 1 {
+		t.Errorf("placement_rollout_duration_seconds sample count of %s = %d, want 1", testName, c)
+	}
+}
+
+// rolloutDurationSampleCount returns the number of observations in the rollout duration histogram
+// of a CRP.
+func rolloutDurationSampleCount(t *testing.T, name string) uint64 {
+	t.Helper()
+	observer, err := metrics.PlacementRolloutDurationSeconds.GetMetricWithLabelValues(name)
+	if err != nil {
+		t.Fatalf("failed to get placement_rollout_duration_seconds metric of %s: %v", name, err)
+	}
+	m := &dto.Metric{}
+	if err := observer.(prometheus.Metric).Write(m); err != nil {
+		t.Fatalf("failed to read placement_rollout_duration_seconds metric of %s: %v", name, err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
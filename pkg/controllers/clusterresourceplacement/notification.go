@@ -0,0 +1,125 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterresourceplacement
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/notifier"
+	"go.goms.io/fleet/pkg/utils/condition"
+)
+
+// rolloutStallNotified keeps the names of the CRPs whose in-progress rollouts have been notified as
+// stalled, so that a stalled rollout is notified only once.
+var rolloutStallNotified sync.Map
+
+// notifyPlacementEvents notifies the events of a CRP, based on how its status has changed in this
+// reconciliation.
+//
+// Note that it must be called after trackPlacementRolloutDuration, which tracks when the rollout of
+// the CRP starts.
+func (r *Reconciler) notifyPlacementEvents(oldCRP, crp *fleetv1beta1.ClusterResourcePlacement) {
+	if r.Notifier == nil {
+		return
+	}
+	now := metav1.Now()
+	for _, event := range placementEvents(oldCRP, crp, now) {
+		r.Notifier.Notify(crp, event)
+	}
+
+	if isRolloutCompleted(crp) {
+		rolloutStallNotified.Delete(crp.Name)
+		return
+	}
+	if r.RolloutStallThreshold <= 0 {
+		return
+	}
+	// The controller requeues the CRPs with in-progress rollouts periodically, so a stalled rollout
+	// is noticed even if the CRP status does not change.
+	startTime, found := rolloutStartTimes.Load(crp.Name)
+	if !found || now.Sub(startTime.(time.Time)) < r.RolloutStallThreshold {
+		return
+	}
+	if _, notified := rolloutStallNotified.LoadOrStore(crp.Name, true); notified {
+		return
+	}
+	r.Notifier.Notify(crp, notifier.Event{
+		Type:       notifier.PlacementRolloutStalled,
+		Placement:  crp.Name,
+		Generation: crp.Generation,
+		Reason:     "PlacementRolloutStalled",
+		Message:    fmt.Sprintf("The rollout has not completed after %s", r.RolloutStallThreshold),
+		Time:       now,
+	})
+}
+
+// forgetPlacementNotifications removes the notification states of a CRP that is gone.
+func forgetPlacementNotifications(crpName string) {
+	rolloutStallNotified.Delete(crpName)
+}
+
+// placementEvents returns the events of a CRP which happen as its status changes from the old one,
+// i.e., the CRP becomes available, or the resources fail on a selected cluster.
+func placementEvents(oldCRP, crp *fleetv1beta1.ClusterResourcePlacement, now metav1.Time) []notifier.Event {
+	var events []notifier.Event
+	if isRolloutCompleted(crp) && !isRolloutCompleted(oldCRP) {
+		event := notifier.Event{
+			Type:       notifier.PlacementAvailable,
+			Placement:  crp.Name,
+			Generation: crp.Generation,
+			Time:       now,
+		}
+		if cond := crp.GetCondition(string(fleetv1beta1.ClusterResourcePlacementAvailableConditionType)); cond != nil {
+			event.Reason = cond.Reason
+			event.Message = cond.Message
+		}
+		events = append(events, event)
+	}
+
+	oldStatusByCluster := make(map[string]*fleetv1beta1.ResourcePlacementStatus, len(oldCRP.Status.PlacementStatuses))
+	for i := range oldCRP.Status.PlacementStatuses {
+		oldStatusByCluster[oldCRP.Status.PlacementStatuses[i].ClusterName] = &oldCRP.Status.PlacementStatuses[i]
+	}
+	for i := range crp.Status.PlacementStatuses {
+		ps := &crp.Status.PlacementStatuses[i]
+		// Placement statuses without a cluster name are reported for unselected clusters.
+		if len(ps.ClusterName) == 0 {
+			continue
+		}
+		for _, condType := range []fleetv1beta1.ResourcePlacementConditionType{
+			fleetv1beta1.ResourcesAppliedConditionType,
+			fleetv1beta1.ResourcesAvailableConditionType,
+		} {
+			cond := meta.FindStatusCondition(ps.Conditions, string(condType))
+			if !condition.IsConditionStatusFalse(cond, crp.Generation) {
+				continue
+			}
+			if oldPS := oldStatusByCluster[ps.ClusterName]; oldPS != nil &&
+				condition.IsConditionStatusFalse(meta.FindStatusCondition(oldPS.Conditions, string(condType)), crp.Generation) {
+				// The failure has been notified.
+				continue
+			}
+			events = append(events, notifier.Event{
+				Type:       notifier.PlacementClusterFailed,
+				Placement:  crp.Name,
+				Generation: crp.Generation,
+				Cluster:    ps.ClusterName,
+				Reason:     cond.Reason,
+				Message:    cond.Message,
+				Time:       now,
+			})
+			// Notify one failure per cluster at a time.
+			break
+		}
+	}
+	return events
+}
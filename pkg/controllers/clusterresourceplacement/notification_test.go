@@ -0,0 +1,155 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterresourceplacement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/notifier"
+	"go.goms.io/fleet/pkg/utils/condition"
+)
+
+func TestPlacementEvents(t *testing.T) {
+	now := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	completedConditions := func(generation int64) []metav1.Condition {
+		conds := []metav1.Condition{
+			{
+				Type:               string(fleetv1beta1.ClusterResourcePlacementScheduledConditionType),
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: generation,
+			},
+		}
+		for i := condition.RolloutStartedCondition; i < condition.TotalCondition; i++ {
+			conds = append(conds, metav1.Condition{
+				Type:               string(i.ClusterResourcePlacementConditionType()),
+				Status:             metav1.ConditionTrue,
+				Reason:             "Succeeded",
+				Message:            "All good",
+				ObservedGeneration: generation,
+			})
+		}
+		return conds
+	}
+	clusterStatus := func(cluster string, condType fleetv1beta1.ResourcePlacementConditionType, status metav1.ConditionStatus, generation int64) fleetv1beta1.ResourcePlacementStatus {
+		return fleetv1beta1.ResourcePlacementStatus{
+			ClusterName: cluster,
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(condType),
+					Status:             status,
+					Reason:             "Failed",
+					Message:            "Something went wrong",
+					ObservedGeneration: generation,
+				},
+			},
+		}
+	}
+	crpWith := func(generation int64, conds []metav1.Condition, statuses ...fleetv1beta1.ResourcePlacementStatus) *fleetv1beta1.ClusterResourcePlacement {
+		return &fleetv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       testName,
+				Generation: generation,
+			},
+			Status: fleetv1beta1.ClusterResourcePlacementStatus{
+				Conditions:        conds,
+				PlacementStatuses: statuses,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		oldCRP *fleetv1beta1.ClusterResourcePlacement
+		crp    *fleetv1beta1.ClusterResourcePlacement
+		want   []notifier.Event
+	}{
+		{
+			name:   "placement becomes available",
+			oldCRP: crpWith(1, nil),
+			crp:    crpWith(1, completedConditions(1)),
+			want: []notifier.Event{
+				{
+					Type:       notifier.PlacementAvailable,
+					Placement:  testName,
+					Generation: 1,
+					Reason:     "Succeeded",
+					Message:    "All good",
+					Time:       now,
+				},
+			},
+		},
+		{
+			name:   "placement stays available",
+			oldCRP: crpWith(1, completedConditions(1)),
+			crp:    crpWith(1, completedConditions(1)),
+		},
+		{
+			name:   "placement becomes available at a new generation",
+			oldCRP: crpWith(2, completedConditions(1)),
+			crp:    crpWith(2, completedConditions(2)),
+			want: []notifier.Event{
+				{
+					Type:       notifier.PlacementAvailable,
+					Placement:  testName,
+					Generation: 2,
+					Reason:     "Succeeded",
+					Message:    "All good",
+					Time:       now,
+				},
+			},
+		},
+		{
+			name:   "resources fail on clusters",
+			oldCRP: crpWith(1, nil, clusterStatus("member-1", fleetv1beta1.ResourcesAppliedConditionType, metav1.ConditionTrue, 1)),
+			crp: crpWith(1, nil,
+				clusterStatus("member-1", fleetv1beta1.ResourcesAppliedConditionType, metav1.ConditionFalse, 1),
+				clusterStatus("member-2", fleetv1beta1.ResourcesAvailableConditionType, metav1.ConditionFalse, 1),
+				// Stale failure.
+				clusterStatus("member-3", fleetv1beta1.ResourcesAppliedConditionType, metav1.ConditionFalse, 0),
+				// Unselected cluster.
+				clusterStatus("", fleetv1beta1.ResourcesAppliedConditionType, metav1.ConditionFalse, 1),
+			),
+			want: []notifier.Event{
+				{
+					Type:       notifier.PlacementClusterFailed,
+					Placement:  testName,
+					Generation: 1,
+					Cluster:    "member-1",
+					Reason:     "Failed",
+					Message:    "Something went wrong",
+					Time:       now,
+				},
+				{
+					Type:       notifier.PlacementClusterFailed,
+					Placement:  testName,
+					Generation: 1,
+					Cluster:    "member-2",
+					Reason:     "Failed",
+					Message:    "Something went wrong",
+					Time:       now,
+				},
+			},
+		},
+		{
+			name:   "failure has been notified",
+			oldCRP: crpWith(1, nil, clusterStatus("member-1", fleetv1beta1.ResourcesAppliedConditionType, metav1.ConditionFalse, 1)),
+			crp:    crpWith(1, nil, clusterStatus("member-1", fleetv1beta1.ResourcesAppliedConditionType, metav1.ConditionFalse, 1)),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := placementEvents(tc.oldCRP, tc.crp, now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("placementEvents() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
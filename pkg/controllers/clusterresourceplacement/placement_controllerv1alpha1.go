@@ -23,6 +23,7 @@ import (
 
 	fleetv1alpha1 "go.goms.io/fleet/apis/v1alpha1"
 	"go.goms.io/fleet/pkg/metrics"
+	"go.goms.io/fleet/pkg/notifier"
 	"go.goms.io/fleet/pkg/utils"
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/informer"
@@ -58,6 +59,18 @@ type Reconciler struct {
 	Recorder record.EventRecorder
 
 	Scheme *runtime.Scheme
+
+	// PlacementMetricsLabeler controls which CRPs have their own series in the per-CRP metrics.
+	// It's only needed by v1beta1 APIs; a nil labeler gives every CRP its own series.
+	PlacementMetricsLabeler *metrics.PlacementLabeler
+
+	// Notifier pushes the events of the CRPs to external webhooks; a nil notifier disables the notifications.
+	// It's only needed by v1beta1 APIs.
+	Notifier *notifier.Notifier
+
+	// RolloutStallThreshold is how long the rollout of a CRP can stay in progress before it is notified
+	// as stalled; 0 disables the notification.
+	RolloutStallThreshold time.Duration
 }
 
 // ReconcileV1Alpha1 reconciles v1aplha1 APIs.
@@ -8,6 +8,7 @@ package clusterresourceplacement
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -130,6 +131,7 @@ func (r *Reconciler) setResourceConditions(ctx context.Context, crp *fleetv1beta
 		klog.V(2).InfoS("Populated the resource placement status for the unscheduled cluster", "clusterResourcePlacement", klog.KObj(crp), "cluster", unselected[i].ClusterName)
 	}
 	crp.Status.PlacementStatuses = placementStatuses
+	crp.Status.ApplyErrorSummary = buildApplyErrorSummary(placementStatuses)
 
 	if !isClusterScheduled {
 		// It covers one special case: CRP selects a cluster which joins (resource are applied) and then leaves.
@@ -177,11 +179,39 @@ func (r *Reconciler) setResourceConditions(ctx context.Context, crp *fleetv1beta
 	return true, nil
 }
 
+// buildApplyErrorSummary counts the clusters where some resources fail to be applied by the category of the errors.
+// A cluster is counted once for each category of the errors of its failed resources.
+func buildApplyErrorSummary(placementStatuses []fleetv1beta1.ResourcePlacementStatus) []fleetv1beta1.ApplyErrorCount {
+	clusterCounts := make(map[fleetv1beta1.ApplyErrorCategory]int32)
+	for i := range placementStatuses {
+		categories := make(map[fleetv1beta1.ApplyErrorCategory]bool)
+		for _, failed := range placementStatuses[i].FailedPlacements {
+			if failed.ApplyErrorCategory != "" {
+				categories[failed.ApplyErrorCategory] = true
+			}
+		}
+		for category := range categories {
+			clusterCounts[category]++
+		}
+	}
+	if len(clusterCounts) == 0 {
+		return nil
+	}
+	summary := make([]fleetv1beta1.ApplyErrorCount, 0, len(clusterCounts))
+	for category, count := range clusterCounts {
+		summary = append(summary, fleetv1beta1.ApplyErrorCount{Category: category, ClusterCount: count})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].Category < summary[j].Category
+	})
+	return summary
+}
+
 func (r *Reconciler) buildClusterResourceBindings(ctx context.Context, crp *fleetv1beta1.ClusterResourcePlacement, latestSchedulingPolicySnapshot *fleetv1beta1.ClusterSchedulingPolicySnapshot) (map[string]*fleetv1beta1.ClusterResourceBinding, error) {
 	// List all bindings derived from the CRP.
 	bindingList := &fleetv1beta1.ClusterResourceBindingList{}
-	listOptions := client.MatchingLabels{
-		fleetv1beta1.CRPTrackingLabel: crp.Name,
+	listOptions := client.MatchingFields{
+		controller.BindingCRPTrackingIndexKey: crp.Name,
 	}
 	crpKObj := klog.KObj(crp)
 	if err := r.Client.List(ctx, bindingList, listOptions); err != nil {
@@ -24,6 +24,7 @@ import (
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
 )
 
 var statusCmpOptions = []cmp.Option{
@@ -3154,6 +3155,7 @@ func TestSetPlacementStatus(t *testing.T) {
 			fakeClient := fake.NewClientBuilder().
 				WithScheme(scheme).
 				WithObjects(objects...).
+				WithIndex(&fleetv1beta1.ClusterResourceBinding{}, controller.BindingCRPTrackingIndexKey, controller.ExtractBindingCRPTracking).
 				Build()
 			r := Reconciler{
 				Client:   fakeClient,
@@ -3267,6 +3269,74 @@ func TestBuildResourcePlacementStatusMap(t *testing.T) {
 	}
 }
 
+func TestBuildApplyErrorSummary(t *testing.T) {
+	failed := func(name string, category fleetv1beta1.ApplyErrorCategory) fleetv1beta1.FailedResourcePlacement {
+		return fleetv1beta1.FailedResourcePlacement{
+			ResourceIdentifier: fleetv1beta1.ResourceIdentifier{Version: "v1", Kind: "ConfigMap", Name: name, Namespace: "app"},
+			ApplyErrorCategory: category,
+		}
+	}
+	tests := []struct {
+		name   string
+		status []fleetv1beta1.ResourcePlacementStatus
+		want   []fleetv1beta1.ApplyErrorCount
+	}{
+		{
+			name:   "nil status",
+			status: nil,
+		},
+		{
+			name: "no failed resources",
+			status: []fleetv1beta1.ResourcePlacementStatus{
+				{ClusterName: "member-1"},
+			},
+		},
+		{
+			name: "unavailable resources only",
+			status: []fleetv1beta1.ResourcePlacementStatus{
+				{
+					ClusterName:      "member-1",
+					FailedPlacements: []fleetv1beta1.FailedResourcePlacement{failed("cm-1", "")},
+				},
+			},
+		},
+		{
+			name: "failed resources across clusters",
+			status: []fleetv1beta1.ResourcePlacementStatus{
+				{
+					ClusterName: "member-1",
+					FailedPlacements: []fleetv1beta1.FailedResourcePlacement{
+						failed("cm-1", fleetv1beta1.ApplyErrorCategoryRBAC),
+						failed("cm-2", fleetv1beta1.ApplyErrorCategoryRBAC),
+						failed("cm-3", fleetv1beta1.ApplyErrorCategoryAdmission),
+					},
+				},
+				{
+					ClusterName: "member-2",
+					FailedPlacements: []fleetv1beta1.FailedResourcePlacement{
+						failed("cm-1", fleetv1beta1.ApplyErrorCategoryRBAC),
+					},
+				},
+				{
+					ClusterName: "member-3",
+				},
+			},
+			want: []fleetv1beta1.ApplyErrorCount{
+				{Category: fleetv1beta1.ApplyErrorCategoryAdmission, ClusterCount: 1},
+				{Category: fleetv1beta1.ApplyErrorCategoryRBAC, ClusterCount: 2},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := buildApplyErrorSummary(tc.status)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("buildApplyErrorSummary() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestBuildClusterResourceBindings(t *testing.T) {
 	policySnapshotName := "policy-2"
 	tests := []struct {
@@ -3412,6 +3482,7 @@ func TestBuildClusterResourceBindings(t *testing.T) {
 			fakeClient := fake.NewClientBuilder().
 				WithScheme(scheme).
 				WithObjects(objects...).
+				WithIndex(&fleetv1beta1.ClusterResourceBinding{}, controller.BindingCRPTrackingIndexKey, controller.ExtractBindingCRPTracking).
 				Build()
 			r := Reconciler{
 				Client: fakeClient,
@@ -99,6 +99,7 @@ var _ = BeforeSuite(func() {
 		Logger: textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(4))),
 	})
 	Expect(err).Should(Succeed(), "failed to create manager")
+	Expect(controller.SetupBindingCRPTrackingIndex(ctx, mgr.GetFieldIndexer())).Should(Succeed(), "failed to set up the binding index")
 
 	reconciler := &Reconciler{
 		Client:          mgr.GetClient(),
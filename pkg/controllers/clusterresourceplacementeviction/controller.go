@@ -13,7 +13,6 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	runtime "sigs.k8s.io/controller-runtime"
@@ -99,7 +98,7 @@ func (r *Reconciler) validateEviction(ctx context.Context, eviction *placementv1
 	validationResult.crp = &crp
 
 	var crbList placementv1beta1.ClusterResourceBindingList
-	if err := r.Client.List(ctx, &crbList, client.MatchingLabels{placementv1beta1.CRPTrackingLabel: crp.Name}); err != nil {
+	if err := r.Client.List(ctx, &crbList, client.MatchingFields{controller.BindingCRPTrackingIndexKey: crp.Name}); err != nil {
 		return nil, controller.NewAPIServerError(true, err)
 	}
 	validationResult.bindings = crbList.Items
@@ -198,16 +197,14 @@ func (r *Reconciler) executeEviction(ctx context.Context, validationResult *evic
 	}
 
 	// handle special case for PickAll CRP.
-	if crp.Spec.Policy.PlacementType == placementv1beta1.PickAllPlacementType {
-		if db.Spec.MaxUnavailable != nil || (db.Spec.MinAvailable != nil && db.Spec.MinAvailable.Type == intstr.String) {
-			markEvictionNotExecuted(eviction, condition.EvictionBlockedMisconfiguredPDBSpecifiedMessage)
-			return nil
-		}
+	if bindingutils.IsDisruptionBudgetMisconfigured(crp, &db) {
+		markEvictionNotExecuted(eviction, condition.EvictionBlockedMisconfiguredPDBSpecifiedMessage)
+		return nil
 	}
 
 	totalBindings := len(bindingList)
-	allowed, availableBindings := isEvictionAllowed(bindingList, *crp, db)
-	if allowed {
+	disruptionsAllowed, availableBindings := bindingutils.DisruptionsAllowed(bindingList, crp, &db)
+	if disruptionsAllowed > 0 {
 		if err := r.deleteClusterResourceBinding(ctx, evictionTargetBinding); err != nil {
 			return err
 		}
@@ -249,46 +246,6 @@ func isPlacementPresent(binding *placementv1beta1.ClusterResourceBinding) bool {
 	return false
 }
 
-// isEvictionAllowed calculates if eviction allowed based on available bindings and spec specified in placement disruption budget.
-func isEvictionAllowed(bindings []placementv1beta1.ClusterResourceBinding, crp placementv1beta1.ClusterResourcePlacement, db placementv1alpha1.ClusterResourcePlacementDisruptionBudget) (bool, int) {
-	availableBindings := 0
-	for i := range bindings {
-		availableCondition := bindings[i].GetCondition(string(placementv1beta1.ResourceBindingAvailable))
-		if condition.IsConditionStatusTrue(availableCondition, bindings[i].GetGeneration()) {
-			availableBindings++
-		}
-	}
-
-	var desiredBindings int
-	placementType := crp.Spec.Policy.PlacementType
-	// we don't know the desired bindings for PickAll and we won't evict a binding for PickFixed CRP.
-	if placementType == placementv1beta1.PickNPlacementType {
-		desiredBindings = int(*crp.Spec.Policy.NumberOfClusters)
-	}
-
-	var disruptionsAllowed int
-	switch {
-	// For PickAll CRPs, MaxUnavailable won't be specified in DB.
-	case db.Spec.MaxUnavailable != nil:
-		maxUnavailable, _ := intstr.GetScaledValueFromIntOrPercent(db.Spec.MaxUnavailable, desiredBindings, true)
-		unavailableBindings := len(bindings) - availableBindings
-		disruptionsAllowed = maxUnavailable - unavailableBindings
-	case db.Spec.MinAvailable != nil:
-		var minAvailable int
-		if placementType == placementv1beta1.PickAllPlacementType {
-			// MinAvailable will be an Integer value for PickAll CRP.
-			minAvailable = db.Spec.MinAvailable.IntValue()
-		} else {
-			minAvailable, _ = intstr.GetScaledValueFromIntOrPercent(db.Spec.MinAvailable, desiredBindings, true)
-		}
-		disruptionsAllowed = availableBindings - minAvailable
-	}
-	if disruptionsAllowed < 0 {
-		disruptionsAllowed = 0
-	}
-	return disruptionsAllowed > 0, availableBindings
-}
-
 // markEvictionValid sets the valid condition as true in eviction status.
 func markEvictionValid(eviction *placementv1alpha1.ClusterResourcePlacementEviction) {
 	cond := metav1.Condition{
@@ -26,6 +26,7 @@ import (
 
 	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
 )
 
 var (
@@ -82,9 +83,11 @@ var _ = BeforeSuite(func() {
 		Logger: textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(4))),
 	})
 	Expect(err).Should(Succeed())
+	Expect(controller.SetupBindingCRPTrackingIndex(ctx, mgr.GetFieldIndexer())).Should(Succeed())
 
+	// The bindings are looked up by the index, which the manager client (cache) serves.
 	err = (&Reconciler{
-		Client: k8sClient,
+		Client: mgr.GetClient(),
 	}).SetupWithManager(mgr)
 	Expect(err).Should(Succeed())
 
@@ -0,0 +1,211 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package clusterschedulingdecision features a controller that maintains one ClusterSchedulingDecision
+// object per (CRP, cluster) pair, which mirrors the scheduling decisions in the latest policy snapshot
+// of the CRP, so that the decisions can be watched.
+package clusterschedulingdecision
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework/uniquename"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// Reconciler reconciles the ClusterSchedulingDecision objects of a CRP with the scheduling decisions
+// in the latest policy snapshot of the CRP.
+type Reconciler struct {
+	client.Client
+}
+
+// Reconcile creates, updates, and deletes the ClusterSchedulingDecision objects of the CRP, so that
+// there is exactly one object for each cluster decision in the latest policy snapshot.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	crpRef := klog.KRef("", req.Name)
+	startTime := time.Now()
+	klog.V(2).InfoS("Reconciliation starts (cluster scheduling decision controller)", "clusterResourcePlacement", crpRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("Reconciliation ends (cluster scheduling decision controller)", "clusterResourcePlacement", crpRef, "latency", latency)
+	}()
+
+	crp := &placementv1beta1.ClusterResourcePlacement{}
+	if err := r.Get(ctx, req.NamespacedName, crp); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The decisions are garbage collected with the CRP.
+			klog.V(2).InfoS("Cluster resource placement is not found", "clusterResourcePlacement", crpRef)
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get cluster resource placement", "clusterResourcePlacement", crpRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	if crp.DeletionTimestamp != nil {
+		klog.V(2).InfoS("Cluster resource placement is being deleted", "clusterResourcePlacement", crpRef)
+		return ctrl.Result{}, nil
+	}
+
+	policySnapshot, err := r.latestScheduledPolicySnapshot(ctx, crp.Name)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get the latest policy snapshot", "clusterResourcePlacement", crpRef)
+		return ctrl.Result{}, err
+	}
+	if policySnapshot == nil {
+		// The scheduler has not scheduled the latest policy yet; keep the last known decisions, and
+		// the controller will be triggered again when the scheduler does.
+		klog.V(2).InfoS("The latest policy snapshot has not been scheduled yet", "clusterResourcePlacement", crpRef)
+		return ctrl.Result{}, nil
+	}
+
+	desired := buildDecisions(crp, policySnapshot)
+	decisionList := &placementv1alpha1.ClusterSchedulingDecisionList{}
+	if err := r.List(ctx, decisionList, client.MatchingLabels{placementv1beta1.CRPTrackingLabel: crp.Name}); err != nil {
+		klog.ErrorS(err, "Failed to list the cluster scheduling decisions", "clusterResourcePlacement", crpRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	for i := range decisionList.Items {
+		decision := &decisionList.Items[i]
+		want, found := desired[decision.Name]
+		if !found {
+			if err := r.Delete(ctx, decision); err != nil {
+				klog.ErrorS(err, "Failed to delete the cluster scheduling decision", "clusterSchedulingDecision", klog.KObj(decision))
+				return ctrl.Result{}, controller.NewDeleteIgnoreNotFoundError(err)
+			}
+			klog.V(2).InfoS("Deleted the cluster scheduling decision", "clusterSchedulingDecision", klog.KObj(decision))
+			continue
+		}
+		delete(desired, decision.Name)
+		if equality.Semantic.DeepEqual(decision.Spec, want.Spec) &&
+			equality.Semantic.DeepEqual(decision.Labels, want.Labels) &&
+			equality.Semantic.DeepEqual(decision.OwnerReferences, want.OwnerReferences) {
+			continue
+		}
+		decision.Labels = want.Labels
+		decision.OwnerReferences = want.OwnerReferences
+		decision.Spec = want.Spec
+		if err := r.Update(ctx, decision); err != nil {
+			klog.ErrorS(err, "Failed to update the cluster scheduling decision", "clusterSchedulingDecision", klog.KObj(decision))
+			return ctrl.Result{}, controller.NewUpdateIgnoreConflictError(err)
+		}
+		klog.V(2).InfoS("Updated the cluster scheduling decision", "clusterSchedulingDecision", klog.KObj(decision))
+	}
+	for _, decision := range desired {
+		if err := r.Create(ctx, decision); err != nil {
+			klog.ErrorS(err, "Failed to create the cluster scheduling decision", "clusterSchedulingDecision", klog.KObj(decision))
+			return ctrl.Result{}, controller.NewCreateIgnoreAlreadyExistError(err)
+		}
+		klog.V(2).InfoS("Created the cluster scheduling decision", "clusterSchedulingDecision", klog.KObj(decision))
+	}
+	return ctrl.Result{}, nil
+}
+
+// latestScheduledPolicySnapshot returns the latest policy snapshot of the CRP; it returns nil if the
+// latest policy snapshot has not been scheduled yet.
+func (r *Reconciler) latestScheduledPolicySnapshot(ctx context.Context, crpName string) (*placementv1beta1.ClusterSchedulingPolicySnapshot, error) {
+	policySnapshotList := &placementv1beta1.ClusterSchedulingPolicySnapshotList{}
+	if err := r.List(ctx, policySnapshotList, client.MatchingLabels{
+		placementv1beta1.CRPTrackingLabel:      crpName,
+		placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+	}); err != nil {
+		return nil, controller.NewAPIServerError(true, err)
+	}
+	if len(policySnapshotList.Items) != 1 {
+		// The latest policy snapshot has not been created yet, or the sequence of policy snapshots
+		// is in an inconsistent state, which the CRP controller will correct.
+		return nil, nil
+	}
+
+	policySnapshot := &policySnapshotList.Items[0]
+	scheduledCond := meta.FindStatusCondition(policySnapshot.Status.Conditions, string(placementv1beta1.PolicySnapshotScheduled))
+	if scheduledCond == nil || scheduledCond.ObservedGeneration != policySnapshot.Generation {
+		return nil, nil
+	}
+	return policySnapshot, nil
+}
+
+// buildDecisions returns the ClusterSchedulingDecision objects of the CRP per the policy snapshot,
+// keyed by their names.
+func buildDecisions(crp *placementv1beta1.ClusterResourcePlacement, policySnapshot *placementv1beta1.ClusterSchedulingPolicySnapshot) map[string]*placementv1alpha1.ClusterSchedulingDecision {
+	decisions := make(map[string]*placementv1alpha1.ClusterSchedulingDecision, len(policySnapshot.Status.ClusterDecisions))
+	for i := range policySnapshot.Status.ClusterDecisions {
+		d := &policySnapshot.Status.ClusterDecisions[i]
+		name, err := uniquename.NewClusterSchedulingDecisionName(crp.Name, d.ClusterName)
+		if err != nil {
+			// This should never happen, as both the CRP name and the cluster name are valid DNS label names.
+			klog.ErrorS(err, "Failed to build the cluster scheduling decision name", "clusterResourcePlacement", klog.KObj(crp), "cluster", d.ClusterName)
+			continue
+		}
+		decisions[name] = &placementv1alpha1.ClusterSchedulingDecision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel:    crp.Name,
+					placementv1alpha1.TargetClusterLabel: d.ClusterName,
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(crp, placementv1beta1.GroupVersion.WithKind(placementv1beta1.ClusterResourcePlacementKind)),
+				},
+			},
+			Spec: placementv1alpha1.ClusterSchedulingDecisionSpec{
+				PlacementName:      crp.Name,
+				ClusterName:        d.ClusterName,
+				PolicySnapshotName: policySnapshot.Name,
+				Selected:           d.Selected,
+				ClusterScore:       d.ClusterScore.DeepCopy(),
+				Reason:             d.Reason,
+			},
+		}
+	}
+	return decisions
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cluster-scheduling-decision-controller").
+		// Sync the decisions of the existing CRPs on start; the decisions of a deleted CRP are
+		// garbage collected.
+		For(&placementv1beta1.ClusterResourcePlacement{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(_ event.UpdateEvent) bool {
+				return false
+			},
+			DeleteFunc: func(_ event.DeleteEvent) bool {
+				return false
+			},
+		})).
+		// Sync the decisions when the scheduler updates the latest policy snapshot.
+		Watches(&placementv1beta1.ClusterSchedulingPolicySnapshot{},
+			handler.EnqueueRequestsFromMapFunc(enqueueCRPForPolicySnapshot)).
+		// Revert the changes made to the decisions by others.
+		Owns(&placementv1alpha1.ClusterSchedulingDecision{}).
+		Complete(r)
+}
+
+// enqueueCRPForPolicySnapshot returns the reconcile request for the CRP which owns the policy snapshot.
+func enqueueCRPForPolicySnapshot(_ context.Context, obj client.Object) []reconcile.Request {
+	crpName, ok := obj.GetLabels()[placementv1beta1.CRPTrackingLabel]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: crpName}}}
+}
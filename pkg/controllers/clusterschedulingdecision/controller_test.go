@@ -0,0 +1,187 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterschedulingdecision
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework/uniquename"
+)
+
+const (
+	testCRPName = "test-crp"
+)
+
+var (
+	testPolicySnapshotName = fmt.Sprintf(placementv1beta1.PolicySnapshotNameFmt, testCRPName, 0)
+)
+
+func policySnapshot(scheduled bool, decisions ...placementv1beta1.ClusterDecision) *placementv1beta1.ClusterSchedulingPolicySnapshot {
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testPolicySnapshotName,
+			Generation: 1,
+			Labels: map[string]string{
+				placementv1beta1.CRPTrackingLabel:      testCRPName,
+				placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+			},
+		},
+		Status: placementv1beta1.SchedulingPolicySnapshotStatus{
+			ClusterDecisions: decisions,
+		},
+	}
+	if scheduled {
+		policy.Status.Conditions = []metav1.Condition{
+			{
+				Type:               string(placementv1beta1.PolicySnapshotScheduled),
+				Status:             metav1.ConditionTrue,
+				Reason:             "Scheduled",
+				ObservedGeneration: 1,
+			},
+		}
+	}
+	return policy
+}
+
+var testCRP = &placementv1beta1.ClusterResourcePlacement{
+	ObjectMeta: metav1.ObjectMeta{
+		Name: testCRPName,
+		UID:  "test-uid",
+	},
+}
+
+func decision(t *testing.T, clusterName string, selected bool, reason string) *placementv1alpha1.ClusterSchedulingDecision {
+	name, err := uniquename.NewClusterSchedulingDecisionName(testCRPName, clusterName)
+	if err != nil {
+		t.Fatalf("NewClusterSchedulingDecisionName() = %v, want no error", err)
+	}
+	return &placementv1alpha1.ClusterSchedulingDecision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				placementv1beta1.CRPTrackingLabel:    testCRPName,
+				placementv1alpha1.TargetClusterLabel: clusterName,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(testCRP, placementv1beta1.GroupVersion.WithKind(placementv1beta1.ClusterResourcePlacementKind)),
+			},
+		},
+		Spec: placementv1alpha1.ClusterSchedulingDecisionSpec{
+			PlacementName:      testCRPName,
+			ClusterName:        clusterName,
+			PolicySnapshotName: testPolicySnapshotName,
+			Selected:           selected,
+			Reason:             reason,
+		},
+	}
+}
+
+// TestReconcile tests the Reconcile method.
+func TestReconcile(t *testing.T) {
+	crp := testCRP
+	scoredDecision := decision(t, "member-1", true, "picked")
+	scoredDecision.Spec.ClusterScore = &placementv1beta1.ClusterScore{AffinityScore: ptr.To(int32(10))}
+
+	tests := map[string]struct {
+		objs []client.Object
+		want []*placementv1alpha1.ClusterSchedulingDecision
+	}{
+		"placement not found": {
+			objs: []client.Object{decision(t, "member-1", true, "picked")},
+			want: []*placementv1alpha1.ClusterSchedulingDecision{decision(t, "member-1", true, "picked")},
+		},
+		"scheduling pending keeps the last known decisions": {
+			objs: []client.Object{
+				crp.DeepCopy(),
+				policySnapshot(false, placementv1beta1.ClusterDecision{ClusterName: "member-2", Selected: true, Reason: "picked"}),
+				decision(t, "member-1", true, "picked"),
+			},
+			want: []*placementv1alpha1.ClusterSchedulingDecision{decision(t, "member-1", true, "picked")},
+		},
+		"decisions created": {
+			objs: []client.Object{
+				crp.DeepCopy(),
+				policySnapshot(true,
+					placementv1beta1.ClusterDecision{ClusterName: "member-1", Selected: true, Reason: "picked", ClusterScore: &placementv1beta1.ClusterScore{AffinityScore: ptr.To(int32(10))}},
+					placementv1beta1.ClusterDecision{ClusterName: "member-2", Selected: false, Reason: "not picked"},
+				),
+			},
+			want: []*placementv1alpha1.ClusterSchedulingDecision{
+				scoredDecision,
+				decision(t, "member-2", false, "not picked"),
+			},
+		},
+		"decisions updated and deleted": {
+			objs: []client.Object{
+				crp.DeepCopy(),
+				policySnapshot(true,
+					placementv1beta1.ClusterDecision{ClusterName: "member-1", Selected: false, Reason: "not picked"},
+					placementv1beta1.ClusterDecision{ClusterName: "member-3", Selected: true, Reason: "picked"},
+				),
+				decision(t, "member-1", true, "picked"),
+				decision(t, "member-2", true, "picked"),
+			},
+			want: []*placementv1alpha1.ClusterSchedulingDecision{
+				decision(t, "member-1", false, "not picked"),
+				decision(t, "member-3", true, "picked"),
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := placementv1alpha1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add placement v1alpha1 scheme: %v", err)
+			}
+			if err := placementv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add placement v1beta1 scheme: %v", err)
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tc.objs...).
+				Build()
+			r := &Reconciler{Client: fakeClient}
+
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: testCRPName}}); err != nil {
+				t.Fatalf("Reconcile() = %v, want no error", err)
+			}
+
+			decisionList := &placementv1alpha1.ClusterSchedulingDecisionList{}
+			if err := fakeClient.List(context.Background(), decisionList); err != nil {
+				t.Fatalf("failed to list the cluster scheduling decisions: %v", err)
+			}
+			got := make([]*placementv1alpha1.ClusterSchedulingDecision, 0, len(decisionList.Items))
+			for i := range decisionList.Items {
+				d := &decisionList.Items[i]
+				got = append(got, &placementv1alpha1.ClusterSchedulingDecision{
+					ObjectMeta: metav1.ObjectMeta{Name: d.Name, Labels: d.Labels, OwnerReferences: d.OwnerReferences},
+					Spec:       d.Spec,
+				})
+			}
+			sort.Slice(got, func(i, j int) bool {
+				return got[i].Spec.ClusterName < got[j].Spec.ClusterName
+			})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("cluster scheduling decisions mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
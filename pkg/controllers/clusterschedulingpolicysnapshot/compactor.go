@@ -0,0 +1,236 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterschedulingpolicysnapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// compactorControllerName is the name of the policy snapshot compaction controller.
+	compactorControllerName = "cluster-scheduling-policy-snapshot-compactor"
+
+	// compactedConditionMessage is the message set on the conditions of a compacted policy snapshot.
+	compactedConditionMessage = "the condition message has been removed as the policy snapshot has been inactive beyond the retention period"
+)
+
+// decisionSummary summarizes the cluster decisions removed from a compacted policy snapshot.
+type decisionSummary struct {
+	// CompactedAt is the time when the policy snapshot is compacted.
+	CompactedAt metav1.Time `json:"compactedAt"`
+	// SelectedClusterCount is the number of selected clusters in the removed decisions.
+	SelectedClusterCount int `json:"selectedClusterCount"`
+	// UnselectedClusterCount is the number of unselected clusters in the removed decisions.
+	UnselectedClusterCount int `json:"unselectedClusterCount"`
+}
+
+// CompactionReconciler compacts inactive clusterSchedulingPolicySnapshot objects, i.e., it removes
+// the cluster decisions and the condition messages from a policy snapshot once the snapshot has
+// been inactive beyond the retention period, leaving only a summary of the decisions behind.
+//
+// This helps reduce the storage footprint of long-lived CRPs with many policy changes.
+type CompactionReconciler struct {
+	client.Client
+
+	// RetentionPeriod is how long the full scheduling decisions on a policy snapshot are kept
+	// after the snapshot becomes inactive.
+	RetentionPeriod time.Duration
+}
+
+// Reconcile compacts an inactive policy snapshot if it has been inactive beyond the retention period.
+func (r *CompactionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	snapshotKRef := klog.KRef(req.Namespace, req.Name)
+
+	startTime := time.Now()
+	klog.V(2).InfoS("Compaction reconciliation starts", "clusterSchedulingPolicySnapshot", snapshotKRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("Compaction reconciliation ends", "clusterSchedulingPolicySnapshot", snapshotKRef, "latency", latency)
+	}()
+
+	snapshot := &fleetv1beta1.ClusterSchedulingPolicySnapshot{}
+	if err := r.Client.Get(ctx, req.NamespacedName, snapshot); err != nil {
+		if errors.IsNotFound(err) {
+			klog.V(4).InfoS("Ignoring NotFound clusterSchedulingPolicySnapshot", "clusterSchedulingPolicySnapshot", snapshotKRef)
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get clusterSchedulingPolicySnapshot", "clusterSchedulingPolicySnapshot", snapshotKRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+
+	if !snapshot.DeletionTimestamp.IsZero() || isLatestPolicySnapshot(snapshot) {
+		// Only inactive policy snapshots are compacted.
+		return ctrl.Result{}, nil
+	}
+	_, hasSummary := snapshot.Annotations[fleetv1beta1.CompactedDecisionSummaryAnnotation]
+	if hasSummary && isPolicySnapshotStatusCompacted(snapshot) {
+		klog.V(4).InfoS("The clusterSchedulingPolicySnapshot has been compacted", "clusterSchedulingPolicySnapshot", snapshotKRef)
+		return ctrl.Result{}, nil
+	}
+
+	inactiveSince, err := r.lookupInactiveSince(ctx, snapshot)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if inactiveSince == nil {
+		// A newer policy snapshot has not been created yet; the CRP controller will create one soon.
+		klog.V(2).InfoS("No newer clusterSchedulingPolicySnapshot is found; skip the compaction", "clusterSchedulingPolicySnapshot", snapshotKRef)
+		return ctrl.Result{}, nil
+	}
+	if remaining := r.RetentionPeriod - time.Since(inactiveSince.Time); remaining > 0 {
+		klog.V(2).InfoS("The clusterSchedulingPolicySnapshot is still within the retention period", "clusterSchedulingPolicySnapshot", snapshotKRef, "inactiveSince", inactiveSince, "remaining", remaining)
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	// Write the summary first, so that the summary can always be built from the full decisions
+	// should the status update fail.
+	if !hasSummary {
+		if err := r.addDecisionSummary(ctx, snapshot); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if err := r.compactPolicySnapshotStatus(ctx, snapshot); err != nil {
+		return ctrl.Result{}, err
+	}
+	klog.V(2).InfoS("Compacted the clusterSchedulingPolicySnapshot", "clusterSchedulingPolicySnapshot", snapshotKRef, "inactiveSince", inactiveSince)
+	return ctrl.Result{}, nil
+}
+
+// lookupInactiveSince returns the time when the given policy snapshot becomes inactive, i.e., when
+// the immediately newer policy snapshot of the same CRP is created.
+//
+// Nil is returned if there is no newer policy snapshot.
+func (r *CompactionReconciler) lookupInactiveSince(ctx context.Context, snapshot *fleetv1beta1.ClusterSchedulingPolicySnapshot) (*metav1.Time, error) {
+	snapshotKObj := klog.KObj(snapshot)
+	crpName := snapshot.Labels[fleetv1beta1.CRPTrackingLabel]
+	index, err := strconv.Atoi(snapshot.Labels[fleetv1beta1.PolicyIndexLabel])
+	if len(crpName) == 0 || err != nil {
+		err := fmt.Errorf("invalid label value %s or %s", fleetv1beta1.CRPTrackingLabel, fleetv1beta1.PolicyIndexLabel)
+		klog.ErrorS(err, "Invalid clusterSchedulingPolicySnapshot", "clusterSchedulingPolicySnapshot", snapshotKObj)
+		return nil, controller.NewUnexpectedBehaviorError(err)
+	}
+
+	snapshotList := &fleetv1beta1.ClusterSchedulingPolicySnapshotList{}
+	if err := r.Client.List(ctx, snapshotList, client.MatchingLabels{fleetv1beta1.CRPTrackingLabel: crpName}); err != nil {
+		klog.ErrorS(err, "Failed to list clusterSchedulingPolicySnapshots", "clusterResourcePlacement", crpName)
+		return nil, controller.NewAPIServerError(true, err)
+	}
+
+	var next *fleetv1beta1.ClusterSchedulingPolicySnapshot
+	nextIndex := -1
+	for i := range snapshotList.Items {
+		candidate := &snapshotList.Items[i]
+		candidateIndex, err := strconv.Atoi(candidate.Labels[fleetv1beta1.PolicyIndexLabel])
+		if err != nil || candidateIndex <= index {
+			continue
+		}
+		if next == nil || candidateIndex < nextIndex {
+			next, nextIndex = candidate, candidateIndex
+		}
+	}
+	if next == nil {
+		return nil, nil
+	}
+	return &next.CreationTimestamp, nil
+}
+
+// addDecisionSummary adds the decision summary annotation to the policy snapshot.
+func (r *CompactionReconciler) addDecisionSummary(ctx context.Context, snapshot *fleetv1beta1.ClusterSchedulingPolicySnapshot) error {
+	snapshotKObj := klog.KObj(snapshot)
+	summary := decisionSummary{
+		CompactedAt: metav1.Now(),
+	}
+	for _, decision := range snapshot.Status.ClusterDecisions {
+		if decision.Selected {
+			summary.SelectedClusterCount++
+		} else {
+			summary.UnselectedClusterCount++
+		}
+	}
+	// The scheduler might have already aggregated the unselected clusters into per-reason counts.
+	for _, count := range snapshot.Status.UnselectedClusterSummary {
+		summary.UnselectedClusterCount += count.Count
+	}
+	summaryJSON, err := json.Marshal(summary)
+	if err != nil {
+		klog.ErrorS(err, "Failed to marshal the decision summary", "clusterSchedulingPolicySnapshot", snapshotKObj)
+		return controller.NewUnexpectedBehaviorError(err)
+	}
+
+	annotations := snapshot.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[fleetv1beta1.CompactedDecisionSummaryAnnotation] = string(summaryJSON)
+	snapshot.SetAnnotations(annotations)
+	if err := r.Client.Update(ctx, snapshot); err != nil {
+		klog.ErrorS(err, "Failed to add the decision summary annotation", "clusterSchedulingPolicySnapshot", snapshotKObj)
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	return nil
+}
+
+// compactPolicySnapshotStatus removes the cluster decisions, the unselected cluster summary, and the
+// condition messages from the status of the policy snapshot.
+func (r *CompactionReconciler) compactPolicySnapshotStatus(ctx context.Context, snapshot *fleetv1beta1.ClusterSchedulingPolicySnapshot) error {
+	snapshot.Status.ClusterDecisions = nil
+	snapshot.Status.UnselectedClusterSummary = nil
+	for i := range snapshot.Status.Conditions {
+		snapshot.Status.Conditions[i].Message = compactedConditionMessage
+	}
+	if err := r.Client.Status().Update(ctx, snapshot); err != nil {
+		klog.ErrorS(err, "Failed to compact the status", "clusterSchedulingPolicySnapshot", klog.KObj(snapshot))
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	return nil
+}
+
+// isLatestPolicySnapshot returns if the policy snapshot is the latest one of its CRP.
+func isLatestPolicySnapshot(snapshot *fleetv1beta1.ClusterSchedulingPolicySnapshot) bool {
+	isLatest, err := strconv.ParseBool(snapshot.Labels[fleetv1beta1.IsLatestSnapshotLabel])
+	// Treat a snapshot with an invalid label value as the latest one to be on the safe side.
+	return err != nil || isLatest
+}
+
+// isPolicySnapshotStatusCompacted returns if the status of the policy snapshot has been compacted.
+func isPolicySnapshotStatusCompacted(snapshot *fleetv1beta1.ClusterSchedulingPolicySnapshot) bool {
+	if len(snapshot.Status.ClusterDecisions) > 0 || len(snapshot.Status.UnselectedClusterSummary) > 0 {
+		return false
+	}
+	for i := range snapshot.Status.Conditions {
+		if snapshot.Status.Conditions[i].Message != compactedConditionMessage {
+			return false
+		}
+	}
+	return true
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CompactionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(compactorControllerName).
+		For(&fleetv1beta1.ClusterSchedulingPolicySnapshot{}).
+		WithEventFilter(predicate.Funcs{
+			DeleteFunc: func(e event.DeleteEvent) bool {
+				return false
+			},
+		}).Complete(r)
+}
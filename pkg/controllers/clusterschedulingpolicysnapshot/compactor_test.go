@@ -0,0 +1,186 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterschedulingpolicysnapshot
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	testRetentionPeriod = time.Hour
+)
+
+func policySnapshotWithDecisions(index int, isLatest bool, createdAt time.Time) *fleetv1beta1.ClusterSchedulingPolicySnapshot {
+	return &fleetv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testCRPName + "-" + strconv.Itoa(index),
+			Labels: map[string]string{
+				fleetv1beta1.PolicyIndexLabel:      strconv.Itoa(index),
+				fleetv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(isLatest),
+				fleetv1beta1.CRPTrackingLabel:      testCRPName,
+			},
+			CreationTimestamp: metav1.NewTime(createdAt),
+		},
+		Status: fleetv1beta1.SchedulingPolicySnapshotStatus{
+			ClusterDecisions: []fleetv1beta1.ClusterDecision{
+				{ClusterName: "member-1", Selected: true, Reason: "picked"},
+				{ClusterName: "member-2", Selected: true, Reason: "picked"},
+				{ClusterName: "member-3", Selected: false, Reason: "not picked"},
+			},
+			Conditions: []metav1.Condition{
+				{
+					Type:    string(fleetv1beta1.PolicySnapshotScheduled),
+					Status:  metav1.ConditionTrue,
+					Reason:  "Scheduled",
+					Message: "all required clusters are selected",
+				},
+			},
+		},
+	}
+}
+
+// TestCompactionReconcile tests the Reconcile function of the CompactionReconciler.
+func TestCompactionReconcile(t *testing.T) {
+	now := time.Now()
+	tests := map[string]struct {
+		snapshots       []*fleetv1beta1.ClusterSchedulingPolicySnapshot
+		reconcileIndex  int
+		wantCompacted   bool
+		wantSummary     *decisionSummary
+		wantRequeue     bool
+		wantRequeueOver time.Duration
+	}{
+		"latest snapshot is never compacted": {
+			snapshots: []*fleetv1beta1.ClusterSchedulingPolicySnapshot{
+				policySnapshotWithDecisions(0, true, now.Add(-2*testRetentionPeriod)),
+			},
+			reconcileIndex: 0,
+		},
+		"inactive snapshot within the retention period": {
+			snapshots: []*fleetv1beta1.ClusterSchedulingPolicySnapshot{
+				policySnapshotWithDecisions(0, false, now.Add(-2*testRetentionPeriod)),
+				policySnapshotWithDecisions(1, true, now.Add(-testRetentionPeriod/2)),
+			},
+			reconcileIndex:  0,
+			wantRequeue:     true,
+			wantRequeueOver: testRetentionPeriod / 4,
+		},
+		"inactive snapshot beyond the retention period": {
+			snapshots: []*fleetv1beta1.ClusterSchedulingPolicySnapshot{
+				policySnapshotWithDecisions(0, false, now.Add(-3*testRetentionPeriod)),
+				policySnapshotWithDecisions(1, false, now.Add(-2*testRetentionPeriod)),
+				policySnapshotWithDecisions(2, true, now.Add(-testRetentionPeriod/2)),
+			},
+			reconcileIndex: 0,
+			wantCompacted:  true,
+			wantSummary: &decisionSummary{
+				SelectedClusterCount:   2,
+				UnselectedClusterCount: 1,
+			},
+		},
+		"inactive snapshot with scheduler-compacted decisions": {
+			snapshots: []*fleetv1beta1.ClusterSchedulingPolicySnapshot{
+				func() *fleetv1beta1.ClusterSchedulingPolicySnapshot {
+					snapshot := policySnapshotWithDecisions(0, false, now.Add(-3*testRetentionPeriod))
+					snapshot.Status.UnselectedClusterSummary = []fleetv1beta1.UnselectedClusterCount{
+						{Reason: "ClusterAffinity", Count: 40},
+						{Reason: "NotPickedByScore", Count: 2},
+					}
+					return snapshot
+				}(),
+				policySnapshotWithDecisions(1, true, now.Add(-2*testRetentionPeriod)),
+			},
+			reconcileIndex: 0,
+			wantCompacted:  true,
+			wantSummary: &decisionSummary{
+				SelectedClusterCount:   2,
+				UnselectedClusterCount: 43,
+			},
+		},
+		"inactive snapshot whose next snapshot is within the retention period": {
+			snapshots: []*fleetv1beta1.ClusterSchedulingPolicySnapshot{
+				policySnapshotWithDecisions(0, false, now.Add(-3*testRetentionPeriod)),
+				policySnapshotWithDecisions(1, false, now.Add(-testRetentionPeriod/2)),
+				policySnapshotWithDecisions(2, true, now.Add(-testRetentionPeriod/4)),
+			},
+			reconcileIndex: 0,
+			wantRequeue:    true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme() = %v, want nil", err)
+			}
+			objs := make([]client.Object, 0, len(tc.snapshots))
+			for _, s := range tc.snapshots {
+				objs = append(objs, s)
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objs...).
+				WithStatusSubresource(objs...).
+				Build()
+			r := &CompactionReconciler{
+				Client:          fakeClient,
+				RetentionPeriod: testRetentionPeriod,
+			}
+			name := tc.snapshots[tc.reconcileIndex].Name
+			got, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+			if err != nil {
+				t.Fatalf("Reconcile() got error %v, want nil", err)
+			}
+			if gotRequeue := got.RequeueAfter > 0; gotRequeue != tc.wantRequeue {
+				t.Errorf("Reconcile() requeueAfter = %v, want requeue %v", got.RequeueAfter, tc.wantRequeue)
+			}
+			if tc.wantRequeue && got.RequeueAfter < tc.wantRequeueOver {
+				t.Errorf("Reconcile() requeueAfter = %v, want over %v", got.RequeueAfter, tc.wantRequeueOver)
+			}
+
+			snapshot := &fleetv1beta1.ClusterSchedulingPolicySnapshot{}
+			if err := fakeClient.Get(ctx, types.NamespacedName{Name: name}, snapshot); err != nil {
+				t.Fatalf("failed to get the policy snapshot: %v", err)
+			}
+			if gotCompacted := isPolicySnapshotStatusCompacted(snapshot); gotCompacted != tc.wantCompacted {
+				t.Errorf("isPolicySnapshotStatusCompacted() = %v, want %v", gotCompacted, tc.wantCompacted)
+			}
+			summaryJSON, ok := snapshot.Annotations[fleetv1beta1.CompactedDecisionSummaryAnnotation]
+			if tc.wantSummary == nil {
+				if ok {
+					t.Errorf("got decision summary annotation %s, want no annotation", summaryJSON)
+				}
+				return
+			}
+			gotSummary := decisionSummary{}
+			if err := json.Unmarshal([]byte(summaryJSON), &gotSummary); err != nil {
+				t.Fatalf("failed to unmarshal the decision summary: %v", err)
+			}
+			if gotSummary.CompactedAt.IsZero() {
+				t.Errorf("decision summary compactedAt is zero, want non-zero")
+			}
+			gotSummary.CompactedAt = metav1.Time{}
+			if diff := cmp.Diff(*tc.wantSummary, gotSummary); diff != "" {
+				t.Errorf("decision summary mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
@@ -33,6 +35,7 @@ import (
 	"go.goms.io/fleet/pkg/propertyprovider"
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/version"
 )
 
 // propertyProviderConfig is a group of settings for configuring the the property provider.
@@ -77,7 +80,7 @@ type Reconciler struct {
 	//
 	// This client allows the controller to directly send requests to specific endpoints,
 	// specifically to allow health/readiness probes on the API server.
-	rawMemberClientSet *kubernetes.Clientset
+	rawMemberClientSet kubernetes.Interface
 
 	// the join/leave agent maintains the list of controllers in the member cluster
 	// so that it can make sure that all the agents on the member cluster have joined/left
@@ -97,6 +100,10 @@ type Reconciler struct {
 	// The property provider configuration.
 	propertyProviderCfg *propertyProviderConfig
 
+	// selfUpgradeCfg is the self-upgrade configuration of the member agent; self-upgrade is disabled
+	// if it is nil.
+	selfUpgradeCfg *SelfUpgradeConfig
+
 	recorder record.EventRecorder
 }
 
@@ -167,6 +174,7 @@ func NewReconciler(globalCtx context.Context,
 	memberClient client.Client,
 	workController controller.MemberController,
 	propertyProvider propertyprovider.PropertyProvider,
+	selfUpgradeCfg *SelfUpgradeConfig,
 ) (*Reconciler, error) {
 	rawMemberClientSet, err := kubernetes.NewForConfig(memberCfg)
 	if err != nil {
@@ -183,6 +191,7 @@ func NewReconciler(globalCtx context.Context,
 			memberConfig:     memberCfg,
 			propertyProvider: propertyProvider,
 		},
+		selfUpgradeCfg: selfUpgradeCfg,
 	}, nil
 }
 
@@ -206,8 +215,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			return ctrl.Result{}, err
 		}
 		updateMemberAgentHeartBeat(&imc)
+		if err := r.upgradeAgent(ctx, &imc); err != nil {
+			// The agent keeps running its current version if it fails to upgrade itself.
+			klog.ErrorS(err, "Failed to upgrade the member agent", "imc", klog.KObj(&imc))
+		}
 		updateHealthErr := r.updateHealth(ctx, &imc)
 		clusterPropertyCollectionErr := r.connectToPropertyProvider(ctx, &imc)
+		r.reportKubernetesVersion(&imc)
+		r.reportAPIGroupVersions(&imc)
 		r.markInternalMemberClusterJoined(&imc)
 		if err := r.updateInternalMemberClusterWithRetry(ctx, &imc); err != nil {
 			if apierrors.IsConflict(err) {
@@ -356,6 +371,62 @@ func (r *Reconciler) connectToPropertyProvider(ctx context.Context, imc *cluster
 	return nil
 }
 
+// reportKubernetesVersion reports the Kubernetes version of the member cluster, as observed from
+// its API server, as a group of cluster properties.
+//
+// A failure to retrieve the version is logged only, and does not block the heartbeat; the version
+// properties reported last time (if any) are kept.
+func (r *Reconciler) reportKubernetesVersion(imc *clusterv1beta1.InternalMemberCluster) {
+	serverVersion, err := r.rawMemberClientSet.Discovery().ServerVersion()
+	if err != nil {
+		klog.ErrorS(err, "Failed to retrieve the Kubernetes version of the member cluster", "internalMemberCluster", klog.KObj(imc))
+		return
+	}
+	v, err := utilversion.ParseGeneric(serverVersion.GitVersion)
+	if err != nil {
+		klog.ErrorS(err, "Failed to parse the Kubernetes version of the member cluster", "internalMemberCluster", klog.KObj(imc), "gitVersion", serverVersion.GitVersion)
+		return
+	}
+
+	if imc.Status.Properties == nil {
+		imc.Status.Properties = make(map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue)
+	}
+	observationTime := metav1.Now()
+	versionProperties := map[clusterv1beta1.PropertyName]uint{
+		propertyprovider.KubernetesMajorVersionProperty: v.Major(),
+		propertyprovider.KubernetesMinorVersionProperty: v.Minor(),
+		propertyprovider.KubernetesPatchVersionProperty: v.Patch(),
+	}
+	for name, value := range versionProperties {
+		imc.Status.Properties[name] = clusterv1beta1.PropertyValue{
+			Value:           fmt.Sprintf("%d", value),
+			ObservationTime: observationTime,
+		}
+	}
+}
+
+// reportAPIGroupVersions reports the API group versions served by the member cluster, as discovered
+// from its API server, so that the scheduler can filter out clusters missing the APIs (e.g., CRDs)
+// placements require.
+//
+// A failure to discover the API group versions is logged only, and does not block the heartbeat; the
+// API group versions reported last time (if any) are kept.
+func (r *Reconciler) reportAPIGroupVersions(imc *clusterv1beta1.InternalMemberCluster) {
+	groupList, err := r.rawMemberClientSet.Discovery().ServerGroups()
+	if err != nil {
+		klog.ErrorS(err, "Failed to discover the API groups of the member cluster", "internalMemberCluster", klog.KObj(imc))
+		return
+	}
+	groupVersions := make([]string, 0, len(groupList.Groups))
+	for _, group := range groupList.Groups {
+		for _, version := range group.Versions {
+			groupVersions = append(groupVersions, version.GroupVersion)
+		}
+	}
+	sort.Strings(groupVersions)
+	imc.Status.APIGroupVersions = groupVersions
+}
+
 // reportPropertyProviderCollectionCondition reports the condition of whether a property
 // collection attempt has been successful.
 func reportPropertyProviderCollectionCondition(imc *clusterv1beta1.InternalMemberCluster, status metav1.ConditionStatus, reason, message string) {
@@ -442,10 +513,8 @@ func (r *Reconciler) reportClusterPropertiesWithPropertyProvider(ctx context.Con
 		imc.Status.Properties = res.Properties
 		imc.Status.ResourceUsage = res.Resources
 		for idx := range res.Conditions {
-			cond := res.Conditions[idx]
 			// Reset the observed generation, as the property provider is not be aware of it.
-			cond.ObservedGeneration = imc.GetGeneration()
-			meta.SetStatusCondition(&imc.Status.Conditions, cond)
+			condition.SetConditionWithGeneration(&imc.Status.Conditions, res.Conditions[idx], imc.GetGeneration())
 		}
 		return nil
 	}
@@ -560,6 +629,7 @@ func updateMemberAgentHeartBeat(imc *clusterv1beta1.InternalMemberCluster) {
 	desiredAgentStatus := imc.GetAgentStatus(clusterv1beta1.MemberAgent)
 	if desiredAgentStatus != nil {
 		desiredAgentStatus.LastReceivedHeartbeat = metav1.Now()
+		desiredAgentStatus.Version = version.Version
 	}
 }
 
@@ -574,7 +644,7 @@ func (r *Reconciler) markInternalMemberClusterHealthy(imc clusterv1beta1.Conditi
 
 	// Healthy status changed.
 	existingCondition := imc.GetConditionWithType(clusterv1beta1.MemberAgent, newCondition.Type)
-	if existingCondition == nil || existingCondition.Status != newCondition.Status {
+	if condition.IsTransitioned(existingCondition, &newCondition) {
 		klog.V(2).InfoS("InternalMemberCluster is healthy", "internalMemberCluster", klog.KObj(imc))
 		r.recorder.Event(imc, corev1.EventTypeNormal, EventReasonInternalMemberClusterHealthy, "internal member cluster healthy")
 	}
@@ -594,7 +664,7 @@ func (r *Reconciler) markInternalMemberClusterUnhealthy(imc clusterv1beta1.Condi
 
 	// Healthy status changed.
 	existingCondition := imc.GetConditionWithType(clusterv1beta1.MemberAgent, newCondition.Type)
-	if existingCondition == nil || existingCondition.Status != newCondition.Status {
+	if condition.IsTransitioned(existingCondition, &newCondition) {
 		klog.V(2).InfoS("InternalMemberCluster is unhealthy", "internalMemberCluster", klog.KObj(imc))
 		r.recorder.Event(imc, corev1.EventTypeWarning, EventReasonInternalMemberClusterUnhealthy, "internal member cluster unhealthy")
 	}
@@ -613,7 +683,7 @@ func (r *Reconciler) markInternalMemberClusterJoined(imc clusterv1beta1.Conditio
 
 	// Joined status changed.
 	existingCondition := imc.GetConditionWithType(clusterv1beta1.MemberAgent, newCondition.Type)
-	if existingCondition == nil || existingCondition.ObservedGeneration != imc.GetGeneration() || existingCondition.Status != newCondition.Status {
+	if condition.IsTransitioned(existingCondition, &newCondition) || existingCondition.ObservedGeneration != imc.GetGeneration() {
 		r.recorder.Event(imc, corev1.EventTypeNormal, EventReasonInternalMemberClusterJoined, "internal member cluster joined")
 		klog.V(2).InfoS("InternalMemberCluster has joined", "internalMemberCluster", klog.KObj(imc))
 		metrics.ReportJoinResultMetric()
@@ -634,7 +704,7 @@ func (r *Reconciler) markInternalMemberClusterJoinFailed(imc clusterv1beta1.Cond
 
 	// Joined status changed.
 	existingCondition := imc.GetConditionWithType(clusterv1beta1.MemberAgent, newCondition.Type)
-	if existingCondition == nil || existingCondition.ObservedGeneration != imc.GetGeneration() || existingCondition.Status != newCondition.Status {
+	if condition.IsTransitioned(existingCondition, &newCondition) || existingCondition.ObservedGeneration != imc.GetGeneration() {
 		r.recorder.Event(imc, corev1.EventTypeNormal, EventReasonInternalMemberClusterFailedToJoin, "internal member cluster failed to join")
 		klog.ErrorS(err, "Agent failed to join", "internalMemberCluster", klog.KObj(imc))
 	}
@@ -653,7 +723,7 @@ func (r *Reconciler) markInternalMemberClusterLeft(imc clusterv1beta1.Conditione
 
 	// Joined status changed.
 	existingCondition := imc.GetConditionWithType(clusterv1beta1.MemberAgent, newCondition.Type)
-	if existingCondition == nil || existingCondition.ObservedGeneration != imc.GetGeneration() || existingCondition.Status != newCondition.Status {
+	if condition.IsTransitioned(existingCondition, &newCondition) || existingCondition.ObservedGeneration != imc.GetGeneration() {
 		r.recorder.Event(imc, corev1.EventTypeNormal, EventReasonInternalMemberClusterLeft, "internal member cluster left")
 		klog.V(2).InfoS("InternalMemberCluster has left", "internalMemberCluster", klog.KObj(imc))
 		metrics.ReportLeaveResultMetric()
@@ -685,6 +755,8 @@ func (r *Reconciler) markInternalMemberClusterLeaveFailed(imc clusterv1beta1.Con
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.recorder = mgr.GetEventRecorderFor("v1beta1InternalMemberClusterController")
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&clusterv1beta1.InternalMemberCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Annotation changes are watched as well, as the hub agent declares the target version of the member agent
+		// with annotations.
+		For(&clusterv1beta1.InternalMemberCluster{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
 		Complete(r)
 }
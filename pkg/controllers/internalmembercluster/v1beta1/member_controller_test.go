@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"testing"
 	"time"
 
@@ -23,6 +24,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	k8sversion "k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,6 +35,7 @@ import (
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	"go.goms.io/fleet/pkg/propertyprovider"
 	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/version"
 )
 
 const (
@@ -106,6 +111,7 @@ func TestUpdateMemberAgentHeartBeat(t *testing.T) {
 	updateMemberAgentHeartBeat(internalMemberCluster)
 	lastReceivedHeartBeat := internalMemberCluster.Status.AgentStatus[0].LastReceivedHeartbeat
 	assert.NotNil(t, lastReceivedHeartBeat)
+	assert.Equal(t, version.Version, internalMemberCluster.Status.AgentStatus[0].Version)
 
 	updateMemberAgentHeartBeat(internalMemberCluster)
 	newLastReceivedHeartBeat := internalMemberCluster.Status.AgentStatus[0].LastReceivedHeartbeat
@@ -1457,3 +1463,114 @@ func TestConnectToPropertyProvider(t *testing.T) {
 		})
 	}
 }
+
+// TestReportKubernetesVersion tests the reportKubernetesVersion method.
+func TestReportKubernetesVersion(t *testing.T) {
+	existingProperties := map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+		propertyprovider.NodeCountProperty: {Value: "3"},
+	}
+
+	testCases := []struct {
+		name           string
+		gitVersion     string
+		properties     map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue
+		wantProperties map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue
+	}{
+		{
+			name:       "no properties reported yet",
+			gitVersion: "v1.29.4",
+			wantProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				propertyprovider.KubernetesMajorVersionProperty: {Value: "1"},
+				propertyprovider.KubernetesMinorVersionProperty: {Value: "29"},
+				propertyprovider.KubernetesPatchVersionProperty: {Value: "4"},
+			},
+		},
+		{
+			name:       "version with build metadata, along with other properties",
+			gitVersion: "v1.30.2+k3s1",
+			properties: existingProperties,
+			wantProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				propertyprovider.NodeCountProperty:              {Value: "3"},
+				propertyprovider.KubernetesMajorVersionProperty: {Value: "1"},
+				propertyprovider.KubernetesMinorVersionProperty: {Value: "30"},
+				propertyprovider.KubernetesPatchVersionProperty: {Value: "2"},
+			},
+		},
+		{
+			name:           "invalid version",
+			gitVersion:     "unknown",
+			properties:     existingProperties,
+			wantProperties: existingProperties,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientSet := fakekubernetes.NewSimpleClientset()
+			clientSet.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &k8sversion.Info{GitVersion: tc.gitVersion}
+			r := &Reconciler{
+				rawMemberClientSet: clientSet,
+			}
+			imc := &clusterv1beta1.InternalMemberCluster{
+				Status: clusterv1beta1.InternalMemberClusterStatus{
+					Properties: maps.Clone(tc.properties),
+				},
+			}
+
+			r.reportKubernetesVersion(imc)
+			if diff := cmp.Diff(imc.Status.Properties, tc.wantProperties, ignoreAllTimeFields); diff != "" {
+				t.Errorf("reportKubernetesVersion() properties (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestReportAPIGroupVersions tests the reportAPIGroupVersions method.
+func TestReportAPIGroupVersions(t *testing.T) {
+	testCases := []struct {
+		name              string
+		resources         []*metav1.APIResourceList
+		groupVersions     []string
+		wantGroupVersions []string
+	}{
+		{
+			name: "core and named groups",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "v1"},
+				{GroupVersion: "monitoring.coreos.com/v1"},
+				{GroupVersion: "apps/v1"},
+				{GroupVersion: "autoscaling/v2"},
+				{GroupVersion: "autoscaling/v1"},
+			},
+			wantGroupVersions: []string{"apps/v1", "autoscaling/v1", "autoscaling/v2", "monitoring.coreos.com/v1", "v1"},
+		},
+		{
+			name: "CRD removed",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "v1"},
+			},
+			groupVersions:     []string{"monitoring.coreos.com/v1", "v1"},
+			wantGroupVersions: []string{"v1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientSet := fakekubernetes.NewSimpleClientset()
+			clientSet.Discovery().(*fakediscovery.FakeDiscovery).Resources = tc.resources
+			r := &Reconciler{
+				rawMemberClientSet: clientSet,
+			}
+			imc := &clusterv1beta1.InternalMemberCluster{
+				Status: clusterv1beta1.InternalMemberClusterStatus{
+					APIGroupVersions: tc.groupVersions,
+				},
+			}
+
+			r.reportAPIGroupVersions(imc)
+			if diff := cmp.Diff(imc.Status.APIGroupVersions, tc.wantGroupVersions); diff != "" {
+				t.Errorf("reportAPIGroupVersions() API group versions (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
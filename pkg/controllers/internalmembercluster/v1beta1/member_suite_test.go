@@ -370,7 +370,7 @@ var _ = BeforeSuite(func() {
 	workApplier1 = work.NewApplyWorkReconciler(hubClient, nil, nil, nil, nil, 0, "")
 
 	propertyProvider1 = &manuallyUpdatedProvider{}
-	member1Reconciler, err := NewReconciler(ctx, hubClient, member1Cfg, member1Client, workApplier1, propertyProvider1)
+	member1Reconciler, err := NewReconciler(ctx, hubClient, member1Cfg, member1Client, workApplier1, propertyProvider1, nil)
 	Expect(err).NotTo(HaveOccurred())
 	Expect(member1Reconciler.SetupWithManager(member1Mgr)).To(Succeed())
 
@@ -392,7 +392,7 @@ var _ = BeforeSuite(func() {
 	// run.
 	workApplier2 = work.NewApplyWorkReconciler(hubClient, nil, nil, nil, nil, 0, "")
 
-	member2Reconciler, err := NewReconciler(ctx, hubClient, member2Cfg, member2Client, workApplier2, nil)
+	member2Reconciler, err := NewReconciler(ctx, hubClient, member2Cfg, member2Client, workApplier2, nil, nil)
 	Expect(err).NotTo(HaveOccurred())
 	Expect(member2Reconciler.SetupWithManager(member2Mgr)).To(Succeed())
 
@@ -0,0 +1,81 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/version"
+)
+
+const (
+	// EventReasonMemberAgentUpgrading is the event type and reason string when the agent starts to upgrade itself.
+	EventReasonMemberAgentUpgrading = "MemberAgentUpgrading"
+)
+
+// SelfUpgradeConfig is the configuration for the member agent to upgrade itself to the target version
+// declared by the hub agent.
+type SelfUpgradeConfig struct {
+	// DeploymentNamespace is the namespace of the deployment of the member agent.
+	DeploymentNamespace string
+	// DeploymentName is the name of the deployment of the member agent.
+	DeploymentName string
+	// ContainerName is the name of the member agent container in the deployment.
+	ContainerName string
+}
+
+// upgradeAgent updates the deployment of the member agent with the target image declared by the hub
+// agent, if self-upgrade is enabled and the agent does not run the target version yet.
+//
+// The deployment then rolls out the new image, and the agent of the target version reports its version
+// to the hub cluster after it starts.
+func (r *Reconciler) upgradeAgent(ctx context.Context, imc *clusterv1beta1.InternalMemberCluster) error {
+	if r.selfUpgradeCfg == nil {
+		return nil
+	}
+	targetVersion := imc.Annotations[clusterv1beta1.MemberAgentTargetVersionAnnotation]
+	targetImage := imc.Annotations[clusterv1beta1.MemberAgentTargetImageAnnotation]
+	if targetVersion == "" || targetImage == "" || targetVersion == version.Version {
+		return nil
+	}
+
+	deployments := r.rawMemberClientSet.AppsV1().Deployments(r.selfUpgradeCfg.DeploymentNamespace)
+	deploy, err := deployments.Get(ctx, r.selfUpgradeCfg.DeploymentName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get the member agent deployment %s/%s: %w", r.selfUpgradeCfg.DeploymentNamespace, r.selfUpgradeCfg.DeploymentName, err)
+	}
+	containers := deploy.Spec.Template.Spec.Containers
+	idx := -1
+	for i := range containers {
+		if containers[i].Name == r.selfUpgradeCfg.ContainerName {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return fmt.Errorf("failed to find the member agent container %s in deployment %s", r.selfUpgradeCfg.ContainerName, klog.KObj(deploy))
+	}
+	if containers[idx].Image == targetImage {
+		// The deployment is rolling out the target image already.
+		return nil
+	}
+
+	klog.V(2).InfoS("Upgrading the member agent", "deployment", klog.KObj(deploy), "currentVersion", version.Version,
+		"targetVersion", targetVersion, "currentImage", containers[idx].Image, "targetImage", targetImage)
+	containers[idx].Image = targetImage
+	if _, err := deployments.Update(ctx, deploy, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update the member agent deployment %s: %w", klog.KObj(deploy), err)
+	}
+	r.recorder.Eventf(imc, corev1.EventTypeNormal, EventReasonMemberAgentUpgrading,
+		"Member agent is upgrading from version %s to version %s", version.Version, targetVersion)
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/version"
+)
+
+const (
+	agentNamespace      = "fleet-system"
+	agentDeploymentName = "member-agent"
+	currentAgentImage   = "ghcr.io/azure/fleet/member-agent:v0.10.0"
+	targetAgentImage    = "ghcr.io/azure/fleet/member-agent:v0.11.0"
+	targetAgentVersion  = "v0.11.0"
+)
+
+// TestUpgradeAgent tests the upgradeAgent method.
+func TestUpgradeAgent(t *testing.T) {
+	selfUpgradeCfg := &SelfUpgradeConfig{
+		DeploymentNamespace: agentNamespace,
+		DeploymentName:      agentDeploymentName,
+		ContainerName:       agentDeploymentName,
+	}
+	targetAnnotations := map[string]string{
+		clusterv1beta1.MemberAgentTargetVersionAnnotation: targetAgentVersion,
+		clusterv1beta1.MemberAgentTargetImageAnnotation:   targetAgentImage,
+	}
+
+	testCases := map[string]struct {
+		selfUpgradeCfg *SelfUpgradeConfig
+		annotations    map[string]string
+		containerName  string
+		wantImage      string
+		wantErr        bool
+	}{
+		"self-upgrade disabled": {
+			annotations: targetAnnotations,
+			wantImage:   currentAgentImage,
+		},
+		"no target declared": {
+			selfUpgradeCfg: selfUpgradeCfg,
+			wantImage:      currentAgentImage,
+		},
+		"no target image declared": {
+			selfUpgradeCfg: selfUpgradeCfg,
+			annotations: map[string]string{
+				clusterv1beta1.MemberAgentTargetVersionAnnotation: targetAgentVersion,
+			},
+			wantImage: currentAgentImage,
+		},
+		"running the target version already": {
+			selfUpgradeCfg: selfUpgradeCfg,
+			annotations: map[string]string{
+				clusterv1beta1.MemberAgentTargetVersionAnnotation: version.Version,
+				clusterv1beta1.MemberAgentTargetImageAnnotation:   targetAgentImage,
+			},
+			wantImage: currentAgentImage,
+		},
+		"upgrade to the target image": {
+			selfUpgradeCfg: selfUpgradeCfg,
+			annotations:    targetAnnotations,
+			wantImage:      targetAgentImage,
+		},
+		"container not found": {
+			selfUpgradeCfg: selfUpgradeCfg,
+			annotations:    targetAnnotations,
+			containerName:  "other",
+			wantImage:      currentAgentImage,
+			wantErr:        true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			containerName := agentDeploymentName
+			if tc.containerName != "" {
+				containerName = tc.containerName
+			}
+			deploy := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: agentDeploymentName, Namespace: agentNamespace},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: containerName, Image: currentAgentImage}},
+						},
+					},
+				},
+			}
+			clientSet := fake.NewSimpleClientset(deploy)
+			r := &Reconciler{
+				rawMemberClientSet: clientSet,
+				selfUpgradeCfg:     tc.selfUpgradeCfg,
+				recorder:           utils.NewFakeRecorder(1),
+			}
+			imc := &clusterv1beta1.InternalMemberCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "member-1", Namespace: "fleet-member-member-1", Annotations: tc.annotations},
+			}
+
+			err := r.upgradeAgent(context.Background(), imc)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("upgradeAgent() = %v, want error %t", err, tc.wantErr)
+			}
+
+			got, err := clientSet.AppsV1().Deployments(agentNamespace).Get(context.Background(), agentDeploymentName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Get() deployment = %v, want no error", err)
+			}
+			if gotImage := got.Spec.Template.Spec.Containers[0].Image; gotImage != tc.wantImage {
+				t.Errorf("member agent image = %s, want %s", gotImage, tc.wantImage)
+			}
+		})
+	}
+}
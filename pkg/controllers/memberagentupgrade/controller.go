@@ -0,0 +1,478 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package memberagentupgrade features a controller that rolls out member agent versions across the
+// member clusters, stage by stage, as declared in MemberAgentUpgrade objects.
+package memberagentupgrade
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/version"
+)
+
+const (
+	// defaultStageName is the name of the implicit stage when no stages are specified.
+	defaultStageName = "all"
+
+	// defaultMaxMinorVersionSkew is the max minor version skew when the skew policy does not specify one.
+	defaultMaxMinorVersionSkew = 1
+
+	// the reasons of the Progressing condition.
+	stageInProgressReason        = "StageInProgress"
+	rolloutCompletedReason       = "RolloutCompleted"
+	supersededReason             = "Superseded"
+	invalidClusterSelectorReason = "InvalidClusterSelector"
+	targetVersionOutOfSkewReason = "TargetVersionOutOfSkew"
+
+	// the reasons of the VersionSkewWithinPolicy condition.
+	versionSkewWithinPolicyReason = "VersionSkewWithinPolicy"
+	versionSkewViolatedReason     = "VersionSkewViolated"
+	hubVersionUnknownReason       = "HubVersionUnknown"
+
+	// the reasons of the events.
+	eventReasonVersionSkewViolated    = "MemberAgentVersionSkewViolated"
+	eventReasonTargetVersionOutOfSkew = "TargetVersionOutOfSkew"
+
+	declareTargetFailedTemplate = "Failed to declare the target version on %d member cluster(s): %v"
+)
+
+// Reconciler reconciles a MemberAgentUpgrade object and rolls out the target version of the member agent.
+type Reconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+	// HubAgentVersion is the version of the hub agent, against which the version skew is checked.
+	HubAgentVersion string
+}
+
+// stage is a stage of a MemberAgentUpgrade with the member clusters in it.
+type stage struct {
+	name           string
+	memberClusters []*clusterv1beta1.MemberCluster
+}
+
+// Reconcile declares the target version of the member agent on the member clusters in the current stage,
+// moves on to the next stage once all the member agents in the stage report the target version, and checks
+// the versions of the member agents against the skew policy.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	upgradeRef := klog.KRef("", req.Name)
+	startTime := time.Now()
+	klog.V(2).InfoS("Reconciliation starts (member agent upgrade controller)", "memberAgentUpgrade", upgradeRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("Reconciliation ends (member agent upgrade controller)", "memberAgentUpgrade", upgradeRef, "latency", latency)
+	}()
+
+	upgrade := &clusterv1beta1.MemberAgentUpgrade{}
+	if err := r.Get(ctx, req.NamespacedName, upgrade); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(2).InfoS("Member agent upgrade object is not found", "memberAgentUpgrade", upgradeRef)
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get member agent upgrade", "memberAgentUpgrade", upgradeRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	if upgrade.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	upgradeList := &clusterv1beta1.MemberAgentUpgradeList{}
+	if err := r.List(ctx, upgradeList); err != nil {
+		klog.ErrorS(err, "Failed to list member agent upgrades", "memberAgentUpgrade", upgradeRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	if newer := findNewerUpgrade(upgrade, upgradeList.Items); newer != nil {
+		klog.V(2).InfoS("Member agent upgrade is superseded", "memberAgentUpgrade", upgradeRef, "supersededBy", klog.KObj(newer))
+		r.setProgressingCondition(upgrade, metav1.ConditionFalse, supersededReason,
+			fmt.Sprintf("The rollout is superseded by member agent upgrade %s", newer.Name))
+		upgrade.Status.CurrentStage = ""
+		return ctrl.Result{}, r.updateStatus(ctx, upgrade)
+	}
+
+	memberClusterList := &clusterv1beta1.MemberClusterList{}
+	if err := r.List(ctx, memberClusterList); err != nil {
+		klog.ErrorS(err, "Failed to list member clusters", "memberAgentUpgrade", upgradeRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	memberClusters := make([]*clusterv1beta1.MemberCluster, 0, len(memberClusterList.Items))
+	for i := range memberClusterList.Items {
+		if memberClusterList.Items[i].DeletionTimestamp == nil {
+			memberClusters = append(memberClusters, &memberClusterList.Items[i])
+		}
+	}
+	sort.Slice(memberClusters, func(i, j int) bool {
+		return memberClusters[i].Name < memberClusters[j].Name
+	})
+
+	maxSkew := maxMinorVersionSkew(upgrade)
+	r.checkVersionSkew(upgrade, memberClusters, maxSkew)
+
+	if skew, err := version.MinorVersionSkew(r.HubAgentVersion, upgrade.Spec.Version); err == nil && skew > maxSkew {
+		// Rolling out the target version would break the skew policy; this is a user error and
+		// retrying will not help.
+		msg := fmt.Sprintf("The target version %s is beyond the supported skew of %d minor version(s) from the hub agent version %s",
+			upgrade.Spec.Version, maxSkew, r.HubAgentVersion)
+		klog.V(2).InfoS("Refused to roll out the target version", "memberAgentUpgrade", upgradeRef, "reason", msg)
+		if cond := upgrade.GetCondition(string(clusterv1beta1.MemberAgentUpgradeConditionTypeProgressing)); cond == nil || cond.Reason != targetVersionOutOfSkewReason {
+			r.Recorder.Event(upgrade, corev1.EventTypeWarning, eventReasonTargetVersionOutOfSkew, msg)
+		}
+		r.setProgressingCondition(upgrade, metav1.ConditionFalse, targetVersionOutOfSkewReason, msg)
+		upgrade.Status.CurrentStage = ""
+		return ctrl.Result{}, r.updateStatus(ctx, upgrade)
+	}
+
+	stages, err := collectStages(upgrade, memberClusters)
+	if err != nil {
+		// The selector is invalid; this is a user error and retrying will not help.
+		klog.ErrorS(err, "Failed to collect the member clusters in the stages", "memberAgentUpgrade", upgradeRef)
+		r.setProgressingCondition(upgrade, metav1.ConditionFalse, invalidClusterSelectorReason, err.Error())
+		upgrade.Status.CurrentStage = ""
+		return ctrl.Result{}, r.updateStatus(ctx, upgrade)
+	}
+
+	declareErr := r.rollOut(ctx, upgrade, stages)
+	if err := r.updateStatus(ctx, upgrade); err != nil {
+		return ctrl.Result{}, err
+	}
+	// Retry the failed member clusters (if any).
+	return ctrl.Result{}, declareErr
+}
+
+// rollOut refreshes the status of the stages, and declares the target version on the member clusters in
+// the first stage that has not completed.
+func (r *Reconciler) rollOut(ctx context.Context, upgrade *clusterv1beta1.MemberAgentUpgrade, stages []stage) error {
+	upgrade.Status.CurrentStage = ""
+	upgrade.Status.Stages = make([]clusterv1beta1.MemberAgentUpgradeStageStatus, 0, len(stages))
+	var declareErr error
+	for _, s := range stages {
+		stageStatus := clusterv1beta1.MemberAgentUpgradeStageStatus{
+			Name:     s.name,
+			Clusters: make([]clusterv1beta1.MemberAgentUpgradeClusterStatus, 0, len(s.memberClusters)),
+		}
+		upgraded := 0
+		for _, mc := range s.memberClusters {
+			reported := memberAgentVersion(mc)
+			clusterStatus := clusterv1beta1.MemberAgentUpgradeClusterStatus{
+				ClusterName: mc.Name,
+				Version:     reported,
+				Upgraded:    reported == upgrade.Spec.Version,
+			}
+			if clusterStatus.Upgraded {
+				upgraded++
+			}
+			stageStatus.Clusters = append(stageStatus.Clusters, clusterStatus)
+		}
+		stageStatus.Completed = upgraded == len(s.memberClusters)
+		upgrade.Status.Stages = append(upgrade.Status.Stages, stageStatus)
+
+		if stageStatus.Completed || upgrade.Status.CurrentStage != "" {
+			continue
+		}
+		// This is the first stage that has not completed; roll out the target version to it.
+		upgrade.Status.CurrentStage = s.name
+		declareErr = r.declareTargetVersion(ctx, upgrade, s.memberClusters)
+		r.setProgressingCondition(upgrade, metav1.ConditionTrue, stageInProgressReason,
+			fmt.Sprintf("Rolling out version %s to stage %s: %d of %d member cluster(s) upgraded", upgrade.Spec.Version, s.name, upgraded, len(s.memberClusters)))
+	}
+
+	succeeded := metav1.Condition{
+		Type:               string(clusterv1beta1.MemberAgentUpgradeConditionTypeSucceeded),
+		Status:             metav1.ConditionFalse,
+		Reason:             stageInProgressReason,
+		Message:            fmt.Sprintf("Stage %s has not completed yet", upgrade.Status.CurrentStage),
+		ObservedGeneration: upgrade.Generation,
+	}
+	if upgrade.Status.CurrentStage == "" {
+		msg := fmt.Sprintf("The member agents in all the %d stage(s) run version %s", len(stages), upgrade.Spec.Version)
+		r.setProgressingCondition(upgrade, metav1.ConditionFalse, rolloutCompletedReason, msg)
+		succeeded.Status = metav1.ConditionTrue
+		succeeded.Reason = rolloutCompletedReason
+		succeeded.Message = msg
+	}
+	upgrade.SetConditions(succeeded)
+	return declareErr
+}
+
+// declareTargetVersion adds the target version (and image) annotations to the InternalMemberCluster
+// objects of the member clusters.
+func (r *Reconciler) declareTargetVersion(ctx context.Context, upgrade *clusterv1beta1.MemberAgentUpgrade, memberClusters []*clusterv1beta1.MemberCluster) error {
+	failed := 0
+	var lastErr error
+	for _, mc := range memberClusters {
+		imc := &clusterv1beta1.InternalMemberCluster{}
+		imcKey := types.NamespacedName{Name: mc.Name, Namespace: fmt.Sprintf(utils.NamespaceNameFormat, mc.Name)}
+		if err := r.Get(ctx, imcKey, imc); err != nil {
+			if apierrors.IsNotFound(err) {
+				// The member cluster has not been set up yet.
+				continue
+			}
+			klog.ErrorS(err, "Failed to get internal member cluster", "memberAgentUpgrade", klog.KObj(upgrade), "internalMemberCluster", imcKey)
+			failed++
+			lastErr = err
+			continue
+		}
+
+		if imc.Annotations[clusterv1beta1.MemberAgentTargetVersionAnnotation] == upgrade.Spec.Version &&
+			imc.Annotations[clusterv1beta1.MemberAgentTargetImageAnnotation] == upgrade.Spec.Image {
+			continue
+		}
+		patch := client.MergeFrom(imc.DeepCopy())
+		if imc.Annotations == nil {
+			imc.Annotations = make(map[string]string)
+		}
+		imc.Annotations[clusterv1beta1.MemberAgentTargetVersionAnnotation] = upgrade.Spec.Version
+		if upgrade.Spec.Image != "" {
+			imc.Annotations[clusterv1beta1.MemberAgentTargetImageAnnotation] = upgrade.Spec.Image
+		} else {
+			delete(imc.Annotations, clusterv1beta1.MemberAgentTargetImageAnnotation)
+		}
+		if err := r.Patch(ctx, imc, patch); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			klog.ErrorS(err, "Failed to declare the target version on internal member cluster",
+				"memberAgentUpgrade", klog.KObj(upgrade), "internalMemberCluster", klog.KObj(imc))
+			failed++
+			lastErr = err
+			continue
+		}
+		klog.V(2).InfoS("Declared the target version on internal member cluster",
+			"memberAgentUpgrade", klog.KObj(upgrade), "internalMemberCluster", klog.KObj(imc), "version", upgrade.Spec.Version)
+	}
+	if failed > 0 {
+		return controller.NewAPIServerError(false, fmt.Errorf(declareTargetFailedTemplate, failed, lastErr))
+	}
+	return nil
+}
+
+// checkVersionSkew checks the versions the member agents report against the skew policy, and sets the
+// VersionSkewWithinPolicy condition accordingly.
+func (r *Reconciler) checkVersionSkew(upgrade *clusterv1beta1.MemberAgentUpgrade, memberClusters []*clusterv1beta1.MemberCluster, maxSkew int) {
+	cond := metav1.Condition{
+		Type:               string(clusterv1beta1.MemberAgentUpgradeConditionTypeVersionSkewWithinPolicy),
+		ObservedGeneration: upgrade.Generation,
+	}
+	upgrade.Status.SkewedClusters = nil
+	if _, err := utilversion.ParseGeneric(r.HubAgentVersion); err != nil {
+		cond.Status = metav1.ConditionUnknown
+		cond.Reason = hubVersionUnknownReason
+		cond.Message = fmt.Sprintf("The hub agent version %s is not a valid version: %v", r.HubAgentVersion, err)
+		upgrade.SetConditions(cond)
+		return
+	}
+
+	for _, mc := range memberClusters {
+		reported := memberAgentVersion(mc)
+		if reported == "" {
+			continue
+		}
+		// Member agents of invalid versions, e.g., development builds, are not checked.
+		if skew, err := version.MinorVersionSkew(r.HubAgentVersion, reported); err == nil && skew > maxSkew {
+			upgrade.Status.SkewedClusters = append(upgrade.Status.SkewedClusters, mc.Name)
+		}
+	}
+
+	if len(upgrade.Status.SkewedClusters) == 0 {
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = versionSkewWithinPolicyReason
+		cond.Message = fmt.Sprintf("All the member agents are within %d minor version(s) of the hub agent version %s", maxSkew, r.HubAgentVersion)
+		upgrade.SetConditions(cond)
+		return
+	}
+
+	cond.Status = metav1.ConditionFalse
+	cond.Reason = versionSkewViolatedReason
+	cond.Message = fmt.Sprintf("The member agents of %d member cluster(s) are beyond %d minor version(s) of the hub agent version %s: %s",
+		len(upgrade.Status.SkewedClusters), maxSkew, r.HubAgentVersion, strings.Join(upgrade.Status.SkewedClusters, ", "))
+	if old := upgrade.GetCondition(cond.Type); old == nil || old.Status != metav1.ConditionFalse {
+		// Warn only when the skew policy starts to be violated.
+		r.Recorder.Event(upgrade, corev1.EventTypeWarning, eventReasonVersionSkewViolated, cond.Message)
+	}
+	upgrade.SetConditions(cond)
+}
+
+// setProgressingCondition sets the Progressing condition of a MemberAgentUpgrade.
+func (r *Reconciler) setProgressingCondition(upgrade *clusterv1beta1.MemberAgentUpgrade, status metav1.ConditionStatus, reason, message string) {
+	upgrade.SetConditions(metav1.Condition{
+		Type:               string(clusterv1beta1.MemberAgentUpgradeConditionTypeProgressing),
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: upgrade.Generation,
+	})
+}
+
+// updateStatus updates the status of a MemberAgentUpgrade.
+func (r *Reconciler) updateStatus(ctx context.Context, upgrade *clusterv1beta1.MemberAgentUpgrade) error {
+	if err := r.Status().Update(ctx, upgrade); err != nil {
+		klog.ErrorS(err, "Failed to update member agent upgrade status", "memberAgentUpgrade", klog.KObj(upgrade))
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	return nil
+}
+
+// findNewerUpgrade returns a MemberAgentUpgrade that supersedes the given one, i.e., one that is created
+// later (or at the same time with a greater name), or nil if there is none.
+func findNewerUpgrade(upgrade *clusterv1beta1.MemberAgentUpgrade, upgrades []clusterv1beta1.MemberAgentUpgrade) *clusterv1beta1.MemberAgentUpgrade {
+	var newest *clusterv1beta1.MemberAgentUpgrade
+	for i := range upgrades {
+		other := &upgrades[i]
+		if other.DeletionTimestamp != nil || !isNewer(other, upgrade) {
+			continue
+		}
+		if newest == nil || isNewer(other, newest) {
+			newest = other
+		}
+	}
+	return newest
+}
+
+// isNewer returns true if a MemberAgentUpgrade is created later than another one.
+func isNewer(a, b *clusterv1beta1.MemberAgentUpgrade) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return b.CreationTimestamp.Before(&a.CreationTimestamp)
+	}
+	return a.Name > b.Name
+}
+
+// collectStages returns the stages of a MemberAgentUpgrade with the member clusters in them; a member cluster
+// belongs to the first stage whose cluster selector it matches.
+func collectStages(upgrade *clusterv1beta1.MemberAgentUpgrade, memberClusters []*clusterv1beta1.MemberCluster) ([]stage, error) {
+	if len(upgrade.Spec.Stages) == 0 {
+		return []stage{{name: defaultStageName, memberClusters: memberClusters}}, nil
+	}
+
+	selectors := make([]labels.Selector, len(upgrade.Spec.Stages))
+	for i := range upgrade.Spec.Stages {
+		selectors[i] = labels.Everything()
+		if upgrade.Spec.Stages[i].ClusterSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(upgrade.Spec.Stages[i].ClusterSelector)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse the cluster selector of stage %s: %w", upgrade.Spec.Stages[i].Name, err)
+			}
+			selectors[i] = selector
+		}
+	}
+
+	stages := make([]stage, len(upgrade.Spec.Stages))
+	for i := range upgrade.Spec.Stages {
+		stages[i].name = upgrade.Spec.Stages[i].Name
+	}
+	for _, mc := range memberClusters {
+		for i := range selectors {
+			if selectors[i].Matches(labels.Set(mc.Labels)) {
+				stages[i].memberClusters = append(stages[i].memberClusters, mc)
+				break
+			}
+		}
+	}
+	return stages, nil
+}
+
+// maxMinorVersionSkew returns the max minor version skew of a MemberAgentUpgrade.
+func maxMinorVersionSkew(upgrade *clusterv1beta1.MemberAgentUpgrade) int {
+	if upgrade.Spec.SkewPolicy.MaxMinorVersionSkew == nil {
+		return defaultMaxMinorVersionSkew
+	}
+	return int(*upgrade.Spec.SkewPolicy.MaxMinorVersionSkew)
+}
+
+// memberAgentVersion returns the version the member agent of a member cluster reports.
+func memberAgentVersion(mc *clusterv1beta1.MemberCluster) string {
+	for i := range mc.Status.AgentStatus {
+		if mc.Status.AgentStatus[i].Type == clusterv1beta1.MemberAgent {
+			return mc.Status.AgentStatus[i].Version
+		}
+	}
+	return ""
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("member-agent-upgrade-controller").
+		For(&clusterv1beta1.MemberAgentUpgrade{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Re-evaluate all the member agent upgrades when one is created or deleted, as the newest one
+		// supersedes the others.
+		Watches(&clusterv1beta1.MemberAgentUpgrade{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueAllUpgrades),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc: func(_ event.CreateEvent) bool {
+					return true
+				},
+				UpdateFunc: func(_ event.UpdateEvent) bool {
+					return false
+				},
+				DeleteFunc: func(_ event.DeleteEvent) bool {
+					return true
+				},
+				GenericFunc: func(_ event.GenericEvent) bool {
+					return false
+				},
+			})).
+		// Re-evaluate all the member agent upgrades when a member cluster joins or leaves, or when
+		// the labels or the member agent version of a member cluster change.
+		Watches(&clusterv1beta1.MemberCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueAllUpgrades),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc: func(_ event.CreateEvent) bool {
+					return true
+				},
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldMC, oldOK := e.ObjectOld.(*clusterv1beta1.MemberCluster)
+					newMC, newOK := e.ObjectNew.(*clusterv1beta1.MemberCluster)
+					if !oldOK || !newOK {
+						return false
+					}
+					return !labels.Equals(oldMC.Labels, newMC.Labels) ||
+						memberAgentVersion(oldMC) != memberAgentVersion(newMC) ||
+						oldMC.DeletionTimestamp.IsZero() != newMC.DeletionTimestamp.IsZero()
+				},
+				DeleteFunc: func(_ event.DeleteEvent) bool {
+					return true
+				},
+				GenericFunc: func(_ event.GenericEvent) bool {
+					return false
+				},
+			})).
+		Complete(r)
+}
+
+// enqueueAllUpgrades returns reconcile requests for all the member agent upgrades.
+func (r *Reconciler) enqueueAllUpgrades(ctx context.Context, _ client.Object) []reconcile.Request {
+	upgradeList := &clusterv1beta1.MemberAgentUpgradeList{}
+	if err := r.List(ctx, upgradeList); err != nil {
+		klog.ErrorS(controller.NewAPIServerError(true, err), "Failed to list member agent upgrades")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(upgradeList.Items))
+	for i := range upgradeList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&upgradeList.Items[i])})
+	}
+	return requests
+}
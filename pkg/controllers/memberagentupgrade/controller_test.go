@@ -0,0 +1,412 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package memberagentupgrade
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+const (
+	testUpgradeName = "upgrade-1"
+	hubVersion      = "v0.11.0"
+	currentVersion  = "v0.11.0"
+	targetVersion   = "v0.12.0"
+	targetImage     = "ghcr.io/azure/fleet/member-agent:v0.12.0"
+)
+
+var (
+	ignoreConditionOption = cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "Message", "ObservedGeneration")
+	sortConditionsOption  = cmpopts.SortSlices(func(a, b metav1.Condition) bool { return a.Type < b.Type })
+)
+
+func serviceScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add cluster v1beta1 scheme: %v", err)
+	}
+	return scheme
+}
+
+func memberCluster(name, agentVersion string, labels map[string]string) *clusterv1beta1.MemberCluster {
+	return &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: clusterv1beta1.MemberClusterStatus{
+			AgentStatus: []clusterv1beta1.AgentStatus{
+				{Type: clusterv1beta1.MemberAgent, Version: agentVersion},
+			},
+		},
+	}
+}
+
+func internalMemberCluster(name string) *clusterv1beta1.InternalMemberCluster {
+	return &clusterv1beta1.InternalMemberCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: fmt.Sprintf(utils.NamespaceNameFormat, name)},
+	}
+}
+
+func memberAgentUpgrade(name string, created time.Time, stages ...clusterv1beta1.MemberAgentUpgradeStage) *clusterv1beta1.MemberAgentUpgrade {
+	return &clusterv1beta1.MemberAgentUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)},
+		Spec: clusterv1beta1.MemberAgentUpgradeSpec{
+			Version: targetVersion,
+			Image:   targetImage,
+			Stages:  stages,
+		},
+	}
+}
+
+func condition(conditionType clusterv1beta1.MemberAgentUpgradeConditionType, status metav1.ConditionStatus, reason string) metav1.Condition {
+	return metav1.Condition{Type: string(conditionType), Status: status, Reason: reason}
+}
+
+// TestReconcile tests the Reconcile method.
+func TestReconcile(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	canaryLabels := map[string]string{"ring": "canary"}
+	stages := []clusterv1beta1.MemberAgentUpgradeStage{
+		{Name: "canary", ClusterSelector: &metav1.LabelSelector{MatchLabels: canaryLabels}},
+		{Name: "rest"},
+	}
+	targetAnnotations := map[string]string{
+		clusterv1beta1.MemberAgentTargetVersionAnnotation: targetVersion,
+		clusterv1beta1.MemberAgentTargetImageAnnotation:   targetImage,
+	}
+
+	testCases := []struct {
+		name            string
+		hubVersion      string
+		upgrade         *clusterv1beta1.MemberAgentUpgrade
+		objects         []client.Object
+		wantStatus      clusterv1beta1.MemberAgentUpgradeStatus
+		wantAnnotations map[string]map[string]string
+	}{
+		{
+			name:    "roll out to all the member clusters in a single stage",
+			upgrade: memberAgentUpgrade(testUpgradeName, now),
+			objects: []client.Object{
+				memberCluster("member-1", currentVersion, nil),
+				memberCluster("member-2", targetVersion, nil),
+				internalMemberCluster("member-1"),
+				internalMemberCluster("member-2"),
+			},
+			wantStatus: clusterv1beta1.MemberAgentUpgradeStatus{
+				CurrentStage: defaultStageName,
+				Stages: []clusterv1beta1.MemberAgentUpgradeStageStatus{
+					{
+						Name: defaultStageName,
+						Clusters: []clusterv1beta1.MemberAgentUpgradeClusterStatus{
+							{ClusterName: "member-1", Version: currentVersion},
+							{ClusterName: "member-2", Version: targetVersion, Upgraded: true},
+						},
+					},
+				},
+				Conditions: []metav1.Condition{
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeProgressing, metav1.ConditionTrue, stageInProgressReason),
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeSucceeded, metav1.ConditionFalse, stageInProgressReason),
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeVersionSkewWithinPolicy, metav1.ConditionTrue, versionSkewWithinPolicyReason),
+				},
+			},
+			wantAnnotations: map[string]map[string]string{
+				"member-1": targetAnnotations,
+				"member-2": targetAnnotations,
+			},
+		},
+		{
+			name:    "roll out to the first stage only",
+			upgrade: memberAgentUpgrade(testUpgradeName, now, stages...),
+			objects: []client.Object{
+				memberCluster("member-1", currentVersion, canaryLabels),
+				memberCluster("member-2", currentVersion, nil),
+				internalMemberCluster("member-1"),
+				internalMemberCluster("member-2"),
+			},
+			wantStatus: clusterv1beta1.MemberAgentUpgradeStatus{
+				CurrentStage: "canary",
+				Stages: []clusterv1beta1.MemberAgentUpgradeStageStatus{
+					{
+						Name:     "canary",
+						Clusters: []clusterv1beta1.MemberAgentUpgradeClusterStatus{{ClusterName: "member-1", Version: currentVersion}},
+					},
+					{
+						Name:     "rest",
+						Clusters: []clusterv1beta1.MemberAgentUpgradeClusterStatus{{ClusterName: "member-2", Version: currentVersion}},
+					},
+				},
+				Conditions: []metav1.Condition{
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeProgressing, metav1.ConditionTrue, stageInProgressReason),
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeSucceeded, metav1.ConditionFalse, stageInProgressReason),
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeVersionSkewWithinPolicy, metav1.ConditionTrue, versionSkewWithinPolicyReason),
+				},
+			},
+			wantAnnotations: map[string]map[string]string{
+				"member-1": targetAnnotations,
+				"member-2": nil,
+			},
+		},
+		{
+			name:    "move on to the next stage",
+			upgrade: memberAgentUpgrade(testUpgradeName, now, stages...),
+			objects: []client.Object{
+				memberCluster("member-1", targetVersion, canaryLabels),
+				memberCluster("member-2", currentVersion, nil),
+				internalMemberCluster("member-1"),
+				internalMemberCluster("member-2"),
+			},
+			wantStatus: clusterv1beta1.MemberAgentUpgradeStatus{
+				CurrentStage: "rest",
+				Stages: []clusterv1beta1.MemberAgentUpgradeStageStatus{
+					{
+						Name:      "canary",
+						Clusters:  []clusterv1beta1.MemberAgentUpgradeClusterStatus{{ClusterName: "member-1", Version: targetVersion, Upgraded: true}},
+						Completed: true,
+					},
+					{
+						Name:     "rest",
+						Clusters: []clusterv1beta1.MemberAgentUpgradeClusterStatus{{ClusterName: "member-2", Version: currentVersion}},
+					},
+				},
+				Conditions: []metav1.Condition{
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeProgressing, metav1.ConditionTrue, stageInProgressReason),
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeSucceeded, metav1.ConditionFalse, stageInProgressReason),
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeVersionSkewWithinPolicy, metav1.ConditionTrue, versionSkewWithinPolicyReason),
+				},
+			},
+			wantAnnotations: map[string]map[string]string{
+				"member-1": nil,
+				"member-2": targetAnnotations,
+			},
+		},
+		{
+			name:    "all the stages have completed",
+			upgrade: memberAgentUpgrade(testUpgradeName, now, stages...),
+			objects: []client.Object{
+				memberCluster("member-1", targetVersion, canaryLabels),
+				memberCluster("member-2", targetVersion, nil),
+				internalMemberCluster("member-1"),
+				internalMemberCluster("member-2"),
+			},
+			wantStatus: clusterv1beta1.MemberAgentUpgradeStatus{
+				Stages: []clusterv1beta1.MemberAgentUpgradeStageStatus{
+					{
+						Name:      "canary",
+						Clusters:  []clusterv1beta1.MemberAgentUpgradeClusterStatus{{ClusterName: "member-1", Version: targetVersion, Upgraded: true}},
+						Completed: true,
+					},
+					{
+						Name:      "rest",
+						Clusters:  []clusterv1beta1.MemberAgentUpgradeClusterStatus{{ClusterName: "member-2", Version: targetVersion, Upgraded: true}},
+						Completed: true,
+					},
+				},
+				Conditions: []metav1.Condition{
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeProgressing, metav1.ConditionFalse, rolloutCompletedReason),
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeSucceeded, metav1.ConditionTrue, rolloutCompletedReason),
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeVersionSkewWithinPolicy, metav1.ConditionTrue, versionSkewWithinPolicyReason),
+				},
+			},
+			wantAnnotations: map[string]map[string]string{
+				"member-1": nil,
+				"member-2": nil,
+			},
+		},
+		{
+			name:       "refuse to roll out a target version beyond the supported skew",
+			hubVersion: "v0.10.0",
+			upgrade: func() *clusterv1beta1.MemberAgentUpgrade {
+				u := memberAgentUpgrade(testUpgradeName, now)
+				u.Spec.SkewPolicy.MaxMinorVersionSkew = ptr.To(int32(1))
+				return u
+			}(),
+			objects: []client.Object{
+				memberCluster("member-1", currentVersion, nil),
+				internalMemberCluster("member-1"),
+			},
+			wantStatus: clusterv1beta1.MemberAgentUpgradeStatus{
+				Conditions: []metav1.Condition{
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeProgressing, metav1.ConditionFalse, targetVersionOutOfSkewReason),
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeVersionSkewWithinPolicy, metav1.ConditionTrue, versionSkewWithinPolicyReason),
+				},
+			},
+			wantAnnotations: map[string]map[string]string{
+				"member-1": nil,
+			},
+		},
+		{
+			name:    "superseded by a newer upgrade",
+			upgrade: memberAgentUpgrade(testUpgradeName, now),
+			objects: []client.Object{
+				memberAgentUpgrade("upgrade-2", now.Add(time.Minute)),
+				memberCluster("member-1", currentVersion, nil),
+				internalMemberCluster("member-1"),
+			},
+			wantStatus: clusterv1beta1.MemberAgentUpgradeStatus{
+				Conditions: []metav1.Condition{
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeProgressing, metav1.ConditionFalse, supersededReason),
+				},
+			},
+			wantAnnotations: map[string]map[string]string{
+				"member-1": nil,
+			},
+		},
+		{
+			name:    "warn about member agents beyond the supported skew",
+			upgrade: memberAgentUpgrade(testUpgradeName, now, stages[0]),
+			objects: []client.Object{
+				memberCluster("member-1", targetVersion, canaryLabels),
+				memberCluster("member-2", "v0.9.0", nil),
+				memberCluster("member-3", "unknown", nil),
+				internalMemberCluster("member-1"),
+			},
+			wantStatus: clusterv1beta1.MemberAgentUpgradeStatus{
+				Stages: []clusterv1beta1.MemberAgentUpgradeStageStatus{
+					{
+						Name:      "canary",
+						Clusters:  []clusterv1beta1.MemberAgentUpgradeClusterStatus{{ClusterName: "member-1", Version: targetVersion, Upgraded: true}},
+						Completed: true,
+					},
+				},
+				SkewedClusters: []string{"member-2"},
+				Conditions: []metav1.Condition{
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeProgressing, metav1.ConditionFalse, rolloutCompletedReason),
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeSucceeded, metav1.ConditionTrue, rolloutCompletedReason),
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeVersionSkewWithinPolicy, metav1.ConditionFalse, versionSkewViolatedReason),
+				},
+			},
+		},
+		{
+			name:       "unknown hub agent version",
+			hubVersion: "unknown",
+			upgrade:    memberAgentUpgrade(testUpgradeName, now),
+			objects: []client.Object{
+				memberCluster("member-1", currentVersion, nil),
+				internalMemberCluster("member-1"),
+			},
+			wantStatus: clusterv1beta1.MemberAgentUpgradeStatus{
+				CurrentStage: defaultStageName,
+				Stages: []clusterv1beta1.MemberAgentUpgradeStageStatus{
+					{
+						Name:     defaultStageName,
+						Clusters: []clusterv1beta1.MemberAgentUpgradeClusterStatus{{ClusterName: "member-1", Version: currentVersion}},
+					},
+				},
+				Conditions: []metav1.Condition{
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeProgressing, metav1.ConditionTrue, stageInProgressReason),
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeSucceeded, metav1.ConditionFalse, stageInProgressReason),
+					condition(clusterv1beta1.MemberAgentUpgradeConditionTypeVersionSkewWithinPolicy, metav1.ConditionUnknown, hubVersionUnknownReason),
+				},
+			},
+			wantAnnotations: map[string]map[string]string{
+				"member-1": targetAnnotations,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(serviceScheme(t)).
+				WithObjects(append(tc.objects, tc.upgrade)...).
+				WithStatusSubresource(&clusterv1beta1.MemberAgentUpgrade{}).
+				Build()
+			r := &Reconciler{
+				Client:          fakeClient,
+				Recorder:        utils.NewFakeRecorder(10),
+				HubAgentVersion: hubVersion,
+			}
+			if tc.hubVersion != "" {
+				r.HubAgentVersion = tc.hubVersion
+			}
+
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: tc.upgrade.Name}}); err != nil {
+				t.Fatalf("Reconcile() = %v, want no error", err)
+			}
+
+			got := &clusterv1beta1.MemberAgentUpgrade{}
+			if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: tc.upgrade.Name}, got); err != nil {
+				t.Fatalf("Get() member agent upgrade = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tc.wantStatus, got.Status, ignoreConditionOption, sortConditionsOption); diff != "" {
+				t.Errorf("member agent upgrade status mismatch (-want, +got):\n%s", diff)
+			}
+
+			for name, wantAnnotations := range tc.wantAnnotations {
+				imc := &clusterv1beta1.InternalMemberCluster{}
+				if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(internalMemberCluster(name)), imc); err != nil {
+					t.Fatalf("Get() internal member cluster %s = %v, want no error", name, err)
+				}
+				if diff := cmp.Diff(wantAnnotations, imc.Annotations, cmpopts.EquateEmpty()); diff != "" {
+					t.Errorf("internal member cluster %s annotations mismatch (-want, +got):\n%s", name, diff)
+				}
+			}
+		})
+	}
+}
+
+// TestFindNewerUpgrade tests the findNewerUpgrade function.
+func TestFindNewerUpgrade(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	upgrade := memberAgentUpgrade("upgrade-b", now)
+	deleting := memberAgentUpgrade("upgrade-e", now.Add(2*time.Minute))
+	deleting.DeletionTimestamp = ptr.To(metav1.NewTime(now))
+
+	testCases := map[string]struct {
+		upgrades []clusterv1beta1.MemberAgentUpgrade
+		want     string
+	}{
+		"no other upgrades": {
+			upgrades: []clusterv1beta1.MemberAgentUpgrade{*upgrade},
+		},
+		"older upgrades only": {
+			upgrades: []clusterv1beta1.MemberAgentUpgrade{*memberAgentUpgrade("upgrade-c", now.Add(-time.Minute)), *upgrade},
+		},
+		"created at the same time with a smaller name": {
+			upgrades: []clusterv1beta1.MemberAgentUpgrade{*memberAgentUpgrade("upgrade-a", now), *upgrade},
+		},
+		"created at the same time with a greater name": {
+			upgrades: []clusterv1beta1.MemberAgentUpgrade{*memberAgentUpgrade("upgrade-c", now), *upgrade},
+			want:     "upgrade-c",
+		},
+		"the newest upgrade wins": {
+			upgrades: []clusterv1beta1.MemberAgentUpgrade{
+				*upgrade,
+				*memberAgentUpgrade("upgrade-c", now.Add(time.Minute)),
+				*memberAgentUpgrade("upgrade-a", now.Add(time.Hour)),
+			},
+			want: "upgrade-a",
+		},
+		"deleting upgrades are ignored": {
+			upgrades: []clusterv1beta1.MemberAgentUpgrade{*upgrade, *deleting},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := findNewerUpgrade(upgrade, tc.upgrades)
+			gotName := ""
+			if got != nil {
+				gotName = got.Name
+			}
+			if gotName != tc.want {
+				t.Errorf("findNewerUpgrade() = %q, want %q", gotName, tc.want)
+			}
+		})
+	}
+}
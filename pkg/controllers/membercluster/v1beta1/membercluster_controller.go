@@ -45,6 +45,7 @@ const (
 	eventReasonRoleUpdated            = "RoleUpdated"
 	eventReasonRoleBindingCreated     = "RoleBindingCreated"
 	eventReasonRoleBindingUpdated     = "RoleBindingUpdated"
+	eventReasonRoleBindingRotated     = "RoleBindingRotated"
 	eventReasonIMCCreated             = "InternalMemberClusterCreated"
 	eventReasonIMCSpecUpdated         = "InternalMemberClusterSpecUpdated"
 	reasonMemberClusterReadyToJoin    = "MemberClusterReadyToJoin"
@@ -233,7 +234,15 @@ func (r *Reconciler) garbageCollectWork(ctx context.Context, mc *clusterv1beta1.
 }
 
 // garbageCollect is used to garbage collect all the resources in the cluster namespace associated with the member cluster.
+//
+// If the member cluster has the cleanup finalizer, the cleanup is handed over to the cluster deletion controller,
+// which cleans up all the artifacts of the member cluster in a safe order.
 func (r *Reconciler) garbageCollect(ctx context.Context, mc *clusterv1beta1.MemberCluster) error {
+	if controllerutil.ContainsFinalizer(mc, placementv1beta1.MemberClusterCleanupFinalizer) {
+		klog.V(2).InfoS("Hand over the cleanup to the cluster deletion controller", "memberCluster", klog.KObj(mc))
+		controllerutil.RemoveFinalizer(mc, placementv1beta1.MemberClusterFinalizer)
+		return controller.NewUpdateIgnoreConflictError(r.Update(ctx, mc))
+	}
 	// check if the namespace still exist
 	var clusterNS corev1.Namespace
 	namespaceName := fmt.Sprintf(utils.NamespaceNameFormat, mc.Name)
@@ -355,6 +364,9 @@ func (r *Reconciler) syncNamespace(ctx context.Context, mc *clusterv1beta1.Membe
 }
 
 // syncRole creates or updates the role for member cluster to access its namespace in hub cluster.
+//
+// The role only grants the member agent access to the objects it consumes in its own namespace, i.e.,
+// the Works (and their status) and the InternalMemberCluster (and its status).
 func (r *Reconciler) syncRole(ctx context.Context, mc *clusterv1beta1.MemberCluster, namespaceName string) (string, error) {
 	klog.V(2).InfoS("Sync the role for the member cluster", "memberCluster", klog.KObj(mc))
 	// Role name is created using member cluster name.
@@ -365,7 +377,14 @@ func (r *Reconciler) syncRole(ctx context.Context, mc *clusterv1beta1.MemberClus
 			Namespace:       namespaceName,
 			OwnerReferences: []metav1.OwnerReference{*toOwnerReference(mc)},
 		},
-		Rules: []rbacv1.PolicyRule{utils.FleetClusterRule, utils.FleetPlacementRule, utils.FleetNetworkRule, utils.EventRule},
+		Rules: []rbacv1.PolicyRule{
+			utils.InternalMemberClusterRule,
+			utils.InternalMemberClusterStatusRule,
+			utils.PlacementWorkRule,
+			utils.PlacementWorkStatusRule,
+			utils.FleetNetworkRule,
+			utils.EventRule,
+		},
 	}
 
 	// Creates role if not found.
@@ -383,11 +402,13 @@ func (r *Reconciler) syncRole(ctx context.Context, mc *clusterv1beta1.MemberClus
 		return roleName, nil
 	}
 
-	// Updates role if currentRole != expectedRole.
-	if reflect.DeepEqual(currentRole.Rules, expectedRole.Rules) {
+	// Updates role if currentRole != expectedRole, or if the role was created for a previous member
+	// cluster of the same name.
+	if reflect.DeepEqual(currentRole.Rules, expectedRole.Rules) && !isOwnedByPreviousMemberCluster(&currentRole, mc) {
 		return roleName, nil
 	}
 	currentRole.Rules = expectedRole.Rules
+	currentRole.OwnerReferences = expectedRole.OwnerReferences
 	klog.V(2).InfoS("updating role", "memberCluster", klog.KObj(mc), "role", roleName)
 	if err := r.Client.Update(ctx, &currentRole, client.FieldOwner(utils.MCControllerFieldManagerName)); err != nil {
 		return "", fmt.Errorf("failed to update role %s with rules %+v: %w", roleName, currentRole.Rules, err)
@@ -398,6 +419,10 @@ func (r *Reconciler) syncRole(ctx context.Context, mc *clusterv1beta1.MemberClus
 }
 
 // syncRoleBinding creates or updates the role binding for member cluster to access its namespace in hub cluster.
+//
+// If the role binding was created for a previous member cluster of the same name, i.e., the member cluster
+// has been recreated, the role binding is rotated (deleted and created again) so that no access granted
+// to the previous member cluster identity carries over.
 func (r *Reconciler) syncRoleBinding(ctx context.Context, mc *clusterv1beta1.MemberCluster, namespaceName string, roleName string) error {
 	klog.V(2).InfoS("Sync the roleBinding for the member cluster", "memberCluster", klog.KObj(mc))
 	// Role binding name is created using member cluster name
@@ -431,6 +456,19 @@ func (r *Reconciler) syncRoleBinding(ctx context.Context, mc *clusterv1beta1.Mem
 		return nil
 	}
 
+	if isOwnedByPreviousMemberCluster(&currentRoleBinding, mc) {
+		klog.V(2).InfoS("rotating role binding", "memberCluster", klog.KObj(mc), "subject", mc.Spec.Identity)
+		if err := r.Client.Delete(ctx, &currentRoleBinding, client.Preconditions{UID: &currentRoleBinding.UID}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete role binding %s of a previous member cluster: %w", roleBindingName, err)
+		}
+		if err := r.Client.Create(ctx, &expectedRoleBinding, client.FieldOwner(utils.MCControllerFieldManagerName)); err != nil {
+			return fmt.Errorf("failed to create role binding %s: %w", roleBindingName, err)
+		}
+		r.recorder.Event(mc, corev1.EventTypeNormal, eventReasonRoleBindingRotated, "role binding was rotated")
+		klog.V(2).InfoS("rotated role binding", "memberCluster", klog.KObj(mc), "subject", mc.Spec.Identity)
+		return nil
+	}
+
 	// Updates role binding if currentRoleBinding != expectedRoleBinding.
 	if reflect.DeepEqual(currentRoleBinding.Subjects, expectedRoleBinding.Subjects) && reflect.DeepEqual(currentRoleBinding.RoleRef, expectedRoleBinding.RoleRef) {
 		return nil
@@ -438,7 +476,7 @@ func (r *Reconciler) syncRoleBinding(ctx context.Context, mc *clusterv1beta1.Mem
 	currentRoleBinding.Subjects = expectedRoleBinding.Subjects
 	currentRoleBinding.RoleRef = expectedRoleBinding.RoleRef
 	klog.V(2).InfoS("updating role binding", "memberCluster", klog.KObj(mc), "subject", mc.Spec.Identity)
-	if err := r.Client.Update(ctx, &expectedRoleBinding, client.FieldOwner(utils.MCControllerFieldManagerName)); err != nil {
+	if err := r.Client.Update(ctx, &currentRoleBinding, client.FieldOwner(utils.MCControllerFieldManagerName)); err != nil {
 		return fmt.Errorf("failed to update role binding %s: %w", roleBindingName, err)
 	}
 	r.recorder.Event(mc, corev1.EventTypeNormal, eventReasonRoleBindingUpdated, "role binding was updated")
@@ -496,6 +534,18 @@ func toOwnerReference(memberCluster *clusterv1beta1.MemberCluster) *metav1.Owner
 		Name: memberCluster.Name, UID: memberCluster.UID, Controller: ptr.To(true)}
 }
 
+// isOwnedByPreviousMemberCluster returns true if the object is owned by a member cluster of the same name
+// but with a different UID, i.e., the member cluster has been deleted and then recreated.
+func isOwnedByPreviousMemberCluster(obj metav1.Object, mc *clusterv1beta1.MemberCluster) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == clusterv1beta1.MemberClusterKind && ref.APIVersion == clusterv1beta1.GroupVersion.String() &&
+			ref.Name == mc.Name && ref.UID != mc.UID {
+			return true
+		}
+	}
+	return false
+}
+
 // syncInternalMemberClusterStatus is used to sync status from InternalMemberCluster to MemberCluster & aggregate join conditions from all agents.
 func (r *Reconciler) syncInternalMemberClusterStatus(imc *clusterv1beta1.InternalMemberCluster, mc *clusterv1beta1.MemberCluster) {
 	klog.V(2).InfoS("Sync the internalMemberCluster status", "memberCluster", klog.KObj(mc))
@@ -511,12 +561,12 @@ func (r *Reconciler) syncInternalMemberClusterStatus(imc *clusterv1beta1.Interna
 	mc.Status.ResourceUsage = imc.Status.ResourceUsage
 	// Copy additional conditions.
 	for idx := range imc.Status.Conditions {
-		cond := imc.Status.Conditions[idx]
-		cond.ObservedGeneration = mc.GetGeneration()
-		meta.SetStatusCondition(&mc.Status.Conditions, cond)
+		condition.SetConditionWithGeneration(&mc.Status.Conditions, imc.Status.Conditions[idx], mc.GetGeneration())
 	}
 	// Copy the cluster properties.
 	mc.Status.Properties = imc.Status.Properties
+	// Copy the API group versions.
+	mc.Status.APIGroupVersions = imc.Status.APIGroupVersions
 }
 
 // updateMemberClusterStatus is used to update member cluster status.
@@ -586,7 +636,7 @@ func markMemberClusterReadyToJoin(recorder record.EventRecorder, mc apis.Conditi
 
 	// Joined status changed.
 	existingCondition := mc.GetCondition(newCondition.Type)
-	if existingCondition == nil || existingCondition.Status != newCondition.Status {
+	if condition.IsTransitioned(existingCondition, &newCondition) {
 		recorder.Event(mc, corev1.EventTypeNormal, reasonMemberClusterReadyToJoin, "member cluster ready to join")
 		klog.V(2).InfoS("member cluster ready to join", "memberCluster", klog.KObj(mc))
 	}
@@ -606,7 +656,7 @@ func markMemberClusterJoined(recorder record.EventRecorder, mc apis.ConditionedO
 
 	// Joined status changed.
 	existingCondition := mc.GetCondition(newCondition.Type)
-	if existingCondition == nil || existingCondition.Status != newCondition.Status {
+	if condition.IsTransitioned(existingCondition, &newCondition) {
 		recorder.Event(mc, corev1.EventTypeNormal, reasonMemberClusterJoined, "member cluster joined")
 		klog.V(2).InfoS("memberCluster joined", "memberCluster", klog.KObj(mc))
 		metrics.ReportJoinResultMetric()
@@ -633,7 +683,7 @@ func markMemberClusterLeft(recorder record.EventRecorder, mc apis.ConditionedObj
 
 	// Joined status changed.
 	existingCondition := mc.GetCondition(newCondition.Type)
-	if existingCondition == nil || existingCondition.Status != newCondition.Status {
+	if condition.IsTransitioned(existingCondition, &newCondition) {
 		recorder.Event(mc, corev1.EventTypeNormal, reasonMemberClusterJoined, "member cluster left")
 		klog.V(2).InfoS("memberCluster left", "memberCluster", klog.KObj(mc))
 		metrics.ReportLeaveResultMetric()
@@ -654,7 +704,7 @@ func markMemberClusterUnknown(recorder record.EventRecorder, mc apis.Conditioned
 
 	// Joined status changed.
 	existingCondition := mc.GetCondition(newCondition.Type)
-	if existingCondition == nil || existingCondition.Status != newCondition.Status {
+	if condition.IsTransitioned(existingCondition, &newCondition) {
 		recorder.Event(mc, corev1.EventTypeWarning, reasonMemberClusterUnknown, "member cluster join state unknown")
 		klog.V(2).InfoS("memberCluster join state unknown", "memberCluster", klog.KObj(mc))
 	}
@@ -194,6 +194,9 @@ func TestSyncRole(t *testing.T) {
 	expectedMemberCluster2 := clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "mc3"}}
 	expectedEvent1 := utils.GetEventString(&expectedMemberCluster1, corev1.EventTypeNormal, eventReasonRoleUpdated, "role was updated")
 	expectedEvent2 := utils.GetEventString(&expectedMemberCluster2, corev1.EventTypeNormal, eventReasonRoleCreated, "role was created")
+	previousMemberCluster := clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "mc7", UID: "previous-uid"}}
+	recreatedMemberCluster := clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: "mc7", UID: "recreated-uid"}}
+	expectedEvent3 := utils.GetEventString(&recreatedMemberCluster, corev1.EventTypeNormal, eventReasonRoleUpdated, "role was updated")
 
 	tests := map[string]struct {
 		r              *Reconciler
@@ -217,7 +220,14 @@ func TestSyncRole(t *testing.T) {
 								Name:      "fleet-role-mc1",
 								Namespace: namespace1,
 							},
-							Rules: []rbacv1.PolicyRule{utils.FleetClusterRule, utils.FleetPlacementRule, utils.FleetNetworkRule, utils.EventRule},
+							Rules: []rbacv1.PolicyRule{
+								utils.InternalMemberClusterRule,
+								utils.InternalMemberClusterStatusRule,
+								utils.PlacementWorkRule,
+								utils.PlacementWorkStatusRule,
+								utils.FleetNetworkRule,
+								utils.EventRule,
+							},
 						}
 						return nil
 					},
@@ -253,6 +263,44 @@ func TestSyncRole(t *testing.T) {
 			wantedEvent:    expectedEvent1,
 			wantedError:    "",
 		},
+		"role exists but owned by a previous member cluster": {
+			r: &Reconciler{
+				Client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						o := obj.(*rbacv1.Role)
+						*o = rbacv1.Role{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:            "fleet-role-mc7",
+								Namespace:       "fleet-mc7",
+								OwnerReferences: []metav1.OwnerReference{*toOwnerReference(&previousMemberCluster)},
+							},
+							Rules: []rbacv1.PolicyRule{
+								utils.InternalMemberClusterRule,
+								utils.InternalMemberClusterStatusRule,
+								utils.PlacementWorkRule,
+								utils.PlacementWorkStatusRule,
+								utils.FleetNetworkRule,
+								utils.EventRule,
+							},
+						}
+						return nil
+					},
+					MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+						o := obj.(*rbacv1.Role)
+						if len(o.OwnerReferences) != 1 || o.OwnerReferences[0].UID != recreatedMemberCluster.UID {
+							return fmt.Errorf("role owner references %+v, want the recreated member cluster", o.OwnerReferences)
+						}
+						return nil
+					},
+				},
+				recorder: utils.NewFakeRecorder(1),
+			},
+			memberCluster:  &recreatedMemberCluster,
+			namespaceName:  "fleet-mc7",
+			wantedRoleName: "fleet-role-mc7",
+			wantedEvent:    expectedEvent3,
+			wantedError:    "",
+		},
 		"role doesn't exist": {
 			r: &Reconciler{
 				Client: &test.MockClient{
@@ -374,6 +422,15 @@ func TestSyncRoleBinding(t *testing.T) {
 	}
 	expectedEvent1 := utils.GetEventString(&expectedMemberCluster1, corev1.EventTypeNormal, eventReasonRoleBindingUpdated, "role binding was updated")
 	expectedEvent2 := utils.GetEventString(&expectedMemberCluster2, corev1.EventTypeNormal, eventReasonRoleBindingCreated, "role binding was created")
+	previousMemberCluster := clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc7", UID: "previous-uid"},
+		Spec:       clusterv1beta1.MemberClusterSpec{Identity: rbacv1.Subject{Kind: "User", Name: "PreviousMemberClusterIdentity"}},
+	}
+	recreatedMemberCluster := clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc7", UID: "recreated-uid"},
+		Spec:       clusterv1beta1.MemberClusterSpec{Identity: identity},
+	}
+	expectedEvent3 := utils.GetEventString(&recreatedMemberCluster, corev1.EventTypeNormal, eventReasonRoleBindingRotated, "role binding was rotated")
 
 	tests := map[string]struct {
 		r             *Reconciler
@@ -450,6 +507,52 @@ func TestSyncRoleBinding(t *testing.T) {
 			wantedEvent:   expectedEvent1,
 			wantedError:   "",
 		},
+		"role binding owned by a previous member cluster": {
+			r: &Reconciler{
+				Client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						o := obj.(*rbacv1.RoleBinding)
+						*o = rbacv1.RoleBinding{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:            "fleet-rolebinding-mc7",
+								Namespace:       "fleet-mc7",
+								UID:             "previous-role-binding-uid",
+								OwnerReferences: []metav1.OwnerReference{*toOwnerReference(&previousMemberCluster)},
+							},
+							Subjects: []rbacv1.Subject{previousMemberCluster.Spec.Identity},
+							RoleRef: rbacv1.RoleRef{
+								APIGroup: rbacv1.GroupName,
+								Kind:     "Role",
+								Name:     "fleet-role-mc7",
+							},
+						}
+						return nil
+					},
+					MockDelete: func(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+						if obj.GetUID() != "previous-role-binding-uid" {
+							return fmt.Errorf("deleted role binding with UID %s, want the previous role binding", obj.GetUID())
+						}
+						return nil
+					},
+					MockCreate: func(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+						o := obj.(*rbacv1.RoleBinding)
+						if len(o.OwnerReferences) != 1 || o.OwnerReferences[0].UID != recreatedMemberCluster.UID {
+							return fmt.Errorf("role binding owner references %+v, want the recreated member cluster", o.OwnerReferences)
+						}
+						if len(o.Subjects) != 1 || o.Subjects[0] != identity {
+							return fmt.Errorf("role binding subjects %+v, want %+v", o.Subjects, identity)
+						}
+						return nil
+					},
+				},
+				recorder: utils.NewFakeRecorder(1),
+			},
+			memberCluster: &recreatedMemberCluster,
+			namespaceName: "fleet-mc7",
+			roleName:      "fleet-role-mc7",
+			wantedEvent:   expectedEvent3,
+			wantedError:   "",
+		},
 		"role binding doesn't exist": {
 			r: &Reconciler{
 				Client: &test.MockClient{
@@ -501,6 +604,13 @@ func TestSyncRoleBinding(t *testing.T) {
 			r: &Reconciler{
 				Client: &test.MockClient{
 					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						o := obj.(*rbacv1.RoleBinding)
+						*o = rbacv1.RoleBinding{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "fleet-rolebinding-mc6",
+								Namespace: "fleet-mc6",
+							},
+						}
 						return nil
 					},
 					MockUpdate: updateMock},
@@ -752,6 +862,7 @@ func TestSyncInternalMemberClusterStatus(t *testing.T) {
 						},
 						ObservationTime: now,
 					},
+					APIGroupVersions: []string{"apps/v1", "v1"},
 					AgentStatus: []clusterv1beta1.AgentStatus{
 						{
 							Type: clusterv1beta1.MemberAgent,
@@ -825,6 +936,7 @@ func TestSyncInternalMemberClusterStatus(t *testing.T) {
 						},
 						ObservationTime: now,
 					},
+					APIGroupVersions: []string{"apps/v1", "v1"},
 					AgentStatus: []clusterv1beta1.AgentStatus{
 						{
 							Type: clusterv1beta1.MemberAgent,
@@ -1455,6 +1567,8 @@ func TestSyncInternalMemberClusterStatus(t *testing.T) {
 			assert.Equal(t, tt.wantedMemberCluster.Status.Properties, tt.memberCluster.Status.Properties)
 			// Compare the resource usage.
 			assert.Equal(t, tt.wantedMemberCluster.Status.ResourceUsage, tt.memberCluster.Status.ResourceUsage)
+			// Compare the API group versions.
+			assert.Equal(t, tt.wantedMemberCluster.Status.APIGroupVersions, tt.memberCluster.Status.APIGroupVersions)
 			// Compare the agent status.
 			assert.Equal(t, tt.wantedMemberCluster.Status.AgentStatus, tt.memberCluster.Status.AgentStatus)
 		})
@@ -1551,6 +1665,12 @@ func TestHandleDelete(t *testing.T) {
 			Finalizers: []string{placementv1beta1.MemberClusterFinalizer},
 		},
 	}
+	memberClusterWithCleanupFinalizer := clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "mc1",
+			Finalizers: []string{placementv1beta1.MemberClusterFinalizer, placementv1beta1.MemberClusterCleanupFinalizer},
+		},
+	}
 	tests := map[string]struct {
 		r             *Reconciler
 		memberCluster *clusterv1beta1.MemberCluster
@@ -1669,6 +1789,39 @@ func TestHandleDelete(t *testing.T) {
 			wantResult:    ctrl.Result{Requeue: true},
 			wantErr:       nil,
 		},
+		"hand over the cleanup to the cluster deletion controller when the imc does not exist": {
+			r: &Reconciler{
+				Client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						if key.Namespace == "" {
+							// this is to get the namespace
+							o := obj.(*corev1.Namespace)
+							*o = corev1.Namespace{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:   namespace1,
+									Labels: map[string]string{placementv1beta1.FleetResourceLabelKey: "true"},
+								},
+							}
+							return nil
+						}
+						// this is to get the imc
+						return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+					},
+					MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+						// this is to verify that only the memberCluster finalizer is removed
+						o := obj.(*clusterv1beta1.MemberCluster)
+						if len(o.Finalizers) != 1 || o.Finalizers[0] != placementv1beta1.MemberClusterCleanupFinalizer {
+							return fmt.Errorf("unexpected MemberCluster object %+v", o)
+						}
+						return nil
+					},
+				},
+				recorder: utils.NewFakeRecorder(1),
+			},
+			memberCluster: memberClusterWithCleanupFinalizer.DeepCopy(),
+			wantResult:    ctrl.Result{Requeue: true},
+			wantErr:       nil,
+		},
 	}
 
 	for name, tt := range tests {
@@ -0,0 +1,217 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package pinnedplacement features a controller that exports the scheduling decisions of CRPs to
+// PinnedPlacement objects, and detects the drift between the pinned and the computed decisions.
+package pinnedplacement
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// the reasons of the InSync condition.
+	pinnedPlacementInSyncReason            = "DecisionsInSync"
+	pinnedPlacementDriftedReason           = "DecisionsDrifted"
+	pinnedPlacementPlacementNotFoundReason = "PlacementNotFound"
+	pinnedPlacementSchedulingPendingReason = "SchedulingPending"
+)
+
+// Reconciler reconciles a PinnedPlacement object with the scheduling decisions of the CRP of the same name.
+type Reconciler struct {
+	client.Client
+}
+
+// Reconcile exports the scheduling decisions of the CRP to the PinnedPlacement in the Export mode,
+// and reports whether the pinned decisions and the computed decisions are in sync.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	pinnedPlacementRef := klog.KRef("", req.Name)
+	startTime := time.Now()
+	klog.V(2).InfoS("Reconciliation starts (pinned placement controller)", "pinnedPlacement", pinnedPlacementRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("Reconciliation ends (pinned placement controller)", "pinnedPlacement", pinnedPlacementRef, "latency", latency)
+	}()
+
+	pinnedPlacement := &placementv1alpha1.PinnedPlacement{}
+	if err := r.Get(ctx, req.NamespacedName, pinnedPlacement); err != nil {
+		if apierrors.IsNotFound(err) {
+			klog.V(2).InfoS("Pinned placement object is not found", "pinnedPlacement", pinnedPlacementRef)
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get pinned placement", "pinnedPlacement", pinnedPlacementRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+
+	crp := &placementv1beta1.ClusterResourcePlacement{}
+	if err := r.Get(ctx, types.NamespacedName{Name: pinnedPlacement.Name}, crp); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "Failed to get cluster resource placement", "pinnedPlacement", pinnedPlacementRef)
+			return ctrl.Result{}, controller.NewAPIServerError(true, err)
+		}
+		pinnedPlacement.Status.ComputedClusterNames = nil
+		pinnedPlacement.SetConditions(metav1.Condition{
+			Type:               string(placementv1alpha1.PinnedPlacementConditionTypeInSync),
+			Status:             metav1.ConditionFalse,
+			Reason:             pinnedPlacementPlacementNotFoundReason,
+			Message:            fmt.Sprintf("ClusterResourcePlacement %s is not found", pinnedPlacement.Name),
+			ObservedGeneration: pinnedPlacement.Generation,
+		})
+		return ctrl.Result{}, r.updateStatus(ctx, pinnedPlacement)
+	}
+
+	computed, ok, err := r.collectComputedClusters(ctx, crp)
+	if err != nil {
+		klog.ErrorS(err, "Failed to collect the scheduling decisions", "pinnedPlacement", pinnedPlacementRef)
+		return ctrl.Result{}, err
+	}
+	if !ok {
+		// The scheduler has not scheduled the latest policy yet; the controller will be triggered
+		// again when it does.
+		pinnedPlacement.SetConditions(metav1.Condition{
+			Type:               string(placementv1alpha1.PinnedPlacementConditionTypeInSync),
+			Status:             metav1.ConditionUnknown,
+			Reason:             pinnedPlacementSchedulingPendingReason,
+			Message:            "The scheduler has not scheduled the latest scheduling policy of the placement yet",
+			ObservedGeneration: pinnedPlacement.Generation,
+		})
+		return ctrl.Result{}, r.updateStatus(ctx, pinnedPlacement)
+	}
+
+	if pinnedPlacement.Spec.Mode != placementv1alpha1.PinnedPlacementModeImport && !sets.New(pinnedPlacement.Spec.ClusterNames...).Equal(computed) {
+		// Export the scheduling decisions.
+		pinnedPlacement.Spec.ClusterNames = sets.List(computed)
+		if err := r.Update(ctx, pinnedPlacement); err != nil {
+			klog.ErrorS(err, "Failed to export the scheduling decisions", "pinnedPlacement", pinnedPlacementRef)
+			return ctrl.Result{}, controller.NewUpdateIgnoreConflictError(err)
+		}
+		klog.V(2).InfoS("Exported the scheduling decisions", "pinnedPlacement", pinnedPlacementRef, "clusterCount", computed.Len())
+	}
+
+	pinnedPlacement.Status.ComputedClusterNames = sets.List(computed)
+	pinnedPlacement.SetConditions(inSyncCondition(pinnedPlacement, computed))
+	return ctrl.Result{}, r.updateStatus(ctx, pinnedPlacement)
+}
+
+// collectComputedClusters returns the names of the clusters picked by the scheduler per the latest
+// policy snapshot of the CRP; it returns false if the latest policy snapshot has not been scheduled yet.
+func (r *Reconciler) collectComputedClusters(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement) (sets.Set[string], bool, error) {
+	policySnapshotList := &placementv1beta1.ClusterSchedulingPolicySnapshotList{}
+	if err := r.List(ctx, policySnapshotList, client.MatchingLabels{
+		placementv1beta1.CRPTrackingLabel:      crp.Name,
+		placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+	}); err != nil {
+		return nil, false, controller.NewAPIServerError(true, err)
+	}
+	if len(policySnapshotList.Items) != 1 {
+		// The latest policy snapshot has not been created yet, or the sequence of policy snapshots
+		// is in an inconsistent state, which the CRP controller will correct.
+		return nil, false, nil
+	}
+
+	policy := &policySnapshotList.Items[0]
+	scheduledCond := meta.FindStatusCondition(policy.Status.Conditions, string(placementv1beta1.PolicySnapshotScheduled))
+	if scheduledCond == nil || scheduledCond.ObservedGeneration != policy.Generation {
+		return nil, false, nil
+	}
+
+	computed := sets.New[string]()
+	for _, decision := range policy.Status.ClusterDecisions {
+		if decision.Selected {
+			computed.Insert(decision.ClusterName)
+		}
+	}
+	return computed, true, nil
+}
+
+// inSyncCondition returns the InSync condition of a PinnedPlacement, given the computed clusters.
+func inSyncCondition(pinnedPlacement *placementv1alpha1.PinnedPlacement, computed sets.Set[string]) metav1.Condition {
+	pinned := sets.New(pinnedPlacement.Spec.ClusterNames...)
+	if pinned.Equal(computed) {
+		return metav1.Condition{
+			Type:               string(placementv1alpha1.PinnedPlacementConditionTypeInSync),
+			Status:             metav1.ConditionTrue,
+			Reason:             pinnedPlacementInSyncReason,
+			Message:            fmt.Sprintf("The pinned clusters are the same as the %d cluster(s) picked by the scheduler", computed.Len()),
+			ObservedGeneration: pinnedPlacement.Generation,
+		}
+	}
+
+	var diffs []string
+	if missing := pinned.Difference(computed); missing.Len() > 0 {
+		diffs = append(diffs, fmt.Sprintf("pinned but not picked by the scheduler: [%s]", strings.Join(sets.List(missing), ", ")))
+	}
+	if extra := computed.Difference(pinned); extra.Len() > 0 {
+		diffs = append(diffs, fmt.Sprintf("picked by the scheduler but not pinned: [%s]", strings.Join(sets.List(extra), ", ")))
+	}
+	return metav1.Condition{
+		Type:               string(placementv1alpha1.PinnedPlacementConditionTypeInSync),
+		Status:             metav1.ConditionFalse,
+		Reason:             pinnedPlacementDriftedReason,
+		Message:            fmt.Sprintf("The pinned clusters have drifted from the clusters picked by the scheduler; %s", strings.Join(diffs, "; ")),
+		ObservedGeneration: pinnedPlacement.Generation,
+	}
+}
+
+// updateStatus updates the status of a PinnedPlacement.
+func (r *Reconciler) updateStatus(ctx context.Context, pinnedPlacement *placementv1alpha1.PinnedPlacement) error {
+	pinnedPlacement.Status.ObservedGeneration = pinnedPlacement.Generation
+	if err := r.Status().Update(ctx, pinnedPlacement); err != nil {
+		klog.ErrorS(err, "Failed to update pinned placement status", "pinnedPlacement", klog.KObj(pinnedPlacement))
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("pinned-placement-controller").
+		For(&placementv1alpha1.PinnedPlacement{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Re-evaluate the PinnedPlacement when the scheduling decisions of the CRP change.
+		Watches(&placementv1beta1.ClusterSchedulingPolicySnapshot{},
+			handler.EnqueueRequestsFromMapFunc(enqueuePinnedPlacementForPolicySnapshot)).
+		// Re-evaluate the PinnedPlacement when the CRP is created or deleted.
+		Watches(&placementv1beta1.ClusterResourcePlacement{},
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(_ event.UpdateEvent) bool {
+					return false
+				},
+			})).
+		Complete(r)
+}
+
+// enqueuePinnedPlacementForPolicySnapshot returns the reconcile request for the PinnedPlacement
+// that shares the name of the CRP which owns the policy snapshot.
+func enqueuePinnedPlacementForPolicySnapshot(_ context.Context, obj client.Object) []reconcile.Request {
+	crpName, ok := obj.GetLabels()[placementv1beta1.CRPTrackingLabel]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: crpName}}}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package pinnedplacement
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	testCRPName = "test-crp"
+)
+
+func pinnedPlacement(mode placementv1alpha1.PinnedPlacementMode, clusterNames ...string) *placementv1alpha1.PinnedPlacement {
+	return &placementv1alpha1.PinnedPlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testCRPName,
+			Generation: 1,
+		},
+		Spec: placementv1alpha1.PinnedPlacementSpec{
+			Mode:         mode,
+			ClusterNames: clusterNames,
+		},
+	}
+}
+
+func policySnapshot(scheduled bool, selected ...string) *placementv1beta1.ClusterSchedulingPolicySnapshot {
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       fmt.Sprintf(placementv1beta1.PolicySnapshotNameFmt, testCRPName, 0),
+			Generation: 1,
+			Labels: map[string]string{
+				placementv1beta1.CRPTrackingLabel:      testCRPName,
+				placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+			},
+		},
+	}
+	if scheduled {
+		policy.Status.Conditions = []metav1.Condition{
+			{
+				Type:               string(placementv1beta1.PolicySnapshotScheduled),
+				Status:             metav1.ConditionTrue,
+				Reason:             "Scheduled",
+				ObservedGeneration: 1,
+			},
+		}
+	}
+	for _, name := range selected {
+		policy.Status.ClusterDecisions = append(policy.Status.ClusterDecisions, placementv1beta1.ClusterDecision{ClusterName: name, Selected: true})
+	}
+	policy.Status.ClusterDecisions = append(policy.Status.ClusterDecisions, placementv1beta1.ClusterDecision{ClusterName: "unselected", Selected: false})
+	return policy
+}
+
+// TestReconcile tests the Reconcile method.
+func TestReconcile(t *testing.T) {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testCRPName,
+		},
+	}
+
+	tests := map[string]struct {
+		pinnedPlacement  *placementv1alpha1.PinnedPlacement
+		objs             []client.Object
+		wantClusterNames []string
+		wantComputed     []string
+		wantInSync       metav1.ConditionStatus
+		wantReason       string
+	}{
+		"placement not found": {
+			pinnedPlacement:  pinnedPlacement(placementv1alpha1.PinnedPlacementModeImport, "member-1"),
+			wantClusterNames: []string{"member-1"},
+			wantInSync:       metav1.ConditionFalse,
+			wantReason:       pinnedPlacementPlacementNotFoundReason,
+		},
+		"scheduling pending": {
+			pinnedPlacement: pinnedPlacement(placementv1alpha1.PinnedPlacementModeExport),
+			objs:            []client.Object{crp.DeepCopy(), policySnapshot(false, "member-1")},
+			wantInSync:      metav1.ConditionUnknown,
+			wantReason:      pinnedPlacementSchedulingPendingReason,
+		},
+		"decisions exported": {
+			pinnedPlacement:  pinnedPlacement(placementv1alpha1.PinnedPlacementModeExport, "member-3"),
+			objs:             []client.Object{crp.DeepCopy(), policySnapshot(true, "member-2", "member-1")},
+			wantClusterNames: []string{"member-1", "member-2"},
+			wantComputed:     []string{"member-1", "member-2"},
+			wantInSync:       metav1.ConditionTrue,
+			wantReason:       pinnedPlacementInSyncReason,
+		},
+		"imported decisions in sync": {
+			pinnedPlacement:  pinnedPlacement(placementv1alpha1.PinnedPlacementModeImport, "member-1", "member-2"),
+			objs:             []client.Object{crp.DeepCopy(), policySnapshot(true, "member-2", "member-1")},
+			wantClusterNames: []string{"member-1", "member-2"},
+			wantComputed:     []string{"member-1", "member-2"},
+			wantInSync:       metav1.ConditionTrue,
+			wantReason:       pinnedPlacementInSyncReason,
+		},
+		"imported decisions drifted": {
+			pinnedPlacement:  pinnedPlacement(placementv1alpha1.PinnedPlacementModeImport, "member-1", "member-3"),
+			objs:             []client.Object{crp.DeepCopy(), policySnapshot(true, "member-1")},
+			wantClusterNames: []string{"member-1", "member-3"},
+			wantComputed:     []string{"member-1"},
+			wantInSync:       metav1.ConditionFalse,
+			wantReason:       pinnedPlacementDriftedReason,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := placementv1alpha1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add placement v1alpha1 scheme: %v", err)
+			}
+			if err := placementv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add placement v1beta1 scheme: %v", err)
+			}
+			objs := append([]client.Object{tc.pinnedPlacement}, tc.objs...)
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objs...).
+				WithStatusSubresource(&placementv1alpha1.PinnedPlacement{}).
+				Build()
+			r := &Reconciler{Client: fakeClient}
+
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: testCRPName}}); err != nil {
+				t.Fatalf("Reconcile() = %v, want no error", err)
+			}
+
+			got := &placementv1alpha1.PinnedPlacement{}
+			if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: testCRPName}, got); err != nil {
+				t.Fatalf("failed to get the pinned placement: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantClusterNames, got.Spec.ClusterNames); diff != "" {
+				t.Errorf("spec.clusterNames mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantComputed, got.Status.ComputedClusterNames); diff != "" {
+				t.Errorf("status.computedClusterNames mismatch (-want, +got):\n%s", diff)
+			}
+			cond := got.GetCondition(string(placementv1alpha1.PinnedPlacementConditionTypeInSync))
+			if cond == nil || cond.Status != tc.wantInSync || cond.Reason != tc.wantReason {
+				t.Errorf("InSync condition = %+v, want status %s and reason %s", cond, tc.wantInSync, tc.wantReason)
+			}
+		})
+	}
+}
+
+// TestInSyncCondition tests the inSyncCondition function.
+func TestInSyncCondition(t *testing.T) {
+	pinned := pinnedPlacement(placementv1alpha1.PinnedPlacementModeImport, "member-1", "member-2")
+	got := inSyncCondition(pinned, sets.New("member-2", "member-3"))
+	want := "The pinned clusters have drifted from the clusters picked by the scheduler; pinned but not picked by the scheduler: [member-1]; picked by the scheduler but not pinned: [member-3]"
+	if got.Message != want {
+		t.Errorf("inSyncCondition() message = %q, want %q", got.Message, want)
+	}
+}
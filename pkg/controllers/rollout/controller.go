@@ -9,15 +9,17 @@ package rollout
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"time"
 
-	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
@@ -39,6 +41,13 @@ import (
 	"go.goms.io/fleet/pkg/utils/defaulter"
 	"go.goms.io/fleet/pkg/utils/informer"
 	"go.goms.io/fleet/pkg/utils/overrider"
+	"go.goms.io/fleet/pkg/utils/parallelizer"
+)
+
+const (
+	// dependencyRecheckInterval is how long the controller waits before checking again whether the dependencies
+	// of a placement have become available, when some of its bindings are held back by them.
+	dependencyRecheckInterval = 15 * time.Second
 )
 
 // Reconciler recomputes the cluster resource binding.
@@ -51,6 +60,9 @@ type Reconciler struct {
 	// the informer contains the cache for all the resources we need.
 	// to check the resource scope
 	InformerManager informer.Manager
+	// Parallelizer runs the fan-out operations of the controller, e.g., updating bindings, in parallel;
+	// a nil parallelizer uses the default number of workers.
+	Parallelizer *parallelizer.Parallerlizer
 }
 
 // Reconcile triggers a single binding reconcile round.
@@ -169,8 +181,7 @@ func (r *Reconciler) checkAndUpdateStaleBindingsStatus(ctx context.Context, bind
 	if len(bindings) == 0 {
 		return nil
 	}
-	// issue all the update requests in parallel
-	errs, cctx := errgroup.WithContext(ctx)
+	staleBindings := make([]*fleetv1beta1.ClusterResourceBinding, 0, len(bindings))
 	for i := 0; i < len(bindings); i++ {
 		binding := bindings[i]
 		if binding.Spec.State != fleetv1beta1.BindingStateScheduled && binding.Spec.State != fleetv1beta1.BindingStateBound {
@@ -181,11 +192,13 @@ func (r *Reconciler) checkAndUpdateStaleBindingsStatus(ctx context.Context, bind
 			continue
 		}
 		klog.V(2).InfoS("Found a stale binding status and set rolloutStartedCondition to true", "binding", klog.KObj(binding))
-		errs.Go(func() error {
-			return r.updateBindingStatus(cctx, binding, true)
-		})
+		staleBindings = append(staleBindings, binding)
+	}
+	// issue all the update requests in parallel
+	doWork := func(cctx context.Context, piece int) error {
+		return r.updateBindingStatus(cctx, staleBindings[piece], true)
 	}
-	return errs.Wait()
+	return r.Parallelizer.ParallelizeUntilError(ctx, len(staleBindings), doWork, "checkAndUpdateStaleBindingsStatus")
 }
 
 // fetchLatestResourceSnapshot lists all the latest clusterResourceSnapshots associated with a CRP and returns the master clusterResourceSnapshot.
@@ -274,8 +287,27 @@ func waitForResourcesToCleanUp(allBindings []*fleetv1beta1.ClusterResourceBindin
 type toBeUpdatedBinding struct {
 	currentBinding *fleetv1beta1.ClusterResourceBinding
 	desiredBinding *fleetv1beta1.ClusterResourceBinding // only valid for scheduled or bound binding
+
+	// blockedReason and blockedMessage explain why the binding cannot be updated in this rolling phase;
+	// only valid for stale bindings.
+	blockedReason  string
+	blockedMessage string
 }
 
+const (
+	// staleBindingMessage is the message of the RolloutStarted condition of a stale binding, when the
+	// specific cause is not known.
+	staleBindingMessage = "The resources cannot be updated to the latest because of the rollout strategy"
+
+	maxUnavailableBlockedMessage = "The resources cannot be updated to the latest yet, as the number of unavailable clusters has reached the maxUnavailable limit of the rollout strategy"
+	maxSurgeBlockedMessage       = "The resources cannot be placed on the cluster yet, as the number of clusters with the resources has reached the maxSurge limit of the rollout strategy"
+	rolloutGroupBlockedMessage   = "The resources cannot be updated to the latest yet, as the rollout group at position %d is still rolling out"
+
+	// waitingForClustersMessageFmt is appended to the messages above to list the clusters that the rollout
+	// is waiting for.
+	waitingForClustersMessageFmt = "%s; waiting for the resources to become available on cluster(s) %v"
+)
+
 func createUpdateInfo(binding *fleetv1beta1.ClusterResourceBinding, crp *fleetv1beta1.ClusterResourcePlacement,
 	latestResourceSnapshot *fleetv1beta1.ClusterResourceSnapshot, cro []string, ro []fleetv1beta1.NamespacedName) toBeUpdatedBinding {
 	desiredBinding := binding.DeepCopy()
@@ -439,12 +471,164 @@ func (r *Reconciler) pickBindingsToRoll(ctx context.Context, allBindings []*flee
 		return toBeUpdatedBindingList, nil, false, minWaitTime, nil
 	}
 
+	// If the scheduler has assigned the bindings to rollout groups, roll out the groups one after another;
+	// bindings of a group wait until all the bindings of the earlier groups are updated and ready.
+	var heldBackBindings []toBeUpdatedBinding
+	if activeOrder, grouped := activeRolloutGroupOrder(schedulerTargetedBinds, readyBindings, updateCandidates, boundingCandidates, applyFailedUpdateCandidates); grouped {
+		klog.V(2).InfoS("Rolling out bindings by rollout group", "clusterResourcePlacement", crpKObj, "activeRolloutGroupOrder", activeOrder)
+		var held []toBeUpdatedBinding
+		notReadyClusters := notReadyTargetClusters(canBeReadyBindings, readyBindings)
+		updateCandidates, held = holdBackByRolloutGroup(updateCandidates, activeOrder)
+		heldBackBindings = append(heldBackBindings, held...)
+		boundingCandidates, held = holdBackByRolloutGroup(boundingCandidates, activeOrder)
+		heldBackBindings = append(heldBackBindings, held...)
+		applyFailedUpdateCandidates, held = holdBackByRolloutGroup(applyFailedUpdateCandidates, activeOrder)
+		heldBackBindings = append(heldBackBindings, held...)
+		for i := range heldBackBindings {
+			heldBackBindings[i].blockedReason = condition.RolloutBlockedByRolloutGroupReason
+			heldBackBindings[i].blockedMessage = withWaitingClusters(fmt.Sprintf(rolloutGroupBlockedMessage, activeOrder), notReadyClusters)
+		}
+	}
+
+	// If the placement depends on other placements, roll out a binding only after all the dependencies
+	// are available on its target cluster.
+	if len(crp.Spec.DependsOn) > 0 {
+		availableClusters, err := r.clustersWithDependenciesAvailable(ctx, crp)
+		if err != nil {
+			return nil, nil, false, minWaitTime, err
+		}
+		var held []toBeUpdatedBinding
+		heldCount := len(heldBackBindings)
+		updateCandidates, held = holdBackByDependencies(updateCandidates, availableClusters)
+		heldBackBindings = append(heldBackBindings, held...)
+		boundingCandidates, held = holdBackByDependencies(boundingCandidates, availableClusters)
+		heldBackBindings = append(heldBackBindings, held...)
+		applyFailedUpdateCandidates, held = holdBackByDependencies(applyFailedUpdateCandidates, availableClusters)
+		heldBackBindings = append(heldBackBindings, held...)
+		if len(heldBackBindings) > heldCount {
+			klog.V(2).InfoS("Holding back bindings whose dependencies are not available yet", "clusterResourcePlacement", crpKObj,
+				"dependsOn", crp.Spec.DependsOn, "numberOfHeldBackBindings", len(heldBackBindings)-heldCount)
+			// The status changes of the bindings of the dependencies do not trigger a reconcile; check back later.
+			if minWaitTime == 0 || minWaitTime > dependencyRecheckInterval {
+				minWaitTime = dependencyRecheckInterval
+			}
+		}
+	}
+
 	toBeUpdatedBindingList, staleUnselectedBinding := determineBindingsToUpdate(crp, removeCandidates, updateCandidates, boundingCandidates, applyFailedUpdateCandidates, targetNumber,
 		readyBindings, canBeReadyBindings, canBeUnavailableBindings)
+	staleUnselectedBinding = append(staleUnselectedBinding, heldBackBindings...)
 
 	return toBeUpdatedBindingList, staleUnselectedBinding, true, minWaitTime, nil
 }
 
+// rolloutGroupOrder returns the position of the rollout group of a binding in the rollout order, as
+// assigned by the scheduler; bindings that do not belong to any rollout group are rolled out last.
+func rolloutGroupOrder(binding *fleetv1beta1.ClusterResourceBinding) (order int, grouped bool) {
+	orderStr, ok := binding.Annotations[fleetv1beta1.RolloutGroupOrderAnnotation]
+	if !ok {
+		return math.MaxInt, false
+	}
+	order, err := strconv.Atoi(orderStr)
+	if err != nil || order < 0 {
+		klog.V(2).InfoS("Ignoring the invalid rollout group order of a binding", "binding", klog.KObj(binding), "order", orderStr)
+		return math.MaxInt, false
+	}
+	return order, true
+}
+
+// activeRolloutGroupOrder returns the position of the rollout group that is being rolled out, i.e., the
+// first group in the rollout order with bindings that are yet to be updated or yet to become ready.
+//
+// It returns false if none of the bindings belongs to a rollout group, in which case all the bindings
+// are rolled out together.
+func activeRolloutGroupOrder(schedulerTargetedBinds, readyBindings []*fleetv1beta1.ClusterResourceBinding, candidates ...[]toBeUpdatedBinding) (int, bool) {
+	grouped := false
+	activeOrder := math.MaxInt
+	readyBindingNames := make(map[string]bool, len(readyBindings))
+	for _, binding := range readyBindings {
+		readyBindingNames[binding.Name] = true
+	}
+	for _, binding := range schedulerTargetedBinds {
+		order, ok := rolloutGroupOrder(binding)
+		grouped = grouped || ok
+		// A bound binding which is not ready yet, e.g., one that has just been updated, blocks the
+		// rollout of the later groups.
+		if binding.Spec.State == fleetv1beta1.BindingStateBound && binding.DeletionTimestamp.IsZero() &&
+			!readyBindingNames[binding.Name] && order < activeOrder {
+			activeOrder = order
+		}
+	}
+	if !grouped {
+		return 0, false
+	}
+	for _, candidateSet := range candidates {
+		for _, candidate := range candidateSet {
+			if order, _ := rolloutGroupOrder(candidate.currentBinding); order < activeOrder {
+				activeOrder = order
+			}
+		}
+	}
+	return activeOrder, true
+}
+
+// holdBackByRolloutGroup splits the candidate bindings into the ones of the rollout groups up to the active
+// one, which can be rolled out, and the ones of the later groups, which must wait.
+func holdBackByRolloutGroup(candidates []toBeUpdatedBinding, activeOrder int) (allowed, held []toBeUpdatedBinding) {
+	allowed = make([]toBeUpdatedBinding, 0, len(candidates))
+	for _, candidate := range candidates {
+		if order, _ := rolloutGroupOrder(candidate.currentBinding); order > activeOrder {
+			held = append(held, candidate)
+			continue
+		}
+		allowed = append(allowed, candidate)
+	}
+	return allowed, held
+}
+
+// clustersWithDependenciesAvailable returns the clusters on which all the placements that a CRP depends on
+// are available, i.e., their bindings to the clusters are bound and available with the latest spec.
+func (r *Reconciler) clustersWithDependenciesAvailable(ctx context.Context, crp *fleetv1beta1.ClusterResourcePlacement) (sets.Set[string], error) {
+	var availableClusters sets.Set[string]
+	for _, dep := range crp.Spec.DependsOn {
+		bindingList := &fleetv1beta1.ClusterResourceBindingList{}
+		if err := r.Client.List(ctx, bindingList, client.MatchingLabels{fleetv1beta1.CRPTrackingLabel: dep}); err != nil {
+			klog.ErrorS(err, "Failed to list the bindings of a dependency", "clusterResourcePlacement", klog.KObj(crp), "dependency", dep)
+			return nil, controller.NewAPIServerError(true, err)
+		}
+		clusters := sets.New[string]()
+		for i := range bindingList.Items {
+			binding := &bindingList.Items[i]
+			if binding.Spec.State != fleetv1beta1.BindingStateBound || !binding.DeletionTimestamp.IsZero() {
+				continue
+			}
+			if condition.IsConditionStatusTrue(binding.GetCondition(string(fleetv1beta1.ResourceBindingAvailable)), binding.GetGeneration()) {
+				clusters.Insert(binding.Spec.TargetCluster)
+			}
+		}
+		if availableClusters == nil {
+			availableClusters = clusters
+		} else {
+			availableClusters = availableClusters.Intersection(clusters)
+		}
+	}
+	return availableClusters, nil
+}
+
+// holdBackByDependencies splits the candidate bindings into the ones targeting the clusters on which the
+// dependencies are available, which can be rolled out, and the other ones, which must wait.
+func holdBackByDependencies(candidates []toBeUpdatedBinding, availableClusters sets.Set[string]) (allowed, held []toBeUpdatedBinding) {
+	allowed = make([]toBeUpdatedBinding, 0, len(candidates))
+	for _, candidate := range candidates {
+		if !availableClusters.Has(candidate.currentBinding.Spec.TargetCluster) {
+			held = append(held, candidate)
+			continue
+		}
+		allowed = append(allowed, candidate)
+	}
+	return allowed, held
+}
+
 // determineBindingsToUpdate determines which bindings to update
 func determineBindingsToUpdate(
 	crp *fleetv1beta1.ClusterResourcePlacement,
@@ -485,16 +669,49 @@ func determineBindingsToUpdate(
 		toBeUpdatedBindingList = append(toBeUpdatedBindingList, boundingCandidates[boundingCandidatesUnselectedIndex])
 	}
 
+	// Explain why the stale bindings are not selected, so that a stuck rollout can be diagnosed from the bindings.
+	notReadyClusters := notReadyTargetClusters(canBeReadyBindings, readyBindings)
 	staleUnselectedBinding := make([]toBeUpdatedBinding, 0)
-	if updateCandidateUnselectedIndex < len(updateCandidates) {
-		staleUnselectedBinding = append(staleUnselectedBinding, updateCandidates[updateCandidateUnselectedIndex:]...)
+	for i := updateCandidateUnselectedIndex; i < len(updateCandidates); i++ {
+		stale := updateCandidates[i]
+		stale.blockedReason = condition.RolloutBlockedByMaxUnavailableReason
+		stale.blockedMessage = withWaitingClusters(maxUnavailableBlockedMessage, notReadyClusters)
+		staleUnselectedBinding = append(staleUnselectedBinding, stale)
 	}
-	if boundingCandidatesUnselectedIndex < len(boundingCandidates) {
-		staleUnselectedBinding = append(staleUnselectedBinding, boundingCandidates[boundingCandidatesUnselectedIndex:]...)
+	for i := boundingCandidatesUnselectedIndex; i < len(boundingCandidates); i++ {
+		stale := boundingCandidates[i]
+		stale.blockedReason = condition.RolloutBlockedByMaxSurgeReason
+		stale.blockedMessage = maxSurgeBlockedMessage
+		staleUnselectedBinding = append(staleUnselectedBinding, stale)
 	}
 	return toBeUpdatedBindingList, staleUnselectedBinding
 }
 
+// notReadyTargetClusters returns the sorted names of the target clusters of the bindings which can become
+// ready but are not ready yet, i.e., the clusters that the rollout is waiting for.
+func notReadyTargetClusters(canBeReadyBindings, readyBindings []*fleetv1beta1.ClusterResourceBinding) []string {
+	readyBindingNames := make(map[string]bool, len(readyBindings))
+	for _, binding := range readyBindings {
+		readyBindingNames[binding.Name] = true
+	}
+	clusters := make([]string, 0)
+	for _, binding := range canBeReadyBindings {
+		if !readyBindingNames[binding.Name] {
+			clusters = append(clusters, binding.Spec.TargetCluster)
+		}
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
+// withWaitingClusters appends the clusters that the rollout is waiting for (if any) to a message.
+func withWaitingClusters(message string, clusters []string) string {
+	if len(clusters) == 0 {
+		return message
+	}
+	return fmt.Sprintf(waitingForClustersMessageFmt, message, clusters)
+}
+
 func calculateMaxToRemove(crp *fleetv1beta1.ClusterResourcePlacement, targetNumber int, readyBindings, canBeUnavailableBindings []*fleetv1beta1.ClusterResourceBinding) int {
 	maxUnavailableNumber, _ := intstr.GetScaledValueFromIntOrPercent(crp.Spec.Strategy.RollingUpdate.MaxUnavailable, targetNumber, true)
 	minAvailableNumber := targetNumber - maxUnavailableNumber
@@ -573,48 +790,41 @@ func isBindingReady(binding *fleetv1beta1.ClusterResourceBinding, readyTimeCutOf
 
 // updateBindings updates the bindings according to its state.
 func (r *Reconciler) updateBindings(ctx context.Context, bindings []toBeUpdatedBinding) error {
-	// issue all the update requests in parallel
-	errs, cctx := errgroup.WithContext(ctx)
 	// handle the bindings depends on its state
-	for i := 0; i < len(bindings); i++ {
-		binding := bindings[i]
+	doWork := func(cctx context.Context, piece int) error {
+		binding := bindings[piece]
 		bindObj := klog.KObj(binding.currentBinding)
 		switch binding.currentBinding.Spec.State {
 		// The only thing we can do on a bound binding is to update its resource resourceBinding
 		case fleetv1beta1.BindingStateBound:
-			errs.Go(func() error {
-				if err := r.Client.Update(cctx, binding.desiredBinding); err != nil {
-					klog.ErrorS(err, "Failed to update a binding to the latest resource", "clusterResourceBinding", bindObj)
-					return controller.NewUpdateIgnoreConflictError(err)
-				}
-				klog.V(2).InfoS("Updated a binding to the latest resource", "clusterResourceBinding", bindObj, "spec", binding.desiredBinding.Spec)
-				return r.updateBindingStatus(ctx, binding.desiredBinding, true)
-			})
+			if err := r.Client.Update(cctx, binding.desiredBinding); err != nil {
+				klog.ErrorS(err, "Failed to update a binding to the latest resource", "clusterResourceBinding", bindObj)
+				return controller.NewUpdateIgnoreConflictError(err)
+			}
+			klog.V(2).InfoS("Updated a binding to the latest resource", "clusterResourceBinding", bindObj, "spec", binding.desiredBinding.Spec)
+			return r.updateBindingStatus(ctx, binding.desiredBinding, true)
 		// We need to bound the scheduled binding to the latest resource snapshot, scheduler doesn't set the resource snapshot name
 		case fleetv1beta1.BindingStateScheduled:
-			errs.Go(func() error {
-				if err := r.Client.Update(cctx, binding.desiredBinding); err != nil {
-					klog.ErrorS(err, "Failed to mark a binding bound", "clusterResourceBinding", bindObj)
-					return controller.NewUpdateIgnoreConflictError(err)
-				}
-				klog.V(2).InfoS("Marked a binding bound", "clusterResourceBinding", bindObj)
-				return r.updateBindingStatus(ctx, binding.desiredBinding, true)
-			})
+			if err := r.Client.Update(cctx, binding.desiredBinding); err != nil {
+				klog.ErrorS(err, "Failed to mark a binding bound", "clusterResourceBinding", bindObj)
+				return controller.NewUpdateIgnoreConflictError(err)
+			}
+			klog.V(2).InfoS("Marked a binding bound", "clusterResourceBinding", bindObj)
+			return r.updateBindingStatus(ctx, binding.desiredBinding, true)
 		// The only thing we can do on an unscheduled binding is to delete it
 		case fleetv1beta1.BindingStateUnscheduled:
-			errs.Go(func() error {
-				if err := r.Client.Delete(cctx, binding.currentBinding); err != nil {
-					if !errors.IsNotFound(err) {
-						klog.ErrorS(err, "Failed to delete an unselected binding", "clusterResourceBinding", bindObj)
-						return controller.NewAPIServerError(false, err)
-					}
+			if err := r.Client.Delete(cctx, binding.currentBinding); err != nil {
+				if !errors.IsNotFound(err) {
+					klog.ErrorS(err, "Failed to delete an unselected binding", "clusterResourceBinding", bindObj)
+					return controller.NewAPIServerError(false, err)
 				}
-				klog.V(2).InfoS("Deleted an unselected binding", "clusterResourceBinding", bindObj)
-				return nil
-			})
+			}
+			klog.V(2).InfoS("Deleted an unselected binding", "clusterResourceBinding", bindObj)
 		}
+		return nil
 	}
-	return errs.Wait()
+	// issue all the update requests in parallel
+	return r.Parallelizer.ParallelizeUntilError(ctx, len(bindings), doWork, "updateBindings")
 }
 
 // SetupWithManager sets up the rollout controller with the Manager.
@@ -799,19 +1009,25 @@ func (r *Reconciler) updateStaleBindingsStatus(ctx context.Context, staleBinding
 		return nil
 	}
 	// issue all the update requests in parallel
-	errs, cctx := errgroup.WithContext(ctx)
-	for i := 0; i < len(staleBindings); i++ {
-		binding := staleBindings[i]
+	doWork := func(cctx context.Context, piece int) error {
+		binding := staleBindings[piece]
 		if binding.currentBinding.Spec.State != fleetv1beta1.BindingStateScheduled && binding.currentBinding.Spec.State != fleetv1beta1.BindingStateBound {
 			klog.ErrorS(controller.NewUnexpectedBehaviorError(fmt.Errorf("invalid stale binding state %s", binding.currentBinding.Spec.State)),
 				"Found a stale binding with unexpected state", "clusterResourceBinding", klog.KObj(binding.currentBinding))
-			continue
+			return nil
 		}
-		errs.Go(func() error {
-			return r.updateBindingStatus(cctx, binding.currentBinding, false)
-		})
+		if binding.blockedReason != "" {
+			return r.setBindingRolloutStartedCondition(cctx, binding.currentBinding, metav1.Condition{
+				Type:               string(fleetv1beta1.ResourceBindingRolloutStarted),
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: binding.currentBinding.Generation,
+				Reason:             binding.blockedReason,
+				Message:            binding.blockedMessage,
+			})
+		}
+		return r.updateBindingStatus(cctx, binding.currentBinding, false)
 	}
-	return errs.Wait()
+	return r.Parallelizer.ParallelizeUntilError(ctx, len(staleBindings), doWork, "updateStaleBindingsStatus")
 }
 
 func (r *Reconciler) updateBindingStatus(ctx context.Context, binding *fleetv1beta1.ClusterResourceBinding, rolloutStarted bool) error {
@@ -820,7 +1036,7 @@ func (r *Reconciler) updateBindingStatus(ctx context.Context, binding *fleetv1be
 		Status:             metav1.ConditionFalse,
 		ObservedGeneration: binding.Generation,
 		Reason:             condition.RolloutNotStartedYetReason,
-		Message:            "The resources cannot be updated to the latest because of the rollout strategy",
+		Message:            staleBindingMessage,
 	}
 	if rolloutStarted {
 		cond = metav1.Condition{
@@ -831,6 +1047,11 @@ func (r *Reconciler) updateBindingStatus(ctx context.Context, binding *fleetv1be
 			Message:            "Detected the new changes on the resources and started the rollout process",
 		}
 	}
+	return r.setBindingRolloutStartedCondition(ctx, binding, cond)
+}
+
+// setBindingRolloutStartedCondition sets the RolloutStarted condition of a binding.
+func (r *Reconciler) setBindingRolloutStartedCondition(ctx context.Context, binding *fleetv1beta1.ClusterResourceBinding, cond metav1.Condition) error {
 	binding.SetConditions(cond)
 	if err := r.Client.Status().Update(ctx, binding); err != nil {
 		klog.ErrorS(err, "Failed to update binding status", "clusterResourceBinding", klog.KObj(binding), "condition", cond)
@@ -8,6 +8,9 @@ package rollout
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -43,6 +46,8 @@ var (
 
 	cmpOptions = []cmp.Option{
 		cmp.AllowUnexported(toBeUpdatedBinding{}),
+		// The blocked reasons of the stale bindings are verified separately.
+		cmpopts.IgnoreFields(toBeUpdatedBinding{}, "blockedReason", "blockedMessage"),
 		cmpopts.EquateEmpty(),
 		cmpopts.IgnoreFields(fleetv1beta1.ClusterResourceBinding{}, "TypeMeta"),
 		cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion"),
@@ -750,6 +755,7 @@ func TestPickBindingsToRoll(t *testing.T) {
 		matchedCROs                 []*fleetv1alpha1.ClusterResourceOverrideSnapshot
 		matchedROs                  []*fleetv1alpha1.ResourceOverrideSnapshot
 		clusters                    []clusterv1beta1.MemberCluster
+		dependencyBindings          []*fleetv1beta1.ClusterResourceBinding
 		wantTobeUpdatedBindings     []int
 		wantDesiredBindingsSpec     []fleetv1beta1.ResourceBindingSpec // used to construct the want toBeUpdatedBindings
 		wantStaleUnselectedBindings []int
@@ -2022,6 +2028,258 @@ func TestPickBindingsToRoll(t *testing.T) {
 			wantNeedRoll:                true,
 			wantWaitTime:                0,
 		},
+		"test rollout groups, update the first group only - rollout allowed for the bindings in the first group": {
+			allBindings: []*fleetv1beta1.ClusterResourceBinding{
+				setRolloutGroupForBinding(generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster1), "canary", 0),
+				setRolloutGroupForBinding(generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster2), "batch-2", 1),
+				setRolloutGroupForBinding(generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster3), "batch-2", 1),
+			},
+			latestResourceSnapshotName: "snapshot-2",
+			crp: clusterResourcePlacementForTest("test",
+				createPlacementPolicyForTest(fleetv1beta1.PickAllPlacementType, 0),
+				createPlacementRolloutStrategyForTest(fleetv1beta1.RollingUpdateRolloutStrategyType, &fleetv1beta1.RollingUpdateConfig{
+					MaxUnavailable: &intstr.IntOrString{
+						Type:   intstr.Int,
+						IntVal: 3,
+					},
+					MaxSurge: &intstr.IntOrString{
+						Type:   intstr.Int,
+						IntVal: 0,
+					},
+					UnavailablePeriodSeconds: ptr.To(1),
+				}, nil)),
+			wantDesiredBindingsSpec: []fleetv1beta1.ResourceBindingSpec{
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster1,
+					ResourceSnapshotName: "snapshot-2",
+				},
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster2,
+					ResourceSnapshotName: "snapshot-2",
+				},
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster3,
+					ResourceSnapshotName: "snapshot-2",
+				},
+			},
+			wantTobeUpdatedBindings:     []int{0},
+			wantStaleUnselectedBindings: []int{1, 2}, // the bindings in the later group wait for the first group to be ready
+			wantNeedRoll:                true,
+			wantWaitTime:                0,
+		},
+		"test rollout groups, the first group is not ready yet - rollout blocked for the bindings in the later groups": {
+			allBindings: []*fleetv1beta1.ClusterResourceBinding{
+				setRolloutGroupForBinding(generateNotReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-2", cluster1, metav1.NewTime(now)), "canary", 0),
+				setRolloutGroupForBinding(generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster2), "batch-2", 1),
+				setRolloutGroupForBinding(generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster3), "batch-2", 1),
+			},
+			latestResourceSnapshotName: "snapshot-2",
+			crp: clusterResourcePlacementForTest("test",
+				createPlacementPolicyForTest(fleetv1beta1.PickAllPlacementType, 0),
+				createPlacementRolloutStrategyForTest(fleetv1beta1.RollingUpdateRolloutStrategyType, &fleetv1beta1.RollingUpdateConfig{
+					MaxUnavailable: &intstr.IntOrString{
+						Type:   intstr.Int,
+						IntVal: 3,
+					},
+					MaxSurge: &intstr.IntOrString{
+						Type:   intstr.Int,
+						IntVal: 0,
+					},
+					UnavailablePeriodSeconds: ptr.To(1),
+				}, nil)),
+			wantDesiredBindingsSpec: []fleetv1beta1.ResourceBindingSpec{
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster1,
+					ResourceSnapshotName: "snapshot-2",
+				},
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster2,
+					ResourceSnapshotName: "snapshot-2",
+				},
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster3,
+					ResourceSnapshotName: "snapshot-2",
+				},
+			},
+			wantStaleUnselectedBindings: []int{1, 2},
+			wantNeedRoll:                true,
+			wantWaitTime:                time.Second,
+		},
+		"test rollout groups, the first group is ready - rollout allowed for the bindings in the next group": {
+			allBindings: []*fleetv1beta1.ClusterResourceBinding{
+				setRolloutGroupForBinding(generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-2", cluster1), "canary", 0),
+				setRolloutGroupForBinding(generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster2), "batch-2", 1),
+				setRolloutGroupForBinding(generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster3), "batch-2", 1),
+			},
+			latestResourceSnapshotName: "snapshot-2",
+			crp: clusterResourcePlacementForTest("test",
+				createPlacementPolicyForTest(fleetv1beta1.PickAllPlacementType, 0),
+				createPlacementRolloutStrategyForTest(fleetv1beta1.RollingUpdateRolloutStrategyType, &fleetv1beta1.RollingUpdateConfig{
+					MaxUnavailable: &intstr.IntOrString{
+						Type:   intstr.Int,
+						IntVal: 3,
+					},
+					MaxSurge: &intstr.IntOrString{
+						Type:   intstr.Int,
+						IntVal: 0,
+					},
+					UnavailablePeriodSeconds: ptr.To(1),
+				}, nil)),
+			wantDesiredBindingsSpec: []fleetv1beta1.ResourceBindingSpec{
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster1,
+					ResourceSnapshotName: "snapshot-2",
+				},
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster2,
+					ResourceSnapshotName: "snapshot-2",
+				},
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster3,
+					ResourceSnapshotName: "snapshot-2",
+				},
+			},
+			wantTobeUpdatedBindings: []int{1, 2},
+			wantNeedRoll:            true,
+			wantWaitTime:            0,
+		},
+		"test rollout groups, ungrouped bindings are rolled out after the grouped ones": {
+			allBindings: []*fleetv1beta1.ClusterResourceBinding{
+				setRolloutGroupForBinding(generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster1), "canary", 0),
+				generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster2),
+				setRolloutGroupForBinding(generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster3), "batch-2", 1),
+			},
+			latestResourceSnapshotName: "snapshot-2",
+			crp: clusterResourcePlacementForTest("test",
+				createPlacementPolicyForTest(fleetv1beta1.PickAllPlacementType, 0),
+				createPlacementRolloutStrategyForTest(fleetv1beta1.RollingUpdateRolloutStrategyType, &fleetv1beta1.RollingUpdateConfig{
+					MaxUnavailable: &intstr.IntOrString{
+						Type:   intstr.Int,
+						IntVal: 3,
+					},
+					MaxSurge: &intstr.IntOrString{
+						Type:   intstr.Int,
+						IntVal: 0,
+					},
+					UnavailablePeriodSeconds: ptr.To(1),
+				}, nil)),
+			wantDesiredBindingsSpec: []fleetv1beta1.ResourceBindingSpec{
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster1,
+					ResourceSnapshotName: "snapshot-2",
+				},
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster2,
+					ResourceSnapshotName: "snapshot-2",
+				},
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster3,
+					ResourceSnapshotName: "snapshot-2",
+				},
+			},
+			wantTobeUpdatedBindings:     []int{0},
+			wantStaleUnselectedBindings: []int{1, 2},
+			wantNeedRoll:                true,
+			wantWaitTime:                0,
+		},
+		"test dependsOn, the dependency has no bindings - rollout blocked": {
+			allBindings: []*fleetv1beta1.ClusterResourceBinding{
+				generateClusterResourceBinding(fleetv1beta1.BindingStateScheduled, "snapshot-1", cluster1),
+				generateClusterResourceBinding(fleetv1beta1.BindingStateScheduled, "snapshot-1", cluster2),
+			},
+			latestResourceSnapshotName: "snapshot-2",
+			crp: setDependenciesForCRP(clusterResourcePlacementForTest("test",
+				createPlacementPolicyForTest(fleetv1beta1.PickAllPlacementType, 0),
+				createPlacementRolloutStrategyForTest(fleetv1beta1.RollingUpdateRolloutStrategyType, generateDefaultRollingUpdateConfig(), nil)), "crds"),
+			wantDesiredBindingsSpec: []fleetv1beta1.ResourceBindingSpec{
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster1,
+					ResourceSnapshotName: "snapshot-2",
+				},
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster2,
+					ResourceSnapshotName: "snapshot-2",
+				},
+			},
+			wantTobeUpdatedBindings:     nil,
+			wantStaleUnselectedBindings: []int{0, 1},
+			wantNeedRoll:                true,
+			wantWaitTime:                dependencyRecheckInterval,
+		},
+		"test dependsOn, the dependency is not available on a cluster - rollout blocked on the cluster": {
+			allBindings: []*fleetv1beta1.ClusterResourceBinding{
+				generateClusterResourceBinding(fleetv1beta1.BindingStateScheduled, "snapshot-1", cluster1),
+				generateClusterResourceBinding(fleetv1beta1.BindingStateScheduled, "snapshot-1", cluster2),
+			},
+			latestResourceSnapshotName: "snapshot-2",
+			crp: setDependenciesForCRP(clusterResourcePlacementForTest("test",
+				createPlacementPolicyForTest(fleetv1beta1.PickAllPlacementType, 0),
+				createPlacementRolloutStrategyForTest(fleetv1beta1.RollingUpdateRolloutStrategyType, generateDefaultRollingUpdateConfig(), nil)), "crds"),
+			dependencyBindings: []*fleetv1beta1.ClusterResourceBinding{
+				setCRPForBinding(generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster1), "crds"),
+				setCRPForBinding(generateClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster2), "crds"),
+			},
+			wantDesiredBindingsSpec: []fleetv1beta1.ResourceBindingSpec{
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster1,
+					ResourceSnapshotName: "snapshot-2",
+				},
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster2,
+					ResourceSnapshotName: "snapshot-2",
+				},
+			},
+			wantTobeUpdatedBindings:     []int{0},
+			wantStaleUnselectedBindings: []int{1},
+			wantNeedRoll:                true,
+			wantWaitTime:                dependencyRecheckInterval,
+		},
+		"test dependsOn, the dependency is available on all the clusters - rollout allowed": {
+			allBindings: []*fleetv1beta1.ClusterResourceBinding{
+				generateClusterResourceBinding(fleetv1beta1.BindingStateScheduled, "snapshot-1", cluster1),
+				generateClusterResourceBinding(fleetv1beta1.BindingStateScheduled, "snapshot-1", cluster2),
+			},
+			latestResourceSnapshotName: "snapshot-2",
+			crp: setDependenciesForCRP(clusterResourcePlacementForTest("test",
+				createPlacementPolicyForTest(fleetv1beta1.PickAllPlacementType, 0),
+				createPlacementRolloutStrategyForTest(fleetv1beta1.RollingUpdateRolloutStrategyType, generateDefaultRollingUpdateConfig(), nil)), "crds"),
+			dependencyBindings: []*fleetv1beta1.ClusterResourceBinding{
+				setCRPForBinding(generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster1), "crds"),
+				setCRPForBinding(generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster2), "crds"),
+			},
+			wantDesiredBindingsSpec: []fleetv1beta1.ResourceBindingSpec{
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster1,
+					ResourceSnapshotName: "snapshot-2",
+				},
+				{
+					State:                fleetv1beta1.BindingStateBound,
+					TargetCluster:        cluster2,
+					ResourceSnapshotName: "snapshot-2",
+				},
+			},
+			wantTobeUpdatedBindings:     []int{0, 1},
+			wantStaleUnselectedBindings: nil,
+			wantNeedRoll:                true,
+			wantWaitTime:                0,
+		},
 	}
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -2030,6 +2288,9 @@ func TestPickBindingsToRoll(t *testing.T) {
 			for i := range tt.clusters {
 				objects = append(objects, &tt.clusters[i])
 			}
+			for i := range tt.dependencyBindings {
+				objects = append(objects, tt.dependencyBindings[i])
+			}
 			fakeClient := fake.NewClientBuilder().
 				WithScheme(scheme).
 				WithObjects(objects...).
@@ -2196,6 +2457,23 @@ func generateNotReadyClusterResourceBinding(state fleetv1beta1.BindingState, res
 	return binding
 }
 
+func setRolloutGroupForBinding(binding *fleetv1beta1.ClusterResourceBinding, group string, order int) *fleetv1beta1.ClusterResourceBinding {
+	binding.Labels = map[string]string{fleetv1beta1.RolloutGroupLabel: group}
+	binding.Annotations = map[string]string{fleetv1beta1.RolloutGroupOrderAnnotation: strconv.Itoa(order)}
+	return binding
+}
+
+func setCRPForBinding(binding *fleetv1beta1.ClusterResourceBinding, crpName string) *fleetv1beta1.ClusterResourceBinding {
+	binding.Name = crpName + "-" + binding.Name
+	binding.Labels = map[string]string{fleetv1beta1.CRPTrackingLabel: crpName}
+	return binding
+}
+
+func setDependenciesForCRP(crp *fleetv1beta1.ClusterResourcePlacement, dependsOn ...string) *fleetv1beta1.ClusterResourcePlacement {
+	crp.Spec.DependsOn = dependsOn
+	return crp
+}
+
 func setDeletionTimeStampForBinding(binding *fleetv1beta1.ClusterResourceBinding) *fleetv1beta1.ClusterResourceBinding {
 	binding.DeletionTimestamp = &metav1.Time{
 		Time: now,
@@ -2224,9 +2502,10 @@ func TestUpdateStaleBindingsStatus(t *testing.T) {
 	tests := map[string]struct {
 		skipPuttingBindings bool // whether skip to put the bindings into the api server
 		// build toBeUpdatedBinding with currentBinding and desiredBinding
-		bindings     []fleetv1beta1.ClusterResourceBinding
-		wantBindings []fleetv1beta1.ClusterResourceBinding
-		wantErr      error
+		bindings      []fleetv1beta1.ClusterResourceBinding
+		blockedReason string // the blocked reason of all the stale bindings
+		wantBindings  []fleetv1beta1.ClusterResourceBinding
+		wantErr       error
 	}{
 		"update bindings with nil": {
 			bindings:     nil,
@@ -2275,6 +2554,46 @@ func TestUpdateStaleBindingsStatus(t *testing.T) {
 				},
 			},
 		},
+		"update a bounded binding status with a blocked reason": {
+			bindings: []fleetv1beta1.ClusterResourceBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "binding-1",
+						Generation: 15,
+					},
+					Spec: fleetv1beta1.ResourceBindingSpec{
+						State:                fleetv1beta1.BindingStateBound,
+						TargetCluster:        cluster1,
+						ResourceSnapshotName: "snapshot-1",
+					},
+				},
+			},
+			blockedReason: condition.RolloutBlockedByMaxUnavailableReason,
+			wantBindings: []fleetv1beta1.ClusterResourceBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "binding-1",
+						Generation: 15,
+					},
+					Spec: fleetv1beta1.ResourceBindingSpec{
+						State:                fleetv1beta1.BindingStateBound,
+						TargetCluster:        cluster1,
+						ResourceSnapshotName: "snapshot-1",
+					},
+					Status: fleetv1beta1.ResourceBindingStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:               string(fleetv1beta1.ResourceBindingRolloutStarted),
+								Status:             metav1.ConditionFalse,
+								ObservedGeneration: 15,
+								LastTransitionTime: metav1.NewTime(currentTime),
+								Reason:             condition.RolloutBlockedByMaxUnavailableReason,
+							},
+						},
+					},
+				},
+			},
+		},
 		"skip updating unscheduled binding status": {
 			bindings: []fleetv1beta1.ClusterResourceBinding{
 				{
@@ -2431,6 +2750,7 @@ func TestUpdateStaleBindingsStatus(t *testing.T) {
 				}
 				inputs[i] = toBeUpdatedBinding{
 					currentBinding: &tt.bindings[i],
+					blockedReason:  tt.blockedReason,
 				}
 			}
 			if err := r.updateStaleBindingsStatus(ctx, inputs); err != nil {
@@ -2636,3 +2956,74 @@ func TestCheckAndUpdateStaleBindingsStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestStaleBindingBlockedReasons(t *testing.T) {
+	noSurgeStrategy := createPlacementRolloutStrategyForTest(fleetv1beta1.RollingUpdateRolloutStrategyType, &fleetv1beta1.RollingUpdateConfig{
+		MaxUnavailable:           &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+		MaxSurge:                 &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+		UnavailablePeriodSeconds: ptr.To(60),
+	}, nil)
+	tests := map[string]struct {
+		allBindings     []*fleetv1beta1.ClusterResourceBinding
+		crp             *fleetv1beta1.ClusterResourcePlacement
+		wantStale       map[string]string // target cluster -> blocked reason
+		wantWaitingList string
+	}{
+		"stale bindings blocked by maxUnavailable wait for the unavailable clusters": {
+			allBindings: []*fleetv1beta1.ClusterResourceBinding{
+				generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster1),
+				generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-1", cluster2),
+				generateCanBeReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-2", cluster3),
+			},
+			crp: clusterResourcePlacementForTest("test",
+				createPlacementPolicyForTest(fleetv1beta1.PickNPlacementType, 3), noSurgeStrategy),
+			wantStale: map[string]string{
+				cluster1: condition.RolloutBlockedByMaxUnavailableReason,
+				cluster2: condition.RolloutBlockedByMaxUnavailableReason,
+			},
+			wantWaitingList: fmt.Sprintf("[%s]", cluster3),
+		},
+		"scheduled bindings blocked by maxSurge": {
+			allBindings: []*fleetv1beta1.ClusterResourceBinding{
+				generateReadyClusterResourceBinding(fleetv1beta1.BindingStateBound, "snapshot-2", cluster1),
+				generateReadyClusterResourceBinding(fleetv1beta1.BindingStateUnscheduled, "snapshot-2", cluster2),
+				generateClusterResourceBinding(fleetv1beta1.BindingStateScheduled, "snapshot-2", cluster3),
+			},
+			crp: clusterResourcePlacementForTest("test",
+				createPlacementPolicyForTest(fleetv1beta1.PickNPlacementType, 2), noSurgeStrategy),
+			wantStale: map[string]string{
+				cluster3: condition.RolloutBlockedByMaxSurgeReason,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scheme := serviceScheme(t)
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				Build()
+			r := Reconciler{
+				Client: fakeClient,
+			}
+			resourceSnapshot := &fleetv1beta1.ClusterResourceSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "snapshot-2",
+				},
+			}
+			_, gotStaleUnselectedBindings, _, _, err := r.pickBindingsToRoll(context.Background(), tt.allBindings, resourceSnapshot, tt.crp, nil, nil)
+			if err != nil {
+				t.Fatalf("pickBindingsToRoll() got error %v, want no err", err)
+			}
+			gotStale := make(map[string]string, len(gotStaleUnselectedBindings))
+			for _, binding := range gotStaleUnselectedBindings {
+				gotStale[binding.currentBinding.Spec.TargetCluster] = binding.blockedReason
+				if !strings.Contains(binding.blockedMessage, tt.wantWaitingList) {
+					t.Errorf("blockedMessage of the stale binding on %s = %q, want it to contain %q", binding.currentBinding.Spec.TargetCluster, binding.blockedMessage, tt.wantWaitingList)
+				}
+			}
+			if diff := cmp.Diff(tt.wantStale, gotStale); diff != "" {
+				t.Errorf("pickBindingsToRoll() blocked reasons of the stale bindings mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
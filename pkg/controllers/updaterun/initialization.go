@@ -163,8 +163,8 @@ func (r *Reconciler) collectScheduledClusters(
 	updateRunRef := klog.KObj(updateRun)
 	// List all the bindings according to the ClusterResourcePlacement.
 	var bindingList placementv1beta1.ClusterResourceBindingList
-	resourceBindingMatcher := client.MatchingLabels{
-		placementv1beta1.CRPTrackingLabel: placementName,
+	resourceBindingMatcher := client.MatchingFields{
+		controller.BindingCRPTrackingIndexKey: placementName,
 	}
 	if err := r.Client.List(ctx, &bindingList, resourceBindingMatcher); err != nil {
 		klog.ErrorS(err, "Failed to list clusterResourceBindings", "clusterResourcePlacement", placementName, "latestPolicySnapshot", latestPolicySnapshot.Name, "clusterStagedUpdateRun", updateRunRef)
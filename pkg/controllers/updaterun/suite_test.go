@@ -30,6 +30,7 @@ import (
 	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/informer"
 )
 
@@ -84,6 +85,7 @@ var _ = BeforeSuite(func() {
 		Logger: textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(4))),
 	})
 	Expect(err).Should(Succeed())
+	Expect(controller.SetupBindingCRPTrackingIndex(ctx, mgr.GetFieldIndexer())).Should(Succeed())
 
 	// make sure the k8s client is same as the controller client, or we can have cache delay
 	By("set k8s client same as the controller manager")
@@ -122,6 +122,7 @@ func (r *ApplyWorkReconciler) deleteStaleManifest(ctx context.Context, staleMani
 			// It is possible that the staled manifest was already deleted but the status wasn't updated to reflect that yet.
 			if apierrors.IsNotFound(err) {
 				klog.V(2).InfoS("the staled manifest already deleted", "manifest", staleManifest, "owner", owner)
+				r.provenanceIndex.deleteIfFromWork(staleManifest.WorkResourceIdentifier, owner.Name)
 				continue
 			}
 			klog.ErrorS(err, "failed to get the staled manifest", "manifest", staleManifest, "owner", owner)
@@ -149,6 +150,7 @@ func (r *ApplyWorkReconciler) deleteStaleManifest(ctx context.Context, staleMani
 			if err != nil && !apierrors.IsNotFound(err) {
 				klog.ErrorS(err, "failed to delete the staled manifest", "manifest", staleManifest, "owner", owner)
 				errs = append(errs, err)
+				continue
 			}
 		} else {
 			klog.V(2).InfoS("remove the owner reference from the staled manifest", "manifest", staleManifest, "owner", owner)
@@ -157,8 +159,10 @@ func (r *ApplyWorkReconciler) deleteStaleManifest(ctx context.Context, staleMani
 			if err != nil {
 				klog.ErrorS(err, "failed to remove the owner reference from manifest", "manifest", staleManifest, "owner", owner)
 				errs = append(errs, err)
+				continue
 			}
 		}
+		r.provenanceIndex.deleteIfFromWork(staleManifest.WorkResourceIdentifier, owner.Name)
 	}
 	return utilerrors.NewAggregate(errs)
 }
@@ -67,6 +67,12 @@ func (applier *ClientSideApplier) ApplyUnstructured(ctx context.Context, applySt
 	case err != nil:
 		return nil, errorApplyAction, controller.NewAPIServerError(false, err)
 	}
+	if curObj.GetDeletionTimestamp() != nil {
+		// the previous object is still being deleted (e.g., its finalizers are not cleared yet),
+		// wait for it to be fully gone before creating the replacement
+		klog.V(2).InfoS("Waiting for the existing object to be deleted", "gvr", gvr, "manifest", manifestRef)
+		return curObj, manifestWaitingForDeletionAction, nil
+	}
 
 	result, err := validateOwnerReference(ctx, applier.HubClient, applier.WorkNamespace, applyStrategy, curObj.GetOwnerReferences())
 	if err != nil {
@@ -74,6 +80,7 @@ func (applier *ClientSideApplier) ApplyUnstructured(ctx context.Context, applySt
 			"gvr", gvr, "manifest", manifestRef, "applyStrategy", applyStrategy, "ownerReferences", curObj.GetOwnerReferences())
 		return nil, result, err
 	}
+	preserveProvenanceAppliedAt(manifestObj, curObj)
 
 	// We only try to update the object if its spec hash value has changed.
 	if manifestObj.GetAnnotations()[fleetv1beta1.ManifestHashAnnotation] != curObj.GetAnnotations()[fleetv1beta1.ManifestHashAnnotation] {
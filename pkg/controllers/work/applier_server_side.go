@@ -45,6 +45,12 @@ func (applier *ServerSideApplier) ApplyUnstructured(ctx context.Context, applySt
 	case err != nil:
 		return nil, errorApplyAction, controller.NewAPIServerError(false, err)
 	}
+	if curObj.GetDeletionTimestamp() != nil {
+		// the previous object is still being deleted (e.g., its finalizers are not cleared yet),
+		// wait for it to be fully gone before creating the replacement
+		klog.V(2).InfoS("Waiting for the existing object to be deleted", "gvr", gvr, "manifest", manifestRef)
+		return curObj, manifestWaitingForDeletionAction, nil
+	}
 
 	result, err := validateOwnerReference(ctx, applier.HubClient, applier.WorkNamespace, applyStrategy, curObj.GetOwnerReferences())
 	if err != nil {
@@ -52,5 +58,6 @@ func (applier *ServerSideApplier) ApplyUnstructured(ctx context.Context, applySt
 			"gvr", gvr, "manifest", manifestRef, "applyStrategy", applyStrategy, "ownerReferences", curObj.GetOwnerReferences())
 		return nil, result, err
 	}
+	preserveProvenanceAppliedAt(manifestObj, curObj)
 	return serverSideApply(ctx, applier.SpokeDynamicClient, force, gvr, manifestObj)
 }
@@ -23,7 +23,9 @@ package work
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"go.uber.org/atomic"
@@ -40,6 +42,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
@@ -69,6 +72,7 @@ const (
 	workAppliedCompletedReason    = "WorkAppliedCompleted"
 	workNotAvailableYetReason     = "WorkNotAvailableYet"
 	workAvailabilityUnknownReason = "WorkAvailabilityUnknown"
+	workApplyPendingReason        = "WorkApplyPending"
 	// WorkAvailableReason is the reason string of condition when the manifest is available.
 	WorkAvailableReason = "WorkAvailable"
 	// WorkNotTrackableReason is the reason string of condition when the manifest is already up to date but we don't have
@@ -76,6 +80,9 @@ const (
 	WorkNotTrackableReason = "WorkNotTrackable"
 	// ManifestApplyFailedReason is the reason string of condition when it failed to apply manifest.
 	ManifestApplyFailedReason = "ManifestApplyFailed"
+	// ManifestDeniedByAdmissionWebhookReason is the reason string of condition when an admission webhook on the
+	// member cluster has denied the manifest.
+	ManifestDeniedByAdmissionWebhookReason = "ManifestDeniedByAdmissionWebhook"
 	// ApplyConflictBetweenPlacementsReason is the reason string of condition when the manifest is owned by multiple placements,
 	// and they have conflicts.
 	ApplyConflictBetweenPlacementsReason = "ApplyConflictBetweenPlacements"
@@ -101,11 +108,33 @@ type ApplyWorkReconciler struct {
 	workNameSpace      string
 	joined             *atomic.Bool
 	appliers           map[fleetv1beta1.ApplyStrategyType]Applier
+	// hubIdentity is the identity of the hub cluster, which is stamped on the applied objects as part of their provenance.
+	hubIdentity string
+	// provenanceIndex keeps track of the provenance of the applied objects.
+	provenanceIndex *provenanceIndex
+	// impersonationConfig is the member cluster config used to impersonate the service accounts specified in the
+	// apply strategies; impersonation is disabled if it is nil.
+	impersonationConfig *rest.Config
+	// impersonatedClients caches the dynamic clients which impersonate the service accounts.
+	impersonatedClients *impersonatedClientCache
+	// manifestTransformers are the transformers that mutate manifests right before they are applied.
+	manifestTransformers []ManifestTransformer
+}
+
+// ApplyWorkReconcilerOption configures an ApplyWorkReconciler.
+type ApplyWorkReconcilerOption func(*ApplyWorkReconciler)
+
+// WithHubIdentity sets the identity of the hub cluster (e.g., its API server URL), which the reconciler
+// stamps on the applied objects as part of their provenance.
+func WithHubIdentity(hubIdentity string) ApplyWorkReconcilerOption {
+	return func(r *ApplyWorkReconciler) {
+		r.hubIdentity = hubIdentity
+	}
 }
 
 func NewApplyWorkReconciler(hubClient client.Client, spokeDynamicClient dynamic.Interface, spokeClient client.Client,
-	restMapper meta.RESTMapper, recorder record.EventRecorder, concurrency int, workNameSpace string) *ApplyWorkReconciler {
-	return &ApplyWorkReconciler{
+	restMapper meta.RESTMapper, recorder record.EventRecorder, concurrency int, workNameSpace string, opts ...ApplyWorkReconcilerOption) *ApplyWorkReconciler {
+	r := &ApplyWorkReconciler{
 		client:             hubClient,
 		spokeDynamicClient: spokeDynamicClient,
 		spokeClient:        spokeClient,
@@ -114,7 +143,12 @@ func NewApplyWorkReconciler(hubClient client.Client, spokeDynamicClient dynamic.
 		concurrency:        concurrency,
 		workNameSpace:      workNameSpace,
 		joined:             atomic.NewBool(false),
+		provenanceIndex:    newProvenanceIndex(),
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	return r
 }
 
 // ApplyAction represents the action we take to apply the manifest.
@@ -150,6 +184,10 @@ const (
 
 	// manifestAvailableAction indicates that the manifest is available.
 	manifestAvailableAction ApplyAction = "ManifestAvailable"
+
+	// manifestWaitingForDeletionAction indicates that an object of the same name is still being deleted in the member
+	// cluster, and we need to wait for it to be fully gone before applying the manifest.
+	manifestWaitingForDeletionAction ApplyAction = "ManifestWaitingForDeletion"
 )
 
 // applyResult contains the result of a manifest being applied.
@@ -213,7 +251,7 @@ func (r *ApplyWorkReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	// apply the manifests to the member cluster
-	results := r.applyManifests(ctx, work.Spec.Workload.Manifests, owner, work.Spec.ApplyStrategy)
+	results := r.applyManifests(ctx, work.Spec.Workload.Manifests, owner, work.Spec.ApplyStrategy, r.buildProvenance(work))
 
 	// collect the latency from the work update time to now.
 	lastUpdateTime, ok := work.GetAnnotations()[utils.LastWorkUpdateTimeAnnotationKey]
@@ -305,6 +343,7 @@ func (r *ApplyWorkReconciler) garbageCollectAppliedWork(ctx context.Context, wor
 	default:
 		klog.InfoS("Successfully deleted the appliedWork", "appliedWork", work.Name)
 	}
+	r.provenanceIndex.deleteAllFromWork(work.Name)
 	controllerutil.RemoveFinalizer(work, fleetv1beta1.WorkFinalizer)
 	return ctrl.Result{}, r.client.Update(ctx, work, &client.UpdateOptions{})
 }
@@ -352,13 +391,22 @@ func (r *ApplyWorkReconciler) ensureAppliedWork(ctx context.Context, work *fleet
 }
 
 // applyManifests processes a given set of Manifests by: setting ownership, validating the manifest, and passing it on for application to the cluster.
-func (r *ApplyWorkReconciler) applyManifests(ctx context.Context, manifests []fleetv1beta1.Manifest, owner metav1.OwnerReference, applyStrategy *fleetv1beta1.ApplyStrategy) []applyResult {
+func (r *ApplyWorkReconciler) applyManifests(ctx context.Context, manifests []fleetv1beta1.Manifest, owner metav1.OwnerReference, applyStrategy *fleetv1beta1.ApplyStrategy, provenance Provenance) []applyResult {
 	var appliedObj *unstructured.Unstructured
 
 	results := make([]applyResult, len(manifests))
 	for index, manifest := range manifests {
 		var result applyResult
 		gvr, rawObj, err := r.decodeManifest(manifest)
+		if err == nil && len(r.manifestTransformers) > 0 {
+			// mutate the manifest with member-local data before it is stamped and applied
+			var transformed *unstructured.Unstructured
+			if transformed, err = r.transformManifest(ctx, &gvr, rawObj); err != nil {
+				err = fmt.Errorf("failed to transform the manifest: %w", err)
+			} else {
+				rawObj = transformed
+			}
+		}
 		switch {
 		case err != nil:
 			result.applyErr = err
@@ -375,18 +423,25 @@ func (r *ApplyWorkReconciler) applyManifests(ctx context.Context, manifests []fl
 
 		default:
 			addOwnerRef(owner, rawObj)
+			setProvenanceAnnotations(rawObj, provenance)
+			setDeletionProtectedAnnotation(rawObj, applyStrategy)
 			appliedObj, result.action, result.applyErr = r.applyUnstructuredAndTrackAvailability(ctx, gvr, rawObj, applyStrategy)
 			result.identifier = buildResourceIdentifier(index, rawObj, gvr)
 			logObjRef := klog.ObjectRef{
 				Name:      result.identifier.Name,
 				Namespace: result.identifier.Namespace,
 			}
-			if result.applyErr == nil {
+			switch {
+			case result.applyErr != nil:
+				klog.ErrorS(result.applyErr, "manifest upsert failed", "gvr", gvr, "manifest", logObjRef)
+			case result.action == manifestWaitingForDeletionAction:
+				// the object in the member cluster is not the one we apply, so skip recording its generation and provenance
+				klog.V(2).InfoS("Apply manifest is pending on the deletion of the existing object", "gvr", gvr, "manifest", logObjRef)
+			default:
 				result.generation = appliedObj.GetGeneration()
+				r.provenanceIndex.set(result.identifier, provenanceFromAnnotations(appliedObj, provenance.Work))
 				klog.V(2).InfoS("Apply manifest succeeded", "gvr", gvr, "manifest", logObjRef,
 					"action", result.action, "applyStrategy", applyStrategy, "new ObservedGeneration", result.generation)
-			} else {
-				klog.ErrorS(result.applyErr, "manifest upsert failed", "gvr", gvr, "manifest", logObjRef)
 			}
 		}
 		results[index] = result
@@ -399,7 +454,7 @@ func (r *ApplyWorkReconciler) decodeManifest(manifest fleetv1beta1.Manifest) (sc
 	unstructuredObj := &unstructured.Unstructured{}
 	err := unstructuredObj.UnmarshalJSON(manifest.Raw)
 	if err != nil {
-		return schema.GroupVersionResource{}, nil, fmt.Errorf("failed to decode object: %w", err)
+		return schema.GroupVersionResource{}, nil, controller.NewUserError(fmt.Errorf("failed to decode object: %w", err))
 	}
 
 	mapping, err := r.restMapper.RESTMapping(unstructuredObj.GroupVersionKind().GroupKind(), unstructuredObj.GroupVersionKind().Version)
@@ -417,11 +472,10 @@ func (r *ApplyWorkReconciler) applyUnstructuredAndTrackAvailability(ctx context.
 	manifestObj *unstructured.Unstructured, applyStrategy *fleetv1beta1.ApplyStrategy) (*unstructured.Unstructured, ApplyAction, error) {
 	// TODO: determine action based on conflict resolution action
 	objManifest := klog.KObj(manifestObj)
-	applier := r.appliers[applyStrategy.Type]
-	if applier == nil {
-		err := fmt.Errorf("unknown apply strategy type %s", applyStrategy.Type)
-		klog.ErrorS(err, "Apply strategy type is unsupported", "gvr", gvr, "manifest", objManifest, "applyStrategyType", applyStrategy.Type)
-		return nil, errorApplyAction, controller.NewUserError(err)
+	applier, err := r.applierFor(applyStrategy)
+	if err != nil {
+		klog.ErrorS(err, "Failed to find the applier for the apply strategy", "gvr", gvr, "manifest", objManifest, "applyStrategy", applyStrategy)
+		return nil, errorApplyAction, err
 	}
 
 	curObj, applyActionRes, err := applier.ApplyUnstructured(ctx, applyStrategy, gvr, manifestObj)
@@ -429,6 +483,10 @@ func (r *ApplyWorkReconciler) applyUnstructuredAndTrackAvailability(ctx context.
 		klog.ErrorS(err, "Failed to apply the manifest", "gvr", gvr, "manifest", objManifest, "applyStrategyType", applyStrategy.Type)
 		return nil, applyActionRes, err // do not overwrite the applyActionRes
 	}
+	if applyActionRes == manifestWaitingForDeletionAction {
+		// there is nothing to track until the manifest is applied
+		return curObj, applyActionRes, nil
+	}
 	klog.V(2).InfoS("Applied the manifest", "gvr", gvr, "manifest", objManifest, "applyStrategyType", applyStrategy.Type)
 
 	// the manifest is already up to date, we just need to track its availability
@@ -612,6 +670,14 @@ func constructWorkCondition(results []applyResult, work *fleetv1beta1.Work) []er
 		if existingManifestCondition != nil {
 			manifestCondition.Conditions = existingManifestCondition.Conditions
 		}
+		if result.applyErr != nil {
+			// count the consecutive failures so that users can tell a persistent failure from a transient one
+			manifestCondition.ApplyFailureCount = 1
+			if existingManifestCondition != nil {
+				manifestCondition.ApplyFailureCount = existingManifestCondition.ApplyFailureCount + 1
+			}
+			manifestCondition.LastApplyErrorCategory = classifyApplyError(result.applyErr, result.action)
+		}
 		// merge the status of the manifest condition
 		for _, condition := range newConditions {
 			meta.SetStatusCondition(&manifestCondition.Conditions, condition)
@@ -628,6 +694,26 @@ func constructWorkCondition(results []applyResult, work *fleetv1beta1.Work) []er
 	return errs
 }
 
+// classifyApplyError returns the category of the error that a manifest fails to be applied with.
+func classifyApplyError(err error, action ApplyAction) fleetv1beta1.ApplyErrorCategory {
+	if _, _, denied := controller.ParseAdmissionWebhookDenial(err); denied {
+		return fleetv1beta1.ApplyErrorCategoryAdmission
+	}
+	switch {
+	case action == applyConflictBetweenPlacements || action == manifestAlreadyOwnedByOthers:
+		return fleetv1beta1.ApplyErrorCategoryConflict
+	case apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err):
+		return fleetv1beta1.ApplyErrorCategoryConflict
+	case apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err):
+		return fleetv1beta1.ApplyErrorCategoryRBAC
+	case apierrors.IsInvalid(err) || apierrors.IsBadRequest(err) || errors.Is(err, controller.ErrUserError):
+		// the manifests which cannot be decoded, or the apply strategies which cannot be used, are user errors
+		return fleetv1beta1.ApplyErrorCategoryInvalid
+	default:
+		return fleetv1beta1.ApplyErrorCategoryOther
+	}
+}
+
 // Join starts to reconcile
 func (r *ApplyWorkReconciler) Join(_ context.Context) error {
 	if !r.joined.Load() {
@@ -700,6 +786,8 @@ func computeManifestHash(obj *unstructured.Unstructured) (string, error) {
 	if annotation != nil {
 		delete(annotation, fleetv1beta1.ManifestHashAnnotation)
 		delete(annotation, fleetv1beta1.LastAppliedConfigAnnotation)
+		// the applied-at timestamp changes on every apply and does not reflect a change of the spec
+		delete(annotation, fleetv1beta1.ProvenanceAppliedAtAnnotation)
 		if len(annotation) == 0 {
 			manifest.SetAnnotations(nil)
 		} else {
@@ -778,6 +866,27 @@ func setManifestHashAnnotation(manifestObj *unstructured.Unstructured) error {
 	return nil
 }
 
+// setDeletionProtectedAnnotation sets the deletion protected annotation on the provided unstructured object,
+// if it is a critical resource (a namespace or a CustomResourceDefinition) and the apply strategy has the
+// CriticalResources deletion protection. It must be called before the manifest hash is computed, so that the
+// annotation is removed from the applied object once the protection is lifted.
+func setDeletionProtectedAnnotation(manifestObj *unstructured.Unstructured, applyStrategy *fleetv1beta1.ApplyStrategy) {
+	if applyStrategy == nil || applyStrategy.DeletionProtection != fleetv1beta1.DeletionProtectionTypeCriticalResources {
+		return
+	}
+	gvk := manifestObj.GroupVersionKind()
+	if !utils.IsCriticalResource(gvk.Group, gvk.Kind) {
+		return
+	}
+
+	annotation := manifestObj.GetAnnotations()
+	if annotation == nil {
+		annotation = map[string]string{}
+	}
+	annotation[fleetv1beta1.DeletionProtectedAnnotation] = strconv.FormatBool(true)
+	manifestObj.SetAnnotations(annotation)
+}
+
 // Builds a resource identifier for a given unstructured.Unstructured object.
 func buildResourceIdentifier(index int, object *unstructured.Unstructured, gvr schema.GroupVersionResource) fleetv1beta1.WorkResourceIdentifier {
 	return fleetv1beta1.WorkResourceIdentifier{
@@ -816,6 +925,11 @@ func buildManifestCondition(err error, action ApplyAction, observedGeneration in
 		}
 		// TODO: handle the max length (32768) of the message field
 		applyCondition.Message = fmt.Sprintf("Failed to apply manifest: %v", err)
+		if webhookName, message, denied := controller.ParseAdmissionWebhookDenial(err); denied {
+			// Surface the webhook details so that users can act on the rejection (e.g., fix a policy violation).
+			applyCondition.Reason = ManifestDeniedByAdmissionWebhookReason
+			applyCondition.Message = fmt.Sprintf("Admission webhook %q on the member cluster denied the manifest: %s", webhookName, message)
+		}
 		availableCondition.Status = metav1.ConditionUnknown
 		availableCondition.Reason = ManifestApplyFailedReason
 		availableCondition.Message = "Manifest is not applied yet"
@@ -859,6 +973,15 @@ func buildManifestCondition(err error, action ApplyAction, observedGeneration in
 			availableCondition.Reason = string(manifestNotAvailableYetAction)
 			availableCondition.Message = "Manifest is trackable but not available yet"
 
+		// the manifest is not applied yet but it's not a failure either
+		case manifestWaitingForDeletionAction:
+			applyCondition.Status = metav1.ConditionUnknown
+			applyCondition.Reason = string(manifestWaitingForDeletionAction)
+			applyCondition.Message = "Manifest is waiting for the existing object of the same name to be deleted"
+			availableCondition.Status = metav1.ConditionUnknown
+			availableCondition.Reason = string(manifestWaitingForDeletionAction)
+			availableCondition.Message = "Manifest is not applied yet"
+
 		// we cannot stuck at unknown so we have to mark it as true
 		case manifestNotTrackableAction:
 			applyCondition.Reason = ManifestAlreadyUpToDateReason
@@ -877,6 +1000,7 @@ func buildManifestCondition(err error, action ApplyAction, observedGeneration in
 
 // buildWorkCondition generate overall applied and available status condition for work.
 // If one of the manifests is applied failed on the member cluster, the applied status condition of the work is false.
+// If one of the manifests is still pending to be applied, both the applied and available status conditions of the work are unknown.
 // If one of the manifests is not available yet on the member cluster, the available status condition of the work is false.
 // If all the manifests are available, the available status condition of the work is true.
 // Otherwise, the available status condition of the work is unknown.
@@ -903,6 +1027,17 @@ func buildWorkCondition(manifestConditions []fleetv1beta1.ManifestCondition, obs
 			return []metav1.Condition{applyCondition, availableCondition}
 		}
 	}
+	// now that there is no failure, we mark the entire work applied condition to unknown if one of the manifests is pending
+	for _, manifestCond := range manifestConditions {
+		if meta.IsStatusConditionPresentAndEqual(manifestCond.Conditions, fleetv1beta1.WorkConditionTypeApplied, metav1.ConditionUnknown) {
+			applyCondition.Status = metav1.ConditionUnknown
+			applyCondition.Reason = workApplyPendingReason
+			applyCondition.Message = fmt.Sprintf("Manifest %+v is pending to be applied", manifestCond.Identifier)
+			availableCondition.Status = metav1.ConditionUnknown
+			availableCondition.Reason = workApplyPendingReason
+			return []metav1.Condition{applyCondition, availableCondition}
+		}
+	}
 	applyCondition.Status = metav1.ConditionTrue
 	applyCondition.Reason = workAppliedCompletedReason
 	applyCondition.Message = "Work is applied successfully"
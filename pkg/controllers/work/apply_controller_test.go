@@ -146,6 +146,22 @@ func TestSetManifestHashAnnotation(t *testing.T) {
 			}(),
 			isSame: true,
 		},
+		"manifest's has provenance applied-at annotation, same": {
+			manifestObj: func() *appsv1.Deployment {
+				alterObj := manifestObj.DeepCopy()
+				alterObj.Annotations[fleetv1beta1.ProvenanceAppliedAtAnnotation] = utilrand.String(10)
+				return alterObj
+			}(),
+			isSame: true,
+		},
+		"manifest has a different provenance placement annotation, need update": {
+			manifestObj: func() *appsv1.Deployment {
+				alterObj := manifestObj.DeepCopy()
+				alterObj.Annotations[fleetv1beta1.ProvenancePlacementAnnotation] = utilrand.String(10)
+				return alterObj
+			}(),
+			isSame: false,
+		},
 		"manifest has extra metadata, same": {
 			manifestObj: func() *appsv1.Deployment {
 				noObj := manifestObj.DeepCopy()
@@ -216,6 +232,56 @@ func TestSetManifestHashAnnotation(t *testing.T) {
 	}
 }
 
+func TestSetDeletionProtectedAnnotation(t *testing.T) {
+	rawNamespace, _ := json.Marshal(v1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "work",
+		},
+	})
+	tests := map[string]struct {
+		rawManifest   []byte
+		applyStrategy *fleetv1beta1.ApplyStrategy
+		wantProtected bool
+	}{
+		"namespace with the critical resources deletion protection": {
+			rawManifest: rawNamespace,
+			applyStrategy: &fleetv1beta1.ApplyStrategy{
+				Type:               fleetv1beta1.ApplyStrategyTypeClientSideApply,
+				DeletionProtection: fleetv1beta1.DeletionProtectionTypeCriticalResources,
+			},
+			wantProtected: true,
+		},
+		"namespace without deletion protection": {
+			rawManifest:   rawNamespace,
+			applyStrategy: &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply},
+			wantProtected: false,
+		},
+		"deployment with the critical resources deletion protection": {
+			rawManifest: rawTestDeployment,
+			applyStrategy: &fleetv1beta1.ApplyStrategy{
+				Type:               fleetv1beta1.ApplyStrategyTypeClientSideApply,
+				DeletionProtection: fleetv1beta1.DeletionProtectionTypeCriticalResources,
+			},
+			wantProtected: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var uManifestObj unstructured.Unstructured
+			if err := uManifestObj.UnmarshalJSON(tt.rawManifest); err != nil {
+				t.Fatalf("failed to unmarshal the manifest: %v", err)
+			}
+			setDeletionProtectedAnnotation(&uManifestObj, tt.applyStrategy)
+			_, gotProtected := uManifestObj.GetAnnotations()[fleetv1beta1.DeletionProtectedAnnotation]
+			assert.Equalf(t, tt.wantProtected, gotProtected, "testcase %s: deletion protected annotation mismatch", name)
+		})
+	}
+}
+
 func TestIsManifestManagedByWork(t *testing.T) {
 	tests := map[string]struct {
 		ownerRefs []metav1.OwnerReference
@@ -355,6 +421,22 @@ func TestBuildManifestCondition(t *testing.T) {
 				},
 			},
 		},
+		"TestNoErrorManifestWaitingForDeletion": {
+			err:    nil,
+			action: manifestWaitingForDeletionAction,
+			want: []metav1.Condition{
+				{
+					Type:   fleetv1beta1.WorkConditionTypeApplied,
+					Status: metav1.ConditionUnknown,
+					Reason: string(manifestWaitingForDeletionAction),
+				},
+				{
+					Type:   fleetv1beta1.WorkConditionTypeAvailable,
+					Status: metav1.ConditionUnknown,
+					Reason: string(manifestWaitingForDeletionAction),
+				},
+			},
+		},
 		"TestNoErrorManifestNotTrackableAction": {
 			err:    nil,
 			action: manifestNotTrackableAction,
@@ -403,6 +485,22 @@ func TestBuildManifestCondition(t *testing.T) {
 				},
 			},
 		},
+		"TestApplyDeniedByAdmissionWebhook": {
+			err:    errors.New(`admission webhook "validation.gatekeeper.sh" denied the request: [required-labels] you must provide labels`),
+			action: errorApplyAction,
+			want: []metav1.Condition{
+				{
+					Type:   fleetv1beta1.WorkConditionTypeApplied,
+					Status: metav1.ConditionFalse,
+					Reason: ManifestDeniedByAdmissionWebhookReason,
+				},
+				{
+					Type:   fleetv1beta1.WorkConditionTypeAvailable,
+					Status: metav1.ConditionUnknown,
+					Reason: ManifestApplyFailedReason,
+				},
+			},
+		},
 		"TestApplyConflictBetweenPlacements": {
 			err:    errors.New("test error"),
 			action: applyConflictBetweenPlacements,
@@ -446,6 +544,119 @@ func TestBuildManifestCondition(t *testing.T) {
 	}
 }
 
+func TestClassifyApplyError(t *testing.T) {
+	gr := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	tests := map[string]struct {
+		err    error
+		action ApplyAction
+		want   fleetv1beta1.ApplyErrorCategory
+	}{
+		"denied by admission webhook": {
+			err:    errors.New(`admission webhook "validation.gatekeeper.sh" denied the request: [required-labels] you must provide labels`),
+			action: errorApplyAction,
+			want:   fleetv1beta1.ApplyErrorCategoryAdmission,
+		},
+		"conflict between placements": {
+			err:    controller.NewUserError(errors.New("test error")),
+			action: applyConflictBetweenPlacements,
+			want:   fleetv1beta1.ApplyErrorCategoryConflict,
+		},
+		"owned by others": {
+			err:    controller.NewUserError(errors.New("test error")),
+			action: manifestAlreadyOwnedByOthers,
+			want:   fleetv1beta1.ApplyErrorCategoryConflict,
+		},
+		"modified concurrently": {
+			err:    apierrors.NewConflict(gr, "web", errors.New("the object has been modified")),
+			action: errorApplyAction,
+			want:   fleetv1beta1.ApplyErrorCategoryConflict,
+		},
+		"forbidden": {
+			err:    apierrors.NewForbidden(gr, "web", errors.New("not allowed")),
+			action: errorApplyAction,
+			want:   fleetv1beta1.ApplyErrorCategoryRBAC,
+		},
+		"unauthorized": {
+			err:    apierrors.NewUnauthorized("unauthorized"),
+			action: errorApplyAction,
+			want:   fleetv1beta1.ApplyErrorCategoryRBAC,
+		},
+		"invalid": {
+			err:    apierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "web", nil),
+			action: errorApplyAction,
+			want:   fleetv1beta1.ApplyErrorCategoryInvalid,
+		},
+		"cannot be decoded": {
+			err:  controller.NewUserError(errors.New("failed to decode object")),
+			want: fleetv1beta1.ApplyErrorCategoryInvalid,
+		},
+		"other error": {
+			err:    apierrors.NewServiceUnavailable("unavailable"),
+			action: errorApplyAction,
+			want:   fleetv1beta1.ApplyErrorCategoryOther,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := classifyApplyError(tt.err, tt.action); got != tt.want {
+				t.Errorf("classifyApplyError() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstructWorkConditionApplyFailures(t *testing.T) {
+	identifier := fleetv1beta1.WorkResourceIdentifier{Ordinal: 0, Group: "apps", Version: "v1", Kind: "Deployment", Name: "web", Namespace: "app"}
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "web", errors.New("not allowed"))
+	tests := map[string]struct {
+		existing      []fleetv1beta1.ManifestCondition
+		result        applyResult
+		wantCount     int32
+		wantCategory  fleetv1beta1.ApplyErrorCategory
+		wantErrsCount int
+	}{
+		"first failure": {
+			result:        applyResult{identifier: identifier, action: errorApplyAction, applyErr: forbidden},
+			wantCount:     1,
+			wantCategory:  fleetv1beta1.ApplyErrorCategoryRBAC,
+			wantErrsCount: 1,
+		},
+		"consecutive failure": {
+			existing: []fleetv1beta1.ManifestCondition{
+				{Identifier: identifier, ApplyFailureCount: 2, LastApplyErrorCategory: fleetv1beta1.ApplyErrorCategoryOther},
+			},
+			result:        applyResult{identifier: identifier, action: errorApplyAction, applyErr: forbidden},
+			wantCount:     3,
+			wantCategory:  fleetv1beta1.ApplyErrorCategoryRBAC,
+			wantErrsCount: 1,
+		},
+		"applied after failures": {
+			existing: []fleetv1beta1.ManifestCondition{
+				{Identifier: identifier, ApplyFailureCount: 2, LastApplyErrorCategory: fleetv1beta1.ApplyErrorCategoryRBAC},
+			},
+			result: applyResult{identifier: identifier, action: manifestCreatedAction},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			work := &fleetv1beta1.Work{Status: fleetv1beta1.WorkStatus{ManifestConditions: tt.existing}}
+			errs := constructWorkCondition([]applyResult{tt.result}, work)
+			if len(errs) != tt.wantErrsCount {
+				t.Errorf("constructWorkCondition() returned %d errors, want %d", len(errs), tt.wantErrsCount)
+			}
+			got := work.Status.ManifestConditions[0]
+			if got.ApplyFailureCount != tt.wantCount {
+				t.Errorf("constructWorkCondition() ApplyFailureCount = %d, want %d", got.ApplyFailureCount, tt.wantCount)
+			}
+			if got.LastApplyErrorCategory != tt.wantCategory {
+				t.Errorf("constructWorkCondition() LastApplyErrorCategory = %s, want %s", got.LastApplyErrorCategory, tt.wantCategory)
+			}
+		})
+	}
+}
+
 func TestGenerateWorkCondition(t *testing.T) {
 	tests := map[string]struct {
 		manifestConditions []fleetv1beta1.ManifestCondition
@@ -482,6 +693,52 @@ func TestGenerateWorkCondition(t *testing.T) {
 				},
 			},
 		},
+		"Test applied one of the two pending": {
+			manifestConditions: []fleetv1beta1.ManifestCondition{
+				{
+					Identifier: fleetv1beta1.WorkResourceIdentifier{
+						Ordinal: 1,
+					},
+					Conditions: []metav1.Condition{
+						{
+							Type:   fleetv1beta1.WorkConditionTypeApplied,
+							Status: metav1.ConditionTrue,
+						},
+						{
+							Type:   fleetv1beta1.WorkConditionTypeAvailable,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+				{
+					Identifier: fleetv1beta1.WorkResourceIdentifier{
+						Ordinal: 2,
+					},
+					Conditions: []metav1.Condition{
+						{
+							Type:   fleetv1beta1.WorkConditionTypeApplied,
+							Status: metav1.ConditionUnknown,
+						},
+						{
+							Type:   fleetv1beta1.WorkConditionTypeAvailable,
+							Status: metav1.ConditionUnknown,
+						},
+					},
+				},
+			},
+			expected: []metav1.Condition{
+				{
+					Type:   fleetv1beta1.WorkConditionTypeApplied,
+					Status: metav1.ConditionUnknown,
+					Reason: workApplyPendingReason,
+				},
+				{
+					Type:   fleetv1beta1.WorkConditionTypeAvailable,
+					Status: metav1.ConditionUnknown,
+					Reason: workApplyPendingReason,
+				},
+			},
+		},
 		"Test applied one of the two failed": {
 			manifestConditions: []fleetv1beta1.ManifestCondition{
 				{
@@ -1601,6 +1858,13 @@ func TestApplyUnstructuredAndTrackAvailability(t *testing.T) {
 				}}
 	})
 
+	terminatingObj := correctObj.DeepCopy()
+	terminatingObj.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	terminatingDynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme())
+	terminatingDynamicClient.PrependReactor("get", "*", func(action testingclient.Action) (handled bool, ret runtime.Object, err error) {
+		return true, terminatingObj.DeepCopy(), nil
+	})
+
 	dynamicClientError := fake.NewSimpleDynamicClient(runtime.NewScheme())
 	dynamicClientError.PrependReactor("get", "*", func(action testingclient.Action) (handled bool, ret runtime.Object, err error) {
 		return true,
@@ -1872,6 +2136,16 @@ func TestApplyUnstructuredAndTrackAvailability(t *testing.T) {
 			resultAction:   manifestNotAvailableYetAction,
 			resultErr:      nil,
 		},
+		"existing object is being deleted / wait for the deletion": {
+			reconciler: ApplyWorkReconciler{
+				spokeDynamicClient: terminatingDynamicClient,
+				recorder:           utils.NewFakeRecorder(1),
+			},
+			workObj:        correctObj.DeepCopy(),
+			resultSpecHash: correctSpecHash,
+			resultAction:   manifestWaitingForDeletionAction,
+			resultErr:      nil,
+		},
 		"unequal spec hash of current vs work object / client patch fail": {
 			reconciler: ApplyWorkReconciler{
 				spokeDynamicClient: patchFailClient,
@@ -2125,6 +2399,29 @@ func TestApplyManifest(t *testing.T) {
 			wantGvr:        expectedGvr,
 			wantErr:        errors.New(failMsg),
 		},
+		"manifest is in proper format/ transform fail": {
+			reconciler: ApplyWorkReconciler{
+				client:             &test.MockClient{},
+				spokeDynamicClient: fakeDynamicClient,
+				spokeClient:        &test.MockClient{},
+				restMapper:         utils.TestMapper{},
+				recorder:           utils.NewFakeRecorder(1),
+				joined:             atomic.NewBool(true),
+				manifestTransformers: []ManifestTransformer{
+					&fakeManifestTransformer{
+						name: "broken",
+						transformFn: func(_ *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+							return nil, errors.New("cannot transform")
+						},
+					},
+				},
+			},
+			manifestList:   append([]fleetv1beta1.Manifest{}, testManifest),
+			wantGeneration: 0,
+			wantAction:     errorApplyAction,
+			wantGvr:        expectedGvr,
+			wantErr:        errors.New("failed to transform the manifest: transformer broken failed: cannot transform"),
+		},
 	}
 
 	for testName, testCase := range testCases {
@@ -2138,7 +2435,7 @@ func TestApplyManifest(t *testing.T) {
 				},
 			}
 			applyStrategy := &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply}
-			resultList := r.applyManifests(context.Background(), testCase.manifestList, ownerRef, applyStrategy)
+			resultList := r.applyManifests(context.Background(), testCase.manifestList, ownerRef, applyStrategy, Provenance{})
 			for _, result := range resultList {
 				if testCase.wantErr != nil {
 					assert.Containsf(t, result.applyErr.Error(), testCase.wantErr.Error(), "Incorrect error for Testcase %s", testName)
@@ -0,0 +1,85 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// WithImpersonation enables the reconciler to apply the manifests of a Work as the service account specified
+// in its apply strategy, by impersonating the service account with the given member cluster config.
+func WithImpersonation(memberConfig *rest.Config) ApplyWorkReconcilerOption {
+	return func(r *ApplyWorkReconciler) {
+		r.impersonationConfig = memberConfig
+		r.impersonatedClients = &impersonatedClientCache{clients: make(map[string]dynamic.Interface)}
+	}
+}
+
+// impersonatedClientCache caches the dynamic clients which impersonate the service accounts, keyed by the
+// usernames; the clients are reused as the same service accounts are used across the Works.
+type impersonatedClientCache struct {
+	mu      sync.Mutex
+	clients map[string]dynamic.Interface
+}
+
+// serviceAccountUsername returns the username with which the member cluster authenticates the service account.
+func serviceAccountUsername(sa *fleetv1beta1.ServiceAccountReference) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", sa.Namespace, sa.Name)
+}
+
+// applierFor returns the applier which applies the manifests with the given apply strategy; if the apply strategy
+// specifies a service account, the applier impersonates the service account.
+func (r *ApplyWorkReconciler) applierFor(applyStrategy *fleetv1beta1.ApplyStrategy) (Applier, error) {
+	applier := r.appliers[applyStrategy.Type]
+	if applier == nil {
+		return nil, controller.NewUserError(fmt.Errorf("unknown apply strategy type %s", applyStrategy.Type))
+	}
+	if applyStrategy.ServiceAccount == nil {
+		return applier, nil
+	}
+	if r.impersonationConfig == nil {
+		return nil, controller.NewUserError(fmt.Errorf("cannot apply as service account %s/%s: impersonation is not enabled on the member agent",
+			applyStrategy.ServiceAccount.Namespace, applyStrategy.ServiceAccount.Name))
+	}
+
+	spokeDynamicClient, err := r.impersonatedClientFor(serviceAccountUsername(applyStrategy.ServiceAccount))
+	if err != nil {
+		return nil, err
+	}
+	switch applyStrategy.Type {
+	case fleetv1beta1.ApplyStrategyTypeServerSideApply:
+		return &ServerSideApplier{HubClient: r.client, WorkNamespace: r.workNameSpace, SpokeDynamicClient: spokeDynamicClient}, nil
+	default:
+		return &ClientSideApplier{HubClient: r.client, WorkNamespace: r.workNameSpace, SpokeDynamicClient: spokeDynamicClient}, nil
+	}
+}
+
+// impersonatedClientFor returns the dynamic client which impersonates the given user.
+func (r *ApplyWorkReconciler) impersonatedClientFor(username string) (dynamic.Interface, error) {
+	r.impersonatedClients.mu.Lock()
+	defer r.impersonatedClients.mu.Unlock()
+	if c, ok := r.impersonatedClients.clients[username]; ok {
+		return c, nil
+	}
+
+	cfg := rest.CopyConfig(r.impersonationConfig)
+	cfg.Impersonate = rest.ImpersonationConfig{UserName: username}
+	c, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		klog.ErrorS(err, "Failed to create the impersonated client", "user", username)
+		return nil, controller.NewUnexpectedBehaviorError(err)
+	}
+	r.impersonatedClients.clients[username] = c
+	return c, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestApplierFor(t *testing.T) {
+	spokeDynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme())
+	serviceAccount := &fleetv1beta1.ServiceAccountReference{Namespace: "tenant", Name: "deployer"}
+	tests := map[string]struct {
+		opts              []ApplyWorkReconcilerOption
+		applyStrategy     *fleetv1beta1.ApplyStrategy
+		wantErr           bool
+		wantImpersonation bool
+	}{
+		"no service account": {
+			applyStrategy: &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeServerSideApply},
+		},
+		"unknown apply strategy type": {
+			applyStrategy: &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeReportDiff},
+			wantErr:       true,
+		},
+		"service account without impersonation enabled": {
+			applyStrategy: &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeClientSideApply, ServiceAccount: serviceAccount},
+			wantErr:       true,
+		},
+		"service account with impersonation enabled": {
+			opts:              []ApplyWorkReconcilerOption{WithImpersonation(&rest.Config{Host: "https://member.example.com"})},
+			applyStrategy:     &fleetv1beta1.ApplyStrategy{Type: fleetv1beta1.ApplyStrategyTypeServerSideApply, ServiceAccount: serviceAccount},
+			wantImpersonation: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := NewApplyWorkReconciler(nil, spokeDynamicClient, nil, nil, nil, 1, "", tc.opts...)
+			r.appliers = map[fleetv1beta1.ApplyStrategyType]Applier{
+				fleetv1beta1.ApplyStrategyTypeServerSideApply: &ServerSideApplier{SpokeDynamicClient: spokeDynamicClient},
+				fleetv1beta1.ApplyStrategyTypeClientSideApply: &ClientSideApplier{SpokeDynamicClient: spokeDynamicClient},
+			}
+			applier, err := r.applierFor(tc.applyStrategy)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("applierFor() got error %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if gotImpersonation := applier != r.appliers[tc.applyStrategy.Type]; gotImpersonation != tc.wantImpersonation {
+				t.Errorf("applierFor() returned an impersonating applier: %t, want %t", gotImpersonation, tc.wantImpersonation)
+			}
+			if !tc.wantImpersonation {
+				return
+			}
+			if _, ok := r.impersonatedClients.clients["system:serviceaccount:tenant:deployer"]; !ok {
+				t.Errorf("applierFor() did not cache the impersonated client for the service account")
+			}
+			again, err := r.applierFor(tc.applyStrategy)
+			if err != nil {
+				t.Fatalf("applierFor() got error %v, want nil", err)
+			}
+			if again.(*ServerSideApplier).SpokeDynamicClient != applier.(*ServerSideApplier).SpokeDynamicClient {
+				t.Errorf("applierFor() did not reuse the impersonated client")
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// Provenance describes where an object applied by the work applier comes from.
+type Provenance struct {
+	// Placement is the name of the placement which places the object.
+	Placement string
+	// ResourceSnapshotIndex is the index of the resource snapshot from which the object is applied.
+	ResourceSnapshotIndex string
+	// Hub is the identity of the hub cluster from which the object is applied.
+	Hub string
+	// AppliedAt is the time (in RFC 3339 format) when the object is first applied with its current provenance.
+	AppliedAt string
+	// Work is the name of the work which carries the object.
+	Work string
+}
+
+// provenanceKey identifies an object in the provenance index; the ordinal and the resource of
+// the object are ignored, same as isSameResourceIdentifier.
+type provenanceKey struct {
+	group     string
+	version   string
+	kind      string
+	namespace string
+	name      string
+}
+
+func newProvenanceKey(identifier fleetv1beta1.WorkResourceIdentifier) provenanceKey {
+	return provenanceKey{
+		group:     identifier.Group,
+		version:   identifier.Version,
+		kind:      identifier.Kind,
+		namespace: identifier.Namespace,
+		name:      identifier.Name,
+	}
+}
+
+// provenanceIndex is an in-memory index which keeps track of the provenance of all the objects
+// that the work applier has applied since it starts.
+//
+// Note that a nil index is valid and always reports that no provenance is found.
+type provenanceIndex struct {
+	mu sync.RWMutex
+	// byObject maps an object to its provenance.
+	byObject map[provenanceKey]provenanceEntry
+}
+
+// provenanceEntry is an entry in the provenance index.
+type provenanceEntry struct {
+	identifier fleetv1beta1.WorkResourceIdentifier
+	provenance Provenance
+}
+
+func newProvenanceIndex() *provenanceIndex {
+	return &provenanceIndex{
+		byObject: make(map[provenanceKey]provenanceEntry),
+	}
+}
+
+// set records the provenance of an object.
+func (idx *provenanceIndex) set(identifier fleetv1beta1.WorkResourceIdentifier, provenance Provenance) {
+	if idx == nil {
+		return
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.byObject[newProvenanceKey(identifier)] = provenanceEntry{
+		identifier: identifier,
+		provenance: provenance,
+	}
+}
+
+// get returns the provenance of an object.
+func (idx *provenanceIndex) get(identifier fleetv1beta1.WorkResourceIdentifier) (Provenance, bool) {
+	if idx == nil {
+		return Provenance{}, false
+	}
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	entry, found := idx.byObject[newProvenanceKey(identifier)]
+	return entry.provenance, found
+}
+
+// listByPlacement returns the identifiers of all the objects placed by a specific placement,
+// sorted by their namespaces and names.
+func (idx *provenanceIndex) listByPlacement(placement string) []fleetv1beta1.WorkResourceIdentifier {
+	if idx == nil {
+		return nil
+	}
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	var identifiers []fleetv1beta1.WorkResourceIdentifier
+	for _, entry := range idx.byObject {
+		if entry.provenance.Placement == placement {
+			identifiers = append(identifiers, entry.identifier)
+		}
+	}
+	sort.Slice(identifiers, func(i, j int) bool {
+		if identifiers[i].Namespace != identifiers[j].Namespace {
+			return identifiers[i].Namespace < identifiers[j].Namespace
+		}
+		if identifiers[i].Name != identifiers[j].Name {
+			return identifiers[i].Name < identifiers[j].Name
+		}
+		return identifiers[i].Kind < identifiers[j].Kind
+	})
+	return identifiers
+}
+
+// deleteIfFromWork removes the provenance of an object from the index, if the object is last
+// applied via the given work.
+func (idx *provenanceIndex) deleteIfFromWork(identifier fleetv1beta1.WorkResourceIdentifier, work string) {
+	if idx == nil {
+		return
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	key := newProvenanceKey(identifier)
+	if entry, found := idx.byObject[key]; found && entry.provenance.Work == work {
+		delete(idx.byObject, key)
+	}
+}
+
+// deleteAllFromWork removes the provenance of all the objects that are last applied via the given work.
+func (idx *provenanceIndex) deleteAllFromWork(work string) {
+	if idx == nil {
+		return
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for key, entry := range idx.byObject {
+		if entry.provenance.Work == work {
+			delete(idx.byObject, key)
+		}
+	}
+}
+
+// LookupProvenance returns the provenance of an object that the work applier has applied.
+func (r *ApplyWorkReconciler) LookupProvenance(identifier fleetv1beta1.WorkResourceIdentifier) (Provenance, bool) {
+	return r.provenanceIndex.get(identifier)
+}
+
+// ListObjectsPlacedBy returns the identifiers of all the objects that the work applier has applied
+// on behalf of a specific placement.
+func (r *ApplyWorkReconciler) ListObjectsPlacedBy(placement string) []fleetv1beta1.WorkResourceIdentifier {
+	return r.provenanceIndex.listByPlacement(placement)
+}
+
+// buildProvenance builds the provenance of the objects in a work.
+func (r *ApplyWorkReconciler) buildProvenance(work *fleetv1beta1.Work) Provenance {
+	return Provenance{
+		Placement:             work.GetLabels()[fleetv1beta1.CRPTrackingLabel],
+		ResourceSnapshotIndex: work.GetLabels()[fleetv1beta1.ParentResourceSnapshotIndexLabel],
+		Hub:                   r.hubIdentity,
+		AppliedAt:             time.Now().UTC().Format(time.RFC3339),
+		Work:                  work.GetName(),
+	}
+}
+
+// setProvenanceAnnotations stamps the provenance annotations on the manifest object.
+func setProvenanceAnnotations(manifestObj *unstructured.Unstructured, provenance Provenance) {
+	annotations := manifestObj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	setOrRemove := func(key, value string) {
+		if value == "" {
+			delete(annotations, key)
+			return
+		}
+		annotations[key] = value
+	}
+	setOrRemove(fleetv1beta1.ProvenancePlacementAnnotation, provenance.Placement)
+	setOrRemove(fleetv1beta1.ProvenanceResourceSnapshotIndexAnnotation, provenance.ResourceSnapshotIndex)
+	setOrRemove(fleetv1beta1.ProvenanceHubAnnotation, provenance.Hub)
+	setOrRemove(fleetv1beta1.ProvenanceAppliedAtAnnotation, provenance.AppliedAt)
+	if len(annotations) == 0 {
+		manifestObj.SetAnnotations(nil)
+		return
+	}
+	manifestObj.SetAnnotations(annotations)
+}
+
+// provenanceFromAnnotations reads the provenance annotations from an applied object.
+func provenanceFromAnnotations(obj *unstructured.Unstructured, work string) Provenance {
+	annotations := obj.GetAnnotations()
+	return Provenance{
+		Placement:             annotations[fleetv1beta1.ProvenancePlacementAnnotation],
+		ResourceSnapshotIndex: annotations[fleetv1beta1.ProvenanceResourceSnapshotIndexAnnotation],
+		Hub:                   annotations[fleetv1beta1.ProvenanceHubAnnotation],
+		AppliedAt:             annotations[fleetv1beta1.ProvenanceAppliedAtAnnotation],
+		Work:                  work,
+	}
+}
+
+// preserveProvenanceAppliedAt keeps the applied-at timestamp of the current object on the manifest
+// object if the provenance of the two objects is the same, so that the timestamp only changes
+// when the object is applied from a different placement, resource snapshot, or hub cluster.
+func preserveProvenanceAppliedAt(manifestObj, curObj *unstructured.Unstructured) {
+	manifestAnnotations := manifestObj.GetAnnotations()
+	if _, found := manifestAnnotations[fleetv1beta1.ProvenanceAppliedAtAnnotation]; !found {
+		return
+	}
+	curAnnotations := curObj.GetAnnotations()
+	curAppliedAt, found := curAnnotations[fleetv1beta1.ProvenanceAppliedAtAnnotation]
+	if !found {
+		return
+	}
+	for _, key := range []string{
+		fleetv1beta1.ProvenancePlacementAnnotation,
+		fleetv1beta1.ProvenanceResourceSnapshotIndexAnnotation,
+		fleetv1beta1.ProvenanceHubAnnotation,
+	} {
+		if manifestAnnotations[key] != curAnnotations[key] {
+			return
+		}
+	}
+	manifestAnnotations[fleetv1beta1.ProvenanceAppliedAtAnnotation] = curAppliedAt
+	manifestObj.SetAnnotations(manifestAnnotations)
+}
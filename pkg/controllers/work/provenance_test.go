@@ -0,0 +1,181 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	provenanceTestPlacement = "test-crp"
+	provenanceTestHub       = "https://hub.example.com"
+	provenanceTestWork      = "test-crp-work"
+)
+
+// TestSetProvenanceAnnotations tests the setProvenanceAnnotations function.
+func TestSetProvenanceAnnotations(t *testing.T) {
+	tests := map[string]struct {
+		annotations     map[string]string
+		provenance      Provenance
+		wantAnnotations map[string]string
+	}{
+		"stamp all provenance annotations": {
+			annotations: map[string]string{"foo": "bar"},
+			provenance: Provenance{
+				Placement:             provenanceTestPlacement,
+				ResourceSnapshotIndex: "1",
+				Hub:                   provenanceTestHub,
+				AppliedAt:             "2024-01-01T00:00:00Z",
+			},
+			wantAnnotations: map[string]string{
+				"foo": "bar",
+				fleetv1beta1.ProvenancePlacementAnnotation:             provenanceTestPlacement,
+				fleetv1beta1.ProvenanceResourceSnapshotIndexAnnotation: "1",
+				fleetv1beta1.ProvenanceHubAnnotation:                   provenanceTestHub,
+				fleetv1beta1.ProvenanceAppliedAtAnnotation:             "2024-01-01T00:00:00Z",
+			},
+		},
+		"remove empty provenance annotations": {
+			annotations: map[string]string{
+				fleetv1beta1.ProvenanceHubAnnotation: provenanceTestHub,
+			},
+			provenance: Provenance{
+				Placement: provenanceTestPlacement,
+			},
+			wantAnnotations: map[string]string{
+				fleetv1beta1.ProvenancePlacementAnnotation: provenanceTestPlacement,
+			},
+		},
+		"no provenance": {
+			provenance:      Provenance{},
+			wantAnnotations: nil,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			obj.SetAnnotations(tt.annotations)
+			setProvenanceAnnotations(obj, tt.provenance)
+			if diff := cmp.Diff(tt.wantAnnotations, obj.GetAnnotations()); diff != "" {
+				t.Errorf("setProvenanceAnnotations() annotations mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestPreserveProvenanceAppliedAt tests the preserveProvenanceAppliedAt function.
+func TestPreserveProvenanceAppliedAt(t *testing.T) {
+	oldAppliedAt := "2024-01-01T00:00:00Z"
+	newAppliedAt := "2024-02-01T00:00:00Z"
+	provenanceAnnotations := func(index, appliedAt string) map[string]string {
+		return map[string]string{
+			fleetv1beta1.ProvenancePlacementAnnotation:             provenanceTestPlacement,
+			fleetv1beta1.ProvenanceResourceSnapshotIndexAnnotation: index,
+			fleetv1beta1.ProvenanceHubAnnotation:                   provenanceTestHub,
+			fleetv1beta1.ProvenanceAppliedAtAnnotation:             appliedAt,
+		}
+	}
+	tests := map[string]struct {
+		manifestAnnotations map[string]string
+		curAnnotations      map[string]string
+		wantAppliedAt       string
+	}{
+		"same provenance": {
+			manifestAnnotations: provenanceAnnotations("1", newAppliedAt),
+			curAnnotations:      provenanceAnnotations("1", oldAppliedAt),
+			wantAppliedAt:       oldAppliedAt,
+		},
+		"different resource snapshot index": {
+			manifestAnnotations: provenanceAnnotations("2", newAppliedAt),
+			curAnnotations:      provenanceAnnotations("1", oldAppliedAt),
+			wantAppliedAt:       newAppliedAt,
+		},
+		"current object has no provenance": {
+			manifestAnnotations: provenanceAnnotations("1", newAppliedAt),
+			curAnnotations:      map[string]string{"foo": "bar"},
+			wantAppliedAt:       newAppliedAt,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			manifestObj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			manifestObj.SetAnnotations(tt.manifestAnnotations)
+			curObj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			curObj.SetAnnotations(tt.curAnnotations)
+			preserveProvenanceAppliedAt(manifestObj, curObj)
+			if got := manifestObj.GetAnnotations()[fleetv1beta1.ProvenanceAppliedAtAnnotation]; got != tt.wantAppliedAt {
+				t.Errorf("preserveProvenanceAppliedAt() applied-at = %q, want %q", got, tt.wantAppliedAt)
+			}
+		})
+	}
+}
+
+// TestProvenanceIndex tests the provenance index.
+func TestProvenanceIndex(t *testing.T) {
+	configMap := fleetv1beta1.WorkResourceIdentifier{Version: "v1", Kind: "ConfigMap", Namespace: "app", Name: "config"}
+	deploy := fleetv1beta1.WorkResourceIdentifier{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "app", Name: "app"}
+	otherDeploy := fleetv1beta1.WorkResourceIdentifier{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "other", Name: "app"}
+	provenance := Provenance{
+		Placement:             provenanceTestPlacement,
+		ResourceSnapshotIndex: "1",
+		Hub:                   provenanceTestHub,
+		Work:                  provenanceTestWork,
+	}
+	otherProvenance := Provenance{
+		Placement: "other-crp",
+		Work:      "other-crp-work",
+	}
+
+	r := &ApplyWorkReconciler{provenanceIndex: newProvenanceIndex()}
+	r.provenanceIndex.set(configMap, provenance)
+	r.provenanceIndex.set(deploy, provenance)
+	r.provenanceIndex.set(otherDeploy, otherProvenance)
+
+	// The ordinal is ignored when looking up the provenance.
+	lookup := configMap
+	lookup.Ordinal = 3
+	got, found := r.LookupProvenance(lookup)
+	if !found {
+		t.Fatalf("LookupProvenance(%v) = not found, want found", lookup)
+	}
+	if diff := cmp.Diff(provenance, got); diff != "" {
+		t.Errorf("LookupProvenance() mismatch (-want, +got):\n%s", diff)
+	}
+
+	wantPlaced := []fleetv1beta1.WorkResourceIdentifier{deploy, configMap}
+	if diff := cmp.Diff(wantPlaced, r.ListObjectsPlacedBy(provenanceTestPlacement)); diff != "" {
+		t.Errorf("ListObjectsPlacedBy() mismatch (-want, +got):\n%s", diff)
+	}
+
+	// Deleting via a work that does not last apply the object is a no-op.
+	r.provenanceIndex.deleteIfFromWork(otherDeploy, provenanceTestWork)
+	if _, found := r.LookupProvenance(otherDeploy); !found {
+		t.Errorf("LookupProvenance(%v) = not found after deleting via another work, want found", otherDeploy)
+	}
+	r.provenanceIndex.deleteIfFromWork(configMap, provenanceTestWork)
+	if _, found := r.LookupProvenance(configMap); found {
+		t.Errorf("LookupProvenance(%v) = found after deletion, want not found", configMap)
+	}
+
+	r.provenanceIndex.deleteAllFromWork(provenanceTestWork)
+	if placed := r.ListObjectsPlacedBy(provenanceTestPlacement); len(placed) != 0 {
+		t.Errorf("ListObjectsPlacedBy() = %v after deleting all from work, want empty", placed)
+	}
+	if _, found := r.LookupProvenance(otherDeploy); !found {
+		t.Errorf("LookupProvenance(%v) = not found after deleting all from another work, want found", otherDeploy)
+	}
+
+	// A reconciler without an index reports no provenance.
+	var empty ApplyWorkReconciler
+	if _, found := empty.LookupProvenance(deploy); found {
+		t.Errorf("LookupProvenance() on a reconciler without index = found, want not found")
+	}
+}
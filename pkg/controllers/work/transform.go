@@ -0,0 +1,154 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// defaultManifestTransformWebhookTimeout is the default timeout for calling a manifest transform webhook.
+	defaultManifestTransformWebhookTimeout = time.Second * 10
+
+	// maxManifestTransformWebhookResponseBytes is the max. size of a response body that Fleet will
+	// read from a manifest transform webhook.
+	maxManifestTransformWebhookResponseBytes = 3 * 1024 * 1024
+)
+
+// ManifestTransformer transforms a manifest right before the work controller applies it to the member
+// cluster, so that the manifest can be mutated with member-local data (e.g., a cluster-specific
+// registry mirror or storage class name) that cannot be expressed via hub-side overrides.
+//
+// Note that the work controller reconciles multiple Work objects concurrently; implementations must
+// be safe for concurrent use.
+type ManifestTransformer interface {
+	// Name returns the name of the transformer.
+	Name() string
+	// Transform returns the transformed manifest object. It must not change the identity of the
+	// object, i.e., its API group, kind, namespace, and name.
+	Transform(ctx context.Context, gvr *schema.GroupVersionResource, manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, error)
+}
+
+// WithManifestTransformers sets the manifest transformers in use by the reconciler; the transformers
+// run in the given order.
+func WithManifestTransformers(transformers ...ManifestTransformer) ApplyWorkReconcilerOption {
+	return func(r *ApplyWorkReconciler) {
+		r.manifestTransformers = transformers
+	}
+}
+
+// transformManifest runs all the manifest transformers on a manifest object.
+func (r *ApplyWorkReconciler) transformManifest(ctx context.Context, gvr *schema.GroupVersionResource, manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	transformed := manifestObj
+	for _, t := range r.manifestTransformers {
+		// Pass a copy of the object so that a failed transformer cannot leave a half-mutated object behind.
+		obj, err := t.Transform(ctx, gvr, transformed.DeepCopy())
+		if err != nil {
+			return nil, fmt.Errorf("transformer %s failed: %w", t.Name(), err)
+		}
+		if obj == nil {
+			return nil, fmt.Errorf("transformer %s returned no object", t.Name())
+		}
+		if obj.GroupVersionKind().GroupKind() != transformed.GroupVersionKind().GroupKind() ||
+			obj.GetNamespace() != transformed.GetNamespace() ||
+			obj.GetName() != transformed.GetName() {
+			return nil, fmt.Errorf("transformer %s changed the identity of the object", t.Name())
+		}
+		transformed = obj
+	}
+	return transformed, nil
+}
+
+// ManifestTransformWebhookRequest is the request that a WebhookManifestTransformer sends to the webhook.
+type ManifestTransformWebhookRequest struct {
+	// Group is the API group of the manifest object.
+	Group string `json:"group"`
+	// Version is the API version of the manifest object.
+	Version string `json:"version"`
+	// Resource is the API resource of the manifest object.
+	Resource string `json:"resource"`
+	// Object is the manifest object to transform.
+	Object *unstructured.Unstructured `json:"object"`
+}
+
+// WebhookManifestTransformer is a ManifestTransformer that delegates the transformation to an HTTP(S)
+// webhook local to the member cluster.
+//
+// The transformer POSTs a ManifestTransformWebhookRequest in JSON to the webhook; the webhook should
+// reply with 200 OK and the transformed object in JSON, or 204 No Content if no change is needed.
+type WebhookManifestTransformer struct {
+	name       string
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookManifestTransformer returns a WebhookManifestTransformer that calls the webhook at the
+// given URL. A default HTTP client is used if none is given.
+func NewWebhookManifestTransformer(name, url string, httpClient *http.Client) *WebhookManifestTransformer {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultManifestTransformWebhookTimeout}
+	}
+	return &WebhookManifestTransformer{
+		name:       name,
+		url:        url,
+		httpClient: httpClient,
+	}
+}
+
+// Name returns the name of the transformer.
+func (w *WebhookManifestTransformer) Name() string {
+	return w.name
+}
+
+// Transform calls the webhook to transform the manifest object.
+func (w *WebhookManifestTransformer) Transform(ctx context.Context, gvr *schema.GroupVersionResource, manifestObj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	reqBody, err := json.Marshal(&ManifestTransformWebhookRequest{
+		Group:    gvr.Group,
+		Version:  gvr.Version,
+		Resource: gvr.Resource,
+		Object:   manifestObj,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the webhook request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call the webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestTransformWebhookResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the webhook response: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return manifestObj, nil
+	case http.StatusOK:
+		transformed := &unstructured.Unstructured{}
+		if err := transformed.UnmarshalJSON(respBody); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the webhook response: %w", err)
+		}
+		return transformed, nil
+	default:
+		return nil, fmt.Errorf("webhook returned unexpected status code %d: %s", resp.StatusCode, string(respBody))
+	}
+}
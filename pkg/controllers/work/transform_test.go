@@ -0,0 +1,191 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package work
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	pvcGVR = schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}
+)
+
+// fakeManifestTransformer is a ManifestTransformer for testing purposes.
+type fakeManifestTransformer struct {
+	name        string
+	transformFn func(obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
+}
+
+func (f *fakeManifestTransformer) Name() string { return f.name }
+
+func (f *fakeManifestTransformer) Transform(_ context.Context, _ *schema.GroupVersionResource, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return f.transformFn(obj)
+}
+
+func pvcManifest(storageClassName string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "PersistentVolumeClaim",
+			"metadata": map[string]interface{}{
+				"name":      "data",
+				"namespace": "work",
+			},
+			"spec": map[string]interface{}{
+				"storageClassName": storageClassName,
+			},
+		},
+	}
+}
+
+func setStorageClassName(name string) func(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return func(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+		if err := unstructured.SetNestedField(obj.Object, name, "spec", "storageClassName"); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+}
+
+// TestTransformManifest tests the transformManifest method.
+func TestTransformManifest(t *testing.T) {
+	testCases := []struct {
+		name         string
+		transformers []ManifestTransformer
+		want         *unstructured.Unstructured
+		wantErr      bool
+	}{
+		{
+			name: "transformers run in order",
+			transformers: []ManifestTransformer{
+				&fakeManifestTransformer{name: "first", transformFn: setStorageClassName("premium")},
+				&fakeManifestTransformer{name: "second", transformFn: setStorageClassName("local-ssd")},
+			},
+			want: pvcManifest("local-ssd"),
+		},
+		{
+			name: "transformer fails",
+			transformers: []ManifestTransformer{
+				&fakeManifestTransformer{
+					name: "broken",
+					transformFn: func(_ *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						return nil, fmt.Errorf("cannot transform")
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "transformer changes the identity of the object",
+			transformers: []ManifestTransformer{
+				&fakeManifestTransformer{
+					name: "renamer",
+					transformFn: func(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+						obj.SetName("renamed")
+						return obj, nil
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &ApplyWorkReconciler{manifestTransformers: tc.transformers}
+			manifestObj := pvcManifest("standard")
+			got, err := r.transformManifest(context.Background(), &pvcGVR, manifestObj)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("transformManifest() = nil, want error")
+				}
+				if diff := cmp.Diff(pvcManifest("standard"), manifestObj); diff != "" {
+					t.Errorf("transformManifest() mutated the original manifest (-want, +got):\n%s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("transformManifest() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("transformManifest() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestWebhookManifestTransformer tests the Transform method of the WebhookManifestTransformer.
+func TestWebhookManifestTransformer(t *testing.T) {
+	testCases := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    *unstructured.Unstructured
+		wantErr bool
+	}{
+		{
+			name: "webhook transforms the object",
+			handler: func(w http.ResponseWriter, req *http.Request) {
+				transformReq := &ManifestTransformWebhookRequest{}
+				if err := json.NewDecoder(req.Body).Decode(transformReq); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if transformReq.Resource != pvcGVR.Resource {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				obj, _ := setStorageClassName("local-ssd")(transformReq.Object)
+				_ = json.NewEncoder(w).Encode(obj)
+			},
+			want: pvcManifest("local-ssd"),
+		},
+		{
+			name: "webhook requests no change",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			want: pvcManifest("standard"),
+		},
+		{
+			name: "webhook fails",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "internal error", http.StatusInternalServerError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+
+			transformer := NewWebhookManifestTransformer("webhook", server.URL, server.Client())
+			got, err := transformer.Transform(context.Background(), &pvcGVR, pvcManifest("standard"))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("Transform() = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Transform() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Transform() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/validation"
@@ -25,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
 	"go.goms.io/fleet/pkg/utils/resource"
 )
 
@@ -98,6 +100,10 @@ func (r *Reconciler) apply(
 	// Add the owner reference information.
 	setOwnerRef(manifestObjCopy, expectedAppliedWorkOwnerRef)
 
+	// Mark the object as protected from deletion, if applicable; this must be done before the
+	// last applied annotation is set, so that the mark is removed once the protection is lifted.
+	setDeletionProtectedAnnotation(manifestObjCopy, applyStrategy)
+
 	// If three-way merge patch is used, set the Fleet-specific last applied annotation.
 	// Note that this op might not complete due to the last applied annotation being too large;
 	// this is not recognized as an error and Fleet will switch to server-side apply instead.
@@ -400,6 +406,26 @@ func setManifestHashAnnotation(manifestObj *unstructured.Unstructured) error {
 	return nil
 }
 
+// setDeletionProtectedAnnotation sets the deletion protected annotation on a manifest to be applied,
+// if it is a critical resource (a namespace or a CustomResourceDefinition) and the apply strategy has
+// the CriticalResources deletion protection.
+func setDeletionProtectedAnnotation(manifestObj *unstructured.Unstructured, applyStrategy *fleetv1beta1.ApplyStrategy) {
+	if applyStrategy.DeletionProtection != fleetv1beta1.DeletionProtectionTypeCriticalResources {
+		return
+	}
+	gvk := manifestObj.GroupVersionKind()
+	if !utils.IsCriticalResource(gvk.Group, gvk.Kind) {
+		return
+	}
+
+	annotations := manifestObj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[fleetv1beta1.DeletionProtectedAnnotation] = strconv.FormatBool(true)
+	manifestObj.SetAnnotations(annotations)
+}
+
 // setOwnerRef sets the expected owner reference (reference to an AppliedWork object)
 // on a manifest to be applied.
 func setOwnerRef(obj *unstructured.Unstructured, expectedAppliedWorkOwnerRef *metav1.OwnerReference) {
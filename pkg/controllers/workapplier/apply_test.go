@@ -321,6 +321,53 @@ func TestSetManifestHashAnnotation(t *testing.T) {
 	}
 }
 
+// TestSetDeletionProtectedAnnotation tests the setDeletionProtectedAnnotation function.
+func TestSetDeletionProtectedAnnotation(t *testing.T) {
+	wantProtectedNS := ns.DeepCopy()
+	wantProtectedNS.Annotations = map[string]string{
+		fleetv1beta1.DeletionProtectedAnnotation: "true",
+	}
+
+	testCases := []struct {
+		name            string
+		manifestObj     *unstructured.Unstructured
+		applyStrategy   *fleetv1beta1.ApplyStrategy
+		wantManifestObj *unstructured.Unstructured
+	}{
+		{
+			name:        "namespace, protected",
+			manifestObj: toUnstructured(t, ns.DeepCopy()),
+			applyStrategy: &fleetv1beta1.ApplyStrategy{
+				DeletionProtection: fleetv1beta1.DeletionProtectionTypeCriticalResources,
+			},
+			wantManifestObj: toUnstructured(t, wantProtectedNS),
+		},
+		{
+			name:            "namespace, not protected",
+			manifestObj:     toUnstructured(t, ns.DeepCopy()),
+			applyStrategy:   &fleetv1beta1.ApplyStrategy{},
+			wantManifestObj: toUnstructured(t, ns.DeepCopy()),
+		},
+		{
+			name:        "deployment, protected",
+			manifestObj: toUnstructured(t, deploy.DeepCopy()),
+			applyStrategy: &fleetv1beta1.ApplyStrategy{
+				DeletionProtection: fleetv1beta1.DeletionProtectionTypeCriticalResources,
+			},
+			wantManifestObj: toUnstructured(t, deploy.DeepCopy()),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setDeletionProtectedAnnotation(tc.manifestObj, tc.applyStrategy)
+			if diff := cmp.Diff(tc.manifestObj, tc.wantManifestObj); diff != "" {
+				t.Errorf("manifest obj mismatches (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
 // TestGetFleetLastAppliedAnnotation tests the getFleetLastAppliedAnnotation function.
 func TestGetFleetLastAppliedAnnotation(t *testing.T) {
 	nsInMemberClusterObj1 := ns.DeepCopy()
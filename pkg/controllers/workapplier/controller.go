@@ -142,6 +142,13 @@ const (
 	ManifestProcessingApplyResultTypeFailedToRunDriftDetection      manifestProcessingAppliedResultType = "FailedToRunDriftDetection"
 	ManifestProcessingApplyResultTypeFoundDrifts                    manifestProcessingAppliedResultType = "FoundDrifts"
 	ManifestProcessingApplyResultTypeFailedToApply                  manifestProcessingAppliedResultType = "FailedToApply"
+	ManifestProcessingApplyResultTypeDeniedByAdmissionWebhook       manifestProcessingAppliedResultType = "DeniedByAdmissionWebhook"
+
+	// The result type and description for processing attempts that wait for an object of the same
+	// name in the member cluster to be fully deleted before the apply op; this is not a failure.
+	ManifestProcessingApplyResultTypeWaitingForObjDeletion manifestProcessingAppliedResultType = "WaitingForObjDeletion"
+
+	ManifestProcessingApplyResultTypeWaitingForObjDeletionDescription = "Manifest is waiting for an object of the same name in the member cluster to be fully deleted before it can be applied"
 
 	// The result type and description for partially successfully processing attempts.
 	ManifestProcessingApplyResultTypeAppliedWithFailedDriftDetection manifestProcessingAppliedResultType = "AppliedWithFailedDriftDetection"
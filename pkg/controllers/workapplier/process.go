@@ -110,6 +110,27 @@ func (r *Reconciler) processOneManifest(
 
 	// Perform the apply op.
 	appliedObj, err := r.apply(ctx, bundle.gvr, bundle.manifestObj, bundle.inMemberClusterObj, work.Spec.ApplyStrategy, expectedAppliedWorkOwnerRef)
+	if err != nil && bundle.inMemberClusterObj == nil && errors.IsAlreadyExists(err) {
+		// The object was not found earlier, but an object of the same name has appeared in the
+		// member cluster right before the creation; most likely the old object has not been fully
+		// removed yet. This is not registered as an apply failure; the reconciler will requeue
+		// the Work object and re-evaluate the object in the next attempt.
+		bundle.applyErr = fmt.Errorf("an object with the same name has been found in the member cluster when creating the object; waiting for the next attempt: %w", err)
+		bundle.applyResTyp = ManifestProcessingApplyResultTypeWaitingForObjDeletion
+		klog.V(2).InfoS("An object with the same name has been found in the member cluster when creating the object; skip the processing",
+			"manifestObj", manifestObjRef, "GVR", *bundle.gvr, "work", workRef)
+		return
+	}
+	if webhookName, message, denied := controller.ParseAdmissionWebhookDenial(err); denied {
+		// An admission webhook in the member cluster (e.g., a policy engine) has rejected the
+		// manifest; report the webhook details so that users can act on the rejection.
+		bundle.applyErr = fmt.Errorf("admission webhook %q on the member cluster denied the manifest: %s", webhookName, message)
+		bundle.applyResTyp = ManifestProcessingApplyResultTypeDeniedByAdmissionWebhook
+		klog.V(2).InfoS("An admission webhook has denied the manifest",
+			"work", klog.KObj(work), "GVR", *bundle.gvr, "manifestObj", klog.KObj(bundle.manifestObj),
+			"webhook", webhookName, "message", message)
+		return
+	}
 	if err != nil {
 		bundle.applyErr = fmt.Errorf("failed to apply the manifest: %w", err)
 		bundle.applyResTyp = ManifestProcessingApplyResultTypeFailedToApply
@@ -160,6 +181,22 @@ func (r *Reconciler) findInMemberClusterObjectFor(
 		Namespace(bundle.manifestObj.GetNamespace()).
 		Get(ctx, bundle.manifestObj.GetName(), metav1.GetOptions{})
 	switch {
+	case err == nil && inMemberClusterObj.GetDeletionTimestamp() != nil:
+		// An object with the same GVR + namespace + name combo has been found in the member
+		// cluster, but it has been marked for deletion (e.g., it has been pruned by Fleet as a
+		// left-over manifest, or it has been deleted by an external agent) and is waiting for
+		// its finalizers to clear.
+		//
+		// Fleet will not apply the manifest until the object is fully gone; patching an object
+		// that is being deleted has no lasting effect, and re-creating the object before the old
+		// one is removed will lead to name conflicts. The Work object will not be considered
+		// as available in this case, and the reconciler will requeue it for another attempt.
+		bundle.applyErr = fmt.Errorf("the corresponding object in the member cluster is being deleted; waiting for the deletion to complete before re-creating the object")
+		bundle.applyResTyp = ManifestProcessingApplyResultTypeWaitingForObjDeletion
+		klog.V(2).InfoS("The corresponding object for the manifest object in the member cluster is being deleted; skip the processing until the deletion completes",
+			"manifestObj", klog.KObj(bundle.manifestObj), "GVR", *bundle.gvr, "work", klog.KObj(work),
+			"inMemberClusterObj", klog.KObj(inMemberClusterObj), "finalizers", inMemberClusterObj.GetFinalizers())
+		return true
 	case err == nil:
 		// An object derived from the manifest object has been found in the member cluster.
 		klog.V(2).InfoS("Found the corresponding object for the manifest object in the member cluster",
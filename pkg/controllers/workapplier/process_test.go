@@ -1,12 +1,15 @@
 package workapplier
 
 import (
+	"context"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 )
@@ -97,3 +100,79 @@ func TestShouldInitiateTakeOverAttempt(t *testing.T) {
 		})
 	}
 }
+
+// TestFindInMemberClusterObjectFor tests the findInMemberClusterObjectFor function.
+func TestFindInMemberClusterObjectFor(t *testing.T) {
+	ctx := context.Background()
+	now := metav1.Now().Rfc3339Copy()
+	work := &fleetv1beta1.Work{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workName,
+			Namespace: memberReservedNSName,
+		},
+	}
+
+	testCases := []struct {
+		name                   string
+		inMemberClusterObj     *corev1.Namespace
+		wantShouldSkip         bool
+		wantInMemberClusterObj bool
+		wantApplyResTyp        manifestProcessingAppliedResultType
+	}{
+		{
+			name: "not found",
+		},
+		{
+			name: "found",
+			inMemberClusterObj: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nsName,
+				},
+			},
+			wantInMemberClusterObj: true,
+		},
+		{
+			name: "found, being deleted",
+			inMemberClusterObj: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              nsName,
+					DeletionTimestamp: &now,
+					Finalizers:        []string{"custom-deletion-blocker"},
+				},
+			},
+			wantShouldSkip:  true,
+			wantApplyResTyp: ManifestProcessingApplyResultTypeWaitingForObjDeletion,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fakeClient *fake.FakeDynamicClient
+			if tc.inMemberClusterObj != nil {
+				fakeClient = fake.NewSimpleDynamicClient(scheme.Scheme, tc.inMemberClusterObj)
+			} else {
+				fakeClient = fake.NewSimpleDynamicClient(scheme.Scheme)
+			}
+			r := &Reconciler{
+				spokeDynamicClient: fakeClient,
+			}
+
+			bundle := &manifestProcessingBundle{
+				manifestObj: nsUnstructured.DeepCopy(),
+				gvr:         &nsGVR,
+			}
+			if gotShouldSkip := r.findInMemberClusterObjectFor(ctx, bundle, work, appliedWorkOwnerRef); gotShouldSkip != tc.wantShouldSkip {
+				t.Errorf("findInMemberClusterObjectFor() = %t, want %t", gotShouldSkip, tc.wantShouldSkip)
+			}
+			if gotInMemberClusterObj := bundle.inMemberClusterObj != nil; gotInMemberClusterObj != tc.wantInMemberClusterObj {
+				t.Errorf("bundle.inMemberClusterObj set = %t, want %t", gotInMemberClusterObj, tc.wantInMemberClusterObj)
+			}
+			if bundle.applyResTyp != tc.wantApplyResTyp {
+				t.Errorf("bundle.applyResTyp = %s, want %s", bundle.applyResTyp, tc.wantApplyResTyp)
+			}
+			if gotErr := bundle.applyErr != nil; gotErr != tc.wantShouldSkip {
+				t.Errorf("bundle.applyErr = %v, want error %t", bundle.applyErr, tc.wantShouldSkip)
+			}
+		})
+	}
+}
@@ -252,6 +252,16 @@ func setManifestAppliedCondition(
 			Message:            ManifestProcessingApplyResultTypeAppliedWithFailedDriftDetectionDescription,
 			ObservedGeneration: inMemberClusterObjGeneration,
 		}
+	case ManifestProcessingApplyResultTypeWaitingForObjDeletion:
+		// An object of the same name in the member cluster is being deleted; the apply op
+		// will run after the deletion completes, and it has not failed.
+		appliedCond = &metav1.Condition{
+			Type:               fleetv1beta1.WorkConditionTypeApplied,
+			Status:             metav1.ConditionUnknown,
+			Reason:             string(ManifestProcessingApplyResultTypeWaitingForObjDeletion),
+			Message:            ManifestProcessingApplyResultTypeWaitingForObjDeletionDescription,
+			ObservedGeneration: inMemberClusterObjGeneration,
+		}
 	case ManifestProcessingApplyResultTypeNoApplyPerformed:
 		// ReportDiff mode is on and no apply op has been performed. In this case, Fleet
 		// will leave the Applied condition as it is (i.e., it might be unset, or has become
@@ -131,6 +131,68 @@ func TestRefreshWorkStatus(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "waiting for object deletion",
+			work: &fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       workName,
+					Namespace:  memberReservedNSName,
+					Generation: 1,
+				},
+			},
+			bundles: []*manifestProcessingBundle{
+				{
+					id: &fleetv1beta1.WorkResourceIdentifier{
+						Ordinal:   0,
+						Group:     "apps",
+						Version:   "v1",
+						Kind:      "Deployment",
+						Name:      deployName,
+						Namespace: nsName,
+						Resource:  "deployments",
+					},
+					applyResTyp:        ManifestProcessingApplyResultTypeWaitingForObjDeletion,
+					availabilityResTyp: ManifestProcessingAvailabilityResultTypeSkipped,
+					reportDiffResTyp:   ManifestProcessingReportDiffResultTypeNotEnabled,
+				},
+			},
+			wantWorkStatus: &fleetv1beta1.WorkStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               fleetv1beta1.WorkConditionTypeApplied,
+						Status:             metav1.ConditionFalse,
+						Reason:             notAllManifestsAppliedReason,
+						ObservedGeneration: 1,
+					},
+					{
+						Type:               fleetv1beta1.WorkConditionTypeAvailable,
+						Status:             metav1.ConditionFalse,
+						Reason:             notAllAppliedObjectsAvailableReason,
+						ObservedGeneration: 1,
+					},
+				},
+				ManifestConditions: []fleetv1beta1.ManifestCondition{
+					{
+						Identifier: fleetv1beta1.WorkResourceIdentifier{
+							Ordinal:   0,
+							Group:     "apps",
+							Version:   "v1",
+							Kind:      "Deployment",
+							Name:      deployName,
+							Namespace: nsName,
+							Resource:  "deployments",
+						},
+						Conditions: []metav1.Condition{
+							{
+								Type:   fleetv1beta1.WorkConditionTypeApplied,
+								Status: metav1.ConditionUnknown,
+								Reason: string(ManifestProcessingApplyResultTypeWaitingForObjDeletion),
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "mixed applied result",
 			work: &fleetv1beta1.Work{
@@ -16,7 +16,6 @@ import (
 	"time"
 
 	"go.uber.org/atomic"
-	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -45,11 +44,13 @@ import (
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/controllers/work"
+	"go.goms.io/fleet/pkg/metrics"
 	"go.goms.io/fleet/pkg/utils"
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/informer"
 	"go.goms.io/fleet/pkg/utils/labels"
+	"go.goms.io/fleet/pkg/utils/parallelizer"
 	"go.goms.io/fleet/pkg/utils/resource"
 )
 
@@ -62,6 +63,10 @@ var (
 	maxDiffedResourcePlacementLimit = 100
 
 	errResourceSnapshotNotFound = fmt.Errorf("the master resource snapshot is not found")
+
+	// errResourceSnapshotNotFullyCreated indicates that the works of the resource snapshots created so far are synced,
+	// but some resource snapshots in the same index group are still being created.
+	errResourceSnapshotNotFullyCreated = fmt.Errorf("not all the resource snapshots are created")
 )
 
 // Reconciler watches binding objects and generate work objects in the designated cluster namespace
@@ -75,6 +80,18 @@ type Reconciler struct {
 	// the informer contains the cache for all the resources we need.
 	// to check the resource scope
 	InformerManager informer.Manager
+	// Parallelizer runs the fan-out operations of the controller, e.g., syncing works, in parallel;
+	// a nil parallelizer uses the default number of workers.
+	Parallelizer *parallelizer.Parallerlizer
+	// ManifestLimits caps the manifests in a work; the manifests of a resource snapshot which do not fit
+	// in a single work are split into multiple works.
+	ManifestLimits resource.ManifestLimits
+}
+
+// workToUpsert is a work to create or update, along with the resource snapshot it is generated from.
+type workToUpsert struct {
+	work     *fleetv1beta1.Work
+	snapshot *fleetv1beta1.ClusterResourceSnapshot
 }
 
 // Reconcile triggers a single binding reconcile round.
@@ -187,6 +204,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req controllerruntime.Reques
 				Message:            fmt.Sprintf("Failed to apply the override rules on the resources: %s", errorMessage),
 				ObservedGeneration: resourceBinding.Generation,
 			})
+		} else if errors.Is(syncErr, errResourceSnapshotNotFullyCreated) {
+			resourceBinding.SetConditions(metav1.Condition{
+				Status:             metav1.ConditionFalse,
+				Type:               string(fleetv1beta1.ResourceBindingWorkSynchronized),
+				Reason:             condition.SyncWorkPendingReason,
+				Message:            fmt.Sprintf("In the processing of synchronizing the work to the latest: %s", errorMessage),
+				ObservedGeneration: resourceBinding.Generation,
+			})
 		} else {
 			resourceBinding.SetConditions(metav1.Condition{
 				Status:             metav1.ConditionFalse,
@@ -238,6 +263,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req controllerruntime.Reques
 		// This error can also happen if the user uses a customized rollout controller that does not share the same informer cache with this controller.
 		return controllerruntime.Result{Requeue: true}, nil
 	}
+	if errors.Is(syncErr, errResourceSnapshotNotFullyCreated) {
+		// The rest of the resource snapshots are being created by the placement controller; retry with backoff
+		// to pick them up.
+		klog.V(2).InfoS("Requeue the resource binding until all the resource snapshots are created", "resourceBinding", bindingRef)
+		return controllerruntime.Result{Requeue: true}, nil
+	}
 	// requeue if we failed to sync the work
 	// If we update the works, their status will be changed and will be detected by the watch event.
 	return controllerruntime.Result{}, syncErr
@@ -287,35 +318,60 @@ func (r *Reconciler) updateBindingStatusWithRetry(ctx context.Context, resourceB
 }
 
 // handleDelete handle a deleting binding
+//
+// The work finalizer is only removed from the binding after all of its works are gone, i.e., the work
+// controller has confirmed that the resources they carry have been removed from the member cluster;
+// this prevents the binding from being deleted (and its cluster from being deemed vacant) while the
+// member side cleanup is still in progress.
 func (r *Reconciler) handleDelete(ctx context.Context, resourceBinding *fleetv1beta1.ClusterResourceBinding) (controllerruntime.Result, error) {
 	klog.V(4).InfoS("Start to handle deleting resource binding", "resourceBinding", klog.KObj(resourceBinding))
-	// list all the corresponding works if exist
-	works, err := r.listAllWorksAssociated(ctx, resourceBinding)
+	// list all the corresponding works if exist, including the ones that are being deleted
+	works, err := r.listAllWorks(ctx, resourceBinding)
 	if err != nil {
 		return controllerruntime.Result{}, err
 	}
 
-	// delete all the listed works
+	// delete all the listed works that are not being deleted yet
 	//
 	// TO-DO: this controller should be able to garbage collect all works automatically via
 	// background/foreground cascade deletion. This may render the finalizer unnecessary.
-	for workName := range works {
-		work := works[workName]
+	for i := range works {
+		work := &works[i]
+		if work.DeletionTimestamp != nil {
+			continue
+		}
 		if err := r.Client.Delete(ctx, work); err != nil && !apierrors.IsNotFound(err) {
 			return controllerruntime.Result{}, controller.NewAPIServerError(false, err)
 		}
 	}
 
-	// remove the work finalizer on the binding if all the work objects are deleted
+	// remove the work finalizer on the binding if all the work objects are gone
 	if len(works) == 0 {
 		controllerutil.RemoveFinalizer(resourceBinding, fleetv1beta1.WorkFinalizer)
 		if err = r.Client.Update(ctx, resourceBinding); err != nil {
 			klog.ErrorS(err, "Failed to remove the work finalizer from resource binding", "resourceBinding", klog.KObj(resourceBinding))
 			return controllerruntime.Result{}, controller.NewUpdateIgnoreConflictError(err)
 		}
+		metrics.BindingCleanupPendingSeconds.DeleteLabelValues(resourceBinding.Name)
 		klog.V(2).InfoS("The resource binding is deleted", "resourceBinding", klog.KObj(resourceBinding))
 		return controllerruntime.Result{}, nil
 	}
+
+	metrics.BindingCleanupPendingSeconds.WithLabelValues(resourceBinding.Name).Set(time.Since(resourceBinding.DeletionTimestamp.Time).Seconds())
+	cleanupCond := metav1.Condition{
+		Type:               string(fleetv1beta1.ResourceBindingWorkCleanedUp),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: resourceBinding.Generation,
+		Reason:             condition.WorkCleanupInProgressReason,
+		Message:            "Waiting for the works to be removed from the target cluster",
+	}
+	if !condition.EqualCondition(resourceBinding.GetCondition(cleanupCond.Type), &cleanupCond) {
+		resourceBinding.SetConditions(cleanupCond)
+		if err := r.Client.Status().Update(ctx, resourceBinding); err != nil {
+			klog.ErrorS(err, "Failed to update the work cleanup condition of resource binding", "resourceBinding", klog.KObj(resourceBinding))
+			return controllerruntime.Result{}, controller.NewUpdateIgnoreConflictError(err)
+		}
+	}
 	klog.V(2).InfoS("The resource binding still has undeleted work", "resourceBinding", klog.KObj(resourceBinding),
 		"number of associated work", len(works))
 	// we watch the work objects deleting events, so we can afford to wait a bit longer here as a fallback case.
@@ -354,19 +410,28 @@ func (r *Reconciler) ensureFinalizer(ctx context.Context, resourceBinding client
 	return nil
 }
 
-// listAllWorksAssociated finds all the live work objects that are associated with this binding.
-func (r *Reconciler) listAllWorksAssociated(ctx context.Context, resourceBinding *fleetv1beta1.ClusterResourceBinding) (map[string]*fleetv1beta1.Work, error) {
+// listAllWorks finds all the work objects that are associated with this binding, including the ones that are being deleted.
+func (r *Reconciler) listAllWorks(ctx context.Context, resourceBinding *fleetv1beta1.ClusterResourceBinding) ([]fleetv1beta1.Work, error) {
 	namespaceMatcher := client.InNamespace(fmt.Sprintf(utils.NamespaceNameFormat, resourceBinding.Spec.TargetCluster))
 	parentBindingLabelMatcher := client.MatchingLabels{
 		fleetv1beta1.ParentBindingLabel: resourceBinding.Name,
 	}
-	currentWork := make(map[string]*fleetv1beta1.Work)
 	workList := &fleetv1beta1.WorkList{}
 	if err := r.Client.List(ctx, workList, parentBindingLabelMatcher, namespaceMatcher); err != nil {
 		klog.ErrorS(err, "Failed to list all the work associated with the resourceSnapshot", "resourceBinding", klog.KObj(resourceBinding))
 		return nil, controller.NewAPIServerError(true, err)
 	}
-	for _, work := range workList.Items {
+	return workList.Items, nil
+}
+
+// listAllWorksAssociated finds all the live work objects that are associated with this binding.
+func (r *Reconciler) listAllWorksAssociated(ctx context.Context, resourceBinding *fleetv1beta1.ClusterResourceBinding) (map[string]*fleetv1beta1.Work, error) {
+	works, err := r.listAllWorks(ctx, resourceBinding)
+	if err != nil {
+		return nil, err
+	}
+	currentWork := make(map[string]*fleetv1beta1.Work)
+	for _, work := range works {
 		if work.DeletionTimestamp == nil {
 			currentWork[work.Name] = work.DeepCopy()
 		}
@@ -393,8 +458,9 @@ func (r *Reconciler) syncAllWork(ctx context.Context, resourceBinding *fleetv1be
 	}
 	// TODO: check all work synced first before fetching the snapshots after we put ParentResourceOverrideSnapshotHashAnnotation and ParentClusterResourceOverrideSnapshotHashAnnotation in all the work objects
 
-	// Gather all the resource resourceSnapshots
-	resourceSnapshots, err := r.fetchAllResourceSnapshots(ctx, resourceBinding)
+	// Gather the resource snapshots created so far; for a large selection, the snapshots of the same index group
+	// are created one by one, and we sync the works of those already created instead of waiting for all of them.
+	resourceSnapshots, snapshotCount, err := r.fetchCreatedResourceSnapshots(ctx, resourceBinding)
 	if err != nil {
 		if errors.Is(err, errResourceSnapshotNotFound) {
 			// the resourceIndex is deleted but the works might still be up to date with the binding.
@@ -404,9 +470,9 @@ func (r *Reconciler) syncAllWork(ctx context.Context, resourceBinding *fleetv1be
 			}
 			return false, false, controller.NewUserError(err)
 		}
-		// TODO(RZ): handle errResourceNotFullyCreated error so we don't need to wait for all the snapshots to be created
 		return false, false, err
 	}
+	missingSubindexes := findMissingSubindexes(resourceSnapshots, snapshotCount)
 
 	croMap, err := r.fetchClusterResourceOverrideSnapshots(ctx, resourceBinding)
 	if err != nil {
@@ -418,9 +484,9 @@ func (r *Reconciler) syncAllWork(ctx context.Context, resourceBinding *fleetv1be
 		return false, false, err
 	}
 
-	// issue all the create/update requests for the corresponding works for each snapshot in parallel
 	activeWork := make(map[string]*fleetv1beta1.Work, len(resourceSnapshots))
-	errs, cctx := errgroup.WithContext(ctx)
+	// the works to create/update, along with the resource snapshots they are generated from
+	var toUpsert []workToUpsert
 	// generate work objects for each resource snapshot
 	for i := range resourceSnapshots {
 		snapshot := resourceSnapshots[i]
@@ -449,6 +515,10 @@ func (r *Reconciler) syncAllWork(ctx context.Context, resourceBinding *fleetv1be
 				klog.ErrorS(unMarshallErr, "work has invalid content", "snapshot", klog.KObj(snapshot), "selectedResource", selectedResource.Raw)
 				return true, false, controller.NewUnexpectedBehaviorError(unMarshallErr)
 			}
+			if err := checkManifestSize(r.ManifestLimits, &uResource, len(selectedResource.Raw)); err != nil {
+				klog.ErrorS(err, "The resource is too large to be placed", "snapshot", klog.KObj(snapshot), "resourceBinding", resourceBindingRef)
+				return true, false, err
+			}
 			if uResource.GetObjectKind().GroupVersionKind() == utils.ConfigMapGVK &&
 				len(uResource.GetAnnotations()[fleetv1beta1.EnvelopeConfigMapAnnotation]) != 0 {
 				// get a work object for the enveloped configMap
@@ -468,49 +538,69 @@ func (r *Reconciler) syncAllWork(ctx context.Context, resourceBinding *fleetv1be
 		// generate a work object for the manifests even if there is nothing to place
 		// to allow CRP to collect the status of the placement
 		// TODO (RZ): revisit to see if we need this hack
-		work := generateSnapshotWorkObj(workNamePrefix, resourceBinding, snapshot, simpleManifests, resourceOverrideSnapshotHash, clusterResourceOverrideSnapshotHash)
-		activeWork[work.Name] = work
-		newWork = append(newWork, work)
+		parts := splitManifests(r.ManifestLimits, simpleManifests)
+		if len(parts) > 1 {
+			klog.V(2).InfoS("Split the manifests of the snapshot into multiple works", "snapshot", klog.KObj(snapshot), "resourceBinding", resourceBindingRef, "numOfWorks", len(parts))
+		}
+		for part := range parts {
+			work := generateSnapshotWorkObj(workNameOfPart(workNamePrefix, part), resourceBinding, snapshot, parts[part], resourceOverrideSnapshotHash, clusterResourceOverrideSnapshotHash)
+			activeWork[work.Name] = work
+			newWork = append(newWork, work)
+		}
 
-		// issue all the create/update requests for the corresponding works for each snapshot in parallel
 		for ni := range newWork {
-			w := newWork[ni]
-			errs.Go(func() error {
-				updated, err := r.upsertWork(cctx, w, existingWorks[w.Name].DeepCopy(), snapshot)
-				if err != nil {
-					return err
-				}
-				if updated {
-					updateAny.Store(true)
-				}
-				return nil
-			})
+			toUpsert = append(toUpsert, workToUpsert{work: newWork[ni], snapshot: snapshot})
 		}
 	}
 
-	//  delete the works that are not associated with any resource snapshot
+	// find the works that are not associated with any resource snapshot
+	var toDelete []*fleetv1beta1.Work
 	for i := range existingWorks {
-		work := existingWorks[i]
-		if _, exist := activeWork[work.Name]; exist {
-			continue
+		if len(missingSubindexes) > 0 {
+			// The works of the resource snapshots which are still being created are not known yet; keep the
+			// existing works until all the resource snapshots are created.
+			break
 		}
-		errs.Go(func() error {
-			if err := r.Client.Delete(ctx, work); err != nil {
-				if !apierrors.IsNotFound(err) {
-					klog.ErrorS(err, "Failed to delete the no longer needed work", "work", klog.KObj(work))
-					return controller.NewAPIServerError(false, err)
-				}
+		if _, exist := activeWork[existingWorks[i].Name]; !exist {
+			toDelete = append(toDelete, existingWorks[i])
+		}
+	}
+
+	// issue all the create/update requests for the corresponding works for each snapshot, and the delete requests
+	// for the works that are no longer needed, in parallel
+	doWork := func(cctx context.Context, piece int) error {
+		if piece < len(toUpsert) {
+			w := toUpsert[piece]
+			updated, err := r.upsertWork(cctx, w.work, existingWorks[w.work.Name].DeepCopy(), w.snapshot)
+			if err != nil {
+				return err
+			}
+			if updated {
+				updateAny.Store(true)
 			}
-			klog.V(2).InfoS("Deleted the work that is not associated with any resource snapshot", "work", klog.KObj(work))
-			updateAny.Store(true)
 			return nil
-		})
+		}
+		work := toDelete[piece-len(toUpsert)]
+		if err := r.Client.Delete(ctx, work); err != nil {
+			if !apierrors.IsNotFound(err) {
+				klog.ErrorS(err, "Failed to delete the no longer needed work", "work", klog.KObj(work))
+				return controller.NewAPIServerError(false, err)
+			}
+		}
+		klog.V(2).InfoS("Deleted the work that is not associated with any resource snapshot", "work", klog.KObj(work))
+		updateAny.Store(true)
+		return nil
 	}
-
 	// wait for all the create/update/delete requests to finish
-	if updateErr := errs.Wait(); updateErr != nil {
+	if updateErr := r.Parallelizer.ParallelizeUntilError(ctx, len(toUpsert)+len(toDelete), doWork, "syncAllWork"); updateErr != nil {
 		return true, false, updateErr
 	}
+	if len(missingSubindexes) > 0 {
+		klog.V(2).InfoS("Synced the works of the resource snapshots created so far", "resourceBinding", resourceBindingRef,
+			"createdSnapshots", len(resourceSnapshots), "totalSnapshots", snapshotCount, "missingSubindexes", missingSubindexes)
+		return true, updateAny.Load(), fmt.Errorf("%w: synchronized the works of %d out of %d resource snapshots, the resource snapshots with sub-index %v are still being created",
+			errResourceSnapshotNotFullyCreated, len(resourceSnapshots), snapshotCount, missingSubindexes)
+	}
 	klog.V(2).InfoS("Successfully synced all the work associated with the resourceBinding", "updateAny", updateAny.Load(), "resourceBinding", resourceBindingRef)
 	return true, updateAny.Load(), nil
 }
@@ -541,20 +631,40 @@ func areAllWorkSynced(existingWorks map[string]*fleetv1beta1.Work, resourceBindi
 	return true
 }
 
-// fetchAllResourceSnapshots gathers all the resource snapshots for the resource binding.
-func (r *Reconciler) fetchAllResourceSnapshots(ctx context.Context, resourceBinding *fleetv1beta1.ClusterResourceBinding) (map[string]*fleetv1beta1.ClusterResourceSnapshot, error) {
+// fetchCreatedResourceSnapshots gathers the resource snapshots created so far for the resource binding, along with the
+// total number of the resource snapshots in the index group.
+func (r *Reconciler) fetchCreatedResourceSnapshots(ctx context.Context, resourceBinding *fleetv1beta1.ClusterResourceBinding) (map[string]*fleetv1beta1.ClusterResourceSnapshot, int, error) {
 	// fetch the master snapshot first
 	masterResourceSnapshot := fleetv1beta1.ClusterResourceSnapshot{}
 	if err := r.Client.Get(ctx, client.ObjectKey{Name: resourceBinding.Spec.ResourceSnapshotName}, &masterResourceSnapshot); err != nil {
 		if apierrors.IsNotFound(err) {
 			klog.V(2).InfoS("The master resource snapshot is deleted", "resourceBinding", klog.KObj(resourceBinding), "resourceSnapshotName", resourceBinding.Spec.ResourceSnapshotName)
-			return nil, errResourceSnapshotNotFound
+			return nil, 0, errResourceSnapshotNotFound
 		}
 		klog.ErrorS(err, "Failed to get the resource snapshot from resource masterResourceSnapshot",
 			"resourceBinding", klog.KObj(resourceBinding), "masterResourceSnapshot", resourceBinding.Spec.ResourceSnapshotName)
-		return nil, controller.NewAPIServerError(true, err)
+		return nil, 0, controller.NewAPIServerError(true, err)
 	}
-	return controller.FetchAllClusterResourceSnapshots(ctx, r.Client, resourceBinding.Labels[fleetv1beta1.CRPTrackingLabel], &masterResourceSnapshot)
+	return controller.FetchCreatedClusterResourceSnapshots(ctx, r.Client, resourceBinding.Labels[fleetv1beta1.CRPTrackingLabel], &masterResourceSnapshot)
+}
+
+// findMissingSubindexes returns the sorted sub-indexes of the resource snapshots in the index group which have not been
+// created yet, given the created resource snapshots and the total number of the resource snapshots in the group.
+func findMissingSubindexes(resourceSnapshots map[string]*fleetv1beta1.ClusterResourceSnapshot, snapshotCount int) []int {
+	// The master snapshot has no sub-index; the others have the sub-indexes from 0 to snapshotCount-2.
+	created := make(map[string]bool, len(resourceSnapshots))
+	for _, snapshot := range resourceSnapshots {
+		if subIndex, exist := snapshot.Annotations[fleetv1beta1.SubindexOfResourceSnapshotAnnotation]; exist {
+			created[subIndex] = true
+		}
+	}
+	var missing []int
+	for i := 0; i < snapshotCount-1; i++ {
+		if !created[strconv.Itoa(i)] {
+			missing = append(missing, i)
+		}
+	}
+	return missing
 }
 
 // getConfigMapEnvelopWorkObj first try to locate a work object for the corresponding envelopObj of type configMap.
@@ -568,6 +678,11 @@ func (r *Reconciler) getConfigMapEnvelopWorkObj(ctx context.Context, workNamePre
 			"resourceBinding", klog.KObj(resourceBinding), "configMapWrapper", klog.KObj(envelopeObj))
 		return nil, controller.NewUserError(err)
 	}
+	if err := checkEnvelopeWorkSize(r.ManifestLimits, envelopeObj, manifest); err != nil {
+		klog.ErrorS(err, "The enveloped resources are too large to be placed", "snapshot", klog.KObj(resourceSnapshot),
+			"resourceBinding", klog.KObj(resourceBinding), "configMapWrapper", klog.KObj(envelopeObj))
+		return nil, err
+	}
 	klog.V(2).InfoS("Successfully extract the enveloped resources from the configMap", "numOfResources", len(manifest),
 		"snapshot", klog.KObj(resourceSnapshot), "resourceBinding", klog.KObj(resourceBinding), "configMapWrapper", klog.KObj(envelopeObj))
 
@@ -985,6 +1100,8 @@ func extractFailedResourcePlacementsFromWork(work *fleetv1beta1.Work) []fleetv1b
 					"version", manifestCondition.Identifier.Version, "kind", manifestCondition.Identifier.Kind)
 			}
 			failedManifest.Condition = *appliedCond
+			failedManifest.ApplyFailureCount = manifestCondition.ApplyFailureCount
+			failedManifest.ApplyErrorCategory = manifestCondition.LastApplyErrorCategory
 			res = append(res, failedManifest)
 			continue //jump to the next manifest
 		}
@@ -8,6 +8,8 @@ Licensed under the MIT license.
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
@@ -27,6 +29,7 @@ import (
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/controllers/work"
+	"go.goms.io/fleet/pkg/metrics"
 	"go.goms.io/fleet/pkg/utils"
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/controller"
@@ -147,6 +150,60 @@ func TestGetWorkNamePrefixFromSnapshotName(t *testing.T) {
 	}
 }
 
+func TestFindMissingSubindexes(t *testing.T) {
+	subIndexSnapshot := func(subIndex int) *fleetv1beta1.ClusterResourceSnapshot {
+		return &fleetv1beta1.ClusterResourceSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf(fleetv1beta1.ResourceSnapshotNameWithSubindexFmt, "placement", 1, subIndex),
+				Annotations: map[string]string{
+					fleetv1beta1.SubindexOfResourceSnapshotAnnotation: strconv.Itoa(subIndex),
+				},
+			},
+		}
+	}
+	master := &fleetv1beta1.ClusterResourceSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf(fleetv1beta1.ResourceSnapshotNameFmt, "placement", 1),
+		},
+	}
+	tests := map[string]struct {
+		resourceSnapshots []*fleetv1beta1.ClusterResourceSnapshot
+		snapshotCount     int
+		want              []int
+	}{
+		"only the master snapshot in the group": {
+			resourceSnapshots: []*fleetv1beta1.ClusterResourceSnapshot{master},
+			snapshotCount:     1,
+		},
+		"all the snapshots are created": {
+			resourceSnapshots: []*fleetv1beta1.ClusterResourceSnapshot{master, subIndexSnapshot(0), subIndexSnapshot(1)},
+			snapshotCount:     3,
+		},
+		"only the master snapshot is created": {
+			resourceSnapshots: []*fleetv1beta1.ClusterResourceSnapshot{master},
+			snapshotCount:     3,
+			want:              []int{0, 1},
+		},
+		"some of the sub-index snapshots are created": {
+			resourceSnapshots: []*fleetv1beta1.ClusterResourceSnapshot{master, subIndexSnapshot(0), subIndexSnapshot(2)},
+			snapshotCount:     5,
+			want:              []int{1, 3},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			snapshots := make(map[string]*fleetv1beta1.ClusterResourceSnapshot, len(tt.resourceSnapshots))
+			for _, snapshot := range tt.resourceSnapshots {
+				snapshots[snapshot.Name] = snapshot
+			}
+			got := findMissingSubindexes(snapshots, tt.snapshotCount)
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("findMissingSubindexes() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestUpsertWork(t *testing.T) {
 	workName := "work"
 	namespace := "default"
@@ -1906,6 +1963,60 @@ func TestExtractFailedResourcePlacementsFromWork(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "apply is false with the apply failures",
+			work: fleetv1beta1.Work{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: workGeneration,
+				},
+				Status: fleetv1beta1.WorkStatus{
+					ManifestConditions: []fleetv1beta1.ManifestCondition{
+						{
+							Identifier: fleetv1beta1.WorkResourceIdentifier{
+								Ordinal:   0,
+								Group:     "",
+								Version:   "v1",
+								Kind:      "ConfigMap",
+								Name:      "config-name",
+								Namespace: "config-namespace",
+							},
+							Conditions: []metav1.Condition{
+								{
+									Type:   fleetv1beta1.WorkConditionTypeApplied,
+									Status: metav1.ConditionFalse,
+								},
+							},
+							ApplyFailureCount:      3,
+							LastApplyErrorCategory: fleetv1beta1.ApplyErrorCategoryRBAC,
+						},
+					},
+					Conditions: []metav1.Condition{
+						{
+							Type:               fleetv1beta1.WorkConditionTypeApplied,
+							Status:             metav1.ConditionFalse,
+							ObservedGeneration: workGeneration,
+						},
+					},
+				},
+			},
+			want: []fleetv1beta1.FailedResourcePlacement{
+				{
+					ResourceIdentifier: fleetv1beta1.ResourceIdentifier{
+						Group:     "",
+						Version:   "v1",
+						Kind:      "ConfigMap",
+						Name:      "config-name",
+						Namespace: "config-namespace",
+					},
+					Condition: metav1.Condition{
+						Type:   fleetv1beta1.WorkConditionTypeApplied,
+						Status: metav1.ConditionFalse,
+					},
+					ApplyFailureCount:  3,
+					ApplyErrorCategory: fleetv1beta1.ApplyErrorCategoryRBAC,
+				},
+			},
+		},
 		{
 			name: "apply is false for enveloped object",
 			work: fleetv1beta1.Work{
@@ -2933,3 +3044,121 @@ func (s *conflictStatusWriter) Update(ctx context.Context, obj client.Object, _
 	}
 	return s.StatusWriter.Update(ctx, obj)
 }
+
+func TestHandleDelete(t *testing.T) {
+	deletionTime := metav1.NewTime(time.Now().Add(-time.Minute))
+	newBinding := func(name string) *fleetv1beta1.ClusterResourceBinding {
+		return &fleetv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Generation:        2,
+				DeletionTimestamp: &deletionTime,
+				Finalizers:        []string{fleetv1beta1.WorkFinalizer},
+			},
+			Spec: fleetv1beta1.ResourceBindingSpec{
+				State:         fleetv1beta1.BindingStateUnscheduled,
+				TargetCluster: "cluster-1",
+			},
+		}
+	}
+	newWork := func(name, bindingName string, deleting bool) *fleetv1beta1.Work {
+		w := &fleetv1beta1.Work{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "fleet-member-cluster-1",
+				Labels: map[string]string{
+					fleetv1beta1.ParentBindingLabel: bindingName,
+				},
+			},
+		}
+		if deleting {
+			// The work controller keeps the work until the member cluster confirms the cleanup.
+			w.DeletionTimestamp = &deletionTime
+			w.Finalizers = []string{fleetv1beta1.WorkFinalizer}
+		}
+		return w
+	}
+	wantCleanupCond := metav1.Condition{
+		Type:               string(fleetv1beta1.ResourceBindingWorkCleanedUp),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: 2,
+		Reason:             condition.WorkCleanupInProgressReason,
+	}
+
+	tests := []struct {
+		name        string
+		binding     *fleetv1beta1.ClusterResourceBinding
+		works       []client.Object
+		wantDeleted bool
+		wantRequeue bool
+	}{
+		{
+			name:        "no works left",
+			binding:     newBinding("binding-no-work"),
+			works:       []client.Object{newWork("other-work", "other-binding", false)},
+			wantDeleted: true,
+		},
+		{
+			name:        "live works are deleted first",
+			binding:     newBinding("binding-live-work"),
+			works:       []client.Object{newWork("work-1", "binding-live-work", false)},
+			wantRequeue: true,
+		},
+		{
+			name:        "works are still being removed from the member cluster",
+			binding:     newBinding("binding-deleting-work"),
+			works:       []client.Object{newWork("work-1", "binding-deleting-work", true), newWork("work-2", "binding-deleting-work", false)},
+			wantRequeue: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := append([]client.Object{tt.binding}, tt.works...)
+			fakeClient := fake.NewClientBuilder().
+				WithStatusSubresource(objects...).
+				WithScheme(serviceScheme(t)).
+				WithObjects(objects...).
+				Build()
+			reconciler := &Reconciler{
+				Client:   fakeClient,
+				recorder: record.NewFakeRecorder(10),
+			}
+			res, err := reconciler.handleDelete(ctx, tt.binding.DeepCopy())
+			if err != nil {
+				t.Fatalf("handleDelete() = %v, want nil", err)
+			}
+			if gotRequeue := res.RequeueAfter > 0; gotRequeue != tt.wantRequeue {
+				t.Errorf("handleDelete() requeue = %v, want %v", gotRequeue, tt.wantRequeue)
+			}
+			// The series of a binding is only kept while its cleanup is pending.
+			if gotPending := metrics.BindingCleanupPendingSeconds.DeleteLabelValues(tt.binding.Name); gotPending != tt.wantRequeue {
+				t.Errorf("binding cleanup pending metric reported = %v, want %v", gotPending, tt.wantRequeue)
+			}
+
+			var binding fleetv1beta1.ClusterResourceBinding
+			err = fakeClient.Get(ctx, client.ObjectKeyFromObject(tt.binding), &binding)
+			if tt.wantDeleted {
+				if !k8serrors.IsNotFound(err) {
+					t.Fatalf("Get(binding) = %v, want not found", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get(binding) = %v, want nil", err)
+			}
+			if diff := cmp.Diff([]metav1.Condition{wantCleanupCond}, binding.Status.Conditions, utils.IgnoreConditionLTTAndMessageFields); diff != "" {
+				t.Errorf("binding conditions mismatch (-want, +got):\n%s", diff)
+			}
+			var works fleetv1beta1.WorkList
+			if err := fakeClient.List(ctx, &works, client.MatchingLabels{fleetv1beta1.ParentBindingLabel: tt.binding.Name}); err != nil {
+				t.Fatalf("List(works) = %v, want nil", err)
+			}
+			for _, w := range works.Items {
+				if w.DeletionTimestamp == nil {
+					t.Errorf("work %s is not deleted", w.Name)
+				}
+			}
+		})
+	}
+}
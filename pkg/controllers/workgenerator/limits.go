@@ -0,0 +1,82 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workgenerator
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/resource"
+)
+
+// describeManifest returns a short description of a manifest for the messages, e.g., "Deployment app/web".
+func describeManifest(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s %s", obj.GetKind(), types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()})
+}
+
+// checkManifestSize returns a user error if a manifest is too large to be placed in any work.
+func checkManifestSize(limits resource.ManifestLimits, obj *unstructured.Unstructured, size int) error {
+	if !limits.ManifestTooLarge(size) {
+		return nil
+	}
+	return controller.NewUserError(fmt.Errorf("the manifest of %s is %d bytes, which exceeds the limit of %d bytes",
+		describeManifest(obj), size, limits.MaxSizeOfManifest()))
+}
+
+// checkEnvelopeWorkSize returns a user error if the manifests enveloped in a configMap do not fit in a single
+// work; the manifests of an envelope are always placed in the same work.
+func checkEnvelopeWorkSize(limits resource.ManifestLimits, envelopeObj *unstructured.Unstructured, manifests []fleetv1beta1.Manifest) error {
+	totalSize := 0
+	for i := range manifests {
+		size := len(manifests[i].Raw)
+		if limits.ManifestTooLarge(size) {
+			var obj unstructured.Unstructured
+			if err := obj.UnmarshalJSON(manifests[i].Raw); err != nil {
+				return controller.NewUserError(fmt.Errorf("the configMap %s has invalid content: %w", describeManifest(envelopeObj), err))
+			}
+			return checkManifestSize(limits, &obj, size)
+		}
+		totalSize += size
+	}
+	if limits.WorkTooLarge(totalSize, len(manifests)) {
+		return controller.NewUserError(fmt.Errorf("the %d manifests (%d bytes in total) enveloped in %s do not fit in a single work, whose limits are %d manifests and %d bytes (0 means no limit)",
+			len(manifests), totalSize, describeManifest(envelopeObj), limits.MaxManifestsPerWork, limits.MaxWorkSize))
+	}
+	return nil
+}
+
+// splitManifests splits the manifests into parts in order, each of which fits in a single work per the limits;
+// it assumes that every manifest fits in a work on its own. It always returns at least one part, which can be
+// empty, as a work is generated for a resource snapshot even if there is nothing to place.
+func splitManifests(limits resource.ManifestLimits, manifests []fleetv1beta1.Manifest) [][]fleetv1beta1.Manifest {
+	var parts [][]fleetv1beta1.Manifest
+	var current []fleetv1beta1.Manifest
+	currentSize := 0
+	for i := range manifests {
+		size := len(manifests[i].Raw)
+		if len(current) > 0 && limits.WorkTooLarge(currentSize+size, len(current)+1) {
+			parts = append(parts, current)
+			current = nil
+			currentSize = 0
+		}
+		current = append(current, manifests[i])
+		currentSize += size
+	}
+	return append(parts, current)
+}
+
+// workNameOfPart returns the name of the work which holds the given part of the manifests generated with a
+// resource snapshot; the first part keeps the name of the work as if the manifests were not split.
+func workNameOfPart(workNamePrefix string, part int) string {
+	if part == 0 {
+		return workNamePrefix
+	}
+	return fmt.Sprintf(fleetv1beta1.WorkNameWithPartFmt, workNamePrefix, part)
+}
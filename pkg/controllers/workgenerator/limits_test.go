@@ -0,0 +1,150 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package workgenerator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/resource"
+)
+
+// manifestOfSize returns a ConfigMap manifest whose raw content is exactly of the given size.
+func manifestOfSize(t *testing.T, name string, size int) fleetv1beta1.Manifest {
+	raw := fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"%s","namespace":"app"},"data":{"k":""}}`, name)
+	if len(raw) > size {
+		t.Fatalf("manifest %s cannot be smaller than %d bytes", name, len(raw))
+	}
+	raw = strings.Replace(raw, `"k":""`, fmt.Sprintf(`"k":"%s"`, strings.Repeat("x", size-len(raw))), 1)
+	return fleetv1beta1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(raw)}}
+}
+
+func manifestNames(t *testing.T, parts [][]fleetv1beta1.Manifest) [][]string {
+	names := make([][]string, 0, len(parts))
+	for _, part := range parts {
+		partNames := []string{}
+		for i := range part {
+			var obj unstructured.Unstructured
+			if err := obj.UnmarshalJSON(part[i].Raw); err != nil {
+				t.Fatalf("failed to unmarshal the manifest: %v", err)
+			}
+			partNames = append(partNames, obj.GetName())
+		}
+		names = append(names, partNames)
+	}
+	return names
+}
+
+func TestSplitManifests(t *testing.T) {
+	testCases := []struct {
+		name      string
+		limits    resource.ManifestLimits
+		manifests []fleetv1beta1.Manifest
+		want      [][]string
+	}{
+		{
+			name: "no manifest",
+			want: [][]string{{}},
+		},
+		{
+			name: "no limit",
+			manifests: []fleetv1beta1.Manifest{
+				manifestOfSize(t, "a", 200), manifestOfSize(t, "b", 200), manifestOfSize(t, "c", 200),
+			},
+			want: [][]string{{"a", "b", "c"}},
+		},
+		{
+			name:   "split by size",
+			limits: resource.ManifestLimits{MaxWorkSize: 450},
+			manifests: []fleetv1beta1.Manifest{
+				manifestOfSize(t, "a", 200), manifestOfSize(t, "b", 200), manifestOfSize(t, "c", 200), manifestOfSize(t, "d", 450),
+			},
+			want: [][]string{{"a", "b"}, {"c"}, {"d"}},
+		},
+		{
+			name:   "split by count",
+			limits: resource.ManifestLimits{MaxManifestsPerWork: 2},
+			manifests: []fleetv1beta1.Manifest{
+				manifestOfSize(t, "a", 200), manifestOfSize(t, "b", 200), manifestOfSize(t, "c", 200),
+			},
+			want: [][]string{{"a", "b"}, {"c"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := manifestNames(t, splitManifests(tc.limits, tc.manifests))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("splitManifests() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckEnvelopeWorkSize(t *testing.T) {
+	envelope := &unstructured.Unstructured{}
+	envelope.SetKind("ConfigMap")
+	envelope.SetNamespace("app")
+	envelope.SetName("envelope")
+
+	testCases := []struct {
+		name      string
+		limits    resource.ManifestLimits
+		manifests []fleetv1beta1.Manifest
+		wantErr   string
+	}{
+		{
+			name:      "fits",
+			limits:    resource.ManifestLimits{MaxManifestSize: 200, MaxWorkSize: 400, MaxManifestsPerWork: 2},
+			manifests: []fleetv1beta1.Manifest{manifestOfSize(t, "a", 200), manifestOfSize(t, "b", 200)},
+		},
+		{
+			name:      "manifest too large",
+			limits:    resource.ManifestLimits{MaxManifestSize: 200},
+			manifests: []fleetv1beta1.Manifest{manifestOfSize(t, "a", 200), manifestOfSize(t, "b", 201)},
+			wantErr:   "the manifest of ConfigMap app/b is 201 bytes, which exceeds the limit of 200 bytes",
+		},
+		{
+			name:      "too many manifests",
+			limits:    resource.ManifestLimits{MaxManifestsPerWork: 1},
+			manifests: []fleetv1beta1.Manifest{manifestOfSize(t, "a", 200), manifestOfSize(t, "b", 200)},
+			wantErr:   "the 2 manifests (400 bytes in total) enveloped in ConfigMap app/envelope do not fit in a single work",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkEnvelopeWorkSize(tc.limits, envelope, tc.manifests)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkEnvelopeWorkSize() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("checkEnvelopeWorkSize() = %v, want error containing %q", err, tc.wantErr)
+			}
+			if !errors.Is(err, controller.ErrUserError) {
+				t.Errorf("checkEnvelopeWorkSize() = %v, want a user error", err)
+			}
+		})
+	}
+}
+
+func TestWorkNameOfPart(t *testing.T) {
+	if got, want := workNameOfPart("crp-work", 0), "crp-work"; got != want {
+		t.Errorf("workNameOfPart(0) = %s, want %s", got, want)
+	}
+	if got, want := workNameOfPart("crp-work", 2), "crp-work-part-2"; got != want {
+		t.Errorf("workNameOfPart(2) = %s, want %s", got, want)
+	}
+}
@@ -8,11 +8,11 @@ package workgenerator
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
-	"k8s.io/apimachinery/pkg/api/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
@@ -33,7 +33,7 @@ func (r *Reconciler) fetchClusterResourceOverrideSnapshots(ctx context.Context,
 	for _, name := range resourceBinding.Spec.ClusterResourceOverrideSnapshots {
 		snapshot := &placementv1alpha1.ClusterResourceOverrideSnapshot{}
 		if err := r.Client.Get(ctx, types.NamespacedName{Name: name}, snapshot); err != nil {
-			if errors.IsNotFound(err) {
+			if apierrors.IsNotFound(err) {
 				klog.ErrorS(err, "The clusterResourceOverrideSnapshot is deleted", "resourceBinding", klog.KObj(resourceBinding), "clusterResourceOverrideSnapshot", name)
 				// It could be caused by that the user updates the override too frequently and the snapshot has been replaced
 				// by the new one.
@@ -67,7 +67,7 @@ func (r *Reconciler) fetchResourceOverrideSnapshots(ctx context.Context, resourc
 	for _, namespacedName := range resourceBinding.Spec.ResourceOverrideSnapshots {
 		snapshot := &placementv1alpha1.ResourceOverrideSnapshot{}
 		if err := r.Client.Get(ctx, types.NamespacedName{Name: namespacedName.Name, Namespace: namespacedName.Namespace}, snapshot); err != nil {
-			if errors.IsNotFound(err) {
+			if apierrors.IsNotFound(err) {
 				// It could be caused by that the user updates the override too frequently and the snapshot has been replaced
 				// by the new one.
 				// TODO: support customized revision history limit
@@ -194,6 +194,7 @@ func applyOverrideRules(resource *placementv1beta1.ResourceContent, cluster *clu
 }
 
 // applyJSONPatchOverride applies a JSON patch on the selected resources following [RFC 6902](https://datatracker.ietf.org/doc/html/rfc6902).
+// The patch is applied as a whole; if any of its test operations fails, the resource is left unchanged.
 func applyJSONPatchOverride(resourceContent *placementv1beta1.ResourceContent, cluster *clusterv1beta1.MemberCluster, overrides []placementv1alpha1.JSONPatchOverride) error {
 	if len(overrides) == 0 { // do nothing
 		return nil
@@ -201,9 +202,12 @@ func applyJSONPatchOverride(resourceContent *placementv1beta1.ResourceContent, c
 	// go through the JSON patch overrides to replace the built-in variables before json Marshal
 	// as it may contain the built-in variables that cannot be marshaled directly
 	for i := range overrides {
-		// find and replace a few special built-in variables
-		// replace the built-in variable with the actual cluster name
-		processedJSONStr := []byte(strings.ReplaceAll(string(overrides[i].Value.Raw), placementv1alpha1.OverrideClusterNameVariable, cluster.Name))
+		// find and replace a few special built-in variables with the actual cluster name and labels
+		processedJSONStr, err := overrider.ReplaceVariables(overrides[i].Value.Raw, cluster)
+		if err != nil {
+			klog.ErrorS(err, "Failed to replace the built-in variables in the JSON patch override", "memberCluster", cluster.Name)
+			return err
+		}
 		overrides[i].Value.Raw = processedJSONStr
 	}
 
@@ -220,6 +224,11 @@ func applyJSONPatchOverride(resourceContent *placementv1beta1.ResourceContent, c
 	}
 
 	patchedObjectJSONBytes, err := patch.Apply(resourceContent.Raw)
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		// A failed test operation means the precondition of the rule does not hold, so the resource is left unchanged.
+		klog.V(2).InfoS("Skipped the JSON patch as its test operation failed", "error", err)
+		return nil
+	}
 	if err != nil {
 		klog.ErrorS(err, "Failed to apply the JSON patch to the resource")
 		return err
@@ -74,4 +74,101 @@ var (
 		Name: "scheduling_active_workers",
 		Help: "Number of currently running scheduling loop",
 	}, []string{})
+
+	// SchedulerStalePolicyResyncsTotal is a Fleet scheduler metric that tracks the number of times
+	// the resyncer re-queues a placement whose bindings still reference a stale policy snapshot.
+	SchedulerStalePolicyResyncsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "scheduling_stale_policy_resyncs_total",
+		Help: "Number of times a placement is re-queued for scheduling as its bindings still reference a stale policy snapshot",
+	}, []string{})
+)
+
+// The placement availability related metrics.
+//
+// The metrics are exported per CRP so that platform automation can consume the health of placements
+// via the metrics API (e.g., through an external metrics adapter), or derive other signals with recording rules.
+var (
+	// PlacementSelectedClusterCount is a Fleet metric that tracks the number of clusters that are
+	// selected by a CRP.
+	PlacementSelectedClusterCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "placement_selected_cluster_count",
+		Help: "Number of clusters selected by the cluster resource placement",
+	}, []string{"name"})
+
+	// PlacementAvailableClusterCount is a Fleet metric that tracks the number of selected clusters
+	// where the resources of a CRP have become available.
+	PlacementAvailableClusterCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "placement_available_cluster_count",
+		Help: "Number of selected clusters where the resources of the cluster resource placement are available",
+	}, []string{"name"})
+
+	// PlacementAvailableClusterPercent is a Fleet metric that tracks the percentage of selected
+	// clusters where the resources of a CRP have become available.
+	PlacementAvailableClusterPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "placement_available_cluster_percent",
+		Help: "Percentage (0-100) of selected clusters where the resources of the cluster resource placement are available",
+	}, []string{"name"})
+
+	// PlacementRolloutDurationSeconds is a Fleet metric that tracks how long it takes for the
+	// resources of a CRP to become available in all the selected clusters after a rollout starts.
+	PlacementRolloutDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "placement_rollout_duration_seconds",
+		Help:    "Length of time between when a rollout of the cluster resource placement starts and when the resources are available in all the selected clusters",
+		Buckets: []float64{1, 2.5, 5, 10, 15, 30, 45, 60, 90, 120, 180, 300, 600, 900, 1800, 3600},
+	}, []string{"name"})
+)
+
+// The binding cleanup related metrics.
+var (
+	// BindingCleanupPendingSeconds is a Fleet metric that tracks how long a deleting binding has
+	// been waiting for its works to be removed from the member cluster; the series of a binding is
+	// removed once the binding is fully deleted.
+	BindingCleanupPendingSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "binding_cleanup_pending_seconds",
+		Help: "Length of time a deleting cluster resource binding has been waiting for its works to be removed from the member cluster",
+	}, []string{"name"})
+)
+
+// The parallelizer related metrics.
+//
+// The metrics are labeled by the name of the parallelized operation, e.g., runFilterPlugins.
+var (
+	// ParallelizerWorkChunksTotal is a Fleet metric that tracks the number of chunks of work pieces
+	// processed by the parallelizer.
+	ParallelizerWorkChunksTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "parallelizer_work_chunks_total",
+		Help: "Number of chunks of work pieces processed by the parallelizer",
+	}, []string{"operation"})
+
+	// ParallelizerWorkDurationSeconds is a Fleet metric that tracks how long it takes for the
+	// parallelizer to complete (or abort) an operation.
+	ParallelizerWorkDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "parallelizer_work_duration_seconds",
+		Help:    "Length of time the parallelizer takes to complete or abort an operation",
+		Buckets: []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"operation"})
+
+	// ParallelizerAbortedWorkTotal is a Fleet metric that tracks the number of operations aborted by
+	// the parallelizer before all the work pieces are processed.
+	ParallelizerAbortedWorkTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "parallelizer_aborted_work_total",
+		Help: "Number of operations aborted by the parallelizer before all the work pieces are processed",
+	}, []string{"operation"})
+)
+
+// The fleet size related metrics.
+var (
+	// FleetObjectCount is a Fleet metric that tracks the number of the objects the fleet size
+	// guardrails count, labeled by the kind of the objects, e.g., MemberCluster.
+	FleetObjectCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fleet_object_count",
+		Help: "Number of the objects on the hub cluster which the fleet size guardrails count",
+	}, []string{"kind"})
+
+	// PlacementBindingCount is a Fleet metric that tracks the number of bindings of each cluster
+	// resource placement.
+	PlacementBindingCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "placement_binding_count",
+		Help: "Number of bindings of the cluster resource placement",
+	}, []string{"name"})
 )
@@ -0,0 +1,77 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package metrics
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// OtherPlacementsLabelValue is the value of the name label for the CRPs that do not have their
+	// own series in the per-CRP metrics. CRP names must be valid DNS subdomain names, which cannot
+	// start with an underscore; as a result, the value never collides with the name of a CRP.
+	OtherPlacementsLabelValue = "_other"
+)
+
+// PlacementLabeler controls the cardinality of the name label in the per-CRP metrics.
+//
+// CRPs in the allow list always have their own series; all other CRPs have their own series on a
+// first-come, first-served basis until the number of such CRPs reaches the cap. CRPs beyond the cap
+// are reported under the OtherPlacementsLabelValue label value in the per-CRP counters and histograms,
+// and are not reported at all in the per-CRP gauges, as their values cannot be aggregated.
+//
+// A nil PlacementLabeler is valid; it gives every CRP its own series.
+type PlacementLabeler struct {
+	mu sync.Mutex
+	// allowList is the names of the CRPs that always have their own series.
+	allowList sets.Set[string]
+	// maxTracked is the max number of CRPs not in the allow list that can have their own series;
+	// a negative value means no limit.
+	maxTracked int
+	// tracked is the names of the CRPs not in the allow list that have their own series.
+	tracked sets.Set[string]
+}
+
+// NewPlacementLabeler returns a new PlacementLabeler.
+func NewPlacementLabeler(allowList []string, maxTracked int) *PlacementLabeler {
+	return &PlacementLabeler{
+		allowList:  sets.New(allowList...),
+		maxTracked: maxTracked,
+		tracked:    sets.New[string](),
+	}
+}
+
+// LabelFor returns the value of the name label for a CRP in the per-CRP metrics, and whether
+// the CRP has its own series.
+func (l *PlacementLabeler) LabelFor(crpName string) (string, bool) {
+	if l == nil || l.allowList.Has(crpName) {
+		return crpName, true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.tracked.Has(crpName) {
+		return crpName, true
+	}
+	if l.maxTracked >= 0 && l.tracked.Len() >= l.maxTracked {
+		return OtherPlacementsLabelValue, false
+	}
+	l.tracked.Insert(crpName)
+	return crpName, true
+}
+
+// Forget releases the series slot (if any) of a CRP that is gone, so that another CRP can take it.
+func (l *PlacementLabeler) Forget(crpName string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tracked.Delete(crpName)
+}
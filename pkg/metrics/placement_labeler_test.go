@@ -0,0 +1,81 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package metrics
+
+import (
+	"testing"
+)
+
+// TestPlacementLabelerLabelFor tests the LabelFor and the Forget methods of PlacementLabeler.
+func TestPlacementLabelerLabelFor(t *testing.T) {
+	type lookup struct {
+		crpName   string
+		forget    bool
+		wantLabel string
+		wantOwn   bool
+	}
+	testCases := []struct {
+		name    string
+		labeler *PlacementLabeler
+		lookups []lookup
+	}{
+		{
+			name:    "nil labeler",
+			labeler: nil,
+			lookups: []lookup{
+				{crpName: "crp-1", wantLabel: "crp-1", wantOwn: true},
+				{crpName: "crp-2", wantLabel: "crp-2", wantOwn: true},
+			},
+		},
+		{
+			name:    "no limit",
+			labeler: NewPlacementLabeler(nil, -1),
+			lookups: []lookup{
+				{crpName: "crp-1", wantLabel: "crp-1", wantOwn: true},
+				{crpName: "crp-2", wantLabel: "crp-2", wantOwn: true},
+			},
+		},
+		{
+			name:    "allow list only",
+			labeler: NewPlacementLabeler([]string{"critical-crp"}, 0),
+			lookups: []lookup{
+				{crpName: "critical-crp", wantLabel: "critical-crp", wantOwn: true},
+				{crpName: "crp-1", wantLabel: OtherPlacementsLabelValue, wantOwn: false},
+			},
+		},
+		{
+			name:    "capped",
+			labeler: NewPlacementLabeler([]string{"critical-crp"}, 2),
+			lookups: []lookup{
+				{crpName: "crp-1", wantLabel: "crp-1", wantOwn: true},
+				{crpName: "crp-2", wantLabel: "crp-2", wantOwn: true},
+				{crpName: "crp-3", wantLabel: OtherPlacementsLabelValue, wantOwn: false},
+				// Allow-listed CRPs do not count against the cap.
+				{crpName: "critical-crp", wantLabel: "critical-crp", wantOwn: true},
+				// Tracked CRPs keep their own series.
+				{crpName: "crp-1", wantLabel: "crp-1", wantOwn: true},
+				{crpName: "crp-1", forget: true},
+				{crpName: "crp-3", wantLabel: "crp-3", wantOwn: true},
+				{crpName: "crp-4", wantLabel: OtherPlacementsLabelValue, wantOwn: false},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, l := range tc.lookups {
+				if l.forget {
+					tc.labeler.Forget(l.crpName)
+					continue
+				}
+				gotLabel, gotOwn := tc.labeler.LabelFor(l.crpName)
+				if gotLabel != l.wantLabel || gotOwn != l.wantOwn {
+					t.Errorf("LabelFor(%s) = (%s, %t), want (%s, %t)", l.crpName, gotLabel, gotOwn, l.wantLabel, l.wantOwn)
+				}
+			}
+		})
+	}
+}
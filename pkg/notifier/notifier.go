@@ -0,0 +1,269 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package notifier features a notifier that pushes the events of cluster resource placements, e.g.,
+// a placement has become available on all the selected clusters, to external HTTP webhooks.
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// EventType is the type of a placement event.
+type EventType string
+
+const (
+	// PlacementAvailable signals that the resources of a placement have become available on all the
+	// selected clusters.
+	PlacementAvailable EventType = "PlacementAvailable"
+	// PlacementRolloutStalled signals that the rollout of a placement has not completed for a
+	// prolonged period of time.
+	PlacementRolloutStalled EventType = "PlacementRolloutStalled"
+	// PlacementClusterFailed signals that the resources of a placement have failed to be applied, or
+	// have become unavailable, on a selected cluster.
+	PlacementClusterFailed EventType = "PlacementClusterFailed"
+)
+
+const (
+	// SignatureHeader is the HTTP header in which the notifier sends the HMAC-SHA256 signature of the
+	// request body, in the format of "sha256=<hex digest>", if a signing key is configured.
+	SignatureHeader = "X-Fleet-Signature-256"
+	// EventTypeHeader is the HTTP header in which the notifier sends the type of the event.
+	EventTypeHeader = "X-Fleet-Event"
+
+	// defaultQueueSize is the default number of notifications that can wait for delivery; new
+	// notifications are dropped when the queue is full.
+	defaultQueueSize = 1000
+	// defaultRequestTimeout is the default timeout of each delivery attempt.
+	defaultRequestTimeout = time.Second * 10
+)
+
+var (
+	_ manager.Runnable               = &Notifier{}
+	_ manager.LeaderElectionRunnable = &Notifier{}
+
+	// defaultRetryBackoff is the default backoff between the delivery attempts of a notification.
+	defaultRetryBackoff = wait.Backoff{
+		Duration: time.Second,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    5,
+	}
+
+	// errPermanent signals that a delivery attempt fails in a way that retries cannot fix.
+	errPermanent = errors.New("permanent delivery failure")
+)
+
+// Event is the payload the notifier sends to the webhooks, as a JSON object.
+type Event struct {
+	// Type is the type of the event.
+	Type EventType `json:"type"`
+	// Placement is the name of the cluster resource placement.
+	Placement string `json:"placement"`
+	// Generation is the generation of the placement when the event happens.
+	Generation int64 `json:"generation"`
+	// Cluster is the name of the cluster the event is about, if any.
+	Cluster string `json:"cluster,omitempty"`
+	// Reason is a brief reason of the event, in CamelCase.
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message of the event.
+	Message string `json:"message,omitempty"`
+	// Time is when the event happens.
+	Time metav1.Time `json:"time"`
+}
+
+// notification is an event waiting for delivery to a webhook.
+type notification struct {
+	url   string
+	event Event
+}
+
+// Notifier delivers placement events to HTTP webhooks asynchronously, with retries.
+type Notifier struct {
+	// defaultURL is the webhook URL the events of all placements are sent to; it can be empty.
+	defaultURL string
+	// allowURLAnnotation, if set, allows a placement to send its events to its own webhook, as
+	// specified in the NotificationURLAnnotation annotation.
+	allowURLAnnotation bool
+	// signingKey is the key with which the request bodies are signed; it can be empty.
+	signingKey []byte
+
+	httpClient   *http.Client
+	retryBackoff wait.Backoff
+	queue        chan notification
+}
+
+// Option helps set up the notifier.
+type Option func(*Notifier)
+
+// WithSigningKey sets the key with which the notifier signs the request bodies.
+func WithSigningKey(key []byte) Option {
+	return func(n *Notifier) {
+		n.signingKey = key
+	}
+}
+
+// WithURLAnnotation allows the placements to send their events to their own webhooks, as specified
+// in the NotificationURLAnnotation annotation.
+func WithURLAnnotation() Option {
+	return func(n *Notifier) {
+		n.allowURLAnnotation = true
+	}
+}
+
+// WithHTTPClient sets the HTTP client the notifier uses to deliver the events.
+func WithHTTPClient(c *http.Client) Option {
+	return func(n *Notifier) {
+		n.httpClient = c
+	}
+}
+
+// WithRetryBackoff sets the backoff between the delivery attempts of a notification.
+func WithRetryBackoff(backoff wait.Backoff) Option {
+	return func(n *Notifier) {
+		n.retryBackoff = backoff
+	}
+}
+
+// New returns a new notifier, which sends the events of all placements to the given webhook URL,
+// unless it is empty.
+func New(defaultURL string, opts ...Option) *Notifier {
+	n := &Notifier{
+		defaultURL:   defaultURL,
+		httpClient:   &http.Client{Timeout: defaultRequestTimeout},
+		retryBackoff: defaultRetryBackoff,
+		queue:        make(chan notification, defaultQueueSize),
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// Notify queues an event of a placement for delivery; it never blocks. The event is dropped if the
+// placement has no webhook to send to, or if the queue is full.
+func (n *Notifier) Notify(crp *placementv1beta1.ClusterResourcePlacement, event Event) {
+	if n == nil {
+		return
+	}
+	url := n.urlFor(crp)
+	if url == "" {
+		return
+	}
+	select {
+	case n.queue <- notification{url: url, event: event}:
+	default:
+		klog.ErrorS(errors.New("notification queue is full"), "Dropped a placement notification", "clusterResourcePlacement", klog.KObj(crp), "event", event.Type)
+	}
+}
+
+// urlFor returns the webhook URL the events of a placement are sent to.
+func (n *Notifier) urlFor(crp *placementv1beta1.ClusterResourcePlacement) string {
+	if n.allowURLAnnotation {
+		if url := crp.GetAnnotations()[placementv1beta1.NotificationURLAnnotation]; url != "" {
+			return url
+		}
+	}
+	return n.defaultURL
+}
+
+// Start delivers the queued notifications until the context is cancelled.
+func (n *Notifier) Start(ctx context.Context) error {
+	klog.InfoS("Starting the placement notifier", "defaultURL", n.defaultURL, "allowURLAnnotation", n.allowURLAnnotation)
+	for {
+		select {
+		case <-ctx.Done():
+			klog.InfoS("The placement notifier has exited")
+			return nil
+		case notif := <-n.queue:
+			if err := n.deliver(ctx, notif); err != nil {
+				klog.ErrorS(err, "Failed to deliver a placement notification", "url", notif.url, "event", notif.event.Type, "clusterResourcePlacement", notif.event.Placement)
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface, so that the events are only
+// delivered once, by the leader.
+func (n *Notifier) NeedLeaderElection() bool {
+	return true
+}
+
+// deliver sends a notification to its webhook, retrying on transient failures.
+func (n *Notifier) deliver(ctx context.Context, notif notification) error {
+	body, err := json.Marshal(notif.event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the event: %w", err)
+	}
+	var lastErr error
+	err = wait.ExponentialBackoffWithContext(ctx, n.retryBackoff, func(ctx context.Context) (bool, error) {
+		lastErr = n.send(ctx, notif.url, notif.event.Type, body)
+		switch {
+		case lastErr == nil:
+			return true, nil
+		case errors.Is(lastErr, errPermanent):
+			return false, lastErr
+		default:
+			klog.V(2).InfoS("Failed to send a placement notification; will retry", "url", notif.url, "event", notif.event.Type, "error", lastErr)
+			return false, nil
+		}
+	})
+	if wait.Interrupted(err) && lastErr != nil {
+		return fmt.Errorf("gave up after retries: %w", lastErr)
+	}
+	return err
+}
+
+// send makes one delivery attempt.
+func (n *Notifier) send(ctx context.Context, url string, eventType EventType, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: failed to build the request: %v", errPermanent, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventTypeHeader, string(eventType))
+	if len(n.signingKey) > 0 {
+		req.Header.Set(SignatureHeader, Sign(n.signingKey, body))
+	}
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	default:
+		return fmt.Errorf("%w: webhook responded with status %d", errPermanent, resp.StatusCode)
+	}
+}
+
+// Sign returns the HMAC-SHA256 signature of a request body, in the format of "sha256=<hex digest>";
+// the receivers can verify the requests by computing the same signature with the shared key.
+func Sign(key, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,253 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	defaultTestURL    = "https://hooks.example.com/fleet"
+	annotatedTestURL  = "https://hooks.example.com/team-a"
+	testPlacementName = "test-crp"
+)
+
+var testBackoff = wait.Backoff{
+	Duration: time.Millisecond,
+	Factor:   1,
+	Steps:    3,
+}
+
+func TestSign(t *testing.T) {
+	// The well-known HMAC-SHA256 test vector.
+	got := Sign([]byte("key"), []byte("The quick brown fox jumps over the lazy dog"))
+	want := "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	if got != want {
+		t.Errorf("Sign() = %s, want %s", got, want)
+	}
+}
+
+func TestURLFor(t *testing.T) {
+	annotated := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testPlacementName,
+			Annotations: map[string]string{
+				placementv1beta1.NotificationURLAnnotation: annotatedTestURL,
+			},
+		},
+	}
+	plain := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testPlacementName,
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		notifier *Notifier
+		crp      *placementv1beta1.ClusterResourcePlacement
+		want     string
+	}{
+		{
+			name:     "default URL",
+			notifier: New(defaultTestURL),
+			crp:      plain,
+			want:     defaultTestURL,
+		},
+		{
+			name:     "annotation is ignored when not allowed",
+			notifier: New(defaultTestURL),
+			crp:      annotated,
+			want:     defaultTestURL,
+		},
+		{
+			name:     "annotation overrides the default URL",
+			notifier: New(defaultTestURL, WithURLAnnotation()),
+			crp:      annotated,
+			want:     annotatedTestURL,
+		},
+		{
+			name:     "annotation without default URL",
+			notifier: New("", WithURLAnnotation()),
+			crp:      annotated,
+			want:     annotatedTestURL,
+		},
+		{
+			name:     "no URL",
+			notifier: New("", WithURLAnnotation()),
+			crp:      plain,
+			want:     "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.notifier.urlFor(tc.crp); got != tc.want {
+				t.Errorf("urlFor() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testPlacementName,
+		},
+	}
+	event := Event{Type: PlacementAvailable, Placement: testPlacementName}
+
+	// A nil notifier drops the events.
+	var nilNotifier *Notifier
+	nilNotifier.Notify(crp, event)
+
+	// Events are dropped if the placement has no webhook.
+	n := New("")
+	n.Notify(crp, event)
+	if got := len(n.queue); got != 0 {
+		t.Errorf("Notify() without URL queued %d notifications, want 0", got)
+	}
+
+	// Events are dropped instead of blocking when the queue is full.
+	n = New(defaultTestURL)
+	for i := 0; i < defaultQueueSize+1; i++ {
+		n.Notify(crp, event)
+	}
+	if got := len(n.queue); got != defaultQueueSize {
+		t.Errorf("Notify() queued %d notifications, want %d", got, defaultQueueSize)
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	signingKey := []byte("test-key")
+	event := Event{
+		Type:       PlacementClusterFailed,
+		Placement:  testPlacementName,
+		Generation: 2,
+		Cluster:    "member-1",
+		Reason:     "ApplyFailed",
+		Message:    "Failed to apply the manifests",
+		Time:       metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+
+	testCases := []struct {
+		name         string
+		statusCodes  []int
+		wantErr      bool
+		wantAttempts int32
+	}{
+		{
+			name:         "delivered at the first attempt",
+			statusCodes:  []int{http.StatusOK},
+			wantAttempts: 1,
+		},
+		{
+			name:         "delivered after transient failures",
+			statusCodes:  []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusNoContent},
+			wantAttempts: 3,
+		},
+		{
+			name:         "no retry on client errors",
+			statusCodes:  []int{http.StatusBadRequest},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "give up after retries",
+			statusCodes:  []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK},
+			wantErr:      true,
+			wantAttempts: 3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				attempt := attempts.Add(1)
+				body, err := io.ReadAll(req.Body)
+				if err != nil {
+					t.Errorf("failed to read the request body: %v", err)
+				}
+				if got, want := req.Header.Get(SignatureHeader), Sign(signingKey, body); got != want {
+					t.Errorf("%s header = %s, want %s", SignatureHeader, got, want)
+				}
+				if got := req.Header.Get(EventTypeHeader); got != string(event.Type) {
+					t.Errorf("%s header = %s, want %s", EventTypeHeader, got, event.Type)
+				}
+				var got Event
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Errorf("failed to unmarshal the request body: %v", err)
+				}
+				if diff := cmp.Diff(event, got); diff != "" {
+					t.Errorf("request body mismatch (-want, +got):\n%s", diff)
+				}
+				w.WriteHeader(tc.statusCodes[attempt-1])
+			}))
+			defer server.Close()
+
+			n := New(server.URL, WithSigningKey(signingKey), WithHTTPClient(server.Client()), WithRetryBackoff(testBackoff))
+			err := n.deliver(context.Background(), notification{url: server.URL, event: event})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("deliver() = %v, want error %t", err, tc.wantErr)
+			}
+			if got := attempts.Load(); got != tc.wantAttempts {
+				t.Errorf("deliver() made %d attempts, want %d", got, tc.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestStart(t *testing.T) {
+	received := make(chan Event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var event Event
+		if err := json.NewDecoder(req.Body).Decode(&event); err != nil {
+			t.Errorf("failed to decode the request body: %v", err)
+		}
+		received <- event
+	}))
+	defer server.Close()
+
+	n := New(server.URL, WithHTTPClient(server.Client()), WithRetryBackoff(testBackoff))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- n.Start(ctx)
+	}()
+
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testPlacementName,
+		},
+	}
+	n.Notify(crp, Event{Type: PlacementAvailable, Placement: testPlacementName})
+	select {
+	case got := <-received:
+		if got.Type != PlacementAvailable || got.Placement != testPlacementName {
+			t.Errorf("received event %+v, want a %s event of %s", got, PlacementAvailable, testPlacementName)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("timed out waiting for the notification")
+	}
+
+	cancel()
+	if err := <-done; err != nil && !errors.Is(err, context.Canceled) {
+		t.Errorf("Start() = %v, want nil", err)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
@@ -40,6 +41,29 @@ const (
 	PerGBMemoryCostProperty = "kubernetes.azure.com/per-gb-memory-cost"
 
 	CostPrecisionTemplate = "%.3f"
+
+	// AvailabilityZoneCountProperty is a property that describes the number of availability zones
+	// in which the nodes of a Kubernetes cluster are deployed.
+	AvailabilityZoneCountProperty = "kubernetes.azure.com/availability-zone-count"
+
+	// The properties below are named after the region, the availability zones, and the node SKUs
+	// of a Kubernetes cluster respectively, e.g., kubernetes.azure.com/region.eastus; each of them
+	// describes the number of nodes in the region, in the availability zone, or of the SKU.
+	//
+	// As property values must be quantities, the region, the availability zones, and the node SKUs
+	// are reported as a part of the property names, so that a scheduling policy can select
+	// clusters by them, e.g., with a kubernetes.azure.com/region.eastus property selector
+	// requirement that uses the Ge operator and a value of 1.
+
+	// RegionPropertyPrefix is the prefix of the property that describes the region of a
+	// Kubernetes cluster.
+	RegionPropertyPrefix = "kubernetes.azure.com/region."
+	// AvailabilityZonePropertyPrefix is the prefix of the properties that describe the availability
+	// zones of a Kubernetes cluster.
+	AvailabilityZonePropertyPrefix = "kubernetes.azure.com/zone."
+	// NodeSKUPropertyPrefix is the prefix of the properties that describe the node SKUs of a
+	// Kubernetes cluster.
+	NodeSKUPropertyPrefix = "kubernetes.azure.com/vm-size."
 )
 
 const (
@@ -192,6 +216,22 @@ func (p *PropertyProvider) Collect(_ context.Context) propertyprovider.PropertyC
 		ObservationTime: metav1.Now(),
 	}
 
+	// Collect the location and SKU related properties.
+	nodeCountByZone := p.nodeTracker.NodeCountByZone()
+	properties[AvailabilityZoneCountProperty] = clusterv1beta1.PropertyValue{
+		Value:           fmt.Sprintf("%d", len(nodeCountByZone)),
+		ObservationTime: metav1.Now(),
+	}
+	if p.region != nil && len(*p.region) > 0 {
+		addNodeCountProperty(properties, RegionPropertyPrefix+*p.region, p.nodeTracker.NodeCount())
+	}
+	for zone, count := range nodeCountByZone {
+		addNodeCountProperty(properties, AvailabilityZonePropertyPrefix+zone, count)
+	}
+	for sku, count := range p.nodeTracker.NodeCountBySKU() {
+		addNodeCountProperty(properties, NodeSKUPropertyPrefix+sku, count)
+	}
+
 	perCPUCost, perGBMemoryCost, err := p.nodeTracker.Costs()
 	if err != nil {
 		// Note that the last transition time is not tracked here, as the provider does not
@@ -259,6 +299,19 @@ func (p *PropertyProvider) Collect(_ context.Context) propertyprovider.PropertyC
 	}
 }
 
+// addNodeCountProperty adds a node count property with the given name to the collected properties;
+// properties whose names are not valid label names are skipped.
+func addNodeCountProperty(properties map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue, name string, count int) {
+	if errs := validation.IsQualifiedName(name); len(errs) > 0 {
+		klog.V(2).InfoS("Skipping a property with an invalid name", "property", name, "errors", errs)
+		return
+	}
+	properties[clusterv1beta1.PropertyName(name)] = clusterv1beta1.PropertyValue{
+		Value:           fmt.Sprintf("%d", count),
+		ObservationTime: metav1.Now(),
+	}
+}
+
 // autoDiscoverRegionAndSetupTrackers auto-discovers the region of the AKS cluster.
 func (p *PropertyProvider) autoDiscoverRegionAndSetupTrackers(ctx context.Context, c client.Reader) (*string, error) {
 	klog.V(2).Info("Auto-discover region for the Azure property provider")
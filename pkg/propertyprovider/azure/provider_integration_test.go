@@ -183,6 +183,9 @@ var (
 						PerGBMemoryCostProperty: {
 							Value: perGBMemoryCost,
 						},
+						AvailabilityZoneCountProperty: {
+							Value: "0",
+						},
 					},
 					Resources: clusterv1beta1.ResourceUsage{
 						Capacity: corev1.ResourceList{
@@ -208,6 +211,16 @@ var (
 					},
 				}
 
+				nodeCountBySKU := make(map[string]int)
+				for idx := range nodes {
+					nodeCountBySKU[nodes[idx].Labels[trackers.AKSClusterNodeSKULabelName]]++
+				}
+				for sku, count := range nodeCountBySKU {
+					expectedRes.Properties[clusterv1beta1.PropertyName(NodeSKUPropertyPrefix+sku)] = clusterv1beta1.PropertyValue{
+						Value: fmt.Sprintf("%d", count),
+					}
+				}
+
 				res := p.Collect(ctx)
 				if diff := cmp.Diff(res, expectedRes, ignoreObservationTimeFieldInPropertyValue); diff != "" {
 					return fmt.Errorf("property collection response (-got, +want):\n%s", diff)
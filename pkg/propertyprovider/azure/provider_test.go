@@ -14,6 +14,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	"go.goms.io/fleet/pkg/propertyprovider"
@@ -43,6 +44,9 @@ const (
 	nodeSKU1 = "Standard_1"
 	nodeSKU2 = "Standard_2"
 
+	zone1 = "eastus-1"
+	zone2 = "eastus-2"
+
 	imageName = "nginx"
 )
 
@@ -66,6 +70,7 @@ func (d *dummyPricingProvider) LastUpdated() time.Time {
 func TestCollect(t *testing.T) {
 	testCases := []struct {
 		name                         string
+		region                       *string
 		nodes                        []corev1.Node
 		pods                         []corev1.Pod
 		wantMetricCollectionResponse propertyprovider.PropertyCollectionResponse
@@ -174,6 +179,15 @@ func TestCollect(t *testing.T) {
 					PerGBMemoryCostProperty: {
 						Value: "0.042",
 					},
+					AvailabilityZoneCountProperty: {
+						Value: "0",
+					},
+					NodeSKUPropertyPrefix + nodeSKU1: {
+						Value: "1",
+					},
+					NodeSKUPropertyPrefix + nodeSKU2: {
+						Value: "1",
+					},
 				},
 				Resources: clusterv1beta1.ResourceUsage{
 					Capacity: corev1.ResourceList{
@@ -303,6 +317,15 @@ func TestCollect(t *testing.T) {
 					PerGBMemoryCostProperty: {
 						Value: "0.042",
 					},
+					AvailabilityZoneCountProperty: {
+						Value: "0",
+					},
+					NodeSKUPropertyPrefix + nodeSKU1: {
+						Value: "1",
+					},
+					NodeSKUPropertyPrefix + nodeSKU2: {
+						Value: "1",
+					},
 				},
 				Resources: clusterv1beta1.ResourceUsage{
 					Capacity: corev1.ResourceList{
@@ -328,6 +351,142 @@ func TestCollect(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "can report region, availability zones, and node SKUs",
+			region: ptr.To(region),
+			nodes: []corev1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: nodeName1,
+						Labels: map[string]string{
+							trackers.AKSClusterNodeSKULabelName: nodeSKU1,
+							corev1.LabelTopologyZone:            zone1,
+						},
+					},
+					Status: corev1.NodeStatus{
+						Capacity: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("16Gi"),
+						},
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("16Gi"),
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: nodeName2,
+						Labels: map[string]string{
+							trackers.AKSClusterNodeSKULabelName: nodeSKU1,
+							corev1.LabelTopologyZone:            zone2,
+						},
+					},
+					Status: corev1.NodeStatus{
+						Capacity: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("16Gi"),
+						},
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("16Gi"),
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: nodeName3,
+						Labels: map[string]string{
+							trackers.AKSClusterNodeSKULabelName: nodeSKU2,
+							corev1.LabelTopologyZone:            zone2,
+						},
+					},
+					Status: corev1.NodeStatus{
+						Capacity: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("8"),
+							corev1.ResourceMemory: resource.MustParse("32Gi"),
+						},
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("8"),
+							corev1.ResourceMemory: resource.MustParse("32Gi"),
+						},
+					},
+				},
+				{
+					// A node that is not deployed in an availability zone.
+					ObjectMeta: metav1.ObjectMeta{
+						Name: nodeName4,
+						Labels: map[string]string{
+							trackers.AKSClusterNodeSKULabelName: nodeSKU2,
+							corev1.LabelTopologyZone:            "0",
+						},
+					},
+					Status: corev1.NodeStatus{
+						Capacity: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("8"),
+							corev1.ResourceMemory: resource.MustParse("32Gi"),
+						},
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("8"),
+							corev1.ResourceMemory: resource.MustParse("32Gi"),
+						},
+					},
+				},
+			},
+			wantMetricCollectionResponse: propertyprovider.PropertyCollectionResponse{
+				Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+					propertyprovider.NodeCountProperty: {
+						Value: "4",
+					},
+					PerCPUCoreCostProperty: {
+						Value: "0.167",
+					},
+					PerGBMemoryCostProperty: {
+						Value: "0.042",
+					},
+					AvailabilityZoneCountProperty: {
+						Value: "2",
+					},
+					RegionPropertyPrefix + region: {
+						Value: "4",
+					},
+					AvailabilityZonePropertyPrefix + zone1: {
+						Value: "1",
+					},
+					AvailabilityZonePropertyPrefix + zone2: {
+						Value: "2",
+					},
+					NodeSKUPropertyPrefix + nodeSKU1: {
+						Value: "2",
+					},
+					NodeSKUPropertyPrefix + nodeSKU2: {
+						Value: "2",
+					},
+				},
+				Resources: clusterv1beta1.ResourceUsage{
+					Capacity: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("24"),
+						corev1.ResourceMemory: resource.MustParse("96Gi"),
+					},
+					Allocatable: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("24"),
+						corev1.ResourceMemory: resource.MustParse("96Gi"),
+					},
+					Available: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("24"),
+						corev1.ResourceMemory: resource.MustParse("96Gi"),
+					},
+				},
+				Conditions: []metav1.Condition{
+					{
+						Type:    PropertyCollectionSucceededConditionType,
+						Status:  metav1.ConditionTrue,
+						Reason:  PropertyCollectionSucceededReason,
+						Message: PropertyCollectionSucceededMessage,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -347,6 +506,7 @@ func TestCollect(t *testing.T) {
 			p := &PropertyProvider{
 				nodeTracker: nodeTracker,
 				podTracker:  podTracker,
+				region:      tc.region,
 			}
 			res := p.Collect(ctx)
 			if diff := cmp.Diff(res, tc.wantMetricCollectionResponse, ignoreObservationTimeFieldInPropertyValue); diff != "" {
@@ -10,6 +10,7 @@ package trackers
 import (
 	"fmt"
 	"math"
+	"strings"
 	"sync"
 	"time"
 
@@ -24,12 +25,34 @@ const (
 	AKSClusterNodeSKULabelName = "beta.kubernetes.io/instance-type"
 )
 
+// nvidiaGPUResourceName is the name of the extended resource that the NVIDIA device plugin
+// uses to expose GPUs on nodes.
+const nvidiaGPUResourceName corev1.ResourceName = "nvidia.com/gpu"
+
 // supportedResourceNames is a list of resource names that the Azure property provider supports.
 //
-// Currently the supported resources are CPU and memory.
+// Currently the supported resources are CPU, memory, and NVIDIA GPUs.
 var supportedResourceNames []corev1.ResourceName = []corev1.ResourceName{
 	corev1.ResourceCPU,
 	corev1.ResourceMemory,
+	nvidiaGPUResourceName,
+}
+
+// extendedResourceNames is the set of supported resources that are tracked (and consequently
+// reported) only when they are present, e.g., clusters without any GPU nodes do not report
+// GPUs at all, rather than a capacity of zero.
+var extendedResourceNames = map[corev1.ResourceName]bool{
+	nvidiaGPUResourceName: true,
+}
+
+// setQuantity sets the quantity of a resource in a resource list; zero quantities of extended
+// resources are dropped from the list instead.
+func setQuantity(rl corev1.ResourceList, rn corev1.ResourceName, q resource.Quantity) {
+	if extendedResourceNames[rn] && q.IsZero() {
+		delete(rl, rn)
+		return
+	}
+	rl[rn] = q
 }
 
 // NodeSet is a set of nodes.
@@ -67,6 +90,10 @@ type NodeTracker struct {
 	allocatableByNode map[string]corev1.ResourceList
 	nodeSetBySKU      map[string]NodeSet
 	skuByNode         map[string]string
+	// nodeSetByZone and zoneByNode track the availability zones of the nodes; they are initialized
+	// when the first node with an availability zone is tracked.
+	nodeSetByZone map[string]NodeSet
+	zoneByNode    map[string]string
 
 	// pricingProvider facilitates cost calculation.
 	pricingProvider PricingProvider
@@ -91,8 +118,8 @@ func NewNodeTracker(pp PricingProvider) *NodeTracker {
 	}
 
 	for _, rn := range supportedResourceNames {
-		nt.totalCapacity[rn] = resource.Quantity{}
-		nt.totalAllocatable[rn] = resource.Quantity{}
+		setQuantity(nt.totalCapacity, rn, resource.Quantity{})
+		setQuantity(nt.totalAllocatable, rn, resource.Quantity{})
 	}
 
 	return nt
@@ -224,6 +251,46 @@ func (nt *NodeTracker) trackSKU(node *corev1.Node) bool {
 	}
 }
 
+// trackZone tracks the availability zone of a node.
+//
+// Nodes that are not deployed in an availability zone are not tracked; on AKS, such nodes
+// have their zone label set to the fault domain number (e.g., 0) instead of a zone name in
+// the format of [REGION]-[ZONE NUMBER] (e.g., eastus-1).
+//
+// Note that this method assumes that the access lock has been acquired.
+func (nt *NodeTracker) trackZone(node *corev1.Node) {
+	zone := node.Labels[corev1.LabelTopologyZone]
+	if !strings.Contains(zone, "-") {
+		// The node is not deployed in an availability zone; untrack the zone of the node in
+		// case it has been tracked before.
+		nt.untrackZone(node.Name)
+		return
+	}
+
+	registeredZone, found := nt.zoneByNode[node.Name]
+	if found && registeredZone == zone {
+		// No further action is needed if the node's zone remains the same.
+		return
+	}
+	if found {
+		// The node's zone has changed; normally this will never happen.
+		nt.untrackZone(node.Name)
+	}
+
+	if nt.zoneByNode == nil {
+		nt.zoneByNode = make(map[string]string)
+		nt.nodeSetByZone = make(map[string]NodeSet)
+	}
+	nt.zoneByNode[node.Name] = zone
+	ns := nt.nodeSetByZone[zone]
+	if ns == nil {
+		ns = make(NodeSet)
+	}
+	ns[node.Name] = true
+	nt.nodeSetByZone[zone] = ns
+	klog.V(2).InfoS("Tracked the node's availability zone", "zone", zone, "node", klog.KObj(node))
+}
+
 // trackAllocatableCapacity tracks the allocatable capacity of a node.
 //
 // Note that this method assumes that the access lock has been acquired.
@@ -246,10 +313,10 @@ func (nt *NodeTracker) trackAllocatableCapacity(node *corev1.Node) {
 				ta := nt.totalAllocatable[rn]
 				ta.Sub(c1)
 				ta.Add(c2)
-				nt.totalAllocatable[rn] = ta
+				setQuantity(nt.totalAllocatable, rn, ta)
 
 				// Update the tracked total capacity of the node.
-				ra[rn] = c2
+				setQuantity(ra, rn, c2)
 			}
 			klog.V(2).InfoS("Found an allocatable capacity change", "resource", rn, "node", klog.KObj(node), "oldCapacity", c1, "newCapacity", c2)
 		}
@@ -259,11 +326,11 @@ func (nt *NodeTracker) trackAllocatableCapacity(node *corev1.Node) {
 		// The node's allocatable capacity has not been tracked.
 		for _, rn := range supportedResourceNames {
 			a := node.Status.Allocatable[rn]
-			ra[rn] = a
+			setQuantity(ra, rn, a)
 
 			ta := nt.totalAllocatable[rn]
 			ta.Add(a)
-			nt.totalAllocatable[rn] = ta
+			setQuantity(nt.totalAllocatable, rn, ta)
 			klog.V(2).InfoS("Added allocatable capacity", "resource", rn, "node", klog.KObj(node), "capacity", a)
 		}
 
@@ -295,10 +362,10 @@ func (nt *NodeTracker) trackTotalCapacity(node *corev1.Node) bool {
 				tc := nt.totalCapacity[rn]
 				tc.Sub(c1)
 				tc.Add(c2)
-				nt.totalCapacity[rn] = tc
+				setQuantity(nt.totalCapacity, rn, tc)
 
 				// Update the tracked total capacity of the node.
-				rc[rn] = c2
+				setQuantity(rc, rn, c2)
 
 				isCapacityChanged = true
 				klog.V(2).InfoS("Found a total capacity change", "resource", rn, "node", klog.KObj(node), "oldCapacity", c1, "newCapacity", c2)
@@ -311,11 +378,11 @@ func (nt *NodeTracker) trackTotalCapacity(node *corev1.Node) bool {
 
 		for _, rn := range supportedResourceNames {
 			c := node.Status.Capacity[rn]
-			rc[rn] = c
+			setQuantity(rc, rn, c)
 
 			tc := nt.totalCapacity[rn]
 			tc.Add(c)
-			nt.totalCapacity[rn] = tc
+			setQuantity(nt.totalCapacity, rn, tc)
 			klog.V(2).InfoS("Added total capacity", "resource", rn, "node", klog.KObj(node), "capacity", c)
 		}
 
@@ -338,6 +405,8 @@ func (nt *NodeTracker) AddOrUpdate(node *corev1.Node) {
 	nt.trackAllocatableCapacity(node)
 	// Track the SKU of the node.
 	isSKUChanged := nt.trackSKU(node)
+	// Track the availability zone of the node.
+	nt.trackZone(node)
 
 	if isCapacityChanged || isSKUChanged {
 		// Only re-calculate cost information if the capacity or the SKU of any node has changed.
@@ -360,6 +429,21 @@ func (nt *NodeTracker) untrackSKU(nodeName string) {
 	}
 }
 
+// untrackZone untracks the availability zone of a node.
+//
+// Note that this method assumes that the access lock has been acquired.
+func (nt *NodeTracker) untrackZone(nodeName string) {
+	zone, found := nt.zoneByNode[nodeName]
+	if found {
+		delete(nt.zoneByNode, nodeName)
+		delete(nt.nodeSetByZone[zone], nodeName)
+		if len(nt.nodeSetByZone[zone]) == 0 {
+			delete(nt.nodeSetByZone, zone)
+		}
+		klog.V(4).InfoS("Untracked the node's availability zone", "zone", zone, "node", nodeName)
+	}
+}
+
 // untrackTotalCapacity untracks the total capacity of a node.
 //
 // Note that this method assumes that the access lock has been acquired.
@@ -370,7 +454,7 @@ func (nt *NodeTracker) untrackTotalCapacity(nodeName string) {
 			c := rc[rn]
 			tc := nt.totalCapacity[rn]
 			tc.Sub(c)
-			nt.totalCapacity[rn] = tc
+			setQuantity(nt.totalCapacity, rn, tc)
 		}
 		delete(nt.capacityByNode, nodeName)
 		klog.V(4).InfoS("Untracked the node's total capacity", "node", nodeName)
@@ -385,7 +469,7 @@ func (nt *NodeTracker) untrackAllocatableCapacity(nodeName string) {
 			a := ra[rn]
 			ta := nt.totalAllocatable[rn]
 			ta.Sub(a)
-			nt.totalAllocatable[rn] = ta
+			setQuantity(nt.totalAllocatable, rn, ta)
 		}
 
 		delete(nt.allocatableByNode, nodeName)
@@ -404,6 +488,8 @@ func (nt *NodeTracker) Remove(nodeName string) {
 
 	// Untrack the node's SKU.
 	nt.untrackSKU(nodeName)
+	// Untrack the node's availability zone.
+	nt.untrackZone(nodeName)
 
 	// Re-calculate costs.
 	//
@@ -421,6 +507,36 @@ func (nt *NodeTracker) NodeCount() int {
 	return len(nt.allocatableByNode)
 }
 
+// NodeCountBySKU returns the number of nodes of each SKU that the node tracker tracks.
+//
+// Nodes with no SKU information are not counted.
+func (nt *NodeTracker) NodeCountBySKU() map[string]int {
+	nt.mu.RLock()
+	defer nt.mu.RUnlock()
+
+	counts := make(map[string]int, len(nt.nodeSetBySKU))
+	for sku, ns := range nt.nodeSetBySKU {
+		if len(sku) == 0 {
+			continue
+		}
+		counts[sku] = len(ns)
+	}
+	return counts
+}
+
+// NodeCountByZone returns the number of nodes in each availability zone that the node tracker
+// tracks.
+func (nt *NodeTracker) NodeCountByZone() map[string]int {
+	nt.mu.RLock()
+	defer nt.mu.RUnlock()
+
+	counts := make(map[string]int, len(nt.nodeSetByZone))
+	for zone, ns := range nt.nodeSetByZone {
+		counts[zone] = len(ns)
+	}
+	return counts
+}
+
 // TotalCapacityFor returns the total capacity of a specific resource that the node
 // tracker tracks.
 func (nt *NodeTracker) TotalCapacityFor(rn corev1.ResourceName) resource.Quantity {
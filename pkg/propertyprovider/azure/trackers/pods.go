@@ -32,7 +32,7 @@ func NewPodTracker() *PodTracker {
 	}
 
 	for _, rn := range supportedResourceNames {
-		pt.totalRequested[rn] = resource.Quantity{}
+		setQuantity(pt.totalRequested, rn, resource.Quantity{})
 	}
 
 	return pt
@@ -49,7 +49,7 @@ func (pt *PodTracker) AddOrUpdate(pod *corev1.Pod) {
 		for _, rn := range supportedResourceNames {
 			r := requestsAcrossAllContainers[rn]
 			r.Add(container.Resources.Requests[rn])
-			requestsAcrossAllContainers[rn] = r
+			setQuantity(requestsAcrossAllContainers, rn, r)
 		}
 	}
 
@@ -71,10 +71,10 @@ func (pt *PodTracker) AddOrUpdate(pod *corev1.Pod) {
 				tr := pt.totalRequested[rn]
 				tr.Sub(r1)
 				tr.Add(r2)
-				pt.totalRequested[rn] = tr
+				setQuantity(pt.totalRequested, rn, tr)
 
 				// Update the tracked requested resources of the pod.
-				rp[rn] = r2
+				setQuantity(rp, rn, r2)
 			}
 		}
 	} else {
@@ -83,11 +83,11 @@ func (pt *PodTracker) AddOrUpdate(pod *corev1.Pod) {
 		// The pod's requested resources have not been tracked.
 		for _, rn := range supportedResourceNames {
 			r := requestsAcrossAllContainers[rn]
-			rp[rn] = r
+			setQuantity(rp, rn, r)
 
 			tr := pt.totalRequested[rn]
 			tr.Add(r)
-			pt.totalRequested[rn] = tr
+			setQuantity(pt.totalRequested, rn, tr)
 		}
 
 		pt.requestedByPod[podIdentifier] = rp
@@ -106,7 +106,7 @@ func (pt *PodTracker) Remove(podIdentifier string) {
 			r := rp[rn]
 			tr := pt.totalRequested[rn]
 			tr.Sub(r)
-			pt.totalRequested[rn] = tr
+			setQuantity(pt.totalRequested, rn, tr)
 		}
 
 		delete(pt.requestedByPod, podIdentifier)
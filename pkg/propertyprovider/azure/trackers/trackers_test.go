@@ -40,6 +40,9 @@ const (
 	nodeSKU2 = "Standard_2"
 	nodeSKU3 = "Standard_3"
 	nodeSKU4 = "Standard_4"
+
+	zone1 = "eastus-1"
+	zone2 = "eastus-2"
 )
 
 var (
@@ -969,6 +972,201 @@ func TestNodeTrackerUntrackSKU(t *testing.T) {
 	}
 }
 
+// TestNodeTrackerTrackZone tests the trackZone method of the NodeTracker.
+func TestNodeTrackerTrackZone(t *testing.T) {
+	testCases := []struct {
+		name              string
+		nt                *NodeTracker
+		node              *corev1.Node
+		wantZoneByNode    map[string]string
+		wantNodeSetByZone map[string]NodeSet
+	}{
+		{
+			name: "new node in a new zone",
+			nt:   &NodeTracker{},
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nodeName1,
+					Labels: map[string]string{
+						corev1.LabelTopologyZone: zone1,
+					},
+				},
+			},
+			wantZoneByNode: map[string]string{
+				nodeName1: zone1,
+			},
+			wantNodeSetByZone: map[string]NodeSet{
+				zone1: {
+					nodeName1: true,
+				},
+			},
+		},
+		{
+			name: "new node in a known zone",
+			nt: &NodeTracker{
+				zoneByNode: map[string]string{
+					nodeName1: zone1,
+				},
+				nodeSetByZone: map[string]NodeSet{
+					zone1: {
+						nodeName1: true,
+					},
+				},
+			},
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nodeName2,
+					Labels: map[string]string{
+						corev1.LabelTopologyZone: zone1,
+					},
+				},
+			},
+			wantZoneByNode: map[string]string{
+				nodeName1: zone1,
+				nodeName2: zone1,
+			},
+			wantNodeSetByZone: map[string]NodeSet{
+				zone1: {
+					nodeName1: true,
+					nodeName2: true,
+				},
+			},
+		},
+		{
+			name: "new node not in an availability zone",
+			nt:   &NodeTracker{},
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nodeName1,
+					Labels: map[string]string{
+						corev1.LabelTopologyZone: "0",
+					},
+				},
+			},
+		},
+		{
+			name: "known node with zone changed",
+			nt: &NodeTracker{
+				zoneByNode: map[string]string{
+					nodeName1: zone1,
+				},
+				nodeSetByZone: map[string]NodeSet{
+					zone1: {
+						nodeName1: true,
+					},
+				},
+			},
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nodeName1,
+					Labels: map[string]string{
+						corev1.LabelTopologyZone: zone2,
+					},
+				},
+			},
+			wantZoneByNode: map[string]string{
+				nodeName1: zone2,
+			},
+			wantNodeSetByZone: map[string]NodeSet{
+				zone2: {
+					nodeName1: true,
+				},
+			},
+		},
+		{
+			name: "known node with zone label removed",
+			nt: &NodeTracker{
+				zoneByNode: map[string]string{
+					nodeName1: zone1,
+				},
+				nodeSetByZone: map[string]NodeSet{
+					zone1: {
+						nodeName1: true,
+					},
+				},
+			},
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nodeName1,
+				},
+			},
+			wantZoneByNode:    map[string]string{},
+			wantNodeSetByZone: map[string]NodeSet{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.nt.trackZone(tc.node)
+			if diff := cmp.Diff(tc.nt.zoneByNode, tc.wantZoneByNode); diff != "" {
+				t.Errorf("trackZone() zoneByNode diff (-got, +want):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.nt.nodeSetByZone, tc.wantNodeSetByZone); diff != "" {
+				t.Errorf("trackZone() nodeSetByZone diff (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestNodeTrackerNodeCountBySKUAndZone tests the NodeCountBySKU and NodeCountByZone methods of the NodeTracker.
+func TestNodeTrackerNodeCountBySKUAndZone(t *testing.T) {
+	nt := NewNodeTracker(&dummyPricingProvider{})
+	nodes := []*corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName1,
+				Labels: map[string]string{
+					AKSClusterNodeSKULabelName: nodeSKU1,
+					corev1.LabelTopologyZone:   zone1,
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName2,
+				Labels: map[string]string{
+					AKSClusterNodeSKULabelName: nodeSKU1,
+					corev1.LabelTopologyZone:   zone2,
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName3,
+				Labels: map[string]string{
+					AKSClusterNodeSKULabelName: nodeSKU2,
+					corev1.LabelTopologyZone:   zone2,
+				},
+			},
+		},
+		{
+			// A node with no SKU or zone information.
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName4,
+			},
+		},
+	}
+	for _, n := range nodes {
+		nt.AddOrUpdate(n)
+	}
+	nt.Remove(nodeName2)
+
+	wantNodeCountBySKU := map[string]int{
+		nodeSKU1: 1,
+		nodeSKU2: 1,
+	}
+	if diff := cmp.Diff(nt.NodeCountBySKU(), wantNodeCountBySKU); diff != "" {
+		t.Errorf("NodeCountBySKU() diff (-got, +want):\n%s", diff)
+	}
+	wantNodeCountByZone := map[string]int{
+		zone1: 1,
+		zone2: 1,
+	}
+	if diff := cmp.Diff(nt.NodeCountByZone(), wantNodeCountByZone); diff != "" {
+		t.Errorf("NodeCountByZone() diff (-got, +want):\n%s", diff)
+	}
+}
+
 // TestNodeTrackerUntrackAllocatableCapacity tests the UntrackAllocatableCapacity method of the NodeTracker.
 func TestNodeTrackerUntrackAllocatableCapacity(t *testing.T) {
 	testCases := []struct {
@@ -1535,6 +1733,100 @@ func TestNodeTrackerAddOrUpdateNode(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "can track GPUs only on the nodes that have them",
+			nt:   NewNodeTracker(&dummyPricingProvider{}),
+			nodes: []*corev1.Node{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: nodeName1,
+						Labels: map[string]string{
+							AKSClusterNodeSKULabelName: nodeSKU1,
+						},
+					},
+					Spec: corev1.NodeSpec{},
+					Status: corev1.NodeStatus{
+						Capacity: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("16Gi"),
+							nvidiaGPUResourceName: resource.MustParse("2"),
+						},
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("3"),
+							corev1.ResourceMemory: resource.MustParse("15Gi"),
+							nvidiaGPUResourceName: resource.MustParse("2"),
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: nodeName2,
+						Labels: map[string]string{
+							AKSClusterNodeSKULabelName: nodeSKU1,
+						},
+					},
+					Spec: corev1.NodeSpec{},
+					Status: corev1.NodeStatus{
+						Capacity: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("16Gi"),
+						},
+						Allocatable: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("3"),
+							corev1.ResourceMemory: resource.MustParse("15Gi"),
+						},
+					},
+				},
+			},
+			wantNT: &NodeTracker{
+				totalCapacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("8"),
+					corev1.ResourceMemory: resource.MustParse("32Gi"),
+					nvidiaGPUResourceName: resource.MustParse("2"),
+				},
+				totalAllocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("6"),
+					corev1.ResourceMemory: resource.MustParse("30Gi"),
+					nvidiaGPUResourceName: resource.MustParse("2"),
+				},
+				capacityByNode: map[string]corev1.ResourceList{
+					nodeName1: {
+						corev1.ResourceCPU:    resource.MustParse("4"),
+						corev1.ResourceMemory: resource.MustParse("16Gi"),
+						nvidiaGPUResourceName: resource.MustParse("2"),
+					},
+					nodeName2: {
+						corev1.ResourceCPU:    resource.MustParse("4"),
+						corev1.ResourceMemory: resource.MustParse("16Gi"),
+					},
+				},
+				allocatableByNode: map[string]corev1.ResourceList{
+					nodeName1: {
+						corev1.ResourceCPU:    resource.MustParse("3"),
+						corev1.ResourceMemory: resource.MustParse("15Gi"),
+						nvidiaGPUResourceName: resource.MustParse("2"),
+					},
+					nodeName2: {
+						corev1.ResourceCPU:    resource.MustParse("3"),
+						corev1.ResourceMemory: resource.MustParse("15Gi"),
+					},
+				},
+				nodeSetBySKU: map[string]NodeSet{
+					nodeSKU1: {
+						nodeName1: true,
+						nodeName2: true,
+					},
+				},
+				skuByNode: map[string]string{
+					nodeName1: nodeSKU1,
+					nodeName2: nodeSKU1,
+				},
+				costs: &costInfo{
+					perCPUCoreHourlyCost:  0.25,
+					perGBMemoryHourlyCost: 0.0625,
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -5,6 +5,12 @@ Licensed under the MIT license.
 
 package propertyprovider
 
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
 const (
 	// A list of property names that should be supported by every property provider and
 	// is available out of the box in Fleet without any property provider configuration.
@@ -12,6 +18,15 @@ const (
 	// The non-resource properties.
 	// NodeCountProperty is a property that describes the number of nodes in the cluster.
 	NodeCountProperty = "kubernetes-fleet.io/node-count"
+	// KubernetesMajorVersionProperty, KubernetesMinorVersionProperty, and KubernetesPatchVersionProperty
+	// are properties that describe the major, minor, and patch versions of Kubernetes that the
+	// cluster runs respectively, e.g., 1, 29, and 4 for Kubernetes v1.29.4.
+	//
+	// Note that these properties are always reported by the Fleet member agent itself, as the
+	// version information comes directly from the API server of the cluster.
+	KubernetesMajorVersionProperty = "kubernetes-fleet.io/kubernetes-major-version"
+	KubernetesMinorVersionProperty = "kubernetes-fleet.io/kubernetes-minor-version"
+	KubernetesPatchVersionProperty = "kubernetes-fleet.io/kubernetes-patch-version"
 
 	// The resource properties.
 	// Total and allocatable CPU resource properties.
@@ -24,6 +39,12 @@ const (
 	AllocatableMemoryCapacityProperty = "resources.kubernetes-fleet.io/allocatable-memory"
 	AvailableMemoryCapacityProperty   = "resources.kubernetes-fleet.io/available-memory"
 
+	// Total, allocatable, and available GPU resource properties; GPUs are exposed by the NVIDIA
+	// device plugin as the extended resource `nvidia.com/gpu`.
+	TotalGPUCapacityProperty       = "resources.kubernetes-fleet.io/total-nvidia.com_gpu"
+	AllocatableGPUCapacityProperty = "resources.kubernetes-fleet.io/allocatable-nvidia.com_gpu"
+	AvailableGPUCapacityProperty   = "resources.kubernetes-fleet.io/available-nvidia.com_gpu"
+
 	// ResourcePropertyNamePrefix is the prefix (also known as the subdomain) of the label name
 	// associated with all resource properties.
 	ResourcePropertyNamePrefix = "resources.kubernetes-fleet.io/"
@@ -32,4 +53,24 @@ const (
 	TotalCapacityName       = "total"
 	AllocatableCapacityName = "allocatable"
 	AvailableCapacityName   = "available"
+
+	// ExtendedResourceNameSeparator stands in for the slash in the name of an extended resource,
+	// e.g., `nvidia.com/gpu`, when the resource name is part of a resource property name, as a
+	// property name must be a valid label name, which allows only one slash (after the prefix).
+	//
+	// Note that the domain of an extended resource name cannot include this character.
+	ExtendedResourceNameSeparator = "_"
 )
+
+// ResourcePropertyName returns the name of the resource property of a specific capacity type
+// and resource, in the format of `[PREFIX]/[CAPACITY_TYPE]-[RESOURCE_NAME]`, e.g.,
+// `resources.kubernetes-fleet.io/allocatable-nvidia.com_gpu`.
+func ResourcePropertyName(capacityType string, rn corev1.ResourceName) string {
+	return ResourcePropertyNamePrefix + capacityType + "-" + strings.Replace(string(rn), "/", ExtendedResourceNameSeparator, 1)
+}
+
+// ResourceNameFromProperty returns the name of the resource that the resource name segment of
+// a resource property name, i.e., the part after `[CAPACITY_TYPE]-`, refers to.
+func ResourceNameFromProperty(segment string) corev1.ResourceName {
+	return corev1.ResourceName(strings.Replace(segment, ExtendedResourceNameSeparator, "/", 1))
+}
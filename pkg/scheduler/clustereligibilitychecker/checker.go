@@ -12,6 +12,7 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
 
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 )
@@ -34,6 +35,13 @@ type ClusterEligibilityChecker struct {
 	// clusterHealthCheckTimeout is the timeout value this checker uses for checking if a cluster is
 	// still in a healthy state.
 	clusterHealthCheckTimeout time.Duration
+
+	// requiredAgentTypes is the list of agent types, in addition to the member agent, that must have
+	// joined and stay healthy in a cluster for the cluster to be eligible for resource placement.
+	requiredAgentTypes []clusterv1beta1.AgentType
+	// clock is the clock this checker uses for checking heartbeat staleness and how long a cluster
+	// has stayed unhealthy.
+	clock clock.PassiveClock
 }
 
 // checkerOptions is the options for this checker.
@@ -45,6 +53,13 @@ type checkerOptions struct {
 	// clusterHealthCheckTimeout is the timeout value this checker uses for checking if a cluster is
 	// still in a healthy state.
 	clusterHealthCheckTimeout time.Duration
+
+	// requiredAgentTypes is the list of agent types, in addition to the member agent, that must have
+	// joined and stay healthy in a cluster for the cluster to be eligible for resource placement.
+	requiredAgentTypes []clusterv1beta1.AgentType
+	// clock is the clock this checker uses for checking heartbeat staleness and how long a cluster
+	// has stayed unhealthy.
+	clock clock.PassiveClock
 }
 
 // Option helps set up the plugin.
@@ -66,10 +81,28 @@ func WithClusterHealthCheckTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithRequiredAgentTypes sets the agent types, in addition to the member agent, that must have
+// joined and stay healthy in a cluster for the cluster to be eligible for resource placement,
+// e.g., the networking agents.
+func WithRequiredAgentTypes(agentTypes []clusterv1beta1.AgentType) Option {
+	return func(o *checkerOptions) {
+		o.requiredAgentTypes = agentTypes
+	}
+}
+
+// WithClock sets the clock this checker uses for checking heartbeat staleness and how long a cluster
+// has stayed unhealthy; it is mostly useful for verifying the time-based checks with a fake clock.
+func WithClock(c clock.PassiveClock) Option {
+	return func(o *checkerOptions) {
+		o.clock = c
+	}
+}
+
 // defaultPluginOptions is the default options for this plugin.
 var defaultCheckerOptions = checkerOptions{
 	clusterHeartbeatCheckTimeout: defaultClusterHeartbeatCheckTimeout,
 	clusterHealthCheckTimeout:    defaultClusterHealthCheckTimeout,
+	clock:                        clock.RealClock{},
 }
 
 // New returns a new cluster eligibility checker.
@@ -82,6 +115,8 @@ func New(opts ...Option) *ClusterEligibilityChecker {
 	return &ClusterEligibilityChecker{
 		clusterHeartbeatCheckTimeout: options.clusterHeartbeatCheckTimeout,
 		clusterHealthCheckTimeout:    options.clusterHealthCheckTimeout,
+		requiredAgentTypes:           options.requiredAgentTypes,
+		clock:                        options.clock,
 	}
 }
 
@@ -93,46 +128,69 @@ func (checker *ClusterEligibilityChecker) IsEligible(cluster *clusterv1beta1.Mem
 		return false, "cluster has left the fleet"
 	}
 
-	// Note that the following checks are performed against one specific agent, i.e., the member
-	// agent, which is critical for the work orchestration related tasks in the fleet; non-related
-	// agents (e.g., networking) are not accounted for in this plugin.
+	// Note that the following checks are performed against the member agent, which is critical
+	// for the work orchestration related tasks in the fleet, and the agents that the fleet
+	// administrators require explicitly (e.g., networking); other agents are not accounted for
+	// in this checker.
+	if eligible, reason := checker.checkAgent(cluster, clusterv1beta1.MemberAgent); !eligible {
+		return false, reason
+	}
+	for _, agentType := range checker.requiredAgentTypes {
+		if agentType == clusterv1beta1.MemberAgent {
+			continue
+		}
+		if eligible, reason := checker.checkAgent(cluster, agentType); !eligible {
+			return false, reason
+		}
+	}
+
+	return true, ""
+}
+
+// checkAgent returns if an agent in a cluster has joined the fleet and stays healthy; if not, it
+// will also return the reason.
+func (checker *ClusterEligibilityChecker) checkAgent(cluster *clusterv1beta1.MemberCluster, agentType clusterv1beta1.AgentType) (ok bool, reason string) {
+	agentName := string(agentType)
+	if agentType == clusterv1beta1.MemberAgent {
+		agentName = "member agent"
+	}
 
 	// Filter out clusters that are no longer connected to the fleet, i.e., its heartbeat signals
 	// have stopped for a prolonged period of time.
-	memberAgentStatus := cluster.GetAgentStatus(clusterv1beta1.MemberAgent)
-	if memberAgentStatus == nil {
-		// The member agent has not updated its status with the hub cluster yet.
-		return false, "cluster is not connected to the fleet: member agent not online yet"
+	agentStatus := cluster.GetAgentStatus(agentType)
+	if agentStatus == nil {
+		// The agent has not updated its status with the hub cluster yet.
+		return false, fmt.Sprintf("cluster is not connected to the fleet: %s not online yet", agentName)
 	}
 
-	sinceLastHeartbeat := time.Since(memberAgentStatus.LastReceivedHeartbeat.Time)
+	sinceLastHeartbeat := checker.clock.Since(agentStatus.LastReceivedHeartbeat.Time)
 	if sinceLastHeartbeat > checker.clusterHeartbeatCheckTimeout {
-		// The member agent has not sent heartbeat signals for a prolonged period of time.
+		// The agent has not sent heartbeat signals for a prolonged period of time.
 		//
 		// Note that this plugin assumes minimum clock drifts between clusters in the fleet.
-		return false, fmt.Sprintf("cluster is not connected to the fleet: no recent heartbeat signals (last received %.2f minutes ago)", sinceLastHeartbeat.Minutes())
+		return false, fmt.Sprintf("cluster is not connected to the fleet: no recent heartbeat signals from %s (last received %.2f minutes ago)", agentName, sinceLastHeartbeat.Minutes())
 	}
 
-	memberAgentJoinedCond := cluster.GetAgentCondition(clusterv1beta1.MemberAgent, clusterv1beta1.AgentJoined)
-	if memberAgentJoinedCond == nil || memberAgentJoinedCond.Status != metav1.ConditionTrue {
-		// The member agent has not joined yet; i.e., some of the controllers have not been
+	joinedCond := cluster.GetAgentCondition(agentType, clusterv1beta1.AgentJoined)
+	if joinedCond == nil || joinedCond.Status != metav1.ConditionTrue {
+		// The agent has not joined yet; i.e., some of the controllers have not been
 		// spun up.
 		//
 		// Note that here no generation check is performed, as
 		// a) the member cluster object spec is most of the time not touched after creation; and
 		// b) as long as the heartbeat signal does not timeout, a little drift in generations
 		//    should not exclude a cluster from resource scheduling.
-		return false, "cluster is not connected to the fleet: member agent not joined yet"
+		return false, fmt.Sprintf("cluster is not connected to the fleet: %s not joined yet", agentName)
 	}
 
-	memberAgentHealthyCond := cluster.GetAgentCondition(clusterv1beta1.MemberAgent, clusterv1beta1.AgentHealthy)
-	if memberAgentHealthyCond == nil {
+	healthyCond := cluster.GetAgentCondition(agentType, clusterv1beta1.AgentHealthy)
+	if healthyCond == nil {
 		// The health condition is absent.
-		return false, "cluster is not connected to the fleet: health condition from member agent is not available"
+		return false, fmt.Sprintf("cluster is not connected to the fleet: health condition from %s is not available", agentName)
 	}
 
-	sinceLastTransition := time.Since(memberAgentHealthyCond.LastTransitionTime.Time)
-	if memberAgentHealthyCond.Status != metav1.ConditionTrue && sinceLastTransition > checker.clusterHealthCheckTimeout {
+	sinceLastTransition := checker.clock.Since(healthyCond.LastTransitionTime.Time)
+	if healthyCond.Status != metav1.ConditionTrue && sinceLastTransition > checker.clusterHealthCheckTimeout {
 		// The cluster health check fails.
 		//
 		// Note that sporadic (isolated) health check failures will not preclude a cluster.
@@ -145,7 +203,7 @@ func (checker *ClusterEligibilityChecker) IsEligible(cluster *clusterv1beta1.Mem
 		// Note that this plugin assumes minimum clock drifts between clusters in the fleet.
 		//
 		// Also note that no generation check is performed here, for the same reason as above.
-		return false, fmt.Sprintf("cluster is not connected to the fleet: unhealthy for a prolonged period of time (last transitioned %.2f minutes ago)", sinceLastTransition.Minutes())
+		return false, fmt.Sprintf("cluster is not connected to the fleet: unhealthy for a prolonged period of time as reported by %s (last transitioned %.2f minutes ago)", agentName, sinceLastTransition.Minutes())
 	}
 
 	return true, ""
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
 
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 )
@@ -238,3 +239,189 @@ func TestIsClusterEligible(t *testing.T) {
 		})
 	}
 }
+
+// TestIsClusterEligibleWithRequiredAgentTypes tests the IsClusterEligible function with additional required agent types.
+func TestIsClusterEligibleWithRequiredAgentTypes(t *testing.T) {
+	checker := New(WithRequiredAgentTypes([]clusterv1beta1.AgentType{clusterv1beta1.MemberAgent, clusterv1beta1.ServiceExportImportAgent}))
+	healthyAgentStatus := func(agentType clusterv1beta1.AgentType) clusterv1beta1.AgentStatus {
+		return clusterv1beta1.AgentStatus{
+			Type: agentType,
+			Conditions: []metav1.Condition{
+				{
+					Type:   string(clusterv1beta1.AgentJoined),
+					Status: metav1.ConditionTrue,
+				},
+				{
+					Type:   string(clusterv1beta1.AgentHealthy),
+					Status: metav1.ConditionTrue,
+				},
+			},
+			LastReceivedHeartbeat: metav1.NewTime(time.Now()),
+		}
+	}
+	testCases := []struct {
+		name             string
+		agentStatus      []clusterv1beta1.AgentStatus
+		wantEligible     bool
+		wantReasonPrefix string
+	}{
+		{
+			name:             "required agent not online",
+			agentStatus:      []clusterv1beta1.AgentStatus{healthyAgentStatus(clusterv1beta1.MemberAgent)},
+			wantReasonPrefix: "cluster is not connected to the fleet: ServiceExportImportAgent not online yet",
+		},
+		{
+			name: "required agent not joined",
+			agentStatus: []clusterv1beta1.AgentStatus{
+				healthyAgentStatus(clusterv1beta1.MemberAgent),
+				{
+					Type: clusterv1beta1.ServiceExportImportAgent,
+					Conditions: []metav1.Condition{
+						{
+							Type:   string(clusterv1beta1.AgentJoined),
+							Status: metav1.ConditionFalse,
+						},
+					},
+					LastReceivedHeartbeat: metav1.NewTime(time.Now()),
+				},
+			},
+			wantReasonPrefix: "cluster is not connected to the fleet: ServiceExportImportAgent not joined yet",
+		},
+		{
+			name: "member agent not online",
+			agentStatus: []clusterv1beta1.AgentStatus{
+				healthyAgentStatus(clusterv1beta1.ServiceExportImportAgent),
+			},
+			wantReasonPrefix: "cluster is not connected to the fleet: member agent not online yet",
+		},
+		{
+			name: "all required agents joined and healthy",
+			agentStatus: []clusterv1beta1.AgentStatus{
+				healthyAgentStatus(clusterv1beta1.MemberAgent),
+				healthyAgentStatus(clusterv1beta1.ServiceExportImportAgent),
+			},
+			wantEligible: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					AgentStatus: tc.agentStatus,
+				},
+			}
+			eligible, reason := checker.IsEligible(cluster)
+			if eligible != tc.wantEligible {
+				t.Errorf("IsClusterEligible() eligible = %t, want %t", eligible, tc.wantEligible)
+			}
+			if !eligible && !strings.HasPrefix(reason, tc.wantReasonPrefix) {
+				t.Errorf("IsClusterEligible() reason = %s, want %s", reason, tc.wantReasonPrefix)
+			}
+		})
+	}
+}
+
+// TestIsClusterEligibleWithClock tests the IsClusterEligible function at the boundaries of the heartbeat
+// and health check timeouts, with a fake clock.
+func TestIsClusterEligibleWithClock(t *testing.T) {
+	clusterHeartbeatCheckTimeout := time.Minute * 15
+	clusterHealthCheckTimeout := time.Minute * 10
+	// Use a fixed point in time so that the boundaries can be verified precisely.
+	lastSeen := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name             string
+		sinceLastSeen    time.Duration
+		healthStatus     metav1.ConditionStatus
+		wantEligible     bool
+		wantReasonPrefix string
+	}{
+		{
+			name:          "heartbeat received just now",
+			sinceLastSeen: 0,
+			healthStatus:  metav1.ConditionTrue,
+			wantEligible:  true,
+		},
+		{
+			name:          "heartbeat staleness reaches the timeout",
+			sinceLastSeen: clusterHeartbeatCheckTimeout,
+			healthStatus:  metav1.ConditionTrue,
+			wantEligible:  true,
+		},
+		{
+			name:             "heartbeat staleness exceeds the timeout",
+			sinceLastSeen:    clusterHeartbeatCheckTimeout + time.Nanosecond,
+			healthStatus:     metav1.ConditionTrue,
+			wantReasonPrefix: "cluster is not connected to the fleet: no recent heartbeat signals from member agent",
+		},
+		{
+			name:          "healthy for longer than the health check timeout",
+			sinceLastSeen: clusterHealthCheckTimeout + time.Minute,
+			healthStatus:  metav1.ConditionTrue,
+			wantEligible:  true,
+		},
+		{
+			name:          "unhealthy for exactly the health check timeout",
+			sinceLastSeen: clusterHealthCheckTimeout,
+			healthStatus:  metav1.ConditionFalse,
+			wantEligible:  true,
+		},
+		{
+			name:             "unhealthy for longer than the health check timeout",
+			sinceLastSeen:    clusterHealthCheckTimeout + time.Nanosecond,
+			healthStatus:     metav1.ConditionFalse,
+			wantReasonPrefix: "cluster is not connected to the fleet: unhealthy for a prolonged period of time",
+		},
+		{
+			name:             "health status unknown for longer than the health check timeout",
+			sinceLastSeen:    clusterHealthCheckTimeout + time.Nanosecond,
+			healthStatus:     metav1.ConditionUnknown,
+			wantReasonPrefix: "cluster is not connected to the fleet: unhealthy for a prolonged period of time",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := New(
+				WithClusterHeartbeatCheckTimeout(clusterHeartbeatCheckTimeout),
+				WithClusterHealthCheckTimeout(clusterHealthCheckTimeout),
+				WithClock(clocktesting.NewFakePassiveClock(lastSeen.Add(tc.sinceLastSeen))),
+			)
+			cluster := &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					AgentStatus: []clusterv1beta1.AgentStatus{
+						{
+							Type: clusterv1beta1.MemberAgent,
+							Conditions: []metav1.Condition{
+								{
+									Type:   string(clusterv1beta1.AgentJoined),
+									Status: metav1.ConditionTrue,
+								},
+								{
+									Type:               string(clusterv1beta1.AgentHealthy),
+									Status:             tc.healthStatus,
+									LastTransitionTime: metav1.NewTime(lastSeen),
+								},
+							},
+							LastReceivedHeartbeat: metav1.NewTime(lastSeen),
+						},
+					},
+				},
+			}
+			eligible, reason := checker.IsEligible(cluster)
+			if eligible != tc.wantEligible {
+				t.Errorf("IsClusterEligible() eligible = %t, want %t", eligible, tc.wantEligible)
+			}
+			if !eligible && !strings.HasPrefix(reason, tc.wantReasonPrefix) {
+				t.Errorf("IsClusterEligible() reason = %s, want %s", reason, tc.wantReasonPrefix)
+			}
+		})
+	}
+}
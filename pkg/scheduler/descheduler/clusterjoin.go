@@ -0,0 +1,239 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// clusterJoinControllerName is the name of the controller that rebalances placements on cluster joins.
+	clusterJoinControllerName = "descheduler-cluster-join"
+)
+
+// RebalanceOnClusterJoin moves each placement that opts in to rebalancing on cluster joins from one
+// of its clusters to a cluster that has just joined the fleet, if the placement is better off there
+// per its RebalanceOnClusterJoin settings.
+//
+// Unlike the periodic runs, the moves are not subject to the budget of moves per run, as there is
+// at most one move per placement for each join.
+func (d *Descheduler) RebalanceOnClusterJoin(ctx context.Context, clusterName string) error {
+	crpList := &placementv1beta1.ClusterResourcePlacementList{}
+	if err := d.client.List(ctx, crpList); err != nil {
+		return controller.NewAPIServerError(true, err)
+	}
+
+	var errs []error
+	for idx := range crpList.Items {
+		crp := &crpList.Items[idx]
+		if crp.Spec.Policy == nil || crp.Spec.Policy.RebalanceOnClusterJoin == nil {
+			continue
+		}
+		if err := d.rebalanceOnClusterJoinFor(ctx, crp, clusterName); err != nil {
+			klog.ErrorS(err, "Failed to rebalance cluster resource placement on cluster join",
+				"clusterResourcePlacement", klog.KObj(crp), "memberCluster", clusterName)
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// rebalanceOnClusterJoinFor moves a placement from one of its clusters to the new cluster, if the
+// new cluster meets either threshold of the placement.
+func (d *Descheduler) rebalanceOnClusterJoinFor(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement, clusterName string) error {
+	ps, err := d.inspect(ctx, crp)
+	if err != nil || ps == nil {
+		return err
+	}
+	// Use the settings the placement has been scheduled with.
+	rebalance := ps.policy.Spec.Policy.RebalanceOnClusterJoin
+	if rebalance == nil || ps.movesInProgress >= d.maxMovesPerPlacement || ps.disruptionsAllowed <= 0 || ps.occupied.Has(clusterName) || len(ps.bound) == 0 {
+		return nil
+	}
+
+	scored, ranks, err := rank(ctx, crp.Name, ps)
+	if err != nil {
+		return err
+	}
+	if _, ok := ranks[clusterName]; !ok {
+		// The new cluster does not pass the Filter stage.
+		return nil
+	}
+	current := rankedBindings(ps.bound, ranks)
+	if len(current) == 0 {
+		return nil
+	}
+
+	var from *placementv1beta1.ClusterResourceBinding
+	if rebalance.TopologyKey != "" && rebalance.MaxSkew != nil {
+		from = bindingToEvenOutSkew(current, scored, ranks, clusterName, rebalance.TopologyKey, int(*rebalance.MaxSkew))
+	}
+	if from == nil {
+		minScoreImprovement := defaultMinScoreImprovement
+		if rebalance.MinScoreImprovement != nil {
+			minScoreImprovement = int(*rebalance.MinScoreImprovement)
+		}
+		worst := current[0]
+		if ranks[clusterName] < ranks[worst.Spec.TargetCluster] &&
+			improvement(scored[ranks[worst.Spec.TargetCluster]].Score, scored[ranks[clusterName]].Score) >= minScoreImprovement {
+			from = worst
+		}
+	}
+	if from == nil {
+		return nil
+	}
+	return d.move(ctx, crp, ps, []*placementv1beta1.ClusterResourceBinding{from}, []string{clusterName})
+}
+
+// bindingToEvenOutSkew returns the binding to move to the new cluster, so as to even out the numbers
+// of clusters the placement is bound to across the topology domains; it returns nil if the skew is
+// tolerated, or the new cluster is not in a domain with the fewest clusters of the placement.
+//
+// The domains are those of the clusters that pass the Filter stage; the binding to move is the
+// worst-ranked one in a domain with the most clusters of the placement, which still ranks below
+// the new cluster, so that the scheduler picks the new cluster in its place.
+func bindingToEvenOutSkew(
+	current []*placementv1beta1.ClusterResourceBinding,
+	scored framework.ScoredClusters,
+	ranks map[string]int,
+	clusterName, topologyKey string,
+	maxSkew int,
+) *placementv1beta1.ClusterResourceBinding {
+	domainOf := func(name string) (string, bool) {
+		domain, ok := scored[ranks[name]].Cluster.Labels[topologyKey]
+		return domain, ok
+	}
+	newDomain, ok := domainOf(clusterName)
+	if !ok {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, sc := range scored {
+		if domain, ok := sc.Cluster.Labels[topologyKey]; ok {
+			counts[domain] += 0
+		}
+	}
+	for _, binding := range current {
+		if domain, ok := domainOf(binding.Spec.TargetCluster); ok {
+			counts[domain]++
+		}
+	}
+	minCount, maxCount := math.MaxInt, 0
+	for _, count := range counts {
+		minCount = min(minCount, count)
+		maxCount = max(maxCount, count)
+	}
+	if maxCount-minCount <= maxSkew || counts[newDomain] != minCount {
+		return nil
+	}
+
+	// The bindings are sorted worst first.
+	for _, binding := range current {
+		if domain, ok := domainOf(binding.Spec.TargetCluster); ok && counts[domain] == maxCount &&
+			ranks[clusterName] < ranks[binding.Spec.TargetCluster] {
+			return binding
+		}
+	}
+	return nil
+}
+
+// ClusterJoinReconciler reconciles member clusters that join the fleet (or become eligible for
+// resource placement), and has the descheduler rebalance the placements that opt in to it.
+type ClusterJoinReconciler struct {
+	// Client is a (cached) client for accessing the Kubernetes API server.
+	Client client.Client
+
+	// Descheduler moves the placements to the new clusters.
+	Descheduler *Descheduler
+
+	// ClusterEligibilityChecker helps check if a cluster is eligible for resource placement.
+	ClusterEligibilityChecker *clustereligibilitychecker.ClusterEligibilityChecker
+}
+
+// Reconcile reconciles a member cluster.
+func (r *ClusterJoinReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	memberClusterRef := klog.KRef(req.Namespace, req.Name)
+	startTime := time.Now()
+	klog.V(2).InfoS("Reconciliation starts", "memberCluster", memberClusterRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("Reconciliation ends", "memberCluster", memberClusterRef, "latency", latency)
+	}()
+
+	cluster := &clusterv1beta1.MemberCluster{}
+	if err := r.Client.Get(ctx, req.NamespacedName, cluster); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get member cluster", "memberCluster", memberClusterRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	if !cluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	if eligible, reason := r.ClusterEligibilityChecker.IsEligible(cluster); !eligible {
+		klog.V(2).InfoS("Member cluster is not eligible for resource placement", "memberCluster", memberClusterRef, "reason", reason)
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.Descheduler.RebalanceOnClusterJoin(ctx, cluster.Name); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager builds a controller with ClusterJoinReconciler and sets it up with a controller manager.
+func (r *ClusterJoinReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	customPredicate := predicate.Funcs{
+		CreateFunc: func(_ event.CreateEvent) bool {
+			// Newly created clusters are not yet eligible for resource placement; and on restarts,
+			// the clusters have joined already.
+			return false
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(_ event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCluster, oldOk := e.ObjectOld.(*clusterv1beta1.MemberCluster)
+			newCluster, newOk := e.ObjectNew.(*clusterv1beta1.MemberCluster)
+			if !oldOk || !newOk {
+				err := controller.NewUnexpectedBehaviorError(fmt.Errorf("failed to cast runtime objects in update event to member cluster objects"))
+				klog.ErrorS(err, "Failed to process update event")
+				return false
+			}
+			oldEligible, _ := r.ClusterEligibilityChecker.IsEligible(oldCluster)
+			newEligible, _ := r.ClusterEligibilityChecker.IsEligible(newCluster)
+			return !oldEligible && newEligible
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(clusterJoinControllerName).
+		For(&clusterv1beta1.MemberCluster{}).
+		WithEventFilter(customPredicate).
+		Complete(r)
+}
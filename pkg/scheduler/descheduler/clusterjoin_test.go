@@ -0,0 +1,333 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+)
+
+// newClusterJoinTestObjects returns the test objects, with the placement opting in to rebalancing
+// on cluster joins with the given settings, and the good and poor clusters in the east region and
+// the fair cluster in the west region.
+func newClusterJoinTestObjects(rebalance *placementv1beta1.RebalanceOnClusterJoin) []client.Object {
+	objs := newTestObjects()
+	regions := map[string]string{goodCluster: "east", fairCluster: "west", poorCluster: "east"}
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *placementv1beta1.ClusterResourcePlacement:
+			// The placement shares its policy with the policy snapshot.
+			o.Spec.Policy.RebalanceOnClusterJoin = rebalance
+		case *clusterv1beta1.MemberCluster:
+			o.Labels["region"] = regions[o.Name]
+		}
+	}
+	return objs
+}
+
+// TestRebalanceOnClusterJoin tests the RebalanceOnClusterJoin method.
+func TestRebalanceOnClusterJoin(t *testing.T) {
+	testCases := []struct {
+		name       string
+		rebalance  *placementv1beta1.RebalanceOnClusterJoin
+		bindings   []client.Object
+		newCluster string
+		wantStates map[string]placementv1beta1.BindingState
+		wantQueued bool
+	}{
+		{
+			name: "not opted in",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			newCluster: goodCluster,
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+		},
+		{
+			name:      "new cluster scores better, move to it",
+			rebalance: &placementv1beta1.RebalanceOnClusterJoin{},
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			newCluster: goodCluster,
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateUnscheduled},
+			wantQueued: true,
+		},
+		{
+			name:      "improvement below the threshold",
+			rebalance: &placementv1beta1.RebalanceOnClusterJoin{MinScoreImprovement: ptr.To(int32(50))},
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                fairCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			newCluster: goodCluster,
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+		},
+		{
+			name:      "new cluster scores worse",
+			rebalance: &placementv1beta1.RebalanceOnClusterJoin{MinScoreImprovement: ptr.To(int32(1))},
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                goodCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			newCluster: fairCluster,
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+		},
+		{
+			name: "skew above the max, move from the most loaded region",
+			rebalance: &placementv1beta1.RebalanceOnClusterJoin{
+				MinScoreImprovement: ptr.To(int32(50)),
+				TopologyKey:         "region",
+				MaxSkew:             ptr.To(int32(1)),
+			},
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: altBindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                goodCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			newCluster: fairCluster,
+			wantStates: map[string]placementv1beta1.BindingState{
+				bindingName:    placementv1beta1.BindingStateUnscheduled,
+				altBindingName: placementv1beta1.BindingStateBound,
+			},
+			wantQueued: true,
+		},
+		{
+			name: "skew within the max",
+			rebalance: &placementv1beta1.RebalanceOnClusterJoin{
+				MinScoreImprovement: ptr.To(int32(50)),
+				TopologyKey:         "region",
+				MaxSkew:             ptr.To(int32(2)),
+			},
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: altBindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                goodCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			newCluster: fairCluster,
+			wantStates: map[string]placementv1beta1.BindingState{
+				bindingName:    placementv1beta1.BindingStateBound,
+				altBindingName: placementv1beta1.BindingStateBound,
+			},
+		},
+		{
+			name:      "move in progress",
+			rebalance: &placementv1beta1.RebalanceOnClusterJoin{},
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: altBindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+						Annotations: map[string]string{
+							placementv1beta1.UnscheduledReasonAnnotation: placementv1beta1.UnscheduledReasonRebalanced,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateUnscheduled,
+						TargetCluster:                fairCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			newCluster: goodCluster,
+			wantStates: map[string]placementv1beta1.BindingState{
+				bindingName:    placementv1beta1.BindingStateBound,
+				altBindingName: placementv1beta1.BindingStateUnscheduled,
+			},
+		},
+		{
+			name:      "blocked by the disruption budget",
+			rebalance: &placementv1beta1.RebalanceOnClusterJoin{},
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+					Status: placementv1beta1.ResourceBindingStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:               string(placementv1beta1.ResourceBindingAvailable),
+								Status:             metav1.ConditionTrue,
+								Reason:             "Available",
+								LastTransitionTime: metav1.Now(),
+							},
+						},
+					},
+				},
+				&placementv1alpha1.ClusterResourcePlacementDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1alpha1.PlacementDisruptionBudgetSpec{
+						MinAvailable: ptr.To(intstr.FromInt32(1)),
+					},
+				},
+			},
+			newCluster: goodCluster,
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(serviceScheme(t)).
+				WithObjects(append(newClusterJoinTestObjects(tc.rebalance), tc.bindings...)...).
+				Build()
+			schedulingQueue := queue.NewSimpleClusterResourcePlacementSchedulingQueue()
+			defer schedulingQueue.Close()
+			d := New(fakeClient, schedulingQueue, []framework.Framework{newTestFramework(fakeClient)})
+
+			if err := d.RebalanceOnClusterJoin(ctx, tc.newCluster); err != nil {
+				t.Fatalf("RebalanceOnClusterJoin() = %v, want no error", err)
+			}
+
+			gotStates := map[string]placementv1beta1.BindingState{}
+			bindingList := &placementv1beta1.ClusterResourceBindingList{}
+			if err := fakeClient.List(ctx, bindingList); err != nil {
+				t.Fatalf("List() = %v, want no error", err)
+			}
+			for _, binding := range bindingList.Items {
+				gotStates[binding.Name] = binding.Spec.State
+			}
+			if diff := cmp.Diff(gotStates, tc.wantStates); diff != "" {
+				t.Errorf("binding states diff (-got, +want): %s", diff)
+			}
+			if gotQueued := schedulingQueue.Len() == 1; gotQueued != tc.wantQueued {
+				t.Errorf("placement queued = %t, want %t", gotQueued, tc.wantQueued)
+			}
+		})
+	}
+}
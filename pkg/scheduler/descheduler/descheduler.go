@@ -0,0 +1,382 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package descheduler features a component that periodically re-scores the clusters picked for
+// placements, and moves placements to clusters that have become significantly better; a controller
+// that moves placements which opt in to it to clusters that join the fleet; and a controller that
+// moves placements off clusters that are cordoned and drained.
+package descheduler
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+	bindingutils "go.goms.io/fleet/pkg/utils/binding"
+	"go.goms.io/fleet/pkg/utils/condition"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// defaultInterval is the default interval between two descheduling runs.
+	defaultInterval = 10 * time.Minute
+	// defaultMinScoreImprovement is the default minimum score improvement for a move.
+	defaultMinScoreImprovement = 20
+	// defaultMaxMovesPerPlacement is the default max number of moves in progress for a placement.
+	defaultMaxMovesPerPlacement = 1
+	// defaultMaxMovesPerRun is the default max number of moves started in one descheduling run.
+	defaultMaxMovesPerRun = 10
+)
+
+// Descheduler periodically re-scores the clusters that placements of the PickN placement type have
+// been bound to, with the same scheduling frameworks as the scheduler, and moves a placement from a
+// cluster to another one that scores significantly better, if any.
+//
+// To move a placement, the descheduler marks the binding on the worse cluster as unscheduled, with
+// the reason UnscheduledReasonRebalanced, and has the scheduler pick a new cluster for the placement;
+// as the better cluster ranks above the worse one, the scheduler picks it (or an even better one).
+// The moves are subject to a disruption budget: a placement can only have a limited number of moves
+// in progress, i.e., rebalanced bindings whose resources are yet to be removed, and only a limited
+// number of moves are started in each run. A placement with a ClusterResourcePlacementDisruptionBudget
+// can only move as many bindings as the disruption budget allows, the same as evictions.
+type Descheduler struct {
+	client client.Client
+	queue  queue.ClusterResourcePlacementSchedulingQueueWriter
+
+	// framework is the default scheduling framework, i.e., the framework for placements that do
+	// not name a scheduling profile; frameworks is all the frameworks, keyed by their profile names.
+	framework  framework.Framework
+	frameworks map[string]framework.Framework
+
+	interval             time.Duration
+	minScoreImprovement  int
+	maxMovesPerPlacement int
+	maxMovesPerRun       int
+}
+
+// Option is the function that configures the descheduler.
+type Option func(*Descheduler)
+
+// WithInterval sets the interval between two descheduling runs.
+func WithInterval(interval time.Duration) Option {
+	return func(d *Descheduler) {
+		d.interval = interval
+	}
+}
+
+// WithMinScoreImprovement sets how much better, in the sum of the affinity and topology spread scores,
+// a cluster must score than a cluster that a placement has been bound to, for the placement to move.
+func WithMinScoreImprovement(improvement int) Option {
+	return func(d *Descheduler) {
+		d.minScoreImprovement = improvement
+	}
+}
+
+// WithMaxMovesPerPlacement sets the max number of moves a placement can have in progress.
+func WithMaxMovesPerPlacement(maxMoves int) Option {
+	return func(d *Descheduler) {
+		d.maxMovesPerPlacement = maxMoves
+	}
+}
+
+// WithMaxMovesPerRun sets the max number of moves the descheduler starts in one run, across all
+// placements.
+func WithMaxMovesPerRun(maxMoves int) Option {
+	return func(d *Descheduler) {
+		d.maxMovesPerRun = maxMoves
+	}
+}
+
+// New returns a new descheduler.
+//
+// The frameworks should be the ones in use by the scheduler, so that the bindings the descheduler
+// unschedules are visible to the scheduling cycles right away; the first one is the default.
+func New(hubClient client.Client, schedulingQueue queue.ClusterResourcePlacementSchedulingQueueWriter, frameworks []framework.Framework, opts ...Option) *Descheduler {
+	d := &Descheduler{
+		client:               hubClient,
+		queue:                schedulingQueue,
+		frameworks:           make(map[string]framework.Framework, len(frameworks)),
+		interval:             defaultInterval,
+		minScoreImprovement:  defaultMinScoreImprovement,
+		maxMovesPerPlacement: defaultMaxMovesPerPlacement,
+		maxMovesPerRun:       defaultMaxMovesPerRun,
+	}
+	for idx, fw := range frameworks {
+		if idx == 0 {
+			d.framework = fw
+		}
+		d.frameworks[fw.ProfileName()] = fw
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Start runs the descheduler periodically until the context is cancelled.
+func (d *Descheduler) Start(ctx context.Context) error {
+	klog.InfoS("Starting the descheduler", "interval", d.interval, "minScoreImprovement", d.minScoreImprovement,
+		"maxMovesPerPlacement", d.maxMovesPerPlacement, "maxMovesPerRun", d.maxMovesPerRun)
+	wait.UntilWithContext(ctx, d.deschedule, d.interval)
+	klog.InfoS("The descheduler has exited")
+	return nil
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface, so that the descheduler
+// only runs on the leader, alongside the scheduler.
+func (d *Descheduler) NeedLeaderElection() bool {
+	return true
+}
+
+// deschedule runs one round of descheduling over all placements.
+func (d *Descheduler) deschedule(ctx context.Context) {
+	startTime := time.Now()
+	crpList := &placementv1beta1.ClusterResourcePlacementList{}
+	if err := d.client.List(ctx, crpList); err != nil {
+		klog.ErrorS(controller.NewAPIServerError(true, err), "Failed to list cluster resource placements")
+		return
+	}
+
+	budget := d.maxMovesPerRun
+	for idx := range crpList.Items {
+		if budget <= 0 {
+			klog.V(2).InfoS("The descheduler has used up the budget of moves for this run")
+			break
+		}
+		crp := &crpList.Items[idx]
+		moved, err := d.descheduleFor(ctx, crp, budget)
+		if err != nil {
+			klog.ErrorS(err, "Failed to deschedule cluster resource placement", "clusterResourcePlacement", klog.KObj(crp))
+			continue
+		}
+		budget -= moved
+	}
+	klog.V(2).InfoS("Descheduling run ends", "movesStarted", d.maxMovesPerRun-budget, "latency", time.Since(startTime).Milliseconds())
+}
+
+// placementState is what the descheduler knows about a placement that it may move.
+type placementState struct {
+	fw     framework.Framework
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot
+	// bound is the bindings of the placement bound to clusters with the latest policy.
+	bound []*placementv1beta1.ClusterResourceBinding
+	// occupied is the clusters with any binding of the placement, including the ones being removed;
+	// they are not destinations for a move.
+	occupied sets.Set[string]
+	// movesInProgress is the number of rebalanced bindings whose resources are yet to be removed.
+	movesInProgress int
+	// disruptionsAllowed is the number of bindings that can be disrupted per the disruption budget
+	// of the placement, if any.
+	disruptionsAllowed int
+}
+
+// inspect returns the state of a placement, or nil if the placement should be left alone, e.g.,
+// it is not of the PickN placement type, or it is yet to be fully scheduled or rolled out.
+func (d *Descheduler) inspect(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement) (*placementState, error) {
+	crpRef := klog.KObj(crp)
+	if !crp.DeletionTimestamp.IsZero() || crp.Spec.Policy == nil || crp.Spec.Policy.PlacementType != placementv1beta1.PickNPlacementType {
+		// Only placements of the PickN placement type can move; those of the PickAll placement type
+		// are bound to all the matching clusters already.
+		return nil, nil
+	}
+	fw, ok := d.frameworkFor(crp)
+	if !ok {
+		klog.V(2).InfoS("Skipping cluster resource placement with an unknown scheduling profile", "clusterResourcePlacement", crpRef)
+		return nil, nil
+	}
+
+	policy, err := d.lookupLatestPolicySnapshot(ctx, crp)
+	if err != nil || policy == nil {
+		return nil, err
+	}
+	// Leave the placement alone until the scheduler has fully scheduled it with the latest policy.
+	if !condition.IsConditionStatusTrue(policy.GetCondition(string(placementv1beta1.PolicySnapshotScheduled)), policy.Generation) {
+		klog.V(2).InfoS("Skipping cluster resource placement which is not fully scheduled", "clusterResourcePlacement", crpRef)
+		return nil, nil
+	}
+
+	bindingList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := d.client.List(ctx, bindingList, client.MatchingLabels{placementv1beta1.CRPTrackingLabel: crp.Name}); err != nil {
+		return nil, controller.NewAPIServerError(true, err)
+	}
+	ps := &placementState{
+		fw:       fw,
+		policy:   policy,
+		occupied: sets.New[string](),
+	}
+	for idx := range bindingList.Items {
+		binding := &bindingList.Items[idx]
+		ps.occupied.Insert(binding.Spec.TargetCluster)
+		switch {
+		case binding.Spec.State == placementv1beta1.BindingStateUnscheduled &&
+			binding.Annotations[placementv1beta1.UnscheduledReasonAnnotation] == placementv1beta1.UnscheduledReasonRebalanced:
+			ps.movesInProgress++
+		case !binding.DeletionTimestamp.IsZero():
+		case binding.Spec.State == placementv1beta1.BindingStateScheduled:
+			// The placement is still being rolled out; leave it alone.
+			klog.V(2).InfoS("Skipping cluster resource placement which is being rolled out", "clusterResourcePlacement", crpRef)
+			return nil, nil
+		case binding.Spec.State == placementv1beta1.BindingStateBound && binding.Spec.SchedulingPolicySnapshotName == policy.Name:
+			ps.bound = append(ps.bound, binding)
+		}
+	}
+	if ps.disruptionsAllowed, err = d.disruptionsAllowed(ctx, crp, bindingList.Items); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// disruptionsAllowed returns the number of bindings of a placement that can be disrupted per its
+// disruption budget; there is no limit if the placement has no disruption budget.
+func (d *Descheduler) disruptionsAllowed(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement, bindings []placementv1beta1.ClusterResourceBinding) (int, error) {
+	db := &placementv1alpha1.ClusterResourcePlacementDisruptionBudget{}
+	if err := d.client.Get(ctx, types.NamespacedName{Name: crp.Name}, db); err != nil {
+		if apierrors.IsNotFound(err) {
+			return math.MaxInt, nil
+		}
+		return 0, controller.NewAPIServerError(true, err)
+	}
+	allowed, _ := bindingutils.DisruptionsAllowed(bindings, crp, db)
+	return allowed, nil
+}
+
+// rank scores the clusters for a placement, and returns them in the same order as the scheduler
+// picks them, i.e., best first, along with the rank of each cluster.
+func rank(ctx context.Context, crpName string, ps *placementState) (framework.ScoredClusters, map[string]int, error) {
+	scored, err := ps.fw.ScoreClustersFor(ctx, crpName, ps.policy)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Sort(sort.Reverse(scored))
+	ranks := make(map[string]int, len(scored))
+	for r, sc := range scored {
+		ranks[sc.Cluster.Name] = r
+	}
+	return scored, ranks, nil
+}
+
+// move unschedules the given bindings of a placement as rebalanced, and has the scheduler pick
+// new clusters for the placement right away.
+func (d *Descheduler) move(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement, ps *placementState, toMove []*placementv1beta1.ClusterResourceBinding, destinations []string) error {
+	if err := ps.fw.UnscheduleBindings(ctx, ps.policy, toMove, placementv1beta1.UnscheduledReasonRebalanced); err != nil {
+		return err
+	}
+	klog.V(2).InfoS("Moving cluster resource placement to better clusters", "clusterResourcePlacement", klog.KObj(crp),
+		"clusterResourceBindings", klog.KObjSlice(toMove), "betterClusters", destinations)
+	d.queue.Add(queue.ClusterResourcePlacementKey(crp.Name))
+	return nil
+}
+
+// descheduleFor moves a placement to better clusters, if any, with no more moves than the given
+// budget; it returns the number of moves started.
+func (d *Descheduler) descheduleFor(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement, budget int) (int, error) {
+	ps, err := d.inspect(ctx, crp)
+	if err != nil || ps == nil {
+		return 0, err
+	}
+	allowed := min(d.maxMovesPerPlacement-ps.movesInProgress, budget, ps.disruptionsAllowed)
+	if allowed <= 0 || len(ps.bound) == 0 {
+		return 0, nil
+	}
+
+	scored, ranks, err := rank(ctx, crp.Name, ps)
+	if err != nil {
+		return 0, err
+	}
+
+	// Pair the worst bound clusters with the best free clusters.
+	//
+	// Bound clusters that no longer pass the Filter stage are left alone, as scheduling policies
+	// are ignored during execution.
+	current := rankedBindings(ps.bound, ranks)
+	var candidates framework.ScoredClusters
+	for _, sc := range scored {
+		if !ps.occupied.Has(sc.Cluster.Name) {
+			candidates = append(candidates, sc)
+		}
+	}
+
+	var toMove []*placementv1beta1.ClusterResourceBinding
+	var destinations []string
+	for i := 0; i < len(current) && i < len(candidates) && len(toMove) < allowed; i++ {
+		from := scored[ranks[current[i].Spec.TargetCluster]]
+		to := candidates[i]
+		if ranks[to.Cluster.Name] > ranks[from.Cluster.Name] || improvement(from.Score, to.Score) < d.minScoreImprovement {
+			break
+		}
+		toMove = append(toMove, current[i])
+		destinations = append(destinations, to.Cluster.Name)
+	}
+	if len(toMove) == 0 {
+		return 0, nil
+	}
+
+	if err := d.move(ctx, crp, ps, toMove, destinations); err != nil {
+		return 0, err
+	}
+	return len(toMove), nil
+}
+
+// rankedBindings returns the bindings on clusters that have a rank, worst first.
+func rankedBindings(bindings []*placementv1beta1.ClusterResourceBinding, ranks map[string]int) []*placementv1beta1.ClusterResourceBinding {
+	var ranked []*placementv1beta1.ClusterResourceBinding
+	for _, binding := range bindings {
+		if _, ok := ranks[binding.Spec.TargetCluster]; ok {
+			ranked = append(ranked, binding)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		return ranks[ranked[i].Spec.TargetCluster] > ranks[ranked[j].Spec.TargetCluster]
+	})
+	return ranked
+}
+
+// frameworkFor returns the scheduling framework for the scheduling profile a placement names.
+func (d *Descheduler) frameworkFor(crp *placementv1beta1.ClusterResourcePlacement) (framework.Framework, bool) {
+	profileName, ok := crp.Annotations[placementv1beta1.SchedulerProfileAnnotation]
+	if !ok || profileName == "" {
+		return d.framework, d.framework != nil
+	}
+	fw, ok := d.frameworks[profileName]
+	return fw, ok
+}
+
+// lookupLatestPolicySnapshot returns the latest policy snapshot of a placement, or nil if there is
+// not exactly one.
+func (d *Descheduler) lookupLatestPolicySnapshot(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement) (*placementv1beta1.ClusterSchedulingPolicySnapshot, error) {
+	policySnapshotList := &placementv1beta1.ClusterSchedulingPolicySnapshotList{}
+	if err := d.client.List(ctx, policySnapshotList, client.MatchingLabels{
+		placementv1beta1.CRPTrackingLabel:      crp.Name,
+		placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+	}); err != nil {
+		return nil, controller.NewAPIServerError(true, err)
+	}
+	if len(policySnapshotList.Items) != 1 {
+		// The scheduler reports the case, if it persists.
+		klog.V(2).InfoS("Skipping cluster resource placement without exactly one latest policy snapshot",
+			"clusterResourcePlacement", klog.KObj(crp), "policySnapshotCount", len(policySnapshotList.Items))
+		return nil, nil
+	}
+	return &policySnapshotList.Items[0], nil
+}
+
+// improvement returns how much better a score is than another, in the sum of the affinity and
+// topology spread scores.
+func improvement(from, to *framework.ClusterScore) int {
+	return (to.AffinityScore + to.TopologySpreadScore) - (from.AffinityScore + from.TopologySpreadScore)
+}
@@ -0,0 +1,402 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/clusteraffinity"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+)
+
+const (
+	crpName        = "test-crp"
+	policyName     = "test-crp-1"
+	goodCluster    = "bravelion"
+	fairCluster    = "smartcat"
+	poorCluster    = "singingbutterfly"
+	profileName    = "TestProfile"
+	bindingName    = "test-crp-binding"
+	altBindingName = "test-crp-binding-alt"
+)
+
+func serviceScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add cluster v1beta1 scheme: %v", err)
+	}
+	if err := placementv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add placement v1alpha1 scheme: %v", err)
+	}
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add placement v1beta1 scheme: %v", err)
+	}
+	return scheme
+}
+
+// newTestObjects returns a placement which picks one cluster and prefers the clusters labelled as
+// good (by 50) and fair (by 10), its policy snapshot, and the three clusters.
+func newTestObjects() []client.Object {
+	policy := &placementv1beta1.PlacementPolicy{
+		PlacementType:    placementv1beta1.PickNPlacementType,
+		NumberOfClusters: ptr.To(int32(1)),
+		Affinity: &placementv1beta1.Affinity{
+			ClusterAffinity: &placementv1beta1.ClusterAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.PreferredClusterSelector{
+					{
+						Weight:     50,
+						Preference: placementv1beta1.ClusterSelectorTerm{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "good"}}},
+					},
+					{
+						Weight:     10,
+						Preference: placementv1beta1.ClusterSelectorTerm{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "fair"}}},
+					},
+				},
+			},
+		},
+	}
+	objs := []client.Object{
+		&placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: crpName,
+			},
+			Spec: placementv1beta1.ClusterResourcePlacementSpec{
+				Policy: policy,
+			},
+		},
+		&placementv1beta1.ClusterSchedulingPolicySnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       policyName,
+				Generation: 1,
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel:      crpName,
+					placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+				},
+			},
+			Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+				Policy: policy,
+			},
+			Status: placementv1beta1.SchedulingPolicySnapshotStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(placementv1beta1.PolicySnapshotScheduled),
+						Status:             metav1.ConditionTrue,
+						ObservedGeneration: 1,
+						Reason:             "Scheduled",
+						LastTransitionTime: metav1.Now(),
+					},
+				},
+			},
+		},
+	}
+	for name, tier := range map[string]string{goodCluster: "good", fairCluster: "fair", poorCluster: "poor"} {
+		objs = append(objs, &clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"tier": tier},
+			},
+		})
+	}
+	return objs
+}
+
+// newTestFramework returns a framework which scores clusters by affinity only.
+func newTestFramework(hubClient client.Client) framework.Framework {
+	p := framework.NewProfile(profileName)
+	clusterAffinityPlugin := clusteraffinity.New()
+	p.WithPreFilterPlugin(&clusterAffinityPlugin).WithFilterPlugin(&clusterAffinityPlugin).
+		WithPreScorePlugin(&clusterAffinityPlugin).WithScorePlugin(&clusterAffinityPlugin)
+	return framework.NewDryRunFramework(p, hubClient)
+}
+
+// TestDeschedule tests the deschedule method.
+func TestDeschedule(t *testing.T) {
+	testCases := []struct {
+		name       string
+		bindings   []client.Object
+		opts       []Option
+		wantStates map[string]placementv1beta1.BindingState
+		wantQueued bool
+	}{
+		{
+			name: "bound to the poor cluster, move to the good one",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateUnscheduled},
+			wantQueued: true,
+		},
+		{
+			name: "bound to the fair cluster, improvement below the threshold",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                fairCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			opts:       []Option{WithMinScoreImprovement(50)},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+		},
+		{
+			name: "bound to the good cluster already",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                goodCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			opts:       []Option{WithMinScoreImprovement(1)},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+		},
+		{
+			name: "move in progress",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: altBindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+						Annotations: map[string]string{
+							placementv1beta1.UnscheduledReasonAnnotation: placementv1beta1.UnscheduledReasonRebalanced,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateUnscheduled,
+						TargetCluster:                fairCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{
+				bindingName:    placementv1beta1.BindingStateBound,
+				altBindingName: placementv1beta1.BindingStateUnscheduled,
+			},
+		},
+		{
+			name: "being rolled out",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: altBindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateScheduled,
+						TargetCluster:                fairCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{
+				bindingName:    placementv1beta1.BindingStateBound,
+				altBindingName: placementv1beta1.BindingStateScheduled,
+			},
+		},
+		{
+			name: "blocked by the disruption budget",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+					Status: placementv1beta1.ResourceBindingStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:               string(placementv1beta1.ResourceBindingAvailable),
+								Status:             metav1.ConditionTrue,
+								Reason:             "Available",
+								LastTransitionTime: metav1.Now(),
+							},
+						},
+					},
+				},
+				&placementv1alpha1.ClusterResourcePlacementDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1alpha1.PlacementDisruptionBudgetSpec{
+						MinAvailable: ptr.To(intstr.FromInt32(1)),
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+		},
+		{
+			name: "allowed by the disruption budget",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+					Status: placementv1beta1.ResourceBindingStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:               string(placementv1beta1.ResourceBindingAvailable),
+								Status:             metav1.ConditionTrue,
+								Reason:             "Available",
+								LastTransitionTime: metav1.Now(),
+							},
+						},
+					},
+				},
+				&placementv1alpha1.ClusterResourcePlacementDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1alpha1.PlacementDisruptionBudgetSpec{
+						MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateUnscheduled},
+			wantQueued: true,
+		},
+		{
+			name: "no budget for the run",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			opts:       []Option{WithMaxMovesPerRun(0)},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(serviceScheme(t)).
+				WithObjects(append(newTestObjects(), tc.bindings...)...).
+				Build()
+			schedulingQueue := queue.NewSimpleClusterResourcePlacementSchedulingQueue()
+			defer schedulingQueue.Close()
+			d := New(fakeClient, schedulingQueue, []framework.Framework{newTestFramework(fakeClient)}, tc.opts...)
+
+			d.deschedule(ctx)
+
+			gotStates := map[string]placementv1beta1.BindingState{}
+			bindingList := &placementv1beta1.ClusterResourceBindingList{}
+			if err := fakeClient.List(ctx, bindingList); err != nil {
+				t.Fatalf("List() = %v, want no error", err)
+			}
+			for _, binding := range bindingList.Items {
+				gotStates[binding.Name] = binding.Spec.State
+				if binding.Name == bindingName && binding.Spec.State == placementv1beta1.BindingStateUnscheduled {
+					if reason := binding.Annotations[placementv1beta1.UnscheduledReasonAnnotation]; reason != placementv1beta1.UnscheduledReasonRebalanced {
+						t.Errorf("binding unscheduled reason = %q, want %q", reason, placementv1beta1.UnscheduledReasonRebalanced)
+					}
+				}
+			}
+			if diff := cmp.Diff(gotStates, tc.wantStates); diff != "" {
+				t.Errorf("binding states diff (-got, +want): %s", diff)
+			}
+			if gotQueued := schedulingQueue.Len() == 1; gotQueued != tc.wantQueued {
+				t.Errorf("placement queued = %t, want %t", gotQueued, tc.wantQueued)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+	bindingutils "go.goms.io/fleet/pkg/utils/binding"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// drainControllerName is the name of the controller that drains cordoned clusters.
+	drainControllerName = "descheduler-cluster-drain"
+
+	// drainRequeuePeriod is how long the controller waits before it checks again on a cluster whose
+	// draining is in progress, e.g., whose bindings are blocked by disruption budgets.
+	drainRequeuePeriod = 30 * time.Second
+)
+
+// Drain unschedules the bindings on a member cluster, with the reason UnscheduledReasonClusterDrained,
+// so that the scheduler picks other clusters for the placements (if any) and the resources are removed
+// from the cluster; it returns the number of bindings which are yet to be unscheduled.
+//
+// As the cluster is cordoned, the scheduler never picks it again. To keep the placements available, a
+// binding is unscheduled only if no other binding of its placement is being rolled out or removed, and
+// the disruption budget (if any) of its placement allows it; the bindings of placements of the PickFixed
+// placement type are never unscheduled, as their clusters are named explicitly.
+func (d *Descheduler) Drain(ctx context.Context, clusterName string) (int, error) {
+	bindingList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := d.client.List(ctx, bindingList, client.MatchingFields{controller.BindingTargetClusterIndexKey: clusterName}); err != nil {
+		return 0, controller.NewAPIServerError(true, err)
+	}
+
+	pending := 0
+	for idx := range bindingList.Items {
+		binding := &bindingList.Items[idx]
+		if !binding.DeletionTimestamp.IsZero() || binding.Spec.State == placementv1beta1.BindingStateUnscheduled {
+			continue
+		}
+		drained, err := d.drainBinding(ctx, binding)
+		if err != nil {
+			klog.ErrorS(err, "Failed to drain cluster resource binding", "clusterResourceBinding", klog.KObj(binding), "memberCluster", clusterName)
+			return 0, err
+		}
+		if !drained {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// drainBinding unschedules a binding on a drained cluster, if its placement can afford it; it returns
+// true if the binding has been unscheduled.
+func (d *Descheduler) drainBinding(ctx context.Context, binding *placementv1beta1.ClusterResourceBinding) (bool, error) {
+	bindingRef := klog.KObj(binding)
+	crp := &placementv1beta1.ClusterResourcePlacement{}
+	if err := d.client.Get(ctx, types.NamespacedName{Name: binding.Labels[placementv1beta1.CRPTrackingLabel]}, crp); err != nil {
+		if errors.IsNotFound(err) {
+			// The binding is to be garbage collected with its placement.
+			return false, nil
+		}
+		return false, controller.NewAPIServerError(true, err)
+	}
+	crpRef := klog.KObj(crp)
+	if !crp.DeletionTimestamp.IsZero() {
+		return false, nil
+	}
+	if crp.Spec.Policy == nil {
+		// A placement without a scheduling policy is of the PickAll placement type.
+		crp.Spec.Policy = &placementv1beta1.PlacementPolicy{PlacementType: placementv1beta1.PickAllPlacementType}
+	}
+	if crp.Spec.Policy.PlacementType == placementv1beta1.PickFixedPlacementType {
+		klog.V(2).InfoS("Cluster resource binding of a PickFixed placement cannot be drained",
+			"clusterResourceBinding", bindingRef, "clusterResourcePlacement", crpRef)
+		return false, nil
+	}
+	fw, ok := d.frameworkFor(crp)
+	if !ok {
+		klog.V(2).InfoS("Skipping cluster resource placement with an unknown scheduling profile", "clusterResourcePlacement", crpRef)
+		return false, nil
+	}
+	policy, err := d.lookupLatestPolicySnapshot(ctx, crp)
+	if err != nil || policy == nil {
+		return false, err
+	}
+
+	bindingList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := d.client.List(ctx, bindingList, client.MatchingLabels{placementv1beta1.CRPTrackingLabel: crp.Name}); err != nil {
+		return false, controller.NewAPIServerError(true, err)
+	}
+	for idx := range bindingList.Items {
+		other := &bindingList.Items[idx]
+		if other.Name == binding.Name || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if other.Spec.State == placementv1beta1.BindingStateScheduled || other.Spec.State == placementv1beta1.BindingStateUnscheduled {
+			// Another disruption of the placement, e.g., the draining of another cluster, is in progress.
+			klog.V(2).InfoS("Waiting for cluster resource placement to settle before draining its binding",
+				"clusterResourceBinding", bindingRef, "clusterResourcePlacement", crpRef)
+			return false, nil
+		}
+	}
+
+	// A failed binding can be drained without disrupting the placement any further.
+	if !bindingutils.HasBindingFailed(binding) {
+		allowed, err := d.isDisruptionAllowed(ctx, crp, bindingList.Items)
+		if err != nil {
+			return false, err
+		}
+		if !allowed {
+			klog.V(2).InfoS("Draining cluster resource binding is blocked by the disruption budget",
+				"clusterResourceBinding", bindingRef, "clusterResourcePlacement", crpRef)
+			return false, nil
+		}
+	}
+
+	toDrain := []*placementv1beta1.ClusterResourceBinding{binding}
+	if err := fw.UnscheduleBindings(ctx, policy, toDrain, placementv1beta1.UnscheduledReasonClusterDrained); err != nil {
+		return false, err
+	}
+	klog.V(2).InfoS("Drained cluster resource binding", "clusterResourceBinding", bindingRef, "clusterResourcePlacement", crpRef)
+	d.queue.Add(queue.ClusterResourcePlacementKey(crp.Name))
+	return true, nil
+}
+
+// isDisruptionAllowed returns whether the disruption budget (if any) of a placement allows one of its
+// bindings to be disrupted.
+func (d *Descheduler) isDisruptionAllowed(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement, bindings []placementv1beta1.ClusterResourceBinding) (bool, error) {
+	db := placementv1alpha1.ClusterResourcePlacementDisruptionBudget{}
+	if err := d.client.Get(ctx, types.NamespacedName{Name: crp.Name}, &db); err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, controller.NewAPIServerError(true, err)
+	}
+	if bindingutils.IsDisruptionBudgetMisconfigured(crp, &db) {
+		klog.V(2).InfoS("Disruption budget is misconfigured", "clusterResourcePlacement", klog.KObj(crp))
+		return false, nil
+	}
+	allowed, _ := bindingutils.DisruptionsAllowed(bindings, crp, &db)
+	return allowed > 0, nil
+}
+
+// DrainReconciler reconciles member clusters that are cordoned and drained, and has the descheduler
+// unschedule the bindings on them.
+type DrainReconciler struct {
+	// Client is a (cached) client for accessing the Kubernetes API server.
+	Client client.Client
+
+	// Descheduler unschedules the bindings on the drained clusters.
+	Descheduler *Descheduler
+}
+
+// Reconcile reconciles a member cluster.
+func (r *DrainReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	memberClusterRef := klog.KRef(req.Namespace, req.Name)
+	startTime := time.Now()
+	klog.V(2).InfoS("Reconciliation starts", "memberCluster", memberClusterRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("Reconciliation ends", "memberCluster", memberClusterRef, "latency", latency)
+	}()
+
+	cluster := &clusterv1beta1.MemberCluster{}
+	if err := r.Client.Get(ctx, req.NamespacedName, cluster); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get member cluster", "memberCluster", memberClusterRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	if !cluster.DeletionTimestamp.IsZero() || !isDrained(cluster) {
+		// The scheduler unschedules the bindings on leaving clusters.
+		return ctrl.Result{}, nil
+	}
+
+	pending, err := r.Descheduler.Drain(ctx, cluster.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if pending > 0 {
+		klog.V(2).InfoS("Draining of member cluster is in progress", "memberCluster", memberClusterRef, "pendingBindings", pending)
+		return ctrl.Result{RequeueAfter: drainRequeuePeriod}, nil
+	}
+	klog.V(2).InfoS("Drained member cluster", "memberCluster", memberClusterRef)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager builds a controller with DrainReconciler and sets it up with a controller manager.
+func (r *DrainReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	customPredicate := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			// Pick up the clusters being drained on restarts.
+			cluster, ok := e.Object.(*clusterv1beta1.MemberCluster)
+			return ok && isDrained(cluster)
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(_ event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCluster, oldOk := e.ObjectOld.(*clusterv1beta1.MemberCluster)
+			newCluster, newOk := e.ObjectNew.(*clusterv1beta1.MemberCluster)
+			if !oldOk || !newOk {
+				err := controller.NewUnexpectedBehaviorError(fmt.Errorf("failed to cast runtime objects in update event to member cluster objects"))
+				klog.ErrorS(err, "Failed to process update event")
+				return false
+			}
+			return !isDrained(oldCluster) && isDrained(newCluster)
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(drainControllerName).
+		For(&clusterv1beta1.MemberCluster{}).
+		WithEventFilter(customPredicate).
+		Complete(r)
+}
+
+// isDrained returns whether a member cluster is cordoned and drained.
+func isDrained(cluster *clusterv1beta1.MemberCluster) bool {
+	return cluster.Spec.Cordon != nil && cluster.Spec.Cordon.Drain
+}
@@ -0,0 +1,342 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// newDrainTestObjects returns the test objects, with the poor cluster cordoned as specified.
+func newDrainTestObjects(cordon *clusterv1beta1.Cordon, placementType placementv1beta1.PlacementType) []client.Object {
+	objs := newTestObjects()
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *clusterv1beta1.MemberCluster:
+			if o.Name == poorCluster {
+				o.Spec.Cordon = cordon
+			}
+		case *placementv1beta1.ClusterResourcePlacement:
+			if placementType != "" {
+				o.Spec.Policy.PlacementType = placementType
+			}
+		}
+	}
+	return objs
+}
+
+// TestDrainReconcile tests the Reconcile method of DrainReconciler.
+func TestDrainReconcile(t *testing.T) {
+	drain := &clusterv1beta1.Cordon{Drain: true}
+	testCases := []struct {
+		name          string
+		cordon        *clusterv1beta1.Cordon
+		placementType placementv1beta1.PlacementType
+		objs          []client.Object
+		wantStates    map[string]placementv1beta1.BindingState
+		wantQueued    bool
+		wantResult    ctrl.Result
+	}{
+		{
+			name:   "cordoned only",
+			cordon: &clusterv1beta1.Cordon{Reason: "maintenance"},
+			objs: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+		},
+		{
+			name:   "drained, no disruption budget",
+			cordon: drain,
+			objs: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateUnscheduled},
+			wantQueued: true,
+		},
+		{
+			name:   "drained, binding on another cluster",
+			cordon: drain,
+			objs: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                goodCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+		},
+		{
+			name:   "drained, blocked by the disruption budget",
+			cordon: drain,
+			objs: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+					Status: placementv1beta1.ResourceBindingStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   string(placementv1beta1.ResourceBindingAvailable),
+								Status: metav1.ConditionTrue,
+								Reason: "Test",
+							},
+						},
+					},
+				},
+				&placementv1alpha1.ClusterResourcePlacementDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1alpha1.PlacementDisruptionBudgetSpec{
+						MinAvailable: ptr.To(intstr.FromInt32(1)),
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+			wantResult: ctrl.Result{RequeueAfter: drainRequeuePeriod},
+		},
+		{
+			name:   "drained, allowed by the disruption budget",
+			cordon: drain,
+			objs: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+					Status: placementv1beta1.ResourceBindingStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   string(placementv1beta1.ResourceBindingAvailable),
+								Status: metav1.ConditionTrue,
+								Reason: "Test",
+							},
+						},
+					},
+				},
+				&placementv1alpha1.ClusterResourcePlacementDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1alpha1.PlacementDisruptionBudgetSpec{
+						MinAvailable: ptr.To(intstr.FromInt32(0)),
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateUnscheduled},
+			wantQueued: true,
+		},
+		{
+			name:   "drained, failed binding despite the disruption budget",
+			cordon: drain,
+			objs: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+					Status: placementv1beta1.ResourceBindingStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:   string(placementv1beta1.ResourceBindingApplied),
+								Status: metav1.ConditionFalse,
+								Reason: "Test",
+							},
+						},
+					},
+				},
+				&placementv1alpha1.ClusterResourcePlacementDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1alpha1.PlacementDisruptionBudgetSpec{
+						MinAvailable: ptr.To(intstr.FromInt32(1)),
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateUnscheduled},
+			wantQueued: true,
+		},
+		{
+			name:   "drained, another disruption in progress",
+			cordon: drain,
+			objs: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: altBindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+						Annotations: map[string]string{
+							placementv1beta1.UnscheduledReasonAnnotation: placementv1beta1.UnscheduledReasonClusterDrained,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateUnscheduled,
+						TargetCluster:                fairCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{
+				bindingName:    placementv1beta1.BindingStateBound,
+				altBindingName: placementv1beta1.BindingStateUnscheduled,
+			},
+			wantResult: ctrl.Result{RequeueAfter: drainRequeuePeriod},
+		},
+		{
+			name:          "drained, PickFixed placement",
+			cordon:        drain,
+			placementType: placementv1beta1.PickFixedPlacementType,
+			objs: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+			wantResult: ctrl.Result{RequeueAfter: drainRequeuePeriod},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(serviceScheme(t)).
+				WithObjects(append(newDrainTestObjects(tc.cordon, tc.placementType), tc.objs...)...).
+				WithIndex(&placementv1beta1.ClusterResourceBinding{}, controller.BindingTargetClusterIndexKey, controller.ExtractBindingTargetCluster).
+				Build()
+			schedulingQueue := queue.NewSimpleClusterResourcePlacementSchedulingQueue()
+			defer schedulingQueue.Close()
+			r := &DrainReconciler{
+				Client:      fakeClient,
+				Descheduler: New(fakeClient, schedulingQueue, []framework.Framework{newTestFramework(fakeClient)}),
+			}
+
+			gotResult, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: poorCluster}})
+			if err != nil {
+				t.Fatalf("Reconcile() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(gotResult, tc.wantResult); diff != "" {
+				t.Errorf("Reconcile() result diff (-got, +want): %s", diff)
+			}
+
+			gotStates := map[string]placementv1beta1.BindingState{}
+			bindingList := &placementv1beta1.ClusterResourceBindingList{}
+			if err := fakeClient.List(ctx, bindingList); err != nil {
+				t.Fatalf("List() = %v, want no error", err)
+			}
+			for _, binding := range bindingList.Items {
+				gotStates[binding.Name] = binding.Spec.State
+				if binding.Name == bindingName && binding.Spec.State == placementv1beta1.BindingStateUnscheduled {
+					if reason := binding.Annotations[placementv1beta1.UnscheduledReasonAnnotation]; reason != placementv1beta1.UnscheduledReasonClusterDrained {
+						t.Errorf("binding unscheduled reason = %q, want %q", reason, placementv1beta1.UnscheduledReasonClusterDrained)
+					}
+				}
+			}
+			if diff := cmp.Diff(gotStates, tc.wantStates); diff != "" {
+				t.Errorf("binding states diff (-got, +want): %s", diff)
+			}
+			if gotQueued := schedulingQueue.Len() == 1; gotQueued != tc.wantQueued {
+				t.Errorf("placement queued = %t, want %t", gotQueued, tc.wantQueued)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// rescheduleControllerName is the name of the controller that reschedules placements on request.
+	rescheduleControllerName = "descheduler-reschedule"
+
+	// rescheduleRequeuePeriod is how long the controller waits before it checks again on a placement whose
+	// rescheduling is in progress, e.g., whose moves are yet to be rolled out.
+	rescheduleRequeuePeriod = 30 * time.Second
+)
+
+// Reschedule moves a placement, which has been annotated with RescheduleAnnotation, to the clusters it
+// would be scheduled to from scratch; it returns true if the rescheduling is done, i.e., the placement is
+// on the best clusters, or it cannot be rescheduled.
+//
+// The clusters are ranked without the bindings of the placement, i.e., with no stickiness, and any better
+// cluster is a destination, whatever the score improvement. The moves are subject to the max number of
+// moves a placement can have in progress, so a rescheduling may take several rounds, each of which waits
+// for the moves of the previous one to be rolled out; as with cluster joins, the moves are not subject to
+// the budget of moves per run.
+func (d *Descheduler) Reschedule(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement) (bool, error) {
+	crpRef := klog.KObj(crp)
+	if crp.Spec.Policy == nil || crp.Spec.Policy.PlacementType != placementv1beta1.PickNPlacementType {
+		// Placements of the other placement types are bound to all of their clusters already.
+		klog.V(2).InfoS("Cluster resource placement of a placement type other than PickN cannot be rescheduled", "clusterResourcePlacement", crpRef)
+		return true, nil
+	}
+	if _, ok := d.frameworkFor(crp); !ok {
+		klog.V(2).InfoS("Cluster resource placement with an unknown scheduling profile cannot be rescheduled", "clusterResourcePlacement", crpRef)
+		return true, nil
+	}
+
+	ps, err := d.inspect(ctx, crp)
+	if err != nil || ps == nil {
+		// The placement is yet to be fully scheduled or rolled out; check again later.
+		return false, err
+	}
+
+	scored, ranks, err := rank(ctx, crp.Name, ps)
+	if err != nil {
+		return false, err
+	}
+
+	// Pair the worst bound clusters with the best free clusters, as long as the latter rank above the
+	// former.
+	current := rankedBindings(ps.bound, ranks)
+	var candidates framework.ScoredClusters
+	for _, sc := range scored {
+		if !ps.occupied.Has(sc.Cluster.Name) {
+			candidates = append(candidates, sc)
+		}
+	}
+	var toMove []*placementv1beta1.ClusterResourceBinding
+	var destinations []string
+	for i := 0; i < len(current) && i < len(candidates); i++ {
+		if ranks[candidates[i].Cluster.Name] > ranks[current[i].Spec.TargetCluster] {
+			break
+		}
+		toMove = append(toMove, current[i])
+		destinations = append(destinations, candidates[i].Cluster.Name)
+	}
+	if len(toMove) == 0 {
+		if ps.movesInProgress > 0 {
+			// The moves of the previous round are yet to complete.
+			return false, nil
+		}
+		klog.V(2).InfoS("Cluster resource placement is on the best clusters", "clusterResourcePlacement", crpRef)
+		return true, nil
+	}
+
+	allowed := d.maxMovesPerPlacement - ps.movesInProgress
+	if allowed <= 0 {
+		return false, nil
+	}
+	if len(toMove) > allowed {
+		toMove, destinations = toMove[:allowed], destinations[:allowed]
+	}
+	if err := d.move(ctx, crp, ps, toMove, destinations); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// RescheduleReconciler reconciles placements annotated with RescheduleAnnotation, and has the descheduler
+// reschedule them.
+type RescheduleReconciler struct {
+	// Client is a (cached) client for accessing the Kubernetes API server.
+	Client client.Client
+
+	// Descheduler moves the placements to the best clusters.
+	Descheduler *Descheduler
+}
+
+// Reconcile reconciles a cluster resource placement.
+func (r *RescheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	crpRef := klog.KRef("", req.Name)
+	startTime := time.Now()
+	klog.V(2).InfoS("Reconciliation starts", "clusterResourcePlacement", crpRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("Reconciliation ends", "clusterResourcePlacement", crpRef, "latency", latency)
+	}()
+
+	crp := &placementv1beta1.ClusterResourcePlacement{}
+	if err := r.Client.Get(ctx, req.NamespacedName, crp); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		klog.ErrorS(err, "Failed to get cluster resource placement", "clusterResourcePlacement", crpRef)
+		return ctrl.Result{}, controller.NewAPIServerError(true, err)
+	}
+	if _, ok := crp.Annotations[placementv1beta1.RescheduleAnnotation]; !ok || !crp.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	done, err := r.Descheduler.Reschedule(ctx, crp)
+	if err != nil {
+		klog.ErrorS(err, "Failed to reschedule cluster resource placement", "clusterResourcePlacement", crpRef)
+		return ctrl.Result{}, err
+	}
+	if !done {
+		klog.V(2).InfoS("Rescheduling of cluster resource placement is in progress", "clusterResourcePlacement", crpRef)
+		return ctrl.Result{RequeueAfter: rescheduleRequeuePeriod}, nil
+	}
+
+	// Remove the annotation, so that the placement can be rescheduled again on a new request.
+	original := crp.DeepCopy()
+	delete(crp.Annotations, placementv1beta1.RescheduleAnnotation)
+	if err := r.Client.Patch(ctx, crp, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		klog.ErrorS(err, "Failed to remove the reschedule annotation from cluster resource placement", "clusterResourcePlacement", crpRef)
+		return ctrl.Result{}, controller.NewUpdateIgnoreConflictError(err)
+	}
+	klog.V(2).InfoS("Rescheduled cluster resource placement", "clusterResourcePlacement", crpRef)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager builds a controller with RescheduleReconciler and sets it up with a controller manager.
+func (r *RescheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	hasRescheduleAnnotation := func(obj client.Object) bool {
+		_, ok := obj.GetAnnotations()[placementv1beta1.RescheduleAnnotation]
+		return ok
+	}
+	customPredicate := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			// Pick up the pending requests on restarts.
+			return hasRescheduleAnnotation(e.Object)
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(_ event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				err := controller.NewUnexpectedBehaviorError(fmt.Errorf("update event is missing objects"))
+				klog.ErrorS(err, "Failed to process update event")
+				return false
+			}
+			return !hasRescheduleAnnotation(e.ObjectOld) && hasRescheduleAnnotation(e.ObjectNew)
+		},
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(rescheduleControllerName).
+		For(&placementv1beta1.ClusterResourcePlacement{}).
+		WithEventFilter(customPredicate).
+		Complete(r)
+}
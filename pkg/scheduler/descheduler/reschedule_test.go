@@ -0,0 +1,238 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+)
+
+// newRescheduleTestObjects returns the test objects, with the placement annotated for rescheduling.
+func newRescheduleTestObjects() []client.Object {
+	objs := newTestObjects()
+	for _, obj := range objs {
+		if crp, ok := obj.(*placementv1beta1.ClusterResourcePlacement); ok {
+			crp.Annotations = map[string]string{placementv1beta1.RescheduleAnnotation: "now"}
+		}
+	}
+	return objs
+}
+
+// TestRescheduleReconcile tests the Reconcile method of RescheduleReconciler.
+func TestRescheduleReconcile(t *testing.T) {
+	testCases := []struct {
+		name           string
+		bindings       []client.Object
+		opts           []Option
+		wantStates     map[string]placementv1beta1.BindingState
+		wantQueued     bool
+		wantAnnotation bool
+		wantResult     ctrl.Result
+	}{
+		{
+			name: "bound to the fair cluster, move to the good one regardless of the threshold",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                fairCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			opts:       []Option{WithMinScoreImprovement(50)},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateUnscheduled},
+			wantQueued: true,
+			// The rescheduling is done once the placement is on the good cluster.
+			wantAnnotation: true,
+			wantResult:     ctrl.Result{RequeueAfter: rescheduleRequeuePeriod},
+		},
+		{
+			name: "bound to the good cluster already",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                goodCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+		},
+		{
+			name: "move in progress, at the max moves",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+						Annotations: map[string]string{
+							placementv1beta1.UnscheduledReasonAnnotation: placementv1beta1.UnscheduledReasonRebalanced,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateUnscheduled,
+						TargetCluster:                fairCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: altBindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{
+				bindingName:    placementv1beta1.BindingStateUnscheduled,
+				altBindingName: placementv1beta1.BindingStateBound,
+			},
+			wantAnnotation: true,
+			wantResult:     ctrl.Result{RequeueAfter: rescheduleRequeuePeriod},
+		},
+		{
+			name: "move in progress, on the best clusters left",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+						Annotations: map[string]string{
+							placementv1beta1.UnscheduledReasonAnnotation: placementv1beta1.UnscheduledReasonRebalanced,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateUnscheduled,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: altBindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                goodCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{
+				bindingName:    placementv1beta1.BindingStateUnscheduled,
+				altBindingName: placementv1beta1.BindingStateBound,
+			},
+			wantAnnotation: true,
+			wantResult:     ctrl.Result{RequeueAfter: rescheduleRequeuePeriod},
+		},
+		{
+			name: "being rolled out",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateScheduled,
+						TargetCluster:                poorCluster,
+						SchedulingPolicySnapshotName: policyName,
+					},
+				},
+			},
+			wantStates:     map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateScheduled},
+			wantAnnotation: true,
+			wantResult:     ctrl.Result{RequeueAfter: rescheduleRequeuePeriod},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(serviceScheme(t)).
+				WithObjects(append(newRescheduleTestObjects(), tc.bindings...)...).
+				Build()
+			schedulingQueue := queue.NewSimpleClusterResourcePlacementSchedulingQueue()
+			defer schedulingQueue.Close()
+			r := &RescheduleReconciler{
+				Client:      fakeClient,
+				Descheduler: New(fakeClient, schedulingQueue, []framework.Framework{newTestFramework(fakeClient)}, tc.opts...),
+			}
+
+			gotResult, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: crpName}})
+			if err != nil {
+				t.Fatalf("Reconcile() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(gotResult, tc.wantResult); diff != "" {
+				t.Errorf("Reconcile() result diff (-got, +want): %s", diff)
+			}
+
+			gotStates := map[string]placementv1beta1.BindingState{}
+			bindingList := &placementv1beta1.ClusterResourceBindingList{}
+			if err := fakeClient.List(ctx, bindingList); err != nil {
+				t.Fatalf("List() = %v, want no error", err)
+			}
+			for _, binding := range bindingList.Items {
+				gotStates[binding.Name] = binding.Spec.State
+			}
+			if diff := cmp.Diff(gotStates, tc.wantStates); diff != "" {
+				t.Errorf("binding states diff (-got, +want): %s", diff)
+			}
+			if gotQueued := schedulingQueue.Len() == 1; gotQueued != tc.wantQueued {
+				t.Errorf("placement queued = %t, want %t", gotQueued, tc.wantQueued)
+			}
+
+			crp := &placementv1beta1.ClusterResourcePlacement{}
+			if err := fakeClient.Get(ctx, types.NamespacedName{Name: crpName}, crp); err != nil {
+				t.Fatalf("Get() = %v, want no error", err)
+			}
+			if _, gotAnnotation := crp.Annotations[placementv1beta1.RescheduleAnnotation]; gotAnnotation != tc.wantAnnotation {
+				t.Errorf("reschedule annotation present = %t, want %t", gotAnnotation, tc.wantAnnotation)
+			}
+		})
+	}
+}
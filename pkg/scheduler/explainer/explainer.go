@@ -0,0 +1,287 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package explainer features a read-only scheduler, which explains the scheduling decisions of
+// placements, and simulates the scheduling of placements, without writing anything to the hub
+// cluster.
+package explainer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// simulatedPolicySnapshotIndex is the index of the policy snapshot created for simulations.
+	simulatedPolicySnapshotIndex = 0
+
+	// maxSimulatedCycles is the maximum number of scheduling cycles the explainer runs for a
+	// placement; the scheduler retries a placement right away after preempting bindings of other
+	// placements for it, and the explainer does the same, so as to report the outcome.
+	maxSimulatedCycles = 10
+)
+
+// ProfileBuilder builds the scheduling profiles, in the same way as the scheduler does; the default
+// profile comes first.
+//
+// Plugins keep a handle to the framework they are set up with; a fresh set of profiles is therefore
+// built for every scheduling cycle the explainer runs.
+type ProfileBuilder func() ([]*framework.Profile, error)
+
+// Explanation is the outcome of a scheduling cycle run by the explainer.
+type Explanation struct {
+	// Placement is the name of the placement.
+	Placement string `json:"placement"`
+	// Profile is the name of the scheduling profile in use.
+	Profile string `json:"profile"`
+	// Scheduled is true if the placement can be fully scheduled.
+	Scheduled bool `json:"scheduled"`
+	// Message is the message of the Scheduled condition of the policy snapshot.
+	Message string `json:"message,omitempty"`
+	// Decisions is the scheduling decisions, one for each cluster considered.
+	Decisions []placementv1beta1.ClusterDecision `json:"decisions"`
+}
+
+// Explainer runs scheduling cycles for placements against the objects in a (cached) reader, which
+// it usually shares with the scheduler, in a sandbox, i.e., all the writes of the cycles, including
+// those to bindings, go to an in-memory copy of the objects and are discarded afterwards.
+//
+// As it never writes to the hub cluster, the explainer is safe to run on any hub agent replica,
+// leader or not, alongside the active scheduler.
+type Explainer struct {
+	reader           client.Reader
+	scheme           *runtime.Scheme
+	buildProfiles    ProfileBuilder
+	frameworkOpts    []framework.Option
+	pinnedPlacements bool
+}
+
+// Option is the function that configures the explainer.
+type Option func(*Explainer)
+
+// WithFrameworkOptions sets the options of the scheduler frameworks the explainer runs.
+//
+// Regardless of the options, the explainer reports a decision for every cluster, and does not pace
+// the (sandboxed) binding writes.
+func WithFrameworkOptions(opts ...framework.Option) Option {
+	return func(e *Explainer) {
+		e.frameworkOpts = append(e.frameworkOpts, opts...)
+	}
+}
+
+// WithPinnedPlacements makes the explainer take PinnedPlacements into account; the PinnedPlacement
+// API must have been installed.
+func WithPinnedPlacements() Option {
+	return func(e *Explainer) {
+		e.pinnedPlacements = true
+	}
+}
+
+// New returns a new explainer, which reads objects from the given reader; the scheme must know
+// about all the objects the scheduler reads.
+func New(reader client.Reader, scheme *runtime.Scheme, buildProfiles ProfileBuilder, opts ...Option) *Explainer {
+	e := &Explainer{
+		reader:        reader,
+		scheme:        scheme,
+		buildProfiles: buildProfiles,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Explain runs a scheduling cycle for an existing placement with its latest scheduling policy
+// snapshot, and explains the outcome.
+func (e *Explainer) Explain(ctx context.Context, crpName string) (*Explanation, error) {
+	crp := &placementv1beta1.ClusterResourcePlacement{}
+	if err := e.reader.Get(ctx, types.NamespacedName{Name: crpName}, crp); err != nil {
+		return nil, err
+	}
+	policyList := &placementv1beta1.ClusterSchedulingPolicySnapshotList{}
+	if err := e.reader.List(ctx, policyList, client.MatchingLabels{
+		placementv1beta1.CRPTrackingLabel:      crpName,
+		placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+	}); err != nil {
+		return nil, controller.NewAPIServerError(true, err)
+	}
+	if len(policyList.Items) != 1 {
+		return nil, fmt.Errorf("found %d latest scheduling policy snapshots of ClusterResourcePlacement %s, want 1", len(policyList.Items), crpName)
+	}
+	return e.run(ctx, crp, &policyList.Items[0])
+}
+
+// Simulate runs a scheduling cycle for a placement, which might or might not exist, as if its spec
+// were the given one, and explains the outcome; the existing bindings of the placement, if any, are
+// taken into account.
+func (e *Explainer) Simulate(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement) (*Explanation, error) {
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf(placementv1beta1.PolicySnapshotNameFmt, crp.Name, simulatedPolicySnapshotIndex),
+			Labels: map[string]string{
+				placementv1beta1.CRPTrackingLabel:      crp.Name,
+				placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+				placementv1beta1.PolicyIndexLabel:      strconv.Itoa(simulatedPolicySnapshotIndex),
+			},
+			Annotations: map[string]string{
+				placementv1beta1.CRPGenerationAnnotation: strconv.FormatInt(crp.Generation, 10),
+			},
+		},
+		Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+			Policy: crp.Spec.Policy,
+		},
+	}
+	if crp.Spec.Policy != nil &&
+		crp.Spec.Policy.PlacementType == placementv1beta1.PickNPlacementType &&
+		crp.Spec.Policy.NumberOfClusters != nil {
+		policy.Annotations[placementv1beta1.NumberOfClustersAnnotation] = strconv.Itoa(int(*crp.Spec.Policy.NumberOfClusters))
+	}
+	return e.run(ctx, crp, policy)
+}
+
+// run runs scheduling cycles for the placement with the given policy snapshot in a sandbox, until
+// the placement needs no immediate retry.
+func (e *Explainer) run(ctx context.Context, crp *placementv1beta1.ClusterResourcePlacement, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (*Explanation, error) {
+	profile, err := e.pickProfile(crp)
+	if err != nil {
+		return nil, err
+	}
+
+	objs, clusterCount, err := e.collectObjects(ctx, crp.Name)
+	if err != nil {
+		return nil, err
+	}
+	objs = append(objs, sanitize(crp), sanitize(policy))
+	sandbox := fake.NewClientBuilder().
+		WithScheme(e.scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&placementv1beta1.ClusterSchedulingPolicySnapshot{}).
+		WithIndex(&placementv1beta1.ClusterResourceBinding{}, controller.BindingCRPTrackingIndexKey, controller.ExtractBindingCRPTracking).
+		Build()
+
+	frameworkOpts := append(append([]framework.Option{}, e.frameworkOpts...),
+		framework.WithMaxClusterDecisionCount(clusterCount),
+		framework.WithDecisionCompactionThreshold(0),
+		framework.WithBindingWriteRateLimit(0, 0),
+		framework.WithBindingWriteJitter(0),
+	)
+	fw := framework.NewDryRunFramework(profile, sandbox, frameworkOpts...)
+	sandboxPolicy := &placementv1beta1.ClusterSchedulingPolicySnapshot{}
+	if err := sandbox.Get(ctx, types.NamespacedName{Name: policy.Name}, sandboxPolicy); err != nil {
+		return nil, err
+	}
+	for i := 0; i < maxSimulatedCycles; i++ {
+		res, err := fw.RunSchedulingCycleFor(ctx, crp.Name, sandboxPolicy)
+		if err != nil {
+			return nil, err
+		}
+		if err := sandbox.Get(ctx, types.NamespacedName{Name: policy.Name}, sandboxPolicy); err != nil {
+			return nil, err
+		}
+		if !res.Requeue || res.RequeueAfter > 0 {
+			break
+		}
+	}
+
+	explanation := &Explanation{
+		Placement: crp.Name,
+		Profile:   profile.Name(),
+		Decisions: sandboxPolicy.Status.ClusterDecisions,
+	}
+	if scheduledCond := meta.FindStatusCondition(sandboxPolicy.Status.Conditions, string(placementv1beta1.PolicySnapshotScheduled)); scheduledCond != nil {
+		explanation.Scheduled = scheduledCond.Status == metav1.ConditionTrue
+		explanation.Message = scheduledCond.Message
+	}
+	return explanation, nil
+}
+
+// pickProfile builds the scheduling profiles and picks the one the placement names, or the default
+// one if the placement names none.
+func (e *Explainer) pickProfile(crp *placementv1beta1.ClusterResourcePlacement) (*framework.Profile, error) {
+	profiles, err := e.buildProfiles()
+	if err != nil {
+		return nil, err
+	}
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("no scheduling profile is found")
+	}
+	profileName := crp.Annotations[placementv1beta1.SchedulerProfileAnnotation]
+	if profileName == "" {
+		return profiles[0], nil
+	}
+	for _, p := range profiles {
+		if p.Name() == profileName {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("scheduling profile %q is not found", profileName)
+}
+
+// collectObjects reads the objects the scheduler reads in a scheduling cycle for the placement,
+// other than the placement and its policy snapshot; it also returns the number of clusters.
+func (e *Explainer) collectObjects(ctx context.Context, crpName string) ([]client.Object, int, error) {
+	var objs []client.Object
+
+	clusterList := &clusterv1beta1.MemberClusterList{}
+	if err := e.reader.List(ctx, clusterList); err != nil {
+		return nil, 0, controller.NewAPIServerError(true, err)
+	}
+	for idx := range clusterList.Items {
+		objs = append(objs, sanitize(&clusterList.Items[idx]))
+	}
+
+	// Bindings of other placements are read as well, e.g., by the exclusivity plugin.
+	bindingList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := e.reader.List(ctx, bindingList); err != nil {
+		return nil, 0, controller.NewAPIServerError(true, err)
+	}
+	for idx := range bindingList.Items {
+		objs = append(objs, sanitize(&bindingList.Items[idx]))
+	}
+
+	// Other placements are read as well, e.g., by the preemption plugin for their priorities.
+	crpList := &placementv1beta1.ClusterResourcePlacementList{}
+	if err := e.reader.List(ctx, crpList); err != nil {
+		return nil, 0, controller.NewAPIServerError(true, err)
+	}
+	for idx := range crpList.Items {
+		if crpList.Items[idx].Name != crpName {
+			objs = append(objs, sanitize(&crpList.Items[idx]))
+		}
+	}
+
+	if e.pinnedPlacements {
+		pinnedPlacement := &placementv1alpha1.PinnedPlacement{}
+		switch err := e.reader.Get(ctx, types.NamespacedName{Name: crpName}, pinnedPlacement); {
+		case err == nil:
+			objs = append(objs, sanitize(pinnedPlacement))
+		case !apierrors.IsNotFound(err):
+			return nil, 0, controller.NewAPIServerError(true, err)
+		}
+	}
+	return objs, len(clusterList.Items), nil
+}
+
+// sanitize returns a copy of the object that can be added to the sandbox.
+func sanitize(obj client.Object) client.Object {
+	objCopy := obj.DeepCopyObject().(client.Object)
+	objCopy.SetResourceVersion("")
+	return objCopy
+}
@@ -0,0 +1,338 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package explainer
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/clusteraffinity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/exclusivity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/preemption"
+)
+
+const (
+	crpName = "test-crp"
+)
+
+func serviceScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add cluster v1beta1 scheme: %v", err)
+	}
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add placement v1beta1 scheme: %v", err)
+	}
+	if err := placementv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add placement v1alpha1 scheme: %v", err)
+	}
+	return scheme
+}
+
+// newTestProfiles builds the profiles the explainer tests use, which pick clusters by affinity only.
+func newTestProfiles() ([]*framework.Profile, error) {
+	p := framework.NewProfile("TestProfile")
+	clusterAffinityPlugin := clusteraffinity.New()
+	p.WithPreFilterPlugin(&clusterAffinityPlugin).WithFilterPlugin(&clusterAffinityPlugin).
+		WithPreScorePlugin(&clusterAffinityPlugin).WithScorePlugin(&clusterAffinityPlugin)
+	return []*framework.Profile{p}, nil
+}
+
+// TestExplain tests the Explain method of the explainer.
+func TestExplain(t *testing.T) {
+	placementPolicy := &placementv1beta1.PlacementPolicy{
+		PlacementType:    placementv1beta1.PickNPlacementType,
+		NumberOfClusters: ptr.To(int32(1)),
+		Affinity: &placementv1beta1.Affinity{
+			ClusterAffinity: &placementv1beta1.ClusterAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &placementv1beta1.ClusterSelector{
+					ClusterSelectorTerms: []placementv1beta1.ClusterSelectorTerm{
+						{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+					},
+				},
+			},
+		},
+	}
+	objs := []client.Object{
+		&placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       crpName,
+				Generation: 1,
+			},
+			Spec: placementv1beta1.ClusterResourcePlacementSpec{
+				ResourceSelectors: []placementv1beta1.ClusterResourceSelector{
+					{Group: "", Version: "v1", Kind: "Namespace", Name: "test-ns"},
+				},
+				Policy: placementPolicy,
+			},
+		},
+		&placementv1beta1.ClusterSchedulingPolicySnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: crpName + "-1",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel:      crpName,
+					placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+					placementv1beta1.PolicyIndexLabel:      "1",
+				},
+				Annotations: map[string]string{
+					placementv1beta1.CRPGenerationAnnotation:    "1",
+					placementv1beta1.NumberOfClustersAnnotation: "1",
+				},
+			},
+			Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+				Policy: placementPolicy,
+			},
+		},
+		&clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "bravelion",
+				Labels: map[string]string{"env": "prod"},
+			},
+		},
+		&clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "smartcat",
+				Labels: map[string]string{"env": "dev"},
+			},
+		},
+	}
+	scheme := serviceScheme(t)
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	e := New(reader, scheme, newTestProfiles, WithPinnedPlacements())
+
+	explanation, err := e.Explain(context.Background(), crpName)
+	if err != nil {
+		t.Fatalf("Explain() = %v, want no error", err)
+	}
+	if !explanation.Scheduled || explanation.Profile != "TestProfile" {
+		t.Errorf("Explain() = %+v, want the placement scheduled with profile TestProfile", explanation)
+	}
+	gotSelected := map[string]bool{}
+	for _, decision := range explanation.Decisions {
+		gotSelected[decision.ClusterName] = decision.Selected
+	}
+	if diff := cmp.Diff(gotSelected, map[string]bool{"bravelion": true, "smartcat": false}); diff != "" {
+		t.Errorf("Explain() selected clusters diff (-got, +want): %s", diff)
+	}
+
+	// The explainer must not write anything to the hub cluster.
+	bindingList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := reader.List(context.Background(), bindingList); err != nil {
+		t.Fatalf("failed to list bindings: %v", err)
+	}
+	if len(bindingList.Items) != 0 {
+		t.Errorf("Explain() created %d bindings on the hub cluster, want none", len(bindingList.Items))
+	}
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{}
+	if err := reader.Get(context.Background(), client.ObjectKey{Name: crpName + "-1"}, policy); err != nil {
+		t.Fatalf("failed to get the policy snapshot: %v", err)
+	}
+	if len(policy.Status.ClusterDecisions) != 0 {
+		t.Errorf("Explain() updated the policy snapshot status on the hub cluster, want no updates")
+	}
+
+	if _, err := e.Explain(context.Background(), "missing-crp"); !apierrors.IsNotFound(err) {
+		t.Errorf("Explain(missing-crp) = %v, want a not found error", err)
+	}
+}
+
+// TestSimulate tests the Simulate method of the explainer.
+func TestSimulate(t *testing.T) {
+	objs := []client.Object{
+		&clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "bravelion",
+				Labels: map[string]string{"env": "prod"},
+			},
+		},
+		&clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "smartcat",
+				Labels: map[string]string{"env": "dev"},
+			},
+		},
+	}
+	scheme := serviceScheme(t)
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	e := New(reader, scheme, newTestProfiles, WithPinnedPlacements())
+
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "new-crp",
+			Generation: 1,
+		},
+		Spec: placementv1beta1.ClusterResourcePlacementSpec{
+			ResourceSelectors: []placementv1beta1.ClusterResourceSelector{
+				{Group: "", Version: "v1", Kind: "Namespace", Name: "test-ns"},
+			},
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: ptr.To(int32(2)),
+				Affinity: &placementv1beta1.Affinity{
+					ClusterAffinity: &placementv1beta1.ClusterAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &placementv1beta1.ClusterSelector{
+							ClusterSelectorTerms: []placementv1beta1.ClusterSelectorTerm{
+								{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	explanation, err := e.Simulate(context.Background(), crp)
+	if err != nil {
+		t.Fatalf("Simulate() = %v, want no error", err)
+	}
+	// Only one cluster matches the affinity.
+	if explanation.Scheduled {
+		t.Errorf("Simulate() = %+v, want the placement not fully scheduled", explanation)
+	}
+
+	crp.Annotations = map[string]string{placementv1beta1.SchedulerProfileAnnotation: "MissingProfile"}
+	if _, err := e.Simulate(context.Background(), crp); err == nil {
+		t.Errorf("Simulate() with a missing profile = nil, want an error")
+	}
+}
+
+// TestSimulateWithPreemption tests the Simulate method of the explainer with a placement which
+// preempts a placement of a lower priority.
+func TestSimulateWithPreemption(t *testing.T) {
+	objs := []client.Object{
+		&placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "low-crp",
+				Generation: 1,
+			},
+			Spec: placementv1beta1.ClusterResourcePlacementSpec{
+				ResourceSelectors: []placementv1beta1.ClusterResourceSelector{
+					{Group: "", Version: "v1", Kind: "Namespace", Name: "test-ns"},
+				},
+				Policy: &placementv1beta1.PlacementPolicy{
+					PlacementType:    placementv1beta1.PickNPlacementType,
+					NumberOfClusters: ptr.To(int32(1)),
+					ExclusivityGroup: "dedicated",
+				},
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "low-crp-bravelion",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel:      "low-crp",
+					placementv1beta1.ExclusivityGroupLabel: "dedicated",
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateBound,
+				TargetCluster: "bravelion",
+			},
+		},
+		&clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "bravelion",
+				Labels: map[string]string{"env": "prod"},
+			},
+		},
+		&clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "smartcat",
+				Labels: map[string]string{"env": "dev"},
+			},
+		},
+	}
+	scheme := serviceScheme(t)
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	buildProfiles := func() ([]*framework.Profile, error) {
+		p := framework.NewProfile("TestProfile")
+		clusterAffinityPlugin := clusteraffinity.New()
+		exclusivityPlugin := exclusivity.New()
+		preemptionPlugin := preemption.New()
+		p.WithPreFilterPlugin(&clusterAffinityPlugin).WithFilterPlugin(&clusterAffinityPlugin).
+			WithPreFilterPlugin(&exclusivityPlugin).WithFilterPlugin(&exclusivityPlugin).
+			WithPreFilterPlugin(&preemptionPlugin).WithFilterPlugin(&preemptionPlugin).WithPostFilterPlugin(&preemptionPlugin).
+			WithPreScorePlugin(&clusterAffinityPlugin).WithScorePlugin(&clusterAffinityPlugin)
+		return []*framework.Profile{p}, nil
+	}
+	e := New(reader, scheme, buildProfiles)
+
+	testCases := []struct {
+		name          string
+		priority      int32
+		wantScheduled bool
+	}{
+		{
+			name:          "same priority",
+			priority:      0,
+			wantScheduled: false,
+		},
+		{
+			name:          "higher priority",
+			priority:      10,
+			wantScheduled: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			crp := &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "new-crp",
+					Generation: 1,
+				},
+				Spec: placementv1beta1.ClusterResourcePlacementSpec{
+					ResourceSelectors: []placementv1beta1.ClusterResourceSelector{
+						{Group: "", Version: "v1", Kind: "Namespace", Name: "test-ns"},
+					},
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:    placementv1beta1.PickNPlacementType,
+						NumberOfClusters: ptr.To(int32(1)),
+						Affinity: &placementv1beta1.Affinity{
+							ClusterAffinity: &placementv1beta1.ClusterAffinity{
+								RequiredDuringSchedulingIgnoredDuringExecution: &placementv1beta1.ClusterSelector{
+									ClusterSelectorTerms: []placementv1beta1.ClusterSelectorTerm{
+										{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+									},
+								},
+							},
+						},
+						ExclusivityGroup: "dedicated",
+					},
+					Priority: tc.priority,
+				},
+			}
+			explanation, err := e.Simulate(context.Background(), crp)
+			if err != nil {
+				t.Fatalf("Simulate() = %v, want no error", err)
+			}
+			if explanation.Scheduled != tc.wantScheduled {
+				t.Errorf("Simulate() = %+v, want scheduled %t", explanation, tc.wantScheduled)
+			}
+		})
+	}
+
+	// The explainer must not preempt anything on the hub cluster.
+	binding := &placementv1beta1.ClusterResourceBinding{}
+	if err := reader.Get(context.Background(), client.ObjectKey{Name: "low-crp-bravelion"}, binding); err != nil {
+		t.Fatalf("failed to get the binding: %v", err)
+	}
+	if binding.Spec.State != placementv1beta1.BindingStateBound {
+		t.Errorf("Simulate() changed the binding state on the hub cluster to %s, want %s", binding.Spec.State, placementv1beta1.BindingStateBound)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package explainer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/yaml"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/validator"
+)
+
+const (
+	// ExplainPath is the path on which the scheduling decisions of existing placements are explained,
+	// e.g., /scheduler/explain?placement=web&cluster=bravelion.
+	ExplainPath = "/scheduler/explain"
+	// SimulatePath is the path on which placements (in JSON or YAML) posted are simulated.
+	SimulatePath = "/scheduler/simulate"
+
+	// maxPlacementBytes is the max size of a placement posted for simulation.
+	maxPlacementBytes = 1 << 20
+	// readHeaderTimeout is how long the server waits for the headers of a request.
+	readHeaderTimeout = 10 * time.Second
+)
+
+// NewServer returns an HTTP server, run by a controller manager, which serves the explainer at the
+// given address.
+//
+// The server runs whether or not the manager is the leader.
+func NewServer(addr string, e *Explainer) *manager.Server {
+	return &manager.Server{
+		Name: "scheduler-explainer",
+		Server: &http.Server{
+			Addr:              addr,
+			Handler:           NewHandler(e),
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}
+}
+
+// NewHandler returns an HTTP handler which serves the explainer.
+//
+// A GET request to ExplainPath explains the scheduling decisions of the placement named by the
+// placement query parameter; with the cluster query parameter, only the decision on that cluster is
+// reported, and no decision means that the cluster is not considered at all, e.g., it has left the
+// fleet. A POST request to SimulatePath simulates the scheduling of the placement in the body.
+func NewHandler(e *Explainer) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ExplainPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		crpName := r.URL.Query().Get("placement")
+		if crpName == "" {
+			http.Error(w, "the placement query parameter is required", http.StatusBadRequest)
+			return
+		}
+		explanation, err := e.Explain(r.Context(), crpName)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if clusterName := r.URL.Query().Get("cluster"); clusterName != "" {
+			decisions := explanation.Decisions[:0]
+			for _, decision := range explanation.Decisions {
+				if decision.ClusterName == clusterName {
+					decisions = append(decisions, decision)
+				}
+			}
+			explanation.Decisions = decisions
+		}
+		writeJSON(w, explanation)
+	})
+	mux.HandleFunc(SimulatePath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPlacementBytes))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read the placement: %v", err), http.StatusBadRequest)
+			return
+		}
+		crp := &placementv1beta1.ClusterResourcePlacement{}
+		if err := yaml.UnmarshalStrict(data, crp); err != nil {
+			http.Error(w, fmt.Sprintf("failed to parse the placement: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := validator.ValidateClusterResourcePlacementOffline(crp); err != nil {
+			http.Error(w, fmt.Sprintf("the placement is invalid: %v", err), http.StatusBadRequest)
+			return
+		}
+		explanation, err := e.Simulate(r.Context(), crp)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, explanation)
+	})
+	return mux
+}
+
+// writeJSON writes the explanation as the response.
+func writeJSON(w http.ResponseWriter, explanation *Explanation) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(explanation); err != nil {
+		klog.ErrorS(err, "Failed to write the scheduling explanation")
+	}
+}
+
+// writeError writes the error as the response.
+func writeError(w http.ResponseWriter, err error) {
+	if apierrors.IsNotFound(err) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	klog.ErrorS(err, "Failed to run the scheduler explainer")
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package explainer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	simulatedCRP = `
+apiVersion: placement.kubernetes-fleet.io/v1beta1
+kind: ClusterResourcePlacement
+metadata:
+  name: new-crp
+spec:
+  resourceSelectors:
+  - group: ""
+    version: v1
+    kind: Namespace
+    name: test-ns
+  policy:
+    placementType: PickAll
+`
+)
+
+// TestNewHandler tests the handler which serves the explainer.
+func TestNewHandler(t *testing.T) {
+	placementPolicy := &placementv1beta1.PlacementPolicy{
+		PlacementType:    placementv1beta1.PickNPlacementType,
+		NumberOfClusters: ptr.To(int32(1)),
+		Affinity: &placementv1beta1.Affinity{
+			ClusterAffinity: &placementv1beta1.ClusterAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &placementv1beta1.ClusterSelector{
+					ClusterSelectorTerms: []placementv1beta1.ClusterSelectorTerm{
+						{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+					},
+				},
+			},
+		},
+	}
+	objs := []client.Object{
+		&placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       crpName,
+				Generation: 1,
+			},
+			Spec: placementv1beta1.ClusterResourcePlacementSpec{
+				ResourceSelectors: []placementv1beta1.ClusterResourceSelector{
+					{Group: "", Version: "v1", Kind: "Namespace", Name: "test-ns"},
+				},
+				Policy: placementPolicy,
+			},
+		},
+		&placementv1beta1.ClusterSchedulingPolicySnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: crpName + "-1",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel:      crpName,
+					placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+					placementv1beta1.PolicyIndexLabel:      "1",
+				},
+				Annotations: map[string]string{
+					placementv1beta1.CRPGenerationAnnotation:    "1",
+					placementv1beta1.NumberOfClustersAnnotation: "1",
+				},
+			},
+			Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+				Policy: placementPolicy,
+			},
+		},
+		&clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "bravelion",
+				Labels: map[string]string{"env": "prod"},
+			},
+		},
+		&clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "smartcat",
+				Labels: map[string]string{"env": "dev"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		target        string
+		body          string
+		wantStatus    int
+		wantScheduled bool
+		wantClusters  []string
+	}{
+		{
+			name:          "explain",
+			method:        http.MethodGet,
+			target:        ExplainPath + "?placement=" + crpName,
+			wantStatus:    http.StatusOK,
+			wantScheduled: true,
+			wantClusters:  []string{"bravelion", "smartcat"},
+		},
+		{
+			name:          "explain a cluster",
+			method:        http.MethodGet,
+			target:        ExplainPath + "?placement=" + crpName + "&cluster=smartcat",
+			wantStatus:    http.StatusOK,
+			wantScheduled: true,
+			wantClusters:  []string{"smartcat"},
+		},
+		{
+			name:       "explain without a placement",
+			method:     http.MethodGet,
+			target:     ExplainPath,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "explain a missing placement",
+			method:     http.MethodGet,
+			target:     ExplainPath + "?placement=missing-crp",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "explain with a wrong method",
+			method:     http.MethodPost,
+			target:     ExplainPath + "?placement=" + crpName,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:          "simulate",
+			method:        http.MethodPost,
+			target:        SimulatePath,
+			body:          simulatedCRP,
+			wantStatus:    http.StatusOK,
+			wantScheduled: true,
+			wantClusters:  []string{"bravelion", "smartcat"},
+		},
+		{
+			name:       "simulate an invalid placement",
+			method:     http.MethodPost,
+			target:     SimulatePath,
+			body:       strings.Replace(simulatedCRP, "PickAll", "PickN", 1),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "simulate with a wrong method",
+			method:     http.MethodGet,
+			target:     SimulatePath,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := serviceScheme(t)
+			reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+			e := New(reader, scheme, newTestProfiles, WithPinnedPlacements())
+			recorder := httptest.NewRecorder()
+			NewHandler(e).ServeHTTP(recorder, httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body)))
+			if recorder.Code != tc.wantStatus {
+				t.Fatalf("ServeHTTP() status = %d, want %d: %s", recorder.Code, tc.wantStatus, recorder.Body.String())
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			explanation := &Explanation{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), explanation); err != nil {
+				t.Fatalf("failed to decode the response: %v", err)
+			}
+			if explanation.Scheduled != tc.wantScheduled {
+				t.Errorf("ServeHTTP() scheduled = %t, want %t", explanation.Scheduled, tc.wantScheduled)
+			}
+			var gotClusters []string
+			for _, decision := range explanation.Decisions {
+				gotClusters = append(gotClusters, decision.ClusterName)
+			}
+			if diff := cmp.Diff(gotClusters, tc.wantClusters); diff != "" {
+				t.Errorf("ServeHTTP() clusters diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package extender features a scheduler extender that calls an out-of-tree web service over HTTP(S)
+// to filter and/or score clusters.
+package extender
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// defaultTimeout is the default timeout of the calls to an extender.
+	defaultTimeout = 5 * time.Second
+
+	// maxResponseBytes is the max size of a response from an extender.
+	maxResponseBytes = 10 << 20
+)
+
+// Config is the configuration of an HTTP extender.
+type Config struct {
+	// Name is the name of the extender; it must be unique among the plugins and extenders in a profile.
+	Name string `json:"name"`
+	// URLPrefix is the URL prefix of the extender, e.g., https://extender.example.com/fleet.
+	URLPrefix string `json:"urlPrefix"`
+	// FilterVerb is the path, relative to the URL prefix, to POST filter requests to; empty means the
+	// extender does not filter clusters.
+	FilterVerb string `json:"filterVerb,omitempty"`
+	// ScoreVerb is the path, relative to the URL prefix, to POST score requests to; empty means the
+	// extender does not score clusters.
+	ScoreVerb string `json:"scoreVerb,omitempty"`
+	// Weight is the weight of the scores from the extender; it defaults to 1.
+	Weight *int `json:"weight,omitempty"`
+	// Priority is the priority class of the scores from the extender with the Lexicographic aggregation
+	// strategy; it defaults to 0.
+	Priority *int `json:"priority,omitempty"`
+	// Timeout is the timeout of each call to the extender; it defaults to 5 seconds.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Ignorable is true if the scheduler should carry on without the extender should the extender fail.
+	Ignorable bool `json:"ignorable,omitempty"`
+	// CAFile is the path to the PEM-encoded CA bundle to verify the certificate of the extender with;
+	// empty means the system CA bundle is used.
+	CAFile string `json:"caFile,omitempty"`
+}
+
+// Args is the body of the requests to an extender.
+type Args struct {
+	// Policy is the scheduling policy snapshot being scheduled.
+	Policy *placementv1beta1.ClusterSchedulingPolicySnapshot `json:"policy"`
+	// Clusters is the candidate clusters.
+	Clusters []clusterv1beta1.MemberCluster `json:"clusters"`
+}
+
+// FilterResult is the body of the responses to filter requests.
+type FilterResult struct {
+	// FailedClusters is the clusters that the extender rejects, with the reasons keyed by the cluster names.
+	FailedClusters map[string]string `json:"failedClusters,omitempty"`
+	// Error is the error the extender has encountered, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// ScoreResult is the body of the responses to score requests.
+type ScoreResult struct {
+	// Scores is the scores of the clusters, keyed by the cluster names; each score should be in the
+	// range of [-100, 100], and is clamped into the range otherwise.
+	Scores map[string]int `json:"scores,omitempty"`
+	// Error is the error the extender has encountered, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// HTTPExtender is an extender that calls an out-of-tree web service over HTTP(S), with JSON bodies.
+type HTTPExtender struct {
+	name       string
+	urlPrefix  string
+	filterVerb string
+	scoreVerb  string
+	ignorable  bool
+	client     *http.Client
+}
+
+// Verify that HTTPExtender implements framework.Extender at compile time.
+var _ framework.Extender = &HTTPExtender{}
+
+// NewHTTPExtender returns an HTTP extender with the given configuration.
+func NewHTTPExtender(config *Config) (*HTTPExtender, error) {
+	if config.Name == "" {
+		return nil, fmt.Errorf("the name of the extender is empty")
+	}
+	u, err := url.Parse(config.URLPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL prefix %q of extender %s: %w", config.URLPrefix, config.Name, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid URL prefix %q of extender %s: the scheme must be http or https", config.URLPrefix, config.Name)
+	}
+	if config.FilterVerb == "" && config.ScoreVerb == "" {
+		return nil, fmt.Errorf("extender %s neither filters nor scores clusters", config.Name)
+	}
+
+	timeout := defaultTimeout
+	if config.Timeout != nil {
+		if config.Timeout.Duration <= 0 {
+			return nil, fmt.Errorf("the timeout of extender %s must be greater than 0, got %s", config.Name, config.Timeout.Duration)
+		}
+		timeout = config.Timeout.Duration
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.CAFile != "" {
+		caBundle, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA file of extender %s: %w", config.Name, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no valid certificate is found in the CA file of extender %s", config.Name)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	return &HTTPExtender{
+		name:       config.Name,
+		urlPrefix:  strings.TrimSuffix(config.URLPrefix, "/"),
+		filterVerb: strings.TrimPrefix(config.FilterVerb, "/"),
+		scoreVerb:  strings.TrimPrefix(config.ScoreVerb, "/"),
+		ignorable:  config.Ignorable,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+		},
+	}, nil
+}
+
+// Name returns the name of the extender.
+func (e *HTTPExtender) Name() string {
+	return e.name
+}
+
+// IsFilter returns true if the extender filters clusters.
+func (e *HTTPExtender) IsFilter() bool {
+	return e.filterVerb != ""
+}
+
+// IsScorer returns true if the extender scores clusters.
+func (e *HTTPExtender) IsScorer() bool {
+	return e.scoreVerb != ""
+}
+
+// IsIgnorable returns true if the scheduler should carry on without the extender should it fail.
+func (e *HTTPExtender) IsIgnorable() bool {
+	return e.ignorable
+}
+
+// Filter calls the extender to filter the clusters.
+func (e *HTTPExtender) Filter(ctx context.Context, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, clusters []*clusterv1beta1.MemberCluster) (map[string]string, error) {
+	result := &FilterResult{}
+	if err := e.send(ctx, e.filterVerb, newArgs(policy, clusters), result); err != nil {
+		return nil, err
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("extender returned an error: %s", result.Error)
+	}
+	return result.FailedClusters, nil
+}
+
+// Score calls the extender to score the clusters.
+func (e *HTTPExtender) Score(ctx context.Context, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, clusters []*clusterv1beta1.MemberCluster) (map[string]int, error) {
+	result := &ScoreResult{}
+	if err := e.send(ctx, e.scoreVerb, newArgs(policy, clusters), result); err != nil {
+		return nil, err
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("extender returned an error: %s", result.Error)
+	}
+	return result.Scores, nil
+}
+
+// newArgs returns the body of a request to an extender.
+func newArgs(policy *placementv1beta1.ClusterSchedulingPolicySnapshot, clusters []*clusterv1beta1.MemberCluster) *Args {
+	args := &Args{
+		Policy:   policy,
+		Clusters: make([]clusterv1beta1.MemberCluster, 0, len(clusters)),
+	}
+	for _, cluster := range clusters {
+		args.Clusters = append(args.Clusters, *cluster)
+	}
+	return args
+}
+
+// send POSTs a request to the extender and decodes the response into the result.
+func (e *HTTPExtender) send(ctx context.Context, verb string, args *Args, result interface{}) error {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("failed to encode the request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.urlPrefix+"/"+verb, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build the request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call the extender: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("extender responded with status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode the response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package extender
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	extenderName   = "test-extender"
+	clusterName    = "bravelion"
+	altClusterName = "smartcat"
+	policyName     = "test-policy"
+)
+
+// newTestServer returns a test server which checks the requests and responds with the given
+// status code and body.
+func newTestServer(t *testing.T, wantPath string, statusCode int, body interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != wantPath {
+			t.Errorf("request = %s %s, want POST %s", r.Method, r.URL.Path, wantPath)
+		}
+		args := &Args{}
+		if err := json.NewDecoder(r.Body).Decode(args); err != nil {
+			t.Errorf("failed to decode the request: %v", err)
+		}
+		if args.Policy == nil || args.Policy.Name != policyName || len(args.Clusters) != 2 {
+			t.Errorf("request args = %+v, want policy %s and 2 clusters", args, policyName)
+		}
+		w.WriteHeader(statusCode)
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			t.Errorf("failed to encode the response: %v", err)
+		}
+	}))
+}
+
+// TestFilter tests the Filter method.
+func TestFilter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statusCode int
+		body       *FilterResult
+		want       map[string]string
+		wantErr    bool
+	}{
+		{
+			name:       "clusters filtered",
+			statusCode: http.StatusOK,
+			body:       &FilterResult{FailedClusters: map[string]string{clusterName: "not approved"}},
+			want:       map[string]string{clusterName: "not approved"},
+		},
+		{
+			name:       "extender returned an error",
+			statusCode: http.StatusOK,
+			body:       &FilterResult{Error: "internal error"},
+			wantErr:    true,
+		},
+		{
+			name:       "extender responded with an error status",
+			statusCode: http.StatusInternalServerError,
+			body:       &FilterResult{},
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t, "/fleet/filter", tc.statusCode, tc.body)
+			defer server.Close()
+
+			e, err := NewHTTPExtender(&Config{Name: extenderName, URLPrefix: server.URL + "/fleet/", FilterVerb: "filter"})
+			if err != nil {
+				t.Fatalf("NewHTTPExtender() = %v, want no error", err)
+			}
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{ObjectMeta: metav1.ObjectMeta{Name: policyName}}
+			clusters := []*clusterv1beta1.MemberCluster{
+				{ObjectMeta: metav1.ObjectMeta{Name: clusterName}},
+				{ObjectMeta: metav1.ObjectMeta{Name: altClusterName}},
+			}
+			got, err := e.Filter(context.Background(), policy, clusters)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Filter() = %v, want error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Filter() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestScore tests the Score method.
+func TestScore(t *testing.T) {
+	server := newTestServer(t, "/score", http.StatusOK, &ScoreResult{Scores: map[string]int{clusterName: 10, altClusterName: 5}})
+	defer server.Close()
+
+	e, err := NewHTTPExtender(&Config{Name: extenderName, URLPrefix: server.URL, ScoreVerb: "/score"})
+	if err != nil {
+		t.Fatalf("NewHTTPExtender() = %v, want no error", err)
+	}
+	if e.IsFilter() || !e.IsScorer() {
+		t.Errorf("IsFilter(), IsScorer() = %t, %t, want false, true", e.IsFilter(), e.IsScorer())
+	}
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{ObjectMeta: metav1.ObjectMeta{Name: policyName}}
+	clusters := []*clusterv1beta1.MemberCluster{
+		{ObjectMeta: metav1.ObjectMeta{Name: clusterName}},
+		{ObjectMeta: metav1.ObjectMeta{Name: altClusterName}},
+	}
+	got, err := e.Score(context.Background(), policy, clusters)
+	if err != nil {
+		t.Fatalf("Score() = %v, want no error", err)
+	}
+	if diff := cmp.Diff(map[string]int{clusterName: 10, altClusterName: 5}, got); diff != "" {
+		t.Errorf("Score() mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+// TestNewHTTPExtender tests the NewHTTPExtender function.
+func TestNewHTTPExtender(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  *Config
+		wantErr bool
+	}{
+		{
+			name:   "valid config",
+			config: &Config{Name: extenderName, URLPrefix: "https://extender.example.com", FilterVerb: "filter", Timeout: &metav1.Duration{Duration: time.Second}},
+		},
+		{
+			name:    "no name",
+			config:  &Config{URLPrefix: "https://extender.example.com", FilterVerb: "filter"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid scheme",
+			config:  &Config{Name: extenderName, URLPrefix: "grpc://extender.example.com", FilterVerb: "filter"},
+			wantErr: true,
+		},
+		{
+			name:    "no verbs",
+			config:  &Config{Name: extenderName, URLPrefix: "https://extender.example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "non-positive timeout",
+			config:  &Config{Name: extenderName, URLPrefix: "https://extender.example.com", ScoreVerb: "score", Timeout: &metav1.Duration{}},
+			wantErr: true,
+		},
+		{
+			name:    "missing CA file",
+			config:  &Config{Name: extenderName, URLPrefix: "https://extender.example.com", ScoreVerb: "score", CAFile: "/nonexistent/ca.crt"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewHTTPExtender(tc.config)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("NewHTTPExtender() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
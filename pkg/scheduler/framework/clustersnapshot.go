@@ -0,0 +1,166 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+)
+
+// ClusterSnapshot is an in-memory view of all the member clusters in the fleet, kept up to date
+// by the events of the member cluster informer.
+//
+// Scheduling cycles read the fleet from the snapshot at their start, rather than listing (and
+// deep-copying) every member cluster from the cache in each cycle. Every change to the fleet bumps
+// the generation of the snapshot; the list of clusters of a generation is built at most once and
+// then shared by all the cycles that read the same generation, so that these cycles operate on
+// the same, consistent view of the fleet.
+type ClusterSnapshot struct {
+	mu sync.RWMutex
+	// clusters is the set of member clusters in the fleet, keyed by their names.
+	clusters map[string]*clusterv1beta1.MemberCluster
+	// generation is bumped every time a member cluster is added, updated, or removed.
+	generation int64
+
+	// list is the list of member clusters, sorted by their names, built at listGeneration.
+	list           []clusterv1beta1.MemberCluster
+	listGeneration int64
+
+	// hasSynced reports whether the snapshot has received all the member clusters from the
+	// initial list of the informer; it is nil before the snapshot is set up.
+	hasSynced func() bool
+}
+
+var (
+	// Verify that ClusterSnapshot can handle informer events at compile time.
+	_ toolscache.ResourceEventHandler = &ClusterSnapshot{}
+)
+
+// NewClusterSnapshot returns a new ClusterSnapshot.
+func NewClusterSnapshot() *ClusterSnapshot {
+	return &ClusterSnapshot{
+		clusters: make(map[string]*clusterv1beta1.MemberCluster),
+		// Start from a generation different from the list generation, so that the (empty)
+		// list is always built on the first read.
+		generation: 1,
+	}
+}
+
+// SetUp registers the snapshot with the member cluster informer of a cache.
+//
+// Note that it does not wait for the informer to sync; until then, the snapshot reports that it
+// is not ready for reads.
+func (s *ClusterSnapshot) SetUp(ctx context.Context, informers cache.Informers) error {
+	informer, err := informers.GetInformer(ctx, &clusterv1beta1.MemberCluster{})
+	if err != nil {
+		return fmt.Errorf("failed to get the member cluster informer: %w", err)
+	}
+	registration, err := informer.AddEventHandler(s)
+	if err != nil {
+		return fmt.Errorf("failed to register the cluster snapshot with the member cluster informer: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hasSynced = registration.HasSynced
+	return nil
+}
+
+// OnAdd adds a member cluster to the snapshot.
+func (s *ClusterSnapshot) OnAdd(obj interface{}, _ bool) {
+	cluster, ok := obj.(*clusterv1beta1.MemberCluster)
+	if !ok {
+		klog.V(2).InfoS("Ignored an added object of an unexpected type", "type", fmt.Sprintf("%T", obj))
+		return
+	}
+	s.set(cluster)
+}
+
+// OnUpdate updates a member cluster in the snapshot.
+func (s *ClusterSnapshot) OnUpdate(_, newObj interface{}) {
+	cluster, ok := newObj.(*clusterv1beta1.MemberCluster)
+	if !ok {
+		klog.V(2).InfoS("Ignored an updated object of an unexpected type", "type", fmt.Sprintf("%T", newObj))
+		return
+	}
+	s.set(cluster)
+}
+
+// OnDelete removes a member cluster from the snapshot.
+func (s *ClusterSnapshot) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		// The informer has missed the deletion event; the object is the last known state.
+		obj = tombstone.Obj
+	}
+	cluster, ok := obj.(*clusterv1beta1.MemberCluster)
+	if !ok {
+		klog.V(2).InfoS("Ignored a deleted object of an unexpected type", "type", fmt.Sprintf("%T", obj))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, found := s.clusters[cluster.Name]; found {
+		delete(s.clusters, cluster.Name)
+		s.generation++
+	}
+}
+
+// set adds or replaces a member cluster in the snapshot.
+func (s *ClusterSnapshot) set(cluster *clusterv1beta1.MemberCluster) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, found := s.clusters[cluster.Name]; found && existing.ResourceVersion == cluster.ResourceVersion && cluster.ResourceVersion != "" {
+		// The cluster has not changed, e.g., the informer resyncs; keep the generation as it is.
+		return
+	}
+	s.clusters[cluster.Name] = cluster
+	s.generation++
+}
+
+// Clusters returns the member clusters in the snapshot, sorted by their names, along with the
+// generation of the snapshot they come from.
+//
+// The returned list is shared by all the readers of the same generation, and must not be
+// modified. It returns false if the snapshot is not ready for reads yet, i.e., it has not been
+// set up, or the member cluster informer has not synced.
+func (s *ClusterSnapshot) Clusters() ([]clusterv1beta1.MemberCluster, int64, bool) {
+	s.mu.RLock()
+	if s.hasSynced == nil || !s.hasSynced() {
+		s.mu.RUnlock()
+		return nil, 0, false
+	}
+	if s.listGeneration == s.generation {
+		list, generation := s.list, s.generation
+		s.mu.RUnlock()
+		return list, generation, true
+	}
+	s.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listGeneration != s.generation {
+		// Build the list of the current generation; other readers might have done so between
+		// the two locks.
+		list := make([]clusterv1beta1.MemberCluster, 0, len(s.clusters))
+		for _, cluster := range s.clusters {
+			list = append(list, *cluster.DeepCopy())
+		}
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Name < list[j].Name
+		})
+		s.list, s.listGeneration = list, s.generation
+	}
+	return s.list, s.generation, true
+}
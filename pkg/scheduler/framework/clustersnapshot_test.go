@@ -0,0 +1,89 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+)
+
+// newSyncedClusterSnapshot returns a cluster snapshot that is ready for reads, with the given
+// clusters added.
+func newSyncedClusterSnapshot(clusters ...*clusterv1beta1.MemberCluster) *ClusterSnapshot {
+	s := NewClusterSnapshot()
+	s.hasSynced = func() bool { return true }
+	for _, cluster := range clusters {
+		s.OnAdd(cluster, true)
+	}
+	return s
+}
+
+// TestClusterSnapshot tests the basic operations of ClusterSnapshot.
+func TestClusterSnapshot(t *testing.T) {
+	cluster1 := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "cluster-1",
+			ResourceVersion: "1",
+		},
+	}
+	cluster2 := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "cluster-2",
+			ResourceVersion: "1",
+		},
+	}
+	updatedCluster2 := cluster2.DeepCopy()
+	updatedCluster2.ResourceVersion = "2"
+	updatedCluster2.Labels = map[string]string{"region": "east"}
+
+	s := NewClusterSnapshot()
+	if _, _, ok := s.Clusters(); ok {
+		t.Fatalf("Clusters() = _, _, true, want false before the snapshot is set up")
+	}
+	s.hasSynced = func() bool { return true }
+
+	s.OnAdd(cluster2, true)
+	s.OnAdd(cluster1, true)
+	clusters, generation, ok := s.Clusters()
+	if !ok {
+		t.Fatalf("Clusters() = _, _, false, want true")
+	}
+	want := []clusterv1beta1.MemberCluster{*cluster1, *cluster2}
+	if diff := cmp.Diff(clusters, want); diff != "" {
+		t.Fatalf("Clusters() diff (-got, +want):\n%s", diff)
+	}
+
+	// A resync with no changes keeps the generation, and the list is shared.
+	s.OnUpdate(cluster1, cluster1)
+	sameClusters, sameGeneration, _ := s.Clusters()
+	if sameGeneration != generation || &sameClusters[0] != &clusters[0] {
+		t.Fatalf("Clusters() = generation %d, want the list of generation %d", sameGeneration, generation)
+	}
+
+	// An update bumps the generation.
+	s.OnUpdate(cluster2, updatedCluster2)
+	clusters, updatedGeneration, _ := s.Clusters()
+	if updatedGeneration <= generation {
+		t.Fatalf("Clusters() = generation %d, want a generation newer than %d", updatedGeneration, generation)
+	}
+	want = []clusterv1beta1.MemberCluster{*cluster1, *updatedCluster2}
+	if diff := cmp.Diff(clusters, want); diff != "" {
+		t.Fatalf("Clusters() after update diff (-got, +want):\n%s", diff)
+	}
+
+	// Deletions, including the ones the informer has missed, remove clusters.
+	s.OnDelete(cluster1)
+	s.OnDelete(toolscache.DeletedFinalStateUnknown{Key: updatedCluster2.Name, Obj: updatedCluster2})
+	clusters, _, _ = s.Clusters()
+	if len(clusters) != 0 {
+		t.Fatalf("Clusters() = %v, want no clusters", clusters)
+	}
+}
@@ -21,6 +21,39 @@ type StateKey string
 // StateValue is the value stored in a CycleState under a specific key.
 type StateValue interface{}
 
+// PluginStateKey returns a StateKey namespaced by the name of a plugin.
+//
+// Plugins are encouraged to store their data under namespaced keys, so that values written
+// by one plugin will not be accidentally overwritten (or read) by another.
+func PluginStateKey(pluginName, key string) StateKey {
+	return StateKey(fmt.Sprintf("%s/%s", pluginName, key))
+}
+
+// ReadAs retrieves a value from a cycle state by a key, and casts it to the specified type.
+//
+// An error is returned if the key is not found, or the value stored under the key is not
+// of the specified type.
+func ReadAs[T any](state CycleStatePluginReadWriter, key StateKey) (T, error) {
+	var zero T
+	val, err := state.Read(key)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := val.(T)
+	if !ok {
+		return zero, fmt.Errorf("value of key %s is of type %T, want %T", key, val, zero)
+	}
+	return typed, nil
+}
+
+// WriteAs stores a typed value in a cycle state under a key.
+//
+// It is a type-safe counterpart of Write; values stored via WriteAs can be retrieved
+// via ReadAs with the same type parameter.
+func WriteAs[T any](state CycleStatePluginReadWriter, key StateKey, val T) {
+	state.Write(key, val)
+}
+
 // CycleStatePluginReadWriter is an interface through which plugins can store and retrieve data.
 //
 // TO-DO (chenyu1): Add methods which allow plugins to query for bindings of different types being
@@ -57,6 +90,11 @@ type CycleState struct {
 	// cycle associated with the cluster.
 	obsoleteBindings map[string]bool
 
+	// takenBindingNames is the set of names of all the bindings of the placement, including the ones
+	// that are being deleted, and the ones to create in the current cycle; new bindings must not
+	// reuse any of these names.
+	takenBindingNames sets.Set[string]
+
 	// skippedFilterPlugins is a set of Filter plugins that should be skipped in the current scheduling cycle.
 	skippedFilterPlugins sets.Set[string]
 
@@ -71,6 +109,14 @@ type CycleState struct {
 	//
 	// This is set when scheduling policies of the PickN placement type.
 	batchSizeLimit int
+	// remediations is the list of actionable remediations suggested by post-filter plugins, when
+	// fewer clusters than needed have passed the Filter stage.
+	//
+	// This is set when scheduling policies of the PickN placement type.
+	remediations []string
+	// preempted is true if bindings of other placements have been marked as unscheduled at the
+	// PostFilter stage to make room for the placement; the placement is retried in a new cycle.
+	preempted bool
 }
 
 // Read retrieves a value from CycleState by a key.
@@ -135,6 +181,7 @@ func NewCycleState(clusters []clusterv1beta1.MemberCluster, obsoleteBindings []*
 		clusters:                 clusters,
 		scheduledOrBoundBindings: prepareScheduledOrBoundBindingsMap(scheduledOrBoundBindings...),
 		obsoleteBindings:         prepareObsoleteBindingsMap(obsoleteBindings),
+		takenBindingNames:        sets.New[string](),
 		skippedFilterPlugins:     sets.New[string](),
 		skippedScorePlugins:      sets.New[string](),
 	}
@@ -151,3 +151,33 @@ func TestPrepareObsoleteBindingsMap(t *testing.T) {
 		t.Errorf("prepareObsoleteBindingsMap() obsoleteBindingsMap diff (-got, +want): %s", diff)
 	}
 }
+
+// TestCycleStateTypedAccessors tests the typed accessors of a CycleState.
+func TestCycleStateTypedAccessors(t *testing.T) {
+	cs := NewCycleState(nil, nil)
+
+	type pluginState struct {
+		count int
+	}
+
+	key := PluginStateKey(dummyPluginName, "pluginState")
+	if want := StateKey(dummyPluginName + "/pluginState"); key != want {
+		t.Fatalf("PluginStateKey() = %v, want %v", key, want)
+	}
+
+	ps := &pluginState{count: 1}
+	WriteAs(cs, key, ps)
+	got, err := ReadAs[*pluginState](cs, key)
+	if err != nil || got != ps {
+		t.Fatalf("ReadAs(%v) = %v, %v, want %v, nil", key, got, err, ps)
+	}
+
+	if got, err := ReadAs[string](cs, key); got != "" || err == nil {
+		t.Fatalf("ReadAs(%v) = %v, %v, want empty value, type mismatch error", key, got, err)
+	}
+
+	altKey := PluginStateKey("anotherPlugin", "pluginState")
+	if got, err := ReadAs[*pluginState](cs, altKey); got != nil || err == nil {
+		t.Fatalf("ReadAs(%v) = %v, %v, want nil, not found error", altKey, got, err)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/klog/v2"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// ScoreClustersFor runs the Filter and Score stages of a scheduling cycle for a cluster resource
+// placement, as if it had not picked any cluster yet, and returns the clusters that pass the Filter
+// stage with their scores; it writes nothing.
+//
+// As the placement is considered to have no bindings, the clusters it has picked are scored
+// alongside the others, which allows the caller to tell if a better cluster has become available.
+func (f *framework) ScoreClustersFor(ctx context.Context, crpName string, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (ScoredClusters, error) {
+	policyRef := klog.KObj(policy)
+
+	if policy.Spec.Policy == nil || policy.Spec.Policy.PlacementType != placementv1beta1.PickNPlacementType {
+		err := fmt.Errorf("clusters can only be scored for policies of the PickN placement type")
+		klog.ErrorS(err, "Failed to score clusters", "clusterResourcePlacement", klog.KRef("", crpName), "clusterSchedulingPolicySnapshot", policyRef)
+		return nil, controller.NewUnexpectedBehaviorError(err)
+	}
+
+	clusters, err := f.collectClusters(ctx)
+	if err != nil {
+		klog.ErrorS(err, "Failed to collect clusters", "clusterSchedulingPolicySnapshot", policyRef)
+		return nil, err
+	}
+
+	// Prepare a cycle state with no bindings, so that none of the clusters is deemed as selected.
+	state := NewCycleState(clusters, nil)
+
+	if status := f.runPreFilterPlugins(ctx, state, policy); status.IsInteralError() {
+		klog.ErrorS(status.AsError(), "Failed to run pre filter plugins", "clusterSchedulingPolicySnapshot", policyRef)
+		return nil, controller.NewUnexpectedBehaviorError(status.AsError())
+	}
+	passed, _, err := f.runFilterPlugins(ctx, state, policy, clusters)
+	if err != nil {
+		klog.ErrorS(err, "Failed to run filter plugins", "clusterSchedulingPolicySnapshot", policyRef)
+		return nil, controller.NewUnexpectedBehaviorError(err)
+	}
+	if status := f.runPreScorePlugins(ctx, state, policy); status.IsInteralError() {
+		klog.ErrorS(status.AsError(), "Failed to run pre score plugins", "clusterSchedulingPolicySnapshot", policyRef)
+		return nil, controller.NewUnexpectedBehaviorError(status.AsError())
+	}
+	scored, err := f.runScorePlugins(ctx, state, policy, passed)
+	if err != nil {
+		klog.ErrorS(err, "Failed to run score plugins", "clusterSchedulingPolicySnapshot", policyRef)
+		return nil, controller.NewUnexpectedBehaviorError(err)
+	}
+	return scored, nil
+}
+
+// UnscheduleBindings marks bindings of a cluster resource placement as unscheduled for the given
+// reason, outside of a scheduling cycle.
+//
+// The writes go through the framework, so that the scheduling cycles that follow read them from the
+// (cached) client right away.
+func (f *framework) UnscheduleBindings(ctx context.Context, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, bindings []*placementv1beta1.ClusterResourceBinding, reason string) error {
+	if err := f.updateBindings(ctx, bindings, markUnscheduledForAndUpdate(reason, policy)); err != nil {
+		klog.ErrorS(err, "Failed to mark bindings as unscheduled", "clusterSchedulingPolicySnapshot", klog.KObj(policy), "reason", reason)
+		return err
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ package framework
 
 import (
 	"context"
+	"time"
 
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
@@ -18,12 +19,17 @@ const (
 
 // A no-op, dummy plugin which connects to all extension points.
 type DummyAllPurposePlugin struct {
-	name            string
-	postBatchRunner func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (size int, status *Status)
-	preFilterRunner func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (status *Status)
-	filterRunner    func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status)
-	preScoreRunner  func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (status *Status)
-	scoreRunner     func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (score *ClusterScore, status *Status)
+	name             string
+	postBatchRunner  func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (size int, status *Status)
+	preFilterRunner  func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (status *Status)
+	filterRunner     func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status)
+	postFilterRunner func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, filtered []*FilteredCluster, want int) (victims []*placementv1beta1.ClusterResourceBinding, status *Status)
+	preScoreRunner   func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (status *Status)
+	scoreRunner      func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (score *ClusterScore, status *Status)
+	preBindRunner    func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster, binding *placementv1beta1.ClusterResourceBinding) (status *Status)
+	permitRunner     func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status, timeout time.Duration)
+	reserveRunner    func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status)
+	unreserveRunner  func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster)
 }
 
 // Check that the dummy plugin implements all the interfaces at compile time.
@@ -32,8 +38,12 @@ type DummyAllPurposePlugin struct {
 var _ PostBatchPlugin = &DummyAllPurposePlugin{}
 var _ PreFilterPlugin = &DummyAllPurposePlugin{}
 var _ FilterPlugin = &DummyAllPurposePlugin{}
+var _ PostFilterPlugin = &DummyAllPurposePlugin{}
 var _ PreScorePlugin = &DummyAllPurposePlugin{}
 var _ ScorePlugin = &DummyAllPurposePlugin{}
+var _ PreBindPlugin = &DummyAllPurposePlugin{}
+var _ PermitPlugin = &DummyAllPurposePlugin{}
+var _ ReservePlugin = &DummyAllPurposePlugin{}
 
 // Name returns the name of the dummy plugin.
 func (p *DummyAllPurposePlugin) Name() string {
@@ -55,6 +65,11 @@ func (p *DummyAllPurposePlugin) Filter(ctx context.Context, state CycleStatePlug
 	return p.filterRunner(ctx, state, policy, cluster)
 }
 
+// PostFilter implements the PostFilter interface for the dummy plugin.
+func (p *DummyAllPurposePlugin) PostFilter(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, filtered []*FilteredCluster, want int) (victims []*placementv1beta1.ClusterResourceBinding, status *Status) { //nolint:revive
+	return p.postFilterRunner(ctx, state, policy, filtered, want)
+}
+
 // PreScore implements the PreScore interface for the dummy plugin.
 func (p *DummyAllPurposePlugin) PreScore(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (status *Status) { //nolint:revive
 	return p.preScoreRunner(ctx, state, policy)
@@ -65,5 +80,25 @@ func (p *DummyAllPurposePlugin) Score(ctx context.Context, state CycleStatePlugi
 	return p.scoreRunner(ctx, state, policy, cluster)
 }
 
+// PreBind implements the PreBind interface for the dummy plugin.
+func (p *DummyAllPurposePlugin) PreBind(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster, binding *placementv1beta1.ClusterResourceBinding) (status *Status) { //nolint:revive
+	return p.preBindRunner(ctx, state, policy, cluster, binding)
+}
+
+// Permit implements the Permit interface for the dummy plugin.
+func (p *DummyAllPurposePlugin) Permit(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status, timeout time.Duration) { //nolint:revive
+	return p.permitRunner(ctx, state, policy, cluster)
+}
+
+// Reserve implements the Reserve interface for the dummy plugin.
+func (p *DummyAllPurposePlugin) Reserve(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status) { //nolint:revive
+	return p.reserveRunner(ctx, state, policy, cluster)
+}
+
+// Unreserve implements the Reserve interface for the dummy plugin.
+func (p *DummyAllPurposePlugin) Unreserve(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) { //nolint:revive
+	p.unreserveRunner(ctx, state, policy, cluster)
+}
+
 // SetUpWithFramework is a no-op to satisfy the Plugin interface.
 func (p *DummyAllPurposePlugin) SetUpWithFramework(handle Handle) {} // nolint:revive
@@ -0,0 +1,153 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+// dedupEventRecorder is an event recorder that deduplicates and rate limits the events emitted by
+// a scheduler framework, so that scheduling storms do not flood the API server with events on
+// policy snapshots and placements.
+//
+// Events of the same type and reason on the same object are emitted at most once per window; the
+// next event emitted after the window reports how many events have been suppressed in between.
+type dedupEventRecorder struct {
+	recorder record.EventRecorder
+	// window is the period in which the events of the same key are emitted at most once.
+	window time.Duration
+	// limiter caps the rate of the events emitted across all keys; nil means no limit.
+	limiter *rate.Limiter
+	// now returns the current time; it is replaced in tests.
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[eventKey]*eventEntry
+	// nextSweep is the time after which the stale entries are removed.
+	nextSweep time.Time
+}
+
+// eventKey identifies the events which are deduplicated together.
+type eventKey struct {
+	kind      string
+	namespace string
+	name      string
+	eventType string
+	reason    string
+}
+
+// eventEntry tracks the events of a key.
+type eventEntry struct {
+	// lastEmitted is the time when the last event of the key was emitted.
+	lastEmitted time.Time
+	// suppressed is the number of events of the key which have been suppressed since then.
+	suppressed int
+}
+
+var (
+	// Verify that dedupEventRecorder implements record.EventRecorder at compile time.
+	_ record.EventRecorder = &dedupEventRecorder{}
+)
+
+// newDedupEventRecorder returns a new dedupEventRecorder wrapping an event recorder, or the event
+// recorder itself if the events need no deduplication or rate limiting.
+func newDedupEventRecorder(recorder record.EventRecorder, window time.Duration, limiter *rate.Limiter) record.EventRecorder {
+	if window <= 0 && limiter == nil {
+		return recorder
+	}
+	return &dedupEventRecorder{
+		recorder: recorder,
+		window:   window,
+		limiter:  limiter,
+		now:      time.Now,
+		entries:  make(map[eventKey]*eventEntry),
+	}
+}
+
+// Event emits an event, unless it is deduplicated or rate limited.
+func (r *dedupEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if message, ok := r.admit(object, eventtype, reason, message); ok {
+		r.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+// Eventf emits an event with a formatted message, unless it is deduplicated or rate limited.
+func (r *dedupEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	if message, ok := r.admit(object, eventtype, reason, fmt.Sprintf(messageFmt, args...)); ok {
+		r.recorder.Event(object, eventtype, reason, message)
+	}
+}
+
+// AnnotatedEventf emits an event with annotations and a formatted message, unless it is
+// deduplicated or rate limited.
+func (r *dedupEventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	if message, ok := r.admit(object, eventtype, reason, fmt.Sprintf(messageFmt, args...)); ok {
+		r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+	}
+}
+
+// admit checks if an event can be emitted; if so, it returns the message to emit, which reports
+// the number of events suppressed since the last one of the same key.
+func (r *dedupEventRecorder) admit(object runtime.Object, eventtype, reason, message string) (string, bool) {
+	key := eventKey{eventType: eventtype, reason: reason}
+	key.kind = object.GetObjectKind().GroupVersionKind().Kind
+	if accessor, err := meta.Accessor(object); err == nil {
+		key.namespace, key.name = accessor.GetNamespace(), accessor.GetName()
+	}
+	now := r.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sweep(now)
+
+	entry, found := r.entries[key]
+	if !found {
+		entry = &eventEntry{}
+		r.entries[key] = entry
+	}
+	if found && now.Sub(entry.lastEmitted) < r.window {
+		entry.suppressed++
+		return "", false
+	}
+	if r.limiter != nil && !r.limiter.AllowN(now, 1) {
+		klog.V(2).InfoS("Dropped a scheduler event due to rate limiting", "reason", reason, "kind", key.kind, "namespace", key.namespace, "name", key.name)
+		entry.suppressed++
+		return "", false
+	}
+
+	if entry.suppressed > 0 {
+		message = fmt.Sprintf("%s (%d similar event(s) suppressed)", message, entry.suppressed)
+	}
+	entry.lastEmitted = now
+	entry.suppressed = 0
+	return message, true
+}
+
+// sweep removes the entries whose last events are too old to suppress any event, so that the
+// entries of deleted objects do not pile up; it runs at most once per window.
+//
+// The caller must hold the lock.
+func (r *dedupEventRecorder) sweep(now time.Time) {
+	if now.Before(r.nextSweep) {
+		return
+	}
+	for key, entry := range r.entries {
+		// Keep the entries with suppressed events for another window, so that their counts can be
+		// reported by the next event.
+		if now.Sub(entry.lastEmitted) > 2*r.window {
+			delete(r.entries, key)
+		}
+	}
+	r.nextSweep = now.Add(r.window)
+}
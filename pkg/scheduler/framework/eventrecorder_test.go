@@ -0,0 +1,131 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// TestDedupEventRecorder tests the dedupEventRecorder.
+func TestDedupEventRecorder(t *testing.T) {
+	policySnapshot := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policyName,
+		},
+	}
+	anotherPolicySnapshot := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: altPolicyName,
+		},
+	}
+
+	type event struct {
+		// after is the time elapsed since the start of the test case.
+		after  time.Duration
+		object *placementv1beta1.ClusterSchedulingPolicySnapshot
+		reason string
+	}
+	testCases := []struct {
+		name       string
+		window     time.Duration
+		limiter    *rate.Limiter
+		events     []event
+		wantEvents []string
+	}{
+		{
+			name:   "repeated events within the window",
+			window: time.Minute,
+			events: []event{
+				{object: policySnapshot, reason: "Reason1"},
+				{after: time.Second, object: policySnapshot, reason: "Reason1"},
+				{after: 2 * time.Second, object: policySnapshot, reason: "Reason1"},
+			},
+			wantEvents: []string{
+				"Normal Reason1 message",
+			},
+		},
+		{
+			name:   "repeated event after the window reports the suppressed count",
+			window: time.Minute,
+			events: []event{
+				{object: policySnapshot, reason: "Reason1"},
+				{after: time.Second, object: policySnapshot, reason: "Reason1"},
+				{after: 2 * time.Second, object: policySnapshot, reason: "Reason1"},
+				{after: 2 * time.Minute, object: policySnapshot, reason: "Reason1"},
+			},
+			wantEvents: []string{
+				"Normal Reason1 message",
+				"Normal Reason1 message (2 similar event(s) suppressed)",
+			},
+		},
+		{
+			name:   "events of different reasons or objects",
+			window: time.Minute,
+			events: []event{
+				{object: policySnapshot, reason: "Reason1"},
+				{object: policySnapshot, reason: "Reason2"},
+				{object: anotherPolicySnapshot, reason: "Reason1"},
+			},
+			wantEvents: []string{
+				"Normal Reason1 message",
+				"Normal Reason2 message",
+				"Normal Reason1 message",
+			},
+		},
+		{
+			name:    "events over the rate limit",
+			limiter: rate.NewLimiter(rate.Limit(0.001), 2),
+			events: []event{
+				{object: policySnapshot, reason: "Reason1"},
+				{object: policySnapshot, reason: "Reason2"},
+				{object: anotherPolicySnapshot, reason: "Reason1"},
+			},
+			wantEvents: []string{
+				"Normal Reason1 message",
+				"Normal Reason2 message",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeRecorder := record.NewFakeRecorder(len(tc.events))
+			recorder := newDedupEventRecorder(fakeRecorder, tc.window, tc.limiter).(*dedupEventRecorder)
+			start := time.Now()
+			for _, e := range tc.events {
+				recorder.now = func() time.Time { return start.Add(e.after) }
+				recorder.Eventf(e.object, corev1.EventTypeNormal, e.reason, "%s", "message")
+			}
+			close(fakeRecorder.Events)
+
+			var gotEvents []string
+			for e := range fakeRecorder.Events {
+				gotEvents = append(gotEvents, e)
+			}
+			if diff := cmp.Diff(tc.wantEvents, gotEvents); diff != "" {
+				t.Errorf("emitted events (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestNewDedupEventRecorderNoOp tests that newDedupEventRecorder returns the wrapped recorder when
+// no deduplication or rate limiting is needed.
+func TestNewDedupEventRecorderNoOp(t *testing.T) {
+	fakeRecorder := record.NewFakeRecorder(1)
+	if got := newDedupEventRecorder(fakeRecorder, 0, nil); got != record.EventRecorder(fakeRecorder) {
+		t.Errorf("newDedupEventRecorder() = %v, want the wrapped recorder", got)
+	}
+}
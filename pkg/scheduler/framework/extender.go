@@ -0,0 +1,132 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/klog/v2"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// MaxExtenderScore and MinExtenderScore bound the scores from extenders; they match the range of
+	// the weight of a preferred cluster affinity term, so that an extender, unless weighted otherwise
+	// in the profile, counts no more than one such term.
+	MaxExtenderScore = 100
+	MinExtenderScore = -100
+)
+
+// Extender is an out-of-tree extension to the scheduler, e.g., a web service with organization-specific
+// placement logic, which filters and/or scores clusters in addition to the in-tree plugins.
+//
+// Unlike plugins, which are called for one cluster at a time, an extender is called once per
+// scheduling cycle with all the candidate clusters.
+type Extender interface {
+	// Name returns the name of the extender; it must be unique among the plugins and extenders
+	// in a profile, as the weight and priority class of the scores from the extender are looked up
+	// by the name.
+	Name() string
+
+	// IsFilter returns true if the extender filters clusters.
+	IsFilter() bool
+	// IsScorer returns true if the extender scores clusters.
+	IsScorer() bool
+	// IsIgnorable returns true if the scheduler should carry on without the extender should the
+	// extender fail; otherwise, the scheduling cycle fails with the extender.
+	IsIgnorable() bool
+
+	// Filter runs after the in-tree filter plugins, with the clusters that have passed them; it
+	// returns the clusters that the extender rejects, with the reasons keyed by the cluster names.
+	Filter(ctx context.Context, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, clusters []*clusterv1beta1.MemberCluster) (failed map[string]string, err error)
+
+	// Score runs along with the in-tree score plugins; it returns the scores of the clusters, keyed
+	// by the cluster names. A cluster without a score is scored 0.
+	//
+	// The scores are treated as affinity scores, i.e., preferences of the placement, and are
+	// aggregated with the scores from the in-tree score plugins per the profile; scores out of the
+	// range of [MinExtenderScore, MaxExtenderScore] are clamped.
+	Score(ctx context.Context, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, clusters []*clusterv1beta1.MemberCluster) (scores map[string]int, err error)
+}
+
+// runExtenderFilters runs the filtering extenders sequentially on the clusters that have passed the
+// in-tree filter plugins.
+func (f *framework) runExtenderFilters(ctx context.Context, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, clusters []*clusterv1beta1.MemberCluster) (passed []*clusterv1beta1.MemberCluster, filtered []*filteredClusterWithStatus, err error) {
+	passed = clusters
+	for _, ext := range f.profile.extenders {
+		if !ext.IsFilter() || len(passed) == 0 {
+			continue
+		}
+		failed, err := ext.Filter(ctx, policy, passed)
+		if err != nil {
+			if ext.IsIgnorable() {
+				klog.ErrorS(err, "Skipped an ignorable extender that has failed to filter clusters", "extender", ext.Name(), "clusterSchedulingPolicySnapshot", klog.KObj(policy))
+				continue
+			}
+			return nil, nil, fmt.Errorf("extender %s failed to filter clusters: %w", ext.Name(), err)
+		}
+		if len(failed) == 0 {
+			continue
+		}
+
+		stillPassed := make([]*clusterv1beta1.MemberCluster, 0, len(passed))
+		for _, cluster := range passed {
+			reason, ok := failed[cluster.Name]
+			if !ok {
+				stillPassed = append(stillPassed, cluster)
+				continue
+			}
+			filtered = append(filtered, &filteredClusterWithStatus{
+				cluster: cluster,
+				status:  NewNonErrorStatus(ClusterUnschedulable, ext.Name(), reason),
+			})
+		}
+		passed = stillPassed
+	}
+	return passed, filtered, nil
+}
+
+// runExtenderScores runs the scoring extenders sequentially on the scored clusters, and adds the
+// scores from the extenders to the scores of individual plugins, before they are aggregated.
+func (f *framework) runExtenderScores(ctx context.Context, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, scoredClusters ScoredClusters) error {
+	if len(scoredClusters) == 0 {
+		return nil
+	}
+
+	clusters := make([]*clusterv1beta1.MemberCluster, 0, len(scoredClusters))
+	for _, sc := range scoredClusters {
+		clusters = append(clusters, sc.Cluster)
+	}
+	for _, ext := range f.profile.extenders {
+		if !ext.IsScorer() {
+			continue
+		}
+		scores, err := ext.Score(ctx, policy, clusters)
+		if err != nil {
+			if ext.IsIgnorable() {
+				klog.ErrorS(err, "Skipped an ignorable extender that has failed to score clusters", "extender", ext.Name(), "clusterSchedulingPolicySnapshot", klog.KObj(policy))
+				continue
+			}
+			return fmt.Errorf("extender %s failed to score clusters: %w", ext.Name(), err)
+		}
+		for _, sc := range scoredClusters {
+			if sc.pluginScores == nil {
+				sc.pluginScores = make(map[string]*ClusterScore)
+			}
+			score := scores[sc.Cluster.Name]
+			if score > MaxExtenderScore || score < MinExtenderScore {
+				// Do not let a misbehaving extender override the scores from all the other plugins.
+				klog.V(2).InfoS("Clamped an out-of-range score from an extender", "extender", ext.Name(), "memberCluster", klog.KObj(sc.Cluster), "score", score, "clusterSchedulingPolicySnapshot", klog.KObj(policy))
+				score = min(max(score, MinExtenderScore), MaxExtenderScore)
+			}
+			sc.pluginScores[ext.Name()] = &ClusterScore{AffinityScore: score}
+		}
+	}
+	return nil
+}
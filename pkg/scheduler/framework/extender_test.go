@@ -0,0 +1,238 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	dummyExtenderName = "dummyExtender"
+)
+
+// dummyExtender is an extender which can be customized for testing purposes.
+type dummyExtender struct {
+	name      string
+	ignorable bool
+	failed    map[string]string
+	scores    map[string]int
+	err       error
+}
+
+// Verify that dummyExtender implements Extender at compile time.
+var _ Extender = &dummyExtender{}
+
+func (e *dummyExtender) Name() string      { return e.name }
+func (e *dummyExtender) IsFilter() bool    { return e.failed != nil || e.err != nil }
+func (e *dummyExtender) IsScorer() bool    { return e.scores != nil || e.err != nil }
+func (e *dummyExtender) IsIgnorable() bool { return e.ignorable }
+
+func (e *dummyExtender) Filter(_ context.Context, _ *placementv1beta1.ClusterSchedulingPolicySnapshot, _ []*clusterv1beta1.MemberCluster) (map[string]string, error) {
+	return e.failed, e.err
+}
+
+func (e *dummyExtender) Score(_ context.Context, _ *placementv1beta1.ClusterSchedulingPolicySnapshot, _ []*clusterv1beta1.MemberCluster) (map[string]int, error) {
+	return e.scores, e.err
+}
+
+// newTestClusters returns member clusters with the given names.
+func newTestClusters(names ...string) []*clusterv1beta1.MemberCluster {
+	clusters := make([]*clusterv1beta1.MemberCluster, 0, len(names))
+	for _, name := range names {
+		clusters = append(clusters, &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	return clusters
+}
+
+// TestRunExtenderFilters tests the runExtenderFilters method.
+func TestRunExtenderFilters(t *testing.T) {
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: policyName},
+	}
+
+	testCases := []struct {
+		name         string
+		extenders    []Extender
+		wantPassed   []string
+		wantFiltered map[string]string
+		wantErr      bool
+	}{
+		{
+			name:       "no extenders",
+			wantPassed: []string{clusterName, altClusterName, anotherClusterName},
+		},
+		{
+			name: "clusters filtered by multiple extenders",
+			extenders: []Extender{
+				&dummyExtender{name: dummyExtenderName, failed: map[string]string{clusterName: "not approved"}},
+				&dummyExtender{name: "anotherExtender", failed: map[string]string{altClusterName: "frozen", clusterName: "frozen"}},
+			},
+			wantPassed: []string{anotherClusterName},
+			wantFiltered: map[string]string{
+				clusterName:    "not approved",
+				altClusterName: "frozen",
+			},
+		},
+		{
+			name: "ignorable extender failed",
+			extenders: []Extender{
+				&dummyExtender{name: dummyExtenderName, ignorable: true, err: fmt.Errorf("unavailable")},
+			},
+			wantPassed: []string{clusterName, altClusterName, anotherClusterName},
+		},
+		{
+			name: "extender failed",
+			extenders: []Extender{
+				&dummyExtender{name: dummyExtenderName, err: fmt.Errorf("unavailable")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := NewProfile(dummyProfileName)
+			for _, ext := range tc.extenders {
+				profile.WithExtender(ext)
+			}
+			f := &framework{
+				profile: profile,
+			}
+
+			passed, filtered, err := f.runExtenderFilters(context.Background(), policy, newTestClusters(clusterName, altClusterName, anotherClusterName))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("runExtenderFilters() = %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			var gotPassed []string
+			for _, cluster := range passed {
+				gotPassed = append(gotPassed, cluster.Name)
+			}
+			if diff := cmp.Diff(tc.wantPassed, gotPassed); diff != "" {
+				t.Errorf("runExtenderFilters() passed clusters diff (-want, +got):\n%s", diff)
+			}
+			var gotFiltered map[string]string
+			for _, fc := range filtered {
+				if gotFiltered == nil {
+					gotFiltered = make(map[string]string)
+				}
+				if !fc.status.IsClusterUnschedulable() {
+					t.Errorf("runExtenderFilters() status of filtered cluster %s = %v, want ClusterUnschedulable", fc.cluster.Name, fc.status)
+				}
+				gotFiltered[fc.cluster.Name] = fc.status.Reasons()[0]
+			}
+			if diff := cmp.Diff(tc.wantFiltered, gotFiltered); diff != "" {
+				t.Errorf("runExtenderFilters() filtered clusters diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestRunExtenderScores tests the runExtenderScores method, along with the aggregation of the scores
+// from the extenders.
+func TestRunExtenderScores(t *testing.T) {
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: policyName},
+	}
+
+	testCases := []struct {
+		name      string
+		extender  *dummyExtender
+		configure func(profile *Profile)
+		wantOrder []string
+		wantErr   bool
+	}{
+		{
+			name:      "extender scores added with a weight",
+			extender:  &dummyExtender{name: dummyExtenderName, scores: map[string]int{altClusterName: 10}},
+			configure: func(profile *Profile) { profile.WithScorePluginWeight(dummyExtenderName, 10) },
+			// Plugin scores: cluster 50, altCluster 20, anotherCluster 0.
+			wantOrder: []string{altClusterName, clusterName, anotherClusterName},
+		},
+		{
+			name:      "out-of-range extender scores clamped",
+			extender:  &dummyExtender{name: dummyExtenderName, scores: map[string]int{clusterName: 90, altClusterName: 1000, anotherClusterName: -1000}},
+			configure: func(_ *Profile) {},
+			// Scores with the extender: cluster 140, altCluster 120, anotherCluster -100.
+			wantOrder: []string{clusterName, altClusterName, anotherClusterName},
+		},
+		{
+			name:     "extender scores prioritized with the lexicographic strategy",
+			extender: &dummyExtender{name: dummyExtenderName, scores: map[string]int{anotherClusterName: 1}},
+			configure: func(profile *Profile) {
+				profile.WithScoreAggregationStrategy(ScoreAggregationStrategyLexicographic).
+					WithScorePluginPriority(dummyExtenderName, 1)
+			},
+			wantOrder: []string{anotherClusterName, clusterName, altClusterName},
+		},
+		{
+			name:      "ignorable extender failed",
+			extender:  &dummyExtender{name: dummyExtenderName, ignorable: true, err: fmt.Errorf("unavailable")},
+			configure: func(_ *Profile) {},
+			wantOrder: []string{clusterName, altClusterName, anotherClusterName},
+		},
+		{
+			name:      "extender failed",
+			extender:  &dummyExtender{name: dummyExtenderName, err: fmt.Errorf("unavailable")},
+			configure: func(_ *Profile) {},
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := NewProfile(dummyProfileName)
+			profile.WithScorePlugin(&DummyAllPurposePlugin{name: affinityScorePluginName}).WithExtender(tc.extender)
+			tc.configure(profile)
+			f := &framework{
+				profile: profile,
+			}
+
+			pluginScores := map[string]int{clusterName: 50, altClusterName: 20, anotherClusterName: 0}
+			var scoredClusters ScoredClusters
+			for _, cluster := range newTestClusters(clusterName, altClusterName, anotherClusterName) {
+				scoredClusters = append(scoredClusters, &ScoredCluster{
+					Cluster: cluster,
+					pluginScores: map[string]*ClusterScore{
+						affinityScorePluginName: {AffinityScore: pluginScores[cluster.Name]},
+					},
+				})
+			}
+
+			err := f.runExtenderScores(context.Background(), policy, scoredClusters)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("runExtenderScores() = %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if err := profile.aggregateScores(scoredClusters); err != nil {
+				t.Fatalf("aggregateScores() = %v, want no error", err)
+			}
+
+			sort.Sort(sort.Reverse(scoredClusters))
+			gotOrder := make([]string, 0, len(scoredClusters))
+			for _, sc := range scoredClusters {
+				gotOrder = append(gotOrder, sc.Cluster.Name)
+			}
+			if diff := cmp.Diff(tc.wantOrder, gotOrder); diff != "" {
+				t.Errorf("ranked clusters diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -10,15 +10,19 @@ package framework
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
 	"sort"
 	"sync/atomic"
 	"time"
 
-	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
@@ -44,14 +48,19 @@ const (
 	// NotFullyScheduledReason is the reason string of placement condition when the placement policy cannot be fully satisfied.
 	NotFullyScheduledReason = "SchedulingPolicyUnfulfilled"
 
-	fullyScheduledMessage    = "found all cluster needed as specified by the scheduling policy, found %d cluster(s)"
-	notFullyScheduledMessage = "could not find all clusters needed as specified by the scheduling policy, found %d cluster(s) instead"
+	fullyScheduledMessage     = "found all cluster needed as specified by the scheduling policy, found %d cluster(s)"
+	notFullyScheduledMessage  = "could not find all clusters needed as specified by the scheduling policy, found %d cluster(s) instead"
+	remediationsMessageFormat = "%s; suggested remediations: %s"
 
 	// The reasons to use for scheduling decisions.
 	pickFixedInvalidClusterReasonTemplate  = "Cluster \"%s\" is not eligible for resource placement yet: %s"
 	pickFixedNotFoundClusterReasonTemplate = "Specified cluster \"%s\" is not found"
 	notPickedByScoreReasonTemplate         = "Cluster \"%s\" does not score high enough (affinity score: %d, topology spread score: %d)"
 
+	// The reasons to use for the aggregated counts of unselected clusters in compacted decisions.
+	notPickedByScoreSummaryReason = "NotPickedByScore"
+	filteredOutSummaryReason      = "FilteredOut"
+
 	// ClusterDecision schedule message templates.
 	resourceScheduleSucceededMessageFormat          = "Successfully scheduled resources for placement in \"%s\": picked by scheduling policy"
 	resourceScheduleSucceededWithScoreMessageFormat = "Successfully scheduled resources for placement in \"%s\" (affinity score: %d, topology spread score: %d): picked by scheduling policy"
@@ -59,6 +68,29 @@ const (
 	// The array length limit of the cluster decision array in the scheduling policy snapshot
 	// status API.
 	clustersDecisionArrayLengthLimitInAPI = 1000
+
+	// maxBindingNameAttempts is the maximum number of candidate names the scheduler tries for a new
+	// binding before it gives up, when the names it derives are taken by existing bindings.
+	maxBindingNameAttempts = 10
+
+	// maxCollectedPluginErrors is the maximum number of distinct plugin errors the scheduler collects
+	// when running plugins on clusters in parallel; the run is stopped once the limit is reached.
+	maxCollectedPluginErrors = 10
+
+	// maxPermitWaitTimeout is the longest time the scheduler waits for a Permit plugin to allow the
+	// creation of a binding; a plugin asking for no timeout, or a longer one, waits for this long.
+	maxPermitWaitTimeout = time.Hour
+	// permitWaitRequeueInterval is the interval at which the scheduler checks again with Permit plugins
+	// for the decisions that are held.
+	permitWaitRequeueInterval = time.Second * 15
+
+	permitWaitTimeoutReasonTemplate = "timed out waiting for plugin %s to permit the placement after %s"
+)
+
+var (
+	// bindingUpdateBackoff bounds the attempts the scheduler makes to update a binding on transient
+	// errors and conflicts.
+	bindingUpdateBackoff = retry.DefaultBackoff
 )
 
 // Handle is an interface which allows plugins to access some shared structs (e.g., client, manager)
@@ -75,15 +107,30 @@ type Handle interface {
 	EventRecorder() record.EventRecorder
 	// ClusterEligibilityChecker returns the cluster eligibility checker associated with the scheduler.
 	ClusterEligibilityChecker() *clustereligibilitychecker.ClusterEligibilityChecker
+	// PropertyReader returns the property reader shared by all plugins, which performs cached
+	// lookups of the properties of member clusters.
+	PropertyReader() *PropertyReader
 }
 
 // Framework is an interface which scheduler framework should implement.
 type Framework interface {
 	Handle
 
+	// ProfileName returns the name of the scheduling profile in use by the framework.
+	ProfileName() string
+
 	// RunSchedulingCycleFor performs scheduling for a cluster resource placement, specifically
 	// its associated latest scheduling policy snapshot.
 	RunSchedulingCycleFor(ctx context.Context, crpName string, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (result ctrl.Result, err error)
+
+	// ScoreClustersFor runs the Filter and Score stages of a scheduling cycle for a cluster resource
+	// placement, as if it had not picked any cluster yet, and returns the clusters that pass the Filter
+	// stage with their scores; it writes nothing.
+	ScoreClustersFor(ctx context.Context, crpName string, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (ScoredClusters, error)
+
+	// UnscheduleBindings marks bindings of a cluster resource placement as unscheduled for the given
+	// reason, outside of a scheduling cycle, e.g., to move the placement to better clusters.
+	UnscheduleBindings(ctx context.Context, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, bindings []*placementv1beta1.ClusterResourceBinding, reason string) error
 }
 
 // framework implements the Framework interface.
@@ -97,8 +144,10 @@ type framework struct {
 	// uncachedReader is the uncached read-only client in use by the scheduler framework for accessing
 	// Kubernetes API server; in most cases client should be used instead, unless consistency becomes
 	// a serious concern.
-	// TO-DO (chenyu1): explore the possibilities of using a mutation cache for better performance.
 	uncachedReader client.Reader
+	// bindingMutationCache keeps the writes to bindings made by the scheduler framework, so that bindings
+	// can be read from the (cached) client with read-after-write consistency.
+	bindingMutationCache *bindingMutationCache
 	// manager is the controller manager in use by the scheduler framework.
 	manager ctrl.Manager
 	// eventRecorder is the event recorder in use by the scheduler framework.
@@ -110,11 +159,42 @@ type framework struct {
 	// eligibilityChecker is a utility which helps determine if a cluster is eligible for resource placement.
 	clusterEligibilityChecker *clustereligibilitychecker.ClusterEligibilityChecker
 
+	// propertyReader is a utility which helps plugins look up the properties of member clusters.
+	propertyReader *PropertyReader
+
 	// maxUnselectedClusterDecisionCount controls the maximum number of decisions for unselected clusters
 	// added to the policy snapshot status.
 	//
 	// Note that all picked clusters will always have their associated decisions written to the status.
 	maxUnselectedClusterDecisionCount int
+
+	// decisionCompactionThreshold is the number of clusters above which the scheduler framework
+	// compacts the decisions in the policy snapshot status, i.e., it writes decisions for the selected
+	// clusters only and aggregates the unselected clusters into per-reason counts; zero disables
+	// the compaction.
+	decisionCompactionThreshold int
+
+	// strictConsistency controls whether the scheduler framework lists clusters directly from the API
+	// server (a consistent read) rather than from the cache, which might be slightly stale.
+	strictConsistency bool
+
+	// clusterSnapshot is the in-memory view of the fleet that the scheduler framework reads clusters
+	// from, if set and unless strict consistency is required.
+	clusterSnapshot *ClusterSnapshot
+
+	// excludedClusterNames is the set of names of clusters that the scheduler framework should never
+	// consider for any placement.
+	excludedClusterNames sets.Set[string]
+	// excludedClusterNamePattern is the pattern that the scheduler framework uses to exclude clusters
+	// by name from consideration for any placement; nil means no cluster is excluded by pattern.
+	excludedClusterNamePattern *regexp.Regexp
+
+	// permitWaits keeps track of the scheduling decisions held by Permit plugins.
+	permitWaits *permitWaits
+
+	// bindingWriteThrottle paces the writes to bindings made by the scheduler framework; nil means
+	// the writes are not paced.
+	bindingWriteThrottle *bindingWriteThrottle
 }
 
 var (
@@ -132,9 +212,38 @@ type frameworkOptions struct {
 	// unselected clusters added to the policy snapshot status.
 	maxUnselectedClusterDecisionCount int
 
+	// decisionCompactionThreshold is the number of clusters above which the decisions in the
+	// policy snapshot status are compacted; zero disables the compaction.
+	decisionCompactionThreshold int
+
+	// strictConsistency controls whether clusters are listed directly from the API server.
+	strictConsistency bool
+
+	// clusterSnapshot is the in-memory view of the fleet to read clusters from.
+	clusterSnapshot *ClusterSnapshot
+
 	// checker is the cluster eligibility checker the scheduler framework will use to check
 	// if a cluster is eligibile for resource placement.
 	clusterEligibilityChecker *clustereligibilitychecker.ClusterEligibilityChecker
+
+	// excludedClusterNames is the list of names of clusters to exclude from scheduling.
+	excludedClusterNames []string
+
+	// excludedClusterNamePattern is the pattern of names of clusters to exclude from scheduling.
+	excludedClusterNamePattern *regexp.Regexp
+
+	// bindingWriteLimiter is the rate limiter of the writes to bindings; nil means no limit.
+	bindingWriteLimiter *rate.Limiter
+
+	// bindingWriteJitter is the max random delay before each write to bindings.
+	bindingWriteJitter time.Duration
+
+	// eventDedupWindow is the period in which the events of the same type and reason on the same
+	// object are emitted at most once; zero disables the deduplication.
+	eventDedupWindow time.Duration
+
+	// eventLimiter is the rate limiter of the events emitted; nil means no limit.
+	eventLimiter *rate.Limiter
 }
 
 // Option is the function for configuring a scheduler framework.
@@ -161,6 +270,37 @@ func WithMaxClusterDecisionCount(maxUnselectedClusterDecisionCount int) Option {
 	}
 }
 
+// WithDecisionCompactionThreshold sets the number of clusters above which a scheduler framework
+// compacts the decisions in the policy snapshot status; zero disables the compaction.
+func WithDecisionCompactionThreshold(threshold int) Option {
+	return func(fo *frameworkOptions) {
+		fo.decisionCompactionThreshold = threshold
+	}
+}
+
+// WithStrictConsistency sets whether a scheduler framework lists clusters directly from the API server,
+// i.e., a consistent read, instead of from the cache.
+//
+// Strict consistency guarantees that each scheduling cycle sees the latest cluster states, at the cost of
+// one extra (and possibly large) list call to the API server per scheduling cycle; it is best suited for
+// small fleets.
+func WithStrictConsistency(enabled bool) Option {
+	return func(fo *frameworkOptions) {
+		fo.strictConsistency = enabled
+	}
+}
+
+// WithClusterSnapshot sets the in-memory view of the fleet that a scheduler framework reads clusters
+// from at the start of each scheduling cycle, instead of listing them from the cache.
+//
+// The framework falls back to listing clusters if the snapshot is not ready yet; the snapshot is
+// not in use if strict consistency is enabled.
+func WithClusterSnapshot(snapshot *ClusterSnapshot) Option {
+	return func(fo *frameworkOptions) {
+		fo.clusterSnapshot = snapshot
+	}
+}
+
 // WithClusterEligibilityChecker sets the cluster eligibility checker for a scheduler framework.
 func WithClusterEligibilityChecker(checker *clustereligibilitychecker.ClusterEligibilityChecker) Option {
 	return func(fo *frameworkOptions) {
@@ -168,6 +308,68 @@ func WithClusterEligibilityChecker(checker *clustereligibilitychecker.ClusterEli
 	}
 }
 
+// WithExcludedClusterNames sets the names of clusters that a scheduler framework should never
+// consider for any placement, regardless of the scheduling policy in use.
+func WithExcludedClusterNames(names []string) Option {
+	return func(fo *frameworkOptions) {
+		fo.excludedClusterNames = names
+	}
+}
+
+// WithExcludedClusterNamePattern sets the pattern of names of clusters that a scheduler framework
+// should never consider for any placement, regardless of the scheduling policy in use.
+func WithExcludedClusterNamePattern(pattern *regexp.Regexp) Option {
+	return func(fo *frameworkOptions) {
+		fo.excludedClusterNamePattern = pattern
+	}
+}
+
+// WithBindingWriteRateLimit sets the max rate (in writes per second) and burst at which a scheduler
+// framework creates, updates, and patches bindings; a non-positive QPS means no limit.
+//
+// The limit is shared by all the scheduler frameworks set up with the same option, i.e., it applies
+// to the scheduler as a whole, regardless of the scheduling profiles in use.
+func WithBindingWriteRateLimit(qps float64, burst int) Option {
+	var limiter *rate.Limiter
+	if qps > 0 {
+		limiter = rate.NewLimiter(rate.Limit(qps), burst)
+	}
+	return func(fo *frameworkOptions) {
+		fo.bindingWriteLimiter = limiter
+	}
+}
+
+// WithBindingWriteJitter sets the max random delay a scheduler framework waits for before each write
+// to bindings, which spreads out the writes of scheduling cycles running at the same time.
+func WithBindingWriteJitter(jitter time.Duration) Option {
+	return func(fo *frameworkOptions) {
+		fo.bindingWriteJitter = jitter
+	}
+}
+
+// WithEventDedupWindow sets the period in which a scheduler framework emits the events of the same
+// type and reason on the same object at most once; the next event emitted after the period reports
+// the number of events suppressed in between.
+func WithEventDedupWindow(window time.Duration) Option {
+	return func(fo *frameworkOptions) {
+		fo.eventDedupWindow = window
+	}
+}
+
+// WithEventRateLimit sets the max rate (in events per second) and burst at which a scheduler
+// framework emits events; a non-positive QPS means no limit. Events over the limit are dropped.
+//
+// The limit is shared by all the scheduler frameworks set up with the same option.
+func WithEventRateLimit(qps float64, burst int) Option {
+	var limiter *rate.Limiter
+	if qps > 0 {
+		limiter = rate.NewLimiter(rate.Limit(qps), burst)
+	}
+	return func(fo *frameworkOptions) {
+		fo.eventLimiter = limiter
+	}
+}
+
 // NewFramework returns a new scheduler framework.
 func NewFramework(profile *Profile, manager ctrl.Manager, opts ...Option) Framework {
 	options := defaultFrameworkOptions
@@ -188,15 +390,42 @@ func NewFramework(profile *Profile, manager ctrl.Manager, opts ...Option) Framew
 	//
 	// Also note that an indexer might need to be set up for improved performance.
 
+	return newFramework(profile, manager.GetClient(), manager.GetAPIReader(), manager, manager.GetEventRecorderFor(fmt.Sprintf(eventRecorderNameTemplate, profile.Name())), options)
+}
+
+// NewDryRunFramework returns a new scheduler framework that works with the given client only, without
+// a controller manager; the client is used for both cached and uncached reads, and events are discarded.
+//
+// This is for running scheduling cycles outside the hub agent, e.g., against a fake client
+// populated with a cluster inventory, to preview the scheduling decisions of a placement.
+func NewDryRunFramework(profile *Profile, hubClient client.Client, opts ...Option) Framework {
+	options := defaultFrameworkOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return newFramework(profile, hubClient, hubClient, nil, &record.FakeRecorder{}, options)
+}
+
+// newFramework returns a new scheduler framework with the given clients and options.
+func newFramework(profile *Profile, hubClient client.Client, uncachedReader client.Reader, manager ctrl.Manager, eventRecorder record.EventRecorder, options frameworkOptions) *framework {
 	f := &framework{
 		profile:                           profile,
-		client:                            manager.GetClient(),
-		uncachedReader:                    manager.GetAPIReader(),
+		client:                            hubClient,
+		uncachedReader:                    uncachedReader,
 		manager:                           manager,
-		eventRecorder:                     manager.GetEventRecorderFor(fmt.Sprintf(eventRecorderNameTemplate, profile.Name())),
+		eventRecorder:                     newDedupEventRecorder(eventRecorder, options.eventDedupWindow, options.eventLimiter),
 		parallelizer:                      parallelizer.NewParallelizer(options.numOfWorkers),
 		maxUnselectedClusterDecisionCount: options.maxUnselectedClusterDecisionCount,
+		decisionCompactionThreshold:       options.decisionCompactionThreshold,
+		strictConsistency:                 options.strictConsistency,
+		clusterSnapshot:                   options.clusterSnapshot,
 		clusterEligibilityChecker:         options.clusterEligibilityChecker,
+		propertyReader:                    NewPropertyReader(),
+		excludedClusterNames:              sets.New(options.excludedClusterNames...),
+		excludedClusterNamePattern:        options.excludedClusterNamePattern,
+		permitWaits:                       newPermitWaits(),
+		bindingMutationCache:              newBindingMutationCache(bindingMutationTTL),
+		bindingWriteThrottle:              newBindingWriteThrottle(options.bindingWriteLimiter, options.bindingWriteJitter),
 	}
 	// initialize all the plugins
 	for _, plugin := range f.profile.registeredPlugins {
@@ -230,6 +459,16 @@ func (f *framework) ClusterEligibilityChecker() *clustereligibilitychecker.Clust
 	return f.clusterEligibilityChecker
 }
 
+// PropertyReader returns the property reader in use by the scheduler framework.
+func (f *framework) PropertyReader() *PropertyReader {
+	return f.propertyReader
+}
+
+// ProfileName returns the name of the scheduling profile in use by the framework.
+func (f *framework) ProfileName() string {
+	return f.profile.Name()
+}
+
 // RunSchedulingCycleFor performs scheduling for a cluster resource placement
 // (more specifically, its associated scheduling policy snapshot).
 func (f *framework) RunSchedulingCycleFor(ctx context.Context, crpName string, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (result ctrl.Result, err error) {
@@ -256,17 +495,13 @@ func (f *framework) RunSchedulingCycleFor(ctx context.Context, crpName string, p
 
 	// Collect all bindings.
 	//
-	// Note that for consistency reasons, bindings are listed directly from the API server; this helps
-	// avoid a classic read-after-write consistency issue, which, though should only happen when there
-	// are connectivity issues and/or API server is overloaded, can lead to over-scheduling in adverse
-	// scenarios. It is true that even when bindings are over-scheduled, the scheduler can still correct
-	// the situation in the next cycle; however, considering that placing resources to clusters, unlike
-	// pods to nodes, is more expensive, it is better to avoid over-scheduling in the first place.
-	//
-	// This, of course, has additional performance overhead (and may further exacerbate API server
-	// overloading). In the long run we might still want to resort to a cached situation.
-	//
-	// TO-DO (chenyu1): explore the possibilities of using a mutation cache for better performance.
+	// Note that for consistency reasons, the writes the scheduler framework has made to bindings are
+	// layered over the cache; this helps avoid a classic read-after-write consistency issue, which,
+	// though should only happen when there are connectivity issues and/or API server is overloaded,
+	// can lead to over-scheduling in adverse scenarios. It is true that even when bindings are
+	// over-scheduled, the scheduler can still correct the situation in the next cycle; however,
+	// considering that placing resources to clusters, unlike pods to nodes, is more expensive, it is
+	// better to avoid over-scheduling in the first place.
 	bindings, err := f.collectBindings(ctx, crpName)
 	if err != nil {
 		klog.ErrorS(err, "Failed to collect bindings", "clusterSchedulingPolicySnapshot", policyRef)
@@ -302,17 +537,37 @@ func (f *framework) RunSchedulingCycleFor(ctx context.Context, crpName string, p
 	}
 
 	// Mark all dangling bindings as unscheduled.
-	if err := f.updateBindings(ctx, dangling, markUnscheduledForAndUpdate); err != nil {
+	if err := f.updateBindings(ctx, dangling, markUnscheduledForAndUpdate(placementv1beta1.UnscheduledReasonClusterLeft, policy)); err != nil {
 		klog.ErrorS(err, "Failed to mark dangling bindings as unscheduled", "clusterSchedulingPolicySnapshot", policyRef)
 		return ctrl.Result{}, err
 	}
 
+	// Clean up the leftovers of earlier scheduling cycles that failed midway: keep only one binding
+	// for each cluster, and mark the duplicated ones as unscheduled.
+	//
+	// Other partially applied decisions need no special handling, as the current cycle picks up
+	// where the failed one stops: bindings created by the failed cycle are already counted as
+	// scheduled, and the rest are created (with the same names) or patched again.
+	bound, scheduled, obsolete, duplicated := dedupBindingsByTargetCluster(policy, bound, scheduled, obsolete)
+	if len(duplicated) > 0 {
+		klog.V(2).InfoS("Found duplicated bindings", "clusterSchedulingPolicySnapshot", policyRef, "count", len(duplicated))
+	}
+	if err := f.updateBindings(ctx, duplicated, markUnscheduledForAndUpdate(placementv1beta1.UnscheduledReasonDuplicated, policy)); err != nil {
+		klog.ErrorS(err, "Failed to mark duplicated bindings as unscheduled", "clusterSchedulingPolicySnapshot", policyRef)
+		return ctrl.Result{}, err
+	}
+
 	// Prepare the cycle state for this run.
 	//
 	// Note that this state is shared between all plugins and the scheduler framework itself (though some fields are reserved by
 	// the framework). These reserved fields are never accessed concurrently, as each scheduling run has its own cycle and a run
 	// is always executed in one single goroutine; plugin access to the state is guarded by sync.Map.
 	state := NewCycleState(clusters, obsolete, bound, scheduled)
+	// Keep track of the names of all the bindings of the CRP, including the ones that are being deleted,
+	// so that the names of new bindings never collide with them.
+	for idx := range bindings {
+		state.takenBindingNames.Insert(bindings[idx].Name)
+	}
 
 	switch {
 	case policy.Spec.Policy == nil:
@@ -322,7 +577,7 @@ func (f *framework) RunSchedulingCycleFor(ctx context.Context, crpName string, p
 	case policy.Spec.Policy.PlacementType == placementv1beta1.PickFixedPlacementType:
 		// The placement policy features a fixed set of clusters to select; in such cases, the
 		// scheduler will bind to these clusters directly.
-		return f.runSchedulingCycleForPickFixedPlacementType(ctx, crpName, policy, clusters, bound, scheduled, unscheduled, obsolete)
+		return f.runSchedulingCycleForPickFixedPlacementType(ctx, state, crpName, policy, clusters, bound, scheduled, unscheduled, obsolete)
 	case policy.Spec.Policy.PlacementType == placementv1beta1.PickAllPlacementType:
 		// Run the scheduling cycle for policy of the PickAll placement type.
 		return f.runSchedulingCycleForPickAllPlacementType(ctx, state, crpName, policy, clusters, bound, scheduled, unscheduled, obsolete)
@@ -336,17 +591,69 @@ func (f *framework) RunSchedulingCycleFor(ctx context.Context, crpName string, p
 	}
 }
 
-// collectClusters lists all clusters in the cache.
+// collectClusters lists all clusters in the cache (or, with strict consistency, **using the uncached
+// client**), minus the ones excluded from scheduling.
+//
+// Note that excluded clusters are never considered for any placement; bindings that have been
+// created for such clusters before the exclusion takes effect will be treated as dangling
+// bindings, i.e., the clusters are handled as if they have left the fleet.
 func (f *framework) collectClusters(ctx context.Context) ([]clusterv1beta1.MemberCluster, error) {
 	clusterList := &clusterv1beta1.MemberClusterList{}
-	if err := f.client.List(ctx, clusterList, &client.ListOptions{}); err != nil {
+	if snapshotClusters, generation, ok := f.readClusterSnapshot(); ok {
+		klog.V(4).InfoS("Read clusters from the cluster snapshot", "generation", generation, "clusterCount", len(snapshotClusters))
+		clusterList.Items = snapshotClusters
+	} else if f.strictConsistency {
+		// List clusters directly from the API server; with no resource version specified, the API
+		// server serves the list with a quorum read.
+		if err := f.uncachedReader.List(ctx, clusterList, &client.ListOptions{}); err != nil {
+			return nil, controller.NewAPIServerError(false, err)
+		}
+	} else if err := f.client.List(ctx, clusterList, &client.ListOptions{}); err != nil {
 		return nil, controller.NewAPIServerError(true, err)
 	}
-	return clusterList.Items, nil
+	// Drop the cached property values of the clusters that have left the fleet.
+	f.propertyReader.retainOnly(clusterList.Items)
+	if f.excludedClusterNames.Len() == 0 && f.excludedClusterNamePattern == nil {
+		return clusterList.Items, nil
+	}
+
+	clusters := make([]clusterv1beta1.MemberCluster, 0, len(clusterList.Items))
+	for idx := range clusterList.Items {
+		cluster := clusterList.Items[idx]
+		if f.isClusterExcluded(cluster.Name) {
+			klog.V(2).InfoS("Cluster is excluded from scheduling", "memberCluster", klog.KObj(&cluster))
+			continue
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters, nil
 }
 
-// collectBindings lists all bindings associated with a CRP **using the uncached client**.
+// readClusterSnapshot reads clusters from the cluster snapshot, if it is in use and ready.
+//
+// Note that the returned clusters are shared with other scheduling cycles and must not be modified.
+func (f *framework) readClusterSnapshot() ([]clusterv1beta1.MemberCluster, int64, bool) {
+	if f.strictConsistency || f.clusterSnapshot == nil {
+		return nil, 0, false
+	}
+	return f.clusterSnapshot.Clusters()
+}
+
+// isClusterExcluded returns if a cluster is excluded from scheduling by name.
+func (f *framework) isClusterExcluded(clusterName string) bool {
+	if f.excludedClusterNames.Has(clusterName) {
+		return true
+	}
+	return f.excludedClusterNamePattern != nil && f.excludedClusterNamePattern.MatchString(clusterName)
+}
+
+// collectBindings lists all bindings associated with a CRP, using the (cached) client with the writes
+// made by the scheduler framework layered over; without a binding mutation cache, it falls back to
+// the uncached client.
 func (f *framework) collectBindings(ctx context.Context, crpName string) ([]placementv1beta1.ClusterResourceBinding, error) {
+	if f.bindingMutationCache != nil {
+		return f.bindingMutationCache.list(ctx, f.client, crpName, time.Now())
+	}
 	bindingList := &placementv1beta1.ClusterResourceBindingList{}
 	labelSelector := labels.SelectorFromSet(labels.Set{placementv1beta1.CRPTrackingLabel: crpName})
 	// List bindings directly from the API server.
@@ -356,26 +663,49 @@ func (f *framework) collectBindings(ctx context.Context, crpName string) ([]plac
 	return bindingList.Items, nil
 }
 
-// markAsUnscheduledForAndUpdate marks a binding as unscheduled and updates it.
-var markUnscheduledForAndUpdate = func(ctx context.Context, hubClient client.Client, binding *placementv1beta1.ClusterResourceBinding) error {
-	// Remember the previous unscheduledBinding state so that we might be able to revert this change if this
-	// cluster is being selected again before the resources are removed from it. Need to do a get and set if
-	// we add more annotations to the binding.
-	binding.SetAnnotations(map[string]string{placementv1beta1.PreviousBindingStateAnnotation: string(binding.Spec.State)})
-	// Mark the unscheduledBinding as unscheduled which can conflict with the rollout controller which also changes the state of a
-	// unscheduledBinding from "scheduled" to "bound".
-	binding.Spec.State = placementv1beta1.BindingStateUnscheduled
-	err := hubClient.Update(ctx, binding, &client.UpdateOptions{})
-	if err == nil {
-		klog.V(2).InfoS("Marked binding as unscheduled", "clusterResourceBinding", klog.KObj(binding))
+// markUnscheduledForAndUpdate returns a function that marks a binding as unscheduled and patches it.
+//
+// The reason why the binding is unscheduled and the scheduling policy snapshot whose cycle unschedules it
+// are recorded in the binding annotations, so that downstream controllers and users can tell different
+// kinds of removals apart.
+//
+// The binding is patched with a JSON merge patch that touches only the fields the scheduler owns, i.e., the
+// state and the annotations above, without an optimistic lock, so that it does not conflict with the rollout
+// controller, which updates the same binding (e.g., its resource snapshot) at the same time.
+func markUnscheduledForAndUpdate(reason string, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) func(ctx context.Context, hubClient client.Client, binding *placementv1beta1.ClusterResourceBinding) error {
+	return func(ctx context.Context, hubClient client.Client, binding *placementv1beta1.ClusterResourceBinding) error {
+		patch := client.MergeFrom(binding.DeepCopy())
+		// Remember the previous unscheduledBinding state so that we might be able to revert this change if this
+		// cluster is being selected again before the resources are removed from it.
+		//
+		// Note that the rollout controller might have changed the state from "scheduled" to "bound" since the binding
+		// was read; in this case, the previous state is recorded as "scheduled", and a reverted binding is bound again
+		// by the rollout controller, which is safe.
+		annotations := binding.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[placementv1beta1.PreviousBindingStateAnnotation] = string(binding.Spec.State)
+		annotations[placementv1beta1.UnscheduledReasonAnnotation] = reason
+		annotations[placementv1beta1.UnscheduledByPolicySnapshotAnnotation] = policy.Name
+		binding.SetAnnotations(annotations)
+		binding.Spec.State = placementv1beta1.BindingStateUnscheduled
+		err := hubClient.Patch(ctx, binding, patch)
+		if err == nil {
+			klog.V(2).InfoS("Marked binding as unscheduled", "clusterResourceBinding", klog.KObj(binding), "reason", reason)
+		}
+		return err
 	}
-	return err
 }
 
-// removeFinalizerAndUpdate removes scheduler CRB cleanup finalizer from ClusterResourceBinding and updates it.
+// removeFinalizerAndUpdate removes scheduler CRB cleanup finalizer from ClusterResourceBinding and patches it.
+//
+// A JSON merge patch replaces the whole list of finalizers, which other controllers also add to; the patch
+// is made with an optimistic lock so that their finalizers are never dropped.
 var removeFinalizerAndUpdate = func(ctx context.Context, hubClient client.Client, binding *placementv1beta1.ClusterResourceBinding) error {
+	patch := client.MergeFromWithOptions(binding.DeepCopy(), client.MergeFromWithOptimisticLock{})
 	controllerutil.RemoveFinalizer(binding, placementv1beta1.SchedulerCRBCleanupFinalizer)
-	err := hubClient.Update(ctx, binding, &client.UpdateOptions{})
+	err := hubClient.Patch(ctx, binding, patch)
 	if err == nil {
 		klog.V(2).InfoS("Removed scheduler CRB cleanup finalizer", "clusterResourceBinding", klog.KObj(binding))
 	}
@@ -383,30 +713,36 @@ var removeFinalizerAndUpdate = func(ctx context.Context, hubClient client.Client
 }
 
 // updateBindings iterates over bindings and updates them using the update function provided.
+//
+// Each update is retried a bounded number of times on transient errors and conflicts; on conflicts, the
+// binding is read again before the retry.
 func (f *framework) updateBindings(ctx context.Context, bindings []*placementv1beta1.ClusterResourceBinding, updateFn func(ctx context.Context, client client.Client, binding *placementv1beta1.ClusterResourceBinding) error) error {
 	// issue all the update requests in parallel
-	errs, cctx := errgroup.WithContext(ctx)
-	for _, binding := range bindings {
-		updateBinding := binding
-		errs.Go(func() error {
-			return retry.OnError(retry.DefaultBackoff,
-				func(err error) bool {
-					return apierrors.IsServiceUnavailable(err) || apierrors.IsServerTimeout(err) || apierrors.IsConflict(err)
-				},
-				func() error {
-					err := updateFn(cctx, f.client, updateBinding)
-					// We will retry on conflicts.
-					if apierrors.IsConflict(err) {
-						// get the binding again to make sure we have the latest version to update again.
-						if getErr := f.client.Get(cctx, client.ObjectKeyFromObject(updateBinding), updateBinding); getErr != nil {
-							return getErr
-						}
-					}
+	doWork := func(cctx context.Context, piece int) error {
+		updateBinding := bindings[piece]
+		return retry.OnError(bindingUpdateBackoff,
+			func(err error) bool {
+				return apierrors.IsServiceUnavailable(err) || apierrors.IsServerTimeout(err) || apierrors.IsConflict(err)
+			},
+			func() error {
+				if err := f.bindingWriteThrottle.wait(cctx); err != nil {
 					return err
-				})
-		})
+				}
+				err := updateFn(cctx, f.client, updateBinding)
+				if err == nil {
+					f.bindingMutationCache.mutated(updateBinding, false, time.Now())
+				}
+				// We will retry on conflicts.
+				if apierrors.IsConflict(err) {
+					// get the binding again to make sure we have the latest version to update again.
+					if getErr := f.client.Get(cctx, client.ObjectKeyFromObject(updateBinding), updateBinding); getErr != nil {
+						return getErr
+					}
+				}
+				return err
+			})
 	}
-	return errs.Wait()
+	return f.parallelizer.ParallelizeUntilError(ctx, len(bindings), doWork, "updateBindings")
 }
 
 // runSchedulingCycleForPickAllPlacementType runs a scheduling cycle for a scheduling policy of the
@@ -436,6 +772,10 @@ func (f *framework) runSchedulingCycleForPickAllPlacementType(
 		klog.ErrorS(err, "Failed to run all plugins (pickAll placement type)", "clusterSchedulingPolicySnapshot", policyRef)
 		return ctrl.Result{}, err
 	}
+	if state.preempted {
+		// Bindings of other placements have been preempted; retry the placement right away.
+		return ctrl.Result{Requeue: true}, nil
+	}
 
 	// Sort all the scored clusters.
 	//
@@ -455,15 +795,30 @@ func (f *framework) runSchedulingCycleForPickAllPlacementType(
 	//
 	// Fields in the returned bindings are fulfilled and/or refreshed as applicable.
 	klog.V(2).InfoS("Cross-referencing bindings with picked clusters", "clusterSchedulingPolicySnapshot", policyRef)
-	toCreate, toDelete, toPatch, err := crossReferencePickedClustersAndDeDupBindings(crpName, policy, scored, unscheduled, obsolete)
+	toCreate, toDelete, toPatch, err := crossReferencePickedClustersAndDeDupBindings(crpName, policy, scored, unscheduled, obsolete, state.takenBindingNames)
 	if err != nil {
 		klog.ErrorS(err, "Failed to cross-reference bindings with picked clusters", "clusterSchedulingPolicySnapshot", policyRef)
 		return ctrl.Result{}, err
 	}
 
+	// Run the Permit plugins to find out the bindings that can be created right now; clusters that
+	// are not permitted are reported as filtered out.
+	toCreate, notPermitted, requeueAfter, err := f.runPermitPlugins(ctx, state, crpName, policy, clusters, toCreate)
+	if err != nil {
+		klog.ErrorS(err, "Failed to run permit plugins", "clusterSchedulingPolicySnapshot", policyRef)
+		return ctrl.Result{}, err
+	}
+	filtered = append(filtered, notPermitted...)
+
+	// Run the PreBind plugins to decorate the bindings to create or patch.
+	if err := f.runPreBindPlugins(ctx, state, policy, clusters, toCreate, toPatch); err != nil {
+		klog.ErrorS(err, "Failed to run prebind plugins", "clusterSchedulingPolicySnapshot", policyRef)
+		return ctrl.Result{}, err
+	}
+
 	// Manipulate bindings accordingly.
 	klog.V(2).InfoS("Manipulating bindings", "clusterSchedulingPolicySnapshot", policyRef)
-	if err := f.manipulateBindings(ctx, policy, toCreate, toDelete, toPatch); err != nil {
+	if err := f.manipulateBindings(ctx, state, policy, clusters, toCreate, toDelete, toPatch); err != nil {
 		klog.ErrorS(err, "Failed to manipulate bindings", "clusterSchedulingPolicySnapshot", policyRef)
 		return ctrl.Result{}, err
 	}
@@ -480,15 +835,16 @@ func (f *framework) runSchedulingCycleForPickAllPlacementType(
 	// With the PickAll placement type, the desired number of clusters to select always matches
 	// with the count of scheduled + bound bindings.
 	numOfClusters := len(toCreate) + len(patched) + len(scheduled) + len(bound)
-	if err := f.updatePolicySnapshotStatusFromBindings(ctx, policy, numOfClusters, nil, filtered, toCreate, patched, scheduled, bound); err != nil {
+	if err := f.updatePolicySnapshotStatusFromBindings(ctx, policy, numOfClusters, nil, filtered, nil, toCreate, patched, scheduled, bound); err != nil {
 		klog.ErrorS(err, "Failed to update latest scheduling decisions and condition", "clusterSchedulingPolicySnapshot", policyRef)
 		return ctrl.Result{}, err
 	}
 
 	// The scheduling cycle has completed.
 	//
-	// Note that for CRPs of the PickAll type, no requeue check is needed.
-	return ctrl.Result{}, nil
+	// Note that for CRPs of the PickAll type, a requeue is needed only if some decisions are held
+	// by Permit plugins.
+	return permitWaitResult(requeueAfter), nil
 }
 
 // runAllPluginsForPickAllPlacementType runs all plugins in each stage of the scheduling cycle for a
@@ -531,6 +887,14 @@ func (f *framework) runAllPluginsForPickAllPlacementType(
 		return nil, nil, controller.NewUnexpectedBehaviorError(err)
 	}
 
+	// Run post-filter plugins, if any cluster has been filtered out.
+	//
+	// A placement of the PickAll placement type wants all the clusters it can get.
+	if err := f.runPostFilterPlugins(ctx, state, policy, passed, filtered, len(passed)+len(filtered)); err != nil {
+		klog.ErrorS(err, "Failed to run post filter plugins", "clusterSchedulingPolicySnapshot", policyRef)
+		return nil, nil, err
+	}
+
 	// Wrap all clusters that have passed the Filter stage as scored clusters.
 	scored = make(ScoredClusters, 0, len(passed))
 	for _, cluster := range passed {
@@ -607,7 +971,7 @@ func (f *framework) runFilterPlugins(ctx context.Context, state *CycleState, pol
 	filtered = make([]*filteredClusterWithStatus, len(clusters))
 	var filteredIdx int32 = -1
 
-	errFlag := parallelizer.NewErrorFlag()
+	errs := parallelizer.NewErrorCollector(maxCollectedPluginErrors)
 
 	doWork := func(pieces int) {
 		cluster := clusters[pieces]
@@ -629,18 +993,20 @@ func (f *framework) runFilterPlugins(ctx context.Context, state *CycleState, pol
 			// to run for this cluster, and it should not be considered as a filtered out one
 			// either.
 		default: // An error has occurred.
-			errFlag.Raise(status.AsError())
-			// Cancel the child context, which will lead the parallelizer to stop running tasks.
-			cancel()
+			if errs.Collect(status.AsError()) {
+				// Cancel the child context, which will lead the parallelizer to stop running tasks.
+				cancel()
+			}
 		}
 	}
 
 	// Run inspection in parallel.
 	//
-	// Note that the parallel run will be stopped immediately upon encounter of the first error.
+	// Note that the parallel run carries on upon encounter of errors, so that distinct failures
+	// across clusters are all reported; it is stopped only when enough errors have been collected.
 	f.parallelizer.ParallelizeUntil(childCtx, len(clusters), doWork, "runFilterPlugins")
-	// Retrieve the first error from the error flag.
-	if err := errFlag.Lower(); err != nil {
+	// Retrieve the collected errors.
+	if err := errs.Err(); err != nil {
 		return nil, nil, err
 	}
 
@@ -648,18 +1014,43 @@ func (f *framework) runFilterPlugins(ctx context.Context, state *CycleState, pol
 	passed = passed[:passedIdx+1]
 	filtered = filtered[:filteredIdx+1]
 
+	// Run the extenders (if any) on the clusters that have passed the filter plugins.
+	passed, filteredByExtenders, err := f.runExtenderFilters(ctx, policy, passed)
+	if err != nil {
+		return nil, nil, err
+	}
+	filtered = append(filtered, filteredByExtenders...)
+
 	return passed, filtered, nil
 }
 
 // manipulateBindings creates, patches, and deletes bindings.
+//
+// Before any binding is manipulated, the reserve plugins are run for the target clusters of the
+// bindings to create or patch; should any step fail, the reservations are rolled back.
 func (f *framework) manipulateBindings(
 	ctx context.Context,
+	state *CycleState,
 	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	clusters []clusterv1beta1.MemberCluster,
 	toCreate, toDelete []*placementv1beta1.ClusterResourceBinding,
 	toPatch []*bindingWithPatch,
-) error {
+) (err error) {
 	policyRef := klog.KObj(policy)
 
+	// Reserve resources on the target clusters.
+	reserved, err := f.runReservePlugins(ctx, state, policy, clusters, toCreate, toPatch)
+	// Roll back the reservations on any error path, including a failed reservation.
+	defer func() {
+		if err != nil {
+			f.runUnreservePlugins(ctx, state, policy, reserved)
+		}
+	}()
+	if err != nil {
+		klog.ErrorS(err, "Failed to run reserve plugins", "clusterSchedulingPolicySnapshot", policyRef)
+		return err
+	}
+
 	// Create new bindings; these bindings will be of the Scheduled state.
 	if err := f.createBindings(ctx, toCreate); err != nil {
 		klog.ErrorS(err, "Failed to create new bindings", "clusterSchedulingPolicySnapshot", policyRef)
@@ -683,7 +1074,7 @@ func (f *framework) manipulateBindings(
 	//
 	// This is set to happen after new bindings are created and old bindings are updated, to
 	// avoid interruptions (deselected then reselected) in a best effort manner.
-	if err := f.updateBindings(ctx, toDelete, markUnscheduledForAndUpdate); err != nil {
+	if err := f.updateBindings(ctx, toDelete, markUnscheduledForAndUpdate(placementv1beta1.UnscheduledReasonPolicyChanged, policy)); err != nil {
 		klog.ErrorS(err, "Failed to mark bindings as unschedulable", "clusterSchedulingPolicySnapshot", policyRef)
 		return err
 	}
@@ -694,53 +1085,77 @@ func (f *framework) manipulateBindings(
 // createBindings creates a list of new bindings.
 func (f *framework) createBindings(ctx context.Context, toCreate []*placementv1beta1.ClusterResourceBinding) error {
 	// issue all the create requests in parallel
-	errs, cctx := errgroup.WithContext(ctx)
-	for _, binding := range toCreate {
-		newBinding := binding
-		errs.Go(func() error {
-			return retry.OnError(retry.DefaultBackoff,
-				func(err error) bool {
-					return apierrors.IsServiceUnavailable(err) || apierrors.IsServerTimeout(err)
-				},
-				func() error {
-					err := f.client.Create(cctx, newBinding)
-					if err != nil {
-						if apierrors.IsAlreadyExists(err) {
-							// The binding already exists, which is fine.
-							return nil
-						}
-						klog.ErrorS(err, "Failed to create a new binding", "clusterResourceBinding", klog.KObj(newBinding))
+	doWork := func(cctx context.Context, piece int) error {
+		newBinding := toCreate[piece]
+		return retry.OnError(retry.DefaultBackoff,
+			func(err error) bool {
+				return apierrors.IsServiceUnavailable(err) || apierrors.IsServerTimeout(err)
+			},
+			func() error {
+				if err := f.bindingWriteThrottle.wait(cctx); err != nil {
+					return err
+				}
+				err := f.client.Create(cctx, newBinding)
+				if err != nil {
+					if apierrors.IsAlreadyExists(err) {
+						// The binding might have been created by an earlier attempt of the same
+						// scheduling cycle that failed midway, as binding names are deterministic.
+						return f.checkExistingBinding(cctx, newBinding, err)
 					}
+					klog.ErrorS(err, "Failed to create a new binding", "clusterResourceBinding", klog.KObj(newBinding))
 					return err
-				})
-		})
+				}
+				f.bindingMutationCache.mutated(newBinding, true, time.Now())
+				return nil
+			})
 	}
-	return controller.NewAPIServerError(false, errs.Wait())
+	return controller.NewCreateIgnoreAlreadyExistError(f.parallelizer.ParallelizeUntilError(ctx, len(toCreate), doWork, "createBindings"))
+}
+
+// checkExistingBinding checks if an existing binding with the same name as a binding to create
+// carries the same scheduling decision, in which case the creation is considered done.
+//
+// Otherwise, e.g., the existing binding is still being deleted, the already exists error is returned
+// so that the scheduling cycle is retried later.
+func (f *framework) checkExistingBinding(ctx context.Context, binding *placementv1beta1.ClusterResourceBinding, alreadyExistsErr error) error {
+	existing := &placementv1beta1.ClusterResourceBinding{}
+	if err := f.uncachedReader.Get(ctx, types.NamespacedName{Name: binding.Name}, existing); err != nil {
+		return err
+	}
+	if !existing.DeletionTimestamp.IsZero() ||
+		existing.Spec.TargetCluster != binding.Spec.TargetCluster ||
+		existing.Spec.SchedulingPolicySnapshotName != binding.Spec.SchedulingPolicySnapshotName {
+		klog.V(2).InfoS("A different binding with the same name exists", "clusterResourceBinding", klog.KObj(binding))
+		return alreadyExistsErr
+	}
+	return nil
 }
 
 // patchBindings patches a list of existing bindings using JSON patch.
 func (f *framework) patchBindings(ctx context.Context, toPatch []*bindingWithPatch) error {
 	// issue all the patch requests in parallel
-	errs, cctx := errgroup.WithContext(ctx)
-	for _, bp := range toPatch {
-		patchBinding := bp
-		errs.Go(func() error {
-			return retry.OnError(retry.DefaultBackoff,
-				func(err error) bool {
-					// we can't retry on conflict errors here easily as we need to fetch the original binding to patch it.
-					return apierrors.IsServiceUnavailable(err) || apierrors.IsServerTimeout(err)
-				},
-				func() error {
-					// we will get conflict error if the binding has been updated.
-					err := f.client.Patch(cctx, patchBinding.updated, patchBinding.patch)
-					if err != nil {
-						klog.ErrorS(err, "Failed to patch a binding", "clusterResourceBinding", klog.KObj(patchBinding.updated))
-					}
+	doWork := func(cctx context.Context, piece int) error {
+		patchBinding := toPatch[piece]
+		return retry.OnError(retry.DefaultBackoff,
+			func(err error) bool {
+				// we can't retry on conflict errors here easily as we need to fetch the original binding to patch it.
+				return apierrors.IsServiceUnavailable(err) || apierrors.IsServerTimeout(err)
+			},
+			func() error {
+				if err := f.bindingWriteThrottle.wait(cctx); err != nil {
 					return err
-				})
-		})
+				}
+				// we will get conflict error if the binding has been updated.
+				err := f.client.Patch(cctx, patchBinding.updated, patchBinding.patch)
+				if err != nil {
+					klog.ErrorS(err, "Failed to patch a binding", "clusterResourceBinding", klog.KObj(patchBinding.updated))
+					return err
+				}
+				f.bindingMutationCache.mutated(patchBinding.updated, false, time.Now())
+				return nil
+			})
 	}
-	return controller.NewUpdateIgnoreConflictError(errs.Wait())
+	return controller.NewUpdateIgnoreConflictError(f.parallelizer.ParallelizeUntilError(ctx, len(toPatch), doWork, "patchBindings"))
 }
 
 // updatePolicySnapshotStatusFromBindings updates the policy snapshot status, in accordance with the list of
@@ -751,6 +1166,7 @@ func (f *framework) updatePolicySnapshotStatusFromBindings(
 	numOfClusters int,
 	notPicked ScoredClusters,
 	filtered []*filteredClusterWithStatus,
+	remediations []string,
 	existing ...[]*placementv1beta1.ClusterResourceBinding,
 ) error {
 	policyRef := klog.KObj(policy)
@@ -763,15 +1179,26 @@ func (f *framework) updatePolicySnapshotStatusFromBindings(
 	}
 
 	// Prepare new scheduling decisions.
-	newDecisions := newSchedulingDecisionsFromBindings(f.maxUnselectedClusterDecisionCount, notPicked, filtered, existing...)
+	//
+	// For placements that consider many clusters (e.g., a PickAll placement in a large fleet), the
+	// decisions are compacted: only the selected clusters are listed, and the unselected clusters
+	// are aggregated into per-reason counts.
+	var newDecisions []placementv1beta1.ClusterDecision
+	var newSummary []placementv1beta1.UnselectedClusterCount
+	if f.shouldCompactDecisions(notPicked, filtered, existing...) {
+		newDecisions, newSummary = newCompactedSchedulingDecisionsFromBindings(notPicked, filtered, existing...)
+	} else {
+		newDecisions = newSchedulingDecisionsFromBindings(f.maxUnselectedClusterDecisionCount, notPicked, filtered, existing...)
+	}
 	// Prepare new scheduling condition.
-	newCondition := newScheduledConditionFromBindings(policy, numOfClusters, existing...)
+	newCondition := newScheduledConditionFromBindings(policy, numOfClusters, remediations, existing...)
 
 	// Compare the new decisions + condition with the old ones.
 	currentDecisions := policy.Status.ClusterDecisions
 	currentCondition := meta.FindStatusCondition(policy.Status.Conditions, string(placementv1beta1.PolicySnapshotScheduled))
 	if observedCRPGeneration == policy.Status.ObservedCRPGeneration &&
 		equalDecisions(currentDecisions, newDecisions) &&
+		slices.Equal(policy.Status.UnselectedClusterSummary, newSummary) &&
 		condition.EqualCondition(currentCondition, &newCondition) {
 		// Skip if there is no change in decisions and conditions.
 		klog.InfoS(
@@ -780,12 +1207,44 @@ func (f *framework) updatePolicySnapshotStatusFromBindings(
 		return nil
 	}
 
-	// Update the status.
+	// Patch the status.
+	original := policy.DeepCopy()
 	policy.Status.ClusterDecisions = newDecisions
+	policy.Status.UnselectedClusterSummary = newSummary
 	policy.Status.ObservedCRPGeneration = observedCRPGeneration
 	meta.SetStatusCondition(&policy.Status.Conditions, newCondition)
-	if err := f.client.Status().Update(ctx, policy, &client.SubResourceUpdateOptions{}); err != nil {
-		klog.ErrorS(err, "Failed to update policy snapshot status", "clusterSchedulingPolicySnapshot", policyRef)
+	return f.patchPolicySnapshotStatus(ctx, original, policy)
+}
+
+// shouldCompactDecisions returns if the scheduler framework should compact the decisions in the
+// policy snapshot status, i.e., if compaction is enabled and the number of clusters considered in the
+// scheduling cycle is above the threshold.
+func (f *framework) shouldCompactDecisions(
+	notPicked ScoredClusters,
+	filtered []*filteredClusterWithStatus,
+	existing ...[]*placementv1beta1.ClusterResourceBinding,
+) bool {
+	if f.decisionCompactionThreshold <= 0 {
+		return false
+	}
+	count := len(notPicked) + len(filtered)
+	for _, bindingSet := range existing {
+		count += len(bindingSet)
+	}
+	return count > f.decisionCompactionThreshold
+}
+
+// patchPolicySnapshotStatus patches the status of a policy snapshot with a JSON merge patch
+// computed against its original copy.
+//
+// Using a merge patch (rather than a full update) helps keep the size of status writes small; note
+// that the patch carries the resource version of the original copy, so that concurrent writes will
+// still be rejected with a conflict as they would be with an update.
+func (f *framework) patchPolicySnapshotStatus(ctx context.Context, original, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) error {
+	policyRef := klog.KObj(policy)
+	patch := client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+	if err := f.client.Status().Patch(ctx, policy, patch); err != nil {
+		klog.ErrorS(err, "Failed to patch policy snapshot status", "clusterSchedulingPolicySnapshot", policyRef)
 		return controller.NewAPIServerError(false, err)
 	}
 	return nil
@@ -836,13 +1295,13 @@ func (f *framework) runSchedulingCycleForPickNPlacementType(
 		klog.V(2).InfoS("Downscaling is needed", "clusterSchedulingPolicySnapshot", policyRef, "downscaleCount", downscaleCount)
 
 		// Mark all obsolete bindings as unscheduled first.
-		if err := f.updateBindings(ctx, obsolete, markUnscheduledForAndUpdate); err != nil {
+		if err := f.updateBindings(ctx, obsolete, markUnscheduledForAndUpdate(placementv1beta1.UnscheduledReasonPolicyChanged, policy)); err != nil {
 			klog.ErrorS(err, "Failed to mark obsolete bindings as unscheduled", "clusterSchedulingPolicySnapshot", policyRef)
 			return ctrl.Result{}, err
 		}
 
 		// Perform actual downscaling; this will be skipped if the downscale count is zero.
-		scheduled, bound, err = f.downscale(ctx, scheduled, bound, downscaleCount)
+		scheduled, bound, err = f.downscale(ctx, policy, scheduled, bound, downscaleCount)
 		if err != nil {
 			klog.ErrorS(err, "failed to downscale", "clusterSchedulingPolicySnapshot", policyRef)
 			return ctrl.Result{}, err
@@ -853,7 +1312,7 @@ func (f *framework) runSchedulingCycleForPickNPlacementType(
 		// Note that since there is no reliable way to determine the validity of old decisions added
 		// to the policy snapshot status, we will only update the status with the known facts, i.e.,
 		// the clusters that are currently selected.
-		if err := f.updatePolicySnapshotStatusFromBindings(ctx, policy, numOfClusters, nil, nil, scheduled, bound); err != nil {
+		if err := f.updatePolicySnapshotStatusFromBindings(ctx, policy, numOfClusters, nil, nil, nil, scheduled, bound); err != nil {
 			klog.ErrorS(err, "Failed to update latest scheduling decisions and condition when downscaling", "clusterSchedulingPolicySnapshot", policyRef)
 			return ctrl.Result{}, err
 		}
@@ -873,7 +1332,7 @@ func (f *framework) runSchedulingCycleForPickNPlacementType(
 		// Note that since there is no reliable way to determine the validity of old decisions added
 		// to the policy snapshot status, we will only update the status with the known facts, i.e.,
 		// the clusters that are currently selected.
-		if err := f.updatePolicySnapshotStatusFromBindings(ctx, policy, numOfClusters, nil, nil, bound, scheduled); err != nil {
+		if err := f.updatePolicySnapshotStatusFromBindings(ctx, policy, numOfClusters, nil, nil, nil, bound, scheduled); err != nil {
 			klog.ErrorS(err, "Failed to update latest scheduling decisions and condition when no scheduling run is needed", "clusterSchedulingPolicySnapshot", policyRef)
 			return ctrl.Result{}, err
 		}
@@ -896,6 +1355,10 @@ func (f *framework) runSchedulingCycleForPickNPlacementType(
 		klog.ErrorS(err, "Failed to run all plugins", "clusterSchedulingPolicySnapshot", policyRef)
 		return ctrl.Result{}, err
 	}
+	if state.preempted {
+		// Bindings of other placements have been preempted; retry the placement right away.
+		return ctrl.Result{Requeue: true}, nil
+	}
 
 	// Pick the top scored clusters.
 	klog.V(2).InfoS("Picking clusters", "clusterSchedulingPolicySnapshot", policyRef)
@@ -929,15 +1392,30 @@ func (f *framework) runSchedulingCycleForPickNPlacementType(
 	//
 	// Fields in the returned bindings are fulfilled and/or refreshed as applicable.
 	klog.V(2).InfoS("Cross-referencing bindings with picked clusters", "clusterSchedulingPolicySnapshot", policyRef, "numOfClustersToPick", numOfClustersToPick)
-	toCreate, toDelete, toPatch, err := crossReferencePickedClustersAndDeDupBindings(crpName, policy, picked, unscheduled, obsolete)
+	toCreate, toDelete, toPatch, err := crossReferencePickedClustersAndDeDupBindings(crpName, policy, picked, unscheduled, obsolete, state.takenBindingNames)
 	if err != nil {
 		klog.ErrorS(err, "Failed to cross-reference bindings with picked clusters", "clusterSchedulingPolicySnapshot", policyRef)
 		return ctrl.Result{}, err
 	}
 
+	// Run the Permit plugins to find out the bindings that can be created right now; clusters that
+	// are not permitted are reported as filtered out.
+	toCreate, notPermitted, requeueAfter, err := f.runPermitPlugins(ctx, state, crpName, policy, clusters, toCreate)
+	if err != nil {
+		klog.ErrorS(err, "Failed to run permit plugins", "clusterSchedulingPolicySnapshot", policyRef)
+		return ctrl.Result{}, err
+	}
+	filtered = append(filtered, notPermitted...)
+
+	// Run the PreBind plugins to decorate the bindings to create or patch.
+	if err := f.runPreBindPlugins(ctx, state, policy, clusters, toCreate, toPatch); err != nil {
+		klog.ErrorS(err, "Failed to run prebind plugins", "clusterSchedulingPolicySnapshot", policyRef)
+		return ctrl.Result{}, err
+	}
+
 	// Manipulate bindings accordingly.
 	klog.V(2).InfoS("Manipulating bindings", "clusterSchedulingPolicySnapshot", policyRef)
-	if err := f.manipulateBindings(ctx, policy, toCreate, toDelete, toPatch); err != nil {
+	if err := f.manipulateBindings(ctx, state, policy, clusters, toCreate, toDelete, toPatch); err != nil {
 		klog.ErrorS(err, "Failed to manipulate bindings", "clusterSchedulingPolicySnapshot", policyRef)
 		return ctrl.Result{}, err
 	}
@@ -966,13 +1444,13 @@ func (f *framework) runSchedulingCycleForPickNPlacementType(
 
 	// Update policy snapshot status with the latest scheduling decisions and condition.
 	klog.V(2).InfoS("Updating policy snapshot status", "clusterSchedulingPolicySnapshot", policyRef)
-	if err := f.updatePolicySnapshotStatusFromBindings(ctx, policy, numOfClusters, notPicked, filtered, toCreate, patched, scheduled, bound); err != nil {
+	if err := f.updatePolicySnapshotStatusFromBindings(ctx, policy, numOfClusters, notPicked, filtered, state.remediations, toCreate, patched, scheduled, bound); err != nil {
 		klog.ErrorS(err, "Failed to update latest scheduling decisions and condition", "clusterSchedulingPolicySnapshot", policyRef)
 		return ctrl.Result{}, err
 	}
 
 	// The scheduling cycle has completed.
-	return ctrl.Result{}, nil
+	return permitWaitResult(requeueAfter), nil
 }
 
 // downscale performs downscaling on scheduled and bound bindings, i.e., marks some of them as unscheduled.
@@ -980,7 +1458,7 @@ func (f *framework) runSchedulingCycleForPickNPlacementType(
 // To minimize interruptions, the scheduler picks scheduled bindings first (in any order); if there
 // are still more bindings to trim, the scheduler will move onto bound bindings, and it prefers
 // ones with a lower cluster score and a smaller name (in alphabetical order) .
-func (f *framework) downscale(ctx context.Context, scheduled, bound []*placementv1beta1.ClusterResourceBinding, count int) (updatedScheduled, updatedBound []*placementv1beta1.ClusterResourceBinding, err error) {
+func (f *framework) downscale(ctx context.Context, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, scheduled, bound []*placementv1beta1.ClusterResourceBinding, count int) (updatedScheduled, updatedBound []*placementv1beta1.ClusterResourceBinding, err error) {
 	if count == 0 {
 		// Skip if the downscale count is zero.
 		return scheduled, bound, nil
@@ -1013,10 +1491,10 @@ func (f *framework) downscale(ctx context.Context, scheduled, bound []*placement
 			bindingsToDelete = append(bindingsToDelete, sortedScheduled[i])
 		}
 
-		return sortedScheduled[count:], bound, f.updateBindings(ctx, bindingsToDelete, markUnscheduledForAndUpdate)
+		return sortedScheduled[count:], bound, f.updateBindings(ctx, bindingsToDelete, markUnscheduledForAndUpdate(placementv1beta1.UnscheduledReasonDownscaled, policy))
 	case count == len(scheduled):
 		// Trim all scheduled bindings.
-		return nil, bound, f.updateBindings(ctx, scheduled, markUnscheduledForAndUpdate)
+		return nil, bound, f.updateBindings(ctx, scheduled, markUnscheduledForAndUpdate(placementv1beta1.UnscheduledReasonDownscaled, policy))
 	case count < len(scheduled)+len(bound):
 		// Trim all scheduled bindings and part of bound bindings.
 		bindingsToDelete := make([]*placementv1beta1.ClusterResourceBinding, 0, count)
@@ -1037,13 +1515,13 @@ func (f *framework) downscale(ctx context.Context, scheduled, bound []*placement
 			bindingsToDelete = append(bindingsToDelete, sortedBound[i])
 		}
 
-		return nil, sortedBound[left:], f.updateBindings(ctx, bindingsToDelete, markUnscheduledForAndUpdate)
+		return nil, sortedBound[left:], f.updateBindings(ctx, bindingsToDelete, markUnscheduledForAndUpdate(placementv1beta1.UnscheduledReasonDownscaled, policy))
 	case count == len(scheduled)+len(bound):
 		// Trim all scheduled and bound bindings.
 		bindingsToDelete := make([]*placementv1beta1.ClusterResourceBinding, 0, count)
 		bindingsToDelete = append(bindingsToDelete, scheduled...)
 		bindingsToDelete = append(bindingsToDelete, bound...)
-		return nil, nil, f.updateBindings(ctx, bindingsToDelete, markUnscheduledForAndUpdate)
+		return nil, nil, f.updateBindings(ctx, bindingsToDelete, markUnscheduledForAndUpdate(placementv1beta1.UnscheduledReasonDownscaled, policy))
 	default:
 		// Normally this branch will never run, as an earlier check has guaranteed that
 		// count <= len(scheduled) + len(bound).
@@ -1124,6 +1602,19 @@ func (f *framework) runAllPluginsForPickNPlacementType(
 		return nil, nil, controller.NewUnexpectedBehaviorError(err)
 	}
 
+	// Run post-filter plugins.
+	//
+	// If fewer clusters than needed have passed the Filter stage, plugins may suggest remediations,
+	// which are recorded in the status of the policy snapshot, or preempt bindings of other placements.
+	if err := f.runPostFilterPlugins(ctx, state, policy, passed, filtered, state.batchSizeLimit); err != nil {
+		klog.ErrorS(err, "Failed to run post filter plugins", "clusterSchedulingPolicySnapshot", policyRef)
+		return nil, nil, err
+	}
+	if state.preempted {
+		// Skip the Score stage, as the placement is retried in a new cycle.
+		return nil, filtered, nil
+	}
+
 	// Run pre-score plugins.
 	if status := f.runPreScorePlugins(ctx, state, policy); status.IsInteralError() {
 		klog.ErrorS(status.AsError(), "Failed ro run pre-score plugins", "clusterSchedulingPolicySnapshot", policyRef)
@@ -1168,6 +1659,255 @@ func (f *framework) runPostBatchPlugins(ctx context.Context, state *CycleState,
 	return minBatchSizeLimit, nil
 }
 
+// runPreBindPlugins runs all prebind plugins sequentially, for each binding that the scheduler is about
+// to create or patch.
+func (f *framework) runPreBindPlugins(
+	ctx context.Context,
+	state *CycleState,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	clusters []clusterv1beta1.MemberCluster,
+	toCreate []*placementv1beta1.ClusterResourceBinding,
+	toPatch []*bindingWithPatch,
+) error {
+	if len(f.profile.preBindPlugins) == 0 {
+		return nil
+	}
+
+	clustersByName := make(map[string]*clusterv1beta1.MemberCluster, len(clusters))
+	for idx := range clusters {
+		clustersByName[clusters[idx].Name] = &clusters[idx]
+	}
+	bindings := make([]*placementv1beta1.ClusterResourceBinding, 0, len(toCreate)+len(toPatch))
+	bindings = append(bindings, toCreate...)
+	for _, bp := range toPatch {
+		// Changes made to the updated binding are included in the patch, as the patch is
+		// computed against the original binding when it is sent.
+		bindings = append(bindings, bp.updated)
+	}
+
+	for _, binding := range bindings {
+		cluster, ok := clustersByName[binding.Spec.TargetCluster]
+		if !ok {
+			// This normally should never occur; a binding is created or patched only for a picked cluster.
+			err := fmt.Errorf("target cluster %s of binding %s is not found", binding.Spec.TargetCluster, binding.Name)
+			return controller.NewUnexpectedBehaviorError(err)
+		}
+		for _, pl := range f.profile.preBindPlugins {
+			status := pl.PreBind(ctx, state, policy, cluster, binding)
+			switch {
+			case status.IsSuccess(): // Do nothing.
+			case status.IsInteralError():
+				return controller.NewUnexpectedBehaviorError(status.AsError())
+			case status.IsSkip(): // Do nothing.
+			default:
+				// Any status that is not Success, InternalError, or Skip is considered an error.
+				return controller.NewUnexpectedBehaviorError(fmt.Errorf("prebind plugin %s returned an unsupported status: %s", pl.Name(), status))
+			}
+		}
+	}
+	return nil
+}
+
+// runPermitPlugins runs all permit plugins sequentially, for the target cluster of each binding that
+// the scheduler is about to create.
+//
+// It returns the bindings that are permitted, the clusters that are not permitted (with the reasons),
+// and, if some decisions are held, the delay after which the scheduler should check again.
+func (f *framework) runPermitPlugins(
+	ctx context.Context,
+	state *CycleState,
+	crpName string,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	clusters []clusterv1beta1.MemberCluster,
+	toCreate []*placementv1beta1.ClusterResourceBinding,
+) (permitted []*placementv1beta1.ClusterResourceBinding, notPermitted []*filteredClusterWithStatus, requeueAfter time.Duration, err error) {
+	if len(f.profile.permitPlugins) == 0 {
+		return toCreate, nil, 0, nil
+	}
+
+	clustersByName := make(map[string]*clusterv1beta1.MemberCluster, len(clusters))
+	for idx := range clusters {
+		clustersByName[clusters[idx].Name] = &clusters[idx]
+	}
+
+	permitted = make([]*placementv1beta1.ClusterResourceBinding, 0, len(toCreate))
+	// waiting is the set of clusters with decisions held in this cycle, including the timed out ones.
+	waiting := make(map[string]bool)
+	now := time.Now()
+	for _, binding := range toCreate {
+		cluster, ok := clustersByName[binding.Spec.TargetCluster]
+		if !ok {
+			// This normally should never occur; a binding is created only for a picked cluster.
+			err := fmt.Errorf("target cluster %s of binding %s is not found", binding.Spec.TargetCluster, binding.Name)
+			return nil, nil, 0, controller.NewUnexpectedBehaviorError(err)
+		}
+
+		var denied, waitStatus *Status
+		var timeout time.Duration
+		for _, pl := range f.profile.permitPlugins {
+			status, plTimeout := pl.Permit(ctx, state, policy, cluster)
+			switch {
+			case status.IsSuccess(): // Do nothing.
+			case status.IsClusterUnschedulable():
+				denied = status
+			case status.IsWait():
+				if plTimeout <= 0 || plTimeout > maxPermitWaitTimeout {
+					plTimeout = maxPermitWaitTimeout
+				}
+				// Wait for the plugin with the shortest timeout.
+				if waitStatus == nil || plTimeout < timeout {
+					waitStatus, timeout = status, plTimeout
+				}
+			case status.IsInteralError():
+				return nil, nil, 0, controller.NewUnexpectedBehaviorError(status.AsError())
+			case status.IsSkip(): // Do nothing.
+			default:
+				// Any status that is not Success, ClusterUnschedulable, Wait, InternalError, or Skip is considered an error.
+				return nil, nil, 0, controller.NewUnexpectedBehaviorError(fmt.Errorf("permit plugin %s returned an unsupported status: %s", pl.Name(), status))
+			}
+			if denied != nil {
+				// A denial overrides all other decisions; no need to run the rest of the plugins.
+				break
+			}
+		}
+
+		switch {
+		case denied != nil:
+			notPermitted = append(notPermitted, &filteredClusterWithStatus{cluster: cluster, status: denied})
+		case waitStatus != nil:
+			waiting[cluster.Name] = true
+			waited := now.Sub(f.permitWaits.waitingSince(crpName, policy.Name, cluster.Name, now))
+			if waited >= timeout {
+				// The wait has timed out; deny the creation.
+				//
+				// Note that the wait is still tracked, so that the creation stays denied until the
+				// plugins change their minds or a new policy snapshot is produced.
+				klog.V(2).InfoS("Timed out waiting for permit plugin", "clusterSchedulingPolicySnapshot", klog.KObj(policy), "memberCluster", klog.KObj(cluster), "plugin", waitStatus.SourcePlugin())
+				reason := fmt.Sprintf(permitWaitTimeoutReasonTemplate, waitStatus.SourcePlugin(), timeout)
+				denied = NewNonErrorStatus(ClusterUnschedulable, waitStatus.SourcePlugin(), reason)
+				notPermitted = append(notPermitted, &filteredClusterWithStatus{cluster: cluster, status: denied})
+				continue
+			}
+			notPermitted = append(notPermitted, &filteredClusterWithStatus{cluster: cluster, status: waitStatus})
+			recheckAfter := min(timeout-waited, permitWaitRequeueInterval)
+			if requeueAfter == 0 || recheckAfter < requeueAfter {
+				requeueAfter = recheckAfter
+			}
+		default:
+			permitted = append(permitted, binding)
+		}
+	}
+
+	// Stop tracking the decisions that are no longer held.
+	f.permitWaits.retain(crpName, waiting)
+	return permitted, notPermitted, requeueAfter, nil
+}
+
+// permitWaitResult returns the result of a completed scheduling cycle, which requeues the placement
+// after the given delay if some decisions are held by Permit plugins.
+func permitWaitResult(requeueAfter time.Duration) ctrl.Result {
+	if requeueAfter <= 0 {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{Requeue: true, RequeueAfter: requeueAfter}
+}
+
+// excludeNotPermittedTargets moves the valid target clusters that are not permitted by Permit plugins
+// to the list of invalid targets, for scheduling policies of the PickFixed placement type.
+func excludeNotPermittedTargets(
+	valid []*clusterv1beta1.MemberCluster,
+	invalid []*invalidClusterWithReason,
+	notPermitted []*filteredClusterWithStatus,
+) ([]*clusterv1beta1.MemberCluster, []*invalidClusterWithReason) {
+	if len(notPermitted) == 0 {
+		return valid, invalid
+	}
+
+	notPermittedByName := make(map[string]*Status, len(notPermitted))
+	for _, np := range notPermitted {
+		notPermittedByName[np.cluster.Name] = np.status
+	}
+	permittedValid := make([]*clusterv1beta1.MemberCluster, 0, len(valid))
+	for _, cluster := range valid {
+		status, ok := notPermittedByName[cluster.Name]
+		if !ok {
+			permittedValid = append(permittedValid, cluster)
+			continue
+		}
+		invalid = append(invalid, &invalidClusterWithReason{cluster: cluster, reason: status.String()})
+	}
+	return permittedValid, invalid
+}
+
+// runReservePlugins runs all reserve plugins sequentially, for the target cluster of each binding that
+// the scheduler is about to create or patch.
+//
+// It returns the clusters for which the reserve plugins have been run, even partially, so that the
+// reservations can be rolled back with runUnreservePlugins.
+func (f *framework) runReservePlugins(
+	ctx context.Context,
+	state *CycleState,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	clusters []clusterv1beta1.MemberCluster,
+	toCreate []*placementv1beta1.ClusterResourceBinding,
+	toPatch []*bindingWithPatch,
+) ([]*clusterv1beta1.MemberCluster, error) {
+	if len(f.profile.reservePlugins) == 0 {
+		return nil, nil
+	}
+
+	clustersByName := make(map[string]*clusterv1beta1.MemberCluster, len(clusters))
+	for idx := range clusters {
+		clustersByName[clusters[idx].Name] = &clusters[idx]
+	}
+	bindings := make([]*placementv1beta1.ClusterResourceBinding, 0, len(toCreate)+len(toPatch))
+	bindings = append(bindings, toCreate...)
+	for _, bp := range toPatch {
+		bindings = append(bindings, bp.updated)
+	}
+
+	reserved := make([]*clusterv1beta1.MemberCluster, 0, len(bindings))
+	for _, binding := range bindings {
+		cluster, ok := clustersByName[binding.Spec.TargetCluster]
+		if !ok {
+			// This normally should never occur; a binding is created or patched only for a picked cluster.
+			err := fmt.Errorf("target cluster %s of binding %s is not found", binding.Spec.TargetCluster, binding.Name)
+			return reserved, controller.NewUnexpectedBehaviorError(err)
+		}
+		// Track the cluster before running any plugin, so that a partial reservation can be
+		// rolled back as well.
+		reserved = append(reserved, cluster)
+		for _, pl := range f.profile.reservePlugins {
+			status := pl.Reserve(ctx, state, policy, cluster)
+			switch {
+			case status.IsSuccess(): // Do nothing.
+			case status.IsInteralError():
+				return reserved, controller.NewUnexpectedBehaviorError(status.AsError())
+			case status.IsSkip(): // Do nothing.
+			default:
+				// Any status that is not Success, InternalError, or Skip is considered an error.
+				return reserved, controller.NewUnexpectedBehaviorError(fmt.Errorf("reserve plugin %s returned an unsupported status: %s", pl.Name(), status))
+			}
+		}
+	}
+	return reserved, nil
+}
+
+// runUnreservePlugins runs all reserve plugins in the reverse order of their registration, to roll
+// back the reservations made on the given clusters.
+func (f *framework) runUnreservePlugins(
+	ctx context.Context,
+	state *CycleState,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	reserved []*clusterv1beta1.MemberCluster,
+) {
+	for _, cluster := range reserved {
+		for idx := len(f.profile.reservePlugins) - 1; idx >= 0; idx-- {
+			f.profile.reservePlugins[idx].Unreserve(ctx, state, policy, cluster)
+		}
+	}
+}
+
 // runPreScorePlugins runs all pre score plugins sequentially.
 func (f *framework) runPreScorePlugins(ctx context.Context, state *CycleState, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) *Status {
 	for _, pl := range f.profile.preScorePlugins {
@@ -1227,41 +1967,52 @@ func (f *framework) runScorePlugins(ctx context.Context, state *CycleState, poli
 
 	var scoredClustersIdx int32 = -1
 
-	errFlag := parallelizer.NewErrorFlag()
+	errs := parallelizer.NewErrorCollector(maxCollectedPluginErrors)
 
 	doWork := func(pieces int) {
 		cluster := clusters[pieces]
 		scoreList, status := f.runScorePluginsFor(childCtx, state, policy, cluster)
 		switch {
 		case status.IsSuccess():
-			totalScore := &ClusterScore{}
-			for _, score := range scoreList {
-				totalScore.Add(score)
-			}
 			// Use atomic add to avoid races with minimum overhead.
+			//
+			// Note that the scores from individual plugins are aggregated after all clusters
+			// have been scored, as some aggregation strategies need to inspect the scores of
+			// all clusters.
 			newScoredClustersIdx := atomic.AddInt32(&scoredClustersIdx, 1)
 			scoredClusters[newScoredClustersIdx] = &ScoredCluster{
-				Cluster: cluster,
-				Score:   totalScore,
+				Cluster:      cluster,
+				pluginScores: scoreList,
 			}
 		default: // An error has occurred.
-			errFlag.Raise(status.AsError())
-			// Cancel the child context, which will lead the parallelizer to stop running tasks.
-			cancel()
+			if errs.Collect(status.AsError()) {
+				// Cancel the child context, which will lead the parallelizer to stop running tasks.
+				cancel()
+			}
 		}
 	}
 
 	// Run inspection in parallel.
 	//
-	// Note that the parallel run will be stopped immediately upon encounter of the first error.
+	// Note that the parallel run carries on upon encounter of errors, so that distinct failures
+	// across clusters are all reported; it is stopped only when enough errors have been collected.
 	f.parallelizer.ParallelizeUntil(childCtx, len(clusters), doWork, "runScorePlugins")
-	if err := errFlag.Lower(); err != nil {
+	if err := errs.Err(); err != nil {
 		return nil, err
 	}
 
 	// Trim the slice to its actual size.
 	scoredClusters = scoredClusters[:scoredClustersIdx+1]
 
+	// Run the extenders (if any) on the scored clusters.
+	if err := f.runExtenderScores(ctx, policy, scoredClusters); err != nil {
+		return nil, err
+	}
+
+	// Aggregate the scores from all score plugins.
+	if err := f.profile.aggregateScores(scoredClusters); err != nil {
+		return nil, err
+	}
 	return scoredClusters, nil
 }
 
@@ -1364,6 +2115,7 @@ func (f *framework) updatePolicySnapshotStatusForPickFixedPlacementType(
 	currentCondition := meta.FindStatusCondition(policy.Status.Conditions, string(placementv1beta1.PolicySnapshotScheduled))
 	if observedCRPGeneration == policy.Status.ObservedCRPGeneration &&
 		equalDecisions(currentDecisions, newDecisions) &&
+		len(policy.Status.UnselectedClusterSummary) == 0 &&
 		condition.EqualCondition(currentCondition, &newCondition) {
 		// Skip if there is no change in decisions and conditions.
 		klog.InfoS(
@@ -1372,22 +2124,21 @@ func (f *framework) updatePolicySnapshotStatusForPickFixedPlacementType(
 		return nil
 	}
 
-	// Update the status.
+	// Patch the status.
+	original := policy.DeepCopy()
 	policy.Status.ClusterDecisions = newDecisions
+	// Decisions of the PickFixed placement type are never compacted.
+	policy.Status.UnselectedClusterSummary = nil
 	policy.Status.ObservedCRPGeneration = observedCRPGeneration
 	meta.SetStatusCondition(&policy.Status.Conditions, newCondition)
-	if err := f.client.Status().Update(ctx, policy, &client.SubResourceUpdateOptions{}); err != nil {
-		klog.ErrorS(err, "Failed to update policy snapshot status", "clusterSchedulingPolicySnapshot", policyRef)
-		return controller.NewAPIServerError(false, err)
-	}
-
-	return nil
+	return f.patchPolicySnapshotStatus(ctx, original, policy)
 }
 
 // runSchedulingCycleForPickFixedPlacementType runs the scheduling cycle when there is a fixed
 // set of clusters to select in the placement policy.
 func (f *framework) runSchedulingCycleForPickFixedPlacementType(
 	ctx context.Context,
+	state *CycleState,
 	crpName string,
 	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
 	clusters []clusterv1beta1.MemberCluster,
@@ -1424,15 +2175,30 @@ func (f *framework) runSchedulingCycleForPickFixedPlacementType(
 	//
 	// Fields in the returned bindings are fulfilled and/or refreshed as applicable.
 	klog.V(2).InfoS("Cross-referencing bindings with valid target clusters", "clusterSchedulingPolicySnapshot", policyRef)
-	toCreate, toDelete, toPatch, err := crossReferenceValidTargetsWithBindings(crpName, policy, valid, bound, scheduled, unscheduled, obsolete)
+	toCreate, toDelete, toPatch, err := crossReferenceValidTargetsWithBindings(crpName, policy, valid, bound, scheduled, unscheduled, obsolete, state.takenBindingNames)
 	if err != nil {
 		klog.ErrorS(err, "Failed to cross-reference bindings with valid targets", "clusterSchedulingPolicySnapshot", policyRef)
 		return ctrl.Result{}, err
 	}
 
+	// Run the Permit plugins to find out the bindings that can be created right now; clusters that
+	// are not permitted are reported as invalid targets.
+	toCreate, notPermitted, requeueAfter, err := f.runPermitPlugins(ctx, state, crpName, policy, clusters, toCreate)
+	if err != nil {
+		klog.ErrorS(err, "Failed to run permit plugins", "clusterSchedulingPolicySnapshot", policyRef)
+		return ctrl.Result{}, err
+	}
+	valid, invalid = excludeNotPermittedTargets(valid, invalid, notPermitted)
+
+	// Run the PreBind plugins to decorate the bindings to create or patch.
+	if err := f.runPreBindPlugins(ctx, state, policy, clusters, toCreate, toPatch); err != nil {
+		klog.ErrorS(err, "Failed to run prebind plugins", "clusterSchedulingPolicySnapshot", policyRef)
+		return ctrl.Result{}, err
+	}
+
 	// Manipulate bindings accordingly.
 	klog.V(2).InfoS("Manipulating bindings", "clusterSchedulingPolicySnapshot", policyRef)
-	if err := f.manipulateBindings(ctx, policy, toCreate, toDelete, toPatch); err != nil {
+	if err := f.manipulateBindings(ctx, state, policy, clusters, toCreate, toDelete, toPatch); err != nil {
 		klog.ErrorS(err, "Failed to manipulate bindings", "clusterSchedulingPolicySnapshot", policyRef)
 		return ctrl.Result{}, err
 	}
@@ -1444,5 +2210,5 @@ func (f *framework) runSchedulingCycleForPickFixedPlacementType(
 	}
 
 	// The scheduling cycle is completed.
-	return ctrl.Result{}, nil
+	return permitWaitResult(requeueAfter), nil
 }
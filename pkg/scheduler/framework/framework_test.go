@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -22,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -29,6 +31,8 @@ import (
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/framework/uniquename"
+	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/parallelizer"
 )
 
@@ -165,25 +169,101 @@ func TestCollectClusters(t *testing.T) {
 			Name: "cluster-1",
 		},
 	}
-
-	fakeClient := fake.NewClientBuilder().
-		WithScheme(scheme.Scheme).
-		WithObjects(&cluster).
-		Build()
-	// Construct framework manually instead of using NewFramework() to avoid mocking the controller manager.
-	f := &framework{
-		client: fakeClient,
+	reservedCluster := clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "reserved-cluster",
+		},
+	}
+	testCluster := clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster-1",
+		},
 	}
 
-	ctx := context.Background()
-	clusters, err := f.collectClusters(ctx)
-	if err != nil {
-		t.Fatalf("collectClusters() = %v, want no error", err)
+	testCases := []struct {
+		name                       string
+		excludedClusterNames       []string
+		excludedClusterNamePattern *regexp.Regexp
+		strictConsistency          bool
+		clusterSnapshot            *ClusterSnapshot
+		want                       []clusterv1beta1.MemberCluster
+	}{
+		{
+			name: "no exclusion",
+			want: []clusterv1beta1.MemberCluster{cluster, reservedCluster, testCluster},
+		},
+		{
+			name:                 "exclude by name",
+			excludedClusterNames: []string{reservedCluster.Name},
+			want:                 []clusterv1beta1.MemberCluster{cluster, testCluster},
+		},
+		{
+			name:                       "exclude by name pattern",
+			excludedClusterNamePattern: regexp.MustCompile("^test-"),
+			want:                       []clusterv1beta1.MemberCluster{cluster, reservedCluster},
+		},
+		{
+			name:                       "exclude by both name and name pattern",
+			excludedClusterNames:       []string{reservedCluster.Name},
+			excludedClusterNamePattern: regexp.MustCompile("^test-"),
+			want:                       []clusterv1beta1.MemberCluster{cluster},
+		},
+		{
+			name:                 "strict consistency",
+			excludedClusterNames: []string{reservedCluster.Name},
+			strictConsistency:    true,
+			want:                 []clusterv1beta1.MemberCluster{cluster},
+		},
+		{
+			name:                 "cluster snapshot",
+			excludedClusterNames: []string{reservedCluster.Name},
+			clusterSnapshot:      newSyncedClusterSnapshot(&testCluster, &cluster),
+			want:                 []clusterv1beta1.MemberCluster{cluster, testCluster},
+		},
+		{
+			name:            "cluster snapshot, not synced",
+			clusterSnapshot: NewClusterSnapshot(),
+			want:            []clusterv1beta1.MemberCluster{cluster, reservedCluster, testCluster},
+		},
+		{
+			name:              "strict consistency, cluster snapshot not in use",
+			strictConsistency: true,
+			clusterSnapshot:   newSyncedClusterSnapshot(&testCluster),
+			want:              []clusterv1beta1.MemberCluster{cluster, reservedCluster},
+		},
 	}
 
-	want := []clusterv1beta1.MemberCluster{cluster}
-	if diff := cmp.Diff(clusters, want); diff != "" {
-		t.Fatalf("collectClusters() diff (-got, +want) = %s", diff)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(&cluster, &reservedCluster, &testCluster).
+				Build()
+			// The uncached reader sees a newer state of the fleet, where the test cluster has left.
+			fakeUncachedReader := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(&cluster, &reservedCluster).
+				Build()
+			// Construct framework manually instead of using NewFramework() to avoid mocking the controller manager.
+			f := &framework{
+				client:                     fakeClient,
+				uncachedReader:             fakeUncachedReader,
+				excludedClusterNames:       sets.New(tc.excludedClusterNames...),
+				excludedClusterNamePattern: tc.excludedClusterNamePattern,
+				strictConsistency:          tc.strictConsistency,
+				clusterSnapshot:            tc.clusterSnapshot,
+			}
+
+			ctx := context.Background()
+			clusters, err := f.collectClusters(ctx)
+			if err != nil {
+				t.Fatalf("collectClusters() = %v, want no error", err)
+			}
+
+			if diff := cmp.Diff(clusters, tc.want, ignoreObjectMetaResourceVersionField); diff != "" {
+				t.Fatalf("collectClusters() diff (-got, +want) = %s", diff)
+			}
+		})
 	}
 }
 
@@ -265,6 +345,7 @@ func TestClassifyBindings(t *testing.T) {
 		{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: clusterName2,
+				UID:  "cluster-2-uid",
 			},
 		},
 		{
@@ -336,6 +417,9 @@ func TestClassifyBindings(t *testing.T) {
 	scheduledBinding := placementv1beta1.ClusterResourceBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "binding-8",
+			Annotations: map[string]string{
+				placementv1beta1.TargetClusterUIDAnnotation: "cluster-2-uid",
+			},
 		},
 		Spec: placementv1beta1.ResourceBindingSpec{
 			State:                        placementv1beta1.BindingStateScheduled,
@@ -343,6 +427,19 @@ func TestClassifyBindings(t *testing.T) {
 			SchedulingPolicySnapshotName: policyName,
 		},
 	}
+	associatedWithRecreatedClusterBinding := placementv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "binding-9",
+			Annotations: map[string]string{
+				placementv1beta1.TargetClusterUIDAnnotation: "old-cluster-2-uid",
+			},
+		},
+		Spec: placementv1beta1.ResourceBindingSpec{
+			State:                        placementv1beta1.BindingStateBound,
+			TargetCluster:                clusterName2,
+			SchedulingPolicySnapshotName: policyName,
+		},
+	}
 
 	bindings := []placementv1beta1.ClusterResourceBinding{
 		deletingBinding,
@@ -352,12 +449,13 @@ func TestClassifyBindings(t *testing.T) {
 		obsoleteBinding,
 		boundBinding,
 		scheduledBinding,
+		associatedWithRecreatedClusterBinding,
 	}
 	wantBound := []*placementv1beta1.ClusterResourceBinding{&boundBinding}
 	wantScheduled := []*placementv1beta1.ClusterResourceBinding{&scheduledBinding}
 	wantObsolete := []*placementv1beta1.ClusterResourceBinding{&obsoleteBinding}
 	wantUnscheduled := []*placementv1beta1.ClusterResourceBinding{&unscheduledBinding}
-	wantDangling := []*placementv1beta1.ClusterResourceBinding{&associatedWithLeavingClusterBinding, &assocaitedWithDisappearedClusterBinding}
+	wantDangling := []*placementv1beta1.ClusterResourceBinding{&associatedWithLeavingClusterBinding, &assocaitedWithDisappearedClusterBinding, &associatedWithRecreatedClusterBinding}
 	wantDeleting := []*placementv1beta1.ClusterResourceBinding{&deletingBinding}
 
 	bound, scheduled, obsolete, unscheduled, dangling, deleting := classifyBindings(policy, bindings, clusters)
@@ -386,6 +484,189 @@ func TestClassifyBindings(t *testing.T) {
 	}
 }
 
+// TestDedupBindingsByTargetCluster tests the dedupBindingsByTargetCluster function.
+func TestDedupBindingsByTargetCluster(t *testing.T) {
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policyName,
+		},
+	}
+	newBinding := func(name, clusterName string, state placementv1beta1.BindingState, policyName string) *placementv1beta1.ClusterResourceBinding {
+		return &placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:                        state,
+				SchedulingPolicySnapshotName: policyName,
+				TargetCluster:                clusterName,
+			},
+		}
+	}
+	clusterName1 := fmt.Sprintf(clusterNameTemplate, 1)
+	clusterName2 := fmt.Sprintf(clusterNameTemplate, 2)
+	clusterName3 := fmt.Sprintf(clusterNameTemplate, 3)
+
+	testCases := []struct {
+		name           string
+		bound          []*placementv1beta1.ClusterResourceBinding
+		scheduled      []*placementv1beta1.ClusterResourceBinding
+		obsolete       []*placementv1beta1.ClusterResourceBinding
+		wantBound      []*placementv1beta1.ClusterResourceBinding
+		wantScheduled  []*placementv1beta1.ClusterResourceBinding
+		wantObsolete   []*placementv1beta1.ClusterResourceBinding
+		wantDuplicated []*placementv1beta1.ClusterResourceBinding
+	}{
+		{
+			name: "no duplicates",
+			bound: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-1", clusterName1, placementv1beta1.BindingStateBound, policyName),
+			},
+			scheduled: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-2", clusterName2, placementv1beta1.BindingStateScheduled, policyName),
+			},
+			obsolete: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-3", clusterName3, placementv1beta1.BindingStateBound, altPolicyName),
+			},
+			wantBound: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-1", clusterName1, placementv1beta1.BindingStateBound, policyName),
+			},
+			wantScheduled: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-2", clusterName2, placementv1beta1.BindingStateScheduled, policyName),
+			},
+			wantObsolete: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-3", clusterName3, placementv1beta1.BindingStateBound, altPolicyName),
+			},
+		},
+		{
+			name: "bound bindings are preferred",
+			bound: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-2", clusterName1, placementv1beta1.BindingStateBound, policyName),
+			},
+			scheduled: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-1", clusterName1, placementv1beta1.BindingStateScheduled, policyName),
+			},
+			obsolete: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-0", clusterName1, placementv1beta1.BindingStateBound, altPolicyName),
+			},
+			wantBound: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-2", clusterName1, placementv1beta1.BindingStateBound, policyName),
+			},
+			wantScheduled: []*placementv1beta1.ClusterResourceBinding{},
+			wantObsolete:  []*placementv1beta1.ClusterResourceBinding{},
+			wantDuplicated: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-1", clusterName1, placementv1beta1.BindingStateScheduled, policyName),
+				newBinding("binding-0", clusterName1, placementv1beta1.BindingStateBound, altPolicyName),
+			},
+		},
+		{
+			name: "obsolete bound bindings are preferred over scheduled ones",
+			scheduled: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-1", clusterName1, placementv1beta1.BindingStateScheduled, policyName),
+			},
+			obsolete: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-2", clusterName1, placementv1beta1.BindingStateBound, altPolicyName),
+			},
+			wantBound:     []*placementv1beta1.ClusterResourceBinding{},
+			wantScheduled: []*placementv1beta1.ClusterResourceBinding{},
+			wantObsolete: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-2", clusterName1, placementv1beta1.BindingStateBound, altPolicyName),
+			},
+			wantDuplicated: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-1", clusterName1, placementv1beta1.BindingStateScheduled, policyName),
+			},
+		},
+		{
+			name: "the binding with the smallest name is preferred",
+			scheduled: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-2", clusterName1, placementv1beta1.BindingStateScheduled, policyName),
+				newBinding("binding-1", clusterName1, placementv1beta1.BindingStateScheduled, policyName),
+				newBinding("binding-3", clusterName2, placementv1beta1.BindingStateScheduled, policyName),
+			},
+			wantBound: []*placementv1beta1.ClusterResourceBinding{},
+			wantScheduled: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-1", clusterName1, placementv1beta1.BindingStateScheduled, policyName),
+				newBinding("binding-3", clusterName2, placementv1beta1.BindingStateScheduled, policyName),
+			},
+			wantObsolete: []*placementv1beta1.ClusterResourceBinding{},
+			wantDuplicated: []*placementv1beta1.ClusterResourceBinding{
+				newBinding("binding-2", clusterName1, placementv1beta1.BindingStateScheduled, policyName),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bound, scheduled, obsolete, duplicated := dedupBindingsByTargetCluster(policy, tc.bound, tc.scheduled, tc.obsolete)
+			if diff := cmp.Diff(bound, tc.wantBound, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("dedupBindingsByTargetCluster() bound diff (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(scheduled, tc.wantScheduled, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("dedupBindingsByTargetCluster() scheduled diff (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(obsolete, tc.wantObsolete, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("dedupBindingsByTargetCluster() obsolete diff (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(duplicated, tc.wantDuplicated, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("dedupBindingsByTargetCluster() duplicated diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+// BenchmarkClassifyBindings benchmarks the classifyBindings function with large fleets.
+func BenchmarkClassifyBindings(b *testing.B) {
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policyName,
+		},
+	}
+
+	for _, count := range []int{100, 1000, 5000} {
+		b.Run(fmt.Sprintf("%d bindings", count), func(b *testing.B) {
+			clusters := make([]clusterv1beta1.MemberCluster, 0, count)
+			bindings := make([]placementv1beta1.ClusterResourceBinding, 0, count)
+			for i := 0; i < count; i++ {
+				clusterName := fmt.Sprintf(clusterNameTemplate, i)
+				clusters = append(clusters, clusterv1beta1.MemberCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   clusterName,
+						UID:    types.UID(fmt.Sprintf("%s-uid", clusterName)),
+						Labels: map[string]string{"region": "east"},
+					},
+				})
+
+				// Mix the binding states; one in every ten bindings is associated with an
+				// out-of-date scheduling policy snapshot.
+				binding := placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: fmt.Sprintf("binding-%d", i),
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						SchedulingPolicySnapshotName: policyName,
+						TargetCluster:                clusterName,
+					},
+				}
+				switch i % 10 {
+				case 0:
+					binding.Spec.SchedulingPolicySnapshotName = altPolicyName
+				case 1:
+					binding.Spec.State = placementv1beta1.BindingStateScheduled
+				case 2:
+					binding.Spec.State = placementv1beta1.BindingStateUnscheduled
+				}
+				bindings = append(bindings, binding)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				classifyBindings(policy, bindings, clusters)
+			}
+		})
+	}
+}
+
 func TestUpdateBindingsWithErrors(t *testing.T) {
 	binding := placementv1beta1.ClusterResourceBinding{
 		ObjectMeta: metav1.ObjectMeta{
@@ -530,9 +811,14 @@ func TestUpdateBindingsMarkAsUnscheduledForAndUpdate(t *testing.T) {
 	f := &framework{
 		client: fakeClient,
 	}
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policyName,
+		},
+	}
 	// call markAsUnscheduledForAndUpdate
 	ctx := context.Background()
-	if err := f.updateBindings(ctx, []*placementv1beta1.ClusterResourceBinding{&boundBinding, &scheduledBinding}, markUnscheduledForAndUpdate); err != nil {
+	if err := f.updateBindings(ctx, []*placementv1beta1.ClusterResourceBinding{&boundBinding, &scheduledBinding}, markUnscheduledForAndUpdate(placementv1beta1.UnscheduledReasonDownscaled, policy)); err != nil {
 		t.Fatalf("updateBindings() = %v, want no error", err)
 	}
 	// check if the boundBinding has been updated
@@ -543,7 +829,9 @@ func TestUpdateBindingsMarkAsUnscheduledForAndUpdate(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name: bindingName,
 			Annotations: map[string]string{
-				placementv1beta1.PreviousBindingStateAnnotation: string(placementv1beta1.BindingStateBound),
+				placementv1beta1.PreviousBindingStateAnnotation:        string(placementv1beta1.BindingStateBound),
+				placementv1beta1.UnscheduledReasonAnnotation:           placementv1beta1.UnscheduledReasonDownscaled,
+				placementv1beta1.UnscheduledByPolicySnapshotAnnotation: policyName,
 			},
 		},
 		Spec: placementv1beta1.ResourceBindingSpec{
@@ -561,7 +849,9 @@ func TestUpdateBindingsMarkAsUnscheduledForAndUpdate(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name: altBindingName,
 			Annotations: map[string]string{
-				placementv1beta1.PreviousBindingStateAnnotation: string(placementv1beta1.BindingStateScheduled),
+				placementv1beta1.PreviousBindingStateAnnotation:        string(placementv1beta1.BindingStateScheduled),
+				placementv1beta1.UnscheduledReasonAnnotation:           placementv1beta1.UnscheduledReasonDownscaled,
+				placementv1beta1.UnscheduledByPolicySnapshotAnnotation: policyName,
 			},
 		},
 		Spec: placementv1beta1.ResourceBindingSpec{
@@ -573,6 +863,59 @@ func TestUpdateBindingsMarkAsUnscheduledForAndUpdate(t *testing.T) {
 	}
 }
 
+// TestMarkUnscheduledForAndUpdateStaleBinding tests that markUnscheduledForAndUpdate marks a binding as
+// unscheduled even if the binding has been updated by others since it was read.
+func TestMarkUnscheduledForAndUpdateStaleBinding(t *testing.T) {
+	binding := placementv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: bindingName,
+		},
+		Spec: placementv1beta1.ResourceBindingSpec{
+			State:                placementv1beta1.BindingStateBound,
+			ResourceSnapshotName: "snapshot-1",
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(&binding).
+		Build()
+	ctx := context.Background()
+	staleBinding := binding.DeepCopy()
+	if err := fakeClient.Get(ctx, types.NamespacedName{Name: bindingName}, staleBinding); err != nil {
+		t.Fatalf("Get cluster resource binding %s = %v, want no error", bindingName, err)
+	}
+
+	// Update the binding as the rollout controller would do.
+	updated := staleBinding.DeepCopy()
+	updated.Spec.ResourceSnapshotName = "snapshot-2"
+	if err := fakeClient.Update(ctx, updated); err != nil {
+		t.Fatalf("Update cluster resource binding %s = %v, want no error", bindingName, err)
+	}
+
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policyName,
+		},
+	}
+	if err := markUnscheduledForAndUpdate(placementv1beta1.UnscheduledReasonDownscaled, policy)(ctx, fakeClient, staleBinding); err != nil {
+		t.Fatalf("markUnscheduledForAndUpdate() = %v, want no error", err)
+	}
+
+	got := &placementv1beta1.ClusterResourceBinding{}
+	if err := fakeClient.Get(ctx, types.NamespacedName{Name: bindingName}, got); err != nil {
+		t.Fatalf("Get cluster resource binding %s = %v, want no error", bindingName, err)
+	}
+	if got.Spec.State != placementv1beta1.BindingStateUnscheduled {
+		t.Errorf("binding state = %s, want %s", got.Spec.State, placementv1beta1.BindingStateUnscheduled)
+	}
+	if got.Spec.ResourceSnapshotName != "snapshot-2" {
+		t.Errorf("binding resource snapshot name = %s, want the one set by others, snapshot-2", got.Spec.ResourceSnapshotName)
+	}
+	if got.Annotations[placementv1beta1.UnscheduledReasonAnnotation] != placementv1beta1.UnscheduledReasonDownscaled {
+		t.Errorf("binding unscheduled reason annotation = %s, want %s", got.Annotations[placementv1beta1.UnscheduledReasonAnnotation], placementv1beta1.UnscheduledReasonDownscaled)
+	}
+}
+
 func TestUpdateBindingRemoveFinalizerAndUpdate(t *testing.T) {
 	boundBinding := placementv1beta1.ClusterResourceBinding{
 		ObjectMeta: metav1.ObjectMeta{
@@ -913,6 +1256,7 @@ func TestRunFilterPlugins(t *testing.T) {
 		wantClusters   []*clusterv1beta1.MemberCluster
 		wantFiltered   []*filteredClusterWithStatus
 		expectedToFail bool
+		wantErrReasons []string
 	}{
 		{
 			name: "three clusters, two filter plugins, all passed",
@@ -1049,6 +1393,31 @@ func TestRunFilterPlugins(t *testing.T) {
 			},
 			expectedToFail: true,
 		},
+		{
+			name: "three clusters, two filter plugins, distinct internal errors on different clusters",
+			filterPlugins: []FilterPlugin{
+				&DummyAllPurposePlugin{
+					name: dummyFilterPluginNameA,
+					filterRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status) {
+						if cluster.Name == clusterName {
+							return FromError(fmt.Errorf("internal error A"), dummyFilterPluginNameA)
+						}
+						return nil
+					},
+				},
+				&DummyAllPurposePlugin{
+					name: dummyFilterPluginNameB,
+					filterRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status) {
+						if cluster.Name != clusterName {
+							return FromError(fmt.Errorf("internal error B"), dummyFilterPluginNameB)
+						}
+						return nil
+					},
+				},
+			},
+			expectedToFail: true,
+			wantErrReasons: []string{"internal error A", "internal error B"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1075,6 +1444,11 @@ func TestRunFilterPlugins(t *testing.T) {
 				if err == nil {
 					t.Fatalf("runFilterPlugins(%v, %v, %v) = %v %v %v, want error", state, policy, clusters, passed, filtered, err)
 				}
+				for _, reason := range tc.wantErrReasons {
+					if !strings.Contains(err.Error(), reason) {
+						t.Errorf("runFilterPlugins() = %v, want the error to include %q", err, reason)
+					}
+				}
 				return
 			}
 
@@ -1091,6 +1465,127 @@ func TestRunFilterPlugins(t *testing.T) {
 	}
 }
 
+// TestRunPostFilterPlugins tests the runPostFilterPlugins method.
+func TestRunPostFilterPlugins(t *testing.T) {
+	dummyPostFilterPluginNameA := fmt.Sprintf(dummyAllPurposePluginNameFormat, 0)
+	dummyPostFilterPluginNameB := fmt.Sprintf(dummyAllPurposePluginNameFormat, 1)
+
+	passed := []*clusterv1beta1.MemberCluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: clusterName,
+			},
+		},
+	}
+	filtered := []*filteredClusterWithStatus{
+		{
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: altClusterName,
+				},
+			},
+			status: NewNonErrorStatus(ClusterUnschedulable, dummyPostFilterPluginNameA),
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		postFilterPlugins []PostFilterPlugin
+		want              int
+		wantRemediations  []string
+		wantErr           bool
+	}{
+		{
+			name: "enough clusters have passed, skip",
+			postFilterPlugins: []PostFilterPlugin{
+				&DummyAllPurposePlugin{
+					name: dummyPostFilterPluginNameA,
+					postFilterRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, filtered []*FilteredCluster, want int) ([]*placementv1beta1.ClusterResourceBinding, *Status) {
+						return nil, FromError(fmt.Errorf("should not run"), dummyPostFilterPluginNameA)
+					},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "multiple plugins, one success, one skip",
+			postFilterPlugins: []PostFilterPlugin{
+				&DummyAllPurposePlugin{
+					name: dummyPostFilterPluginNameA,
+					postFilterRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, filtered []*FilteredCluster, want int) ([]*placementv1beta1.ClusterResourceBinding, *Status) {
+						if len(filtered) != 1 || filtered[0].Cluster.Name != altClusterName || want != 1 {
+							return nil, FromError(fmt.Errorf("unexpected filtered clusters or want count"), dummyPostFilterPluginNameA)
+						}
+						return nil, NewNonErrorStatus(Success, dummyPostFilterPluginNameA, "remediation")
+					},
+				},
+				&DummyAllPurposePlugin{
+					name: dummyPostFilterPluginNameB,
+					postFilterRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, filtered []*FilteredCluster, want int) ([]*placementv1beta1.ClusterResourceBinding, *Status) {
+						return nil, NewNonErrorStatus(Skip, dummyPostFilterPluginNameB)
+					},
+				},
+			},
+			want:             2,
+			wantRemediations: []string{"remediation"},
+		},
+		{
+			name: "single plugin, internal error",
+			postFilterPlugins: []PostFilterPlugin{
+				&DummyAllPurposePlugin{
+					name: dummyPostFilterPluginNameA,
+					postFilterRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, filtered []*FilteredCluster, want int) ([]*placementv1beta1.ClusterResourceBinding, *Status) {
+						return nil, FromError(fmt.Errorf("internal error"), dummyPostFilterPluginNameA)
+					},
+				},
+			},
+			want:    2,
+			wantErr: true,
+		},
+		{
+			name: "single plugin, cannot make room",
+			postFilterPlugins: []PostFilterPlugin{
+				&DummyAllPurposePlugin{
+					name: dummyPostFilterPluginNameA,
+					postFilterRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, filtered []*FilteredCluster, want int) ([]*placementv1beta1.ClusterResourceBinding, *Status) {
+						return nil, NewNonErrorStatus(ClusterUnschedulable, dummyPostFilterPluginNameA)
+					},
+				},
+			},
+			want: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := NewProfile(dummyProfileName)
+			for _, p := range tc.postFilterPlugins {
+				profile.WithPostFilterPlugin(p)
+			}
+			// Construct framework manually instead of using NewFramework() to avoid mocking the controller manager.
+			f := &framework{
+				profile: profile,
+			}
+
+			ctx := context.Background()
+			state := NewCycleState([]clusterv1beta1.MemberCluster{}, []*placementv1beta1.ClusterResourceBinding{})
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: policyName,
+				},
+			}
+
+			err := f.runPostFilterPlugins(ctx, state, policy, passed, filtered, tc.want)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("runPostFilterPlugins() = %v, want error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(state.remediations, tc.wantRemediations); diff != "" {
+				t.Errorf("runPostFilterPlugins() remediations diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
 // TestRunAllPluginsForPickAllPlacementType tests the runAllPluginsForPickAllPlacementType method.
 func TestRunAllPluginsForPickAllPlacementType(t *testing.T) {
 	dummyPreFilterPluginNameA := fmt.Sprintf(dummyAllPurposePluginNameFormat, 0)
@@ -1388,6 +1883,81 @@ func TestRunAllPluginsForPickAllPlacementType(t *testing.T) {
 	}
 }
 
+// TestNewBindingName tests the newBindingName function.
+func TestNewBindingName(t *testing.T) {
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policyName,
+		},
+	}
+	cluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterName,
+			UID:  "cluster-uid",
+		},
+	}
+	seed := fmt.Sprintf("%s/%s", policyName, cluster.UID)
+	candidate := func(attempt int) string {
+		attemptSeed := seed
+		if attempt > 0 {
+			attemptSeed = fmt.Sprintf("%s/%d", seed, attempt)
+		}
+		name, err := uniquename.NewDeterministicClusterResourceBindingName(crpName, clusterName, attemptSeed)
+		if err != nil {
+			t.Fatalf("NewDeterministicClusterResourceBindingName() = %v, want no error", err)
+		}
+		return name
+	}
+	allTaken := sets.New[string]()
+	for attempt := 0; attempt < maxBindingNameAttempts; attempt++ {
+		allTaken.Insert(candidate(attempt))
+	}
+
+	testCases := []struct {
+		name     string
+		taken    sets.Set[string]
+		wantName string
+		wantErr  bool
+	}{
+		{
+			name:     "no collision",
+			taken:    sets.New[string](),
+			wantName: candidate(0),
+		},
+		{
+			name:     "collision with an existing binding",
+			taken:    sets.New(candidate(0), candidate(1)),
+			wantName: candidate(2),
+		},
+		{
+			name:    "all candidates taken",
+			taken:   allTaken,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, err := newBindingName(crpName, policy, cluster, tc.taken)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("newBindingName() = %s, want error", name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newBindingName() = %v, want no error", err)
+			}
+			if name != tc.wantName {
+				t.Errorf("newBindingName() = %s, want %s", name, tc.wantName)
+			}
+			if !tc.taken.Has(name) {
+				t.Errorf("newBindingName() did not add %s to the taken names", name)
+			}
+		})
+	}
+}
+
 // TestCrossReferencePickedClustersAndDeDupBindings tests the crossReferencePickedClustersAndDeDupBindings function.
 func TestCrossReferencePickedClustersAndDeDupBindings(t *testing.T) {
 	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
@@ -2084,38 +2654,100 @@ func TestCrossReferencePickedClustersAndDeDupBindings(t *testing.T) {
 							Name:        bindingName2,
 							Annotations: map[string]string{},
 						},
-						Spec: placementv1beta1.ResourceBindingSpec{
-							TargetCluster:                clusterName2,
-							SchedulingPolicySnapshotName: policyName,
-							State:                        placementv1beta1.BindingStateScheduled,
-							ClusterDecision: placementv1beta1.ClusterDecision{
-								ClusterName: clusterName2,
-								Selected:    true,
-								ClusterScore: &placementv1beta1.ClusterScore{
-									AffinityScore:       &affinityScore2,
-									TopologySpreadScore: &topologySpreadScore2,
-								},
-								Reason: fmt.Sprintf(resourceScheduleSucceededWithScoreMessageFormat, clusterName2, affinityScore2, topologySpreadScore2),
+						Spec: placementv1beta1.ResourceBindingSpec{
+							TargetCluster:                clusterName2,
+							SchedulingPolicySnapshotName: policyName,
+							State:                        placementv1beta1.BindingStateScheduled,
+							ClusterDecision: placementv1beta1.ClusterDecision{
+								ClusterName: clusterName2,
+								Selected:    true,
+								ClusterScore: &placementv1beta1.ClusterScore{
+									AffinityScore:       &affinityScore2,
+									TopologySpreadScore: &topologySpreadScore2,
+								},
+								Reason: fmt.Sprintf(resourceScheduleSucceededWithScoreMessageFormat, clusterName2, affinityScore2, topologySpreadScore2),
+							},
+						},
+					},
+					patch: client.MergeFromWithOptions(&placementv1beta1.ClusterResourceBinding{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: bindingName2,
+						},
+						Spec: placementv1beta1.ResourceBindingSpec{
+							TargetCluster: clusterName2,
+						},
+					}, client.MergeFromWithOptimisticLock{}),
+				},
+			},
+			wantToDelete: []*placementv1beta1.ClusterResourceBinding{},
+		},
+		{
+			name: "unscheduled binding whose target cluster has been recreated",
+			picked: ScoredClusters{
+				{
+					Cluster: &clusterv1beta1.MemberCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterName1,
+							UID:  "new-cluster-1-uid",
+						},
+					},
+					Score: &ClusterScore{
+						TopologySpreadScore: int(topologySpreadScore1),
+						AffinityScore:       int(affinityScore1),
+					},
+				},
+			},
+			unscheduled: []*placementv1beta1.ClusterResourceBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName1,
+						Annotations: map[string]string{
+							placementv1beta1.PreviousBindingStateAnnotation: string(placementv1beta1.BindingStateBound),
+							placementv1beta1.TargetClusterUIDAnnotation:     "old-cluster-1-uid",
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						TargetCluster: clusterName1,
+						State:         placementv1beta1.BindingStateUnscheduled,
+					},
+				},
+			},
+			wantToCreate: []*placementv1beta1.ClusterResourceBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName1,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+						Annotations: map[string]string{
+							placementv1beta1.TargetClusterUIDAnnotation: "new-cluster-1-uid",
+						},
+						Finalizers: []string{placementv1beta1.SchedulerCRBCleanupFinalizer},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateScheduled,
+						SchedulingPolicySnapshotName: policyName,
+						TargetCluster:                clusterName1,
+						ClusterDecision: placementv1beta1.ClusterDecision{
+							ClusterName: clusterName1,
+							Selected:    true,
+							ClusterScore: &placementv1beta1.ClusterScore{
+								AffinityScore:       &affinityScore1,
+								TopologySpreadScore: &topologySpreadScore1,
 							},
+							Reason: fmt.Sprintf(resourceScheduleSucceededWithScoreMessageFormat, clusterName1, affinityScore1, topologySpreadScore1),
 						},
 					},
-					patch: client.MergeFromWithOptions(&placementv1beta1.ClusterResourceBinding{
-						ObjectMeta: metav1.ObjectMeta{
-							Name: bindingName2,
-						},
-						Spec: placementv1beta1.ResourceBindingSpec{
-							TargetCluster: clusterName2,
-						},
-					}, client.MergeFromWithOptimisticLock{}),
 				},
 			},
+			wantToPatch:  []*bindingWithPatch{},
 			wantToDelete: []*placementv1beta1.ClusterResourceBinding{},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			toCreate, toDelete, toPatch, err := crossReferencePickedClustersAndDeDupBindings(crpName, policy, tc.picked, tc.unscheduled, tc.obsolete)
+			toCreate, toDelete, toPatch, err := crossReferencePickedClustersAndDeDupBindings(crpName, policy, tc.picked, tc.unscheduled, tc.obsolete, sets.New[string]())
 			if err != nil {
 				t.Errorf("crossReferencePickedClustersAndDeDupBindings test `%s`, err = %v, want no error", tc.name, err)
 				return
@@ -2178,6 +2810,66 @@ func TestCreateBindings(t *testing.T) {
 	}
 }
 
+// TestCreateBindingsAlreadyExist tests the createBindings method when bindings with the same names exist.
+func TestCreateBindingsAlreadyExist(t *testing.T) {
+	deleteTime := metav1.Now()
+	newBinding := func(clusterName string) *placementv1beta1.ClusterResourceBinding {
+		return &placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: bindingName,
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:                        placementv1beta1.BindingStateScheduled,
+				SchedulingPolicySnapshotName: policyName,
+				TargetCluster:                clusterName,
+			},
+		}
+	}
+	deletingBinding := newBinding(clusterName)
+	deletingBinding.DeletionTimestamp = &deleteTime
+	deletingBinding.Finalizers = []string{placementv1beta1.SchedulerCRBCleanupFinalizer}
+
+	testCases := []struct {
+		name     string
+		existing *placementv1beta1.ClusterResourceBinding
+		wantErr  error
+	}{
+		{
+			name:     "created by an earlier attempt",
+			existing: newBinding(clusterName),
+		},
+		{
+			name:     "a different binding",
+			existing: newBinding(altClusterName),
+			wantErr:  controller.ErrExpectedBehavior,
+		},
+		{
+			name:     "a deleting binding",
+			existing: deletingBinding,
+			wantErr:  controller.ErrExpectedBehavior,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(tc.existing).
+				Build()
+			// Construct framework manually instead of using NewFramework() to avoid mocking the controller manager.
+			f := &framework{
+				client:         fakeClient,
+				uncachedReader: fakeClient,
+			}
+
+			err := f.createBindings(context.Background(), []*placementv1beta1.ClusterResourceBinding{newBinding(clusterName)})
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("createBindings() = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
 // TestUpdateBindings tests the updateBindings method.
 func TestPatchBindings(t *testing.T) {
 	binding := &placementv1beta1.ClusterResourceBinding{
@@ -2297,7 +2989,9 @@ func TestManipulateBindings(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name: anotherBindingName,
 			Annotations: map[string]string{
-				placementv1beta1.PreviousBindingStateAnnotation: string(placementv1beta1.BindingStateBound),
+				placementv1beta1.PreviousBindingStateAnnotation:        string(placementv1beta1.BindingStateBound),
+				placementv1beta1.UnscheduledReasonAnnotation:           placementv1beta1.UnscheduledReasonPolicyChanged,
+				placementv1beta1.UnscheduledByPolicySnapshotAnnotation: policyName,
 			},
 		},
 		Spec: placementv1beta1.ResourceBindingSpec{
@@ -2317,7 +3011,8 @@ func TestManipulateBindings(t *testing.T) {
 		Build()
 	// Construct framework manually instead of using NewFramework() to avoid mocking the controller manager.
 	f := &framework{
-		client: fakeClient,
+		client:  fakeClient,
+		profile: NewProfile(dummyProfileName),
 	}
 
 	ctx := context.Background()
@@ -2330,7 +3025,7 @@ func TestManipulateBindings(t *testing.T) {
 		},
 	}
 	toDelete := []*placementv1beta1.ClusterResourceBinding{toDeleteBinding}
-	if err := f.manipulateBindings(ctx, policy, toCreate, toDelete, toPatch); err != nil {
+	if err := f.manipulateBindings(ctx, NewCycleState(nil, nil), policy, nil, toCreate, toDelete, toPatch); err != nil {
 		t.Fatalf("manipulateBindings() = %v, want no error", err)
 	}
 
@@ -2425,8 +3120,10 @@ func TestUpdatePolicySnapshotStatusFromBindings(t *testing.T) {
 		notPicked                         ScoredClusters
 		filtered                          []*filteredClusterWithStatus
 		existing                          [][]*placementv1beta1.ClusterResourceBinding
+		decisionCompactionThreshold       int
 		wantObservedCRPGeneration         int64
 		wantDecisions                     []placementv1beta1.ClusterDecision
+		wantSummary                       []placementv1beta1.UnselectedClusterCount
 		wantCondition                     metav1.Condition
 	}{
 		{
@@ -2932,6 +3629,44 @@ func TestUpdatePolicySnapshotStatusFromBindings(t *testing.T) {
 			},
 			wantCondition: newScheduledCondition(policyWithNoStatus, metav1.ConditionTrue, FullyScheduledReason, fmt.Sprintf(fullyScheduledMessage, 2)),
 		},
+		{
+			name:                              "decisions compacted above the threshold",
+			policy:                            policyWithNoStatus,
+			maxUnselectedClusterDecisionCount: defaultMaxUnselectedClusterDecisionCount,
+			notPicked:                         generateNotPickedScoredClusters(3, 0),
+			filtered:                          generatedFilterdClusterWithStatus(2, 10),
+			existing: [][]*placementv1beta1.ClusterResourceBinding{
+				{
+					&placementv1beta1.ClusterResourceBinding{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: bindingName,
+						},
+						Spec: placementv1beta1.ResourceBindingSpec{
+							TargetCluster: clusterName,
+							ClusterDecision: placementv1beta1.ClusterDecision{
+								ClusterName: clusterName,
+								Selected:    true,
+								Reason:      fmt.Sprintf(resourceScheduleSucceededMessageFormat, clusterName),
+							},
+						},
+					},
+				},
+			},
+			decisionCompactionThreshold: 3,
+			wantObservedCRPGeneration:   crpGeneration1,
+			wantDecisions: []placementv1beta1.ClusterDecision{
+				{
+					ClusterName: clusterName,
+					Selected:    true,
+					Reason:      fmt.Sprintf(resourceScheduleSucceededMessageFormat, clusterName),
+				},
+			},
+			wantSummary: []placementv1beta1.UnselectedClusterCount{
+				{Reason: notPickedByScoreSummaryReason, Count: 3},
+				{Reason: dummyPluginName, Count: 2},
+			},
+			wantCondition: newScheduledCondition(policyWithNoStatus, metav1.ConditionTrue, FullyScheduledReason, fmt.Sprintf(fullyScheduledMessage, 1)),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2945,6 +3680,7 @@ func TestUpdatePolicySnapshotStatusFromBindings(t *testing.T) {
 			f := &framework{
 				client:                            fakeClient,
 				maxUnselectedClusterDecisionCount: tc.maxUnselectedClusterDecisionCount,
+				decisionCompactionThreshold:       tc.decisionCompactionThreshold,
 			}
 
 			ctx := context.Background()
@@ -2952,7 +3688,7 @@ func TestUpdatePolicySnapshotStatusFromBindings(t *testing.T) {
 			for _, bindingSet := range tc.existing {
 				numOfClusters += len(bindingSet)
 			}
-			if err := f.updatePolicySnapshotStatusFromBindings(ctx, tc.policy, numOfClusters, tc.notPicked, tc.filtered, tc.existing...); err != nil {
+			if err := f.updatePolicySnapshotStatusFromBindings(ctx, tc.policy, numOfClusters, tc.notPicked, tc.filtered, nil, tc.existing...); err != nil {
 				t.Fatalf("updatePolicySnapshotStatusFromBindings() = %v, want no error", err)
 			}
 
@@ -2965,6 +3701,10 @@ func TestUpdatePolicySnapshotStatusFromBindings(t *testing.T) {
 				t.Errorf("policy snapshot status cluster decisions not equal (-got, +want): %s", diff)
 			}
 
+			if diff := cmp.Diff(updatedPolicy.Status.UnselectedClusterSummary, tc.wantSummary); diff != "" {
+				t.Errorf("policy snapshot status unselected cluster summary not equal (-got, +want): %s", diff)
+			}
+
 			updatedCondition := meta.FindStatusCondition(updatedPolicy.Status.Conditions, string(placementv1beta1.PolicySnapshotScheduled))
 			if diff := cmp.Diff(updatedCondition, &tc.wantCondition, ignoredCondFields); diff != "" {
 				t.Errorf("policy snapshot scheduled condition not equal (-got, +want): %s", diff)
@@ -2977,6 +3717,58 @@ func TestUpdatePolicySnapshotStatusFromBindings(t *testing.T) {
 	}
 }
 
+// TestPatchPolicySnapshotStatus tests the patchPolicySnapshotStatus method.
+func TestPatchPolicySnapshotStatus(t *testing.T) {
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policyName,
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithStatusSubresource(policy).
+		WithScheme(scheme.Scheme).
+		WithObjects(policy).
+		Build()
+	// Construct framework manually instead of using NewFramework() to avoid mocking the controller manager.
+	f := &framework{
+		client: fakeClient,
+	}
+
+	ctx := context.Background()
+	current := &placementv1beta1.ClusterSchedulingPolicySnapshot{}
+	if err := fakeClient.Get(ctx, types.NamespacedName{Name: policyName}, current); err != nil {
+		t.Fatalf("Get policy snapshot, got %v, want no error", err)
+	}
+	stale := current.DeepCopy()
+
+	wantDecisions := []placementv1beta1.ClusterDecision{
+		{
+			ClusterName: clusterName,
+			Selected:    true,
+		},
+	}
+	original := current.DeepCopy()
+	current.Status.ClusterDecisions = wantDecisions
+	if err := f.patchPolicySnapshotStatus(ctx, original, current); err != nil {
+		t.Fatalf("patchPolicySnapshotStatus() = %v, want no error", err)
+	}
+
+	updatedPolicy := &placementv1beta1.ClusterSchedulingPolicySnapshot{}
+	if err := fakeClient.Get(ctx, types.NamespacedName{Name: policyName}, updatedPolicy); err != nil {
+		t.Fatalf("Get policy snapshot, got %v, want no error", err)
+	}
+	if diff := cmp.Diff(updatedPolicy.Status.ClusterDecisions, wantDecisions); diff != "" {
+		t.Errorf("policy snapshot status cluster decisions not equal (-got, +want): %s", diff)
+	}
+
+	// Patching with a stale copy should fail due to the optimistic lock.
+	staleOriginal := stale.DeepCopy()
+	stale.Status.ClusterDecisions = nil
+	if err := f.patchPolicySnapshotStatus(ctx, staleOriginal, stale); err == nil {
+		t.Fatalf("patchPolicySnapshotStatus() with stale copy = nil, want conflict error")
+	}
+}
+
 // TestShouldDownscale tests the shouldDownscale function.
 func TestShouldDownscale(t *testing.T) {
 	testCases := []struct {
@@ -3907,6 +4699,86 @@ func TestNewSchedulingDecisionsFromBindings(t *testing.T) {
 	}
 }
 
+// TestNewCompactedSchedulingDecisionsFromBindings tests the newCompactedSchedulingDecisionsFromBindings function.
+func TestNewCompactedSchedulingDecisionsFromBindings(t *testing.T) {
+	selectedBinding := &placementv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: bindingName,
+		},
+		Spec: placementv1beta1.ResourceBindingSpec{
+			TargetCluster: fmt.Sprintf(clusterNameTemplate, 0),
+			ClusterDecision: placementv1beta1.ClusterDecision{
+				ClusterName: fmt.Sprintf(clusterNameTemplate, 0),
+				Selected:    true,
+			},
+		},
+	}
+	unknownPluginFiltered := &filteredClusterWithStatus{
+		cluster: &clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: clusterName,
+			},
+		},
+		status: NewNonErrorStatus(ClusterUnschedulable, ""),
+	}
+
+	testCases := []struct {
+		name          string
+		notPicked     ScoredClusters
+		filtered      []*filteredClusterWithStatus
+		existing      [][]*placementv1beta1.ClusterResourceBinding
+		wantDecisions []placementv1beta1.ClusterDecision
+		wantSummary   []placementv1beta1.UnselectedClusterCount
+	}{
+		{
+			name:     "selected clusters only",
+			existing: [][]*placementv1beta1.ClusterResourceBinding{{selectedBinding}},
+			wantDecisions: []placementv1beta1.ClusterDecision{
+				selectedBinding.Spec.ClusterDecision,
+			},
+		},
+		{
+			name:      "not picked and filtered clusters are aggregated",
+			notPicked: generateNotPickedScoredClusters(30, 1),
+			filtered:  append(generatedFilterdClusterWithStatus(50, 31), unknownPluginFiltered),
+			existing:  [][]*placementv1beta1.ClusterResourceBinding{{selectedBinding}},
+			wantDecisions: []placementv1beta1.ClusterDecision{
+				selectedBinding.Spec.ClusterDecision,
+			},
+			wantSummary: []placementv1beta1.UnselectedClusterCount{
+				{Reason: filteredOutSummaryReason, Count: 1},
+				{Reason: notPickedByScoreSummaryReason, Count: 30},
+				{Reason: dummyPluginName, Count: 50},
+			},
+		},
+		{
+			name:      "selected clusters are not counted as unselected",
+			notPicked: generateNotPickedScoredClusters(5, 0),
+			filtered:  generatedFilterdClusterWithStatus(5, 0),
+			existing:  [][]*placementv1beta1.ClusterResourceBinding{{selectedBinding}},
+			wantDecisions: []placementv1beta1.ClusterDecision{
+				selectedBinding.Spec.ClusterDecision,
+			},
+			wantSummary: []placementv1beta1.UnselectedClusterCount{
+				{Reason: notPickedByScoreSummaryReason, Count: 4},
+				{Reason: dummyPluginName, Count: 4},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decisions, summary := newCompactedSchedulingDecisionsFromBindings(tc.notPicked, tc.filtered, tc.existing...)
+			if diff := cmp.Diff(tc.wantDecisions, decisions); diff != "" {
+				t.Errorf("newCompactedSchedulingDecisionsFromBindings() decisions diff (-want, +got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantSummary, summary); diff != "" {
+				t.Errorf("newCompactedSchedulingDecisionsFromBindings() summary diff (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
 // TestNewSchedulingDecisionsFrom tests a special case in the newSchedulingDecisionsFrom function,
 // specifically the case where the number of new decisions exceeds the API limit.
 func TestNewSchedulingDecisionsFromOversized(t *testing.T) {
@@ -4186,27 +5058,180 @@ func TestRunPostBatchPlugins(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			profile := NewProfile(dummyProfileName)
-			for _, p := range tc.postBatchPlugins {
-				profile.WithPostBatchPlugin(p)
+			for _, p := range tc.postBatchPlugins {
+				profile.WithPostBatchPlugin(p)
+			}
+			f := &framework{
+				profile: profile,
+			}
+
+			ctx := context.Background()
+			state := NewCycleState([]clusterv1beta1.MemberCluster{}, []*placementv1beta1.ClusterResourceBinding{})
+			state.desiredBatchSize = tc.desiredBatchSize
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: policyName,
+				},
+			}
+			batchLimit, status := f.runPostBatchPlugins(ctx, state, policy)
+			if batchLimit != tc.wantBatchLimit {
+				t.Errorf("runPostBatchPlugins() batch limit = %d, want %d", batchLimit, tc.wantBatchLimit)
+			}
+			if diff := cmp.Diff(status, tc.wantStatus, cmpopts.IgnoreUnexported(Status{}), ignoredStatusFields); diff != "" {
+				t.Errorf("runPostBatchPlugins() status diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+// TestRunPreBindPlugins tests the runPreBindPlugins method.
+func TestRunPreBindPlugins(t *testing.T) {
+	dummyPreBindPluginNameA := fmt.Sprintf(dummyAllPurposePluginNameFormat, 0)
+	dummyPreBindPluginNameB := fmt.Sprintf(dummyAllPurposePluginNameFormat, 1)
+	regionLabel := "region"
+
+	clusters := []clusterv1beta1.MemberCluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   clusterName,
+				Labels: map[string]string{regionLabel: "east"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   altClusterName,
+				Labels: map[string]string{regionLabel: "west"},
+			},
+		},
+	}
+	labelFromCluster := func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster, binding *placementv1beta1.ClusterResourceBinding) (status *Status) {
+		binding.Labels = map[string]string{regionLabel: cluster.Labels[regionLabel]}
+		return nil
+	}
+	newBinding := func(name, targetCluster string) *placementv1beta1.ClusterResourceBinding {
+		return &placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				TargetCluster: targetCluster,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		preBindPlugins []PreBindPlugin
+		targetCluster  string
+		wantLabels     map[string]string
+		wantErr        bool
+	}{
+		{
+			name:          "no plugins",
+			targetCluster: clusterName,
+		},
+		{
+			name: "single plugin, success",
+			preBindPlugins: []PreBindPlugin{
+				&DummyAllPurposePlugin{
+					name:          dummyPreBindPluginNameA,
+					preBindRunner: labelFromCluster,
+				},
+			},
+			targetCluster: altClusterName,
+			wantLabels:    map[string]string{regionLabel: "west"},
+		},
+		{
+			name: "multiple plugins, one skip",
+			preBindPlugins: []PreBindPlugin{
+				&DummyAllPurposePlugin{
+					name: dummyPreBindPluginNameA,
+					preBindRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster, binding *placementv1beta1.ClusterResourceBinding) (status *Status) {
+						return NewNonErrorStatus(Skip, dummyPreBindPluginNameA)
+					},
+				},
+				&DummyAllPurposePlugin{
+					name:          dummyPreBindPluginNameB,
+					preBindRunner: labelFromCluster,
+				},
+			},
+			targetCluster: clusterName,
+			wantLabels:    map[string]string{regionLabel: "east"},
+		},
+		{
+			name: "single plugin, internal error",
+			preBindPlugins: []PreBindPlugin{
+				&DummyAllPurposePlugin{
+					name: dummyPreBindPluginNameA,
+					preBindRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster, binding *placementv1beta1.ClusterResourceBinding) (status *Status) {
+						return FromError(fmt.Errorf("internal error"), dummyPreBindPluginNameA)
+					},
+				},
+			},
+			targetCluster: clusterName,
+			wantErr:       true,
+		},
+		{
+			name: "single plugin, unsupported status",
+			preBindPlugins: []PreBindPlugin{
+				&DummyAllPurposePlugin{
+					name: dummyPreBindPluginNameA,
+					preBindRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster, binding *placementv1beta1.ClusterResourceBinding) (status *Status) {
+						return NewNonErrorStatus(ClusterUnschedulable, dummyPreBindPluginNameA)
+					},
+				},
+			},
+			targetCluster: clusterName,
+			wantErr:       true,
+		},
+		{
+			name: "target cluster not found",
+			preBindPlugins: []PreBindPlugin{
+				&DummyAllPurposePlugin{
+					name:          dummyPreBindPluginNameA,
+					preBindRunner: labelFromCluster,
+				},
+			},
+			targetCluster: anotherClusterName,
+			wantErr:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := NewProfile(dummyProfileName)
+			for _, p := range tc.preBindPlugins {
+				profile.WithPreBindPlugin(p)
 			}
 			f := &framework{
 				profile: profile,
 			}
 
 			ctx := context.Background()
-			state := NewCycleState([]clusterv1beta1.MemberCluster{}, []*placementv1beta1.ClusterResourceBinding{})
-			state.desiredBatchSize = tc.desiredBatchSize
+			state := NewCycleState(clusters, []*placementv1beta1.ClusterResourceBinding{})
 			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: policyName,
 				},
 			}
-			batchLimit, status := f.runPostBatchPlugins(ctx, state, policy)
-			if batchLimit != tc.wantBatchLimit {
-				t.Errorf("runPostBatchPlugins() batch limit = %d, want %d", batchLimit, tc.wantBatchLimit)
+			toCreate := newBinding(bindingName, tc.targetCluster)
+			original := newBinding(altBindingName, tc.targetCluster)
+			toPatch := &bindingWithPatch{
+				updated: original.DeepCopy(),
+				patch:   client.MergeFrom(original),
 			}
-			if diff := cmp.Diff(status, tc.wantStatus, cmpopts.IgnoreUnexported(Status{}), ignoredStatusFields); diff != "" {
-				t.Errorf("runPostBatchPlugins() status diff (-got, +want): %s", diff)
+			err := f.runPreBindPlugins(ctx, state, policy, clusters, []*placementv1beta1.ClusterResourceBinding{toCreate}, []*bindingWithPatch{toPatch})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("runPreBindPlugins() = %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(toCreate.Labels, tc.wantLabels); diff != "" {
+				t.Errorf("binding to create labels diff (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(toPatch.updated.Labels, tc.wantLabels); diff != "" {
+				t.Errorf("binding to patch labels diff (-got, +want): %s", diff)
 			}
 		})
 	}
@@ -4325,6 +5350,12 @@ func TestDownscale(t *testing.T) {
 	topologySpreadScore2 := int32(0)
 	affinityScore2 := int32(20)
 
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: policyName,
+		},
+	}
+
 	testCases := []struct {
 		name                 string
 		scheduled            []*placementv1beta1.ClusterResourceBinding
@@ -5048,7 +6079,7 @@ func TestDownscale(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			scheduled, bound, err := f.downscale(ctx, tc.scheduled, tc.bound, tc.count)
+			scheduled, bound, err := f.downscale(ctx, policy, tc.scheduled, tc.bound, tc.count)
 			if tc.expectedToFail {
 				if err == nil {
 					t.Fatalf("downscaled() = nil, want error")
@@ -5626,11 +6657,11 @@ func TestPickTopNScoredClusters(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			picked, notPicked := pickTopNScoredClusters(tc.scoredClusters, tc.picks)
-			if diff := cmp.Diff(picked, tc.wantPicked); diff != "" {
+			if diff := cmp.Diff(picked, tc.wantPicked, cmp.AllowUnexported(ScoredCluster{})); diff != "" {
 				t.Errorf("pickTopNScoredClusters() picked diff (-got, +want): %s", diff)
 			}
 
-			if diff := cmp.Diff(notPicked, tc.wantNotPicked); diff != "" {
+			if diff := cmp.Diff(notPicked, tc.wantNotPicked, cmp.AllowUnexported(ScoredCluster{})); diff != "" {
 				t.Errorf("pickTopNScoredClusters() not picked diff (-got, +want): %s", diff)
 			}
 		})
@@ -6303,7 +7334,7 @@ func TestRunAllPluginsForPickNPlacementType(t *testing.T) {
 
 			// The method runs in parallel; as a result the order cannot be guaranteed.
 			// Sort the results by cluster name for comparison.
-			if diff := cmp.Diff(scored, tc.wantScoredClusters, cmpopts.SortSlices(lessFuncScoredCluster)); diff != "" {
+			if diff := cmp.Diff(scored, tc.wantScoredClusters, cmpopts.SortSlices(lessFuncScoredCluster), cmp.AllowUnexported(ScoredCluster{})); diff != "" {
 				t.Errorf("runAllPluginsForPickNPlacementType() scored diff (-got, +want): %s", diff)
 			}
 			if diff := cmp.Diff(filtered, tc.wantFiltered, cmpopts.SortSlices(lessFuncFilteredCluster), cmp.AllowUnexported(filteredClusterWithStatus{}, Status{})); diff != "" {
@@ -6567,3 +7598,489 @@ func TestUpdatePolicySnapshotStatusForPickFixedPlacementType(t *testing.T) {
 		})
 	}
 }
+
+// TestRunPermitPlugins tests the runPermitPlugins method.
+func TestRunPermitPlugins(t *testing.T) {
+	dummyPermitPluginNameA := fmt.Sprintf(dummyAllPurposePluginNameFormat, 0)
+	dummyPermitPluginNameB := fmt.Sprintf(dummyAllPurposePluginNameFormat, 1)
+
+	clusters := []clusterv1beta1.MemberCluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: clusterName,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: altClusterName,
+			},
+		},
+	}
+	toCreate := []*placementv1beta1.ClusterResourceBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: bindingName,
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				TargetCluster: clusterName,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: altBindingName,
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				TargetCluster: altClusterName,
+			},
+		},
+	}
+	// newPermitPlugin returns a plugin that returns the given status and timeout for the alt. cluster
+	// and permits all other clusters.
+	newPermitPlugin := func(name string, status *Status, timeout time.Duration) *DummyAllPurposePlugin {
+		return &DummyAllPurposePlugin{
+			name: name,
+			permitRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (*Status, time.Duration) {
+				if cluster.Name != altClusterName {
+					return nil, 0
+				}
+				return status, timeout
+			},
+		}
+	}
+	waitStatus := NewNonErrorStatus(Wait, dummyPermitPluginNameB, "pending approval")
+	denyStatus := NewNonErrorStatus(ClusterUnschedulable, dummyPermitPluginNameA, "denied")
+
+	testCases := []struct {
+		name             string
+		permitPlugins    []PermitPlugin
+		waitingSince     time.Duration
+		wantPermitted    []string
+		wantNotPermitted map[string]*Status
+		wantRequeueAfter time.Duration
+		wantWaiting      bool
+		wantErr          bool
+	}{
+		{
+			name:          "no plugins",
+			wantPermitted: []string{bindingName, altBindingName},
+		},
+		{
+			name: "multiple plugins, success and skip",
+			permitPlugins: []PermitPlugin{
+				newPermitPlugin(dummyPermitPluginNameA, nil, 0),
+				newPermitPlugin(dummyPermitPluginNameB, NewNonErrorStatus(Skip, dummyPermitPluginNameB), 0),
+			},
+			wantPermitted: []string{bindingName, altBindingName},
+		},
+		{
+			name: "multiple plugins, deny overrides wait",
+			permitPlugins: []PermitPlugin{
+				newPermitPlugin(dummyPermitPluginNameA, denyStatus, 0),
+				newPermitPlugin(dummyPermitPluginNameB, waitStatus, time.Minute),
+			},
+			wantPermitted:    []string{bindingName},
+			wantNotPermitted: map[string]*Status{altClusterName: denyStatus},
+		},
+		{
+			name: "single plugin, wait",
+			permitPlugins: []PermitPlugin{
+				newPermitPlugin(dummyPermitPluginNameB, waitStatus, time.Minute),
+			},
+			wantPermitted:    []string{bindingName},
+			wantNotPermitted: map[string]*Status{altClusterName: waitStatus},
+			wantRequeueAfter: permitWaitRequeueInterval,
+			wantWaiting:      true,
+		},
+		{
+			name: "single plugin, wait about to time out",
+			permitPlugins: []PermitPlugin{
+				newPermitPlugin(dummyPermitPluginNameB, waitStatus, time.Minute),
+			},
+			// The scheduler should check again right before the wait times out.
+			waitingSince:     time.Minute - time.Second*5,
+			wantPermitted:    []string{bindingName},
+			wantNotPermitted: map[string]*Status{altClusterName: waitStatus},
+			wantRequeueAfter: time.Second * 5,
+			wantWaiting:      true,
+		},
+		{
+			name: "single plugin, wait timed out",
+			permitPlugins: []PermitPlugin{
+				newPermitPlugin(dummyPermitPluginNameB, waitStatus, time.Minute),
+			},
+			waitingSince:  time.Minute * 2,
+			wantPermitted: []string{bindingName},
+			wantNotPermitted: map[string]*Status{
+				altClusterName: NewNonErrorStatus(ClusterUnschedulable, dummyPermitPluginNameB, fmt.Sprintf(permitWaitTimeoutReasonTemplate, dummyPermitPluginNameB, time.Minute)),
+			},
+			wantWaiting: true,
+		},
+		{
+			name: "single plugin, internal error",
+			permitPlugins: []PermitPlugin{
+				newPermitPlugin(dummyPermitPluginNameA, FromError(fmt.Errorf("internal error"), dummyPermitPluginNameA), 0),
+			},
+			wantErr: true,
+		},
+		{
+			name: "single plugin, unsupported status",
+			permitPlugins: []PermitPlugin{
+				newPermitPlugin(dummyPermitPluginNameA, NewNonErrorStatus(ClusterAlreadySelected, dummyPermitPluginNameA), 0),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := NewProfile(dummyProfileName)
+			for _, p := range tc.permitPlugins {
+				profile.WithPermitPlugin(p)
+			}
+			f := &framework{
+				profile:     profile,
+				permitWaits: newPermitWaits(),
+			}
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: policyName,
+				},
+			}
+			if tc.waitingSince > 0 {
+				f.permitWaits.waitingSince(crpName, policyName, altClusterName, time.Now().Add(-tc.waitingSince))
+			}
+
+			ctx := context.Background()
+			state := NewCycleState(clusters, []*placementv1beta1.ClusterResourceBinding{})
+			permitted, notPermitted, requeueAfter, err := f.runPermitPlugins(ctx, state, crpName, policy, clusters, toCreate)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("runPermitPlugins() = %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			var permittedNames []string
+			for _, binding := range permitted {
+				permittedNames = append(permittedNames, binding.Name)
+			}
+			if diff := cmp.Diff(permittedNames, tc.wantPermitted); diff != "" {
+				t.Errorf("runPermitPlugins() permitted bindings diff (-got, +want): %s", diff)
+			}
+			var notPermittedByName map[string]*Status
+			for _, np := range notPermitted {
+				if notPermittedByName == nil {
+					notPermittedByName = make(map[string]*Status)
+				}
+				notPermittedByName[np.cluster.Name] = np.status
+			}
+			if diff := cmp.Diff(notPermittedByName, tc.wantNotPermitted, cmp.AllowUnexported(Status{})); diff != "" {
+				t.Errorf("runPermitPlugins() not permitted clusters diff (-got, +want): %s", diff)
+			}
+			// Allow some leeway, as the remaining time of a wait shrinks as the test runs.
+			if requeueAfter > tc.wantRequeueAfter || requeueAfter < tc.wantRequeueAfter-time.Second {
+				t.Errorf("runPermitPlugins() requeueAfter = %v, want %v", requeueAfter, tc.wantRequeueAfter)
+			}
+			if _, gotWaiting := f.permitWaits.waits[crpName][altClusterName]; gotWaiting != tc.wantWaiting {
+				t.Errorf("permit wait tracked = %t, want %t", gotWaiting, tc.wantWaiting)
+			}
+		})
+	}
+}
+
+// TestExcludeNotPermittedTargets tests the excludeNotPermittedTargets function.
+func TestExcludeNotPermittedTargets(t *testing.T) {
+	cluster := &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: clusterName}}
+	altCluster := &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: altClusterName}}
+	anotherCluster := &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: anotherClusterName}}
+	waitStatus := NewNonErrorStatus(Wait, dummyPluginName, "pending approval")
+
+	testCases := []struct {
+		name         string
+		valid        []*clusterv1beta1.MemberCluster
+		invalid      []*invalidClusterWithReason
+		notPermitted []*filteredClusterWithStatus
+		wantValid    []*clusterv1beta1.MemberCluster
+		wantInvalid  []*invalidClusterWithReason
+	}{
+		{
+			name:        "all permitted",
+			valid:       []*clusterv1beta1.MemberCluster{cluster, altCluster},
+			invalid:     []*invalidClusterWithReason{{cluster: anotherCluster, reason: "left"}},
+			wantValid:   []*clusterv1beta1.MemberCluster{cluster, altCluster},
+			wantInvalid: []*invalidClusterWithReason{{cluster: anotherCluster, reason: "left"}},
+		},
+		{
+			name:         "some not permitted",
+			valid:        []*clusterv1beta1.MemberCluster{cluster, altCluster},
+			invalid:      []*invalidClusterWithReason{{cluster: anotherCluster, reason: "left"}},
+			notPermitted: []*filteredClusterWithStatus{{cluster: altCluster, status: waitStatus}},
+			wantValid:    []*clusterv1beta1.MemberCluster{cluster},
+			wantInvalid: []*invalidClusterWithReason{
+				{cluster: anotherCluster, reason: "left"},
+				{cluster: altCluster, reason: waitStatus.String()},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotValid, gotInvalid := excludeNotPermittedTargets(tc.valid, tc.invalid, tc.notPermitted)
+			if diff := cmp.Diff(gotValid, tc.wantValid); diff != "" {
+				t.Errorf("excludeNotPermittedTargets() valid diff (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(gotInvalid, tc.wantInvalid, cmp.AllowUnexported(invalidClusterWithReason{})); diff != "" {
+				t.Errorf("excludeNotPermittedTargets() invalid diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+// TestRunReservePlugins tests the runReservePlugins and runUnreservePlugins methods.
+func TestRunReservePlugins(t *testing.T) {
+	dummyReservePluginNameA := fmt.Sprintf(dummyAllPurposePluginNameFormat, 0)
+	dummyReservePluginNameB := fmt.Sprintf(dummyAllPurposePluginNameFormat, 1)
+
+	clusters := []clusterv1beta1.MemberCluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: clusterName,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: altClusterName,
+			},
+		},
+	}
+	newBinding := func(name, targetCluster string) *placementv1beta1.ClusterResourceBinding {
+		return &placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				TargetCluster: targetCluster,
+			},
+		}
+	}
+
+	// calls records the plugin calls in the form of [plugin name]/[extension point]/[cluster name].
+	var calls []string
+	newReservePlugin := func(name string, reserveStatus *Status) *DummyAllPurposePlugin {
+		return &DummyAllPurposePlugin{
+			name: name,
+			reserveRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status) {
+				calls = append(calls, fmt.Sprintf("%s/Reserve/%s", name, cluster.Name))
+				return reserveStatus
+			},
+			unreserveRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) {
+				calls = append(calls, fmt.Sprintf("%s/Unreserve/%s", name, cluster.Name))
+			},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		reservePlugins []ReservePlugin
+		patchTarget    string
+		wantReserved   []string
+		wantCalls      []string
+		wantErr        bool
+	}{
+		{
+			name:        "no plugins",
+			patchTarget: altClusterName,
+		},
+		{
+			name: "multiple plugins, success and skip",
+			reservePlugins: []ReservePlugin{
+				newReservePlugin(dummyReservePluginNameA, nil),
+				newReservePlugin(dummyReservePluginNameB, NewNonErrorStatus(Skip, dummyReservePluginNameB)),
+			},
+			patchTarget:  altClusterName,
+			wantReserved: []string{clusterName, altClusterName},
+			wantCalls: []string{
+				dummyReservePluginNameA + "/Reserve/" + clusterName,
+				dummyReservePluginNameB + "/Reserve/" + clusterName,
+				dummyReservePluginNameA + "/Reserve/" + altClusterName,
+				dummyReservePluginNameB + "/Reserve/" + altClusterName,
+				dummyReservePluginNameB + "/Unreserve/" + clusterName,
+				dummyReservePluginNameA + "/Unreserve/" + clusterName,
+				dummyReservePluginNameB + "/Unreserve/" + altClusterName,
+				dummyReservePluginNameA + "/Unreserve/" + altClusterName,
+			},
+		},
+		{
+			name: "multiple plugins, internal error",
+			reservePlugins: []ReservePlugin{
+				newReservePlugin(dummyReservePluginNameA, FromError(fmt.Errorf("internal error"), dummyReservePluginNameA)),
+				newReservePlugin(dummyReservePluginNameB, nil),
+			},
+			patchTarget:  altClusterName,
+			wantReserved: []string{clusterName},
+			wantCalls: []string{
+				dummyReservePluginNameA + "/Reserve/" + clusterName,
+				dummyReservePluginNameB + "/Unreserve/" + clusterName,
+				dummyReservePluginNameA + "/Unreserve/" + clusterName,
+			},
+			wantErr: true,
+		},
+		{
+			name: "single plugin, unsupported status",
+			reservePlugins: []ReservePlugin{
+				newReservePlugin(dummyReservePluginNameA, NewNonErrorStatus(ClusterUnschedulable, dummyReservePluginNameA)),
+			},
+			patchTarget:  altClusterName,
+			wantReserved: []string{clusterName},
+			wantCalls: []string{
+				dummyReservePluginNameA + "/Reserve/" + clusterName,
+				dummyReservePluginNameA + "/Unreserve/" + clusterName,
+			},
+			wantErr: true,
+		},
+		{
+			name: "target cluster not found",
+			reservePlugins: []ReservePlugin{
+				newReservePlugin(dummyReservePluginNameA, nil),
+			},
+			patchTarget:  anotherClusterName,
+			wantReserved: []string{clusterName},
+			wantCalls: []string{
+				dummyReservePluginNameA + "/Reserve/" + clusterName,
+				dummyReservePluginNameA + "/Unreserve/" + clusterName,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls = nil
+			profile := NewProfile(dummyProfileName)
+			for _, p := range tc.reservePlugins {
+				profile.WithReservePlugin(p)
+			}
+			f := &framework{
+				profile: profile,
+			}
+
+			ctx := context.Background()
+			state := NewCycleState(clusters, []*placementv1beta1.ClusterResourceBinding{})
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: policyName,
+				},
+			}
+			toCreate := newBinding(bindingName, clusterName)
+			original := newBinding(altBindingName, tc.patchTarget)
+			toPatch := &bindingWithPatch{
+				updated: original.DeepCopy(),
+				patch:   client.MergeFrom(original),
+			}
+			reserved, err := f.runReservePlugins(ctx, state, policy, clusters, []*placementv1beta1.ClusterResourceBinding{toCreate}, []*bindingWithPatch{toPatch})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("runReservePlugins() = %v, want error %t", err, tc.wantErr)
+			}
+			var reservedNames []string
+			for _, cluster := range reserved {
+				reservedNames = append(reservedNames, cluster.Name)
+			}
+			if diff := cmp.Diff(reservedNames, tc.wantReserved); diff != "" {
+				t.Errorf("runReservePlugins() reserved clusters diff (-got, +want): %s", diff)
+			}
+
+			f.runUnreservePlugins(ctx, state, policy, reserved)
+			if diff := cmp.Diff(calls, tc.wantCalls); diff != "" {
+				t.Errorf("plugin calls diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+// TestManipulateBindingsUnreserve tests that the manipulateBindings method rolls back the reservations
+// when it fails to manipulate bindings.
+func TestManipulateBindingsUnreserve(t *testing.T) {
+	clusters := []clusterv1beta1.MemberCluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: clusterName,
+			},
+		},
+	}
+	newBinding := func(targetCluster string) *placementv1beta1.ClusterResourceBinding {
+		return &placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: bindingName,
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:                        placementv1beta1.BindingStateScheduled,
+				SchedulingPolicySnapshotName: policyName,
+				TargetCluster:                targetCluster,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		existing      []client.Object
+		wantErr       bool
+		wantUnreserve bool
+	}{
+		{
+			name: "bindings manipulated",
+		},
+		{
+			name:          "failed to create bindings",
+			existing:      []client.Object{newBinding(altClusterName)},
+			wantErr:       true,
+			wantUnreserve: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reserved, unreserved []string
+			plugin := &DummyAllPurposePlugin{
+				name: dummyPluginName,
+				reserveRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status) {
+					reserved = append(reserved, cluster.Name)
+					return nil
+				},
+				unreserveRunner: func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) {
+					unreserved = append(unreserved, cluster.Name)
+				},
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(tc.existing...).
+				Build()
+			// Construct framework manually instead of using NewFramework() to avoid mocking the controller manager.
+			f := &framework{
+				client:         fakeClient,
+				uncachedReader: fakeClient,
+				profile:        NewProfile(dummyProfileName).WithReservePlugin(plugin),
+			}
+
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: policyName,
+				},
+			}
+			toCreate := []*placementv1beta1.ClusterResourceBinding{newBinding(clusterName)}
+			err := f.manipulateBindings(context.Background(), NewCycleState(clusters, nil), policy, clusters, toCreate, nil, nil)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("manipulateBindings() = %v, want error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(reserved, []string{clusterName}); diff != "" {
+				t.Errorf("reserved clusters diff (-got, +want): %s", diff)
+			}
+			var wantUnreserved []string
+			if tc.wantUnreserve {
+				wantUnreserved = []string{clusterName}
+			}
+			if diff := cmp.Diff(unreserved, wantUnreserved); diff != "" {
+				t.Errorf("unreserved clusters diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
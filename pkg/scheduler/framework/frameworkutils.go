@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,8 +29,9 @@ import (
 //   - scheduled bindings, i.e., bindings that have been associated with a normally operating cluster,
 //     but have not yet been cleared for processing by the dispatcher; and
 //   - dangling bindings, i.e., bindings that are associated with a cluster that is no longer in
-//     a normally operating state (the cluster has left the fleet, or is in the state of leaving),
-//     yet has not been marked as unscheduled by the scheduler; and
+//     a normally operating state (the cluster has left the fleet, is in the state of leaving, or
+//     has been deleted and re-registered with the same name), yet has not been marked as
+//     unscheduled by the scheduler; and
 //   - unscheduled bindings, i.e., bindings that are marked to be removed by the scheduler; and
 //   - obsolete bindings, i.e., bindings that are no longer associated with the latest scheduling
 //     policy; and
@@ -42,41 +45,50 @@ func classifyBindings(policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
 	dangling = make([]*placementv1beta1.ClusterResourceBinding, 0, len(bindings))
 	deleting = make([]*placementv1beta1.ClusterResourceBinding, 0, len(bindings))
 
-	// Build a map for clusters for quick lookup.
-	clusterMap := make(map[string]clusterv1beta1.MemberCluster)
-	for _, cluster := range clusters {
-		clusterMap[cluster.Name] = cluster
+	// Index the clusters by name for quick lookup.
+	//
+	// The index keeps pointers to the clusters rather than copies, as copying member cluster objects
+	// is costly in fleets with thousands of clusters.
+	clusterMap := make(map[string]*clusterv1beta1.MemberCluster, len(clusters))
+	for idx := range clusters {
+		clusterMap[clusters[idx].Name] = &clusters[idx]
 	}
 
 	for idx := range bindings {
-		binding := bindings[idx]
+		// Refer to the bindings in place; the listed bindings are not used after classification, so
+		// there is no need to copy them.
+		binding := &bindings[idx]
 		targetCluster, isTargetClusterPresent := clusterMap[binding.Spec.TargetCluster]
 
 		switch {
 		case !binding.DeletionTimestamp.IsZero():
 			// we need remove scheduler CRB cleanup finalizer from deleting ClusterResourceBindings.
-			deleting = append(deleting, &binding)
+			deleting = append(deleting, binding)
 		case binding.Spec.State == placementv1beta1.BindingStateUnscheduled:
 			// we need to remember those bindings so that we will not create another one.
-			unscheduled = append(unscheduled, &binding)
-		case !isTargetClusterPresent || !targetCluster.GetDeletionTimestamp().IsZero():
+			unscheduled = append(unscheduled, binding)
+		case !isTargetClusterPresent || !targetCluster.GetDeletionTimestamp().IsZero() || isTargetClusterRecreated(binding, targetCluster):
 			// Check if the binding is now dangling, i.e., it is associated with a cluster that
 			// is no longer in normal operations, but is still of a scheduled or bound state.
 			//
+			// A binding whose target cluster has been deleted and re-registered with the same name
+			// is also considered dangling, as the resources should be placed to the new cluster
+			// afresh via a new binding.
+			//
 			// Note that this check is solely for the purpose of detecting a situation where
 			// bindings are stranded on a leaving/left cluster; it does not perform any binding
 			// association eligibility check for the cluster.
-			dangling = append(dangling, &binding)
+			dangling = append(dangling, binding)
 		case binding.Spec.SchedulingPolicySnapshotName != policy.Name:
 			// The binding is in the scheduled or bound state, but is no longer associated
 			// with the latest scheduling policy snapshot.
-			obsolete = append(obsolete, &binding)
+			obsolete = append(obsolete, binding)
 		case binding.Spec.State == placementv1beta1.BindingStateScheduled:
 			// Check if the binding is of the scheduled state.
-			scheduled = append(scheduled, &binding)
+			scheduled = append(scheduled, binding)
 		case binding.Spec.State == placementv1beta1.BindingStateBound:
 			// Check if the binding is of the bound state.
-			bound = append(bound, &binding)
+			bound = append(bound, binding)
 			// At this stage all states are already accounted for, so there is no need for a default
 			// clause.
 		}
@@ -85,6 +97,123 @@ func classifyBindings(policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
 	return bound, scheduled, obsolete, unscheduled, dangling, deleting
 }
 
+// dedupBindingsByTargetCluster finds the bindings that target the same cluster as another scheduled,
+// bound, or obsolete binding, which can be left behind by a scheduling cycle that failed midway.
+//
+// One binding is kept for each cluster deterministically, preferring bound bindings over scheduled
+// ones, then bindings associated with the latest scheduling policy snapshot over obsolete ones, and
+// then the binding with the smallest name; the others are returned as duplicated. The order of the
+// kept bindings is preserved.
+func dedupBindingsByTargetCluster(policy *placementv1beta1.ClusterSchedulingPolicySnapshot, bound, scheduled, obsolete []*placementv1beta1.ClusterResourceBinding) (
+	dedupedBound, dedupedScheduled, dedupedObsolete, duplicated []*placementv1beta1.ClusterResourceBinding,
+) {
+	rank := func(binding *placementv1beta1.ClusterResourceBinding) int {
+		r := 0
+		if binding.Spec.State != placementv1beta1.BindingStateBound {
+			r += 2
+		}
+		if binding.Spec.SchedulingPolicySnapshotName != policy.Name {
+			r++
+		}
+		return r
+	}
+
+	kept := make(map[string]*placementv1beta1.ClusterResourceBinding, len(bound)+len(scheduled)+len(obsolete))
+	for _, bindings := range [][]*placementv1beta1.ClusterResourceBinding{bound, scheduled, obsolete} {
+		for _, binding := range bindings {
+			current, ok := kept[binding.Spec.TargetCluster]
+			if !ok || rank(binding) < rank(current) || (rank(binding) == rank(current) && binding.Name < current.Name) {
+				kept[binding.Spec.TargetCluster] = binding
+			}
+		}
+	}
+
+	dedup := func(bindings []*placementv1beta1.ClusterResourceBinding) []*placementv1beta1.ClusterResourceBinding {
+		deduped := make([]*placementv1beta1.ClusterResourceBinding, 0, len(bindings))
+		for _, binding := range bindings {
+			if kept[binding.Spec.TargetCluster] != binding {
+				duplicated = append(duplicated, binding)
+				continue
+			}
+			deduped = append(deduped, binding)
+		}
+		return deduped
+	}
+	return dedup(bound), dedup(scheduled), dedup(obsolete), duplicated
+}
+
+// isTargetClusterRecreated returns if the cluster with the binding's target cluster name is not the
+// one that the binding is created for, i.e., the cluster has been deleted and then re-registered
+// with the same name.
+//
+// Bindings that do not track the UID of their target clusters (e.g., bindings created by an older
+// version of the scheduler) are always considered to be associated with the right cluster.
+func isTargetClusterRecreated(binding *placementv1beta1.ClusterResourceBinding, cluster *clusterv1beta1.MemberCluster) bool {
+	uid, ok := binding.GetAnnotations()[placementv1beta1.TargetClusterUIDAnnotation]
+	return ok && uid != string(cluster.UID)
+}
+
+// newBindingName returns the name of a new binding for a cluster picked under a scheduling policy.
+//
+// The name is derived from the policy snapshot and the cluster (including its UID), so that the
+// bindings to create in a scheduling cycle have the same names when the cycle is retried after
+// failing midway; creating a binding that has been created in an earlier attempt is then a no-op
+// rather than a duplicate decision.
+//
+// A derived name may still be taken by a binding the scheduler cannot reuse, e.g., a binding of a
+// deleted CRP with the same name that is still being cleaned up, as policy snapshot names restart
+// from the same index when a CRP is recreated. In this case the scheduler repairs the collision by
+// trying the next candidate name, which is derived with an attempt counter appended to the seed;
+// the name picked is added to the set of taken names, so that no two bindings to create share a name.
+func newBindingName(crpName string, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster, taken sets.Set[string]) (string, error) {
+	seed := fmt.Sprintf("%s/%s", policy.Name, cluster.UID)
+	for attempt := 0; attempt < maxBindingNameAttempts; attempt++ {
+		attemptSeed := seed
+		if attempt > 0 {
+			attemptSeed = fmt.Sprintf("%s/%d", seed, attempt)
+		}
+		name, err := uniquename.NewDeterministicClusterResourceBindingName(crpName, cluster.Name, attemptSeed)
+		if err != nil {
+			return "", err
+		}
+		if !taken.Has(name) {
+			taken.Insert(name)
+			return name, nil
+		}
+		klog.V(2).InfoS("Binding name is taken by an existing binding; trying the next candidate", "clusterSchedulingPolicySnapshot", klog.KObj(policy), "memberCluster", klog.KObj(cluster), "clusterResourceBinding", name)
+	}
+	return "", fmt.Errorf("failed to find an available binding name for cluster %s after %d attempts", cluster.Name, maxBindingNameAttempts)
+}
+
+// setTargetClusterUID records the UID of the target cluster on the binding, if the UID is known.
+func setTargetClusterUID(binding *placementv1beta1.ClusterResourceBinding, cluster *clusterv1beta1.MemberCluster) {
+	if len(cluster.UID) == 0 {
+		return
+	}
+	annotations := binding.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[placementv1beta1.TargetClusterUIDAnnotation] = string(cluster.UID)
+	binding.SetAnnotations(annotations)
+}
+
+// setExclusivityGroupLabel labels the binding with the exclusivity group (if any) specified in the
+// scheduling policy.
+func setExclusivityGroupLabel(binding *placementv1beta1.ClusterResourceBinding, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) {
+	labels := binding.GetLabels()
+	if policy.Spec.Policy == nil || len(policy.Spec.Policy.ExclusivityGroup) == 0 {
+		// Deleting from a nil map is a no-op.
+		delete(labels, placementv1beta1.ExclusivityGroupLabel)
+		return
+	}
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[placementv1beta1.ExclusivityGroupLabel] = policy.Spec.Policy.ExclusivityGroup
+	binding.SetLabels(labels)
+}
+
 // bindingWithPatch is a helper struct that includes a binding that needs to be patched and the
 // patch itself.
 type bindingWithPatch struct {
@@ -111,6 +240,7 @@ func crossReferencePickedClustersAndDeDupBindings(
 	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
 	picked ScoredClusters,
 	unscheduled, obsolete []*placementv1beta1.ClusterResourceBinding,
+	takenNames sets.Set[string],
 ) (toCreate, toDelete []*placementv1beta1.ClusterResourceBinding, toPatch []*bindingWithPatch, err error) {
 	// Pre-allocate with a reasonable capacity.
 	toCreate = make([]*placementv1beta1.ClusterResourceBinding, 0, len(picked))
@@ -146,6 +276,11 @@ func crossReferencePickedClustersAndDeDupBindings(
 
 	for _, binding := range unscheduled {
 		scored, ok := pickedMap[binding.Spec.TargetCluster]
+		if ok && isTargetClusterRecreated(binding, scored.Cluster) {
+			// The picked cluster has been re-registered with the same name after the binding is
+			// created; do not revive the binding, and let a new binding be created instead.
+			continue
+		}
 		checked[binding.Spec.TargetCluster] = true
 		if !ok {
 			// this cluster is not picked up again, so we can skip it
@@ -160,8 +295,10 @@ func crossReferencePickedClustersAndDeDupBindings(
 		currentAnnotation := binding.GetAnnotations()
 		if previousState, exist := currentAnnotation[placementv1beta1.PreviousBindingStateAnnotation]; exist {
 			desiredState = placementv1beta1.BindingState(previousState)
-			// remove the annotation just to avoid confusion.
+			// remove the annotations just to avoid confusion.
 			delete(currentAnnotation, placementv1beta1.PreviousBindingStateAnnotation)
+			delete(currentAnnotation, placementv1beta1.UnscheduledReasonAnnotation)
+			delete(currentAnnotation, placementv1beta1.UnscheduledByPolicySnapshotAnnotation)
 			binding.SetAnnotations(currentAnnotation)
 		} else {
 			return nil, nil, nil, controller.NewUnexpectedBehaviorError(fmt.Errorf("failed to find the previous state of an unscheduled binding: %+v", binding))
@@ -172,7 +309,7 @@ func crossReferencePickedClustersAndDeDupBindings(
 	for _, scored := range picked {
 		if _, ok := checked[scored.Cluster.Name]; !ok {
 			// The cluster is newly picked in the current run; it does not have an associated binding in presence.
-			name, err := uniquename.NewClusterResourceBindingName(crpName, scored.Cluster.Name)
+			name, err := newBindingName(crpName, policy, scored.Cluster, takenNames)
 			if err != nil {
 				// Cannot get a unique name for the binding; normally this should never happen.
 				return nil, nil, nil, controller.NewUnexpectedBehaviorError(fmt.Errorf("failed to cross reference picked clusters and existing bindings: %w", err))
@@ -204,6 +341,8 @@ func crossReferencePickedClustersAndDeDupBindings(
 					},
 				},
 			}
+			setTargetClusterUID(binding, scored.Cluster)
+			setExclusivityGroupLabel(binding, policy)
 
 			toCreate = append(toCreate, binding)
 		}
@@ -216,6 +355,11 @@ func patchBindingFromScoredCluster(binding *placementv1beta1.ClusterResourceBind
 	scored *ScoredCluster, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) *bindingWithPatch {
 	// Update the binding so that it is associated with the latest score.
 	updated := binding.DeepCopy()
+	// Backfill the UID of the target cluster, in case the binding is created before the
+	// scheduler starts to track cluster UIDs.
+	setTargetClusterUID(updated, scored.Cluster)
+	// Refresh the exclusivity group, as it might have changed with the scheduling policy.
+	setExclusivityGroupLabel(updated, policy)
 	affinityScore := int32(scored.Score.AffinityScore)
 	topologySpreadScore := int32(scored.Score.TopologySpreadScore)
 	// Update the binding so that it is associated with the latest scheduling policy.
@@ -240,9 +384,15 @@ func patchBindingFromScoredCluster(binding *placementv1beta1.ClusterResourceBind
 }
 
 func patchBindingFromFixedCluster(binding *placementv1beta1.ClusterResourceBinding, desiredState placementv1beta1.BindingState,
-	clusterName string, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) *bindingWithPatch {
+	cluster *clusterv1beta1.MemberCluster, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) *bindingWithPatch {
+	clusterName := cluster.Name
 	// Update the binding so that it is associated with the latest score.
 	updated := binding.DeepCopy()
+	// Backfill the UID of the target cluster, in case the binding is created before the
+	// scheduler starts to track cluster UIDs.
+	setTargetClusterUID(updated, cluster)
+	// Refresh the exclusivity group, as it might have changed with the scheduling policy.
+	setExclusivityGroupLabel(updated, policy)
 	// Update the binding so that it is associated with the latest scheduling policy.
 	updated.Spec.State = desiredState
 	updated.Spec.SchedulingPolicySnapshotName = policy.Name
@@ -345,6 +495,54 @@ func newSchedulingDecisionsFromBindings(
 	return newDecisions
 }
 
+// newCompactedSchedulingDecisionsFromBindings returns a list of scheduling decisions for the selected
+// clusters only, based on the newly manipulated list of bindings, and a summary of the unselected clusters,
+// which aggregates the clusters that are scored but not picked, and the clusters that are filtered out
+// (by the plugin that filters them out), into per-reason counts.
+func newCompactedSchedulingDecisionsFromBindings(
+	notPicked ScoredClusters,
+	filtered []*filteredClusterWithStatus,
+	existing ...[]*placementv1beta1.ClusterResourceBinding,
+) ([]placementv1beta1.ClusterDecision, []placementv1beta1.UnselectedClusterCount) {
+	newDecisions := newSchedulingDecisionsFromBindings(0, nil, nil, existing...)
+
+	seenClusters := make(map[string]bool)
+	for _, bindingSet := range existing {
+		for _, binding := range bindingSet {
+			seenClusters[binding.Spec.TargetCluster] = true
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, sc := range notPicked {
+		if !seenClusters[sc.Cluster.Name] {
+			counts[notPickedByScoreSummaryReason]++
+		}
+	}
+	for _, clusterWithStatus := range filtered {
+		if seenClusters[clusterWithStatus.cluster.Name] {
+			continue
+		}
+		reason := clusterWithStatus.status.SourcePlugin()
+		if reason == "" {
+			reason = filteredOutSummaryReason
+		}
+		counts[reason]++
+	}
+	if len(counts) == 0 {
+		return newDecisions, nil
+	}
+
+	summary := make([]placementv1beta1.UnselectedClusterCount, 0, len(counts))
+	for reason, count := range counts {
+		summary = append(summary, placementv1beta1.UnselectedClusterCount{Reason: reason, Count: count})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].Reason < summary[j].Reason
+	})
+	return newDecisions, summary
+}
+
 // newSchedulingCondition returns a new scheduling condition.
 func newScheduledCondition(policy *placementv1beta1.ClusterSchedulingPolicySnapshot, status metav1.ConditionStatus, reason, message string) metav1.Condition {
 	return metav1.Condition{
@@ -358,7 +556,10 @@ func newScheduledCondition(policy *placementv1beta1.ClusterSchedulingPolicySnaps
 
 // newScheduledConditionFromBindings prepares a scheduling condition by comparing the desired
 // number of cluster and the count of existing bindings.
-func newScheduledConditionFromBindings(policy *placementv1beta1.ClusterSchedulingPolicySnapshot, numOfClusters int, existing ...[]*placementv1beta1.ClusterResourceBinding) metav1.Condition {
+//
+// Remediations suggested at the PostFilter stage, if any, are appended to the message when the
+// desired number has not been achieved.
+func newScheduledConditionFromBindings(policy *placementv1beta1.ClusterSchedulingPolicySnapshot, numOfClusters int, remediations []string, existing ...[]*placementv1beta1.ClusterResourceBinding) metav1.Condition {
 	count := 0
 	for _, bindingSet := range existing {
 		count += len(bindingSet)
@@ -366,7 +567,11 @@ func newScheduledConditionFromBindings(policy *placementv1beta1.ClusterSchedulin
 
 	if count < numOfClusters {
 		// The current count of scheduled + bound bindings is less than the desired number.
-		return newScheduledCondition(policy, metav1.ConditionFalse, NotFullyScheduledReason, fmt.Sprintf(notFullyScheduledMessage, count))
+		message := fmt.Sprintf(notFullyScheduledMessage, count)
+		if len(remediations) > 0 {
+			message = fmt.Sprintf(remediationsMessageFormat, message, strings.Join(remediations, "; "))
+		}
+		return newScheduledCondition(policy, metav1.ConditionFalse, NotFullyScheduledReason, message)
 	}
 	// The desired number has been achieved.
 	return newScheduledCondition(policy, metav1.ConditionTrue, FullyScheduledReason, fmt.Sprintf(fullyScheduledMessage, count))
@@ -599,6 +804,7 @@ func crossReferenceValidTargetsWithBindings(
 	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
 	valid []*clusterv1beta1.MemberCluster,
 	bound, scheduled, unscheduled, obsolete []*placementv1beta1.ClusterResourceBinding,
+	takenNames sets.Set[string],
 ) (
 	toCreate []*placementv1beta1.ClusterResourceBinding,
 	toDelete []*placementv1beta1.ClusterResourceBinding,
@@ -648,9 +854,9 @@ func crossReferenceValidTargetsWithBindings(
 			// The cluster already has a binding associated, but it is selected in a previous
 			// scheduling run; update the binding to refer to the latest scheduling policy
 			// snapshot.
-			toPatch = append(toPatch, patchBindingFromFixedCluster(obsoleteBinding, obsoleteBinding.Spec.State, cluster.Name, policy))
+			toPatch = append(toPatch, patchBindingFromFixedCluster(obsoleteBinding, obsoleteBinding.Spec.State, cluster, policy))
 
-		case foundInUnscheduled:
+		case foundInUnscheduled && !isTargetClusterRecreated(unscheduledBinding, cluster):
 			// The binding's target cluster is picked again in the current run; yet the binding
 			// is originally de-selected by the previous scheduling round.
 			// Add the binding to the toPatch list so that we won't create more and more bindings.
@@ -660,19 +866,21 @@ func crossReferenceValidTargetsWithBindings(
 			currentAnnotation := unscheduledBinding.GetAnnotations()
 			if previousState, exist := currentAnnotation[placementv1beta1.PreviousBindingStateAnnotation]; exist {
 				desiredState = placementv1beta1.BindingState(previousState)
-				// remove the annotation just to avoid confusion.
+				// remove the annotations just to avoid confusion.
 				delete(currentAnnotation, placementv1beta1.PreviousBindingStateAnnotation)
+				delete(currentAnnotation, placementv1beta1.UnscheduledReasonAnnotation)
+				delete(currentAnnotation, placementv1beta1.UnscheduledByPolicySnapshotAnnotation)
 				unscheduledBinding.SetAnnotations(currentAnnotation)
 			} else {
 				return nil, nil, nil, controller.NewUnexpectedBehaviorError(fmt.Errorf("failed to find the previous state of an unscheduled binding: %+v", unscheduledBinding))
 			}
-			toPatch = append(toPatch, patchBindingFromFixedCluster(unscheduledBinding, desiredState, cluster.Name, policy))
+			toPatch = append(toPatch, patchBindingFromFixedCluster(unscheduledBinding, desiredState, cluster, policy))
 
 		default:
 			// The cluster does not have an associated binding yet; create one.
 
 			// Generate a unique name.
-			name, err := uniquename.NewClusterResourceBindingName(crpName, cluster.Name)
+			name, err := newBindingName(crpName, policy, cluster, takenNames)
 			if err != nil {
 				// Cannot get a unique name for the binding; normally this should never happen.
 				return nil, nil, nil, controller.NewUnexpectedBehaviorError(fmt.Errorf("failed to cross reference picked clusters and existing bindings: %w", err))
@@ -700,6 +908,8 @@ func crossReferenceValidTargetsWithBindings(
 					},
 				},
 			}
+			setTargetClusterUID(newBinding, cluster)
+			setExclusivityGroupLabel(newBinding, policy)
 			toCreate = append(toCreate, newBinding)
 		}
 	}
@@ -7,6 +7,7 @@ package framework
 
 import (
 	"context"
+	"time"
 
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
@@ -64,6 +65,59 @@ type FilterPlugin interface {
 	Filter(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status)
 }
 
+// FilteredCluster is a cluster that has been filtered out at the Filter stage, along with the
+// status returned by the plugin that has filtered it out.
+type FilteredCluster struct {
+	// Cluster is the cluster that has been filtered out.
+	Cluster *clusterv1beta1.MemberCluster
+	// Status is the status returned by the Filter plugin; its source plugin and reasons tell
+	// why the cluster has been filtered out.
+	Status *Status
+	// Victims is the bindings of other placements which, once removed, would make the cluster
+	// admit the placement, as reported by the Filter plugins that implement the PreemptionExtension
+	// interface; it is empty if removing bindings would not help.
+	Victims []*placementv1beta1.ClusterResourceBinding
+}
+
+// PostFilterPlugin is the interface which all plugins that would like to run at the PostFilter
+// extension point should implement.
+type PostFilterPlugin interface {
+	Plugin
+
+	// PostFilter runs after the Filter stage if fewer clusters than the placement wants have passed
+	// it; a plugin may inspect the clusters that have been filtered out at this extension point, and
+	// either suggest actionable remediations, e.g., tolerations to add to the placement, which the
+	// scheduler records in the status of the scheduling policy snapshot, or make room for the placement,
+	// e.g., by preempting placements of lower priorities.
+	//
+	// The want parameter is the number of clusters the placement still needs in the current scheduling
+	// cycle. The scheduler marks the victims returned as unscheduled, and retries the placement.
+	//
+	// A plugin which registers at this extension point must return one of the follows:
+	// * A Success status, with the remediations as its reasons, and/or the bindings to remove; or
+	// * A Skip status, if the plugin has nothing to do with the placement; or
+	// * A ClusterUnschedulable status, if the plugin cannot make room for the placement; or
+	// * An InternalError status, if an expected error has occurred
+	PostFilter(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, filtered []*FilteredCluster, want int) (victims []*placementv1beta1.ClusterResourceBinding, status *Status)
+}
+
+// PreemptionExtension is the interface which Filter plugins that filter out clusters for being
+// occupied by other placements, e.g., the exclusivity plugin, can implement, so that PostFilter
+// plugins are able to preempt the occupying placements.
+type PreemptionExtension interface {
+	FilterPlugin
+
+	// Victims runs for a cluster that the plugin has filtered out at the Filter stage; it returns
+	// the bindings of other placements which, once removed, would make the plugin admit the placement
+	// to the cluster.
+	//
+	// A plugin which implements this extension must return one of the follows:
+	// * A Success status, with the bindings to remove; or
+	// * A ClusterUnschedulable status, if removing bindings would not help; or
+	// * An InternalError status, if an expected error has occurred
+	Victims(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (victims []*placementv1beta1.ClusterResourceBinding, status *Status)
+}
+
 // PreScorePlugin is the interface which all plugins that would like to run at the PreScore
 // extension point should implement.
 type PreScorePlugin interface {
@@ -92,3 +146,108 @@ type ScorePlugin interface {
 	// * An InternalError status, if an expected error has occurred
 	Score(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (score *ClusterScore, status *Status)
 }
+
+// PreBindPlugin is the interface which all plugins that would like to run at the PreBind
+// extension point should implement.
+type PreBindPlugin interface {
+	Plugin
+
+	// PreBind runs after the scheduler has picked a cluster, right before it creates or refreshes
+	// the binding for the cluster; a plugin may decorate the binding at this extension point,
+	// e.g., add labels or annotations, but must not change its spec.
+	//
+	// A plugin which registers at this extension point must return one of the follows:
+	// * A Success status, if the plugin has decorated the binding; or
+	// * A Skip status, if the plugin has nothing to do with the binding; or
+	// * An InternalError status, if an expected error has occurred
+	PreBind(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster, binding *placementv1beta1.ClusterResourceBinding) (status *Status)
+}
+
+// PermitPlugin is the interface which all plugins that would like to run at the Permit
+// extension point should implement.
+type PermitPlugin interface {
+	Plugin
+
+	// Permit runs after the scheduler has picked a cluster, right before it creates a new binding
+	// for the cluster; a plugin may allow, deny, or hold the creation at this extension point, e.g.,
+	// until an external change management system has approved it. Existing bindings that are
+	// refreshed by the scheduler are not subject to this extension point.
+	//
+	// A plugin which registers at this extension point must return one of the follows:
+	// * A Success status, if the binding can be created; or
+	// * A ClusterUnschedulable status, if the binding must not be created in this scheduling cycle; or
+	// * A Wait status, with the longest time the scheduler should wait for, if the binding cannot be
+	//   created yet; the scheduler requeues the placement to check again later, and denies the
+	//   creation once the wait times out; or
+	// * A Skip status, if the plugin has nothing to do with the binding; or
+	// * An InternalError status, if an expected error has occurred
+	Permit(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status, timeout time.Duration)
+}
+
+// ReservePlugin is the interface which all plugins that would like to run at the Reserve and
+// Unreserve extension points should implement.
+type ReservePlugin interface {
+	Plugin
+
+	// Reserve runs after the scheduler has picked a cluster, right before it creates or refreshes
+	// the binding for the cluster; a plugin which keeps track of cluster resources, e.g., capacity,
+	// may reserve the resources for the placement at this extension point.
+	//
+	// A plugin which registers at this extension point must return one of the follows:
+	// * A Success status, if the plugin has reserved resources on the cluster; or
+	// * A Skip status, if the plugin has nothing to reserve on the cluster; or
+	// * An InternalError status, if an expected error has occurred
+	Reserve(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status)
+
+	// Unreserve runs if the scheduler fails to create, refresh, or delete bindings after the
+	// Reserve extension point has been run for a cluster; a plugin must roll back the
+	// reservation it has made (if any) on the cluster at this extension point.
+	//
+	// Unreserve is called for a cluster even if Reserve has failed or has been skipped for the
+	// cluster, and the plugins are called in the reverse order of their registration; as a
+	// result, the implementation must be idempotent and must not fail.
+	Unreserve(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster)
+}
+
+// ClusterEvent is a kind of member cluster change that may help a placement, which has not been
+// fully scheduled yet, get scheduled.
+type ClusterEvent string
+
+const (
+	// ClusterLabelChanged signals that the labels of a member cluster have changed.
+	ClusterLabelChanged ClusterEvent = "LabelChanged"
+	// ClusterTaintChanged signals that the taints of a member cluster have been updated or removed.
+	ClusterTaintChanged ClusterEvent = "TaintChanged"
+	// ClusterPropertyChanged signals that the non-resource properties of a member cluster have changed.
+	ClusterPropertyChanged ClusterEvent = "PropertyChanged"
+	// ClusterResourceUsageChanged signals that the resource usage (capacity, allocatable, or available
+	// resources) of a member cluster has changed.
+	ClusterResourceUsageChanged ClusterEvent = "ResourceUsageChanged"
+	// ClusterAPIsChanged signals that the API group versions a member cluster serves have changed.
+	ClusterAPIsChanged ClusterEvent = "APIsChanged"
+	// ClusterUncordoned signals that a member cluster is no longer cordoned.
+	ClusterUncordoned ClusterEvent = "Uncordoned"
+)
+
+// AllClusterEvents is the list of all kinds of member cluster changes that the scheduler watches for.
+var AllClusterEvents = []ClusterEvent{
+	ClusterLabelChanged,
+	ClusterTaintChanged,
+	ClusterPropertyChanged,
+	ClusterResourceUsageChanged,
+	ClusterAPIsChanged,
+	ClusterUncordoned,
+}
+
+// EnqueueExtension is the interface which plugins can implement to let the scheduler know which
+// kinds of member cluster changes may help a placement, which the plugin has previously rejected,
+// get scheduled.
+//
+// Plugins which do not implement this interface are assumed to be interested in all kinds of
+// member cluster changes.
+type EnqueueExtension interface {
+	Plugin
+
+	// EventsToRegister returns the kinds of member cluster changes that the plugin is interested in.
+	EventsToRegister() []ClusterEvent
+}
@@ -0,0 +1,155 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// bindingMutationTTL is how long the binding mutation cache keeps a write made by the scheduler
+	// framework, should the informer cache never catch up with it, e.g., when a created binding is
+	// deleted by others before the informer cache sees it.
+	bindingMutationTTL = time.Minute
+)
+
+// bindingMutation is a write to a binding made by the scheduler framework.
+type bindingMutation struct {
+	// binding is the binding as returned by the API server after the write.
+	binding *placementv1beta1.ClusterResourceBinding
+	// added is true if the binding is created by the scheduler framework, i.e., the informer cache
+	// might not have seen it at all.
+	added bool
+	// deleted is true if the write leads to the deletion of the binding, e.g., the removal of the last
+	// finalizer from a deleting binding.
+	deleted bool
+	// recordedAt is the time when the write is made.
+	recordedAt time.Time
+}
+
+// bindingMutationCache keeps the writes to bindings made by the scheduler framework, and layers them
+// over the informer cache, so that the scheduler framework always reads its own writes, without
+// listing bindings directly from the API server.
+//
+// This helps avoid a classic read-after-write consistency issue, which, though should only happen
+// when there are connectivity issues and/or API server is overloaded, can lead to over-scheduling
+// in adverse scenarios: a scheduling cycle might run before the informer cache sees the bindings
+// created by the last cycle of the same placement.
+//
+// A write is dropped once the informer cache sees the binding at the same or a later resource
+// version, or once it expires. Note that the writes are kept in memory only and per framework.
+type bindingMutationCache struct {
+	mu sync.Mutex
+	// mutations is the writes to bindings, keyed by the name of the binding.
+	mutations map[string]*bindingMutation
+	// ttl is how long a write is kept at most.
+	ttl time.Duration
+}
+
+// newBindingMutationCache returns a new bindingMutationCache.
+func newBindingMutationCache(ttl time.Duration) *bindingMutationCache {
+	return &bindingMutationCache{
+		mutations: make(map[string]*bindingMutation),
+		ttl:       ttl,
+	}
+}
+
+// mutated records a write to a binding; created should be true if the write creates the binding.
+//
+// It is a no-op on a nil cache.
+func (c *bindingMutationCache) mutated(binding *placementv1beta1.ClusterResourceBinding, created bool, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// A binding created by the scheduler framework stays an addition until the informer cache sees it.
+	existing, ok := c.mutations[binding.Name]
+	c.mutations[binding.Name] = &bindingMutation{
+		binding:    binding.DeepCopy(),
+		added:      created || (ok && existing.added),
+		deleted:    !binding.DeletionTimestamp.IsZero() && len(binding.Finalizers) == 0,
+		recordedAt: now,
+	}
+}
+
+// list lists all bindings associated with a CRP from the given (cached) reader, with the writes
+// made by the scheduler framework layered over; the reader must have the bindings indexed by
+// controller.BindingCRPTrackingIndexKey.
+func (c *bindingMutationCache) list(ctx context.Context, reader client.Reader, crpName string, now time.Time) ([]placementv1beta1.ClusterResourceBinding, error) {
+	bindingList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := reader.List(ctx, bindingList, client.MatchingFields{controller.BindingCRPTrackingIndexKey: crpName}); err != nil {
+		return nil, controller.NewAPIServerError(true, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name, m := range c.mutations {
+		if now.Sub(m.recordedAt) > c.ttl {
+			delete(c.mutations, name)
+		}
+	}
+
+	bindings := make([]placementv1beta1.ClusterResourceBinding, 0, len(bindingList.Items))
+	seen := make(map[string]bool, len(bindingList.Items))
+	for idx := range bindingList.Items {
+		binding := &bindingList.Items[idx]
+		seen[binding.Name] = true
+		m, ok := c.mutations[binding.Name]
+		switch {
+		case !ok:
+			bindings = append(bindings, *binding)
+		case !isNewerResourceVersion(m.binding.ResourceVersion, binding.ResourceVersion):
+			// The informer cache has caught up with the write.
+			delete(c.mutations, binding.Name)
+			bindings = append(bindings, *binding)
+		case m.deleted:
+			// The binding is gone, though the informer cache has not seen the deletion yet.
+		default:
+			bindings = append(bindings, *m.binding.DeepCopy())
+		}
+	}
+
+	for name, m := range c.mutations {
+		if seen[name] || m.binding.Labels[placementv1beta1.CRPTrackingLabel] != crpName {
+			continue
+		}
+		if !m.added || m.deleted {
+			// The informer cache has seen the binding before, and it is no longer there, i.e., the
+			// binding has been deleted since.
+			delete(c.mutations, name)
+			continue
+		}
+		bindings = append(bindings, *m.binding.DeepCopy())
+	}
+	return bindings, nil
+}
+
+// isNewerResourceVersion returns true if resource version a is newer than resource version b.
+//
+// Resource versions are compared as integers, as the API server backed by etcd does; should either
+// version be unparseable, the informer cache (version b) is trusted.
+func isNewerResourceVersion(a, b string) bool {
+	aVersion, err := strconv.ParseUint(a, 10, 64)
+	if err != nil {
+		return false
+	}
+	bVersion, err := strconv.ParseUint(b, 10, 64)
+	if err != nil {
+		return false
+	}
+	return aVersion > bVersion
+}
@@ -0,0 +1,174 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// TestBindingMutationCacheList tests the list method of bindingMutationCache.
+func TestBindingMutationCacheList(t *testing.T) {
+	now := time.Now()
+	newBinding := func(name, crp, resourceVersion string, state placementv1beta1.BindingState) *placementv1beta1.ClusterResourceBinding {
+		return &placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				ResourceVersion: resourceVersion,
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: crp,
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State: state,
+			},
+		}
+	}
+	deletedBinding := newBinding(bindingName, crpName, "3", placementv1beta1.BindingStateUnscheduled)
+	deletedBinding.DeletionTimestamp = &metav1.Time{Time: now}
+
+	testCases := []struct {
+		name          string
+		cached        []client.Object
+		mutations     map[string]*bindingMutation
+		wantStates    map[string]placementv1beta1.BindingState
+		wantMutations []string
+	}{
+		{
+			name: "created binding not seen by the cache",
+			mutations: map[string]*bindingMutation{
+				bindingName: {binding: newBinding(bindingName, crpName, "2", placementv1beta1.BindingStateScheduled), added: true, recordedAt: now},
+			},
+			wantStates:    map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateScheduled},
+			wantMutations: []string{bindingName},
+		},
+		{
+			name:   "cache has caught up",
+			cached: []client.Object{newBinding(bindingName, crpName, "3", placementv1beta1.BindingStateBound)},
+			mutations: map[string]*bindingMutation{
+				bindingName: {binding: newBinding(bindingName, crpName, "2", placementv1beta1.BindingStateScheduled), added: true, recordedAt: now},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+		},
+		{
+			name:   "update not seen by the cache",
+			cached: []client.Object{newBinding(bindingName, crpName, "2", placementv1beta1.BindingStateBound)},
+			mutations: map[string]*bindingMutation{
+				bindingName: {binding: newBinding(bindingName, crpName, "3", placementv1beta1.BindingStateUnscheduled), recordedAt: now},
+			},
+			wantStates:    map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateUnscheduled},
+			wantMutations: []string{bindingName},
+		},
+		{
+			name:   "deletion not seen by the cache",
+			cached: []client.Object{newBinding(bindingName, crpName, "2", placementv1beta1.BindingStateUnscheduled)},
+			mutations: map[string]*bindingMutation{
+				bindingName: {binding: deletedBinding, deleted: true, recordedAt: now},
+			},
+			wantStates:    map[string]placementv1beta1.BindingState{},
+			wantMutations: []string{bindingName},
+		},
+		{
+			name: "updated binding deleted since",
+			mutations: map[string]*bindingMutation{
+				bindingName: {binding: newBinding(bindingName, crpName, "3", placementv1beta1.BindingStateUnscheduled), recordedAt: now},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{},
+		},
+		{
+			name: "expired creation",
+			mutations: map[string]*bindingMutation{
+				bindingName: {binding: newBinding(bindingName, crpName, "2", placementv1beta1.BindingStateScheduled), added: true, recordedAt: now.Add(-2 * bindingMutationTTL)},
+			},
+			wantStates: map[string]placementv1beta1.BindingState{},
+		},
+		{
+			name:   "binding of another placement",
+			cached: []client.Object{newBinding(bindingName, crpName, "2", placementv1beta1.BindingStateBound)},
+			mutations: map[string]*bindingMutation{
+				altBindingName: {binding: newBinding(altBindingName, "another-test-placement", "2", placementv1beta1.BindingStateScheduled), added: true, recordedAt: now},
+			},
+			wantStates:    map[string]placementv1beta1.BindingState{bindingName: placementv1beta1.BindingStateBound},
+			wantMutations: []string{altBindingName},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(tc.cached...).
+				WithIndex(&placementv1beta1.ClusterResourceBinding{}, controller.BindingCRPTrackingIndexKey, controller.ExtractBindingCRPTracking).
+				Build()
+			c := newBindingMutationCache(bindingMutationTTL)
+			c.mutations = tc.mutations
+
+			bindings, err := c.list(context.Background(), fakeClient, crpName, now)
+			if err != nil {
+				t.Fatalf("list() = %v, want no error", err)
+			}
+			gotStates := make(map[string]placementv1beta1.BindingState, len(bindings))
+			for _, binding := range bindings {
+				gotStates[binding.Name] = binding.Spec.State
+			}
+			if diff := cmp.Diff(gotStates, tc.wantStates); diff != "" {
+				t.Errorf("list() binding states diff (-got, +want): %s", diff)
+			}
+
+			var gotMutations []string
+			for name := range c.mutations {
+				gotMutations = append(gotMutations, name)
+			}
+			sort.Strings(gotMutations)
+			if diff := cmp.Diff(gotMutations, tc.wantMutations); diff != "" {
+				t.Errorf("mutations diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+// TestBindingMutationCacheMutated tests the mutated method of bindingMutationCache.
+func TestBindingMutationCacheMutated(t *testing.T) {
+	now := time.Now()
+	binding := &placementv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       bindingName,
+			Finalizers: []string{placementv1beta1.SchedulerCRBCleanupFinalizer},
+		},
+	}
+
+	c := newBindingMutationCache(bindingMutationTTL)
+	c.mutated(binding, true, now)
+	// An update to a created binding is still an addition.
+	c.mutated(binding, false, now)
+	if m := c.mutations[bindingName]; !m.added || m.deleted {
+		t.Fatalf("mutation = %+v, want an addition", m)
+	}
+
+	// Removing the last finalizer from a deleting binding deletes it.
+	deleting := binding.DeepCopy()
+	deleting.DeletionTimestamp = &metav1.Time{Time: now}
+	deleting.Finalizers = nil
+	c.mutated(deleting, false, now)
+	if m := c.mutations[bindingName]; !m.deleted {
+		t.Fatalf("mutation = %+v, want a deletion", m)
+	}
+
+	// A nil cache records nothing.
+	var nilCache *bindingMutationCache
+	nilCache.mutated(binding, true, now)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"sync"
+	"time"
+)
+
+// permitWait is a scheduling decision held at the Permit stage.
+type permitWait struct {
+	// policyName is the name of the scheduling policy snapshot that makes the decision.
+	policyName string
+	// since is the time when the scheduler starts waiting.
+	since time.Time
+}
+
+// permitWaits keeps track of the scheduling decisions held at the Permit stage, so that the
+// scheduler can tell how long it has been waiting for a decision across scheduling cycles.
+//
+// Note that the waits are kept in memory only; should the scheduler restart (or a new leader
+// take over), all the waits start over.
+type permitWaits struct {
+	mu sync.Mutex
+	// waits is the held decisions, keyed by the name of the CRP and then the name of the
+	// target cluster.
+	waits map[string]map[string]permitWait
+}
+
+// newPermitWaits returns a new permitWaits.
+func newPermitWaits() *permitWaits {
+	return &permitWaits{
+		waits: make(map[string]map[string]permitWait),
+	}
+}
+
+// waitingSince returns the time when the scheduler starts waiting for a decision of a scheduling
+// policy snapshot on a cluster; a new wait starts now if the decision has not been held before,
+// or if it has been held by an earlier policy snapshot.
+func (w *permitWaits) waitingSince(crpName, policyName, clusterName string, now time.Time) time.Time {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	waitsForCRP, ok := w.waits[crpName]
+	if !ok {
+		waitsForCRP = make(map[string]permitWait)
+		w.waits[crpName] = waitsForCRP
+	}
+	wait, ok := waitsForCRP[clusterName]
+	if !ok || wait.policyName != policyName {
+		wait = permitWait{policyName: policyName, since: now}
+		waitsForCRP[clusterName] = wait
+	}
+	return wait.since
+}
+
+// retain drops the waits of a CRP except for the given clusters, i.e., the decisions that are
+// no longer held.
+func (w *permitWaits) retain(crpName string, clusterNames map[string]bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	waitsForCRP, ok := w.waits[crpName]
+	if !ok {
+		return
+	}
+	for clusterName := range waitsForCRP {
+		if !clusterNames[clusterName] {
+			delete(waitsForCRP, clusterName)
+		}
+	}
+	if len(waitsForCRP) == 0 {
+		delete(w.waits, crpName)
+	}
+}
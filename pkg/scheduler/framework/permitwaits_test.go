@@ -0,0 +1,50 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestPermitWaits tests the basic ops of permitWaits.
+func TestPermitWaits(t *testing.T) {
+	w := newPermitWaits()
+	start := time.Now()
+	later := start.Add(time.Minute)
+
+	if got := w.waitingSince(crpName, policyName, clusterName, start); !got.Equal(start) {
+		t.Fatalf("waitingSince() = %v, want %v", got, start)
+	}
+	// The wait started earlier is kept.
+	if got := w.waitingSince(crpName, policyName, clusterName, later); !got.Equal(start) {
+		t.Fatalf("waitingSince() = %v, want %v", got, start)
+	}
+	// A wait of a new policy snapshot starts over.
+	if got := w.waitingSince(crpName, altPolicyName, clusterName, later); !got.Equal(later) {
+		t.Fatalf("waitingSince() = %v, want %v", got, later)
+	}
+	if got := w.waitingSince(crpName, altPolicyName, altClusterName, later); !got.Equal(later) {
+		t.Fatalf("waitingSince() = %v, want %v", got, later)
+	}
+
+	w.retain(crpName, map[string]bool{altClusterName: true})
+	wantWaits := map[string]map[string]permitWait{
+		crpName: {
+			altClusterName: {policyName: altPolicyName, since: later},
+		},
+	}
+	if diff := cmp.Diff(w.waits, wantWaits, cmp.AllowUnexported(permitWait{})); diff != "" {
+		t.Errorf("waits diff (-got, +want): %s", diff)
+	}
+
+	w.retain(crpName, nil)
+	if len(w.waits) != 0 {
+		t.Errorf("waits = %v, want no waits", w.waits)
+	}
+}
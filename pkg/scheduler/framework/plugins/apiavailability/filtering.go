@@ -0,0 +1,71 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package apiavailability
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	apisNotReportedReason       = "cluster does not report the APIs it serves"
+	apisNotServedReasonTemplate = "cluster does not serve the required APIs %s"
+)
+
+// PreFilter allows the plugin to connect to the PreFilter extension point in the scheduling
+// framework.
+func (p *Plugin) PreFilter(
+	_ context.Context,
+	_ framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+) (status *framework.Status) {
+	if policy.Spec.Policy == nil || len(policy.Spec.Policy.RequiredAPIs) == 0 {
+		// There are no required APIs to enforce; consider all clusters eligible for resource
+		// placement in the scope of this plugin.
+		//
+		// Note that this will set the cluster to skip the Filter stage for all clusters.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no required APIs to enforce")
+	}
+
+	return nil
+}
+
+// Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
+func (p *Plugin) Filter(
+	_ context.Context,
+	_ framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (status *framework.Status) {
+	// Note that this extension point assumes that previous extension point (PreFilter) has
+	// guaranteed that if scheduling policy reaches this stage, it must have required APIs to
+	// enforce.
+	if len(cluster.Status.APIGroupVersions) == 0 {
+		return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), apisNotReportedReason)
+	}
+
+	served := sets.New(cluster.Status.APIGroupVersions...)
+	var missing []string
+	for _, groupVersion := range policy.Spec.Policy.RequiredAPIs {
+		if !served.Has(groupVersion) {
+			missing = append(missing, groupVersion)
+		}
+	}
+	if len(missing) > 0 {
+		reason := fmt.Sprintf(apisNotServedReasonTemplate, strings.Join(missing, ", "))
+		return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reason)
+	}
+
+	// All done.
+	return nil
+}
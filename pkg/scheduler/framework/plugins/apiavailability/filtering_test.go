@@ -0,0 +1,177 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package apiavailability
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	clusterName = "bravelion"
+)
+
+var (
+	p = New()
+)
+
+// TestPreFilter tests the PreFilter extension point of this plugin.
+func TestPreFilter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policy     *placementv1beta1.ClusterSchedulingPolicySnapshot
+		wantStatus *framework.Status
+	}{
+		{
+			name: "no scheduling policy",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: nil,
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.Skip, p.Name(), "no required APIs to enforce"),
+		},
+		{
+			name: "no required APIs",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickAllPlacementType,
+					},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.Skip, p.Name(), "no required APIs to enforce"),
+		},
+		{
+			name: "required APIs",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickAllPlacementType,
+						RequiredAPIs:  []string{"apps/v1"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			state := framework.NewCycleState([]clusterv1beta1.MemberCluster{}, []*placementv1beta1.ClusterResourceBinding{})
+			status := p.PreFilter(ctx, state, tc.policy)
+			if diff := cmp.Diff(status, tc.wantStatus, cmp.AllowUnexported(framework.Status{})); diff != "" {
+				t.Errorf("PreFilter() status diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+// TestFilter tests the Filter extension point of this plugin.
+func TestFilter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policy     *placementv1beta1.ClusterSchedulingPolicySnapshot
+		cluster    *clusterv1beta1.MemberCluster
+		wantStatus *framework.Status
+	}{
+		{
+			name: "all required APIs served",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickAllPlacementType,
+						RequiredAPIs:  []string{"v1", "monitoring.coreos.com/v1"},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					APIGroupVersions: []string{"apps/v1", "monitoring.coreos.com/v1", "v1"},
+				},
+			},
+		},
+		{
+			name: "CRD not installed",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickAllPlacementType,
+						RequiredAPIs:  []string{"apps/v1", "monitoring.coreos.com/v1"},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					APIGroupVersions: []string{"apps/v1", "v1"},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), fmt.Sprintf(apisNotServedReasonTemplate, "monitoring.coreos.com/v1")),
+		},
+		{
+			name: "API version not served",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickAllPlacementType,
+						RequiredAPIs:  []string{"autoscaling/v2beta2", "batch/v1beta1"},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					APIGroupVersions: []string{"autoscaling/v1", "autoscaling/v2", "batch/v1", "v1"},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), fmt.Sprintf(apisNotServedReasonTemplate, "autoscaling/v2beta2, batch/v1beta1")),
+		},
+		{
+			name: "APIs not reported",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickAllPlacementType,
+						RequiredAPIs:  []string{"v1"},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), apisNotReportedReason),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			state := framework.NewCycleState([]clusterv1beta1.MemberCluster{}, []*placementv1beta1.ClusterResourceBinding{})
+			status := p.Filter(ctx, state, tc.policy, tc.cluster)
+			if diff := cmp.Diff(status, tc.wantStatus, cmp.AllowUnexported(framework.Status{})); diff != "" {
+				t.Errorf("Filter() status diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package apiavailability features a scheduler plugin that filters clusters by the API group versions
+// a CRP requires (if any), per the API group versions the clusters report.
+package apiavailability
+
+import (
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// defaultPluginName is the default name of the plugin.
+	defaultPluginName = "APIAvailability"
+)
+
+// Plugin is the scheduler plugin that enforces the required APIs (if any) defined on a CRP.
+type Plugin struct {
+	// The name of the plugin.
+	name string
+
+	// The framework handle.
+	handle framework.Handle
+}
+
+var (
+	// Verify that Plugin can connect to relevant extension points at compile time.
+	//
+	// This plugin leverages the following the extension points:
+	// * PreFilter
+	// * Filter
+	//
+	// Note that successful connection to any of the extension points implies that the
+	// plugin already implements the Plugin interface.
+	_ framework.PreFilterPlugin  = &Plugin{}
+	_ framework.FilterPlugin     = &Plugin{}
+	_ framework.EnqueueExtension = &Plugin{}
+)
+
+// pluginOptions is the options for this plugin.
+type pluginOptions struct {
+	// The name of the plugin.
+	name string
+}
+
+// Option helps set up the plugin.
+type Option func(*pluginOptions)
+
+// defaultPluginOptions is the default options for this plugin.
+var defaultPluginOptions = pluginOptions{
+	name: defaultPluginName,
+}
+
+// WithName sets the name of the plugin.
+func WithName(name string) Option {
+	return func(o *pluginOptions) {
+		o.name = name
+	}
+}
+
+// New returns a new Plugin.
+func New(opts ...Option) Plugin {
+	options := defaultPluginOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return Plugin{
+		name: options.name,
+	}
+}
+
+// Name returns the name of the plugin.
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// SetUpWithFramework sets up this plugin with a scheduler framework.
+func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
+	p.handle = handle
+
+	// This plugin does not need to set up any informer.
+}
+
+// EventsToRegister returns the kinds of member cluster changes that the plugin is interested in.
+func (p *Plugin) EventsToRegister() []framework.ClusterEvent {
+	// The plugin reads the API group versions of clusters only.
+	return []framework.ClusterEvent{
+		framework.ClusterAPIsChanged,
+	}
+}
@@ -11,12 +11,13 @@ import (
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/utils/clusterselector"
 )
 
 // PreFilter allows the plugin to connect to the PreFilter extension point in the scheduling framework.
 func (p *Plugin) PreFilter(
 	_ context.Context,
-	_ framework.CycleStatePluginReadWriter,
+	state framework.CycleStatePluginReadWriter,
 	ps *placementv1beta1.ClusterSchedulingPolicySnapshot,
 ) (status *framework.Status) {
 	noRequiredClusterAffinityTerms := ps.Spec.Policy == nil ||
@@ -32,24 +33,35 @@ func (p *Plugin) PreFilter(
 		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no required cluster affinity terms to enforce")
 	}
 
+	// Compile the required terms once for all the clusters to evaluate in the Filter stage.
+	terms, err := compileTerms(ps.Spec.Policy.Affinity.ClusterAffinity.RequiredDuringSchedulingIgnoredDuringExecution.ClusterSelectorTerms, clusterselector.RequiredAffinityUsage)
+	if err != nil {
+		return framework.FromError(err, p.Name(), "failed to compile required cluster affinity terms")
+	}
+	framework.WriteAs(state, framework.PluginStateKey(p.Name(), requiredTermsStateKey), terms)
+
 	return nil
 }
 
 // Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
 func (p *Plugin) Filter(
 	_ context.Context,
-	_ framework.CycleStatePluginReadWriter,
-	ps *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	state framework.CycleStatePluginReadWriter,
+	_ *placementv1beta1.ClusterSchedulingPolicySnapshot,
 	cluster *clusterv1beta1.MemberCluster,
 ) (status *framework.Status) {
 	// Note that this extension point assumes that previous extension point (PreFilter) has
 	// guaranteed that if scheduling policy reaches this stage, it must have at least one
-	// required cluster affinity term to enforce.
+	// required cluster affinity term to enforce, compiled and saved in the cycle state.
+	terms, err := p.readCompiledTerms(state, requiredTermsStateKey)
+	if err != nil {
+		// This branch should never be reached, as the compiled terms have been saved
+		// in the PreFilter stage.
+		return framework.FromError(err, p.Name(), "failed to read compiled required cluster affinity terms")
+	}
 
-	for idx := range ps.Spec.Policy.Affinity.ClusterAffinity.RequiredDuringSchedulingIgnoredDuringExecution.ClusterSelectorTerms {
-		t := &ps.Spec.Policy.Affinity.ClusterAffinity.RequiredDuringSchedulingIgnoredDuringExecution.ClusterSelectorTerms[idx]
-		r := clusterRequirement(*t)
-		isMatched, err := r.Matches(cluster)
+	for _, t := range terms {
+		isMatched, err := t.Matches(p.propertyReader(), cluster)
 		if err != nil {
 			// An error has occurred when matching the cluster against a required affinity term.
 			return framework.FromError(err, p.Name(), "failed to match the cluster against a required affinity term")
@@ -745,6 +745,9 @@ func TestFilter(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
 			state := framework.NewCycleState(nil, nil, nil)
+			if status := p.PreFilter(ctx, state, tc.ps); status != nil {
+				t.Fatalf("PreFilter() = %v, want nil", status)
+			}
 			status := p.Filter(ctx, state, tc.ps, tc.cluster)
 
 			if diff := cmp.Diff(
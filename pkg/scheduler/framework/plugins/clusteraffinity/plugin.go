@@ -11,6 +11,18 @@ import (
 	"fmt"
 
 	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/utils/clusterselector"
+)
+
+const (
+	// pluginStateKey is the key under which the plugin saves its state in the cycle state.
+	pluginStateKey = "pluginState"
+	// requiredTermsStateKey is the key under which the plugin saves the compiled required cluster
+	// affinity terms in the cycle state.
+	requiredTermsStateKey = "requiredTerms"
+	// preferredTermsStateKey is the key under which the plugin saves the compiled preferred cluster
+	// affinity terms in the cycle state.
+	preferredTermsStateKey = "preferredTerms"
 )
 
 // Plugin is the scheduler plugin that enforces the cluster affinity (if any) defined on a CRP.
@@ -33,10 +45,11 @@ var (
 	//
 	// Note that successful connection to any of the extension points implies that the
 	// plugin already implements the Plugin interface.
-	_ framework.PreFilterPlugin = &Plugin{}
-	_ framework.FilterPlugin    = &Plugin{}
-	_ framework.PreScorePlugin  = &Plugin{}
-	_ framework.ScorePlugin     = &Plugin{}
+	_ framework.PreFilterPlugin  = &Plugin{}
+	_ framework.FilterPlugin     = &Plugin{}
+	_ framework.PreScorePlugin   = &Plugin{}
+	_ framework.ScorePlugin      = &Plugin{}
+	_ framework.EnqueueExtension = &Plugin{}
 )
 
 type clusterAffinityPluginOptions struct {
@@ -79,21 +92,43 @@ func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
 	p.handle = handle
 }
 
+// propertyReader returns the property reader of the framework the plugin is set up with, if any.
+func (p *Plugin) propertyReader() *framework.PropertyReader {
+	if p.handle == nil {
+		return nil
+	}
+	return p.handle.PropertyReader()
+}
+
+// EventsToRegister returns the kinds of member cluster changes that the plugin is interested in.
+func (p *Plugin) EventsToRegister() []framework.ClusterEvent {
+	// Cluster affinity terms select clusters by their labels and properties (resource or
+	// non-resource ones).
+	return []framework.ClusterEvent{
+		framework.ClusterLabelChanged,
+		framework.ClusterPropertyChanged,
+		framework.ClusterResourceUsageChanged,
+	}
+}
+
 // readPluginState reads the plugin state from the cycle state.
 func (p *Plugin) readPluginState(state framework.CycleStatePluginReadWriter) (*pluginState, error) {
 	// Read from the cycle state.
-	val, err := state.Read(framework.StateKey(p.Name()))
+	ps, err := framework.ReadAs[*pluginState](state, framework.PluginStateKey(p.Name(), pluginStateKey))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read value from the cycle state: %w", err)
 	}
-
-	// Cast the value to the right type.
-	ps, ok := val.(*pluginState)
-	if !ok {
-		return nil, fmt.Errorf("failed to cast value %v to the right type", val)
-	}
 	if ps == nil {
 		return nil, errors.New("plugin state is nil")
 	}
 	return ps, nil
 }
+
+// readCompiledTerms reads the compiled cluster affinity terms saved under a key from the cycle state.
+func (p *Plugin) readCompiledTerms(state framework.CycleStatePluginReadWriter, key string) ([]*clusterselector.CompiledTerm, error) {
+	terms, err := framework.ReadAs[[]*clusterselector.CompiledTerm](state, framework.PluginStateKey(p.Name(), key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compiled terms from the cycle state: %w", err)
+	}
+	return terms, nil
+}
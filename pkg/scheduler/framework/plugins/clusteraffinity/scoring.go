@@ -12,6 +12,7 @@ import (
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/utils/clusterselector"
 )
 
 // PreScore allows the plugin to connect to the PreScore extension point in the scheduling
@@ -35,13 +36,25 @@ func (p *Plugin) PreScore(
 
 	// Prepare the plugin state. Specifically, pre-calculate min. and max. values
 	// for properties that require sorting (if any).
-	ps, err := preparePluginState(state, policy)
+	ps, err := preparePluginState(p.propertyReader(), state, policy)
 	if err != nil {
 		return framework.FromError(err, p.Name(), "failed to prepare plugin state")
 	}
 
-	// Save the plugin state.
-	state.Write(framework.StateKey(p.Name()), ps)
+	// Compile the preferred terms once for all the clusters to evaluate in the Score stage.
+	preferences := policy.Spec.Policy.Affinity.ClusterAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	terms := make([]placementv1beta1.ClusterSelectorTerm, 0, len(preferences))
+	for idx := range preferences {
+		terms = append(terms, preferences[idx].Preference)
+	}
+	compiled, err := compileTerms(terms, clusterselector.PreferredAffinityUsage)
+	if err != nil {
+		return framework.FromError(err, p.Name(), "failed to compile preferred cluster affinity terms")
+	}
+
+	// Save the plugin state and the compiled terms.
+	framework.WriteAs(state, framework.PluginStateKey(p.Name(), pluginStateKey), ps)
+	framework.WriteAs(state, framework.PluginStateKey(p.Name(), preferredTermsStateKey), compiled)
 
 	// All done.
 	return nil
@@ -61,12 +74,23 @@ func (p *Plugin) Score(
 		// in the PreScore stage.
 		return nil, framework.FromError(err, p.Name(), "failed to read plugin state")
 	}
+	terms, err := p.readCompiledTerms(state, preferredTermsStateKey)
+	if err != nil {
+		// This branch should never be reached, as the compiled terms have been saved
+		// in the PreScore stage.
+		return nil, framework.FromError(err, p.Name(), "failed to read compiled preferred cluster affinity terms")
+	}
+	preferences := policy.Spec.Policy.Affinity.ClusterAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	if len(terms) != len(preferences) {
+		// Normally this should never occur.
+		return nil, framework.FromError(fmt.Errorf("found %d compiled terms for %d preferred cluster affinity terms", len(terms), len(preferences)), p.Name())
+	}
 
 	score = &framework.ClusterScore{}
-	for _, t := range policy.Spec.Policy.Affinity.ClusterAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+	for idx, t := range preferences {
 		if t.Weight != 0 {
 			cp := clusterPreference(t)
-			ts, err := cp.Scores(ps, cluster)
+			ts, err := cp.Scores(p.propertyReader(), ps, terms[idx], cluster)
 			if err != nil {
 				return nil, framework.FromError(fmt.Errorf("failed to calculate score for cluster %s: %w", cluster.Name, err), p.Name())
 			}
@@ -19,6 +19,7 @@ import (
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/propertyprovider"
 	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/utils/clusterselector"
 )
 
 // TestPreScore tests the PreScore extension point of this plugin.
@@ -627,7 +628,16 @@ func TestPluginScore(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
 			state := framework.NewCycleState(nil, nil, nil)
-			state.Write(framework.StateKey(p.Name()), tc.ps)
+			framework.WriteAs(state, framework.PluginStateKey(p.Name(), pluginStateKey), tc.ps)
+			terms := make([]placementv1beta1.ClusterSelectorTerm, 0)
+			for _, pt := range tc.policy.Spec.Policy.Affinity.ClusterAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+				terms = append(terms, pt.Preference)
+			}
+			compiled, err := compileTerms(terms, clusterselector.PreferredAffinityUsage)
+			if err != nil {
+				t.Fatalf("compileTerms() = %v, want no error", err)
+			}
+			framework.WriteAs(state, framework.PluginStateKey(p.Name(), preferredTermsStateKey), compiled)
 
 			score, status := p.Score(ctx, state, tc.policy, tc.cluster)
 			if diff := cmp.Diff(
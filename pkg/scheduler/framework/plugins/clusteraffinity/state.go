@@ -24,7 +24,7 @@ type pluginState struct {
 
 // preparePluginState prepares a common state for easier queries of min. and max.
 // observed values of properties (if applicable).
-func preparePluginState(state framework.CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (*pluginState, error) {
+func preparePluginState(reader *framework.PropertyReader, state framework.CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot) (*pluginState, error) {
 	ps := &pluginState{
 		minMaxValuesByProperty: make(map[string]observedMinMaxValues),
 	}
@@ -47,7 +47,7 @@ func preparePluginState(state framework.CycleStatePluginReadWriter, policy *plac
 
 			for cidx := range cs {
 				c := &cs[cidx]
-				q, err := retrievePropertyValueFrom(c, n)
+				q, err := reader.Quantity(c, n)
 				if err != nil {
 					// An error has occurred when retrieving the property value from the cluster.
 					//
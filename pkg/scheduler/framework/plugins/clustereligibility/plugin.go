@@ -38,7 +38,8 @@ var (
 	//
 	// Note that successful connection to any of the extension points implies that the
 	// plugin already implements the Plugin interface.
-	_ framework.FilterPlugin = &Plugin{}
+	_ framework.FilterPlugin     = &Plugin{}
+	_ framework.EnqueueExtension = &Plugin{}
 )
 
 // pluginOptions is the options for this plugin.
@@ -86,6 +87,13 @@ func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
 	// This plugin does not need to set up any informer.
 }
 
+// EventsToRegister returns the kinds of member cluster changes that the plugin is interested in.
+func (p *Plugin) EventsToRegister() []framework.ClusterEvent {
+	// Changes in cluster eligibility are always watched for by the scheduler, regardless of
+	// the plugins in use.
+	return []framework.ClusterEvent{}
+}
+
 // Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
 func (p *Plugin) Filter(
 	_ context.Context,
@@ -49,6 +49,7 @@ func (mh *MockHandle) EventRecorder() record.EventRecorder { return nil }
 func (mh *MockHandle) ClusterEligibilityChecker() *clustereligibilitychecker.ClusterEligibilityChecker {
 	return mh.clusterEligibilityChecker
 }
+func (mh *MockHandle) PropertyReader() *framework.PropertyReader { return nil }
 
 // TestFilter tests the Filter method.
 func TestFilter(t *testing.T) {
@@ -0,0 +1,39 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package cordon
+
+import (
+	"context"
+	"fmt"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	cordonedReason         = "cluster is cordoned"
+	cordonedReasonTemplate = "cluster is cordoned: %s"
+)
+
+// Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
+func (p *Plugin) Filter(
+	_ context.Context,
+	_ framework.CycleStatePluginReadWriter,
+	_ *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (status *framework.Status) {
+	cordon := cluster.Spec.Cordon
+	if cordon == nil {
+		return nil
+	}
+
+	reason := cordonedReason
+	if cordon.Reason != "" {
+		reason = fmt.Sprintf(cordonedReasonTemplate, cordon.Reason)
+	}
+	return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reason)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package cordon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	clusterName = "bravelion"
+)
+
+var (
+	p = New()
+
+	ignoreStatusErrorField = cmpopts.IgnoreFields(framework.Status{}, "err")
+)
+
+// TestFilter tests the Filter extension point of this plugin.
+func TestFilter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		cordon     *clusterv1beta1.Cordon
+		wantStatus *framework.Status
+	}{
+		{
+			name: "not cordoned",
+		},
+		{
+			name:       "cordoned",
+			cordon:     &clusterv1beta1.Cordon{},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), "cluster is cordoned"),
+		},
+		{
+			name:       "cordoned with a reason",
+			cordon:     &clusterv1beta1.Cordon{Reason: "node pool upgrade"},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), "cluster is cordoned: node pool upgrade"),
+		},
+		{
+			name:       "cordoned and drained",
+			cordon:     &clusterv1beta1.Cordon{Drain: true},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), "cluster is cordoned"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			state := framework.NewCycleState([]clusterv1beta1.MemberCluster{}, []*placementv1beta1.ClusterResourceBinding{})
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickAllPlacementType,
+					},
+				},
+			}
+			cluster := &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Spec: clusterv1beta1.MemberClusterSpec{
+					Cordon: tc.cordon,
+				},
+			}
+			status := p.Filter(ctx, state, policy, cluster)
+			if diff := cmp.Diff(status, tc.wantStatus, cmp.AllowUnexported(framework.Status{}), ignoreStatusErrorField); diff != "" {
+				t.Errorf("Filter() status diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package exclusivity
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	occupiedClusterReasonTemplate = "cluster is occupied by placement %s of the exclusivity group %q"
+)
+
+// pluginState is the state this plugin saves in the cycle state.
+type pluginState struct {
+	// occupiedBy maps the names of clusters that have been occupied by other placements of the
+	// same exclusivity group to the names of the occupying placements.
+	occupiedBy map[string]string
+	// occupyingBindings maps the names of occupied clusters to the bindings that occupy them.
+	occupyingBindings map[string][]*placementv1beta1.ClusterResourceBinding
+}
+
+// PreFilter allows the plugin to connect to the PreFilter extension point in the scheduling
+// framework.
+//
+// Note that the scheduler will not run this extension point in parallel.
+func (p *Plugin) PreFilter(
+	ctx context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+) (status *framework.Status) {
+	if policy.Spec.Policy == nil || len(policy.Spec.Policy.ExclusivityGroup) == 0 {
+		// The placement does not belong to any exclusivity group; skip.
+		//
+		// Note that this will lead the scheduler to skip this plugin in the next stage
+		// (Filter).
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no exclusivity group is specified")
+	}
+	group := policy.Spec.Policy.ExclusivityGroup
+	crpName := policy.Labels[placementv1beta1.CRPTrackingLabel]
+
+	// Find all the bindings of the same exclusivity group.
+	bindingList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := p.handle.Client().List(ctx, bindingList, client.MatchingLabels{placementv1beta1.ExclusivityGroupLabel: group}); err != nil {
+		return framework.FromError(err, p.Name(), "failed to list bindings of the exclusivity group")
+	}
+
+	ps := &pluginState{
+		occupiedBy:        make(map[string]string),
+		occupyingBindings: make(map[string][]*placementv1beta1.ClusterResourceBinding),
+	}
+	for idx := range bindingList.Items {
+		binding := &bindingList.Items[idx]
+		owner := binding.Labels[placementv1beta1.CRPTrackingLabel]
+		switch {
+		case owner == crpName:
+			// A placement does not exclude itself.
+		case !binding.DeletionTimestamp.IsZero() || binding.Spec.State == placementv1beta1.BindingStateUnscheduled:
+			// The binding is being removed from the cluster; the cluster is no longer occupied by it.
+		default:
+			ps.occupiedBy[binding.Spec.TargetCluster] = owner
+			ps.occupyingBindings[binding.Spec.TargetCluster] = append(ps.occupyingBindings[binding.Spec.TargetCluster], binding)
+		}
+	}
+
+	// Save the plugin state.
+	framework.WriteAs(state, framework.PluginStateKey(p.Name(), pluginStateKey), ps)
+
+	if len(ps.occupiedBy) == 0 {
+		// No cluster has been occupied in the exclusivity group; skip.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no cluster is occupied in the exclusivity group")
+	}
+
+	// All done.
+	return nil
+}
+
+// Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
+func (p *Plugin) Filter(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (status *framework.Status) {
+	// Read the plugin state.
+	ps, err := p.readPluginState(state)
+	if err != nil {
+		// This branch should never be reached, as for any policy with an exclusivity group,
+		// a plugin state has been set at the PreFilter extension point.
+		return framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	// The state is safe for concurrent reads.
+	if owner, ok := ps.occupiedBy[cluster.Name]; ok {
+		reason := fmt.Sprintf(occupiedClusterReasonTemplate, owner, policy.Spec.Policy.ExclusivityGroup)
+		return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reason)
+	}
+
+	// All done.
+	return nil
+}
+
+// Victims allows the plugin to report the bindings to preempt from a cluster that it has filtered
+// out, i.e., all the bindings of other placements of the same exclusivity group on the cluster.
+func (p *Plugin) Victims(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	_ *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (victims []*placementv1beta1.ClusterResourceBinding, status *framework.Status) {
+	// Read the plugin state.
+	ps, err := p.readPluginState(state)
+	if err != nil {
+		// This branch should never be reached, as the plugin filters out clusters only after a
+		// plugin state has been set at the PreFilter extension point.
+		return nil, framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	bindings := ps.occupyingBindings[cluster.Name]
+	if len(bindings) == 0 {
+		return nil, framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), "cluster is not occupied in the exclusivity group")
+	}
+	// Return copies, as the state is shared by all the clusters evaluated in the cycle.
+	victims = make([]*placementv1beta1.ClusterResourceBinding, 0, len(bindings))
+	for _, binding := range bindings {
+		victims = append(victims, binding.DeepCopy())
+	}
+	return victims, nil
+}
@@ -0,0 +1,315 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package exclusivity
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	crpName      = "test-crp"
+	otherCRPName = "other-crp"
+	groupName    = "dedicated"
+	clusterName  = "bravelion"
+	clusterName2 = "smartfish"
+)
+
+var (
+	cmpStatusOptions = cmp.Options{
+		cmpopts.IgnoreFields(framework.Status{}, "reasons", "err"),
+		cmp.AllowUnexported(framework.Status{}),
+	}
+)
+
+// Mock framework.Handle interface for set up the plugin.
+type MockHandle struct {
+	client client.Client
+}
+
+var (
+	_ framework.Handle = &MockHandle{}
+)
+
+func (mh *MockHandle) Client() client.Client               { return mh.client }
+func (mh *MockHandle) Manager() ctrl.Manager               { return nil }
+func (mh *MockHandle) UncachedReader() client.Reader       { return nil }
+func (mh *MockHandle) EventRecorder() record.EventRecorder { return nil }
+func (mh *MockHandle) ClusterEligibilityChecker() *clustereligibilitychecker.ClusterEligibilityChecker {
+	return nil
+}
+func (mh *MockHandle) PropertyReader() *framework.PropertyReader { return nil }
+
+// TestPreFilterAndFilter tests the PreFilter and Filter methods.
+func TestPreFilterAndFilter(t *testing.T) {
+	testCases := []struct {
+		name          string
+		group         string
+		bindings      []client.Object
+		wantPreFilter *framework.Status
+		wantFilter    map[string]*framework.Status
+	}{
+		{
+			name: "no exclusivity group",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-1",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel:      otherCRPName,
+							placementv1beta1.ExclusivityGroupLabel: groupName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName,
+					},
+				},
+			},
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name:  "no cluster is occupied",
+			group: groupName,
+			bindings: []client.Object{
+				// Bindings of the same placement.
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-1",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel:      crpName,
+							placementv1beta1.ExclusivityGroupLabel: groupName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName,
+					},
+				},
+				// Bindings of a different exclusivity group.
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-2",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel:      otherCRPName,
+							placementv1beta1.ExclusivityGroupLabel: "other-group",
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName2,
+					},
+				},
+				// Unscheduled bindings.
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-3",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel:      otherCRPName,
+							placementv1beta1.ExclusivityGroupLabel: groupName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateUnscheduled,
+						TargetCluster: clusterName2,
+					},
+				},
+			},
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name:  "cluster is occupied by another placement",
+			group: groupName,
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-1",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel:      crpName,
+							placementv1beta1.ExclusivityGroupLabel: groupName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-2",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel:      otherCRPName,
+							placementv1beta1.ExclusivityGroupLabel: groupName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateScheduled,
+						TargetCluster: clusterName2,
+					},
+				},
+			},
+			wantFilter: map[string]*framework.Status{
+				clusterName:  nil,
+				clusterName2: framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := placementv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme() = %v, want nil", err)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.bindings...).Build()
+			p := New()
+			p.SetUpWithFramework(&MockHandle{client: fakeClient})
+
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("%s-1", crpName),
+					Labels: map[string]string{
+						placementv1beta1.CRPTrackingLabel: crpName,
+					},
+				},
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:    placementv1beta1.PickAllPlacementType,
+						ExclusivityGroup: tc.group,
+					},
+				},
+			}
+			state := framework.NewCycleState(nil, nil)
+			got := p.PreFilter(context.Background(), state, policy)
+			if diff := cmp.Diff(tc.wantPreFilter, got, cmpStatusOptions); diff != "" {
+				t.Fatalf("PreFilter() status mismatch (-want, +got):\n%s", diff)
+			}
+
+			for name, want := range tc.wantFilter {
+				cluster := &clusterv1beta1.MemberCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: name,
+					},
+				}
+				got := p.Filter(context.Background(), state, policy, cluster)
+				if diff := cmp.Diff(want, got, cmpStatusOptions); diff != "" {
+					t.Errorf("Filter(%s) status mismatch (-want, +got):\n%s", name, diff)
+				}
+			}
+		})
+	}
+}
+
+// TestVictims tests the Victims method.
+func TestVictims(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want nil", err)
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-1",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel:      crpName,
+					placementv1beta1.ExclusivityGroupLabel: groupName,
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateBound,
+				TargetCluster: clusterName,
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-2",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel:      otherCRPName,
+					placementv1beta1.ExclusivityGroupLabel: groupName,
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateScheduled,
+				TargetCluster: clusterName2,
+			},
+		},
+	).Build()
+	p := New()
+	p.SetUpWithFramework(&MockHandle{client: fakeClient})
+
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-1", crpName),
+			Labels: map[string]string{
+				placementv1beta1.CRPTrackingLabel: crpName,
+			},
+		},
+		Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickAllPlacementType,
+				ExclusivityGroup: groupName,
+			},
+		},
+	}
+	state := framework.NewCycleState(nil, nil)
+	if status := p.PreFilter(context.Background(), state, policy); !status.IsSuccess() {
+		t.Fatalf("PreFilter() = %v, want success", status)
+	}
+
+	testCases := []struct {
+		name        string
+		clusterName string
+		wantVictims []string
+		wantStatus  *framework.Status
+	}{
+		{
+			name:        "cluster is not occupied",
+			clusterName: clusterName,
+			wantStatus:  framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+		},
+		{
+			name:        "cluster is occupied by another placement",
+			clusterName: clusterName2,
+			wantVictims: []string{"binding-2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: tc.clusterName,
+				},
+			}
+			victims, status := p.Victims(context.Background(), state, policy, cluster)
+			if diff := cmp.Diff(tc.wantStatus, status, cmpStatusOptions); diff != "" {
+				t.Fatalf("Victims() status mismatch (-want, +got):\n%s", diff)
+			}
+			var gotVictims []string
+			for _, victim := range victims {
+				gotVictims = append(gotVictims, victim.Name)
+			}
+			if diff := cmp.Diff(tc.wantVictims, gotVictims); diff != "" {
+				t.Errorf("Victims() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
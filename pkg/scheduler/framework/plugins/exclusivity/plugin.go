@@ -0,0 +1,109 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package exclusivity features a scheduler plugin that filters out any cluster that has been
+// occupied by another resource placement of the same exclusivity group.
+package exclusivity
+
+import (
+	"fmt"
+
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// defaultPluginName is the default name of the plugin.
+	defaultPluginName = "Exclusivity"
+
+	// pluginStateKey is the key under which the plugin saves its state in the cycle state.
+	pluginStateKey = "pluginState"
+)
+
+// Plugin is the scheduler plugin that enforces the exclusivity group (if any) defined on a CRP,
+// i.e., a CRP will not be placed on a cluster where another CRP of the same exclusivity group
+// has been scheduled or bound.
+type Plugin struct {
+	// The name of the plugin.
+	name string
+
+	// The framework handle.
+	handle framework.Handle
+}
+
+var (
+	// Verify that Plugin can connect to relevant extension points at compile time.
+	//
+	// This plugin leverages the following the extension points:
+	// * PreFilter
+	// * Filter
+	//
+	// Note that successful connection to any of the extension points implies that the
+	// plugin already implements the Plugin interface.
+	_ framework.PreFilterPlugin     = &Plugin{}
+	_ framework.FilterPlugin        = &Plugin{}
+	_ framework.PreemptionExtension = &Plugin{}
+	_ framework.EnqueueExtension    = &Plugin{}
+)
+
+// pluginOptions is the options for this plugin.
+type pluginOptions struct {
+	// The name of the plugin.
+	name string
+}
+
+// Option helps set up the plugin.
+type Option func(*pluginOptions)
+
+// defaultPluginOptions is the default options for this plugin.
+var defaultPluginOptions = pluginOptions{
+	name: defaultPluginName,
+}
+
+// WithName sets the name of the plugin.
+func WithName(name string) Option {
+	return func(o *pluginOptions) {
+		o.name = name
+	}
+}
+
+// New returns a new Plugin.
+func New(opts ...Option) Plugin {
+	options := defaultPluginOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return Plugin{
+		name: options.name,
+	}
+}
+
+// Name returns the name of the plugin.
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// SetUpWithFramework sets up this plugin with a scheduler framework.
+func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
+	p.handle = handle
+
+	// This plugin does not need to set up any informer.
+}
+
+// EventsToRegister returns the kinds of member cluster changes that the plugin is interested in.
+func (p *Plugin) EventsToRegister() []framework.ClusterEvent {
+	// The plugin reads binding information only; no cluster change is relevant.
+	return []framework.ClusterEvent{}
+}
+
+// readPluginState reads the plugin state from the cycle state.
+func (p *Plugin) readPluginState(state framework.CycleStatePluginReadWriter) (*pluginState, error) {
+	// Read from the cycle state.
+	ps, err := framework.ReadAs[*pluginState](state, framework.PluginStateKey(p.Name(), pluginStateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read value from the cycle state: %w", err)
+	}
+	return ps, nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package kubernetesversion
+
+import (
+	"context"
+	"fmt"
+
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	versionNotReportedReason      = "cluster does not report its Kubernetes version"
+	versionTooOldReasonTemplate   = "cluster runs Kubernetes %s, older than the min version %s"
+	versionTooNewReasonTemplate   = "cluster runs Kubernetes %s, newer than the max version %s"
+	invalidVersionRangeErrMessage = "failed to parse the Kubernetes version range"
+)
+
+// PreFilter allows the plugin to connect to the PreFilter extension point in the scheduling
+// framework.
+func (p *Plugin) PreFilter(
+	_ context.Context,
+	_ framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+) (status *framework.Status) {
+	noVersionRange := policy.Spec.Policy == nil ||
+		policy.Spec.Policy.KubernetesVersion == nil ||
+		(policy.Spec.Policy.KubernetesVersion.Min == "" && policy.Spec.Policy.KubernetesVersion.Max == "")
+	if noVersionRange {
+		// There is no Kubernetes version range to enforce; consider all clusters eligible
+		// for resource placement in the scope of this plugin.
+		//
+		// Note that this will set the cluster to skip the Filter stage for all clusters.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no Kubernetes version range to enforce")
+	}
+
+	return nil
+}
+
+// Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
+func (p *Plugin) Filter(
+	_ context.Context,
+	_ framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (status *framework.Status) {
+	// Note that this extension point assumes that previous extension point (PreFilter) has
+	// guaranteed that if scheduling policy reaches this stage, it must have a Kubernetes
+	// version range to enforce.
+	versionRange := policy.Spec.Policy.KubernetesVersion
+
+	version, err := p.clusterVersion(cluster)
+	if err != nil {
+		return framework.FromError(err, p.Name(), "failed to read the Kubernetes version of the cluster")
+	}
+	if version == nil {
+		return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), versionNotReportedReason)
+	}
+
+	// Note that the webhook rejects version ranges that cannot be parsed.
+	if versionRange.Min != "" {
+		minVersion, err := utilversion.ParseGeneric(versionRange.Min)
+		if err != nil {
+			return framework.FromError(err, p.Name(), invalidVersionRangeErrMessage)
+		}
+		if version.LessThan(minVersion) {
+			reason := fmt.Sprintf(versionTooOldReasonTemplate, version.String(), versionRange.Min)
+			return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reason)
+		}
+	}
+	if versionRange.Max != "" {
+		maxVersion, err := utilversion.ParseGeneric(versionRange.Max)
+		if err != nil {
+			return framework.FromError(err, p.Name(), invalidVersionRangeErrMessage)
+		}
+		compared := version
+		if len(maxVersion.Components()) < 3 {
+			// A max version without the patch version includes all the patch versions of its
+			// minor version.
+			compared = utilversion.MajorMinor(version.Major(), version.Minor())
+		}
+		if maxVersion.LessThan(compared) {
+			reason := fmt.Sprintf(versionTooNewReasonTemplate, version.String(), versionRange.Max)
+			return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reason)
+		}
+	}
+
+	// All done.
+	return nil
+}
+
+// clusterVersion returns the Kubernetes version a cluster reports; it returns nil if the cluster
+// does not report one.
+func (p *Plugin) clusterVersion(cluster *clusterv1beta1.MemberCluster) (*utilversion.Version, error) {
+	components := make([]uint, 0, 3)
+	for _, name := range []string{
+		propertyprovider.KubernetesMajorVersionProperty,
+		propertyprovider.KubernetesMinorVersionProperty,
+		propertyprovider.KubernetesPatchVersionProperty,
+	} {
+		q, err := p.propertyReader().Quantity(cluster, name)
+		if err != nil {
+			return nil, err
+		}
+		if q == nil {
+			return nil, nil
+		}
+		v, ok := q.AsInt64()
+		if !ok || v < 0 {
+			return nil, fmt.Errorf("property %s has an invalid value %s", name, q.String())
+		}
+		components = append(components, uint(v))
+	}
+	return utilversion.MajorMinor(components[0], components[1]).WithPatch(components[2]), nil
+}
@@ -0,0 +1,275 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package kubernetesversion
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	clusterName = "bravelion"
+)
+
+var (
+	p = New()
+
+	ignoreStatusErrorField = cmpopts.IgnoreFields(framework.Status{}, "err")
+)
+
+// TestPreFilter tests the PreFilter extension point of this plugin.
+func TestPreFilter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policy     *placementv1beta1.ClusterSchedulingPolicySnapshot
+		wantStatus *framework.Status
+	}{
+		{
+			name: "no scheduling policy",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: nil,
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.Skip, p.Name(), "no Kubernetes version range to enforce"),
+		},
+		{
+			name: "no Kubernetes version range",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickAllPlacementType,
+					},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.Skip, p.Name(), "no Kubernetes version range to enforce"),
+		},
+		{
+			name: "empty Kubernetes version range",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:     placementv1beta1.PickAllPlacementType,
+						KubernetesVersion: &placementv1beta1.KubernetesVersionRange{},
+					},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.Skip, p.Name(), "no Kubernetes version range to enforce"),
+		},
+		{
+			name: "min version",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:     placementv1beta1.PickAllPlacementType,
+						KubernetesVersion: &placementv1beta1.KubernetesVersionRange{Min: "1.28"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			state := framework.NewCycleState([]clusterv1beta1.MemberCluster{}, []*placementv1beta1.ClusterResourceBinding{})
+			status := p.PreFilter(ctx, state, tc.policy)
+			if diff := cmp.Diff(status, tc.wantStatus, cmp.AllowUnexported(framework.Status{}), ignoreStatusErrorField); diff != "" {
+				t.Errorf("PreFilter() status diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+// TestFilter tests the Filter extension point of this plugin.
+func TestFilter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policy     *placementv1beta1.ClusterSchedulingPolicySnapshot
+		cluster    *clusterv1beta1.MemberCluster
+		wantStatus *framework.Status
+	}{
+		{
+			name: "within range",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:     placementv1beta1.PickAllPlacementType,
+						KubernetesVersion: &placementv1beta1.KubernetesVersionRange{Min: "1.28", Max: "v1.30.4"},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						propertyprovider.KubernetesMajorVersionProperty: {Value: "1"},
+						propertyprovider.KubernetesMinorVersionProperty: {Value: "29"},
+						propertyprovider.KubernetesPatchVersionProperty: {Value: "8"},
+					},
+				},
+			},
+		},
+		{
+			name: "at min version without patch",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:     placementv1beta1.PickAllPlacementType,
+						KubernetesVersion: &placementv1beta1.KubernetesVersionRange{Min: "1.28"},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						propertyprovider.KubernetesMajorVersionProperty: {Value: "1"},
+						propertyprovider.KubernetesMinorVersionProperty: {Value: "28"},
+						propertyprovider.KubernetesPatchVersionProperty: {Value: "0"},
+					},
+				},
+			},
+		},
+		{
+			name: "older than min version",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:     placementv1beta1.PickAllPlacementType,
+						KubernetesVersion: &placementv1beta1.KubernetesVersionRange{Min: "1.28.3"},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						propertyprovider.KubernetesMajorVersionProperty: {Value: "1"},
+						propertyprovider.KubernetesMinorVersionProperty: {Value: "28"},
+						propertyprovider.KubernetesPatchVersionProperty: {Value: "2"},
+					},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), fmt.Sprintf(versionTooOldReasonTemplate, "1.28.2", "1.28.3")),
+		},
+		{
+			name: "any patch of max version without patch",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:     placementv1beta1.PickAllPlacementType,
+						KubernetesVersion: &placementv1beta1.KubernetesVersionRange{Max: "1.30"},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						propertyprovider.KubernetesMajorVersionProperty: {Value: "1"},
+						propertyprovider.KubernetesMinorVersionProperty: {Value: "30"},
+						propertyprovider.KubernetesPatchVersionProperty: {Value: "12"},
+					},
+				},
+			},
+		},
+		{
+			name: "newer than max version without patch",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:     placementv1beta1.PickAllPlacementType,
+						KubernetesVersion: &placementv1beta1.KubernetesVersionRange{Max: "1.30"},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						propertyprovider.KubernetesMajorVersionProperty: {Value: "1"},
+						propertyprovider.KubernetesMinorVersionProperty: {Value: "31"},
+						propertyprovider.KubernetesPatchVersionProperty: {Value: "0"},
+					},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), fmt.Sprintf(versionTooNewReasonTemplate, "1.31.0", "1.30")),
+		},
+		{
+			name: "newer than max version",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:     placementv1beta1.PickAllPlacementType,
+						KubernetesVersion: &placementv1beta1.KubernetesVersionRange{Max: "1.30.4"},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						propertyprovider.KubernetesMajorVersionProperty: {Value: "1"},
+						propertyprovider.KubernetesMinorVersionProperty: {Value: "30"},
+						propertyprovider.KubernetesPatchVersionProperty: {Value: "5"},
+					},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), fmt.Sprintf(versionTooNewReasonTemplate, "1.30.5", "1.30.4")),
+		},
+		{
+			name: "version not reported",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:     placementv1beta1.PickAllPlacementType,
+						KubernetesVersion: &placementv1beta1.KubernetesVersionRange{Min: "1.28"},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), versionNotReportedReason),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			state := framework.NewCycleState([]clusterv1beta1.MemberCluster{}, []*placementv1beta1.ClusterResourceBinding{})
+			status := p.Filter(ctx, state, tc.policy, tc.cluster)
+			if diff := cmp.Diff(status, tc.wantStatus, cmp.AllowUnexported(framework.Status{}), ignoreStatusErrorField); diff != "" {
+				t.Errorf("Filter() status diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
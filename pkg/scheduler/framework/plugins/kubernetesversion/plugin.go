@@ -0,0 +1,102 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package kubernetesversion features a scheduler plugin that filters clusters by the Kubernetes
+// version range a CRP requires (if any), per the Kubernetes versions the clusters report.
+package kubernetesversion
+
+import (
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// defaultPluginName is the default name of the plugin.
+	defaultPluginName = "KubernetesVersion"
+)
+
+// Plugin is the scheduler plugin that enforces the Kubernetes version range (if any) defined
+// on a CRP.
+type Plugin struct {
+	// The name of the plugin.
+	name string
+
+	// The framework handle.
+	handle framework.Handle
+}
+
+var (
+	// Verify that Plugin can connect to relevant extension points at compile time.
+	//
+	// This plugin leverages the following the extension points:
+	// * PreFilter
+	// * Filter
+	//
+	// Note that successful connection to any of the extension points implies that the
+	// plugin already implements the Plugin interface.
+	_ framework.PreFilterPlugin  = &Plugin{}
+	_ framework.FilterPlugin     = &Plugin{}
+	_ framework.EnqueueExtension = &Plugin{}
+)
+
+// pluginOptions is the options for this plugin.
+type pluginOptions struct {
+	// The name of the plugin.
+	name string
+}
+
+// Option helps set up the plugin.
+type Option func(*pluginOptions)
+
+// defaultPluginOptions is the default options for this plugin.
+var defaultPluginOptions = pluginOptions{
+	name: defaultPluginName,
+}
+
+// WithName sets the name of the plugin.
+func WithName(name string) Option {
+	return func(o *pluginOptions) {
+		o.name = name
+	}
+}
+
+// New returns a new Plugin.
+func New(opts ...Option) Plugin {
+	options := defaultPluginOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return Plugin{
+		name: options.name,
+	}
+}
+
+// Name returns the name of the plugin.
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// SetUpWithFramework sets up this plugin with a scheduler framework.
+func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
+	p.handle = handle
+
+	// This plugin does not need to set up any informer.
+}
+
+// propertyReader returns the property reader of the framework the plugin is set up with, if any.
+func (p *Plugin) propertyReader() *framework.PropertyReader {
+	if p.handle == nil {
+		return nil
+	}
+	return p.handle.PropertyReader()
+}
+
+// EventsToRegister returns the kinds of member cluster changes that the plugin is interested in.
+func (p *Plugin) EventsToRegister() []framework.ClusterEvent {
+	// The plugin reads the Kubernetes versions (non-resource properties) of clusters only.
+	return []framework.ClusterEvent{
+		framework.ClusterPropertyChanged,
+	}
+}
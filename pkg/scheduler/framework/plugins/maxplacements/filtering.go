@@ -0,0 +1,130 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package maxplacements
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	invalidMaxPlacementsReasonTemplate = "cluster has an invalid value %q for label %s"
+	clusterAtCapacityReasonTemplate    = "cluster hosts %d other placement(s), reaching its max of %d"
+)
+
+// pluginState is the state this plugin saves in the cycle state.
+type pluginState struct {
+	// placementCounts maps the names of clusters to the number of other placements that have been
+	// scheduled or bound to them.
+	placementCounts map[string]int
+}
+
+// PreFilter allows the plugin to connect to the PreFilter extension point in the scheduling
+// framework.
+//
+// Note that the scheduler will not run this extension point in parallel.
+func (p *Plugin) PreFilter(
+	ctx context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+) (status *framework.Status) {
+	capped := false
+	for _, cluster := range state.ListClusters() {
+		if _, ok := cluster.Labels[clusterv1beta1.MaxPlacementsLabel]; ok {
+			capped = true
+			break
+		}
+	}
+	if !capped {
+		// No cluster caps the number of placements; skip.
+		//
+		// Note that this will lead the scheduler to skip this plugin in the next stage
+		// (Filter).
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no cluster caps the number of placements")
+	}
+	crpName := policy.Labels[placementv1beta1.CRPTrackingLabel]
+
+	// Find all the bindings in the fleet.
+	bindingList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := p.handle.Client().List(ctx, bindingList); err != nil {
+		return framework.FromError(err, p.Name(), "failed to list bindings")
+	}
+
+	placementsByCluster := make(map[string]sets.Set[string])
+	for idx := range bindingList.Items {
+		binding := &bindingList.Items[idx]
+		owner := binding.Labels[placementv1beta1.CRPTrackingLabel]
+		switch {
+		case owner == crpName:
+			// A placement does not count against itself.
+		case !binding.DeletionTimestamp.IsZero() || binding.Spec.State == placementv1beta1.BindingStateUnscheduled:
+			// The binding is being removed from the cluster; it no longer counts.
+		default:
+			if _, ok := placementsByCluster[binding.Spec.TargetCluster]; !ok {
+				placementsByCluster[binding.Spec.TargetCluster] = sets.New[string]()
+			}
+			placementsByCluster[binding.Spec.TargetCluster].Insert(owner)
+		}
+	}
+
+	ps := &pluginState{
+		placementCounts: make(map[string]int, len(placementsByCluster)),
+	}
+	for cluster, placements := range placementsByCluster {
+		ps.placementCounts[cluster] = placements.Len()
+	}
+
+	// Save the plugin state.
+	framework.WriteAs(state, framework.PluginStateKey(p.Name(), pluginStateKey), ps)
+
+	// All done.
+	return nil
+}
+
+// Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
+func (p *Plugin) Filter(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	_ *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (status *framework.Status) {
+	val, ok := cluster.Labels[clusterv1beta1.MaxPlacementsLabel]
+	if !ok {
+		// The cluster does not cap the number of placements.
+		return nil
+	}
+	// Note that the webhook rejects invalid values; the check here is for clusters labelled
+	// before the webhook was set up.
+	maxPlacements, err := strconv.Atoi(val)
+	if err != nil || maxPlacements < 0 {
+		reason := fmt.Sprintf(invalidMaxPlacementsReasonTemplate, val, clusterv1beta1.MaxPlacementsLabel)
+		return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reason)
+	}
+
+	// Read the plugin state.
+	ps, err := framework.ReadAs[*pluginState](state, framework.PluginStateKey(p.Name(), pluginStateKey))
+	if err != nil {
+		// This branch should never be reached, as for any cluster with the label, a plugin state
+		// has been set at the PreFilter extension point.
+		return framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	// The state is safe for concurrent reads.
+	if count := ps.placementCounts[cluster.Name]; count >= maxPlacements {
+		reason := fmt.Sprintf(clusterAtCapacityReasonTemplate, count, maxPlacements)
+		return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reason)
+	}
+
+	// All done.
+	return nil
+}
@@ -0,0 +1,302 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package maxplacements
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	crpName      = "test-crp"
+	clusterName  = "bravelion"
+	clusterName2 = "smartfish"
+	clusterName3 = "singingbutterfly"
+)
+
+var (
+	cmpStatusOptions = cmp.Options{
+		cmpopts.IgnoreFields(framework.Status{}, "reasons", "err"),
+		cmp.AllowUnexported(framework.Status{}),
+	}
+)
+
+// Mock framework.Handle interface for set up the plugin.
+type MockHandle struct {
+	client client.Client
+}
+
+var (
+	_ framework.Handle = &MockHandle{}
+)
+
+func (mh *MockHandle) Client() client.Client               { return mh.client }
+func (mh *MockHandle) Manager() ctrl.Manager               { return nil }
+func (mh *MockHandle) UncachedReader() client.Reader       { return nil }
+func (mh *MockHandle) EventRecorder() record.EventRecorder { return nil }
+func (mh *MockHandle) ClusterEligibilityChecker() *clustereligibilitychecker.ClusterEligibilityChecker {
+	return nil
+}
+func (mh *MockHandle) PropertyReader() *framework.PropertyReader { return nil }
+
+// TestPreFilterAndFilter tests the PreFilter and Filter methods.
+func TestPreFilterAndFilter(t *testing.T) {
+	// The first cluster hosts the placement itself and two other placements; the second cluster hosts
+	// one other placement (with two bindings); the third cluster hosts one placement that is being
+	// removed.
+	objs := []client.Object{
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-1",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: crpName,
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateBound,
+				TargetCluster: clusterName,
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-2",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: "db-crp",
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateBound,
+				TargetCluster: clusterName,
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-3",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: "cache-crp",
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateScheduled,
+				TargetCluster: clusterName,
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-4",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: "db-crp",
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateBound,
+				TargetCluster: clusterName2,
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-5",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: "db-crp",
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateScheduled,
+				TargetCluster: clusterName2,
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-6",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: "cache-crp",
+				},
+				DeletionTimestamp: &metav1.Time{Time: time.Now()},
+				Finalizers:        []string{"test-finalizer"},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateBound,
+				TargetCluster: clusterName3,
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-7",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: "db-crp",
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateUnscheduled,
+				TargetCluster: clusterName3,
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want nil", err)
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-1", crpName),
+			Labels: map[string]string{
+				placementv1beta1.CRPTrackingLabel: crpName,
+			},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		clusters      []clusterv1beta1.MemberCluster
+		wantPreFilter *framework.Status
+		wantFilter    map[string]*framework.Status
+	}{
+		{
+			name: "no cluster caps the number of placements",
+			clusters: []clusterv1beta1.MemberCluster{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: clusterName,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: clusterName2,
+					},
+				},
+			},
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "clusters below their caps",
+			clusters: []clusterv1beta1.MemberCluster{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   clusterName,
+						Labels: map[string]string{clusterv1beta1.MaxPlacementsLabel: "3"},
+					},
+				},
+				// Multiple bindings of the same placement count once.
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   clusterName2,
+						Labels: map[string]string{clusterv1beta1.MaxPlacementsLabel: "2"},
+					},
+				},
+				// Bindings that are deleting or unscheduled do not count.
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   clusterName3,
+						Labels: map[string]string{clusterv1beta1.MaxPlacementsLabel: "1"},
+					},
+				},
+			},
+			wantFilter: map[string]*framework.Status{
+				clusterName:  nil,
+				clusterName2: nil,
+				clusterName3: nil,
+			},
+		},
+		{
+			name: "clusters at their caps",
+			clusters: []clusterv1beta1.MemberCluster{
+				// The placement itself does not count.
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   clusterName,
+						Labels: map[string]string{clusterv1beta1.MaxPlacementsLabel: "2"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   clusterName2,
+						Labels: map[string]string{clusterv1beta1.MaxPlacementsLabel: "1"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   clusterName3,
+						Labels: map[string]string{clusterv1beta1.MaxPlacementsLabel: "0"},
+					},
+				},
+			},
+			wantFilter: map[string]*framework.Status{
+				clusterName:  framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+				clusterName2: framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+				clusterName3: framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+			},
+		},
+		{
+			name: "mixed clusters",
+			clusters: []clusterv1beta1.MemberCluster{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   clusterName,
+						Labels: map[string]string{clusterv1beta1.MaxPlacementsLabel: "invalid"},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: clusterName2,
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   clusterName3,
+						Labels: map[string]string{clusterv1beta1.MaxPlacementsLabel: "-1"},
+					},
+				},
+			},
+			wantFilter: map[string]*framework.Status{
+				clusterName:  framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+				clusterName2: nil,
+				clusterName3: framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New()
+			p.SetUpWithFramework(&MockHandle{client: fakeClient})
+			state := framework.NewCycleState(tc.clusters, nil)
+			got := p.PreFilter(context.Background(), state, policy)
+			if diff := cmp.Diff(tc.wantPreFilter, got, cmpStatusOptions); diff != "" {
+				t.Fatalf("PreFilter() status mismatch (-want, +got):\n%s", diff)
+			}
+
+			for idx := range tc.clusters {
+				cluster := &tc.clusters[idx]
+				want, ok := tc.wantFilter[cluster.Name]
+				if !ok {
+					continue
+				}
+				got := p.Filter(context.Background(), state, policy, cluster)
+				if diff := cmp.Diff(want, got, cmpStatusOptions); diff != "" {
+					t.Errorf("Filter(%s) status mismatch (-want, +got):\n%s", cluster.Name, diff)
+				}
+			}
+		})
+	}
+}
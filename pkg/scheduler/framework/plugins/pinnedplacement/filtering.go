@@ -0,0 +1,92 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package pinnedplacement
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	notPinnedClusterReasonTemplate = "cluster is not pinned by PinnedPlacement %s"
+)
+
+// pluginState is the state this plugin saves in the cycle state.
+type pluginState struct {
+	// pinned is the set of names of the clusters pinned for the placement.
+	pinned sets.Set[string]
+}
+
+// PreFilter allows the plugin to connect to the PreFilter extension point in the scheduling
+// framework.
+//
+// Note that the scheduler will not run this extension point in parallel.
+func (p *Plugin) PreFilter(
+	ctx context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+) (status *framework.Status) {
+	crpName := policy.Labels[placementv1beta1.CRPTrackingLabel]
+
+	pinnedPlacement := &placementv1alpha1.PinnedPlacement{}
+	if err := p.handle.Client().Get(ctx, types.NamespacedName{Name: crpName}, pinnedPlacement); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The placement is not pinned; skip.
+			//
+			// Note that this will lead the scheduler to skip this plugin in the next stage
+			// (Filter).
+			return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no PinnedPlacement is found")
+		}
+		return framework.FromError(err, p.Name(), "failed to get the PinnedPlacement")
+	}
+	if pinnedPlacement.Spec.Mode != placementv1alpha1.PinnedPlacementModeImport {
+		// The PinnedPlacement only exports the scheduling decisions; skip.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "the PinnedPlacement is not in the Import mode")
+	}
+
+	// Save the plugin state.
+	ps := &pluginState{
+		pinned: sets.New(pinnedPlacement.Spec.ClusterNames...),
+	}
+	framework.WriteAs(state, framework.PluginStateKey(p.Name(), pluginStateKey), ps)
+
+	// All done.
+	return nil
+}
+
+// Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
+func (p *Plugin) Filter(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (status *framework.Status) {
+	// Read the plugin state.
+	ps, err := p.readPluginState(state)
+	if err != nil {
+		// This branch should never be reached, as for any pinned placement, a plugin state has
+		// been set at the PreFilter extension point.
+		return framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	// The state is safe for concurrent reads.
+	if !ps.pinned.Has(cluster.Name) {
+		reason := fmt.Sprintf(notPinnedClusterReasonTemplate, policy.Labels[placementv1beta1.CRPTrackingLabel])
+		return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reason)
+	}
+
+	// All done.
+	return nil
+}
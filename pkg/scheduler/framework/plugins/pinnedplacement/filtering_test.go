@@ -0,0 +1,160 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package pinnedplacement
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	crpName      = "test-crp"
+	clusterName  = "bravelion"
+	clusterName2 = "smartfish"
+)
+
+var (
+	cmpStatusOptions = cmp.Options{
+		cmpopts.IgnoreFields(framework.Status{}, "reasons", "err"),
+		cmp.AllowUnexported(framework.Status{}),
+	}
+)
+
+// Mock framework.Handle interface for set up the plugin.
+type MockHandle struct {
+	client client.Client
+}
+
+var (
+	_ framework.Handle = &MockHandle{}
+)
+
+func (mh *MockHandle) Client() client.Client               { return mh.client }
+func (mh *MockHandle) Manager() ctrl.Manager               { return nil }
+func (mh *MockHandle) UncachedReader() client.Reader       { return nil }
+func (mh *MockHandle) EventRecorder() record.EventRecorder { return nil }
+func (mh *MockHandle) ClusterEligibilityChecker() *clustereligibilitychecker.ClusterEligibilityChecker {
+	return nil
+}
+func (mh *MockHandle) PropertyReader() *framework.PropertyReader { return nil }
+
+// TestPreFilterAndFilter tests the PreFilter and Filter methods.
+func TestPreFilterAndFilter(t *testing.T) {
+	testCases := []struct {
+		name          string
+		objs          []client.Object
+		wantPreFilter *framework.Status
+		wantFilter    map[string]*framework.Status
+	}{
+		{
+			name: "no pinned placement",
+			objs: []client.Object{
+				&placementv1alpha1.PinnedPlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "other-crp",
+					},
+					Spec: placementv1alpha1.PinnedPlacementSpec{
+						Mode:         placementv1alpha1.PinnedPlacementModeImport,
+						ClusterNames: []string{clusterName},
+					},
+				},
+			},
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "pinned placement in the Export mode",
+			objs: []client.Object{
+				&placementv1alpha1.PinnedPlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1alpha1.PinnedPlacementSpec{
+						Mode:         placementv1alpha1.PinnedPlacementModeExport,
+						ClusterNames: []string{clusterName},
+					},
+				},
+			},
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "pinned placement in the Import mode",
+			objs: []client.Object{
+				&placementv1alpha1.PinnedPlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1alpha1.PinnedPlacementSpec{
+						Mode:         placementv1alpha1.PinnedPlacementModeImport,
+						ClusterNames: []string{clusterName},
+					},
+				},
+			},
+			wantFilter: map[string]*framework.Status{
+				clusterName:  nil,
+				clusterName2: framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := placementv1alpha1.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme() = %v, want nil", err)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objs...).Build()
+			p := New()
+			p.SetUpWithFramework(&MockHandle{client: fakeClient})
+
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("%s-1", crpName),
+					Labels: map[string]string{
+						placementv1beta1.CRPTrackingLabel: crpName,
+					},
+				},
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickAllPlacementType,
+					},
+				},
+			}
+			state := framework.NewCycleState(nil, nil)
+			got := p.PreFilter(context.Background(), state, policy)
+			if diff := cmp.Diff(tc.wantPreFilter, got, cmpStatusOptions); diff != "" {
+				t.Fatalf("PreFilter() status mismatch (-want, +got):\n%s", diff)
+			}
+
+			for name, want := range tc.wantFilter {
+				cluster := &clusterv1beta1.MemberCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: name,
+					},
+				}
+				got := p.Filter(context.Background(), state, policy, cluster)
+				if diff := cmp.Diff(want, got, cmpStatusOptions); diff != "" {
+					t.Errorf("Filter(%s) status mismatch (-want, +got):\n%s", name, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package placementantiaffinity
+
+import (
+	"context"
+	"fmt"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	occupiedClusterReasonTemplate = "cluster is occupied by placement %s, which the placement has a required anti-affinity to"
+)
+
+// filterState is the state this plugin saves in the cycle state for the Filter extension point.
+type filterState struct {
+	// occupiedBy maps the names of clusters that have been occupied by placements selected by the
+	// required placement anti-affinity terms to the names of the occupying placements.
+	occupiedBy map[string]string
+}
+
+// PreFilter allows the plugin to connect to the PreFilter extension point in the scheduling
+// framework.
+//
+// Note that the scheduler will not run this extension point in parallel.
+func (p *Plugin) PreFilter(
+	ctx context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+) (status *framework.Status) {
+	noRequiredPlacementAntiAffinityTerms := policy.Spec.Policy == nil ||
+		policy.Spec.Policy.Affinity == nil ||
+		policy.Spec.Policy.Affinity.PlacementAntiAffinity == nil ||
+		len(policy.Spec.Policy.Affinity.PlacementAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution) == 0
+	if noRequiredPlacementAntiAffinityTerms {
+		// There are no required placement anti-affinity terms; skip.
+		//
+		// Note that this will lead the scheduler to skip this plugin in the next stage
+		// (Filter).
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no required placement anti-affinity terms specified")
+	}
+	crpName := policy.Labels[placementv1beta1.CRPTrackingLabel]
+
+	fs := &filterState{
+		occupiedBy: make(map[string]string),
+	}
+	terms := policy.Spec.Policy.Affinity.PlacementAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	for idx := range terms {
+		occupied, err := p.occupiedClusters(ctx, &terms[idx], crpName)
+		if err != nil {
+			return framework.FromError(err, p.Name(), "failed to find the clusters occupied by the selected placements")
+		}
+		for cluster, owner := range occupied {
+			fs.occupiedBy[cluster] = owner
+		}
+	}
+
+	// Save the plugin state.
+	framework.WriteAs(state, framework.PluginStateKey(p.Name(), filterStateKey), fs)
+
+	if len(fs.occupiedBy) == 0 {
+		// No cluster has been occupied by the selected placements; skip.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no cluster is occupied by the selected placements")
+	}
+
+	// All done.
+	return nil
+}
+
+// Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
+func (p *Plugin) Filter(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	_ *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (status *framework.Status) {
+	// Read the plugin state.
+	fs, err := framework.ReadAs[*filterState](state, framework.PluginStateKey(p.Name(), filterStateKey))
+	if err != nil {
+		// This branch should never be reached, as for any policy with required placement
+		// anti-affinity terms, a plugin state has been set at the PreFilter extension point.
+		return framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	// The state is safe for concurrent reads.
+	if owner, ok := fs.occupiedBy[cluster.Name]; ok {
+		reason := fmt.Sprintf(occupiedClusterReasonTemplate, owner)
+		return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reason)
+	}
+
+	// All done.
+	return nil
+}
@@ -0,0 +1,233 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package placementantiaffinity
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	crpName      = "test-crp"
+	dbCRPName    = "db-crp"
+	cacheCRPName = "cache-crp"
+	clusterName  = "bravelion"
+	clusterName2 = "smartfish"
+	clusterName3 = "singingbutterfly"
+)
+
+var (
+	cmpStatusOptions = cmp.Options{
+		cmpopts.IgnoreFields(framework.Status{}, "reasons", "err"),
+		cmp.AllowUnexported(framework.Status{}),
+	}
+
+	dbTerm    = placementv1beta1.PlacementAffinityTerm{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}}
+	cacheTerm = placementv1beta1.PlacementAffinityTerm{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "cache"}}}
+)
+
+// Mock framework.Handle interface for set up the plugin.
+type MockHandle struct {
+	client client.Client
+}
+
+var (
+	_ framework.Handle = &MockHandle{}
+)
+
+func (mh *MockHandle) Client() client.Client               { return mh.client }
+func (mh *MockHandle) Manager() ctrl.Manager               { return nil }
+func (mh *MockHandle) UncachedReader() client.Reader       { return nil }
+func (mh *MockHandle) EventRecorder() record.EventRecorder { return nil }
+func (mh *MockHandle) ClusterEligibilityChecker() *clustereligibilitychecker.ClusterEligibilityChecker {
+	return nil
+}
+func (mh *MockHandle) PropertyReader() *framework.PropertyReader { return nil }
+
+// TestPreFilterAndFilter tests the PreFilter and Filter methods.
+func TestPreFilterAndFilter(t *testing.T) {
+	// A db placement is on the first cluster, the placement itself and a cache placement are on the
+	// second cluster, and the cache placement is being removed from the third cluster.
+	objs := []client.Object{
+		&placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   crpName,
+				Labels: map[string]string{"app": "db"},
+			},
+		},
+		&placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   dbCRPName,
+				Labels: map[string]string{"app": "db"},
+			},
+		},
+		&placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   cacheCRPName,
+				Labels: map[string]string{"app": "cache"},
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-1",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: crpName,
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateBound,
+				TargetCluster: clusterName2,
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-2",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: dbCRPName,
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateBound,
+				TargetCluster: clusterName,
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-3",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: cacheCRPName,
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateScheduled,
+				TargetCluster: clusterName2,
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-4",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: cacheCRPName,
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateUnscheduled,
+				TargetCluster: clusterName3,
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want nil", err)
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	testCases := []struct {
+		name          string
+		antiAffinity  *placementv1beta1.PlacementAntiAffinity
+		wantPreFilter *framework.Status
+		wantFilter    map[string]*framework.Status
+	}{
+		{
+			name:          "no placement anti-affinity",
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "preferred terms only",
+			antiAffinity: &placementv1beta1.PlacementAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.WeightedPlacementAffinityTerm{{Weight: 10, PlacementAffinityTerm: dbTerm}},
+			},
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "no cluster is occupied by the selected placements",
+			antiAffinity: &placementv1beta1.PlacementAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.PlacementAffinityTerm{
+					{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+				},
+			},
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "clusters occupied by the selected placements",
+			antiAffinity: &placementv1beta1.PlacementAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.PlacementAffinityTerm{dbTerm, cacheTerm},
+			},
+			wantFilter: map[string]*framework.Status{
+				// The placement itself is never selected.
+				clusterName:  framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+				clusterName2: framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+				// The binding on the cluster is being removed.
+				clusterName3: nil,
+			},
+		},
+		{
+			name: "cluster occupied by the placement itself only",
+			antiAffinity: &placementv1beta1.PlacementAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.PlacementAffinityTerm{dbTerm},
+			},
+			wantFilter: map[string]*framework.Status{
+				clusterName:  framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+				clusterName2: nil,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New()
+			p.SetUpWithFramework(&MockHandle{client: fakeClient})
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("%s-1", crpName),
+					Labels: map[string]string{
+						placementv1beta1.CRPTrackingLabel: crpName,
+					},
+				},
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						Affinity: &placementv1beta1.Affinity{
+							PlacementAntiAffinity: tc.antiAffinity,
+						},
+					},
+				},
+			}
+			state := framework.NewCycleState(nil, nil)
+			got := p.PreFilter(context.Background(), state, policy)
+			if diff := cmp.Diff(tc.wantPreFilter, got, cmpStatusOptions); diff != "" {
+				t.Fatalf("PreFilter() status mismatch (-want, +got):\n%s", diff)
+			}
+
+			for name, want := range tc.wantFilter {
+				cluster := &clusterv1beta1.MemberCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: name,
+					},
+				}
+				got := p.Filter(context.Background(), state, policy, cluster)
+				if diff := cmp.Diff(want, got, cmpStatusOptions); diff != "" {
+					t.Errorf("Filter(%s) status mismatch (-want, +got):\n%s", name, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package placementantiaffinity features a scheduler plugin that keeps a placement away from the
+// clusters where other placements selected by its placement anti-affinity terms (if any) have been
+// scheduled or bound.
+package placementantiaffinity
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// defaultPluginName is the default name of the plugin.
+	defaultPluginName = "PlacementAntiAffinity"
+
+	// filterStateKey is the key under which the plugin saves its state for the Filter extension
+	// point in the cycle state.
+	filterStateKey = "filterState"
+	// scoreStateKey is the key under which the plugin saves its state for the Score extension
+	// point in the cycle state.
+	scoreStateKey = "scoreState"
+)
+
+// Plugin is the scheduler plugin that enforces the placement anti-affinity terms (if any) defined
+// on a CRP.
+type Plugin struct {
+	// The name of the plugin.
+	name string
+
+	// The framework handle.
+	handle framework.Handle
+}
+
+var (
+	// Verify that Plugin can connect to relevant extension points at compile time.
+	//
+	// This plugin leverages the following the extension points:
+	// * PreFilter
+	// * Filter
+	// * PreScore
+	// * Score
+	//
+	// Note that successful connection to any of the extension points implies that the
+	// plugin already implements the Plugin interface.
+	_ framework.PreFilterPlugin  = &Plugin{}
+	_ framework.FilterPlugin     = &Plugin{}
+	_ framework.PreScorePlugin   = &Plugin{}
+	_ framework.ScorePlugin      = &Plugin{}
+	_ framework.EnqueueExtension = &Plugin{}
+)
+
+// pluginOptions is the options for this plugin.
+type pluginOptions struct {
+	// The name of the plugin.
+	name string
+}
+
+// Option helps set up the plugin.
+type Option func(*pluginOptions)
+
+// defaultPluginOptions is the default options for this plugin.
+var defaultPluginOptions = pluginOptions{
+	name: defaultPluginName,
+}
+
+// WithName sets the name of the plugin.
+func WithName(name string) Option {
+	return func(o *pluginOptions) {
+		o.name = name
+	}
+}
+
+// New returns a new Plugin.
+func New(opts ...Option) Plugin {
+	options := defaultPluginOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return Plugin{
+		name: options.name,
+	}
+}
+
+// Name returns the name of the plugin.
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// SetUpWithFramework sets up this plugin with a scheduler framework.
+func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
+	p.handle = handle
+
+	// This plugin does not need to set up any informer.
+}
+
+// EventsToRegister returns the kinds of member cluster changes that the plugin is interested in.
+func (p *Plugin) EventsToRegister() []framework.ClusterEvent {
+	// The plugin reads placement and binding information only; no cluster change is relevant.
+	return []framework.ClusterEvent{}
+}
+
+// occupiedClusters returns the names of the clusters where the placements selected by a placement
+// anti-affinity term, other than the placement itself, have been scheduled or bound, mapped to the
+// names of the selected placements.
+func (p *Plugin) occupiedClusters(ctx context.Context, term *placementv1beta1.PlacementAffinityTerm, crpName string) (map[string]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&term.LabelSelector)
+	if err != nil {
+		// This branch should never be reached, as the webhook rejects invalid label selectors.
+		return nil, fmt.Errorf("failed to parse the label selector of the placement anti-affinity term: %w", err)
+	}
+	crpList := &placementv1beta1.ClusterResourcePlacementList{}
+	if err := p.handle.Client().List(ctx, crpList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list the placements selected by the placement anti-affinity term: %w", err)
+	}
+
+	occupied := make(map[string]string)
+	for idx := range crpList.Items {
+		selected := crpList.Items[idx].Name
+		if selected == crpName {
+			// A placement does not repel itself.
+			continue
+		}
+		bindingList := &placementv1beta1.ClusterResourceBindingList{}
+		if err := p.handle.Client().List(ctx, bindingList, client.MatchingLabels{placementv1beta1.CRPTrackingLabel: selected}); err != nil {
+			return nil, fmt.Errorf("failed to list the bindings of placement %s: %w", selected, err)
+		}
+		for bidx := range bindingList.Items {
+			binding := &bindingList.Items[bidx]
+			if !binding.DeletionTimestamp.IsZero() || binding.Spec.State == placementv1beta1.BindingStateUnscheduled {
+				// The binding is being removed from the cluster; the cluster is no longer occupied by it.
+				continue
+			}
+			occupied[binding.Spec.TargetCluster] = selected
+		}
+	}
+	return occupied, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package placementantiaffinity
+
+import (
+	"context"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+// scoreState is the state this plugin saves in the cycle state for the Score extension point.
+type scoreState struct {
+	// penalties maps the names of clusters that have been occupied by placements selected by the
+	// preferred placement anti-affinity terms to the sums of the weights of the matching terms.
+	penalties map[string]int
+}
+
+// PreScore allows the plugin to connect to the PreScore extension point in the scheduling
+// framework.
+func (p *Plugin) PreScore(
+	ctx context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+) (status *framework.Status) {
+	noPreferredPlacementAntiAffinityTerms := policy.Spec.Policy == nil ||
+		policy.Spec.Policy.Affinity == nil ||
+		policy.Spec.Policy.Affinity.PlacementAntiAffinity == nil ||
+		len(policy.Spec.Policy.Affinity.PlacementAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution) == 0
+	if noPreferredPlacementAntiAffinityTerms {
+		// There are no preferred placement anti-affinity terms specified in the scheduling
+		// policy; skip the step.
+		//
+		// Note that this will also skip the Score() extension point for the plugin.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no preferred placement anti-affinity terms specified")
+	}
+	crpName := policy.Labels[placementv1beta1.CRPTrackingLabel]
+
+	ss := &scoreState{
+		penalties: make(map[string]int),
+	}
+	terms := policy.Spec.Policy.Affinity.PlacementAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	for idx := range terms {
+		occupied, err := p.occupiedClusters(ctx, &terms[idx].PlacementAffinityTerm, crpName)
+		if err != nil {
+			return framework.FromError(err, p.Name(), "failed to find the clusters occupied by the selected placements")
+		}
+		for cluster := range occupied {
+			ss.penalties[cluster] += int(terms[idx].Weight)
+		}
+	}
+
+	// Save the plugin state.
+	framework.WriteAs(state, framework.PluginStateKey(p.Name(), scoreStateKey), ss)
+
+	// All done.
+	return nil
+}
+
+// Score allows the plugin to connect to the Score extension point in the scheduling framework.
+func (p *Plugin) Score(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	_ *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (score *framework.ClusterScore, status *framework.Status) {
+	// Read the plugin state.
+	ss, err := framework.ReadAs[*scoreState](state, framework.PluginStateKey(p.Name(), scoreStateKey))
+	if err != nil {
+		// This branch should never be reached, as a state has been set
+		// in the PreScore stage.
+		return nil, framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	// The state is safe for concurrent reads.
+	return &framework.ClusterScore{AffinityScore: -ss.penalties[cluster.Name]}, nil
+}
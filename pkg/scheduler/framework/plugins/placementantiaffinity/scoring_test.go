@@ -0,0 +1,179 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package placementantiaffinity
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+// TestPreScoreAndScore tests the PreScore and Score methods.
+func TestPreScoreAndScore(t *testing.T) {
+	// A db placement is on the first cluster, the placement itself and a cache placement are on the
+	// second cluster, and the cache placement is being removed from the third cluster.
+	objs := []client.Object{
+		&placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   crpName,
+				Labels: map[string]string{"app": "db"},
+			},
+		},
+		&placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   dbCRPName,
+				Labels: map[string]string{"app": "db"},
+			},
+		},
+		&placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   cacheCRPName,
+				Labels: map[string]string{"app": "cache"},
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-1",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: crpName,
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateBound,
+				TargetCluster: clusterName2,
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-2",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: dbCRPName,
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateBound,
+				TargetCluster: clusterName,
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-3",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: cacheCRPName,
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateScheduled,
+				TargetCluster: clusterName2,
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "binding-4",
+				Labels: map[string]string{
+					placementv1beta1.CRPTrackingLabel: cacheCRPName,
+				},
+			},
+			Spec: placementv1beta1.ResourceBindingSpec{
+				State:         placementv1beta1.BindingStateUnscheduled,
+				TargetCluster: clusterName3,
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("AddToScheme() = %v, want nil", err)
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	testCases := []struct {
+		name         string
+		antiAffinity *placementv1beta1.PlacementAntiAffinity
+		wantPreScore *framework.Status
+		wantScores   map[string]*framework.ClusterScore
+	}{
+		{
+			name:         "no placement anti-affinity",
+			wantPreScore: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "required terms only",
+			antiAffinity: &placementv1beta1.PlacementAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.PlacementAffinityTerm{dbTerm},
+			},
+			wantPreScore: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "preferred terms",
+			antiAffinity: &placementv1beta1.PlacementAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.WeightedPlacementAffinityTerm{
+					{Weight: 30, PlacementAffinityTerm: dbTerm},
+					{Weight: 20, PlacementAffinityTerm: cacheTerm},
+					{Weight: 10, PlacementAffinityTerm: placementv1beta1.PlacementAffinityTerm{LabelSelector: metav1.LabelSelector{}}},
+				},
+			},
+			wantScores: map[string]*framework.ClusterScore{
+				// The empty selector selects all placements other than the placement itself.
+				clusterName:  {AffinityScore: -40},
+				clusterName2: {AffinityScore: -30},
+				clusterName3: {AffinityScore: 0},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New()
+			p.SetUpWithFramework(&MockHandle{client: fakeClient})
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("%s-1", crpName),
+					Labels: map[string]string{
+						placementv1beta1.CRPTrackingLabel: crpName,
+					},
+				},
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						Affinity: &placementv1beta1.Affinity{
+							PlacementAntiAffinity: tc.antiAffinity,
+						},
+					},
+				},
+			}
+			state := framework.NewCycleState(nil, nil)
+			got := p.PreScore(context.Background(), state, policy)
+			if diff := cmp.Diff(tc.wantPreScore, got, cmpStatusOptions); diff != "" {
+				t.Fatalf("PreScore() status mismatch (-want, +got):\n%s", diff)
+			}
+
+			for name, want := range tc.wantScores {
+				cluster := &clusterv1beta1.MemberCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: name,
+					},
+				}
+				score, status := p.Score(context.Background(), state, policy, cluster)
+				if !status.IsSuccess() {
+					t.Fatalf("Score(%s) status = %v, want success", name, status)
+				}
+				if diff := cmp.Diff(want, score); diff != "" {
+					t.Errorf("Score(%s) score mismatch (-want, +got):\n%s", name, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package preemption
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	preemptedClusterReasonTemplate = "placement has been preempted from the cluster by scheduling policy snapshot %s"
+)
+
+// pluginState is the state this plugin saves in the cycle state.
+type pluginState struct {
+	// preemptedBy maps the names of clusters that the placement has been preempted from, and
+	// whose preempted bindings are still around, to the names of the policy snapshots of the
+	// preemptors.
+	preemptedBy map[string]string
+}
+
+// PreFilter allows the plugin to connect to the PreFilter extension point in the scheduling
+// framework.
+//
+// Note that the scheduler will not run this extension point in parallel.
+func (p *Plugin) PreFilter(
+	ctx context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+) (status *framework.Status) {
+	crpName := policy.Labels[placementv1beta1.CRPTrackingLabel]
+
+	// Find the bindings of the placement that have been preempted.
+	bindingList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := p.handle.Client().List(ctx, bindingList, client.MatchingLabels{placementv1beta1.CRPTrackingLabel: crpName}); err != nil {
+		return framework.FromError(err, p.Name(), "failed to list bindings of the placement")
+	}
+	ps := &pluginState{
+		preemptedBy: make(map[string]string),
+	}
+	for idx := range bindingList.Items {
+		binding := &bindingList.Items[idx]
+		if binding.Spec.State == placementv1beta1.BindingStateUnscheduled &&
+			binding.Annotations[placementv1beta1.UnscheduledReasonAnnotation] == placementv1beta1.UnscheduledReasonPreempted {
+			ps.preemptedBy[binding.Spec.TargetCluster] = binding.Annotations[placementv1beta1.UnscheduledByPolicySnapshotAnnotation]
+		}
+	}
+
+	// Save the plugin state.
+	framework.WriteAs(state, framework.PluginStateKey(p.Name(), pluginStateKey), ps)
+
+	if len(ps.preemptedBy) == 0 {
+		// The placement has not been preempted from any cluster; skip.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "placement has not been preempted from any cluster")
+	}
+
+	// All done.
+	return nil
+}
+
+// Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
+func (p *Plugin) Filter(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	_ *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (status *framework.Status) {
+	// Read the plugin state.
+	ps, err := p.readPluginState(state)
+	if err != nil {
+		// This branch should never be reached, as a plugin state has been set at the PreFilter
+		// extension point for any placement that has been preempted.
+		return framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	// The state is safe for concurrent reads.
+	if preemptor, ok := ps.preemptedBy[cluster.Name]; ok {
+		return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), fmt.Sprintf(preemptedClusterReasonTemplate, preemptor))
+	}
+
+	// All done.
+	return nil
+}
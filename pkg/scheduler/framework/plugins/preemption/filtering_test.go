@@ -0,0 +1,198 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package preemption
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	crpName      = "test-crp"
+	policyName   = "test-crp-1"
+	otherCRPName = "other-crp"
+	clusterName  = "bravelion"
+	clusterName2 = "smartfish"
+	clusterName3 = "singingbutterfly"
+)
+
+var (
+	cmpStatusOptions = cmp.Options{
+		cmpopts.IgnoreFields(framework.Status{}, "reasons", "err"),
+		cmp.AllowUnexported(framework.Status{}),
+	}
+)
+
+// Mock framework.Handle interface for set up the plugin.
+type MockHandle struct {
+	client client.Client
+}
+
+var (
+	_ framework.Handle = &MockHandle{}
+)
+
+func (mh *MockHandle) Client() client.Client               { return mh.client }
+func (mh *MockHandle) Manager() ctrl.Manager               { return nil }
+func (mh *MockHandle) UncachedReader() client.Reader       { return nil }
+func (mh *MockHandle) EventRecorder() record.EventRecorder { return nil }
+func (mh *MockHandle) ClusterEligibilityChecker() *clustereligibilitychecker.ClusterEligibilityChecker {
+	return nil
+}
+func (mh *MockHandle) PropertyReader() *framework.PropertyReader { return nil }
+
+// TestPreFilterAndFilter tests the PreFilter and Filter methods.
+func TestPreFilterAndFilter(t *testing.T) {
+	testCases := []struct {
+		name          string
+		bindings      []client.Object
+		wantPreFilter *framework.Status
+		wantFilter    map[string]*framework.Status
+	}{
+		{
+			name: "placement has not been preempted",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-1",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName,
+					},
+				},
+				// Bindings unscheduled for other reasons.
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-2",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+						Annotations: map[string]string{
+							placementv1beta1.UnscheduledReasonAnnotation:           "ClusterUnselected",
+							placementv1beta1.UnscheduledByPolicySnapshotAnnotation: "preemptor-1",
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateUnscheduled,
+						TargetCluster: clusterName2,
+					},
+				},
+				// Bindings of other placements.
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-3",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: otherCRPName,
+						},
+						Annotations: map[string]string{
+							placementv1beta1.UnscheduledReasonAnnotation:           placementv1beta1.UnscheduledReasonPreempted,
+							placementv1beta1.UnscheduledByPolicySnapshotAnnotation: "preemptor-1",
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateUnscheduled,
+						TargetCluster: clusterName3,
+					},
+				},
+			},
+			wantPreFilter: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "placement has been preempted from a cluster",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-1",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-2",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+						Annotations: map[string]string{
+							placementv1beta1.UnscheduledReasonAnnotation:           placementv1beta1.UnscheduledReasonPreempted,
+							placementv1beta1.UnscheduledByPolicySnapshotAnnotation: "preemptor-1",
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateUnscheduled,
+						TargetCluster: clusterName2,
+					},
+				},
+			},
+			wantFilter: map[string]*framework.Status{
+				clusterName:  nil,
+				clusterName2: framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+				clusterName3: nil,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New()
+			scheme := runtime.NewScheme()
+			if err := placementv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme() = %v, want nil", err)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.bindings...).Build()
+			p.SetUpWithFramework(&MockHandle{client: fakeClient})
+
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: policyName,
+					Labels: map[string]string{
+						placementv1beta1.CRPTrackingLabel: crpName,
+					},
+				},
+			}
+			state := framework.NewCycleState(nil, nil)
+			got := p.PreFilter(context.Background(), state, policy)
+			if diff := cmp.Diff(tc.wantPreFilter, got, cmpStatusOptions); diff != "" {
+				t.Fatalf("PreFilter() status mismatch (-want, +got):\n%s", diff)
+			}
+
+			for name, want := range tc.wantFilter {
+				cluster := &clusterv1beta1.MemberCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: name,
+					},
+				}
+				got := p.Filter(context.Background(), state, policy, cluster)
+				if diff := cmp.Diff(want, got, cmpStatusOptions); diff != "" {
+					t.Errorf("Filter(%s) status mismatch (-want, +got):\n%s", name, diff)
+				}
+			}
+		})
+	}
+}
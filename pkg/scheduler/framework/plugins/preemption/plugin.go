@@ -0,0 +1,129 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package preemption features a scheduler plugin that, when no cluster can admit a placement,
+// preempts placements of lower priorities from clusters that would otherwise admit it.
+package preemption
+
+import (
+	"fmt"
+
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// defaultPluginName is the default name of the plugin.
+	defaultPluginName = "Preemption"
+
+	// pluginStateKey is the key under which the plugin saves its state in the cycle state.
+	pluginStateKey = "pluginState"
+)
+
+// Plugin is the scheduler plugin that preempts placements of lower priorities to make room for a
+// placement that cannot be scheduled otherwise.
+//
+// The plugin picks victims only among the bindings reported by the Filter plugins that implement
+// the framework.PreemptionExtension interface, e.g., the exclusivity plugin; it also keeps a
+// placement that has been preempted from a cluster off the cluster, until its preempted binding
+// is gone, so that the placement does not take the cluster back from its preemptor.
+type Plugin struct {
+	// The name of the plugin.
+	name string
+
+	// dryRun, if set, makes the plugin report the bindings it would preempt, without preempting them.
+	dryRun bool
+
+	// The framework handle.
+	handle framework.Handle
+}
+
+var (
+	// Verify that Plugin can connect to relevant extension points at compile time.
+	//
+	// This plugin leverages the following the extension points:
+	// * PreFilter
+	// * Filter
+	// * PostFilter
+	//
+	// Note that successful connection to any of the extension points implies that the
+	// plugin already implements the Plugin interface.
+	_ framework.PreFilterPlugin  = &Plugin{}
+	_ framework.FilterPlugin     = &Plugin{}
+	_ framework.PostFilterPlugin = &Plugin{}
+	_ framework.EnqueueExtension = &Plugin{}
+)
+
+// pluginOptions is the options for this plugin.
+type pluginOptions struct {
+	// The name of the plugin.
+	name string
+
+	// dryRun controls whether the plugin reports the bindings it would preempt only.
+	dryRun bool
+}
+
+// Option helps set up the plugin.
+type Option func(*pluginOptions)
+
+// defaultPluginOptions is the default options for this plugin.
+var defaultPluginOptions = pluginOptions{
+	name: defaultPluginName,
+}
+
+// WithName sets the name of the plugin.
+func WithName(name string) Option {
+	return func(o *pluginOptions) {
+		o.name = name
+	}
+}
+
+// WithDryRun sets whether the plugin only reports (in the logs) the bindings it would preempt,
+// without preempting them.
+func WithDryRun(dryRun bool) Option {
+	return func(o *pluginOptions) {
+		o.dryRun = dryRun
+	}
+}
+
+// New returns a new Plugin.
+func New(opts ...Option) Plugin {
+	options := defaultPluginOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return Plugin{
+		name:   options.name,
+		dryRun: options.dryRun,
+	}
+}
+
+// Name returns the name of the plugin.
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// SetUpWithFramework sets up this plugin with a scheduler framework.
+func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
+	p.handle = handle
+
+	// This plugin does not need to set up any informer.
+}
+
+// EventsToRegister returns the kinds of member cluster changes that the plugin is interested in.
+func (p *Plugin) EventsToRegister() []framework.ClusterEvent {
+	// The plugin reads binding and placement information only; no cluster change is relevant.
+	return []framework.ClusterEvent{}
+}
+
+// readPluginState reads the plugin state from the cycle state.
+func (p *Plugin) readPluginState(state framework.CycleStatePluginReadWriter) (*pluginState, error) {
+	// Read from the cycle state.
+	ps, err := framework.ReadAs[*pluginState](state, framework.PluginStateKey(p.Name(), pluginStateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read value from the cycle state: %w", err)
+	}
+	return ps, nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package preemption
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	bindingutils "go.goms.io/fleet/pkg/utils/binding"
+)
+
+// rankedCandidate is a cluster that can be freed up for the placement, along with the highest
+// priority among its victims.
+type rankedCandidate struct {
+	*framework.FilteredCluster
+	maxVictimPriority int32
+}
+
+// PostFilter allows the plugin to connect to the PostFilter extension point in the scheduling
+// framework.
+//
+// The plugin considers only the filtered clusters with victims, i.e., those that can be freed up
+// for the placement, whose victims all belong to placements of lower priorities than the one
+// being scheduled; it prefers candidates whose victims are of lower priorities, then those with
+// fewer victims, and picks as many as the placement needs.
+//
+// Preemption is a voluntary disruption to the victim placements: a candidate is skipped if its
+// victims, along with those of the candidates picked before it, would exceed the disruption
+// budget of any victim placement.
+func (p *Plugin) PostFilter(
+	ctx context.Context,
+	_ framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	filtered []*framework.FilteredCluster,
+	want int,
+) (victims []*placementv1beta1.ClusterResourceBinding, status *framework.Status) {
+	crpName := policy.Labels[placementv1beta1.CRPTrackingLabel]
+	priority, err := p.priorityOf(ctx, crpName)
+	if err != nil {
+		return nil, framework.FromError(err, p.Name(), "failed to get the priority of the placement")
+	}
+
+	// Cache the priorities of the victim placements, as a placement might have bindings on many
+	// of the candidates.
+	priorities := map[string]int32{crpName: priority}
+	ranked := make([]*rankedCandidate, 0, len(filtered))
+	for _, fc := range filtered {
+		if len(fc.Victims) == 0 {
+			// The cluster cannot be freed up for the placement.
+			continue
+		}
+		rc := &rankedCandidate{
+			FilteredCluster:   fc,
+			maxVictimPriority: math.MinInt32,
+		}
+		for _, victim := range fc.Victims {
+			owner := victim.Labels[placementv1beta1.CRPTrackingLabel]
+			victimPriority, ok := priorities[owner]
+			if !ok {
+				if victimPriority, err = p.priorityOf(ctx, owner); err != nil {
+					return nil, framework.FromError(err, p.Name(), "failed to get the priority of a victim placement")
+				}
+				priorities[owner] = victimPriority
+			}
+			if victimPriority > rc.maxVictimPriority {
+				rc.maxVictimPriority = victimPriority
+			}
+		}
+		if rc.maxVictimPriority < priority {
+			ranked = append(ranked, rc)
+		}
+	}
+	if len(ranked) == 0 {
+		return nil, framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), "no cluster can be freed up by preempting placements of lower priorities")
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].maxVictimPriority != ranked[j].maxVictimPriority {
+			return ranked[i].maxVictimPriority < ranked[j].maxVictimPriority
+		}
+		if len(ranked[i].Victims) != len(ranked[j].Victims) {
+			return len(ranked[i].Victims) < len(ranked[j].Victims)
+		}
+		return ranked[i].Cluster.Name < ranked[j].Cluster.Name
+	})
+
+	// Track how many more bindings can be disrupted for each victim placement.
+	disruptionsAllowed := make(map[string]int)
+	picked := make([]*rankedCandidate, 0, want)
+	for _, rc := range ranked {
+		if len(picked) >= want {
+			break
+		}
+		fits, err := p.fitsDisruptionBudgets(ctx, rc.Victims, disruptionsAllowed)
+		if err != nil {
+			return nil, framework.FromError(err, p.Name(), "failed to check the disruption budgets of the victim placements")
+		}
+		if fits {
+			picked = append(picked, rc)
+		}
+	}
+	if len(picked) == 0 {
+		return nil, framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), "no cluster can be freed up without exceeding the disruption budgets of the placements to preempt")
+	}
+
+	clusterNames := make([]string, 0, len(picked))
+	for _, rc := range picked {
+		clusterNames = append(clusterNames, rc.Cluster.Name)
+		victims = append(victims, rc.Victims...)
+	}
+	victimNames := make([]string, 0, len(victims))
+	for _, victim := range victims {
+		victimNames = append(victimNames, victim.Name)
+	}
+
+	if p.dryRun {
+		klog.InfoS("Would preempt bindings to make room for the placement (dry run)",
+			"clusterSchedulingPolicySnapshot", klog.KObj(policy), "clusters", clusterNames, "clusterResourceBindings", victimNames)
+		return nil, framework.NewNonErrorStatus(framework.Skip, p.Name(), "preemption runs in the dry-run mode")
+	}
+	klog.V(2).InfoS("Picked bindings to preempt", "clusterSchedulingPolicySnapshot", klog.KObj(policy), "clusters", clusterNames, "clusterResourceBindings", victimNames)
+	return victims, nil
+}
+
+// fitsDisruptionBudgets checks if the victims fit in the disruption budgets of their placements,
+// given the number of bindings that can still be disrupted for each placement seen so far; if so,
+// the victims are deducted from the budgets.
+func (p *Plugin) fitsDisruptionBudgets(ctx context.Context, victims []*placementv1beta1.ClusterResourceBinding, disruptionsAllowed map[string]int) (bool, error) {
+	disruptions := make(map[string]int)
+	for _, victim := range victims {
+		disruptions[victim.Labels[placementv1beta1.CRPTrackingLabel]]++
+	}
+	for owner, count := range disruptions {
+		allowed, ok := disruptionsAllowed[owner]
+		if !ok {
+			var err error
+			if allowed, err = p.disruptionsAllowedFor(ctx, owner); err != nil {
+				return false, err
+			}
+			disruptionsAllowed[owner] = allowed
+		}
+		if count > allowed {
+			return false, nil
+		}
+	}
+	for owner, count := range disruptions {
+		disruptionsAllowed[owner] -= count
+	}
+	return true, nil
+}
+
+// disruptionsAllowedFor returns the number of bindings of a placement that can be disrupted per
+// its disruption budget; there is no limit if the placement has no disruption budget, or has been
+// deleted.
+func (p *Plugin) disruptionsAllowedFor(ctx context.Context, crpName string) (int, error) {
+	db := &placementv1alpha1.ClusterResourcePlacementDisruptionBudget{}
+	if err := p.handle.Client().Get(ctx, types.NamespacedName{Name: crpName}, db); err != nil {
+		if apierrors.IsNotFound(err) {
+			return math.MaxInt, nil
+		}
+		return 0, err
+	}
+	crp := &placementv1beta1.ClusterResourcePlacement{}
+	if err := p.handle.Client().Get(ctx, types.NamespacedName{Name: crpName}, crp); err != nil {
+		if apierrors.IsNotFound(err) {
+			return math.MaxInt, nil
+		}
+		return 0, err
+	}
+	bindingList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := p.handle.Client().List(ctx, bindingList, client.MatchingLabels{placementv1beta1.CRPTrackingLabel: crpName}); err != nil {
+		return 0, err
+	}
+	allowed, _ := bindingutils.DisruptionsAllowed(bindingList.Items, crp, db)
+	return allowed, nil
+}
+
+// priorityOf returns the priority of a placement; a placement that has been deleted is considered
+// to be of the lowest priority.
+func (p *Plugin) priorityOf(ctx context.Context, crpName string) (int32, error) {
+	crp := &placementv1beta1.ClusterResourcePlacement{}
+	if err := p.handle.Client().Get(ctx, types.NamespacedName{Name: crpName}, crp); err != nil {
+		if apierrors.IsNotFound(err) {
+			return math.MinInt32, nil
+		}
+		return 0, err
+	}
+	return crp.Spec.Priority, nil
+}
@@ -0,0 +1,643 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package preemption
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+// TestPostFilter tests the PostFilter method.
+func TestPostFilter(t *testing.T) {
+	lowCRPName := "low-crp"
+	deletedCRPName := "deleted-crp"
+
+	testCases := []struct {
+		name        string
+		opts        []Option
+		placements  []client.Object
+		candidates  []*framework.FilteredCluster
+		want        int
+		wantVictims []string
+		wantStatus  *framework.Status
+	}{
+		{
+			name: "no victim of lower priority",
+			placements: []client.Object{
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 10,
+					},
+				},
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: otherCRPName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 10,
+					},
+				},
+			},
+			candidates: []*framework.FilteredCluster{
+				{
+					Cluster: &clusterv1beta1.MemberCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterName,
+						},
+					},
+					Victims: []*placementv1beta1.ClusterResourceBinding{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "binding-1",
+								Labels: map[string]string{
+									placementv1beta1.CRPTrackingLabel: otherCRPName,
+								},
+							},
+							Spec: placementv1beta1.ResourceBindingSpec{
+								State:         placementv1beta1.BindingStateBound,
+								TargetCluster: clusterName,
+							},
+						},
+					},
+				},
+			},
+			want:       1,
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+		},
+		{
+			name: "candidates ranked by victim priority, victim count, and cluster name",
+			placements: []client.Object{
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 10,
+					},
+				},
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: otherCRPName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 5,
+					},
+				},
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: lowCRPName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 0,
+					},
+				},
+			},
+			candidates: []*framework.FilteredCluster{
+				{
+					Cluster: &clusterv1beta1.MemberCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterName,
+						},
+					},
+					Victims: []*placementv1beta1.ClusterResourceBinding{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "binding-1",
+								Labels: map[string]string{
+									placementv1beta1.CRPTrackingLabel: otherCRPName,
+								},
+							},
+							Spec: placementv1beta1.ResourceBindingSpec{
+								State:         placementv1beta1.BindingStateBound,
+								TargetCluster: clusterName,
+							},
+						},
+					},
+				},
+				{
+					Cluster: &clusterv1beta1.MemberCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterName2,
+						},
+					},
+					Victims: []*placementv1beta1.ClusterResourceBinding{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "binding-2",
+								Labels: map[string]string{
+									placementv1beta1.CRPTrackingLabel: lowCRPName,
+								},
+							},
+							Spec: placementv1beta1.ResourceBindingSpec{
+								State:         placementv1beta1.BindingStateBound,
+								TargetCluster: clusterName2,
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "binding-3",
+								Labels: map[string]string{
+									placementv1beta1.CRPTrackingLabel: lowCRPName,
+								},
+							},
+							Spec: placementv1beta1.ResourceBindingSpec{
+								State:         placementv1beta1.BindingStateBound,
+								TargetCluster: clusterName2,
+							},
+						},
+					},
+				},
+				{
+					Cluster: &clusterv1beta1.MemberCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterName3,
+						},
+					},
+					Victims: []*placementv1beta1.ClusterResourceBinding{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "binding-4",
+								Labels: map[string]string{
+									placementv1beta1.CRPTrackingLabel: deletedCRPName,
+								},
+							},
+							Spec: placementv1beta1.ResourceBindingSpec{
+								State:         placementv1beta1.BindingStateBound,
+								TargetCluster: clusterName3,
+							},
+						},
+					},
+				},
+			},
+			want:        2,
+			wantVictims: []string{"binding-4", "binding-2", "binding-3"},
+		},
+		{
+			name: "candidates with victims of equal or higher priorities are skipped",
+			placements: []client.Object{
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 5,
+					},
+				},
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: otherCRPName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 5,
+					},
+				},
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: lowCRPName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 0,
+					},
+				},
+			},
+			candidates: []*framework.FilteredCluster{
+				{
+					Cluster: &clusterv1beta1.MemberCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterName,
+						},
+					},
+					Victims: []*placementv1beta1.ClusterResourceBinding{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "binding-1",
+								Labels: map[string]string{
+									placementv1beta1.CRPTrackingLabel: otherCRPName,
+								},
+							},
+							Spec: placementv1beta1.ResourceBindingSpec{
+								State:         placementv1beta1.BindingStateBound,
+								TargetCluster: clusterName,
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "binding-2",
+								Labels: map[string]string{
+									placementv1beta1.CRPTrackingLabel: lowCRPName,
+								},
+							},
+							Spec: placementv1beta1.ResourceBindingSpec{
+								State:         placementv1beta1.BindingStateBound,
+								TargetCluster: clusterName,
+							},
+						},
+					},
+				},
+				{
+					Cluster: &clusterv1beta1.MemberCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterName2,
+						},
+					},
+					Victims: []*placementv1beta1.ClusterResourceBinding{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "binding-3",
+								Labels: map[string]string{
+									placementv1beta1.CRPTrackingLabel: lowCRPName,
+								},
+							},
+							Spec: placementv1beta1.ResourceBindingSpec{
+								State:         placementv1beta1.BindingStateBound,
+								TargetCluster: clusterName2,
+							},
+						},
+					},
+				},
+			},
+			want:        2,
+			wantVictims: []string{"binding-3"},
+		},
+		{
+			name: "clusters without victims are skipped",
+			placements: []client.Object{
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 10,
+					},
+				},
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: otherCRPName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 0,
+					},
+				},
+			},
+			candidates: []*framework.FilteredCluster{
+				{
+					Cluster: &clusterv1beta1.MemberCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterName,
+						},
+					},
+				},
+				{
+					Cluster: &clusterv1beta1.MemberCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterName2,
+						},
+					},
+					Victims: []*placementv1beta1.ClusterResourceBinding{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "binding-1",
+								Labels: map[string]string{
+									placementv1beta1.CRPTrackingLabel: otherCRPName,
+								},
+							},
+							Spec: placementv1beta1.ResourceBindingSpec{
+								State:         placementv1beta1.BindingStateBound,
+								TargetCluster: clusterName2,
+							},
+						},
+					},
+				},
+			},
+			want:        2,
+			wantVictims: []string{"binding-1"},
+		},
+		{
+			name: "victims blocked by the disruption budget",
+			placements: []client.Object{
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 10,
+					},
+				},
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: otherCRPName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 0,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-1",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: otherCRPName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName,
+					},
+					Status: placementv1beta1.ResourceBindingStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:               string(placementv1beta1.ResourceBindingAvailable),
+								Status:             metav1.ConditionTrue,
+								Reason:             "Available",
+								LastTransitionTime: metav1.Now(),
+							},
+						},
+					},
+				},
+				&placementv1alpha1.ClusterResourcePlacementDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: otherCRPName,
+					},
+					Spec: placementv1alpha1.PlacementDisruptionBudgetSpec{
+						MinAvailable: ptr.To(intstr.FromInt32(1)),
+					},
+				},
+			},
+			candidates: []*framework.FilteredCluster{
+				{
+					Cluster: &clusterv1beta1.MemberCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterName,
+						},
+					},
+					Victims: []*placementv1beta1.ClusterResourceBinding{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "binding-1",
+								Labels: map[string]string{
+									placementv1beta1.CRPTrackingLabel: otherCRPName,
+								},
+							},
+							Spec: placementv1beta1.ResourceBindingSpec{
+								State:         placementv1beta1.BindingStateBound,
+								TargetCluster: clusterName,
+							},
+							Status: placementv1beta1.ResourceBindingStatus{
+								Conditions: []metav1.Condition{
+									{
+										Type:               string(placementv1beta1.ResourceBindingAvailable),
+										Status:             metav1.ConditionTrue,
+										Reason:             "Available",
+										LastTransitionTime: metav1.Now(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want:       1,
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, defaultPluginName),
+		},
+		{
+			name: "victims across candidates limited by the disruption budget",
+			placements: []client.Object{
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 10,
+					},
+				},
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: otherCRPName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 0,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-1",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: otherCRPName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName,
+					},
+					Status: placementv1beta1.ResourceBindingStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:               string(placementv1beta1.ResourceBindingAvailable),
+								Status:             metav1.ConditionTrue,
+								Reason:             "Available",
+								LastTransitionTime: metav1.Now(),
+							},
+						},
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-2",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: otherCRPName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName2,
+					},
+					Status: placementv1beta1.ResourceBindingStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:               string(placementv1beta1.ResourceBindingAvailable),
+								Status:             metav1.ConditionTrue,
+								Reason:             "Available",
+								LastTransitionTime: metav1.Now(),
+							},
+						},
+					},
+				},
+				&placementv1alpha1.ClusterResourcePlacementDisruptionBudget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: otherCRPName,
+					},
+					Spec: placementv1alpha1.PlacementDisruptionBudgetSpec{
+						MinAvailable: ptr.To(intstr.FromInt32(1)),
+					},
+				},
+			},
+			candidates: []*framework.FilteredCluster{
+				{
+					Cluster: &clusterv1beta1.MemberCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterName,
+						},
+					},
+					Victims: []*placementv1beta1.ClusterResourceBinding{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "binding-1",
+								Labels: map[string]string{
+									placementv1beta1.CRPTrackingLabel: otherCRPName,
+								},
+							},
+							Spec: placementv1beta1.ResourceBindingSpec{
+								State:         placementv1beta1.BindingStateBound,
+								TargetCluster: clusterName,
+							},
+							Status: placementv1beta1.ResourceBindingStatus{
+								Conditions: []metav1.Condition{
+									{
+										Type:               string(placementv1beta1.ResourceBindingAvailable),
+										Status:             metav1.ConditionTrue,
+										Reason:             "Available",
+										LastTransitionTime: metav1.Now(),
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Cluster: &clusterv1beta1.MemberCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterName2,
+						},
+					},
+					Victims: []*placementv1beta1.ClusterResourceBinding{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "binding-2",
+								Labels: map[string]string{
+									placementv1beta1.CRPTrackingLabel: otherCRPName,
+								},
+							},
+							Spec: placementv1beta1.ResourceBindingSpec{
+								State:         placementv1beta1.BindingStateBound,
+								TargetCluster: clusterName2,
+							},
+							Status: placementv1beta1.ResourceBindingStatus{
+								Conditions: []metav1.Condition{
+									{
+										Type:               string(placementv1beta1.ResourceBindingAvailable),
+										Status:             metav1.ConditionTrue,
+										Reason:             "Available",
+										LastTransitionTime: metav1.Now(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want:        2,
+			wantVictims: []string{"binding-1"},
+		},
+		{
+			name: "dry run",
+			opts: []Option{WithDryRun(true)},
+			placements: []client.Object{
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 10,
+					},
+				},
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: otherCRPName,
+					},
+					Spec: placementv1beta1.ClusterResourcePlacementSpec{
+						Priority: 0,
+					},
+				},
+			},
+			candidates: []*framework.FilteredCluster{
+				{
+					Cluster: &clusterv1beta1.MemberCluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterName,
+						},
+					},
+					Victims: []*placementv1beta1.ClusterResourceBinding{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "binding-1",
+								Labels: map[string]string{
+									placementv1beta1.CRPTrackingLabel: otherCRPName,
+								},
+							},
+							Spec: placementv1beta1.ResourceBindingSpec{
+								State:         placementv1beta1.BindingStateBound,
+								TargetCluster: clusterName,
+							},
+						},
+					},
+				},
+			},
+			want:       1,
+			wantStatus: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New(tc.opts...)
+			scheme := runtime.NewScheme()
+			if err := placementv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme() = %v, want nil", err)
+			}
+			if err := placementv1alpha1.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme() = %v, want nil", err)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.placements...).Build()
+			p.SetUpWithFramework(&MockHandle{client: fakeClient})
+
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: policyName,
+					Labels: map[string]string{
+						placementv1beta1.CRPTrackingLabel: crpName,
+					},
+				},
+			}
+			state := framework.NewCycleState(nil, nil)
+			victims, status := p.PostFilter(context.Background(), state, policy, tc.candidates, tc.want)
+			if diff := cmp.Diff(tc.wantStatus, status, cmpStatusOptions); diff != "" {
+				t.Fatalf("PostFilter() status mismatch (-want, +got):\n%s", diff)
+			}
+			var gotVictims []string
+			for _, victim := range victims {
+				gotVictims = append(gotVictims, victim.Name)
+			}
+			if diff := cmp.Diff(tc.wantVictims, gotVictims); diff != "" {
+				t.Errorf("PostFilter() victims mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package recommendedclusters
+
+import (
+	"context"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// scoreBonus is the score bonus a recommended cluster receives, before the weight of the plugin
+	// is applied.
+	scoreBonus = 20
+)
+
+// pluginState is the state this plugin saves in the cycle state.
+type pluginState struct {
+	// recommended is the set of the names of the recommended clusters.
+	recommended map[string]bool
+}
+
+// PreScore allows the plugin to connect to the PreScore extension point in the scheduling
+// framework.
+//
+// Note that the scheduler will not run this extension point in parallel.
+func (p *Plugin) PreScore(
+	ctx context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+) (status *framework.Status) {
+	crpName := policy.Labels[placementv1beta1.CRPTrackingLabel]
+	crp := &placementv1beta1.ClusterResourcePlacement{}
+	if err := p.handle.Client().Get(ctx, client.ObjectKey{Name: crpName}, crp); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The placement has been deleted; there is nothing to recommend.
+			return framework.NewNonErrorStatus(framework.Skip, p.Name(), "the placement is not found")
+		}
+		return framework.FromError(err, p.Name(), "failed to get the placement")
+	}
+
+	recommended := parseRecommendedClusters(crp.Annotations[placementv1beta1.RecommendedClustersAnnotation])
+	if len(recommended) == 0 {
+		// The placement does not have any recommended cluster; skip the step.
+		//
+		// Note that this will also skip the Score() extension point for the plugin.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no clusters are recommended")
+	}
+
+	// Save the plugin state.
+	framework.WriteAs(state, framework.PluginStateKey(p.Name(), pluginStateKey), &pluginState{recommended: recommended})
+
+	// All done.
+	return nil
+}
+
+// Score allows the plugin to connect to the Score extension point in the scheduling framework.
+func (p *Plugin) Score(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	_ *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (score *framework.ClusterScore, status *framework.Status) {
+	// Read the plugin state.
+	ps, err := p.readPluginState(state)
+	if err != nil {
+		// This branch should never be reached, as for any placement with recommended clusters,
+		// a plugin state has been set at the PreScore extension point.
+		return nil, framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	// The state is safe for concurrent reads.
+	if ps.recommended[cluster.Name] {
+		return &framework.ClusterScore{AffinityScore: scoreBonus}, nil
+	}
+	return &framework.ClusterScore{AffinityScore: 0}, nil
+}
+
+// parseRecommendedClusters parses the comma-separated list of cluster names in the annotation,
+// ignoring the blank entries.
+func parseRecommendedClusters(value string) map[string]bool {
+	recommended := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			recommended[name] = true
+		}
+	}
+	return recommended
+}
@@ -0,0 +1,161 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package recommendedclusters
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	crpName      = "test-crp"
+	clusterName  = "bravelion"
+	clusterName2 = "smartfish"
+	clusterName3 = "jumpingcat"
+)
+
+var (
+	cmpStatusOptions = cmp.Options{
+		cmpopts.IgnoreFields(framework.Status{}, "reasons", "err"),
+		cmp.AllowUnexported(framework.Status{}),
+	}
+)
+
+// Mock framework.Handle interface for set up the plugin.
+type MockHandle struct {
+	client client.Client
+}
+
+var (
+	_ framework.Handle = &MockHandle{}
+)
+
+func (mh *MockHandle) Client() client.Client               { return mh.client }
+func (mh *MockHandle) Manager() ctrl.Manager               { return nil }
+func (mh *MockHandle) UncachedReader() client.Reader       { return nil }
+func (mh *MockHandle) EventRecorder() record.EventRecorder { return nil }
+func (mh *MockHandle) ClusterEligibilityChecker() *clustereligibilitychecker.ClusterEligibilityChecker {
+	return nil
+}
+func (mh *MockHandle) PropertyReader() *framework.PropertyReader { return nil }
+
+// TestPreScoreAndScore tests the PreScore and Score methods.
+func TestPreScoreAndScore(t *testing.T) {
+	testCases := []struct {
+		name         string
+		crp          *placementv1beta1.ClusterResourcePlacement
+		wantPreScore *framework.Status
+		wantScores   map[string]*framework.ClusterScore
+	}{
+		{
+			name:         "placement not found",
+			wantPreScore: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "no annotation",
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: crpName,
+				},
+			},
+			wantPreScore: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "blank annotation",
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: crpName,
+					Annotations: map[string]string{
+						placementv1beta1.RecommendedClustersAnnotation: " , ",
+					},
+				},
+			},
+			wantPreScore: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "clusters are recommended",
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: crpName,
+					Annotations: map[string]string{
+						placementv1beta1.RecommendedClustersAnnotation: fmt.Sprintf("%s, %s,", clusterName, clusterName2),
+					},
+				},
+			},
+			wantScores: map[string]*framework.ClusterScore{
+				clusterName:  {AffinityScore: scoreBonus},
+				clusterName2: {AffinityScore: scoreBonus},
+				clusterName3: {AffinityScore: 0},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := placementv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme() = %v, want nil", err)
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tc.crp != nil {
+				builder = builder.WithObjects(tc.crp)
+			}
+			p := New()
+			p.SetUpWithFramework(&MockHandle{client: builder.Build()})
+
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("%s-1", crpName),
+					Labels: map[string]string{
+						placementv1beta1.CRPTrackingLabel: crpName,
+					},
+				},
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:    placementv1beta1.PickNPlacementType,
+						NumberOfClusters: ptr.To(int32(1)),
+					},
+				},
+			}
+			state := framework.NewCycleState(nil, nil)
+			got := p.PreScore(context.Background(), state, policy)
+			if diff := cmp.Diff(tc.wantPreScore, got, cmpStatusOptions); diff != "" {
+				t.Fatalf("PreScore() status mismatch (-want, +got):\n%s", diff)
+			}
+
+			for name, want := range tc.wantScores {
+				cluster := &clusterv1beta1.MemberCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: name,
+					},
+				}
+				score, status := p.Score(context.Background(), state, policy, cluster)
+				if !status.IsSuccess() {
+					t.Fatalf("Score(%s) = %v, want success", name, status)
+				}
+				if diff := cmp.Diff(want, score); diff != "" {
+					t.Errorf("Score(%s) mismatch (-want, +got):\n%s", name, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package resourcefit
+
+import (
+	"context"
+	"fmt"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	nodeCountNotReportedReason          = "cluster does not report its node count"
+	insufficientNodeCountReasonTemplate = "cluster has %s node(s), fewer than the %d required"
+	resourceNotReportedReasonTemplate   = "cluster does not report its available %s"
+	insufficientResourceReasonTemplate  = "cluster has %s of %s available, less than the %s requested"
+)
+
+// PreFilter allows the plugin to connect to the PreFilter extension point in the scheduling
+// framework.
+func (p *Plugin) PreFilter(
+	_ context.Context,
+	_ framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+) (status *framework.Status) {
+	noResourceFitRequirements := policy.Spec.Policy == nil ||
+		policy.Spec.Policy.ResourceFit == nil ||
+		(len(policy.Spec.Policy.ResourceFit.Requests) == 0 && policy.Spec.Policy.ResourceFit.MinNodeCount == nil)
+	if noResourceFitRequirements {
+		// There are no resource fit requirements to enforce; consider all clusters eligible
+		// for resource placement in the scope of this plugin.
+		//
+		// Note that this will set the cluster to skip the Filter stage for all clusters.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no resource fit requirements to enforce")
+	}
+
+	return nil
+}
+
+// Filter allows the plugin to connect to the Filter extension point in the scheduling framework.
+func (p *Plugin) Filter(
+	_ context.Context,
+	_ framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (status *framework.Status) {
+	// Note that this extension point assumes that previous extension point (PreFilter) has
+	// guaranteed that if scheduling policy reaches this stage, it must have at least one
+	// resource fit requirement to enforce.
+	resourceFit := policy.Spec.Policy.ResourceFit
+
+	if resourceFit.MinNodeCount != nil {
+		nodeCount, err := p.propertyReader().Quantity(cluster, propertyprovider.NodeCountProperty)
+		if err != nil {
+			return framework.FromError(err, p.Name(), "failed to read the node count of the cluster")
+		}
+		if nodeCount == nil {
+			return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), nodeCountNotReportedReason)
+		}
+		if nodeCount.CmpInt64(int64(*resourceFit.MinNodeCount)) < 0 {
+			reason := fmt.Sprintf(insufficientNodeCountReasonTemplate, nodeCount.String(), *resourceFit.MinNodeCount)
+			return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reason)
+		}
+	}
+
+	// Note that the webhook rejects requests of unsupported resources.
+	for _, r := range supportedResources {
+		request, ok := resourceFit.Requests[r.name]
+		if !ok {
+			continue
+		}
+		available, err := p.propertyReader().Quantity(cluster, r.available)
+		if err != nil {
+			return framework.FromError(err, p.Name(), "failed to read the available resources of the cluster")
+		}
+		if available == nil {
+			reason := fmt.Sprintf(resourceNotReportedReasonTemplate, r.name)
+			return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reason)
+		}
+		if available.Cmp(request) < 0 {
+			reason := fmt.Sprintf(insufficientResourceReasonTemplate, available.String(), r.name, request.String())
+			return framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), reason)
+		}
+	}
+
+	// All done.
+	return nil
+}
@@ -0,0 +1,299 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package resourcefit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	clusterName = "bravelion"
+)
+
+var (
+	p = New()
+
+	ignoreStatusErrorField = cmpopts.IgnoreFields(framework.Status{}, "err")
+)
+
+// TestPreFilter tests the PreFilter extension point of this plugin.
+func TestPreFilter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policy     *placementv1beta1.ClusterSchedulingPolicySnapshot
+		wantStatus *framework.Status
+	}{
+		{
+			name: "no scheduling policy",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: nil,
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.Skip, p.Name(), "no resource fit requirements to enforce"),
+		},
+		{
+			name: "no resource fit",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+					},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.Skip, p.Name(), "no resource fit requirements to enforce"),
+		},
+		{
+			name: "scoring strategy only",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit: &placementv1beta1.ResourceFit{
+							ScoringStrategy: placementv1beta1.MostAllocatedScoringStrategy,
+						},
+					},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.Skip, p.Name(), "no resource fit requirements to enforce"),
+		},
+		{
+			name: "min node count",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit: &placementv1beta1.ResourceFit{
+							MinNodeCount: ptr.To(int32(3)),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "resource requests",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit: &placementv1beta1.ResourceFit{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			state := framework.NewCycleState([]clusterv1beta1.MemberCluster{}, []*placementv1beta1.ClusterResourceBinding{})
+			status := p.PreFilter(ctx, state, tc.policy)
+			if diff := cmp.Diff(status, tc.wantStatus, cmp.AllowUnexported(framework.Status{}), ignoreStatusErrorField); diff != "" {
+				t.Errorf("PreFilter() status diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+// TestFilter tests the Filter extension point of this plugin.
+func TestFilter(t *testing.T) {
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("8"),
+		corev1.ResourceMemory: resource.MustParse("32Gi"),
+	}
+	available := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("16Gi"),
+	}
+
+	testCases := []struct {
+		name       string
+		policy     *placementv1beta1.ClusterSchedulingPolicySnapshot
+		cluster    *clusterv1beta1.MemberCluster
+		wantStatus *framework.Status
+	}{
+		{
+			name: "enough nodes",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit:   &placementv1beta1.ResourceFit{MinNodeCount: ptr.To(int32(3))},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						propertyprovider.NodeCountProperty: {Value: "3"},
+					},
+					ResourceUsage: clusterv1beta1.ResourceUsage{
+						Allocatable: resources,
+						Available:   available,
+					},
+				},
+			},
+		},
+		{
+			name: "too few nodes",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit:   &placementv1beta1.ResourceFit{MinNodeCount: ptr.To(int32(3))},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						propertyprovider.NodeCountProperty: {Value: "2"},
+					},
+					ResourceUsage: clusterv1beta1.ResourceUsage{
+						Allocatable: resources,
+						Available:   available,
+					},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), fmt.Sprintf(insufficientNodeCountReasonTemplate, "2", 3)),
+		},
+		{
+			name: "node count not reported",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit:   &placementv1beta1.ResourceFit{MinNodeCount: ptr.To(int32(3))},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					ResourceUsage: clusterv1beta1.ResourceUsage{
+						Allocatable: resources,
+						Available:   available,
+					},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), nodeCountNotReportedReason),
+		},
+		{
+			name: "enough headroom",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit: &placementv1beta1.ResourceFit{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("2"),
+								corev1.ResourceMemory: resource.MustParse("8Gi"),
+							},
+						},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					ResourceUsage: clusterv1beta1.ResourceUsage{
+						Allocatable: resources,
+						Available:   available,
+					},
+				},
+			},
+		},
+		{
+			name: "not enough memory",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit: &placementv1beta1.ResourceFit{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("20Gi"),
+							},
+						},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					ResourceUsage: clusterv1beta1.ResourceUsage{
+						Allocatable: resources,
+						Available:   available,
+					},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), fmt.Sprintf(insufficientResourceReasonTemplate, "16Gi", corev1.ResourceMemory, "20Gi")),
+		},
+		{
+			name: "available resources not reported",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit: &placementv1beta1.ResourceFit{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+						},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					ResourceUsage: clusterv1beta1.ResourceUsage{
+						Allocatable: resources,
+					},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name(), fmt.Sprintf(resourceNotReportedReasonTemplate, corev1.ResourceCPU)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			state := framework.NewCycleState([]clusterv1beta1.MemberCluster{}, []*placementv1beta1.ClusterResourceBinding{})
+			status := p.Filter(ctx, state, tc.policy, tc.cluster)
+			if diff := cmp.Diff(status, tc.wantStatus, cmp.AllowUnexported(framework.Status{}), ignoreStatusErrorField); diff != "" {
+				t.Errorf("Filter() status diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
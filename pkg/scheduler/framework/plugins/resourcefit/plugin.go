@@ -0,0 +1,134 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package resourcefit features a scheduler plugin that filters and scores clusters by the headroom
+// they have for the resources a CRP requests (if any), per the resource usage and properties the
+// clusters report.
+package resourcefit
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"go.goms.io/fleet/pkg/propertyprovider"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// defaultPluginName is the default name of the plugin.
+	defaultPluginName = "ResourceFit"
+)
+
+// supportedResource is a resource a CRP may request, along with the names of the properties that
+// report its allocatable and available capacities.
+type supportedResource struct {
+	name        corev1.ResourceName
+	allocatable string
+	available   string
+}
+
+// supportedResources is the list of resources a CRP may request, in the order they are checked.
+var supportedResources = []supportedResource{
+	{
+		name:        corev1.ResourceCPU,
+		allocatable: propertyprovider.AllocatableCPUCapacityProperty,
+		available:   propertyprovider.AvailableCPUCapacityProperty,
+	},
+	{
+		name:        corev1.ResourceMemory,
+		allocatable: propertyprovider.AllocatableMemoryCapacityProperty,
+		available:   propertyprovider.AvailableMemoryCapacityProperty,
+	},
+}
+
+// Plugin is the scheduler plugin that enforces the resource fit requirements (if any) defined
+// on a CRP.
+type Plugin struct {
+	// The name of the plugin.
+	name string
+
+	// The framework handle.
+	handle framework.Handle
+}
+
+var (
+	// Verify that Plugin can connect to relevant extension points at compile time.
+	//
+	// This plugin leverages the following the extension points:
+	// * PreFilter
+	// * Filter
+	// * PreScore
+	// * Score
+	//
+	// Note that successful connection to any of the extension points implies that the
+	// plugin already implements the Plugin interface.
+	_ framework.PreFilterPlugin  = &Plugin{}
+	_ framework.FilterPlugin     = &Plugin{}
+	_ framework.PreScorePlugin   = &Plugin{}
+	_ framework.ScorePlugin      = &Plugin{}
+	_ framework.EnqueueExtension = &Plugin{}
+)
+
+// pluginOptions is the options for this plugin.
+type pluginOptions struct {
+	// The name of the plugin.
+	name string
+}
+
+// Option helps set up the plugin.
+type Option func(*pluginOptions)
+
+// defaultPluginOptions is the default options for this plugin.
+var defaultPluginOptions = pluginOptions{
+	name: defaultPluginName,
+}
+
+// WithName sets the name of the plugin.
+func WithName(name string) Option {
+	return func(o *pluginOptions) {
+		o.name = name
+	}
+}
+
+// New returns a new Plugin.
+func New(opts ...Option) Plugin {
+	options := defaultPluginOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return Plugin{
+		name: options.name,
+	}
+}
+
+// Name returns the name of the plugin.
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// SetUpWithFramework sets up this plugin with a scheduler framework.
+func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
+	p.handle = handle
+
+	// This plugin does not need to set up any informer.
+}
+
+// propertyReader returns the property reader of the framework the plugin is set up with, if any.
+func (p *Plugin) propertyReader() *framework.PropertyReader {
+	if p.handle == nil {
+		return nil
+	}
+	return p.handle.PropertyReader()
+}
+
+// EventsToRegister returns the kinds of member cluster changes that the plugin is interested in.
+func (p *Plugin) EventsToRegister() []framework.ClusterEvent {
+	// The plugin reads the node counts (a non-resource property) and the resource usage of
+	// clusters only.
+	return []framework.ClusterEvent{
+		framework.ClusterPropertyChanged,
+		framework.ClusterResourceUsageChanged,
+	}
+}
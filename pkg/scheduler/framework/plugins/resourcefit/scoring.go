@@ -0,0 +1,94 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package resourcefit
+
+import (
+	"context"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// maxResourceScore is the score a cluster receives for a resource that is least (or most,
+	// depending on the scoring strategy) allocated.
+	maxResourceScore = 100
+)
+
+// PreScore allows the plugin to connect to the PreScore extension point in the scheduling
+// framework.
+func (p *Plugin) PreScore(
+	_ context.Context,
+	_ framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+) (status *framework.Status) {
+	if policy.Spec.Policy == nil || policy.Spec.Policy.ResourceFit == nil {
+		// The placement does not opt in to resource fit scoring; skip the step.
+		//
+		// Note that this will also skip the Score() extension point for the plugin.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no resource fit is specified")
+	}
+
+	return nil
+}
+
+// Score allows the plugin to connect to the Score extension point in the scheduling framework.
+func (p *Plugin) Score(
+	_ context.Context,
+	_ framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (score *framework.ClusterScore, status *framework.Status) {
+	resourceFit := policy.Spec.Policy.ResourceFit
+
+	// Score the cluster by the requested resources only, or by all the supported ones if no
+	// resource is requested.
+	scored, total := 0, 0
+	for _, r := range supportedResources {
+		request, ok := resourceFit.Requests[r.name]
+		if !ok && len(resourceFit.Requests) > 0 {
+			continue
+		}
+		scored++
+
+		allocatable, err := p.propertyReader().Quantity(cluster, r.allocatable)
+		if err != nil {
+			return nil, framework.FromError(err, p.Name(), "failed to read the allocatable resources of the cluster")
+		}
+		available, err := p.propertyReader().Quantity(cluster, r.available)
+		if err != nil {
+			return nil, framework.FromError(err, p.Name(), "failed to read the available resources of the cluster")
+		}
+		if allocatable == nil || available == nil || allocatable.Sign() <= 0 {
+			// The cluster does not report enough information about the resource; it receives
+			// no score for the resource.
+			continue
+		}
+
+		// Calculate the fraction of the allocatable resource that would be allocated after the
+		// request is placed.
+		//
+		// This conversion will incur precision loss, though in most cases such loss has very limited
+		// impact.
+		allocatableF := allocatable.AsApproximateFloat64()
+		allocatedF := allocatableF - available.AsApproximateFloat64() + request.AsApproximateFloat64()
+		fraction := min(max(allocatedF/allocatableF, 0), 1)
+		if resourceFit.ScoringStrategy == placementv1beta1.MostAllocatedScoringStrategy {
+			total += int(fraction * maxResourceScore)
+		} else {
+			total += int((1 - fraction) * maxResourceScore)
+		}
+	}
+
+	score = &framework.ClusterScore{}
+	if scored > 0 {
+		score.AffinityScore = total / scored
+	}
+
+	// All done.
+	return score, nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package resourcefit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+// TestPreScore tests the PreScore extension point of this plugin.
+func TestPreScore(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policy     *placementv1beta1.ClusterSchedulingPolicySnapshot
+		wantStatus *framework.Status
+	}{
+		{
+			name: "no resource fit",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+					},
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.Skip, p.Name(), "no resource fit is specified"),
+		},
+		{
+			name: "resource fit",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit:   &placementv1beta1.ResourceFit{},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			state := framework.NewCycleState([]clusterv1beta1.MemberCluster{}, []*placementv1beta1.ClusterResourceBinding{})
+			status := p.PreScore(ctx, state, tc.policy)
+			if diff := cmp.Diff(status, tc.wantStatus, cmp.AllowUnexported(framework.Status{}), ignoreStatusErrorField); diff != "" {
+				t.Errorf("PreScore() status diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+// TestScore tests the Score extension point of this plugin.
+func TestScore(t *testing.T) {
+	allocatable := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("10"),
+		corev1.ResourceMemory: resource.MustParse("40Gi"),
+	}
+	available := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("6"),
+		corev1.ResourceMemory: resource.MustParse("10Gi"),
+	}
+	requests := corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("2"),
+	}
+
+	testCases := []struct {
+		name      string
+		policy    *placementv1beta1.ClusterSchedulingPolicySnapshot
+		cluster   *clusterv1beta1.MemberCluster
+		wantScore *framework.ClusterScore
+	}{
+		{
+			name: "least allocated, with requests",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit:   &placementv1beta1.ResourceFit{Requests: requests},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					ResourceUsage: clusterv1beta1.ResourceUsage{
+						Allocatable: allocatable,
+						Available:   available,
+					},
+				},
+			},
+			// CPU: (10 - 6 + 2) / 10 = 60% allocated.
+			wantScore: &framework.ClusterScore{AffinityScore: 40},
+		},
+		{
+			name: "most allocated, with requests",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit: &placementv1beta1.ResourceFit{
+							Requests:        requests,
+							ScoringStrategy: placementv1beta1.MostAllocatedScoringStrategy,
+						},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					ResourceUsage: clusterv1beta1.ResourceUsage{
+						Allocatable: allocatable,
+						Available:   available,
+					},
+				},
+			},
+			wantScore: &framework.ClusterScore{AffinityScore: 60},
+		},
+		{
+			name: "least allocated, without requests",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit:   &placementv1beta1.ResourceFit{},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					ResourceUsage: clusterv1beta1.ResourceUsage{
+						Allocatable: allocatable,
+						Available:   available,
+					},
+				},
+			},
+			// CPU: 40% allocated; memory: 75% allocated.
+			wantScore: &framework.ClusterScore{AffinityScore: 42},
+		},
+		{
+			name: "most allocated, request exceeding the allocatable",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit: &placementv1beta1.ResourceFit{
+							Requests:        corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("20")},
+							ScoringStrategy: placementv1beta1.MostAllocatedScoringStrategy,
+						},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					ResourceUsage: clusterv1beta1.ResourceUsage{
+						Allocatable: allocatable,
+						Available:   available,
+					},
+				},
+			},
+			wantScore: &framework.ClusterScore{AffinityScore: 100},
+		},
+		{
+			name: "resource usage not reported",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						ResourceFit:   &placementv1beta1.ResourceFit{Requests: requests},
+					},
+				},
+			},
+			cluster: &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: clusterName,
+				},
+			},
+			wantScore: &framework.ClusterScore{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			state := framework.NewCycleState([]clusterv1beta1.MemberCluster{}, []*placementv1beta1.ClusterResourceBinding{})
+			score, status := p.Score(ctx, state, tc.policy, tc.cluster)
+			if !status.IsSuccess() {
+				t.Fatalf("Score() status = %v, want success", status)
+			}
+			if diff := cmp.Diff(score, tc.wantScore); diff != "" {
+				t.Errorf("Score() score diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
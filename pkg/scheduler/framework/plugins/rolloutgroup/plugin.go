@@ -0,0 +1,129 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package rolloutgroup features a scheduler plugin that assigns bindings to rollout groups by the
+// labels of their target clusters, e.g., canary or batch-2, so that the rollout controller rolls out
+// changes to the bindings group by group.
+package rolloutgroup
+
+import (
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// defaultPluginName is the default name of the plugin.
+	defaultPluginName = "RolloutGroup"
+)
+
+// Plugin is the scheduler plugin that assigns a binding to the rollout group that its target cluster
+// belongs to, as specified by a label on the cluster.
+type Plugin struct {
+	// The name of the plugin.
+	name string
+
+	// clusterLabelKey is the key of the member cluster label whose value is the rollout group of the cluster.
+	clusterLabelKey string
+
+	// groupOrder maps the names of the rollout groups to their positions in the rollout order.
+	groupOrder map[string]int
+
+	// The framework handle.
+	handle framework.Handle
+}
+
+var (
+	// Verify that Plugin can connect to relevant extension points at compile time.
+	//
+	// This plugin leverages the following the extension points:
+	// * PreBind
+	//
+	// Note that successful connection to any of the extension points implies that the
+	// plugin already implements the Plugin interface.
+	_ framework.PreBindPlugin    = &Plugin{}
+	_ framework.EnqueueExtension = &Plugin{}
+)
+
+// pluginOptions is the options for this plugin.
+type pluginOptions struct {
+	// The name of the plugin.
+	name string
+
+	// clusterLabelKey is the key of the member cluster label whose value is the rollout group of the cluster.
+	clusterLabelKey string
+
+	// groupOrder is the list of rollout group names, in the order the groups are rolled out.
+	groupOrder []string
+}
+
+// Option helps set up the plugin.
+type Option func(*pluginOptions)
+
+// defaultPluginOptions is the default options for this plugin.
+var defaultPluginOptions = pluginOptions{
+	name:            defaultPluginName,
+	clusterLabelKey: placementv1beta1.RolloutGroupLabel,
+}
+
+// WithName sets the name of the plugin.
+func WithName(name string) Option {
+	return func(o *pluginOptions) {
+		o.name = name
+	}
+}
+
+// WithClusterLabelKey sets the key of the member cluster label whose value is the rollout group of
+// the cluster.
+func WithClusterLabelKey(key string) Option {
+	return func(o *pluginOptions) {
+		o.clusterLabelKey = key
+	}
+}
+
+// WithGroupOrder sets the order in which the rollout groups are rolled out; groups not in the list
+// are rolled out after all the listed groups.
+func WithGroupOrder(groups []string) Option {
+	return func(o *pluginOptions) {
+		o.groupOrder = groups
+	}
+}
+
+// New returns a new Plugin.
+func New(opts ...Option) Plugin {
+	options := defaultPluginOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	groupOrder := make(map[string]int, len(options.groupOrder))
+	for idx, group := range options.groupOrder {
+		if _, ok := groupOrder[group]; !ok {
+			groupOrder[group] = idx
+		}
+	}
+	return Plugin{
+		name:            options.name,
+		clusterLabelKey: options.clusterLabelKey,
+		groupOrder:      groupOrder,
+	}
+}
+
+// Name returns the name of the plugin.
+func (p *Plugin) Name() string {
+	return p.name
+}
+
+// SetUpWithFramework sets up this plugin with a scheduler framework.
+func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
+	p.handle = handle
+
+	// This plugin does not need to set up any informer.
+}
+
+// EventsToRegister returns the kinds of member cluster changes that the plugin is interested in.
+func (p *Plugin) EventsToRegister() []framework.ClusterEvent {
+	// The plugin never rejects a cluster; no cluster change can help a placement get scheduled.
+	return []framework.ClusterEvent{}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package rolloutgroup
+
+import (
+	"context"
+	"strconv"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+// PreBind allows the plugin to connect to the PreBind extension point in the scheduling
+// framework.
+//
+// The plugin labels the binding with the rollout group of its target cluster, and annotates the
+// binding with the position of the group in the rollout order; the group assignment is removed
+// if the target cluster no longer belongs to any group.
+func (p *Plugin) PreBind(
+	_ context.Context,
+	_ framework.CycleStatePluginReadWriter,
+	_ *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+	binding *placementv1beta1.ClusterResourceBinding,
+) (status *framework.Status) {
+	group := cluster.Labels[p.clusterLabelKey]
+	if group == "" {
+		_, hasGroup := binding.Labels[placementv1beta1.RolloutGroupLabel]
+		_, hasOrder := binding.Annotations[placementv1beta1.RolloutGroupOrderAnnotation]
+		if !hasGroup && !hasOrder {
+			return framework.NewNonErrorStatus(framework.Skip, p.Name(), "target cluster does not belong to any rollout group")
+		}
+		delete(binding.Labels, placementv1beta1.RolloutGroupLabel)
+		delete(binding.Annotations, placementv1beta1.RolloutGroupOrderAnnotation)
+		return nil
+	}
+
+	order, ok := p.groupOrder[group]
+	if !ok {
+		// Groups that are not in the rollout order are rolled out after all the listed groups.
+		order = len(p.groupOrder)
+	}
+	if binding.Labels == nil {
+		binding.Labels = make(map[string]string)
+	}
+	binding.Labels[placementv1beta1.RolloutGroupLabel] = group
+	if binding.Annotations == nil {
+		binding.Annotations = make(map[string]string)
+	}
+	binding.Annotations[placementv1beta1.RolloutGroupOrderAnnotation] = strconv.Itoa(order)
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package rolloutgroup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	clusterName = "bravelion"
+	bindingName = "test-binding"
+	crpName     = "test-placement"
+	stageLabel  = "stage"
+)
+
+var (
+	cmpStatusOptions = cmp.Options{
+		cmpopts.IgnoreFields(framework.Status{}, "reasons", "err"),
+		cmp.AllowUnexported(framework.Status{}),
+	}
+)
+
+// TestPreBind tests the PreBind method.
+func TestPreBind(t *testing.T) {
+	p := New(WithClusterLabelKey(stageLabel), WithGroupOrder([]string{"canary", "batch-1", "batch-2"}))
+
+	testCases := []struct {
+		name              string
+		clusterLabels     map[string]string
+		bindingLabels     map[string]string
+		bindingAnnots     map[string]string
+		wantStatus        *framework.Status
+		wantBindingLabels map[string]string
+		wantBindingAnnots map[string]string
+	}{
+		{
+			name:              "cluster in no group",
+			bindingLabels:     map[string]string{placementv1beta1.CRPTrackingLabel: crpName},
+			wantStatus:        framework.NewNonErrorStatus(framework.Skip, p.Name()),
+			wantBindingLabels: map[string]string{placementv1beta1.CRPTrackingLabel: crpName},
+		},
+		{
+			name:          "cluster in an ordered group",
+			clusterLabels: map[string]string{stageLabel: "batch-1"},
+			bindingLabels: map[string]string{placementv1beta1.CRPTrackingLabel: crpName},
+			wantBindingLabels: map[string]string{
+				placementv1beta1.CRPTrackingLabel:  crpName,
+				placementv1beta1.RolloutGroupLabel: "batch-1",
+			},
+			wantBindingAnnots: map[string]string{placementv1beta1.RolloutGroupOrderAnnotation: "1"},
+		},
+		{
+			name:          "cluster in an unordered group",
+			clusterLabels: map[string]string{stageLabel: "batch-9"},
+			bindingLabels: map[string]string{placementv1beta1.CRPTrackingLabel: crpName},
+			wantBindingLabels: map[string]string{
+				placementv1beta1.CRPTrackingLabel:  crpName,
+				placementv1beta1.RolloutGroupLabel: "batch-9",
+			},
+			wantBindingAnnots: map[string]string{placementv1beta1.RolloutGroupOrderAnnotation: "3"},
+		},
+		{
+			name:          "cluster moved to another group",
+			clusterLabels: map[string]string{stageLabel: "canary"},
+			bindingLabels: map[string]string{
+				placementv1beta1.CRPTrackingLabel:  crpName,
+				placementv1beta1.RolloutGroupLabel: "batch-2",
+			},
+			bindingAnnots: map[string]string{placementv1beta1.RolloutGroupOrderAnnotation: "2"},
+			wantBindingLabels: map[string]string{
+				placementv1beta1.CRPTrackingLabel:  crpName,
+				placementv1beta1.RolloutGroupLabel: "canary",
+			},
+			wantBindingAnnots: map[string]string{placementv1beta1.RolloutGroupOrderAnnotation: "0"},
+		},
+		{
+			name: "cluster removed from its group",
+			bindingLabels: map[string]string{
+				placementv1beta1.CRPTrackingLabel:  crpName,
+				placementv1beta1.RolloutGroupLabel: "batch-2",
+			},
+			bindingAnnots:     map[string]string{placementv1beta1.RolloutGroupOrderAnnotation: "2"},
+			wantBindingLabels: map[string]string{placementv1beta1.CRPTrackingLabel: crpName},
+			wantBindingAnnots: map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   clusterName,
+					Labels: tc.clusterLabels,
+				},
+			}
+			binding := &placementv1beta1.ClusterResourceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        bindingName,
+					Labels:      tc.bindingLabels,
+					Annotations: tc.bindingAnnots,
+				},
+				Spec: placementv1beta1.ResourceBindingSpec{
+					TargetCluster: clusterName,
+				},
+			}
+
+			status := p.PreBind(context.Background(), framework.NewCycleState(nil, nil), nil, cluster, binding)
+			if diff := cmp.Diff(status, tc.wantStatus, cmpStatusOptions); diff != "" {
+				t.Errorf("PreBind() status diff (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(binding.Labels, tc.wantBindingLabels); diff != "" {
+				t.Errorf("PreBind() binding labels diff (-got, +want): %s", diff)
+			}
+			if diff := cmp.Diff(binding.Annotations, tc.wantBindingAnnots); diff != "" {
+				t.Errorf("PreBind() binding annotations diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
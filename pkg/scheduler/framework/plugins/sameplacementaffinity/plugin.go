@@ -31,8 +31,9 @@ var (
 	//
 	// Note that successful connection to any of the extension points implies that the
 	// plugin already implements the Plugin interface.
-	_ framework.FilterPlugin = &Plugin{}
-	_ framework.ScorePlugin  = &Plugin{}
+	_ framework.FilterPlugin     = &Plugin{}
+	_ framework.ScorePlugin      = &Plugin{}
+	_ framework.EnqueueExtension = &Plugin{}
 )
 
 type samePlacementAntiAffinityPluginOptions struct {
@@ -74,3 +75,9 @@ func (p *Plugin) Name() string {
 func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
 	p.handle = handle
 }
+
+// EventsToRegister returns the kinds of member cluster changes that the plugin is interested in.
+func (p *Plugin) EventsToRegister() []framework.ClusterEvent {
+	// The plugin reads binding information only; no cluster change is relevant.
+	return []framework.ClusterEvent{}
+}
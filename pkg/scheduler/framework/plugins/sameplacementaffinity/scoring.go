@@ -17,12 +17,27 @@ import (
 func (p *Plugin) Score(
 	_ context.Context,
 	state framework.CycleStatePluginReadWriter,
-	_ *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
 	cluster *clusterv1beta1.MemberCluster,
 ) (score *framework.ClusterScore, status *framework.Status) {
 	if state.HasObsoleteBindingFor(cluster.Name) {
-		return &framework.ClusterScore{ObsoletePlacementAffinityScore: 1}, nil
+		// The cluster has been picked in a previous scheduling cycle; if the user has configured
+		// stickiness, grant the cluster an extra affinity score so that minor score fluctuations
+		// will not move the placement away from it.
+		return &framework.ClusterScore{
+			AffinityScore:                  stickinessScoreBonus(policy),
+			ObsoletePlacementAffinityScore: 1,
+		}, nil
 	}
 	// All done.
 	return &framework.ClusterScore{ObsoletePlacementAffinityScore: 0}, nil
 }
+
+// stickinessScoreBonus returns the score bonus for clusters that have been picked in a previous
+// scheduling cycle, as configured in the scheduling policy.
+func stickinessScoreBonus(policy *placementv1beta1.ClusterSchedulingPolicySnapshot) int {
+	if policy == nil || policy.Spec.Policy == nil || policy.Spec.Policy.Stickiness == nil {
+		return 0
+	}
+	return int(policy.Spec.Policy.Stickiness.ScoreBonus)
+}
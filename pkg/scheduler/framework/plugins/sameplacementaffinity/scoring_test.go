@@ -21,6 +21,7 @@ func TestScore(t *testing.T) {
 	tests := []struct {
 		name             string
 		obsoleteBindings []*placementv1beta1.ClusterResourceBinding
+		policy           *placementv1beta1.ClusterSchedulingPolicySnapshot
 		want             *framework.ClusterScore
 	}{
 		{
@@ -53,6 +54,45 @@ func TestScore(t *testing.T) {
 			},
 			want: &framework.ClusterScore{ObsoletePlacementAffinityScore: 0},
 		},
+		{
+			name: "has an obsolete binding, with stickiness",
+			obsoleteBindings: []*placementv1beta1.ClusterResourceBinding{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding1",
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						TargetCluster: clusterName,
+						State:         placementv1beta1.BindingStateBound,
+					},
+				},
+			},
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						Stickiness: &placementv1beta1.Stickiness{
+							ScoreBonus: 20,
+						},
+					},
+				},
+			},
+			want: &framework.ClusterScore{AffinityScore: 20, ObsoletePlacementAffinityScore: 1},
+		},
+		{
+			name: "no obsolete binding, with stickiness",
+			policy: &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType: placementv1beta1.PickNPlacementType,
+						Stickiness: &placementv1beta1.Stickiness{
+							ScoreBonus: 20,
+						},
+					},
+				},
+			},
+			want: &framework.ClusterScore{ObsoletePlacementAffinityScore: 0},
+		},
 		{
 			name: "nil obsolete binding",
 			want: &framework.ClusterScore{ObsoletePlacementAffinityScore: 0},
@@ -67,7 +107,7 @@ func TestScore(t *testing.T) {
 					Name: clusterName,
 				},
 			}
-			got, gotStatus := p.Score(context.Background(), state, nil, &cluster)
+			got, gotStatus := p.Score(context.Background(), state, tc.policy, &cluster)
 			if gotStatus != nil {
 				t.Fatalf("Score() = status %v, want nil", gotStatus)
 			}
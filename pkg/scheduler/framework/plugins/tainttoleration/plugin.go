@@ -17,10 +17,13 @@ var (
 	//
 	// This plugin leverages the following the extension points:
 	// * Filter
+	// * PostFilter
 	//
 	// Note that successful connection to any of the extension points implies that the
 	// plugin already implements the Plugin interface.
-	_ framework.FilterPlugin = &Plugin{}
+	_ framework.FilterPlugin     = &Plugin{}
+	_ framework.PostFilterPlugin = &Plugin{}
+	_ framework.EnqueueExtension = &Plugin{}
 )
 
 type taintTolerationPluginOptions struct {
@@ -62,3 +65,9 @@ func (p *Plugin) Name() string {
 func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
 	p.handle = handle
 }
+
+// EventsToRegister returns the kinds of member cluster changes that the plugin is interested in.
+func (p *Plugin) EventsToRegister() []framework.ClusterEvent {
+	// Only the update or removal of a taint may help a placement get scheduled.
+	return []framework.ClusterEvent{framework.ClusterTaintChanged}
+}
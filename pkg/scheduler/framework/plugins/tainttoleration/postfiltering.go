@@ -0,0 +1,77 @@
+package tainttoleration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+var (
+	remediationFmt = "add a toleration for taint %s to allow placement on cluster(s) %s"
+)
+
+// PostFilter allows the plugin to connect to the PostFilter extension point in the scheduling framework.
+//
+// For clusters that the plugin has filtered out, it suggests the tolerations to add to the placement.
+func (p *Plugin) PostFilter(
+	_ context.Context,
+	_ framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	filtered []*framework.FilteredCluster,
+	_ int,
+) (victims []*placementv1beta1.ClusterResourceBinding, status *framework.Status) {
+	// Group the clusters by the taints that cannot be tolerated.
+	clustersByTaint := make(map[string][]string)
+	for _, fc := range filtered {
+		if fc.Status.SourcePlugin() != p.Name() {
+			continue
+		}
+		for _, taint := range findUntoleratedTaints(fc.Cluster.Spec.Taints, policy.Tolerations()) {
+			key := describeTaint(taint)
+			clustersByTaint[key] = append(clustersByTaint[key], fc.Cluster.Name)
+		}
+	}
+	if len(clustersByTaint) == 0 {
+		return nil, framework.NewNonErrorStatus(framework.Skip, p.Name())
+	}
+
+	taints := make([]string, 0, len(clustersByTaint))
+	for taint := range clustersByTaint {
+		taints = append(taints, taint)
+	}
+	sort.Strings(taints)
+
+	remediations := make([]string, 0, len(taints))
+	for _, taint := range taints {
+		clusters := clustersByTaint[taint]
+		sort.Strings(clusters)
+		remediations = append(remediations, fmt.Sprintf(remediationFmt, taint, strings.Join(clusters, ", ")))
+	}
+	klog.V(2).InfoS("Suggesting tolerations for clusters with untolerated taints", "clusterSchedulingPolicySnapshot", klog.KObj(policy), "taintCount", len(taints))
+	return nil, framework.NewNonErrorStatus(framework.Success, p.Name(), remediations...)
+}
+
+func findUntoleratedTaints(taints []clusterv1beta1.Taint, tolerations []placementv1beta1.Toleration) []clusterv1beta1.Taint {
+	var untolerated []clusterv1beta1.Taint
+	for _, taint := range taints {
+		if !tolerationsTolerateTaint(taint, tolerations) {
+			untolerated = append(untolerated, taint)
+		}
+	}
+	return untolerated
+}
+
+// describeTaint returns the taint in the key=value:effect form.
+func describeTaint(taint clusterv1beta1.Taint) string {
+	if taint.Value == "" {
+		return fmt.Sprintf("%s:%s", taint.Key, taint.Effect)
+	}
+	return fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect)
+}
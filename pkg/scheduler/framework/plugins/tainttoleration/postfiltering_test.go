@@ -0,0 +1,103 @@
+package tainttoleration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+func TestPostFilter(t *testing.T) {
+	p := New()
+	taintA := clusterv1beta1.Taint{
+		Key:    "key1",
+		Value:  "value1",
+		Effect: corev1.TaintEffectNoSchedule,
+	}
+	taintB := clusterv1beta1.Taint{
+		Key:    "key2",
+		Effect: corev1.TaintEffectNoSchedule,
+	}
+	taintedCluster := func(name string, taints ...clusterv1beta1.Taint) *clusterv1beta1.MemberCluster {
+		return &clusterv1beta1.MemberCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: clusterv1beta1.MemberClusterSpec{
+				Taints: taints,
+			},
+		}
+	}
+	policySnapshot := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "csp-1",
+		},
+		Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickNPlacementType,
+				Tolerations: []placementv1beta1.Toleration{
+					{
+						Key:      "key3",
+						Operator: corev1.TolerationOpExists,
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		filtered   []*framework.FilteredCluster
+		wantStatus *framework.Status
+	}{
+		{
+			name: "no cluster filtered out by the plugin",
+			filtered: []*framework.FilteredCluster{
+				{
+					Cluster: taintedCluster("test-mc-1", taintA),
+					Status:  framework.NewNonErrorStatus(framework.ClusterUnschedulable, "ClusterAffinity"),
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.Skip, p.Name()),
+		},
+		{
+			name: "clusters filtered out by the plugin",
+			filtered: []*framework.FilteredCluster{
+				{
+					Cluster: taintedCluster("test-mc-2", taintA, taintB),
+					Status:  framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name()),
+				},
+				{
+					Cluster: taintedCluster("test-mc-1", taintA, clusterv1beta1.Taint{Key: "key3", Effect: corev1.TaintEffectNoSchedule}),
+					Status:  framework.NewNonErrorStatus(framework.ClusterUnschedulable, p.Name()),
+				},
+				{
+					Cluster: taintedCluster("test-mc-3", taintB),
+					Status:  framework.NewNonErrorStatus(framework.ClusterUnschedulable, "ClusterAffinity"),
+				},
+			},
+			wantStatus: framework.NewNonErrorStatus(framework.Success, p.Name(),
+				fmt.Sprintf(remediationFmt, "key1=value1:NoSchedule", "test-mc-1, test-mc-2"),
+				fmt.Sprintf(remediationFmt, "key2:NoSchedule", "test-mc-2"),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			victims, status := p.PostFilter(context.Background(), framework.NewCycleState(nil, nil), policySnapshot, tt.filtered, 2)
+			if diff := cmp.Diff(tt.wantStatus, status, cmpStatusOptions); diff != "" {
+				t.Errorf("PostFilter() status mismatch (-want, +got):\n%s", diff)
+			}
+			if len(victims) != 0 {
+				t.Errorf("PostFilter() victims = %v, want none", victims)
+			}
+		})
+	}
+}
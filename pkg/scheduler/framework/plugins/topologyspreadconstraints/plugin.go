@@ -32,6 +32,9 @@ const (
 
 	// defaultPluginName is the default name for the topology spread constraints plugin.
 	defaultPluginName = "TopologySpreadConstraints"
+
+	// pluginStateKey is the key under which the plugin saves its state in the cycle state.
+	pluginStateKey = "pluginState"
 )
 
 var (
@@ -61,11 +64,12 @@ var (
 	//
 	// Note that successful connection to any of the extension points implies that the
 	// plugin already implements the Plugin interface.
-	_ framework.PostBatchPlugin = &Plugin{}
-	_ framework.PreFilterPlugin = &Plugin{}
-	_ framework.FilterPlugin    = &Plugin{}
-	_ framework.PreScorePlugin  = &Plugin{}
-	_ framework.ScorePlugin     = &Plugin{}
+	_ framework.PostBatchPlugin  = &Plugin{}
+	_ framework.PreFilterPlugin  = &Plugin{}
+	_ framework.FilterPlugin     = &Plugin{}
+	_ framework.PreScorePlugin   = &Plugin{}
+	_ framework.ScorePlugin      = &Plugin{}
+	_ framework.EnqueueExtension = &Plugin{}
 )
 
 type topologySpreadConstraintsPluginOptions struct {
@@ -110,19 +114,19 @@ func (p *Plugin) SetUpWithFramework(handle framework.Handle) {
 	// This plugin does not need to set up any informer.
 }
 
+// EventsToRegister returns the kinds of member cluster changes that the plugin is interested in.
+func (p *Plugin) EventsToRegister() []framework.ClusterEvent {
+	// Topology domains are formed by cluster labels.
+	return []framework.ClusterEvent{framework.ClusterLabelChanged}
+}
+
 // readPluginState reads the plugin state from the cycle state.
 func (p *Plugin) readPluginState(state framework.CycleStatePluginReadWriter) (*pluginState, error) {
 	// Read from the cycle state.
-	val, err := state.Read(framework.StateKey(p.Name()))
+	ps, err := framework.ReadAs[*pluginState](state, framework.PluginStateKey(p.Name(), pluginStateKey))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read value from the cycle state: %w", err)
 	}
-
-	// Cast the value to the right type.
-	ps, ok := val.(*pluginState)
-	if !ok {
-		return nil, fmt.Errorf("failed to cast value %v to the right type", val)
-	}
 	return ps, nil
 }
 
@@ -191,7 +195,7 @@ func (p *Plugin) PreFilter(
 	}
 
 	// Save the plugin state.
-	state.Write(framework.StateKey(p.Name()), ps)
+	framework.WriteAs(state, framework.PluginStateKey(p.Name(), pluginStateKey), ps)
 
 	if len(ps.doNotScheduleConstraints) == 0 {
 		// There are no DoNotSchedule topology spread constraints to enforce; skip.
@@ -407,7 +407,7 @@ func TestFilter(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
 			state := framework.NewCycleState(nil, nil)
-			state.Write(defaultPluginName, tc.ps)
+			framework.WriteAs(state, framework.PluginStateKey(defaultPluginName, pluginStateKey), tc.ps)
 
 			status := plugin.Filter(ctx, state, &policy, tc.cluster)
 			// It is safe to compare unexported fields here as the struct is owned by the project.
@@ -519,7 +519,7 @@ func TestPreScore(t *testing.T) {
 			ctx := context.Background()
 			state := framework.NewCycleState(nil, nil)
 			if tc.ps != nil {
-				state.Write(defaultPluginName, tc.ps)
+				framework.WriteAs(state, framework.PluginStateKey(defaultPluginName, pluginStateKey), tc.ps)
 			}
 
 			status := plugin.PreScore(ctx, state, tc.policy)
@@ -584,7 +584,7 @@ func TestScore(t *testing.T) {
 			ctx := context.Background()
 			state := framework.NewCycleState(nil, nil)
 			if tc.ps != nil {
-				state.Write(defaultPluginName, tc.ps)
+				framework.WriteAs(state, framework.PluginStateKey(defaultPluginName, pluginStateKey), tc.ps)
 			}
 
 			score, status := plugin.Score(ctx, state, policy, cluster)
@@ -0,0 +1,119 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package warmdependencies
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// defaultScoreBonus is the score bonus a cluster receives for each warm dependency, if the
+	// placement does not specify one.
+	defaultScoreBonus = 20
+)
+
+// pluginState is the state this plugin saves in the cycle state.
+type pluginState struct {
+	// warmDependencyCounts maps the names of clusters to the number of warm dependencies that
+	// have been scheduled or bound to them.
+	warmDependencyCounts map[string]int
+}
+
+// PreScore allows the plugin to connect to the PreScore extension point in the scheduling
+// framework.
+//
+// Note that the scheduler will not run this extension point in parallel.
+func (p *Plugin) PreScore(
+	ctx context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+) (status *framework.Status) {
+	if policy.Spec.Policy == nil || policy.Spec.Policy.WarmDependencies == nil || len(policy.Spec.Policy.WarmDependencies.PlacementNames) == 0 {
+		// The placement does not specify any warm dependency; skip the step.
+		//
+		// Note that this will also skip the Score() extension point for the plugin.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no warm dependencies are specified")
+	}
+	crpName := policy.Labels[placementv1beta1.CRPTrackingLabel]
+
+	// Find all the bindings of the warm dependencies.
+	requirement, err := labels.NewRequirement(placementv1beta1.CRPTrackingLabel, selection.In, policy.Spec.Policy.WarmDependencies.PlacementNames)
+	if err != nil {
+		return framework.FromError(err, p.Name(), "failed to build the label selector for the warm dependencies")
+	}
+	bindingList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := p.handle.Client().List(ctx, bindingList, client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*requirement)}); err != nil {
+		return framework.FromError(err, p.Name(), "failed to list bindings of the warm dependencies")
+	}
+
+	// A cluster may have more than one binding of the same placement (e.g., during a rollout);
+	// count each placement only once per cluster.
+	warmFor := make(map[string]map[string]bool)
+	for idx := range bindingList.Items {
+		binding := &bindingList.Items[idx]
+		owner := binding.Labels[placementv1beta1.CRPTrackingLabel]
+		switch {
+		case owner == crpName:
+			// A placement is not a warm dependency of itself.
+		case !binding.DeletionTimestamp.IsZero() || binding.Spec.State == placementv1beta1.BindingStateUnscheduled:
+			// The binding is being removed from the cluster; the cluster is no longer warm for it.
+		default:
+			if warmFor[binding.Spec.TargetCluster] == nil {
+				warmFor[binding.Spec.TargetCluster] = make(map[string]bool)
+			}
+			warmFor[binding.Spec.TargetCluster][owner] = true
+		}
+	}
+
+	ps := &pluginState{
+		warmDependencyCounts: make(map[string]int, len(warmFor)),
+	}
+	for cluster, owners := range warmFor {
+		ps.warmDependencyCounts[cluster] = len(owners)
+	}
+
+	// Save the plugin state.
+	framework.WriteAs(state, framework.PluginStateKey(p.Name(), pluginStateKey), ps)
+
+	if len(ps.warmDependencyCounts) == 0 {
+		// No cluster is warm for any of the dependencies; skip.
+		return framework.NewNonErrorStatus(framework.Skip, p.Name(), "no cluster is warm for the warm dependencies")
+	}
+
+	// All done.
+	return nil
+}
+
+// Score allows the plugin to connect to the Score extension point in the scheduling framework.
+func (p *Plugin) Score(
+	_ context.Context,
+	state framework.CycleStatePluginReadWriter,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) (score *framework.ClusterScore, status *framework.Status) {
+	// Read the plugin state.
+	ps, err := p.readPluginState(state)
+	if err != nil {
+		// This branch should never be reached, as for any policy with warm dependencies,
+		// a plugin state has been set at the PreScore extension point.
+		return nil, framework.FromError(err, p.Name(), "failed to read plugin state")
+	}
+
+	// The state is safe for concurrent reads.
+	scoreBonus := int32(defaultScoreBonus)
+	if bonus := policy.Spec.Policy.WarmDependencies.ScoreBonus; bonus != nil {
+		scoreBonus = *bonus
+	}
+	return &framework.ClusterScore{AffinityScore: ps.warmDependencyCounts[cluster.Name] * int(scoreBonus)}, nil
+}
@@ -0,0 +1,274 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package warmdependencies
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	crpName      = "test-crp"
+	ingressName  = "ingress"
+	meshName     = "service-mesh"
+	clusterName  = "bravelion"
+	clusterName2 = "smartfish"
+	clusterName3 = "jumpingcat"
+)
+
+var (
+	cmpStatusOptions = cmp.Options{
+		cmpopts.IgnoreFields(framework.Status{}, "reasons", "err"),
+		cmp.AllowUnexported(framework.Status{}),
+	}
+)
+
+// Mock framework.Handle interface for set up the plugin.
+type MockHandle struct {
+	client client.Client
+}
+
+var (
+	_ framework.Handle = &MockHandle{}
+)
+
+func (mh *MockHandle) Client() client.Client               { return mh.client }
+func (mh *MockHandle) Manager() ctrl.Manager               { return nil }
+func (mh *MockHandle) UncachedReader() client.Reader       { return nil }
+func (mh *MockHandle) EventRecorder() record.EventRecorder { return nil }
+func (mh *MockHandle) ClusterEligibilityChecker() *clustereligibilitychecker.ClusterEligibilityChecker {
+	return nil
+}
+func (mh *MockHandle) PropertyReader() *framework.PropertyReader { return nil }
+
+// TestPreScoreAndScore tests the PreScore and Score methods.
+func TestPreScoreAndScore(t *testing.T) {
+	testCases := []struct {
+		name             string
+		warmDependencies *placementv1beta1.WarmDependencies
+		bindings         []client.Object
+		wantPreScore     *framework.Status
+		wantScores       map[string]*framework.ClusterScore
+	}{
+		{
+			name: "no warm dependencies",
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-1",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: ingressName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName,
+					},
+				},
+			},
+			wantPreScore: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "no cluster is warm",
+			warmDependencies: &placementv1beta1.WarmDependencies{
+				PlacementNames: []string{ingressName},
+			},
+			bindings: []client.Object{
+				// Bindings of the same placement.
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-1",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName,
+					},
+				},
+				// Bindings of a placement which is not a warm dependency.
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-2",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: meshName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName2,
+					},
+				},
+				// Unscheduled bindings.
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-3",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: ingressName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateUnscheduled,
+						TargetCluster: clusterName2,
+					},
+				},
+			},
+			wantPreScore: framework.NewNonErrorStatus(framework.Skip, defaultPluginName),
+		},
+		{
+			name: "clusters are warm, with the default score bonus",
+			warmDependencies: &placementv1beta1.WarmDependencies{
+				PlacementNames: []string{ingressName, meshName},
+			},
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-1",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: ingressName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-2",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: meshName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateScheduled,
+						TargetCluster: clusterName,
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-3",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: ingressName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName2,
+					},
+				},
+				// A second binding of the same placement on the same cluster.
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-4",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: ingressName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateScheduled,
+						TargetCluster: clusterName2,
+					},
+				},
+			},
+			wantScores: map[string]*framework.ClusterScore{
+				clusterName:  {AffinityScore: 2 * defaultScoreBonus},
+				clusterName2: {AffinityScore: defaultScoreBonus},
+				clusterName3: {AffinityScore: 0},
+			},
+		},
+		{
+			name: "clusters are warm, with a custom score bonus",
+			warmDependencies: &placementv1beta1.WarmDependencies{
+				PlacementNames: []string{ingressName},
+				ScoreBonus:     ptr.To(int32(50)),
+			},
+			bindings: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "binding-1",
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: ingressName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:         placementv1beta1.BindingStateBound,
+						TargetCluster: clusterName,
+					},
+				},
+			},
+			wantScores: map[string]*framework.ClusterScore{
+				clusterName:  {AffinityScore: 50},
+				clusterName2: {AffinityScore: 0},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := placementv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme() = %v, want nil", err)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.bindings...).Build()
+			p := New()
+			p.SetUpWithFramework(&MockHandle{client: fakeClient})
+
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("%s-1", crpName),
+					Labels: map[string]string{
+						placementv1beta1.CRPTrackingLabel: crpName,
+					},
+				},
+				Spec: placementv1beta1.SchedulingPolicySnapshotSpec{
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:    placementv1beta1.PickNPlacementType,
+						NumberOfClusters: ptr.To(int32(1)),
+						WarmDependencies: tc.warmDependencies,
+					},
+				},
+			}
+			state := framework.NewCycleState(nil, nil)
+			got := p.PreScore(context.Background(), state, policy)
+			if diff := cmp.Diff(tc.wantPreScore, got, cmpStatusOptions); diff != "" {
+				t.Fatalf("PreScore() status mismatch (-want, +got):\n%s", diff)
+			}
+
+			for name, want := range tc.wantScores {
+				cluster := &clusterv1beta1.MemberCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: name,
+					},
+				}
+				score, status := p.Score(context.Background(), state, policy, cluster)
+				if !status.IsSuccess() {
+					t.Fatalf("Score(%s) = %v, want success", name, status)
+				}
+				if diff := cmp.Diff(want, score); diff != "" {
+					t.Errorf("Score(%s) mismatch (-want, +got):\n%s", name, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+// runPostFilterPlugins runs all post filter plugins sequentially, if fewer clusters than the placement
+// wants have passed the Filter stage.
+//
+// The remediations the plugins suggest are kept in the cycle state, and are later recorded in the
+// status of the policy snapshot. The first plugin that returns victims has them marked as unscheduled,
+// and the remaining plugins are not run; the placement is then retried in a new cycle.
+//
+// The want parameter is the number of clusters the placement wants in the current cycle.
+func (f *framework) runPostFilterPlugins(
+	ctx context.Context,
+	state *CycleState,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	passed []*clusterv1beta1.MemberCluster,
+	filtered []*filteredClusterWithStatus,
+	want int,
+) error {
+	if len(f.profile.postFilterPlugins) == 0 || len(passed) >= want || len(filtered) == 0 {
+		return nil
+	}
+	policyRef := klog.KObj(policy)
+
+	filteredClusters, err := f.findVictims(ctx, state, policy, filtered)
+	if err != nil {
+		return controller.NewUnexpectedBehaviorError(err)
+	}
+
+	for _, pl := range f.profile.postFilterPlugins {
+		victims, status := pl.PostFilter(ctx, state, policy, filteredClusters, want-len(passed))
+		switch {
+		case status.IsSuccess():
+			state.remediations = append(state.remediations, status.Reasons()...)
+			if len(victims) == 0 {
+				// The plugin has made no room; carry on with the next one.
+				continue
+			}
+			klog.V(2).InfoS("Preempting bindings to make room for the placement", "clusterSchedulingPolicySnapshot", policyRef, "postFilterPlugin", pl.Name(), "victimCount", len(victims))
+			if err := f.updateBindings(ctx, victims, markUnscheduledForAndUpdate(placementv1beta1.UnscheduledReasonPreempted, policy)); err != nil {
+				return err
+			}
+			state.preempted = true
+			return nil
+		case status.IsInteralError():
+			return controller.NewUnexpectedBehaviorError(status.AsError())
+		case status.IsSkip(), status.IsClusterUnschedulable():
+			// Do nothing.
+		default:
+			// Any status that is not Success, InternalError, Skip, or ClusterUnschedulable is considered an error.
+			return controller.NewUnexpectedBehaviorError(fmt.Errorf("postfilter plugin %s returned an unknown status %s", pl.Name(), status))
+		}
+	}
+	return nil
+}
+
+// findVictims wraps the clusters filtered out at the Filter stage for the post filter plugins, along
+// with the bindings of other placements to remove, should the placement be bound to them.
+//
+// A cluster has victims only if every Filter plugin that rejects it implements the
+// PreemptionExtension interface and reports the bindings to remove; clusters filtered out by an
+// extender have none, as extenders cannot report any.
+func (f *framework) findVictims(
+	ctx context.Context,
+	state *CycleState,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	filtered []*filteredClusterWithStatus,
+) ([]*FilteredCluster, error) {
+	filteredClusters := make([]*FilteredCluster, 0, len(filtered))
+	for _, fc := range filtered {
+		filteredCluster := &FilteredCluster{
+			Cluster: fc.cluster,
+			Status:  fc.status,
+		}
+		filteredClusters = append(filteredClusters, filteredCluster)
+		if !fc.status.IsClusterUnschedulable() {
+			continue
+		}
+		victims, ok, err := f.victimsFor(ctx, state, policy, fc.cluster)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filteredCluster.Victims = victims
+		}
+	}
+	return filteredClusters, nil
+}
+
+// victimsFor runs all the Filter plugins on a cluster, and collects the bindings to remove from the
+// cluster for every plugin that rejects it; it reports false if removing bindings would not make
+// the cluster admit the placement.
+func (f *framework) victimsFor(
+	ctx context.Context,
+	state *CycleState,
+	policy *placementv1beta1.ClusterSchedulingPolicySnapshot,
+	cluster *clusterv1beta1.MemberCluster,
+) ([]*placementv1beta1.ClusterResourceBinding, bool, error) {
+	var victims []*placementv1beta1.ClusterResourceBinding
+	seen := sets.New[string]()
+	for _, pl := range f.profile.filterPlugins {
+		if state.skippedFilterPlugins.Has(pl.Name()) {
+			continue
+		}
+		status := pl.Filter(ctx, state, policy, cluster)
+		switch {
+		case status.IsSuccess():
+			continue
+		case status.IsInteralError():
+			return nil, false, status.AsError()
+		case !status.IsClusterUnschedulable():
+			// The cluster has been selected already, or the plugin has returned an unknown status.
+			return nil, false, nil
+		}
+
+		ext, ok := pl.(PreemptionExtension)
+		if !ok {
+			return nil, false, nil
+		}
+		pluginVictims, status := ext.Victims(ctx, state, policy, cluster)
+		switch {
+		case status.IsSuccess():
+		case status.IsInteralError():
+			return nil, false, status.AsError()
+		default:
+			return nil, false, nil
+		}
+		for _, victim := range pluginVictims {
+			if !seen.Has(victim.Name) {
+				seen.Insert(victim.Name)
+				victims = append(victims, victim)
+			}
+		}
+	}
+	return victims, true, nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/parallelizer"
+)
+
+// dummyPreemptionPlugin is a dummy filter plugin which implements the PreemptionExtension interface.
+type dummyPreemptionPlugin struct {
+	DummyAllPurposePlugin
+	victimsRunner func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (victims []*placementv1beta1.ClusterResourceBinding, status *Status)
+}
+
+// Verify that dummyPreemptionPlugin implements PreemptionExtension at compile time.
+var _ PreemptionExtension = &dummyPreemptionPlugin{}
+
+// Victims implements the PreemptionExtension interface for the dummy plugin.
+func (p *dummyPreemptionPlugin) Victims(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (victims []*placementv1beta1.ClusterResourceBinding, status *Status) { //nolint:revive
+	return p.victimsRunner(ctx, state, policy, cluster)
+}
+
+// TestRunPostFilterPluginsWithPreemption tests the runPostFilterPlugins method with plugins that
+// preempt bindings of other placements.
+func TestRunPostFilterPluginsWithPreemption(t *testing.T) {
+	dummyFilterPluginNameA := fmt.Sprintf(dummyAllPurposePluginNameFormat, 0)
+	dummyFilterPluginNameB := fmt.Sprintf(dummyAllPurposePluginNameFormat, 1)
+	dummyPostFilterPluginName := fmt.Sprintf(dummyAllPurposePluginNameFormat, 2)
+
+	cluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterName,
+		},
+	}
+	altCluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: altClusterName,
+		},
+	}
+	victim := &placementv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: bindingName,
+		},
+		Spec: placementv1beta1.ResourceBindingSpec{
+			State:         placementv1beta1.BindingStateBound,
+			TargetCluster: clusterName,
+		},
+	}
+	filtered := []*filteredClusterWithStatus{
+		{
+			cluster: cluster,
+			status:  NewNonErrorStatus(ClusterUnschedulable, dummyFilterPluginNameA),
+		},
+		{
+			cluster: altCluster,
+			status:  NewNonErrorStatus(ClusterUnschedulable, dummyFilterPluginNameB),
+		},
+	}
+
+	// The preemptable plugin rejects the first cluster, which is occupied by the victim; the other
+	// plugin rejects the second one, which cannot be freed up.
+	preemptablePlugin := &dummyPreemptionPlugin{
+		DummyAllPurposePlugin: DummyAllPurposePlugin{
+			name: dummyFilterPluginNameA,
+			filterRunner: func(_ context.Context, _ CycleStatePluginReadWriter, _ *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status) {
+				if cluster.Name == clusterName {
+					return NewNonErrorStatus(ClusterUnschedulable, dummyFilterPluginNameA)
+				}
+				return nil
+			},
+		},
+		victimsRunner: func(_ context.Context, _ CycleStatePluginReadWriter, _ *placementv1beta1.ClusterSchedulingPolicySnapshot, _ *clusterv1beta1.MemberCluster) (victims []*placementv1beta1.ClusterResourceBinding, status *Status) {
+			return []*placementv1beta1.ClusterResourceBinding{victim.DeepCopy()}, nil
+		},
+	}
+	otherPlugin := &DummyAllPurposePlugin{
+		name: dummyFilterPluginNameB,
+		filterRunner: func(_ context.Context, _ CycleStatePluginReadWriter, _ *placementv1beta1.ClusterSchedulingPolicySnapshot, cluster *clusterv1beta1.MemberCluster) (status *Status) {
+			if cluster.Name == altClusterName {
+				return NewNonErrorStatus(ClusterUnschedulable, dummyFilterPluginNameB)
+			}
+			return nil
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		passed           []*clusterv1beta1.MemberCluster
+		postFilterRunner func(ctx context.Context, state CycleStatePluginReadWriter, policy *placementv1beta1.ClusterSchedulingPolicySnapshot, filtered []*FilteredCluster, want int) (victims []*placementv1beta1.ClusterResourceBinding, status *Status)
+		wantPreempted    bool
+		wantVictimState  placementv1beta1.BindingState
+		wantErr          bool
+	}{
+		{
+			name:   "some clusters have passed the Filter stage",
+			passed: []*clusterv1beta1.MemberCluster{altCluster},
+			postFilterRunner: func(_ context.Context, _ CycleStatePluginReadWriter, _ *placementv1beta1.ClusterSchedulingPolicySnapshot, _ []*FilteredCluster, _ int) ([]*placementv1beta1.ClusterResourceBinding, *Status) {
+				return nil, FromError(fmt.Errorf("post filter plugin should not run"), dummyPostFilterPluginName)
+			},
+			wantVictimState: placementv1beta1.BindingStateBound,
+		},
+		{
+			name: "victims preempted",
+			postFilterRunner: func(_ context.Context, _ CycleStatePluginReadWriter, _ *placementv1beta1.ClusterSchedulingPolicySnapshot, filtered []*FilteredCluster, want int) ([]*placementv1beta1.ClusterResourceBinding, *Status) {
+				// Only the first cluster can be freed up.
+				if len(filtered) != 2 || len(filtered[0].Victims) != 1 || len(filtered[1].Victims) != 0 || want != 1 {
+					return nil, FromError(fmt.Errorf("got filtered clusters %v and want %d, want victims on the first cluster only and 1", filtered, want), dummyPostFilterPluginName)
+				}
+				return filtered[0].Victims, nil
+			},
+			wantPreempted:   true,
+			wantVictimState: placementv1beta1.BindingStateUnscheduled,
+		},
+		{
+			name: "skipped",
+			postFilterRunner: func(_ context.Context, _ CycleStatePluginReadWriter, _ *placementv1beta1.ClusterSchedulingPolicySnapshot, _ []*FilteredCluster, _ int) ([]*placementv1beta1.ClusterResourceBinding, *Status) {
+				return nil, NewNonErrorStatus(Skip, dummyPostFilterPluginName)
+			},
+			wantVictimState: placementv1beta1.BindingStateBound,
+		},
+		{
+			name: "internal error",
+			postFilterRunner: func(_ context.Context, _ CycleStatePluginReadWriter, _ *placementv1beta1.ClusterSchedulingPolicySnapshot, _ []*FilteredCluster, _ int) ([]*placementv1beta1.ClusterResourceBinding, *Status) {
+				return nil, FromError(fmt.Errorf("internal error"), dummyPostFilterPluginName)
+			},
+			wantErr:         true,
+			wantVictimState: placementv1beta1.BindingStateBound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(victim.DeepCopy()).
+				Build()
+			profile := NewProfile(dummyProfileName).
+				WithFilterPlugin(preemptablePlugin).
+				WithFilterPlugin(otherPlugin).
+				WithPostFilterPlugin(&DummyAllPurposePlugin{
+					name:             dummyPostFilterPluginName,
+					postFilterRunner: tc.postFilterRunner,
+				})
+			f := &framework{
+				profile:      profile,
+				client:       fakeClient,
+				parallelizer: parallelizer.NewParallelizer(parallelizer.DefaultNumOfWorkers),
+			}
+
+			ctx := context.Background()
+			state := NewCycleState([]clusterv1beta1.MemberCluster{}, []*placementv1beta1.ClusterResourceBinding{})
+			policy := &placementv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: policyName,
+				},
+			}
+
+			err := f.runPostFilterPlugins(ctx, state, policy, tc.passed, filtered, 1)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("runPostFilterPlugins() = %v, want error %t", err, tc.wantErr)
+			}
+			if state.preempted != tc.wantPreempted {
+				t.Errorf("runPostFilterPlugins() preempted = %t, want %t", state.preempted, tc.wantPreempted)
+			}
+
+			got := &placementv1beta1.ClusterResourceBinding{}
+			if err := fakeClient.Get(ctx, types.NamespacedName{Name: bindingName}, got); err != nil {
+				t.Fatalf("Get(%s) = %v, want no error", bindingName, err)
+			}
+			if diff := cmp.Diff(got.Spec.State, tc.wantVictimState); diff != "" {
+				t.Errorf("victim state diff (-got, +want): %s", diff)
+			}
+			if tc.wantPreempted {
+				if reason := got.Annotations[placementv1beta1.UnscheduledReasonAnnotation]; reason != placementv1beta1.UnscheduledReasonPreempted {
+					t.Errorf("victim unscheduled reason = %q, want %q", reason, placementv1beta1.UnscheduledReasonPreempted)
+				}
+			}
+		})
+	}
+}
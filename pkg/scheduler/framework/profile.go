@@ -5,6 +5,12 @@ Licensed under the MIT license.
 
 package framework
 
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
 // Profile specifies the scheduling profile a framework uses; it includes the plugins in use
 // by the framework at each extension point in order.
 //
@@ -14,11 +20,32 @@ package framework
 type Profile struct {
 	name string
 
-	postBatchPlugins []PostBatchPlugin
-	preFilterPlugins []PreFilterPlugin
-	filterPlugins    []FilterPlugin
-	preScorePlugins  []PreScorePlugin
-	scorePlugins     []ScorePlugin
+	postBatchPlugins  []PostBatchPlugin
+	preFilterPlugins  []PreFilterPlugin
+	filterPlugins     []FilterPlugin
+	postFilterPlugins []PostFilterPlugin
+	preScorePlugins   []PreScorePlugin
+	scorePlugins      []ScorePlugin
+	preBindPlugins    []PreBindPlugin
+	permitPlugins     []PermitPlugin
+	reservePlugins    []ReservePlugin
+
+	// extenders is the out-of-tree extensions which filter and/or score clusters in addition to
+	// the plugins.
+	extenders []Extender
+
+	// scoreAggregationStrategy is the strategy the framework uses to aggregate the scores
+	// from different score plugins.
+	scoreAggregationStrategy ScoreAggregationStrategy
+	// scorePluginWeights is the weights of score plugins, keyed by their names; a plugin without
+	// a weight has the weight of 1.
+	scorePluginWeights map[string]int
+	// scorePluginPriorities is the priority classes of score plugins, keyed by their names; a plugin
+	// without a priority class has the priority of 0.
+	scorePluginPriorities map[string]int
+	// pluginArgs is the args the plugins are built with, keyed by their names; it is for reporting
+	// only, as plugins are registered in their instantiated forms.
+	pluginArgs map[string]json.RawMessage
 
 	// RegisteredPlugins is a map of all plugins registered to the profile, keyed by their names.
 	// This helps to avoid setting up same plugin multiple times with the framework if the plugin
@@ -47,6 +74,13 @@ func (profile *Profile) WithFilterPlugin(plugin FilterPlugin) *Profile {
 	return profile
 }
 
+// WithPostFilterPlugin registers a PostFilterPlugin to the profile.
+func (profile *Profile) WithPostFilterPlugin(plugin PostFilterPlugin) *Profile {
+	profile.postFilterPlugins = append(profile.postFilterPlugins, plugin)
+	profile.registeredPlugins[plugin.Name()] = plugin
+	return profile
+}
+
 // WithPreScorePlugin registers a PreScorePlugin to the profile.
 func (profile *Profile) WithPreScorePlugin(plugin PreScorePlugin) *Profile {
 	profile.preScorePlugins = append(profile.preScorePlugins, plugin)
@@ -61,15 +95,93 @@ func (profile *Profile) WithScorePlugin(plugin ScorePlugin) *Profile {
 	return profile
 }
 
+// WithPreBindPlugin registers a PreBindPlugin to the profile.
+func (profile *Profile) WithPreBindPlugin(plugin PreBindPlugin) *Profile {
+	profile.preBindPlugins = append(profile.preBindPlugins, plugin)
+	profile.registeredPlugins[plugin.Name()] = plugin
+	return profile
+}
+
+// WithPermitPlugin registers a PermitPlugin to the profile.
+func (profile *Profile) WithPermitPlugin(plugin PermitPlugin) *Profile {
+	profile.permitPlugins = append(profile.permitPlugins, plugin)
+	profile.registeredPlugins[plugin.Name()] = plugin
+	return profile
+}
+
+// WithReservePlugin registers a ReservePlugin to the profile.
+func (profile *Profile) WithReservePlugin(plugin ReservePlugin) *Profile {
+	profile.reservePlugins = append(profile.reservePlugins, plugin)
+	profile.registeredPlugins[plugin.Name()] = plugin
+	return profile
+}
+
+// WithExtender registers an Extender to the profile; the scores from the extender can be weighted
+// and prioritized by the name of the extender, just like those from a score plugin.
+func (profile *Profile) WithExtender(extender Extender) *Profile {
+	profile.extenders = append(profile.extenders, extender)
+	return profile
+}
+
+// WithScoreAggregationStrategy sets the strategy the framework uses to aggregate the scores from
+// different score plugins; by default the scores are aggregated with a weighted sum.
+func (profile *Profile) WithScoreAggregationStrategy(strategy ScoreAggregationStrategy) *Profile {
+	profile.scoreAggregationStrategy = strategy
+	return profile
+}
+
+// WithScorePluginWeight sets the weight of a score plugin in the profile, which all the scores
+// from the plugin are multiplied by before aggregation.
+func (profile *Profile) WithScorePluginWeight(pluginName string, weight int) *Profile {
+	profile.scorePluginWeights[pluginName] = weight
+	return profile
+}
+
+// WithScorePluginPriority sets the priority class of a score plugin in the profile; with the
+// Lexicographic aggregation strategy, scores from plugins of a higher priority class always
+// dominate those from plugins of a lower priority class.
+func (profile *Profile) WithScorePluginPriority(pluginName string, priority int) *Profile {
+	profile.scorePluginPriorities[pluginName] = priority
+	return profile
+}
+
+// WithPluginArgs records the args that a plugin in the profile is built with, so that they are
+// reported along with the profile.
+func (profile *Profile) WithPluginArgs(pluginName string, args json.RawMessage) *Profile {
+	profile.pluginArgs[pluginName] = args
+	return profile
+}
+
 // Name returns the name of the profile.
 func (profile *Profile) Name() string {
 	return profile.name
 }
 
+// RegisteredClusterEvents returns the kinds of member cluster changes that plugins in the profile
+// are interested in.
+//
+// A plugin which does not implement the EnqueueExtension interface is considered to be interested
+// in all kinds of member cluster changes.
+func (profile *Profile) RegisteredClusterEvents() sets.Set[ClusterEvent] {
+	events := sets.New[ClusterEvent]()
+	for _, plugin := range profile.registeredPlugins {
+		ext, ok := plugin.(EnqueueExtension)
+		if !ok {
+			return sets.New(AllClusterEvents...)
+		}
+		events.Insert(ext.EventsToRegister()...)
+	}
+	return events
+}
+
 // NewProfile creates scheduling profile.
 func NewProfile(name string) *Profile {
 	return &Profile{
-		name:              name,
-		registeredPlugins: map[string]Plugin{},
+		name:                     name,
+		registeredPlugins:        map[string]Plugin{},
+		scoreAggregationStrategy: ScoreAggregationStrategyWeightedSum,
+		scorePluginWeights:       map[string]int{},
+		scorePluginPriorities:    map[string]int{},
+		pluginArgs:               map[string]json.RawMessage{},
 	}
 }
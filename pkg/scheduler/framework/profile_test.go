@@ -6,9 +6,12 @@ Licensed under the MIT license.
 package framework
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 )
 
 const (
@@ -28,22 +31,148 @@ func TestProfile(t *testing.T) {
 	profile.WithPostBatchPlugin(dummyAllPurposePlugin)
 	profile.WithPreFilterPlugin(dummyAllPurposePlugin)
 	profile.WithFilterPlugin(dummyAllPurposePlugin)
+	profile.WithPostFilterPlugin(dummyAllPurposePlugin)
 	profile.WithPreScorePlugin(dummyAllPurposePlugin)
 	profile.WithScorePlugin(dummyAllPurposePlugin)
+	profile.WithPreBindPlugin(dummyAllPurposePlugin)
+	profile.WithPermitPlugin(dummyAllPurposePlugin)
+	profile.WithReservePlugin(dummyAllPurposePlugin)
 
 	wantProfile := &Profile{
-		name:             dummyProfileName,
-		postBatchPlugins: []PostBatchPlugin{dummyAllPurposePlugin},
-		preFilterPlugins: []PreFilterPlugin{dummyAllPurposePlugin},
-		filterPlugins:    []FilterPlugin{dummyAllPurposePlugin},
-		preScorePlugins:  []PreScorePlugin{dummyAllPurposePlugin},
-		scorePlugins:     []ScorePlugin{dummyAllPurposePlugin},
+		name:              dummyProfileName,
+		postBatchPlugins:  []PostBatchPlugin{dummyAllPurposePlugin},
+		preFilterPlugins:  []PreFilterPlugin{dummyAllPurposePlugin},
+		filterPlugins:     []FilterPlugin{dummyAllPurposePlugin},
+		postFilterPlugins: []PostFilterPlugin{dummyAllPurposePlugin},
+		preScorePlugins:   []PreScorePlugin{dummyAllPurposePlugin},
+		scorePlugins:      []ScorePlugin{dummyAllPurposePlugin},
+		preBindPlugins:    []PreBindPlugin{dummyAllPurposePlugin},
+		permitPlugins:     []PermitPlugin{dummyAllPurposePlugin},
+		reservePlugins:    []ReservePlugin{dummyAllPurposePlugin},
 		registeredPlugins: map[string]Plugin{
 			dummyPluginName: dummyPlugin,
 		},
+		scoreAggregationStrategy: ScoreAggregationStrategyWeightedSum,
+		scorePluginWeights:       map[string]int{},
+		scorePluginPriorities:    map[string]int{},
+		pluginArgs:               map[string]json.RawMessage{},
 	}
 
 	if !cmp.Equal(profile, wantProfile, cmp.AllowUnexported(Profile{}, DummyAllPurposePlugin{})) {
 		t.Fatalf("NewProfile() = %v, want %v", profile, wantProfile)
 	}
 }
+
+// dummyEnqueueExtensionPlugin is a dummy plugin which registers for a specific set of cluster events.
+type dummyEnqueueExtensionPlugin struct {
+	DummyAllPurposePlugin
+	events []ClusterEvent
+}
+
+// EventsToRegister implements the EnqueueExtension interface for the dummy plugin.
+func (p *dummyEnqueueExtensionPlugin) EventsToRegister() []ClusterEvent {
+	return p.events
+}
+
+// TestProfileRegisteredClusterEvents tests the RegisteredClusterEvents method of a Profile.
+func TestProfileRegisteredClusterEvents(t *testing.T) {
+	labelPlugin := &dummyEnqueueExtensionPlugin{
+		DummyAllPurposePlugin: DummyAllPurposePlugin{name: "labelPlugin"},
+		events:                []ClusterEvent{ClusterLabelChanged},
+	}
+	taintPlugin := &dummyEnqueueExtensionPlugin{
+		DummyAllPurposePlugin: DummyAllPurposePlugin{name: "taintPlugin"},
+		events:                []ClusterEvent{ClusterTaintChanged},
+	}
+
+	testCases := []struct {
+		name    string
+		profile *Profile
+		want    sets.Set[ClusterEvent]
+	}{
+		{
+			name:    "all plugins register events",
+			profile: NewProfile(dummyProfileName).WithFilterPlugin(labelPlugin).WithFilterPlugin(taintPlugin),
+			want:    sets.New(ClusterLabelChanged, ClusterTaintChanged),
+		},
+		{
+			name:    "some plugin does not register events",
+			profile: NewProfile(dummyProfileName).WithFilterPlugin(labelPlugin).WithScorePlugin(&DummyAllPurposePlugin{name: dummyPluginName}),
+			want:    sets.New(AllClusterEvents...),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.profile.RegisteredClusterEvents(); !got.Equal(tc.want) {
+				t.Errorf("RegisteredClusterEvents() = %v, want %v", sets.List(got), sets.List(tc.want))
+			}
+		})
+	}
+}
+
+// TestProfileDescribe tests the Describe method of a Profile.
+func TestProfileDescribe(t *testing.T) {
+	filterPlugin := &DummyAllPurposePlugin{name: "filterPlugin"}
+	scorePlugin := &DummyAllPurposePlugin{name: "scorePlugin"}
+	weightedScorePlugin := &DummyAllPurposePlugin{name: "weightedScorePlugin"}
+	args := json.RawMessage(`{"key":"value"}`)
+
+	testCases := []struct {
+		name    string
+		profile *Profile
+		want    ProfileDescription
+	}{
+		{
+			name:    "empty profile",
+			profile: NewProfile(dummyProfileName),
+			want: ProfileDescription{
+				Name:                     dummyProfileName,
+				ScoreAggregationStrategy: ScoreAggregationStrategyWeightedSum,
+			},
+		},
+		{
+			name: "plugins with weights, priorities and args",
+			profile: NewProfile(dummyProfileName).
+				WithFilterPlugin(filterPlugin).WithPluginArgs(filterPlugin.Name(), args).
+				WithPreScorePlugin(scorePlugin).
+				WithScorePlugin(scorePlugin).WithScorePlugin(weightedScorePlugin).
+				WithScorePluginWeight(weightedScorePlugin.Name(), 3).
+				WithScorePluginPriority(weightedScorePlugin.Name(), 1).
+				WithScoreAggregationStrategy(ScoreAggregationStrategyLexicographic),
+			want: ProfileDescription{
+				Name:                     dummyProfileName,
+				ScoreAggregationStrategy: ScoreAggregationStrategyLexicographic,
+				Filter:                   []PluginDescription{{Name: "filterPlugin", Args: args}},
+				PreScore:                 []PluginDescription{{Name: "scorePlugin"}},
+				Score: []PluginDescription{
+					{Name: "scorePlugin", Weight: ptr.To(1), Priority: ptr.To(0)},
+					{Name: "weightedScorePlugin", Weight: ptr.To(3), Priority: ptr.To(1)},
+				},
+			},
+		},
+		{
+			name: "extenders",
+			profile: NewProfile(dummyProfileName).
+				WithExtender(&dummyExtender{name: "filterExtender", failed: map[string]string{}, ignorable: true}).
+				WithExtender(&dummyExtender{name: "scoreExtender", scores: map[string]int{}}).
+				WithScorePluginWeight("scoreExtender", 2),
+			want: ProfileDescription{
+				Name:                     dummyProfileName,
+				ScoreAggregationStrategy: ScoreAggregationStrategyWeightedSum,
+				Extenders: []ExtenderDescription{
+					{Name: "filterExtender", Filter: true, Ignorable: true},
+					{Name: "scoreExtender", Score: true, Weight: ptr.To(2), Priority: ptr.To(0)},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.profile.Describe(), tc.want); diff != "" {
+				t.Errorf("Describe() diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
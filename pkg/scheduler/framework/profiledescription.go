@@ -0,0 +1,133 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"encoding/json"
+)
+
+// ProfileDescription describes a scheduling profile as the scheduler runs it, i.e., the plugins at
+// each extension point in order, the weights and priority classes of the scores, and the args of
+// the plugins.
+type ProfileDescription struct {
+	// Name is the name of the profile.
+	Name string `json:"name"`
+	// ScoreAggregationStrategy is the strategy the profile uses to aggregate scores.
+	ScoreAggregationStrategy ScoreAggregationStrategy `json:"scoreAggregationStrategy"`
+
+	PostBatch  []PluginDescription `json:"postBatch,omitempty"`
+	PreFilter  []PluginDescription `json:"preFilter,omitempty"`
+	Filter     []PluginDescription `json:"filter,omitempty"`
+	PostFilter []PluginDescription `json:"postFilter,omitempty"`
+	PreScore   []PluginDescription `json:"preScore,omitempty"`
+	Score      []PluginDescription `json:"score,omitempty"`
+	Permit     []PluginDescription `json:"permit,omitempty"`
+	Reserve    []PluginDescription `json:"reserve,omitempty"`
+	PreBind    []PluginDescription `json:"preBind,omitempty"`
+
+	// Extenders is the out-of-tree extenders of the profile, in order.
+	Extenders []ExtenderDescription `json:"extenders,omitempty"`
+}
+
+// PluginDescription describes a plugin at an extension point.
+type PluginDescription struct {
+	// Name is the name of the plugin.
+	Name string `json:"name"`
+	// Weight is the weight of the scores from the plugin; it is only set at the score extension point.
+	Weight *int `json:"weight,omitempty"`
+	// Priority is the priority class of the scores from the plugin; it is only set at the score
+	// extension point.
+	Priority *int `json:"priority,omitempty"`
+	// Args is the args the plugin is built with, if any.
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+// ExtenderDescription describes an out-of-tree extender.
+type ExtenderDescription struct {
+	// Name is the name of the extender.
+	Name string `json:"name"`
+	// Filter is true if the extender filters clusters.
+	Filter bool `json:"filter"`
+	// Score is true if the extender scores clusters.
+	Score bool `json:"score"`
+	// Ignorable is true if the scheduler carries on without the extender should it fail.
+	Ignorable bool `json:"ignorable"`
+	// Weight is the weight of the scores from the extender; it is only set if the extender scores clusters.
+	Weight *int `json:"weight,omitempty"`
+	// Priority is the priority class of the scores from the extender; it is only set if the extender
+	// scores clusters.
+	Priority *int `json:"priority,omitempty"`
+}
+
+// Describe returns the description of the profile.
+func (profile *Profile) Describe() ProfileDescription {
+	return ProfileDescription{
+		Name:                     profile.name,
+		ScoreAggregationStrategy: profile.scoreAggregationStrategy,
+		PostBatch:                describePlugins(profile, profile.postBatchPlugins, false),
+		PreFilter:                describePlugins(profile, profile.preFilterPlugins, false),
+		Filter:                   describePlugins(profile, profile.filterPlugins, false),
+		PostFilter:               describePlugins(profile, profile.postFilterPlugins, false),
+		PreScore:                 describePlugins(profile, profile.preScorePlugins, false),
+		Score:                    describePlugins(profile, profile.scorePlugins, true),
+		Permit:                   describePlugins(profile, profile.permitPlugins, false),
+		Reserve:                  describePlugins(profile, profile.reservePlugins, false),
+		PreBind:                  describePlugins(profile, profile.preBindPlugins, false),
+		Extenders:                describeExtenders(profile),
+	}
+}
+
+// describePlugins returns the descriptions of the plugins at an extension point; the weights and
+// priority classes are included for score plugins only.
+func describePlugins[P Plugin](profile *Profile, plugins []P, scores bool) []PluginDescription {
+	if len(plugins) == 0 {
+		return nil
+	}
+	descriptions := make([]PluginDescription, 0, len(plugins))
+	for _, plugin := range plugins {
+		description := PluginDescription{
+			Name: plugin.Name(),
+			Args: profile.pluginArgs[plugin.Name()],
+		}
+		if scores {
+			description.Weight, description.Priority = profile.scoreWeightAndPriority(plugin.Name())
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
+
+// describeExtenders returns the descriptions of the extenders of the profile.
+func describeExtenders(profile *Profile) []ExtenderDescription {
+	if len(profile.extenders) == 0 {
+		return nil
+	}
+	descriptions := make([]ExtenderDescription, 0, len(profile.extenders))
+	for _, ext := range profile.extenders {
+		description := ExtenderDescription{
+			Name:      ext.Name(),
+			Filter:    ext.IsFilter(),
+			Score:     ext.IsScorer(),
+			Ignorable: ext.IsIgnorable(),
+		}
+		if ext.IsScorer() {
+			description.Weight, description.Priority = profile.scoreWeightAndPriority(ext.Name())
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
+
+// scoreWeightAndPriority returns the effective weight and priority class of the scores from a
+// plugin or an extender.
+func (profile *Profile) scoreWeightAndPriority(name string) (weight, priority *int) {
+	w, ok := profile.scorePluginWeights[name]
+	if !ok {
+		w = 1
+	}
+	p := profile.scorePluginPriorities[name]
+	return &w, &p
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/utils/clusterselector"
+)
+
+// PropertyReader performs typed lookups of the properties, resource or non-resource, of member
+// clusters.
+//
+// The parsed values are cached per member cluster and invalidated as soon as the resource version
+// of the member cluster changes, so that plugins running in the same (or subsequent) scheduling
+// cycles do not have to parse the same values over and over again.
+//
+// A nil PropertyReader is valid; it performs lookups without caching.
+type PropertyReader struct {
+	mu sync.RWMutex
+	// clusters is the cache of parsed property values, keyed by the names of member clusters.
+	clusters map[string]*cachedClusterProperties
+}
+
+// cachedClusterProperties is the parsed property values of a specific version of a member cluster.
+type cachedClusterProperties struct {
+	resourceVersion string
+	// quantities is keyed by property names; a nil value means that the property is not
+	// available on the cluster.
+	quantities map[string]*resource.Quantity
+}
+
+// NewPropertyReader returns a new PropertyReader.
+func NewPropertyReader() *PropertyReader {
+	return &PropertyReader{
+		clusters: make(map[string]*cachedClusterProperties),
+	}
+}
+
+// Quantity returns the value of a property, resource or non-resource, of a member cluster as a
+// resource quantity.
+//
+// Resource properties are named in the format of `[PREFIX]/[CAPACITY_TYPE]-[RESOURCE_NAME]`, e.g.,
+// `resources.kubernetes-fleet.io/allocatable-cpu`; all other names refer to the non-resource
+// properties reported in the status of the member cluster.
+//
+// Note that it will return nil if the property is not available for the cluster; the zero value
+// of resource.Quantity, i.e., resource.Quantity{}, is a valid quantity. The returned quantity is
+// a copy owned by the caller.
+func (r *PropertyReader) Quantity(cluster *clusterv1beta1.MemberCluster, name string) (*resource.Quantity, error) {
+	if r == nil || cluster.ResourceVersion == "" {
+		// Skip the cache if caching is disabled, or the cluster object does not come from
+		// the API server (and consequently cannot be versioned).
+		return clusterselector.PropertyValue(cluster, name)
+	}
+
+	r.mu.RLock()
+	cached, found := r.clusters[cluster.Name]
+	if found && cached.resourceVersion == cluster.ResourceVersion {
+		if q, found := cached.quantities[name]; found {
+			r.mu.RUnlock()
+			return copyQuantity(q), nil
+		}
+	}
+	r.mu.RUnlock()
+
+	q, err := clusterselector.PropertyValue(cluster, name)
+	if err != nil {
+		// Errors are not cached; normally they should rarely occur.
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cached, found = r.clusters[cluster.Name]
+	if !found || cached.resourceVersion != cluster.ResourceVersion {
+		cached = &cachedClusterProperties{
+			resourceVersion: cluster.ResourceVersion,
+			quantities:      make(map[string]*resource.Quantity),
+		}
+		r.clusters[cluster.Name] = cached
+	}
+	cached.quantities[name] = q
+	return copyQuantity(q), nil
+}
+
+// retainOnly drops the cached values of all the member clusters not in the given list, e.g.,
+// clusters that have left the fleet.
+func (r *PropertyReader) retainOnly(clusters []clusterv1beta1.MemberCluster) {
+	if r == nil {
+		return
+	}
+
+	names := sets.New[string]()
+	for idx := range clusters {
+		names.Insert(clusters[idx].Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name := range r.clusters {
+		if !names.Has(name) {
+			delete(r.clusters, name)
+		}
+	}
+}
+
+// copyQuantity returns a copy of a quantity, so that the cached values are never shared
+// with (and possibly mutated by) the callers.
+func copyQuantity(q *resource.Quantity) *resource.Quantity {
+	if q == nil {
+		return nil
+	}
+	qc := q.DeepCopy()
+	return &qc
+}
@@ -0,0 +1,105 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
+)
+
+const (
+	nonExistentNonResourcePropertyName = "non-existent-non-resource-property"
+	invalidNonResourcePropertyName     = "invalid-non-resource-property"
+)
+
+// TestPropertyReaderQuantity tests the Quantity method of PropertyReader.
+func TestPropertyReaderQuantity(t *testing.T) {
+	cluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            clusterName,
+			ResourceVersion: "1",
+		},
+		Status: clusterv1beta1.MemberClusterStatus{
+			ResourceUsage: clusterv1beta1.ResourceUsage{
+				Available: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("2"),
+				},
+			},
+			Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				propertyprovider.NodeCountProperty: {
+					Value: "4",
+				},
+				invalidNonResourcePropertyName: {
+					Value: "invalid",
+				},
+			},
+		},
+	}
+
+	r := NewPropertyReader()
+	q, err := r.Quantity(cluster, propertyprovider.NodeCountProperty)
+	if err != nil {
+		t.Fatalf("Quantity() = %v, want no error", err)
+	}
+	if diff := cmp.Diff(ptr.To(resource.MustParse("4")), q); diff != "" {
+		t.Errorf("Quantity() mismatch (-want, +got):\n%s", diff)
+	}
+	if _, err := r.Quantity(cluster, invalidNonResourcePropertyName); err == nil {
+		t.Errorf("Quantity(%s) = nil, want error", invalidNonResourcePropertyName)
+	}
+	q, err = r.Quantity(cluster, nonExistentNonResourcePropertyName)
+	if err != nil || q != nil {
+		t.Errorf("Quantity(%s) = %v, %v, want nil, nil", nonExistentNonResourcePropertyName, q, err)
+	}
+
+	// Mutating the returned quantity does not affect the cached value.
+	q, _ = r.Quantity(cluster, propertyprovider.AvailableCPUCapacityProperty)
+	q.Add(resource.MustParse("1"))
+
+	// The cached value is used as long as the resource version of the cluster stays the same.
+	stale := cluster.DeepCopy()
+	stale.Status.Properties[propertyprovider.NodeCountProperty] = clusterv1beta1.PropertyValue{Value: "8"}
+	stale.Status.ResourceUsage.Available[corev1.ResourceCPU] = resource.MustParse("3")
+	q, _ = r.Quantity(stale, propertyprovider.NodeCountProperty)
+	if diff := cmp.Diff(ptr.To(resource.MustParse("4")), q); diff != "" {
+		t.Errorf("Quantity() with the same resource version mismatch (-want, +got):\n%s", diff)
+	}
+	q, _ = r.Quantity(stale, propertyprovider.AvailableCPUCapacityProperty)
+	if diff := cmp.Diff(ptr.To(resource.MustParse("2")), q); diff != "" {
+		t.Errorf("Quantity() of a mutated quantity mismatch (-want, +got):\n%s", diff)
+	}
+
+	// The cache is invalidated when the resource version changes.
+	updated := stale.DeepCopy()
+	updated.ResourceVersion = "2"
+	q, _ = r.Quantity(updated, propertyprovider.NodeCountProperty)
+	if diff := cmp.Diff(ptr.To(resource.MustParse("8")), q); diff != "" {
+		t.Errorf("Quantity() with a new resource version mismatch (-want, +got):\n%s", diff)
+	}
+
+	r.retainOnly([]clusterv1beta1.MemberCluster{})
+	if len(r.clusters) != 0 {
+		t.Errorf("retainOnly() kept %d cluster(s), want 0", len(r.clusters))
+	}
+
+	// A nil reader performs lookups without caching.
+	var nilReader *PropertyReader
+	q, err = nilReader.Quantity(updated, propertyprovider.NodeCountProperty)
+	if err != nil {
+		t.Fatalf("Quantity() on a nil reader = %v, want no error", err)
+	}
+	if diff := cmp.Diff(ptr.To(resource.MustParse("8")), q); diff != "" {
+		t.Errorf("Quantity() on a nil reader mismatch (-want, +got):\n%s", diff)
+	}
+}
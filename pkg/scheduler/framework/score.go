@@ -72,6 +72,13 @@ func (s1 *ClusterScore) Less(s2 *ClusterScore) bool {
 type ScoredCluster struct {
 	Cluster *clusterv1beta1.MemberCluster
 	Score   *ClusterScore
+
+	// pluginScores is the scores from individual score plugins, keyed by the plugin names; it is
+	// only used during score aggregation.
+	pluginScores map[string]*ClusterScore
+	// sortKeys, if set, take precedence over the score when ranking clusters; they are compared
+	// in order, i.e., lexicographically. See ScoreAggregationStrategy for more information.
+	sortKeys []*ClusterScore
 }
 
 // ScoredClusters is a list of ScoredClusters; this type implements the sort.Interface.
@@ -80,8 +87,8 @@ type ScoredClusters []*ScoredCluster
 // Len returns the length of a ScoredClusters; it implemented sort.Interface.Len().
 func (sc ScoredClusters) Len() int { return len(sc) }
 
-// Less returns true if a ScoredCluster is of a lower score than another; when two clusters have
-// the same score, the one with a name that is lexicographically smaller is considered to be the
+// Less returns true if a ScoredCluster is of a lower score than another; the sort keys (if any)
+// are compared first, then the scores. When two clusters have the same sort keys and score, the one with a name that is lexicographically smaller is considered to be the
 // smaller one.
 //
 // It implemented sort.Interface.Less().
@@ -89,6 +96,12 @@ func (sc ScoredClusters) Len() int { return len(sc) }
 // Note that this will panic if there is a reference to nil scores and/or clusters; caller
 // should verify if the list is valid.
 func (sc ScoredClusters) Less(i, j int) bool {
+	for k := 0; k < len(sc[i].sortKeys) && k < len(sc[j].sortKeys); k++ {
+		if !sc[i].sortKeys[k].Equal(sc[j].sortKeys[k]) {
+			return sc[i].sortKeys[k].Less(sc[j].sortKeys[k])
+		}
+	}
+
 	if sc[i].Score.Equal(sc[j].Score) {
 		return sc[i].Cluster.Name < sc[j].Cluster.Name
 	}
@@ -521,7 +521,7 @@ func TestScoredClustersSort(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			sort.Sort(sort.Reverse(tc.scs))
-			if diff := cmp.Diff(tc.scs, tc.want); diff != "" {
+			if diff := cmp.Diff(tc.scs, tc.want, cmp.AllowUnexported(ScoredCluster{})); diff != "" {
 				t.Fatalf("Sort() sorted diff (-got, +want): %s", diff)
 			}
 		})
@@ -0,0 +1,184 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ScoreAggregationStrategy is the strategy the scheduler framework uses to aggregate the scores
+// that different score plugins assign to a cluster.
+type ScoreAggregationStrategy string
+
+const (
+	// ScoreAggregationStrategyWeightedSum ranks clusters by the weighted sum of the scores from all
+	// score plugins. This is the default strategy.
+	ScoreAggregationStrategyWeightedSum ScoreAggregationStrategy = "WeightedSum"
+
+	// ScoreAggregationStrategyLexicographic groups score plugins by their priority classes, and ranks
+	// clusters by the (weighted) scores from the highest priority class first; scores from a lower
+	// priority class are only used to break ties. This allows, for example, affinity preferences to
+	// strictly dominate capacity scoring, instead of blending with it.
+	ScoreAggregationStrategyLexicographic ScoreAggregationStrategy = "Lexicographic"
+
+	// ScoreAggregationStrategyMinMaxFairness normalizes the scores from each score plugin to the
+	// same range across all the clusters being scored (min-max normalization) before adding them up
+	// with their weights, so that no plugin dominates the ranking simply because its scores happen
+	// to have a wider range.
+	ScoreAggregationStrategyMinMaxFairness ScoreAggregationStrategy = "MinMaxFairness"
+)
+
+const (
+	// normalizedScoreMax is the upper bound of normalized scores with the MinMaxFairness strategy.
+	normalizedScoreMax = 100
+)
+
+// pluginWeight returns the weight of a score plugin.
+func (profile *Profile) pluginWeight(pluginName string) int {
+	if w, ok := profile.scorePluginWeights[pluginName]; ok {
+		return w
+	}
+	return 1
+}
+
+// weighted returns a copy of a score multiplied by a weight.
+func weighted(score *ClusterScore, weight int) *ClusterScore {
+	return &ClusterScore{
+		TopologySpreadScore:            score.TopologySpreadScore * weight,
+		AffinityScore:                  score.AffinityScore * weight,
+		ObsoletePlacementAffinityScore: score.ObsoletePlacementAffinityScore * weight,
+	}
+}
+
+// aggregateScores sets the scores of the given clusters by aggregating the scores from different
+// score plugins with the strategy specified in the profile.
+//
+// The (total) score of a cluster is always the weighted sum of the scores from all score plugins,
+// which is reported in the scheduling decisions; with strategies other than WeightedSum, the
+// clusters are further assigned sort keys, which take precedence over the total scores when ranking
+// the clusters.
+func (profile *Profile) aggregateScores(scoredClusters ScoredClusters) error {
+	for _, sc := range scoredClusters {
+		total := &ClusterScore{}
+		for pluginName, score := range sc.pluginScores {
+			total.Add(weighted(score, profile.pluginWeight(pluginName)))
+		}
+		sc.Score = total
+	}
+
+	switch profile.scoreAggregationStrategy {
+	case "", ScoreAggregationStrategyWeightedSum:
+		// No sort keys are needed.
+	case ScoreAggregationStrategyLexicographic:
+		profile.setLexicographicSortKeys(scoredClusters)
+	case ScoreAggregationStrategyMinMaxFairness:
+		profile.setMinMaxFairnessSortKeys(scoredClusters)
+	default:
+		return fmt.Errorf("unknown score aggregation strategy %s", profile.scoreAggregationStrategy)
+	}
+
+	// The scores from individual plugins are no longer needed.
+	for _, sc := range scoredClusters {
+		sc.pluginScores = nil
+	}
+	return nil
+}
+
+// setLexicographicSortKeys assigns each cluster one sort key per priority class of score plugins,
+// in descending order of the priority classes.
+func (profile *Profile) setLexicographicSortKeys(scoredClusters ScoredClusters) {
+	priorities := make([]int, 0, len(profile.scorePlugins))
+	seen := make(map[int]bool, len(profile.scorePlugins))
+	scorerNames := make([]string, 0, len(profile.scorePlugins)+len(profile.extenders))
+	for _, pl := range profile.scorePlugins {
+		scorerNames = append(scorerNames, pl.Name())
+	}
+	for _, ext := range profile.extenders {
+		if ext.IsScorer() {
+			scorerNames = append(scorerNames, ext.Name())
+		}
+	}
+	for _, name := range scorerNames {
+		p := profile.scorePluginPriorities[name]
+		if !seen[p] {
+			seen[p] = true
+			priorities = append(priorities, p)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(priorities)))
+	keyIdxByPriority := make(map[int]int, len(priorities))
+	for idx, p := range priorities {
+		keyIdxByPriority[p] = idx
+	}
+
+	for _, sc := range scoredClusters {
+		keys := make([]*ClusterScore, len(priorities))
+		for idx := range keys {
+			keys[idx] = &ClusterScore{}
+		}
+		for pluginName, score := range sc.pluginScores {
+			idx, ok := keyIdxByPriority[profile.scorePluginPriorities[pluginName]]
+			if !ok {
+				// Normally this should never happen, as all scores come from the plugins
+				// and extenders registered in the profile.
+				continue
+			}
+			keys[idx].Add(weighted(score, profile.pluginWeight(pluginName)))
+		}
+		sc.sortKeys = keys
+	}
+}
+
+// setMinMaxFairnessSortKeys assigns each cluster a sort key, which is the weighted sum of the
+// scores from all score plugins, each normalized to the range of [0, normalizedScoreMax] across
+// all the clusters.
+func (profile *Profile) setMinMaxFairnessSortKeys(scoredClusters ScoredClusters) {
+	type extremums struct {
+		min, max ClusterScore
+	}
+	extremumsByPlugin := make(map[string]*extremums)
+	for _, sc := range scoredClusters {
+		for pluginName, score := range sc.pluginScores {
+			e, ok := extremumsByPlugin[pluginName]
+			if !ok {
+				extremumsByPlugin[pluginName] = &extremums{min: *score, max: *score}
+				continue
+			}
+			e.min.TopologySpreadScore = min(e.min.TopologySpreadScore, score.TopologySpreadScore)
+			e.max.TopologySpreadScore = max(e.max.TopologySpreadScore, score.TopologySpreadScore)
+			e.min.AffinityScore = min(e.min.AffinityScore, score.AffinityScore)
+			e.max.AffinityScore = max(e.max.AffinityScore, score.AffinityScore)
+			e.min.ObsoletePlacementAffinityScore = min(e.min.ObsoletePlacementAffinityScore, score.ObsoletePlacementAffinityScore)
+			e.max.ObsoletePlacementAffinityScore = max(e.max.ObsoletePlacementAffinityScore, score.ObsoletePlacementAffinityScore)
+		}
+	}
+
+	for _, sc := range scoredClusters {
+		key := &ClusterScore{}
+		for pluginName, score := range sc.pluginScores {
+			e := extremumsByPlugin[pluginName]
+			normalized := &ClusterScore{
+				TopologySpreadScore:            normalize(score.TopologySpreadScore, e.min.TopologySpreadScore, e.max.TopologySpreadScore),
+				AffinityScore:                  normalize(score.AffinityScore, e.min.AffinityScore, e.max.AffinityScore),
+				ObsoletePlacementAffinityScore: normalize(score.ObsoletePlacementAffinityScore, e.min.ObsoletePlacementAffinityScore, e.max.ObsoletePlacementAffinityScore),
+			}
+			key.Add(weighted(normalized, profile.pluginWeight(pluginName)))
+		}
+		sc.sortKeys = []*ClusterScore{key}
+	}
+}
+
+// normalize maps a value in the range of [minV, maxV] to the range of [0, normalizedScoreMax].
+//
+// If all the values are the same, i.e., minV equals maxV, the value is normalized to 0.
+func normalize(v, minV, maxV int) int {
+	if maxV == minV {
+		return 0
+	}
+	return int(math.Round(float64(v-minV) / float64(maxV-minV) * normalizedScoreMax))
+}
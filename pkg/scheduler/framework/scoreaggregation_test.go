@@ -0,0 +1,154 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+)
+
+const (
+	affinityScorePluginName = "affinity"
+	capacityScorePluginName = "capacity"
+)
+
+// TestAggregateScores tests the aggregateScores method of Profile.
+func TestAggregateScores(t *testing.T) {
+	// With plain sums, cluster-1 wins with a large capacity score, even though cluster-2 and
+	// cluster-3 are preferred by the affinity plugin.
+	pluginScoresByCluster := map[string]map[string]*ClusterScore{
+		"cluster-1": {
+			affinityScorePluginName: {AffinityScore: 10},
+			capacityScorePluginName: {AffinityScore: 1000},
+		},
+		"cluster-2": {
+			affinityScorePluginName: {AffinityScore: 20},
+			capacityScorePluginName: {AffinityScore: 100},
+		},
+		"cluster-3": {
+			affinityScorePluginName: {AffinityScore: 20},
+			capacityScorePluginName: {AffinityScore: 500},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		configure       func(profile *Profile)
+		wantOrder       []string
+		wantTotalScores map[string]int
+		wantErr         bool
+	}{
+		{
+			name:      "weighted sum (default)",
+			configure: func(_ *Profile) {},
+			wantOrder: []string{"cluster-1", "cluster-3", "cluster-2"},
+			wantTotalScores: map[string]int{
+				"cluster-1": 1010,
+				"cluster-2": 120,
+				"cluster-3": 520,
+			},
+		},
+		{
+			name: "weighted sum with plugin weights",
+			configure: func(profile *Profile) {
+				profile.WithScorePluginWeight(affinityScorePluginName, 100)
+			},
+			wantOrder: []string{"cluster-3", "cluster-2", "cluster-1"},
+			wantTotalScores: map[string]int{
+				"cluster-1": 2000,
+				"cluster-2": 2100,
+				"cluster-3": 2500,
+			},
+		},
+		{
+			name: "lexicographic",
+			configure: func(profile *Profile) {
+				profile.WithScoreAggregationStrategy(ScoreAggregationStrategyLexicographic).
+					WithScorePluginPriority(affinityScorePluginName, 1)
+			},
+			wantOrder: []string{"cluster-3", "cluster-2", "cluster-1"},
+			wantTotalScores: map[string]int{
+				"cluster-1": 1010,
+				"cluster-2": 120,
+				"cluster-3": 520,
+			},
+		},
+		{
+			name: "min-max fairness",
+			configure: func(profile *Profile) {
+				profile.WithScoreAggregationStrategy(ScoreAggregationStrategyMinMaxFairness)
+			},
+			// Normalized scores: cluster-1 0+100, cluster-2 100+0, cluster-3 100+44; the tie between
+			// cluster-1 and cluster-2 is broken by their total scores.
+			wantOrder: []string{"cluster-3", "cluster-1", "cluster-2"},
+			wantTotalScores: map[string]int{
+				"cluster-1": 1010,
+				"cluster-2": 120,
+				"cluster-3": 520,
+			},
+		},
+		{
+			name: "unknown strategy",
+			configure: func(profile *Profile) {
+				profile.WithScoreAggregationStrategy("Unknown")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			profile := NewProfile(dummyProfileName)
+			profile.WithScorePlugin(&DummyAllPurposePlugin{name: affinityScorePluginName}).
+				WithScorePlugin(&DummyAllPurposePlugin{name: capacityScorePluginName})
+			tc.configure(profile)
+
+			scoredClusters := make(ScoredClusters, 0, len(pluginScoresByCluster))
+			for clusterName, pluginScores := range pluginScoresByCluster {
+				scoredClusters = append(scoredClusters, &ScoredCluster{
+					Cluster:      &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: clusterName}},
+					pluginScores: pluginScores,
+				})
+			}
+
+			err := profile.aggregateScores(scoredClusters)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("aggregateScores() = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("aggregateScores() = %v, want no error", err)
+			}
+
+			gotTotalScores := make(map[string]int, len(scoredClusters))
+			for _, sc := range scoredClusters {
+				gotTotalScores[sc.Cluster.Name] = sc.Score.AffinityScore
+				if sc.pluginScores != nil {
+					t.Errorf("aggregateScores() kept plugin scores for cluster %s, want nil", sc.Cluster.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.wantTotalScores, gotTotalScores); diff != "" {
+				t.Errorf("aggregateScores() total scores diff (-want, +got):\n%s", diff)
+			}
+
+			sort.Sort(sort.Reverse(scoredClusters))
+			gotOrder := make([]string, 0, len(scoredClusters))
+			for _, sc := range scoredClusters {
+				gotOrder = append(gotOrder, sc.Cluster.Name)
+			}
+			if diff := cmp.Diff(tc.wantOrder, gotOrder); diff != "" {
+				t.Errorf("ranked clusters diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -36,9 +36,14 @@ const (
 	// reduce the overhead of having to repeatedly call a plugin that is not needed for every
 	// cluster in the Filter or Score stage.
 	Skip
+	// Wait signals that a plugin has found that a placement may be bound to a specific cluster,
+	// but not until some condition is met, e.g., an external approval has been granted.
+	//
+	// This status code is only valid at the Permit stage.
+	Wait
 )
 
-var statusCodeNames = []string{"Success", "InternalError", "ClusterUnschedulable", "ClusterAlreadySelected", "Skip"}
+var statusCodeNames = []string{"Success", "InternalError", "ClusterUnschedulable", "ClusterAlreadySelected", "Skip", "Wait"}
 
 // Name returns the name of a status code.
 func (sc StatusCode) Name() string {
@@ -82,6 +87,11 @@ func (s *Status) IsSkip() bool {
 	return s.code() == Skip
 }
 
+// IsWait returns if a Status is of the status code Wait.
+func (s *Status) IsWait() bool {
+	return s.code() == Wait
+}
+
 // IsClusterUnschedulable returns if a Status is of the status code ClusterUnschedulable.
 func (s *Status) IsClusterUnschedulable() bool {
 	return s.code() == ClusterUnschedulable
@@ -62,6 +62,12 @@ func TestNonNilStatusMethods(t *testing.T) {
 			reasons:      dummyReasons,
 			sourcePlugin: dummyPlugin,
 		},
+		{
+			name:         "status wait",
+			statusCode:   Wait,
+			reasons:      dummyReasons,
+			sourcePlugin: dummyPlugin,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -81,6 +87,7 @@ func TestNonNilStatusMethods(t *testing.T) {
 				status.IsClusterUnschedulable,
 				status.IsClusterAlreadySelected,
 				status.IsSkip,
+				status.IsWait,
 			}
 			for idx, checkFunc := range checkFuncs {
 				if wantCheckOutputs[idx] != checkFunc() {
@@ -8,6 +8,8 @@ Licensed under the MIT license.
 package uniquename
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/util/uuid"
@@ -40,15 +42,48 @@ func minInt(a, b int) int {
 // In addition, note that this function assumes that both the CRP name and the cluster name
 // are valid DNS label names (RFC 1123).
 func NewClusterResourceBindingName(CRPName string, clusterName string) (string, error) {
-	reservedSlots := 2 + uuidLength // 2 dashes + 8 character UUID string
+	return formatClusterResourceBindingName(CRPName, clusterName, string(uuid.NewUUID()[:uuidLength]))
+}
+
+// NewDeterministicClusterResourceBindingName returns a name for a cluster resource binding in the
+// same format as NewClusterResourceBindingName, except that the suffix is derived from the given
+// seed rather than generated at random; the same CRP name, cluster name, and seed always yield
+// the same name.
+//
+// The name is generated using the following format:
+// * [CRP-NAME] - [TARGET-CLUSTER-NAME] - [HASH-SUFFIX]
+//
+// where the hash suffix is the first 8 hex characters of the SHA-256 hash of
+// [CRP-NAME]/[TARGET-CLUSTER-NAME]/[SEED]; as the hash covers the full CRP and cluster names,
+// names with the same truncated segments still differ in their suffixes.
+//
+// The scheduler uses such names so that retrying the creation of a binding (e.g., after a
+// scheduling cycle fails midway) never produces a second binding for the same decision; it is
+// up to the caller to detect names that are already taken by other bindings, and pick a different
+// seed for them.
+func NewDeterministicClusterResourceBindingName(CRPName string, clusterName string, seed string) (string, error) {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", CRPName, clusterName, seed)))
+	return formatClusterResourceBindingName(CRPName, clusterName, hex.EncodeToString(hash[:])[:uuidLength])
+}
 
+// NewClusterSchedulingDecisionName returns the name of the cluster scheduling decision about a
+// cluster for a CRP, in the same format as NewDeterministicClusterResourceBindingName, except
+// that the suffix is derived from the CRP name and the cluster name only; that is, there is
+// exactly one name for each (CRP, cluster) pair.
+func NewClusterSchedulingDecisionName(CRPName string, clusterName string) (string, error) {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s", CRPName, clusterName)))
+	return formatClusterResourceBindingName(CRPName, clusterName, hex.EncodeToString(hash[:])[:uuidLength])
+}
+
+// formatClusterResourceBindingName formats a cluster resource binding name with the given suffix.
+func formatClusterResourceBindingName(CRPName string, clusterName string, suffix string) (string, error) {
+	reservedSlots := 2 + uuidLength // 2 dashes + 8 character suffix
 	slotsPerSeg := (validation.DNS1123LabelMaxLength - reservedSlots) / 2
 	uniqueName := fmt.Sprintf("%s-%s-%s",
 		CRPName[:minInt(slotsPerSeg, len(CRPName))],
 		clusterName[:minInt(slotsPerSeg+1, len(clusterName))],
-		uuid.NewUUID()[:uuidLength],
+		suffix,
 	)
-
 	if errs := validation.IsDNS1123Label(uniqueName); len(errs) != 0 {
 		// Do a sanity check here; normally this would not occur.
 		return "", fmt.Errorf("failed to format a unique RFC 1123 label name with CRP name %s, cluster name %s: %v", CRPName, clusterName, errs)
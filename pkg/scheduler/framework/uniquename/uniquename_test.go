@@ -74,3 +74,57 @@ func TestClusterResourceBindingUniqueName(t *testing.T) {
 		})
 	}
 }
+
+// TestNewDeterministicClusterResourceBindingName tests the NewDeterministicClusterResourceBindingName function.
+func TestNewDeterministicClusterResourceBindingName(t *testing.T) {
+	name, err := NewDeterministicClusterResourceBindingName(crpName, clusterName, "policy-1")
+	if err != nil {
+		t.Fatalf("NewDeterministicClusterResourceBindingName() = %v, want no error", err)
+	}
+	if wantPrefix := fmt.Sprintf("%s-%s-", crpName, clusterName); !strings.HasPrefix(name, wantPrefix) {
+		t.Errorf("NewDeterministicClusterResourceBindingName() = %s, want to have prefix %s", name, wantPrefix)
+	}
+
+	again, err := NewDeterministicClusterResourceBindingName(crpName, clusterName, "policy-1")
+	if err != nil || again != name {
+		t.Errorf("NewDeterministicClusterResourceBindingName() with the same seed = %s, %v, want %s", again, err, name)
+	}
+	other, err := NewDeterministicClusterResourceBindingName(crpName, clusterName, "policy-2")
+	if err != nil || other == name {
+		t.Errorf("NewDeterministicClusterResourceBindingName() with a different seed = %s, %v, want a name other than %s", other, err, name)
+	}
+
+	truncated, err := NewDeterministicClusterResourceBindingName(longName, longName, "policy-1")
+	if err != nil || len(truncated) != 63 {
+		t.Errorf("NewDeterministicClusterResourceBindingName() with long names = %s, %v, want a name of length 63", truncated, err)
+	}
+}
+
+func TestNewClusterSchedulingDecisionName(t *testing.T) {
+	name, err := NewClusterSchedulingDecisionName(crpName, clusterName)
+	if err != nil {
+		t.Fatalf("NewClusterSchedulingDecisionName() = %v, want no error", err)
+	}
+	if wantPrefix := fmt.Sprintf("%s-%s-", crpName, clusterName); !strings.HasPrefix(name, wantPrefix) {
+		t.Errorf("NewClusterSchedulingDecisionName() = %s, want to have prefix %s", name, wantPrefix)
+	}
+
+	again, err := NewClusterSchedulingDecisionName(crpName, clusterName)
+	if err != nil || again != name {
+		t.Errorf("NewClusterSchedulingDecisionName() with the same names = %s, %v, want %s", again, err, name)
+	}
+	// The names of different pairs never collide, even if their segments join into the same prefix.
+	other, err := NewClusterSchedulingDecisionName(crpName+"-"+clusterName, "x")
+	if err != nil {
+		t.Fatalf("NewClusterSchedulingDecisionName() = %v, want no error", err)
+	}
+	another, err := NewClusterSchedulingDecisionName(crpName, clusterName+"-x")
+	if err != nil || other == another {
+		t.Errorf("NewClusterSchedulingDecisionName() with different pairs = %s, %v, want a name other than %s", another, err, other)
+	}
+
+	truncated, err := NewClusterSchedulingDecisionName(longName, longName)
+	if err != nil || len(truncated) != 63 {
+		t.Errorf("NewClusterSchedulingDecisionName() with long names = %s, %v, want a name of length 63", truncated, err)
+	}
+}
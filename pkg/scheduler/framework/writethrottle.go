@@ -0,0 +1,56 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// bindingWriteThrottle paces the writes to bindings made by the scheduler framework, so that
+// scheduling cycles which create or update many bindings at once do not overwhelm the API server.
+type bindingWriteThrottle struct {
+	// limiter caps the rate of the writes; nil means no limit.
+	limiter *rate.Limiter
+	// jitter is the max random delay before each write; zero means no delay.
+	jitter time.Duration
+}
+
+// newBindingWriteThrottle returns a new bindingWriteThrottle, or nil if the writes need no pacing.
+func newBindingWriteThrottle(limiter *rate.Limiter, jitter time.Duration) *bindingWriteThrottle {
+	if limiter == nil && jitter <= 0 {
+		return nil
+	}
+	return &bindingWriteThrottle{
+		limiter: limiter,
+		jitter:  jitter,
+	}
+}
+
+// wait blocks until a write can be made, or the context is cancelled.
+//
+// It returns immediately on a nil throttle.
+func (t *bindingWriteThrottle) wait(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	if t.jitter > 0 {
+		timer := time.NewTimer(rand.N(t.jitter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if t.limiter != nil {
+		return t.limiter.Wait(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package framework
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// TestBindingWriteThrottleWait tests the wait method of bindingWriteThrottle.
+func TestBindingWriteThrottleWait(t *testing.T) {
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []struct {
+		name     string
+		throttle *bindingWriteThrottle
+		ctx      context.Context
+		waits    int
+		wantErr  bool
+	}{
+		{
+			name:  "no pacing",
+			ctx:   cancelledCtx,
+			waits: 10,
+		},
+		{
+			name:     "within burst",
+			throttle: newBindingWriteThrottle(rate.NewLimiter(rate.Limit(0.001), 2), 0),
+			ctx:      context.Background(),
+			waits:    2,
+		},
+		{
+			name:     "beyond burst",
+			throttle: newBindingWriteThrottle(rate.NewLimiter(rate.Limit(0.001), 2), 0),
+			ctx:      context.Background(),
+			waits:    3,
+			wantErr:  true,
+		},
+		{
+			name:     "jitter with cancelled context",
+			throttle: newBindingWriteThrottle(nil, time.Hour),
+			ctx:      cancelledCtx,
+			waits:    1,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(tc.ctx, time.Second)
+			defer cancel()
+			var err error
+			for i := 0; i < tc.waits && err == nil; i++ {
+				err = tc.throttle.wait(ctx)
+			}
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("wait() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// TestWithBindingWriteRateLimit tests the WithBindingWriteRateLimit option.
+func TestWithBindingWriteRateLimit(t *testing.T) {
+	opt := WithBindingWriteRateLimit(10, 5)
+	options1, options2 := defaultFrameworkOptions, defaultFrameworkOptions
+	opt(&options1)
+	opt(&options2)
+	if options1.bindingWriteLimiter == nil || options1.bindingWriteLimiter != options2.bindingWriteLimiter {
+		t.Errorf("WithBindingWriteRateLimit() set limiters %p and %p, want the same limiter", options1.bindingWriteLimiter, options2.bindingWriteLimiter)
+	}
+
+	options := defaultFrameworkOptions
+	WithBindingWriteRateLimit(0, 5)(&options)
+	if options.bindingWriteLimiter != nil {
+		t.Errorf("WithBindingWriteRateLimit(0) set a limiter, want no limit")
+	}
+	if throttle := newBindingWriteThrottle(options.bindingWriteLimiter, options.bindingWriteJitter); throttle != nil {
+		t.Errorf("newBindingWriteThrottle() = %+v, want nil", throttle)
+	}
+}
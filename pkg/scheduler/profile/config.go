@@ -0,0 +1,450 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"go.goms.io/fleet/pkg/scheduler/extender"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// allPlugins is the wildcard name which disables all the default plugins at an extension point.
+	allPlugins = "*"
+)
+
+// Configuration is the configuration of the scheduling profiles in use by the scheduler.
+//
+// A configuration is usually written in YAML and mounted to the hub agent from a ConfigMap, e.g.,
+//
+//	profiles:
+//	- name: DefaultProfile
+//	  scoreAggregationStrategy: Lexicographic
+//	  plugins:
+//	    score:
+//	      disabled:
+//	      - name: ClusterAffinity
+//	      enabled:
+//	      - name: ClusterAffinity
+//	        priority: 1
+//	- name: CanaryProfile
+//	  plugins:
+//	    filter:
+//	      disabled:
+//	      - name: TaintToleration
+//	    preBind:
+//	      enabled:
+//	      - name: RolloutGroup
+//	  pluginConfig:
+//	  - name: RolloutGroup
+//	    args:
+//	      clusterLabelKey: example.com/rollout-group
+//	      groupOrder: [canary, prod]
+//	  extenders:
+//	  - name: ChangeWindow
+//	    urlPrefix: https://change-window.example.com/fleet
+//	    filterVerb: filter
+//	    ignorable: true
+type Configuration struct {
+	// Profiles is the scheduling profiles; the first profile is the default one, which the scheduler
+	// uses for placements that do not name a profile with the SchedulerProfileAnnotation.
+	Profiles []ProfileConfiguration `json:"profiles"`
+}
+
+// ProfileConfiguration is the configuration of a scheduling profile; it controls which plugins run at
+// each extension point and in which order, along with the weights of score plugins and the args of
+// plugins.
+type ProfileConfiguration struct {
+	// Name is the name of the profile; it defaults to the name of the default profile.
+	Name string `json:"name,omitempty"`
+
+	// Plugins specifies the plugins to enable or disable at each extension point, on top of the
+	// plugins of the default profile.
+	Plugins Plugins `json:"plugins,omitempty"`
+
+	// PluginConfig is the args of the plugins in use.
+	PluginConfig []PluginConfig `json:"pluginConfig,omitempty"`
+
+	// ScoreAggregationStrategy is the strategy the scheduler uses to aggregate the scores from
+	// different score plugins; it defaults to WeightedSum.
+	ScoreAggregationStrategy framework.ScoreAggregationStrategy `json:"scoreAggregationStrategy,omitempty"`
+
+	// Extenders is the out-of-tree web services which filter and/or score clusters in addition to
+	// the plugins; they are called in order, after the plugins at the same stage.
+	Extenders []extender.Config `json:"extenders,omitempty"`
+}
+
+// Plugins specifies the plugins to enable or disable at each extension point.
+type Plugins struct {
+	PostBatch  PluginSet `json:"postBatch,omitempty"`
+	PreFilter  PluginSet `json:"preFilter,omitempty"`
+	Filter     PluginSet `json:"filter,omitempty"`
+	PostFilter PluginSet `json:"postFilter,omitempty"`
+	PreScore   PluginSet `json:"preScore,omitempty"`
+	Score      PluginSet `json:"score,omitempty"`
+	Permit     PluginSet `json:"permit,omitempty"`
+	Reserve    PluginSet `json:"reserve,omitempty"`
+	PreBind    PluginSet `json:"preBind,omitempty"`
+}
+
+// PluginSet specifies the plugins to enable or disable at an extension point.
+//
+// The plugins that run at an extension point are the default plugins, minus the disabled ones, plus
+// the enabled ones in order; to reorder the default plugins, disable all of them with the wildcard
+// name "*" and enable them again in the expected order.
+type PluginSet struct {
+	// Enabled is the plugins to run after the default ones, in order.
+	Enabled []Plugin `json:"enabled,omitempty"`
+	// Disabled is the default plugins not to run.
+	Disabled []Plugin `json:"disabled,omitempty"`
+}
+
+// Plugin specifies a plugin at an extension point.
+type Plugin struct {
+	// Name is the name of the plugin.
+	Name string `json:"name"`
+	// Weight is the weight of the plugin; it only applies to score plugins, and defaults to 1.
+	Weight *int `json:"weight,omitempty"`
+	// Priority is the priority class of the plugin; it only applies to score plugins with the
+	// Lexicographic aggregation strategy, and defaults to 0.
+	Priority *int `json:"priority,omitempty"`
+}
+
+// PluginConfig is the args of a plugin.
+type PluginConfig struct {
+	// Name is the name of the plugin.
+	Name string `json:"name"`
+	// Args is the args of the plugin, the format of which depends on the plugin.
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+// defaultPlugins is the plugins of the default profile at each extension point.
+var defaultPlugins = Plugins{
+	PostBatch: PluginSet{
+		Enabled: []Plugin{{Name: TopologySpreadConstraintsPluginName}},
+	},
+	PreFilter: PluginSet{
+		Enabled: []Plugin{{Name: ClusterAffinityPluginName}, {Name: TopologySpreadConstraintsPluginName}, {Name: ExclusivityPluginName}, {Name: ResourceFitPluginName}, {Name: KubernetesVersionPluginName}, {Name: APIAvailabilityPluginName}, {Name: MaxPlacementsPluginName}, {Name: PlacementAntiAffinityPluginName}},
+	},
+	Filter: PluginSet{
+		Enabled: []Plugin{
+			{Name: ClusterAffinityPluginName},
+			{Name: ClusterEligibilityPluginName},
+			{Name: CordonPluginName},
+			{Name: TaintTolerationPluginName},
+			{Name: SamePlacementAntiAffinityPluginName},
+			{Name: TopologySpreadConstraintsPluginName},
+			{Name: ExclusivityPluginName},
+			{Name: ResourceFitPluginName},
+			{Name: KubernetesVersionPluginName},
+			{Name: APIAvailabilityPluginName},
+			{Name: MaxPlacementsPluginName},
+			{Name: PlacementAntiAffinityPluginName},
+		},
+	},
+	PostFilter: PluginSet{
+		Enabled: []Plugin{{Name: TaintTolerationPluginName}},
+	},
+	PreScore: PluginSet{
+		Enabled: []Plugin{{Name: ClusterAffinityPluginName}, {Name: TopologySpreadConstraintsPluginName}, {Name: ResourceFitPluginName}, {Name: WarmDependenciesPluginName}, {Name: PlacementAntiAffinityPluginName}, {Name: RecommendedClustersPluginName}},
+	},
+	Score: PluginSet{
+		Enabled: []Plugin{{Name: ClusterAffinityPluginName}, {Name: SamePlacementAntiAffinityPluginName}, {Name: TopologySpreadConstraintsPluginName}, {Name: ResourceFitPluginName}, {Name: WarmDependenciesPluginName}, {Name: PlacementAntiAffinityPluginName}, {Name: RecommendedClustersPluginName}},
+	},
+}
+
+// LoadConfiguration reads a scheduler configuration, in YAML or JSON, from a file.
+func LoadConfiguration(path string) (*Configuration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the scheduler configuration file %q: %w", path, err)
+	}
+	config := &Configuration{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse the scheduler configuration file %q: %w", path, err)
+	}
+	return config, nil
+}
+
+// NewProfilesFromConfiguration creates the scheduling profiles from a scheduler configuration, with
+// the plugins built by the factories in the registry; the default profile comes first.
+func NewProfilesFromConfiguration(config *Configuration, registry Registry) ([]*framework.Profile, error) {
+	if len(config.Profiles) == 0 {
+		return nil, fmt.Errorf("no scheduling profile is configured")
+	}
+
+	profiles := make([]*framework.Profile, 0, len(config.Profiles))
+	names := make(map[string]bool, len(config.Profiles))
+	for idx := range config.Profiles {
+		p, err := NewProfileFromConfiguration(&config.Profiles[idx], registry)
+		if err != nil {
+			return nil, fmt.Errorf("profiles[%d]: %w", idx, err)
+		}
+		if names[p.Name()] {
+			return nil, fmt.Errorf("profiles[%d]: profile %q is configured more than once", idx, p.Name())
+		}
+		names[p.Name()] = true
+		profiles = append(profiles, p)
+	}
+	return profiles, nil
+}
+
+// NewProfileFromConfiguration creates a scheduling profile from a profile configuration, with the
+// plugins built by the factories in the registry.
+func NewProfileFromConfiguration(config *ProfileConfiguration, registry Registry) (*framework.Profile, error) {
+	name := config.Name
+	if name == "" {
+		name = defaultProfileName
+	}
+	p := framework.NewProfile(name)
+
+	switch config.ScoreAggregationStrategy {
+	case "":
+	case framework.ScoreAggregationStrategyWeightedSum, framework.ScoreAggregationStrategyLexicographic, framework.ScoreAggregationStrategyMinMaxFairness:
+		p.WithScoreAggregationStrategy(config.ScoreAggregationStrategy)
+	default:
+		return nil, fmt.Errorf("unknown score aggregation strategy %q", config.ScoreAggregationStrategy)
+	}
+
+	args := make(map[string]json.RawMessage, len(config.PluginConfig))
+	for _, pluginConfig := range config.PluginConfig {
+		if _, ok := registry[pluginConfig.Name]; !ok {
+			return nil, fmt.Errorf("pluginConfig: unknown plugin %q", pluginConfig.Name)
+		}
+		if _, ok := args[pluginConfig.Name]; ok {
+			return nil, fmt.Errorf("pluginConfig: plugin %q is configured more than once", pluginConfig.Name)
+		}
+		args[pluginConfig.Name] = pluginConfig.Args
+	}
+
+	// Each plugin is built only once, so that a plugin which runs at multiple extension points
+	// shares its state across the extension points.
+	plugins := make(map[string]framework.Plugin)
+	pluginFor := func(extensionPoint, name string) (framework.Plugin, error) {
+		if plugin, ok := plugins[name]; ok {
+			return plugin, nil
+		}
+		factory, ok := registry[name]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown plugin %q", extensionPoint, name)
+		}
+		plugin, err := factory(args[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to build plugin %q: %w", name, err)
+		}
+		plugins[name] = plugin
+		if len(args[name]) != 0 {
+			p.WithPluginArgs(plugin.Name(), args[name])
+		}
+		return plugin, nil
+	}
+
+	extensionPoints := []struct {
+		name     string
+		defaults PluginSet
+		set      PluginSet
+		register func(plugin framework.Plugin) bool
+	}{
+		{
+			name:     "postBatch",
+			defaults: defaultPlugins.PostBatch,
+			set:      config.Plugins.PostBatch,
+			register: func(plugin framework.Plugin) bool {
+				pl, ok := plugin.(framework.PostBatchPlugin)
+				if ok {
+					p.WithPostBatchPlugin(pl)
+				}
+				return ok
+			},
+		},
+		{
+			name:     "preFilter",
+			defaults: defaultPlugins.PreFilter,
+			set:      config.Plugins.PreFilter,
+			register: func(plugin framework.Plugin) bool {
+				pl, ok := plugin.(framework.PreFilterPlugin)
+				if ok {
+					p.WithPreFilterPlugin(pl)
+				}
+				return ok
+			},
+		},
+		{
+			name:     "filter",
+			defaults: defaultPlugins.Filter,
+			set:      config.Plugins.Filter,
+			register: func(plugin framework.Plugin) bool {
+				pl, ok := plugin.(framework.FilterPlugin)
+				if ok {
+					p.WithFilterPlugin(pl)
+				}
+				return ok
+			},
+		},
+		{
+			name:     "postFilter",
+			defaults: defaultPlugins.PostFilter,
+			set:      config.Plugins.PostFilter,
+			register: func(plugin framework.Plugin) bool {
+				pl, ok := plugin.(framework.PostFilterPlugin)
+				if ok {
+					p.WithPostFilterPlugin(pl)
+				}
+				return ok
+			},
+		},
+		{
+			name:     "preScore",
+			defaults: defaultPlugins.PreScore,
+			set:      config.Plugins.PreScore,
+			register: func(plugin framework.Plugin) bool {
+				pl, ok := plugin.(framework.PreScorePlugin)
+				if ok {
+					p.WithPreScorePlugin(pl)
+				}
+				return ok
+			},
+		},
+		{
+			name:     "score",
+			defaults: defaultPlugins.Score,
+			set:      config.Plugins.Score,
+			register: func(plugin framework.Plugin) bool {
+				pl, ok := plugin.(framework.ScorePlugin)
+				if ok {
+					p.WithScorePlugin(pl)
+				}
+				return ok
+			},
+		},
+		{
+			name:     "permit",
+			defaults: defaultPlugins.Permit,
+			set:      config.Plugins.Permit,
+			register: func(plugin framework.Plugin) bool {
+				pl, ok := plugin.(framework.PermitPlugin)
+				if ok {
+					p.WithPermitPlugin(pl)
+				}
+				return ok
+			},
+		},
+		{
+			name:     "reserve",
+			defaults: defaultPlugins.Reserve,
+			set:      config.Plugins.Reserve,
+			register: func(plugin framework.Plugin) bool {
+				pl, ok := plugin.(framework.ReservePlugin)
+				if ok {
+					p.WithReservePlugin(pl)
+				}
+				return ok
+			},
+		},
+		{
+			name:     "preBind",
+			defaults: defaultPlugins.PreBind,
+			set:      config.Plugins.PreBind,
+			register: func(plugin framework.Plugin) bool {
+				pl, ok := plugin.(framework.PreBindPlugin)
+				if ok {
+					p.WithPreBindPlugin(pl)
+				}
+				return ok
+			},
+		},
+	}
+
+	for _, ep := range extensionPoints {
+		enabled, err := mergePluginSet(ep.name, ep.defaults, ep.set)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range enabled {
+			if (entry.Weight != nil || entry.Priority != nil) && ep.name != "score" {
+				return nil, fmt.Errorf("%s: weight and priority only apply to score plugins, but are set for plugin %q", ep.name, entry.Name)
+			}
+			if entry.Weight != nil && *entry.Weight <= 0 {
+				return nil, fmt.Errorf("%s: weight of plugin %q must be greater than 0, got %d", ep.name, entry.Name, *entry.Weight)
+			}
+
+			plugin, err := pluginFor(ep.name, entry.Name)
+			if err != nil {
+				return nil, err
+			}
+			if !ep.register(plugin) {
+				return nil, fmt.Errorf("%s: plugin %q does not support the extension point", ep.name, entry.Name)
+			}
+			if entry.Weight != nil {
+				p.WithScorePluginWeight(entry.Name, *entry.Weight)
+			}
+			if entry.Priority != nil {
+				p.WithScorePluginPriority(entry.Name, *entry.Priority)
+			}
+		}
+	}
+
+	extenderNames := make(map[string]bool, len(config.Extenders))
+	for idx := range config.Extenders {
+		extenderConfig := &config.Extenders[idx]
+		if _, ok := registry[extenderConfig.Name]; ok || extenderNames[extenderConfig.Name] {
+			return nil, fmt.Errorf("extenders[%d]: name %q is already in use", idx, extenderConfig.Name)
+		}
+		extenderNames[extenderConfig.Name] = true
+
+		ext, err := extender.NewHTTPExtender(extenderConfig)
+		if err != nil {
+			return nil, fmt.Errorf("extenders[%d]: %w", idx, err)
+		}
+		if (extenderConfig.Weight != nil || extenderConfig.Priority != nil) && !ext.IsScorer() {
+			return nil, fmt.Errorf("extenders[%d]: weight and priority only apply to extenders that score clusters", idx)
+		}
+		if extenderConfig.Weight != nil {
+			if *extenderConfig.Weight <= 0 {
+				return nil, fmt.Errorf("extenders[%d]: weight must be greater than 0, got %d", idx, *extenderConfig.Weight)
+			}
+			p.WithScorePluginWeight(ext.Name(), *extenderConfig.Weight)
+		}
+		if extenderConfig.Priority != nil {
+			p.WithScorePluginPriority(ext.Name(), *extenderConfig.Priority)
+		}
+		p.WithExtender(ext)
+	}
+	return p, nil
+}
+
+// mergePluginSet returns the plugins that run at an extension point, in order, i.e., the default
+// plugins minus the disabled ones, plus the enabled ones.
+func mergePluginSet(extensionPoint string, defaults, set PluginSet) ([]Plugin, error) {
+	disabled := make(map[string]bool, len(set.Disabled))
+	for _, entry := range set.Disabled {
+		disabled[entry.Name] = true
+	}
+
+	var merged []Plugin
+	seen := make(map[string]bool)
+	if !disabled[allPlugins] {
+		for _, entry := range defaults.Enabled {
+			if !disabled[entry.Name] {
+				merged = append(merged, entry)
+				seen[entry.Name] = true
+			}
+		}
+	}
+	for _, entry := range set.Enabled {
+		if seen[entry.Name] {
+			return nil, fmt.Errorf("%s: plugin %q is enabled more than once; disable it first to change its order", extensionPoint, entry.Name)
+		}
+		merged = append(merged, entry)
+		seen[entry.Name] = true
+	}
+	return merged, nil
+}
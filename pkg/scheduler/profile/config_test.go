@@ -0,0 +1,455 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package profile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/utils/ptr"
+
+	"go.goms.io/fleet/pkg/scheduler/extender"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/apiavailability"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/clusteraffinity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/clustereligibility"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/cordon"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/exclusivity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/kubernetesversion"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/maxplacements"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/placementantiaffinity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/preemption"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/recommendedclusters"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/resourcefit"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/rolloutgroup"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/sameplacementaffinity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/tainttoleration"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/topologyspreadconstraints"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/warmdependencies"
+)
+
+var (
+	profileCmpOptions = []cmp.Option{
+		cmp.AllowUnexported(
+			framework.Profile{},
+			apiavailability.Plugin{},
+			clusteraffinity.Plugin{},
+			clustereligibility.Plugin{},
+			cordon.Plugin{},
+			exclusivity.Plugin{},
+			kubernetesversion.Plugin{},
+			maxplacements.Plugin{},
+			placementantiaffinity.Plugin{},
+			preemption.Plugin{},
+			recommendedclusters.Plugin{},
+			resourcefit.Plugin{},
+			rolloutgroup.Plugin{},
+			sameplacementaffinity.Plugin{},
+			tainttoleration.Plugin{},
+			topologyspreadconstraints.Plugin{},
+			warmdependencies.Plugin{},
+		),
+	}
+)
+
+// TestLoadConfiguration tests the LoadConfiguration function.
+func TestLoadConfiguration(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		want    *Configuration
+		wantErr bool
+	}{
+		{
+			name: "valid configuration",
+			content: `
+profiles:
+- name: CustomProfile
+  scoreAggregationStrategy: Lexicographic
+  plugins:
+    score:
+      disabled:
+      - name: ClusterAffinity
+      enabled:
+      - name: ClusterAffinity
+        weight: 2
+        priority: 1
+  pluginConfig:
+  - name: RolloutGroup
+    args:
+      groupOrder: [canary, prod]
+`,
+			want: &Configuration{
+				Profiles: []ProfileConfiguration{
+					{
+						Name:                     "CustomProfile",
+						ScoreAggregationStrategy: framework.ScoreAggregationStrategyLexicographic,
+						Plugins: Plugins{
+							Score: PluginSet{
+								Enabled:  []Plugin{{Name: ClusterAffinityPluginName, Weight: ptr.To(2), Priority: ptr.To(1)}},
+								Disabled: []Plugin{{Name: ClusterAffinityPluginName}},
+							},
+						},
+						PluginConfig: []PluginConfig{
+							{Name: RolloutGroupPluginName, Args: json.RawMessage(`{"groupOrder":["canary","prod"]}`)},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unknown field",
+			content: `
+profiles:
+- plugins:
+    bind:
+      enabled:
+      - name: ClusterAffinity
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "profile.yaml")
+			if err := os.WriteFile(path, []byte(tc.content), 0600); err != nil {
+				t.Fatalf("failed to write the configuration file: %v", err)
+			}
+			got, err := LoadConfiguration(path)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("LoadConfiguration() = %v, want error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("LoadConfiguration() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestNewProfileFromConfiguration tests the NewProfileFromConfiguration function.
+func TestNewProfileFromConfiguration(t *testing.T) {
+	clusterAffinityPlugin := clusteraffinity.New()
+	clusterEligibilityPlugin := clustereligibility.New()
+	cordonPlugin := cordon.New()
+	samePlacementAffinityPlugin := sameplacementaffinity.New()
+	topologySpreadConstraintsPlugin := topologyspreadconstraints.New()
+	exclusivityPlugin := exclusivity.New()
+	rolloutGroupPlugin := rolloutgroup.New(rolloutgroup.WithClusterLabelKey("example.com/rollout-group"), rolloutgroup.WithGroupOrder([]string{"canary", "prod"}))
+	taintTolerationPlugin := tainttoleration.New()
+	preemptionPlugin := preemption.New(preemption.WithDryRun(true))
+	resourceFitPlugin := resourcefit.New()
+	warmDependenciesPlugin := warmdependencies.New()
+	kubernetesVersionPlugin := kubernetesversion.New()
+	apiAvailabilityPlugin := apiavailability.New()
+	maxPlacementsPlugin := maxplacements.New()
+	placementAntiAffinityPlugin := placementantiaffinity.New()
+	recommendedClustersPlugin := recommendedclusters.New()
+
+	testCases := []struct {
+		name    string
+		config  *ProfileConfiguration
+		want    *framework.Profile
+		wantErr bool
+	}{
+		{
+			name:   "empty configuration",
+			config: &ProfileConfiguration{},
+			want:   NewDefaultProfile(),
+		},
+		{
+			name: "customized configuration",
+			config: &ProfileConfiguration{
+				Name:                     "CustomProfile",
+				ScoreAggregationStrategy: framework.ScoreAggregationStrategyLexicographic,
+				Plugins: Plugins{
+					PreFilter: PluginSet{
+						Disabled: []Plugin{{Name: allPlugins}},
+						Enabled:  []Plugin{{Name: ExclusivityPluginName}, {Name: ClusterAffinityPluginName}},
+					},
+					Filter: PluginSet{
+						Disabled: []Plugin{{Name: TaintTolerationPluginName}, {Name: TopologySpreadConstraintsPluginName}},
+					},
+					PostFilter: PluginSet{
+						Disabled: []Plugin{{Name: TaintTolerationPluginName}},
+					},
+					Score: PluginSet{
+						Disabled: []Plugin{{Name: ClusterAffinityPluginName}},
+						Enabled:  []Plugin{{Name: ClusterAffinityPluginName, Weight: ptr.To(2), Priority: ptr.To(1)}},
+					},
+					PreBind: PluginSet{
+						Enabled: []Plugin{{Name: RolloutGroupPluginName}},
+					},
+				},
+				PluginConfig: []PluginConfig{
+					{Name: RolloutGroupPluginName, Args: json.RawMessage(`{"clusterLabelKey":"example.com/rollout-group","groupOrder":["canary","prod"]}`)},
+				},
+			},
+			want: framework.NewProfile("CustomProfile").
+				WithScoreAggregationStrategy(framework.ScoreAggregationStrategyLexicographic).
+				WithPostBatchPlugin(&topologySpreadConstraintsPlugin).
+				WithPreFilterPlugin(&exclusivityPlugin).WithPreFilterPlugin(&clusterAffinityPlugin).
+				WithFilterPlugin(&clusterAffinityPlugin).WithFilterPlugin(&clusterEligibilityPlugin).WithFilterPlugin(&cordonPlugin).WithFilterPlugin(&samePlacementAffinityPlugin).WithFilterPlugin(&exclusivityPlugin).WithFilterPlugin(&resourceFitPlugin).WithFilterPlugin(&kubernetesVersionPlugin).WithFilterPlugin(&apiAvailabilityPlugin).WithFilterPlugin(&maxPlacementsPlugin).WithFilterPlugin(&placementAntiAffinityPlugin).
+				WithPreScorePlugin(&clusterAffinityPlugin).WithPreScorePlugin(&topologySpreadConstraintsPlugin).WithPreScorePlugin(&resourceFitPlugin).WithPreScorePlugin(&warmDependenciesPlugin).WithPreScorePlugin(&placementAntiAffinityPlugin).WithPreScorePlugin(&recommendedClustersPlugin).
+				WithScorePlugin(&samePlacementAffinityPlugin).WithScorePlugin(&topologySpreadConstraintsPlugin).WithScorePlugin(&resourceFitPlugin).WithScorePlugin(&warmDependenciesPlugin).WithScorePlugin(&placementAntiAffinityPlugin).WithScorePlugin(&recommendedClustersPlugin).WithScorePlugin(&clusterAffinityPlugin).
+				WithScorePluginWeight(ClusterAffinityPluginName, 2).WithScorePluginPriority(ClusterAffinityPluginName, 1).
+				WithPreBindPlugin(&rolloutGroupPlugin).
+				WithPluginArgs(RolloutGroupPluginName, json.RawMessage(`{"clusterLabelKey":"example.com/rollout-group","groupOrder":["canary","prod"]}`)),
+		},
+		{
+			name: "preemption enabled",
+			config: &ProfileConfiguration{
+				Plugins: Plugins{
+					PreFilter:  PluginSet{Enabled: []Plugin{{Name: PreemptionPluginName}}},
+					Filter:     PluginSet{Enabled: []Plugin{{Name: PreemptionPluginName}}},
+					PostFilter: PluginSet{Enabled: []Plugin{{Name: PreemptionPluginName}}},
+				},
+				PluginConfig: []PluginConfig{
+					{Name: PreemptionPluginName, Args: json.RawMessage(`{"dryRun":true}`)},
+				},
+			},
+			want: framework.NewProfile(defaultProfileName).
+				WithPostBatchPlugin(&topologySpreadConstraintsPlugin).
+				WithPreFilterPlugin(&clusterAffinityPlugin).WithPreFilterPlugin(&topologySpreadConstraintsPlugin).WithPreFilterPlugin(&exclusivityPlugin).WithPreFilterPlugin(&resourceFitPlugin).WithPreFilterPlugin(&kubernetesVersionPlugin).WithPreFilterPlugin(&apiAvailabilityPlugin).WithPreFilterPlugin(&maxPlacementsPlugin).WithPreFilterPlugin(&placementAntiAffinityPlugin).WithPreFilterPlugin(&preemptionPlugin).
+				WithFilterPlugin(&clusterAffinityPlugin).WithFilterPlugin(&clusterEligibilityPlugin).WithFilterPlugin(&cordonPlugin).WithFilterPlugin(&taintTolerationPlugin).WithFilterPlugin(&samePlacementAffinityPlugin).WithFilterPlugin(&topologySpreadConstraintsPlugin).WithFilterPlugin(&exclusivityPlugin).WithFilterPlugin(&resourceFitPlugin).WithFilterPlugin(&kubernetesVersionPlugin).WithFilterPlugin(&apiAvailabilityPlugin).WithFilterPlugin(&maxPlacementsPlugin).WithFilterPlugin(&placementAntiAffinityPlugin).WithFilterPlugin(&preemptionPlugin).
+				WithPostFilterPlugin(&taintTolerationPlugin).WithPostFilterPlugin(&preemptionPlugin).
+				WithPreScorePlugin(&clusterAffinityPlugin).WithPreScorePlugin(&topologySpreadConstraintsPlugin).WithPreScorePlugin(&resourceFitPlugin).WithPreScorePlugin(&warmDependenciesPlugin).WithPreScorePlugin(&placementAntiAffinityPlugin).WithPreScorePlugin(&recommendedClustersPlugin).
+				WithScorePlugin(&clusterAffinityPlugin).WithScorePlugin(&samePlacementAffinityPlugin).WithScorePlugin(&topologySpreadConstraintsPlugin).WithScorePlugin(&resourceFitPlugin).WithScorePlugin(&warmDependenciesPlugin).WithScorePlugin(&placementAntiAffinityPlugin).WithScorePlugin(&recommendedClustersPlugin).
+				WithPluginArgs(PreemptionPluginName, json.RawMessage(`{"dryRun":true}`)),
+		},
+		{
+			name: "unknown score aggregation strategy",
+			config: &ProfileConfiguration{
+				ScoreAggregationStrategy: "Unknown",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown plugin",
+			config: &ProfileConfiguration{
+				Plugins: Plugins{
+					Filter: PluginSet{Enabled: []Plugin{{Name: "Unknown"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported extension point",
+			config: &ProfileConfiguration{
+				Plugins: Plugins{
+					Score: PluginSet{Enabled: []Plugin{{Name: ClusterEligibilityPluginName}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "plugin enabled more than once",
+			config: &ProfileConfiguration{
+				Plugins: Plugins{
+					Filter: PluginSet{Enabled: []Plugin{{Name: TaintTolerationPluginName}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "weight on a non-score plugin",
+			config: &ProfileConfiguration{
+				Plugins: Plugins{
+					Filter: PluginSet{
+						Disabled: []Plugin{{Name: TaintTolerationPluginName}},
+						Enabled:  []Plugin{{Name: TaintTolerationPluginName, Weight: ptr.To(2)}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "non-positive weight",
+			config: &ProfileConfiguration{
+				Plugins: Plugins{
+					Score: PluginSet{
+						Disabled: []Plugin{{Name: ClusterAffinityPluginName}},
+						Enabled:  []Plugin{{Name: ClusterAffinityPluginName, Weight: ptr.To(0)}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "args for a plugin without args",
+			config: &ProfileConfiguration{
+				PluginConfig: []PluginConfig{
+					{Name: ClusterAffinityPluginName, Args: json.RawMessage(`{"key":"value"}`)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid args",
+			config: &ProfileConfiguration{
+				Plugins: Plugins{
+					PreBind: PluginSet{Enabled: []Plugin{{Name: RolloutGroupPluginName}}},
+				},
+				PluginConfig: []PluginConfig{
+					{Name: RolloutGroupPluginName, Args: json.RawMessage(`{"groups":["canary"]}`)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "plugin configured more than once",
+			config: &ProfileConfiguration{
+				PluginConfig: []PluginConfig{
+					{Name: RolloutGroupPluginName},
+					{Name: RolloutGroupPluginName},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "extender name in use by a plugin",
+			config: &ProfileConfiguration{
+				Extenders: []extender.Config{
+					{Name: ClusterAffinityPluginName, URLPrefix: "https://extender.example.com", FilterVerb: "filter"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "extender configured more than once",
+			config: &ProfileConfiguration{
+				Extenders: []extender.Config{
+					{Name: "Approval", URLPrefix: "https://extender.example.com", FilterVerb: "filter"},
+					{Name: "Approval", URLPrefix: "https://extender.example.com", ScoreVerb: "score"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "weight on a non-score extender",
+			config: &ProfileConfiguration{
+				Extenders: []extender.Config{
+					{Name: "Approval", URLPrefix: "https://extender.example.com", FilterVerb: "filter", Weight: ptr.To(2)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid extender",
+			config: &ProfileConfiguration{
+				Extenders: []extender.Config{
+					{Name: "Approval", URLPrefix: "extender.example.com", FilterVerb: "filter"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewProfileFromConfiguration(tc.config, NewInTreeRegistry())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("NewProfileFromConfiguration() = %v, want error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, profileCmpOptions...); diff != "" {
+				t.Errorf("NewProfileFromConfiguration() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestNewProfileFromConfigurationWithExtenders tests the NewProfileFromConfiguration function with
+// extenders configured.
+func TestNewProfileFromConfigurationWithExtenders(t *testing.T) {
+	config := &ProfileConfiguration{
+		Extenders: []extender.Config{
+			{Name: "Approval", URLPrefix: "https://extender.example.com", FilterVerb: "filter"},
+			{Name: "Cost", URLPrefix: "https://extender.example.com", ScoreVerb: "score", Weight: ptr.To(3)},
+		},
+	}
+	got, err := NewProfileFromConfiguration(config, NewInTreeRegistry())
+	if err != nil {
+		t.Fatalf("NewProfileFromConfiguration() = %v, want no error", err)
+	}
+
+	// The extenders hold HTTP clients, which cannot be compared; compare the rest of the profile
+	// with the default one instead.
+	want := NewDefaultProfile().WithScorePluginWeight("Cost", 3)
+	if diff := cmp.Diff(want, got, append(profileCmpOptions, cmpopts.IgnoreFields(framework.Profile{}, "extenders"))...); diff != "" {
+		t.Errorf("NewProfileFromConfiguration() mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+// TestNewProfilesFromConfiguration tests the NewProfilesFromConfiguration function.
+func TestNewProfilesFromConfiguration(t *testing.T) {
+	testCases := []struct {
+		name      string
+		config    *Configuration
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name: "multiple profiles",
+			config: &Configuration{
+				Profiles: []ProfileConfiguration{
+					{},
+					{
+						Name: "NoTaintsProfile",
+						Plugins: Plugins{
+							Filter: PluginSet{Disabled: []Plugin{{Name: TaintTolerationPluginName}}},
+						},
+					},
+				},
+			},
+			wantNames: []string{defaultProfileName, "NoTaintsProfile"},
+		},
+		{
+			name:    "no profiles",
+			config:  &Configuration{},
+			wantErr: true,
+		},
+		{
+			name: "duplicate profile names",
+			config: &Configuration{
+				Profiles: []ProfileConfiguration{
+					{Name: defaultProfileName},
+					{},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid profile",
+			config: &Configuration{
+				Profiles: []ProfileConfiguration{
+					{},
+					{Name: "InvalidProfile", ScoreAggregationStrategy: "Unknown"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewProfilesFromConfiguration(tc.config, NewInTreeRegistry())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("NewProfilesFromConfiguration() = %v, want error %t", err, tc.wantErr)
+			}
+			var gotNames []string
+			for _, p := range got {
+				gotNames = append(gotNames, p.Name())
+			}
+			if diff := cmp.Diff(tc.wantNames, gotNames); diff != "" {
+				t.Errorf("NewProfilesFromConfiguration() profile names mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
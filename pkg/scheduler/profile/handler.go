@@ -0,0 +1,54 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package profile
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/klog/v2"
+
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+const (
+	// HandlerPath is the path on which the scheduling profiles in use are served.
+	HandlerPath = "/scheduler/profiles"
+)
+
+// Descriptions is the description of the scheduling profiles in use, as served by the handler.
+type Descriptions struct {
+	// Profiles is the descriptions of the profiles; the default profile always comes first.
+	Profiles []framework.ProfileDescription `json:"profiles"`
+}
+
+// NewHandler returns an HTTP handler which serves the description of the given profiles in JSON,
+// so that users can find out what the scheduler actually runs, i.e., the plugins enabled at each
+// extension point, the weights of the scores and the args of the plugins.
+//
+// The profiles are described once when the handler is created; they must have been fully set up by
+// then.
+func NewHandler(profiles []*framework.Profile) http.Handler {
+	descriptions := Descriptions{
+		Profiles: make([]framework.ProfileDescription, 0, len(profiles)),
+	}
+	for _, p := range profiles {
+		descriptions.Profiles = append(descriptions.Profiles, p.Describe())
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(descriptions); err != nil {
+			klog.ErrorS(err, "Failed to write the description of the scheduling profiles")
+		}
+	})
+}
@@ -0,0 +1,65 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package profile
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"go.goms.io/fleet/pkg/scheduler/framework"
+)
+
+// TestNewHandler tests the handler which serves the description of the scheduling profiles.
+func TestNewHandler(t *testing.T) {
+	defaultProfile := NewDefaultProfile()
+	customProfile := framework.NewProfile("custom")
+	handler := NewHandler([]*framework.Profile{defaultProfile, customProfile})
+
+	testCases := []struct {
+		name       string
+		method     string
+		wantStatus int
+		want       *Descriptions
+	}{
+		{
+			name:       "get",
+			method:     http.MethodGet,
+			wantStatus: http.StatusOK,
+			want: &Descriptions{
+				Profiles: []framework.ProfileDescription{defaultProfile.Describe(), customProfile.Describe()},
+			},
+		},
+		{
+			name:       "post",
+			method:     http.MethodPost,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(tc.method, HandlerPath, nil))
+			if recorder.Code != tc.wantStatus {
+				t.Fatalf("ServeHTTP() status = %d, want %d", recorder.Code, tc.wantStatus)
+			}
+			if tc.want == nil {
+				return
+			}
+			got := &Descriptions{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), got); err != nil {
+				t.Fatalf("failed to decode the response: %v", err)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("ServeHTTP() response diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
@@ -8,11 +8,20 @@ package profile
 
 import (
 	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/apiavailability"
 	"go.goms.io/fleet/pkg/scheduler/framework/plugins/clusteraffinity"
 	"go.goms.io/fleet/pkg/scheduler/framework/plugins/clustereligibility"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/cordon"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/exclusivity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/kubernetesversion"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/maxplacements"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/placementantiaffinity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/recommendedclusters"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/resourcefit"
 	"go.goms.io/fleet/pkg/scheduler/framework/plugins/sameplacementaffinity"
 	"go.goms.io/fleet/pkg/scheduler/framework/plugins/tainttoleration"
 	"go.goms.io/fleet/pkg/scheduler/framework/plugins/topologyspreadconstraints"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/warmdependencies"
 )
 
 const (
@@ -27,14 +36,24 @@ func NewDefaultProfile() *framework.Profile {
 	// default plugin list
 	clusterAffinityPlugin := clusteraffinity.New()
 	clusterEligibilityPlugin := clustereligibility.New()
+	cordonPlugin := cordon.New()
 	samePlacementAffinityPlugin := sameplacementaffinity.New()
 	topologySpreadConstraintsPlugin := topologyspreadconstraints.New()
 	taintTolerationPlugin := tainttoleration.New()
+	exclusivityPlugin := exclusivity.New()
+	resourceFitPlugin := resourcefit.New()
+	warmDependenciesPlugin := warmdependencies.New()
+	kubernetesVersionPlugin := kubernetesversion.New()
+	apiAvailabilityPlugin := apiavailability.New()
+	maxPlacementsPlugin := maxplacements.New()
+	placementAntiAffinityPlugin := placementantiaffinity.New()
+	recommendedClustersPlugin := recommendedclusters.New()
 
 	p.WithPostBatchPlugin(&topologySpreadConstraintsPlugin).
-		WithPreFilterPlugin(&clusterAffinityPlugin).WithPreFilterPlugin(&topologySpreadConstraintsPlugin).
-		WithFilterPlugin(&clusterAffinityPlugin).WithFilterPlugin(&clusterEligibilityPlugin).WithFilterPlugin(&taintTolerationPlugin).WithFilterPlugin(&samePlacementAffinityPlugin).WithFilterPlugin(&topologySpreadConstraintsPlugin).
-		WithPreScorePlugin(&clusterAffinityPlugin).WithPreScorePlugin(&topologySpreadConstraintsPlugin).
-		WithScorePlugin(&clusterAffinityPlugin).WithScorePlugin(&samePlacementAffinityPlugin).WithScorePlugin(&topologySpreadConstraintsPlugin)
+		WithPreFilterPlugin(&clusterAffinityPlugin).WithPreFilterPlugin(&topologySpreadConstraintsPlugin).WithPreFilterPlugin(&exclusivityPlugin).WithPreFilterPlugin(&resourceFitPlugin).WithPreFilterPlugin(&kubernetesVersionPlugin).WithPreFilterPlugin(&apiAvailabilityPlugin).WithPreFilterPlugin(&maxPlacementsPlugin).WithPreFilterPlugin(&placementAntiAffinityPlugin).
+		WithFilterPlugin(&clusterAffinityPlugin).WithFilterPlugin(&clusterEligibilityPlugin).WithFilterPlugin(&cordonPlugin).WithFilterPlugin(&taintTolerationPlugin).WithFilterPlugin(&samePlacementAffinityPlugin).WithFilterPlugin(&topologySpreadConstraintsPlugin).WithFilterPlugin(&exclusivityPlugin).WithFilterPlugin(&resourceFitPlugin).WithFilterPlugin(&kubernetesVersionPlugin).WithFilterPlugin(&apiAvailabilityPlugin).WithFilterPlugin(&maxPlacementsPlugin).WithFilterPlugin(&placementAntiAffinityPlugin).
+		WithPostFilterPlugin(&taintTolerationPlugin).
+		WithPreScorePlugin(&clusterAffinityPlugin).WithPreScorePlugin(&topologySpreadConstraintsPlugin).WithPreScorePlugin(&resourceFitPlugin).WithPreScorePlugin(&warmDependenciesPlugin).WithPreScorePlugin(&placementAntiAffinityPlugin).WithPreScorePlugin(&recommendedClustersPlugin).
+		WithScorePlugin(&clusterAffinityPlugin).WithScorePlugin(&samePlacementAffinityPlugin).WithScorePlugin(&topologySpreadConstraintsPlugin).WithScorePlugin(&resourceFitPlugin).WithScorePlugin(&warmDependenciesPlugin).WithScorePlugin(&placementAntiAffinityPlugin).WithScorePlugin(&recommendedClustersPlugin)
 	return p
 }
@@ -0,0 +1,186 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/apiavailability"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/clusteraffinity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/clustereligibility"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/cordon"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/exclusivity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/kubernetesversion"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/maxplacements"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/placementantiaffinity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/preemption"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/recommendedclusters"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/resourcefit"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/rolloutgroup"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/sameplacementaffinity"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/tainttoleration"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/topologyspreadconstraints"
+	"go.goms.io/fleet/pkg/scheduler/framework/plugins/warmdependencies"
+)
+
+// The names of the in-tree plugins, which are used to refer to the plugins in a profile configuration.
+const (
+	APIAvailabilityPluginName           = "APIAvailability"
+	ClusterAffinityPluginName           = "ClusterAffinity"
+	ClusterEligibilityPluginName        = "ClusterEligibility"
+	CordonPluginName                    = "Cordon"
+	ExclusivityPluginName               = "Exclusivity"
+	KubernetesVersionPluginName         = "KubernetesVersion"
+	MaxPlacementsPluginName             = "MaxPlacements"
+	PlacementAntiAffinityPluginName     = "PlacementAntiAffinity"
+	PreemptionPluginName                = "Preemption"
+	RecommendedClustersPluginName       = "RecommendedClusters"
+	ResourceFitPluginName               = "ResourceFit"
+	RolloutGroupPluginName              = "RolloutGroup"
+	SamePlacementAntiAffinityPluginName = "SamePlacementAntiAffinity"
+	TaintTolerationPluginName           = "TaintToleration"
+	TopologySpreadConstraintsPluginName = "TopologySpreadConstraints"
+	WarmDependenciesPluginName          = "WarmDependencies"
+)
+
+// PluginFactory builds a plugin with its args in a profile configuration; the args are nil if
+// none has been configured for the plugin.
+type PluginFactory func(args json.RawMessage) (framework.Plugin, error)
+
+// Registry is a collection of plugin factories, keyed by the names of the plugins.
+type Registry map[string]PluginFactory
+
+// NewInTreeRegistry returns a registry of all the in-tree plugins.
+func NewInTreeRegistry() Registry {
+	return Registry{
+		APIAvailabilityPluginName: withoutArgs(func() framework.Plugin {
+			p := apiavailability.New()
+			return &p
+		}),
+		ClusterAffinityPluginName: withoutArgs(func() framework.Plugin {
+			p := clusteraffinity.New()
+			return &p
+		}),
+		ClusterEligibilityPluginName: withoutArgs(func() framework.Plugin {
+			p := clustereligibility.New()
+			return &p
+		}),
+		CordonPluginName: withoutArgs(func() framework.Plugin {
+			p := cordon.New()
+			return &p
+		}),
+		ExclusivityPluginName: withoutArgs(func() framework.Plugin {
+			p := exclusivity.New()
+			return &p
+		}),
+		KubernetesVersionPluginName: withoutArgs(func() framework.Plugin {
+			p := kubernetesversion.New()
+			return &p
+		}),
+		MaxPlacementsPluginName: withoutArgs(func() framework.Plugin {
+			p := maxplacements.New()
+			return &p
+		}),
+		PlacementAntiAffinityPluginName: withoutArgs(func() framework.Plugin {
+			p := placementantiaffinity.New()
+			return &p
+		}),
+		PreemptionPluginName: newPreemptionPlugin,
+		RecommendedClustersPluginName: withoutArgs(func() framework.Plugin {
+			p := recommendedclusters.New()
+			return &p
+		}),
+		ResourceFitPluginName: withoutArgs(func() framework.Plugin {
+			p := resourcefit.New()
+			return &p
+		}),
+		RolloutGroupPluginName: newRolloutGroupPlugin,
+		SamePlacementAntiAffinityPluginName: withoutArgs(func() framework.Plugin {
+			p := sameplacementaffinity.New()
+			return &p
+		}),
+		TaintTolerationPluginName: withoutArgs(func() framework.Plugin {
+			p := tainttoleration.New()
+			return &p
+		}),
+		TopologySpreadConstraintsPluginName: withoutArgs(func() framework.Plugin {
+			p := topologyspreadconstraints.New()
+			return &p
+		}),
+		WarmDependenciesPluginName: withoutArgs(func() framework.Plugin {
+			p := warmdependencies.New()
+			return &p
+		}),
+	}
+}
+
+// withoutArgs returns a factory for a plugin that does not accept any args.
+func withoutArgs(newPlugin func() framework.Plugin) PluginFactory {
+	return func(args json.RawMessage) (framework.Plugin, error) {
+		if len(args) != 0 && !bytes.Equal(args, []byte("null")) {
+			return nil, fmt.Errorf("the plugin does not accept any args")
+		}
+		return newPlugin(), nil
+	}
+}
+
+// PreemptionArgs is the args of the Preemption plugin.
+type PreemptionArgs struct {
+	// DryRun, if set, makes the plugin report (in the hub agent logs) the bindings it would preempt,
+	// without preempting them.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// newPreemptionPlugin builds the Preemption plugin with its args.
+func newPreemptionPlugin(args json.RawMessage) (framework.Plugin, error) {
+	preemptionArgs := PreemptionArgs{}
+	if len(args) != 0 {
+		if err := decodeArgs(args, &preemptionArgs); err != nil {
+			return nil, err
+		}
+	}
+	p := preemption.New(preemption.WithDryRun(preemptionArgs.DryRun))
+	return &p, nil
+}
+
+// RolloutGroupArgs is the args of the RolloutGroup plugin.
+type RolloutGroupArgs struct {
+	// ClusterLabelKey is the key of the member cluster label whose value names the rollout group of
+	// the cluster.
+	ClusterLabelKey string `json:"clusterLabelKey,omitempty"`
+	// GroupOrder is the list of rollout group names, in the order in which the groups are rolled out.
+	GroupOrder []string `json:"groupOrder,omitempty"`
+}
+
+// newRolloutGroupPlugin builds the RolloutGroup plugin with its args.
+func newRolloutGroupPlugin(args json.RawMessage) (framework.Plugin, error) {
+	var opts []rolloutgroup.Option
+	if len(args) != 0 {
+		rolloutGroupArgs := RolloutGroupArgs{}
+		if err := decodeArgs(args, &rolloutGroupArgs); err != nil {
+			return nil, err
+		}
+		if rolloutGroupArgs.ClusterLabelKey != "" {
+			opts = append(opts, rolloutgroup.WithClusterLabelKey(rolloutGroupArgs.ClusterLabelKey))
+		}
+		opts = append(opts, rolloutgroup.WithGroupOrder(rolloutGroupArgs.GroupOrder))
+	}
+	p := rolloutgroup.New(opts...)
+	return &p, nil
+}
+
+// decodeArgs decodes the args of a plugin, rejecting any unknown fields.
+func decodeArgs(args json.RawMessage, into interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(args))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(into); err != nil {
+		return fmt.Errorf("failed to decode the plugin args: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package queue
+
+import (
+	"container/heap"
+	"sync"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// PriorityFunc returns the priority of a ClusterResourcePlacement; keys of higher priorities are
+// dequeued first.
+type PriorityFunc func(crpKey ClusterResourcePlacementKey) int32
+
+// priorityItem is a key waiting in a priorityQueue.
+type priorityItem struct {
+	key      ClusterResourcePlacementKey
+	priority int32
+	// seq is the order in which the key is queued; it breaks ties between keys of the same priority,
+	// so that such keys are dequeued in FIFO order.
+	seq uint64
+	// index is the index of the item in the heap.
+	index int
+}
+
+// priorityHeap is a max-heap of priorityItems, ordered by priority first and then by seq.
+type priorityHeap []*priorityItem
+
+// Verify that priorityHeap implements heap.Interface at compile time.
+var _ heap.Interface = &priorityHeap{}
+
+func (h priorityHeap) Len() int { return len(h) }
+
+func (h priorityHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h priorityHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *priorityHeap) Push(x interface{}) {
+	item := x.(*priorityItem)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *priorityHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
+
+// priorityQueue is a workqueue.Interface which dequeues ClusterResourcePlacementKeys by priority.
+//
+// It follows the same semantics as the default work queue: a key is queued at most once at any
+// time, and a key being processed is not handed out again until it is marked as done, at which
+// point it is queued again if it has been added in the meantime.
+type priorityQueue struct {
+	priorityFunc PriorityFunc
+
+	cond *sync.Cond
+	// heap is the keys waiting to be processed.
+	heap priorityHeap
+	// queued maps the keys in the heap to their items.
+	queued map[ClusterResourcePlacementKey]*priorityItem
+	// dirty is the keys that need processing, with their latest known priorities; a key might be
+	// both dirty and being processed, in which case it is queued when it is marked as done.
+	dirty map[ClusterResourcePlacementKey]int32
+	// processing is the keys being processed.
+	processing map[ClusterResourcePlacementKey]bool
+	seq        uint64
+
+	shuttingDown bool
+	drain        bool
+}
+
+// Verify that priorityQueue implements workqueue.Interface at compile time.
+var _ workqueue.Interface = &priorityQueue{}
+
+// newPriorityQueue returns a new priorityQueue.
+func newPriorityQueue(priorityFunc PriorityFunc) *priorityQueue {
+	return &priorityQueue{
+		priorityFunc: priorityFunc,
+		cond:         sync.NewCond(&sync.Mutex{}),
+		queued:       make(map[ClusterResourcePlacementKey]*priorityItem),
+		dirty:        make(map[ClusterResourcePlacementKey]int32),
+		processing:   make(map[ClusterResourcePlacementKey]bool),
+	}
+}
+
+// Add marks a key as needing processing; if the key is already queued, its priority is refreshed.
+func (q *priorityQueue) Add(item interface{}) {
+	key := item.(ClusterResourcePlacementKey)
+	// Look up the priority before acquiring the lock, as the lookup might be slow.
+	priority := q.priorityFunc(key)
+
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return
+	}
+	q.dirty[key] = priority
+	if existing, ok := q.queued[key]; ok {
+		if existing.priority != priority {
+			existing.priority = priority
+			heap.Fix(&q.heap, existing.index)
+		}
+		return
+	}
+	if q.processing[key] {
+		return
+	}
+	q.push(key, priority)
+	q.cond.Signal()
+}
+
+// push queues a key; the caller must hold the lock.
+func (q *priorityQueue) push(key ClusterResourcePlacementKey, priority int32) {
+	q.seq++
+	item := &priorityItem{key: key, priority: priority, seq: q.seq}
+	heap.Push(&q.heap, item)
+	q.queued[key] = item
+}
+
+// Len returns the number of keys waiting to be processed.
+func (q *priorityQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return len(q.heap)
+}
+
+// Get blocks until it can return the key of the highest priority to be processed.
+func (q *priorityQueue) Get() (item interface{}, shutdown bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for len(q.heap) == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if len(q.heap) == 0 {
+		// The queue must be shutting down.
+		return nil, true
+	}
+
+	next := heap.Pop(&q.heap).(*priorityItem)
+	delete(q.queued, next.key)
+	delete(q.dirty, next.key)
+	q.processing[next.key] = true
+	return next.key, false
+}
+
+// Done marks a key as done processing; the key is queued again if it has been added while being
+// processed.
+func (q *priorityQueue) Done(item interface{}) {
+	key := item.(ClusterResourcePlacementKey)
+
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	delete(q.processing, key)
+	if priority, ok := q.dirty[key]; ok {
+		q.push(key, priority)
+		q.cond.Signal()
+	} else if len(q.processing) == 0 {
+		q.cond.Signal()
+	}
+}
+
+// ShutDown makes the queue ignore all new keys and instructs the consumers to exit immediately.
+func (q *priorityQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.drain = false
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
+
+// ShutDownWithDrain makes the queue ignore all new keys, and returns after all the keys being
+// processed are marked as done.
+func (q *priorityQueue) ShutDownWithDrain() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.drain = true
+	q.shuttingDown = true
+	q.cond.Broadcast()
+	for len(q.processing) != 0 && q.drain {
+		q.cond.Wait()
+	}
+}
+
+// ShuttingDown returns true if the queue is shutting down.
+func (q *priorityQueue) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return q.shuttingDown
+}
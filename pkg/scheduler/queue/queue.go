@@ -8,8 +8,11 @@ Licensed under the MIT license.
 package queue
 
 import (
+	"math"
+	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
 )
 
@@ -29,6 +32,10 @@ type ClusterResourcePlacementSchedulingQueueWriter interface {
 	AddRateLimited(crpKey ClusterResourcePlacementKey)
 	// AddAfter adds a ClusterResourcePlacementKey to the work queue after a set duration.
 	AddAfter(crpKey ClusterResourcePlacementKey, duration time.Duration)
+	// FlushUnschedulable moves all the ClusterResourcePlacementKeys in the unschedulable pool to the
+	// work queue; sources call it when an event arrives that might make these placements schedulable,
+	// e.g., a cluster joins the fleet.
+	FlushUnschedulable()
 }
 
 // ClusterResourcePlacementSchedulingQueue is an interface which queues ClusterResourcePlacements for the scheduler
@@ -47,21 +54,77 @@ type ClusterResourcePlacementSchedulingQueue interface {
 	NextClusterResourcePlacementKey() (key ClusterResourcePlacementKey, closed bool)
 	// Done marks a ClusterResourcePlacementKey as done.
 	Done(crpKey ClusterResourcePlacementKey)
+	// AddUnschedulable parks a ClusterResourcePlacementKey, whose placement cannot be fully scheduled
+	// at the moment, in the unschedulable pool, until it is added again or flushed.
+	AddUnschedulable(crpKey ClusterResourcePlacementKey)
+	// Requeue adds a ClusterResourcePlacementKey back to the work queue after a scheduling cycle that
+	// has taken a set duration and requested more cycles; the key might be delayed if its placement has
+	// taken more than its fair share of the scheduler workers, so that other placements can make progress.
+	//
+	// Note that this bypasses the rate limiter (if any).
+	Requeue(crpKey ClusterResourcePlacementKey, cycleDuration time.Duration)
 	// Forget untracks a ClusterResourcePlacementKey from rate limiter(s) (if any) set up with the queue.
 	Forget(crpKey ClusterResourcePlacementKey)
+	// Len returns the number of ClusterResourcePlacementKeys ready for processing in the queue.
+	Len() int
 }
 
 // simpleClusterResourcePlacementSchedulingQueue is a simple implementation of
 // ClusterResourcePlacementSchedulingQueue.
 //
-// At this moment, one single workqueue would suffice, as sources such as the cluster watcher,
-// the binding watcher, etc., can catch all changes that need the scheduler's attention.
-// In the future, when more features, e.g., inter-placement affinity/anti-affinity, are added,
-// more queues, such as a backoff queue, might become necessary.
+// Keys are kept in three pools:
+//   - the active pool, i.e., the keys ready for processing;
+//   - the backoff pool, i.e., the keys of placements which have failed to be scheduled, waiting for
+//     their (exponential) backoff to pass before they become active again; and
+//   - the unschedulable pool, i.e., the keys of placements which cannot be fully scheduled at the
+//     moment, waiting for an event (or a timeout) that moves them back to the active pool.
+//
+// A repeatedly failing placement thus backs off instead of taking up the workers, and does not starve
+// the healthy ones.
+//
+// In addition, the queue keeps track of how long the scheduling cycles of each placement have taken
+// recently (with the usage decaying over time). A placement which keeps requeueing itself, e.g., a very
+// large one scheduled in batches, or one that is hot-looping, is delayed once its usage exceeds the
+// fair share, as long as other placements are waiting; each placement thus gets its turn, no matter
+// how large the others are.
 type simpleClusterResourcePlacementSchedulingQueue struct {
+	// active is the active pool; the delaying heap of the rate limiting work queue, which holds the
+	// keys added with rate limiting until they are ready, serves as the backoff pool.
 	active workqueue.RateLimitingInterface
+
+	mu sync.Mutex
+	// unschedulable is the unschedulable pool, which tracks when each key was parked.
+	unschedulable map[ClusterResourcePlacementKey]time.Time
+	// unschedulableTimeout is how long a key stays in the unschedulable pool at most before it is moved
+	// back to the active pool; zero means no timeout.
+	unschedulableTimeout time.Duration
+
+	// usage tracks the (decayed) time the scheduling cycles of each key have taken recently.
+	usage map[ClusterResourcePlacementKey]*workerUsage
+	// fairShare is the usage above which a requeued key is delayed when other keys are waiting; zero
+	// disables the fair queuing.
+	fairShare time.Duration
+	// now returns the current time; it is replaced in tests.
+	now func() time.Time
+
+	// stopCh stops the goroutines started by Run.
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// workerUsage is the time the scheduling cycles of a key have taken, as of a point in time.
+type workerUsage struct {
+	seconds float64
+	at      time.Time
 }
 
+const (
+	// usageHalfLife is the period in which the usage of a key decays by half.
+	usageHalfLife = 30 * time.Second
+	// maxFairnessDelay is the max delay added to a requeued key which has exceeded its fair share.
+	maxFairnessDelay = 10 * time.Second
+)
+
 // Verify that simpleClusterResourcePlacementSchedulingQueue implements
 // ClusterResourceSchedulingQueue at compile time.
 var _ ClusterResourcePlacementSchedulingQueue = &simpleClusterResourcePlacementSchedulingQueue{}
@@ -69,16 +132,21 @@ var _ ClusterResourcePlacementSchedulingQueue = &simpleClusterResourcePlacementS
 // simpleClusterResourcePlacementSchedulingQueueOptions are the options for the
 // simpleClusterResourcePlacementSchedulingQueue.
 type simpleClusterResourcePlacementSchedulingQueueOptions struct {
-	rateLimiter workqueue.RateLimiter
-	name        string
+	rateLimiter          workqueue.RateLimiter
+	name                 string
+	unschedulableTimeout time.Duration
+	fairShare            time.Duration
+	priorityFunc         PriorityFunc
 }
 
 // Option is the function that configures the simpleClusterResourcePlacmentSchedulingQueue.
 type Option func(*simpleClusterResourcePlacementSchedulingQueueOptions)
 
 var defaultSimpleClusterResourcePlacementSchedulingQueueOptions = simpleClusterResourcePlacementSchedulingQueueOptions{
-	rateLimiter: workqueue.DefaultControllerRateLimiter(),
-	name:        "clusterResourcePlacementSchedulingQueue",
+	rateLimiter:          workqueue.DefaultControllerRateLimiter(),
+	name:                 "clusterResourcePlacementSchedulingQueue",
+	unschedulableTimeout: 5 * time.Minute,
+	fairShare:            5 * time.Second,
 }
 
 // WithRateLimiter sets a rate limiter for the workqueue.
@@ -95,23 +163,71 @@ func WithName(name string) Option {
 	}
 }
 
+// WithUnschedulableTimeout sets how long a key stays in the unschedulable pool at most before it is
+// moved back to the active pool, so that placements are retried even if no event arrives; zero means
+// no timeout.
+func WithUnschedulableTimeout(timeout time.Duration) Option {
+	return func(o *simpleClusterResourcePlacementSchedulingQueueOptions) {
+		o.unschedulableTimeout = timeout
+	}
+}
+
+// WithFairShare sets the time the scheduling cycles of a placement can take recently before the
+// requeues of the placement are delayed in favor of other waiting placements; zero disables the
+// fair queuing.
+func WithFairShare(fairShare time.Duration) Option {
+	return func(o *simpleClusterResourcePlacementSchedulingQueueOptions) {
+		o.fairShare = fairShare
+	}
+}
+
+// WithPriorityFunc makes the workqueue hand out keys of higher priorities first, as told by the
+// given function; keys of the same priority are handed out in FIFO order.
+//
+// Note that the function is called each time a key is added, and must be safe for concurrent use.
+func WithPriorityFunc(priorityFunc PriorityFunc) Option {
+	return func(o *simpleClusterResourcePlacementSchedulingQueueOptions) {
+		o.priorityFunc = priorityFunc
+	}
+}
+
 // Run starts the scheduling queue.
 //
-// At this moment, Run is an no-op as there is only one queue present; in the future,
-// when more queues are added, Run would start goroutines that move items between queues as
-// appropriate.
-func (sq *simpleClusterResourcePlacementSchedulingQueue) Run() {}
+// Run starts a goroutine that moves the keys which have stayed in the unschedulable pool for too long
+// back to the active pool, and one that removes the usage of keys which have been idle for a while.
+func (sq *simpleClusterResourcePlacementSchedulingQueue) Run() {
+	if sq.fairShare > 0 {
+		go wait.Until(sq.sweepUsage, usageHalfLife, sq.stopCh)
+	}
+	if sq.unschedulableTimeout <= 0 {
+		return
+	}
+	// Check the unschedulable pool at a fraction of the timeout, so that keys do not stay in the pool
+	// much longer than the timeout.
+	go wait.Until(func() {
+		sq.flushUnschedulableOlderThan(sq.unschedulableTimeout)
+	}, sq.unschedulableTimeout/10, sq.stopCh)
+}
 
 // Close shuts down the scheduling queue immediately.
 func (sq *simpleClusterResourcePlacementSchedulingQueue) Close() {
+	sq.stop()
 	sq.active.ShutDown()
 }
 
 // CloseWithDrain shuts down the scheduling queue and returns until all items are processed.
 func (sq *simpleClusterResourcePlacementSchedulingQueue) CloseWithDrain() {
+	sq.stop()
 	sq.active.ShutDownWithDrain()
 }
 
+// stop stops the goroutines started by Run.
+func (sq *simpleClusterResourcePlacementSchedulingQueue) stop() {
+	sq.stopOnce.Do(func() {
+		close(sq.stopCh)
+	})
+}
+
 // NextClusterResourcePlacementKey returns the next ClusterResourcePlacementKey in the work queue for
 // the scheduler to process.
 //
@@ -124,7 +240,11 @@ func (sq *simpleClusterResourcePlacementSchedulingQueue) NextClusterResourcePlac
 	if shutdown {
 		return "", true
 	}
-	return crpKey.(ClusterResourcePlacementKey), false
+	key = crpKey.(ClusterResourcePlacementKey)
+	// The key is being processed again; it is no longer unschedulable, should it have been parked
+	// while it was dirty in the work queue.
+	sq.removeUnschedulable(key)
+	return key, false
 }
 
 // Done marks a ClusterResourcePlacementKey as done.
@@ -136,12 +256,14 @@ func (sq *simpleClusterResourcePlacementSchedulingQueue) Done(crpKey ClusterReso
 //
 // Note that this bypasses the rate limiter (if any).
 func (sq *simpleClusterResourcePlacementSchedulingQueue) Add(crpKey ClusterResourcePlacementKey) {
+	sq.removeUnschedulable(crpKey)
 	sq.active.Add(crpKey)
 }
 
 // AddRateLimited adds a ClusterResourcePlacementKey to the work queue after the rate limiter (if any)
 // says that it is OK.
 func (sq *simpleClusterResourcePlacementSchedulingQueue) AddRateLimited(crpKey ClusterResourcePlacementKey) {
+	sq.removeUnschedulable(crpKey)
 	sq.active.AddRateLimited(crpKey)
 }
 
@@ -149,14 +271,126 @@ func (sq *simpleClusterResourcePlacementSchedulingQueue) AddRateLimited(crpKey C
 //
 // Note that this bypasses the rate limiter (if any)
 func (sq *simpleClusterResourcePlacementSchedulingQueue) AddAfter(crpKey ClusterResourcePlacementKey, duration time.Duration) {
+	sq.removeUnschedulable(crpKey)
 	sq.active.AddAfter(crpKey, duration)
 }
 
+// Requeue adds a ClusterResourcePlacementKey back to the work queue after a scheduling cycle which
+// has requested more cycles.
+//
+// The key is added immediately if its recent usage is within the fair share, or if no other key is
+// waiting; otherwise it is delayed by how much it has exceeded the fair share (up to a limit).
+func (sq *simpleClusterResourcePlacementSchedulingQueue) Requeue(crpKey ClusterResourcePlacementKey, cycleDuration time.Duration) {
+	sq.removeUnschedulable(crpKey)
+	if sq.fairShare <= 0 {
+		sq.active.Add(crpKey)
+		return
+	}
+
+	excess := sq.recordUsage(crpKey, cycleDuration) - sq.fairShare
+	if excess <= 0 || sq.active.Len() == 0 {
+		sq.active.Add(crpKey)
+		return
+	}
+	if excess > maxFairnessDelay {
+		excess = maxFairnessDelay
+	}
+	sq.active.AddAfter(crpKey, excess)
+}
+
+// recordUsage adds the duration of a scheduling cycle to the usage of a ClusterResourcePlacementKey,
+// and returns the updated usage.
+func (sq *simpleClusterResourcePlacementSchedulingQueue) recordUsage(crpKey ClusterResourcePlacementKey, cycleDuration time.Duration) time.Duration {
+	now := sq.now()
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	u, found := sq.usage[crpKey]
+	if !found {
+		u = &workerUsage{}
+		sq.usage[crpKey] = u
+	}
+	u.seconds = decayedSeconds(u, now) + cycleDuration.Seconds()
+	u.at = now
+	return time.Duration(u.seconds * float64(time.Second))
+}
+
+// sweepUsage removes the usage which has decayed to (almost) nothing, so that the usage of deleted
+// placements does not pile up.
+func (sq *simpleClusterResourcePlacementSchedulingQueue) sweepUsage() {
+	now := sq.now()
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	for key, u := range sq.usage {
+		if decayedSeconds(u, now) < sq.fairShare.Seconds()/100 {
+			delete(sq.usage, key)
+		}
+	}
+}
+
+// decayedSeconds returns a usage as of a point in time.
+func decayedSeconds(u *workerUsage, now time.Time) float64 {
+	if u.at.IsZero() {
+		return u.seconds
+	}
+	return u.seconds * math.Pow(0.5, now.Sub(u.at).Seconds()/usageHalfLife.Seconds())
+}
+
+// AddUnschedulable parks a ClusterResourcePlacementKey in the unschedulable pool.
+func (sq *simpleClusterResourcePlacementSchedulingQueue) AddUnschedulable(crpKey ClusterResourcePlacementKey) {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	sq.unschedulable[crpKey] = time.Now()
+}
+
+// FlushUnschedulable moves all the ClusterResourcePlacementKeys in the unschedulable pool to the
+// work queue.
+func (sq *simpleClusterResourcePlacementSchedulingQueue) FlushUnschedulable() {
+	sq.flushUnschedulableOlderThan(0)
+}
+
+// flushUnschedulableOlderThan moves the ClusterResourcePlacementKeys which have stayed in the
+// unschedulable pool for longer than a duration to the work queue.
+func (sq *simpleClusterResourcePlacementSchedulingQueue) flushUnschedulableOlderThan(age time.Duration) {
+	sq.mu.Lock()
+	var keys []ClusterResourcePlacementKey
+	now := time.Now()
+	for key, parkedAt := range sq.unschedulable {
+		if now.Sub(parkedAt) >= age {
+			keys = append(keys, key)
+			delete(sq.unschedulable, key)
+		}
+	}
+	sq.mu.Unlock()
+
+	for _, key := range keys {
+		sq.active.Add(key)
+	}
+}
+
+// removeUnschedulable removes a ClusterResourcePlacementKey from the unschedulable pool, if present.
+func (sq *simpleClusterResourcePlacementSchedulingQueue) removeUnschedulable(crpKey ClusterResourcePlacementKey) {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	delete(sq.unschedulable, crpKey)
+}
+
+// unschedulableLen returns the number of ClusterResourcePlacementKeys in the unschedulable pool.
+func (sq *simpleClusterResourcePlacementSchedulingQueue) unschedulableLen() int {
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	return len(sq.unschedulable)
+}
+
 // Forget untracks a ClusterResourcePlacementKey from rate limiter(s) (if any) set up with the queue.
 func (sq *simpleClusterResourcePlacementSchedulingQueue) Forget(crpKey ClusterResourcePlacementKey) {
 	sq.active.Forget(crpKey)
 }
 
+// Len returns the number of ClusterResourcePlacementKeys ready for processing in the queue.
+func (sq *simpleClusterResourcePlacementSchedulingQueue) Len() int {
+	return sq.active.Len()
+}
+
 // NewSimpleClusterResourcePlacementSchedulingQueue returns a
 // simpleClusterResourcePlacementSchedulingQueue.
 func NewSimpleClusterResourcePlacementSchedulingQueue(opts ...Option) ClusterResourcePlacementSchedulingQueue {
@@ -165,9 +399,23 @@ func NewSimpleClusterResourcePlacementSchedulingQueue(opts ...Option) ClusterRes
 		opt(&options)
 	}
 
+	config := workqueue.RateLimitingQueueConfig{
+		Name: options.name,
+	}
+	if options.priorityFunc != nil {
+		// Note that the work queue depth and latency metrics are not reported for a priority queue.
+		config.DelayingQueue = workqueue.NewDelayingQueueWithConfig(workqueue.DelayingQueueConfig{
+			Name:  options.name,
+			Queue: newPriorityQueue(options.priorityFunc),
+		})
+	}
 	return &simpleClusterResourcePlacementSchedulingQueue{
-		active: workqueue.NewRateLimitingQueueWithConfig(options.rateLimiter, workqueue.RateLimitingQueueConfig{
-			Name: options.name,
-		}),
+		active:               workqueue.NewRateLimitingQueueWithConfig(options.rateLimiter, config),
+		unschedulable:        make(map[ClusterResourcePlacementKey]time.Time),
+		unschedulableTimeout: options.unschedulableTimeout,
+		usage:                make(map[ClusterResourcePlacementKey]*workerUsage),
+		fairShare:            options.fairShare,
+		now:                  time.Now,
+		stopCh:               make(chan struct{}),
 	}
 }
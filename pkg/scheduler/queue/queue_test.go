@@ -6,7 +6,9 @@ Licensed under the MIT license.
 package queue
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -39,3 +41,230 @@ func TestSimpleClusterResourcePlacementSchedulingQueueBasicOps(t *testing.T) {
 
 	sq.Close()
 }
+
+// TestSimpleClusterResourcePlacementSchedulingQueueUnschedulable tests the unschedulable pool of a
+// simpleClusterResourcePlacementSchedulingQueue.
+func TestSimpleClusterResourcePlacementSchedulingQueueUnschedulable(t *testing.T) {
+	sq := NewSimpleClusterResourcePlacementSchedulingQueue(WithUnschedulableTimeout(0)).(*simpleClusterResourcePlacementSchedulingQueue)
+	sq.Run()
+	defer sq.Close()
+
+	sq.AddUnschedulable("A")
+	sq.AddUnschedulable("B")
+	sq.AddUnschedulable("C")
+	if got := sq.Len(); got != 0 {
+		t.Fatalf("Len() = %d, want 0 after parking keys in the unschedulable pool", got)
+	}
+	if got := sq.unschedulableLen(); got != 3 {
+		t.Fatalf("unschedulableLen() = %d, want 3", got)
+	}
+
+	// Adding a key moves it out of the unschedulable pool.
+	sq.Add("A")
+	if got := sq.unschedulableLen(); got != 2 {
+		t.Fatalf("unschedulableLen() = %d, want 2 after adding a parked key", got)
+	}
+
+	// Flushing moves all the keys to the active pool.
+	sq.FlushUnschedulable()
+	if got := sq.unschedulableLen(); got != 0 {
+		t.Fatalf("unschedulableLen() = %d, want 0 after flushing", got)
+	}
+	if got := sq.Len(); got != 3 {
+		t.Fatalf("Len() = %d, want 3 after flushing", got)
+	}
+}
+
+// TestSimpleClusterResourcePlacementSchedulingQueueUnschedulableTimeout tests that keys which stay
+// in the unschedulable pool for too long are moved back to the active pool.
+func TestSimpleClusterResourcePlacementSchedulingQueueUnschedulableTimeout(t *testing.T) {
+	sq := NewSimpleClusterResourcePlacementSchedulingQueue(WithUnschedulableTimeout(100 * time.Millisecond))
+	sq.Run()
+	defer sq.Close()
+
+	sq.AddUnschedulable("A")
+	key, closed := sq.NextClusterResourcePlacementKey()
+	if closed {
+		t.Fatalf("Queue closed unexpectedly")
+	}
+	if key != "A" {
+		t.Fatalf("NextClusterResourcePlacementKey() = %s, want A", key)
+	}
+	sq.Done(key)
+}
+
+// TestSimpleClusterResourcePlacementSchedulingQueueRequeue tests that a key which has exceeded its
+// fair share is delayed when other keys are waiting.
+func TestSimpleClusterResourcePlacementSchedulingQueueRequeue(t *testing.T) {
+	sq := NewSimpleClusterResourcePlacementSchedulingQueue(WithFairShare(time.Second)).(*simpleClusterResourcePlacementSchedulingQueue)
+	sq.Run()
+	defer sq.Close()
+
+	// The usage of A is within the fair share; it is added immediately.
+	sq.Requeue("A", 500*time.Millisecond)
+	sq.Add("B")
+	if got := sq.Len(); got != 2 {
+		t.Fatalf("Len() = %d, want 2 after requeueing a key within its fair share", got)
+	}
+
+	// The usage of A now exceeds the fair share, and B is waiting; A is delayed.
+	for _, want := range []ClusterResourcePlacementKey{"A", "B"} {
+		key, _ := sq.NextClusterResourcePlacementKey()
+		if key != want {
+			t.Fatalf("NextClusterResourcePlacementKey() = %s, want %s", key, want)
+		}
+		if key == "A" {
+			sq.Requeue("A", time.Second)
+		}
+		sq.Done(key)
+	}
+	if got := sq.Len(); got != 0 {
+		t.Fatalf("Len() = %d, want 0 after requeueing a key over its fair share", got)
+	}
+
+	// With no other key waiting, a key over its fair share is added immediately.
+	sq.Requeue("C", 5*time.Second)
+	if got := sq.Len(); got != 1 {
+		t.Fatalf("Len() = %d, want 1 after requeueing a key with no other key waiting", got)
+	}
+}
+
+// TestSimpleClusterResourcePlacementSchedulingQueueUsage tests how the usage of a key is recorded,
+// decayed, and swept.
+func TestSimpleClusterResourcePlacementSchedulingQueueUsage(t *testing.T) {
+	sq := NewSimpleClusterResourcePlacementSchedulingQueue(WithFairShare(time.Second)).(*simpleClusterResourcePlacementSchedulingQueue)
+	start := time.Now()
+	sq.now = func() time.Time { return start }
+
+	if got := sq.recordUsage("A", 2*time.Second); got != 2*time.Second {
+		t.Errorf("recordUsage() = %v, want 2s", got)
+	}
+	// The usage decays by half in a half-life.
+	sq.now = func() time.Time { return start.Add(usageHalfLife) }
+	if got := sq.recordUsage("A", time.Second); got != 2*time.Second {
+		t.Errorf("recordUsage() = %v, want 2s after a half-life", got)
+	}
+
+	sq.now = func() time.Time { return start.Add(10 * usageHalfLife) }
+	sq.sweepUsage()
+	if got := len(sq.usage); got != 0 {
+		t.Errorf("len(usage) = %d, want 0 after the usage has decayed", got)
+	}
+}
+
+// TestSimpleClusterResourcePlacementSchedulingQueueWithPriorityFunc tests that a
+// simpleClusterResourcePlacementSchedulingQueue with a priority function hands out keys of higher
+// priorities first, and keys of the same priority in FIFO order.
+func TestSimpleClusterResourcePlacementSchedulingQueueWithPriorityFunc(t *testing.T) {
+	testCases := []struct {
+		name string
+		// reprioritize, if set, changes the priorities after all the keys are added, and re-adds
+		// the keys whose priorities are changed.
+		reprioritize map[ClusterResourcePlacementKey]int32
+		want         []ClusterResourcePlacementKey
+	}{
+		{
+			name: "by priority, ties in FIFO order",
+			want: []ClusterResourcePlacementKey{"B", "D", "A", "E", "C"},
+		},
+		{
+			name: "re-added with changed priorities",
+			reprioritize: map[ClusterResourcePlacementKey]int32{
+				"C": 20,
+				"B": -10,
+			},
+			want: []ClusterResourcePlacementKey{"C", "D", "A", "E", "B"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			priorities := map[ClusterResourcePlacementKey]int32{
+				"A": 0,
+				"B": 10,
+				"C": -5,
+				"D": 10,
+				"E": 0,
+			}
+			sq := NewSimpleClusterResourcePlacementSchedulingQueue(WithPriorityFunc(func(crpKey ClusterResourcePlacementKey) int32 {
+				mu.Lock()
+				defer mu.Unlock()
+				return priorities[crpKey]
+			}))
+			sq.Run()
+			defer sq.Close()
+
+			for _, key := range []ClusterResourcePlacementKey{"A", "B", "C", "D", "E"} {
+				sq.Add(key)
+			}
+			for key, priority := range tc.reprioritize {
+				mu.Lock()
+				priorities[key] = priority
+				mu.Unlock()
+				sq.Add(key)
+			}
+			if got := sq.Len(); got != len(tc.want) {
+				t.Fatalf("Len() = %d, want %d", got, len(tc.want))
+			}
+
+			got := []ClusterResourcePlacementKey{}
+			for i := 0; i < len(tc.want); i++ {
+				key, closed := sq.NextClusterResourcePlacementKey()
+				if closed {
+					t.Fatalf("Queue closed unexpectedly")
+				}
+				got = append(got, key)
+				sq.Done(key)
+				sq.Forget(key)
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("received keys mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestPriorityQueueAddWhileProcessing tests that a key added while being processed is handed out
+// again, with its latest priority, only after it is marked as done.
+func TestPriorityQueueAddWhileProcessing(t *testing.T) {
+	priorities := map[ClusterResourcePlacementKey]int32{"A": 0, "B": 5}
+	q := newPriorityQueue(func(crpKey ClusterResourcePlacementKey) int32 {
+		return priorities[crpKey]
+	})
+	defer q.ShutDown()
+
+	q.Add(ClusterResourcePlacementKey("A"))
+	key, _ := q.Get()
+	if key != ClusterResourcePlacementKey("A") {
+		t.Fatalf("Get() = %v, want A", key)
+	}
+
+	// A is being processed; adding it again must not queue it yet.
+	priorities["A"] = 10
+	q.Add(ClusterResourcePlacementKey("A"))
+	q.Add(ClusterResourcePlacementKey("B"))
+	if got := q.Len(); got != 1 {
+		t.Fatalf("Len() = %d, want 1", got)
+	}
+
+	q.Done(ClusterResourcePlacementKey("A"))
+	got := []interface{}{}
+	for i := 0; i < 2; i++ {
+		key, shutdown := q.Get()
+		if shutdown {
+			t.Fatalf("Get() reports shutdown unexpectedly")
+		}
+		got = append(got, key)
+		q.Done(key)
+	}
+	want := []interface{}{ClusterResourcePlacementKey("A"), ClusterResourcePlacementKey("B")}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("received keys mismatch (-got, +want):\n%s", diff)
+	}
+
+	q.ShutDown()
+	if _, shutdown := q.Get(); !shutdown {
+		t.Errorf("Get() after ShutDown() = not shut down, want shut down")
+	}
+}
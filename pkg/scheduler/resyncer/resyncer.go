@@ -0,0 +1,149 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package resyncer features a component that periodically re-queues placements whose bindings
+// still reference a stale policy snapshot long after a new one has been created, as a safeguard
+// against scheduling events that the scheduler has missed, e.g., due to watch hiccups.
+package resyncer
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+	"go.goms.io/fleet/pkg/utils/controller"
+)
+
+const (
+	// defaultInterval is the default interval between two resync runs.
+	defaultInterval = 5 * time.Minute
+	// defaultStaleThreshold is the default age of the latest policy snapshot of a placement, past
+	// which the placement is considered drifted if any of its bindings still references an older one.
+	defaultStaleThreshold = 10 * time.Minute
+)
+
+// Resyncer periodically looks for placements with bindings that reference a policy snapshot other
+// than the latest one, and re-queues them for scheduling, if the latest policy snapshot has been
+// around for longer than a threshold.
+//
+// In a healthy system the scheduler refreshes the bindings of a placement soon after a new policy
+// snapshot is created; a drift that persists past the threshold implies that the scheduler has
+// missed the change, and re-running the scheduling for the placement repairs it.
+type Resyncer struct {
+	client client.Client
+	queue  queue.ClusterResourcePlacementSchedulingQueueWriter
+
+	interval       time.Duration
+	staleThreshold time.Duration
+}
+
+// Option is the function that configures the resyncer.
+type Option func(*Resyncer)
+
+// WithInterval sets the interval between two resync runs.
+func WithInterval(interval time.Duration) Option {
+	return func(r *Resyncer) {
+		r.interval = interval
+	}
+}
+
+// WithStaleThreshold sets how long the latest policy snapshot of a placement must have been around
+// before the placement is re-queued for bindings that still reference an older one.
+func WithStaleThreshold(threshold time.Duration) Option {
+	return func(r *Resyncer) {
+		r.staleThreshold = threshold
+	}
+}
+
+// New returns a new resyncer.
+func New(hubClient client.Client, schedulingQueue queue.ClusterResourcePlacementSchedulingQueueWriter, opts ...Option) *Resyncer {
+	r := &Resyncer{
+		client:         hubClient,
+		queue:          schedulingQueue,
+		interval:       defaultInterval,
+		staleThreshold: defaultStaleThreshold,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Start runs the resyncer periodically until the context is cancelled.
+func (r *Resyncer) Start(ctx context.Context) error {
+	klog.InfoS("Starting the scheduler resyncer", "interval", r.interval, "staleThreshold", r.staleThreshold)
+	wait.UntilWithContext(ctx, r.resync, r.interval)
+	klog.InfoS("The scheduler resyncer has exited")
+	return nil
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface, so that the resyncer only
+// runs on the leader, alongside the scheduler.
+func (r *Resyncer) NeedLeaderElection() bool {
+	return true
+}
+
+// resync runs one round of resync over all placements.
+func (r *Resyncer) resync(ctx context.Context) {
+	startTime := time.Now()
+	policySnapshotList := &placementv1beta1.ClusterSchedulingPolicySnapshotList{}
+	if err := r.client.List(ctx, policySnapshotList, client.MatchingLabels{placementv1beta1.IsLatestSnapshotLabel: "true"}); err != nil {
+		klog.ErrorS(controller.NewAPIServerError(true, err), "Failed to list the latest policy snapshots")
+		return
+	}
+	// Only the latest policy snapshots that have been around for long enough are considered; the
+	// scheduler may still be working on the newer ones.
+	latest := make(map[string]string, len(policySnapshotList.Items))
+	for idx := range policySnapshotList.Items {
+		policy := &policySnapshotList.Items[idx]
+		crpName := policy.Labels[placementv1beta1.CRPTrackingLabel]
+		if crpName == "" || startTime.Sub(policy.CreationTimestamp.Time) < r.staleThreshold {
+			continue
+		}
+		if _, dup := latest[crpName]; dup {
+			// The scheduler reports the case of multiple latest policy snapshots.
+			latest[crpName] = ""
+			continue
+		}
+		latest[crpName] = policy.Name
+	}
+
+	bindingList := &placementv1beta1.ClusterResourceBindingList{}
+	if err := r.client.List(ctx, bindingList); err != nil {
+		klog.ErrorS(controller.NewAPIServerError(true, err), "Failed to list cluster resource bindings")
+		return
+	}
+	drifted := sets.New[string]()
+	for idx := range bindingList.Items {
+		binding := &bindingList.Items[idx]
+		// Unscheduled bindings keep the policy snapshot they were last scheduled with.
+		if !binding.DeletionTimestamp.IsZero() || binding.Spec.State == placementv1beta1.BindingStateUnscheduled {
+			continue
+		}
+		crpName := binding.Labels[placementv1beta1.CRPTrackingLabel]
+		policyName, ok := latest[crpName]
+		if !ok || policyName == "" || binding.Spec.SchedulingPolicySnapshotName == policyName {
+			continue
+		}
+		if !drifted.Has(crpName) {
+			klog.V(2).InfoS("Found a binding that references a stale policy snapshot", "clusterResourceBinding", klog.KObj(binding),
+				"clusterResourcePlacement", crpName, "policySnapshot", binding.Spec.SchedulingPolicySnapshotName, "latestPolicySnapshot", policyName)
+		}
+		drifted.Insert(crpName)
+	}
+
+	for _, crpName := range sets.List(drifted) {
+		r.queue.Add(queue.ClusterResourcePlacementKey(crpName))
+		metrics.SchedulerStalePolicyResyncsTotal.WithLabelValues().Inc()
+	}
+	klog.V(2).InfoS("Scheduler resync run ends", "placementsRequeued", drifted.Len(), "latency", time.Since(startTime).Milliseconds())
+}
@@ -0,0 +1,208 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package resyncer
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+)
+
+const (
+	crpName          = "test-crp"
+	oldPolicyName    = "test-crp-1"
+	latestPolicyName = "test-crp-2"
+	bindingName      = "test-crp-binding"
+	clusterName      = "bravelion"
+)
+
+func serviceScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to add placement v1beta1 scheme: %v", err)
+	}
+	return scheme
+}
+
+// TestResync tests the resync method.
+func TestResync(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		name       string
+		objs       []client.Object
+		wantQueued bool
+	}{
+		{
+			name: "binding references a stale policy snapshot past the threshold",
+			objs: []client.Object{
+				&placementv1beta1.ClusterSchedulingPolicySnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              latestPolicyName,
+						CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel:      crpName,
+							placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+						},
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                clusterName,
+						SchedulingPolicySnapshotName: oldPolicyName,
+					},
+				},
+			},
+			wantQueued: true,
+		},
+		{
+			name: "binding references a stale policy snapshot within the threshold",
+			objs: []client.Object{
+				&placementv1beta1.ClusterSchedulingPolicySnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              latestPolicyName,
+						CreationTimestamp: metav1.NewTime(now.Add(-time.Minute)),
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel:      crpName,
+							placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+						},
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateScheduled,
+						TargetCluster:                clusterName,
+						SchedulingPolicySnapshotName: oldPolicyName,
+					},
+				},
+			},
+		},
+		{
+			name: "binding references the latest policy snapshot",
+			objs: []client.Object{
+				&placementv1beta1.ClusterSchedulingPolicySnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              latestPolicyName,
+						CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel:      crpName,
+							placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+						},
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                clusterName,
+						SchedulingPolicySnapshotName: latestPolicyName,
+					},
+				},
+			},
+		},
+		{
+			name: "unscheduled binding references a stale policy snapshot",
+			objs: []client.Object{
+				&placementv1beta1.ClusterSchedulingPolicySnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              latestPolicyName,
+						CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel:      crpName,
+							placementv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+						},
+					},
+				},
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateUnscheduled,
+						TargetCluster:                clusterName,
+						SchedulingPolicySnapshotName: oldPolicyName,
+					},
+				},
+			},
+		},
+		{
+			name: "no latest policy snapshot",
+			objs: []client.Object{
+				&placementv1beta1.ClusterResourceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: bindingName,
+						Labels: map[string]string{
+							placementv1beta1.CRPTrackingLabel: crpName,
+						},
+					},
+					Spec: placementv1beta1.ResourceBindingSpec{
+						State:                        placementv1beta1.BindingStateBound,
+						TargetCluster:                clusterName,
+						SchedulingPolicySnapshotName: oldPolicyName,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(serviceScheme(t)).
+				WithObjects(tc.objs...).
+				Build()
+			schedulingQueue := queue.NewSimpleClusterResourcePlacementSchedulingQueue()
+			defer schedulingQueue.Close()
+			r := New(fakeClient, schedulingQueue, WithStaleThreshold(10*time.Minute))
+
+			before := testutil.ToFloat64(metrics.SchedulerStalePolicyResyncsTotal.WithLabelValues())
+			r.resync(ctx)
+
+			if gotQueued := schedulingQueue.Len() == 1; gotQueued != tc.wantQueued {
+				t.Errorf("placement queued = %t, want %t", gotQueued, tc.wantQueued)
+			}
+			wantResyncs := 0.0
+			if tc.wantQueued {
+				wantResyncs = 1
+			}
+			if got := testutil.ToFloat64(metrics.SchedulerStalePolicyResyncsTotal.WithLabelValues()) - before; got != wantResyncs {
+				t.Errorf("stale policy resyncs = %v, want %v", got, wantResyncs)
+			}
+		})
+	}
+}
@@ -11,9 +11,13 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -27,7 +31,10 @@ import (
 	"go.goms.io/fleet/pkg/metrics"
 	"go.goms.io/fleet/pkg/scheduler/framework"
 	"go.goms.io/fleet/pkg/scheduler/queue"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/pkg/utils/controller"
+	"go.goms.io/fleet/pkg/utils/healthcheck"
 )
 
 // Scheduler is the scheduler for Fleet workloads.
@@ -35,13 +42,15 @@ type Scheduler struct {
 	// name is the name of the scheduler.
 	name string
 
-	// framework is the scheduling framework in use by the scheduler.
-	//
-	// At this stage, a scheduler is always associated with one scheduling framework; in the long
-	// run, multiple frameworks may be supported, to allow the usage of varying scheduling configurations
-	// for different types of workloads.
+	// framework is the default scheduling framework in use by the scheduler, i.e., the framework
+	// for placements that do not name a scheduling profile.
 	framework framework.Framework
 
+	// frameworks is the additional scheduling frameworks in use by the scheduler, keyed by the names
+	// of their profiles; a placement can select one of them with the SchedulerProfileAnnotation, so that
+	// different types of workloads can be scheduled with varying scheduling configurations.
+	frameworks map[string]framework.Framework
+
 	// queue is the work queue in use by the scheduler; the scheduler pulls items from the queue and
 	// performs scheduling in accordance with them.
 	queue queue.ClusterResourcePlacementSchedulingQueue
@@ -64,8 +73,65 @@ type Scheduler struct {
 
 	// eventRecorder is the event recorder in use by the scheduler.
 	eventRecorder record.EventRecorder
+
+	// queueActivity tracks the activity of the work queue for health checking.
+	queueActivity healthcheck.QueueActivity
+
+	// drainTimeout is how long the scheduler waits for the in-flight scheduling cycles to finish
+	// when it shuts down.
+	drainTimeout time.Duration
+
+	// shuttingDown is set when the scheduler starts shutting down.
+	shuttingDown atomic.Bool
+
+	// pendingKeys are the items left unprocessed as the scheduler shuts down.
+	pendingKeys   []queue.ClusterResourcePlacementKey
+	pendingKeysMu sync.Mutex
 }
 
+const (
+	// defaultDrainTimeout is the default drain timeout of the scheduler.
+	defaultDrainTimeout = 20 * time.Second
+
+	// policySnapshotSupersededReason is the reason of the Superseded condition added to a policy snapshot
+	// which is found active along with a newer one.
+	policySnapshotSupersededReason        = "NewerPolicySnapshotActive"
+	policySnapshotSupersededMessageFormat = "policy snapshot %s of a larger index is active"
+
+	// unknownSchedulerProfileEventReason is the reason of the event the scheduler emits when a CRP
+	// names a scheduling profile that is not found.
+	unknownSchedulerProfileEventReason = "UnknownSchedulerProfile"
+
+	// deletionUnconfirmedEventReason is the reason of the event the scheduler emits when it holds
+	// off the cleanup of a deleted CRP whose deletion is yet to be confirmed.
+	deletionUnconfirmedEventReason = "DeletionUnconfirmed"
+)
+
+// Option helps set up a scheduler.
+type Option func(*Scheduler)
+
+// WithDrainTimeout sets how long the scheduler waits for the in-flight scheduling cycles to finish
+// when it shuts down.
+func WithDrainTimeout(drainTimeout time.Duration) Option {
+	return func(s *Scheduler) {
+		s.drainTimeout = drainTimeout
+	}
+}
+
+// WithFramework registers an additional scheduling framework with the scheduler, which placements
+// can select by the name of its profile.
+func WithFramework(fw framework.Framework) Option {
+	return func(s *Scheduler) {
+		if s.frameworks == nil {
+			s.frameworks = make(map[string]framework.Framework)
+		}
+		s.frameworks[fw.ProfileName()] = fw
+	}
+}
+
+// Verify that Scheduler implements healthcheck.QueueProbe at compile time.
+var _ healthcheck.QueueProbe = &Scheduler{}
+
 // NewScheduler creates a scheduler.
 func NewScheduler(
 	name string,
@@ -73,8 +139,9 @@ func NewScheduler(
 	queue queue.ClusterResourcePlacementSchedulingQueue,
 	manager ctrl.Manager,
 	workerNumber int,
+	opts ...Option,
 ) *Scheduler {
-	return &Scheduler{
+	s := &Scheduler{
 		name:           name,
 		framework:      framework,
 		queue:          queue,
@@ -83,9 +150,48 @@ func NewScheduler(
 		manager:        manager,
 		workerNumber:   workerNumber,
 		eventRecorder:  manager.GetEventRecorderFor(name),
+		drainTimeout:   defaultDrainTimeout,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewPlacementPriorityFunc returns a queue.PriorityFunc which reads the priorities of placements
+// from the given (cached) reader, so that the scheduling queue hands out placements of higher
+// priorities first.
+//
+// A placement that cannot be read, e.g., one that has been deleted, is considered to be of the
+// default priority (0).
+func NewPlacementPriorityFunc(reader client.Reader) queue.PriorityFunc {
+	return func(crpKey queue.ClusterResourcePlacementKey) int32 {
+		crp := &fleetv1beta1.ClusterResourcePlacement{}
+		if err := reader.Get(context.Background(), types.NamespacedName{Name: string(crpKey)}, crp); err != nil {
+			if !errors.IsNotFound(err) {
+				klog.ErrorS(err, "Failed to get the placement for its priority; using the default priority", "clusterResourcePlacement", crpKey)
+			}
+			return 0
+		}
+		return crp.Spec.Priority
 	}
 }
 
+// Started returns true if the scheduler has started running.
+func (s *Scheduler) Started() bool {
+	return s.queueActivity.Started()
+}
+
+// Len returns the number of items ready for processing in the work queue of the scheduler.
+func (s *Scheduler) Len() int {
+	return s.queue.Len()
+}
+
+// LastDequeueTime returns the last time the scheduler pulled an item from the work queue.
+func (s *Scheduler) LastDequeueTime() time.Time {
+	return s.queueActivity.LastDequeueTime()
+}
+
 // ScheduleOnce performs scheduling for one single item pulled from the work queue.
 // it returns true if the context is not canceled, false otherwise.
 func (s *Scheduler) scheduleOnce(ctx context.Context, worker int) {
@@ -98,6 +204,15 @@ func (s *Scheduler) scheduleOnce(ctx context.Context, worker int) {
 		klog.InfoS("Work queue has been closed")
 		return
 	}
+	s.queueActivity.MarkDequeued()
+
+	if s.shuttingDown.Load() {
+		// The scheduler is shutting down and no longer accepts new scheduling cycles; keep track
+		// of the key so that it can be reported as pending.
+		s.queue.Done(crpName)
+		s.addPendingKey(crpName)
+		return
+	}
 
 	defer func() {
 		// Mark the key as done.
@@ -145,6 +260,18 @@ func (s *Scheduler) scheduleOnce(ctx context.Context, worker int) {
 
 	// Check if the CRP has been marked for deletion, and if it has the scheduler cleanup finalizer.
 	if crp.DeletionTimestamp != nil {
+		if controllerutil.ContainsFinalizer(crp, fleetv1beta1.SchedulerCRPCleanupFinalizer) && isDeletionUnconfirmed(crp) {
+			// Keep the bindings, and thus the placed resources on the member clusters, until the
+			// user confirms the deletion; the scheduler will be triggered again then.
+			klog.V(2).InfoS("Holding off the cleanup of cluster resource placement whose deletion is not confirmed", "clusterResourcePlacement", crpRef)
+			if s.eventRecorder != nil {
+				s.eventRecorder.Eventf(crp, corev1.EventTypeWarning, deletionUnconfirmedEventReason,
+					"The placement selects critical resources and is protected from deletion; annotate it with %s=true to confirm the deletion",
+					fleetv1beta1.ConfirmDeletionAnnotation)
+			}
+			s.queue.Forget(crpName)
+			return
+		}
 		if controllerutil.ContainsFinalizer(crp, fleetv1beta1.SchedulerCRPCleanupFinalizer) {
 			if err := s.cleanUpAllBindingsFor(ctx, crp); err != nil {
 				klog.ErrorS(err, "Failed to clean up all bindings for cluster resource placement", "clusterResourcePlacement", crpRef)
@@ -183,12 +310,29 @@ func (s *Scheduler) scheduleOnce(ctx context.Context, worker int) {
 		return
 	}
 
+	// Pick the scheduling framework for the CRP.
+	fw, ok := s.frameworkFor(crp)
+	if !ok {
+		profileName := crp.Annotations[fleetv1beta1.SchedulerProfileAnnotation]
+		klog.ErrorS(controller.NewUserError(fmt.Errorf("scheduling profile %q is not found", profileName)),
+			"Failed to pick the scheduling profile for cluster resource placement", "clusterResourcePlacement", crpRef)
+		if s.eventRecorder != nil {
+			s.eventRecorder.Eventf(crp, corev1.EventTypeWarning, unknownSchedulerProfileEventReason, "Scheduling profile %q is not found", profileName)
+		}
+		// No requeue is needed; the scheduler will be triggered again when the profile of the
+		// CRP changes.
+
+		// Untrack the key for quicker reprocessing.
+		s.queue.Forget(crpName)
+		return
+	}
+
 	// Run the scheduling cycle.
 	//
 	// Note that the scheduler will enter this cycle as long as the CRP is active and an active
 	// policy snapshot has been produced.
 	cycleStartTime := time.Now()
-	res, err := s.framework.RunSchedulingCycleFor(ctx, crp.Name, latestPolicySnapshot)
+	res, err := fw.RunSchedulingCycleFor(ctx, crp.Name, latestPolicySnapshot)
 	if err != nil {
 		klog.ErrorS(err, "Failed to run scheduling cycle", "clusterResourcePlacement", crpRef)
 		// Requeue for later processing.
@@ -213,19 +357,52 @@ func (s *Scheduler) scheduleOnce(ctx context.Context, worker int) {
 		// is certain that more scheduling work needs to be done but it cannot be completed in
 		// one cycle (e.g., a plugin sets up a per-cycle batch limit, and consequently the scheduler must
 		// finish the scheduling in multiple cycles); in such cases, rate limiter should not add
-		// any delay to the requeues.
-		s.queue.Add(crpName)
+		// any delay to the requeues. The queue might still delay the key if the placement has taken
+		// more than its fair share of the workers, so that a very large (or hot-looping) placement
+		// does not hold up the others.
+		s.queue.Requeue(crpName, time.Since(cycleStartTime))
 		observeSchedulingCycleMetrics(cycleStartTime, false, true)
 	} else {
 		// no more failure, the following queue don't need to be rate limited
 		s.queue.Forget(crpName)
+		if isNotFullyScheduled(latestPolicySnapshot) {
+			// Park the key until an event arrives that might make the placement schedulable.
+			s.queue.AddUnschedulable(crpName)
+		}
 		observeSchedulingCycleMetrics(cycleStartTime, false, false)
 	}
 }
 
+// isNotFullyScheduled returns if the scheduling cycle has found that a scheduling policy cannot be
+// fully satisfied, as reported in the status of the policy snapshot (which the cycle has updated).
+func isNotFullyScheduled(policy *fleetv1beta1.ClusterSchedulingPolicySnapshot) bool {
+	scheduledCondition := policy.GetCondition(string(fleetv1beta1.PolicySnapshotScheduled))
+	return condition.IsConditionStatusFalse(scheduledCondition, policy.Generation)
+}
+
+// frameworkFor returns the scheduling framework for a CRP, i.e., the framework of the profile that
+// the CRP names, or the default framework if the CRP does not name any; it returns false if the
+// profile the CRP names is not found.
+func (s *Scheduler) frameworkFor(crp *fleetv1beta1.ClusterResourcePlacement) (framework.Framework, bool) {
+	profileName, ok := crp.Annotations[fleetv1beta1.SchedulerProfileAnnotation]
+	if !ok || profileName == "" {
+		return s.framework, true
+	}
+	if fw, ok := s.frameworks[profileName]; ok {
+		return fw, true
+	}
+	if s.framework != nil && s.framework.ProfileName() == profileName {
+		return s.framework, true
+	}
+	return nil, false
+}
+
 // Run starts the scheduler.
 //
-// Note that this is a blocking call. It will only return when the context is cancelled.
+// Note that this is a blocking call. It will only return when the context is cancelled and
+// the scheduler has shut down gracefully, i.e., it stops pulling new items from the work queue,
+// and waits for the in-flight scheduling cycles to finish, for up to the drain timeout, so that
+// no half-written set of bindings is left behind.
 func (s *Scheduler) Run(ctx context.Context) {
 	klog.V(2).InfoS("Starting the scheduler")
 	defer func() {
@@ -234,6 +411,12 @@ func (s *Scheduler) Run(ctx context.Context) {
 
 	// Starting the scheduling queue.
 	s.queue.Run()
+	s.queueActivity.MarkStarted()
+
+	// The scheduling cycles run with a context that is not cancelled along with the given context;
+	// it is cancelled only when the drain timeout expires during a shutdown.
+	cycleCtx, cancelCycles := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelCycles()
 
 	wg := &sync.WaitGroup{}
 	wg.Add(s.workerNumber)
@@ -241,27 +424,72 @@ func (s *Scheduler) Run(ctx context.Context) {
 		go func(index int) {
 			defer wg.Done()
 			defer utilruntime.HandleCrash()
-			// Run scheduleOnce forever until context is cancelled
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				default:
-					s.scheduleOnce(ctx, index)
-				}
+			// Run scheduleOnce forever until the scheduler starts shutting down.
+			for !s.shuttingDown.Load() {
+				s.scheduleOnce(cycleCtx, index)
 			}
 		}(i)
 	}
 
 	// Wait for the context to be canceled.
 	<-ctx.Done()
-	// The loop starts in a dedicated goroutine; it exits when the context is canceled.
-	wg.Wait()
-	// Stopping the scheduling queue; drain if necessary.
+
+	// Stop accepting new scheduling cycles.
 	//
-	// Note that if a scheduling cycle is in progress; this will only return when the
-	// cycle completes.
-	s.queue.CloseWithDrain()
+	// Closing the queue wakes up the idle workers; the workers will not pick up any new item
+	// from the queue once the scheduler starts shutting down.
+	klog.InfoS("Shutdown signal received, draining in-flight scheduling cycles", "drainTimeout", s.drainTimeout)
+	s.shuttingDown.Store(true)
+	s.queue.Close()
+
+	drained := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		klog.InfoS("All in-flight scheduling cycles have finished")
+	case <-time.After(s.drainTimeout):
+		klog.InfoS("Drain timeout expired, cancelling in-flight scheduling cycles", "drainTimeout", s.drainTimeout)
+		cancelCycles()
+		<-drained
+	}
+
+	// Pull the left-over items from the queue.
+	//
+	// The queue lives in memory only; on restart, the left-over items will be re-enqueued, as the
+	// scheduler watchers process all the existing policy snapshots when they start. The items are
+	// logged here for reference.
+	pending := s.drainPendingKeys()
+	if len(pending) > 0 {
+		klog.InfoS("Scheduler is stopped with pending items, which will be re-enqueued on restart",
+			"pendingCount", len(pending), "pending", pending)
+	}
+}
+
+// addPendingKey keeps track of an item that is left unprocessed as the scheduler shuts down.
+func (s *Scheduler) addPendingKey(crpName queue.ClusterResourcePlacementKey) {
+	s.pendingKeysMu.Lock()
+	defer s.pendingKeysMu.Unlock()
+	s.pendingKeys = append(s.pendingKeys, crpName)
+}
+
+// drainPendingKeys pulls all the left-over items from a closed work queue, and returns them along
+// with the items that the workers have left unprocessed.
+func (s *Scheduler) drainPendingKeys() []queue.ClusterResourcePlacementKey {
+	for {
+		crpName, closed := s.queue.NextClusterResourcePlacementKey()
+		if closed {
+			break
+		}
+		s.queue.Done(crpName)
+		s.addPendingKey(crpName)
+	}
+
+	s.pendingKeysMu.Lock()
+	defer s.pendingKeysMu.Unlock()
+	return s.pendingKeys
 }
 
 // cleanUpAllBindingsFor cleans up all bindings derived from a CRP.
@@ -316,6 +544,24 @@ func (s *Scheduler) cleanUpAllBindingsFor(ctx context.Context, crp *fleetv1beta1
 	return nil
 }
 
+// isDeletionUnconfirmed returns if a CRP is protected by the CriticalResources deletion protection,
+// selects any critical resource, and has not been annotated to confirm its deletion.
+func isDeletionUnconfirmed(crp *fleetv1beta1.ClusterResourcePlacement) bool {
+	applyStrategy := crp.Spec.Strategy.ApplyStrategy
+	if applyStrategy == nil || applyStrategy.DeletionProtection != fleetv1beta1.DeletionProtectionTypeCriticalResources {
+		return false
+	}
+	if crp.Annotations[fleetv1beta1.ConfirmDeletionAnnotation] == strconv.FormatBool(true) {
+		return false
+	}
+	for _, res := range crp.Status.SelectedResources {
+		if utils.IsCriticalResource(res.Group, res.Kind) {
+			return true
+		}
+	}
+	return false
+}
+
 // lookupLatestPolicySnapshot returns the latest (i.e., active) policy snapshot associated with
 // a CRP.
 func (s *Scheduler) lookupLatestPolicySnapshot(ctx context.Context, crp *fleetv1beta1.ClusterResourcePlacement) (*fleetv1beta1.ClusterSchedulingPolicySnapshot, error) {
@@ -347,21 +593,94 @@ func (s *Scheduler) lookupLatestPolicySnapshot(ctx context.Context, crp *fleetv1
 		klog.ErrorS(err, "Failed to find the latest policy snapshot", "clusterResourcePlacement", crpRef)
 		return nil, err
 	case len(policySnapshotList.Items) > 1:
-		// There are multiple active policy snapshots associated with the CRP; normally this
-		// will never happen, as only one policy snapshot can be active in the sequence.
+		// There are multiple active policy snapshots associated with the CRP; this may briefly happen
+		// when the scheduling policy is edited in rapid succession, as only one policy snapshot is
+		// expected to be active in the sequence.
 		//
-		// Similarly, it is out of the scheduler's scope to handle such a case; the scheduler will
-		// report this unexpected occurrence but does not register it as a scheduler-side error.
-		// If (and when) the situation is corrected, the scheduler will be triggered again.
-		err := controller.NewUnexpectedBehaviorError(fmt.Errorf("too many active policy snapshots: %d, want 1", len(policySnapshotList.Items)))
-		klog.ErrorS(err, "There are multiple latest policy snapshots associated with cluster resource placement", "clusterResourcePlacement", crpRef)
-		return nil, err
+		// The scheduler resolves the conflict by picking the policy snapshot with the largest index,
+		// and marking the others as inactive.
+		latest, superseded, err := resolveActivePolicySnapshots(policySnapshotList.Items)
+		if err != nil {
+			// The policy snapshots cannot be ordered; it is out of the scheduler's scope to handle
+			// such a case. The scheduler will report this unexpected occurrence but does not register
+			// it as a scheduler-side error. If (and when) the situation is corrected, the scheduler
+			// will be triggered again.
+			err := controller.NewUnexpectedBehaviorError(fmt.Errorf("failed to resolve %d active policy snapshots: %w", len(policySnapshotList.Items), err))
+			klog.ErrorS(err, "There are multiple latest policy snapshots associated with cluster resource placement", "clusterResourcePlacement", crpRef)
+			return nil, err
+		}
+		klog.V(2).InfoS("Found multiple active policy snapshots; the latest one wins", "clusterResourcePlacement", crpRef, "clusterSchedulingPolicySnapshot", klog.KObj(latest), "supersededCount", len(superseded))
+		for _, policySnapshot := range superseded {
+			if err := s.markPolicySnapshotAsSuperseded(ctx, policySnapshot, latest); err != nil {
+				return nil, err
+			}
+		}
+		return latest, nil
 	default:
 		// Found the one and only active policy snapshot.
 		return &policySnapshotList.Items[0], nil
 	}
 }
 
+// resolveActivePolicySnapshots picks the policy snapshot with the largest index from a list of
+// active policy snapshots of the same CRP; the others are returned as superseded.
+//
+// It returns an error if any of the policy snapshots does not have a valid index, or the largest
+// index is shared by multiple policy snapshots, as the conflict cannot be resolved in either case.
+func resolveActivePolicySnapshots(policySnapshots []fleetv1beta1.ClusterSchedulingPolicySnapshot) (latest *fleetv1beta1.ClusterSchedulingPolicySnapshot, superseded []*fleetv1beta1.ClusterSchedulingPolicySnapshot, err error) {
+	latestIndex := -1
+	indices := make([]int, len(policySnapshots))
+	for idx := range policySnapshots {
+		policySnapshot := &policySnapshots[idx]
+		index, err := strconv.Atoi(policySnapshot.Labels[fleetv1beta1.PolicyIndexLabel])
+		if err != nil || index < 0 {
+			return nil, nil, fmt.Errorf("policy snapshot %s does not have a valid index: %q", policySnapshot.Name, policySnapshot.Labels[fleetv1beta1.PolicyIndexLabel])
+		}
+		indices[idx] = index
+		switch {
+		case index > latestIndex:
+			latestIndex = index
+			latest = policySnapshot
+		case index == latestIndex:
+			return nil, nil, fmt.Errorf("policy snapshots %s and %s have the same index %d", latest.Name, policySnapshot.Name, index)
+		}
+	}
+
+	superseded = make([]*fleetv1beta1.ClusterSchedulingPolicySnapshot, 0, len(policySnapshots)-1)
+	for idx := range policySnapshots {
+		if indices[idx] != latestIndex {
+			superseded = append(superseded, &policySnapshots[idx])
+		}
+	}
+	return latest, superseded, nil
+}
+
+// markPolicySnapshotAsSuperseded marks a policy snapshot as inactive, and adds a Superseded condition
+// to its status, which points to the policy snapshot that supersedes it.
+func (s *Scheduler) markPolicySnapshotAsSuperseded(ctx context.Context, policySnapshot, latest *fleetv1beta1.ClusterSchedulingPolicySnapshot) error {
+	policySnapshotRef := klog.KObj(policySnapshot)
+
+	policySnapshot.Labels[fleetv1beta1.IsLatestSnapshotLabel] = strconv.FormatBool(false)
+	if err := s.client.Update(ctx, policySnapshot); err != nil {
+		klog.ErrorS(err, "Failed to mark a superseded policy snapshot as inactive", "clusterSchedulingPolicySnapshot", policySnapshotRef)
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+
+	meta.SetStatusCondition(&policySnapshot.Status.Conditions, metav1.Condition{
+		Type:               string(fleetv1beta1.PolicySnapshotSuperseded),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: policySnapshot.Generation,
+		Reason:             policySnapshotSupersededReason,
+		Message:            fmt.Sprintf(policySnapshotSupersededMessageFormat, latest.Name),
+	})
+	if err := s.client.Status().Update(ctx, policySnapshot); err != nil {
+		klog.ErrorS(err, "Failed to add the superseded condition to a policy snapshot", "clusterSchedulingPolicySnapshot", policySnapshotRef)
+		return controller.NewUpdateIgnoreConflictError(err)
+	}
+	klog.V(2).InfoS("Marked a superseded policy snapshot as inactive", "clusterSchedulingPolicySnapshot", policySnapshotRef, "latestClusterSchedulingPolicySnapshot", klog.KObj(latest))
+	return nil
+}
+
 // addSchedulerCleanupFinalizer adds the scheduler cleanup finalizer to a CRP (if it does not
 // have it yet).
 func (s *Scheduler) addSchedulerCleanUpFinalizer(ctx context.Context, crp *fleetv1beta1.ClusterResourcePlacement) error {
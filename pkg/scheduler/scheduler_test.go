@@ -17,13 +17,18 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/metrics"
+	"go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+	"go.goms.io/fleet/pkg/utils/condition"
 )
 
 const (
@@ -137,6 +142,7 @@ func TestLookupLatestPolicySnapshot(t *testing.T) {
 		name               string
 		policySnapshots    []*fleetv1beta1.ClusterSchedulingPolicySnapshot
 		wantPolicySnapshot *fleetv1beta1.ClusterSchedulingPolicySnapshot
+		wantSuperseded     []string
 		expectedToFail     bool
 	}{
 		{
@@ -144,7 +150,79 @@ func TestLookupLatestPolicySnapshot(t *testing.T) {
 			expectedToFail: true,
 		},
 		{
-			name: "multiple active policy snapshots",
+			name: "multiple active policy snapshots, latest index wins",
+			policySnapshots: []*fleetv1beta1.ClusterSchedulingPolicySnapshot{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: policySnapshotName,
+						Labels: map[string]string{
+							fleetv1beta1.CRPTrackingLabel:      crpName,
+							fleetv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+							fleetv1beta1.PolicyIndexLabel:      "1",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: altPolicySnapshotName,
+						Labels: map[string]string{
+							fleetv1beta1.CRPTrackingLabel:      crpName,
+							fleetv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+							fleetv1beta1.PolicyIndexLabel:      "2",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: anotherPolicySnapshotName,
+						Labels: map[string]string{
+							fleetv1beta1.CRPTrackingLabel:      crpName,
+							fleetv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+							fleetv1beta1.PolicyIndexLabel:      "0",
+						},
+					},
+				},
+			},
+			wantPolicySnapshot: &fleetv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: altPolicySnapshotName,
+					Labels: map[string]string{
+						fleetv1beta1.CRPTrackingLabel:      crpName,
+						fleetv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+						fleetv1beta1.PolicyIndexLabel:      "2",
+					},
+				},
+			},
+			wantSuperseded: []string{policySnapshotName, anotherPolicySnapshotName},
+		},
+		{
+			name: "multiple active policy snapshots with the same index",
+			policySnapshots: []*fleetv1beta1.ClusterSchedulingPolicySnapshot{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: policySnapshotName,
+						Labels: map[string]string{
+							fleetv1beta1.CRPTrackingLabel:      crpName,
+							fleetv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+							fleetv1beta1.PolicyIndexLabel:      "1",
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: altPolicySnapshotName,
+						Labels: map[string]string{
+							fleetv1beta1.CRPTrackingLabel:      crpName,
+							fleetv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+							fleetv1beta1.PolicyIndexLabel:      "1",
+						},
+					},
+				},
+			},
+			expectedToFail: true,
+		},
+		{
+			name: "multiple active policy snapshots without valid indices",
 			policySnapshots: []*fleetv1beta1.ClusterSchedulingPolicySnapshot{
 				{
 					ObjectMeta: metav1.ObjectMeta{
@@ -214,7 +292,7 @@ func TestLookupLatestPolicySnapshot(t *testing.T) {
 				WithScheme(scheme.Scheme).
 				WithObjects(crp)
 			for _, policySnapshot := range tc.policySnapshots {
-				fakeClientBuilder.WithObjects(policySnapshot)
+				fakeClientBuilder.WithObjects(policySnapshot).WithStatusSubresource(policySnapshot)
 			}
 			fakeClient := fakeClientBuilder.Build()
 			// Construct scheduler manually instead of using NewScheduler() to avoid mocking the controller
@@ -237,6 +315,19 @@ func TestLookupLatestPolicySnapshot(t *testing.T) {
 			if diff := cmp.Diff(activePolicySnapshot, tc.wantPolicySnapshot, ignoreObjectMetaResourceVersionField); diff != "" {
 				t.Errorf("active policy snapshot diff (-got, +want): %s", diff)
 			}
+
+			for _, name := range tc.wantSuperseded {
+				policySnapshot := &fleetv1beta1.ClusterSchedulingPolicySnapshot{}
+				if err := fakeClient.Get(ctx, types.NamespacedName{Name: name}, policySnapshot); err != nil {
+					t.Fatalf("Get(%s) = %v, want no error", name, err)
+				}
+				if policySnapshot.Labels[fleetv1beta1.IsLatestSnapshotLabel] != strconv.FormatBool(false) {
+					t.Errorf("superseded policy snapshot %s labels = %v, want it to be marked as inactive", name, policySnapshot.Labels)
+				}
+				if !condition.IsConditionStatusTrue(meta.FindStatusCondition(policySnapshot.Status.Conditions, string(fleetv1beta1.PolicySnapshotSuperseded)), policySnapshot.Generation) {
+					t.Errorf("superseded policy snapshot %s conditions = %v, want a true Superseded condition", name, policySnapshot.Status.Conditions)
+				}
+			}
 		})
 	}
 }
@@ -279,6 +370,257 @@ func TestAddSchedulerCleanUpFinalizer(t *testing.T) {
 	}
 }
 
+// namedFramework is a scheduler framework that only has a profile name.
+type namedFramework struct {
+	framework.Framework
+
+	profileName string
+}
+
+// ProfileName returns the name of the profile of the framework.
+func (f *namedFramework) ProfileName() string {
+	return f.profileName
+}
+
+// TestNewPlacementPriorityFunc tests the priority function returned by NewPlacementPriorityFunc.
+func TestNewPlacementPriorityFunc(t *testing.T) {
+	crp := &fleetv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: crpName,
+		},
+		Spec: fleetv1beta1.ClusterResourcePlacementSpec{
+			Priority: 100,
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(crp).
+		Build()
+	priorityFunc := NewPlacementPriorityFunc(fakeClient)
+
+	testCases := []struct {
+		name   string
+		crpKey queue.ClusterResourcePlacementKey
+		want   int32
+	}{
+		{
+			name:   "placement with a priority",
+			crpKey: queue.ClusterResourcePlacementKey(crpName),
+			want:   100,
+		},
+		{
+			name:   "placement not found",
+			crpKey: queue.ClusterResourcePlacementKey("deleted-crp"),
+			want:   0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := priorityFunc(tc.crpKey); got != tc.want {
+				t.Errorf("priorityFunc(%s) = %d, want %d", tc.crpKey, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestIsDeletionUnconfirmed tests the isDeletionUnconfirmed function.
+func TestIsDeletionUnconfirmed(t *testing.T) {
+	protected := &fleetv1beta1.ApplyStrategy{
+		DeletionProtection: fleetv1beta1.DeletionProtectionTypeCriticalResources,
+	}
+	namespace := fleetv1beta1.ResourceIdentifier{Version: "v1", Kind: "Namespace", Name: "app"}
+	crd := fleetv1beta1.ResourceIdentifier{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition", Name: "tests.example.com"}
+	configMap := fleetv1beta1.ResourceIdentifier{Version: "v1", Kind: "ConfigMap", Name: "app", Namespace: "app"}
+
+	testCases := []struct {
+		name              string
+		applyStrategy     *fleetv1beta1.ApplyStrategy
+		annotations       map[string]string
+		selectedResources []fleetv1beta1.ResourceIdentifier
+		want              bool
+	}{
+		{
+			name:              "no apply strategy",
+			selectedResources: []fleetv1beta1.ResourceIdentifier{namespace},
+		},
+		{
+			name:              "no deletion protection",
+			applyStrategy:     &fleetv1beta1.ApplyStrategy{DeletionProtection: fleetv1beta1.DeletionProtectionTypeNone},
+			selectedResources: []fleetv1beta1.ResourceIdentifier{namespace},
+		},
+		{
+			name:              "protected, selects a namespace",
+			applyStrategy:     protected,
+			selectedResources: []fleetv1beta1.ResourceIdentifier{configMap, namespace},
+			want:              true,
+		},
+		{
+			name:              "protected, selects a CRD",
+			applyStrategy:     protected,
+			selectedResources: []fleetv1beta1.ResourceIdentifier{crd},
+			want:              true,
+		},
+		{
+			name:              "protected, selects no critical resources",
+			applyStrategy:     protected,
+			selectedResources: []fleetv1beta1.ResourceIdentifier{configMap},
+		},
+		{
+			name:              "protected, deletion confirmed",
+			applyStrategy:     protected,
+			annotations:       map[string]string{fleetv1beta1.ConfirmDeletionAnnotation: "true"},
+			selectedResources: []fleetv1beta1.ResourceIdentifier{namespace},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			crp := &fleetv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        crpName,
+					Annotations: tc.annotations,
+				},
+				Spec: fleetv1beta1.ClusterResourcePlacementSpec{
+					Strategy: fleetv1beta1.RolloutStrategy{
+						ApplyStrategy: tc.applyStrategy,
+					},
+				},
+				Status: fleetv1beta1.ClusterResourcePlacementStatus{
+					SelectedResources: tc.selectedResources,
+				},
+			}
+			if got := isDeletionUnconfirmed(crp); got != tc.want {
+				t.Errorf("isDeletionUnconfirmed() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestFrameworkFor tests the frameworkFor method.
+func TestFrameworkFor(t *testing.T) {
+	defaultFramework := &namedFramework{profileName: "DefaultProfile"}
+	customFramework := &namedFramework{profileName: "CustomProfile"}
+	s := &Scheduler{
+		framework: defaultFramework,
+	}
+	WithFramework(customFramework)(s)
+
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		want        framework.Framework
+		wantFound   bool
+	}{
+		{
+			name:      "no profile",
+			want:      defaultFramework,
+			wantFound: true,
+		},
+		{
+			name:        "empty profile",
+			annotations: map[string]string{fleetv1beta1.SchedulerProfileAnnotation: ""},
+			want:        defaultFramework,
+			wantFound:   true,
+		},
+		{
+			name:        "default profile by name",
+			annotations: map[string]string{fleetv1beta1.SchedulerProfileAnnotation: "DefaultProfile"},
+			want:        defaultFramework,
+			wantFound:   true,
+		},
+		{
+			name:        "custom profile",
+			annotations: map[string]string{fleetv1beta1.SchedulerProfileAnnotation: "CustomProfile"},
+			want:        customFramework,
+			wantFound:   true,
+		},
+		{
+			name:        "unknown profile",
+			annotations: map[string]string{fleetv1beta1.SchedulerProfileAnnotation: "UnknownProfile"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			crp := &fleetv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        crpName,
+					Annotations: tc.annotations,
+				},
+			}
+			got, found := s.frameworkFor(crp)
+			if found != tc.wantFound {
+				t.Fatalf("frameworkFor() found = %t, want %t", found, tc.wantFound)
+			}
+			if got != tc.want {
+				t.Errorf("frameworkFor() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestIsNotFullyScheduled tests the isNotFullyScheduled function.
+func TestIsNotFullyScheduled(t *testing.T) {
+	testCases := []struct {
+		name       string
+		conditions []metav1.Condition
+		want       bool
+	}{
+		{
+			name: "no scheduled condition",
+		},
+		{
+			name: "fully scheduled",
+			conditions: []metav1.Condition{
+				{
+					Type:               string(fleetv1beta1.PolicySnapshotScheduled),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 1,
+				},
+			},
+		},
+		{
+			name: "not fully scheduled",
+			conditions: []metav1.Condition{
+				{
+					Type:               string(fleetv1beta1.PolicySnapshotScheduled),
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: 1,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "stale scheduled condition",
+			conditions: []metav1.Condition{
+				{
+					Type:               string(fleetv1beta1.PolicySnapshotScheduled),
+					Status:             metav1.ConditionFalse,
+					ObservedGeneration: 0,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := &fleetv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       policySnapshotName,
+					Generation: 1,
+				},
+				Status: fleetv1beta1.SchedulingPolicySnapshotStatus{
+					Conditions: tc.conditions,
+				},
+			}
+			if got := isNotFullyScheduled(policy); got != tc.want {
+				t.Errorf("isNotFullyScheduled() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestObserveSchedulingCycleMetrics(t *testing.T) {
 	metricMetadata := `
 		# HELP scheduling_cycle_duration_milliseconds The duration of a scheduling cycle run in milliseconds
@@ -325,3 +667,124 @@ func TestObserveSchedulingCycleMetrics(t *testing.T) {
 		})
 	}
 }
+
+// blockingFramework is a scheduler framework whose scheduling cycles block until released or
+// cancelled.
+type blockingFramework struct {
+	framework.Framework
+
+	started  chan struct{}
+	release  chan struct{}
+	cycleErr chan error
+}
+
+// RunSchedulingCycleFor blocks until the cycle is released or its context is cancelled.
+func (f *blockingFramework) RunSchedulingCycleFor(ctx context.Context, _ string, _ *fleetv1beta1.ClusterSchedulingPolicySnapshot) (ctrl.Result, error) {
+	f.started <- struct{}{}
+	select {
+	case <-f.release:
+	case <-ctx.Done():
+	}
+	f.cycleErr <- ctx.Err()
+	return ctrl.Result{}, nil
+}
+
+// TestRunGracefulShutdown tests that the Run method drains in-flight scheduling cycles on shutdown.
+func TestRunGracefulShutdown(t *testing.T) {
+	altCRPName := "another-test-crp"
+
+	testCases := []struct {
+		name         string
+		drainTimeout time.Duration
+		// releaseCycle is true if the in-flight cycle completes by itself after the shutdown starts.
+		releaseCycle bool
+		wantCycleErr bool
+	}{
+		{
+			name:         "in-flight cycle finishes within drain timeout",
+			drainTimeout: time.Minute,
+			releaseCycle: true,
+		},
+		{
+			name:         "in-flight cycle is cancelled after drain timeout",
+			drainTimeout: 10 * time.Millisecond,
+			wantCycleErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			crp := &fleetv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       crpName,
+					Finalizers: []string{fleetv1beta1.SchedulerCRPCleanupFinalizer},
+				},
+			}
+			policySnapshot := &fleetv1beta1.ClusterSchedulingPolicySnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: policySnapshotName,
+					Labels: map[string]string{
+						fleetv1beta1.CRPTrackingLabel:      crpName,
+						fleetv1beta1.IsLatestSnapshotLabel: strconv.FormatBool(true),
+					},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(crp, policySnapshot).
+				Build()
+			fw := &blockingFramework{
+				started:  make(chan struct{}, 1),
+				release:  make(chan struct{}),
+				cycleErr: make(chan error, 1),
+			}
+			schedulingQueue := queue.NewSimpleClusterResourcePlacementSchedulingQueue()
+			// Construct scheduler manually instead of using NewScheduler() to avoid mocking the controller
+			// manager.
+			s := &Scheduler{
+				framework:      fw,
+				queue:          schedulingQueue,
+				client:         fakeClient,
+				uncachedReader: fakeClient,
+				workerNumber:   1,
+				drainTimeout:   tc.drainTimeout,
+			}
+			schedulingQueue.Add(crpName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			stopped := make(chan struct{})
+			go func() {
+				defer close(stopped)
+				s.Run(ctx)
+			}()
+
+			select {
+			case <-fw.started:
+			case <-time.After(10 * time.Second):
+				t.Fatalf("scheduling cycle has not started")
+			}
+			// Add an item which cannot be processed before the shutdown, as the only worker is busy.
+			schedulingQueue.Add(queue.ClusterResourcePlacementKey(altCRPName))
+
+			cancel()
+			if tc.releaseCycle {
+				// Give the scheduler some time to start shutting down before releasing the cycle.
+				time.Sleep(100 * time.Millisecond)
+				close(fw.release)
+			}
+
+			select {
+			case <-stopped:
+			case <-time.After(10 * time.Second):
+				t.Fatalf("Run() has not returned after shutdown")
+			}
+			if err := <-fw.cycleErr; (err != nil) != tc.wantCycleErr {
+				t.Errorf("in-flight scheduling cycle context error = %v, want error %t", err, tc.wantCycleErr)
+			}
+			wantPendingKeys := []queue.ClusterResourcePlacementKey{queue.ClusterResourcePlacementKey(altCRPName)}
+			if diff := cmp.Diff(s.pendingKeys, wantPendingKeys); diff != "" {
+				t.Errorf("pending keys diff (-got, +want): %s", diff)
+			}
+		})
+	}
+}
@@ -59,12 +59,38 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			return ctrl.Result{}, nil
 		}
 		r.SchedulerWorkQueue.AddRateLimited(queue.ClusterResourcePlacementKey(crpName))
+
+		// If the CRB belongs to an exclusivity group, its target cluster might become available to other CRPs
+		// of the same group; enqueue them as well.
+		if group, ok := crb.GetLabels()[fleetv1beta1.ExclusivityGroupLabel]; ok {
+			if err := r.enqueueCRPsInExclusivityGroup(ctx, group, crpName); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 	}
 
 	// No action is needed for the scheduler to take in other cases.
 	return ctrl.Result{}, nil
 }
 
+// enqueueCRPsInExclusivityGroup enqueues all the CRPs, except the given one, of the given exclusivity group.
+func (r *Reconciler) enqueueCRPsInExclusivityGroup(ctx context.Context, group, excludedCRPName string) error {
+	crpList := &fleetv1beta1.ClusterResourcePlacementList{}
+	if err := r.Client.List(ctx, crpList); err != nil {
+		klog.ErrorS(err, "Failed to list cluster resource placements", "exclusivityGroup", group)
+		return controller.NewAPIServerError(true, err)
+	}
+	for idx := range crpList.Items {
+		crp := &crpList.Items[idx]
+		if crp.Name == excludedCRPName || crp.Spec.Policy == nil || crp.Spec.Policy.ExclusivityGroup != group {
+			continue
+		}
+		klog.V(2).InfoS("Enqueueing CRP of the same exclusivity group", "clusterResourcePlacement", klog.KObj(crp), "exclusivityGroup", group)
+		r.SchedulerWorkQueue.AddRateLimited(queue.ClusterResourcePlacementKey(crp.Name))
+	}
+	return nil
+}
+
 // SetupWithManager sets up the controller with the manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 	customPredicate := predicate.Funcs{
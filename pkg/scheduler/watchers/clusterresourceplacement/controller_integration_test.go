@@ -160,6 +160,48 @@ var _ = Describe("scheduler cluster resource placement source controller", Seria
 		})
 	})
 
+	Context("crp scheduling profile changed", func() {
+		BeforeAll(func() {
+			Consistently(noKeyEnqueuedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Workqueue is not empty")
+
+			crp := &fleetv1beta1.ClusterResourcePlacement{}
+			Expect(hubClient.Get(ctx, client.ObjectKey{Name: crpName}, crp)).Should(Succeed(), "Failed to get cluster resource placement")
+
+			crp.Annotations = map[string]string{fleetv1beta1.SchedulerProfileAnnotation: "CustomProfile"}
+			Expect(hubClient.Update(ctx, crp)).Should(Succeed(), "Failed to update cluster resource placement")
+		})
+
+		It("should enqueue the CRP when its scheduling profile is changed", func() {
+			Eventually(expectedKeySetEnqueuedActual, eventuallyDuration, eventuallyInterval).Should(Succeed(), "Workqueue is empty")
+			Consistently(expectedKeySetEnqueuedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Workqueue is empty")
+		})
+
+		AfterAll(func() {
+			keyCollector.Reset()
+		})
+	})
+
+	Context("crp recommended clusters changed", func() {
+		BeforeAll(func() {
+			Consistently(noKeyEnqueuedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Workqueue is not empty")
+
+			crp := &fleetv1beta1.ClusterResourcePlacement{}
+			Expect(hubClient.Get(ctx, client.ObjectKey{Name: crpName}, crp)).Should(Succeed(), "Failed to get cluster resource placement")
+
+			crp.Annotations[fleetv1beta1.RecommendedClustersAnnotation] = "member-1,member-2"
+			Expect(hubClient.Update(ctx, crp)).Should(Succeed(), "Failed to update cluster resource placement")
+		})
+
+		It("should enqueue the CRP when its recommended clusters are changed", func() {
+			Eventually(expectedKeySetEnqueuedActual, eventuallyDuration, eventuallyInterval).Should(Succeed(), "Workqueue is empty")
+			Consistently(expectedKeySetEnqueuedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Workqueue is empty")
+		})
+
+		AfterAll(func() {
+			keyCollector.Reset()
+		})
+	})
+
 	Context("crp with finalizer is deleted", func() {
 		BeforeAll(func() {
 			Consistently(noKeyEnqueuedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Workqueue is not empty")
@@ -4,7 +4,8 @@ Licensed under the MIT license.
 */
 
 // Package clusterresourceplacement features a controller that enqueues CRPs for the
-// scheduler to process where the CRP is marked for deletion.
+// scheduler to process where the CRP is marked for deletion, or where the scheduling
+// profile of the CRP has changed.
 package clusterresourceplacement
 
 import (
@@ -24,7 +25,7 @@ import (
 	"go.goms.io/fleet/pkg/utils/controller"
 )
 
-// Reconciler reconciles the deletion of a CRP.
+// Reconciler reconciles the deletion, and the scheduling profile changes, of a CRP.
 type Reconciler struct {
 	// Client is the client the controller uses to access the hub cluster.
 	client.Client
@@ -56,6 +57,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		r.SchedulerWorkQueue.AddRateLimited(queue.ClusterResourcePlacementKey(crp.Name))
 	}
 
+	// Check if the CRP is still active; as the controller filters out all other changes, the CRP
+	// must have switched to a different scheduling profile.
+	if crp.DeletionTimestamp == nil {
+		// Enqueue the CRP for the scheduler to process with the new profile.
+		r.SchedulerWorkQueue.Add(queue.ClusterResourcePlacementKey(crp.Name))
+	}
+
 	// No action is needed for the scheduler to take in other cases.
 	return ctrl.Result{}, nil
 }
@@ -87,7 +95,23 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 				return true
 			}
 
-			return false
+			// Check if the deletion of a deleted CRP has been confirmed.
+			if newDeletionTimestamp != nil &&
+				e.ObjectOld.GetAnnotations()[fleetv1beta1.ConfirmDeletionAnnotation] != e.ObjectNew.GetAnnotations()[fleetv1beta1.ConfirmDeletionAnnotation] {
+				return true
+			}
+
+			// Check if the scheduling profile has been changed.
+			oldProfile := e.ObjectOld.GetAnnotations()[fleetv1beta1.SchedulerProfileAnnotation]
+			newProfile := e.ObjectNew.GetAnnotations()[fleetv1beta1.SchedulerProfileAnnotation]
+			if oldProfile != newProfile {
+				return true
+			}
+
+			// Check if the recommended clusters have been changed.
+			oldRecommended := e.ObjectOld.GetAnnotations()[fleetv1beta1.RecommendedClustersAnnotation]
+			newRecommended := e.ObjectNew.GetAnnotations()[fleetv1beta1.RecommendedClustersAnnotation]
+			return oldRecommended != newRecommended
 		},
 	}
 
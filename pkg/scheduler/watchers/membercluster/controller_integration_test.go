@@ -38,6 +38,10 @@ const (
 	dummyNonResourcePropertyName   = "dummy-non-resource-property"
 	dummyNonResourcePropertyValue1 = "0"
 	dummyNonResourcePropertyValue2 = "1"
+
+	bindingName               = "binding-1"
+	dummyResourceSnapshotName = "dummy-resource-snapshot"
+	dummyPolicySnapshotName   = "dummy-policy-snapshot"
 )
 
 var (
@@ -55,9 +59,10 @@ var (
 		return nil
 	}
 
-	allKeysEnqueuedActual = func() error {
+	leftClusterKeysEnqueuedActual = func() error {
 		errorFormat := "CRP keys %v have not been enqueued"
-		requiredKeys := []string{crpName1, crpName2, crpName3, crpName4, crpName5, crpName6}
+		// Only the CRPs that target the cluster (PickFixed), or have a binding on it, are affected.
+		requiredKeys := []string{crpName3, crpName4, crpName5}
 		if isAllPresent, absentKeys := keyCollector.IsPresent(requiredKeys...); !isAllPresent {
 			return fmt.Errorf(errorFormat, absentKeys)
 		}
@@ -501,6 +506,43 @@ var _ = Describe("scheduler member cluster source controller", Serial, Ordered,
 		})
 	})
 
+	Context("ready cluster is cordoned & uncordoned", func() {
+		BeforeAll(func() {
+			Consistently(noKeyEnqueuedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Workqueue is not empty")
+
+			// Retrieve the cluster.
+			memberCluster := &clusterv1beta1.MemberCluster{}
+			Expect(hubClient.Get(ctx, types.NamespacedName{Name: clusterName1}, memberCluster)).To(Succeed(), "Failed to get member cluster")
+
+			// Cordon the cluster.
+			memberCluster.Spec.Cordon = &clusterv1beta1.Cordon{Reason: "maintenance"}
+			Expect(hubClient.Update(ctx, memberCluster)).Should(Succeed(), "Failed to cordon member cluster")
+		})
+
+		It("should not enqueue CRPs for cordoned cluster", func() {
+			Consistently(noKeyEnqueuedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Workqueue is not empty")
+		})
+
+		It("uncordon cluster", func() {
+			memberCluster := &clusterv1beta1.MemberCluster{}
+			// Retrieve the cluster.
+			Expect(hubClient.Get(ctx, types.NamespacedName{Name: clusterName1}, memberCluster)).To(Succeed(), "Failed to get member cluster")
+
+			// Uncordon the cluster.
+			memberCluster.Spec.Cordon = nil
+			Expect(hubClient.Update(ctx, memberCluster)).Should(Succeed(), "Failed to uncordon member cluster")
+		})
+
+		It("should enqueue CRPs for uncordoned cluster", func() {
+			Eventually(qualifiedKeysEnqueuedActual, eventuallyDuration, eventuallyInterval).Should(Succeed(), "Keys are not enqueued as expected")
+			Consistently(qualifiedKeysEnqueuedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Keys are not enqueued as expected")
+		})
+
+		AfterAll(func() {
+			keyCollector.Reset()
+		})
+	})
+
 	Context("ready cluster has left", func() {
 		BeforeAll(func() {
 			Consistently(noKeyEnqueuedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Workqueue is not empty")
@@ -513,9 +555,9 @@ var _ = Describe("scheduler member cluster source controller", Serial, Ordered,
 			Expect(hubClient.Delete(ctx, memberCluster)).To(Succeed(), "Failed to delete member cluster")
 		})
 
-		It("should enqueue all CRPs for cluster left (case 1b)", func() {
-			Eventually(allKeysEnqueuedActual, eventuallyDuration, eventuallyInterval).Should(Succeed(), "Keys are not enqueued as expected")
-			Consistently(allKeysEnqueuedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Keys are not enqueued as expected")
+		It("should enqueue affected CRPs for cluster left (case 2c)", func() {
+			Eventually(leftClusterKeysEnqueuedActual, eventuallyDuration, eventuallyInterval).Should(Succeed(), "Keys are not enqueued as expected")
+			Consistently(leftClusterKeysEnqueuedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Keys are not enqueued as expected")
 		})
 
 		AfterAll(func() {
@@ -27,6 +27,7 @@ import (
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
 	"go.goms.io/fleet/pkg/scheduler/queue"
+	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/test/utils/keycollector"
 )
 
@@ -124,6 +125,27 @@ func setupResources() {
 	})
 	Expect(hubClient.Status().Update(ctx, crp)).Should(Succeed(), "Failed to update CRP status")
 
+	// Create a binding of the fully scheduled PickN CRP on the member cluster.
+	Expect(hubClient.Create(ctx, &placementv1beta1.ClusterResourceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: bindingName,
+			Labels: map[string]string{
+				placementv1beta1.CRPTrackingLabel: crpName5,
+			},
+		},
+		Spec: placementv1beta1.ResourceBindingSpec{
+			State:                        placementv1beta1.BindingStateBound,
+			ResourceSnapshotName:         dummyResourceSnapshotName,
+			SchedulingPolicySnapshotName: dummyPolicySnapshotName,
+			TargetCluster:                clusterName1,
+			ClusterDecision: placementv1beta1.ClusterDecision{
+				ClusterName: clusterName1,
+				Selected:    true,
+				Reason:      dummyReason,
+			},
+		},
+	})).Should(Succeed(), "Failed to create binding")
+
 	// Create a CRP that is of the PickN placement type and has not been fully scheduled.
 	Expect(hubClient.Create(ctx, newCRP(crpName6, &placementv1beta1.PlacementPolicy{
 		PlacementType:    placementv1beta1.PickNPlacementType,
@@ -170,8 +192,11 @@ var _ = BeforeSuite(func() {
 
 	schedulerWorkQueue := queue.NewSimpleClusterResourcePlacementSchedulingQueue()
 
+	// The controller looks up the bindings on a leaving cluster from the cache by the index.
+	Expect(controller.SetupBindingTargetClusterIndex(ctx, ctrlMgr.GetFieldIndexer())).To(Succeed(), "Failed to set up the binding index")
+
 	reconciler := Reconciler{
-		Client:                    hubClient,
+		Client:                    ctrlMgr.GetClient(),
 		SchedulerWorkQueue:        schedulerWorkQueue,
 		ClusterEligibilityChecker: clustereligibilitychecker.New(),
 	}
@@ -6,9 +6,15 @@ Licensed under the MIT license.
 package membercluster
 
 import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
 
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
 	"go.goms.io/fleet/pkg/utils/condition"
 )
 
@@ -60,3 +66,52 @@ func classifyCRPs(crps []fleetv1beta1.ClusterResourcePlacement) (toProcess []fle
 
 	return toProcess
 }
+
+// classifyCRPsForLeavingCluster returns a list of CRPs that are affected by a cluster leaving the
+// fleet (case 2c)), i.e., the CRPs with bindings on the cluster, and the CRPs of the PickFixed
+// placement type that target the cluster.
+func classifyCRPsForLeavingCluster(crps []fleetv1beta1.ClusterResourcePlacement, clusterName string, boundCRPNames sets.Set[string]) (toProcess []fleetv1beta1.ClusterResourcePlacement) {
+	for idx := range crps {
+		crp := crps[idx]
+		switch {
+		case boundCRPNames.Has(crp.Name):
+			toProcess = append(toProcess, crp)
+		case crp.Spec.Policy != nil && crp.Spec.Policy.PlacementType == fleetv1beta1.PickFixedPlacementType &&
+			slices.Contains(crp.Spec.Policy.ClusterNames, clusterName):
+			toProcess = append(toProcess, crp)
+		}
+	}
+	return toProcess
+}
+
+// isClusterEventRegistered returns whether a kind of member cluster change is of interest to
+// the scheduler; all kinds of changes are considered relevant if no registration is present.
+func isClusterEventRegistered(registered sets.Set[framework.ClusterEvent], event framework.ClusterEvent) bool {
+	return registered == nil || registered.Has(event)
+}
+
+// isPropertiesChanged returns whether the values of the non-resource properties of a member cluster
+// have changed.
+//
+// Observation time refreshes are not considered as changes.
+func isPropertiesChanged(oldProperties, newProperties map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue) bool {
+	if len(oldProperties) != len(newProperties) {
+		return true
+	}
+	for oldK, oldV := range oldProperties {
+		newV, ok := newProperties[oldK]
+		if !ok || oldV.Value != newV.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// isResourceUsageChanged returns whether the resource usage of a member cluster has changed.
+//
+// Observation time refreshes are not considered as changes.
+func isResourceUsageChanged(oldUsage, newUsage *clusterv1beta1.ResourceUsage) bool {
+	return !equality.Semantic.DeepEqual(oldUsage.Capacity, newUsage.Capacity) ||
+		!equality.Semantic.DeepEqual(oldUsage.Allocatable, newUsage.Allocatable) ||
+		!equality.Semantic.DeepEqual(oldUsage.Available, newUsage.Available)
+}
@@ -9,9 +9,14 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/scheduler/framework"
 )
 
 const (
@@ -408,3 +413,203 @@ func TestClassifyCRPs(t *testing.T) {
 		})
 	}
 }
+
+// TestClassifyCRPsForLeavingCluster tests the classifyCRPsForLeavingCluster function.
+func TestClassifyCRPsForLeavingCluster(t *testing.T) {
+	pickAllCRP := placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: crpName1,
+		},
+	}
+	pickFixedCRP := placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: crpName2,
+		},
+		Spec: placementv1beta1.ClusterResourcePlacementSpec{
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickFixedPlacementType,
+				ClusterNames:  []string{clusterName1},
+			},
+		},
+	}
+	pickNCRP := placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: crpName,
+		},
+		Spec: placementv1beta1.ClusterResourcePlacementSpec{
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &numOfClusters,
+			},
+		},
+	}
+	crps := []placementv1beta1.ClusterResourcePlacement{pickAllCRP, pickFixedCRP, pickNCRP}
+
+	testCases := []struct {
+		name          string
+		clusterName   string
+		boundCRPNames sets.Set[string]
+		want          []placementv1beta1.ClusterResourcePlacement
+	}{
+		{
+			name:          "no bindings on the cluster, targeted by a PickFixed CRP",
+			clusterName:   clusterName1,
+			boundCRPNames: sets.New[string](),
+			want:          []placementv1beta1.ClusterResourcePlacement{pickFixedCRP},
+		},
+		{
+			name:          "bindings on the cluster",
+			clusterName:   clusterName2,
+			boundCRPNames: sets.New(crpName1, crpName),
+			want:          []placementv1beta1.ClusterResourcePlacement{pickAllCRP, pickNCRP},
+		},
+		{
+			name:          "no affected CRPs",
+			clusterName:   clusterName2,
+			boundCRPNames: sets.New[string](),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			toProcess := classifyCRPsForLeavingCluster(crps, tc.clusterName, tc.boundCRPNames)
+			if diff := cmp.Diff(toProcess, tc.want); diff != "" {
+				t.Errorf("classifyCRPsForLeavingCluster() toProcess (-got, +want): %s", diff)
+			}
+		})
+	}
+}
+
+// TestIsClusterEventRegistered tests the isClusterEventRegistered function.
+func TestIsClusterEventRegistered(t *testing.T) {
+	testCases := []struct {
+		name       string
+		registered sets.Set[framework.ClusterEvent]
+		event      framework.ClusterEvent
+		want       bool
+	}{
+		{
+			name:  "no registration",
+			event: framework.ClusterTaintChanged,
+			want:  true,
+		},
+		{
+			name:       "registered",
+			registered: sets.New(framework.ClusterLabelChanged, framework.ClusterTaintChanged),
+			event:      framework.ClusterTaintChanged,
+			want:       true,
+		},
+		{
+			name:       "not registered",
+			registered: sets.New(framework.ClusterLabelChanged),
+			event:      framework.ClusterResourceUsageChanged,
+			want:       false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isClusterEventRegistered(tc.registered, tc.event); got != tc.want {
+				t.Errorf("isClusterEventRegistered() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestIsPropertiesChanged tests the isPropertiesChanged function.
+func TestIsPropertiesChanged(t *testing.T) {
+	testCases := []struct {
+		name          string
+		oldProperties map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue
+		newProperties map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue
+		want          bool
+	}{
+		{
+			name: "observation time refreshed",
+			oldProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				"foo": {Value: "1"},
+			},
+			newProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				"foo": {Value: "1", ObservationTime: metav1.Now()},
+			},
+			want: false,
+		},
+		{
+			name: "value changed",
+			oldProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				"foo": {Value: "1"},
+			},
+			newProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				"foo": {Value: "2"},
+			},
+			want: true,
+		},
+		{
+			name: "property added",
+			oldProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				"foo": {Value: "1"},
+			},
+			newProperties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				"foo": {Value: "1"},
+				"bar": {Value: "1"},
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isPropertiesChanged(tc.oldProperties, tc.newProperties); got != tc.want {
+				t.Errorf("isPropertiesChanged() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestIsResourceUsageChanged tests the isResourceUsageChanged function.
+func TestIsResourceUsageChanged(t *testing.T) {
+	testCases := []struct {
+		name     string
+		oldUsage *clusterv1beta1.ResourceUsage
+		newUsage *clusterv1beta1.ResourceUsage
+		want     bool
+	}{
+		{
+			name: "semantically equal",
+			oldUsage: &clusterv1beta1.ResourceUsage{
+				Available: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1"),
+				},
+			},
+			newUsage: &clusterv1beta1.ResourceUsage{
+				Available: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1000m"),
+				},
+				ObservationTime: metav1.Now(),
+			},
+			want: false,
+		},
+		{
+			name: "available changed",
+			oldUsage: &clusterv1beta1.ResourceUsage{
+				Available: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("1"),
+				},
+			},
+			newUsage: &clusterv1beta1.ResourceUsage{
+				Available: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("2"),
+				},
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isResourceUsageChanged(tc.oldUsage, tc.newUsage); got != tc.want {
+				t.Errorf("isResourceUsageChanged() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
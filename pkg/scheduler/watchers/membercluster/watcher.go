@@ -10,11 +10,12 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"time"
 
-	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,6 +25,7 @@ import (
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/scheduler/clustereligibilitychecker"
+	"go.goms.io/fleet/pkg/scheduler/framework"
 	"go.goms.io/fleet/pkg/scheduler/queue"
 	"go.goms.io/fleet/pkg/utils/controller"
 )
@@ -38,6 +40,12 @@ type Reconciler struct {
 
 	// clusterEligibilityCheck helps check if a cluster is eligible for resource replacement.
 	ClusterEligibilityChecker *clustereligibilitychecker.ClusterEligibilityChecker
+
+	// RegisteredClusterEvents is the set of member cluster changes that the scheduler plugins
+	// in use are interested in; changes of other kinds are ignored by the controller.
+	//
+	// If not set, the controller considers all kinds of member cluster changes.
+	RegisteredClusterEvents sets.Set[framework.ClusterEvent]
 }
 
 // Reconcile reconciles a member cluster.
@@ -96,6 +104,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	//   - All CRPs, which have already selected this cluster, regardless of its placement type,
 	//     must deselect it, as the binding is no longer valid (dangling). CRPs of the PickN type
 	//     may further need to pick another cluster as replacement.
+	//   - CRPs of the PickFixed placement type, which target this cluster, must report that the
+	//     cluster is no longer found in their scheduling decisions.
+	//   Other CRPs are not affected; the controller finds the CRPs with bindings on the cluster via
+	//   the binding index by target cluster, so that a batch of clusters leaving the fleet does not
+	//   trigger scheduling cycles for all CRPs.
 	//
 	// This controller is set to handle cases 1a), 1b), and 2c). Note that it is only guaranteed
 	// that this controller will not emit false negatives, i.e., all the changes that require
@@ -114,8 +127,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	case errors.IsNotFound(memberClusterGetErr):
 		// On very unlikely occasions, it could happen that the member cluster is deleted
 		// before this controller gets a chance to process it, it may happen when a member cluster
-		// leaves the fleet. In such cases, this controller will request the scheduler to check
-		// all CRPs just in case.
+		// leaves the fleet. In such cases, this controller will handle the cluster as if it
+		// were leaving the fleet.
 		isMemberClusterMissing = true
 	case memberClusterGetErr != nil:
 		klog.ErrorS(memberClusterGetErr, "Failed to get member cluster", "memberCluster", memberClusterRef)
@@ -132,12 +145,24 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, controller.NewAPIServerError(true, err)
 	}
 
-	crps := crpList.Items
+	var crps []placementv1beta1.ClusterResourcePlacement
 	if !isMemberClusterMissing && memberCluster.GetDeletionTimestamp().IsZero() {
-		// If the member cluster is set to the left state, the scheduler needs to process all
-		// CRPs (case 2c)); otherwise, only CRPs of the PickAll type + CRPs of the PickN type,
-		// which have not been fully scheduled, need to be processed (case 1a) and 1b)).
+		// Only CRPs of the PickAll type + CRPs of the PickN type, which have not been fully
+		// scheduled, need to be processed (case 1a) and 1b)).
 		crps = classifyCRPs(crpList.Items)
+	} else {
+		// If the member cluster is set to the left state, the scheduler needs to process the
+		// CRPs that have selected or target the cluster (case 2c)).
+		bindingList := &placementv1beta1.ClusterResourceBindingList{}
+		if err := r.Client.List(ctx, bindingList, client.MatchingFields{controller.BindingTargetClusterIndexKey: req.Name}); err != nil {
+			klog.ErrorS(err, "Failed to list bindings on the member cluster", "memberCluster", memberClusterRef)
+			return ctrl.Result{}, controller.NewAPIServerError(true, err)
+		}
+		boundCRPNames := sets.New[string]()
+		for idx := range bindingList.Items {
+			boundCRPNames.Insert(bindingList.Items[idx].Labels[placementv1beta1.CRPTrackingLabel])
+		}
+		crps = classifyCRPsForLeavingCluster(crpList.Items, req.Name, boundCRPNames)
 	}
 
 	// Enqueue the CRPs.
@@ -153,6 +178,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 			"clusterResourcePlacement", klog.KObj(crp))
 		r.SchedulerWorkQueue.Add(queue.ClusterResourcePlacementKey(crp.Name))
 	}
+	// Also move all the placements which the scheduler has found unschedulable back to the active
+	// pool, as the cluster change might make them schedulable.
+	r.SchedulerWorkQueue.FlushUnschedulable()
 
 	// The reconciliation loop completes.
 	return ctrl.Result{}, nil
@@ -205,13 +233,15 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 			// Capture label changes.
 			//
 			// Note that the controller runs only when label changes happen on joined clusters.
-			if !reflect.DeepEqual(oldCluster.Labels, newCluster.Labels) {
+			if isClusterEventRegistered(r.RegisteredClusterEvents, framework.ClusterLabelChanged) &&
+				!reflect.DeepEqual(oldCluster.Labels, newCluster.Labels) {
 				klog.V(2).InfoS("A member cluster label change has been detected", "memberCluster", clusterKObj)
 				return true
 			}
 
 			// Capture taint update/delete changes.
-			if isTaintsUpdatedOrDeleted(oldCluster.Spec.Taints, newCluster.Spec.Taints) {
+			if isClusterEventRegistered(r.RegisteredClusterEvents, framework.ClusterTaintChanged) &&
+				isTaintsUpdatedOrDeleted(oldCluster.Spec.Taints, newCluster.Spec.Taints) {
 				klog.V(2).InfoS("A member cluster taint update/delete has been detected", "memberCluster", clusterKObj)
 				return true
 			}
@@ -219,34 +249,32 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 			// Capture non-resource property changes.
 			//
 			// Observation time refreshes is not considered as a change.
-			oldProperties := oldCluster.Status.Properties
-			newProperties := newCluster.Status.Properties
-			if len(oldProperties) != len(newProperties) {
+			if isClusterEventRegistered(r.RegisteredClusterEvents, framework.ClusterPropertyChanged) &&
+				isPropertiesChanged(oldCluster.Status.Properties, newCluster.Status.Properties) {
+				klog.V(2).InfoS("A member cluster property change has been detected", "memberCluster", clusterKObj)
 				return true
 			}
-			for oldK, oldV := range oldProperties {
-				newV, ok := newProperties[oldK]
-				if !ok || oldV.Value != newV.Value {
-					return true
-				}
-			}
 
 			// Capture resource usage changes.
-			oldCapacity := oldCluster.Status.ResourceUsage.Capacity
-			newCapacity := newCluster.Status.ResourceUsage.Capacity
-			if !equality.Semantic.DeepEqual(oldCapacity, newCapacity) {
+			if isClusterEventRegistered(r.RegisteredClusterEvents, framework.ClusterResourceUsageChanged) &&
+				isResourceUsageChanged(&oldCluster.Status.ResourceUsage, &newCluster.Status.ResourceUsage) {
+				klog.V(2).InfoS("A member cluster resource usage change has been detected", "memberCluster", clusterKObj)
 				return true
 			}
 
-			oldAllocatable := oldCluster.Status.ResourceUsage.Allocatable
-			newAllocatable := newCluster.Status.ResourceUsage.Allocatable
-			if !equality.Semantic.DeepEqual(oldAllocatable, newAllocatable) {
+			// Capture API group version changes, e.g., CRDs installed on a member cluster.
+			if isClusterEventRegistered(r.RegisteredClusterEvents, framework.ClusterAPIsChanged) &&
+				!slices.Equal(oldCluster.Status.APIGroupVersions, newCluster.Status.APIGroupVersions) {
+				klog.V(2).InfoS("A member cluster API group version change has been detected", "memberCluster", clusterKObj)
 				return true
 			}
 
-			oldAvailable := oldCluster.Status.ResourceUsage.Available
-			newAvailable := newCluster.Status.ResourceUsage.Available
-			if !equality.Semantic.DeepEqual(oldAvailable, newAvailable) {
+			// Capture the cluster being uncordoned.
+			//
+			// The reverse, i.e., a cluster being cordoned, is ignored, as it cannot help any placement get scheduled.
+			if isClusterEventRegistered(r.RegisteredClusterEvents, framework.ClusterUncordoned) &&
+				oldCluster.Spec.Cordon != nil && newCluster.Spec.Cordon == nil {
+				klog.V(2).InfoS("A member cluster has been uncordoned", "memberCluster", clusterKObj)
 				return true
 			}
 
@@ -0,0 +1,55 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package pinnedplacement features a controller that enqueues CRPs for the scheduler to process
+// where the PinnedPlacements of the CRPs have been created, updated, or deleted.
+package pinnedplacement
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	"go.goms.io/fleet/pkg/scheduler/queue"
+)
+
+// Reconciler reconciles the changes of a PinnedPlacement.
+type Reconciler struct {
+	// SchedulerWorkQueue is the workqueue in use by the scheduler.
+	SchedulerWorkQueue queue.ClusterResourcePlacementSchedulingQueueWriter
+}
+
+// Reconcile reconciles the PinnedPlacement.
+func (r *Reconciler) Reconcile(_ context.Context, req ctrl.Request) (ctrl.Result, error) {
+	pinnedPlacementRef := klog.KRef("", req.Name)
+	startTime := time.Now()
+	klog.V(2).InfoS("Scheduler source reconciliation starts", "pinnedPlacement", pinnedPlacementRef)
+	defer func() {
+		latency := time.Since(startTime).Milliseconds()
+		klog.V(2).InfoS("Scheduler source reconciliation ends", "pinnedPlacement", pinnedPlacementRef, "latency", latency)
+	}()
+
+	// A PinnedPlacement shares the name of the CRP it pins; enqueue the CRP for the scheduler to
+	// process, regardless of whether the PinnedPlacement still exists, as the removal of a
+	// PinnedPlacement unpins the CRP.
+	//
+	// Note that the scheduler ignores CRPs that do not exist.
+	r.SchedulerWorkQueue.Add(queue.ClusterResourcePlacementKey(req.Name))
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("pinned-placement-scheduler-watcher").
+		For(&placementv1alpha1.PinnedPlacement{}).
+		// Status updates are irrelevant to the scheduler.
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Complete(r)
+}
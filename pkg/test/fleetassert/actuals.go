@@ -0,0 +1,190 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package fleetassert
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+)
+
+var (
+	// CRPStatusCmpOptions are the options used when comparing the status of a CRP with the
+	// expected one. The options ignore the ordering of conditions, placement statuses, and
+	// resource identifiers, as well as the fields that are not deterministic (e.g., the last
+	// transition time and the message of a condition).
+	CRPStatusCmpOptions = cmp.Options{
+		cmpopts.SortSlices(func(a, b metav1.Condition) bool {
+			return a.Type < b.Type
+		}),
+		cmpopts.SortSlices(func(a, b placementv1beta1.ResourcePlacementStatus) bool {
+			return a.ClusterName < b.ClusterName
+		}),
+		cmpopts.SortSlices(utils.LessFuncResourceIdentifier),
+		cmpopts.SortSlices(utils.LessFuncFailedResourcePlacements),
+		utils.IgnoreConditionLTTAndMessageFields,
+		cmpopts.EquateEmpty(),
+	}
+)
+
+// CRPStatusUpdatedActual returns an actual that checks if the status of a CRP has been updated
+// to reflect that the selected resources have been rolled out to the selected clusters, and
+// that the scheduler fails to pick the unselected ones (if any).
+//
+// Note that the CRP controller will only keep decisions regarding unselected clusters for a CRP if:
+//
+//   - The CRP is of the PickN placement type and the required N count cannot be fulfilled; or
+//   - The CRP is of the PickFixed placement type and the list of target clusters specified cannot be fulfilled.
+func CRPStatusUpdatedActual(
+	ctx context.Context,
+	hubClient client.Client,
+	crpName string,
+	wantSelectedResourceIdentifiers []placementv1beta1.ResourceIdentifier,
+	wantSelectedClusters, wantUnselectedClusters []string,
+	wantObservedResourceIndex string,
+	resourceIsTrackable bool,
+) func() error {
+	return func() error {
+		crp := &placementv1beta1.ClusterResourcePlacement{}
+		if err := hubClient.Get(ctx, types.NamespacedName{Name: crpName}, crp); err != nil {
+			return err
+		}
+
+		wantPlacementStatus := []placementv1beta1.ResourcePlacementStatus{}
+		for _, name := range wantSelectedClusters {
+			wantPlacementStatus = append(wantPlacementStatus, placementv1beta1.ResourcePlacementStatus{
+				ClusterName: name,
+				Conditions:  ResourcePlacementRolloutCompletedConditions(crp.Generation, resourceIsTrackable, false),
+			})
+		}
+		for i := 0; i < len(wantUnselectedClusters); i++ {
+			wantPlacementStatus = append(wantPlacementStatus, placementv1beta1.ResourcePlacementStatus{
+				Conditions: ResourcePlacementScheduleFailedConditions(crp.Generation),
+			})
+		}
+
+		var wantCRPConditions []metav1.Condition
+		switch {
+		case len(wantUnselectedClusters) > 0 && len(wantSelectedClusters) > 0:
+			wantCRPConditions = CRPSchedulePartiallyFailedConditions(crp.Generation)
+		case len(wantUnselectedClusters) > 0:
+			// The remaining resource conditions are not set if there is no cluster to select.
+			wantCRPConditions = CRPScheduleFailedConditions(crp.Generation)
+		case len(wantSelectedClusters) > 0:
+			wantCRPConditions = CRPRolloutCompletedConditions(crp.Generation, false)
+		default:
+			// The remaining resource conditions are not set if there is no cluster to select.
+			wantCRPConditions = CRPScheduledConditions(crp.Generation)
+		}
+
+		wantStatus := placementv1beta1.ClusterResourcePlacementStatus{
+			Conditions:            wantCRPConditions,
+			PlacementStatuses:     wantPlacementStatus,
+			SelectedResources:     wantSelectedResourceIdentifiers,
+			ObservedResourceIndex: wantObservedResourceIndex,
+		}
+		if diff := cmp.Diff(crp.Status, wantStatus, CRPStatusCmpOptions...); diff != "" {
+			return fmt.Errorf("CRP status diff (-got, +want): %s", diff)
+		}
+		return nil
+	}
+}
+
+// CRPStatusWithOverrideUpdatedActual returns an actual that checks if the status of a CRP has
+// been updated to reflect that the selected resources have been rolled out, with the given
+// overrides applied, to the selected clusters.
+func CRPStatusWithOverrideUpdatedActual(
+	ctx context.Context,
+	hubClient client.Client,
+	crpName string,
+	wantSelectedResourceIdentifiers []placementv1beta1.ResourceIdentifier,
+	wantSelectedClusters []string,
+	wantObservedResourceIndex string,
+	wantClusterResourceOverrides []string,
+	wantResourceOverrides []placementv1beta1.NamespacedName,
+) func() error {
+	return func() error {
+		crp := &placementv1beta1.ClusterResourcePlacement{}
+		if err := hubClient.Get(ctx, types.NamespacedName{Name: crpName}, crp); err != nil {
+			return err
+		}
+
+		var wantPlacementStatus []placementv1beta1.ResourcePlacementStatus
+		for _, name := range wantSelectedClusters {
+			wantPlacementStatus = append(wantPlacementStatus, placementv1beta1.ResourcePlacementStatus{
+				ClusterName:                        name,
+				Conditions:                         ResourcePlacementRolloutCompletedConditions(crp.Generation, true, true),
+				ApplicableResourceOverrides:        wantResourceOverrides,
+				ApplicableClusterResourceOverrides: wantClusterResourceOverrides,
+			})
+		}
+
+		wantStatus := placementv1beta1.ClusterResourcePlacementStatus{
+			Conditions:            CRPRolloutCompletedConditions(crp.Generation, true),
+			PlacementStatuses:     wantPlacementStatus,
+			SelectedResources:     wantSelectedResourceIdentifiers,
+			ObservedResourceIndex: wantObservedResourceIndex,
+		}
+		if diff := cmp.Diff(crp.Status, wantStatus, CRPStatusCmpOptions...); diff != "" {
+			return fmt.Errorf("CRP status diff (-got, +want): %s", diff)
+		}
+		return nil
+	}
+}
+
+// CRPRemovedActual returns an actual that checks if a CRP has been removed from the hub cluster.
+func CRPRemovedActual(ctx context.Context, hubClient client.Client, crpName string) func() error {
+	return ObjectRemovedActual(ctx, hubClient, types.NamespacedName{Name: crpName}, &placementv1beta1.ClusterResourcePlacement{})
+}
+
+// ObjectPlacedActual returns an actual that checks if an object has been placed on a member
+// cluster, i.e., the object found on the member cluster matches the one in the hub cluster.
+//
+// The wantObj and the placedObj should be of the same type; placedObj is used to receive the
+// object read from the member cluster. The opts are applied when comparing the two objects,
+// which typically should ignore auto-generated metadata fields and the object status.
+func ObjectPlacedActual(
+	ctx context.Context,
+	hubClient, memberClient client.Client,
+	key types.NamespacedName,
+	wantObj, placedObj client.Object,
+	opts ...cmp.Option,
+) func() error {
+	return func() error {
+		// Use the object created in the hub cluster as reference; this helps to avoid the trouble
+		// of having to ignore default fields in the spec.
+		if err := hubClient.Get(ctx, key, wantObj); err != nil {
+			return err
+		}
+		if err := memberClient.Get(ctx, key, placedObj); err != nil {
+			return err
+		}
+
+		if diff := cmp.Diff(placedObj, wantObj, opts...); diff != "" {
+			return fmt.Errorf("placed object %s diff (-got, +want): %s", key, diff)
+		}
+		return nil
+	}
+}
+
+// ObjectRemovedActual returns an actual that checks if an object no longer exists in a cluster.
+func ObjectRemovedActual(ctx context.Context, c client.Client, key types.NamespacedName, obj client.Object) func() error {
+	return func() error {
+		if err := c.Get(ctx, key, obj); !errors.IsNotFound(err) {
+			return fmt.Errorf("object %s still exists or an unexpected error occurred: %w", key, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package fleetassert
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	crpName     = "test-crp"
+	clusterName = "bravelion"
+)
+
+func serviceScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme: %v", err)
+	}
+	return scheme
+}
+
+// TestCRPStatusUpdatedActual tests the CRPStatusUpdatedActual function.
+func TestCRPStatusUpdatedActual(t *testing.T) {
+	resourceIdentifiers := []placementv1beta1.ResourceIdentifier{
+		{
+			Kind:    "Namespace",
+			Name:    "work",
+			Version: "v1",
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		crp     *placementv1beta1.ClusterResourcePlacement
+		wantErr bool
+	}{
+		{
+			name: "status matches",
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       crpName,
+					Generation: 1,
+				},
+				Status: placementv1beta1.ClusterResourcePlacementStatus{
+					Conditions: CRPRolloutCompletedConditions(1, false),
+					PlacementStatuses: []placementv1beta1.ResourcePlacementStatus{
+						{
+							ClusterName: clusterName,
+							Conditions:  ResourcePlacementRolloutCompletedConditions(1, true, false),
+						},
+					},
+					SelectedResources:     resourceIdentifiers,
+					ObservedResourceIndex: "0",
+				},
+			},
+		},
+		{
+			name: "status is stale",
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       crpName,
+					Generation: 2,
+				},
+				Status: placementv1beta1.ClusterResourcePlacementStatus{
+					Conditions: CRPRolloutCompletedConditions(1, false),
+					PlacementStatuses: []placementv1beta1.ResourcePlacementStatus{
+						{
+							ClusterName: clusterName,
+							Conditions:  ResourcePlacementRolloutCompletedConditions(1, true, false),
+						},
+					},
+					SelectedResources:     resourceIdentifiers,
+					ObservedResourceIndex: "0",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "CRP does not exist",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(serviceScheme(t))
+			if tc.crp != nil {
+				builder = builder.WithObjects(tc.crp)
+			}
+			fakeClient := builder.Build()
+
+			actual := CRPStatusUpdatedActual(context.Background(), fakeClient, crpName, resourceIdentifiers, []string{clusterName}, nil, "0", true)
+			if err := actual(); (err != nil) != tc.wantErr {
+				t.Errorf("CRPStatusUpdatedActual() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// TestObjectRemovedActual tests the ObjectRemovedActual function.
+func TestObjectRemovedActual(t *testing.T) {
+	testCases := []struct {
+		name    string
+		objects []client.Object
+		wantErr bool
+	}{
+		{
+			name: "object exists",
+			objects: []client.Object{
+				&placementv1beta1.ClusterResourcePlacement{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: crpName,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "object has been removed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(serviceScheme(t)).WithObjects(tc.objects...).Build()
+
+			actual := CRPRemovedActual(context.Background(), fakeClient, crpName)
+			if err := actual(); (err != nil) != tc.wantErr {
+				t.Errorf("CRPRemovedActual() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package fleetassert features a set of test helpers, mostly in the form of actuals (functions
+// that return an error when the observed state does not match the expected one), that can be
+// used with polling matchers such as Gomega's Eventually and Consistently to verify the state
+// of resources in a fleet.
+//
+// The helpers are free of test framework globals (clients, contexts, parallel process indices,
+// etc.); all the inputs are passed in explicitly, so that users of the Fleet APIs can write their
+// own E2E test suites against a fleet with them.
+package fleetassert
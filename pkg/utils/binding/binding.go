@@ -6,6 +6,9 @@ Licensed under the MIT license.
 package binding
 
 import (
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils/condition"
 )
@@ -19,3 +22,67 @@ func HasBindingFailed(binding *placementv1beta1.ClusterResourceBinding) bool {
 	}
 	return false
 }
+
+// IsDisruptionBudgetMisconfigured checks if a disruption budget cannot be enforced for its CRP, i.e., MaxUnavailable, or
+// MinAvailable as a percentage, is specified for a PickAll CRP, of which the desired number of bindings is unknown.
+func IsDisruptionBudgetMisconfigured(crp *placementv1beta1.ClusterResourcePlacement, db *placementv1alpha1.ClusterResourcePlacementDisruptionBudget) bool {
+	if placementTypeOf(crp) != placementv1beta1.PickAllPlacementType {
+		return false
+	}
+	return db.Spec.MaxUnavailable != nil || (db.Spec.MinAvailable != nil && db.Spec.MinAvailable.Type == intstr.String)
+}
+
+// DisruptionsAllowed calculates how many of the given bindings of a CRP can be voluntarily disrupted (e.g., evicted,
+// or marked as unscheduled to move the placement elsewhere) at this moment per the disruption budget of the CRP,
+// along with the number of available bindings.
+//
+// The bindings should be all the bindings of the CRP, including the ones that are being removed, which count as
+// unavailable ones. No disruption is allowed if the disruption budget is misconfigured.
+func DisruptionsAllowed(bindings []placementv1beta1.ClusterResourceBinding, crp *placementv1beta1.ClusterResourcePlacement, db *placementv1alpha1.ClusterResourcePlacementDisruptionBudget) (allowed, available int) {
+	for i := range bindings {
+		availableCondition := bindings[i].GetCondition(string(placementv1beta1.ResourceBindingAvailable))
+		if condition.IsConditionStatusTrue(availableCondition, bindings[i].GetGeneration()) {
+			available++
+		}
+	}
+	if IsDisruptionBudgetMisconfigured(crp, db) {
+		return 0, available
+	}
+
+	var desiredBindings int
+	placementType := placementTypeOf(crp)
+	// we don't know the desired bindings for PickAll and we won't evict a binding for PickFixed CRP.
+	if placementType == placementv1beta1.PickNPlacementType && crp.Spec.Policy.NumberOfClusters != nil {
+		desiredBindings = int(*crp.Spec.Policy.NumberOfClusters)
+	}
+
+	switch {
+	// For PickAll CRPs, MaxUnavailable won't be specified in DB.
+	case db.Spec.MaxUnavailable != nil:
+		maxUnavailable, _ := intstr.GetScaledValueFromIntOrPercent(db.Spec.MaxUnavailable, desiredBindings, true)
+		unavailableBindings := len(bindings) - available
+		allowed = maxUnavailable - unavailableBindings
+	case db.Spec.MinAvailable != nil:
+		var minAvailable int
+		if placementType == placementv1beta1.PickAllPlacementType {
+			// MinAvailable will be an Integer value for PickAll CRP.
+			minAvailable = db.Spec.MinAvailable.IntValue()
+		} else {
+			minAvailable, _ = intstr.GetScaledValueFromIntOrPercent(db.Spec.MinAvailable, desiredBindings, true)
+		}
+		allowed = available - minAvailable
+	}
+	if allowed < 0 {
+		allowed = 0
+	}
+	return allowed, available
+}
+
+// placementTypeOf returns the placement type of a CRP; a CRP without a placement policy is of the PickAll
+// placement type.
+func placementTypeOf(crp *placementv1beta1.ClusterResourcePlacement) placementv1beta1.PlacementType {
+	if crp.Spec.Policy == nil {
+		return placementv1beta1.PickAllPlacementType
+	}
+	return crp.Spec.Policy.PlacementType
+}
@@ -0,0 +1,233 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package clusterselector provides utils to validate, normalize and match cluster selector terms,
+// which are shared by the cluster affinities of placements and the override rules of overrides.
+package clusterselector
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// Usage is where a cluster selector term is used; the fields allowed in a term vary by its usage.
+type Usage string
+
+const (
+	// RequiredAffinityUsage is the usage of a term in the RequiredDuringSchedulingIgnoredDuringExecution
+	// cluster affinity of a placement; label and property selectors are allowed.
+	RequiredAffinityUsage Usage = "RequiredDuringSchedulingIgnoredDuringExecution affinity"
+	// PreferredAffinityUsage is the usage of a term in the PreferredDuringSchedulingIgnoredDuringExecution
+	// cluster affinity of a placement; label selectors and property sorters are allowed.
+	PreferredAffinityUsage Usage = "PreferredDuringSchedulingIgnoredDuringExecution affinity"
+	// OverrideUsage is the usage of a term in the cluster selector of an override rule; label and
+	// property selectors are allowed, and a term must feature at least one of them.
+	OverrideUsage Usage = "override rule"
+)
+
+// PropertyReader reads the observed values of the properties of member clusters.
+type PropertyReader interface {
+	// Quantity returns the observed value of a property of a member cluster; it returns nil if
+	// the property is not available for the cluster.
+	Quantity(cluster *clusterv1beta1.MemberCluster, name string) (*resource.Quantity, error)
+}
+
+// Normalize returns a copy of the term in its canonical form, so that terms with the same semantics
+// look the same:
+//   - a missing label selector becomes an empty one, i.e., the term selects clusters regardless of labels,
+//     except for terms in override rules, which require a label selector if they have no property selector;
+//   - a property selector without any expressions is dropped, i.e., the term selects clusters regardless
+//     of properties.
+func Normalize(term *placementv1beta1.ClusterSelectorTerm, usage Usage) *placementv1beta1.ClusterSelectorTerm {
+	normalized := term.DeepCopy()
+	if normalized.LabelSelector == nil && usage != OverrideUsage {
+		normalized.LabelSelector = &metav1.LabelSelector{}
+	}
+	if normalized.PropertySelector != nil && len(normalized.PropertySelector.MatchExpressions) == 0 {
+		normalized.PropertySelector = nil
+	}
+	return normalized
+}
+
+// MatchesLabels checks if the labels of a member cluster match the label selector of a term.
+//
+// A term without a label selector matches all clusters, except for terms in override rules, which
+// require a label selector if they have no property selector; such (invalid) terms match no cluster.
+func MatchesLabels(term *placementv1beta1.ClusterSelectorTerm, usage Usage, cluster *clusterv1beta1.MemberCluster) (bool, error) {
+	compiled, err := Compile(term, usage)
+	if err != nil {
+		return false, err
+	}
+	return compiled.MatchesLabels(cluster), nil
+}
+
+// Matches checks if a member cluster matches both the label selector and the property selector
+// of a term in the RequiredDuringSchedulingIgnoredDuringExecution cluster affinity of a placement.
+func Matches(reader PropertyReader, term *placementv1beta1.ClusterSelectorTerm, cluster *clusterv1beta1.MemberCluster) (bool, error) {
+	compiled, err := Compile(term, RequiredAffinityUsage)
+	if err != nil {
+		return false, err
+	}
+	return compiled.Matches(reader, cluster)
+}
+
+// compiledPropertyRequirement is a property selector expression with its expected value parsed.
+type compiledPropertyRequirement struct {
+	name     string
+	operator placementv1beta1.PropertySelectorOperator
+	// expected is the parsed expected value of the expression; it is nil if the expression
+	// is invalid, in which case err explains why.
+	expected *resource.Quantity
+	err      error
+}
+
+// CompiledTerm is a cluster selector term with its label selector and property selector values
+// parsed in advance, so that it can be matched against many clusters without being parsed again
+// for each of them.
+//
+// A CompiledTerm is read-only once compiled, and is safe for concurrent use.
+type CompiledTerm struct {
+	// labelSelector is the parsed label selector of the term; it is nil if the term does not
+	// feature a label selector.
+	labelSelector labels.Selector
+	usage         Usage
+
+	propertyRequirements []compiledPropertyRequirement
+}
+
+// Compile parses the label selector and the property selector values of a term.
+//
+// An error is returned if the label selector cannot be parsed. Invalid property selector
+// expressions are not reported here; as with Matches, they are reported only when the
+// expressions are evaluated against a cluster.
+func Compile(term *placementv1beta1.ClusterSelectorTerm, usage Usage) (*CompiledTerm, error) {
+	compiled := &CompiledTerm{
+		usage: usage,
+	}
+	if term.LabelSelector != nil {
+		ls, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse label selector: %w", err)
+		}
+		compiled.labelSelector = ls
+	}
+
+	if term.PropertySelector == nil {
+		return compiled, nil
+	}
+	compiled.propertyRequirements = make([]compiledPropertyRequirement, 0, len(term.PropertySelector.MatchExpressions))
+	for _, exp := range term.PropertySelector.MatchExpressions {
+		req := compiledPropertyRequirement{
+			name:     exp.Name,
+			operator: exp.Operator,
+		}
+		// With the current set of operators, only one expected value can be specified.
+		if len(exp.Values) != 1 {
+			// The property selector expression is invalid, as there are too many expected
+			// values.
+			//
+			// Normally this should never happen.
+			req.err = fmt.Errorf("more than one value in the property selector expression")
+		} else if q, err := resource.ParseQuantity(exp.Values[0]); err != nil {
+			req.err = fmt.Errorf("value specified in property selector %s is not a valid resource quantity: %w", exp.Values[0], err)
+		} else {
+			req.expected = &q
+		}
+		compiled.propertyRequirements = append(compiled.propertyRequirements, req)
+	}
+	return compiled, nil
+}
+
+// MatchesLabels checks if the labels of a member cluster match the label selector of the
+// compiled term.
+func (c *CompiledTerm) MatchesLabels(cluster *clusterv1beta1.MemberCluster) bool {
+	if c.labelSelector == nil {
+		// A term in an override rule without a label selector selects clusters by properties only.
+		return c.usage != OverrideUsage || len(c.propertyRequirements) > 0
+	}
+	return c.labelSelector.Matches(labels.Set(cluster.Labels))
+}
+
+// Matches checks if a member cluster matches both the label selector and the property selector
+// of the compiled term; a nil reader reads the property values directly from the cluster.
+func (c *CompiledTerm) Matches(reader PropertyReader, cluster *clusterv1beta1.MemberCluster) (bool, error) {
+	// Match the cluster against the label selector.
+	if !c.MatchesLabels(cluster) {
+		// The cluster does not match with the label selector.
+		return false, nil
+	}
+
+	// Match the cluster against the property selector; a term without any property
+	// selector expressions requires no check.
+	for _, req := range c.propertyRequirements {
+		// Compare the observed value with the expected one using the specified operator.
+		var q *resource.Quantity
+		var err error
+		if reader != nil {
+			q, err = reader.Quantity(cluster, req.name)
+		} else {
+			q, err = PropertyValue(cluster, req.name)
+		}
+		if err != nil {
+			return false, err
+		}
+		if q == nil {
+			// The property is not available for the cluster.
+			return false, nil
+		}
+		if req.err != nil {
+			return false, req.err
+		}
+		expectedQ := *req.expected
+
+		switch req.operator {
+		case placementv1beta1.PropertySelectorEqualTo:
+			if !q.Equal(expectedQ) {
+				// The observed value is not equal to the expected one (equality is expected)
+				return false, nil
+			}
+		case placementv1beta1.PropertySelectorNotEqualTo:
+			if q.Equal(expectedQ) {
+				// The observed value is equal to the expected one (inequality is expected).
+				return false, nil
+			}
+		case placementv1beta1.PropertySelectorGreaterThan:
+			if q.Cmp(expectedQ) <= 0 {
+				// The observed value is less than or equal to the expected one (expected to be
+				// greater than the value).
+				return false, nil
+			}
+		case placementv1beta1.PropertySelectorGreaterThanOrEqualTo:
+			if q.Cmp(expectedQ) < 0 {
+				// The observed value is less than the expected one (expected to be greater
+				// than or equal to the value).
+				return false, nil
+			}
+		case placementv1beta1.PropertySelectorLessThan:
+			if q.Cmp(expectedQ) >= 0 {
+				// The observed value is greater than or equal to the expected one (expected to be
+				// less than the value).
+				return false, nil
+			}
+		case placementv1beta1.PropertySelectorLessThanOrEqualTo:
+			if q.Cmp(expectedQ) > 0 {
+				// The observed value is greater than the expected one (expected to be less than
+				// or equal to the value).
+				return false, nil
+			}
+		default:
+			// The operator is not recognized; normally this should never happen.
+			return false, fmt.Errorf("invalid operator: %s", req.operator)
+		}
+	}
+	// The cluster matches the property selector.
+	return true, nil
+}
@@ -0,0 +1,368 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterselector
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	regionLabel       = "region"
+	nodeCountProperty = "kubernetes-fleet.io/node-count"
+)
+
+// fakePropertyReader reads the observed property values from a map keyed by property names.
+type fakePropertyReader map[string]string
+
+func (r fakePropertyReader) Quantity(_ *clusterv1beta1.MemberCluster, name string) (*resource.Quantity, error) {
+	v, ok := r[name]
+	if !ok {
+		return nil, nil
+	}
+	q := resource.MustParse(v)
+	return &q, nil
+}
+
+// TestNormalize tests the Normalize function.
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		name  string
+		term  *placementv1beta1.ClusterSelectorTerm
+		usage Usage
+		want  *placementv1beta1.ClusterSelectorTerm
+	}{
+		{
+			name:  "missing label selector, required affinity",
+			term:  &placementv1beta1.ClusterSelectorTerm{},
+			usage: RequiredAffinityUsage,
+			want: &placementv1beta1.ClusterSelectorTerm{
+				LabelSelector: &metav1.LabelSelector{},
+			},
+		},
+		{
+			name:  "missing label selector, override",
+			term:  &placementv1beta1.ClusterSelectorTerm{},
+			usage: OverrideUsage,
+			want:  &placementv1beta1.ClusterSelectorTerm{},
+		},
+		{
+			name: "empty property selector",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{regionLabel: "east"},
+				},
+				PropertySelector: &placementv1beta1.PropertySelector{},
+			},
+			usage: RequiredAffinityUsage,
+			want: &placementv1beta1.ClusterSelectorTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{regionLabel: "east"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			original := tc.term.DeepCopy()
+			got := Normalize(tc.term, tc.usage)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Normalize() mismatch (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(original, tc.term); diff != "" {
+				t.Errorf("Normalize() modified the term (-original, +now):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestMatchesLabels tests the MatchesLabels function.
+func TestMatchesLabels(t *testing.T) {
+	cluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "member-1",
+			Labels: map[string]string{regionLabel: "east"},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		term    *placementv1beta1.ClusterSelectorTerm
+		usage   Usage
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "matched",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{regionLabel: "east"},
+				},
+			},
+			usage: OverrideUsage,
+			want:  true,
+		},
+		{
+			name: "not matched",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{regionLabel: "west"},
+				},
+			},
+			usage: RequiredAffinityUsage,
+			want:  false,
+		},
+		{
+			name:  "missing label selector, required affinity",
+			term:  &placementv1beta1.ClusterSelectorTerm{},
+			usage: RequiredAffinityUsage,
+			want:  true,
+		},
+		{
+			name:  "missing label selector, override",
+			term:  &placementv1beta1.ClusterSelectorTerm{},
+			usage: OverrideUsage,
+			want:  false,
+		},
+		{
+			name: "missing label selector with a property selector, override",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				PropertySelector: &placementv1beta1.PropertySelector{
+					MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+						{Name: nodeCountProperty, Operator: placementv1beta1.PropertySelectorLessThan, Values: []string{"5"}},
+					},
+				},
+			},
+			usage: OverrideUsage,
+			want:  true,
+		},
+		{
+			name: "invalid label selector",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: regionLabel, Operator: "Unknown"},
+					},
+				},
+			},
+			usage:   PreferredAffinityUsage,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := MatchesLabels(tc.term, tc.usage, cluster)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("MatchesLabels() = %v, want error %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("MatchesLabels() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestMatches tests the Matches function.
+func TestMatches(t *testing.T) {
+	cluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "member-1",
+			Labels: map[string]string{regionLabel: "east"},
+		},
+	}
+	reader := fakePropertyReader{nodeCountProperty: "3"}
+
+	testCases := []struct {
+		name    string
+		term    *placementv1beta1.ClusterSelectorTerm
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "label and property selectors matched",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{regionLabel: "east"},
+				},
+				PropertySelector: &placementv1beta1.PropertySelector{
+					MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+						{Name: nodeCountProperty, Operator: placementv1beta1.PropertySelectorGreaterThanOrEqualTo, Values: []string{"3"}},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "label selector not matched",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{regionLabel: "west"},
+				},
+				PropertySelector: &placementv1beta1.PropertySelector{
+					MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+						{Name: nodeCountProperty, Operator: placementv1beta1.PropertySelectorGreaterThanOrEqualTo, Values: []string{"3"}},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "property selector not matched",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				PropertySelector: &placementv1beta1.PropertySelector{
+					MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+						{Name: nodeCountProperty, Operator: placementv1beta1.PropertySelectorGreaterThan, Values: []string{"3"}},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "property not available",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				PropertySelector: &placementv1beta1.PropertySelector{
+					MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+						{Name: "unknown", Operator: placementv1beta1.PropertySelectorEqualTo, Values: []string{"1"}},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "invalid operator",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				PropertySelector: &placementv1beta1.PropertySelector{
+					MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+						{Name: nodeCountProperty, Operator: "Unknown", Values: []string{"3"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Matches(reader, tc.term, cluster)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Matches() = %v, want error %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Matches() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestCompiledTerm tests matching clusters against compiled terms.
+func TestCompiledTerm(t *testing.T) {
+	east := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "member-1",
+			Labels: map[string]string{regionLabel: "east"},
+		},
+	}
+	west := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "member-2",
+			Labels: map[string]string{regionLabel: "west"},
+		},
+	}
+	reader := fakePropertyReader{nodeCountProperty: "3"}
+
+	testCases := []struct {
+		name           string
+		term           *placementv1beta1.ClusterSelectorTerm
+		usage          Usage
+		wantCompileErr bool
+		wantEast       bool
+		wantWest       bool
+		wantMatchErr   bool
+	}{
+		{
+			name: "invalid label selector",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: regionLabel, Operator: "Unknown", Values: []string{"east"}},
+					},
+				},
+			},
+			usage:          RequiredAffinityUsage,
+			wantCompileErr: true,
+		},
+		{
+			name:     "missing label selector, override",
+			term:     &placementv1beta1.ClusterSelectorTerm{},
+			usage:    OverrideUsage,
+			wantEast: false,
+			wantWest: false,
+		},
+		{
+			name: "label and property selectors, compiled once for many clusters",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{regionLabel: "east"},
+				},
+				PropertySelector: &placementv1beta1.PropertySelector{
+					MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+						{Name: nodeCountProperty, Operator: placementv1beta1.PropertySelectorEqualTo, Values: []string{"3"}},
+					},
+				},
+			},
+			usage:    RequiredAffinityUsage,
+			wantEast: true,
+			wantWest: false,
+		},
+		{
+			name: "invalid property value, reported when evaluated",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				PropertySelector: &placementv1beta1.PropertySelector{
+					MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+						{Name: nodeCountProperty, Operator: placementv1beta1.PropertySelectorEqualTo, Values: []string{"invalid"}},
+					},
+				},
+			},
+			usage:        RequiredAffinityUsage,
+			wantMatchErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			compiled, err := Compile(tc.term, tc.usage)
+			if gotErr := err != nil; gotErr != tc.wantCompileErr {
+				t.Fatalf("Compile() = %v, want error %t", err, tc.wantCompileErr)
+			}
+			if tc.wantCompileErr {
+				return
+			}
+
+			gotEast, err := compiled.Matches(reader, east)
+			if gotErr := err != nil; gotErr != tc.wantMatchErr {
+				t.Fatalf("Matches(%s) = %v, want error %t", east.Name, err, tc.wantMatchErr)
+			}
+			if tc.wantMatchErr {
+				return
+			}
+			gotWest, err := compiled.Matches(reader, west)
+			if err != nil {
+				t.Fatalf("Matches(%s) = %v, want no error", west.Name, err)
+			}
+			if gotEast != tc.wantEast || gotWest != tc.wantWest {
+				t.Errorf("Matches() = %t, %t, want %t, %t", gotEast, gotWest, tc.wantEast, tc.wantWest)
+			}
+		})
+	}
+}
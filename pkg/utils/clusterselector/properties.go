@@ -0,0 +1,103 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterselector
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
+)
+
+// resourceUsageFrom retrieves a resource property value from a member cluster.
+//
+// Note that it will return nil if the property is not available for the cluster;
+// the zero value of resource.Quantity, i.e., resource.Quantity{}, is a valid
+// quantity.
+func resourceUsageFrom(cluster *clusterv1beta1.MemberCluster, name string) (*resource.Quantity, error) {
+	// Split the name into two segments, the capacity type, and the resource name.
+	//
+	// As a pre-defined rule, all the resource properties are assigned a label name of the format
+	// `[PREFIX]/[CAPACITY_TYPE]-[RESOURCE_NAME]`; for example, the allocatable CPU capacity of a
+	// a cluster has the label name, `resources.kubernetes-fleet.io/allocatable-cpu`. Note that at
+	// this point of process, the prefix has been removed.
+	//
+	// The resource name may include dashes (e.g., `ephemeral-storage`), and, for extended resources
+	// (e.g., `nvidia.com/gpu`), the separator that stands in for the slash.
+	cn, rn, ok := strings.Cut(name, "-")
+	if !ok || len(cn) == 0 || len(rn) == 0 {
+		return nil, fmt.Errorf("invalid resource property name: %s", name)
+	}
+	tn := propertyprovider.ResourceNameFromProperty(rn)
+
+	// Query the resource usage data.
+	var q resource.Quantity
+	var found bool
+	switch cn {
+	case propertyprovider.TotalCapacityName:
+		// The property concerns the total capacity of a resource.
+		q, found = cluster.Status.ResourceUsage.Capacity[tn]
+	case propertyprovider.AllocatableCapacityName:
+		// The property concerns the allocatable capacity of a resource.
+		q, found = cluster.Status.ResourceUsage.Allocatable[tn]
+	case propertyprovider.AvailableCapacityName:
+		// The property concerns the available capacity of a resource.
+		q, found = cluster.Status.ResourceUsage.Available[tn]
+	default:
+		// The property concerns a capacity type that cannot be recognized.
+		return nil, fmt.Errorf("invalid capacity type %s in resource property name %s", cn, name)
+	}
+
+	if !found {
+		// The property concerns a resource that is not present in the resource usage data.
+		//
+		// It could be that the resource is not available in the cluster; consequently Fleet
+		// does not consider this as an error.
+		return nil, nil
+	}
+	return &q, nil
+}
+
+// PropertyValue retrieves a property value, resource or non-resource, from a member cluster.
+//
+// Resource properties are named in the format of `[PREFIX]/[CAPACITY_TYPE]-[RESOURCE_NAME]`, e.g.,
+// `resources.kubernetes-fleet.io/allocatable-cpu`; all other names refer to the non-resource
+// properties reported in the status of the member cluster.
+//
+// Note that it will return nil if the property is not available for the cluster;
+// the zero value of resource.Quantity, i.e., resource.Quantity{}, is a valid
+// quantity.
+func PropertyValue(cluster *clusterv1beta1.MemberCluster, name string) (*resource.Quantity, error) {
+	// Check if the expression concerns a resource property.
+	var q *resource.Quantity
+	var err error
+	if strings.HasPrefix(name, propertyprovider.ResourcePropertyNamePrefix) {
+		name, _ := strings.CutPrefix(name, propertyprovider.ResourcePropertyNamePrefix)
+
+		// Retrieve the property value from the cluster resource usage data.
+		q, err = resourceUsageFrom(cluster, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve resource property value for %s from cluster %s: %w", name, cluster.Name, err)
+		}
+	} else {
+		v, found := cluster.Status.Properties[clusterv1beta1.PropertyName(name)]
+		if !found {
+			// The property is not available for the cluster.
+			//
+			// Note that this is not considered an error.
+			return nil, nil
+		}
+		qv, err := resource.ParseQuantity(v.Value)
+		if err != nil {
+			return nil, fmt.Errorf("value %s of property %s from cluster %s is not a valid quantity: %w", v.Value, name, cluster.Name, err)
+		}
+		q = &qv
+	}
+	return q, nil
+}
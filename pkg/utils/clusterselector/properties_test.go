@@ -0,0 +1,230 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterselector
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
+)
+
+const (
+	clusterName                        = "bravelion"
+	nonExistentNonResourcePropertyName = "non-existent-non-resource-property"
+	invalidNonResourcePropertyName     = "invalid-non-resource-property"
+)
+
+// TestResourceUsageFrom tests the resourceUsageFrom function.
+func TestResourceUsageFrom(t *testing.T) {
+	cluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterName,
+		},
+		Status: clusterv1beta1.MemberClusterStatus{
+			ResourceUsage: clusterv1beta1.ResourceUsage{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("10"),
+					corev1.ResourceMemory: resource.MustParse("40Gi"),
+				},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("8"),
+					corev1.ResourceMemory: resource.MustParse("36Gi"),
+				},
+				Available: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("2"),
+					corev1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		cluster        *clusterv1beta1.MemberCluster
+		propertyName   string
+		wantQuantity   *resource.Quantity
+		expectedToFail bool
+	}{
+		{
+			name:           "invalid property name (multiple segments)",
+			propertyName:   "resources.kubernetes-fleet.io/allocatable-cpu",
+			expectedToFail: true,
+		},
+		{
+			name:           "invalid property name (no capacity type)",
+			propertyName:   "-cpu",
+			expectedToFail: true,
+		},
+		{
+			name:           "invalid property name (no resource name)",
+			propertyName:   "allocatable-",
+			expectedToFail: true,
+		},
+		{
+			name:           "invalid property name (not a known capacity type)",
+			propertyName:   "additional-",
+			expectedToFail: true,
+		},
+		{
+			name:         "resource not available",
+			propertyName: "allocatable-gpu",
+			cluster:      cluster,
+		},
+		{
+			name:         "total capacity usage",
+			propertyName: "total-cpu",
+			cluster:      cluster,
+			wantQuantity: ptr.To(resource.MustParse("10")),
+		},
+		{
+			name:         "allocatable capacity usage",
+			propertyName: "allocatable-memory",
+			cluster:      cluster,
+			wantQuantity: ptr.To(resource.MustParse("36Gi")),
+		},
+		{
+			name:         "available capacity usage",
+			propertyName: "available-cpu",
+			cluster:      cluster,
+			wantQuantity: ptr.To(resource.MustParse("2")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := resourceUsageFrom(tc.cluster, tc.propertyName)
+			if tc.expectedToFail {
+				if err == nil {
+					t.Errorf("resourceUsageFrom(), want error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("resourceUsageFrom() = %v, want nil", err)
+			}
+			if diff := cmp.Diff(q, tc.wantQuantity); diff != "" {
+				t.Errorf("resourceUsageFrom() quantity diff (-got, +want): %s\n", diff)
+			}
+		})
+	}
+}
+
+// TestPropertyValue tests the PropertyValue function.
+func TestPropertyValue(t *testing.T) {
+	cluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterName,
+		},
+		Status: clusterv1beta1.MemberClusterStatus{
+			ResourceUsage: clusterv1beta1.ResourceUsage{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("10"),
+					corev1.ResourceMemory: resource.MustParse("40Gi"),
+				},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("8"),
+					corev1.ResourceMemory: resource.MustParse("36Gi"),
+				},
+				Available: corev1.ResourceList{
+					corev1.ResourceCPU:              resource.MustParse("2"),
+					corev1.ResourceMemory:           resource.MustParse("4Gi"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("100Gi"),
+					"nvidia.com/gpu":                resource.MustParse("3"),
+				},
+			},
+			Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+				propertyprovider.NodeCountProperty: {
+					Value: "4",
+				},
+				invalidNonResourcePropertyName: {
+					Value: "invalid",
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		cluster        *clusterv1beta1.MemberCluster
+		propertyName   string
+		wantQuantity   *resource.Quantity
+		expectedToFail bool
+	}{
+		{
+			name:           "invalid resource property (name format error)",
+			propertyName:   "resources.kubernetes-fleet.io/allocatable",
+			cluster:        cluster,
+			expectedToFail: true,
+		},
+		{
+			name:         "resource property retrieval",
+			propertyName: propertyprovider.AvailableMemoryCapacityProperty,
+			cluster:      cluster,
+			wantQuantity: ptr.To(resource.MustParse("4Gi")),
+		},
+		{
+			name:         "resource property retrieval (resource name with dashes)",
+			propertyName: propertyprovider.ResourcePropertyName(propertyprovider.AvailableCapacityName, corev1.ResourceEphemeralStorage),
+			cluster:      cluster,
+			wantQuantity: ptr.To(resource.MustParse("100Gi")),
+		},
+		{
+			name:         "extended resource property retrieval",
+			propertyName: propertyprovider.AvailableGPUCapacityProperty,
+			cluster:      cluster,
+			wantQuantity: ptr.To(resource.MustParse("3")),
+		},
+		{
+			name:         "absent extended resource property",
+			propertyName: propertyprovider.TotalGPUCapacityProperty,
+			cluster:      cluster,
+		},
+		{
+			name:         "absent non-resource property",
+			propertyName: nonExistentNonResourcePropertyName,
+			cluster:      cluster,
+		},
+		{
+			name:           "invalid non-resource property (value format error)",
+			propertyName:   invalidNonResourcePropertyName,
+			cluster:        cluster,
+			expectedToFail: true,
+		},
+		{
+			name:         "non-resource property retrieval",
+			propertyName: propertyprovider.NodeCountProperty,
+			wantQuantity: ptr.To(resource.MustParse("4")),
+			cluster:      cluster,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := PropertyValue(tc.cluster, tc.propertyName)
+			if tc.expectedToFail {
+				if err == nil {
+					t.Errorf("PropertyValue(), want error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("PropertyValue() = %v, want nil", err)
+			}
+			if diff := cmp.Diff(q, tc.wantQuantity); diff != "" {
+				t.Errorf("PropertyValue() quantity diff (-got, +want): %s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterselector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiErrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
+)
+
+var (
+	// Below is the map of supported capacity types.
+	supportedResourceCapacityTypesMap = map[string]bool{propertyprovider.AllocatableCapacityName: true, propertyprovider.AvailableCapacityName: true, propertyprovider.TotalCapacityName: true}
+	resourceCapacityTypes             = supportedResourceCapacityTypes()
+)
+
+// Validate checks if a cluster selector term is valid for its usage.
+func Validate(term *placementv1beta1.ClusterSelectorTerm, usage Usage) error {
+	allErr := make([]error, 0)
+	switch usage {
+	case RequiredAffinityUsage:
+		// Since label selector is a required field in ClusterSelectorTerm, not checking to see if it's an empty object.
+		allErr = append(allErr, ValidateLabelSelector(term.LabelSelector, "cluster selector"))
+
+		// Affinity is RequiredDuringSchedulingIgnoredDuringExecution, so check that PropertySorter is nil.
+		if term.PropertySorter != nil {
+			allErr = append(allErr, fmt.Errorf("PropertySorter is not allowed for %s", usage))
+		}
+
+		// Affinity is RequiredDuringSchedulingIgnoredDuringExecution, so validate PropertySelector if exists
+		if term.PropertySelector != nil {
+			allErr = append(allErr, validatePropertySelector(term.PropertySelector))
+		}
+	case PreferredAffinityUsage:
+		allErr = append(allErr, ValidateLabelSelector(term.LabelSelector, "preferred cluster selector"))
+
+		// Affinity is PreferredDuringSchedulingIgnoredDuringExecution, so check that PropertySelector is nil.
+		if term.PropertySelector != nil {
+			allErr = append(allErr, fmt.Errorf("PropertySelector is not allowed for %s", usage))
+		}
+
+		if term.PropertySorter != nil {
+			allErr = append(allErr, validatePropertySorter(term.PropertySorter))
+		}
+	case OverrideUsage:
+		// Override rules select clusters without ranking them, so property sorters make no sense.
+		if term.PropertySorter != nil {
+			return fmt.Errorf("invalid clusterSelector %v: propertySorter is not supported", *term)
+		}
+		normalized := Normalize(term, usage)
+		if normalized.LabelSelector == nil && normalized.PropertySelector == nil {
+			return fmt.Errorf("invalid clusterSelector %v: labelSelector is required when there is no propertySelector", *term)
+		}
+		if normalized.LabelSelector != nil {
+			allErr = append(allErr, ValidateLabelSelector(normalized.LabelSelector, "cluster selector"))
+		}
+		if normalized.PropertySelector != nil {
+			allErr = append(allErr, validatePropertySelector(normalized.PropertySelector))
+		}
+	default:
+		allErr = append(allErr, fmt.Errorf("unknown cluster selector usage %q", usage))
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+// ValidateLabelSelector checks if a label selector can be converted to a selector.
+func ValidateLabelSelector(labelSelector *metav1.LabelSelector, parent string) error {
+	if _, err := metav1.LabelSelectorAsSelector(labelSelector); err != nil {
+		return fmt.Errorf("the labelSelector in %s %+v is invalid: %w", parent, labelSelector, err)
+	}
+	return nil
+}
+
+// validatePropertySelector validates the property selector
+func validatePropertySelector(propertySelector *placementv1beta1.PropertySelector) error {
+	return validatePropertySelectorRequirements(propertySelector.MatchExpressions)
+}
+
+func validatePropertySelectorRequirements(propertySelectorRequirements []placementv1beta1.PropertySelectorRequirement) error {
+	var allErr []error
+	for _, req := range propertySelectorRequirements {
+		if err := validateName(req.Name); err != nil {
+			allErr = append(allErr, fmt.Errorf("invalid property name %s: %w", req.Name, err))
+		}
+		if err := validateOperator(req.Operator, req.Values); err != nil {
+			allErr = append(allErr, err)
+		}
+		if err := validateValues(req.Values); err != nil {
+			allErr = append(allErr, fmt.Errorf("invalid values for property %s: %w", req.Name, err))
+		}
+		// TODO: Check for logical contradictions
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+func validatePropertySorter(propertySorter *placementv1beta1.PropertySorter) error {
+	var allErr []error
+	if err := validateName(propertySorter.Name); err != nil {
+		allErr = append(allErr, err)
+	}
+	if propertySorter.SortOrder != placementv1beta1.Descending && propertySorter.SortOrder != placementv1beta1.Ascending {
+		allErr = append(allErr, fmt.Errorf("invalid property sort order %s", propertySorter.SortOrder))
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+func validateName(name string) error {
+	// we expect the resource property names to be in this format `[PREFIX]/[CAPACITY_TYPE]-[RESOURCE_NAME]`.
+	if strings.HasPrefix(name, propertyprovider.ResourcePropertyNamePrefix) {
+		resourcePropertyName, _ := strings.CutPrefix(name, propertyprovider.ResourcePropertyNamePrefix)
+		// n=2 since we only care about the first segment to check capacity type.
+		segments := strings.SplitN(resourcePropertyName, "-", 2)
+		if len(segments) != 2 {
+			return fmt.Errorf("invalid resource property name %s, expected format is [PREFIX]/[CAPACITY_TYPE]-[RESOURCE_NAME]", name)
+		}
+		if !supportedResourceCapacityTypesMap[segments[0]] {
+			return fmt.Errorf("invalid capacity type in resource property name %s, supported values are %+v", name, resourceCapacityTypes)
+		}
+	}
+
+	if err := validation.IsQualifiedName(name); err != nil {
+		return fmt.Errorf("name is not a valid Kubernetes label name: %v", err)
+	}
+	return nil
+}
+
+func validateOperator(op placementv1beta1.PropertySelectorOperator, values []string) error {
+	// TODO: Restructure for Eq (bundle operator and value validation logic)
+	validOperators := map[placementv1beta1.PropertySelectorOperator]bool{
+		placementv1beta1.PropertySelectorGreaterThan:          true,
+		placementv1beta1.PropertySelectorGreaterThanOrEqualTo: true,
+		placementv1beta1.PropertySelectorLessThan:             true,
+		placementv1beta1.PropertySelectorLessThanOrEqualTo:    true,
+		placementv1beta1.PropertySelectorEqualTo:              true,
+		placementv1beta1.PropertySelectorNotEqualTo:           true,
+	}
+	if validOperators[op] && len(values) != 1 {
+		return fmt.Errorf("operator %s requires exactly one value, got %d", op, len(values))
+	}
+	return nil
+}
+
+func validateValues(values []string) error {
+	for _, value := range values {
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return fmt.Errorf("value %s is not a valid resource.Quantity: %w", value, err)
+		}
+	}
+	return nil
+}
+
+func supportedResourceCapacityTypes() []string {
+	i := 0
+	capacityTypes := make([]string, len(supportedResourceCapacityTypesMap))
+	for key := range supportedResourceCapacityTypesMap {
+		capacityTypes[i] = key
+		i++
+	}
+	sort.Strings(capacityTypes)
+	return capacityTypes
+}
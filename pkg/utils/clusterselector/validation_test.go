@@ -0,0 +1,151 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterselector
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// TestValidate tests the Validate function.
+func TestValidate(t *testing.T) {
+	labelSelector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{regionLabel: "east"},
+	}
+	propertySelector := &placementv1beta1.PropertySelector{
+		MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+			{Name: nodeCountProperty, Operator: placementv1beta1.PropertySelectorGreaterThan, Values: []string{"3"}},
+		},
+	}
+	propertySorter := &placementv1beta1.PropertySorter{
+		Name:      nodeCountProperty,
+		SortOrder: placementv1beta1.Descending,
+	}
+
+	testCases := []struct {
+		name          string
+		term          *placementv1beta1.ClusterSelectorTerm
+		usage         Usage
+		wantErrString string
+	}{
+		{
+			name:  "valid required affinity term",
+			term:  &placementv1beta1.ClusterSelectorTerm{LabelSelector: labelSelector, PropertySelector: propertySelector},
+			usage: RequiredAffinityUsage,
+		},
+		{
+			name:          "property sorter in required affinity term",
+			term:          &placementv1beta1.ClusterSelectorTerm{PropertySorter: propertySorter},
+			usage:         RequiredAffinityUsage,
+			wantErrString: "PropertySorter is not allowed for RequiredDuringSchedulingIgnoredDuringExecution affinity",
+		},
+		{
+			name: "invalid property selector in required affinity term",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				PropertySelector: &placementv1beta1.PropertySelector{
+					MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+						{Name: nodeCountProperty, Operator: placementv1beta1.PropertySelectorGreaterThan, Values: []string{"3", "4"}},
+					},
+				},
+			},
+			usage:         RequiredAffinityUsage,
+			wantErrString: "operator Gt requires exactly one value, got 2",
+		},
+		{
+			name:  "valid preferred affinity term",
+			term:  &placementv1beta1.ClusterSelectorTerm{LabelSelector: labelSelector, PropertySorter: propertySorter},
+			usage: PreferredAffinityUsage,
+		},
+		{
+			name:          "property selector in preferred affinity term",
+			term:          &placementv1beta1.ClusterSelectorTerm{PropertySelector: propertySelector},
+			usage:         PreferredAffinityUsage,
+			wantErrString: "PropertySelector is not allowed for PreferredDuringSchedulingIgnoredDuringExecution affinity",
+		},
+		{
+			name:  "valid override term",
+			term:  &placementv1beta1.ClusterSelectorTerm{LabelSelector: labelSelector},
+			usage: OverrideUsage,
+		},
+		{
+			name:  "property selector in override term",
+			term:  &placementv1beta1.ClusterSelectorTerm{LabelSelector: labelSelector, PropertySelector: propertySelector},
+			usage: OverrideUsage,
+		},
+		{
+			name:  "property selector only in override term",
+			term:  &placementv1beta1.ClusterSelectorTerm{PropertySelector: propertySelector},
+			usage: OverrideUsage,
+		},
+		{
+			name:          "property sorter in override term",
+			term:          &placementv1beta1.ClusterSelectorTerm{LabelSelector: labelSelector, PropertySorter: propertySorter},
+			usage:         OverrideUsage,
+			wantErrString: "propertySorter is not supported",
+		},
+		{
+			name:          "empty property selector only in override term",
+			term:          &placementv1beta1.ClusterSelectorTerm{PropertySelector: &placementv1beta1.PropertySelector{}},
+			usage:         OverrideUsage,
+			wantErrString: "labelSelector is required",
+		},
+		{
+			name: "invalid property selector in override term",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				PropertySelector: &placementv1beta1.PropertySelector{
+					MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+						{Name: nodeCountProperty, Operator: placementv1beta1.PropertySelectorLessThan, Values: []string{"five"}},
+					},
+				},
+			},
+			usage:         OverrideUsage,
+			wantErrString: "invalid values for property",
+		},
+		{
+			name:          "missing label selector in override term",
+			term:          &placementv1beta1.ClusterSelectorTerm{},
+			usage:         OverrideUsage,
+			wantErrString: "labelSelector is required",
+		},
+		{
+			name: "invalid label selector",
+			term: &placementv1beta1.ClusterSelectorTerm{
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: regionLabel, Operator: "Unknown"},
+					},
+				},
+			},
+			usage:         OverrideUsage,
+			wantErrString: "the labelSelector in cluster selector",
+		},
+		{
+			name:          "unknown usage",
+			term:          &placementv1beta1.ClusterSelectorTerm{LabelSelector: labelSelector},
+			usage:         "unknown",
+			wantErrString: `unknown cluster selector usage "unknown"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.term, tc.usage)
+			if tc.wantErrString == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErrString) {
+				t.Fatalf("Validate() = %v, want error containing %q", err, tc.wantErrString)
+			}
+		})
+	}
+}
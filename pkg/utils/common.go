@@ -102,15 +102,33 @@ var (
 		APIGroups: []string{fleetv1alpha1.GroupVersion.Group},
 		Resources: []string{"*"},
 	}
-	FleetClusterRule = rbacv1.PolicyRule{
-		Verbs:     []string{"*"},
+	// InternalMemberClusterRule allows a member agent to read the InternalMemberCluster object
+	// in its reserved namespace.
+	InternalMemberClusterRule = rbacv1.PolicyRule{
+		Verbs:     []string{"get", "list", "watch"},
 		APIGroups: []string{clusterv1beta1.GroupVersion.Group},
-		Resources: []string{"*"},
+		Resources: []string{"internalmemberclusters"},
 	}
-	FleetPlacementRule = rbacv1.PolicyRule{
-		Verbs:     []string{"*"},
+	// InternalMemberClusterStatusRule allows a member agent to report its status via the
+	// InternalMemberCluster object in its reserved namespace.
+	InternalMemberClusterStatusRule = rbacv1.PolicyRule{
+		Verbs:     []string{"get", "update", "patch"},
+		APIGroups: []string{clusterv1beta1.GroupVersion.Group},
+		Resources: []string{"internalmemberclusters/status"},
+	}
+	// PlacementWorkRule allows a member agent to consume (and add finalizers to) the Works
+	// in its reserved namespace; it cannot create or delete any Work.
+	PlacementWorkRule = rbacv1.PolicyRule{
+		Verbs:     []string{"get", "list", "watch", "update", "patch"},
 		APIGroups: []string{placementv1beta1.GroupVersion.Group},
-		Resources: []string{"*"},
+		Resources: []string{"works"},
+	}
+	// PlacementWorkStatusRule allows a member agent to report the apply results via the
+	// Works in its reserved namespace.
+	PlacementWorkStatusRule = rbacv1.PolicyRule{
+		Verbs:     []string{"get", "update", "patch"},
+		APIGroups: []string{placementv1beta1.GroupVersion.Group},
+		Resources: []string{"works/status"},
 	}
 	EventRule = rbacv1.PolicyRule{
 		Verbs:     []string{"get", "list", "update", "patch", "watch", "create"},
@@ -491,6 +509,12 @@ func IsReservedNamespace(namespace string) bool {
 	return strings.HasPrefix(namespace, fleetPrefix) || strings.HasPrefix(namespace, kubePrefix)
 }
 
+// IsCriticalResource indicates if resources of an argued group and kind are critical, i.e., namespaces
+// and CustomResourceDefinitions, whose removal also removes the resources within or of them.
+func IsCriticalResource(group, kind string) bool {
+	return (group == NamespaceGVK.Group && kind == NamespaceGVK.Kind) || (group == CRDMetaGVK.Group && kind == CRDMetaGVK.Kind)
+}
+
 // ShouldPropagateNamespace decides if we should propagate the resources in the namespace.
 func ShouldPropagateNamespace(namespace string, skippedNamespaces map[string]bool) bool {
 	if IsReservedNamespace(namespace) {
@@ -569,6 +593,10 @@ func IsFailedResourcePlacementsEqual(oldFailedResourcePlacements, newFailedResou
 		if !condition.EqualCondition(&oldFailedResourcePlacement.Condition, &newFailedResourcePlacement.Condition) {
 			return false
 		}
+		if oldFailedResourcePlacement.ApplyFailureCount != newFailedResourcePlacement.ApplyFailureCount ||
+			oldFailedResourcePlacement.ApplyErrorCategory != newFailedResourcePlacement.ApplyErrorCategory {
+			return false
+		}
 	}
 	return true
 }
@@ -610,6 +610,52 @@ func TestIsFailedResourcePlacementsEqual(t *testing.T) {
 			new:  []fleetv1beta1.FailedResourcePlacement{},
 			want: false,
 		},
+		{
+			name: "compare two failed resource placements with different apply failure counts",
+			old: []fleetv1beta1.FailedResourcePlacement{
+				{
+					ResourceIdentifier: fleetv1beta1.ResourceIdentifier{
+						Group:     "apps",
+						Version:   "v1",
+						Kind:      "Deployment",
+						Name:      "deployment1",
+						Namespace: "default",
+					},
+					Condition: metav1.Condition{
+						Type:               fleetv1beta1.WorkConditionTypeApplied,
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: 1,
+						LastTransitionTime: time1,
+						Reason:             "ManifestApplyFailed",
+						Message:            "message1",
+					},
+					ApplyFailureCount:  1,
+					ApplyErrorCategory: fleetv1beta1.ApplyErrorCategoryRBAC,
+				},
+			},
+			new: []fleetv1beta1.FailedResourcePlacement{
+				{
+					ResourceIdentifier: fleetv1beta1.ResourceIdentifier{
+						Group:     "apps",
+						Version:   "v1",
+						Kind:      "Deployment",
+						Name:      "deployment1",
+						Namespace: "default",
+					},
+					Condition: metav1.Condition{
+						Type:               fleetv1beta1.WorkConditionTypeApplied,
+						Status:             metav1.ConditionFalse,
+						ObservedGeneration: 1,
+						LastTransitionTime: time1,
+						Reason:             "ManifestApplyFailed",
+						Message:            "message1",
+					},
+					ApplyFailureCount:  2,
+					ApplyErrorCategory: fleetv1beta1.ApplyErrorCategoryRBAC,
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -9,6 +9,7 @@ package condition
 import (
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
@@ -23,6 +24,18 @@ const (
 	// RolloutNotStartedYetReason is the reason string of placement condition if the rollout has not started yet.
 	RolloutNotStartedYetReason = "RolloutNotStartedYet"
 
+	// RolloutBlockedByMaxUnavailableReason is the reason string of binding condition if the rollout has not started
+	// yet because updating the binding might leave more clusters unavailable than the rollout strategy allows.
+	RolloutBlockedByMaxUnavailableReason = "RolloutBlockedByMaxUnavailable"
+
+	// RolloutBlockedByMaxSurgeReason is the reason string of binding condition if the rollout has not started yet
+	// because binding the cluster might place the resources on more clusters than the rollout strategy allows.
+	RolloutBlockedByMaxSurgeReason = "RolloutBlockedByMaxSurge"
+
+	// RolloutBlockedByRolloutGroupReason is the reason string of binding condition if the rollout has not started
+	// yet because the rollout group of the binding waits for an earlier group to finish rolling out.
+	RolloutBlockedByRolloutGroupReason = "RolloutBlockedByRolloutGroup"
+
 	// RolloutStartedReason is the reason string of placement condition if rollout status is started.
 	RolloutStartedReason = "RolloutStarted"
 
@@ -68,6 +81,10 @@ const (
 
 	// AvailableReason is the reason string of placement condition if the selected resources are available.
 	AvailableReason = "ResourceAvailable"
+
+	// WorkCleanupInProgressReason is the reason string of binding condition when the binding is being deleted
+	// but not all of its works have been removed from the target cluster yet.
+	WorkCleanupInProgressReason = "WorkCleanupInProgress"
 )
 
 // A group of condition reason string which is used to populate the placement condition per cluster.
@@ -81,6 +98,10 @@ const (
 	// SyncWorkFailedReason is the reason string of placement condition if some works failed to synchronize.
 	SyncWorkFailedReason = "SyncWorkFailed"
 
+	// SyncWorkPendingReason is the reason string of placement condition if the works of the resource snapshots
+	// created so far are synchronized, but some resource snapshots are still being created.
+	SyncWorkPendingReason = "SyncWorkPending"
+
 	// WorkNeedSyncedReason is the reason string of placement condition if some works are in the processing of synchronizing.
 	WorkNeedSyncedReason = "StillNeedToSyncWork"
 
@@ -239,6 +260,21 @@ func IsConditionStatusFalse(cond *metav1.Condition, latestGeneration int64) bool
 	return cond != nil && cond.Status == metav1.ConditionFalse && cond.ObservedGeneration == latestGeneration
 }
 
+// IsTransitioned returns true if setting the desired condition changes the status of the current one, i.e., the
+// current condition is not set yet or has a different status; it is used to emit events and metrics only when a
+// condition transitions.
+func IsTransitioned(current, desired *metav1.Condition) bool {
+	return current == nil || current.Status != desired.Status
+}
+
+// SetConditionWithGeneration sets the condition with its observed generation reset to the generation of the object
+// which owns the conditions, and returns true if the conditions are changed. It is used when copying the conditions
+// reported by other components (e.g., the property providers), which are not aware of the generation of the object.
+func SetConditionWithGeneration(conditions *[]metav1.Condition, cond metav1.Condition, generation int64) bool {
+	cond.ObservedGeneration = generation
+	return meta.SetStatusCondition(conditions, cond)
+}
+
 // ResourceCondition is all the resource related condition, for example, scheduled condition is not included.
 type ResourceCondition int
 
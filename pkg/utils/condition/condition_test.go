@@ -328,3 +328,146 @@ func TestIsConditionStatusFalse(t *testing.T) {
 		})
 	}
 }
+
+func TestIsTransitioned(t *testing.T) {
+	tests := map[string]struct {
+		current *metav1.Condition
+		desired *metav1.Condition
+		want    bool
+	}{
+		"nil current condition is considered transitioned": {
+			current: nil,
+			desired: &metav1.Condition{
+				Type:   conditionType,
+				Status: metav1.ConditionTrue,
+			},
+			want: true,
+		},
+		"status change is considered transitioned": {
+			current: &metav1.Condition{
+				Type:   conditionType,
+				Status: metav1.ConditionUnknown,
+			},
+			desired: &metav1.Condition{
+				Type:   conditionType,
+				Status: metav1.ConditionTrue,
+			},
+			want: true,
+		},
+		"reason and generation changes are not considered transitioned": {
+			current: &metav1.Condition{
+				Type:               conditionType,
+				Status:             metav1.ConditionTrue,
+				Reason:             reason,
+				ObservedGeneration: 1,
+			},
+			desired: &metav1.Condition{
+				Type:               conditionType,
+				Status:             metav1.ConditionTrue,
+				Reason:             altReason,
+				ObservedGeneration: 2,
+			},
+			want: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsTransitioned(tt.current, tt.desired); got != tt.want {
+				t.Errorf("IsTransitioned() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetConditionWithGeneration(t *testing.T) {
+	now := metav1.Now()
+	tests := map[string]struct {
+		conditions []metav1.Condition
+		cond       metav1.Condition
+		want       []metav1.Condition
+		wantChange bool
+	}{
+		"new condition is stamped with the generation": {
+			cond: metav1.Condition{
+				Type:               conditionType,
+				Status:             metav1.ConditionTrue,
+				Reason:             reason,
+				LastTransitionTime: now,
+			},
+			want: []metav1.Condition{
+				{
+					Type:               conditionType,
+					Status:             metav1.ConditionTrue,
+					Reason:             reason,
+					LastTransitionTime: now,
+					ObservedGeneration: 2,
+				},
+			},
+			wantChange: true,
+		},
+		"existing condition observed at an older generation is updated": {
+			conditions: []metav1.Condition{
+				{
+					Type:               conditionType,
+					Status:             metav1.ConditionTrue,
+					Reason:             reason,
+					LastTransitionTime: now,
+					ObservedGeneration: 1,
+				},
+			},
+			cond: metav1.Condition{
+				Type:               conditionType,
+				Status:             metav1.ConditionTrue,
+				Reason:             reason,
+				ObservedGeneration: 5,
+			},
+			want: []metav1.Condition{
+				{
+					Type:               conditionType,
+					Status:             metav1.ConditionTrue,
+					Reason:             reason,
+					LastTransitionTime: now,
+					ObservedGeneration: 2,
+				},
+			},
+			wantChange: true,
+		},
+		"existing condition observed at the generation is unchanged": {
+			conditions: []metav1.Condition{
+				{
+					Type:               conditionType,
+					Status:             metav1.ConditionTrue,
+					Reason:             reason,
+					LastTransitionTime: now,
+					ObservedGeneration: 2,
+				},
+			},
+			cond: metav1.Condition{
+				Type:   conditionType,
+				Status: metav1.ConditionTrue,
+				Reason: reason,
+			},
+			want: []metav1.Condition{
+				{
+					Type:               conditionType,
+					Status:             metav1.ConditionTrue,
+					Reason:             reason,
+					LastTransitionTime: now,
+					ObservedGeneration: 2,
+				},
+			},
+			wantChange: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			conditions := tt.conditions
+			if got := SetConditionWithGeneration(&conditions, tt.cond, 2); got != tt.wantChange {
+				t.Errorf("SetConditionWithGeneration() = %v, want %v", got, tt.wantChange)
+			}
+			if diff := cmp.Diff(tt.want, conditions); diff != "" {
+				t.Errorf("SetConditionWithGeneration() conditions mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package controller
+
+import (
+	"regexp"
+	"strings"
+)
+
+// admissionWebhookDenialRegexp matches the error message that the Kubernetes API server returns when
+// an admission webhook denies a request, i.e., `admission webhook "<name>" denied the request[: <message>]`.
+var admissionWebhookDenialRegexp = regexp.MustCompile(`admission webhook "([^"]+)" denied the request(?::\s*(.*))?`)
+
+// ParseAdmissionWebhookDenial checks if the error is returned by the API server as an admission webhook
+// has denied the request; if so, it returns the name of the webhook and the message from the webhook.
+func ParseAdmissionWebhookDenial(err error) (webhookName, message string, denied bool) {
+	if err == nil {
+		return "", "", false
+	}
+	matches := admissionWebhookDenialRegexp.FindStringSubmatch(err.Error())
+	if matches == nil {
+		return "", "", false
+	}
+	return matches[1], strings.TrimSpace(matches[2]), true
+}
@@ -0,0 +1,53 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// TestParseAdmissionWebhookDenial tests the ParseAdmissionWebhookDenial function.
+func TestParseAdmissionWebhookDenial(t *testing.T) {
+	tests := map[string]struct {
+		err             error
+		wantWebhookName string
+		wantMessage     string
+		wantDenied      bool
+	}{
+		"nil error": {
+			err: nil,
+		},
+		"other API server error": {
+			err: apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "app", errors.New("conflict")),
+		},
+		"denied with a message": {
+			err: apierrors.NewForbidden(schema.GroupResource{Resource: "deployments"}, "app",
+				errors.New(`admission webhook "validation.gatekeeper.sh" denied the request: [required-labels] you must provide labels: {"owner"}`)),
+			wantWebhookName: "validation.gatekeeper.sh",
+			wantMessage:     `[required-labels] you must provide labels: {"owner"}`,
+			wantDenied:      true,
+		},
+		"denied without a message": {
+			err:             fmt.Errorf("failed to apply: %w", errors.New(`admission webhook "policy.example.com" denied the request`)),
+			wantWebhookName: "policy.example.com",
+			wantDenied:      true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotWebhookName, gotMessage, gotDenied := ParseAdmissionWebhookDenial(tc.err)
+			if gotWebhookName != tc.wantWebhookName || gotMessage != tc.wantMessage || gotDenied != tc.wantDenied {
+				t.Errorf("ParseAdmissionWebhookDenial() = (%q, %q, %v), want (%q, %q, %v)",
+					gotWebhookName, gotMessage, gotDenied, tc.wantWebhookName, tc.wantMessage, tc.wantDenied)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils/controller/metrics"
+	"go.goms.io/fleet/pkg/utils/healthcheck"
 	"go.goms.io/fleet/pkg/utils/keys"
 	"go.goms.io/fleet/pkg/utils/labels"
 )
@@ -152,7 +153,10 @@ type KeyFunc func(obj interface{}) (QueueKey, error)
 // ReconcileFunc knows how to consume items(key) from the queue.
 type ReconcileFunc func(ctx context.Context, key QueueKey) (reconcile.Result, error)
 
-var _ Controller = &controller{}
+var (
+	_ Controller             = &controller{}
+	_ healthcheck.QueueProbe = &controller{}
+)
 
 // controller implements Controller interface
 type controller struct {
@@ -167,6 +171,9 @@ type controller struct {
 
 	// queue allowing parallel processing of resources.
 	queue workqueue.RateLimitingInterface
+
+	// activity tracks the activity of the queue for health checking.
+	healthcheck.QueueActivity
 }
 
 // NewController returns a controller which can process resource periodically. We create the queue during the creation
@@ -200,6 +207,7 @@ func (w *controller) Run(ctx context.Context, workerNumber int) error {
 	klog.InfoS("Starting controller", "controller", w.name)
 
 	w.initMetrics(workerNumber)
+	w.MarkStarted()
 
 	// Ensure all goroutines are cleaned up when the context closes
 	go func() {
@@ -233,6 +241,7 @@ func (w *controller) processNextWorkItem(ctx context.Context) bool {
 		// Stop working
 		return false
 	}
+	w.MarkDequeued()
 
 	// Done marks item as done processing, and if it has been marked as dirty again
 	// while it was being processed, it will be re-added to the queue for
@@ -282,6 +291,11 @@ func (w *controller) reconcileHandler(ctx context.Context, key interface{}) {
 	}
 }
 
+// Len returns the number of items ready for processing in the queue.
+func (w *controller) Len() int {
+	return w.queue.Len()
+}
+
 func (w *controller) initMetrics(workerNumber int) {
 	metrics.FleetActiveWorkers.WithLabelValues(w.name).Set(0)
 	metrics.FleetReconcileErrors.WithLabelValues(w.name).Add(0)
@@ -308,6 +322,27 @@ var (
 
 // FetchAllClusterResourceSnapshots fetches the group of clusterResourceSnapshots using master clusterResourceSnapshot.
 func FetchAllClusterResourceSnapshots(ctx context.Context, k8Client client.Client, crp string, masterResourceSnapshot *fleetv1beta1.ClusterResourceSnapshot) (map[string]*fleetv1beta1.ClusterResourceSnapshot, error) {
+	resourceSnapshots, snapshotCount, err := FetchCreatedClusterResourceSnapshots(ctx, k8Client, crp, masterResourceSnapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	// check if all the resource snapshots are created since that may take a while but the rollout controller may update the resource binding on master snapshot creation
+	if len(resourceSnapshots) != snapshotCount {
+		misMatchErr := fmt.Errorf("%w: resource snapshots are still being created for the masterResourceSnapshot %s, total snapshot in the index group = %d, num Of existing snapshot in the group= %d",
+			errResourceNotFullyCreated, masterResourceSnapshot.Name, snapshotCount, len(resourceSnapshots))
+		klog.ErrorS(misMatchErr, "Resource snapshot are not ready", "clusterResourcePlacement", crp)
+		return nil, NewExpectedBehaviorError(misMatchErr)
+	}
+	return resourceSnapshots, nil
+}
+
+// FetchCreatedClusterResourceSnapshots fetches the clusterResourceSnapshots in the same index group as the master
+// clusterResourceSnapshot which have been created so far, along with the total number of snapshots in the group.
+//
+// Unlike FetchAllClusterResourceSnapshots, it does not fail if some of the snapshots in the group are still being
+// created, so that the callers can process the snapshots as they are created.
+func FetchCreatedClusterResourceSnapshots(ctx context.Context, k8Client client.Client, crp string, masterResourceSnapshot *fleetv1beta1.ClusterResourceSnapshot) (map[string]*fleetv1beta1.ClusterResourceSnapshot, int, error) {
 	resourceSnapshots := make(map[string]*fleetv1beta1.ClusterResourceSnapshot)
 	resourceSnapshots[masterResourceSnapshot.Name] = masterResourceSnapshot
 
@@ -315,7 +350,7 @@ func FetchAllClusterResourceSnapshots(ctx context.Context, k8Client client.Clien
 	countAnnotation := masterResourceSnapshot.Annotations[fleetv1beta1.NumberOfResourceSnapshotsAnnotation]
 	snapshotCount, err := strconv.Atoi(countAnnotation)
 	if err != nil || snapshotCount < 1 {
-		return nil, NewUnexpectedBehaviorError(fmt.Errorf(
+		return nil, 0, NewUnexpectedBehaviorError(fmt.Errorf(
 			"master resource snapshot %s has an invalid snapshot count %d or err %w", masterResourceSnapshot.Name, snapshotCount, err))
 	}
 
@@ -324,7 +359,7 @@ func FetchAllClusterResourceSnapshots(ctx context.Context, k8Client client.Clien
 		index, err := labels.ExtractResourceIndexFromClusterResourceSnapshot(masterResourceSnapshot)
 		if err != nil {
 			klog.ErrorS(err, "Master resource snapshot has invalid resource index", "clusterResourceSnapshot", klog.KObj(masterResourceSnapshot))
-			return nil, NewUnexpectedBehaviorError(err)
+			return nil, 0, NewUnexpectedBehaviorError(err)
 		}
 		resourceIndexLabelMatcher := client.MatchingLabels{
 			fleetv1beta1.ResourceIndexLabel: strconv.Itoa(index),
@@ -333,22 +368,14 @@ func FetchAllClusterResourceSnapshots(ctx context.Context, k8Client client.Clien
 		resourceSnapshotList := &fleetv1beta1.ClusterResourceSnapshotList{}
 		if err := k8Client.List(ctx, resourceSnapshotList, resourceIndexLabelMatcher); err != nil {
 			klog.ErrorS(err, "Failed to list all the resource snapshot", "clusterResourcePlacement", crp)
-			return nil, NewAPIServerError(true, err)
+			return nil, 0, NewAPIServerError(true, err)
 		}
 		//insert all the resource snapshot into the map
 		for i := 0; i < len(resourceSnapshotList.Items); i++ {
 			resourceSnapshots[resourceSnapshotList.Items[i].Name] = &resourceSnapshotList.Items[i]
 		}
 	}
-
-	// check if all the resource snapshots are created since that may take a while but the rollout controller may update the resource binding on master snapshot creation
-	if len(resourceSnapshots) != snapshotCount {
-		misMatchErr := fmt.Errorf("%w: resource snapshots are still being created for the masterResourceSnapshot %s, total snapshot in the index group = %d, num Of existing snapshot in the group= %d",
-			errResourceNotFullyCreated, masterResourceSnapshot.Name, snapshotCount, len(resourceSnapshots))
-		klog.ErrorS(misMatchErr, "Resource snapshot are not ready", "clusterResourcePlacement", crp)
-		return nil, NewExpectedBehaviorError(misMatchErr)
-	}
-	return resourceSnapshots, nil
+	return resourceSnapshots, snapshotCount, nil
 }
 
 // MemberController configures how to join or leave the fleet as a member.
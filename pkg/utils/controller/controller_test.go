@@ -453,3 +453,55 @@ func TestFetchAllClusterResourceSnapshots(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchCreatedClusterResourceSnapshots(t *testing.T) {
+	crp := "my-test-crp"
+	master := &fleetv1beta1.ClusterResourceSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf(fleetv1beta1.ResourceSnapshotNameFmt, crp, 0),
+			Labels: map[string]string{
+				fleetv1beta1.ResourceIndexLabel: "0",
+				fleetv1beta1.CRPTrackingLabel:   crp,
+			},
+			Annotations: map[string]string{
+				fleetv1beta1.ResourceGroupHashAnnotation:         "abc",
+				fleetv1beta1.NumberOfResourceSnapshotsAnnotation: "3",
+			},
+		},
+	}
+	subIndexSnapshot := &fleetv1beta1.ClusterResourceSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf(fleetv1beta1.ResourceSnapshotNameWithSubindexFmt, crp, 0, 0),
+			Labels: map[string]string{
+				fleetv1beta1.ResourceIndexLabel: "0",
+				fleetv1beta1.CRPTrackingLabel:   crp,
+			},
+			Annotations: map[string]string{
+				fleetv1beta1.SubindexOfResourceSnapshotAnnotation: "0",
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(serviceScheme(t)).
+		WithObjects(master, subIndexSnapshot).
+		Build()
+
+	got, gotCount, err := FetchCreatedClusterResourceSnapshots(context.Background(), fakeClient, crp, master)
+	if err != nil {
+		t.Fatalf("FetchCreatedClusterResourceSnapshots() got error %v, want no error", err)
+	}
+	if gotCount != 3 {
+		t.Errorf("FetchCreatedClusterResourceSnapshots() got snapshot count %d, want 3", gotCount)
+	}
+	want := map[string]*fleetv1beta1.ClusterResourceSnapshot{
+		master.Name:           master,
+		subIndexSnapshot.Name: subIndexSnapshot,
+	}
+	options := []cmp.Option{
+		cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion"),
+		cmpopts.IgnoreTypes(metav1.TypeMeta{}),
+	}
+	if diff := cmp.Diff(want, got, options...); diff != "" {
+		t.Errorf("FetchCreatedClusterResourceSnapshots() mismatch (-want, +got):\n%s", diff)
+	}
+}
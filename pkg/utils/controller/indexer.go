@@ -0,0 +1,61 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	// BindingCRPTrackingIndexKey is the name of the cache index of ClusterResourceBindings by the
+	// name of the CRP they belong to, i.e., the value of their CRP tracking label.
+	//
+	// Use it with client.MatchingFields to list the bindings of a CRP from the cache, which is an
+	// indexed lookup rather than a label selector scan over all the bindings in the cache.
+	BindingCRPTrackingIndexKey = "metadata.labels." + fleetv1beta1.CRPTrackingLabel
+
+	// BindingTargetClusterIndexKey is the name of the cache index of ClusterResourceBindings by the
+	// name of the member cluster they target.
+	//
+	// Use it with client.MatchingFields to list the bindings on a member cluster from the cache.
+	BindingTargetClusterIndexKey = "spec.targetCluster"
+)
+
+// ExtractBindingCRPTracking is the index function of the BindingCRPTrackingIndexKey index.
+func ExtractBindingCRPTracking(obj client.Object) []string {
+	crpName, ok := obj.GetLabels()[fleetv1beta1.CRPTrackingLabel]
+	if !ok {
+		return nil
+	}
+	return []string{crpName}
+}
+
+// SetupBindingCRPTrackingIndex registers the BindingCRPTrackingIndexKey index with the given
+// indexer, usually the field indexer of a controller manager; it must be called before the
+// manager starts.
+func SetupBindingCRPTrackingIndex(ctx context.Context, indexer client.FieldIndexer) error {
+	return indexer.IndexField(ctx, &fleetv1beta1.ClusterResourceBinding{}, BindingCRPTrackingIndexKey, ExtractBindingCRPTracking)
+}
+
+// ExtractBindingTargetCluster is the index function of the BindingTargetClusterIndexKey index.
+func ExtractBindingTargetCluster(obj client.Object) []string {
+	binding, ok := obj.(*fleetv1beta1.ClusterResourceBinding)
+	if !ok || binding.Spec.TargetCluster == "" {
+		return nil
+	}
+	return []string{binding.Spec.TargetCluster}
+}
+
+// SetupBindingTargetClusterIndex registers the BindingTargetClusterIndexKey index with the given
+// indexer, usually the field indexer of a controller manager; it must be called before the
+// manager starts.
+func SetupBindingTargetClusterIndex(ctx context.Context, indexer client.FieldIndexer) error {
+	return indexer.IndexField(ctx, &fleetv1beta1.ClusterResourceBinding{}, BindingTargetClusterIndexKey, ExtractBindingTargetCluster)
+}
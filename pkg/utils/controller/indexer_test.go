@@ -0,0 +1,95 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+func TestBindingCRPTrackingIndex(t *testing.T) {
+	newBinding := func(name string, labels map[string]string) *fleetv1beta1.ClusterResourceBinding {
+		return &fleetv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+		}
+	}
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme: %v", err)
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newBinding("binding-1", map[string]string{fleetv1beta1.CRPTrackingLabel: "crp-1"}),
+			newBinding("binding-2", map[string]string{fleetv1beta1.CRPTrackingLabel: "crp-2"}),
+			newBinding("binding-3", map[string]string{fleetv1beta1.CRPTrackingLabel: "crp-1"}),
+			newBinding("binding-4", nil),
+		).
+		WithIndex(&fleetv1beta1.ClusterResourceBinding{}, BindingCRPTrackingIndexKey, ExtractBindingCRPTracking).
+		Build()
+
+	bindingList := &fleetv1beta1.ClusterResourceBindingList{}
+	if err := fakeClient.List(context.Background(), bindingList, client.MatchingFields{BindingCRPTrackingIndexKey: "crp-1"}); err != nil {
+		t.Fatalf("List() = %v, want no error", err)
+	}
+	var got []string
+	for _, binding := range bindingList.Items {
+		got = append(got, binding.Name)
+	}
+	if diff := cmp.Diff([]string{"binding-1", "binding-3"}, got); diff != "" {
+		t.Errorf("List() mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestBindingTargetClusterIndex(t *testing.T) {
+	newBinding := func(name, targetCluster string) *fleetv1beta1.ClusterResourceBinding {
+		return &fleetv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: fleetv1beta1.ResourceBindingSpec{
+				TargetCluster: targetCluster,
+			},
+		}
+	}
+	scheme := runtime.NewScheme()
+	if err := fleetv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme: %v", err)
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newBinding("binding-1", "cluster-1"),
+			newBinding("binding-2", "cluster-2"),
+			newBinding("binding-3", "cluster-1"),
+			newBinding("binding-4", ""),
+		).
+		WithIndex(&fleetv1beta1.ClusterResourceBinding{}, BindingTargetClusterIndexKey, ExtractBindingTargetCluster).
+		Build()
+
+	bindingList := &fleetv1beta1.ClusterResourceBindingList{}
+	if err := fakeClient.List(context.Background(), bindingList, client.MatchingFields{BindingTargetClusterIndexKey: "cluster-1"}); err != nil {
+		t.Fatalf("List() = %v, want no error", err)
+	}
+	var got []string
+	for _, binding := range bindingList.Items {
+		got = append(got, binding.Name)
+	}
+	if diff := cmp.Diff([]string{"binding-1", "binding-3"}, got); diff != "" {
+		t.Errorf("List() mismatch (-want, +got):\n%s", diff)
+	}
+}
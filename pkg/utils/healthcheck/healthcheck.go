@@ -0,0 +1,158 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package healthcheck features health checks that reflect the health of the controllers running
+// in a Fleet agent; the checks are served via the healthz/readyz endpoints of the controller manager
+// so that Kubernetes can restart an unhealthy agent.
+package healthcheck
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// QueueProbe reports the status of a work queue and the workers consuming it.
+type QueueProbe interface {
+	// Started returns true if the workers consuming the queue have started.
+	Started() bool
+	// Len returns the number of items ready for processing in the queue.
+	Len() int
+	// LastDequeueTime returns the last time a worker pulled an item from the queue.
+	LastDequeueTime() time.Time
+}
+
+// QueueActivity tracks the activity of a work queue; it implements the Started and LastDequeueTime
+// methods of QueueProbe and is safe for concurrent use.
+type QueueActivity struct {
+	started         atomic.Bool
+	lastDequeueTime atomic.Int64
+}
+
+// MarkStarted marks that the workers consuming the queue have started.
+func (a *QueueActivity) MarkStarted() {
+	a.lastDequeueTime.Store(time.Now().UnixNano())
+	a.started.Store(true)
+}
+
+// MarkDequeued marks that a worker has just pulled an item from the queue.
+func (a *QueueActivity) MarkDequeued() {
+	a.lastDequeueTime.Store(time.Now().UnixNano())
+}
+
+// Started returns true if the workers consuming the queue have started.
+func (a *QueueActivity) Started() bool {
+	return a.started.Load()
+}
+
+// LastDequeueTime returns the last time a worker pulled an item from the queue.
+func (a *QueueActivity) LastDequeueTime() time.Time {
+	return time.Unix(0, a.lastDequeueTime.Load())
+}
+
+// QueueStarvationChecker returns a health checker which fails if a started queue has items ready
+// for processing, yet no worker has pulled any item from it for longer than the given threshold,
+// i.e., all the workers are stuck.
+func QueueStarvationChecker(probe QueueProbe, threshold time.Duration) healthz.Checker {
+	return func(_ *http.Request) error {
+		if !probe.Started() {
+			return nil
+		}
+		if probe.Len() == 0 {
+			return nil
+		}
+		if idle := time.Since(probe.LastDequeueTime()); idle > threshold {
+			return fmt.Errorf("%d item(s) are waiting in the queue, but no item has been processed for %s", probe.Len(), idle.Round(time.Second))
+		}
+		return nil
+	}
+}
+
+// InformerSyncChecker returns a health checker which fails if the informers of the given cache
+// cannot be synced within the given timeout.
+func InformerSyncChecker(c cache.Cache, timeout time.Duration) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return errors.New("informers have not synced yet")
+		}
+		return nil
+	}
+}
+
+// LeaderStatusChecker returns a health checker which fails if the agent has been elected as the
+// leader for longer than the given grace period, yet the workers of some of the given queues have
+// not started.
+//
+// Note that the checker always passes on agents that are not the leader, as the leader-only
+// controllers are not expected to run there.
+func LeaderStatusChecker(ctx context.Context, elected <-chan struct{}, gracePeriod time.Duration, probes map[string]QueueProbe) healthz.Checker {
+	var electedAt atomic.Int64
+	go func() {
+		select {
+		case <-elected:
+			electedAt.Store(time.Now().UnixNano())
+		case <-ctx.Done():
+		}
+	}()
+
+	return func(_ *http.Request) error {
+		at := electedAt.Load()
+		if at == 0 {
+			// The agent has not been elected as the leader (yet).
+			return nil
+		}
+		if time.Since(time.Unix(0, at)) <= gracePeriod {
+			return nil
+		}
+		for name, probe := range probes {
+			if !probe.Started() {
+				return fmt.Errorf("the agent is elected as the leader, but %s has not started", name)
+			}
+		}
+		return nil
+	}
+}
+
+// CertificateValidityChecker returns a health checker which fails if the PEM-encoded certificate
+// at the given path cannot be read, or is not valid at the moment.
+func CertificateValidityChecker(certPath string) healthz.Checker {
+	return func(_ *http.Request) error {
+		certPEM, err := os.ReadFile(certPath)
+		if err != nil {
+			return fmt.Errorf("failed to read the certificate: %w", err)
+		}
+		return checkCertificateValidity(certPEM, time.Now())
+	}
+}
+
+// checkCertificateValidity checks if the first certificate in the PEM-encoded data is valid at the given time.
+func checkCertificateValidity(certPEM []byte, now time.Time) error {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return errors.New("failed to decode the certificate: no PEM-encoded certificate is found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse the certificate: %w", err)
+	}
+	if now.Before(cert.NotBefore) {
+		return fmt.Errorf("the certificate is not valid until %s", cert.NotBefore.Format(time.RFC3339))
+	}
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("the certificate has expired at %s", cert.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package healthcheck
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type fakeQueueProbe struct {
+	started         bool
+	len             int
+	lastDequeueTime time.Time
+}
+
+func (p *fakeQueueProbe) Started() bool              { return p.started }
+func (p *fakeQueueProbe) Len() int                   { return p.len }
+func (p *fakeQueueProbe) LastDequeueTime() time.Time { return p.lastDequeueTime }
+
+// TestQueueStarvationChecker tests the QueueStarvationChecker function.
+func TestQueueStarvationChecker(t *testing.T) {
+	threshold := time.Minute
+	tests := map[string]struct {
+		probe   *fakeQueueProbe
+		wantErr bool
+	}{
+		"not started": {
+			probe: &fakeQueueProbe{len: 10, lastDequeueTime: time.Now().Add(-time.Hour)},
+		},
+		"empty queue": {
+			probe: &fakeQueueProbe{started: true, lastDequeueTime: time.Now().Add(-time.Hour)},
+		},
+		"items processed recently": {
+			probe: &fakeQueueProbe{started: true, len: 10, lastDequeueTime: time.Now()},
+		},
+		"starved": {
+			probe:   &fakeQueueProbe{started: true, len: 10, lastDequeueTime: time.Now().Add(-time.Hour)},
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := QueueStarvationChecker(tc.probe, threshold)(nil)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("QueueStarvationChecker() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// TestQueueActivity tests the QueueActivity type.
+func TestQueueActivity(t *testing.T) {
+	var activity QueueActivity
+	if activity.Started() {
+		t.Fatalf("Started() = true before MarkStarted(), want false")
+	}
+	before := time.Now()
+	activity.MarkStarted()
+	if !activity.Started() {
+		t.Fatalf("Started() = false after MarkStarted(), want true")
+	}
+	activity.MarkDequeued()
+	if got := activity.LastDequeueTime(); got.Before(before) {
+		t.Errorf("LastDequeueTime() = %v, want no earlier than %v", got, before)
+	}
+}
+
+// TestLeaderStatusChecker tests the LeaderStatusChecker function.
+func TestLeaderStatusChecker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	elected := make(chan struct{})
+	probe := &fakeQueueProbe{}
+	checker := LeaderStatusChecker(ctx, elected, 0, map[string]QueueProbe{"test-controller": probe})
+
+	if err := checker(nil); err != nil {
+		t.Fatalf("checker() = %v before election, want no error", err)
+	}
+
+	close(elected)
+	deadline := time.Now().Add(5 * time.Second)
+	for checker(nil) == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("checker() = no error after election with a stopped controller, want error")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	probe.started = true
+	if err := checker(nil); err != nil {
+		t.Errorf("checker() = %v after the controller starts, want no error", err)
+	}
+}
+
+// TestCheckCertificateValidity tests the checkCertificateValidity function.
+func TestCheckCertificateValidity(t *testing.T) {
+	notBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.Add(24 * time.Hour)
+	certPEM := newTestCertificatePEM(t, notBefore, notAfter)
+
+	tests := map[string]struct {
+		certPEM []byte
+		now     time.Time
+		wantErr bool
+	}{
+		"valid": {
+			certPEM: certPEM,
+			now:     notBefore.Add(time.Hour),
+		},
+		"not yet valid": {
+			certPEM: certPEM,
+			now:     notBefore.Add(-time.Hour),
+			wantErr: true,
+		},
+		"expired": {
+			certPEM: certPEM,
+			now:     notAfter.Add(time.Hour),
+			wantErr: true,
+		},
+		"not a certificate": {
+			certPEM: []byte("not a certificate"),
+			now:     notBefore.Add(time.Hour),
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkCertificateValidity(tc.certPEM, tc.now)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("checkCertificateValidity() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func newTestCertificatePEM(t *testing.T, notBefore, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
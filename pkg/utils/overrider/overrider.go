@@ -10,13 +10,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,6 +25,7 @@ import (
 	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/clusterselector"
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/informer"
 )
@@ -233,6 +234,9 @@ func isClusterMatched(cluster *clusterv1beta1.MemberCluster, policy *placementv1
 }
 
 // IsClusterMatched checks if the cluster is matched with the override rules.
+//
+// A cluster selector term matches a cluster if both its label selector and its property selector (if any)
+// match; a cluster which does not report a property is not matched by any expression on the property.
 func IsClusterMatched(cluster *clusterv1beta1.MemberCluster, rule placementv1alpha1.OverrideRule) (bool, error) {
 	if rule.ClusterSelector == nil { // it means matching no member clusters
 		return false, nil
@@ -242,14 +246,59 @@ func IsClusterMatched(cluster *clusterv1beta1.MemberCluster, rule placementv1alp
 		return true, nil // it means matching all member clusters
 	}
 
-	for _, term := range rule.ClusterSelector.ClusterSelectorTerms {
-		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+	for i := range rule.ClusterSelector.ClusterSelectorTerms {
+		term := &rule.ClusterSelector.ClusterSelectorTerms[i]
+		compiled, err := clusterselector.Compile(term, clusterselector.OverrideUsage)
 		if err != nil {
 			return false, fmt.Errorf("invalid cluster label selector %v: %w", term.LabelSelector, err)
 		}
-		if selector.Matches(labels.Set(cluster.Labels)) {
+		matched, err := compiled.Matches(nil, cluster)
+		if err != nil {
+			return false, fmt.Errorf("invalid cluster property selector %v: %w", term.PropertySelector, err)
+		}
+		if matched {
 			return true, nil
 		}
 	}
 	return false, nil
 }
+
+// clusterLabelVariableRegexp matches the cluster label variables in the override values and captures the label keys.
+var clusterLabelVariableRegexp = regexp.MustCompile(regexp.QuoteMeta(placementv1alpha1.OverrideClusterLabelVariablePrefix) + `([^}]*)\}`)
+
+// ClusterLabelVariableKeys returns the label keys referenced by the cluster label variables in the override value.
+// It returns an error if a cluster label variable is not terminated.
+func ClusterLabelVariableKeys(value []byte) ([]string, error) {
+	matches := clusterLabelVariableRegexp.FindAllSubmatch(value, -1)
+	if len(matches) != strings.Count(string(value), placementv1alpha1.OverrideClusterLabelVariablePrefix) {
+		return nil, fmt.Errorf("variable %s must be terminated by }", placementv1alpha1.OverrideClusterLabelVariablePrefix)
+	}
+	keys := make([]string, 0, len(matches))
+	for _, match := range matches {
+		keys = append(keys, string(match[1]))
+	}
+	return keys, nil
+}
+
+// ReplaceVariables replaces the built-in variables in the override value with the metadata of the cluster.
+// It returns an error if the value references a label which the cluster does not have.
+func ReplaceVariables(value []byte, cluster *clusterv1beta1.MemberCluster) ([]byte, error) {
+	replaced := []byte(strings.ReplaceAll(string(value), placementv1alpha1.OverrideClusterNameVariable, cluster.Name))
+
+	var err error
+	replaced = clusterLabelVariableRegexp.ReplaceAllFunc(replaced, func(variable []byte) []byte {
+		key := string(clusterLabelVariableRegexp.FindSubmatch(variable)[1])
+		labelValue, ok := cluster.Labels[key]
+		if !ok {
+			if err == nil {
+				err = fmt.Errorf("label %q referenced by the override is not found on cluster %s", key, cluster.Name)
+			}
+			return variable
+		}
+		return []byte(labelValue)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return replaced, nil
+}
@@ -22,6 +22,7 @@ import (
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/propertyprovider"
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/test/utils/informer"
 	"go.goms.io/fleet/test/utils/resource"
@@ -1883,6 +1884,102 @@ func TestIsClusterMatched(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "matched overrides with property selector",
+			cluster: clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-1",
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						propertyprovider.NodeCountProperty: {Value: "3"},
+					},
+				},
+			},
+			rule: placementv1alpha1.OverrideRule{
+				ClusterSelector: &placementv1beta1.ClusterSelector{
+					ClusterSelectorTerms: []placementv1beta1.ClusterSelectorTerm{
+						{
+							PropertySelector: &placementv1beta1.PropertySelector{
+								MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+									{
+										Name:     propertyprovider.NodeCountProperty,
+										Operator: placementv1beta1.PropertySelectorLessThan,
+										Values:   []string{"5"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "no matched overrides with label and property selectors",
+			cluster: clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-1",
+					Labels: map[string]string{
+						"key1": "value1",
+					},
+				},
+				Status: clusterv1beta1.MemberClusterStatus{
+					Properties: map[clusterv1beta1.PropertyName]clusterv1beta1.PropertyValue{
+						propertyprovider.NodeCountProperty: {Value: "10"},
+					},
+				},
+			},
+			rule: placementv1alpha1.OverrideRule{
+				ClusterSelector: &placementv1beta1.ClusterSelector{
+					ClusterSelectorTerms: []placementv1beta1.ClusterSelectorTerm{
+						{
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"key1": "value1",
+								},
+							},
+							PropertySelector: &placementv1beta1.PropertySelector{
+								MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+									{
+										Name:     propertyprovider.NodeCountProperty,
+										Operator: placementv1beta1.PropertySelectorLessThan,
+										Values:   []string{"5"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "no matched overrides with property not reported",
+			cluster: clusterv1beta1.MemberCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-1",
+				},
+			},
+			rule: placementv1alpha1.OverrideRule{
+				ClusterSelector: &placementv1beta1.ClusterSelector{
+					ClusterSelectorTerms: []placementv1beta1.ClusterSelectorTerm{
+						{
+							PropertySelector: &placementv1beta1.PropertySelector{
+								MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
+									{
+										Name:     propertyprovider.NodeCountProperty,
+										Operator: placementv1beta1.PropertySelectorLessThan,
+										Values:   []string{"5"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: false,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1897,3 +1994,90 @@ func TestIsClusterMatched(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterLabelVariableKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "no variables",
+			value: `"nginx"`,
+			want:  []string{},
+		},
+		{
+			name:  "multiple variables",
+			value: `"${MEMBER-CLUSTER-LABEL:region}-${MEMBER-CLUSTER-LABEL:kubernetes.io/zone}-${MEMBER-CLUSTER-NAME}"`,
+			want:  []string{"region", "kubernetes.io/zone"},
+		},
+		{
+			name:    "unterminated variable",
+			value:   `"${MEMBER-CLUSTER-LABEL:region"`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ClusterLabelVariableKeys([]byte(tc.value))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ClusterLabelVariableKeys() got error %v, want error %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ClusterLabelVariableKeys() keys mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReplaceVariables(t *testing.T) {
+	cluster := &clusterv1beta1.MemberCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster-1",
+			Labels: map[string]string{
+				"region": "us-east",
+				"env":    "prod",
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "no variables",
+			value: `"nginx"`,
+			want:  `"nginx"`,
+		},
+		{
+			name:  "cluster name and labels",
+			value: `{"name": "${MEMBER-CLUSTER-NAME}", "host": "${MEMBER-CLUSTER-LABEL:env}.${MEMBER-CLUSTER-LABEL:region}.example.com"}`,
+			want:  `{"name": "cluster-1", "host": "prod.us-east.example.com"}`,
+		},
+		{
+			name:    "label not found on the cluster",
+			value:   `"${MEMBER-CLUSTER-LABEL:zone}"`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ReplaceVariables([]byte(tc.value), cluster)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ReplaceVariables() got error %v, want error %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if string(got) != tc.want {
+				t.Errorf("ReplaceVariables() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package parallelizer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrorCollector collects the errors that occur when running tasks in parallel with the parallelizer.
+//
+// Unlike ErrorFlag, which keeps only the first error, it keeps all the distinct errors (as told by
+// their messages), up to a limit, so that failures with different causes can be reported together.
+type ErrorCollector struct {
+	mu sync.Mutex
+
+	limit   int
+	errs    []error
+	seen    map[string]bool
+	omitted int
+}
+
+// Collect adds an error to the collector; an error with the same message as one collected earlier is
+// ignored, and an error beyond the limit is only counted.
+//
+// It returns true if the collector is full, i.e., it has collected as many distinct errors as the limit.
+func (c *ErrorCollector) Collect(err error) (full bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	msg := err.Error()
+	switch {
+	case c.seen[msg]:
+		// The error has been collected before.
+	case len(c.errs) >= c.limit:
+		c.omitted++
+	default:
+		c.seen[msg] = true
+		c.errs = append(c.errs, err)
+	}
+	return len(c.errs) >= c.limit
+}
+
+// Err returns the collected errors; it returns nil if no error has been collected, the error itself if
+// only one error has been collected, and an aggregated error otherwise.
+func (c *ErrorCollector) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case len(c.errs) == 0:
+		return nil
+	case len(c.errs) == 1 && c.omitted == 0:
+		return c.errs[0]
+	}
+	errs := make([]error, 0, len(c.errs)+1)
+	errs = append(errs, c.errs...)
+	if c.omitted > 0 {
+		errs = append(errs, fmt.Errorf("%d more error(s) omitted", c.omitted))
+	}
+	return errors.Join(errs...)
+}
+
+// NewErrorCollector returns an error collector that keeps at most limit distinct errors; a non-positive
+// limit is treated as 1.
+func NewErrorCollector(limit int) *ErrorCollector {
+	if limit <= 0 {
+		limit = 1
+	}
+	return &ErrorCollector{
+		limit: limit,
+		seen:  make(map[string]bool),
+	}
+}
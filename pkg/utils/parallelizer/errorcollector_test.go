@@ -0,0 +1,113 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package parallelizer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestErrorCollector tests the basic ops of an ErrorCollector.
+func TestErrorCollector(t *testing.T) {
+	errA := fmt.Errorf("error A")
+	errB := fmt.Errorf("error B")
+	errC := fmt.Errorf("error C")
+
+	testCases := []struct {
+		name     string
+		limit    int
+		errs     []error
+		wantFull bool
+		wantErr  string
+		wantIs   []error
+	}{
+		{
+			name:    "no error",
+			limit:   2,
+			wantErr: "",
+		},
+		{
+			name:    "single error",
+			limit:   2,
+			errs:    []error{errA},
+			wantErr: "error A",
+			wantIs:  []error{errA},
+		},
+		{
+			name:    "duplicated errors",
+			limit:   2,
+			errs:    []error{errA, fmt.Errorf("error A")},
+			wantErr: "error A",
+			wantIs:  []error{errA},
+		},
+		{
+			name:     "distinct errors",
+			limit:    2,
+			errs:     []error{errA, errB},
+			wantFull: true,
+			wantErr:  "error A\nerror B",
+			wantIs:   []error{errA, errB},
+		},
+		{
+			name:     "errors beyond the limit",
+			limit:    2,
+			errs:     []error{errA, errB, errC, errA},
+			wantFull: true,
+			wantErr:  "error A\nerror B\n1 more error(s) omitted",
+			wantIs:   []error{errA, errB},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			collector := NewErrorCollector(tc.limit)
+			var full bool
+			for _, err := range tc.errs {
+				full = collector.Collect(err)
+			}
+			if full != tc.wantFull {
+				t.Errorf("Collect() = %t, want %t", full, tc.wantFull)
+			}
+
+			err := collector.Err()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Err() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("Err() = %v, want %q", err, tc.wantErr)
+			}
+			for _, wantErr := range tc.wantIs {
+				if !errors.Is(err, wantErr) {
+					t.Errorf("errors.Is(Err(), %v) = false, want true", wantErr)
+				}
+			}
+		})
+	}
+}
+
+// TestErrorCollectorConcurrentCollect tests collecting errors from multiple goroutines.
+func TestErrorCollectorConcurrentCollect(t *testing.T) {
+	collector := NewErrorCollector(10)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			collector.Collect(fmt.Errorf("error %d", i%5))
+		}(i)
+	}
+	wg.Wait()
+
+	var joined interface{ Unwrap() []error }
+	if !errors.As(collector.Err(), &joined) || len(joined.Unwrap()) != 5 {
+		t.Fatalf("Err() = %v, want 5 distinct errors", collector.Err())
+	}
+}
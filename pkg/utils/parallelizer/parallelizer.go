@@ -8,9 +8,16 @@ package parallelizer
 
 import (
 	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
 
-	"k8s.io/client-go/util/workqueue"
+	"github.com/prometheus/client_golang/prometheus"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/klog/v2"
+
+	"go.goms.io/fleet/pkg/metrics"
 )
 
 const (
@@ -19,24 +26,146 @@ const (
 )
 
 // Parallelizer helps run tasks in parallel.
+//
+// The work pieces of a task are handed out to the workers in chunks, so that the overhead of
+// coordination stays low when there are many small pieces; by default, the chunk size adapts to
+// the number of pieces and workers.
+//
+// A nil Parallelizer runs tasks with the default number of workers and adaptive chunk sizes.
 type Parallerlizer struct {
 	numOfWorkers int
+	// chunkSize is the fixed number of work pieces in a chunk; zero means the chunk size is
+	// adaptive.
+	chunkSize int
+}
+
+// Option is the function for configuring a Parallelizer.
+type Option func(*Parallerlizer)
+
+// WithChunkSize sets a fixed number of work pieces to hand out to a worker at a time; a non-positive
+// size means the chunk size is adaptive.
+func WithChunkSize(size int) Option {
+	return func(p *Parallerlizer) {
+		p.chunkSize = size
+	}
 }
 
 // NewParallelizer returns a Parallelizer for running tasks in parallel.
-func NewParallelizer(workers int) *Parallerlizer {
-	return &Parallerlizer{
+func NewParallelizer(workers int, opts ...Option) *Parallerlizer {
+	p := &Parallerlizer{
 		numOfWorkers: workers,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
-// ParallelizeUntil wraps workqueue.ParallelizeUntil for running tasks in parallel.
-func (p *Parallerlizer) ParallelizeUntil(ctx context.Context, pieces int, doWork workqueue.DoWorkPieceFunc, operation string) {
-	doWorkWithLogs := func(piece int) {
-		klog.V(4).Infof("run piece %d for operation %s", piece, operation)
-		doWork(piece)
-		klog.V(4).Infof("completed piece %d for operation %s", piece, operation)
+// workers returns the number of workers to use.
+func (p *Parallerlizer) workers() int {
+	if p == nil || p.numOfWorkers <= 0 {
+		return DefaultNumOfWorkers
 	}
+	return p.numOfWorkers
+}
 
-	workqueue.ParallelizeUntil(ctx, p.numOfWorkers, pieces, doWorkWithLogs)
+// chunkSizeFor returns the number of work pieces in a chunk for a task.
+//
+// Unless a fixed chunk size has been set, the chunk size is the square root of the number of pieces,
+// but no more than the share of each worker, so that all the workers are kept busy.
+func (p *Parallerlizer) chunkSizeFor(pieces, workers int) int {
+	if p != nil && p.chunkSize > 0 {
+		return p.chunkSize
+	}
+	size := int(math.Sqrt(float64(pieces)))
+	if share := pieces/workers + 1; size > share {
+		size = share
+	}
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
+
+// ParallelizeUntil runs the work pieces of a task in parallel, until all the pieces are processed or
+// the context is cancelled; no more pieces are handed out once the context is cancelled.
+//
+// The operation name is used for logging and in the metrics.
+func (p *Parallerlizer) ParallelizeUntil(ctx context.Context, pieces int, doWork func(piece int), operation string) {
+	if pieces <= 0 {
+		return
+	}
+
+	startTime := time.Now()
+	workers := p.workers()
+	chunkSize := p.chunkSizeFor(pieces, workers)
+	chunks := (pieces + chunkSize - 1) / chunkSize
+	if workers > chunks {
+		workers = chunks
+	}
+
+	toProcess := make(chan int, chunks)
+	for i := 0; i < chunks; i++ {
+		toProcess <- i
+	}
+	close(toProcess)
+
+	var processedChunks, processedPieces int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer utilruntime.HandleCrash()
+			defer wg.Done()
+			for chunk := range toProcess {
+				if ctx.Err() != nil {
+					return
+				}
+				atomic.AddInt64(&processedChunks, 1)
+				start := chunk * chunkSize
+				end := min(start+chunkSize, pieces)
+				for piece := start; piece < end; piece++ {
+					if ctx.Err() != nil {
+						return
+					}
+					klog.V(4).Infof("run piece %d for operation %s", piece, operation)
+					doWork(piece)
+					atomic.AddInt64(&processedPieces, 1)
+					klog.V(4).Infof("completed piece %d for operation %s", piece, operation)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	labels := prometheus.Labels{"operation": operation}
+	metrics.ParallelizerWorkChunksTotal.With(labels).Add(float64(processedChunks))
+	metrics.ParallelizerWorkDurationSeconds.With(labels).Observe(time.Since(startTime).Seconds())
+	if processedPieces < int64(pieces) {
+		klog.V(2).InfoS("Parallelized operation is aborted", "operation", operation, "pieces", pieces, "processedPieces", processedPieces)
+		metrics.ParallelizerAbortedWorkTotal.With(labels).Inc()
+	}
+}
+
+// ParallelizeUntilError runs the work pieces of a task in parallel, like ParallelizeUntil, but with
+// pieces that may fail; once a piece fails, no more pieces are handed out, and the context passed to
+// the pieces in progress is cancelled.
+//
+// It returns the first error, if any; if the task is aborted as the context is cancelled, it returns
+// the context error.
+func (p *Parallerlizer) ParallelizeUntilError(ctx context.Context, pieces int, doWork func(ctx context.Context, piece int) error, operation string) error {
+	childCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errFlag := NewErrorFlag()
+	p.ParallelizeUntil(childCtx, pieces, func(piece int) {
+		if err := doWork(childCtx, piece); err != nil {
+			errFlag.Raise(err)
+			cancel()
+		}
+	}, operation)
+	if err := errFlag.Lower(); err != nil {
+		return err
+	}
+	return ctx.Err()
 }
@@ -7,8 +7,14 @@ package parallelizer
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync/atomic"
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"go.goms.io/fleet/pkg/metrics"
 )
 
 // TestParallelizer tests the basic ops of a Parallelizer.
@@ -28,3 +34,137 @@ func TestParallelizer(t *testing.T) {
 		t.Errorf("sum of nums, want %d, got %d", 36, sum)
 	}
 }
+
+// TestChunkSizeFor tests the chunkSizeFor method.
+func TestChunkSizeFor(t *testing.T) {
+	testCases := []struct {
+		name    string
+		p       *Parallerlizer
+		pieces  int
+		workers int
+		want    int
+	}{
+		{
+			name:    "few pieces",
+			p:       NewParallelizer(4),
+			pieces:  3,
+			workers: 4,
+			want:    1,
+		},
+		{
+			name:    "many pieces",
+			p:       NewParallelizer(4),
+			pieces:  10000,
+			workers: 4,
+			want:    100,
+		},
+		{
+			name:    "capped by the share of each worker",
+			p:       NewParallelizer(16),
+			pieces:  100,
+			workers: 16,
+			want:    7,
+		},
+		{
+			name:    "fixed chunk size",
+			p:       NewParallelizer(4, WithChunkSize(5)),
+			pieces:  10000,
+			workers: 4,
+			want:    5,
+		},
+		{
+			name:    "nil parallelizer",
+			pieces:  100,
+			workers: DefaultNumOfWorkers,
+			want:    10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.p.chunkSizeFor(tc.pieces, tc.workers); got != tc.want {
+				t.Errorf("chunkSizeFor(%d, %d) = %d, want %d", tc.pieces, tc.workers, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestParallelizeUntilAborted tests that the ParallelizeUntil method stops handing out work pieces
+// once the context is cancelled.
+func TestParallelizeUntilAborted(t *testing.T) {
+	operation := "testParallelizeUntilAborted"
+	p := NewParallelizer(1, WithChunkSize(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var processed int32
+	doWork := func(piece int) {
+		atomic.AddInt32(&processed, 1)
+		if piece == 2 {
+			cancel()
+		}
+	}
+	p.ParallelizeUntil(ctx, 10, doWork, operation)
+
+	if processed != 3 {
+		t.Errorf("processed pieces = %d, want %d", processed, 3)
+	}
+	if got := testutil.ToFloat64(metrics.ParallelizerWorkChunksTotal.WithLabelValues(operation)); got != 3 {
+		t.Errorf("work chunks metric = %v, want %v", got, 3)
+	}
+	if got := testutil.ToFloat64(metrics.ParallelizerAbortedWorkTotal.WithLabelValues(operation)); got != 1 {
+		t.Errorf("aborted work metric = %v, want %v", got, 1)
+	}
+}
+
+// TestParallelizeUntilError tests the ParallelizeUntilError method.
+func TestParallelizeUntilError(t *testing.T) {
+	wantErr := fmt.Errorf("test error")
+
+	testCases := []struct {
+		name          string
+		p             *Parallerlizer
+		failedPiece   int
+		wantErr       error
+		wantProcessed int32
+	}{
+		{
+			name:          "all succeeded",
+			p:             NewParallelizer(DefaultNumOfWorkers),
+			failedPiece:   -1,
+			wantProcessed: 10,
+		},
+		{
+			name:          "all succeeded, nil parallelizer",
+			failedPiece:   -1,
+			wantProcessed: 10,
+		},
+		{
+			name:          "one failed",
+			p:             NewParallelizer(1, WithChunkSize(1)),
+			failedPiece:   4,
+			wantErr:       wantErr,
+			wantProcessed: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var processed int32
+			doWork := func(ctx context.Context, piece int) error {
+				atomic.AddInt32(&processed, 1)
+				if piece == tc.failedPiece {
+					return wantErr
+				}
+				return nil
+			}
+			err := tc.p.ParallelizeUntilError(context.Background(), 10, doWork, "testParallelizeUntilError")
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("ParallelizeUntilError() = %v, want %v", err, tc.wantErr)
+			}
+			if processed != tc.wantProcessed {
+				t.Errorf("processed pieces = %d, want %d", processed, tc.wantProcessed)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package resource
+
+// ManifestLimits caps the manifests that the hub agent puts in a Work object, so that the Work objects
+// are rejected early with a clear message, instead of failing to be stored by the hub cluster. A zero
+// value of a field means no limit.
+type ManifestLimits struct {
+	// MaxManifestSize is the maximum size of a single manifest, in bytes.
+	MaxManifestSize int
+	// MaxWorkSize is the maximum total size of the manifests in a Work object, in bytes.
+	MaxWorkSize int
+	// MaxManifestsPerWork is the maximum number of manifests in a Work object.
+	MaxManifestsPerWork int
+}
+
+// MaxSizeOfManifest returns the maximum size of a single manifest, in bytes; a manifest larger than
+// MaxWorkSize does not fit in any Work object, whatever MaxManifestSize is. It returns 0 if there is
+// no limit.
+func (l ManifestLimits) MaxSizeOfManifest() int {
+	switch {
+	case l.MaxManifestSize == 0:
+		return l.MaxWorkSize
+	case l.MaxWorkSize == 0:
+		return l.MaxManifestSize
+	default:
+		return min(l.MaxManifestSize, l.MaxWorkSize)
+	}
+}
+
+// ManifestTooLarge returns true if a manifest of the given size exceeds the limits.
+func (l ManifestLimits) ManifestTooLarge(size int) bool {
+	maxSize := l.MaxSizeOfManifest()
+	return maxSize > 0 && size > maxSize
+}
+
+// WorkTooLarge returns true if the manifests of the given total size and count do not fit in a
+// single Work object.
+func (l ManifestLimits) WorkTooLarge(totalSize, count int) bool {
+	return (l.MaxWorkSize > 0 && totalSize > l.MaxWorkSize) ||
+		(l.MaxManifestsPerWork > 0 && count > l.MaxManifestsPerWork)
+}
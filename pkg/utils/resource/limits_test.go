@@ -0,0 +1,77 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package resource
+
+import "testing"
+
+func TestManifestLimits(t *testing.T) {
+	testCases := []struct {
+		name             string
+		limits           ManifestLimits
+		wantMaxSize      int
+		manifestSize     int
+		wantTooLarge     bool
+		workSize         int
+		workCount        int
+		wantWorkTooLarge bool
+	}{
+		{
+			name:         "no limit",
+			manifestSize: 1 << 30,
+			workSize:     1 << 30,
+			workCount:    100000,
+		},
+		{
+			name:         "manifest size limit",
+			limits:       ManifestLimits{MaxManifestSize: 100},
+			wantMaxSize:  100,
+			manifestSize: 101,
+			wantTooLarge: true,
+			workSize:     1000,
+			workCount:    10,
+		},
+		{
+			name:             "work size limit caps the manifest size",
+			limits:           ManifestLimits{MaxManifestSize: 200, MaxWorkSize: 100},
+			wantMaxSize:      100,
+			manifestSize:     150,
+			wantTooLarge:     true,
+			workSize:         101,
+			workCount:        1,
+			wantWorkTooLarge: true,
+		},
+		{
+			name:             "manifest count limit",
+			limits:           ManifestLimits{MaxManifestSize: 100, MaxWorkSize: 200, MaxManifestsPerWork: 2},
+			wantMaxSize:      100,
+			manifestSize:     100,
+			workSize:         200,
+			workCount:        3,
+			wantWorkTooLarge: true,
+		},
+		{
+			name:         "within limits",
+			limits:       ManifestLimits{MaxManifestSize: 100, MaxWorkSize: 200, MaxManifestsPerWork: 2},
+			wantMaxSize:  100,
+			manifestSize: 100,
+			workSize:     200,
+			workCount:    2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.limits.MaxSizeOfManifest(); got != tc.wantMaxSize {
+				t.Errorf("MaxSizeOfManifest() = %d, want %d", got, tc.wantMaxSize)
+			}
+			if got := tc.limits.ManifestTooLarge(tc.manifestSize); got != tc.wantTooLarge {
+				t.Errorf("ManifestTooLarge(%d) = %t, want %t", tc.manifestSize, got, tc.wantTooLarge)
+			}
+			if got := tc.limits.WorkTooLarge(tc.workSize, tc.workCount); got != tc.wantWorkTooLarge {
+				t.Errorf("WorkTooLarge(%d, %d) = %t, want %t", tc.workSize, tc.workCount, got, tc.wantWorkTooLarge)
+			}
+		})
+	}
+}
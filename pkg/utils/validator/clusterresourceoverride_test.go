@@ -444,14 +444,9 @@ func TestValidateClusterResourceOverride(t *testing.T) {
 								ClusterSelector: &fleetv1beta1.ClusterSelector{
 									ClusterSelectorTerms: []fleetv1beta1.ClusterSelectorTerm{
 										{
-											PropertySelector: &fleetv1beta1.PropertySelector{
-												MatchExpressions: []fleetv1beta1.PropertySelectorRequirement{
-													{
-														Name:     "example",
-														Operator: fleetv1beta1.PropertySelectorGreaterThanOrEqualTo,
-														Values:   []string{"1"},
-													},
-												},
+											PropertySorter: &fleetv1beta1.PropertySorter{
+												Name:      "example",
+												SortOrder: fleetv1beta1.Ascending,
 											},
 										},
 									},
@@ -462,7 +457,7 @@ func TestValidateClusterResourceOverride(t *testing.T) {
 				},
 			},
 			croList:    &fleetv1alpha1.ClusterResourceOverrideList{},
-			wantErrMsg: errors.New("only labelSelector is supported"),
+			wantErrMsg: errors.New("propertySorter is not supported"),
 		},
 		"invalid cluster resource override - fail validateClusterResourceOverridePolicy with nil label selector": {
 			cro: fleetv1alpha1.ClusterResourceOverride{
@@ -619,14 +614,9 @@ func TestValidateClusterResourceOverride(t *testing.T) {
 								ClusterSelector: &fleetv1beta1.ClusterSelector{
 									ClusterSelectorTerms: []fleetv1beta1.ClusterSelectorTerm{
 										{
-											PropertySelector: &fleetv1beta1.PropertySelector{
-												MatchExpressions: []fleetv1beta1.PropertySelectorRequirement{
-													{
-														Name:     "example",
-														Operator: fleetv1beta1.PropertySelectorGreaterThanOrEqualTo,
-														Values:   []string{"1"},
-													},
-												},
+											PropertySorter: &fleetv1beta1.PropertySorter{
+												Name:      "example",
+												SortOrder: fleetv1beta1.Ascending,
 											},
 										},
 									},
@@ -636,7 +626,7 @@ func TestValidateClusterResourceOverride(t *testing.T) {
 					},
 				},
 			},
-			wantErrMsg: errors.New("only labelSelector is supported"),
+			wantErrMsg: errors.New("propertySorter is not supported"),
 		},
 		"valid cluster resource override - policy with no cluster selector": {
 			cro: fleetv1alpha1.ClusterResourceOverride{
@@ -681,14 +671,9 @@ func TestValidateClusterResourceOverride(t *testing.T) {
 								ClusterSelector: &fleetv1beta1.ClusterSelector{
 									ClusterSelectorTerms: []fleetv1beta1.ClusterSelectorTerm{
 										{
-											PropertySelector: &fleetv1beta1.PropertySelector{
-												MatchExpressions: []fleetv1beta1.PropertySelectorRequirement{
-													{
-														Name:     "example",
-														Operator: fleetv1beta1.PropertySelectorGreaterThanOrEqualTo,
-														Values:   []string{"1"},
-													},
-												},
+											PropertySorter: &fleetv1beta1.PropertySorter{
+												Name:      "example",
+												SortOrder: fleetv1beta1.Ascending,
 											},
 										},
 									},
@@ -698,7 +683,7 @@ func TestValidateClusterResourceOverride(t *testing.T) {
 					},
 				},
 			},
-			wantErrMsg: errors.New("only labelSelector is supported"),
+			wantErrMsg: errors.New("propertySorter is not supported"),
 		},
 		"valid cluster resource override - policy with nil label selector": {
 			cro: fleetv1alpha1.ClusterResourceOverride{
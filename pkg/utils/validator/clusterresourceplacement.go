@@ -9,22 +9,21 @@ package validator
 import (
 	"errors"
 	"fmt"
-	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	apiErrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/klog/v2"
 
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	fleetv1alpha1 "go.goms.io/fleet/apis/v1alpha1"
-	"go.goms.io/fleet/pkg/propertyprovider"
+	"go.goms.io/fleet/pkg/utils/clusterselector"
 	"go.goms.io/fleet/pkg/utils/controller"
 	"go.goms.io/fleet/pkg/utils/informer"
 )
@@ -38,9 +37,8 @@ var (
 	invalidTolerationValueErrFmt = "invalid toleration value %+v: %s"
 	uniqueTolerationErrFmt       = "toleration %+v already exists, tolerations must be unique"
 
-	// Below is the map of supported capacity types.
-	supportedResourceCapacityTypesMap = map[string]bool{propertyprovider.AllocatableCapacityName: true, propertyprovider.AvailableCapacityName: true, propertyprovider.TotalCapacityName: true}
-	resourceCapacityTypes             = supportedResourceCapacityTypes()
+	invalidTopologySpreadConstraintErrFmt = "invalid topology spread constraint with topologyKey %q: %s"
+	uniqueTopologySpreadConstraintErrFmt  = "topology spread constraint with topologyKey %q and whenUnsatisfiable %q already exists, the pair of topologyKey and whenUnsatisfiable must be unique"
 )
 
 // ValidateClusterResourcePlacementAlpha validates a ClusterResourcePlacement v1alpha1 object.
@@ -88,6 +86,19 @@ func ValidateClusterResourcePlacementAlpha(clusterResourcePlacement *fleetv1alph
 
 // ValidateClusterResourcePlacement validates a ClusterResourcePlacement object.
 func ValidateClusterResourcePlacement(clusterResourcePlacement *placementv1beta1.ClusterResourcePlacement) error {
+	return validateClusterResourcePlacement(clusterResourcePlacement, true)
+}
+
+// ValidateClusterResourcePlacementOffline validates a ClusterResourcePlacement object without access
+// to the hub cluster; that is, the resource selectors are not checked against the API resources
+// served by the hub cluster.
+func ValidateClusterResourcePlacementOffline(clusterResourcePlacement *placementv1beta1.ClusterResourcePlacement) error {
+	return validateClusterResourcePlacement(clusterResourcePlacement, false)
+}
+
+// validateClusterResourcePlacement validates a ClusterResourcePlacement object; checkResources controls
+// whether the resource selectors are checked against the API resources served by the hub cluster.
+func validateClusterResourcePlacement(clusterResourcePlacement *placementv1beta1.ClusterResourcePlacement, checkResources bool) error {
 	allErr := make([]error, 0)
 
 	if len(clusterResourcePlacement.Name) > validation.DNS1035LabelMaxLength {
@@ -101,6 +112,9 @@ func ValidateClusterResourcePlacement(clusterResourcePlacement *placementv1beta1
 			}
 			allErr = append(allErr, validateLabelSelector(selector.LabelSelector, "resource selector"))
 		}
+		if !checkResources {
+			continue
+		}
 
 		gk := schema.GroupKind{
 			Group: selector.Group,
@@ -137,6 +151,29 @@ func ValidateClusterResourcePlacement(clusterResourcePlacement *placementv1beta1
 		allErr = append(allErr, fmt.Errorf("the rollout Strategy field  is invalid: %w", err))
 	}
 
+	if err := validateDependsOn(clusterResourcePlacement.Name, clusterResourcePlacement.Spec.DependsOn); err != nil {
+		allErr = append(allErr, fmt.Errorf("the dependsOn field is invalid: %w", err))
+	}
+
+	return apiErrors.NewAggregate(allErr)
+}
+
+// validateDependsOn validates the names of the placements a placement depends on; cycles that span
+// multiple placements are detected by the webhook, which can look up the other placements.
+func validateDependsOn(crpName string, dependsOn []string) error {
+	allErr := make([]error, 0)
+	seen := make(map[string]bool, len(dependsOn))
+	for _, name := range dependsOn {
+		switch {
+		case len(name) == 0:
+			allErr = append(allErr, fmt.Errorf("the name of a placement to depend on cannot be empty"))
+		case name == crpName:
+			allErr = append(allErr, fmt.Errorf("placement %s cannot depend on itself", name))
+		case seen[name]:
+			allErr = append(allErr, fmt.Errorf("placement %s must not be listed more than once", name))
+		}
+		seen[name] = true
+	}
 	return apiErrors.NewAggregate(allErr)
 }
 
@@ -201,6 +238,24 @@ func validatePolicyForPickFixedPlacementType(policy *placementv1beta1.PlacementP
 	if policy.Tolerations != nil {
 		allErr = append(allErr, fmt.Errorf("tolerations needs to be empty for policy type %s, only valid for PickAll/PickN", placementv1beta1.PickFixedPlacementType))
 	}
+	if policy.Stickiness != nil {
+		allErr = append(allErr, fmt.Errorf("stickiness must be nil for policy type %s, only valid for PickN placement policy type", placementv1beta1.PickFixedPlacementType))
+	}
+	if policy.RebalanceOnClusterJoin != nil {
+		allErr = append(allErr, fmt.Errorf("rebalanceOnClusterJoin must be nil for policy type %s, only valid for PickN placement policy type", placementv1beta1.PickFixedPlacementType))
+	}
+	if policy.ResourceFit != nil {
+		allErr = append(allErr, fmt.Errorf("resourceFit must be nil for policy type %s, only valid for PickAll/PickN placement policy types", placementv1beta1.PickFixedPlacementType))
+	}
+	if policy.WarmDependencies != nil {
+		allErr = append(allErr, fmt.Errorf("warmDependencies must be nil for policy type %s, only valid for PickN placement policy type", placementv1beta1.PickFixedPlacementType))
+	}
+	if policy.KubernetesVersion != nil {
+		allErr = append(allErr, fmt.Errorf("kubernetesVersion must be nil for policy type %s, only valid for PickAll/PickN placement policy types", placementv1beta1.PickFixedPlacementType))
+	}
+	if len(policy.RequiredAPIs) > 0 {
+		allErr = append(allErr, fmt.Errorf("requiredAPIs must be empty for policy type %s, only valid for PickAll/PickN placement policy types", placementv1beta1.PickFixedPlacementType))
+	}
 
 	return apiErrors.NewAggregate(allErr)
 }
@@ -217,9 +272,28 @@ func validatePolicyForPickAllPlacementType(policy *placementv1beta1.PlacementPol
 	if policy.Affinity != nil && policy.Affinity.ClusterAffinity != nil {
 		allErr = append(allErr, validateClusterAffinity(policy.Affinity.ClusterAffinity, policy.PlacementType))
 	}
+	if policy.Affinity != nil && policy.Affinity.PlacementAntiAffinity != nil {
+		allErr = append(allErr, validatePlacementAntiAffinity(policy.Affinity.PlacementAntiAffinity, policy.PlacementType))
+	}
 	if len(policy.TopologySpreadConstraints) > 0 {
 		allErr = append(allErr, fmt.Errorf("topology spread constraints needs to be empty for policy type %s, only valid for PickN policy type", placementv1beta1.PickAllPlacementType))
 	}
+	if policy.Stickiness != nil {
+		allErr = append(allErr, fmt.Errorf("stickiness must be nil for policy type %s, only valid for PickN placement policy type", placementv1beta1.PickAllPlacementType))
+	}
+	if policy.RebalanceOnClusterJoin != nil {
+		allErr = append(allErr, fmt.Errorf("rebalanceOnClusterJoin must be nil for policy type %s, only valid for PickN placement policy type", placementv1beta1.PickAllPlacementType))
+	}
+	if policy.ResourceFit != nil {
+		allErr = append(allErr, validateResourceFit(policy.ResourceFit))
+	}
+	if policy.WarmDependencies != nil {
+		allErr = append(allErr, fmt.Errorf("warmDependencies must be nil for policy type %s, only valid for PickN placement policy type", placementv1beta1.PickAllPlacementType))
+	}
+	if policy.KubernetesVersion != nil {
+		allErr = append(allErr, validateKubernetesVersionRange(policy.KubernetesVersion))
+	}
+	allErr = append(allErr, validateRequiredAPIs(policy.RequiredAPIs))
 	allErr = append(allErr, validateTolerations(policy.Tolerations))
 
 	return apiErrors.NewAggregate(allErr)
@@ -241,14 +315,115 @@ func validatePolicyForPickNPolicyType(policy *placementv1beta1.PlacementPolicy)
 	if policy.Affinity != nil && policy.Affinity.ClusterAffinity != nil {
 		allErr = append(allErr, validateClusterAffinity(policy.Affinity.ClusterAffinity, policy.PlacementType))
 	}
+	if policy.Affinity != nil && policy.Affinity.PlacementAntiAffinity != nil {
+		allErr = append(allErr, validatePlacementAntiAffinity(policy.Affinity.PlacementAntiAffinity, policy.PlacementType))
+	}
 	if len(policy.TopologySpreadConstraints) > 0 {
 		allErr = append(allErr, validateTopologySpreadConstraints(policy.TopologySpreadConstraints))
 	}
+	if rebalance := policy.RebalanceOnClusterJoin; rebalance != nil && (rebalance.TopologyKey == "") != (rebalance.MaxSkew == nil) {
+		allErr = append(allErr, fmt.Errorf("topologyKey and maxSkew of rebalanceOnClusterJoin must be specified together"))
+	}
+	if policy.ResourceFit != nil {
+		allErr = append(allErr, validateResourceFit(policy.ResourceFit))
+	}
+	if policy.WarmDependencies != nil {
+		allErr = append(allErr, validateWarmDependencies(policy.WarmDependencies))
+	}
+	if policy.KubernetesVersion != nil {
+		allErr = append(allErr, validateKubernetesVersionRange(policy.KubernetesVersion))
+	}
+	allErr = append(allErr, validateRequiredAPIs(policy.RequiredAPIs))
 	allErr = append(allErr, validateTolerations(policy.Tolerations))
 
 	return apiErrors.NewAggregate(allErr)
 }
 
+func validateWarmDependencies(warmDependencies *placementv1beta1.WarmDependencies) error {
+	allErr := make([]error, 0)
+	if len(warmDependencies.PlacementNames) == 0 {
+		allErr = append(allErr, fmt.Errorf("the placement names of warmDependencies cannot be empty"))
+	}
+	uniqueNames := make(map[string]bool)
+	for _, name := range warmDependencies.PlacementNames {
+		if uniqueNames[name] {
+			allErr = append(allErr, fmt.Errorf("the placement names of warmDependencies must be unique, %q is duplicated", name))
+			continue
+		}
+		uniqueNames[name] = true
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+func validateResourceFit(resourceFit *placementv1beta1.ResourceFit) error {
+	allErr := make([]error, 0)
+	for name, quantity := range resourceFit.Requests {
+		if name != corev1.ResourceCPU && name != corev1.ResourceMemory {
+			allErr = append(allErr, fmt.Errorf("resource %q is not supported in the requests of resourceFit, only %q and %q are supported", name, corev1.ResourceCPU, corev1.ResourceMemory))
+			continue
+		}
+		if quantity.Sign() < 0 {
+			allErr = append(allErr, fmt.Errorf("the request of resource %q in resourceFit cannot be negative", name))
+		}
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
+func validateKubernetesVersionRange(versionRange *placementv1beta1.KubernetesVersionRange) error {
+	if versionRange.Min == "" && versionRange.Max == "" {
+		return fmt.Errorf("at least one of min and max must be specified in kubernetesVersion")
+	}
+	var minVersion, maxVersion *utilversion.Version
+	var err error
+	if versionRange.Min != "" {
+		if minVersion, err = utilversion.ParseGeneric(versionRange.Min); err != nil {
+			return fmt.Errorf("the min version %q in kubernetesVersion is invalid: %w", versionRange.Min, err)
+		}
+	}
+	if versionRange.Max != "" {
+		if maxVersion, err = utilversion.ParseGeneric(versionRange.Max); err != nil {
+			return fmt.Errorf("the max version %q in kubernetesVersion is invalid: %w", versionRange.Max, err)
+		}
+	}
+	if minVersion == nil || maxVersion == nil {
+		return nil
+	}
+	// A max version without the patch version includes all the patch versions of its minor version.
+	if len(maxVersion.Components()) < 3 {
+		minVersion = utilversion.MajorMinor(minVersion.Major(), minVersion.Minor())
+	}
+	if maxVersion.LessThan(minVersion) {
+		return fmt.Errorf("the min version %q in kubernetesVersion cannot be greater than the max version %q", versionRange.Min, versionRange.Max)
+	}
+	return nil
+}
+
+func validateRequiredAPIs(requiredAPIs []string) error {
+	allErr := make([]error, 0)
+	uniqueGroupVersions := make(map[string]bool)
+	for _, groupVersion := range requiredAPIs {
+		gv, err := schema.ParseGroupVersion(groupVersion)
+		if err != nil || gv.Version == "" {
+			allErr = append(allErr, fmt.Errorf("the API group version %q in requiredAPIs is invalid, must be in the format of GROUP/VERSION, or VERSION for the core API group", groupVersion))
+			continue
+		}
+		if gv.Group != "" {
+			if errs := validation.IsDNS1123Subdomain(gv.Group); len(errs) > 0 {
+				allErr = append(allErr, fmt.Errorf("the API group of %q in requiredAPIs is invalid: %s", groupVersion, strings.Join(errs, "; ")))
+			}
+		}
+		if errs := validation.IsDNS1035Label(gv.Version); len(errs) > 0 {
+			allErr = append(allErr, fmt.Errorf("the API version of %q in requiredAPIs is invalid: %s", groupVersion, strings.Join(errs, "; ")))
+		}
+		if uniqueGroupVersions[groupVersion] {
+			allErr = append(allErr, fmt.Errorf("the API group versions in requiredAPIs must be unique, %q is duplicated", groupVersion))
+			continue
+		}
+		uniqueGroupVersions[groupVersion] = true
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
 func validateClusterAffinity(clusterAffinity *placementv1beta1.ClusterAffinity, placementType placementv1beta1.PlacementType) error {
 	allErr := make([]error, 0)
 	// Both RequiredDuringSchedulingIgnoredDuringExecution and PreferredDuringSchedulingIgnoredDuringExecution are optional fields, so validating only if non-nil/length is greater than zero
@@ -271,6 +446,21 @@ func validateClusterAffinity(clusterAffinity *placementv1beta1.ClusterAffinity,
 	return apiErrors.NewAggregate(allErr)
 }
 
+func validatePlacementAntiAffinity(placementAntiAffinity *placementv1beta1.PlacementAntiAffinity, placementType placementv1beta1.PlacementType) error {
+	allErr := make([]error, 0)
+	for i := range placementAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		allErr = append(allErr, validateLabelSelector(&placementAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[i].LabelSelector, "placement anti-affinity term"))
+	}
+	if len(placementAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution) > 0 && placementType == placementv1beta1.PickAllPlacementType {
+		allErr = append(allErr, fmt.Errorf("PreferredDuringSchedulingIgnoredDuringExecution of placementAntiAffinity will be ignored for placement policy type %s", placementType))
+	}
+	for i := range placementAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		// API server validation on object occurs before webhook is triggered hence not validating weight.
+		allErr = append(allErr, validateLabelSelector(&placementAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[i].PlacementAffinityTerm.LabelSelector, "placement anti-affinity term"))
+	}
+	return apiErrors.NewAggregate(allErr)
+}
+
 func validateTolerations(tolerations []placementv1beta1.Toleration) error {
 	allErr := make([]error, 0)
 	tolerationMap := make(map[placementv1beta1.Toleration]bool)
@@ -285,13 +475,20 @@ func validateTolerations(tolerations []placementv1beta1.Toleration) error {
 			if toleration.Value != "" {
 				allErr = append(allErr, fmt.Errorf(invalidTolerationErrFmt, toleration, "toleration value needs to be empty, when operator is Exists"))
 			}
-		case corev1.TolerationOpEqual:
+		case corev1.TolerationOpEqual, "":
+			// An empty operator is equivalent to the Equal operator.
 			if toleration.Key == "" {
 				allErr = append(allErr, fmt.Errorf(invalidTolerationErrFmt, toleration, "toleration key cannot be empty, when operator is Equal"))
 			}
 			for _, msg := range validation.IsValidLabelValue(toleration.Value) {
 				allErr = append(allErr, fmt.Errorf(invalidTolerationValueErrFmt, toleration, msg))
 			}
+		default:
+			allErr = append(allErr, fmt.Errorf(invalidTolerationErrFmt, toleration, fmt.Sprintf("unsupported operator %q, supported operators are %q and %q", toleration.Operator, corev1.TolerationOpEqual, corev1.TolerationOpExists)))
+		}
+		// An empty effect matches all taint effects; NoSchedule is the only taint effect supported by Fleet.
+		if toleration.Effect != "" && toleration.Effect != corev1.TaintEffectNoSchedule {
+			allErr = append(allErr, fmt.Errorf(invalidTolerationErrFmt, toleration, fmt.Sprintf("unsupported effect %q, only %q is supported", toleration.Effect, corev1.TaintEffectNoSchedule)))
 		}
 		if tolerationMap[toleration] {
 			allErr = append(allErr, fmt.Errorf(uniqueTolerationErrFmt, toleration))
@@ -316,56 +513,61 @@ func IsTolerationsUpdatedOrDeleted(oldTolerations []placementv1beta1.Toleration,
 
 func validateTopologySpreadConstraints(topologyConstraints []placementv1beta1.TopologySpreadConstraint) error {
 	allErr := make([]error, 0)
+	// The pair of topology key and the action to take when the constraint is unsatisfiable must
+	// be unique among all the topology spread constraints.
+	type topologySpreadConstraintKey struct {
+		topologyKey       string
+		whenUnsatisfiable placementv1beta1.UnsatisfiableConstraintAction
+	}
+	constraintMap := make(map[topologySpreadConstraintKey]bool)
 	for _, tc := range topologyConstraints {
-		if len(tc.WhenUnsatisfiable) > 0 && tc.WhenUnsatisfiable != placementv1beta1.DoNotSchedule && tc.WhenUnsatisfiable != placementv1beta1.ScheduleAnyway {
+		if tc.MaxSkew != nil && *tc.MaxSkew < 1 {
+			allErr = append(allErr, fmt.Errorf(invalidTopologySpreadConstraintErrFmt, tc.TopologyKey, fmt.Sprintf("maxSkew %d must be greater than or equal to 1", *tc.MaxSkew)))
+		}
+		if tc.TopologyKey == "" {
+			allErr = append(allErr, fmt.Errorf(invalidTopologySpreadConstraintErrFmt, tc.TopologyKey, "topologyKey cannot be empty"))
+		} else {
+			for _, msg := range validation.IsQualifiedName(tc.TopologyKey) {
+				allErr = append(allErr, fmt.Errorf(invalidTopologySpreadConstraintErrFmt, tc.TopologyKey, msg))
+			}
+		}
+		whenUnsatisfiable := tc.WhenUnsatisfiable
+		switch whenUnsatisfiable {
+		case "":
+			// DoNotSchedule is the default action.
+			whenUnsatisfiable = placementv1beta1.DoNotSchedule
+		case placementv1beta1.DoNotSchedule, placementv1beta1.ScheduleAnyway:
+		default:
 			allErr = append(allErr, fmt.Errorf("unknown unsatisfiable type %s", tc.WhenUnsatisfiable))
 		}
+		key := topologySpreadConstraintKey{topologyKey: tc.TopologyKey, whenUnsatisfiable: whenUnsatisfiable}
+		if constraintMap[key] {
+			allErr = append(allErr, fmt.Errorf(uniqueTopologySpreadConstraintErrFmt, tc.TopologyKey, whenUnsatisfiable))
+		}
+		constraintMap[key] = true
 	}
 	return apiErrors.NewAggregate(allErr)
 }
 
 func validateClusterSelector(clusterSelector *placementv1beta1.ClusterSelector) error {
 	allErr := make([]error, 0)
-	for _, clusterSelectorTerm := range clusterSelector.ClusterSelectorTerms {
-		// Since label selector is a required field in ClusterSelectorTerm, not checking to see if it's an empty object.
-		allErr = append(allErr, validateLabelSelector(clusterSelectorTerm.LabelSelector, "cluster selector"))
-
-		// Affinity is RequiredDuringSchedulingIgnoredDuringExecution, so check that PropertySorter is nil.
-		if clusterSelectorTerm.PropertySorter != nil {
-			allErr = append(allErr, fmt.Errorf("PropertySorter is not allowed for RequiredDuringSchedulingIgnoredDuringExecution affinity"))
-		}
-
-		// Affinity is RequiredDuringSchedulingIgnoredDuringExecution, so validate PropertySelector if exists
-		if clusterSelectorTerm.PropertySelector != nil {
-			allErr = append(allErr, validatePropertySelector(clusterSelectorTerm.PropertySelector))
-		}
+	for i := range clusterSelector.ClusterSelectorTerms {
+		allErr = append(allErr, clusterselector.Validate(&clusterSelector.ClusterSelectorTerms[i], clusterselector.RequiredAffinityUsage))
 	}
 	return apiErrors.NewAggregate(allErr)
 }
 
 func validatePreferredClusterSelectors(preferredClusterSelectors []placementv1beta1.PreferredClusterSelector) error {
 	allErr := make([]error, 0)
-	for _, preferredClusterSelector := range preferredClusterSelectors {
+	for i := range preferredClusterSelectors {
 		// API server validation on object occurs before webhook is triggered hence not validating weight.
-		allErr = append(allErr, validateLabelSelector(preferredClusterSelector.Preference.LabelSelector, "preferred cluster selector"))
-
-		// Affinity is PreferredDuringSchedulingIgnoredDuringExecution, so check that PropertySelector is nil.
-		if preferredClusterSelector.Preference.PropertySelector != nil {
-			allErr = append(allErr, fmt.Errorf("PropertySelector is not allowed for PreferredDuringSchedulingIgnoredDuringExecution affinity"))
-		}
-
-		if preferredClusterSelector.Preference.PropertySorter != nil {
-			allErr = append(allErr, validatePropertySorter(preferredClusterSelector.Preference.PropertySorter))
-		}
+		allErr = append(allErr, clusterselector.Validate(&preferredClusterSelectors[i].Preference, clusterselector.PreferredAffinityUsage))
 	}
 	return apiErrors.NewAggregate(allErr)
 }
 
 func validateLabelSelector(labelSelector *metav1.LabelSelector, parent string) error {
-	if _, err := metav1.LabelSelectorAsSelector(labelSelector); err != nil {
-		return fmt.Errorf("the labelSelector in %s %+v is invalid: %w", parent, labelSelector, err)
-	}
-	return nil
+	return clusterselector.ValidateLabelSelector(labelSelector, parent)
 }
 
 func validateRolloutStrategy(rolloutStrategy placementv1beta1.RolloutStrategy) error {
@@ -412,92 +614,3 @@ func validateRolloutStrategy(rolloutStrategy placementv1beta1.RolloutStrategy) e
 
 	return apiErrors.NewAggregate(allErr)
 }
-
-// validatePropertySelector validates the property selector
-func validatePropertySelector(propertySelector *placementv1beta1.PropertySelector) error {
-	return validatePropertySelectorRequirements(propertySelector.MatchExpressions)
-}
-
-func validatePropertySelectorRequirements(propertySelectorRequirements []placementv1beta1.PropertySelectorRequirement) error {
-	var allErr []error
-	for _, req := range propertySelectorRequirements {
-		if err := validateName(req.Name); err != nil {
-			allErr = append(allErr, fmt.Errorf("invalid property name %s: %w", req.Name, err))
-		}
-		if err := validateOperator(req.Operator, req.Values); err != nil {
-			allErr = append(allErr, err)
-		}
-		if err := validateValues(req.Values); err != nil {
-			allErr = append(allErr, fmt.Errorf("invalid values for property %s: %w", req.Name, err))
-		}
-		// TODO: Check for logical contradictions
-	}
-	return apiErrors.NewAggregate(allErr)
-}
-
-func validatePropertySorter(propertySorter *placementv1beta1.PropertySorter) error {
-	var allErr []error
-	if err := validateName(propertySorter.Name); err != nil {
-		allErr = append(allErr, err)
-	}
-	if propertySorter.SortOrder != placementv1beta1.Descending && propertySorter.SortOrder != placementv1beta1.Ascending {
-		allErr = append(allErr, fmt.Errorf("invalid property sort order %s", propertySorter.SortOrder))
-	}
-	return apiErrors.NewAggregate(allErr)
-}
-
-func validateName(name string) error {
-	// we expect the resource property names to be in this format `[PREFIX]/[CAPACITY_TYPE]-[RESOURCE_NAME]`.
-	if strings.HasPrefix(name, propertyprovider.ResourcePropertyNamePrefix) {
-		resourcePropertyName, _ := strings.CutPrefix(name, propertyprovider.ResourcePropertyNamePrefix)
-		// n=2 since we only care about the first segment to check capacity type.
-		segments := strings.SplitN(resourcePropertyName, "-", 2)
-		if len(segments) != 2 {
-			return fmt.Errorf("invalid resource property name %s, expected format is [PREFIX]/[CAPACITY_TYPE]-[RESOURCE_NAME]", name)
-		}
-		if !supportedResourceCapacityTypesMap[segments[0]] {
-			return fmt.Errorf("invalid capacity type in resource property name %s, supported values are %+v", name, resourceCapacityTypes)
-		}
-	}
-
-	if err := validation.IsQualifiedName(name); err != nil {
-		return fmt.Errorf("name is not a valid Kubernetes label name: %v", err)
-	}
-	return nil
-}
-
-func validateOperator(op placementv1beta1.PropertySelectorOperator, values []string) error {
-	// TODO: Restructure for Eq (bundle operator and value validation logic)
-	validOperators := map[placementv1beta1.PropertySelectorOperator]bool{
-		placementv1beta1.PropertySelectorGreaterThan:          true,
-		placementv1beta1.PropertySelectorGreaterThanOrEqualTo: true,
-		placementv1beta1.PropertySelectorLessThan:             true,
-		placementv1beta1.PropertySelectorLessThanOrEqualTo:    true,
-		placementv1beta1.PropertySelectorEqualTo:              true,
-		placementv1beta1.PropertySelectorNotEqualTo:           true,
-	}
-	if validOperators[op] && len(values) != 1 {
-		return fmt.Errorf("operator %s requires exactly one value, got %d", op, len(values))
-	}
-	return nil
-}
-
-func validateValues(values []string) error {
-	for _, value := range values {
-		if _, err := resource.ParseQuantity(value); err != nil {
-			return fmt.Errorf("value %s is not a valid resource.Quantity: %w", value, err)
-		}
-	}
-	return nil
-}
-
-func supportedResourceCapacityTypes() []string {
-	i := 0
-	capacityTypes := make([]string, len(supportedResourceCapacityTypesMap))
-	for key := range supportedResourceCapacityTypesMap {
-		capacityTypes[i] = key
-		i++
-	}
-	sort.Strings(capacityTypes)
-	return capacityTypes
-}
@@ -10,9 +10,11 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	fleetv1alpha1 "go.goms.io/fleet/apis/v1alpha1"
@@ -240,6 +242,44 @@ func TestValidateClusterResourcePlacement(t *testing.T) {
 				IsClusterScopedResource: true},
 			wantErrMsg: "the name field cannot have length exceeding 63",
 		},
+		"CRP that depends on itself": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.ClusterResourcePlacementSpec{
+					ResourceSelectors: []placementv1beta1.ClusterResourceSelector{resourceSelector},
+					Strategy: placementv1beta1.RolloutStrategy{
+						Type: placementv1beta1.RollingUpdateRolloutStrategyType,
+					},
+					DependsOn: []string{"crd-crp", "test-crp"},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true},
+			wantErr:    true,
+			wantErrMsg: "placement test-crp cannot depend on itself",
+		},
+		"CRP with duplicate dependencies": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.ClusterResourcePlacementSpec{
+					ResourceSelectors: []placementv1beta1.ClusterResourceSelector{resourceSelector},
+					Strategy: placementv1beta1.RolloutStrategy{
+						Type: placementv1beta1.RollingUpdateRolloutStrategyType,
+					},
+					DependsOn: []string{"crd-crp", "crd-crp"},
+				},
+			},
+			resourceInformer: &testinformer.FakeManager{
+				APIResources:            map[schema.GroupVersionKind]bool{utils.ClusterRoleGVK: true},
+				IsClusterScopedResource: true},
+			wantErr:    true,
+			wantErrMsg: "placement crd-crp must not be listed more than once",
+		},
 		"invalid Resource Selector with name & label selector": {
 			crp: &placementv1beta1.ClusterResourcePlacement{
 				ObjectMeta: metav1.ObjectMeta{
@@ -339,6 +379,84 @@ func TestValidateClusterResourcePlacement(t *testing.T) {
 	}
 }
 
+func TestValidateClusterResourcePlacementOffline(t *testing.T) {
+	tests := map[string]struct {
+		crp        *placementv1beta1.ClusterResourcePlacement
+		wantErr    bool
+		wantErrMsg string
+	}{
+		"valid CRP with a resource unknown to the hub cluster": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.ClusterResourcePlacementSpec{
+					ResourceSelectors: []placementv1beta1.ClusterResourceSelector{
+						{
+							Group:   "example.com",
+							Version: "v1",
+							Kind:    "Widget",
+							Name:    "test-widget",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		"invalid Resource Selector with name & label selector": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.ClusterResourcePlacementSpec{
+					ResourceSelectors: []placementv1beta1.ClusterResourceSelector{
+						{
+							Group:   "rbac.authorization.k8s.io",
+							Version: "v1",
+							Kind:    "ClusterRole",
+							Name:    "test-cluster-role",
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"test-key": "test-value"},
+							},
+						},
+					},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "the labelSelector and name fields are mutually exclusive in selector",
+		},
+		"invalid placement policy": {
+			crp: &placementv1beta1.ClusterResourcePlacement{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crp",
+				},
+				Spec: placementv1beta1.ClusterResourcePlacementSpec{
+					ResourceSelectors: []placementv1beta1.ClusterResourceSelector{resourceSelector},
+					Policy: &placementv1beta1.PlacementPolicy{
+						PlacementType:    placementv1beta1.PickNPlacementType,
+						NumberOfClusters: &negativeNumberOfClusters,
+					},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "the placement policy field is invalid",
+		},
+	}
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			RestMapper = nil
+			ResourceInformer = nil
+			gotErr := ValidateClusterResourcePlacementOffline(testCase.crp)
+			if (gotErr != nil) != testCase.wantErr {
+				t.Errorf("ValidateClusterResourcePlacementOffline() error = %v, wantErr %v", gotErr, testCase.wantErr)
+			}
+			if testCase.wantErr && !strings.Contains(gotErr.Error(), testCase.wantErrMsg) {
+				t.Errorf("ValidateClusterResourcePlacementOffline() got %v, should contain want %s", gotErr, testCase.wantErrMsg)
+			}
+		})
+	}
+}
+
 func TestValidateClusterResourcePlacement_RolloutStrategy(t *testing.T) {
 	var unavailablePeriodSeconds = -10
 
@@ -576,6 +694,64 @@ func TestValidateClusterResourcePlacement_PickFixedPlacementPolicy(t *testing.T)
 			wantErr:    true,
 			wantErrMsg: "tolerations needs to be empty for policy type PickFixed, only valid for PickAll/PickN",
 		},
+		"invalid placement policy - PickFixed with non-nil stickiness": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickFixedPlacementType,
+				ClusterNames:  []string{"test-cluster"},
+				Stickiness: &placementv1beta1.Stickiness{
+					ScoreBonus: 10,
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "stickiness must be nil for policy type PickFixed, only valid for PickN placement policy type",
+		},
+		"invalid placement policy - PickFixed with non-nil rebalanceOnClusterJoin": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:          placementv1beta1.PickFixedPlacementType,
+				ClusterNames:           []string{"test-cluster"},
+				RebalanceOnClusterJoin: &placementv1beta1.RebalanceOnClusterJoin{},
+			},
+			wantErr:    true,
+			wantErrMsg: "rebalanceOnClusterJoin must be nil for policy type PickFixed, only valid for PickN placement policy type",
+		},
+		"invalid placement policy - PickFixed with non-nil resourceFit": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickFixedPlacementType,
+				ClusterNames:  []string{"test-cluster"},
+				ResourceFit:   &placementv1beta1.ResourceFit{},
+			},
+			wantErr:    true,
+			wantErrMsg: "resourceFit must be nil for policy type PickFixed, only valid for PickAll/PickN placement policy types",
+		},
+		"invalid placement policy - PickFixed with non-nil warmDependencies": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickFixedPlacementType,
+				ClusterNames:  []string{"test-cluster"},
+				WarmDependencies: &placementv1beta1.WarmDependencies{
+					PlacementNames: []string{"ingress"},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "warmDependencies must be nil for policy type PickFixed, only valid for PickN placement policy type",
+		},
+		"invalid placement policy - PickFixed with non-nil kubernetesVersion": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:     placementv1beta1.PickFixedPlacementType,
+				ClusterNames:      []string{"test-cluster"},
+				KubernetesVersion: &placementv1beta1.KubernetesVersionRange{Min: "1.28"},
+			},
+			wantErr:    true,
+			wantErrMsg: "kubernetesVersion must be nil for policy type PickFixed, only valid for PickAll/PickN placement policy types",
+		},
+		"invalid placement policy - PickFixed with non-empty requiredAPIs": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickFixedPlacementType,
+				ClusterNames:  []string{"test-cluster"},
+				RequiredAPIs:  []string{"apps/v1"},
+			},
+			wantErr:    true,
+			wantErrMsg: "requiredAPIs must be empty for policy type PickFixed, only valid for PickAll/PickN placement policy types",
+		},
 	}
 
 	for testName, testCase := range tests {
@@ -597,6 +773,98 @@ func TestValidateClusterResourcePlacement_PickAllPlacementPolicy(t *testing.T) {
 		wantErr    bool
 		wantErrMsg string
 	}{
+		"invalid placement policy - PickAll with non-nil stickiness": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				Stickiness: &placementv1beta1.Stickiness{
+					ScoreBonus: 10,
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "stickiness must be nil for policy type PickAll, only valid for PickN placement policy type",
+		},
+		"invalid placement policy - PickAll with non-nil rebalanceOnClusterJoin": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:          placementv1beta1.PickAllPlacementType,
+				RebalanceOnClusterJoin: &placementv1beta1.RebalanceOnClusterJoin{},
+			},
+			wantErr:    true,
+			wantErrMsg: "rebalanceOnClusterJoin must be nil for policy type PickAll, only valid for PickN placement policy type",
+		},
+		"invalid placement policy - PickAll with non-nil warmDependencies": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				WarmDependencies: &placementv1beta1.WarmDependencies{
+					PlacementNames: []string{"ingress"},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "warmDependencies must be nil for policy type PickAll, only valid for PickN placement policy type",
+		},
+		"valid placement policy - PickAll with resourceFit": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				ResourceFit: &placementv1beta1.ResourceFit{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("4Gi"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		"valid placement policy - PickAll with kubernetesVersion": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				KubernetesVersion: &placementv1beta1.KubernetesVersionRange{
+					Min: "v1.28.5",
+					Max: "1.28",
+				},
+			},
+			wantErr: false,
+		},
+		"invalid placement policy - PickAll with preferred placementAntiAffinity": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				Affinity: &placementv1beta1.Affinity{
+					PlacementAntiAffinity: &placementv1beta1.PlacementAntiAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.WeightedPlacementAffinityTerm{
+							{
+								Weight: 10,
+								PlacementAffinityTerm: placementv1beta1.PlacementAffinityTerm{
+									LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "PreferredDuringSchedulingIgnoredDuringExecution of placementAntiAffinity will be ignored for placement policy type PickAll",
+		},
+		"invalid placement policy - PickAll with empty kubernetesVersion": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:     placementv1beta1.PickAllPlacementType,
+				KubernetesVersion: &placementv1beta1.KubernetesVersionRange{},
+			},
+			wantErr:    true,
+			wantErrMsg: "at least one of min and max must be specified in kubernetesVersion",
+		},
+		"valid placement policy - PickAll with requiredAPIs": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				RequiredAPIs:  []string{"v1", "apps/v1", "monitoring.coreos.com/v1"},
+			},
+			wantErr: false,
+		},
+		"invalid placement policy - PickAll with invalid requiredAPIs": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickAllPlacementType,
+				RequiredAPIs:  []string{"apps/v1/deployments"},
+			},
+			wantErr:    true,
+			wantErrMsg: `the API group version "apps/v1/deployments" in requiredAPIs is invalid`,
+		},
 		"invalid placement policy - PickAll with non-empty cluster names": {
 			policy: &placementv1beta1.PlacementPolicy{
 				PlacementType: placementv1beta1.PickAllPlacementType,
@@ -903,6 +1171,166 @@ func TestValidateClusterResourcePlacement_PickNPlacementPolicy(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "number of clusters cannot be -1 for policy type PickN",
 		},
+		"valid placement policy - PickN with rebalanceOnClusterJoin": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				RebalanceOnClusterJoin: &placementv1beta1.RebalanceOnClusterJoin{
+					TopologyKey: "region",
+					MaxSkew:     &positiveNumberOfClusters,
+				},
+			},
+			wantErr: false,
+		},
+		"invalid placement policy - PickN with rebalanceOnClusterJoin topologyKey but no maxSkew": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				RebalanceOnClusterJoin: &placementv1beta1.RebalanceOnClusterJoin{
+					TopologyKey: "region",
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "topologyKey and maxSkew of rebalanceOnClusterJoin must be specified together",
+		},
+		"invalid placement policy - PickN with unsupported resource in resourceFit requests": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				ResourceFit: &placementv1beta1.ResourceFit{
+					Requests: corev1.ResourceList{
+						corev1.ResourceEphemeralStorage: resource.MustParse("10Gi"),
+					},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: `resource "ephemeral-storage" is not supported in the requests of resourceFit`,
+		},
+		"invalid placement policy - PickN with negative resourceFit request": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				ResourceFit: &placementv1beta1.ResourceFit{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("-1"),
+					},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: `the request of resource "cpu" in resourceFit cannot be negative`,
+		},
+		"valid placement policy - PickN with warmDependencies": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				WarmDependencies: &placementv1beta1.WarmDependencies{
+					PlacementNames: []string{"ingress", "service-mesh"},
+				},
+			},
+			wantErr: false,
+		},
+		"valid placement policy - PickN with placementAntiAffinity": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				Affinity: &placementv1beta1.Affinity{
+					PlacementAntiAffinity: &placementv1beta1.PlacementAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.PlacementAffinityTerm{
+							{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}},
+						},
+						PreferredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.WeightedPlacementAffinityTerm{
+							{
+								Weight: 10,
+								PlacementAffinityTerm: placementv1beta1.PlacementAffinityTerm{
+									LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "cache"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		"invalid placement policy - PickN with empty warmDependencies": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				WarmDependencies: &placementv1beta1.WarmDependencies{},
+			},
+			wantErr:    true,
+			wantErrMsg: "the placement names of warmDependencies cannot be empty",
+		},
+		"invalid placement policy - PickN with duplicated warmDependencies": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				WarmDependencies: &placementv1beta1.WarmDependencies{
+					PlacementNames: []string{"ingress", "ingress"},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: `the placement names of warmDependencies must be unique, "ingress" is duplicated`,
+		},
+		"invalid placement policy - PickN with invalid label selector in placementAntiAffinity": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				Affinity: &placementv1beta1.Affinity{
+					PlacementAntiAffinity: &placementv1beta1.PlacementAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []placementv1beta1.PlacementAffinityTerm{
+							{
+								LabelSelector: metav1.LabelSelector{
+									MatchExpressions: []metav1.LabelSelectorRequirement{
+										{Key: "app", Operator: "Unknown", Values: []string{"db"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "the labelSelector in placement anti-affinity term",
+		},
+		"invalid placement policy - PickN with invalid kubernetesVersion": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:     placementv1beta1.PickNPlacementType,
+				NumberOfClusters:  &positiveNumberOfClusters,
+				KubernetesVersion: &placementv1beta1.KubernetesVersionRange{Max: "latest"},
+			},
+			wantErr:    true,
+			wantErrMsg: `the max version "latest" in kubernetesVersion is invalid`,
+		},
+		"invalid placement policy - PickN with min kubernetesVersion greater than max": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				KubernetesVersion: &placementv1beta1.KubernetesVersionRange{
+					Min: "1.29",
+					Max: "1.28.9",
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: `the min version "1.29" in kubernetesVersion cannot be greater than the max version "1.28.9"`,
+		},
+		"invalid placement policy - PickN with duplicated requiredAPIs": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				RequiredAPIs:     []string{"apps/v1", "apps/v1"},
+			},
+			wantErr:    true,
+			wantErrMsg: `the API group versions in requiredAPIs must be unique, "apps/v1" is duplicated`,
+		},
+		"invalid placement policy - PickN with invalid API group in requiredAPIs": {
+			policy: &placementv1beta1.PlacementPolicy{
+				PlacementType:    placementv1beta1.PickNPlacementType,
+				NumberOfClusters: &positiveNumberOfClusters,
+				RequiredAPIs:     []string{"Monitoring_CoreOS/v1"},
+			},
+			wantErr:    true,
+			wantErrMsg: `the API group of "Monitoring_CoreOS/v1" in requiredAPIs is invalid`,
+		},
 		"invalid placement policy - PickN with invalid label selector terms in RequiredDuringSchedulingIgnoredDuringExecution affinity": {
 			policy: &placementv1beta1.PlacementPolicy{
 				PlacementType:    placementv1beta1.PickNPlacementType,
@@ -1531,6 +1959,46 @@ func TestValidateTolerations(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "tolerations must be unique",
 		},
+		"valid toleration, operator is empty": {
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Key:   "key1",
+					Value: "value1",
+				},
+			},
+			wantErr: false,
+		},
+		"invalid toleration, key is empty, operator is empty": {
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Value: "value1",
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "toleration key cannot be empty, when operator is Equal",
+		},
+		"invalid toleration, unsupported operator": {
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: "In",
+					Value:    "value1",
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "unsupported operator \"In\"",
+		},
+		"invalid toleration, unsupported effect": {
+			tolerations: []placementv1beta1.Toleration{
+				{
+					Key:      "key1",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoExecute,
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "unsupported effect \"NoExecute\"",
+		},
 	}
 	for testName, testCase := range tests {
 		t.Run(testName, func(t *testing.T) {
@@ -1545,6 +2013,97 @@ func TestValidateTolerations(t *testing.T) {
 	}
 }
 
+func TestValidateTopologySpreadConstraints(t *testing.T) {
+	tests := map[string]struct {
+		topologySpreadConstraints []placementv1beta1.TopologySpreadConstraint
+		wantErr                   bool
+		wantErrMsg                string
+	}{
+		"valid topology spread constraints": {
+			topologySpreadConstraints: []placementv1beta1.TopologySpreadConstraint{
+				{
+					MaxSkew:           ptr.To(int32(1)),
+					TopologyKey:       "region",
+					WhenUnsatisfiable: placementv1beta1.DoNotSchedule,
+				},
+				{
+					MaxSkew:           ptr.To(int32(2)),
+					TopologyKey:       "region",
+					WhenUnsatisfiable: placementv1beta1.ScheduleAnyway,
+				},
+				{
+					TopologyKey: "kubernetes-fleet.io/zone",
+				},
+			},
+			wantErr: false,
+		},
+		"invalid topology spread constraint, maxSkew is zero": {
+			topologySpreadConstraints: []placementv1beta1.TopologySpreadConstraint{
+				{
+					MaxSkew:     ptr.To(int32(0)),
+					TopologyKey: "region",
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "maxSkew 0 must be greater than or equal to 1",
+		},
+		"invalid topology spread constraint, topologyKey is empty": {
+			topologySpreadConstraints: []placementv1beta1.TopologySpreadConstraint{
+				{
+					MaxSkew: ptr.To(int32(1)),
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "topologyKey cannot be empty",
+		},
+		"invalid topology spread constraint, topologyKey is invalid": {
+			topologySpreadConstraints: []placementv1beta1.TopologySpreadConstraint{
+				{
+					TopologyKey: "key:123*",
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character",
+		},
+		"invalid topology spread constraint, unknown unsatisfiable type": {
+			topologySpreadConstraints: []placementv1beta1.TopologySpreadConstraint{
+				{
+					TopologyKey:       "region",
+					WhenUnsatisfiable: "random-type",
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "unknown unsatisfiable type random-type",
+		},
+		"invalid topology spread constraints, duplicate terms": {
+			topologySpreadConstraints: []placementv1beta1.TopologySpreadConstraint{
+				{
+					MaxSkew:     ptr.To(int32(1)),
+					TopologyKey: "region",
+				},
+				{
+					MaxSkew:           ptr.To(int32(2)),
+					TopologyKey:       "region",
+					WhenUnsatisfiable: placementv1beta1.DoNotSchedule,
+				},
+			},
+			wantErr:    true,
+			wantErrMsg: "the pair of topologyKey and whenUnsatisfiable must be unique",
+		},
+	}
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			gotErr := validateTopologySpreadConstraints(testCase.topologySpreadConstraints)
+			if (gotErr != nil) != testCase.wantErr {
+				t.Errorf("validateTopologySpreadConstraints() error = %v, wantErr %v", gotErr, testCase.wantErr)
+			}
+			if testCase.wantErr && !strings.Contains(gotErr.Error(), testCase.wantErrMsg) {
+				t.Errorf("validateTopologySpreadConstraints() got %v, should contain want %s", gotErr, testCase.wantErrMsg)
+			}
+		})
+	}
+}
+
 func TestIsTolerationsUpdatedOrDeleted(t *testing.T) {
 	tests := map[string]struct {
 		oldTolerations []placementv1beta1.Toleration
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"fmt"
+	"strconv"
 
 	apiErrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -13,11 +14,27 @@ var (
 	invalidTaintKeyErrFmt   = "invalid taint key %+v: %s"
 	invalidTaintValueErrFmt = "invalid taint value %+v: %s"
 	uniqueTaintErrFmt       = "taint %+v already exists, taints must be unique"
+
+	invalidMaxPlacementsErrFmt = "invalid value %q of label %s: must be a non-negative integer"
 )
 
 // ValidateMemberCluster validates member cluster fields and returns error.
 func ValidateMemberCluster(mc clusterv1beta1.MemberCluster) error {
-	return validateTaints(mc.Spec.Taints)
+	return apiErrors.NewAggregate([]error{
+		validateTaints(mc.Spec.Taints),
+		validateMaxPlacements(mc.Labels),
+	})
+}
+
+func validateMaxPlacements(labels map[string]string) error {
+	val, ok := labels[clusterv1beta1.MaxPlacementsLabel]
+	if !ok {
+		return nil
+	}
+	if maxPlacements, err := strconv.Atoi(val); err != nil || maxPlacements < 0 {
+		return fmt.Errorf(invalidMaxPlacementsErrFmt, val, clusterv1beta1.MaxPlacementsLabel)
+	}
+	return nil
 }
 
 func validateTaints(taints []clusterv1beta1.Taint) error {
@@ -98,3 +98,41 @@ func TestValidateTaints(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMaxPlacements(t *testing.T) {
+	tests := map[string]struct {
+		labels     map[string]string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		"no max placements label": {
+			labels:  map[string]string{"env": "prod"},
+			wantErr: false,
+		},
+		"valid max placements": {
+			labels:  map[string]string{clusterv1beta1.MaxPlacementsLabel: "10"},
+			wantErr: false,
+		},
+		"invalid max placements, not an integer": {
+			labels:     map[string]string{clusterv1beta1.MaxPlacementsLabel: "ten"},
+			wantErr:    true,
+			wantErrMsg: `invalid value "ten" of label kubernetes-fleet.io/max-placements: must be a non-negative integer`,
+		},
+		"invalid max placements, negative": {
+			labels:     map[string]string{clusterv1beta1.MaxPlacementsLabel: "-1"},
+			wantErr:    true,
+			wantErrMsg: `invalid value "-1" of label kubernetes-fleet.io/max-placements: must be a non-negative integer`,
+		},
+	}
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			gotErr := validateMaxPlacements(testCase.labels)
+			if (gotErr != nil) != testCase.wantErr {
+				t.Errorf("validateMaxPlacements() error = %v, wantErr %v", gotErr, testCase.wantErr)
+			}
+			if testCase.wantErr && !strings.Contains(gotErr.Error(), testCase.wantErrMsg) {
+				t.Errorf("validateMaxPlacements() got %v, should contain want %s", gotErr, testCase.wantErrMsg)
+			}
+		})
+	}
+}
@@ -12,8 +12,11 @@ import (
 	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	fleetv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
+	"go.goms.io/fleet/pkg/utils/clusterselector"
+	"go.goms.io/fleet/pkg/utils/overrider"
 )
 
 // ValidateResourceOverride validates resource override fields and returns error.
@@ -89,15 +92,8 @@ func validateOverridePolicy(policy *fleetv1alpha1.OverridePolicy) error {
 	allErr := make([]error, 0)
 	for _, rule := range policy.OverrideRules {
 		if rule.ClusterSelector != nil {
-			for _, selector := range rule.ClusterSelector.ClusterSelectorTerms {
-				// Check that only label selector is supported
-				if selector.PropertySelector != nil || selector.PropertySorter != nil {
-					allErr = append(allErr, fmt.Errorf("invalid clusterSelector %v: only labelSelector is supported", selector))
-					continue
-				}
-				if selector.LabelSelector == nil {
-					allErr = append(allErr, fmt.Errorf("invalid clusterSelector %v: labelSelector is required", selector))
-				} else if err := validateLabelSelector(selector.LabelSelector, "cluster selector"); err != nil {
+			for i := range rule.ClusterSelector.ClusterSelectorTerms {
+				if err := clusterselector.Validate(&rule.ClusterSelector.ClusterSelectorTerms[i], clusterselector.OverrideUsage); err != nil {
 					allErr = append(allErr, err)
 				}
 			}
@@ -125,17 +121,84 @@ func validateJSONPatchOverride(jsonPatchOverrides []fleetv1alpha1.JSONPatchOverr
 
 	allErr := make([]error, 0)
 	for _, patch := range jsonPatchOverrides {
-		if err := validateJSONPatchOverridePath(patch.Path); err != nil {
+		// The test operation only reads the target location, so any field of the resource can be tested.
+		validatePath := validateJSONPatchOverridePath
+		if patch.Operator == fleetv1alpha1.JSONPatchOverrideOpTest {
+			validatePath = validateJSONPointer
+		}
+		if err := validatePath(patch.Path); err != nil {
+			allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: %w", patch, err))
+		}
+
+		if err := validateJSONPatchOverrideVariables(patch.Value.Raw); err != nil {
 			allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: %w", patch, err))
 		}
 
-		if patch.Operator == fleetv1alpha1.JSONPatchOverrideOpRemove && len(patch.Value.Raw) != 0 {
-			allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: remove operation cannot have value", patch))
+		switch patch.Operator {
+		case fleetv1alpha1.JSONPatchOverrideOpMove, fleetv1alpha1.JSONPatchOverrideOpCopy:
+			if len(patch.Value.Raw) != 0 {
+				allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: %s operation cannot have value", patch, patch.Operator))
+			}
+			// The move operation removes the value at the from location, while the copy operation only reads it.
+			validateFrom := validateJSONPointer
+			if patch.Operator == fleetv1alpha1.JSONPatchOverrideOpMove {
+				validateFrom = validateJSONPatchOverridePath
+			}
+			if patch.From == "" {
+				allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: %s operation must have from", patch, patch.Operator))
+			} else if err := validateFrom(patch.From); err != nil {
+				allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: invalid from: %w", patch, err))
+			}
+			continue
+		case fleetv1alpha1.JSONPatchOverrideOpRemove:
+			if len(patch.Value.Raw) != 0 {
+				allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: remove operation cannot have value", patch))
+			}
+		case fleetv1alpha1.JSONPatchOverrideOpTest:
+			if len(patch.Value.Raw) == 0 {
+				allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: test operation must have value", patch))
+			}
+		}
+		if patch.From != "" {
+			allErr = append(allErr, fmt.Errorf("invalid JSONPatchOverride %s: %s operation cannot have from", patch, patch.Operator))
 		}
 	}
 	return apierrors.NewAggregate(allErr)
 }
 
+// validateJSONPatchOverrideVariables checks if the cluster label variables in the value reference valid label keys.
+func validateJSONPatchOverrideVariables(value []byte) error {
+	keys, err := overrider.ClusterLabelVariableKeys(value)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return fmt.Errorf("invalid label key %q in variable: %s", key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// validateJSONPointer checks if the path is a syntactically valid JSON pointer without restricting
+// which fields it points to.
+func validateJSONPointer(path string) error {
+	if path == "" {
+		return fmt.Errorf("path cannot be empty")
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("path must start with /")
+	}
+
+	for _, part := range strings.Split(path, "/")[1:] {
+		if len(strings.TrimSpace(part)) == 0 {
+			return fmt.Errorf("path cannot contain empty string")
+		}
+	}
+	return nil
+}
+
 func validateJSONPatchOverridePath(path string) error {
 	if path == "" {
 		return fmt.Errorf("path cannot be empty")
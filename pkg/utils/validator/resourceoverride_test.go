@@ -381,14 +381,9 @@ func TestValidateResourceOverride(t *testing.T) {
 								ClusterSelector: &fleetv1beta1.ClusterSelector{
 									ClusterSelectorTerms: []fleetv1beta1.ClusterSelectorTerm{
 										{
-											PropertySelector: &fleetv1beta1.PropertySelector{
-												MatchExpressions: []fleetv1beta1.PropertySelectorRequirement{
-													{
-														Name:     "example",
-														Operator: fleetv1beta1.PropertySelectorGreaterThanOrEqualTo,
-														Values:   []string{"1"},
-													},
-												},
+											PropertySorter: &fleetv1beta1.PropertySorter{
+												Name:      "example",
+												SortOrder: fleetv1beta1.Ascending,
 											},
 										},
 									},
@@ -399,7 +394,7 @@ func TestValidateResourceOverride(t *testing.T) {
 				},
 			},
 			roList:     &fleetv1alpha1.ResourceOverrideList{},
-			wantErrMsg: fmt.Errorf("only labelSelector is supported"),
+			wantErrMsg: fmt.Errorf("propertySorter is not supported"),
 		},
 		"invalid resource override - fail validateResourceOverridePolicy with nil label selector": {
 			ro: fleetv1alpha1.ResourceOverride{
@@ -625,21 +620,16 @@ func TestValidateOverridePolicy(t *testing.T) {
 			},
 			wantErrMsg: nil,
 		},
-		"unsupported selector type - property selector": {
+		"unsupported selector type - property sorter": {
 			policy: &fleetv1alpha1.OverridePolicy{
 				OverrideRules: []fleetv1alpha1.OverrideRule{
 					{
 						ClusterSelector: &fleetv1beta1.ClusterSelector{
 							ClusterSelectorTerms: []fleetv1beta1.ClusterSelectorTerm{
 								{
-									PropertySelector: &fleetv1beta1.PropertySelector{
-										MatchExpressions: []fleetv1beta1.PropertySelectorRequirement{
-											{
-												Name:     "example",
-												Operator: fleetv1beta1.PropertySelectorGreaterThanOrEqualTo,
-												Values:   []string{"1"},
-											},
-										},
+									PropertySorter: &fleetv1beta1.PropertySorter{
+										Name:      "example",
+										SortOrder: fleetv1beta1.Ascending,
 									},
 								},
 							},
@@ -647,7 +637,7 @@ func TestValidateOverridePolicy(t *testing.T) {
 					},
 				},
 			},
-			wantErrMsg: fmt.Errorf("only labelSelector is supported"),
+			wantErrMsg: fmt.Errorf("propertySorter is not supported"),
 		},
 		"no cluster selector": {
 			policy: &fleetv1alpha1.OverridePolicy{
@@ -844,6 +834,106 @@ func TestValidateJSONPatchOverride(t *testing.T) {
 			},
 			wantErrMsg: errors.New("cannot override status fields"),
 		},
+		"valid json patch override - test operation on metadata": {
+			jsonPatchOverrides: []fleetv1alpha1.JSONPatchOverride{
+				{
+					Operator: fleetv1alpha1.JSONPatchOverrideOpTest,
+					Path:     "/metadata/name",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`"app"`)},
+				},
+			},
+			wantErrMsg: nil,
+		},
+		"invalid json patch override - test operation without value": {
+			jsonPatchOverrides: []fleetv1alpha1.JSONPatchOverride{
+				{
+					Operator: fleetv1alpha1.JSONPatchOverrideOpTest,
+					Path:     "/spec/replicas",
+				},
+			},
+			wantErrMsg: errors.New("test operation must have value"),
+		},
+		"valid json patch override - copy operation from metadata": {
+			jsonPatchOverrides: []fleetv1alpha1.JSONPatchOverride{
+				{
+					Operator: fleetv1alpha1.JSONPatchOverrideOpCopy,
+					From:     "/metadata/name",
+					Path:     "/metadata/labels/name",
+				},
+			},
+			wantErrMsg: nil,
+		},
+		"invalid json patch override - copy operation without from": {
+			jsonPatchOverrides: []fleetv1alpha1.JSONPatchOverride{
+				{
+					Operator: fleetv1alpha1.JSONPatchOverrideOpCopy,
+					Path:     "/metadata/labels/name",
+				},
+			},
+			wantErrMsg: errors.New("copy operation must have from"),
+		},
+		"invalid json patch override - move operation with value": {
+			jsonPatchOverrides: []fleetv1alpha1.JSONPatchOverride{
+				{
+					Operator: fleetv1alpha1.JSONPatchOverrideOpMove,
+					From:     "/spec/replicas",
+					Path:     "/spec/paused",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`1`)},
+				},
+			},
+			wantErrMsg: errors.New("move operation cannot have value"),
+		},
+		"invalid json patch override - move operation from metadata": {
+			jsonPatchOverrides: []fleetv1alpha1.JSONPatchOverride{
+				{
+					Operator: fleetv1alpha1.JSONPatchOverrideOpMove,
+					From:     "/metadata/name",
+					Path:     "/metadata/labels/name",
+				},
+			},
+			wantErrMsg: errors.New("invalid from: cannot override metadata fields except annotations and labels"),
+		},
+		"invalid json patch override - replace operation with from": {
+			jsonPatchOverrides: []fleetv1alpha1.JSONPatchOverride{
+				{
+					Operator: fleetv1alpha1.JSONPatchOverrideOpReplace,
+					From:     "/spec/replicas",
+					Path:     "/spec/paused",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`true`)},
+				},
+			},
+			wantErrMsg: errors.New("replace operation cannot have from"),
+		},
+		"valid json patch override - cluster label variable": {
+			jsonPatchOverrides: []fleetv1alpha1.JSONPatchOverride{
+				{
+					Operator: fleetv1alpha1.JSONPatchOverrideOpAdd,
+					Path:     "/metadata/labels/region",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`"${MEMBER-CLUSTER-LABEL:topology.kubernetes.io/region}"`)},
+				},
+			},
+			wantErrMsg: nil,
+		},
+		"invalid json patch override - invalid label key in cluster label variable": {
+			jsonPatchOverrides: []fleetv1alpha1.JSONPatchOverride{
+				{
+					Operator: fleetv1alpha1.JSONPatchOverrideOpAdd,
+					Path:     "/metadata/labels/region",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`"${MEMBER-CLUSTER-LABEL:}"`)},
+				},
+			},
+			wantErrMsg: errors.New("invalid label key"),
+		},
+		"invalid json patch override - unterminated cluster label variable": {
+			jsonPatchOverrides: []fleetv1alpha1.JSONPatchOverride{
+				{
+					Operator: fleetv1alpha1.JSONPatchOverrideOpAdd,
+					Path:     "/metadata/labels/region",
+					Value:    apiextensionsv1.JSON{Raw: []byte(`"${MEMBER-CLUSTER-LABEL:region"`)},
+				},
+			},
+			wantErrMsg: errors.New("must be terminated by }"),
+		},
 	}
 	for testName, tt := range tests {
 		t.Run(testName, func(t *testing.T) {
@@ -0,0 +1,42 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package version provides the version of the Fleet agent binaries.
+package version
+
+import (
+	"math"
+
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+)
+
+// Version is the version of the agent binary, e.g., v0.11.0.
+//
+// It is set at build time via
+// `-ldflags "-X go.goms.io/fleet/pkg/version.Version=<version>"`; see the Dockerfiles of the agents.
+var Version = "unknown"
+
+// MinorVersionSkew returns the number of minor versions by which two versions differ.
+//
+// An error is returned if either version is not a valid version. Versions of different major versions
+// are considered to be infinitely skewed, i.e., math.MaxInt is returned.
+func MinorVersionSkew(a, b string) (int, error) {
+	va, err := utilversion.ParseGeneric(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := utilversion.ParseGeneric(b)
+	if err != nil {
+		return 0, err
+	}
+	if va.Major() != vb.Major() {
+		return math.MaxInt, nil
+	}
+	skew := int(va.Minor()) - int(vb.Minor())
+	if skew < 0 {
+		skew = -skew
+	}
+	return skew, nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package version
+
+import (
+	"math"
+	"testing"
+)
+
+// TestMinorVersionSkew tests the MinorVersionSkew function.
+func TestMinorVersionSkew(t *testing.T) {
+	testCases := map[string]struct {
+		a       string
+		b       string
+		want    int
+		wantErr bool
+	}{
+		"same version": {
+			a:    "v0.11.0",
+			b:    "v0.11.3",
+			want: 0,
+		},
+		"newer minor version": {
+			a:    "v0.11.0",
+			b:    "v0.13.1",
+			want: 2,
+		},
+		"older minor version": {
+			a:    "0.11.0",
+			b:    "v0.10.0",
+			want: 1,
+		},
+		"different major versions": {
+			a:    "v1.0.0",
+			b:    "v0.11.0",
+			want: math.MaxInt,
+		},
+		"invalid version": {
+			a:       "unknown",
+			b:       "v0.11.0",
+			wantErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := MinorVersionSkew(tc.a, tc.b)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("MinorVersionSkew() = %v, want error %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("MinorVersionSkew() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package clusterresourceplacement
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	denyDependencyCycleFmt = "deny create/update v1beta1 CRP as its dependsOn field forms a cycle: %s"
+)
+
+// checkDependencyCycle checks if the dependencies of a CRP, along with those of the other CRPs on the
+// hub cluster, form a cycle that includes the CRP; it returns a reason for denial if so.
+//
+// Placements that do not exist (yet) are considered to have no dependencies.
+func checkDependencyCycle(ctx context.Context, c client.Reader, crp *placementv1beta1.ClusterResourcePlacement) (string, error) {
+	crpList := &placementv1beta1.ClusterResourcePlacementList{}
+	if err := c.List(ctx, crpList); err != nil {
+		return "", fmt.Errorf("failed to list CRPs: %w", err)
+	}
+	dependsOn := make(map[string][]string, len(crpList.Items)+1)
+	for idx := range crpList.Items {
+		dependsOn[crpList.Items[idx].Name] = crpList.Items[idx].Spec.DependsOn
+	}
+	// Use the dependencies in the request, which may differ from the stored ones on update.
+	dependsOn[crp.Name] = crp.Spec.DependsOn
+
+	if path := findDependencyPath(dependsOn, crp.Name, crp.Name); path != nil {
+		return fmt.Sprintf(denyDependencyCycleFmt, strings.Join(path, " -> ")), nil
+	}
+	return "", nil
+}
+
+// findDependencyPath returns the path, in placement names, from the placement from to the
+// placement to via the dependencies; it returns nil if there is no such path.
+func findDependencyPath(dependsOn map[string][]string, from, to string) []string {
+	visited := make(map[string]bool)
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		for _, dep := range dependsOn[name] {
+			if dep == to {
+				return []string{name, dep}
+			}
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+			if path := visit(dep); path != nil {
+				return append([]string{name}, path...)
+			}
+		}
+		return nil
+	}
+	return visit(from)
+}
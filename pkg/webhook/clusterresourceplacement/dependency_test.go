@@ -0,0 +1,97 @@
+package clusterresourceplacement
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+// newDependentCRP returns a CRP with the given name and dependencies.
+func newDependentCRP(name string, dependsOn ...string) *placementv1beta1.ClusterResourcePlacement {
+	return &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: placementv1beta1.ClusterResourcePlacementSpec{
+			DependsOn: dependsOn,
+		},
+	}
+}
+
+// TestCheckDependencyCycle tests the checkDependencyCycle function.
+func TestCheckDependencyCycle(t *testing.T) {
+	testCases := map[string]struct {
+		existing   []client.Object
+		crp        *placementv1beta1.ClusterResourcePlacement
+		wantReason string
+	}{
+		"dependency does not exist": {
+			crp: newDependentCRP("app", "crds"),
+		},
+		"chain of dependencies": {
+			existing: []client.Object{
+				newDependentCRP("crds"),
+				newDependentCRP("operator", "crds"),
+			},
+			crp: newDependentCRP("app", "operator", "crds"),
+		},
+		"direct cycle": {
+			existing: []client.Object{
+				newDependentCRP("crds", "app"),
+			},
+			crp:        newDependentCRP("app", "crds"),
+			wantReason: fmt.Sprintf(denyDependencyCycleFmt, "app -> crds -> app"),
+		},
+		"indirect cycle": {
+			existing: []client.Object{
+				newDependentCRP("crds", "app"),
+				newDependentCRP("operator", "crds"),
+			},
+			crp:        newDependentCRP("app", "operator"),
+			wantReason: fmt.Sprintf(denyDependencyCycleFmt, "app -> operator -> crds -> app"),
+		},
+		"cycle broken by the update": {
+			existing: []client.Object{
+				newDependentCRP("crds", "app"),
+				newDependentCRP("app", "crds"),
+			},
+			crp: newDependentCRP("crds", "operator"),
+		},
+		"cycle not including the placement": {
+			existing: []client.Object{
+				newDependentCRP("crds", "operator"),
+				newDependentCRP("operator", "crds"),
+			},
+			crp: newDependentCRP("app", "operator"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := placementv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add placement v1beta1 scheme: %v", err)
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tc.existing...).
+				Build()
+
+			reason, err := checkDependencyCycle(context.Background(), fakeClient, tc.crp)
+			if err != nil {
+				t.Fatalf("checkDependencyCycle() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(reason, tc.wantReason); diff != "" {
+				t.Errorf("checkDependencyCycle() reason mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
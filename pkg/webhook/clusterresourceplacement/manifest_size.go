@@ -0,0 +1,88 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/resource"
+)
+
+const (
+	// maxManifestSizeWarnings caps the number of warnings returned for the manifests that are too large.
+	maxManifestSizeWarnings = 5
+
+	warnManifestTooLargeFmt = "the selected resource %s is %d bytes, which exceeds the limit of %d bytes, and will fail to be placed"
+	warnMoreManifestsFmt    = "%d more selected resources exceed the limit of %d bytes"
+)
+
+// checkSelectedManifestSizes returns the warnings for the resources, in the latest resource snapshot of a CRP,
+// which are too large to be placed in a work.
+//
+// The resources of a CRP are only known after the CRP is created, so the check can only warn on update.
+func checkSelectedManifestSizes(ctx context.Context, c client.Reader, limits resource.ManifestLimits, crpName string) ([]string, error) {
+	if limits.MaxSizeOfManifest() == 0 {
+		return nil, nil
+	}
+	masterList := &placementv1beta1.ClusterResourceSnapshotList{}
+	if err := c.List(ctx, masterList, client.MatchingLabels{
+		placementv1beta1.CRPTrackingLabel:      crpName,
+		placementv1beta1.IsLatestSnapshotLabel: "true",
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list the latest resource snapshots: %w", err)
+	}
+	if len(masterList.Items) != 1 {
+		return nil, nil
+	}
+	index, ok := masterList.Items[0].Labels[placementv1beta1.ResourceIndexLabel]
+	if !ok {
+		return nil, nil
+	}
+	snapshotList := &placementv1beta1.ClusterResourceSnapshotList{}
+	if err := c.List(ctx, snapshotList, client.MatchingLabels{
+		placementv1beta1.CRPTrackingLabel:   crpName,
+		placementv1beta1.ResourceIndexLabel: index,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list the resource snapshots of index %s: %w", index, err)
+	}
+
+	var warnings []string
+	tooLarge := 0
+	for i := range snapshotList.Items {
+		for _, res := range snapshotList.Items[i].Spec.SelectedResources {
+			size := len(res.Raw)
+			if !limits.ManifestTooLarge(size) {
+				continue
+			}
+			tooLarge++
+			if tooLarge > maxManifestSizeWarnings {
+				continue
+			}
+			var obj unstructured.Unstructured
+			if err := obj.UnmarshalJSON(res.Raw); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal a selected resource: %w", err)
+			}
+			warnings = append(warnings, fmt.Sprintf(warnManifestTooLargeFmt, describeObject(&obj), size, limits.MaxSizeOfManifest()))
+		}
+	}
+	if tooLarge > maxManifestSizeWarnings {
+		warnings = append(warnings, fmt.Sprintf(warnMoreManifestsFmt, tooLarge-maxManifestSizeWarnings, limits.MaxSizeOfManifest()))
+	}
+	return warnings, nil
+}
+
+// describeObject returns a short description of an object for the warnings, e.g., "Deployment app/web".
+func describeObject(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", obj.GetKind(), obj.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
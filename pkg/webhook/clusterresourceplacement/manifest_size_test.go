@@ -0,0 +1,138 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package clusterresourceplacement
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/resource"
+)
+
+// newConfigMapContent returns the resource content of a configMap whose data is padded to make it large.
+func newConfigMapContent(name string, padding int) placementv1beta1.ResourceContent {
+	raw := fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":%q,"namespace":"app"},"data":{"key":%q}}`,
+		name, strings.Repeat("a", padding))
+	return placementv1beta1.ResourceContent{RawExtension: runtime.RawExtension{Raw: []byte(raw)}}
+}
+
+// newTestResourceSnapshot returns a resource snapshot of the CRP "crp" with the given index.
+func newTestResourceSnapshot(name, index string, isLatest bool, contents ...placementv1beta1.ResourceContent) *placementv1beta1.ClusterResourceSnapshot {
+	snapshot := &placementv1beta1.ClusterResourceSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				placementv1beta1.CRPTrackingLabel:   "crp",
+				placementv1beta1.ResourceIndexLabel: index,
+			},
+		},
+		Spec: placementv1beta1.ResourceSnapshotSpec{
+			SelectedResources: contents,
+		},
+	}
+	if isLatest {
+		snapshot.Labels[placementv1beta1.IsLatestSnapshotLabel] = "true"
+	}
+	return snapshot
+}
+
+// TestCheckSelectedManifestSizes tests the checkSelectedManifestSizes function.
+func TestCheckSelectedManifestSizes(t *testing.T) {
+	small := newConfigMapContent("small", 10)
+	large := newConfigMapContent("large", 1000)
+	largeSize := len(large.Raw)
+	limits := resource.ManifestLimits{MaxManifestSize: 500}
+
+	manyLarge := make([]placementv1beta1.ResourceContent, maxManifestSizeWarnings+2)
+	for i := range manyLarge {
+		manyLarge[i] = newConfigMapContent(fmt.Sprintf("large-%d", i), 1000)
+	}
+	var manyLargeWarnings []string
+	for i := 0; i < maxManifestSizeWarnings; i++ {
+		manyLargeWarnings = append(manyLargeWarnings, fmt.Sprintf(warnManifestTooLargeFmt, fmt.Sprintf("ConfigMap app/large-%d", i), len(manyLarge[i].Raw), 500))
+	}
+	manyLargeWarnings = append(manyLargeWarnings, fmt.Sprintf(warnMoreManifestsFmt, 2, 500))
+
+	testCases := map[string]struct {
+		limits       resource.ManifestLimits
+		existing     []client.Object
+		wantWarnings []string
+	}{
+		"no limits": {
+			existing: []client.Object{
+				newTestResourceSnapshot("crp-0-snapshot", "0", true, large),
+			},
+		},
+		"no resource snapshot": {
+			limits: limits,
+		},
+		"all resources fit": {
+			limits: limits,
+			existing: []client.Object{
+				newTestResourceSnapshot("crp-0-snapshot", "0", true, small),
+			},
+		},
+		"large resource in an old snapshot": {
+			limits: limits,
+			existing: []client.Object{
+				newTestResourceSnapshot("crp-0-snapshot", "0", false, large),
+				newTestResourceSnapshot("crp-1-snapshot", "1", true, small),
+			},
+		},
+		"large resource in a sub-indexed snapshot": {
+			limits: limits,
+			existing: []client.Object{
+				newTestResourceSnapshot("crp-1-snapshot", "1", true, small),
+				newTestResourceSnapshot("crp-1-0", "1", false, large),
+			},
+			wantWarnings: []string{fmt.Sprintf(warnManifestTooLargeFmt, "ConfigMap app/large", largeSize, 500)},
+		},
+		"large resource exceeding the work size": {
+			limits: resource.ManifestLimits{MaxWorkSize: 500},
+			existing: []client.Object{
+				newTestResourceSnapshot("crp-0-snapshot", "0", true, small, large),
+			},
+			wantWarnings: []string{fmt.Sprintf(warnManifestTooLargeFmt, "ConfigMap app/large", largeSize, 500)},
+		},
+		"too many large resources": {
+			limits: limits,
+			existing: []client.Object{
+				newTestResourceSnapshot("crp-0-snapshot", "0", true, manyLarge...),
+			},
+			wantWarnings: manyLargeWarnings,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := placementv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatalf("failed to add placement v1beta1 scheme: %v", err)
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tc.existing...).
+				Build()
+
+			warnings, err := checkSelectedManifestSizes(context.Background(), fakeClient, tc.limits, "crp")
+			if err != nil {
+				t.Fatalf("checkSelectedManifestSizes() = %v, want no error", err)
+			}
+			if diff := cmp.Diff(warnings, tc.wantWarnings); diff != "" {
+				t.Errorf("checkSelectedManifestSizes() warnings mismatch (-got, +want):\n%s", diff)
+			}
+		})
+	}
+}
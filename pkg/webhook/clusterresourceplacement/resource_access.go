@@ -0,0 +1,118 @@
+package clusterresourceplacement
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils/validator"
+)
+
+const (
+	denyResourceAccessFmt = "deny create/update v1beta1 CRP as user %s cannot %s %s on the hub cluster: %s"
+)
+
+// resourceAccessCheck is an access check the creator of a CRP must pass on the hub cluster.
+type resourceAccessCheck struct {
+	attributes authorizationv1.ResourceAttributes
+	// description describes the resources being checked in the denial message.
+	description string
+}
+
+// resourceAccessChecksFor returns the access checks for a resource selector in a CRP.
+//
+// The creator of a CRP must be able to read the resources the selector selects, i.e., to get
+// the resource if it is selected by name, or to list the resources otherwise. Selecting a namespace
+// places all the resources in the namespace, including secrets; as a result, the creator must also
+// be able to list the secrets in the namespace (or in all namespaces, if the namespaces are selected
+// by labels).
+func resourceAccessChecksFor(selector placementv1beta1.ClusterResourceSelector) ([]resourceAccessCheck, error) {
+	mapping, err := validator.RestMapper.RESTMapping(schema.GroupKind{Group: selector.Group, Kind: selector.Kind}, selector.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GVR of the selector: %w", err)
+	}
+	gvr := mapping.Resource
+
+	check := resourceAccessCheck{
+		attributes: authorizationv1.ResourceAttributes{
+			Verb:     "list",
+			Group:    gvr.Group,
+			Version:  gvr.Version,
+			Resource: gvr.Resource,
+		},
+		description: gvr.GroupResource().String(),
+	}
+	if selector.Name != "" {
+		check.attributes.Verb = "get"
+		check.attributes.Name = selector.Name
+		check.description = fmt.Sprintf("%s %q", gvr.GroupResource().String(), selector.Name)
+	}
+	checks := []resourceAccessCheck{check}
+
+	if selector.Group == "" && selector.Kind == "Namespace" {
+		secretsCheck := resourceAccessCheck{
+			attributes: authorizationv1.ResourceAttributes{
+				Verb:      "list",
+				Version:   "v1",
+				Resource:  "secrets",
+				Namespace: selector.Name,
+			},
+			description: "secrets in all namespaces",
+		}
+		if selector.Name != "" {
+			secretsCheck.description = fmt.Sprintf("secrets in namespace %q", selector.Name)
+		}
+		checks = append(checks, secretsCheck)
+	}
+	return checks, nil
+}
+
+// checkResourceAccess checks, via SubjectAccessReviews, whether the user can read all the resources
+// selected by a CRP on the hub cluster; it returns a denial reason if the user cannot.
+func checkResourceAccess(ctx context.Context, c client.Client, userInfo authenticationv1.UserInfo, selectors []placementv1beta1.ClusterResourceSelector) (string, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(userInfo.Extra))
+	for k, v := range userInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+
+	for _, selector := range selectors {
+		checks, err := resourceAccessChecksFor(selector)
+		if err != nil {
+			return "", err
+		}
+		for i := range checks {
+			sar := &authorizationv1.SubjectAccessReview{
+				Spec: authorizationv1.SubjectAccessReviewSpec{
+					ResourceAttributes: &checks[i].attributes,
+					User:               userInfo.Username,
+					Groups:             userInfo.Groups,
+					UID:                userInfo.UID,
+					Extra:              extra,
+				},
+			}
+			if err := c.Create(ctx, sar); err != nil {
+				return "", fmt.Errorf("failed to create subject access review: %w", err)
+			}
+			if !sar.Status.Allowed {
+				return fmt.Sprintf(denyResourceAccessFmt, userInfo.Username, checks[i].attributes.Verb, checks[i].description, sar.Status.Reason), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// needsResourceAccessCheck returns true if the resource access of the user should be checked
+// for a CRP create/update request, i.e., the CRP is being created, or its resource selectors
+// are being updated.
+func needsResourceAccessCheck(crp, oldCRP *placementv1beta1.ClusterResourcePlacement) bool {
+	if crp.DeletionTimestamp != nil {
+		return false
+	}
+	return oldCRP == nil || !equality.Semantic.DeepEqual(crp.Spec.ResourceSelectors, oldCRP.Spec.ResourceSelectors)
+}
@@ -0,0 +1,176 @@
+package clusterresourceplacement
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/validator"
+)
+
+// TestCheckResourceAccess tests the checkResourceAccess function.
+func TestCheckResourceAccess(t *testing.T) {
+	userInfo := authenticationv1.UserInfo{
+		Username: "test-user",
+		Groups:   []string{"test-group"},
+	}
+	namespaceSelector := placementv1beta1.ClusterResourceSelector{
+		Group:   "",
+		Version: "v1",
+		Kind:    "Namespace",
+		Name:    "app",
+	}
+	namespaceLabelSelector := placementv1beta1.ClusterResourceSelector{
+		Group:         "",
+		Version:       "v1",
+		Kind:          "Namespace",
+		LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+	}
+
+	testCases := map[string]struct {
+		selectors    []placementv1beta1.ClusterResourceSelector
+		allowed      func(attrs *authorizationv1.ResourceAttributes) bool
+		createErr    error
+		wantReviewed []authorizationv1.ResourceAttributes
+		wantDenied   bool
+		wantErr      bool
+	}{
+		"allowed to get a cluster role by name": {
+			selectors: []placementv1beta1.ClusterResourceSelector{resourceSelector},
+			allowed:   func(_ *authorizationv1.ResourceAttributes) bool { return true },
+			wantReviewed: []authorizationv1.ResourceAttributes{
+				{Verb: "get", Group: utils.ClusterRoleGVR.Group, Version: utils.ClusterRoleGVR.Version, Resource: utils.ClusterRoleGVR.Resource, Name: resourceSelector.Name},
+			},
+		},
+		"allowed to read a namespace and its secrets": {
+			selectors: []placementv1beta1.ClusterResourceSelector{namespaceSelector},
+			allowed:   func(_ *authorizationv1.ResourceAttributes) bool { return true },
+			wantReviewed: []authorizationv1.ResourceAttributes{
+				{Verb: "get", Version: "v1", Resource: "namespaces", Name: "app"},
+				{Verb: "list", Version: "v1", Resource: "secrets", Namespace: "app"},
+			},
+		},
+		"not allowed to list secrets in the selected namespaces": {
+			selectors: []placementv1beta1.ClusterResourceSelector{namespaceLabelSelector},
+			allowed: func(attrs *authorizationv1.ResourceAttributes) bool {
+				return attrs.Resource != "secrets"
+			},
+			wantReviewed: []authorizationv1.ResourceAttributes{
+				{Verb: "list", Version: "v1", Resource: "namespaces"},
+				{Verb: "list", Version: "v1", Resource: "secrets"},
+			},
+			wantDenied: true,
+		},
+		"failed to create subject access review": {
+			selectors: []placementv1beta1.ClusterResourceSelector{resourceSelector},
+			createErr: errors.New("internal error"),
+			wantErr:   true,
+		},
+		"failed to map the selector": {
+			selectors: []placementv1beta1.ClusterResourceSelector{
+				{Group: "example.com", Version: "v1", Kind: "Unknown"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			validator.RestMapper = utils.TestMapper{}
+			var gotReviewed []authorizationv1.ResourceAttributes
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(runtime.NewScheme()).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+						if tc.createErr != nil {
+							return tc.createErr
+						}
+						sar, ok := obj.(*authorizationv1.SubjectAccessReview)
+						if !ok {
+							t.Fatalf("Create() got object %T, want a subject access review", obj)
+						}
+						if sar.Spec.User != userInfo.Username || !cmp.Equal(sar.Spec.Groups, userInfo.Groups) {
+							t.Errorf("subject access review user = %s %v, want %s %v", sar.Spec.User, sar.Spec.Groups, userInfo.Username, userInfo.Groups)
+						}
+						gotReviewed = append(gotReviewed, *sar.Spec.ResourceAttributes)
+						sar.Status.Allowed = tc.allowed(sar.Spec.ResourceAttributes)
+						return nil
+					},
+				}).
+				Build()
+
+			reason, err := checkResourceAccess(context.Background(), fakeClient, userInfo, tc.selectors)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("checkResourceAccess() = %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if gotDenied := reason != ""; gotDenied != tc.wantDenied {
+				t.Errorf("checkResourceAccess() reason = %q, want denied %t", reason, tc.wantDenied)
+			}
+			if diff := cmp.Diff(tc.wantReviewed, gotReviewed); diff != "" {
+				t.Errorf("reviewed resource attributes mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestNeedsResourceAccessCheck tests the needsResourceAccessCheck function.
+func TestNeedsResourceAccessCheck(t *testing.T) {
+	crp := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-crp"},
+		Spec: placementv1beta1.ClusterResourcePlacementSpec{
+			ResourceSelectors: []placementv1beta1.ClusterResourceSelector{resourceSelector},
+		},
+	}
+	updatedSelectorsCRP := crp.DeepCopy()
+	updatedSelectorsCRP.Spec.ResourceSelectors[0].Name = "other-cluster-role"
+	deletingCRP := crp.DeepCopy()
+	deletingCRP.DeletionTimestamp = ptr.To(metav1.Now())
+
+	testCases := map[string]struct {
+		crp    *placementv1beta1.ClusterResourcePlacement
+		oldCRP *placementv1beta1.ClusterResourcePlacement
+		want   bool
+	}{
+		"create": {
+			crp:  crp,
+			want: true,
+		},
+		"update with the same resource selectors": {
+			crp:    crp,
+			oldCRP: crp.DeepCopy(),
+			want:   false,
+		},
+		"update with different resource selectors": {
+			crp:    updatedSelectorsCRP,
+			oldCRP: crp,
+			want:   true,
+		},
+		"deleting": {
+			crp:    deletingCRP,
+			oldCRP: updatedSelectorsCRP,
+			want:   false,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := needsResourceAccessCheck(tc.crp, tc.oldCRP); got != tc.want {
+				t.Errorf("needsResourceAccessCheck() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
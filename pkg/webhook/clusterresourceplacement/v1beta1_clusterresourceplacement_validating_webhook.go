@@ -8,13 +8,16 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	"go.goms.io/fleet/pkg/utils"
+	"go.goms.io/fleet/pkg/utils/resource"
 	"go.goms.io/fleet/pkg/utils/validator"
+	"go.goms.io/fleet/pkg/webhook/fleetsize"
 )
 
 const (
@@ -30,24 +33,43 @@ var (
 
 type clusterResourcePlacementValidator struct {
 	decoder webhook.AdmissionDecoder
+	client  client.Client
+	// enableResourceAccessCheck, if set, makes the validator check that the user who creates or updates
+	// a CRP can read the selected resources on the hub cluster, so that a user cannot exfiltrate resources
+	// they cannot read by placing them to a member cluster they control.
+	enableResourceAccessCheck bool
+	// fleetSizeLimits is the fleet size guardrails which cap the number of CRPs and the number of
+	// clusters a CRP can select.
+	fleetSizeLimits fleetsize.Limits
+	// manifestLimits caps the size of the manifests placed in a work; the validator warns about the selected
+	// resources which exceed the limits.
+	manifestLimits resource.ManifestLimits
 }
 
 // Add registers the webhook for K8s bulit-in object types.
-func Add(mgr manager.Manager) error {
+func Add(mgr manager.Manager, enableResourceAccessCheck bool, fleetSizeLimits fleetsize.Limits, manifestLimits resource.ManifestLimits) error {
 	hookServer := mgr.GetWebhookServer()
-	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &clusterResourcePlacementValidator{admission.NewDecoder(mgr.GetScheme())}})
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &clusterResourcePlacementValidator{
+		decoder:                   admission.NewDecoder(mgr.GetScheme()),
+		client:                    mgr.GetClient(),
+		enableResourceAccessCheck: enableResourceAccessCheck,
+		fleetSizeLimits:           fleetSizeLimits,
+		manifestLimits:            manifestLimits,
+	}})
 	return nil
 }
 
 // Handle clusterResourcePlacementValidator handles create, update CRP requests.
-func (v *clusterResourcePlacementValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+func (v *clusterResourcePlacementValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
 	var crp placementv1beta1.ClusterResourcePlacement
+	var warnings []string
 	if req.Operation == admissionv1.Create || req.Operation == admissionv1.Update {
 		klog.V(2).InfoS("handling CRP", "operation", req.Operation, "namespacedName", types.NamespacedName{Name: req.Name})
 		if err := v.decoder.Decode(req, &crp); err != nil {
 			klog.ErrorS(err, "failed to decode v1beta1 CRP object for create/update operation", "userName", req.UserInfo.Username, "groups", req.UserInfo.Groups)
 			return admission.Errored(http.StatusBadRequest, err)
 		}
+		var oldCRPPtr *placementv1beta1.ClusterResourcePlacement
 		if req.Operation == admissionv1.Update {
 			var oldCRP placementv1beta1.ClusterResourcePlacement
 			if err := v.decoder.DecodeRaw(req.OldObject, &oldCRP); err != nil {
@@ -69,12 +91,57 @@ func (v *clusterResourcePlacementValidator) Handle(_ context.Context, req admiss
 			if validator.IsTolerationsUpdatedOrDeleted(oldCRP.Tolerations(), crp.Tolerations()) {
 				return admission.Denied("tolerations have been updated/deleted, only additions to tolerations are allowed")
 			}
+			oldCRPPtr = &oldCRP
 		}
 		if err := validator.ValidateClusterResourcePlacement(&crp); err != nil {
 			klog.V(2).InfoS("v1beta1 cluster resource placement has invalid fields, request is denied", "operation", req.Operation, "namespacedName", types.NamespacedName{Name: crp.Name})
 			return admission.Denied(fmt.Sprintf(denyCreateUpdateInvalidCRPFmt, err))
 		}
+		if reason := fleetsize.CheckBindingsPerPlacement(v.fleetSizeLimits, &crp, oldCRPPtr); reason != "" {
+			klog.V(2).InfoS("v1beta1 cluster resource placement selects too many clusters, request is denied", "operation", req.Operation, "namespacedName", types.NamespacedName{Name: crp.Name})
+			return admission.Denied(reason)
+		}
+		if req.Operation == admissionv1.Create {
+			reason, err := fleetsize.CheckPlacementCount(ctx, v.client, v.fleetSizeLimits, crp.Name)
+			if err != nil {
+				klog.ErrorS(err, "failed to check the number of cluster resource placements", "namespacedName", types.NamespacedName{Name: crp.Name})
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+			if reason != "" {
+				klog.V(2).InfoS("the fleet has too many cluster resource placements, request is denied", "operation", req.Operation, "namespacedName", types.NamespacedName{Name: crp.Name})
+				return admission.Denied(reason)
+			}
+		}
+		if len(crp.Spec.DependsOn) > 0 {
+			reason, err := checkDependencyCycle(ctx, v.client, &crp)
+			if err != nil {
+				klog.ErrorS(err, "failed to check the dependencies of the CRP for cycles", "namespacedName", types.NamespacedName{Name: crp.Name})
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+			if reason != "" {
+				klog.V(2).InfoS("v1beta1 cluster resource placement has cyclic dependencies, request is denied", "operation", req.Operation, "namespacedName", types.NamespacedName{Name: crp.Name})
+				return admission.Denied(reason)
+			}
+		}
+		if v.enableResourceAccessCheck && needsResourceAccessCheck(&crp, oldCRPPtr) {
+			reason, err := checkResourceAccess(ctx, v.client, req.UserInfo, crp.Spec.ResourceSelectors)
+			if err != nil {
+				klog.ErrorS(err, "failed to check resource access of the user", "user", req.UserInfo.Username, "namespacedName", types.NamespacedName{Name: crp.Name})
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+			if reason != "" {
+				klog.V(2).InfoS("user cannot read the selected resources, request is denied", "operation", req.Operation, "user", req.UserInfo.Username, "namespacedName", types.NamespacedName{Name: crp.Name})
+				return admission.Denied(reason)
+			}
+		}
+		if req.Operation == admissionv1.Update {
+			var err error
+			// The warnings are best effort; failing to check the selected resources does not block the request.
+			if warnings, err = checkSelectedManifestSizes(ctx, v.client, v.manifestLimits, crp.Name); err != nil {
+				klog.ErrorS(err, "failed to check the sizes of the selected resources", "namespacedName", types.NamespacedName{Name: crp.Name})
+			}
+		}
 	}
 	klog.V(2).InfoS("user is allowed to modify v1beta1 cluster resource placement", "operation", req.Operation, "user", req.UserInfo.Username, "group", req.UserInfo.Groups, "namespacedName", types.NamespacedName{Name: crp.Name})
-	return admission.Allowed("any user is allowed to modify v1beta1 CRP")
+	return admission.Allowed("any user is allowed to modify v1beta1 CRP").WithWarnings(warnings...)
 }
@@ -0,0 +1,112 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+// Package fleetsize features the fleet size guardrails, which cap the number of member clusters,
+// cluster resource placements, and bindings per placement on the hub cluster, so that runaway
+// automation cannot destabilize the hub.
+package fleetsize
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+)
+
+const (
+	denyMemberClusterCountFmt   = "deny create member cluster %s as the fleet already has %d member clusters, which reaches the limit of %d"
+	denyPlacementCountFmt       = "deny create v1beta1 CRP %s as the fleet already has %d cluster resource placements, which reaches the limit of %d"
+	denyBindingsPerPlacementFmt = "deny create/update v1beta1 CRP %s as it may select %d clusters, which exceeds the limit of %d bindings per placement"
+)
+
+// Limits is the set of fleet size guardrails; a limit of 0 disables the corresponding guardrail.
+type Limits struct {
+	// MaxMemberClusters is the maximum number of member clusters the fleet can have.
+	MaxMemberClusters int
+	// MaxClusterResourcePlacements is the maximum number of cluster resource placements the fleet
+	// can have.
+	MaxClusterResourcePlacements int
+	// MaxBindingsPerPlacement is the maximum number of clusters a cluster resource placement can
+	// select, i.e., the maximum number of bindings per placement.
+	//
+	// Note that the number of clusters a placement of the PickAll type selects is not known at
+	// admission time; such placements are capped by MaxMemberClusters instead.
+	MaxBindingsPerPlacement int
+}
+
+// CheckMemberClusterCount returns the reason why a new member cluster cannot join the fleet, or
+// an empty string if the member cluster count guardrail allows it.
+func CheckMemberClusterCount(ctx context.Context, c client.Client, limits Limits, name string) (string, error) {
+	if limits.MaxMemberClusters <= 0 {
+		return "", nil
+	}
+	var mcList clusterv1beta1.MemberClusterList
+	if err := c.List(ctx, &mcList); err != nil {
+		return "", fmt.Errorf("failed to list member clusters: %w", err)
+	}
+	if len(mcList.Items) >= limits.MaxMemberClusters {
+		return fmt.Sprintf(denyMemberClusterCountFmt, name, len(mcList.Items), limits.MaxMemberClusters), nil
+	}
+	return "", nil
+}
+
+// CheckPlacementCount returns the reason why a new cluster resource placement cannot be created, or
+// an empty string if the placement count guardrail allows it.
+func CheckPlacementCount(ctx context.Context, c client.Client, limits Limits, name string) (string, error) {
+	if limits.MaxClusterResourcePlacements <= 0 {
+		return "", nil
+	}
+	var crpList placementv1beta1.ClusterResourcePlacementList
+	if err := c.List(ctx, &crpList); err != nil {
+		return "", fmt.Errorf("failed to list cluster resource placements: %w", err)
+	}
+	if len(crpList.Items) >= limits.MaxClusterResourcePlacements {
+		return fmt.Sprintf(denyPlacementCountFmt, name, len(crpList.Items), limits.MaxClusterResourcePlacements), nil
+	}
+	return "", nil
+}
+
+// CheckBindingsPerPlacement returns the reason why a cluster resource placement selects too many
+// clusters, or an empty string if the bindings per placement guardrail allows it.
+//
+// On updates, a placement which already exceeds the limit (e.g., it was created before the limit was
+// lowered) is only denied if it selects even more clusters than before.
+func CheckBindingsPerPlacement(limits Limits, crp, oldCRP *placementv1beta1.ClusterResourcePlacement) string {
+	if limits.MaxBindingsPerPlacement <= 0 {
+		return ""
+	}
+	count, ok := selectedClusterCount(crp.Spec.Policy)
+	if !ok || count <= limits.MaxBindingsPerPlacement {
+		return ""
+	}
+	if oldCRP != nil {
+		if oldCount, ok := selectedClusterCount(oldCRP.Spec.Policy); ok && count <= oldCount {
+			return ""
+		}
+	}
+	return fmt.Sprintf(denyBindingsPerPlacementFmt, crp.Name, count, limits.MaxBindingsPerPlacement)
+}
+
+// selectedClusterCount returns the number of clusters a placement policy asks for; it returns false
+// if the number is not known at admission time, i.e., for the PickAll placement type.
+func selectedClusterCount(policy *placementv1beta1.PlacementPolicy) (int, bool) {
+	if policy == nil {
+		return 0, false
+	}
+	switch policy.PlacementType {
+	case placementv1beta1.PickFixedPlacementType:
+		return len(policy.ClusterNames), true
+	case placementv1beta1.PickNPlacementType:
+		if policy.NumberOfClusters == nil {
+			return 0, false
+		}
+		return int(*policy.NumberOfClusters), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package fleetsize
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+)
+
+const (
+	crpName = "test-crp"
+)
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	if err := clusterv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add cluster v1beta1 scheme: %v", err)
+	}
+	if err := placementv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add placement v1beta1 scheme: %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func memberClusters(n int) []client.Object {
+	objs := make([]client.Object, 0, n)
+	for i := 0; i < n; i++ {
+		objs = append(objs, &clusterv1beta1.MemberCluster{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("cluster-%d", i)}})
+	}
+	return objs
+}
+
+func placements(n int) []client.Object {
+	objs := make([]client.Object, 0, n)
+	for i := 0; i < n; i++ {
+		objs = append(objs, &placementv1beta1.ClusterResourcePlacement{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("crp-%d", i)}})
+	}
+	return objs
+}
+
+// TestCheckMemberClusterCount tests the CheckMemberClusterCount function.
+func TestCheckMemberClusterCount(t *testing.T) {
+	testCases := []struct {
+		name       string
+		limits     Limits
+		existing   int
+		wantDenied bool
+	}{
+		{
+			name:     "no limit",
+			existing: 3,
+		},
+		{
+			name:     "below the limit",
+			limits:   Limits{MaxMemberClusters: 3},
+			existing: 2,
+		},
+		{
+			name:       "reaches the limit",
+			limits:     Limits{MaxMemberClusters: 3},
+			existing:   3,
+			wantDenied: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, err := CheckMemberClusterCount(context.Background(), newFakeClient(t, memberClusters(tc.existing)...), tc.limits, "new-cluster")
+			if err != nil {
+				t.Fatalf("CheckMemberClusterCount() = %v, want no error", err)
+			}
+			if gotDenied := reason != ""; gotDenied != tc.wantDenied {
+				t.Errorf("CheckMemberClusterCount() = %q, want denied %t", reason, tc.wantDenied)
+			}
+		})
+	}
+}
+
+// TestCheckPlacementCount tests the CheckPlacementCount function.
+func TestCheckPlacementCount(t *testing.T) {
+	testCases := []struct {
+		name       string
+		limits     Limits
+		existing   int
+		wantDenied bool
+	}{
+		{
+			name:     "no limit",
+			existing: 3,
+		},
+		{
+			name:     "below the limit",
+			limits:   Limits{MaxClusterResourcePlacements: 3},
+			existing: 2,
+		},
+		{
+			name:       "reaches the limit",
+			limits:     Limits{MaxClusterResourcePlacements: 3},
+			existing:   3,
+			wantDenied: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, err := CheckPlacementCount(context.Background(), newFakeClient(t, placements(tc.existing)...), tc.limits, crpName)
+			if err != nil {
+				t.Fatalf("CheckPlacementCount() = %v, want no error", err)
+			}
+			if gotDenied := reason != ""; gotDenied != tc.wantDenied {
+				t.Errorf("CheckPlacementCount() = %q, want denied %t", reason, tc.wantDenied)
+			}
+		})
+	}
+}
+
+// TestCheckBindingsPerPlacement tests the CheckBindingsPerPlacement function.
+func TestCheckBindingsPerPlacement(t *testing.T) {
+	pickN := func(n int32) *placementv1beta1.ClusterResourcePlacement {
+		return &placementv1beta1.ClusterResourcePlacement{
+			ObjectMeta: metav1.ObjectMeta{Name: crpName},
+			Spec: placementv1beta1.ClusterResourcePlacementSpec{
+				Policy: &placementv1beta1.PlacementPolicy{
+					PlacementType:    placementv1beta1.PickNPlacementType,
+					NumberOfClusters: ptr.To(n),
+				},
+			},
+		}
+	}
+	pickFixed := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: crpName},
+		Spec: placementv1beta1.ClusterResourcePlacementSpec{
+			Policy: &placementv1beta1.PlacementPolicy{
+				PlacementType: placementv1beta1.PickFixedPlacementType,
+				ClusterNames:  []string{"cluster-1", "cluster-2", "cluster-3"},
+			},
+		},
+	}
+	pickAll := &placementv1beta1.ClusterResourcePlacement{
+		ObjectMeta: metav1.ObjectMeta{Name: crpName},
+	}
+
+	testCases := []struct {
+		name       string
+		limits     Limits
+		crp        *placementv1beta1.ClusterResourcePlacement
+		oldCRP     *placementv1beta1.ClusterResourcePlacement
+		wantDenied bool
+	}{
+		{
+			name: "no limit",
+			crp:  pickN(100),
+		},
+		{
+			name:   "pickN within the limit",
+			limits: Limits{MaxBindingsPerPlacement: 3},
+			crp:    pickN(3),
+		},
+		{
+			name:       "pickN exceeds the limit",
+			limits:     Limits{MaxBindingsPerPlacement: 3},
+			crp:        pickN(4),
+			wantDenied: true,
+		},
+		{
+			name:       "pickFixed exceeds the limit",
+			limits:     Limits{MaxBindingsPerPlacement: 2},
+			crp:        pickFixed,
+			wantDenied: true,
+		},
+		{
+			name:   "pickAll is not checked",
+			limits: Limits{MaxBindingsPerPlacement: 1},
+			crp:    pickAll,
+		},
+		{
+			name:   "update of a placement already exceeding the limit without selecting more clusters",
+			limits: Limits{MaxBindingsPerPlacement: 3},
+			crp:    pickN(4),
+			oldCRP: pickN(5),
+		},
+		{
+			name:       "update of a placement to select more clusters than the limit",
+			limits:     Limits{MaxBindingsPerPlacement: 3},
+			crp:        pickN(5),
+			oldCRP:     pickN(4),
+			wantDenied: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reason := CheckBindingsPerPlacement(tc.limits, tc.crp, tc.oldCRP)
+			if gotDenied := reason != ""; gotDenied != tc.wantDenied {
+				t.Errorf("CheckBindingsPerPlacement() = %q, want denied %t", reason, tc.wantDenied)
+			}
+		})
+	}
+}
+
+// TestReport tests the report method of the reporter.
+func TestReport(t *testing.T) {
+	objs := append(memberClusters(2), placements(2)...)
+	objs = append(objs,
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "crp-0-binding-1",
+				Labels: map[string]string{placementv1beta1.CRPTrackingLabel: "crp-0"},
+			},
+		},
+		&placementv1beta1.ClusterResourceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "crp-0-binding-2",
+				Labels: map[string]string{placementv1beta1.CRPTrackingLabel: "crp-0"},
+			},
+		},
+	)
+	// Report a placement which no longer exists, which should be cleaned up.
+	metrics.PlacementBindingCount.WithLabelValues("deleted-crp").Set(1)
+
+	r := NewReporter(newFakeClient(t, objs...))
+	r.report(context.Background())
+
+	wantObjectCount := map[string]float64{
+		clusterv1beta1.MemberClusterKind:              2,
+		placementv1beta1.ClusterResourcePlacementKind: 2,
+		placementv1beta1.ClusterResourceBindingKind:   2,
+	}
+	for kind, want := range wantObjectCount {
+		if got := testutil.ToFloat64(metrics.FleetObjectCount.WithLabelValues(kind)); got != want {
+			t.Errorf("FleetObjectCount(%s) = %v, want %v", kind, got, want)
+		}
+	}
+	wantBindingCount := map[string]float64{
+		"crp-0": 2,
+		"crp-1": 0,
+	}
+	for name, want := range wantBindingCount {
+		if got := testutil.ToFloat64(metrics.PlacementBindingCount.WithLabelValues(name)); got != want {
+			t.Errorf("PlacementBindingCount(%s) = %v, want %v", name, got, want)
+		}
+	}
+	if got := testutil.CollectAndCount(metrics.PlacementBindingCount); got != len(wantBindingCount) {
+		t.Errorf("PlacementBindingCount series = %d, want %d", got, len(wantBindingCount))
+	}
+}
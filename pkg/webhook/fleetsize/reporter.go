@@ -0,0 +1,99 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package fleetsize
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
+	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
+	"go.goms.io/fleet/pkg/metrics"
+)
+
+const (
+	// defaultReportInterval is the default interval at which the reporter refreshes the metrics.
+	defaultReportInterval = time.Second * 30
+)
+
+var (
+	_ manager.Runnable               = &Reporter{}
+	_ manager.LeaderElectionRunnable = &Reporter{}
+)
+
+// Reporter periodically reports the current numbers of the objects which the fleet size guardrails
+// count as metrics, so that operators can tell how close the fleet is to the limits.
+type Reporter struct {
+	client   client.Client
+	interval time.Duration
+}
+
+// NewReporter returns a new fleet size metrics reporter.
+func NewReporter(hubClient client.Client) *Reporter {
+	return &Reporter{
+		client:   hubClient,
+		interval: defaultReportInterval,
+	}
+}
+
+// Start runs the reporter periodically until the context is cancelled.
+func (r *Reporter) Start(ctx context.Context) error {
+	klog.InfoS("Starting the fleet size reporter", "interval", r.interval)
+	wait.UntilWithContext(ctx, r.report, r.interval)
+	klog.InfoS("The fleet size reporter has exited")
+	return nil
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface, so that only the leader
+// reports the metrics.
+func (r *Reporter) NeedLeaderElection() bool {
+	return true
+}
+
+// report refreshes the fleet size metrics.
+func (r *Reporter) report(ctx context.Context) {
+	var mcList clusterv1beta1.MemberClusterList
+	if err := r.client.List(ctx, &mcList); err != nil {
+		klog.ErrorS(err, "Failed to list member clusters")
+		return
+	}
+	var crpList placementv1beta1.ClusterResourcePlacementList
+	if err := r.client.List(ctx, &crpList); err != nil {
+		klog.ErrorS(err, "Failed to list cluster resource placements")
+		return
+	}
+	var bindingList placementv1beta1.ClusterResourceBindingList
+	if err := r.client.List(ctx, &bindingList); err != nil {
+		klog.ErrorS(err, "Failed to list cluster resource bindings")
+		return
+	}
+
+	metrics.FleetObjectCount.WithLabelValues(clusterv1beta1.MemberClusterKind).Set(float64(len(mcList.Items)))
+	metrics.FleetObjectCount.WithLabelValues(placementv1beta1.ClusterResourcePlacementKind).Set(float64(len(crpList.Items)))
+	metrics.FleetObjectCount.WithLabelValues(placementv1beta1.ClusterResourceBindingKind).Set(float64(len(bindingList.Items)))
+
+	bindingCountByCRP := make(map[string]int, len(crpList.Items))
+	for i := range crpList.Items {
+		bindingCountByCRP[crpList.Items[i].Name] = 0
+	}
+	for i := range bindingList.Items {
+		crpName := bindingList.Items[i].Labels[placementv1beta1.CRPTrackingLabel]
+		if _, ok := bindingCountByCRP[crpName]; ok {
+			bindingCountByCRP[crpName]++
+		}
+	}
+	// Reset the per placement metric so that the deleted placements are no longer reported.
+	metrics.PlacementBindingCount.Reset()
+	for crpName, count := range bindingCountByCRP {
+		metrics.PlacementBindingCount.WithLabelValues(crpName).Set(float64(count))
+	}
+	klog.V(2).InfoS("Reported the fleet size", "memberClusters", len(mcList.Items), "clusterResourcePlacements", len(crpList.Items), "clusterResourceBindings", len(bindingList.Items))
+}
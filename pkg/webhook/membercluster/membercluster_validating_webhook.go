@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"net/http"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -14,6 +16,7 @@ import (
 	clusterv1beta1 "go.goms.io/fleet/apis/cluster/v1beta1"
 	"go.goms.io/fleet/pkg/utils"
 	"go.goms.io/fleet/pkg/utils/validator"
+	"go.goms.io/fleet/pkg/webhook/fleetsize"
 )
 
 var (
@@ -23,17 +26,24 @@ var (
 
 type memberClusterValidator struct {
 	decoder webhook.AdmissionDecoder
+	client  client.Client
+	// fleetSizeLimits is the fleet size guardrails which cap the number of member clusters.
+	fleetSizeLimits fleetsize.Limits
 }
 
 // Add registers the webhook for K8s bulit-in object types.
-func Add(mgr manager.Manager) error {
+func Add(mgr manager.Manager, fleetSizeLimits fleetsize.Limits) error {
 	hookServer := mgr.GetWebhookServer()
-	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &memberClusterValidator{admission.NewDecoder(mgr.GetScheme())}})
+	hookServer.Register(ValidationPath, &webhook.Admission{Handler: &memberClusterValidator{
+		decoder:         admission.NewDecoder(mgr.GetScheme()),
+		client:          mgr.GetClient(),
+		fleetSizeLimits: fleetSizeLimits,
+	}})
 	return nil
 }
 
 // Handle memberClusterValidator checks to see if member cluster has valid fields.
-func (v *memberClusterValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+func (v *memberClusterValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
 	var mc clusterv1beta1.MemberCluster
 	klog.V(2).InfoS("Validating webhook handling member cluster", "operation", req.Operation, "namespacedName", types.NamespacedName{Name: req.Name})
 	if err := v.decoder.Decode(req, &mc); err != nil {
@@ -44,5 +54,16 @@ func (v *memberClusterValidator) Handle(_ context.Context, req admission.Request
 		klog.V(2).ErrorS(err, "Member cluster has invalid fields, request is denied", "operation", req.Operation, "namespacedName", types.NamespacedName{Name: mc.Name})
 		return admission.Denied(err.Error())
 	}
+	if req.Operation == admissionv1.Create {
+		reason, err := fleetsize.CheckMemberClusterCount(ctx, v.client, v.fleetSizeLimits, mc.Name)
+		if err != nil {
+			klog.ErrorS(err, "Failed to check the number of member clusters", "namespacedName", types.NamespacedName{Name: mc.Name})
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if reason != "" {
+			klog.V(2).InfoS("The fleet has too many member clusters, request is denied", "operation", req.Operation, "namespacedName", types.NamespacedName{Name: mc.Name})
+			return admission.Denied(reason)
+		}
+	}
 	return admission.Allowed("Member cluster has valid fields")
 }
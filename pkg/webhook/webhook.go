@@ -47,9 +47,11 @@ import (
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
 	fleetv1alpha1 "go.goms.io/fleet/apis/v1alpha1"
 	"go.goms.io/fleet/cmd/hubagent/options"
+	"go.goms.io/fleet/pkg/utils/resource"
 	"go.goms.io/fleet/pkg/webhook/clusterresourceoverride"
 	"go.goms.io/fleet/pkg/webhook/clusterresourceplacement"
 	"go.goms.io/fleet/pkg/webhook/fleetresourcehandler"
+	"go.goms.io/fleet/pkg/webhook/fleetsize"
 	"go.goms.io/fleet/pkg/webhook/membercluster"
 	"go.goms.io/fleet/pkg/webhook/pod"
 	"go.goms.io/fleet/pkg/webhook/replicaset"
@@ -117,14 +119,22 @@ var (
 
 var AddToManagerFuncs []func(manager.Manager) error
 var AddToManagerFleetResourceValidator func(manager.Manager, []string, bool) error
+var AddToManagerCRPValidator func(manager.Manager, bool, fleetsize.Limits, resource.ManifestLimits) error
+var AddToManagerMemberClusterValidator func(manager.Manager, fleetsize.Limits) error
 
 // AddToManager adds all Controllers to the Manager
-func AddToManager(m manager.Manager, whiteListedUsers []string, isFleetV1Beta1API bool) error {
+func AddToManager(m manager.Manager, whiteListedUsers []string, isFleetV1Beta1API, enableCRPResourceAccessCheck bool, fleetSizeLimits fleetsize.Limits, manifestLimits resource.ManifestLimits) error {
 	for _, f := range AddToManagerFuncs {
 		if err := f(m); err != nil {
 			return err
 		}
 	}
+	if err := AddToManagerCRPValidator(m, enableCRPResourceAccessCheck, fleetSizeLimits, manifestLimits); err != nil {
+		return err
+	}
+	if err := AddToManagerMemberClusterValidator(m, fleetSizeLimits); err != nil {
+		return err
+	}
 	return AddToManagerFleetResourceValidator(m, whiteListedUsers, isFleetV1Beta1API)
 }
 
@@ -18,9 +18,9 @@ import (
 
 	placementv1alpha1 "go.goms.io/fleet/apis/placement/v1alpha1"
 	placementv1beta1 "go.goms.io/fleet/apis/placement/v1beta1"
-	"go.goms.io/fleet/pkg/controllers/clusterresourceplacement"
 	"go.goms.io/fleet/pkg/controllers/work"
 	scheduler "go.goms.io/fleet/pkg/scheduler/framework"
+	"go.goms.io/fleet/pkg/test/fleetassert"
 	"go.goms.io/fleet/pkg/utils/condition"
 	"go.goms.io/fleet/test/e2e/framework"
 )
@@ -146,58 +146,6 @@ func workNamespacePlacedOnClusterActual(cluster *framework.Cluster) func() error
 	}
 }
 
-func crpScheduleFailedConditions(generation int64) []metav1.Condition {
-	return []metav1.Condition{
-		{
-			Type:               string(placementv1beta1.ClusterResourcePlacementScheduledConditionType),
-			Status:             metav1.ConditionFalse,
-			ObservedGeneration: generation,
-			Reason:             scheduler.NotFullyScheduledReason,
-		},
-	}
-}
-
-func crpSchedulePartiallyFailedConditions(generation int64) []metav1.Condition {
-	return []metav1.Condition{
-		{
-			Type:               string(placementv1beta1.ClusterResourcePlacementScheduledConditionType),
-			Status:             metav1.ConditionFalse,
-			ObservedGeneration: generation,
-			Reason:             scheduler.NotFullyScheduledReason,
-		},
-		{
-			Type:               string(placementv1beta1.ClusterResourcePlacementRolloutStartedConditionType),
-			Status:             metav1.ConditionTrue,
-			Reason:             condition.RolloutStartedReason,
-			ObservedGeneration: generation,
-		},
-		{
-			Type:               string(placementv1beta1.ClusterResourcePlacementOverriddenConditionType),
-			Status:             metav1.ConditionTrue,
-			Reason:             condition.OverrideNotSpecifiedReason,
-			ObservedGeneration: generation,
-		},
-		{
-			Type:               string(placementv1beta1.ClusterResourcePlacementWorkSynchronizedConditionType),
-			Status:             metav1.ConditionTrue,
-			Reason:             condition.WorkSynchronizedReason,
-			ObservedGeneration: generation,
-		},
-		{
-			Type:               string(placementv1beta1.ClusterResourcePlacementAppliedConditionType),
-			Status:             metav1.ConditionTrue,
-			Reason:             condition.ApplySucceededReason,
-			ObservedGeneration: generation,
-		},
-		{
-			Type:               string(placementv1beta1.ClusterResourcePlacementAvailableConditionType),
-			Status:             metav1.ConditionTrue,
-			Reason:             condition.AvailableReason,
-			ObservedGeneration: generation,
-		},
-	}
-}
-
 func crpRolloutStuckConditions(generation int64) []metav1.Condition {
 	return []metav1.Condition{
 		{
@@ -295,51 +243,6 @@ func crpNotAvailableConditions(generation int64, hasOverride bool) []metav1.Cond
 	}
 }
 
-func crpRolloutCompletedConditions(generation int64, hasOverride bool) []metav1.Condition {
-	overrideConditionReason := condition.OverrideNotSpecifiedReason
-	if hasOverride {
-		overrideConditionReason = condition.OverriddenSucceededReason
-	}
-	return []metav1.Condition{
-		{
-			Type:               string(placementv1beta1.ClusterResourcePlacementScheduledConditionType),
-			Status:             metav1.ConditionTrue,
-			Reason:             scheduler.FullyScheduledReason,
-			ObservedGeneration: generation,
-		},
-		{
-			Type:               string(placementv1beta1.ClusterResourcePlacementRolloutStartedConditionType),
-			Status:             metav1.ConditionTrue,
-			Reason:             condition.RolloutStartedReason,
-			ObservedGeneration: generation,
-		},
-		{
-			Type:               string(placementv1beta1.ClusterResourcePlacementOverriddenConditionType),
-			Status:             metav1.ConditionTrue,
-			Reason:             overrideConditionReason,
-			ObservedGeneration: generation,
-		},
-		{
-			Type:               string(placementv1beta1.ClusterResourcePlacementWorkSynchronizedConditionType),
-			Status:             metav1.ConditionTrue,
-			Reason:             condition.WorkSynchronizedReason,
-			ObservedGeneration: generation,
-		},
-		{
-			Type:               string(placementv1beta1.ClusterResourcePlacementAppliedConditionType),
-			Status:             metav1.ConditionTrue,
-			Reason:             condition.ApplySucceededReason,
-			ObservedGeneration: generation,
-		},
-		{
-			Type:               string(placementv1beta1.ClusterResourcePlacementAvailableConditionType),
-			Status:             metav1.ConditionTrue,
-			Reason:             condition.AvailableReason,
-			ObservedGeneration: generation,
-		},
-	}
-}
-
 func resourcePlacementSyncPendingConditions(generation int64) []metav1.Condition {
 	return []metav1.Condition{
 		{
@@ -442,17 +345,6 @@ func resourcePlacementRolloutCompletedConditions(generation int64, resourceIsTra
 	}
 }
 
-func resourcePlacementScheduleFailedConditions(generation int64) []metav1.Condition {
-	return []metav1.Condition{
-		{
-			Type:               string(placementv1beta1.ResourceScheduledConditionType),
-			Status:             metav1.ConditionFalse,
-			ObservedGeneration: generation,
-			Reason:             clusterresourceplacement.ResourceScheduleFailedReason,
-		},
-	}
-}
-
 func crpOverrideFailedConditions(generation int64) []metav1.Condition {
 	return []metav1.Condition{
 		{
@@ -583,34 +475,7 @@ func crpStatusWithOverrideUpdatedActual(
 	wantClusterResourceOverrides []string,
 	wantResourceOverrides []placementv1beta1.NamespacedName) func() error {
 	crpName := fmt.Sprintf(crpNameTemplate, GinkgoParallelProcess())
-
-	return func() error {
-		crp := &placementv1beta1.ClusterResourcePlacement{}
-		if err := hubClient.Get(ctx, types.NamespacedName{Name: crpName}, crp); err != nil {
-			return err
-		}
-
-		var wantPlacementStatus []placementv1beta1.ResourcePlacementStatus
-		for _, name := range wantSelectedClusters {
-			wantPlacementStatus = append(wantPlacementStatus, placementv1beta1.ResourcePlacementStatus{
-				ClusterName:                        name,
-				Conditions:                         resourcePlacementRolloutCompletedConditions(crp.Generation, true, true),
-				ApplicableResourceOverrides:        wantResourceOverrides,
-				ApplicableClusterResourceOverrides: wantClusterResourceOverrides,
-			})
-		}
-
-		wantStatus := placementv1beta1.ClusterResourcePlacementStatus{
-			Conditions:            crpRolloutCompletedConditions(crp.Generation, true),
-			PlacementStatuses:     wantPlacementStatus,
-			SelectedResources:     wantSelectedResourceIdentifiers,
-			ObservedResourceIndex: wantObservedResourceIndex,
-		}
-		if diff := cmp.Diff(crp.Status, wantStatus, crpStatusCmpOptions...); diff != "" {
-			return fmt.Errorf("CRP status diff (-got, +want): %s", diff)
-		}
-		return nil
-	}
+	return fleetassert.CRPStatusWithOverrideUpdatedActual(ctx, hubClient, crpName, wantSelectedResourceIdentifiers, wantSelectedClusters, wantObservedResourceIndex, wantClusterResourceOverrides, wantResourceOverrides)
 }
 
 func crpStatusUpdatedActual(wantSelectedResourceIdentifiers []placementv1beta1.ResourceIdentifier, wantSelectedClusters, wantUnselectedClusters []string, wantObservedResourceIndex string) func() error {
@@ -696,64 +561,7 @@ func customizedCRPStatusUpdatedActual(crpName string,
 	wantSelectedClusters, wantUnselectedClusters []string,
 	wantObservedResourceIndex string,
 	resourceIsTrackable bool) func() error {
-	return func() error {
-		crp := &placementv1beta1.ClusterResourcePlacement{}
-		if err := hubClient.Get(ctx, types.NamespacedName{Name: crpName}, crp); err != nil {
-			return err
-		}
-
-		wantPlacementStatus := []placementv1beta1.ResourcePlacementStatus{}
-		for _, name := range wantSelectedClusters {
-			wantPlacementStatus = append(wantPlacementStatus, placementv1beta1.ResourcePlacementStatus{
-				ClusterName: name,
-				Conditions:  resourcePlacementRolloutCompletedConditions(crp.Generation, resourceIsTrackable, false),
-			})
-		}
-		for i := 0; i < len(wantUnselectedClusters); i++ {
-			wantPlacementStatus = append(wantPlacementStatus, placementv1beta1.ResourcePlacementStatus{
-				Conditions: resourcePlacementScheduleFailedConditions(crp.Generation),
-			})
-		}
-
-		var wantCRPConditions []metav1.Condition
-		if len(wantSelectedClusters) > 0 {
-			wantCRPConditions = crpRolloutCompletedConditions(crp.Generation, false)
-		} else {
-			wantCRPConditions = []metav1.Condition{
-				// we don't set the remaining resource conditions.
-				{
-					Type:               string(placementv1beta1.ClusterResourcePlacementScheduledConditionType),
-					Status:             metav1.ConditionTrue,
-					Reason:             scheduler.FullyScheduledReason,
-					ObservedGeneration: crp.Generation,
-				},
-			}
-		}
-
-		if len(wantUnselectedClusters) > 0 {
-			if len(wantSelectedClusters) > 0 {
-				wantCRPConditions = crpSchedulePartiallyFailedConditions(crp.Generation)
-			} else {
-				// we don't set the remaining resource conditions if there is no clusters to select
-				wantCRPConditions = crpScheduleFailedConditions(crp.Generation)
-			}
-		}
-
-		// Note that the CRP controller will only keep decisions regarding unselected clusters for a CRP if:
-		//
-		// * The CRP is of the PickN placement type and the required N count cannot be fulfilled; or
-		// * The CRP is of the PickFixed placement type and the list of target clusters specified cannot be fulfilled.
-		wantStatus := placementv1beta1.ClusterResourcePlacementStatus{
-			Conditions:            wantCRPConditions,
-			PlacementStatuses:     wantPlacementStatus,
-			SelectedResources:     wantSelectedResourceIdentifiers,
-			ObservedResourceIndex: wantObservedResourceIndex,
-		}
-		if diff := cmp.Diff(crp.Status, wantStatus, crpStatusCmpOptions...); diff != "" {
-			return fmt.Errorf("CRP status diff (-got, +want): %s", diff)
-		}
-		return nil
-	}
+	return fleetassert.CRPStatusUpdatedActual(ctx, hubClient, crpName, wantSelectedResourceIdentifiers, wantSelectedClusters, wantUnselectedClusters, wantObservedResourceIndex, resourceIsTrackable)
 }
 
 func safeRolloutWorkloadCRPStatusUpdatedActual(wantSelectedResourceIdentifiers []placementv1beta1.ResourceIdentifier, failedWorkloadResourceIdentifier placementv1beta1.ResourceIdentifier, wantSelectedClusters []string, wantObservedResourceIndex string, failedResourceObservedGeneration int64) func() error {
@@ -903,13 +711,7 @@ func allFinalizersExceptForCustomDeletionBlockerRemovedFromCRPActual(crpName str
 }
 
 func crpRemovedActual(crpName string) func() error {
-	return func() error {
-		if err := hubClient.Get(ctx, types.NamespacedName{Name: crpName}, &placementv1beta1.ClusterResourcePlacement{}); !errors.IsNotFound(err) {
-			return fmt.Errorf("CRP still exists or an unexpected error occurred: %w", err)
-		}
-
-		return nil
-	}
+	return fleetassert.CRPRemovedActual(ctx, hubClient, crpName)
 }
 
 func crpEvictionRemovedActual(crpEvictionName string) func() error {
@@ -643,14 +643,9 @@ var _ = Describe("webhook tests for ClusterResourceOverride CREATE operations re
 								ClusterSelector: &placementv1beta1.ClusterSelector{
 									ClusterSelectorTerms: []placementv1beta1.ClusterSelectorTerm{
 										{
-											PropertySelector: &placementv1beta1.PropertySelector{
-												MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
-													{
-														Name:     "example",
-														Operator: placementv1beta1.PropertySelectorGreaterThanOrEqualTo,
-														Values:   []string{"1"},
-													},
-												},
+											PropertySorter: &placementv1beta1.PropertySorter{
+												Name:      "example",
+												SortOrder: placementv1beta1.Ascending,
 											},
 										},
 									},
@@ -686,7 +681,7 @@ var _ = Describe("webhook tests for ClusterResourceOverride CREATE operations re
 			var statusErr *k8sErrors.StatusError
 			Expect(errors.As(err, &statusErr)).To(BeTrue(), fmt.Sprintf("Create CRO call produced error %s. Error type wanted is %s.", reflect.TypeOf(err), reflect.TypeOf(&k8sErrors.StatusError{})))
 			Expect(statusErr.Status().Message).Should(MatchRegexp(fmt.Sprintf("invalid resource selector %+v: the resource has been selected by both %v and %v, which is not supported", selector, cro1.Name, croName)))
-			Expect(statusErr.Status().Message).Should(MatchRegexp("only labelSelector is supported"))
+			Expect(statusErr.Status().Message).Should(MatchRegexp("propertySorter is not supported"))
 			Expect(statusErr.Status().Message).Should(MatchRegexp("remove operation cannot have value"))
 			Expect(statusErr.Status().Message).Should(MatchRegexp("cannot override typeMeta fields"))
 			Expect(statusErr.Status().Message).Should(MatchRegexp("path cannot contain empty string"))
@@ -818,14 +813,9 @@ var _ = Describe("webhook tests for CRO UPDATE operations", Ordered, func() {
 			}
 			cro.Spec.ClusterResourceSelectors = append(cro.Spec.ClusterResourceSelectors, selector)
 			clusterSelectorTerm := placementv1beta1.ClusterSelectorTerm{
-				PropertySelector: &placementv1beta1.PropertySelector{
-					MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
-						{
-							Name:     "example",
-							Operator: placementv1beta1.PropertySelectorGreaterThanOrEqualTo,
-							Values:   []string{"1"},
-						},
-					},
+				PropertySorter: &placementv1beta1.PropertySorter{
+					Name:      "example",
+					SortOrder: placementv1beta1.Ascending,
 				},
 			}
 			cro.Spec.Policy.OverrideRules[0].ClusterSelector.ClusterSelectorTerms = append(cro.Spec.Policy.OverrideRules[0].ClusterSelector.ClusterSelectorTerms, clusterSelectorTerm)
@@ -846,7 +836,7 @@ var _ = Describe("webhook tests for CRO UPDATE operations", Ordered, func() {
 			var statusErr *k8sErrors.StatusError
 			Expect(errors.As(err, &statusErr)).To(BeTrue(), fmt.Sprintf("Update CRO call produced error %s. Error type wanted is %s.", reflect.TypeOf(err), reflect.TypeOf(&k8sErrors.StatusError{})))
 			Expect(statusErr.Status().Message).Should(MatchRegexp(fmt.Sprintf("invalid resource selector %+v: the resource has been selected by both %v and %v, which is not supported", selector, cro.Name, cro1.Name)))
-			Expect(statusErr.Status().Message).Should(MatchRegexp("only labelSelector is supported"))
+			Expect(statusErr.Status().Message).Should(MatchRegexp("propertySorter is not supported"))
 			Expect(statusErr.Status().Message).Should(MatchRegexp("cannot override typeMeta fields"))
 			Expect(statusErr.Status().Message).Should(MatchRegexp("path cannot be empty"))
 			return nil
@@ -1240,14 +1230,9 @@ var _ = Describe("webhook tests for ResourceOverride UPDATE operations", Ordered
 			}
 			ro.Spec.ResourceSelectors = append(ro.Spec.ResourceSelectors, newSelector)
 			clusterSelectorTerm := placementv1beta1.ClusterSelectorTerm{
-				PropertySelector: &placementv1beta1.PropertySelector{
-					MatchExpressions: []placementv1beta1.PropertySelectorRequirement{
-						{
-							Name:     "example",
-							Operator: placementv1beta1.PropertySelectorGreaterThanOrEqualTo,
-							Values:   []string{"1"},
-						},
-					},
+				PropertySorter: &placementv1beta1.PropertySorter{
+					Name:      "example",
+					SortOrder: placementv1beta1.Ascending,
 				},
 			}
 			ro.Spec.Policy.OverrideRules[0].ClusterSelector.ClusterSelectorTerms = append(ro.Spec.Policy.OverrideRules[0].ClusterSelector.ClusterSelectorTerms, clusterSelectorTerm)
@@ -1279,7 +1264,7 @@ var _ = Describe("webhook tests for ResourceOverride UPDATE operations", Ordered
 			var statusErr *k8sErrors.StatusError
 			Expect(errors.As(err, &statusErr)).To(BeTrue(), fmt.Sprintf("Update RO call produced error %s. Error type wanted is %s.", reflect.TypeOf(err), reflect.TypeOf(&k8sErrors.StatusError{})))
 			Expect(statusErr.Status().Message).Should(MatchRegexp(fmt.Sprintf("invalid resource selector %+v: the resource has been selected by both %v and %v, which is not supported", newSelector, roName, ro1.Name)))
-			Expect(statusErr.Status().Message).Should(MatchRegexp("only labelSelector is supported"))
+			Expect(statusErr.Status().Message).Should(MatchRegexp("propertySorter is not supported"))
 			Expect(statusErr.Status().Message).Should(MatchRegexp("remove operation cannot have value"))
 			Expect(statusErr.Status().Message).Should(MatchRegexp("cannot override status fields"))
 			Expect(statusErr.Status().Message).Should(MatchRegexp("path cannot contain empty string"))
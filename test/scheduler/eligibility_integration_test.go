@@ -0,0 +1,207 @@
+/*
+Copyright (c) Microsoft Corporation.
+Licensed under the MIT license.
+*/
+
+package tests
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The test cases below verify the time-based cluster eligibility checks (heartbeat staleness and
+// unhealthy toleration) at their boundaries; the cluster eligibility checker runs with a fake clock,
+// which the test cases move instead of sleeping.
+var _ = Describe("scheduling CRPs on member clusters with time-based eligibility", func() {
+	// This is a serial test as adding a new member cluster may interrupt other test cases.
+	Context("heartbeat staleness reaches the timeout", Serial, Ordered, func() {
+		crpName := fmt.Sprintf(crpNameTemplate, GinkgoParallelProcess())
+		policySnapshotName := fmt.Sprintf(policySnapshotNameTemplate, crpName, 1)
+		newClusterName := fmt.Sprintf(provisionalClusterNameTemplate, GinkgoParallelProcess())
+		targetClusters := []string{newClusterName}
+
+		BeforeAll(func() {
+			// Ensure that no bindings have been created so far.
+			noBindingsCreatedActual := noBindingsCreatedForCRPActual(crpName)
+			Consistently(noBindingsCreatedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Some bindings have been created unexpectedly")
+
+			// Create a new member cluster whose last heartbeat is exactly as old as the timeout.
+			createMemberCluster(newClusterName, nil)
+			now := eligibilityCheckerClock.Now()
+			markClusterAgentStatusAt(newClusterName, now.Add(-clusterHeartbeatCheckTimeout), metav1.ConditionTrue, now)
+
+			// Create the CRP and its associated policy snapshot.
+			createPickFixedCRPWithPolicySnapshot(crpName, targetClusters, policySnapshotName)
+		})
+
+		It("should create scheduled bindings for the cluster", func() {
+			scheduledBindingsCreatedActual := scheduledBindingsCreatedOrUpdatedForClustersActual(targetClusters, nilScoreByCluster, crpName, policySnapshotName)
+			Eventually(scheduledBindingsCreatedActual, eventuallyDuration, eventuallyInterval).Should(Succeed(), "Failed to create the expected set of bindings")
+			Consistently(scheduledBindingsCreatedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Failed to create the expected set of bindings")
+		})
+
+		It("should report status correctly", func() {
+			statusUpdatedActual := pickFixedPolicySnapshotStatusUpdatedActual(targetClusters, []string{}, policySnapshotName)
+			Eventually(statusUpdatedActual, eventuallyDuration, eventuallyInterval).Should(Succeed(), "Failed to update policy snapshot status")
+			Consistently(statusUpdatedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Failed to update policy snapshot status")
+		})
+
+		AfterAll(func() {
+			// Delete the CRP.
+			ensureCRPAndAllRelatedResourcesDeletion(crpName)
+
+			// Delete the provisional cluster.
+			ensureProvisionalClusterDeletion(newClusterName)
+		})
+	})
+
+	// This is a serial test as adding a new member cluster and moving the clock may interrupt
+	// other test cases.
+	Context("heartbeat staleness exceeds the timeout", Serial, Ordered, func() {
+		crpName := fmt.Sprintf(crpNameTemplate, GinkgoParallelProcess())
+		policySnapshotName := fmt.Sprintf(policySnapshotNameTemplate, crpName, 1)
+		newClusterName := fmt.Sprintf(provisionalClusterNameTemplate, GinkgoParallelProcess())
+		targetClusters := []string{newClusterName}
+
+		var originalTime time.Time
+
+		BeforeAll(func() {
+			// Ensure that no bindings have been created so far.
+			noBindingsCreatedActual := noBindingsCreatedForCRPActual(crpName)
+			Consistently(noBindingsCreatedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Some bindings have been created unexpectedly")
+
+			// Create a new member cluster whose last heartbeat is exactly as old as the timeout.
+			createMemberCluster(newClusterName, nil)
+			originalTime = eligibilityCheckerClock.Now()
+			markClusterAgentStatusAt(newClusterName, originalTime.Add(-clusterHeartbeatCheckTimeout), metav1.ConditionTrue, originalTime)
+
+			// Move the clock just past the timeout.
+			eligibilityCheckerClock.Step(time.Second)
+
+			// Create the CRP and its associated policy snapshot.
+			createPickFixedCRPWithPolicySnapshot(crpName, targetClusters, policySnapshotName)
+		})
+
+		It("should not create any binding for the cluster", func() {
+			noBindingsCreatedActual := noBindingsCreatedForClustersActual(targetClusters, crpName)
+			Eventually(noBindingsCreatedActual, eventuallyDuration, eventuallyInterval).Should(Succeed(), "Created a binding for a disconnected cluster")
+			Consistently(noBindingsCreatedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Created a binding for a disconnected cluster")
+		})
+
+		It("should report status correctly", func() {
+			statusUpdatedActual := pickFixedPolicySnapshotStatusUpdatedActual([]string{}, targetClusters, policySnapshotName)
+			Eventually(statusUpdatedActual, eventuallyDuration, eventuallyInterval).Should(Succeed(), "Failed to update policy snapshot status")
+			Consistently(statusUpdatedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Failed to update policy snapshot status")
+		})
+
+		AfterAll(func() {
+			// Restore the clock.
+			eligibilityCheckerClock.SetTime(originalTime)
+
+			// Delete the CRP.
+			ensureCRPAndAllRelatedResourcesDeletion(crpName)
+
+			// Delete the provisional cluster.
+			ensureProvisionalClusterDeletion(newClusterName)
+		})
+	})
+
+	// This is a serial test as adding a new member cluster may interrupt other test cases.
+	Context("cluster stays unhealthy for exactly the health check timeout", Serial, Ordered, func() {
+		crpName := fmt.Sprintf(crpNameTemplate, GinkgoParallelProcess())
+		policySnapshotName := fmt.Sprintf(policySnapshotNameTemplate, crpName, 1)
+		newClusterName := fmt.Sprintf(provisionalClusterNameTemplate, GinkgoParallelProcess())
+		targetClusters := []string{newClusterName}
+
+		BeforeAll(func() {
+			// Ensure that no bindings have been created so far.
+			noBindingsCreatedActual := noBindingsCreatedForCRPActual(crpName)
+			Consistently(noBindingsCreatedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Some bindings have been created unexpectedly")
+
+			// Create a new member cluster which has been unhealthy for exactly the health check timeout;
+			// the unhealthy state is still tolerated.
+			createMemberCluster(newClusterName, nil)
+			now := eligibilityCheckerClock.Now()
+			markClusterAgentStatusAt(newClusterName, now, metav1.ConditionFalse, now.Add(-clusterHealthCheckTimeout))
+
+			// Create the CRP and its associated policy snapshot.
+			createPickFixedCRPWithPolicySnapshot(crpName, targetClusters, policySnapshotName)
+		})
+
+		It("should create scheduled bindings for the cluster", func() {
+			scheduledBindingsCreatedActual := scheduledBindingsCreatedOrUpdatedForClustersActual(targetClusters, nilScoreByCluster, crpName, policySnapshotName)
+			Eventually(scheduledBindingsCreatedActual, eventuallyDuration, eventuallyInterval).Should(Succeed(), "Failed to create the expected set of bindings")
+			Consistently(scheduledBindingsCreatedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Failed to create the expected set of bindings")
+		})
+
+		It("should report status correctly", func() {
+			statusUpdatedActual := pickFixedPolicySnapshotStatusUpdatedActual(targetClusters, []string{}, policySnapshotName)
+			Eventually(statusUpdatedActual, eventuallyDuration, eventuallyInterval).Should(Succeed(), "Failed to update policy snapshot status")
+			Consistently(statusUpdatedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Failed to update policy snapshot status")
+		})
+
+		AfterAll(func() {
+			// Delete the CRP.
+			ensureCRPAndAllRelatedResourcesDeletion(crpName)
+
+			// Delete the provisional cluster.
+			ensureProvisionalClusterDeletion(newClusterName)
+		})
+	})
+
+	// This is a serial test as adding a new member cluster and moving the clock may interrupt
+	// other test cases.
+	Context("cluster stays unhealthy for longer than the health check timeout", Serial, Ordered, func() {
+		crpName := fmt.Sprintf(crpNameTemplate, GinkgoParallelProcess())
+		policySnapshotName := fmt.Sprintf(policySnapshotNameTemplate, crpName, 1)
+		newClusterName := fmt.Sprintf(provisionalClusterNameTemplate, GinkgoParallelProcess())
+		targetClusters := []string{newClusterName}
+
+		var originalTime time.Time
+
+		BeforeAll(func() {
+			// Ensure that no bindings have been created so far.
+			noBindingsCreatedActual := noBindingsCreatedForCRPActual(crpName)
+			Consistently(noBindingsCreatedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Some bindings have been created unexpectedly")
+
+			// Create a new member cluster which has been unhealthy for exactly the health check timeout.
+			createMemberCluster(newClusterName, nil)
+			originalTime = eligibilityCheckerClock.Now()
+			markClusterAgentStatusAt(newClusterName, originalTime, metav1.ConditionFalse, originalTime.Add(-clusterHealthCheckTimeout))
+
+			// Move the clock just past the timeout; the heartbeat stays fresh enough.
+			eligibilityCheckerClock.Step(time.Second)
+
+			// Create the CRP and its associated policy snapshot.
+			createPickFixedCRPWithPolicySnapshot(crpName, targetClusters, policySnapshotName)
+		})
+
+		It("should not create any binding for the cluster", func() {
+			noBindingsCreatedActual := noBindingsCreatedForClustersActual(targetClusters, crpName)
+			Eventually(noBindingsCreatedActual, eventuallyDuration, eventuallyInterval).Should(Succeed(), "Created a binding for an unhealthy cluster")
+			Consistently(noBindingsCreatedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Created a binding for an unhealthy cluster")
+		})
+
+		It("should report status correctly", func() {
+			statusUpdatedActual := pickFixedPolicySnapshotStatusUpdatedActual([]string{}, targetClusters, policySnapshotName)
+			Eventually(statusUpdatedActual, eventuallyDuration, eventuallyInterval).Should(Succeed(), "Failed to update policy snapshot status")
+			Consistently(statusUpdatedActual, consistentlyDuration, consistentlyInterval).Should(Succeed(), "Failed to update policy snapshot status")
+		})
+
+		AfterAll(func() {
+			// Restore the clock.
+			eligibilityCheckerClock.SetTime(originalTime)
+
+			// Delete the CRP.
+			ensureCRPAndAllRelatedResourcesDeletion(crpName)
+
+			// Delete the provisional cluster.
+			ensureProvisionalClusterDeletion(newClusterName)
+		})
+	})
+})
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
+	clocktesting "k8s.io/utils/clock/testing"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
@@ -42,6 +43,7 @@ import (
 	"go.goms.io/fleet/pkg/scheduler/watchers/clusterresourceplacement"
 	"go.goms.io/fleet/pkg/scheduler/watchers/clusterschedulingpolicysnapshot"
 	"go.goms.io/fleet/pkg/scheduler/watchers/membercluster"
+	"go.goms.io/fleet/pkg/utils/controller"
 )
 
 const (
@@ -57,6 +59,12 @@ const (
 	consistentlyDuration = time.Second * 1
 	consistentlyInterval = time.Millisecond * 200
 
+	// Use a (much) larger health check and heartbeat check timeouts as in the test
+	// environment there is no actual agent to report health check status and send heartbeat
+	// signals.
+	clusterHealthCheckTimeout    = time.Hour * 24
+	clusterHeartbeatCheckTimeout = time.Hour * 24
+
 	memberCluster1EastProd          = "cluster-1-east-prod"
 	memberCluster2EastProd          = "cluster-2-east-prod"
 	memberCluster3EastCanary        = "cluster-3-east-canary"
@@ -84,6 +92,11 @@ var (
 	ctx        context.Context
 	cancel     context.CancelFunc
 
+	// eligibilityCheckerClock is the fake clock the cluster eligibility checker uses; it stays
+	// still unless a test case steps it, so that the time-based eligibility checks can be verified
+	// deterministically.
+	eligibilityCheckerClock *clocktesting.FakeClock
+
 	// The fleet environment simulated for testing features the following cluster topology:
 	// * 10 member clusters in total
 	//
@@ -549,17 +562,18 @@ func beforeSuiteForProcess1() []byte {
 		},
 	})
 	Expect(err).NotTo(HaveOccurred(), "Failed to create controller manager")
+	Expect(controller.SetupBindingCRPTrackingIndex(ctx, ctrlMgr.GetFieldIndexer())).To(Succeed(), "Failed to set up the binding index")
+	Expect(controller.SetupBindingTargetClusterIndex(ctx, ctrlMgr.GetFieldIndexer())).To(Succeed(), "Failed to set up the binding target cluster index")
 
 	// Spin up a scheduler work queue.
 	schedulerWorkQueue := queue.NewSimpleClusterResourcePlacementSchedulingQueue()
 
 	// Build a custom cluster eligibility checker.
+	eligibilityCheckerClock = clocktesting.NewFakeClock(time.Now())
 	clusterEligibilityChecker := clustereligibilitychecker.New(
-		// Use a (much) larger health check and heartbeat check timeouts as in the test
-		// environment there is no actual agent to report health check status and send heartbeat
-		// signals.
-		clustereligibilitychecker.WithClusterHealthCheckTimeout(time.Hour*24),
-		clustereligibilitychecker.WithClusterHeartbeatCheckTimeout(time.Hour*24),
+		clustereligibilitychecker.WithClusterHealthCheckTimeout(clusterHealthCheckTimeout),
+		clustereligibilitychecker.WithClusterHeartbeatCheckTimeout(clusterHeartbeatCheckTimeout),
+		clustereligibilitychecker.WithClock(eligibilityCheckerClock),
 	)
 
 	// Register the watchers.
@@ -578,7 +592,8 @@ func beforeSuiteForProcess1() []byte {
 	Expect(err).NotTo(HaveOccurred(), "Failed to set up policy snapshot watcher with controller manager")
 
 	memberClusterWatcher := membercluster.Reconciler{
-		Client:                    hubClient,
+		// The watcher looks up the bindings on a leaving cluster from the cache by the index.
+		Client:                    ctrlMgr.GetClient(),
 		SchedulerWorkQueue:        schedulerWorkQueue,
 		ClusterEligibilityChecker: clusterEligibilityChecker,
 	}
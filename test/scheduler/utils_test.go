@@ -261,6 +261,34 @@ func markClusterAsUnhealthy(name string) {
 	Expect(hubClient.Status().Update(ctx, &memberCluster)).To(Succeed(), "Failed to update member cluster status")
 }
 
+// markClusterAgentStatusAt sets the member agent status of a cluster with the given last received
+// heartbeat time, health condition status, and last health condition transition time.
+func markClusterAgentStatusAt(name string, lastReceivedHeartbeat time.Time, healthStatus metav1.ConditionStatus, healthLastTransitionTime time.Time) {
+	memberCluster := clusterv1beta1.MemberCluster{}
+	Expect(hubClient.Get(ctx, types.NamespacedName{Name: name}, &memberCluster)).To(Succeed(), "Failed to get member cluster")
+	memberCluster.Status.AgentStatus = []clusterv1beta1.AgentStatus{
+		{
+			Type: clusterv1beta1.MemberAgent,
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(clusterv1beta1.AgentJoined),
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(lastReceivedHeartbeat),
+					Reason:             dummyReason,
+				},
+				{
+					Type:               string(clusterv1beta1.AgentHealthy),
+					Status:             healthStatus,
+					LastTransitionTime: metav1.NewTime(healthLastTransitionTime),
+					Reason:             dummyReason,
+				},
+			},
+			LastReceivedHeartbeat: metav1.NewTime(lastReceivedHeartbeat),
+		},
+	}
+	Expect(hubClient.Status().Update(ctx, &memberCluster)).To(Succeed(), "Failed to update member cluster status")
+}
+
 func createPickFixedCRPWithPolicySnapshot(crpName string, targetClusters []string, policySnapshotName string) {
 	policy := &placementv1beta1.PlacementPolicy{
 		PlacementType: placementv1beta1.PickFixedPlacementType,